@@ -24,7 +24,7 @@ var (
 	importCmd = &cobra.Command{
 		Use:   "import",
 		Short: "Import data into this instance (choose a format sub-command)",
-		Long:  "Import data into this instance. `capsule` merges portable content idempotently; `snapshot` restores a full instance backup.",
+		Long:  "Import data into this instance. `capsule` merges portable content idempotently; `snapshot` restores a full instance backup; `chain` rebuilds and restores an incremental snapshot chain.",
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return cmd.Help()
 		},
@@ -32,11 +32,13 @@ var (
 )
 
 var (
-	exportCapsuleOpts cli.ExportCapsuleOptions
-	exportSnapshotOut string
+	exportCapsuleOpts         cli.ExportCapsuleOptions
+	exportSnapshotOut         string
+	exportSnapshotIncremental bool
 
 	importCapsuleOpts cli.ImportCapsuleOptions
 	importSnapshotYes bool
+	importChainYes    bool
 
 	checkFix bool
 
@@ -60,7 +62,7 @@ var exportSnapshotCmd = &cobra.Command{
 	Args:         cobra.NoArgs,
 	SilenceUsage: true,
 	RunE: func(_ *cobra.Command, _ []string) error {
-		return cli.DoExportSnapshot(exportSnapshotOut)
+		return cli.DoExportSnapshot(exportSnapshotOut, exportSnapshotIncremental)
 	},
 }
 
@@ -84,6 +86,17 @@ var importSnapshotCmd = &cobra.Command{
 	},
 }
 
+var importChainCmd = &cobra.Command{
+	Use:          "chain <dir|archive.zip>",
+	Short:        "Rebuild an incremental snapshot chain and restore it (destructive)",
+	Long:         "Rebuild a full instance backup from an incremental snapshot chain and restore it. Pass the chain directory to restore its newest archive, or one archive to restore that point in time; the archives it depends on must sit in the same directory.",
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		return cli.DoImportChain(args[0], importChainYes)
+	},
+}
+
 var checkCmd = &cobra.Command{
 	Use:          "check [path]",
 	Short:        "Validate a content capsule",
@@ -121,6 +134,8 @@ func init() {
 
 	exportSnapshotCmd.Flags().
 		StringVarP(&exportSnapshotOut, "output", "o", "", "copy the archive to this path (default: keep it under data/files/snapshots)")
+	exportSnapshotCmd.Flags().
+		BoolVar(&exportSnapshotIncremental, "incremental", false, "append to the incremental chain under data/files/snapshot-chain instead")
 
 	importCapsuleCmd.Flags().
 		BoolVar(&importCapsuleOpts.IncludePrivate, "include-private", false, "include private echoes")
@@ -129,6 +144,8 @@ func init() {
 
 	importSnapshotCmd.Flags().
 		BoolVar(&importSnapshotYes, "yes", false, "confirm this destructive whole-instance restore")
+	importChainCmd.Flags().
+		BoolVar(&importChainYes, "yes", false, "confirm this destructive whole-instance restore")
 
 	checkCmd.Flags().BoolVar(&checkFix, "fix", false, "write back auto-fixable problems (missing ids)")
 
@@ -137,6 +154,6 @@ func init() {
 		StringVar(&buildBaseURL, "base-url", cli.DefaultBaseURL, "site root path when deploying under a sub-path")
 
 	exportCmd.AddCommand(exportCapsuleCmd, exportSnapshotCmd)
-	importCmd.AddCommand(importCapsuleCmd, importSnapshotCmd)
+	importCmd.AddCommand(importCapsuleCmd, importSnapshotCmd, importChainCmd)
 	rootCmd.AddCommand(exportCmd, importCmd, checkCmd, buildCmd)
 }
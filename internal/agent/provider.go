@@ -6,9 +6,14 @@ package agent
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/lin-snow/ech0/internal/metrics"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/lin-snow/ech0/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Provider 是某个 LLM 协议（OpenAI 兼容 / Anthropic）的适配层。
@@ -29,10 +34,73 @@ type Provider interface {
 func providerFor(setting model.AgentSetting) (Provider, error) {
 	switch setting.Protocol {
 	case string(commonModel.OpenAI):
-		return &openaiProvider{setting: setting}, nil
+		return observed{&openaiProvider{setting: setting}, setting.Protocol}, nil
 	case string(commonModel.Anthropic):
-		return &anthropicProvider{setting: setting}, nil
+		return observed{&anthropicProvider{setting: setting}, setting.Protocol}, nil
 	default:
 		return nil, errors.New(commonModel.AGENT_PROTOCOL_NOT_FOUND)
 	}
 }
+
+// observed 给 Provider 套上调用耗时、token 用量指标与追踪 span，各协议实现只需如实回报 Usage。
+// Stream 的耗时按整个流计（从发起到 channel 关闭），与用户感知的一轮生成一致。
+type observed struct {
+	Provider
+	protocol string
+}
+
+func (o observed) Complete(ctx context.Context, req Request) (Response, error) {
+	ctx, span := tracing.Start(ctx, "llm.complete", o.spanOptions())
+	start := time.Now()
+	resp, err := o.Provider.Complete(ctx, req)
+	metrics.ObserveAI(metrics.KindLLM, o.protocol, time.Since(start), err)
+	o.recordUsage(span, resp.Usage)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (o observed) spanOptions() trace.SpanStartOption {
+	return trace.WithAttributes(attribute.String("gen_ai.provider.name", o.protocol))
+}
+
+func (o observed) recordUsage(span trace.Span, u Usage) {
+	metrics.AddAITokens(metrics.KindLLM, o.protocol, u.InputTokens, u.OutputTokens)
+	span.SetAttributes(
+		attribute.Int("gen_ai.usage.input_tokens", u.InputTokens),
+		attribute.Int("gen_ai.usage.output_tokens", u.OutputTokens),
+	)
+}
+
+func (o observed) Stream(ctx context.Context, req Request) (<-chan Event, error) {
+	ctx, span := tracing.Start(ctx, "llm.stream", o.spanOptions())
+	start := time.Now()
+	in, err := o.Provider.Stream(ctx, req)
+	if err != nil {
+		metrics.ObserveAI(metrics.KindLLM, o.protocol, time.Since(start), err)
+		tracing.End(span, err)
+		return nil, err
+	}
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+		var streamErr error
+		for ev := range in {
+			switch ev.Kind {
+			case EventError:
+				streamErr = ev.Err
+			case EventDone:
+				o.recordUsage(span, ev.Usage)
+			}
+			// 下游不再读时仍需排空 in，让 Provider 的发送方 goroutine 能退出。
+			if !send(ctx, out, ev) {
+				for range in {
+				}
+				streamErr = ctx.Err()
+				break
+			}
+		}
+		metrics.ObserveAI(metrics.KindLLM, o.protocol, time.Since(start), streamErr)
+		tracing.End(span, streamErr)
+	}()
+	return out, nil
+}
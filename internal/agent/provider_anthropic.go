@@ -68,12 +68,12 @@ func (p *anthropicProvider) buildParams(req Request) anthropic.MessageNewParams
 	return params
 }
 
-// generate 发起一次（非流式）Anthropic 请求，返回拼好的文本与解析出的工具调用。
-func (p *anthropicProvider) generate(ctx context.Context, req Request) (string, []ToolCall, error) {
+// generate 发起一次（非流式）Anthropic 请求，返回拼好的文本、解析出的工具调用与用量。
+func (p *anthropicProvider) generate(ctx context.Context, req Request) (string, []ToolCall, Usage, error) {
 	client := p.newClient() // Messages.New 是指针方法，需绑定到可寻址的局部变量
 	resp, err := client.Messages.New(ctx, p.buildParams(req))
 	if err != nil {
-		return "", nil, err
+		return "", nil, Usage{}, err
 	}
 
 	var text strings.Builder
@@ -82,7 +82,15 @@ func (p *anthropicProvider) generate(ctx context.Context, req Request) (string,
 			text.WriteString(block.Text)
 		}
 	}
-	return text.String(), toolCallsFromContent(resp.Content), nil
+	return text.String(), toolCallsFromContent(resp.Content), anthropicUsage(resp.Usage), nil
+}
+
+// anthropicUsage 折算用量：input_tokens 不含命中 / 写入 prompt cache 的部分，三者相加才是总输入。
+func anthropicUsage(u anthropic.Usage) Usage {
+	return Usage{
+		InputTokens:  int(u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens),
+		OutputTokens: int(u.OutputTokens),
+	}
 }
 
 // toolCallsFromContent 从 Message.Content（流式 Accumulate 后或一次性返回）里提取所有
@@ -201,14 +209,14 @@ func (p *anthropicProvider) buildTools(defs []ToolDef) []anthropic.ToolUnionPara
 }
 
 func (p *anthropicProvider) Complete(ctx context.Context, req Request) (Response, error) {
-	text, _, err := p.generate(ctx, req)
+	text, _, usage, err := p.generate(ctx, req)
 	if err != nil {
 		return Response{}, err
 	}
 	if text == "" {
 		return Response{}, errors.New("anthropic: empty text response")
 	}
-	return Response{Text: text}, nil
+	return Response{Text: text, Usage: usage}, nil
 }
 
 func (p *anthropicProvider) Stream(ctx context.Context, req Request) (<-chan Event, error) {
@@ -258,5 +266,5 @@ func (p *anthropicProvider) stream(ctx context.Context, req Request, ch chan<- E
 			return
 		}
 	}
-	send(ctx, ch, Event{Kind: EventDone})
+	send(ctx, ch, Event{Kind: EventDone, Usage: anthropicUsage(acc.Usage)})
 }
@@ -122,7 +122,7 @@ func (p *openaiProvider) Complete(ctx context.Context, req Request) (Response, e
 	if len(resp.Choices) == 0 {
 		return Response{}, errors.New("openai: empty response")
 	}
-	return Response{Text: resp.Choices[0].Message.Content}, nil
+	return Response{Text: resp.Choices[0].Message.Content, Usage: openAIUsage(resp.Usage)}, nil
 }
 
 func (p *openaiProvider) Stream(ctx context.Context, req Request) (<-chan Event, error) {
@@ -141,6 +141,8 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 		Messages: p.buildMessages(req.Messages),
 		Tools:    p.buildTools(req.Tools),
 		Stream:   true,
+		// 流式默认不回报用量；include_usage 让服务端在 [DONE] 前多发一个只带 usage 的 chunk。
+		StreamOptions: &openai.StreamOptions{IncludeUsage: true},
 	}
 	if req.Temperature != nil {
 		chatReq.Temperature = *req.Temperature
@@ -162,6 +164,7 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 	guard := &toolCallLeakGuard{}
 	// splitter 把内联在正文里的 <think> 推理段从答案里拆出来（推理模型经 OpenAI 兼容端的怪癖）。
 	splitter := &reasoningSplitter{}
+	var usage Usage
 
 	for {
 		resp, recvErr := stream.Recv()
@@ -172,6 +175,9 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 			send(ctx, ch, Event{Kind: EventError, Err: recvErr})
 			return
 		}
+		if resp.Usage != nil {
+			usage = openAIUsage(*resp.Usage)
+		}
 		if len(resp.Choices) == 0 {
 			continue
 		}
@@ -229,7 +235,11 @@ func (p *openaiProvider) stream(ctx context.Context, req Request, ch chan<- Even
 			return
 		}
 	}
-	send(ctx, ch, Event{Kind: EventDone})
+	send(ctx, ch, Event{Kind: EventDone, Usage: usage})
+}
+
+func openAIUsage(u openai.Usage) Usage {
+	return Usage{InputTokens: u.PromptTokens, OutputTokens: u.CompletionTokens}
 }
 
 // toolCallAccumulator 累积流式 tool_call 分片：OpenAI 把同一个调用的 arguments
@@ -9,7 +9,11 @@ import (
 	"strings"
 	"unicode/utf8"
 
+	"github.com/lin-snow/ech0/internal/tracing"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -75,6 +79,9 @@ func Run(ctx context.Context, req RunRequest) (<-chan AgentEvent, error) {
 			runCtx, cancel = context.WithTimeout(ctx, req.Timeout)
 			defer cancel()
 		}
+		runCtx, span := tracing.Start(runCtx, "agent.run",
+			trace.WithAttributes(attribute.String("agent.protocol", req.Setting.Protocol), attribute.String("agent.model", req.Setting.Model)))
+		defer span.End()
 		runLoop(runCtx, provider, req, out)
 	}()
 	return out, nil
@@ -100,36 +107,49 @@ func runLoop(ctx context.Context, provider Provider, req RunRequest, out chan<-
 	strs := req.Strings.withDefaults()
 
 	for round := 0; round < maxRounds; round++ {
-		// 轮内 token 预算回收：超限时把最旧的工具结果替换为占位，防多轮累积撑爆窗口。
-		trimContext(messages, req.MaxContextTokens, strs.ContextTrimNote)
-		o := streamRound(ctx, provider, out, messages, toolDefs, req.Temp)
-		if o.aborted {
-			return // ctx 取消
-		}
-		if o.err != nil {
-			emit(ctx, out, AgentEvent{Kind: AgentError, Err: o.err})
-			return
-		}
-		if len(o.calls) == 0 {
-			// 模型本轮直接作答（无工具调用）→ 正常收尾
-			emit(ctx, out, AgentEvent{Kind: AgentDone})
-			return
-		}
+		// 每轮（模型生成 + 工具执行）一个 span，返回 true 表示本轮已收尾、不再继续。
+		finished := func() bool {
+			ctx, span := tracing.Start(ctx, "agent.round", trace.WithAttributes(attribute.Int("agent.round", round)))
+			defer span.End()
 
-		// 回灌本轮 assistant 的 tool_calls（连同已产出的文本），供下一轮上下文
-		messages = append(messages, Message{Role: RoleAssistant, Content: o.assistant, ToolCalls: o.calls})
-		if !execTools(ctx, out, o.calls, toolByName, seen, &messages, strs) {
-			return // ctx 取消
+			// 轮内 token 预算回收：超限时把最旧的工具结果替换为占位，防多轮累积撑爆窗口。
+			trimContext(messages, req.MaxContextTokens, strs.ContextTrimNote)
+			o := streamRound(ctx, provider, out, messages, toolDefs, req.Temp)
+			if o.aborted {
+				return true // ctx 取消
+			}
+			if o.err != nil {
+				span.SetStatus(codes.Error, o.err.Error())
+				emit(ctx, out, AgentEvent{Kind: AgentError, Err: o.err})
+				return true
+			}
+			span.SetAttributes(attribute.Int("agent.tool_calls", len(o.calls)))
+			if len(o.calls) == 0 {
+				// 模型本轮直接作答（无工具调用）→ 正常收尾
+				emit(ctx, out, AgentEvent{Kind: AgentDone})
+				return true
+			}
+
+			// 回灌本轮 assistant 的 tool_calls（连同已产出的文本），供下一轮上下文
+			messages = append(messages, Message{Role: RoleAssistant, Content: o.assistant, ToolCalls: o.calls})
+			return !execTools(ctx, out, o.calls, toolByName, seen, &messages, strs) // false 即 ctx 取消
+		}()
+		if finished {
+			return
 		}
 	}
 
 	// 工具轮用尽仍在调工具：强制一轮「不给工具」让模型据已检索到的结果作答，保证有回答。
+	ctx, span := tracing.Start(ctx, "agent.round",
+		trace.WithAttributes(attribute.Int("agent.round", maxRounds), attribute.Bool("agent.final", true)))
+	defer span.End()
 	trimContext(messages, req.MaxContextTokens, strs.ContextTrimNote)
 	o := streamRound(ctx, provider, out, messages, nil, req.Temp)
 	if o.aborted {
 		return
 	}
 	if o.err != nil {
+		span.SetStatus(codes.Error, o.err.Error())
 		emit(ctx, out, AgentEvent{Kind: AgentError, Err: o.err})
 		return
 	}
@@ -249,7 +269,10 @@ func execTools(
 			if !emit(ctx, out, AgentEvent{Kind: AgentSearching, ToolName: tc.Name, ToolArgs: tc.Args}) {
 				return ctx.Err()
 			}
-			outputs[idx], execErrs[idx] = tool.Execute(ctx, tc.Args)
+			toolCtx, span := tracing.Start(ctx, "agent.tool "+tc.Name,
+				trace.WithAttributes(attribute.String("agent.tool", tc.Name)))
+			outputs[idx], execErrs[idx] = tool.Execute(toolCtx, tc.Args)
+			tracing.End(span, execErrs[idx])
 			return nil
 		})
 	}
@@ -78,9 +78,16 @@ type Request struct {
 	MaxTokens   int
 }
 
+// Usage 是提供方回报的 token 用量；未回报时为零值。
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+}
+
 // Response 是一次非流式生成的结果（Generate 用，无工具）。
 type Response struct {
-	Text string
+	Text  string
+	Usage Usage
 }
 
 // EventKind 区分 Provider 上浮的语义事件类型。
@@ -100,6 +107,7 @@ type Event struct {
 	Text     string   // EventTextDelta / EventReasoningDelta
 	ToolCall ToolCall // EventToolCall
 	Err      error    // EventError
+	Usage    Usage    // EventDone
 }
 
 // RunStrings 是 Loop 在工具循环中回喂给模型 / 注入消息的少量提示文案。由领域层（知道 locale）
@@ -13,6 +13,7 @@ import (
 	"github.com/lin-snow/ech0/internal/server"
 	"github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/internal/task"
+	"github.com/lin-snow/ech0/internal/tracing"
 )
 
 func ProvideOptions(
@@ -20,12 +21,14 @@ func ProvideOptions(
 	jobManager *job.Manager,
 	taskManager *task.Manager,
 	httpServer *server.Server,
+	tracer *tracing.Component,
 	durableKV kvstore.Store,
 ) []Option {
 	return []Option{
+		// tracer 排在最前：最先装上 TracerProvider、最后停止，其余组件停机时的 span 也能导出。
 		// jobManager 排在 httpServer 前：其 Start 做启动期孤儿清理，须先于对外服务。
 		// Runner 已在构造期装配进 jobManager、Task 已装配进 taskManager，无需额外注册步骤。
-		Components(jobManager, taskManager, httpServer),
+		Components(tracer, jobManager, taskManager, httpServer),
 		// 启动期一次性副作用，按序执行且必早于任何 component.Start：
 		// 先 seed 缺失的配置 key（此后各读路径直接命中，Get 不再承担「读时 seed」副作用），
 		// 再注册事件订阅。
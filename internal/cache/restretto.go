@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/dgraph-io/ristretto/v2"
+	"github.com/lin-snow/ech0/internal/metrics"
 )
 
 // RistrettoCache 是基于 Ristretto 实现的缓存结构体
@@ -44,6 +45,7 @@ func (r *RistrettoCache[K, V]) SetWithTTL(key K, value V, cost int64, ttl time.D
 // Get 从缓存中获取值
 func (r *RistrettoCache[K, V]) Get(key K) (V, bool, error) {
 	value, found := r.cache.Get(key)
+	metrics.IncCacheLookup(found)
 	if !found {
 		var zeroValue V
 		return zeroValue, false, nil
@@ -56,6 +56,8 @@ func collect(ctx context.Context, deps Deps, opts Options) (*dataset, error) {
 
 // collectEchoes 按创建时间升序读全量 Echo。EchoFiles 必须带 sort_order 排序读出——
 // 展示顺序在胶囊里由 files 数组顺序表达（spec §4.2），预加载的顺序就是胶囊的顺序。
+// 草稿 / 定时 Echo 按私密处理：只随含私密的胶囊导出，且以 private 写出，绝不进公开站点。
+// 回收站中的 Echo 视同已删除，任何胶囊都不导出。
 func collectEchoes(db *gorm.DB, opts Options, data *dataset) error {
 	query := db.
 		Preload("EchoFiles", func(d *gorm.DB) *gorm.DB {
@@ -64,9 +66,10 @@ func collectEchoes(db *gorm.DB, opts Options, data *dataset) error {
 		Preload("EchoFiles.File").
 		Preload("Extension").
 		Preload("Tags").
+		Where("deleted_at = 0").
 		Order("created_at ASC")
 	if !opts.IncludePrivate {
-		query = query.Where("private = ?", false)
+		query = query.Where("private = ? AND status = ?", false, echoModel.StatusPublished)
 	}
 	if err := query.Find(&data.echoes).Error; err != nil {
 		return fmt.Errorf("capsule export: load echoes: %w", err)
@@ -74,7 +77,9 @@ func collectEchoes(db *gorm.DB, opts Options, data *dataset) error {
 
 	if !opts.IncludePrivate {
 		var private int64
-		if err := db.Model(&echoModel.Echo{}).Where("private = ?", true).Count(&private).Error; err != nil {
+		if err := db.Model(&echoModel.Echo{}).
+			Where("deleted_at = 0 AND (private = ? OR status <> ?)", true, echoModel.StatusPublished).
+			Count(&private).Error; err != nil {
 			return fmt.Errorf("capsule export: count private echoes: %w", err)
 		}
 		data.skippedPrivate = int(private)
@@ -114,7 +119,7 @@ func collectFiles(db *gorm.DB, opts Options, data *dataset) error {
 	return nil
 }
 
-// privateOnlyFiles 返回「仅被 private Echo 引用」的文件 id 集合。这些字节不能随
+// privateOnlyFiles 返回「仅被 private（含未发布、回收站中）Echo 引用」的文件 id 集合。这些字节不能随
 // 公开胶囊出门；只要还有任一公开 Echo 引用它，它就是公开内容的一部分，必须导出。
 func privateOnlyFiles(db *gorm.DB) (map[string]struct{}, error) {
 	var refs []struct {
@@ -122,7 +127,8 @@ func privateOnlyFiles(db *gorm.DB) (map[string]struct{}, error) {
 		Private bool
 	}
 	if err := db.Model(&fileModel.EchoFile{}).
-		Select("echo_files.file_id AS file_id, echos.private AS private").
+		Select("echo_files.file_id AS file_id, (echos.private OR echos.status <> ? OR echos.deleted_at > 0) AS private",
+			echoModel.StatusPublished).
 		Joins("JOIN echos ON echos.id = echo_files.echo_id").
 		Scan(&refs).Error; err != nil {
 		return nil, fmt.Errorf("capsule export: resolve file visibility: %w", err)
@@ -86,7 +86,7 @@ func writeEchoes(ctx context.Context, stage virefs.FS, data *dataset) ([]string,
 			Username:  echo.Username,
 			Tags:      tagNames(echo.Tags),
 			Layout:    echo.Layout,
-			Private:   echo.Private,
+			Private:   echo.Private || !echo.IsPublished(),
 			FavCount:  echo.FavCount,
 			Files:     fileRefs(echo.EchoFiles),
 			Extension: extension(echo.Extension),
@@ -27,21 +27,22 @@ import (
 // DoExportSnapshot 产出一份整库快照 zip。
 //
 // 复用 Web 端导出作业的同一个引擎：配置了对象存储时会额外后台上传，
-// 本地产物始终落在 data/files/snapshots/ 下。
-func DoExportSnapshot(output string) error {
+// 本地产物始终落在 data/files/snapshots/ 下。incremental 时改为在增量链上追加一份归档
+// （与定时快照同一条链），见 doExportIncremental。
+func DoExportSnapshot(output string, incremental bool) error {
+	if incremental && strings.TrimSpace(output) != "" {
+		return errors.New("--output cannot be combined with --incremental: chain archives only make sense next to each other")
+	}
+
 	rt, err := newCapsuleRuntime()
 	if err != nil {
 		return err
 	}
+	if incremental {
+		return doExportIncremental(rt)
+	}
 
-	outcome, err := migrator.NewExportEngine(rt.storage).Export(
-		context.Background(),
-		func(phase string, _ any) {
-			if phase != "" {
-				fmt.Fprintf(os.Stderr, "… %s\n", phase)
-			}
-		},
-	)
+	outcome, err := migrator.NewExportEngine(rt.storage).Export(context.Background(), reportPhase)
 	if err != nil {
 		return err
 	}
@@ -60,6 +61,46 @@ func DoExportSnapshot(output string) error {
 	return nil
 }
 
+// doExportIncremental 在 data/files/snapshot-chain/ 上追加一份归档并同步上传到对象存储（若已配置）。
+// CLI 没有作业框架，上传就地同步执行；中断后再跑一次即续传。
+func doExportIncremental(rt *capsuleRuntime) error {
+	engine := migrator.NewExportEngine(rt.storage)
+	result, err := engine.ExportIncremental(context.Background(), reportPhase)
+	if err != nil {
+		return err
+	}
+
+	kind := "delta"
+	if result.Base {
+		kind = "base"
+	}
+	items := []tuiUtil.CLIInfoItem{
+		{Title: "Kind", Msg: kind},
+		{Title: "Changed", Msg: strconv.Itoa(result.Changed) + " / " + strconv.Itoa(result.Total) + " files"},
+		{Title: "Size", Msg: strconv.FormatInt(result.Size, 10) + " bytes"},
+	}
+	if result.Unchanged {
+		items = []tuiUtil.CLIInfoItem{{Title: "Unchanged", Msg: "nothing changed since the chain head"}}
+	}
+
+	if engine.ObjectEnabled() {
+		upload, err := engine.UploadChain(context.Background(), reportPhase)
+		if err != nil {
+			return fmt.Errorf("upload snapshot chain: %w", err)
+		}
+		items = append(items, tuiUtil.CLIInfoItem{
+			Title: "Uploaded",
+			Msg:   strconv.Itoa(upload.Uploaded) + " archives (" + strconv.Itoa(upload.Skipped) + " already there)",
+		})
+	}
+
+	tuiUtil.PrintCLIWithBox(
+		tuiUtil.CLIBoxHeader{Icon: "🗄️", Title: "Snapshot chain", Value: result.Path},
+		items...,
+	)
+	return nil
+}
+
 // DoImportSnapshot 用一份快照替换当前实例的内容。
 //
 // 破坏性操作，必须显式 --yes。落库语义与 Web 端「全局迁移」完全一致（同一个引擎），
@@ -74,23 +115,61 @@ func DoImportSnapshot(path string, yes bool) error {
 	if _, err := os.Stat(path); err != nil {
 		return err
 	}
+	// 增量归档只含变化的文件，单独解开是一份残缺的 data 目录，必须沿链重建。
+	if m, err := snapshot.ReadManifest(path); err == nil && m.Parent != "" {
+		return fmt.Errorf("%s is an incremental chain archive; restore it with `import chain`", path)
+	}
+
+	return importExtracted(path, "unpack snapshot", func(extractDir string) error {
+		return snapshot.Unpack(path, extractDir)
+	})
+}
+
+// DoImportChain 从增量快照链重建一份完整的 data 目录，再按快照导入的同一语义替换当前实例。
+//
+// path 可以是链目录（恢复到链头），也可以是链上某一份归档（恢复到该时刻，依赖的归档须在同一
+// 目录下）。从对象存储恢复时，先把 snapshot-chains/ 下的归档下载到同一个目录。
+func DoImportChain(path string, yes bool) error {
+	if !yes {
+		return errors.New("importing a snapshot chain rewrites instance data; pass --yes to confirm")
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	dir, target := path, ""
+	if !info.IsDir() {
+		dir, target = filepath.Dir(path), filepath.Base(path)
+	}
+
+	return importExtracted(path, "rebuild snapshot chain", func(extractDir string) error {
+		m, err := snapshot.Rebuild(context.Background(), dir, target, extractDir)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "… rebuilt %s (%d files)\n", m.Name, len(m.Files))
+		return nil
+	})
+}
 
+// importExtracted 把 extract 产出的 data 目录交给导入引擎。引擎读的是已解包目录，且
+// resolveTmpDir 只接受 data/files/tmp 之下的相对路径，故这里复刻 Web 上传通道的落点约定，
+// 而不是随便找个临时目录。
+func importExtracted(source, step string, extract func(extractDir string) error) error {
 	rt, err := newCapsuleRuntime()
 	if err != nil {
 		return err
 	}
 
-	// 引擎读的是已解包目录，且 resolveTmpDir 只接受 data/files/tmp 之下的相对路径，
-	// 故这里复刻 Web 上传通道的落点约定，而不是随便找个临时目录。
 	folder := "ech0_" + uuidUtil.MustNewV7()
 	relativeTmpDir := filepath.ToSlash(filepath.Join(migrator.TmpRelativeDir, folder))
 	extractDir := filepath.Join("data", relativeTmpDir)
 	if err := os.MkdirAll(extractDir, 0o755); err != nil {
 		return fmt.Errorf("create extract dir: %w", err)
 	}
-	if err := snapshot.Unpack(path, extractDir); err != nil {
+	if err := extract(extractDir); err != nil {
 		_ = os.RemoveAll(extractDir)
-		return fmt.Errorf("unpack snapshot: %w", err)
+		return fmt.Errorf("%s: %w", step, err)
 	}
 
 	// Import 自带 tmp 清理（CleanupTmpDirFromPayload）。
@@ -101,19 +180,21 @@ func DoImportSnapshot(path string, yes bool) error {
 			SourceType:    migratorModel.MigrationSourceEch0,
 			SourcePayload: map[string]any{"tmp_dir": relativeTmpDir},
 		},
-		func(phase string, _ any) {
-			if phase != "" {
-				fmt.Fprintf(os.Stderr, "… %s\n", phase)
-			}
-		},
+		reportPhase,
 	); err != nil {
 		return err
 	}
 
-	tuiUtil.PrintCLIInfo("📥 Snapshot imported", path)
+	tuiUtil.PrintCLIInfo("📥 Snapshot imported", source)
 	return nil
 }
 
+func reportPhase(phase string, _ any) {
+	if phase != "" {
+		fmt.Fprintf(os.Stderr, "… %s\n", phase)
+	}
+}
+
 // copyArtifact 把引擎产物复制到用户指定位置（引擎只认自己的 snapshots 目录）。
 func copyArtifact(src, dst string) (string, error) {
 	if !strings.HasSuffix(strings.ToLower(dst), ".zip") {
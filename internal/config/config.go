@@ -20,20 +20,25 @@ var (
 )
 
 type AppConfig struct {
-	Server    ServerConfig
-	OpenAPI   OpenAPIConfig
-	Database  DatabaseConfig
-	Log       LogConfig
-	Auth      AuthConfig
-	Upload    UploadConfig
-	Storage   StorageConfig
-	Event     EventConfig
-	Migration MigrationConfig
-	Setting   SettingConfig
-	Comment   CommentConfig
-	Security  SecurityConfig
-	Web       WebConfig
-	Agent     AgentConfig
+	Server     ServerConfig
+	OpenAPI    OpenAPIConfig
+	Database   DatabaseConfig
+	Log        LogConfig
+	Auth       AuthConfig
+	Upload     UploadConfig
+	Storage    StorageConfig
+	Event      EventConfig
+	Migration  MigrationConfig
+	Setting    SettingConfig
+	Comment    CommentConfig
+	Security   SecurityConfig
+	Web        WebConfig
+	Agent      AgentConfig
+	Trash      TrashConfig
+	Job        JobConfig
+	Federation FederationConfig
+	Metrics    MetricsConfig
+	Tracing    TracingConfig
 }
 
 type StorageConfig struct {
@@ -190,6 +195,53 @@ type AgentConfig struct {
 	MaxRounds int `env:"ECH0_AGENT_MAX_ROUNDS"`
 }
 
+type TrashConfig struct {
+	// RetentionDays 是回收站中 Echo / 评论的保留天数，超期由定时任务彻底删除；<=0 表示不自动清理。
+	RetentionDays int `env:"ECH0_TRASH_RETENTION_DAYS"`
+}
+
+type JobConfig struct {
+	// HistoryRetentionDays 是终态作业记录的保留天数，超期的在作业结束或启动时清理；<=0 表示不按时间清理。
+	HistoryRetentionDays int `env:"ECH0_JOB_HISTORY_RETENTION_DAYS"`
+	// HistoryLimit 是每种作业最多保留的记录条数；<=0 表示不限。
+	HistoryLimit int `env:"ECH0_JOB_HISTORY_LIMIT"`
+	// QueueSize 是同类型作业并发占满时允许排队的数量；0 表示不排队，直接拒绝。
+	QueueSize int `env:"ECH0_JOB_QUEUE_SIZE"`
+	// Concurrency 按类型覆盖同时运行的作业数（缺省 1），形如 "reindex:1,export:2"。
+	Concurrency map[string]int `env:"ECH0_JOB_CONCURRENCY"`
+}
+
+type FederationConfig struct {
+	// ActivityPub 开启后站长对外暴露为 ActivityPub actor（需先在系统设置中填写站点地址）。
+	ActivityPub bool `env:"ECH0_ACTIVITYPUB_ENABLED"`
+	// Webmention 开启后接收 /webmention 提及，并向 Echo 中的外链发送 Webmention（同样依赖站点地址）。
+	Webmention bool `env:"ECH0_WEBMENTION_ENABLED"`
+}
+
+// MetricsConfig 控制 /metrics（Prometheus / OpenMetrics）端点，默认关闭。
+type MetricsConfig struct {
+	// Enabled 开启后挂载 /metrics。
+	Enabled bool `env:"ECH0_METRICS_ENABLED"`
+	// Token 非空时允许携带 Authorization: Bearer <token> 的抓取请求。
+	Token string `env:"ECH0_METRICS_TOKEN"`
+	// AllowIPs 是允许直接抓取的来源 IP / CIDR；与 Token 任一满足即放行，二者都未配置时只允许本机。
+	// 按 TCP 对端地址判断，不看 X-Forwarded-For，反向代理之后请改用 Token 或放行代理地址。
+	AllowIPs []string `env:"ECH0_METRICS_ALLOW_IPS" envSeparator:","`
+}
+
+// TracingConfig 控制 OpenTelemetry 追踪，默认关闭。开启后经 OTLP/HTTP 导出，
+// 标准的 OTEL_EXPORTER_OTLP_* 环境变量（如 headers）同样生效。
+type TracingConfig struct {
+	Enabled bool `env:"ECH0_TRACING_ENABLED"`
+	// Endpoint 是 collector 的 OTLP/HTTP 地址，host:port 或完整 URL，默认 localhost:4318。
+	Endpoint string `env:"ECH0_TRACING_ENDPOINT"`
+	// Insecure 对 host:port 形式的 Endpoint 使用明文 HTTP（本地 collector 的常见部署）；完整 URL 按其 scheme。
+	Insecure bool `env:"ECH0_TRACING_INSECURE"`
+	// SampleRatio 是新 trace 的采样比例（0~1）；上游已带采样决定的请求沿用上游决定。
+	SampleRatio float64 `env:"ECH0_TRACING_SAMPLE_RATIO"`
+	ServiceName string  `env:"ECH0_TRACING_SERVICE_NAME"`
+}
+
 // Config 返回全局配置中心
 func Config() *AppConfig {
 	once.Do(func() {
@@ -331,6 +383,20 @@ func defaultConfig() *AppConfig {
 			TimeoutSeconds: 120,
 			MaxRounds:      4,
 		},
+		Trash: TrashConfig{
+			RetentionDays: 30,
+		},
+		Job: JobConfig{
+			HistoryRetentionDays: 90,
+			HistoryLimit:         50,
+			QueueSize:            3,
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4318",
+			Insecure:    true,
+			SampleRatio: 1,
+			ServiceName: "ech0",
+		},
 	}
 }
 
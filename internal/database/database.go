@@ -4,6 +4,7 @@
 package database
 
 import (
+	"database/sql"
 	"errors"
 	"os"
 	"runtime"
@@ -13,10 +14,13 @@ import (
 	sqlite_vec "github.com/asg017/sqlite-vec-go-bindings/cgo"
 	"github.com/lin-snow/ech0/internal/config"
 	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	"github.com/lin-snow/ech0/internal/metrics"
+	activitypubModel "github.com/lin-snow/ech0/internal/model/activitypub"
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	connectModel "github.com/lin-snow/ech0/internal/model/connect"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
@@ -25,6 +29,7 @@ import (
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	visitorModel "github.com/lin-snow/ech0/internal/model/visitor"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	"github.com/lin-snow/ech0/internal/tracing"
 	util "github.com/lin-snow/ech0/internal/util/err"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -47,6 +52,19 @@ func SetDB(newDB *gorm.DB) {
 	db.Store(newDB)
 }
 
+// SQLDB 返回当前连接底层的 *sql.DB；尚未初始化或已关闭时返回 nil。
+func SQLDB() *sql.DB {
+	gdb, _ := db.Load().(*gorm.DB)
+	if gdb == nil {
+		return nil
+	}
+	sqlDB, err := gdb.DB()
+	if err != nil {
+		return nil
+	}
+	return sqlDB
+}
+
 // func DBProvider() func() *gorm.DB {
 // 	return GetDB
 // }
@@ -89,7 +107,16 @@ const sqliteConnParams = "_journal_mode=WAL&_busy_timeout=5000&_synchronous=NORM
 // openSQLite 以统一的连接参数打开运行库。InitDatabase 与 HotChangeDatabase 共用，
 // 保证热切换后的新连接与启动时行为一致。
 func openSQLite(dbPath string, logLevel logger.LogLevel) (*gorm.DB, error) {
-	return gorm.Open(sqlite.Open(dbPath+"?"+sqliteConnParams), buildGormConfig(logLevel))
+	gdb, err := gorm.Open(sqlite.Open(dbPath+"?"+sqliteConnParams), buildGormConfig(logLevel))
+	if err != nil {
+		return nil, err
+	}
+	if tracing.Enabled() {
+		if err := gdb.Use(tracingPlugin{}); err != nil {
+			return nil, err
+		}
+	}
+	return gdb, nil
 }
 
 // configLogLevel 按配置解析 GORM 日志级别。
@@ -133,6 +160,8 @@ func InitDatabase() {
 			})
 		}
 		SetDB(SQLiteDB)
+		// 连接池指标每次抓取现取当前连接，热切换数据库后无需重新登记。
+		metrics.TrackSQLPool(SQLDB)
 	}
 
 	// 自动建表
@@ -160,6 +189,12 @@ func InitDatabase() {
 			dbMigration.NewUserLocalAuthBackfillMigrator(),
 			dbMigration.NewUsersPasswordDropMigrator(),
 			dbMigration.NewEchoExtensionOrphansMigrator(),
+			dbMigration.NewChatSessionThreadsMigrator(),
+			dbMigration.NewUserRoleBackfillMigrator(),
+			dbMigration.NewCommentPathBackfillMigrator(),
+			dbMigration.NewLegacyJobsMigrator(),
+			// 全文索引每次启动补齐缺失行，须排在所有 echos 表结构迁移之后。
+			dbMigration.NewEchoSearchIndexMigrator(),
 		),
 	)
 }
@@ -173,6 +208,7 @@ func MigrateDB() error {
 		&userModel.WebAuthnCredential{},
 		&echoModel.Echo{},
 		&echoModel.EchoExtension{},
+		&echoModel.EchoRevision{},
 		&embeddingModel.EchoEmbedding{},
 		&fileModel.File{},
 		&fileModel.EchoFile{},
@@ -182,11 +218,20 @@ func MigrateDB() error {
 		&echoModel.Tag{},
 		&echoModel.EchoTag{},
 		&commentModel.Comment{},
+		&commentModel.CommentReaction{},
+		&commentModel.SpamToken{},
 		&webhookModel.Webhook{},
+		&webhookModel.WebhookDelivery{},
+		&webhookModel.WebhookOutbox{},
 		&jobModel.Job{},
 		&settingModel.AccessTokenSetting{},
 		&authModel.Passkey{},
 		&visitorModel.DailyStat{},
+		&activitypubModel.Follower{},
+		&activitypubModel.Like{},
+		&copilotModel.ChatThread{},
+		&copilotModel.ChatMessage{},
+		&copilotModel.ChatAction{},
 	}
 
 	return GetDB().AutoMigrate(
@@ -4,11 +4,17 @@
 package database
 
 import (
+	"errors"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -140,3 +146,59 @@ func TestMigrateDB_AccessTokenSettingIncludesScopeFields(t *testing.T) {
 		t.Fatal("expected last_used_at to be set")
 	}
 }
+
+func TestTracingPlugin_RecordsQuerySpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "trace.db")), buildGormConfig(logger.Silent))
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.Use(tracingPlugin{}); err != nil {
+		t.Fatalf("register tracing plugin failed: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, dbErr := db.DB(); dbErr == nil {
+			_ = sqlDB.Close()
+		}
+	})
+
+	type row struct {
+		ID   uint
+		Name string
+	}
+	if err := db.AutoMigrate(&row{}); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	recorder.Reset()
+
+	if err := db.Create(&row{Name: "secret"}).Error; err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	var got row
+	if err := db.Where("name = ?", "missing").First(&got).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("expected record not found, got %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[0].Name() != "gorm.create" || spans[1].Name() != "gorm.query" {
+		t.Fatalf("unexpected span names %q, %q", spans[0].Name(), spans[1].Name())
+	}
+	for _, s := range spans {
+		for _, kv := range s.Attributes() {
+			if kv.Key == "db.query.text" && strings.Contains(kv.Value.AsString(), "secret") {
+				t.Fatalf("span %s leaked bound parameter: %s", s.Name(), kv.Value.AsString())
+			}
+		}
+	}
+	if spans[1].Status().Code == codes.Error {
+		t.Fatal("record not found must not mark the span as failed")
+	}
+}
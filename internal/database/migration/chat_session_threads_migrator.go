@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"gorm.io/gorm"
+)
+
+// chatSessionTitleRunes 与 Copilot 以首个问题自动命名线程时的截取长度一致。
+const chatSessionTitleRunes = 40
+
+// chatSessionThreadsMigrator 把旧版每用户一条的 KV 会话（chat_session:<userID>）
+// 迁成一条 copilot_threads 线程及其消息，标题取第一条用户消息，迁完删除 KV 行。
+//
+// 空会话、JSON 损坏或所属用户已不存在的会话直接丢弃——它们在旧版里同样无法被读到。
+// 整体一个事务：中途失败则什么都不改，下次启动重试。
+type chatSessionThreadsMigrator struct{}
+
+func NewChatSessionThreadsMigrator() Migrator {
+	return &chatSessionThreadsMigrator{}
+}
+
+func (m *chatSessionThreadsMigrator) Name() string {
+	return "chat_session_threads_migrator"
+}
+
+func (m *chatSessionThreadsMigrator) Key() string {
+	return commonModel.ChatSessionsMigratedKey
+}
+
+func (m *chatSessionThreadsMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *chatSessionThreadsMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	var rows []commonModel.KeyValue
+	if err := db.Where("key LIKE ?", commonModel.ChatSessionKeyPrefix+"%").Find(&rows).Error; err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			userID := strings.TrimPrefix(row.Key, commonModel.ChatSessionKeyPrefix)
+			if err := migrateChatSession(tx, userID, row.Value); err != nil {
+				return fmt.Errorf("migrate chat session of %s: %w", userID, err)
+			}
+			if err := tx.Where("key = ?", row.Key).Delete(&commonModel.KeyValue{}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func migrateChatSession(tx *gorm.DB, userID, raw string) error {
+	var msgs []copilotModel.ChatMessage
+	if err := json.Unmarshal([]byte(raw), &msgs); err != nil || len(msgs) == 0 || userID == "" {
+		return nil
+	}
+	var users int64
+	if err := tx.Model(&userModel.User{}).Where("id = ?", userID).Count(&users).Error; err != nil {
+		return err
+	}
+	if users == 0 {
+		return nil
+	}
+
+	thread := copilotModel.ChatThread{UserID: userID, Title: chatSessionTitle(msgs), MessageCount: len(msgs)}
+	if err := tx.Create(&thread).Error; err != nil {
+		return err
+	}
+	for i := range msgs {
+		msgs[i].ThreadID = thread.ID
+		msgs[i].Seq = i
+	}
+	return tx.Create(&msgs).Error
+}
+
+// chatSessionTitle 取第一条用户消息压成单行后截断，作为迁移后线程的标题。
+func chatSessionTitle(msgs []copilotModel.ChatMessage) string {
+	for _, m := range msgs {
+		if m.Role != copilotModel.RoleUser {
+			continue
+		}
+		runes := []rune(strings.Join(strings.Fields(m.Content), " "))
+		if len(runes) > chatSessionTitleRunes {
+			return string(runes[:chatSessionTitleRunes-1]) + "…"
+		}
+		return string(runes)
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"testing"
+
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChatSessionThreadsMigrator_ConvertsLegacySessions(t *testing.T) {
+	db := newLocalAuthTestDB(t)
+	require.NoError(t, db.Exec(
+		`INSERT INTO users (id, username, is_admin, is_owner, locale) VALUES ('u1','alice',1,1,'zh-CN')`,
+	).Error)
+	require.NoError(t, db.Create(&[]commonModel.KeyValue{
+		{
+			Key: commonModel.ChatSessionKeyPrefix + "u1",
+			Value: `[{"role":"user","content":"今年\n读了什么"},` +
+				`{"role":"assistant","content":"三体","sources":[{"echo_id":"e1"}],"reasoning_ms":12}]`,
+		},
+		{Key: commonModel.ChatSessionKeyPrefix + "ghost", Value: `[{"role":"user","content":"孤儿"}]`},
+		{Key: commonModel.ChatSessionKeyPrefix + "u2", Value: `{broken`},
+		{Key: commonModel.AgentSettingKey, Value: `{}`},
+	}).Error)
+
+	dbMigration.Migrate(
+		db,
+		dbMigration.WithStopOnError(),
+		dbMigration.WithMigrators(dbMigration.NewChatSessionThreadsMigrator()),
+	)
+
+	var threads []copilotModel.ChatThread
+	require.NoError(t, db.Find(&threads).Error)
+	require.Len(t, threads, 1, "只有存在的用户、可解析的会话会迁成线程")
+	assert.Equal(t, "u1", threads[0].UserID)
+	assert.Equal(t, "今年 读了什么", threads[0].Title)
+	assert.Equal(t, 2, threads[0].MessageCount)
+
+	var msgs []copilotModel.ChatMessage
+	require.NoError(t, db.Where("thread_id = ?", threads[0].ID).Order("seq").Find(&msgs).Error)
+	require.Len(t, msgs, 2)
+	assert.Equal(t, copilotModel.RoleUser, msgs[0].Role)
+	assert.Equal(t, 1, msgs[1].Seq)
+	require.Len(t, msgs[1].Sources, 1)
+	assert.Equal(t, "e1", msgs[1].Sources[0].EchoID)
+	assert.Equal(t, int64(12), msgs[1].ReasoningMs)
+
+	// 旧 KV 会话全部清掉，其它键不受影响；幂等标记写入。
+	var left int64
+	require.NoError(t, db.Model(&commonModel.KeyValue{}).
+		Where("key LIKE ?", commonModel.ChatSessionKeyPrefix+"%").Count(&left).Error)
+	assert.Zero(t, left)
+	var setting, marker commonModel.KeyValue
+	require.NoError(t, db.Where("key = ?", commonModel.AgentSettingKey).First(&setting).Error)
+	require.NoError(t, db.Where("key = ?", commonModel.ChatSessionsMigratedKey).First(&marker).Error)
+}
+
+func TestChatSessionThreadsMigrator_NoOpWithoutSessions(t *testing.T) {
+	db := newLocalAuthTestDB(t)
+
+	require.NoError(t, dbMigration.NewChatSessionThreadsMigrator().Migrate(db))
+
+	var n int64
+	require.NoError(t, db.Model(&copilotModel.ChatThread{}).Count(&n).Error)
+	assert.Zero(t, n)
+}
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"fmt"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	"gorm.io/gorm"
+)
+
+// commentPathBackfillMigrator 为引入物化路径前的存量评论回填 comments.path / depth：
+// 顶层评论的路径即自身 ID，回复逐层取父评论路径拼上自身 ID，直到没有可回填的行。
+//
+// 父评论已被彻底删除的孤儿回复退化为顶层评论（保留 parent_id 不动）。
+type commentPathBackfillMigrator struct{}
+
+func NewCommentPathBackfillMigrator() Migrator {
+	return &commentPathBackfillMigrator{}
+}
+
+func (m *commentPathBackfillMigrator) Name() string {
+	return "comment_path_backfill_migrator"
+}
+
+func (m *commentPathBackfillMigrator) Key() string {
+	return commonModel.CommentPathsBackfilledKey
+}
+
+func (m *commentPathBackfillMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *commentPathBackfillMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE comments SET path = id, depth = 0
+			WHERE path = '' AND (parent_id IS NULL OR parent_id = '')
+		`).Error; err != nil {
+			return err
+		}
+		// 每轮回填一层：父评论已有路径、自身尚无路径的回复。
+		for {
+			result := tx.Exec(`
+				UPDATE comments SET
+					path = (SELECT p.path FROM comments p WHERE p.id = comments.parent_id) || '/' || id,
+					depth = (SELECT p.depth FROM comments p WHERE p.id = comments.parent_id) + 1
+				WHERE path = '' AND parent_id IN (SELECT id FROM comments WHERE path <> '')
+			`)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				break
+			}
+		}
+		return tx.Exec(`UPDATE comments SET path = id, depth = 0 WHERE path = ''`).Error
+	})
+}
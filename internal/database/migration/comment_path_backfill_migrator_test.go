@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"testing"
+
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentPathBackfillMigrator_BuildsPathsAtAnyDepth(t *testing.T) {
+	db := newLocalAuthTestDB(t)
+	require.NoError(t, db.Exec(`
+		INSERT INTO comments (id, echo_id, parent_id, nickname, email, content, status, source, path) VALUES
+			('c1','e1',NULL,'a','','top','approved','guest',''),
+			('c2','e1','c1','b','','reply','approved','guest',''),
+			('c3','e1','c2','c','','reply to reply','approved','guest',''),
+			('c4','e1','gone','d','','orphan','approved','guest',''),
+			('c5','e1',NULL,'e','','already set','approved','guest','c5')
+	`).Error)
+
+	require.NoError(t, dbMigration.NewCommentPathBackfillMigrator().Migrate(db))
+
+	type row struct {
+		ID    string
+		Path  string
+		Depth int
+	}
+	var rows []row
+	require.NoError(t, db.Raw(`SELECT id, path, depth FROM comments ORDER BY id`).Scan(&rows).Error)
+	assert.Equal(t, []row{
+		{"c1", "c1", 0},
+		{"c2", "c1/c2", 1},
+		{"c3", "c1/c2/c3", 2},
+		{"c4", "c4", 0}, // 父评论已不存在，退化为顶层
+		{"c5", "c5", 0},
+	}, rows)
+}
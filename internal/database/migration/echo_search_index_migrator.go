@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"gorm.io/gorm"
+)
+
+// echoSearchIndexBatchSize 是回填时每批读取的 echo 行数。
+const echoSearchIndexBatchSize = 500
+
+// echoSearchIndexMigrator 建立 Echo 全文检索的 FTS5 虚表 echo_fts，并把尚未入索引的存量 echo 回填进去。
+//
+// 每次启动都会执行（CanRerun()=true，无标记键）：只补缺失行、清理已删除 echo 的残留行，
+// 因此对已建好的索引几乎零开销，同时能兜住迁移导入 / 快照恢复等绕过 EchoService 直接写库的路径。
+//
+// 二进制未以 sqlite_fts5 构建标签编译时 SQLite 没有 fts5 模块：此时跳过并记录告警，
+// 检索自动降级为 LIKE，不阻塞后续迁移器。
+type echoSearchIndexMigrator struct{}
+
+func NewEchoSearchIndexMigrator() Migrator {
+	return &echoSearchIndexMigrator{}
+}
+
+func (m *echoSearchIndexMigrator) Name() string {
+	return "echo_search_index_migrator"
+}
+
+func (m *echoSearchIndexMigrator) Key() string {
+	return ""
+}
+
+func (m *echoSearchIndexMigrator) CanRerun() bool {
+	return true
+}
+
+func (m *echoSearchIndexMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+
+	if err := db.Exec(ftsUtil.CreateEchoIndexSQL).Error; err != nil {
+		if strings.Contains(err.Error(), "no such module") {
+			logUtil.Warn(
+				"fts5 module unavailable, echo search falls back to LIKE",
+				slog.String("module", "database"),
+				logUtil.Err(err),
+			)
+			return nil
+		}
+		return err
+	}
+
+	if err := db.Exec(
+		"DELETE FROM " + ftsUtil.EchoIndexTable + " WHERE echo_id NOT IN (SELECT id FROM echos)",
+	).Error; err != nil {
+		return err
+	}
+
+	type echoRow struct {
+		ID      string
+		Content string
+	}
+	indexed := 0
+	lastID := ""
+	for {
+		// 按主键游标分页，只取索引里还没有的行；已写入的行不会再出现在下一批里。
+		var rows []echoRow
+		if err := db.Raw(
+			"SELECT id, content FROM echos WHERE id > ? AND id NOT IN (SELECT echo_id FROM "+
+				ftsUtil.EchoIndexTable+") ORDER BY id LIMIT ?",
+			lastID, echoSearchIndexBatchSize,
+		).Scan(&rows).Error; err != nil {
+			return err
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		if err := db.Transaction(func(tx *gorm.DB) error {
+			for _, row := range rows {
+				if err := tx.Exec(
+					"INSERT INTO "+ftsUtil.EchoIndexTable+"(echo_id, content) VALUES (?, ?)",
+					row.ID, ftsUtil.NormalizeText(strings.TrimSpace(row.Content)),
+				).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		indexed += len(rows)
+		lastID = rows[len(rows)-1].ID
+	}
+
+	if indexed > 0 {
+		logUtil.Info(
+			"echo search index backfilled",
+			slog.String("module", "database"),
+			slog.Int("echos", indexed),
+		)
+	}
+	return nil
+}
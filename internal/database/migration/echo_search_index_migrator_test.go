@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/database"
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// 无论二进制是否带 fts5 模块，回填迁移器都不能阻塞后续迁移器；带 fts5 时应补齐缺失行并清理残留行。
+func TestEchoSearchIndexMigrator_BackfillsOrSkips(t *testing.T) {
+	dsn := fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	database.SetDB(db)
+	if err := database.MigrateDB(); err != nil {
+		t.Fatalf("migrate db failed: %v", err)
+	}
+
+	for _, stmt := range []string{
+		`INSERT INTO echos (id, content, user_id, private, created_at) VALUES ('e1', 'hello 世界', 'u1', false, 100)`,
+		`INSERT INTO echos (id, content, user_id, private, created_at) VALUES ('e2', 'second', 'u1', false, 200)`,
+	} {
+		if err := db.Exec(stmt).Error; err != nil {
+			t.Fatalf("insert echo failed: %v", err)
+		}
+	}
+
+	migrator := dbMigration.NewEchoSearchIndexMigrator()
+	if err := migrator.Migrate(db); err != nil {
+		t.Fatalf("migrator should never fail on a healthy db: %v", err)
+	}
+	if !migrator.CanRerun() || migrator.Key() != "" {
+		t.Fatalf("search index migrator must rerun on every start")
+	}
+
+	var tables int64
+	if err := db.Raw(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", ftsUtil.EchoIndexTable,
+	).Scan(&tables).Error; err != nil {
+		t.Fatalf("inspect sqlite_master failed: %v", err)
+	}
+	if tables == 0 {
+		t.Skip("fts5 module unavailable in this build; migrator skipped as designed")
+	}
+
+	if err := db.Exec("DELETE FROM echos WHERE id = 'e2'").Error; err != nil {
+		t.Fatalf("delete echo failed: %v", err)
+	}
+	if err := db.Exec(`INSERT INTO echos (id, content, user_id, private, created_at) VALUES ('e3', 'third', 'u1', false, 300)`).Error; err != nil {
+		t.Fatalf("insert echo failed: %v", err)
+	}
+	if err := migrator.Migrate(db); err != nil {
+		t.Fatalf("rerun failed: %v", err)
+	}
+
+	var ids []string
+	if err := db.Raw("SELECT echo_id FROM " + ftsUtil.EchoIndexTable + " ORDER BY echo_id").Scan(&ids).Error; err != nil {
+		t.Fatalf("query index failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "e1" || ids[1] != "e3" {
+		t.Fatalf("expected index rows [e1 e3], got %v", ids)
+	}
+
+	var content string
+	if err := db.Raw("SELECT content FROM "+ftsUtil.EchoIndexTable+" WHERE echo_id = ?", "e1").Scan(&content).Error; err != nil {
+		t.Fatalf("query content failed: %v", err)
+	}
+	if content != "hello 世 界" {
+		t.Fatalf("expected normalized content, got %q", content)
+	}
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"fmt"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// legacyJobRow 是旧版 jobs 表（主键即 type，每类型单行）的一行。
+type legacyJobRow struct {
+	Type       string
+	Status     string
+	Phase      string
+	Error      string
+	Payload    string
+	StartedAt  *int64
+	FinishedAt *int64
+	UpdatedAt  int64
+}
+
+// legacyJobsMigrator 把旧版 jobs 表的每一行搬成 job_runs 里的一条历史（补 ID，提交时间
+// 取原开始时间），再删掉旧表。迁移作业的终态行原样保留、不标 dismissed，上传新迁移前
+// 仍须先清理，与旧语义一致。整体一个事务：中途失败则什么都不改，下次启动重试。
+type legacyJobsMigrator struct{}
+
+func NewLegacyJobsMigrator() Migrator {
+	return &legacyJobsMigrator{}
+}
+
+func (m *legacyJobsMigrator) Name() string {
+	return "legacy_jobs_migrator"
+}
+
+func (m *legacyJobsMigrator) Key() string {
+	return commonModel.LegacyJobsMigratedKey
+}
+
+func (m *legacyJobsMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *legacyJobsMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	if !db.Migrator().HasTable("jobs") {
+		return nil
+	}
+
+	var rows []legacyJobRow
+	if err := db.Table("jobs").Find(&rows).Error; err != nil {
+		return err
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			j := jobModel.Job{
+				ID:         uuidUtil.MustNewV7(),
+				Type:       row.Type,
+				Status:     jobModel.Status(row.Status),
+				Phase:      row.Phase,
+				Error:      row.Error,
+				Payload:    row.Payload,
+				CreatedAt:  row.UpdatedAt,
+				StartedAt:  row.StartedAt,
+				FinishedAt: row.FinishedAt,
+				UpdatedAt:  row.UpdatedAt,
+			}
+			if row.StartedAt != nil {
+				j.CreatedAt = *row.StartedAt
+			}
+			if err := tx.Create(&j).Error; err != nil {
+				return fmt.Errorf("migrate legacy job %s: %w", row.Type, err)
+			}
+		}
+		return tx.Exec(`DROP TABLE IF EXISTS jobs`).Error
+	})
+}
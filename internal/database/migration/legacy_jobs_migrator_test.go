@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"testing"
+
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLegacyJobsMigrator_MovesRowsIntoHistory(t *testing.T) {
+	db := newLocalAuthTestDB(t)
+	require.NoError(t, db.Exec(`CREATE TABLE jobs (
+		type TEXT PRIMARY KEY, status TEXT, phase TEXT, error TEXT, payload TEXT,
+		started_at INTEGER, finished_at INTEGER, updated_at INTEGER)`).Error)
+	require.NoError(t, db.Exec(`INSERT INTO jobs VALUES
+		('migration','success','done','','{"source_type":"ech0"}',100,200,200),
+		('reindex','failed','','boom','',NULL,NULL,300)`).Error)
+
+	dbMigration.Migrate(
+		db,
+		dbMigration.WithStopOnError(),
+		dbMigration.WithMigrators(dbMigration.NewLegacyJobsMigrator()),
+	)
+
+	var rows []jobModel.Job
+	require.NoError(t, db.Order("type").Find(&rows).Error)
+	require.Len(t, rows, 2)
+
+	mig := rows[0]
+	assert.Equal(t, jobModel.TypeMigration, mig.Type)
+	assert.NotEmpty(t, mig.ID)
+	assert.Equal(t, jobModel.StatusSuccess, mig.Status)
+	assert.Equal(t, `{"source_type":"ech0"}`, mig.Payload)
+	assert.Equal(t, int64(100), mig.CreatedAt, "提交时间取原开始时间")
+	require.NotNil(t, mig.FinishedAt)
+	assert.Equal(t, int64(200), *mig.FinishedAt)
+	assert.False(t, mig.Dismissed, "终态迁移行仍是当前作业，须先清理")
+
+	reindex := rows[1]
+	assert.Equal(t, "boom", reindex.Error)
+	assert.Equal(t, int64(300), reindex.CreatedAt, "无开始时间时退回更新时间")
+
+	assert.False(t, db.Migrator().HasTable("jobs"), "旧表应被删除")
+	var marker commonModel.KeyValue
+	require.NoError(t, db.Where("key = ?", commonModel.LegacyJobsMigratedKey).First(&marker).Error)
+}
+
+func TestLegacyJobsMigrator_NoLegacyTable(t *testing.T) {
+	db := newLocalAuthTestDB(t)
+
+	dbMigration.Migrate(
+		db,
+		dbMigration.WithStopOnError(),
+		dbMigration.WithMigrators(dbMigration.NewLegacyJobsMigrator()),
+	)
+
+	var count int64
+	require.NoError(t, db.Model(&jobModel.Job{}).Count(&count).Error)
+	assert.Zero(t, count)
+	var marker commonModel.KeyValue
+	require.NoError(t, db.Where("key = ?", commonModel.LegacyJobsMigratedKey).First(&marker).Error)
+}
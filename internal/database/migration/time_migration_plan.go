@@ -43,7 +43,7 @@ func StorageTimeColumnPlans() []TimeColumnPlan {
 		{Table: "webhooks", Column: "created_at"},
 		{Table: "webhooks", Column: "updated_at"},
 		// migration_jobs 已退役（死表，历来零行）：不再纳入时间规整计划。
-		// 通用 jobs 表的 started_at/finished_at 自始即为 UTC epoch(int64)，无需规整。
+		// 通用作业表（job_runs，前身 jobs）的时间列自始即为 UTC epoch(int64)，无需规整。
 		{Table: "access_token_settings", Column: "expiry"},
 		{Table: "access_token_settings", Column: "last_used_at"},
 		{Table: "access_token_settings", Column: "created_at"},
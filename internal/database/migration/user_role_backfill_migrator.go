@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration
+
+import (
+	"fmt"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"gorm.io/gorm"
+)
+
+// userRoleBackfillMigrator 为引入角色前的存量用户回填 users.role：
+// owner → owner，管理员 → editor，其余 → reader，与 User.EffectiveRole 的推断一致。
+//
+// 只改 role 为空的行，已设置过角色的用户不受影响。
+type userRoleBackfillMigrator struct{}
+
+func NewUserRoleBackfillMigrator() Migrator {
+	return &userRoleBackfillMigrator{}
+}
+
+func (m *userRoleBackfillMigrator) Name() string {
+	return "user_role_backfill_migrator"
+}
+
+func (m *userRoleBackfillMigrator) Key() string {
+	return commonModel.UserRolesBackfilledKey
+}
+
+func (m *userRoleBackfillMigrator) CanRerun() bool {
+	return false
+}
+
+func (m *userRoleBackfillMigrator) Migrate(db *gorm.DB) error {
+	if db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		return tx.Exec(`
+			UPDATE users SET role = CASE
+				WHEN is_owner THEN ?
+				WHEN is_admin THEN ?
+				ELSE ?
+			END
+			WHERE role IS NULL OR role = ''
+		`, userModel.RoleOwner, userModel.RoleEditor, userModel.RoleReader).Error
+	})
+}
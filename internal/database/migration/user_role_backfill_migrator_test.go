@@ -0,0 +1,39 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migration_test
+
+import (
+	"testing"
+
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserRoleBackfillMigrator_DerivesRoleFromFlags(t *testing.T) {
+	db := newLocalAuthTestDB(t)
+	require.NoError(t, db.Exec(`
+		INSERT INTO users (id, username, is_admin, is_owner, locale, role) VALUES
+			('u1','owner',1,1,'zh-CN',''),
+			('u2','admin',1,0,'zh-CN',''),
+			('u3','member',0,0,'zh-CN',''),
+			('u4','author',0,0,'zh-CN','author')
+	`).Error)
+
+	require.NoError(t, dbMigration.NewUserRoleBackfillMigrator().Migrate(db))
+
+	roles := map[string]userModel.Role{}
+	var users []userModel.User
+	require.NoError(t, db.Find(&users).Error)
+	for _, u := range users {
+		roles[u.Username] = u.Role
+	}
+	assert.Equal(t, map[string]userModel.Role{
+		"owner":  userModel.RoleOwner,
+		"admin":  userModel.RoleEditor,
+		"member": userModel.RoleReader,
+		"author": userModel.RoleAuthor, // 已有角色的不被覆盖
+	}, roles)
+}
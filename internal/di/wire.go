@@ -8,9 +8,12 @@
 package di
 
 import (
+	"time"
+
 	"github.com/google/wire"
 	"github.com/lin-snow/ech0/internal/app"
 	"github.com/lin-snow/ech0/internal/cache"
+	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/database"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	eventsubscriber "github.com/lin-snow/ech0/internal/event/subscriber"
@@ -27,9 +30,11 @@ import (
 	"github.com/lin-snow/ech0/internal/service"
 	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
 	userService "github.com/lin-snow/ech0/internal/service/user"
+	webmentionService "github.com/lin-snow/ech0/internal/service/webmention"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/task"
 	"github.com/lin-snow/ech0/internal/task/scheduled"
+	"github.com/lin-snow/ech0/internal/tracing"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/internal/visitor"
 	"github.com/lin-snow/ech0/internal/webhook"
@@ -46,17 +51,34 @@ var VisitorSet = wire.NewSet(visitor.NewTracker)
 
 // ProvideJobManager 构造已装配好 Runner 的共享单例 *job.Manager（在构造期一次性
 // 完成注册）。Runner 只依赖 EmbeddingService / migrator.ImportEngine（均不含 *job.Manager），
-// 故不会与「MigratorService 需要 Manager」形成构造环。
+// 故不会与「MigratorService 需要 Manager」形成构造环。调度上限与历史保留取自 config.Job。
 func ProvideJobManager(
 	repo job.JobRepository,
 	reindex *jobRunner.ReindexRunner,
 	migration *jobRunner.MigrationRunner,
 	export *jobRunner.ExportRunner,
+	snapshotUpload *jobRunner.SnapshotUploadRunner,
 ) *job.Manager {
-	m := job.NewManager(repo)
+	cfg := config.Config().Job
+	opts := []job.Option{
+		job.WithDefaultLimits(job.Limits{Concurrency: 1, QueueSize: cfg.QueueSize}),
+		job.WithRetention(time.Duration(cfg.HistoryRetentionDays)*24*time.Hour, cfg.HistoryLimit),
+	}
+	for jobType, n := range cfg.Concurrency {
+		opts = append(opts, job.WithLimits(jobType, job.Limits{Concurrency: n, QueueSize: cfg.QueueSize}))
+	}
+	// 以下两类不受配置影响：迁移要求「先清理再上传」，不能排队也不能并发；链上传共用同一
+	// 断点，只能串行，排一个就够——它开跑时会把期间新增的归档一并带上。
+	opts = append(opts,
+		job.WithLimits(jobModel.TypeMigration, job.Limits{Concurrency: 1}),
+		job.WithLimits(jobModel.TypeSnapshotUpload, job.Limits{Concurrency: 1, QueueSize: 1}),
+	)
+
+	m := job.NewManager(repo, opts...)
 	m.Register(jobModel.TypeReindex, job.Adapt(reindex.Run))
 	m.Register(jobModel.TypeMigration, job.Adapt(migration.Run))
 	m.Register(jobModel.TypeExport, job.Adapt(export.Run))
+	m.Register(jobModel.TypeSnapshotUpload, job.Adapt(snapshotUpload.Run))
 	return m
 }
 
@@ -66,8 +88,10 @@ func ProvideTaskManager(
 	cleanup *scheduled.Cleanup,
 	snapshot *scheduled.Snapshot,
 	visitorSnapshot *scheduled.VisitorSnapshot,
+	publish *scheduled.ScheduledPublish,
+	purgeTrash *scheduled.PurgeTrash,
 ) (*task.Manager, error) {
-	return task.NewManager(cleanup, snapshot, visitorSnapshot)
+	return task.NewManager(cleanup, snapshot, visitorSnapshot, publish, purgeTrash)
 }
 
 // StorageSet 提供进程级共享单例 *storage.Manager。storage.Manager 是有状态基础设施
@@ -110,7 +134,10 @@ var InfraSet = wire.NewSet(
 	transaction.ProviderSet,
 )
 
-var RuntimeSet = server.ProviderSet
+var RuntimeSet = wire.NewSet(
+	server.ProviderSet,
+	tracing.ProviderSet,
+)
 
 var EventSet = wire.NewSet(
 	repository.EchoSet,
@@ -121,16 +148,23 @@ var EventSet = wire.NewSet(
 	repository.WebhookSet,
 	repository.EmbeddingSet,
 
+	repository.ActivityPubSet,
+
 	webhook.NewDispatcher,
 	eventsubscriber.NewAgentProcessor,
 	eventsubscriber.NewEmbeddingProcessor,
+	eventsubscriber.NewActivityPubProcessor,
+	eventsubscriber.NewWebmentionProcessor,
 	service.EmbeddingSet,
+	service.FederationSet,
+	service.WebmentionSenderSet,
 	ProvideSubscriptionProviders,
 	eventbus.NewEventRegistry,
 )
 
 var HandlerSet = wire.NewSet(
 	repository.FileSet,
+	service.PageSet,
 	handler.WebSet,
 
 	repository.UserSet,
@@ -175,6 +209,10 @@ var HandlerSet = wire.NewSet(
 	service.EmbeddingSet,
 	handler.EmbeddingSet,
 
+	service.SearchSet,
+	handler.SearchSet,
+
+	repository.CopilotSet,
 	service.CopilotSet,
 	// Copilot 的 UserReader 跨域绑定到 user 服务（取当前对话用户：展示名 + 检索按作者收口）。
 	wire.Bind(new(copilotService.UserReader), new(*userService.UserService)),
@@ -185,6 +223,18 @@ var HandlerSet = wire.NewSet(
 
 	handler.MCPSet,
 
+	repository.ActivityPubSet,
+	service.ActivityPubSet,
+	handler.ActivityPubSet,
+
+	service.WebmentionSet,
+	handler.WebmentionSet,
+	ProvideHandlerWorkers,
+
+	service.MicropubSet,
+	handler.MicropubSet,
+	handler.JobSet,
+
 	handler.NewBundle,
 )
 
@@ -206,13 +256,17 @@ var TaskerSet = wire.NewSet(
 
 	repository.EchoSet,
 	service.EchoSet,
+	// scheduled.PurgeTrash 同时清理过期的回收站评论。
+	repository.CommentSet,
+	service.CommentSet,
 
 	repository.CommonSet,
 	service.FileSet,
 	service.CommonSet,
 
 	repository.VisitorSet,
-	// scheduled.Snapshot 依赖 migrator.ExportEngine（打包 + 尽力 S3），定时快照不走 job.Manager。
+	// scheduled.Snapshot 依赖 migrator.ExportEngine（增量打包）；打包本身不走 job.Manager，
+	// 只把链上传作为 snapshot_upload 作业提交，中断后可续传。
 	migrator.NewExportEngine,
 	scheduled.ProviderSet,
 	ProvideTaskManager,
@@ -278,7 +332,8 @@ func BuildJobManager(
 		service.EmbeddingSet,
 		// MigrationRunner ← migrator.ImportEngine（无状态导入，不含 *job.Manager）
 		migrator.NewImportEngine,
-		// ExportRunner ← migrator.ExportEngine（无状态导出，不含 *job.Manager）+ bus（发 SystemSnapshot）
+		// ExportRunner / SnapshotUploadRunner ← migrator.ExportEngine（无状态导出，不含 *job.Manager）
+		// + bus（发 SystemSnapshot）
 		migrator.NewExportEngine,
 		// 两个 Runner 的胶囊分支 ← migrator.CapsuleEngine（直连 GORM + 事务，胶囊包刻意不过 service 层）
 		ProvideGormDB,
@@ -319,15 +374,23 @@ func BuildTasker(
 	ebProvider func() *busen.Bus,
 	tracker *visitor.Tracker,
 	storageManager *storage.Manager,
+	jobManager *job.Manager,
 ) (*task.Manager, error) {
 	wire.Build(TaskerSet)
 	return &task.Manager{}, nil
 }
 
+// ProvideHandlerWorkers 收集随 HTTP 服务启停的后台 Worker（对应 ProvideSubscriptionProviders）。
+func ProvideHandlerWorkers(wm *webmentionService.WebmentionService) []handler.Worker {
+	return []handler.Worker{wm}
+}
+
 func ProvideSubscriptionProviders(
 	ap *eventsubscriber.AgentProcessor,
 	ep *eventsubscriber.EmbeddingProcessor,
+	fp *eventsubscriber.ActivityPubProcessor,
+	wp *eventsubscriber.WebmentionProcessor,
 	disp *webhook.Dispatcher,
 ) []eventbus.Subscriber {
-	return []eventbus.Subscriber{ap, ep, disp}
+	return []eventbus.Subscriber{ap, ep, fp, wp, disp}
 }
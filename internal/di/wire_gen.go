@@ -10,10 +10,12 @@ import (
 	"github.com/google/wire"
 	"github.com/lin-snow/ech0/internal/app"
 	"github.com/lin-snow/ech0/internal/cache"
+	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/database"
 	"github.com/lin-snow/ech0/internal/event/bus"
 	"github.com/lin-snow/ech0/internal/event/subscriber"
 	"github.com/lin-snow/ech0/internal/handler"
+	handler17 "github.com/lin-snow/ech0/internal/handler/activitypub"
 	handler4 "github.com/lin-snow/ech0/internal/handler/auth"
 	handler7 "github.com/lin-snow/ech0/internal/handler/comment"
 	handler9 "github.com/lin-snow/ech0/internal/handler/common"
@@ -24,10 +26,14 @@ import (
 	handler15 "github.com/lin-snow/ech0/internal/handler/embedding"
 	handler6 "github.com/lin-snow/ech0/internal/handler/file"
 	handler8 "github.com/lin-snow/ech0/internal/handler/init"
+	handler20 "github.com/lin-snow/ech0/internal/handler/job"
+	handler19 "github.com/lin-snow/ech0/internal/handler/micropub"
 	handler12 "github.com/lin-snow/ech0/internal/handler/migrator"
+	handler16 "github.com/lin-snow/ech0/internal/handler/search"
 	handler10 "github.com/lin-snow/ech0/internal/handler/setting"
 	handler3 "github.com/lin-snow/ech0/internal/handler/user"
 	handler2 "github.com/lin-snow/ech0/internal/handler/web"
+	handler18 "github.com/lin-snow/ech0/internal/handler/webmention"
 	"github.com/lin-snow/ech0/internal/job"
 	"github.com/lin-snow/ech0/internal/job/runner"
 	"github.com/lin-snow/ech0/internal/kvstore"
@@ -35,44 +41,53 @@ import (
 	"github.com/lin-snow/ech0/internal/middleware"
 	"github.com/lin-snow/ech0/internal/migrator"
 	"github.com/lin-snow/ech0/internal/model/job"
-	repository14 "github.com/lin-snow/ech0/internal/repository"
-	repository7 "github.com/lin-snow/ech0/internal/repository/auth"
-	repository8 "github.com/lin-snow/ech0/internal/repository/comment"
-	repository5 "github.com/lin-snow/ech0/internal/repository/common"
-	repository11 "github.com/lin-snow/ech0/internal/repository/connect"
+	repository16 "github.com/lin-snow/ech0/internal/repository"
+	repository3 "github.com/lin-snow/ech0/internal/repository/activitypub"
+	repository8 "github.com/lin-snow/ech0/internal/repository/auth"
+	repository9 "github.com/lin-snow/ech0/internal/repository/comment"
+	repository6 "github.com/lin-snow/ech0/internal/repository/common"
+	repository12 "github.com/lin-snow/ech0/internal/repository/connect"
+	repository13 "github.com/lin-snow/ech0/internal/repository/copilot"
 	repository2 "github.com/lin-snow/ech0/internal/repository/echo"
 	"github.com/lin-snow/ech0/internal/repository/embedding"
-	repository6 "github.com/lin-snow/ech0/internal/repository/file"
-	repository9 "github.com/lin-snow/ech0/internal/repository/init"
-	repository12 "github.com/lin-snow/ech0/internal/repository/job"
+	repository7 "github.com/lin-snow/ech0/internal/repository/file"
+	repository10 "github.com/lin-snow/ech0/internal/repository/init"
+	repository14 "github.com/lin-snow/ech0/internal/repository/job"
 	"github.com/lin-snow/ech0/internal/repository/keyvalue"
-	repository10 "github.com/lin-snow/ech0/internal/repository/setting"
-	repository4 "github.com/lin-snow/ech0/internal/repository/user"
-	repository13 "github.com/lin-snow/ech0/internal/repository/visitor"
-	repository3 "github.com/lin-snow/ech0/internal/repository/webhook"
+	repository11 "github.com/lin-snow/ech0/internal/repository/setting"
+	repository5 "github.com/lin-snow/ech0/internal/repository/user"
+	repository15 "github.com/lin-snow/ech0/internal/repository/visitor"
+	repository4 "github.com/lin-snow/ech0/internal/repository/webhook"
 	"github.com/lin-snow/ech0/internal/server"
-	service13 "github.com/lin-snow/ech0/internal/service"
+	service18 "github.com/lin-snow/ech0/internal/service"
+	service2 "github.com/lin-snow/ech0/internal/service/activitypub"
 	"github.com/lin-snow/ech0/internal/service/auth"
-	service6 "github.com/lin-snow/ech0/internal/service/comment"
-	service4 "github.com/lin-snow/ech0/internal/service/common"
-	service9 "github.com/lin-snow/ech0/internal/service/connect"
-	service12 "github.com/lin-snow/ech0/internal/service/copilot"
-	service11 "github.com/lin-snow/ech0/internal/service/dashboard"
-	service5 "github.com/lin-snow/ech0/internal/service/echo"
+	service9 "github.com/lin-snow/ech0/internal/service/comment"
+	service7 "github.com/lin-snow/ech0/internal/service/common"
+	service12 "github.com/lin-snow/ech0/internal/service/connect"
+	service16 "github.com/lin-snow/ech0/internal/service/copilot"
+	service14 "github.com/lin-snow/ech0/internal/service/dashboard"
+	service8 "github.com/lin-snow/ech0/internal/service/echo"
 	"github.com/lin-snow/ech0/internal/service/embedding"
-	service2 "github.com/lin-snow/ech0/internal/service/file"
-	service8 "github.com/lin-snow/ech0/internal/service/init"
-	service10 "github.com/lin-snow/ech0/internal/service/migrator"
-	service7 "github.com/lin-snow/ech0/internal/service/setting"
-	service3 "github.com/lin-snow/ech0/internal/service/user"
+	service5 "github.com/lin-snow/ech0/internal/service/file"
+	service11 "github.com/lin-snow/ech0/internal/service/init"
+	service17 "github.com/lin-snow/ech0/internal/service/micropub"
+	service13 "github.com/lin-snow/ech0/internal/service/migrator"
+	service4 "github.com/lin-snow/ech0/internal/service/page"
+	service15 "github.com/lin-snow/ech0/internal/service/search"
+	service10 "github.com/lin-snow/ech0/internal/service/setting"
+	service6 "github.com/lin-snow/ech0/internal/service/user"
+	service3 "github.com/lin-snow/ech0/internal/service/webmention"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/task"
 	"github.com/lin-snow/ech0/internal/task/scheduled"
+	"github.com/lin-snow/ech0/internal/tracing"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/internal/visitor"
 	"github.com/lin-snow/ech0/internal/webhook"
 	"github.com/lin-snow/ech0/pkg/busen"
 	"gorm.io/gorm"
+	"time"
 )
 
 // Injectors from wire.go:
@@ -98,7 +113,7 @@ func BuildApp() (*app.App, error) {
 		return nil, err
 	}
 	tracker := visitor.NewTracker()
-	taskManager, err := BuildTasker(v, iCache, gormTransactor, v2, tracker, manager)
+	taskManager, err := BuildTasker(v, iCache, gormTransactor, v2, tracker, manager, jobManager)
 	if err != nil {
 		return nil, err
 	}
@@ -112,7 +127,8 @@ func BuildApp() (*app.App, error) {
 		return nil, err
 	}
 	serverServer := server.ProvideHTTPServer(engine, bundle, deps)
-	v3 := app.ProvideOptions(eventRegistrar, jobManager, taskManager, serverServer, store)
+	component := tracing.NewComponent()
+	v3 := app.ProvideOptions(eventRegistrar, jobManager, taskManager, serverServer, component, store)
 	appApp := app.NewApp(v3)
 	return appApp, nil
 }
@@ -125,9 +141,14 @@ func BuildEventRegistrar(dbProvider func() *gorm.DB, ebProvider func() *busen.Bu
 	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
 	embeddingService := service.NewEmbeddingService(embeddingRepository, persistent, echoRepository)
 	embeddingProcessor := subscriber.NewEmbeddingProcessor(embeddingService)
-	webhookRepository := repository3.NewWebhookRepository(dbProvider)
+	activityPubRepository := repository3.NewActivityPubRepository(dbProvider)
+	federator := service2.NewFederator(activityPubRepository, persistent)
+	activityPubProcessor := subscriber.NewActivityPubProcessor(federator)
+	webmentionSender := service3.NewWebmentionSender(persistent)
+	webmentionProcessor := subscriber.NewWebmentionProcessor(webmentionSender)
+	webhookRepository := repository4.NewWebhookRepository(dbProvider)
 	dispatcher := webhook.NewDispatcher(webhookRepository)
-	v := ProvideSubscriptionProviders(agentProcessor, embeddingProcessor, dispatcher)
+	v := ProvideSubscriptionProviders(agentProcessor, embeddingProcessor, activityPubProcessor, webmentionProcessor, dispatcher)
 	eventRegistrar := bus.NewEventRegistry(ebProvider, v)
 	return eventRegistrar, nil
 }
@@ -135,50 +156,64 @@ func BuildEventRegistrar(dbProvider func() *gorm.DB, ebProvider func() *busen.Bu
 // BuildHandlers 使用 wire 生成的代码来构建 Handlers 实例。
 // tracker 由顶层 BuildApp/BuildServer 注入,保证整个进程只有一个 visitor.Tracker 实例。
 func BuildHandlers(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, jobManager *job.Manager, storageManager *storage.Manager) (*handler.Bundle, error) {
-	webHandler := handler2.NewWebHandler(tracker)
-	userRepository := repository4.NewUserRepository(dbProvider, appCache)
+	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
-	commonRepository := repository5.NewCommonRepository(dbProvider)
-	fileRepository := repository6.NewFileRepository(dbProvider)
-	fileService := service2.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider)
-	userService := service3.NewUserService(tx, userRepository, persistent, fileService, ebProvider)
+	pageService := service4.NewPageService(echoRepository, persistent)
+	webHandler := handler2.NewWebHandler(tracker, pageService)
+	userRepository := repository5.NewUserRepository(dbProvider, appCache)
+	commonRepository := repository6.NewCommonRepository(dbProvider)
+	fileRepository := repository7.NewFileRepository(dbProvider)
+	fileService := service5.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider)
+	userService := service6.NewUserService(tx, userRepository, persistent, fileService, ebProvider)
 	userHandler := handler3.NewUserHandler(userService)
-	authRepository := repository7.NewAuthRepository(dbProvider, appCache)
+	authRepository := repository8.NewAuthRepository(dbProvider, appCache)
 	authService := auth.NewAuthService(tx, authRepository, authRepository, persistent)
 	authHandler := handler4.NewAuthHandler(authService, userService)
-	commonService := service4.NewCommonService(commonRepository, appCache)
-	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
-	echoService := service5.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
+	commonService := service7.NewCommonService(commonRepository, appCache)
+	echoService := service8.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
 	echoHandler := handler5.NewEchoHandler(echoService)
 	fileHandler := handler6.NewFileHandler(fileService)
-	commentRepository := repository8.NewCommentRepository(dbProvider)
-	goMailSender := service6.NewGoMailSender()
-	commentService := service6.NewCommentService(commonService, commentRepository, persistent, ebProvider, goMailSender)
+	commentRepository := repository9.NewCommentRepository(dbProvider)
+	goMailSender := service9.NewGoMailSender()
+	commentService := service9.NewCommentService(tx, commonService, commentRepository, echoRepository, persistent, ebProvider, goMailSender)
 	commentHandler := handler7.NewCommentHandler(commentService)
-	initRepository := repository9.NewInitRepository(dbProvider)
-	settingRepository := repository10.NewSettingRepository(dbProvider)
-	webhookRepository := repository3.NewWebhookRepository(dbProvider)
+	initRepository := repository10.NewInitRepository(dbProvider)
+	settingRepository := repository11.NewSettingRepository(dbProvider)
+	webhookRepository := repository4.NewWebhookRepository(dbProvider)
 	sender := webhook.NewSender()
-	settingService := service7.NewSettingService(tx, commonService, fileService, storageManager, persistent, settingRepository, webhookRepository, sender, authRepository, ebProvider)
-	initService := service8.NewInitService(initRepository, userService, settingService)
+	settingService := service10.NewSettingService(tx, commonService, fileService, storageManager, persistent, settingRepository, webhookRepository, sender, authRepository, ebProvider)
+	initService := service11.NewInitService(initRepository, userService, settingService)
 	initHandler := handler8.NewInitHandler(initService)
 	commonHandler := handler9.NewCommonHandler(commonService)
 	settingHandler := handler10.NewSettingHandler(settingService)
-	connectRepository := repository11.NewConnectRepository(dbProvider)
-	connectService := service9.NewConnectService(tx, connectRepository, echoRepository, commonService, persistent)
+	connectRepository := repository12.NewConnectRepository(dbProvider)
+	connectService := service12.NewConnectService(tx, connectRepository, echoRepository, commonService, persistent)
 	connectHandler := handler11.NewConnectHandler(connectService)
-	migratorService := service10.NewMigratorService(commonService, jobManager, ebProvider)
+	migratorService := service13.NewMigratorService(commonService, jobManager, ebProvider)
 	migrationHandler := handler12.NewMigrationHandler(migratorService)
-	dashboardService := service11.NewDashboardService(tracker)
+	dashboardService := service14.NewDashboardService(tracker)
 	dashboardHandler := handler13.NewDashboardHandler(dashboardService)
 	embeddingRepository := repository.NewEmbeddingRepository(dbProvider)
 	embeddingService := service.NewEmbeddingService(embeddingRepository, persistent, echoRepository)
-	copilotService := service12.NewCopilotService(echoService, embeddingService, userService, persistent, storageManager)
+	searchService := service15.NewSearchService(echoService, embeddingService)
+	copilotRepository := repository13.NewCopilotRepository(dbProvider)
+	copilotService := service16.NewCopilotService(echoService, searchService, userService, copilotRepository, copilotRepository, persistent, storageManager)
 	copilotHandler := handler14.NewCopilotHandler(copilotService, copilotService)
 	embeddingHandler := handler15.NewEmbeddingHandler(jobManager)
-	mcpHandler := mcp.NewHandler(echoService, userService, commentService, fileService, commonService, connectService, copilotService, settingService, dashboardService)
-	bundle := handler.NewBundle(webHandler, userHandler, authHandler, echoHandler, fileHandler, commentHandler, initHandler, commonHandler, settingHandler, connectHandler, migrationHandler, dashboardHandler, copilotHandler, embeddingHandler, mcpHandler)
+	searchHandler := handler16.NewSearchHandler(searchService)
+	mcpHandler := mcp.NewHandler(echoService, userService, commentService, fileService, commonService, connectService, copilotService, settingService, dashboardService, searchService)
+	activityPubRepository := repository3.NewActivityPubRepository(dbProvider)
+	federator := service2.NewFederator(activityPubRepository, persistent)
+	activityPubService := service2.NewActivityPubService(activityPubRepository, echoRepository, commentService, commonService, persistent, federator)
+	activityPubHandler := handler17.NewActivityPubHandler(activityPubService)
+	webmentionService := service3.NewWebmentionService(echoRepository, commentService, persistent)
+	webmentionHandler := handler18.NewWebmentionHandler(webmentionService)
+	micropubService := service17.NewMicropubService(echoService, fileService, persistent)
+	micropubHandler := handler19.NewMicropubHandler(micropubService)
+	jobHandler := handler20.NewJobHandler(jobManager)
+	v := ProvideHandlerWorkers(webmentionService)
+	bundle := handler.NewBundle(webHandler, userHandler, authHandler, echoHandler, fileHandler, commentHandler, initHandler, commonHandler, settingHandler, connectHandler, migrationHandler, dashboardHandler, copilotHandler, embeddingHandler, searchHandler, mcpHandler, activityPubHandler, webmentionHandler, micropubHandler, jobHandler, v)
 	return bundle, nil
 }
 
@@ -187,7 +222,7 @@ func BuildHandlers(dbProvider func() *gorm.DB, appCache cache.ICache[string, any
 // 含 *job.Manager，故无构造环。storageManager 由顶层共享单例注入，确保迁移导入 S3
 // 设置时 reload 的就是文件服务在用的那份 Manager。
 func BuildJobManager(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], storageManager *storage.Manager, ebProvider func() *busen.Bus, tx transaction.Transactor) (*job.Manager, error) {
-	jobRepository := repository12.NewJobRepository(dbProvider)
+	jobRepository := repository14.NewJobRepository(dbProvider)
 	embeddingRepository := repository.NewEmbeddingRepository(dbProvider)
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
@@ -200,13 +235,14 @@ func BuildJobManager(dbProvider func() *gorm.DB, appCache cache.ICache[string, a
 	migrationRunner := runner.NewMigrationRunner(importEngine, capsuleEngine)
 	exportEngine := migrator.NewExportEngine(storageManager)
 	exportRunner := runner.NewExportRunner(exportEngine, capsuleEngine, ebProvider)
-	manager := ProvideJobManager(jobRepository, reindexRunner, migrationRunner, exportRunner)
+	snapshotUploadRunner := runner.NewSnapshotUploadRunner(exportEngine)
+	manager := ProvideJobManager(jobRepository, reindexRunner, migrationRunner, exportRunner, snapshotUploadRunner)
 	return manager, nil
 }
 
 // BuildMiddlewares 构建中间件依赖。
 func BuildMiddlewares(dbProvider func() *gorm.DB, appCache cache.ICache[string, any]) (*middleware.Deps, error) {
-	authRepository := repository7.NewAuthRepository(dbProvider, appCache)
+	authRepository := repository8.NewAuthRepository(dbProvider, appCache)
 	deps := middleware.NewDeps(authRepository)
 	return deps, nil
 }
@@ -241,18 +277,26 @@ func BuildServer() (*server.Server, error) {
 	return serverServer, nil
 }
 
-func BuildTasker(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, storageManager *storage.Manager) (*task.Manager, error) {
-	commonRepository := repository5.NewCommonRepository(dbProvider)
-	fileRepository := repository6.NewFileRepository(dbProvider)
-	fileService := service2.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider)
+func BuildTasker(dbProvider func() *gorm.DB, appCache cache.ICache[string, any], tx transaction.Transactor, ebProvider func() *busen.Bus, tracker *visitor.Tracker, storageManager *storage.Manager, jobManager *job.Manager) (*task.Manager, error) {
+	commonRepository := repository6.NewCommonRepository(dbProvider)
+	fileRepository := repository7.NewFileRepository(dbProvider)
+	fileService := service5.NewFileService(tx, commonRepository, fileRepository, storageManager, ebProvider)
 	cleanup := scheduled.NewCleanup(fileService)
 	keyValueRepository := keyvalue.NewKeyValueRepository(dbProvider, appCache)
 	persistent := kvstore.NewPersistent(keyValueRepository)
 	exportEngine := migrator.NewExportEngine(storageManager)
-	snapshot := scheduled.NewSnapshot(persistent, exportEngine, ebProvider)
-	visitorRepository := repository13.NewVisitorRepository(dbProvider)
+	snapshot := scheduled.NewSnapshot(persistent, exportEngine, jobManager, ebProvider)
+	visitorRepository := repository15.NewVisitorRepository(dbProvider)
 	visitorSnapshot := scheduled.NewVisitorSnapshot(tracker, visitorRepository)
-	manager, err := ProvideTaskManager(cleanup, snapshot, visitorSnapshot)
+	commonService := service7.NewCommonService(commonRepository, appCache)
+	echoRepository := repository2.NewEchoRepository(dbProvider, appCache)
+	echoService := service8.NewEchoService(tx, commonService, fileService, echoRepository, ebProvider)
+	scheduledPublish := scheduled.NewScheduledPublish(echoService)
+	commentRepository := repository9.NewCommentRepository(dbProvider)
+	goMailSender := service9.NewGoMailSender()
+	commentService := service9.NewCommentService(tx, commonService, commentRepository, echoRepository, persistent, ebProvider, goMailSender)
+	purgeTrash := scheduled.NewPurgeTrash(echoService, commentService)
+	manager, err := ProvideTaskManager(cleanup, snapshot, visitorSnapshot, scheduledPublish, purgeTrash)
 	if err != nil {
 		return nil, err
 	}
@@ -270,17 +314,27 @@ var VisitorSet = wire.NewSet(visitor.NewTracker)
 
 // ProvideJobManager 构造已装配好 Runner 的共享单例 *job.Manager（在构造期一次性
 // 完成注册）。Runner 只依赖 EmbeddingService / migrator.ImportEngine（均不含 *job.Manager），
-// 故不会与「MigratorService 需要 Manager」形成构造环。
+// 故不会与「MigratorService 需要 Manager」形成构造环。调度上限与历史保留取自 config.Job。
 func ProvideJobManager(
 	repo job.JobRepository,
 	reindex *runner.ReindexRunner,
 	migration *runner.MigrationRunner,
 	export *runner.ExportRunner,
+	snapshotUpload *runner.SnapshotUploadRunner,
 ) *job.Manager {
-	m := job.NewManager(repo)
+	cfg := config.Config().Job
+	opts := []job.Option{job.WithDefaultLimits(job.Limits{Concurrency: 1, QueueSize: cfg.QueueSize}), job.WithRetention(time.Duration(cfg.HistoryRetentionDays)*24*time.Hour, cfg.HistoryLimit)}
+	for jobType, n := range cfg.Concurrency {
+		opts = append(opts, job.WithLimits(jobType, job.Limits{Concurrency: n, QueueSize: cfg.QueueSize}))
+	}
+
+	opts = append(opts, job.WithLimits(model.TypeMigration, job.Limits{Concurrency: 1}), job.WithLimits(model.TypeSnapshotUpload, job.Limits{Concurrency: 1, QueueSize: 1}))
+
+	m := job.NewManager(repo, opts...)
 	m.Register(model.TypeReindex, job.Adapt(reindex.Run))
 	m.Register(model.TypeMigration, job.Adapt(migration.Run))
 	m.Register(model.TypeExport, job.Adapt(export.Run))
+	m.Register(model.TypeSnapshotUpload, job.Adapt(snapshotUpload.Run))
 	return m
 }
 
@@ -290,8 +344,10 @@ func ProvideTaskManager(
 	cleanup *scheduled.Cleanup,
 	snapshot *scheduled.Snapshot,
 	visitorSnapshot *scheduled.VisitorSnapshot,
+	publish *scheduled.ScheduledPublish,
+	purgeTrash *scheduled.PurgeTrash,
 ) (*task.Manager, error) {
-	return task.NewManager(cleanup, snapshot, visitorSnapshot)
+	return task.NewManager(cleanup, snapshot, visitorSnapshot, publish, purgeTrash)
 }
 
 // StorageSet 提供进程级共享单例 *storage.Manager。storage.Manager 是有状态基础设施
@@ -325,20 +381,27 @@ var DomainSet = wire.NewSet(
 
 var InfraSet = wire.NewSet(database.ProviderSet, bus.ProvideProvider, cache.ProviderSet, transaction.ProviderSet)
 
-var RuntimeSet = server.ProviderSet
+var RuntimeSet = wire.NewSet(server.ProviderSet, tracing.ProviderSet)
 
-var EventSet = wire.NewSet(repository14.EchoSet, repository14.UserSet, repository14.KeyValueSet, repository14.WebhookSet, repository14.EmbeddingSet, webhook.NewDispatcher, subscriber.NewAgentProcessor, subscriber.NewEmbeddingProcessor, service13.EmbeddingSet, ProvideSubscriptionProviders, bus.NewEventRegistry)
+var EventSet = wire.NewSet(repository16.EchoSet, repository16.UserSet, repository16.KeyValueSet, repository16.WebhookSet, repository16.EmbeddingSet, repository16.ActivityPubSet, webhook.NewDispatcher, subscriber.NewAgentProcessor, subscriber.NewEmbeddingProcessor, subscriber.NewActivityPubProcessor, subscriber.NewWebmentionProcessor, service18.EmbeddingSet, service18.FederationSet, service18.WebmentionSenderSet, ProvideSubscriptionProviders, bus.NewEventRegistry)
 
-var HandlerSet = wire.NewSet(repository14.FileSet, handler.WebSet, repository14.UserSet, repository14.AuthSet, service13.UserSet, service13.AuthSet, handler.UserSet, handler.AuthSet, repository14.EchoSet, service13.EchoSet, handler.EchoSet, repository14.CommentSet, service13.CommentSet, handler.CommentSet, repository14.CommonSet, service13.FileSet, handler.FileSet, repository14.InitSet, service13.InitSet, handler.InitSet, service13.CommonSet, handler.CommonSet, repository14.WebhookSet, webhook.NewSender, repository14.KeyValueSet, repository14.SettingSet, service13.SettingSet, handler.SettingSet, repository14.ConnectSet, service13.ConnectSet, handler.ConnectSet, service13.DashboardSet, handler.DashboardSet, repository14.EmbeddingSet, service13.EmbeddingSet, handler.EmbeddingSet, service13.CopilotSet, wire.Bind(new(service12.UserReader), new(*service3.UserService)), handler.CopilotSet, service13.MigratorSet, handler.MigrationSet, handler.MCPSet, handler.NewBundle)
+var HandlerSet = wire.NewSet(repository16.FileSet, service18.PageSet, handler.WebSet, repository16.UserSet, repository16.AuthSet, service18.UserSet, service18.AuthSet, handler.UserSet, handler.AuthSet, repository16.EchoSet, service18.EchoSet, handler.EchoSet, repository16.CommentSet, service18.CommentSet, handler.CommentSet, repository16.CommonSet, service18.FileSet, handler.FileSet, repository16.InitSet, service18.InitSet, handler.InitSet, service18.CommonSet, handler.CommonSet, repository16.WebhookSet, webhook.NewSender, repository16.KeyValueSet, repository16.SettingSet, service18.SettingSet, handler.SettingSet, repository16.ConnectSet, service18.ConnectSet, handler.ConnectSet, service18.DashboardSet, handler.DashboardSet, repository16.EmbeddingSet, service18.EmbeddingSet, handler.EmbeddingSet, service18.SearchSet, handler.SearchSet, repository16.CopilotSet, service18.CopilotSet, wire.Bind(new(service16.UserReader), new(*service6.UserService)), handler.CopilotSet, service18.MigratorSet, handler.MigrationSet, handler.MCPSet, repository16.ActivityPubSet, service18.ActivityPubSet, handler.ActivityPubSet, service18.WebmentionSet, handler.WebmentionSet, ProvideHandlerWorkers, service18.MicropubSet, handler.MicropubSet, handler.JobSet, handler.NewBundle)
 
-var MiddlewareSet = wire.NewSet(repository14.AuthSet, middleware.ProviderSet)
+var MiddlewareSet = wire.NewSet(repository16.AuthSet, middleware.ProviderSet)
 
-var TaskerSet = wire.NewSet(repository14.FileSet, repository14.KeyValueSet, repository14.WebhookSet, repository14.AuthSet, repository14.SettingSet, service13.SettingSet, repository14.EchoSet, service13.EchoSet, repository14.CommonSet, service13.FileSet, service13.CommonSet, repository14.VisitorSet, migrator.NewExportEngine, scheduled.ProviderSet, ProvideTaskManager)
+var TaskerSet = wire.NewSet(repository16.FileSet, repository16.KeyValueSet, repository16.WebhookSet, repository16.AuthSet, repository16.SettingSet, service18.SettingSet, repository16.EchoSet, service18.EchoSet, repository16.CommentSet, service18.CommentSet, repository16.CommonSet, service18.FileSet, service18.CommonSet, repository16.VisitorSet, migrator.NewExportEngine, scheduled.ProviderSet, ProvideTaskManager)
+
+// ProvideHandlerWorkers 收集随 HTTP 服务启停的后台 Worker（对应 ProvideSubscriptionProviders）。
+func ProvideHandlerWorkers(wm *service3.WebmentionService) []handler.Worker {
+	return []handler.Worker{wm}
+}
 
 func ProvideSubscriptionProviders(
 	ap *subscriber.AgentProcessor,
 	ep *subscriber.EmbeddingProcessor,
+	fp *subscriber.ActivityPubProcessor,
+	wp *subscriber.WebmentionProcessor,
 	disp *webhook.Dispatcher,
 ) []bus.Subscriber {
-	return []bus.Subscriber{ap, ep, disp}
+	return []bus.Subscriber{ap, ep, fp, wp, disp}
 }
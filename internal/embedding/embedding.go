@@ -1,18 +1,22 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package embedding 封装向量 Embedding 的外部 API 调用（OpenAI 兼容 /v1/embeddings）。
+// Package embedding 封装文本向量化：OpenAI 兼容 /v1/embeddings 的外部 API 调用，
+// 以及纯 CPU、不联网的内置本地实现（离线 / 内网部署用）。
 // 与 internal/agent 平级：agent 负责文本生成，embedding 负责向量化。
 package embedding
 
 import (
 	"context"
 	"errors"
-	"fmt"
-	"strings"
+	"time"
 
+	"github.com/lin-snow/ech0/internal/metrics"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
-	openai "github.com/sashabaranov/go-openai"
+	"github.com/lin-snow/ech0/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -22,16 +26,32 @@ var (
 	ErrModelMissing = errors.New("embedding: model missing")
 	// ErrEmptyResponse 表示服务端返回空结果
 	ErrEmptyResponse = errors.New("embedding: empty response")
+	// ErrProviderNotFound 表示配置了未知的 Embedding 提供方
+	ErrProviderNotFound = errors.New("embedding: provider not found")
 )
 
-// defaultBatchSize 是未配置批次大小时，单次 /v1/embeddings 请求的文本条数上限。
-// 取 64 是因为不少国产提供商（如 Qwen/DashScope）限制 input 数组最多 64 条；
-// OpenAI 等可承受更多，但 64 作为保守默认对所有人都安全，用户可在设置里调大/调小。
-const defaultBatchSize = 64
+// Backend 是某种向量化实现（OpenAI 兼容 API / 内置本地）的适配层。
+//
+// 返回的切片顺序与 inputs 一一对应，每条向量长度等于 setting.Dim（Dim 为 0 时由实现决定）；
+// 分批、维度校验等细节封在各自实现内部。
+type Backend interface {
+	Embed(ctx context.Context, setting settingModel.EmbeddingSetting, inputs []string) ([][]float32, error)
+}
+
+// backendFor 按 EmbeddingSetting.Provider 选择 Backend 实现；留空按 OpenAI 兼容处理
+// （provider 字段引入前的历史设置）。
+func backendFor(setting settingModel.EmbeddingSetting) (Backend, error) {
+	switch commonModel.EmbeddingProvider(setting.Provider) {
+	case "", commonModel.EmbeddingOpenAI:
+		return openaiBackend{}, nil
+	case commonModel.EmbeddingLocal:
+		return localBackend{}, nil
+	default:
+		return nil, ErrProviderNotFound
+	}
+}
 
-// Embed 批量生成文本向量（OpenAI 兼容 /v1/embeddings）。
-// 返回的切片顺序与 inputs 一一对应；inputs 超过批次上限时自动分多次请求，
-// 避免触发提供商对单次 input 数组条数的限制（如 "input数组最大不得超过64条"）。
+// Embed 批量生成文本向量，按设置的 provider 分派到对应 Backend。
 func Embed(
 	ctx context.Context,
 	setting settingModel.EmbeddingSetting,
@@ -40,72 +60,31 @@ func Embed(
 	if !setting.Enable {
 		return nil, ErrNotEnabled
 	}
-	if setting.Model == "" {
-		return nil, ErrModelMissing
+	backend, err := backendFor(setting)
+	if err != nil {
+		return nil, err
 	}
 	if len(inputs) == 0 {
 		return nil, nil
 	}
-
-	batchSize := setting.BatchSize
-	if batchSize <= 0 {
-		batchSize = defaultBatchSize
-	}
-
-	cfg := openai.DefaultConfig(setting.ApiKey)
-	if setting.BaseURL != "" {
-		// base_url 按字面量透传，由 go-openai 统一拼接 "/embeddings" 后缀
-		// （对齐 OpenAI / go-openai 惯例）。用户应填到 ".../v4"，不要带 /embeddings。
-		cfg.BaseURL = setting.BaseURL
-	}
-	client := openai.NewClientWithConfig(cfg)
-
-	// sendDim 跟踪是否向 API 传 dimensions 参数。初始值为用户配置的维度；
-	// 若 provider 不支持该参数（首批请求报错），自动降级为 0（omitempty 省略），
-	// 后续批次复用该结论，不再重试。
-	sendDim := setting.Dim
-
-	out := make([][]float32, 0, len(inputs))
-	for start := 0; start < len(inputs); start += batchSize {
-		end := min(start+batchSize, len(inputs))
-		batch := inputs[start:end]
-
-		resp, err := client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-			Model:      openai.EmbeddingModel(setting.Model),
-			Input:      batch,
-			Dimensions: sendDim,
-		})
-		if err != nil && sendDim != 0 && isDimensionsRejected(err) {
-			sendDim = 0
-			resp, err = client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
-				Model: openai.EmbeddingModel(setting.Model),
-				Input: batch,
-			})
-		}
-		if err != nil {
-			return nil, err
-		}
-		if len(resp.Data) != len(batch) {
-			return nil, ErrEmptyResponse
-		}
-		for i := range resp.Data {
-			vec := resp.Data[i].Embedding
-			if setting.Dim > 0 && len(vec) != setting.Dim {
-				return nil, fmt.Errorf(
-					"embedding: 模型 %s 返回维度 %d，与配置维度 %d 不一致，"+
-						"请调整 dim 或换用支持 dimensions 参数的模型",
-					setting.Model, len(vec), setting.Dim,
-				)
-			}
-			out = append(out, vec)
-		}
-	}
-	return out, nil
+	ctx, span := tracing.Start(ctx, "embedding.embed", trace.WithAttributes(
+		attribute.String("gen_ai.provider.name", providerLabel(setting)),
+		attribute.String("gen_ai.request.model", setting.Model),
+		attribute.Int("embedding.inputs", len(inputs)),
+	))
+	start := time.Now()
+	vecs, err := backend.Embed(ctx, setting, inputs)
+	metrics.ObserveAI(metrics.KindEmbedding, providerLabel(setting), time.Since(start), err)
+	tracing.End(span, err)
+	return vecs, err
 }
 
-// isDimensionsRejected 判断 API 错误是否因 provider 不接受 dimensions 参数导致。
-func isDimensionsRejected(err error) bool {
-	return strings.Contains(strings.ToLower(err.Error()), "dimension")
+// providerLabel 是指标里的 provider 标签，留空按 OpenAI 兼容计（与 backendFor 一致）。
+func providerLabel(setting settingModel.EmbeddingSetting) string {
+	if setting.Provider == "" {
+		return string(commonModel.EmbeddingOpenAI)
+	}
+	return setting.Provider
 }
 
 // EmbedOne 生成单条文本向量。
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+	"golang.org/x/text/unicode/norm"
+)
+
+// 各类特征的权重。词与 CJK 二元组承载主要语义；字符三元组 / CJK 单字只做补充，
+// 用来容忍词形变化、拼写差异与单字查询，权重减半以免淹没整词匹配。
+const (
+	localWordWeight    = 1.0
+	localTrigramWeight = 0.5
+	localBigramWeight  = 1.0
+	localUnigramWeight = 0.5
+)
+
+// localBackend 是内置的本地向量化实现：把文本切成词 / 字符 n-gram 特征，经特征哈希
+// （hashing trick）投影到 setting.Dim 维，再做 L2 归一化。
+//
+// 纯 CPU、不联网、无需随包分发模型文件，结果只依赖输入文本，可随时重算。它没有语义模型
+// 那样的同义词理解，本质是「词面相似度」，但对个人笔记的回顾检索已足够，且中文按字二元组
+// 切分，无需分词词典。向量已归一化，vec0 的 L2 距离与余弦距离排序一致。
+type localBackend struct{}
+
+// Embed 逐条计算本地向量。Dim 未配置时取 EmbeddingLocalDefaultDim。
+func (localBackend) Embed(
+	ctx context.Context,
+	setting settingModel.EmbeddingSetting,
+	inputs []string,
+) ([][]float32, error) {
+	dim := setting.Dim
+	if dim <= 0 {
+		dim = settingModel.EmbeddingLocalDefaultDim
+	}
+	out := make([][]float32, 0, len(inputs))
+	for _, input := range inputs {
+		// 回填一次可能上千条，尊重取消
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		out = append(out, localVector(input, dim))
+	}
+	return out, nil
+}
+
+// localVector 把一段文本投影为 dim 维单位向量；没有任何特征（空串 / 纯标点）时返回零向量。
+func localVector(text string, dim int) []float32 {
+	type term struct {
+		count  int
+		weight float64
+	}
+	terms := make(map[string]term)
+	localFeatures(text, func(feature string, weight float64) {
+		t := terms[feature]
+		t.count++
+		t.weight = weight
+		terms[feature] = t
+	})
+
+	acc := make([]float64, dim)
+	for feature, t := range terms {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum64()
+		// 最高位决定符号，使哈希碰撞的特征在期望上互相抵消而不是累加
+		sign := 1.0
+		if sum>>63 == 1 {
+			sign = -1.0
+		}
+		// 次线性 TF：同一特征重复出现的贡献按对数增长，避免长文被个别高频词主导
+		acc[sum%uint64(dim)] += sign * t.weight * (1 + math.Log(float64(t.count)))
+	}
+
+	var norm2 float64
+	for _, v := range acc {
+		norm2 += v * v
+	}
+	vec := make([]float32, dim)
+	if norm2 == 0 {
+		return vec
+	}
+	scale := 1 / math.Sqrt(norm2)
+	for i, v := range acc {
+		vec[i] = float32(v * scale)
+	}
+	return vec
+}
+
+// localFeatures 切出文本的特征并逐个回调。文本先做 NFKC + 小写，使全角 / 半角、
+// 大小写归一；随后按字符类别分段：
+//   - 拉丁字母 / 数字等组成的词：整词 + 带边界标记的字符三元组；
+//   - 中日韩文字（无空格分词）：相邻两字的二元组 + 单字；
+//   - 其余字符（空白、标点、符号）视为分隔。
+//
+// 不同类别的特征带不同前缀，避免「词 go」与「三元组 go」落在同一个哈希桶。
+func localFeatures(text string, emit func(feature string, weight float64)) {
+	text = strings.ToLower(norm.NFKC.String(text))
+
+	var word []rune
+	flushWord := func() {
+		if len(word) == 0 {
+			return
+		}
+		emit("w:"+string(word), localWordWeight)
+		padded := make([]rune, 0, len(word)+2)
+		padded = append(padded, '^')
+		padded = append(padded, word...)
+		padded = append(padded, '$')
+		for i := 0; i+3 <= len(padded); i++ {
+			emit("t:"+string(padded[i:i+3]), localTrigramWeight)
+		}
+		word = word[:0]
+	}
+
+	var prevCJK rune
+	for _, r := range text {
+		switch {
+		case ftsUtil.IsCJK(r):
+			flushWord()
+			emit("u:"+string(r), localUnigramWeight)
+			if prevCJK != 0 {
+				emit("b:"+string([]rune{prevCJK, r}), localBigramWeight)
+			}
+			prevCJK = r
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r):
+			prevCJK = 0
+			word = append(word, r)
+		default:
+			prevCJK = 0
+			flushWord()
+		}
+	}
+	flushWord()
+}
@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func localSetting(dim int) settingModel.EmbeddingSetting {
+	return settingModel.EmbeddingSetting{
+		Enable:   true,
+		Provider: string(commonModel.EmbeddingLocal),
+		Model:    settingModel.EmbeddingLocalModel,
+		Dim:      dim,
+	}
+}
+
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+func TestEmbed_LocalProvider(t *testing.T) {
+	inputs := []string{"Learning Go generics", "今天去爬山了"}
+	vecs, err := Embed(context.Background(), localSetting(128), inputs)
+	require.NoError(t, err)
+	require.Len(t, vecs, len(inputs))
+	for _, v := range vecs {
+		assert.Len(t, v, 128)
+		assert.InDelta(t, 1.0, cosine(v, v), 1e-6)
+	}
+
+	again, err := Embed(context.Background(), localSetting(128), inputs)
+	require.NoError(t, err)
+	assert.Equal(t, vecs, again, "本地向量应确定性可重算")
+}
+
+func TestEmbed_LocalDefaultDim(t *testing.T) {
+	vec, err := EmbedOne(context.Background(), localSetting(0), "hello")
+	require.NoError(t, err)
+	assert.Len(t, vec, settingModel.EmbeddingLocalDefaultDim)
+}
+
+func TestEmbed_LocalHonoursCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := Embed(ctx, localSetting(64), []string{"a", "b"})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestEmbed_UnknownProvider(t *testing.T) {
+	s := localSetting(64)
+	s.Provider = "onnx"
+	_, err := Embed(context.Background(), s, []string{"a"})
+	assert.ErrorIs(t, err, ErrProviderNotFound)
+}
+
+func TestEmbed_NotEnabled(t *testing.T) {
+	s := localSetting(64)
+	s.Enable = false
+	_, err := Embed(context.Background(), s, []string{"a"})
+	assert.ErrorIs(t, err, ErrNotEnabled)
+}
+
+// 相关文本应比无关文本更近：英文靠词与三元组，中文靠字二元组。
+func TestLocalVector_Similarity(t *testing.T) {
+	cases := []struct {
+		name, query, related, unrelated string
+	}{
+		{"english", "golang concurrency", "Notes on Go concurrency patterns in golang", "Baked sourdough bread this morning"},
+		{"inflection", "running", "went for a long run, running again tomorrow", "reading a novel"},
+		{"chinese", "周末爬山", "这个周末和朋友去爬山，山顶风很大", "今天读完了一本小说"},
+		{"fullwidth", "ＧＯＬＡＮＧ", "golang", "python"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := localVector(tc.query, 256)
+			assert.Greater(t, cosine(q, localVector(tc.related, 256)), cosine(q, localVector(tc.unrelated, 256)))
+		})
+	}
+}
+
+func TestLocalVector_NoFeatures(t *testing.T) {
+	vec := localVector(" ，。!? ", 32)
+	assert.Len(t, vec, 32)
+	for _, v := range vec {
+		assert.Zero(t, v)
+	}
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/metrics"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// defaultBatchSize 是未配置批次大小时，单次 /v1/embeddings 请求的文本条数上限。
+// 取 64 是因为不少国产提供商（如 Qwen/DashScope）限制 input 数组最多 64 条；
+// OpenAI 等可承受更多，但 64 作为保守默认对所有人都安全，用户可在设置里调大/调小。
+const defaultBatchSize = 64
+
+// openaiBackend 调用 OpenAI 兼容的 /v1/embeddings 接口。
+type openaiBackend struct{}
+
+// Embed 批量请求 /v1/embeddings。inputs 超过批次上限时自动分多次请求，
+// 避免触发提供商对单次 input 数组条数的限制（如 "input数组最大不得超过64条"）。
+func (openaiBackend) Embed(
+	ctx context.Context,
+	setting settingModel.EmbeddingSetting,
+	inputs []string,
+) ([][]float32, error) {
+	if setting.Model == "" {
+		return nil, ErrModelMissing
+	}
+
+	batchSize := setting.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+
+	cfg := openai.DefaultConfig(setting.ApiKey)
+	if setting.BaseURL != "" {
+		// base_url 按字面量透传，由 go-openai 统一拼接 "/embeddings" 后缀
+		// （对齐 OpenAI / go-openai 惯例）。用户应填到 ".../v4"，不要带 /embeddings。
+		cfg.BaseURL = setting.BaseURL
+	}
+	client := openai.NewClientWithConfig(cfg)
+
+	// sendDim 跟踪是否向 API 传 dimensions 参数。初始值为用户配置的维度；
+	// 若 provider 不支持该参数（首批请求报错），自动降级为 0（omitempty 省略），
+	// 后续批次复用该结论，不再重试。
+	sendDim := setting.Dim
+
+	out := make([][]float32, 0, len(inputs))
+	for start := 0; start < len(inputs); start += batchSize {
+		end := min(start+batchSize, len(inputs))
+		batch := inputs[start:end]
+
+		resp, err := client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+			Model:      openai.EmbeddingModel(setting.Model),
+			Input:      batch,
+			Dimensions: sendDim,
+		})
+		if err != nil && sendDim != 0 && isDimensionsRejected(err) {
+			sendDim = 0
+			resp, err = client.CreateEmbeddings(ctx, openai.EmbeddingRequest{
+				Model: openai.EmbeddingModel(setting.Model),
+				Input: batch,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+		metrics.AddAITokens(metrics.KindEmbedding, providerLabel(setting), resp.Usage.PromptTokens, 0)
+		if len(resp.Data) != len(batch) {
+			return nil, ErrEmptyResponse
+		}
+		for i := range resp.Data {
+			vec := resp.Data[i].Embedding
+			if setting.Dim > 0 && len(vec) != setting.Dim {
+				return nil, fmt.Errorf(
+					"embedding: 模型 %s 返回维度 %d，与配置维度 %d 不一致，"+
+						"请调整 dim 或换用支持 dimensions 参数的模型",
+					setting.Model, len(vec), setting.Dim,
+				)
+			}
+			out = append(out, vec)
+		}
+	}
+	return out, nil
+}
+
+// isDimensionsRejected 判断 API 错误是否因 provider 不接受 dimensions 参数导致。
+func isDimensionsRejected(err error) bool {
+	return strings.Contains(strings.ToLower(err.Error()), "dimension")
+}
@@ -8,6 +8,8 @@ import (
 	"sync"
 
 	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/metrics"
+	"github.com/lin-snow/ech0/internal/tracing"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
@@ -39,6 +41,7 @@ func New() *busen.Bus {
 				slog.Any("panic", info.Value))
 		},
 		OnPublishDone: func(info busen.PublishDone) {
+			metrics.IncEventPublished(metricTopic(info.Topic, info.EventType), info.Err != nil)
 			if info.Err == nil {
 				return
 			}
@@ -51,6 +54,7 @@ func New() *busen.Bus {
 				logUtil.Err(info.Err))
 		},
 		OnEventDropped: func(info busen.DroppedEvent) {
+			metrics.IncEventDropped(metricTopic(info.Topic, info.EventType))
 			logUtil.GetLogger().Warn("busen event dropped",
 				slog.String("event_type", safeTypeString(info.EventType)),
 				slog.String("topic", info.Topic),
@@ -60,6 +64,7 @@ func New() *busen.Bus {
 				logUtil.Err(info.Reason))
 		},
 		OnEventRejected: func(info busen.RejectedEvent) {
+			metrics.IncEventRejected(metricTopic(info.Topic, info.EventType))
 			logUtil.GetLogger().Warn("busen event rejected",
 				slog.String("event_type", safeTypeString(info.EventType)),
 				slog.String("topic", info.Topic),
@@ -74,8 +79,11 @@ func New() *busen.Bus {
 		busen.WithDefaultBuffer(ec.DefaultBuffer),
 		busen.WithDefaultOverflow(MapOverflow(ec.DefaultOverflow)),
 		busen.WithHooks(hooks),
+		busen.WithMiddleware(traceDispatch),
 		busen.WithMetadataBuilder(func(input busen.PublishMetadataInput) map[string]string {
-			return map[string]string{"source": "ech0"}
+			meta := map[string]string{"source": "ech0"}
+			tracing.Inject(input.Context, meta)
+			return meta
 		}),
 	)
 
@@ -5,33 +5,85 @@ package bus
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
+	"reflect"
 
 	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/tracing"
+	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderPhase 标记一次发布属于事务的哪个阶段，见 Notify。不带该头的发布对所有订阅可见。
+const HeaderPhase = "ech0-phase"
+
+const (
+	// PhaseInTx 是事务内的发布，只有 InTx 订阅收到，ctx 带着业务事务。
+	PhaseInTx = "in_tx"
+	// PhaseCommitted 是事务提交后的重发，只有普通订阅收到。
+	PhaseCommitted = "committed"
 )
 
 // Emit 按事件类型发布到总线（busen 按精确 Go 类型路由，无需 topic）。若事件实现 event.Keyed，
 // 则带上排序 key 以获得 busen 的 per-key 局部有序。
-func Emit[T any](ctx context.Context, b *busen.Bus, evt T) error {
-	var opts []busen.PublishOption
+func Emit[T any](ctx context.Context, b *busen.Bus, evt T, opts ...busen.PublishOption) error {
 	if k, ok := any(evt).(event.Keyed); ok {
 		if key := k.OrderingKey(); key != "" {
 			opts = append(opts, busen.WithKey(key))
 		}
 	}
-	return busen.Publish(ctx, b, evt, opts...)
+	// producer span 包住整次发布：同步订阅在其内执行，异步订阅经 ctx / 信封元数据接续到它之下。
+	ctx, span := tracing.Start(ctx, "event.publish "+reflect.TypeFor[T]().String(),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attribute.String("messaging.system", "busen")))
+	err := busen.Publish(ctx, b, evt, opts...)
+	tracing.End(span, err)
+	return err
 }
 
 // Notify 发布一个“最佳努力”副作用事件：失败仅以 Warn 记录（带事件名），绝不影响主流程。
 // 适用于 webhook / 索引 / 缓存失效等旁路通知 —— 既不该阻断业务，也不该静默吞掉错误。
+// 在事务内调用的语义同 NotifyTx，只是 InTx 订阅的失败也只记日志；业务写入需要与 webhook outbox
+// 同进同退时用 NotifyTx。
 func Notify[T any](ctx context.Context, b *busen.Bus, evt T) {
-	if err := Emit(ctx, b, evt); err != nil {
-		name := ""
-		if n, ok := any(evt).(event.Named); ok {
-			name = n.EventName()
-		}
-		logUtil.GetLogger().Warn("event publish failed", slog.String("event", name), logUtil.Err(err))
+	if err := NotifyTx(ctx, b, evt); err != nil {
+		logUtil.GetLogger().Warn("event publish failed", slog.String("event", eventName(evt)), logUtil.Err(err))
+	}
+}
+
+// NotifyTx 在事务内分两段发布：先以 PhaseInTx 同步发给 InTx 订阅（webhook outbox 借此与业务写入
+// 同一事务落库），其余订阅等事务提交后以 PhaseCommitted 收到。InTx 订阅出错时返回该错误且不再
+// 登记提交后的发布，调用方应把它作为事务函数的返回值，让业务写入一起回滚。
+//
+// 不在事务内时退化为 Notify 的最佳努力发布，恒返回 nil。
+func NotifyTx[T any](ctx context.Context, b *busen.Bus, evt T) error {
+	if !transaction.HasTx(ctx) {
+		notify(ctx, b, evt)
+		return nil
+	}
+	if err := Emit(ctx, b, evt, busen.WithHeaders(map[string]string{HeaderPhase: PhaseInTx})); err != nil {
+		return fmt.Errorf("publish %s in transaction: %w", eventName(evt), err)
+	}
+	detached := transaction.Detach(ctx)
+	transaction.AfterCommit(ctx, func() {
+		notify(detached, b, evt, busen.WithHeaders(map[string]string{HeaderPhase: PhaseCommitted}))
+	})
+	return nil
+}
+
+func notify[T any](ctx context.Context, b *busen.Bus, evt T, opts ...busen.PublishOption) {
+	if err := Emit(ctx, b, evt, opts...); err != nil {
+		logUtil.GetLogger().Warn("event publish failed", slog.String("event", eventName(evt)), logUtil.Err(err))
+	}
+}
+
+func eventName(evt any) string {
+	if n, ok := evt.(event.Named); ok {
+		return n.EventName()
 	}
+	return ""
 }
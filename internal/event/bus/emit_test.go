@@ -11,9 +11,11 @@ import (
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/pkg/busen"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 func TestEmit_DeliversToSubscriber(t *testing.T) {
@@ -125,3 +127,47 @@ func TestNotify_SwallowsPublishError(t *testing.T) {
 		eventbus.Notify(context.Background(), b, event.SystemSnapshot{Info: "after-close"})
 	})
 }
+
+// TestNotify_InTransactionSplitsPhases 校验：事务内 Notify 先同步交给 InTx 订阅（ctx 带事务），
+// 普通订阅等提交后才收到；回滚时普通订阅什么也收不到；事务外两者各收到一次。
+func TestNotify_InTransactionSplitsPhases(t *testing.T) {
+	db := helpers.NewTestDB(t)
+	tx := transaction.NewGormTransactor(func() *gorm.DB { return db })
+
+	b := helpers.NewTestBus(t)
+	var inTx, committed []string
+	unsubInTx, err := eventbus.InTx(func(ctx context.Context, e event.EchoCreated, _ map[string]string) error {
+		assert.Equal(t, e.Echo.ID != "outside", transaction.HasTx(ctx), "InTx handler should see the tx only when published in one")
+		inTx = append(inTx, e.Echo.ID)
+		return nil
+	})(b)
+	require.NoError(t, err)
+	t.Cleanup(unsubInTx)
+	unsubOn, err := eventbus.On(func(ctx context.Context, e event.EchoCreated) error {
+		assert.False(t, transaction.HasTx(ctx), "committed phase must not carry the finished tx")
+		committed = append(committed, e.Echo.ID)
+		return nil
+	})(b)
+	require.NoError(t, err)
+	t.Cleanup(unsubOn)
+
+	require.NoError(t, tx.Run(context.Background(), func(txCtx context.Context) error {
+		eventbus.Notify(txCtx, b, event.EchoCreated{Echo: echoModel.Echo{ID: "commit"}})
+		assert.Equal(t, []string{"commit"}, inTx)
+		assert.Empty(t, committed, "ordinary subscribers must wait for the commit")
+		return nil
+	}))
+	assert.Equal(t, []string{"commit"}, committed)
+
+	err = tx.Run(context.Background(), func(txCtx context.Context) error {
+		eventbus.Notify(txCtx, b, event.EchoCreated{Echo: echoModel.Echo{ID: "rollback"}})
+		return assert.AnError
+	})
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Equal(t, []string{"commit", "rollback"}, inTx)
+	assert.Equal(t, []string{"commit"}, committed)
+
+	eventbus.Notify(context.Background(), b, event.EchoCreated{Echo: echoModel.Echo{ID: "outside"}})
+	assert.Equal(t, []string{"commit", "rollback", "outside"}, inTx)
+	assert.Equal(t, []string{"commit", "outside"}, committed)
+}
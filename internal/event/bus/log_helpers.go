@@ -11,3 +11,11 @@ func safeTypeString(t reflect.Type) string {
 	}
 	return t.String()
 }
+
+// metricTopic 是事件指标的 topic 标签：发布未带 topic 时（按类型路由的常态）退回事件类型名。
+func metricTopic(topic string, t reflect.Type) string {
+	if topic != "" {
+		return topic
+	}
+	return safeTypeString(t)
+}
@@ -9,6 +9,12 @@ import (
 	"github.com/lin-snow/ech0/pkg/busen"
 )
 
+// Starting 是订阅者的可选能力：订阅全部注册成功后启动其后台 worker（如 webhook Dispatcher 的
+// outbox worker）。与 Draining 成对。
+type Starting interface {
+	Start()
+}
+
 // Draining 是订阅者的可选能力：停机时排空其内部异步资源（如 webhook Dispatcher 的 worker pool）。
 // 注册器在拆除订阅后按能力调用它，无需为某个订阅者单独开生命周期特例。
 type Draining interface {
@@ -16,8 +22,8 @@ type Draining interface {
 	Wait()
 }
 
-// EventRegistrar 在启动时把所有领域订阅者注册到总线，并在停机时统一拆除订阅、
-// 再排空实现了 Draining 的订阅者。
+// EventRegistrar 在启动时把所有领域订阅者注册到总线并启动实现了 Starting 的订阅者，
+// 在停机时统一拆除订阅、再排空实现了 Draining 的订阅者。
 type EventRegistrar struct {
 	bus         *busen.Bus
 	subscribers []Subscriber
@@ -55,6 +61,11 @@ func (er *EventRegistrar) Register() error {
 	}
 
 	er.registered.Store(true)
+	for _, sub := range er.subscribers {
+		if s, ok := sub.(Starting); ok {
+			s.Start()
+		}
+	}
 	return nil
 }
 
@@ -34,6 +34,14 @@ type drainingSubscriber struct {
 func (d *drainingSubscriber) Stop() { d.stopCalls++ }
 func (d *drainingSubscriber) Wait() { d.waitCalls++ }
 
+// startingSubscriber additionally implements eventbus.Starting.
+type startingSubscriber struct {
+	fakeSubscriber
+	startCalls int
+}
+
+func (s *startingSubscriber) Start() { s.startCalls++ }
+
 // recordingReg returns a registration that records its id into unsubLog when its
 // returned unsubscribe func is invoked.
 func recordingReg(id int, unsubLog *[]int) eventbus.Registration {
@@ -133,6 +141,22 @@ func TestEventRegistrar_StopDrainsDrainingSubscribers(t *testing.T) {
 	assert.Equal(t, 1, d.waitCalls)
 }
 
+func TestEventRegistrar_RegisterStartsStartingSubscribers(t *testing.T) {
+	failing := func(_ *busen.Bus) (func(), error) { return nil, assert.AnError }
+	s := &startingSubscriber{}
+	reg := eventbus.NewEventRegistry(newProvider(t), []eventbus.Subscriber{
+		s, fakeSubscriber{regs: []eventbus.Registration{failing}},
+	})
+	require.ErrorIs(t, reg.Register(), assert.AnError)
+	assert.Zero(t, s.startCalls, "nothing starts when registration fails")
+
+	s = &startingSubscriber{}
+	reg = eventbus.NewEventRegistry(newProvider(t), []eventbus.Subscriber{s})
+	require.NoError(t, reg.Register())
+	require.NoError(t, reg.Register())
+	assert.Equal(t, 1, s.startCalls)
+}
+
 func TestEventRegistrar_StopBeforeRegisterIsNoop(t *testing.T) {
 	var unsubLog []int
 	d := &drainingSubscriber{
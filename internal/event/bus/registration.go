@@ -18,16 +18,16 @@ type Subscriber interface {
 }
 
 // On 构造一条按 Go 类型路由的订阅（busen.Subscribe[T]），把强类型事件交给 handler。
+// 事务内发布的事件要等提交后才会收到，见 Notify。
 func On[T any](handler func(context.Context, T) error, opts ...busen.SubscribeOption) Registration {
 	return func(b *busen.Bus) (func(), error) {
 		return busen.Subscribe(b, func(ctx context.Context, e busen.Event[T]) error {
 			return handler(ctx, e.Value)
-		}, opts...)
+		}, append(opts, busen.WithFilter(skipPhase[T](PhaseInTx)))...)
 	}
 }
 
 // OnWithMeta 同 On，但把 busen 信封的 Meta（source 等元数据）一并交给 handler。
-// webhook 桥接需要 Meta 来填充中立观察，故走它而非 On —— On 只透传 Value。
 func OnWithMeta[T any](
 	handler func(context.Context, T, map[string]string) error,
 	opts ...busen.SubscribeOption,
@@ -35,6 +35,23 @@ func OnWithMeta[T any](
 	return func(b *busen.Bus) (func(), error) {
 		return busen.Subscribe(b, func(ctx context.Context, e busen.Event[T]) error {
 			return handler(ctx, e.Value, e.Meta)
-		}, opts...)
+		}, append(opts, busen.WithFilter(skipPhase[T](PhaseInTx)))...)
+	}
+}
+
+// InTx 构造一条事务内订阅：同步执行，事务内发布时 ctx 带着业务事务，handler 的写入随业务一起
+// 提交或回滚；提交后的重发不再收到。只给必须与业务原子落库的订阅者用（webhook outbox），
+// handler 要快，且不能出网。
+func InTx[T any](handler func(context.Context, T, map[string]string) error) Registration {
+	return func(b *busen.Bus) (func(), error) {
+		return busen.Subscribe(b, func(ctx context.Context, e busen.Event[T]) error {
+			return handler(ctx, e.Value, e.Meta)
+		}, busen.WithFilter(skipPhase[T](PhaseCommitted)))
+	}
+}
+
+func skipPhase[T any](phase string) func(busen.Event[T]) bool {
+	return func(e busen.Event[T]) bool {
+		return e.Headers[HeaderPhase] != phase
 	}
 }
@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package bus
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/tracing"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// traceDispatch 是 busen 的 dispatch 中间件：每次 handler 调用一个 consumer span。
+// 异步订阅的 ctx 通常仍带着发布时的 span；若已丢失（如被替换成 background），
+// 退回从信封元数据里的 traceparent 接续（见 New 的 MetadataBuilder）。
+func traceDispatch(next busen.Next) busen.Next {
+	return func(ctx context.Context, d busen.Dispatch) error {
+		if !trace.SpanContextFromContext(ctx).IsValid() {
+			ctx = tracing.Extract(ctx, d.Meta)
+		}
+		ctx, span := tracing.Start(ctx, "event.handle "+safeTypeString(d.EventType),
+			trace.WithSpanKind(trace.SpanKindConsumer),
+			trace.WithAttributes(
+				attribute.String("messaging.system", "busen"),
+				attribute.String("messaging.destination.name", metricTopic(d.Topic, d.EventType)),
+				attribute.Bool("messaging.busen.async", d.Async),
+			))
+		err := next(ctx, d)
+		tracing.End(span, err)
+		return err
+	}
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package bus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/tracing"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestEmit_AsyncSubscriberContinuesPublishTrace(t *testing.T) {
+	recorder := helpers.RecordSpans(t)
+	b := New()
+
+	got := make(chan trace.SpanContext, 1)
+	unsub, err := busen.Subscribe(b, func(ctx context.Context, _ busen.Event[ping]) error {
+		got <- trace.SpanContextFromContext(ctx)
+		return nil
+	}, AsyncParallel()...)
+	require.NoError(t, err)
+	t.Cleanup(unsub)
+
+	ctx, root := tracing.Start(context.Background(), "request")
+	require.NoError(t, Emit(ctx, b, ping{n: 1}))
+	root.End()
+
+	handlerSpan := recvWithin(t, got)
+	require.NoError(t, b.Close(context.Background()))
+	assert.Equal(t, root.SpanContext().TraceID(), handlerSpan.TraceID())
+
+	var publishID, handleParent trace.SpanID
+	for _, s := range recorder.Ended() {
+		switch s.SpanKind() {
+		case trace.SpanKindProducer:
+			publishID = s.SpanContext().SpanID()
+			assert.Equal(t, root.SpanContext().SpanID(), s.Parent().SpanID())
+		case trace.SpanKindConsumer:
+			handleParent = s.Parent().SpanID()
+			assert.Equal(t, handlerSpan.SpanID(), s.SpanContext().SpanID())
+		}
+	}
+	require.True(t, publishID.IsValid(), "Emit should record a producer span")
+	assert.Equal(t, publishID, handleParent, "handler span should be a child of the publish span")
+}
+
+func TestTraceDispatch_FallsBackToMetadata(t *testing.T) {
+	recorder := helpers.RecordSpans(t)
+
+	ctx, parent := tracing.Start(context.Background(), "publish")
+	meta := map[string]string{}
+	tracing.Inject(ctx, meta)
+	parent.End()
+
+	var seen trace.SpanContext
+	next := traceDispatch(func(ctx context.Context, _ busen.Dispatch) error {
+		seen = trace.SpanContextFromContext(ctx)
+		return nil
+	})
+	require.NoError(t, next(context.Background(), busen.Dispatch{Meta: meta}))
+
+	assert.Equal(t, parent.SpanContext().TraceID(), seen.TraceID())
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, parent.SpanContext().SpanID(), ended[1].Parent().SpanID())
+	assert.True(t, ended[1].Parent().IsRemote())
+}
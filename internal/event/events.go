@@ -33,14 +33,27 @@ type (
 		Echo echoModel.Echo
 		User userModel.User
 	}
+	// EchoDeleted 在移入回收站（Restorable=true）与彻底删除（Restorable=false）时各发一次；
+	// 订阅方据此决定是暂时撤下还是永久清理。
 	EchoDeleted struct {
+		Echo       echoModel.Echo
+		User       userModel.User
+		Restorable bool
+	}
+	// EchoRestored 在 Echo 从回收站恢复后发出，携带恢复后的完整 Echo。
+	EchoRestored struct {
 		Echo echoModel.Echo
 		User userModel.User
 	}
 
 	CommentCreated       struct{ Comment commentModel.Comment }
 	CommentStatusUpdated struct{ Comment commentModel.Comment }
-	CommentDeleted       struct{ Comment commentModel.Comment }
+	// CommentDeleted 的 Restorable 语义同 EchoDeleted。
+	CommentDeleted struct {
+		Comment    commentModel.Comment
+		Restorable bool
+	}
+	CommentRestored struct{ Comment commentModel.Comment }
 
 	ResourceUploaded struct {
 		User     userModel.User
@@ -73,9 +86,11 @@ func (UserDeleted) EventName() string            { return "user.deleted" }
 func (EchoCreated) EventName() string            { return "echo.created" }
 func (EchoUpdated) EventName() string            { return "echo.updated" }
 func (EchoDeleted) EventName() string            { return "echo.deleted" }
+func (EchoRestored) EventName() string           { return "echo.restored" }
 func (CommentCreated) EventName() string         { return "comment.created" }
 func (CommentStatusUpdated) EventName() string   { return "comment.status.updated" }
 func (CommentDeleted) EventName() string         { return "comment.deleted" }
+func (CommentRestored) EventName() string        { return "comment.restored" }
 func (ResourceUploaded) EventName() string       { return "resource.uploaded" }
 func (SystemSnapshot) EventName() string         { return "system.snapshot" }
 func (SystemExport) EventName() string           { return "system.export" }
@@ -88,7 +103,9 @@ func (e UserDeleted) OrderingKey() string          { return e.User.ID }
 func (e EchoCreated) OrderingKey() string          { return e.Echo.ID }
 func (e EchoUpdated) OrderingKey() string          { return e.Echo.ID }
 func (e EchoDeleted) OrderingKey() string          { return e.Echo.ID }
+func (e EchoRestored) OrderingKey() string         { return e.Echo.ID }
 func (e CommentCreated) OrderingKey() string       { return e.Comment.ID }
 func (e CommentStatusUpdated) OrderingKey() string { return e.Comment.ID }
 func (e CommentDeleted) OrderingKey() string       { return e.Comment.ID }
+func (e CommentRestored) OrderingKey() string      { return e.Comment.ID }
 func (e ResourceUploaded) OrderingKey() string     { return e.Key }
@@ -28,9 +28,11 @@ func TestEventName(t *testing.T) {
 		{"EchoCreated", EchoCreated{}, "echo.created"},
 		{"EchoUpdated", EchoUpdated{}, "echo.updated"},
 		{"EchoDeleted", EchoDeleted{}, "echo.deleted"},
+		{"EchoRestored", EchoRestored{}, "echo.restored"},
 		{"CommentCreated", CommentCreated{}, "comment.created"},
 		{"CommentStatusUpdated", CommentStatusUpdated{}, "comment.status.updated"},
 		{"CommentDeleted", CommentDeleted{}, "comment.deleted"},
+		{"CommentRestored", CommentRestored{}, "comment.restored"},
 		{"ResourceUploaded", ResourceUploaded{}, "resource.uploaded"},
 		{"SystemSnapshot", SystemSnapshot{}, "system.snapshot"},
 		{"SystemExport", SystemExport{}, "system.export"},
@@ -38,7 +40,7 @@ func TestEventName(t *testing.T) {
 	}
 
 	// 守卫事件总数：新增/删除事件时此处必须同步更新，避免遗漏 topic 契约锁定。
-	require.Len(t, cases, 13, "expected exactly 13 named events")
+	require.Len(t, cases, 15, "expected exactly 15 named events")
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -56,9 +58,11 @@ func TestEventNamesUnique(t *testing.T) {
 		EchoCreated{},
 		EchoUpdated{},
 		EchoDeleted{},
+		EchoRestored{},
 		CommentCreated{},
 		CommentStatusUpdated{},
 		CommentDeleted{},
+		CommentRestored{},
 		ResourceUploaded{},
 		SystemSnapshot{},
 		SystemExport{},
@@ -79,7 +83,7 @@ func TestEventNamesUnique(t *testing.T) {
 }
 
 // TestOrderingKey 锁定局部有序键：busen 对 async 订阅者按 per-key 保序。
-// 仅历史上带 WithKey 的 10 个事件实现 Keyed，键值取对应资源 ID。
+// 仅带 WithKey 的 12 个事件实现 Keyed，键值取对应资源 ID。
 func TestOrderingKey(t *testing.T) {
 	const (
 		userID    = "user-key-0001"
@@ -99,14 +103,16 @@ func TestOrderingKey(t *testing.T) {
 		{"EchoCreated", EchoCreated{Echo: echoModel.Echo{ID: echoID}}, echoID},
 		{"EchoUpdated", EchoUpdated{Echo: echoModel.Echo{ID: echoID}}, echoID},
 		{"EchoDeleted", EchoDeleted{Echo: echoModel.Echo{ID: echoID}}, echoID},
+		{"EchoRestored", EchoRestored{Echo: echoModel.Echo{ID: echoID}}, echoID},
 		{"CommentCreated", CommentCreated{Comment: commentModel.Comment{ID: commentID}}, commentID},
 		{"CommentStatusUpdated", CommentStatusUpdated{Comment: commentModel.Comment{ID: commentID}}, commentID},
 		{"CommentDeleted", CommentDeleted{Comment: commentModel.Comment{ID: commentID}}, commentID},
+		{"CommentRestored", CommentRestored{Comment: commentModel.Comment{ID: commentID}}, commentID},
 		{"ResourceUploaded", ResourceUploaded{Key: storeKey}, storeKey},
 	}
 
 	// 守卫 Keyed 事件总数。
-	require.Len(t, cases, 10, "expected exactly 10 keyed events")
+	require.Len(t, cases, 12, "expected exactly 12 keyed events")
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package subscriber
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	activitypubService "github.com/lin-snow/ech0/internal/service/activitypub"
+)
+
+// ActivityPubProcessor 把 Echo 的发布、修改、删除与恢复投递给 ActivityPub 关注者。
+// 每类事件顺序处理，避免并发投递打满远端实例的限流。
+// 未开启联邦时 Publisher 为 no-op。
+type ActivityPubProcessor struct {
+	publisher activitypubService.Publisher
+}
+
+func NewActivityPubProcessor(publisher activitypubService.Publisher) *ActivityPubProcessor {
+	return &ActivityPubProcessor{publisher: publisher}
+}
+
+func (ap *ActivityPubProcessor) HandleEchoCreated(ctx context.Context, e event.EchoCreated) error {
+	return ap.publisher.PublishEcho(ctx, e.Echo)
+}
+
+func (ap *ActivityPubProcessor) HandleEchoUpdated(ctx context.Context, e event.EchoUpdated) error {
+	return ap.publisher.UpdateEcho(ctx, e.Echo)
+}
+
+// HandleEchoRestored 把从回收站恢复的 Echo 重新发布（远端此前已收到 Delete）。
+func (ap *ActivityPubProcessor) HandleEchoRestored(ctx context.Context, e event.EchoRestored) error {
+	return ap.publisher.PublishEcho(ctx, e.Echo)
+}
+
+// HandleEchoDeleted 撤回远端的 Note；私密 Echo 从未投递（或改私密时已撤回），跳过。
+func (ap *ActivityPubProcessor) HandleEchoDeleted(ctx context.Context, e event.EchoDeleted) error {
+	if e.Echo.Private {
+		return nil
+	}
+	return ap.publisher.RetractEcho(ctx, e.Echo.ID)
+}
+
+func (ap *ActivityPubProcessor) Registrations() []eventbus.Registration {
+	return []eventbus.Registration{
+		eventbus.On(ap.HandleEchoCreated, eventbus.AsyncSequential()...),
+		eventbus.On(ap.HandleEchoUpdated, eventbus.AsyncSequential()...),
+		eventbus.On(ap.HandleEchoDeleted, eventbus.AsyncSequential()...),
+		eventbus.On(ap.HandleEchoRestored, eventbus.AsyncSequential()...),
+	}
+}
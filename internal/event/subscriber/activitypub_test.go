@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package subscriber_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/event/subscriber"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingPublisher 记录被调用的投递动作，格式为 "动作:EchoID"。
+type recordingPublisher struct {
+	calls []string
+}
+
+func (p *recordingPublisher) PublishEcho(_ context.Context, e echoModel.Echo) error {
+	p.calls = append(p.calls, "publish:"+e.ID)
+	return nil
+}
+
+func (p *recordingPublisher) UpdateEcho(_ context.Context, e echoModel.Echo) error {
+	p.calls = append(p.calls, "update:"+e.ID)
+	return nil
+}
+
+func (p *recordingPublisher) RetractEcho(_ context.Context, id string) error {
+	p.calls = append(p.calls, "retract:"+id)
+	return nil
+}
+
+// TestActivityPubProcessor_Routes 校验各 Echo 生命周期事件映射到对应的投递动作，
+// 私密 Echo 删除时不投递 Delete。
+func TestActivityPubProcessor_Routes(t *testing.T) {
+	pub := &recordingPublisher{}
+	fp := subscriber.NewActivityPubProcessor(pub)
+	ctx := helpers.CtxAnonymous()
+	e := helpers.NewEcho(func(x *echoModel.Echo) { x.ID = "echo-ap" })
+	private := helpers.NewEcho(func(x *echoModel.Echo) { x.ID = "echo-secret" }, helpers.AsPrivate)
+
+	require.NoError(t, fp.HandleEchoCreated(ctx, event.EchoCreated{Echo: e}))
+	require.NoError(t, fp.HandleEchoUpdated(ctx, event.EchoUpdated{Echo: e}))
+	require.NoError(t, fp.HandleEchoDeleted(ctx, event.EchoDeleted{Echo: e, Restorable: true}))
+	require.NoError(t, fp.HandleEchoRestored(ctx, event.EchoRestored{Echo: e}))
+	require.NoError(t, fp.HandleEchoDeleted(ctx, event.EchoDeleted{Echo: private, Restorable: true}))
+
+	assert.Equal(t, []string{
+		"publish:echo-ap",
+		"update:echo-ap",
+		"retract:echo-ap",
+		"publish:echo-ap",
+	}, pub.calls)
+	assert.Len(t, fp.Registrations(), 4)
+}
@@ -13,7 +13,8 @@ import (
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
 
-// EmbeddingProcessor 订阅 Echo 增删改事件，维护向量索引（增量）。
+// EmbeddingProcessor 订阅 Echo 增删改与回收站恢复事件，维护向量索引（增量）。
+// 移入回收站即撤下向量，恢复时重新索引。
 // 未配置/未启用 Embedding 时索引为 no-op；失败有限次重试，最终失败仅记录日志，
 // 不阻塞 Echo 主流程，存量由回填命令兜底。
 type EmbeddingProcessor struct {
@@ -32,6 +33,10 @@ func (ep *EmbeddingProcessor) HandleEchoUpdated(ctx context.Context, e event.Ech
 	return ep.withRetry(func() error { return ep.indexer.IndexEcho(ctx, e.Echo) })
 }
 
+func (ep *EmbeddingProcessor) HandleEchoRestored(ctx context.Context, e event.EchoRestored) error {
+	return ep.withRetry(func() error { return ep.indexer.IndexEcho(ctx, e.Echo) })
+}
+
 func (ep *EmbeddingProcessor) HandleEchoDeleted(ctx context.Context, e event.EchoDeleted) error {
 	return ep.indexer.RemoveEcho(ctx, e.Echo.ID)
 }
@@ -54,5 +59,6 @@ func (ep *EmbeddingProcessor) Registrations() []eventbus.Registration {
 		eventbus.On(ep.HandleEchoCreated, eventbus.AsyncParallel()...),
 		eventbus.On(ep.HandleEchoUpdated, eventbus.AsyncParallel()...),
 		eventbus.On(ep.HandleEchoDeleted, eventbus.AsyncParallel()...),
+		eventbus.On(ep.HandleEchoRestored, eventbus.AsyncParallel()...),
 	}
 }
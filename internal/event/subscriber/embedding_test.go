@@ -59,13 +59,13 @@ func TestHandleEchoCreated_Index(t *testing.T) {
 	})
 }
 
-// TestEmbeddingProcessor_Registrations checks the processor advertises its three
+// TestEmbeddingProcessor_Registrations checks the processor advertises its four
 // echo-lifecycle subscriptions (build-only; not bound to a live bus here).
 func TestEmbeddingProcessor_Registrations(t *testing.T) {
 	idx := embeddingmock.NewMockIndexer(t)
 	ep := subscriber.NewEmbeddingProcessor(idx)
 	regs := ep.Registrations()
-	require.Len(t, regs, 3)
+	require.Len(t, regs, 4)
 	for i, r := range regs {
 		assert.NotNil(t, r, "registration %d should be non-nil", i)
 	}
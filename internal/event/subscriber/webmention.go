@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package subscriber
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	webmentionService "github.com/lin-snow/ech0/internal/service/webmention"
+)
+
+// WebmentionProcessor 在 Echo 发布、修改或恢复后向正文中的外链发送 Webmention，删除后通知此前
+// 提及过的目标。修改时新旧链接都会收到通知，接收方据此更新或撤回提及。未开启 Webmention 时
+// Sender 为 no-op。
+type WebmentionProcessor struct {
+	sender webmentionService.Sender
+}
+
+func NewWebmentionProcessor(sender webmentionService.Sender) *WebmentionProcessor {
+	return &WebmentionProcessor{sender: sender}
+}
+
+func (wp *WebmentionProcessor) HandleEchoCreated(ctx context.Context, e event.EchoCreated) error {
+	return wp.sender.SendForEcho(ctx, e.Echo)
+}
+
+func (wp *WebmentionProcessor) HandleEchoUpdated(ctx context.Context, e event.EchoUpdated) error {
+	return wp.sender.SendForEcho(ctx, e.Echo)
+}
+
+func (wp *WebmentionProcessor) HandleEchoRestored(ctx context.Context, e event.EchoRestored) error {
+	return wp.sender.SendForEcho(ctx, e.Echo)
+}
+
+// HandleEchoDeleted 在移入回收站时通知；彻底删除时记录已随之清掉，再通知也不会有目标。
+func (wp *WebmentionProcessor) HandleEchoDeleted(ctx context.Context, e event.EchoDeleted) error {
+	return wp.sender.RetractForEcho(ctx, e.Echo.ID)
+}
+
+func (wp *WebmentionProcessor) Registrations() []eventbus.Registration {
+	return []eventbus.Registration{
+		eventbus.On(wp.HandleEchoCreated, eventbus.AsyncSequential()...),
+		eventbus.On(wp.HandleEchoUpdated, eventbus.AsyncSequential()...),
+		eventbus.On(wp.HandleEchoRestored, eventbus.AsyncSequential()...),
+		eventbus.On(wp.HandleEchoDeleted, eventbus.AsyncSequential()...),
+	}
+}
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package subscriber_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/event/subscriber"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingSender struct {
+	sent      []string
+	retracted []string
+}
+
+func (s *recordingSender) SendForEcho(_ context.Context, e echoModel.Echo) error {
+	s.sent = append(s.sent, e.ID)
+	return nil
+}
+
+func (s *recordingSender) RetractForEcho(_ context.Context, echoID string) error {
+	s.retracted = append(s.retracted, echoID)
+	return nil
+}
+
+func TestWebmentionProcessor_SendsOnLifecycle(t *testing.T) {
+	sender := &recordingSender{}
+	wp := subscriber.NewWebmentionProcessor(sender)
+	ctx := helpers.CtxAnonymous()
+	e := helpers.NewEcho(func(x *echoModel.Echo) { x.ID = "echo-wm" })
+
+	require.NoError(t, wp.HandleEchoCreated(ctx, event.EchoCreated{Echo: e}))
+	require.NoError(t, wp.HandleEchoUpdated(ctx, event.EchoUpdated{Echo: e}))
+	require.NoError(t, wp.HandleEchoDeleted(ctx, event.EchoDeleted{Echo: e, Restorable: true}))
+	require.NoError(t, wp.HandleEchoRestored(ctx, event.EchoRestored{Echo: e}))
+
+	assert.Equal(t, []string{"echo-wm", "echo-wm", "echo-wm"}, sender.sent)
+	assert.Equal(t, []string{"echo-wm"}, sender.retracted)
+	assert.Len(t, wp.Registrations(), 4)
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	service "github.com/lin-snow/ech0/internal/service/activitypub"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// maxInboxBytes 限制收件箱请求体大小。
+const maxInboxBytes = 1 << 20
+
+// ActivityPubHandler 提供联邦端点。它们面向其他实例而非前端，响应是 ActivityStreams JSON
+// 而不是统一的 Result 包装，故走裸 gin。
+type ActivityPubHandler struct {
+	service service.Service
+}
+
+func NewActivityPubHandler(svc service.Service) *ActivityPubHandler {
+	return &ActivityPubHandler{service: svc}
+}
+
+// WebFinger GET /.well-known/webfinger?resource=acct:user@host
+func (h *ActivityPubHandler) WebFinger(ctx *gin.Context) {
+	result, err := h.service.WebFinger(ctx.Request.Context(), ctx.Query("resource"))
+	writeDocument(ctx, model.JRDContentType, result, err)
+}
+
+// Actor GET /ap/actor
+func (h *ActivityPubHandler) Actor(ctx *gin.Context) {
+	result, err := h.service.Actor(ctx.Request.Context())
+	writeDocument(ctx, model.ContentType, result, err)
+}
+
+// Outbox GET /ap/outbox[?page=N]
+func (h *ActivityPubHandler) Outbox(ctx *gin.Context) {
+	page, _ := strconv.Atoi(ctx.Query("page"))
+	result, err := h.service.Outbox(ctx.Request.Context(), page)
+	writeDocument(ctx, model.ContentType, result, err)
+}
+
+// Followers GET /ap/followers
+func (h *ActivityPubHandler) Followers(ctx *gin.Context) {
+	result, err := h.service.Followers(ctx.Request.Context())
+	writeDocument(ctx, model.ContentType, result, err)
+}
+
+// Note GET /ap/notes/:id
+func (h *ActivityPubHandler) Note(ctx *gin.Context) {
+	result, err := h.service.Note(ctx.Request.Context(), ctx.Param("id"))
+	writeDocument(ctx, model.ContentType, result, err)
+}
+
+// Inbox POST /ap/inbox（个人收件箱与共享收件箱同一地址）。
+func (h *ActivityPubHandler) Inbox(ctx *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxInboxBytes+1))
+	if err != nil || len(body) > maxInboxBytes {
+		ctx.Status(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := h.service.HandleInbox(ctx.Request.Context(), ctx.Request, body); err != nil {
+		ctx.Status(statusOf(err))
+		return
+	}
+	ctx.Status(http.StatusAccepted)
+}
+
+func writeDocument(ctx *gin.Context, contentType string, doc any, err error) {
+	if err != nil {
+		ctx.Status(statusOf(err))
+		return
+	}
+	data, err := json.Marshal(doc)
+	if err != nil {
+		ctx.Status(http.StatusInternalServerError)
+		return
+	}
+	ctx.Data(http.StatusOK, contentType+"; charset=utf-8", data)
+}
+
+// statusOf 把服务层错误映射为状态码；未开启联邦时对外表现为端点不存在。
+func statusOf(err error) int {
+	switch {
+	case errors.Is(err, service.ErrDisabled), errors.Is(err, service.ErrNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUnauthorized):
+		logUtil.GetLogger().Info("activitypub request rejected", logUtil.Err(err))
+		return http.StatusUnauthorized
+	default:
+		logUtil.GetLogger().Warn("activitypub request failed", logUtil.Err(err))
+		return http.StatusInternalServerError
+	}
+}
@@ -117,6 +117,9 @@ func (f *fakeUserService) UpdateUser(context.Context, userModel.UserInfoDto) err
 	panic("not called")
 }
 func (f *fakeUserService) UpdateUserAdmin(context.Context, string) error { panic("not called") }
+func (f *fakeUserService) UpdateUserRole(context.Context, string, userModel.Role) error {
+	panic("not called")
+}
 func (f *fakeUserService) GetAllUsers(context.Context) ([]userModel.User, error) {
 	panic("not called")
 }
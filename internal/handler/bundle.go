@@ -4,6 +4,9 @@
 package handler
 
 import (
+	"context"
+
+	activitypubHandler "github.com/lin-snow/ech0/internal/handler/activitypub"
 	authHandler "github.com/lin-snow/ech0/internal/handler/auth"
 	commentHandler "github.com/lin-snow/ech0/internal/handler/comment"
 	commonHandler "github.com/lin-snow/ech0/internal/handler/common"
@@ -14,29 +17,46 @@ import (
 	embeddingHandler "github.com/lin-snow/ech0/internal/handler/embedding"
 	fileHandler "github.com/lin-snow/ech0/internal/handler/file"
 	initHandler "github.com/lin-snow/ech0/internal/handler/init"
+	jobHandler "github.com/lin-snow/ech0/internal/handler/job"
+	micropubHandler "github.com/lin-snow/ech0/internal/handler/micropub"
 	migratorHandler "github.com/lin-snow/ech0/internal/handler/migrator"
+	searchHandler "github.com/lin-snow/ech0/internal/handler/search"
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	userHandler "github.com/lin-snow/ech0/internal/handler/user"
 	webHandler "github.com/lin-snow/ech0/internal/handler/web"
+	webmentionHandler "github.com/lin-snow/ech0/internal/handler/webmention"
 	"github.com/lin-snow/ech0/internal/mcp"
 )
 
+// Worker 是随 HTTP 服务启停的后台工作：请求只把任务放进它的队列，由它在后台处理。
+// Server 在开始监听前 Start、停止监听后 Stop，停机时已收下的任务得以排空。
+type Worker interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
 type Bundle struct {
-	WebHandler       *webHandler.WebHandler
-	UserHandler      *userHandler.UserHandler
-	AuthHandler      *authHandler.AuthHandler
-	EchoHandler      *echoHandler.EchoHandler
-	FileHandler      *fileHandler.FileHandler
-	CommentHandler   *commentHandler.CommentHandler
-	InitHandler      *initHandler.InitHandler
-	CommonHandler    *commonHandler.CommonHandler
-	SettingHandler   *settingHandler.SettingHandler
-	ConnectHandler   *connectHandler.ConnectHandler
-	MigrationHandler *migratorHandler.MigrationHandler
-	DashboardHandler *dashboardHandler.DashboardHandler
-	CopilotHandler   *copilotHandler.CopilotHandler
-	EmbeddingHandler *embeddingHandler.EmbeddingHandler
-	MCPHandler       *mcp.Handler
+	WebHandler         *webHandler.WebHandler
+	UserHandler        *userHandler.UserHandler
+	AuthHandler        *authHandler.AuthHandler
+	EchoHandler        *echoHandler.EchoHandler
+	FileHandler        *fileHandler.FileHandler
+	CommentHandler     *commentHandler.CommentHandler
+	InitHandler        *initHandler.InitHandler
+	CommonHandler      *commonHandler.CommonHandler
+	SettingHandler     *settingHandler.SettingHandler
+	ConnectHandler     *connectHandler.ConnectHandler
+	MigrationHandler   *migratorHandler.MigrationHandler
+	DashboardHandler   *dashboardHandler.DashboardHandler
+	CopilotHandler     *copilotHandler.CopilotHandler
+	EmbeddingHandler   *embeddingHandler.EmbeddingHandler
+	SearchHandler      *searchHandler.SearchHandler
+	MCPHandler         *mcp.Handler
+	ActivityPubHandler *activitypubHandler.ActivityPubHandler
+	WebmentionHandler  *webmentionHandler.WebmentionHandler
+	MicropubHandler    *micropubHandler.MicropubHandler
+	JobHandler         *jobHandler.JobHandler
+	Workers            []Worker
 }
 
 func NewBundle(
@@ -54,23 +74,35 @@ func NewBundle(
 	dashboardHandler *dashboardHandler.DashboardHandler,
 	copilotHandler *copilotHandler.CopilotHandler,
 	embeddingHandler *embeddingHandler.EmbeddingHandler,
+	searchHandler *searchHandler.SearchHandler,
 	mcpHandler *mcp.Handler,
+	activityPubHandler *activitypubHandler.ActivityPubHandler,
+	webmentionHandler *webmentionHandler.WebmentionHandler,
+	micropubHandler *micropubHandler.MicropubHandler,
+	jobHandler *jobHandler.JobHandler,
+	workers []Worker,
 ) *Bundle {
 	return &Bundle{
-		WebHandler:       webHandler,
-		UserHandler:      userHandler,
-		AuthHandler:      authHandler,
-		EchoHandler:      echoHandler,
-		FileHandler:      fileHandler,
-		CommentHandler:   commentHandler,
-		InitHandler:      initHandler,
-		CommonHandler:    commonHandler,
-		SettingHandler:   settingHandler,
-		ConnectHandler:   connectHandler,
-		MigrationHandler: migratorHandler,
-		DashboardHandler: dashboardHandler,
-		CopilotHandler:   copilotHandler,
-		EmbeddingHandler: embeddingHandler,
-		MCPHandler:       mcpHandler,
+		WebHandler:         webHandler,
+		UserHandler:        userHandler,
+		AuthHandler:        authHandler,
+		EchoHandler:        echoHandler,
+		FileHandler:        fileHandler,
+		CommentHandler:     commentHandler,
+		InitHandler:        initHandler,
+		CommonHandler:      commonHandler,
+		SettingHandler:     settingHandler,
+		ConnectHandler:     connectHandler,
+		MigrationHandler:   migratorHandler,
+		DashboardHandler:   dashboardHandler,
+		CopilotHandler:     copilotHandler,
+		EmbeddingHandler:   embeddingHandler,
+		SearchHandler:      searchHandler,
+		MCPHandler:         mcpHandler,
+		ActivityPubHandler: activityPubHandler,
+		WebmentionHandler:  webmentionHandler,
+		MicropubHandler:    micropubHandler,
+		JobHandler:         jobHandler,
+		Workers:            workers,
 	}
 }
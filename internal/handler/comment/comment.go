@@ -63,7 +63,9 @@ func (h *CommentHandler) OptionalViewer() gin.HandlerFunc {
 }
 
 type (
-	GetFormMetaInput        struct{}
+	GetFormMetaInput struct {
+		EchoID string `query:"echo_id" doc:"可选；带上时返回该 Echo 的评论策略"`
+	}
 	ListCommentsByEchoInput struct {
 		EchoID string `query:"echo_id" required:"true" doc:"Echo ID"`
 	}
@@ -73,6 +75,13 @@ type (
 	CreateCommentInput struct {
 		Body model.CreateCommentDto
 	}
+	ListCommentThreadInput struct {
+		ID string `path:"id" doc:"评论 ID"`
+	}
+	ReactCommentInput struct {
+		ID   string `path:"id" doc:"评论 ID"`
+		Body model.ReactCommentDto
+	}
 	CreateIntegrationCommentInput struct {
 		Body model.CreateIntegrationCommentDto
 	}
@@ -83,6 +92,7 @@ type (
 		Status   string `query:"status"`
 		EchoID   string `query:"echo_id"`
 		Hot      string `query:"hot" doc:"true/false 过滤置顶；缺省不过滤"`
+		Trashed  bool   `query:"trashed" doc:"true 时只列回收站中的评论"`
 	}
 	GetCommentByIDInput struct {
 		ID string `path:"id" doc:"评论 ID"`
@@ -114,15 +124,16 @@ type (
 	FormMetaOutput       = commonModel.Result[model.FormMeta]
 	PublicCommentsOutput = commonModel.Result[[]model.PublicComment]
 	CreateCommentOutput  = commonModel.Result[model.CreateCommentResult]
+	ReactCommentOutput   = commonModel.Result[model.ReactCommentResult]
 	PanelCommentsOutput  = commonModel.Result[model.PageResult[model.Comment]]
 	CommentOutput        = commonModel.Result[model.Comment]
 	CommentSettingOutput = commonModel.Result[model.SystemSetting]
 	EmptyOutput          = commonModel.Result[any]
 )
 
-func (h *CommentHandler) GetFormMeta(ctx context.Context, _ *GetFormMetaInput) (FormMetaOutput, error) {
+func (h *CommentHandler) GetFormMeta(ctx context.Context, in *GetFormMetaInput) (FormMetaOutput, error) {
 	m := metaFrom(ctx)
-	data, err := h.commentService.GetFormMeta(ctx, m.clientIP, m.baseURL)
+	data, err := h.commentService.GetFormMeta(ctx, m.clientIP, m.baseURL, in.EchoID)
 	if err != nil {
 		return FormMetaOutput{}, err
 	}
@@ -145,6 +156,25 @@ func (h *CommentHandler) ListPublicComments(ctx context.Context, in *ListPublicC
 	return commonModel.OK(comments), nil
 }
 
+// ListCommentThread 返回一条公开评论及其全部公开回复（任意深度）。
+func (h *CommentHandler) ListCommentThread(ctx context.Context, in *ListCommentThreadInput) (PublicCommentsOutput, error) {
+	comments, err := h.commentService.ListPublicThread(ctx, strings.TrimSpace(in.ID))
+	if err != nil {
+		return PublicCommentsOutput{}, err
+	}
+	return commonModel.OK(comments), nil
+}
+
+// ReactComment 切换调用方对评论的表情回应。
+func (h *CommentHandler) ReactComment(ctx context.Context, in *ReactCommentInput) (ReactCommentOutput, error) {
+	m := metaFrom(ctx)
+	result, err := h.commentService.ToggleReaction(ctx, m.clientIP, strings.TrimSpace(in.ID), &in.Body)
+	if err != nil {
+		return ReactCommentOutput{}, err
+	}
+	return commonModel.OK(result), nil
+}
+
 func (h *CommentHandler) CreateComment(ctx context.Context, in *CreateCommentInput) (CreateCommentOutput, error) {
 	m := metaFrom(ctx)
 	result, err := h.commentService.CreateComment(ctx, m.clientIP, m.userAgent, &in.Body)
@@ -178,6 +208,7 @@ func (h *CommentHandler) ListPanelComments(ctx context.Context, in *ListPanelCom
 		Status:   in.Status,
 		EchoID:   in.EchoID,
 		Hot:      hot,
+		Trashed:  in.Trashed,
 	})
 	if err != nil {
 		return PanelCommentsOutput{}, err
@@ -214,6 +245,22 @@ func (h *CommentHandler) DeleteComment(ctx context.Context, in *DeleteCommentInp
 	return commonModel.OK[any](nil, commonModel.DELETE_SUCCESS), nil
 }
 
+// RestoreComment 把评论从回收站移回原处，审核状态保持删除前的值。
+func (h *CommentHandler) RestoreComment(ctx context.Context, in *DeleteCommentInput) (EmptyOutput, error) {
+	if err := h.commentService.RestoreComment(ctx, strings.TrimSpace(in.ID)); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil), nil
+}
+
+// PurgeComment 彻底删除回收站中的评论。
+func (h *CommentHandler) PurgeComment(ctx context.Context, in *DeleteCommentInput) (EmptyOutput, error) {
+	if err := h.commentService.PurgeComment(ctx, strings.TrimSpace(in.ID)); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.DELETE_SUCCESS), nil
+}
+
 func (h *CommentHandler) BatchAction(ctx context.Context, in *BatchActionInput) (EmptyOutput, error) {
 	if err := h.commentService.BatchAction(ctx, in.Body.Action, in.Body.IDs); err != nil {
 		return EmptyOutput{}, err
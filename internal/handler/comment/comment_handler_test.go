@@ -28,7 +28,7 @@ func TestCommentHandler_GetFormMeta(t *testing.T) {
 	t.Run("success with empty meta when no middleware ran", func(t *testing.T) {
 		svc := commentmock.NewMockService(t)
 		// 缺少 StashMeta 时 clientIP / baseURL 为零值空串。
-		svc.EXPECT().GetFormMeta(mock.Anything, "", "").
+		svc.EXPECT().GetFormMeta(mock.Anything, "", "", "").
 			Return(model.FormMeta{FormToken: "tok", EnableComment: true}, nil).Once()
 
 		h := handler.NewCommentHandler(svc)
@@ -42,7 +42,7 @@ func TestCommentHandler_GetFormMeta(t *testing.T) {
 
 	t.Run("service error is propagated", func(t *testing.T) {
 		svc := commentmock.NewMockService(t)
-		svc.EXPECT().GetFormMeta(mock.Anything, "", "").
+		svc.EXPECT().GetFormMeta(mock.Anything, "", "", "").
 			Return(model.FormMeta{}, errBoom).Once()
 
 		h := handler.NewCommentHandler(svc)
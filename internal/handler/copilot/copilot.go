@@ -6,10 +6,14 @@ package handler
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/gin-gonic/gin"
+	response "github.com/lin-snow/ech0/internal/handler/response"
 	i18n "github.com/lin-snow/ech0/internal/i18n"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
 	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
 )
@@ -30,15 +34,34 @@ func NewCopilotHandler(
 }
 
 type (
-	GetRecentInput    struct{}
-	GetSessionInput   struct{}
-	ClearSessionInput struct{}
+	GetRecentInput   struct{}
+	ListThreadsInput struct {
+		Page     int    `query:"page"`
+		PageSize int    `query:"pageSize"`
+		Search   string `query:"search" doc:"匹配标题或消息正文"`
+	}
+	ThreadIDInput struct {
+		ID string `path:"id" format:"uuid" doc:"会话 ID"`
+	}
+	CreateThreadInput struct {
+		Body copilotModel.ChatThreadDto
+	}
+	UpdateThreadInput struct {
+		ID   string `path:"id" format:"uuid" doc:"会话 ID"`
+		Body copilotModel.ChatThreadDto
+	}
+	ActionIDInput struct {
+		ID string `path:"id" format:"uuid" doc:"待确认操作 ID"`
+	}
 )
 
 type (
-	RecentOutput  = commonModel.Result[string]
-	SessionOutput = commonModel.Result[[]copilotService.ChatMessage]
-	EmptyOutput   = commonModel.Result[any]
+	RecentOutput       = commonModel.Result[string]
+	ThreadPageOutput   = commonModel.Result[commonModel.PageQueryResult[[]copilotModel.ChatThread]]
+	ThreadOutput       = commonModel.Result[*copilotModel.ChatThread]
+	ThreadDetailOutput = commonModel.Result[*copilotModel.ChatThreadDetail]
+	ActionOutput       = commonModel.Result[*copilotModel.ChatAction]
+	EmptyOutput        = commonModel.Result[any]
 )
 
 func (h *CopilotHandler) GetRecent(ctx context.Context, _ *GetRecentInput) (RecentOutput, error) {
@@ -49,22 +72,86 @@ func (h *CopilotHandler) GetRecent(ctx context.Context, _ *GetRecentInput) (Rece
 	return commonModel.OK(gen, commonModel.AGENT_GET_RECENT_SUCCESS), nil
 }
 
-func (h *CopilotHandler) GetSession(ctx context.Context, _ *GetSessionInput) (SessionOutput, error) {
-	session, err := h.chatService.GetSession(ctx)
+// ListThreads 分页列出当前用户的 Chat 会话，最近活跃的在前。
+func (h *CopilotHandler) ListThreads(ctx context.Context, in *ListThreadsInput) (ThreadPageOutput, error) {
+	result, err := h.chatService.ListThreads(ctx, commonModel.PageQueryDto{
+		Page: in.Page, PageSize: in.PageSize, Search: in.Search,
+	})
+	if err != nil {
+		return ThreadPageOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.CHAT_THREAD_LIST_SUCCESS), nil
+}
+
+func (h *CopilotHandler) CreateThread(ctx context.Context, in *CreateThreadInput) (ThreadOutput, error) {
+	thread, err := h.chatService.CreateThread(ctx, in.Body)
+	if err != nil {
+		return ThreadOutput{}, err
+	}
+	return commonModel.OK(thread, commonModel.CHAT_THREAD_CREATE_SUCCESS), nil
+}
+
+// GetThread 返回会话及其全部消息（恢复展示用）。
+func (h *CopilotHandler) GetThread(ctx context.Context, in *ThreadIDInput) (ThreadDetailOutput, error) {
+	detail, err := h.chatService.GetThread(ctx, in.ID)
+	if err != nil {
+		return ThreadDetailOutput{}, err
+	}
+	return commonModel.OK(detail, commonModel.CHAT_THREAD_GET_SUCCESS), nil
+}
+
+func (h *CopilotHandler) UpdateThread(ctx context.Context, in *UpdateThreadInput) (ThreadOutput, error) {
+	thread, err := h.chatService.UpdateThread(ctx, in.ID, in.Body)
 	if err != nil {
-		return SessionOutput{}, err
+		return ThreadOutput{}, err
 	}
-	return commonModel.OK(session, commonModel.CHAT_SESSION_GET_SUCCESS), nil
+	return commonModel.OK(thread, commonModel.CHAT_THREAD_UPDATE_SUCCESS), nil
 }
 
-func (h *CopilotHandler) ClearSession(ctx context.Context, _ *ClearSessionInput) (EmptyOutput, error) {
-	if err := h.chatService.ClearSession(ctx); err != nil {
+func (h *CopilotHandler) DeleteThread(ctx context.Context, in *ThreadIDInput) (EmptyOutput, error) {
+	if err := h.chatService.DeleteThread(ctx, in.ID); err != nil {
 		return EmptyOutput{}, err
 	}
-	return commonModel.OK[any](nil, commonModel.CHAT_SESSION_CLEAR_SUCCESS), nil
+	return commonModel.OK[any](nil, commonModel.CHAT_THREAD_DELETE_SUCCESS), nil
+}
+
+// ConfirmAction 执行 Chat 里提出的一条待确认操作（草稿、标签、可见性），返回执行后的操作。
+func (h *CopilotHandler) ConfirmAction(ctx context.Context, in *ActionIDInput) (ActionOutput, error) {
+	action, err := h.chatService.ConfirmAction(ctx, in.ID)
+	if err != nil {
+		return ActionOutput{}, err
+	}
+	return commonModel.OK(action, commonModel.CHAT_ACTION_CONFIRM_SUCCESS), nil
+}
+
+func (h *CopilotHandler) CancelAction(ctx context.Context, in *ActionIDInput) (ActionOutput, error) {
+	action, err := h.chatService.CancelAction(ctx, in.ID)
+	if err != nil {
+		return ActionOutput{}, err
+	}
+	return commonModel.OK(action, commonModel.CHAT_ACTION_CANCEL_SUCCESS), nil
+}
+
+// ExportThread 以附件下载会话（裸 gin：响应是 Markdown / JSON 文件而非 JSON 信封）。
+// ?format=markdown（默认）| json。
+func (h *CopilotHandler) ExportThread() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		timezone := timezoneUtil.NormalizeTimezone(ctx.GetHeader(timezoneUtil.DefaultTimezoneHeader))
+		export, err := h.chatService.ExportThread(ctx.Request.Context(), ctx.Param("id"), ctx.Query("format"), timezone)
+		if err != nil {
+			response.Execute(func(*gin.Context) response.Response {
+				return response.Response{Err: err}
+			})(ctx)
+			return
+		}
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename))
+		ctx.Header("Cache-Control", "no-store")
+		ctx.Data(http.StatusOK, export.ContentType, export.Body)
+	}
 }
 
 type askRequest struct {
+	ThreadID string `json:"thread_id"`
 	Question string `json:"question"`
 }
 
@@ -76,6 +163,6 @@ func (h *CopilotHandler) Ask() gin.HandlerFunc {
 		locale := i18n.LocaleFromGin(ctx)
 		// 按用户上报时区算「今天/去年/上个月」与区间日界（与 today/heatmap 一致）。
 		timezone := timezoneUtil.NormalizeTimezone(ctx.GetHeader(timezoneUtil.DefaultTimezoneHeader))
-		_ = h.chatService.AskStream(ctx.Request.Context(), req.Question, locale, timezone, ctx.Writer)
+		_ = h.chatService.AskStream(ctx.Request.Context(), req.ThreadID, req.Question, locale, timezone, ctx.Writer)
 	}
 }
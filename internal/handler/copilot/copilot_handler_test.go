@@ -13,7 +13,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
-	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	copilotmock "github.com/lin-snow/ech0/internal/test/mocks/copilotmock"
 	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
 	"github.com/stretchr/testify/assert"
@@ -60,75 +60,190 @@ func TestGetRecent(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// GetSession（框架中立）
+// Threads（框架中立）
 // ---------------------------------------------------------------------------
 
-func TestGetSession(t *testing.T) {
-	t.Run("success", func(t *testing.T) {
+func TestListThreads(t *testing.T) {
+	t.Run("success-forwards-paging", func(t *testing.T) {
 		summary := copilotmock.NewMockSummaryService(t)
 		chat := copilotmock.NewMockChatService(t)
-		want := []copilotService.ChatMessage{
-			{Role: "user", Content: "hi"},
-			{Role: "assistant", Content: "hello"},
+		want := commonModel.PageQueryResult[[]copilotModel.ChatThread]{
+			Total: 1,
+			Items: []copilotModel.ChatThread{{ID: "t1", Title: "旅行"}},
 		}
-		chat.EXPECT().GetSession(mock.Anything).Return(want, nil).Once()
+		chat.EXPECT().
+			ListThreads(mock.Anything, commonModel.PageQueryDto{Page: 2, PageSize: 5, Search: "旅"}).
+			Return(want, nil).Once()
 
 		h := NewCopilotHandler(summary, chat)
-		out, err := h.GetSession(context.Background(), &GetSessionInput{})
+		out, err := h.ListThreads(context.Background(), &ListThreadsInput{Page: 2, PageSize: 5, Search: "旅"})
 
 		require.NoError(t, err)
-		assert.Equal(t, commonModel.DEFAULT_SUCCESS_CODE, out.Code)
-		assert.Equal(t, commonModel.CHAT_SESSION_GET_SUCCESS, out.Message)
+		assert.Equal(t, commonModel.CHAT_THREAD_LIST_SUCCESS, out.Message)
 		assert.Equal(t, want, out.Data)
 	})
 
 	t.Run("service-error-passthrough", func(t *testing.T) {
 		summary := copilotmock.NewMockSummaryService(t)
 		chat := copilotmock.NewMockChatService(t)
-		sentinel := errors.New("load session failed")
-		chat.EXPECT().GetSession(mock.Anything).Return(nil, sentinel).Once()
+		sentinel := errors.New("list failed")
+		chat.EXPECT().ListThreads(mock.Anything, mock.Anything).
+			Return(commonModel.PageQueryResult[[]copilotModel.ChatThread]{}, sentinel).Once()
 
 		h := NewCopilotHandler(summary, chat)
-		out, err := h.GetSession(context.Background(), &GetSessionInput{})
+		out, err := h.ListThreads(context.Background(), &ListThreadsInput{})
 
 		require.ErrorIs(t, err, sentinel)
-		assert.Equal(t, SessionOutput{}, out)
+		assert.Equal(t, ThreadPageOutput{}, out)
 	})
 }
 
-// ---------------------------------------------------------------------------
-// ClearSession（框架中立，成功 data 为 nil）
-// ---------------------------------------------------------------------------
+func TestGetThread(t *testing.T) {
+	summary := copilotmock.NewMockSummaryService(t)
+	chat := copilotmock.NewMockChatService(t)
+	want := &copilotModel.ChatThreadDetail{
+		Thread: copilotModel.ChatThread{ID: "t1"},
+		Messages: []copilotModel.ChatMessage{
+			{Role: copilotModel.RoleUser, Content: "hi"},
+			{Role: copilotModel.RoleAssistant, Content: "hello"},
+		},
+	}
+	chat.EXPECT().GetThread(mock.Anything, "t1").Return(want, nil).Once()
+
+	h := NewCopilotHandler(summary, chat)
+	out, err := h.GetThread(context.Background(), &ThreadIDInput{ID: "t1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.CHAT_THREAD_GET_SUCCESS, out.Message)
+	assert.Equal(t, want, out.Data)
+}
+
+func TestCreateAndUpdateThread(t *testing.T) {
+	summary := copilotmock.NewMockSummaryService(t)
+	chat := copilotmock.NewMockChatService(t)
+	dto := copilotModel.ChatThreadDto{Title: "读书", TokenBudget: 4000}
+	chat.EXPECT().CreateThread(mock.Anything, dto).
+		Return(&copilotModel.ChatThread{ID: "t1", Title: "读书"}, nil).Once()
+	chat.EXPECT().UpdateThread(mock.Anything, "t1", dto).
+		Return(&copilotModel.ChatThread{ID: "t1", Title: "读书"}, nil).Once()
+
+	h := NewCopilotHandler(summary, chat)
+	created, err := h.CreateThread(context.Background(), &CreateThreadInput{Body: dto})
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.CHAT_THREAD_CREATE_SUCCESS, created.Message)
+
+	updated, err := h.UpdateThread(context.Background(), &UpdateThreadInput{ID: "t1", Body: dto})
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.CHAT_THREAD_UPDATE_SUCCESS, updated.Message)
+	assert.Equal(t, "t1", updated.Data.ID)
+}
 
-func TestClearSession(t *testing.T) {
+func TestDeleteThread(t *testing.T) {
 	t.Run("success-returns-nil-data", func(t *testing.T) {
 		summary := copilotmock.NewMockSummaryService(t)
 		chat := copilotmock.NewMockChatService(t)
-		chat.EXPECT().ClearSession(mock.Anything).Return(nil).Once()
+		chat.EXPECT().DeleteThread(mock.Anything, "t1").Return(nil).Once()
 
 		h := NewCopilotHandler(summary, chat)
-		out, err := h.ClearSession(context.Background(), &ClearSessionInput{})
+		out, err := h.DeleteThread(context.Background(), &ThreadIDInput{ID: "t1"})
 
 		require.NoError(t, err)
-		assert.Equal(t, commonModel.DEFAULT_SUCCESS_CODE, out.Code)
-		assert.Equal(t, commonModel.CHAT_SESSION_CLEAR_SUCCESS, out.Message)
+		assert.Equal(t, commonModel.CHAT_THREAD_DELETE_SUCCESS, out.Message)
 		assert.Nil(t, out.Data)
 	})
 
 	t.Run("service-error-passthrough", func(t *testing.T) {
 		summary := copilotmock.NewMockSummaryService(t)
 		chat := copilotmock.NewMockChatService(t)
-		sentinel := errors.New("clear failed")
-		chat.EXPECT().ClearSession(mock.Anything).Return(sentinel).Once()
+		sentinel := errors.New(commonModel.CHAT_THREAD_NOT_FOUND)
+		chat.EXPECT().DeleteThread(mock.Anything, "t1").Return(sentinel).Once()
 
 		h := NewCopilotHandler(summary, chat)
-		out, err := h.ClearSession(context.Background(), &ClearSessionInput{})
+		out, err := h.DeleteThread(context.Background(), &ThreadIDInput{ID: "t1"})
 
 		require.ErrorIs(t, err, sentinel)
 		assert.Equal(t, EmptyOutput{}, out)
 	})
 }
 
+func TestConfirmAndCancelAction(t *testing.T) {
+	t.Run("confirm-success", func(t *testing.T) {
+		summary := copilotmock.NewMockSummaryService(t)
+		chat := copilotmock.NewMockChatService(t)
+		done := &copilotModel.ChatAction{ID: "a1", Status: copilotModel.ActionStatusDone}
+		chat.EXPECT().ConfirmAction(mock.Anything, "a1").Return(done, nil).Once()
+
+		h := NewCopilotHandler(summary, chat)
+		out, err := h.ConfirmAction(context.Background(), &ActionIDInput{ID: "a1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.CHAT_ACTION_CONFIRM_SUCCESS, out.Message)
+		assert.Same(t, done, out.Data)
+	})
+
+	t.Run("cancel-error-passthrough", func(t *testing.T) {
+		summary := copilotmock.NewMockSummaryService(t)
+		chat := copilotmock.NewMockChatService(t)
+		sentinel := errors.New(commonModel.CHAT_ACTION_NOT_PENDING)
+		chat.EXPECT().CancelAction(mock.Anything, "a1").Return(nil, sentinel).Once()
+
+		h := NewCopilotHandler(summary, chat)
+		out, err := h.CancelAction(context.Background(), &ActionIDInput{ID: "a1"})
+
+		require.ErrorIs(t, err, sentinel)
+		assert.Equal(t, ActionOutput{}, out)
+	})
+}
+
+// ---------------------------------------------------------------------------
+// ExportThread（裸 gin 下载）
+// ---------------------------------------------------------------------------
+
+func TestExportThread_Attachment(t *testing.T) {
+	summary := copilotmock.NewMockSummaryService(t)
+	chat := copilotmock.NewMockChatService(t)
+	chat.EXPECT().
+		ExportThread(mock.Anything, "t1", "json", "Asia/Shanghai").
+		Return(copilotModel.ChatThreadExport{
+			Filename:    "ech0-chat-t1.json",
+			ContentType: "application/json; charset=utf-8",
+			Body:        []byte(`{"thread":{}}`),
+		}, nil).Once()
+
+	h := NewCopilotHandler(summary, chat)
+	r := gin.New()
+	r.GET("/chat/threads/:id/export", h.ExportThread())
+
+	req := httptest.NewRequest(http.MethodGet, "/chat/threads/t1/export?format=json", nil)
+	req.Header.Set(timezoneUtil.DefaultTimezoneHeader, "Asia/Shanghai")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `attachment; filename="ech0-chat-t1.json"`, rec.Header().Get("Content-Disposition"))
+	assert.Equal(t, "application/json; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `{"thread":{}}`, rec.Body.String())
+}
+
+func TestExportThread_ErrorUsesEnvelope(t *testing.T) {
+	summary := copilotmock.NewMockSummaryService(t)
+	chat := copilotmock.NewMockChatService(t)
+	chat.EXPECT().
+		ExportThread(mock.Anything, "t1", "pdf", "UTC").
+		Return(copilotModel.ChatThreadExport{}, errors.New(commonModel.INVALID_CHAT_EXPORT_FORMAT)).Once()
+
+	h := NewCopilotHandler(summary, chat)
+	r := gin.New()
+	r.GET("/chat/threads/:id/export", h.ExportThread())
+
+	req := httptest.NewRequest(http.MethodGet, "/chat/threads/t1/export?format=pdf", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	assert.Empty(t, rec.Header().Get("Content-Disposition"))
+	assert.Contains(t, rec.Header().Get("Content-Type"), "application/json")
+}
+
 // ---------------------------------------------------------------------------
 // Ask（裸 gin SSE）：断 header → timezone 归一化 + AskStream 被调用
 // ---------------------------------------------------------------------------
@@ -149,7 +264,7 @@ func TestAsk_TimezoneNormalizationAndStream(t *testing.T) {
 			chat := copilotmock.NewMockChatService(t)
 			// 未跑 i18n 中间件，LocaleFromGin 回退 "zh-CN"。
 			chat.EXPECT().
-				AskStream(mock.Anything, "今天怎么样", "zh-CN", tc.wantNormTZ, mock.Anything).
+				AskStream(mock.Anything, "t1", "今天怎么样", "zh-CN", tc.wantNormTZ, mock.Anything).
 				Return(nil).Once()
 
 			h := NewCopilotHandler(summary, chat)
@@ -157,7 +272,7 @@ func TestAsk_TimezoneNormalizationAndStream(t *testing.T) {
 			r.POST("/chat/ask", h.Ask())
 
 			req := httptest.NewRequest(http.MethodPost, "/chat/ask",
-				strings.NewReader(`{"question":"今天怎么样"}`))
+				strings.NewReader(`{"thread_id":"t1","question":"今天怎么样"}`))
 			req.Header.Set("Content-Type", "application/json")
 			if tc.headerTZ != "" {
 				req.Header.Set(timezoneUtil.DefaultTimezoneHeader, tc.headerTZ)
@@ -171,12 +286,12 @@ func TestAsk_TimezoneNormalizationAndStream(t *testing.T) {
 	}
 }
 
-// 非法 JSON body 被吞掉（ShouldBindJSON 错误忽略），question 退化为空串，仍调 AskStream。
+// 非法 JSON body 被吞掉（ShouldBindJSON 错误忽略），thread_id / question 退化为空串，仍调 AskStream。
 func TestAsk_InvalidBodyStillStreamsEmptyQuestion(t *testing.T) {
 	summary := copilotmock.NewMockSummaryService(t)
 	chat := copilotmock.NewMockChatService(t)
 	chat.EXPECT().
-		AskStream(mock.Anything, "", "zh-CN", "UTC", mock.Anything).
+		AskStream(mock.Anything, "", "", "zh-CN", "UTC", mock.Anything).
 		Return(nil).Once()
 
 	h := NewCopilotHandler(summary, chat)
@@ -41,6 +41,10 @@ type (
 	EchoPagePostInput struct {
 		Body commonModel.PageQueryDto
 	}
+	TrashPageInput struct {
+		Page     int `query:"page"`
+		PageSize int `query:"pageSize"`
+	}
 	GetEchosByTagIDInput struct {
 		TagID    string `path:"tagid" format:"uuid" doc:"标签 ID"`
 		Page     int    `query:"page"`
@@ -64,6 +68,15 @@ type (
 	LikeEchoInput struct {
 		ID string `path:"id" format:"uuid" doc:"Echo ID"`
 	}
+	EchoRevisionInput struct {
+		ID         string `path:"id"         format:"uuid" doc:"Echo ID"`
+		RevisionID string `path:"revisionId" format:"uuid" doc:"修订 ID"`
+	}
+	GetEchoRevisionInput struct {
+		ID         string `path:"id"         format:"uuid" doc:"Echo ID"`
+		RevisionID string `path:"revisionId" format:"uuid" doc:"修订 ID"`
+		Compare    string `query:"compare" enum:"next,current" default:"next" doc:"对比基准：next 为下一个版本（最新修订即当前内容），current 为当前内容"`
+	}
 )
 
 type (
@@ -73,6 +86,9 @@ type (
 	TagOutput      = commonModel.Result[*model.Tag]
 	TagListOutput  = commonModel.Result[[]model.Tag]
 	EmptyOutput    = commonModel.Result[any]
+
+	EchoRevisionListOutput   = commonModel.Result[[]model.EchoRevision]
+	EchoRevisionDetailOutput = commonModel.Result[*model.EchoRevisionDetail]
 )
 
 func (echoHandler *EchoHandler) PostEcho(ctx context.Context, in *EchoUpsertInput) (EmptyOutput, error) {
@@ -96,6 +112,32 @@ func (echoHandler *EchoHandler) DeleteEcho(ctx context.Context, in *EchoIDInput)
 	return commonModel.OK[any](nil, commonModel.DELETE_ECHO_SUCCESS), nil
 }
 
+// ListTrashedEchos 分页列出回收站中的 Echo（最近删除的在前）。
+func (echoHandler *EchoHandler) ListTrashedEchos(ctx context.Context, in *TrashPageInput) (EchoPageOutput, error) {
+	result, err := echoHandler.echoService.ListTrashedEchos(ctx, commonModel.PageQueryDto{Page: in.Page, PageSize: in.PageSize})
+	if err != nil {
+		return EchoPageOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.LIST_TRASHED_ECHOS_SUCCESS), nil
+}
+
+// RestoreEcho 把 Echo 从回收站移回原处。
+func (echoHandler *EchoHandler) RestoreEcho(ctx context.Context, in *EchoIDInput) (EchoOutput, error) {
+	echo, err := echoHandler.echoService.RestoreEcho(ctx, in.ID)
+	if err != nil {
+		return EchoOutput{}, err
+	}
+	return commonModel.OK(echo, commonModel.RESTORE_ECHO_SUCCESS), nil
+}
+
+// PurgeEcho 彻底删除回收站中的 Echo，连同附件文件，不可恢复。
+func (echoHandler *EchoHandler) PurgeEcho(ctx context.Context, in *EchoIDInput) (EmptyOutput, error) {
+	if err := echoHandler.echoService.PurgeEcho(ctx, in.ID); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.PURGE_ECHO_SUCCESS), nil
+}
+
 // LikeEcho 为指定 Echo 点赞（匿名可访问，限速 + 去重）。
 func (echoHandler *EchoHandler) LikeEcho(ctx context.Context, in *LikeEchoInput) (EmptyOutput, error) {
 	if err := echoHandler.echoService.LikeEcho(ctx, in.ID); err != nil {
@@ -112,6 +154,33 @@ func (echoHandler *EchoHandler) GetEchoById(ctx context.Context, in *EchoIDInput
 	return commonModel.OK(echo, commonModel.GET_ECHO_BY_ID_SUCCESS), nil
 }
 
+// ListEchoRevisions 列出 Echo 的编辑历史（最新在前）。
+func (echoHandler *EchoHandler) ListEchoRevisions(ctx context.Context, in *EchoIDInput) (EchoRevisionListOutput, error) {
+	revisions, err := echoHandler.echoService.ListEchoRevisions(ctx, in.ID)
+	if err != nil {
+		return EchoRevisionListOutput{}, err
+	}
+	return commonModel.OK(revisions, commonModel.LIST_ECHO_REVISIONS_SUCCESS), nil
+}
+
+// GetEchoRevision 查看单个历史版本及其逐行差分。
+func (echoHandler *EchoHandler) GetEchoRevision(ctx context.Context, in *GetEchoRevisionInput) (EchoRevisionDetailOutput, error) {
+	detail, err := echoHandler.echoService.GetEchoRevision(ctx, in.ID, in.RevisionID, in.Compare)
+	if err != nil {
+		return EchoRevisionDetailOutput{}, err
+	}
+	return commonModel.OK(detail, commonModel.GET_ECHO_REVISION_SUCCESS), nil
+}
+
+// RestoreEchoRevision 把 Echo 恢复成指定历史版本，等同一次普通编辑。
+func (echoHandler *EchoHandler) RestoreEchoRevision(ctx context.Context, in *EchoRevisionInput) (EchoOutput, error) {
+	echo, err := echoHandler.echoService.RestoreEchoRevision(ctx, in.ID, in.RevisionID)
+	if err != nil {
+		return EchoOutput{}, err
+	}
+	return commonModel.OK(echo, commonModel.RESTORE_ECHO_REVISION_SUCCESS), nil
+}
+
 func (echoHandler *EchoHandler) QueryEchos(ctx context.Context, in *QueryEchosInput) (EchoPageOutput, error) {
 	result, err := echoHandler.echoService.QueryEchos(ctx, in.Body)
 	if err != nil {
@@ -72,7 +72,7 @@ func (embeddingHandler *EmbeddingHandler) Reindex(ctx context.Context, _ *Reinde
 // ReindexStatus 查询重建索引作业状态（前端按 type 轮询，无需 id）。
 // 查无作业行时合成 idle，供前端判断「无进行中重建」。
 func (embeddingHandler *EmbeddingHandler) ReindexStatus(ctx context.Context, _ *ReindexStatusInput) (ReindexOutput, error) {
-	jb, err := embeddingHandler.jobManager.Get(ctx, jobModel.TypeReindex)
+	jb, err := embeddingHandler.jobManager.Current(ctx, jobModel.TypeReindex)
 	if errors.Is(err, job.ErrNotFound) {
 		return commonModel.OK(ReindexStatusResponse{Status: reindexStatusIdle}), nil
 	}
@@ -84,7 +84,7 @@ func (embeddingHandler *EmbeddingHandler) ReindexStatus(ctx context.Context, _ *
 
 func (embeddingHandler *EmbeddingHandler) CancelReindex(ctx context.Context, _ *CancelReindexInput) (ReindexOutput, error) {
 	_ = embeddingHandler.jobManager.Cancel(jobModel.TypeReindex)
-	jb, err := embeddingHandler.jobManager.Get(ctx, jobModel.TypeReindex)
+	jb, err := embeddingHandler.jobManager.Current(ctx, jobModel.TypeReindex)
 	if errors.Is(err, job.ErrNotFound) {
 		return commonModel.OK(ReindexStatusResponse{Status: reindexStatusIdle}), nil
 	}
@@ -113,7 +113,8 @@ func TestReindexStatus_NoJobSynthesizesIdle(t *testing.T) {
 
 func TestReindexStatus_ExistingJobMapped(t *testing.T) {
 	h, repo := newEmbeddingHandlerWithDB(t)
-	require.NoError(t, repo.Upsert(context.Background(), &jobModel.Job{
+	require.NoError(t, repo.Create(context.Background(), &jobModel.Job{
+		ID:        "job-1",
 		Type:      jobModel.TypeReindex,
 		Status:    jobModel.StatusRunning,
 		Phase:     "embedding",
@@ -139,7 +140,7 @@ func TestReindexStatus_ExistingJobMapped(t *testing.T) {
 func TestCancelReindex_NoJobSynthesizesIdle(t *testing.T) {
 	h, _ := newEmbeddingHandlerWithDB(t)
 
-	// 无在跑作业：Cancel 为 no-op，Get 查无行 → 合成 idle。
+	// 无在跑作业：Cancel 为 no-op，Current 查无行 → 合成 idle。
 	out, err := h.CancelReindex(context.Background(), &CancelReindexInput{})
 
 	require.NoError(t, err)
@@ -149,13 +150,14 @@ func TestCancelReindex_NoJobSynthesizesIdle(t *testing.T) {
 
 func TestCancelReindex_TerminalRowMapped(t *testing.T) {
 	h, repo := newEmbeddingHandlerWithDB(t)
-	require.NoError(t, repo.Upsert(context.Background(), &jobModel.Job{
+	require.NoError(t, repo.Create(context.Background(), &jobModel.Job{
+		ID:     "job-1",
 		Type:   jobModel.TypeReindex,
 		Status: jobModel.StatusSuccess,
 		Phase:  "done",
 	}))
 
-	// 终态行无取消句柄，Cancel no-op；Get 返回该行并映射。
+	// 终态行无取消句柄，Cancel no-op；Current 返回该行并映射。
 	out, err := h.CancelReindex(context.Background(), &CancelReindexInput{})
 
 	require.NoError(t, err)
@@ -134,7 +134,7 @@ func NewAPI(engine *gin.Engine, group *gin.RouterGroup, title, version, basePath
 	}
 
 	api := humagin.NewWithGroup(engine, group, cfg)
-	api.UseMiddleware(injectLocalizer)
+	api.UseMiddleware(traceOperation, injectLocalizer)
 	if useScalar {
 		registerScalarDocs(group, basePath)
 	}
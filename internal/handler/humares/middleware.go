@@ -5,12 +5,17 @@ package humares
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humagin"
 	"github.com/gin-gonic/gin"
 	i18nUtil "github.com/lin-snow/ech0/internal/i18n"
+	"github.com/lin-snow/ech0/internal/tracing"
 	goi18n "github.com/nicksnyder/go-i18n/v2/i18n"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ctxKey int
@@ -36,6 +41,24 @@ func injectLocalizer(ctx huma.Context, next func(huma.Context)) {
 	next(ctx)
 }
 
+// traceOperation 为每个 Huma operation 开一个 span（以 operationId 命名），挂在 gin 请求 span 之下，
+// 便于在 trace 里按业务操作而不是路由模板检索。未开启追踪时 Start 是 no-op。
+func traceOperation(ctx huma.Context, next func(huma.Context)) {
+	gctx := humagin.Unwrap(ctx)
+	op := ctx.Operation()
+	spanCtx, span := tracing.Start(gctx.Request.Context(), "huma "+op.OperationID,
+		trace.WithAttributes(
+			attribute.String("huma.operation_id", op.OperationID),
+			attribute.String("http.route", op.Path),
+		))
+	defer span.End()
+	gctx.Request = gctx.Request.WithContext(spanCtx)
+	next(ctx)
+	if status := ctx.Status(); status >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, http.StatusText(status))
+	}
+}
+
 // localizerFrom 从 context 取回 injectLocalizer 注入的 provider 并在**使用时**解析 localizer；
 // 缺失时返回 nil，i18nUtil.Localize 对 nil localizer 会回退到默认文案。
 func localizerFrom(ctx context.Context) *goi18n.Localizer {
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package handler 暴露通用作业历史的 HTTP 接口（Huma type-first）：列出、查看与取消。
+// 各领域自己的 start/status 端点仍按类型操作，这里按作业 ID。
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/lin-snow/ech0/internal/job"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+)
+
+type JobHandler struct {
+	jobManager *job.Manager
+}
+
+func NewJobHandler(jobManager *job.Manager) *JobHandler {
+	return &JobHandler{
+		jobManager: jobManager,
+	}
+}
+
+// JobResponse 是单个作业的响应。payload 用 RawMessage 内嵌成对象（输入参数，或成功后
+// Runner 的结果），避免被转义成字符串。
+type JobResponse struct {
+	ID           string          `json:"id"`
+	Type         string          `json:"type" doc:"作业类型：reindex/migration/export/snapshot_upload" example:"reindex"`
+	Status       string          `json:"status" doc:"作业状态：pending（排队中）/running/success/failed/cancelled" example:"success"`
+	Phase        string          `json:"phase,omitempty" doc:"最后所处阶段"`
+	Error        string          `json:"error,omitempty" doc:"失败原因（status=failed 时）"`
+	Payload      json.RawMessage `json:"payload,omitempty" doc:"作业输入（可续跑作业运行中为最近的断点）；成功后为最终结果"`
+	Dismissed    bool            `json:"dismissed,omitempty" doc:"已被领域复位，不再是该类型的当前作业"`
+	CheckpointAt *int64          `json:"checkpoint_at,omitempty" doc:"最近一次落断点的时间（Unix 秒）；非空的作业在进程重启后可续跑"`
+	Resumes      int             `json:"resumes,omitempty" doc:"因进程重启而续跑的次数"`
+	CreatedAt    int64           `json:"created_at" doc:"提交时间（Unix 秒）"`
+	StartedAt    *int64          `json:"started_at,omitempty" doc:"首次开始运行时间（Unix 秒），排队中为空"`
+	FinishedAt   *int64          `json:"finished_at,omitempty" doc:"结束时间（Unix 秒）"`
+}
+
+type (
+	ListJobsInput struct {
+		Page     int    `query:"page" default:"1"`
+		PageSize int    `query:"page_size" default:"20" maximum:"100"`
+		Type     string `query:"type" doc:"按作业类型过滤"`
+		Status   string `query:"status" enum:",pending,running,success,failed,cancelled" doc:"按状态过滤"`
+	}
+	GetJobInput struct {
+		ID string `path:"id" doc:"作业 ID"`
+	}
+	CancelJobInput struct {
+		ID string `path:"id" doc:"作业 ID"`
+	}
+)
+
+type (
+	JobPageOutput = commonModel.Result[commonModel.PageQueryResult[[]JobResponse]]
+	JobOutput     = commonModel.Result[JobResponse]
+)
+
+func mapJob(jb jobModel.Job) JobResponse {
+	resp := JobResponse{
+		ID:           jb.ID,
+		Type:         jb.Type,
+		Status:       string(jb.Status),
+		Phase:        jb.Phase,
+		Error:        jb.Error,
+		Dismissed:    jb.Dismissed,
+		CheckpointAt: jb.CheckpointAt,
+		Resumes:      jb.Resumes,
+		CreatedAt:    jb.CreatedAt,
+		StartedAt:    jb.StartedAt,
+		FinishedAt:   jb.FinishedAt,
+	}
+	if jb.Payload != "" && json.Valid([]byte(jb.Payload)) {
+		resp.Payload = json.RawMessage(jb.Payload)
+	}
+	return resp
+}
+
+// ListJobs 按提交时间倒序分页列出作业历史。
+func (h *JobHandler) ListJobs(ctx context.Context, in *ListJobsInput) (JobPageOutput, error) {
+	rows, total, err := h.jobManager.List(ctx, jobModel.ListQuery{
+		Type:     in.Type,
+		Status:   jobModel.Status(in.Status),
+		Page:     in.Page,
+		PageSize: in.PageSize,
+	})
+	if err != nil {
+		return JobPageOutput{}, err
+	}
+	items := make([]JobResponse, 0, len(rows))
+	for _, jb := range rows {
+		items = append(items, mapJob(jb))
+	}
+	return commonModel.OK(commonModel.PageQueryResult[[]JobResponse]{Total: total, Items: items}), nil
+}
+
+// GetJob 查看单个作业，含最终 payload 与错误。
+func (h *JobHandler) GetJob(ctx context.Context, in *GetJobInput) (JobOutput, error) {
+	jb, err := h.jobManager.GetByID(ctx, in.ID)
+	if err != nil {
+		return JobOutput{}, notFound(err)
+	}
+	return commonModel.OK(mapJob(jb)), nil
+}
+
+// CancelJob 取消单个作业：排队中的直接置 cancelled，在跑的协作式取消；返回最新状态。
+func (h *JobHandler) CancelJob(ctx context.Context, in *CancelJobInput) (JobOutput, error) {
+	if err := h.jobManager.CancelByID(ctx, in.ID); err != nil {
+		return JobOutput{}, notFound(err)
+	}
+	jb, err := h.jobManager.GetByID(ctx, in.ID)
+	if err != nil {
+		return JobOutput{}, notFound(err)
+	}
+	return commonModel.OK(mapJob(jb)), nil
+}
+
+func notFound(err error) error {
+	if errors.Is(err, job.ErrNotFound) {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "作业不存在")
+	}
+	return err
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/job"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	jobRepository "github.com/lin-snow/ech0/internal/repository/job"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newJobHandlerWithDB 用 DB-backed job manager 构造 handler。
+func newJobHandlerWithDB(t *testing.T) (*JobHandler, *jobRepository.JobRepository) {
+	t.Helper()
+	db := helpers.NewTestDB(t)
+	repo := jobRepository.NewJobRepository(func() *gorm.DB { return db })
+	return NewJobHandler(job.NewManager(repo)), repo
+}
+
+func seedJob(t *testing.T, repo *jobRepository.JobRepository, j jobModel.Job) {
+	t.Helper()
+	require.NoError(t, repo.Create(context.Background(), &j))
+}
+
+func TestListJobs_FiltersAndOrders(t *testing.T) {
+	h, repo := newJobHandlerWithDB(t)
+	seedJob(t, repo, jobModel.Job{ID: "a", Type: jobModel.TypeReindex, Status: jobModel.StatusFailed, Error: "boom", CreatedAt: 1})
+	seedJob(t, repo, jobModel.Job{ID: "b", Type: jobModel.TypeReindex, Status: jobModel.StatusSuccess, CreatedAt: 2})
+	seedJob(t, repo, jobModel.Job{ID: "c", Type: jobModel.TypeExport, Status: jobModel.StatusSuccess, CreatedAt: 3})
+
+	out, err := h.ListJobs(context.Background(), &ListJobsInput{Page: 1, PageSize: 20, Type: jobModel.TypeReindex})
+
+	require.NoError(t, err)
+	assert.Equal(t, commonModel.DEFAULT_SUCCESS_CODE, out.Code)
+	assert.Equal(t, int64(2), out.Data.Total)
+	require.Len(t, out.Data.Items, 2)
+	assert.Equal(t, "b", out.Data.Items[0].ID, "按提交时间倒序")
+	assert.Equal(t, "boom", out.Data.Items[1].Error)
+
+	out, err = h.ListJobs(context.Background(), &ListJobsInput{Page: 1, PageSize: 20, Status: string(jobModel.StatusSuccess)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), out.Data.Total)
+}
+
+func TestGetJob_ReturnsPayloadAndError(t *testing.T) {
+	h, repo := newJobHandlerWithDB(t)
+	seedJob(t, repo, jobModel.Job{
+		ID:      "job-1",
+		Type:    jobModel.TypeExport,
+		Status:  jobModel.StatusFailed,
+		Error:   "disk full",
+		Payload: `{"format":"zip"}`,
+	})
+
+	out, err := h.GetJob(context.Background(), &GetJobInput{ID: "job-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, string(jobModel.StatusFailed), out.Data.Status)
+	assert.Equal(t, "disk full", out.Data.Error)
+	assert.JSONEq(t, `{"format":"zip"}`, string(out.Data.Payload))
+}
+
+func TestGetJob_NotFound(t *testing.T) {
+	h, _ := newJobHandlerWithDB(t)
+
+	_, err := h.GetJob(context.Background(), &GetJobInput{ID: "missing"})
+
+	var bizErr *commonModel.BizError
+	require.ErrorAs(t, err, &bizErr)
+	assert.Equal(t, commonModel.ErrCodeInvalidRequest, bizErr.Code)
+}
+
+func TestCancelJob_QueuedJobCancelled(t *testing.T) {
+	h, repo := newJobHandlerWithDB(t)
+	seedJob(t, repo, jobModel.Job{ID: "job-1", Type: jobModel.TypeReindex, Status: jobModel.StatusPending})
+
+	out, err := h.CancelJob(context.Background(), &CancelJobInput{ID: "job-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, string(jobModel.StatusCancelled), out.Data.Status)
+	assert.NotNil(t, out.Data.FinishedAt)
+}
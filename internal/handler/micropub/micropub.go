@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	model "github.com/lin-snow/ech0/internal/model/micropub"
+	service "github.com/lin-snow/ech0/internal/service/micropub"
+	urlUtil "github.com/lin-snow/ech0/internal/util/url"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// maxJSONBytes 限制 JSON 请求体大小；multipart 上传的大小由文件服务按类别校验。
+const maxJSONBytes = 1 << 20
+
+// reservedFields 是表单中不属于 h-entry 属性的字段。
+var reservedFields = []string{"h", "action", "url", "access_token"}
+
+// MicropubHandler 提供 Micropub 与媒体端点。请求是表单 / multipart / JSON，错误体是
+// {"error": ..., "error_description": ...}，与统一的 Result 包装不同，故走裸 gin。
+type MicropubHandler struct {
+	service service.Service
+}
+
+func NewMicropubHandler(svc service.Service) *MicropubHandler {
+	return &MicropubHandler{service: svc}
+}
+
+// Query GET /micropub?q=config|source|syndicate-to|category
+func (h *MicropubHandler) Query(ctx *gin.Context) {
+	reqCtx := ctx.Request.Context()
+	switch q := ctx.Query("q"); q {
+	case "config":
+		result, err := h.service.Config(reqCtx)
+		writeJSON(ctx, http.StatusOK, result, err)
+	case "source":
+		properties := ctx.QueryArray("properties[]")
+		if len(properties) == 0 {
+			properties = ctx.QueryArray("properties")
+		}
+		result, err := h.service.Source(reqCtx, ctx.Query("url"), properties)
+		writeJSON(ctx, http.StatusOK, result, err)
+	case "syndicate-to":
+		writeJSON(ctx, http.StatusOK, gin.H{"syndicate-to": []any{}}, nil)
+	case "category":
+		result, err := h.service.Categories(reqCtx, ctx.Query("filter"))
+		writeJSON(ctx, http.StatusOK, gin.H{"categories": result}, err)
+	default:
+		writeError(ctx, http.StatusBadRequest, "invalid_request", "unsupported query "+q)
+	}
+}
+
+// Post POST /micropub：按 action 创建、修改、删除或恢复。创建返回 201 与 Location，其余返回 204。
+func (h *MicropubHandler) Post(ctx *gin.Context) {
+	req, err := readRequest(ctx)
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+	if len(req.Uploads) > 0 && !allowed(ctx, authModel.ScopeFileWrite) {
+		writeError(ctx, http.StatusForbidden, "insufficient_scope", "uploading files requires the file:write scope")
+		return
+	}
+
+	reqCtx := ctx.Request.Context()
+	switch req.Action {
+	case "", model.ActionCreate:
+		location, err := h.service.Create(reqCtx, req)
+		if err != nil {
+			writeServiceError(ctx, err)
+			return
+		}
+		ctx.Header("Location", urlUtil.Absolute(urlUtil.RequestOrigin(ctx.Request), location))
+		ctx.Status(http.StatusCreated)
+	case model.ActionUpdate:
+		writeNoContent(ctx, h.service.Update(reqCtx, req))
+	case model.ActionDelete:
+		writeNoContent(ctx, h.service.Delete(reqCtx, req.URL))
+	case model.ActionUndelete:
+		writeNoContent(ctx, h.service.Undelete(reqCtx, req.URL))
+	default:
+		writeError(ctx, http.StatusBadRequest, "invalid_request", "unsupported action "+req.Action)
+	}
+}
+
+// Media POST /micropub/media（multipart，字段 file）：返回 201 与文件地址。
+func (h *MicropubHandler) Media(ctx *gin.Context) {
+	file, err := ctx.FormFile("file")
+	if err != nil {
+		writeError(ctx, http.StatusBadRequest, "invalid_request", "missing file")
+		return
+	}
+	location, err := h.service.UploadMedia(ctx.Request.Context(), file)
+	if err != nil {
+		writeServiceError(ctx, err)
+		return
+	}
+	ctx.Header("Location", urlUtil.Absolute(urlUtil.RequestOrigin(ctx.Request), location))
+	ctx.Status(http.StatusCreated)
+}
+
+// readRequest 把 JSON 或表单请求归一化为 model.Request。
+func readRequest(ctx *gin.Context) (*model.Request, error) {
+	if ctx.ContentType() == "application/json" {
+		var req model.Request
+		body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxJSONBytes+1))
+		if err != nil || len(body) > maxJSONBytes {
+			return nil, errors.New("request body too large")
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			return nil, errors.New("malformed JSON body")
+		}
+		return &req, nil
+	}
+	if !isForm(ctx.ContentType()) {
+		return nil, errors.New("unsupported content type")
+	}
+
+	var values map[string][]string
+	var files map[string][]*multipart.FileHeader
+	if form, err := ctx.MultipartForm(); err == nil {
+		values, files = form.Value, form.File
+	} else if err := ctx.Request.ParseForm(); err == nil {
+		values = ctx.Request.PostForm
+	} else {
+		return nil, errors.New("malformed form body")
+	}
+
+	req := &model.Request{Properties: model.Properties{}}
+	for key, list := range values {
+		switch key = strings.TrimSuffix(key, "[]"); {
+		case key == "h":
+			req.Type = []string{"h-" + first(list)}
+		case key == "action":
+			req.Action = first(list)
+		case key == "url":
+			req.URL = first(list)
+		case slices.Contains(reservedFields, key) || strings.HasPrefix(key, "mp-"):
+		default:
+			for _, v := range list {
+				req.Properties[key] = append(req.Properties[key], v)
+			}
+		}
+	}
+	for key, list := range files {
+		if req.Uploads == nil {
+			req.Uploads = make(map[string][]*multipart.FileHeader)
+		}
+		key = strings.TrimSuffix(key, "[]")
+		req.Uploads[key] = append(req.Uploads[key], list...)
+	}
+	return req, nil
+}
+
+// allowed 判断当前 token 是否带有 scope；浏览器会话不受 scope 限制。
+func allowed(ctx *gin.Context, scope string) bool {
+	v := viewer.MustFromContext(ctx.Request.Context())
+	return v.TokenType() == authModel.TokenTypeSession || slices.Contains(v.Scopes(), scope)
+}
+
+func isForm(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/x-www-form-urlencoded" || mediaType == "multipart/form-data"
+}
+
+func first(list []string) string {
+	if len(list) == 0 {
+		return ""
+	}
+	return strings.TrimSpace(list[0])
+}
+
+func writeJSON(ctx *gin.Context, status int, body any, err error) {
+	if err != nil {
+		writeServiceError(ctx, err)
+		return
+	}
+	ctx.JSON(status, body)
+}
+
+func writeNoContent(ctx *gin.Context, err error) {
+	if err != nil {
+		writeServiceError(ctx, err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// writeServiceError 把服务层错误映射为 Micropub 错误响应。
+func writeServiceError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidRequest):
+		writeError(ctx, http.StatusBadRequest, "invalid_request", err.Error())
+	case errors.Is(err, service.ErrForbidden):
+		writeError(ctx, http.StatusForbidden, "forbidden", err.Error())
+	default:
+		logUtil.GetLogger().Warn("micropub request failed", logUtil.Err(err))
+		writeError(ctx, http.StatusInternalServerError, "server_error", "")
+	}
+}
+
+func writeError(ctx *gin.Context, status int, code, description string) {
+	body := gin.H{"error": code}
+	if description != "" {
+		body["error_description"] = description
+	}
+	ctx.AbortWithStatusJSON(status, body)
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	model "github.com/lin-snow/ech0/internal/model/micropub"
+	service "github.com/lin-snow/ech0/internal/service/micropub"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeService 记录收到的请求，并按字段返回预设结果。
+type fakeService struct {
+	service.Service
+	created  *model.Request
+	location string
+	err      error
+}
+
+func (f *fakeService) Create(_ context.Context, req *model.Request) (string, error) {
+	f.created = req
+	return f.location, f.err
+}
+
+func (f *fakeService) Delete(context.Context, string) error { return f.err }
+
+func newRouter(svc service.Service, scopes ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		v := viewer.NewUserViewerWithToken("admin", authModel.TokenTypeAccess, scopes, nil, "jti")
+		c.Request = c.Request.WithContext(viewer.WithContext(c.Request.Context(), v))
+	})
+	h := NewMicropubHandler(svc)
+	r.POST("/micropub", h.Post)
+	return r
+}
+
+func TestPost_FormCreate(t *testing.T) {
+	svc := &fakeService{location: "/echo/e1"}
+	form := "h=entry&content=hello&category[]=go&category[]=indieweb&access_token=secret&mp-slug=x"
+	req := httptest.NewRequest(http.MethodPost, "/micropub", strings.NewReader(form))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Host = "ech0.example"
+	rec := httptest.NewRecorder()
+
+	newRouter(svc, authModel.ScopeEchoWrite).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "http://ech0.example/echo/e1", rec.Header().Get("Location"))
+	require.NotNil(t, svc.created)
+	assert.Equal(t, []string{model.TypeEntry}, svc.created.Type)
+	assert.Equal(t, model.Properties{
+		"content":  {"hello"},
+		"category": {"go", "indieweb"},
+	}, svc.created.Properties)
+}
+
+func TestPost_JSONCreate(t *testing.T) {
+	svc := &fakeService{location: "https://ech0.example/echo/e1"}
+	body := `{"type":["h-entry"],"properties":{"content":["hi"],"photo":[{"value":"https://x.example/a.jpg","alt":"a"}]}}`
+	req := httptest.NewRequest(http.MethodPost, "/micropub", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	newRouter(svc, authModel.ScopeEchoWrite).ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "https://ech0.example/echo/e1", rec.Header().Get("Location"))
+	require.NotNil(t, svc.created)
+	assert.Equal(t, []any{"hi"}, svc.created.Properties["content"])
+	assert.Len(t, svc.created.Properties["photo"], 1)
+}
+
+func TestPost_MultipartUploadNeedsFileScope(t *testing.T) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	require.NoError(t, w.WriteField("h", "entry"))
+	part, err := w.CreateFormFile("photo", "a.jpg")
+	require.NoError(t, err)
+	_, _ = part.Write([]byte("jpeg"))
+	require.NoError(t, w.Close())
+
+	send := func(scopes ...string) (*httptest.ResponseRecorder, *fakeService) {
+		svc := &fakeService{location: "/echo/e1"}
+		req := httptest.NewRequest(http.MethodPost, "/micropub", bytes.NewReader(buf.Bytes()))
+		req.Header.Set("Content-Type", w.FormDataContentType())
+		rec := httptest.NewRecorder()
+		newRouter(svc, scopes...).ServeHTTP(rec, req)
+		return rec, svc
+	}
+
+	rec, _ := send(authModel.ScopeEchoWrite)
+	require.Equal(t, http.StatusForbidden, rec.Code)
+	var body map[string]string
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "insufficient_scope", body["error"])
+
+	rec, svc := send(authModel.ScopeEchoWrite, authModel.ScopeFileWrite)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.NotNil(t, svc.created)
+	assert.Len(t, svc.created.Uploads["photo"], 1)
+}
+
+func TestPost_ErrorMapping(t *testing.T) {
+	cases := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{err: service.ErrInvalidRequest, status: http.StatusBadRequest, code: "invalid_request"},
+		{err: service.ErrForbidden, status: http.StatusForbidden, code: "forbidden"},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/micropub",
+			strings.NewReader("action=delete&url=https://ech0.example/echo/e1"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+
+		newRouter(&fakeService{err: tc.err}, authModel.ScopeEchoWrite).ServeHTTP(rec, req)
+
+		require.Equal(t, tc.status, rec.Code)
+		var body map[string]string
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, tc.code, body["error"])
+	}
+}
@@ -5,6 +5,7 @@ package handler
 
 import (
 	"github.com/google/wire"
+	activitypubHandler "github.com/lin-snow/ech0/internal/handler/activitypub"
 	authHandler "github.com/lin-snow/ech0/internal/handler/auth"
 	commentHandler "github.com/lin-snow/ech0/internal/handler/comment"
 	commonHandler "github.com/lin-snow/ech0/internal/handler/common"
@@ -15,27 +16,36 @@ import (
 	embeddingHandler "github.com/lin-snow/ech0/internal/handler/embedding"
 	fileHandler "github.com/lin-snow/ech0/internal/handler/file"
 	initHandler "github.com/lin-snow/ech0/internal/handler/init"
+	jobHandler "github.com/lin-snow/ech0/internal/handler/job"
+	micropubHandler "github.com/lin-snow/ech0/internal/handler/micropub"
 	migratorHandler "github.com/lin-snow/ech0/internal/handler/migrator"
+	searchHandler "github.com/lin-snow/ech0/internal/handler/search"
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	userHandler "github.com/lin-snow/ech0/internal/handler/user"
 	webHandler "github.com/lin-snow/ech0/internal/handler/web"
+	webmentionHandler "github.com/lin-snow/ech0/internal/handler/webmention"
 	"github.com/lin-snow/ech0/internal/mcp"
 )
 
 var (
-	WebSet       = wire.NewSet(webHandler.NewWebHandler)
-	UserSet      = wire.NewSet(userHandler.NewUserHandler)
-	AuthSet      = wire.NewSet(authHandler.NewAuthHandler)
-	EchoSet      = wire.NewSet(echoHandler.NewEchoHandler)
-	FileSet      = wire.NewSet(fileHandler.NewFileHandler)
-	CommentSet   = wire.NewSet(commentHandler.NewCommentHandler)
-	InitSet      = wire.NewSet(initHandler.NewInitHandler)
-	CommonSet    = wire.NewSet(commonHandler.NewCommonHandler)
-	SettingSet   = wire.NewSet(settingHandler.NewSettingHandler)
-	ConnectSet   = wire.NewSet(connectHandler.NewConnectHandler)
-	DashboardSet = wire.NewSet(dashboardHandler.NewDashboardHandler)
-	CopilotSet   = wire.NewSet(copilotHandler.NewCopilotHandler)
-	EmbeddingSet = wire.NewSet(embeddingHandler.NewEmbeddingHandler)
-	MigrationSet = wire.NewSet(migratorHandler.NewMigrationHandler)
-	MCPSet       = wire.NewSet(mcp.NewHandler)
+	WebSet         = wire.NewSet(webHandler.NewWebHandler)
+	UserSet        = wire.NewSet(userHandler.NewUserHandler)
+	AuthSet        = wire.NewSet(authHandler.NewAuthHandler)
+	EchoSet        = wire.NewSet(echoHandler.NewEchoHandler)
+	FileSet        = wire.NewSet(fileHandler.NewFileHandler)
+	CommentSet     = wire.NewSet(commentHandler.NewCommentHandler)
+	InitSet        = wire.NewSet(initHandler.NewInitHandler)
+	CommonSet      = wire.NewSet(commonHandler.NewCommonHandler)
+	SettingSet     = wire.NewSet(settingHandler.NewSettingHandler)
+	ConnectSet     = wire.NewSet(connectHandler.NewConnectHandler)
+	DashboardSet   = wire.NewSet(dashboardHandler.NewDashboardHandler)
+	CopilotSet     = wire.NewSet(copilotHandler.NewCopilotHandler)
+	EmbeddingSet   = wire.NewSet(embeddingHandler.NewEmbeddingHandler)
+	SearchSet      = wire.NewSet(searchHandler.NewSearchHandler)
+	MigrationSet   = wire.NewSet(migratorHandler.NewMigrationHandler)
+	MCPSet         = wire.NewSet(mcp.NewHandler)
+	ActivityPubSet = wire.NewSet(activitypubHandler.NewActivityPubHandler)
+	WebmentionSet  = wire.NewSet(webmentionHandler.NewWebmentionHandler)
+	MicropubSet    = wire.NewSet(micropubHandler.NewMicropubHandler)
+	JobSet         = wire.NewSet(jobHandler.NewJobHandler)
 )
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package handler 暴露 Echo 混合检索、相关推荐与标签建议的 HTTP 接口（Huma type-first）。
+package handler
+
+import (
+	"context"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	service "github.com/lin-snow/ech0/internal/service/search"
+)
+
+type SearchHandler struct {
+	searchService service.Service
+}
+
+func NewSearchHandler(searchService service.Service) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+type (
+	HybridSearchInput struct {
+		Body commonModel.EchoSearchDto
+	}
+	HybridSearchOutput = commonModel.Result[echoModel.EchoSearchResult]
+
+	RelatedEchosInput struct {
+		ID    string `path:"id" format:"uuid" doc:"Echo ID"`
+		Limit int    `query:"limit" default:"5" doc:"返回条数，默认 5，最大 20"`
+	}
+	RelatedEchosOutput = commonModel.Result[echoModel.RelatedEchoResult]
+
+	SuggestTagsInput struct {
+		Body commonModel.TagSuggestDto
+	}
+	SuggestTagsOutput = commonModel.Result[echoModel.TagSuggestionResult]
+)
+
+func (searchHandler *SearchHandler) HybridSearch(ctx context.Context, in *HybridSearchInput) (HybridSearchOutput, error) {
+	result, err := searchHandler.searchService.HybridSearch(ctx, in.Body)
+	if err != nil {
+		return HybridSearchOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.SEARCH_ECHOS_SUCCESS), nil
+}
+
+func (searchHandler *SearchHandler) RelatedEchos(ctx context.Context, in *RelatedEchosInput) (RelatedEchosOutput, error) {
+	result, err := searchHandler.searchService.RelatedEchos(ctx, in.ID, in.Limit)
+	if err != nil {
+		return RelatedEchosOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.GET_RELATED_ECHOS_SUCCESS), nil
+}
+
+func (searchHandler *SearchHandler) SuggestTags(ctx context.Context, in *SuggestTagsInput) (SuggestTagsOutput, error) {
+	result, err := searchHandler.searchService.SuggestTags(ctx, in.Body)
+	if err != nil {
+		return SuggestTagsOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.SUGGEST_TAGS_SUCCESS), nil
+}
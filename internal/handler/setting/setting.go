@@ -37,6 +37,18 @@ type (
 		ID   string `path:"id" format:"uuid" doc:"Webhook ID（UUID）"`
 		Body model.WebhookDto
 	}
+	WebhookDeliveriesInput struct {
+		ID    string `path:"id" format:"uuid" doc:"Webhook ID（UUID）"`
+		Limit int    `query:"limit" minimum:"0" maximum:"100" doc:"返回条数，默认 20，最多 100"`
+	}
+	WebhookRedeliverInput struct {
+		ID         string `path:"id" format:"uuid" doc:"Webhook ID（UUID）"`
+		DeliveryID string `path:"delivery_id" format:"uuid" doc:"投递记录 ID（UUID）"`
+	}
+	WebhookDeadLetterInput struct {
+		ID           string `path:"id" format:"uuid" doc:"Webhook ID（UUID）"`
+		DeadLetterID string `path:"dead_letter_id" format:"uuid" doc:"死信 ID（UUID）"`
+	}
 	AccessTokenInput      struct{ Body model.AccessTokenSettingDto }
 	SnapshotScheduleInput struct{ Body model.SnapshotScheduleDto }
 	AgentSettingInput     struct{ Body model.AgentSettingDto }
@@ -44,19 +56,23 @@ type (
 )
 
 type (
-	SystemSettingOutput    = commonModel.Result[model.SystemSetting]
-	OAuth2StatusOutput     = commonModel.Result[model.OAuth2Status]
-	PasskeyStatusOutput    = commonModel.Result[model.PasskeyStatus]
-	AgentSettingOutput     = commonModel.Result[model.AgentSetting]
-	S3SettingOutput        = commonModel.Result[model.S3Setting]
-	OAuth2SettingOutput    = commonModel.Result[model.OAuth2Setting]
-	PasskeySettingOutput   = commonModel.Result[model.PasskeySetting]
-	WebhookListOutput      = commonModel.Result[[]webhookModel.Webhook]
-	SnapshotScheduleOutput = commonModel.Result[model.SnapshotSchedule]
-	EmbeddingSettingOutput = commonModel.Result[model.EmbeddingSetting]
-	AccessTokenListOutput  = commonModel.Result[[]model.AccessTokenSetting]
-	StringOutput           = commonModel.Result[string]
-	EmptyOutput            = commonModel.Result[any]
+	SystemSettingOutput       = commonModel.Result[model.SystemSetting]
+	OAuth2StatusOutput        = commonModel.Result[model.OAuth2Status]
+	PasskeyStatusOutput       = commonModel.Result[model.PasskeyStatus]
+	AgentSettingOutput        = commonModel.Result[model.AgentSetting]
+	S3SettingOutput           = commonModel.Result[model.S3Setting]
+	OAuth2SettingOutput       = commonModel.Result[model.OAuth2Setting]
+	PasskeySettingOutput      = commonModel.Result[model.PasskeySetting]
+	WebhookListOutput         = commonModel.Result[[]webhookModel.Webhook]
+	WebhookDeliveryListOutput = commonModel.Result[[]webhookModel.WebhookDelivery]
+	WebhookDeliveryOutput     = commonModel.Result[*webhookModel.WebhookDelivery]
+	WebhookDeadLetterOutput   = commonModel.Result[[]webhookModel.WebhookOutbox]
+	WebhookPurgeOutput        = commonModel.Result[int64]
+	SnapshotScheduleOutput    = commonModel.Result[model.SnapshotSchedule]
+	EmbeddingSettingOutput    = commonModel.Result[model.EmbeddingSetting]
+	AccessTokenListOutput     = commonModel.Result[[]model.AccessTokenSetting]
+	StringOutput              = commonModel.Result[string]
+	EmptyOutput               = commonModel.Result[any]
 )
 
 func (h *SettingHandler) GetSettings(ctx context.Context, _ *EmptyInput) (SystemSettingOutput, error) {
@@ -191,6 +207,56 @@ func (h *SettingHandler) TestWebhook(ctx context.Context, in *IDInput) (EmptyOut
 	return commonModel.OK[any](nil, commonModel.TEST_WEBHOOK_SUCCESS), nil
 }
 
+func (h *SettingHandler) ListWebhookDeliveries(
+	ctx context.Context,
+	in *WebhookDeliveriesInput,
+) (WebhookDeliveryListOutput, error) {
+	result, err := h.settingService.ListWebhookDeliveries(ctx, in.ID, in.Limit)
+	if err != nil {
+		return WebhookDeliveryListOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.GET_WEBHOOK_DELIVERIES_SUCCESS), nil
+}
+
+// RedeliverWebhook 重新投递一条记录。对端失败时仍返回成功响应，结果看记录的 success 字段。
+func (h *SettingHandler) RedeliverWebhook(
+	ctx context.Context,
+	in *WebhookRedeliverInput,
+) (WebhookDeliveryOutput, error) {
+	result, err := h.settingService.RedeliverWebhook(ctx, in.ID, in.DeliveryID)
+	if err != nil {
+		return WebhookDeliveryOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.REDELIVER_WEBHOOK_SUCCESS), nil
+}
+
+func (h *SettingHandler) ListWebhookDeadLetters(
+	ctx context.Context,
+	in *WebhookDeliveriesInput,
+) (WebhookDeadLetterOutput, error) {
+	result, err := h.settingService.ListWebhookDeadLetters(ctx, in.ID, in.Limit)
+	if err != nil {
+		return WebhookDeadLetterOutput{}, err
+	}
+	return commonModel.OK(result, commonModel.GET_WEBHOOK_DEAD_LETTERS_SUCCESS), nil
+}
+
+func (h *SettingHandler) RetryWebhookDeadLetter(ctx context.Context, in *WebhookDeadLetterInput) (EmptyOutput, error) {
+	if err := h.settingService.RetryWebhookDeadLetter(ctx, in.ID, in.DeadLetterID); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.RETRY_WEBHOOK_DEAD_LETTER_SUCCESS), nil
+}
+
+// PurgeWebhookDeadLetters 清除 webhook 的全部死信，data 为删除条数。
+func (h *SettingHandler) PurgeWebhookDeadLetters(ctx context.Context, in *IDInput) (WebhookPurgeOutput, error) {
+	purged, err := h.settingService.PurgeWebhookDeadLetters(ctx, in.ID)
+	if err != nil {
+		return WebhookPurgeOutput{}, err
+	}
+	return commonModel.OK(purged, commonModel.PURGE_WEBHOOK_DEAD_LETTERS_SUCCESS), nil
+}
+
 func (h *SettingHandler) GetSnapshotScheduleSetting(ctx context.Context, _ *EmptyInput) (SnapshotScheduleOutput, error) {
 	var snapshotSchedule model.SnapshotSchedule
 	if err := h.settingService.GetSnapshotScheduleSetting(&snapshotSchedule); err != nil {
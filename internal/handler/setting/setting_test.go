@@ -442,6 +442,94 @@ func TestSettingHandler_Webhooks(t *testing.T) {
 
 		assertBizErr(t, err, commonModel.ErrCodeInternal)
 	})
+
+	t.Run("deliveries pass id and limit through", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().
+			ListWebhookDeliveries(mock.Anything, "w-7", 50).
+			Return([]webhookModel.WebhookDelivery{{ID: "d-1"}}, nil).
+			Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.ListWebhookDeliveries(context.Background(), &settingHandler.WebhookDeliveriesInput{ID: "w-7", Limit: 50})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.GET_WEBHOOK_DELIVERIES_SUCCESS, out.Message)
+		assert.Len(t, out.Data, 1)
+	})
+
+	t.Run("redeliver returns the new attempt", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().
+			RedeliverWebhook(mock.Anything, "w-7", "d-1").
+			Return(&webhookModel.WebhookDelivery{ID: "d-2", RedeliveryOf: "d-1"}, nil).
+			Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.RedeliverWebhook(context.Background(), &settingHandler.WebhookRedeliverInput{ID: "w-7", DeliveryID: "d-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.REDELIVER_WEBHOOK_SUCCESS, out.Message)
+		assert.Equal(t, "d-1", out.Data.RedeliveryOf)
+	})
+
+	t.Run("redeliver error", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().RedeliverWebhook(mock.Anything, mock.Anything, mock.Anything).Return(nil, bizErr()).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		_, err := h.RedeliverWebhook(context.Background(), &settingHandler.WebhookRedeliverInput{ID: "x", DeliveryID: "y"})
+
+		assertBizErr(t, err, commonModel.ErrCodeInternal)
+	})
+
+	t.Run("dead letters pass id and limit through", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().
+			ListWebhookDeadLetters(mock.Anything, "w-7", 10).
+			Return([]webhookModel.WebhookOutbox{{ID: "o-1", Status: webhookModel.OutboxStatusDead}}, nil).
+			Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.ListWebhookDeadLetters(context.Background(), &settingHandler.WebhookDeliveriesInput{ID: "w-7", Limit: 10})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.GET_WEBHOOK_DEAD_LETTERS_SUCCESS, out.Message)
+		assert.Len(t, out.Data, 1)
+	})
+
+	t.Run("retry dead letter", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().RetryWebhookDeadLetter(mock.Anything, "w-7", "o-1").Return(nil).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.RetryWebhookDeadLetter(context.Background(), &settingHandler.WebhookDeadLetterInput{ID: "w-7", DeadLetterID: "o-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.RETRY_WEBHOOK_DEAD_LETTER_SUCCESS, out.Message)
+	})
+
+	t.Run("retry dead letter error", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().RetryWebhookDeadLetter(mock.Anything, mock.Anything, mock.Anything).Return(bizErr()).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		_, err := h.RetryWebhookDeadLetter(context.Background(), &settingHandler.WebhookDeadLetterInput{ID: "x", DeadLetterID: "y"})
+
+		assertBizErr(t, err, commonModel.ErrCodeInternal)
+	})
+
+	t.Run("purge returns the count", func(t *testing.T) {
+		svc := settingmock.NewMockService(t)
+		svc.EXPECT().PurgeWebhookDeadLetters(mock.Anything, "w-7").Return(int64(3), nil).Once()
+
+		h := settingHandler.NewSettingHandler(svc)
+		out, err := h.PurgeWebhookDeadLetters(context.Background(), &settingHandler.IDInput{ID: "w-7"})
+
+		require.NoError(t, err)
+		assert.Equal(t, commonModel.PURGE_WEBHOOK_DEAD_LETTERS_SUCCESS, out.Message)
+		assert.Equal(t, int64(3), out.Data)
+	})
 }
 
 func TestSettingHandler_SnapshotSchedule(t *testing.T) {
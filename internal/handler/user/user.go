@@ -32,6 +32,10 @@ type (
 	UpdateUserAdminInput struct {
 		ID string `path:"id" format:"uuid" doc:"用户 ID（UUID）"`
 	}
+	UpdateUserRoleInput struct {
+		ID   string `path:"id" format:"uuid" doc:"用户 ID（UUID）"`
+		Body model.UserRoleDto
+	}
 	GetAllUsersInput struct{}
 	DeleteUserInput  struct {
 		ID string `path:"id" format:"uuid" doc:"用户 ID（UUID）"`
@@ -66,6 +70,13 @@ func (userHandler *UserHandler) UpdateUserAdmin(ctx context.Context, in *UpdateU
 	return commonModel.OK[any](nil, commonModel.UPDATE_USER_SUCCESS), nil
 }
 
+func (userHandler *UserHandler) UpdateUserRole(ctx context.Context, in *UpdateUserRoleInput) (EmptyOutput, error) {
+	if err := userHandler.userService.UpdateUserRole(ctx, in.ID, in.Body.Role); err != nil {
+		return EmptyOutput{}, err
+	}
+	return commonModel.OK[any](nil, commonModel.UPDATE_USER_SUCCESS), nil
+}
+
 func (userHandler *UserHandler) GetAllUsers(ctx context.Context, _ *GetAllUsersInput) (UserListOutput, error) {
 	allusers, err := userHandler.userService.GetAllUsers(ctx)
 	if err != nil {
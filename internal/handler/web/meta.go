@@ -0,0 +1,272 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	model "github.com/lin-snow/ech0/internal/model/page"
+	urlUtil "github.com/lin-snow/ech0/internal/util/url"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const echoJSONLDID = "echo-jsonld"
+
+// echoPageID 从 /echo/{id} 路径取出 Echo ID；不是单条 Echo 页面时返回空串。
+func echoPageID(p string) string {
+	id, ok := strings.CutPrefix(p, "/echo/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return ""
+	}
+	return id
+}
+
+// withOrigin 补全页面里剩下的站内路径：配置了站点地址时页面服务已给出绝对地址，
+// 这里只在未配置时用请求来源兜底，分享卡片只认绝对地址。
+func withOrigin(page model.EchoPage, origin string) model.EchoPage {
+	page.URL = urlUtil.Absolute(origin, page.URL)
+	images := make([]model.Image, len(page.Images))
+	for i, img := range page.Images {
+		img.URL = urlUtil.Absolute(origin, img.URL)
+		images[i] = img
+	}
+	page.Images = images
+	return page
+}
+
+// renderEchoPage 把页面元数据注入 SPA 的 index.html：覆盖 head 中已有的标题、描述与 OG / Twitter
+// 卡片（前端 useSeoHead 运行后会以同样的选择器再次覆盖），补上 canonical、发布时间与 JSON-LD，
+// 并在 body 开头放一段 noscript 正文，供不执行 JS 的爬虫读取。
+func renderEchoPage(index []byte, page model.EchoPage) ([]byte, error) {
+	doc, err := html.Parse(bytes.NewReader(index))
+	if err != nil {
+		return nil, err
+	}
+	head := findElement(doc, atom.Head)
+	body := findElement(doc, atom.Body)
+	if head == nil || body == nil {
+		return index, nil
+	}
+
+	headline, title := page.SiteName, page.SiteName
+	if page.Title != "" {
+		headline, title = page.Title, page.Title+" | "+page.SiteName
+	}
+	setTitle(head, title)
+	upsertMeta(head, "name", "description", page.Description)
+	if page.Author != "" {
+		upsertMeta(head, "name", "author", page.Author)
+	}
+	upsertCanonical(head, page.URL)
+
+	upsertMeta(head, "property", "og:type", "article")
+	upsertMeta(head, "property", "og:site_name", page.SiteName)
+	upsertMeta(head, "property", "og:title", title)
+	upsertMeta(head, "property", "og:description", page.Description)
+	upsertMeta(head, "property", "og:url", page.URL)
+	upsertMeta(head, "property", "article:published_time", page.Published.Format(time.RFC3339))
+	if page.Author != "" {
+		upsertMeta(head, "property", "article:author", page.Author)
+	}
+	for _, tag := range page.Tags {
+		head.AppendChild(element(atom.Meta, "property", "article:tag", "content", tag))
+	}
+
+	card := "summary"
+	if len(page.Images) > 0 {
+		img := page.Images[0]
+		card = "summary_large_image"
+		upsertMeta(head, "property", "og:image", img.URL)
+		upsertMeta(head, "name", "twitter:image", img.URL)
+		if img.Alt != "" {
+			upsertMeta(head, "property", "og:image:alt", img.Alt)
+		}
+		if img.Width > 0 && img.Height > 0 {
+			upsertMeta(head, "property", "og:image:width", strconv.Itoa(img.Width))
+			upsertMeta(head, "property", "og:image:height", strconv.Itoa(img.Height))
+		}
+	} else {
+		// 没有配图时去掉 index.html 里的站点默认图，避免每条 Echo 的卡片都是同一张 Logo。
+		removeMeta(head, "property", "og:image")
+		removeMeta(head, "name", "twitter:image")
+	}
+	upsertMeta(head, "name", "twitter:card", card)
+	upsertMeta(head, "name", "twitter:title", title)
+	upsertMeta(head, "name", "twitter:description", page.Description)
+
+	ld, err := json.Marshal(postingLD(page, headline))
+	if err != nil {
+		return nil, err
+	}
+	script := element(atom.Script, "id", echoJSONLDID, "type", "application/ld+json")
+	script.AppendChild(&html.Node{Type: html.TextNode, Data: string(ld)})
+	head.AppendChild(script)
+
+	noscript := element(atom.Noscript)
+	nodes, err := html.ParseFragment(strings.NewReader(fallbackBody(page, headline)), body)
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		noscript.AppendChild(n)
+	}
+	body.InsertBefore(noscript, body.FirstChild)
+
+	var buf bytes.Buffer
+	if err := html.Render(&buf, doc); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type jsonLDPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type jsonLDPosting struct {
+	Context          string        `json:"@context"`
+	Type             string        `json:"@type"`
+	URL              string        `json:"url"`
+	MainEntityOfPage string        `json:"mainEntityOfPage"`
+	Headline         string        `json:"headline"`
+	Description      string        `json:"description,omitempty"`
+	DatePublished    string        `json:"datePublished"`
+	Author           *jsonLDPerson `json:"author,omitempty"`
+	Image            []string      `json:"image,omitempty"`
+	Keywords         []string      `json:"keywords,omitempty"`
+}
+
+// postingLD 以 schema.org SocialMediaPosting 描述这条 Echo。json.Marshal 会转义 < > &，
+// 正文里的 "</script>" 不会提前闭合脚本块。
+func postingLD(page model.EchoPage, headline string) jsonLDPosting {
+	ld := jsonLDPosting{
+		Context:          "https://schema.org",
+		Type:             "SocialMediaPosting",
+		URL:              page.URL,
+		MainEntityOfPage: page.URL,
+		Headline:         headline,
+		Description:      page.Description,
+		DatePublished:    page.Published.Format(time.RFC3339),
+		Keywords:         page.Tags,
+	}
+	if page.Author != "" {
+		ld.Author = &jsonLDPerson{Type: "Person", Name: page.Author}
+	}
+	for _, img := range page.Images {
+		ld.Image = append(ld.Image, img.URL)
+	}
+	return ld
+}
+
+// fallbackBody 是 noscript 内的正文，按 h-entry 标注。ContentHTML 来自 MdToHTML，
+// 已丢弃原始 HTML 并过滤不安全链接，其余字段一律转义。
+func fallbackBody(page model.EchoPage, headline string) string {
+	esc := html.EscapeString
+	var b strings.Builder
+	b.WriteString(`<article class="h-entry"><h1>`)
+	b.WriteString(esc(headline))
+	b.WriteString(`</h1><p>`)
+	if page.Author != "" {
+		b.WriteString(`<span class="p-author">` + esc(page.Author) + `</span> · `)
+	}
+	b.WriteString(`<time class="dt-published" datetime="` + page.Published.Format(time.RFC3339) + `">`)
+	b.WriteString(page.Published.Format("2006-01-02 15:04") + ` UTC</time></p>`)
+	b.WriteString(`<div class="e-content">` + page.ContentHTML + `</div>`)
+	for _, img := range page.Images {
+		b.WriteString(`<img class="u-photo" src="` + esc(img.URL) + `" alt="` + esc(img.Alt) + `">`)
+	}
+	b.WriteString(`<p><a class="u-url" href="` + esc(page.URL) + `">` + esc(page.URL) + `</a></p></article>`)
+	return b.String()
+}
+
+func findElement(n *html.Node, a atom.Atom) *html.Node {
+	if n.Type == html.ElementNode && n.DataAtom == a {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findElement(c, a); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// element 构造一个元素节点，attrs 为交替的键值。
+func element(a atom.Atom, attrs ...string) *html.Node {
+	n := &html.Node{Type: html.ElementNode, DataAtom: a, Data: a.String()}
+	for i := 0; i+1 < len(attrs); i += 2 {
+		n.Attr = append(n.Attr, html.Attribute{Key: attrs[i], Val: attrs[i+1]})
+	}
+	return n
+}
+
+func attrValue(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func setAttr(n *html.Node, key, value string) {
+	for i := range n.Attr {
+		if n.Attr[i].Key == key {
+			n.Attr[i].Val = value
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: value})
+}
+
+// headChild 找 head 下 tag 相同、attr 值为 value 的第一个子元素。
+func headChild(head *html.Node, a atom.Atom, attr, value string) *html.Node {
+	for c := head.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.ElementNode && c.DataAtom == a && attrValue(c, attr) == value {
+			return c
+		}
+	}
+	return nil
+}
+
+// upsertMeta 覆盖 <meta attr=key> 的 content，不存在时追加。
+func upsertMeta(head *html.Node, attr, key, content string) {
+	if n := headChild(head, atom.Meta, attr, key); n != nil {
+		setAttr(n, "content", content)
+		return
+	}
+	head.AppendChild(element(atom.Meta, attr, key, "content", content))
+}
+
+func removeMeta(head *html.Node, attr, key string) {
+	for n := headChild(head, atom.Meta, attr, key); n != nil; n = headChild(head, atom.Meta, attr, key) {
+		head.RemoveChild(n)
+	}
+}
+
+func upsertCanonical(head *html.Node, href string) {
+	if n := headChild(head, atom.Link, "rel", "canonical"); n != nil {
+		setAttr(n, "href", href)
+		return
+	}
+	head.AppendChild(element(atom.Link, "rel", "canonical", "href", href))
+}
+
+func setTitle(head *html.Node, title string) {
+	n := findElement(head, atom.Title)
+	if n == nil {
+		n = element(atom.Title)
+		head.AppendChild(n)
+	}
+	for n.FirstChild != nil {
+		n.RemoveChild(n.FirstChild)
+	}
+	n.AppendChild(&html.Node{Type: html.TextNode, Data: title})
+}
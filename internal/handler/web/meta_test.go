@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	model "github.com/lin-snow/ech0/internal/model/page"
+)
+
+const testIndex = `<!doctype html><html><head>
+<meta property="og:title" content="Ech0" />
+<meta property="og:image" content="/Ech0.png" />
+<meta name="twitter:image" content="/Ech0.png" />
+<meta name="description" content="default" />
+<title>Ech0</title>
+</head><body><div id="app"></div></body></html>`
+
+func TestRenderEchoPage_ReplacesDefaults(t *testing.T) {
+	page := model.EchoPage{
+		SiteName:    "Ech0",
+		Description: "only text",
+		URL:         "https://ech0.example/echo/e1",
+		Published:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Tags:        []string{"go", "life"},
+		ContentHTML: "<p>only text</p>",
+	}
+	out, err := renderEchoPage([]byte(testIndex), page)
+	if err != nil {
+		t.Fatalf("renderEchoPage failed: %v", err)
+	}
+	body := string(out)
+
+	for _, want := range []string{
+		`<title>Ech0</title>`,
+		`<meta name="description" content="only text"/>`,
+		`<meta property="og:type" content="article"/>`,
+		`<meta property="article:published_time" content="2026-01-02T03:04:05Z"/>`,
+		`<meta property="article:tag" content="go"/>`,
+		`<meta property="article:tag" content="life"/>`,
+		`<meta name="twitter:card" content="summary"/>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q, got: %s", want, body)
+		}
+	}
+	if strings.Count(body, `property="og:title"`) != 1 || strings.Count(body, "<title>") != 1 {
+		t.Fatalf("expected existing tags to be replaced, not duplicated: %s", body)
+	}
+	if strings.Contains(body, "/Ech0.png") {
+		t.Fatalf("expected the default site image to be dropped for an echo without images: %s", body)
+	}
+}
+
+func TestRenderEchoPage_EscapesContent(t *testing.T) {
+	page := model.EchoPage{
+		SiteName:    "Ech0",
+		Title:       `</title><script>alert(1)</script>`,
+		Description: `</script><script>alert(2)</script>`,
+		URL:         "https://ech0.example/echo/e1",
+		Author:      `"><img src=x onerror=alert(3)>`,
+		ContentHTML: "<p>hi</p>",
+	}
+	out, err := renderEchoPage([]byte(testIndex), page)
+	if err != nil {
+		t.Fatalf("renderEchoPage failed: %v", err)
+	}
+	body := string(out)
+	for _, bad := range []string{"<script>alert", "<img src=x"} {
+		if strings.Contains(body, bad) {
+			t.Fatalf("unescaped %q in output: %s", bad, body)
+		}
+	}
+	if !strings.Contains(body, `\u003c/script\u003e`) {
+		t.Fatalf("expected JSON-LD to escape </script>, got: %s", body)
+	}
+}
+
+func TestEchoPageID(t *testing.T) {
+	cases := map[string]string{
+		"/echo/abc":     "abc",
+		"/echo/":        "",
+		"/echo/abc/x":   "",
+		"/echos/abc":    "",
+		"/app/echo/abc": "",
+	}
+	for in, want := range cases {
+		if got := echoPageID(in); got != want {
+			t.Fatalf("echoPageID(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
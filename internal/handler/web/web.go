@@ -4,15 +4,23 @@
 package handler
 
 import (
+	"bytes"
+	"errors"
 	"io/fs"
+	"log/slog"
 	"mime"
 	"net/http"
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
+	pageService "github.com/lin-snow/ech0/internal/service/page"
+	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 	"github.com/lin-snow/ech0/internal/visitor"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
 	"github.com/lin-snow/ech0/template"
 )
 
@@ -24,10 +32,11 @@ var spaBypassPrefixes = []string{
 
 type WebHandler struct {
 	visitorTracker *visitor.Tracker
+	pageService    pageService.Service
 }
 
-func NewWebHandler(visitorTracker *visitor.Tracker) *WebHandler {
-	return &WebHandler{visitorTracker: visitorTracker}
+func NewWebHandler(visitorTracker *visitor.Tracker, pageService pageService.Service) *WebHandler {
+	return &WebHandler{visitorTracker: visitorTracker, pageService: pageService}
 }
 
 func (webHandler *WebHandler) Templates() gin.HandlerFunc {
@@ -53,6 +62,18 @@ func (webHandler *WebHandler) Templates() gin.HandlerFunc {
 		fullPath := path.Clean("." + requestPath)
 		f, err := fileServer.Open(fullPath)
 		if err != nil {
+			if id := echoPageID(requestPath); id != "" {
+				if page, ok := webHandler.renderEchoPage(ctx, subFS, id); ok {
+					webHandler.visitorTracker.Record(ctx.Request, ctx.ClientIP())
+					ctx.Header("Content-Type", "text/html; charset=utf-8")
+					setCacheControlHeader(ctx, "/index.html")
+					advertiseEndpoints(ctx)
+					// 页面随 Echo 变化，不带 Last-Modified，避免按 index.html 的时间戳回 304。
+					http.ServeContent(ctx.Writer, ctx.Request, "index.html", time.Time{}, bytes.NewReader(page))
+					return
+				}
+			}
+
 			// fallback 到 index.html
 			fallback, err := fileServer.Open("index.html")
 			if err != nil {
@@ -64,6 +85,7 @@ func (webHandler *WebHandler) Templates() gin.HandlerFunc {
 			webHandler.visitorTracker.Record(ctx.Request, ctx.ClientIP())
 			ctx.Header("Content-Type", "text/html; charset=utf-8")
 			setCacheControlHeader(ctx, "/index.html")
+			advertiseEndpoints(ctx)
 			http.ServeContent(
 				ctx.Writer,
 				ctx.Request,
@@ -102,6 +124,28 @@ func (webHandler *WebHandler) Templates() gin.HandlerFunc {
 	}
 }
 
+// renderEchoPage 为公开 Echo 生成注入了元数据的 index.html。Echo 不存在或不可见、
+// 以及任何出错的情况都返回 false，由调用方照常返回原始 SPA 页面。
+func (webHandler *WebHandler) renderEchoPage(ctx *gin.Context, webFS fs.FS, id string) ([]byte, bool) {
+	page, err := webHandler.pageService.EchoPage(ctx.Request.Context(), id)
+	if err != nil {
+		if !errors.Is(err, pageService.ErrNotFound) {
+			logUtil.GetLogger().Warn("render echo page failed", slog.String("echo_id", id), logUtil.Err(err))
+		}
+		return nil, false
+	}
+	index, err := fs.ReadFile(webFS, "index.html")
+	if err != nil {
+		return nil, false
+	}
+	out, err := renderEchoPage(index, withOrigin(page, urlUtil.RequestOrigin(ctx.Request)))
+	if err != nil {
+		logUtil.GetLogger().Warn("render echo page failed", slog.String("echo_id", id), logUtil.Err(err))
+		return nil, false
+	}
+	return out, true
+}
+
 func setCacheControlHeader(ctx *gin.Context, requestPath string) {
 	if strings.HasPrefix(requestPath, "/assets/") {
 		ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
@@ -114,6 +158,15 @@ func setCacheControlHeader(ctx *gin.Context, requestPath string) {
 	ctx.Header("Cache-Control", "public, max-age=3600")
 }
 
+// advertiseEndpoints 在 SPA 页面（含 /echo/{id}）上声明 Micropub 与 Webmention 端点，
+// 供客户端与发送方发现。
+func advertiseEndpoints(ctx *gin.Context) {
+	ctx.Writer.Header().Add("Link", `</micropub>; rel="micropub"`)
+	if config.Config().Federation.Webmention {
+		ctx.Writer.Header().Add("Link", `</webmention>; rel="webmention"`)
+	}
+}
+
 func setNoStoreHeaders(ctx *gin.Context) {
 	ctx.Header("Cache-Control", "no-cache, no-store, must-revalidate")
 	ctx.Header("Pragma", "no-cache")
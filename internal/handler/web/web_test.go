@@ -4,11 +4,17 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	model "github.com/lin-snow/ech0/internal/model/page"
+	pageService "github.com/lin-snow/ech0/internal/service/page"
+	"github.com/lin-snow/ech0/internal/test/helpers"
 	"github.com/lin-snow/ech0/internal/visitor"
 )
 
@@ -16,9 +22,37 @@ func init() {
 	gin.SetMode(gin.TestMode)
 }
 
+// stubPages 只认 ID 为 public 的 Echo，其余一律按不可见处理。
+type stubPages struct{}
+
+func (stubPages) EchoPage(_ context.Context, id string) (model.EchoPage, error) {
+	if id == "configured" {
+		// 配置了站点地址时页面服务已给出绝对地址。
+		return model.EchoPage{
+			SiteName: "Ech0",
+			Title:    "Hello",
+			URL:      "https://site.example/echo/configured",
+			Images:   []model.Image{{URL: "https://site.example/api/files/a.png"}},
+		}, nil
+	}
+	if id != "public" {
+		return model.EchoPage{}, pageService.ErrNotFound
+	}
+	return model.EchoPage{
+		SiteName:    "Ech0",
+		Title:       "Hello",
+		Description: "Hello world",
+		URL:         "/echo/public",
+		Author:      "alice",
+		Published:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Images:      []model.Image{{URL: "/api/files/a.png"}},
+		ContentHTML: "<p>Hello world</p>",
+	}, nil
+}
+
 func newTemplatesRouter() *gin.Engine {
 	r := gin.New()
-	r.NoRoute(NewWebHandler(visitor.NewTracker()).Templates())
+	r.NoRoute(NewWebHandler(visitor.NewTracker(), stubPages{}).Templates())
 	return r
 }
 
@@ -55,6 +89,77 @@ func TestTemplates_SPAFallbackSetsNoStoreHeaders(t *testing.T) {
 	}
 }
 
+func TestTemplates_SPAFallbackAdvertisesEndpoints(t *testing.T) {
+	r := newTemplatesRouter()
+	serve := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/echo/some-id", nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return strings.Join(rec.Header().Values("Link"), ", ")
+	}
+
+	helpers.SetWebmention(t, false)
+	if got := serve(); got != `</micropub>; rel="micropub"` {
+		t.Fatalf("expected only the micropub Link header when webmention is disabled, got %q", got)
+	}
+	helpers.SetWebmention(t, true)
+	if got := serve(); got != `</micropub>; rel="micropub", </webmention>; rel="webmention"` {
+		t.Fatalf("expected micropub and webmention Link headers, got %q", got)
+	}
+}
+
+func TestTemplates_EchoPageInjectsMetadata(t *testing.T) {
+	r := newTemplatesRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/echo/public", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Host = "ech0.example"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache, no-store, must-revalidate" {
+		t.Fatalf("expected Cache-Control no-store header, got %q", got)
+	}
+	if got := rec.Header().Get("Last-Modified"); got != "" {
+		t.Fatalf("expected no Last-Modified on rendered echo page, got %q", got)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		`<title>Hello | Ech0</title>`,
+		`<meta property="og:title" content="Hello | Ech0"/>`,
+		`<meta property="og:url" content="https://ech0.example/echo/public"/>`,
+		`<meta property="og:image" content="https://ech0.example/api/files/a.png"/>`,
+		`<link rel="canonical" href="https://ech0.example/echo/public"/>`,
+		`<script id="echo-jsonld" type="application/ld+json">`,
+		`<noscript><article class="h-entry">`,
+		`<div id="app"></div>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in body, got: %s", want, body)
+		}
+	}
+}
+
+func TestTemplates_HiddenEchoServesPlainIndex(t *testing.T) {
+	r := newTemplatesRouter()
+
+	for _, p := range []string{"/echo/private", "/echo/public/extra"} {
+		req := httptest.NewRequest(http.MethodGet, p, nil)
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected %d, got %d", p, http.StatusOK, rec.Code)
+		}
+		if body := rec.Body.String(); strings.Contains(body, "og:") || strings.Contains(body, "noscript") {
+			t.Fatalf("%s: expected the untouched SPA index, got: %s", p, body)
+		}
+	}
+}
+
 func TestSetCacheControlHeader_DefaultStaticUsesShortCache(t *testing.T) {
 	rec := httptest.NewRecorder()
 	ctx, _ := gin.CreateTestContext(rec)
@@ -82,3 +187,25 @@ func TestSetCacheControlHeader_IndexDisablesCacheStorage(t *testing.T) {
 		t.Fatalf("expected Expires 0, got %q", got)
 	}
 }
+
+func TestTemplates_EchoPageIgnoresHostWhenServerURLConfigured(t *testing.T) {
+	r := newTemplatesRouter()
+
+	req := httptest.NewRequest(http.MethodGet, "/echo/configured", nil)
+	req.Host = "evil.example"
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+
+	body := rec.Body.String()
+	if strings.Contains(body, "evil.example") {
+		t.Fatalf("expected request host not to leak into the page, got: %s", body)
+	}
+	for _, want := range []string{
+		`<meta property="og:url" content="https://site.example/echo/configured"/>`,
+		`<link rel="canonical" href="https://site.example/echo/configured"/>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in body, got: %s", want, body)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	service "github.com/lin-snow/ech0/internal/service/webmention"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// WebmentionHandler 提供 Webmention 接收端点。请求是 x-www-form-urlencoded 表单，
+// 响应是纯文本状态，故走裸 gin。
+type WebmentionHandler struct {
+	service service.Service
+}
+
+func NewWebmentionHandler(svc service.Service) *WebmentionHandler {
+	return &WebmentionHandler{service: svc}
+}
+
+// Receive POST /webmention（source=...&target=...）。校验在后台完成，受理即回 202。
+func (h *WebmentionHandler) Receive(ctx *gin.Context) {
+	err := h.service.Receive(ctx.Request.Context(), ctx.PostForm("source"), ctx.PostForm("target"))
+	switch {
+	case err == nil:
+		ctx.String(http.StatusAccepted, "accepted")
+	case errors.Is(err, service.ErrDisabled):
+		ctx.Status(http.StatusNotFound)
+	case errors.Is(err, service.ErrBadRequest):
+		ctx.String(http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrBusy):
+		ctx.Header("Retry-After", "60")
+		ctx.String(http.StatusServiceUnavailable, err.Error())
+	default:
+		logUtil.GetLogger().Warn("webmention request failed", logUtil.Err(err))
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
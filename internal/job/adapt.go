@@ -9,25 +9,26 @@ import (
 	"fmt"
 )
 
-// TypedRun 是作者端 typed 的工作函数：直接拿领域 payload 结构体 P。
-type TypedRun[P any] func(ctx context.Context, p P, report ReportFunc) (any, error)
+// TypedRun 是作者端 typed 的工作函数：直接拿领域 payload 结构体 P。可续跑的作业把断点
+// 也放进 P，经 checkpoint 落库。
+type TypedRun[P any] func(ctx context.Context, p P, report ReportFunc, checkpoint CheckpointFunc) (any, error)
 
 // Adapt 把 typed 的 TypedRun[P] 适配成 untyped 的 Runner。异构注册表无法容纳不同
 // payload 类型，边界必然擦除——故泛型只放作者端，Unmarshal 在此完成。
 func Adapt[P any](fn TypedRun[P]) Runner {
-	return runnerFunc(func(ctx context.Context, raw []byte, report ReportFunc) (any, error) {
+	return runnerFunc(func(ctx context.Context, raw []byte, report ReportFunc, checkpoint CheckpointFunc) (any, error) {
 		var p P
 		if len(raw) > 0 {
 			if err := json.Unmarshal(raw, &p); err != nil {
 				return nil, fmt.Errorf("decode %T payload: %w", p, err)
 			}
 		}
-		return fn(ctx, p, report)
+		return fn(ctx, p, report, checkpoint)
 	})
 }
 
-type runnerFunc func(ctx context.Context, payload []byte, report ReportFunc) (any, error)
+type runnerFunc func(ctx context.Context, payload []byte, report ReportFunc, checkpoint CheckpointFunc) (any, error)
 
-func (f runnerFunc) Run(ctx context.Context, payload []byte, report ReportFunc) (any, error) {
-	return f(ctx, payload, report)
+func (f runnerFunc) Run(ctx context.Context, payload []byte, report ReportFunc, checkpoint CheckpointFunc) (any, error) {
+	return f(ctx, payload, report, checkpoint)
 }
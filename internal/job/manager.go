@@ -9,40 +9,82 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
+	"github.com/lin-snow/ech0/internal/metrics"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
+	"github.com/lin-snow/ech0/internal/tracing"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const logModule = "job"
 
+// maxResumes 是单个作业因进程中断而续跑的次数上限：一个每次都把进程带崩的作业不能无限重来。
+const maxResumes = 3
+
 var (
 	// ErrNoRunner 提交了未注册类型的作业。
 	ErrNoRunner = errors.New("no runner registered for job type")
-	// ErrAlreadyRunning 该类型已有一条非终态作业（同类型互斥）。
+	// ErrAlreadyRunning 该类型并发已满且不允许排队（Limits.QueueSize 为 0）。
 	ErrAlreadyRunning = errors.New("a job of this type is already running")
+	// ErrQueueFull 该类型并发已满，排队数也已到 Limits.QueueSize。
+	ErrQueueFull = errors.New("job queue of this type is full")
 )
 
-// Manager 管理所有作业的生命周期：Runner 注册表、durable 持久化、内存实时进度、
-// 取消句柄。它从不解析领域 payload，只搬运 JSON。实现 app.Component。
+// activeJob 是本进程内正在运行的作业的取消句柄。
+type activeJob struct {
+	jobType string
+	cancel  context.CancelFunc
+}
+
+// Manager 管理所有作业的生命周期：Runner 注册表、按类型的并发与排队、durable 持久化、
+// 内存实时进度、取消句柄与历史清理。它从不解析领域 payload，只搬运 JSON。实现 app.Component。
+//
+// 排队态就是 durable 的 pending 行：某类型有空闲并发槽时按提交顺序取队首开跑，
+// 任一作业结束都会再调度一次。调度依据内存里的按类型队列与在跑句柄（启动时从 durable 载入），
+// mu 只保护这些内存状态，落库一律在锁外进行。
 type Manager struct {
 	repo JobRepository
 
+	defaults  Limits
+	limits    map[string]Limits
+	retention time.Duration
+	keep      int
+
 	mu      sync.Mutex
 	runners map[string]Runner
-	live    map[string]*Progress
-	cancels map[string]context.CancelFunc
+	live    map[string]*Progress  // 按作业 ID
+	active  map[string]*activeJob // 按作业 ID
+	// queues 是各类型排队中的作业（pending 行），按提交顺序。
+	queues map[string][]jobModel.Job
+	// reserved 是各类型已通过上限校验、尚在落库的提交数，计入占用，避免并发提交越过上限。
+	reserved map[string]int
+	// stopping 在 Stop 后置位：被取消的作业收尾时不再从队列里拉起新作业。
+	stopping bool
+	// running 计数在跑的 run goroutine，Stop 据此等它们写回状态。
+	running sync.WaitGroup
 }
 
-func NewManager(repo JobRepository) *Manager {
-	return &Manager{
-		repo:    repo,
-		runners: make(map[string]Runner),
-		live:    make(map[string]*Progress),
-		cancels: make(map[string]context.CancelFunc),
+func NewManager(repo JobRepository, opts ...Option) *Manager {
+	m := &Manager{
+		repo:     repo,
+		defaults: Limits{Concurrency: 1},
+		limits:   make(map[string]Limits),
+		runners:  make(map[string]Runner),
+		live:     make(map[string]*Progress),
+		active:   make(map[string]*activeJob),
+		queues:   make(map[string][]jobModel.Job),
+		reserved: make(map[string]int),
+	}
+	for _, opt := range opts {
+		opt(m)
 	}
+	return m
 }
 
 // Register 登记某类型的 Runner，须在任何 Submit 之前于启动期调用。
@@ -52,104 +94,244 @@ func (m *Manager) Register(jobType string, r Runner) {
 	m.runners[jobType] = r
 }
 
-// Submit 提交一次作业：校验已注册、同类型互斥、upsert pending、登记取消句柄，
-// 起 goroutine 执行，返回 pending 行。
+// Submit 提交一次作业：校验已注册与调度上限、落一条 pending 行，有空闲并发槽即开跑，
+// 否则留在队列里。返回刚建的 pending 行。
 func (m *Manager) Submit(ctx context.Context, jobType string, payload []byte) (jobModel.Job, error) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
-
-	runner, ok := m.runners[jobType]
-	if !ok {
+	if _, ok := m.runners[jobType]; !ok {
+		m.mu.Unlock()
 		return jobModel.Job{}, fmt.Errorf("%w: %s", ErrNoRunner, jobType)
 	}
-
-	// 同类型互斥：现存非终态行则拒绝。持锁读判断+upsert，单进程下即原子。
-	if existing, err := m.repo.GetByType(ctx, jobType); err == nil {
-		if !existing.Status.IsTerminal() {
+	// 并发占满时看队列余量。在跑、排队与正在落库的提交一起计入占用，持锁判断并预留名额，
+	// 落库在锁外进行也不会越过上限。
+	limits := m.limitsFor(jobType)
+	occupied := m.runningLocked(jobType) + len(m.queues[jobType]) + m.reserved[jobType]
+	if occupied >= limits.Concurrency {
+		if limits.QueueSize == 0 {
+			m.mu.Unlock()
 			return jobModel.Job{}, ErrAlreadyRunning
 		}
-	} else if !errors.Is(err, ErrNotFound) {
-		return jobModel.Job{}, err
+		if occupied-limits.Concurrency >= limits.QueueSize {
+			m.mu.Unlock()
+			return jobModel.Job{}, ErrQueueFull
+		}
 	}
+	m.reserved[jobType]++
+	m.mu.Unlock()
 
-	now := time.Now().UTC().Unix()
 	pending := jobModel.Job{
+		ID:        uuidUtil.MustNewV7(),
 		Type:      jobType,
 		Status:    jobModel.StatusPending,
 		Payload:   string(payload),
-		StartedAt: &now,
+		CreatedAt: time.Now().UTC().Unix(),
 	}
-	if err := m.repo.Upsert(ctx, &pending); err != nil {
+	err := m.repo.Create(ctx, &pending)
+
+	m.mu.Lock()
+	m.reserved[jobType]--
+	var launches []launch
+	if err == nil {
+		m.enqueueLocked(pending)
+		launches = m.dispatchLocked(jobType)
+	}
+	m.mu.Unlock()
+	if err != nil {
 		return jobModel.Job{}, err
 	}
+	logUtil.GetLogger().Info("job submitted", slog.String("module", logModule),
+		slog.String("type", jobType), slog.String("id", pending.ID))
 
-	// 作业独立于触发它的 HTTP 请求，用 background 派生可取消 ctx；持锁登记取消句柄，
-	// 消除「pending 已建、cancel 未登记」的窗口。
-	runCtx, cancel := context.WithCancel(context.Background())
-	m.cancels[jobType] = cancel
-	delete(m.live, jobType)
+	m.start(launches)
+	return pending, nil
+}
 
-	go m.run(runCtx, jobType, runner, pending)
+// launch 是调度出、待落成 running 并拉起的作业。
+type launch struct {
+	runner Runner
+	job    jobModel.Job
+	ctx    context.Context
+}
 
-	logUtil.GetLogger().Info("job submitted", slog.String("module", logModule), slog.String("type", jobType))
-	return pending, nil
+// dispatchLocked 在该类型有空闲并发槽时按提交顺序取出队首，登记取消句柄后交给 start 落库并拉起。
+// 只改内存状态，调用方须持锁，并在解锁后调用 start。
+func (m *Manager) dispatchLocked(jobType string) []launch {
+	runner, ok := m.runners[jobType]
+	if !ok || m.stopping {
+		return nil
+	}
+	limits := m.limitsFor(jobType)
+	var launches []launch
+	for len(m.queues[jobType]) > 0 && m.runningLocked(jobType) < limits.Concurrency {
+		next := m.queues[jobType][0]
+		m.queues[jobType] = m.queues[jobType][1:]
+
+		// 作业独立于触发它的 HTTP 请求，用 background 派生可取消 ctx；出队与登记取消句柄同在锁内，
+		// 作业任何时刻都能被 Cancel 找到。running 计数也在锁内加，Stop 置位之后不会再有新作业。
+		runCtx, cancel := context.WithCancel(context.Background())
+		m.active[next.ID] = &activeJob{jobType: jobType, cancel: cancel}
+		delete(m.live, next.ID)
+		m.running.Add(1)
+		launches = append(launches, launch{runner: runner, job: next, ctx: runCtx})
+	}
+	return launches
+}
+
+// start 把 dispatchLocked 调度出的作业落成 running 并拉起。落库失败的行仍是 pending，退回队首
+// 留待下一次调度；期间已被取消的直接置 cancelled。调用方不得持锁。
+func (m *Manager) start(launches []launch) {
+	// durable 写用 background ctx：调度可能由已结束的作业触发，与任何请求无关。
+	dbCtx := context.Background()
+	for _, l := range launches {
+		next := l.job
+		now := time.Now().UTC().Unix()
+		next.Status = jobModel.StatusRunning
+		// 续跑的作业保留首次开跑时间。
+		if next.StartedAt == nil {
+			next.StartedAt = &now
+		}
+		if err := m.repo.Save(dbCtx, &next); err != nil {
+			logUtil.GetLogger().Error("job mark running failed",
+				slog.String("module", logModule), slog.String("type", next.Type),
+				slog.String("id", next.ID), logUtil.Err(err))
+			m.mu.Lock()
+			m.clearLocked(next.ID)
+			if l.ctx.Err() == nil {
+				m.queues[next.Type] = append([]jobModel.Job{l.job}, m.queues[next.Type]...)
+			}
+			m.mu.Unlock()
+			if l.ctx.Err() != nil {
+				if err := m.markCancelled(dbCtx, l.job); err != nil {
+					logUtil.GetLogger().Error("job mark cancelled failed", slog.String("module", logModule),
+						slog.String("type", next.Type), slog.String("id", next.ID), logUtil.Err(err))
+				}
+			}
+			m.running.Done()
+			continue
+		}
+		go m.run(l.ctx, l.runner, next)
+	}
 }
 
 // run 在独立 goroutine 内推进作业：running → success/failed/cancelled。
-func (m *Manager) run(runCtx context.Context, jobType string, runner Runner, base jobModel.Job) {
+func (m *Manager) run(runCtx context.Context, runner Runner, base jobModel.Job) {
+	defer m.running.Done()
 	// durable 写用 background ctx，避免取消后终态行写不进去。
 	dbCtx := context.Background()
-	report := func(phase string, snapshot any) { m.setLive(jobType, phase, snapshot) }
-
-	base.Status = jobModel.StatusRunning
-	if err := m.repo.Upsert(dbCtx, &base); err != nil {
-		logUtil.GetLogger().Error("job mark running failed",
-			slog.String("module", logModule), slog.String("type", jobType), logUtil.Err(err))
+	start := time.Now()
+	report := func(phase string, snapshot any) { m.setLive(base.ID, phase, snapshot) }
+	// Runner 须在 Run 返回前同步调用 checkpoint，它与下面的终态写串行，直接改 base 即可。
+	checkpoint := func(payload any) {
+		now := time.Now().UTC().Unix()
+		base.Payload = mustJSON(payload)
+		base.Phase = m.takeLivePhase(base.ID)
+		base.CheckpointAt = &now
+		if err := m.repo.Save(dbCtx, &base); err != nil {
+			logUtil.GetLogger().Warn("job checkpoint failed", slog.String("module", logModule),
+				slog.String("type", base.Type), slog.String("id", base.ID), logUtil.Err(err))
+		}
 	}
 
-	result, runErr := runner.Run(runCtx, []byte(base.Payload), report)
+	// 作业脱离发起请求在后台运行，单独成一条 trace；内部的 SQL、事件与出站调用都挂在它下面。
+	traceCtx, span := tracing.Start(runCtx, "job.run "+base.Type, trace.WithNewRoot(),
+		trace.WithAttributes(attribute.String("job.id", base.ID), attribute.Int("job.resumes", base.Resumes)))
+	result, runErr := runner.Run(traceCtx, []byte(base.Payload), report, checkpoint)
+	tracing.End(span, runErr)
+
+	// 停机打断的可续跑作业退回 pending、不落终态，下次启动时按断点原样调度。它不是崩溃残留，
+	// 不计入 Resumes——否则连着几次正常重启就会把作业耗到放弃。
+	if errors.Is(runCtx.Err(), context.Canceled) && base.CheckpointAt != nil && m.isStopping() {
+		base.Status = jobModel.StatusPending
+		base.Phase = m.takeLivePhase(base.ID)
+		if err := m.repo.Save(dbCtx, &base); err != nil {
+			logUtil.GetLogger().Error("job requeue on shutdown failed", slog.String("module", logModule),
+				slog.String("type", base.Type), slog.String("id", base.ID), logUtil.Err(err))
+		}
+		logUtil.GetLogger().Info("job interrupted by shutdown, will resume on next start",
+			slog.String("module", logModule), slog.String("type", base.Type), slog.String("id", base.ID))
+		m.mu.Lock()
+		m.clearLocked(base.ID)
+		m.mu.Unlock()
+		return
+	}
 
 	now := time.Now().UTC().Unix()
 	base.FinishedAt = &now
-	base.Phase = m.takeLivePhase(jobType)
+	base.Phase = m.takeLivePhase(base.ID)
 
 	switch {
 	case errors.Is(runCtx.Err(), context.Canceled):
 		base.Status = jobModel.StatusCancelled
 		base.Error = ""
-		logUtil.GetLogger().Warn("job cancelled", slog.String("module", logModule), slog.String("type", jobType))
+		logUtil.GetLogger().Warn("job cancelled", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID))
 	case runErr != nil:
 		base.Status = jobModel.StatusFailed
 		base.Error = runErr.Error()
-		logUtil.GetLogger().Error("job failed",
-			slog.String("module", logModule), slog.String("type", jobType), logUtil.Err(runErr))
+		logUtil.GetLogger().Error("job failed", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID), logUtil.Err(runErr))
 	default:
 		base.Status = jobModel.StatusSuccess
 		base.Error = ""
 		if result != nil {
 			base.Payload = mustJSON(result)
 		}
-		logUtil.GetLogger().Info("job succeeded", slog.String("module", logModule), slog.String("type", jobType))
+		logUtil.GetLogger().Info("job succeeded", slog.String("module", logModule),
+			slog.String("type", base.Type), slog.String("id", base.ID))
 	}
 
-	if err := m.repo.Upsert(dbCtx, &base); err != nil {
+	metrics.ObserveJob(base.Type, string(base.Status), time.Since(start))
+
+	if err := m.repo.Save(dbCtx, &base); err != nil {
 		logUtil.GetLogger().Error("job persist terminal failed",
-			slog.String("module", logModule), slog.String("type", jobType),
+			slog.String("module", logModule), slog.String("type", base.Type), slog.String("id", base.ID),
 			slog.String("status", string(base.Status)), logUtil.Err(err))
 	}
 
-	m.clear(jobType)
+	m.mu.Lock()
+	m.clearLocked(base.ID)
+	launches := m.dispatchLocked(base.Type)
+	m.mu.Unlock()
+	m.start(launches)
+
+	m.prune(dbCtx, base.Type)
 }
 
-// Get 返回 durable 行；本进程正在跑时叠加内存实时进度。snapshot 为 nil 时只覆盖
-// Phase，不动 durable Payload。
-func (m *Manager) Get(ctx context.Context, jobType string) (jobModel.Job, error) {
-	row, err := m.repo.GetByType(ctx, jobType)
+// Current 返回该类型的当前作业（在跑优先，其次队首，否则最近一条未 dismiss 的终态），
+// 并叠加内存实时进度。查无返回 ErrNotFound。
+func (m *Manager) Current(ctx context.Context, jobType string) (jobModel.Job, error) {
+	row, err := m.repo.Current(ctx, jobType)
 	if err != nil {
 		return row, err
 	}
+	return m.withLive(row), nil
+}
+
+// GetByID 按 ID 返回作业行；本进程正在跑时叠加内存实时进度。
+func (m *Manager) GetByID(ctx context.Context, id string) (jobModel.Job, error) {
+	row, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return row, err
+	}
+	return m.withLive(row), nil
+}
+
+// List 按提交时间倒序分页列出作业历史；在跑的行叠加内存实时进度。
+func (m *Manager) List(ctx context.Context, q jobModel.ListQuery) ([]jobModel.Job, int64, error) {
+	rows, total, err := m.repo.List(ctx, q)
+	if err != nil {
+		return nil, 0, err
+	}
+	for i := range rows {
+		rows[i] = m.withLive(rows[i])
+	}
+	return rows, total, nil
+}
+
+// withLive 叠加内存实时进度。snapshot 为 nil 时只覆盖 Phase，不动 durable Payload。
+func (m *Manager) withLive(row jobModel.Job) jobModel.Job {
 	m.mu.Lock()
-	p := m.live[jobType]
+	p := m.live[row.ID]
 	m.mu.Unlock()
 	if p != nil {
 		row.Phase = p.Phase
@@ -157,75 +339,269 @@ func (m *Manager) Get(ctx context.Context, jobType string) (jobModel.Job, error)
 			row.Payload = mustJSON(p.Snapshot)
 		}
 	}
-	return row, nil
+	return row
 }
 
-// Delete 删除该类型的行并清空内存进度，使其回到「无作业」。仅应在终态时调用。
-func (m *Manager) Delete(ctx context.Context, jobType string) error {
-	if err := m.repo.Delete(ctx, jobType); err != nil {
-		return err
-	}
-	m.clear(jobType)
-	return nil
+// Dismiss 把该类型的终态行移出「当前作业」，使其回到「无作业」；行本身留在历史里。
+// 仅应在该类型没有在跑/排队作业时调用。
+func (m *Manager) Dismiss(ctx context.Context, jobType string) error {
+	return m.repo.Dismiss(ctx, jobType)
 }
 
-// Cancel 触发该类型在跑作业的 ctx 取消；无在跑/已终态则 no-op。
+// Cancel 取消该类型的全部作业：排队中的直接置 cancelled，在跑的触发 ctx 取消；
+// 都没有则 no-op。
 func (m *Manager) Cancel(jobType string) error {
 	m.mu.Lock()
-	cancel := m.cancels[jobType]
+	// 先把排队的整体出队，调度便不会在落库期间取走它们。
+	queued := m.queues[jobType]
+	delete(m.queues, jobType)
+	var cancels []context.CancelFunc
+	for _, a := range m.active {
+		if a.jobType == jobType {
+			cancels = append(cancels, a.cancel)
+		}
+	}
 	m.mu.Unlock()
-	if cancel != nil {
-		cancel()
+	for _, c := range cancels {
+		c()
 	}
-	return nil
+
+	var errs []error
+	for _, row := range queued {
+		errs = append(errs, m.markCancelled(context.Background(), row))
+	}
+	return errors.Join(errs...)
+}
+
+// CancelByID 取消单个作业：排队中的直接置 cancelled，在跑的触发 ctx 取消；已终态则 no-op。
+func (m *Manager) CancelByID(ctx context.Context, id string) error {
+	m.mu.Lock()
+	if a := m.active[id]; a != nil {
+		m.mu.Unlock()
+		a.cancel()
+		return nil
+	}
+	row, queued := m.dequeueLocked(id)
+	m.mu.Unlock()
+	if queued {
+		return m.markCancelled(ctx, row)
+	}
+
+	// 不在内存队列里的 pending 行（如无 Runner 的类型）只在 durable 里。
+	row, err := m.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if row.Status != jobModel.StatusPending {
+		return nil
+	}
+	return m.markCancelled(ctx, row)
 }
 
-func (m *Manager) setLive(jobType, phase string, snapshot any) {
+// enqueueLocked 把一条 pending 行按提交顺序插入所属类型的队列；已在队列或在跑的跳过。调用方须持锁。
+func (m *Manager) enqueueLocked(row jobModel.Job) {
+	if m.active[row.ID] != nil {
+		return
+	}
+	queue := m.queues[row.Type]
+	if slices.ContainsFunc(queue, func(j jobModel.Job) bool { return j.ID == row.ID }) {
+		return
+	}
+	i := slices.IndexFunc(queue, func(j jobModel.Job) bool {
+		return j.CreatedAt > row.CreatedAt || (j.CreatedAt == row.CreatedAt && j.ID > row.ID)
+	})
+	if i < 0 {
+		i = len(queue)
+	}
+	m.queues[row.Type] = slices.Insert(queue, i, row)
+}
+
+// dequeueLocked 把指定作业移出队列；不在队列里时返回 false。调用方须持锁。
+func (m *Manager) dequeueLocked(id string) (jobModel.Job, bool) {
+	for jobType, queue := range m.queues {
+		if i := slices.IndexFunc(queue, func(j jobModel.Job) bool { return j.ID == id }); i >= 0 {
+			row := queue[i]
+			m.queues[jobType] = slices.Delete(queue, i, i+1)
+			return row, true
+		}
+	}
+	return jobModel.Job{}, false
+}
+
+// runningLocked 统计该类型在本进程内在跑的作业数。调用方须持锁。
+func (m *Manager) runningLocked(jobType string) int {
+	n := 0
+	for _, a := range m.active {
+		if a.jobType == jobType {
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Manager) markCancelled(ctx context.Context, row jobModel.Job) error {
+	now := time.Now().UTC().Unix()
+	row.Status = jobModel.StatusCancelled
+	row.FinishedAt = &now
+	return m.repo.Save(ctx, &row)
+}
+
+func (m *Manager) limitsFor(jobType string) Limits {
+	if l, ok := m.limits[jobType]; ok {
+		return l
+	}
+	return m.defaults
+}
+
+// prune 按保留策略清理该类型的终态历史；失败只记日志。
+func (m *Manager) prune(ctx context.Context, jobType string) {
+	if m.retention <= 0 && m.keep <= 0 {
+		return
+	}
+	var before int64
+	if m.retention > 0 {
+		before = time.Now().UTC().Add(-m.retention).Unix()
+	}
+	if err := m.repo.Prune(ctx, jobType, before, m.keep); err != nil {
+		logUtil.GetLogger().Error("prune job history failed",
+			slog.String("module", logModule), slog.String("type", jobType), logUtil.Err(err))
+	}
+}
+
+func (m *Manager) setLive(id, phase string, snapshot any) {
 	m.mu.Lock()
-	m.live[jobType] = &Progress{Phase: phase, Snapshot: snapshot}
+	m.live[id] = &Progress{Phase: phase, Snapshot: snapshot}
 	m.mu.Unlock()
 }
 
-func (m *Manager) takeLivePhase(jobType string) string {
+func (m *Manager) takeLivePhase(id string) string {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if p := m.live[jobType]; p != nil {
+	if p := m.live[id]; p != nil {
 		return p.Phase
 	}
 	return ""
 }
 
-func (m *Manager) clear(jobType string) {
+func (m *Manager) clearLocked(id string) {
+	delete(m.live, id)
+	delete(m.active, id)
+}
+
+func (m *Manager) isStopping() bool {
 	m.mu.Lock()
-	delete(m.live, jobType)
-	delete(m.cancels, jobType)
-	m.mu.Unlock()
+	defer m.mu.Unlock()
+	return m.stopping
 }
 
 // Name 实现 app.Namer。
 func (m *Manager) Name() string { return "job" }
 
-// Start 把上次进程残留的 pending/running 行扫成 failed，避免前端永久转圈。幂等。
+// Start 接管上次进程崩溃残留的作业：落过断点的 running 行退回队首、按断点续跑；其余 running
+// 行置 failed 并写明原因，避免前端永久转圈；排队中的 pending 行原样保留。随后按保留策略
+// 清理历史，再把 pending 行载入内存队列、把各类型的队列调度起来。
 func (m *Manager) Start(ctx context.Context) error {
-	if err := m.repo.SweepRunning(ctx, "interrupted by restart"); err != nil {
-		logUtil.GetLogger().Error("sweep orphan jobs failed", slog.String("module", logModule), logUtil.Err(err))
+	orphans, err := m.repo.ListByStatus(ctx, jobModel.StatusRunning)
+	if err != nil {
+		logUtil.GetLogger().Error("list orphan jobs failed", slog.String("module", logModule), logUtil.Err(err))
 		return err
 	}
+	for _, row := range orphans {
+		if err := m.recoverOrphan(ctx, row); err != nil {
+			logUtil.GetLogger().Error("recover orphan job failed", slog.String("module", logModule),
+				slog.String("type", row.Type), slog.String("id", row.ID), logUtil.Err(err))
+			return err
+		}
+	}
+
+	m.mu.Lock()
+	types := make([]string, 0, len(m.runners))
+	for jobType := range m.runners {
+		types = append(types, jobType)
+	}
+	m.mu.Unlock()
+
+	for _, jobType := range types {
+		m.prune(ctx, jobType)
+	}
+
+	pending, err := m.repo.ListByStatus(ctx, jobModel.StatusPending)
+	if err != nil {
+		logUtil.GetLogger().Error("list pending jobs failed", slog.String("module", logModule), logUtil.Err(err))
+		return err
+	}
+	m.mu.Lock()
+	for _, row := range pending {
+		m.enqueueLocked(row)
+	}
+	var launches []launch
+	for _, jobType := range types {
+		launches = append(launches, m.dispatchLocked(jobType)...)
+	}
+	m.mu.Unlock()
+	m.start(launches)
 	return nil
 }
 
-// Stop 取消所有在跑作业，使其协作退出。
-func (m *Manager) Stop(context.Context) error {
+// recoverOrphan 处理一条上次进程崩溃残留的 running 行（正常停机时可续跑的作业已退回
+// pending）：可续跑的退回 pending（CreatedAt 不变，仍排在后来提交的作业之前），否则置 failed。
+func (m *Manager) recoverOrphan(ctx context.Context, row jobModel.Job) error {
+	m.mu.Lock()
+	runner := m.runners[row.Type]
+	m.mu.Unlock()
+
+	var reason string
+	switch {
+	case runner == nil:
+		reason = "interrupted by restart: no runner registered for this job type"
+	case row.CheckpointAt == nil:
+		reason = "interrupted by restart: job has no checkpoint to resume from"
+	case row.Resumes >= maxResumes:
+		reason = fmt.Sprintf("interrupted by restart: gave up after %d resumes", maxResumes)
+	}
+
+	if reason != "" {
+		now := time.Now().UTC().Unix()
+		row.Status = jobModel.StatusFailed
+		row.Error = reason
+		row.FinishedAt = &now
+		logUtil.GetLogger().Warn("orphan job failed", slog.String("module", logModule),
+			slog.String("type", row.Type), slog.String("id", row.ID), slog.String("reason", reason))
+		return m.repo.Save(ctx, &row)
+	}
+
+	row.Status = jobModel.StatusPending
+	row.Resumes++
+	logUtil.GetLogger().Info("job resuming from checkpoint", slog.String("module", logModule),
+		slog.String("type", row.Type), slog.String("id", row.ID), slog.Int("resumes", row.Resumes))
+	return m.repo.Save(ctx, &row)
+}
+
+// Stop 取消所有在跑作业，使其协作退出，并等它们写回状态（最多等到 ctx 结束）。落过断点的
+// 作业退回 pending，下次启动时续跑；来不及写回的仍是 running，下次启动按崩溃残留处理。
+func (m *Manager) Stop(ctx context.Context) error {
 	m.mu.Lock()
-	cancels := make([]context.CancelFunc, 0, len(m.cancels))
-	for _, c := range m.cancels {
-		cancels = append(cancels, c)
+	m.stopping = true
+	cancels := make([]context.CancelFunc, 0, len(m.active))
+	for _, a := range m.active {
+		cancels = append(cancels, a.cancel)
 	}
 	m.mu.Unlock()
 	for _, c := range cancels {
 		c()
 	}
-	return nil
+
+	done := make(chan struct{})
+	go func() {
+		m.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func mustJSON(v any) string {
@@ -6,6 +6,8 @@ package job_test
 import (
 	"context"
 	"errors"
+	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -22,62 +24,164 @@ type stubRepo struct {
 
 func newStubRepo() *stubRepo { return &stubRepo{rows: map[string]jobModel.Job{}} }
 
-func (r *stubRepo) Upsert(_ context.Context, j *jobModel.Job) error {
+// byType 返回该类型的行（为空则全部），按提交顺序升序。调用方须持锁。
+func (r *stubRepo) byType(jobType string) []jobModel.Job {
+	var out []jobModel.Job
+	for _, j := range r.rows {
+		if jobType == "" || j.Type == jobType {
+			out = append(out, j)
+		}
+	}
+	sort.Slice(out, func(a, b int) bool {
+		if out[a].CreatedAt != out[b].CreatedAt {
+			return out[a].CreatedAt < out[b].CreatedAt
+		}
+		return out[a].ID < out[b].ID
+	})
+	return out
+}
+
+func (r *stubRepo) Create(_ context.Context, j *jobModel.Job) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.rows[j.Type] = *j
+	r.rows[j.ID] = *j
 	return nil
 }
 
-func (r *stubRepo) GetByType(_ context.Context, t string) (jobModel.Job, error) {
+func (r *stubRepo) Save(ctx context.Context, j *jobModel.Job) error { return r.Create(ctx, j) }
+
+func (r *stubRepo) GetByID(_ context.Context, id string) (jobModel.Job, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	j, ok := r.rows[t]
+	j, ok := r.rows[id]
 	if !ok {
 		return jobModel.Job{}, job.ErrNotFound
 	}
 	return j, nil
 }
 
-func (r *stubRepo) SweepRunning(_ context.Context, reason string) error {
+func (r *stubRepo) Current(_ context.Context, jobType string) (jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := r.byType(jobType)
+	for _, status := range []jobModel.Status{jobModel.StatusRunning, jobModel.StatusPending} {
+		for _, j := range rows {
+			if j.Status == status {
+				return j, nil
+			}
+		}
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if rows[i].Status.IsTerminal() && !rows[i].Dismissed {
+			return rows[i], nil
+		}
+	}
+	return jobModel.Job{}, job.ErrNotFound
+}
+
+func (r *stubRepo) NextPending(_ context.Context, jobType string) (jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, j := range r.byType(jobType) {
+		if j.Status == jobModel.StatusPending {
+			return j, nil
+		}
+	}
+	return jobModel.Job{}, job.ErrNotFound
+}
+
+func (r *stubRepo) CountByStatus(_ context.Context, jobType string, status jobModel.Status) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, j := range r.byType(jobType) {
+		if j.Status == status {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *stubRepo) List(_ context.Context, q jobModel.ListQuery) ([]jobModel.Job, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []jobModel.Job
+	for _, j := range r.byType(q.Type) {
+		if q.Status == "" || j.Status == q.Status {
+			out = append([]jobModel.Job{j}, out...)
+		}
+	}
+	return out, int64(len(out)), nil
+}
+
+func (r *stubRepo) Dismiss(_ context.Context, jobType string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	for k, j := range r.rows {
-		if !j.Status.IsTerminal() {
-			j.Status = jobModel.StatusFailed
-			j.Error = reason
-			r.rows[k] = j
+	for id, j := range r.rows {
+		if j.Type == jobType && j.Status.IsTerminal() {
+			j.Dismissed = true
+			r.rows[id] = j
 		}
 	}
 	return nil
 }
 
-func (r *stubRepo) Delete(_ context.Context, t string) error {
+func (r *stubRepo) ListByStatus(_ context.Context, status jobModel.Status) ([]jobModel.Job, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	delete(r.rows, t)
+	var out []jobModel.Job
+	for _, j := range r.byType("") {
+		if j.Status == status {
+			out = append(out, j)
+		}
+	}
+	return out, nil
+}
+
+func (r *stubRepo) Prune(_ context.Context, jobType string, _ int64, keep int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rows := r.byType(jobType)
+	for i, j := range rows {
+		if keep > 0 && i < len(rows)-keep && j.Status.IsTerminal() {
+			delete(r.rows, j.ID)
+		}
+	}
 	return nil
 }
 
-// waitForStatus 轮询 Get 直到命中目标状态或超时，消除 goroutine 时序 flakiness。
-func waitForStatus(t *testing.T, mgr *job.Manager, jobType string, want jobModel.Status) jobModel.Job {
+// waitForStatus 轮询 GetByID 直到命中目标状态或超时，消除 goroutine 时序 flakiness。
+func waitForStatus(t *testing.T, mgr *job.Manager, id string, want jobModel.Status) jobModel.Job {
 	t.Helper()
 	deadline := time.Now().Add(2 * time.Second)
 	for time.Now().Before(deadline) {
-		jb, err := mgr.Get(context.Background(), jobType)
+		jb, err := mgr.GetByID(context.Background(), id)
 		if err == nil && jb.Status == want {
 			return jb
 		}
 		time.Sleep(5 * time.Millisecond)
 	}
-	jb, _ := mgr.Get(context.Background(), jobType)
-	t.Fatalf("job %s did not reach status %q in time; last=%q", jobType, want, jb.Status)
+	jb, _ := mgr.GetByID(context.Background(), id)
+	t.Fatalf("job %s did not reach status %q in time; last=%q", id, want, jb.Status)
 	return jobModel.Job{}
 }
 
+// blockingRunner 在 release 关闭前阻塞，started 收到本次运行的开始信号。
+func blockingRunner(started chan<- struct{}, release <-chan struct{}) job.Runner {
+	return job.Adapt(func(ctx context.Context, _ struct{}, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return nil, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+}
+
 func TestSubmit_Success(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
-	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, report job.ReportFunc) (any, error) {
+	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, report job.ReportFunc, _ job.CheckpointFunc) (any, error) {
 		report("indexing", map[string]int{"n": 1})
 		return map[string]string{"ok": "yes"}, nil
 	}))
@@ -86,11 +190,11 @@ func TestSubmit_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("submit failed: %v", err)
 	}
-	if jb.Status != jobModel.StatusPending {
-		t.Fatalf("expected pending on submit, got %q", jb.Status)
+	if jb.Status != jobModel.StatusPending || jb.ID == "" {
+		t.Fatalf("expected pending row with id on submit, got %+v", jb)
 	}
 
-	done := waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
+	done := waitForStatus(t, mgr, jb.ID, jobModel.StatusSuccess)
 	if done.FinishedAt == nil || done.StartedAt == nil {
 		t.Fatalf("expected started/finished timestamps, got %+v", done)
 	}
@@ -101,16 +205,17 @@ func TestSubmit_Success(t *testing.T) {
 
 func TestSubmit_Failure(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
-	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
+	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
 		return nil, errors.New("boom")
 	}))
 
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
+	jb, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
 		t.Fatalf("submit failed: %v", err)
 	}
-	jb := waitForStatus(t, mgr, "t", jobModel.StatusFailed)
-	if jb.Error != "boom" {
-		t.Fatalf("expected error 'boom' persisted, got %q", jb.Error)
+	done := waitForStatus(t, mgr, jb.ID, jobModel.StatusFailed)
+	if done.Error != "boom" {
+		t.Fatalf("expected error 'boom' persisted, got %q", done.Error)
 	}
 }
 
@@ -121,77 +226,355 @@ func TestSubmit_UnknownType(t *testing.T) {
 	}
 }
 
-func TestSubmit_MutexRejectsConcurrent(t *testing.T) {
+func TestSubmit_NoQueueRejectsConcurrent(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
+	started := make(chan struct{}, 1)
 	release := make(chan struct{})
-	started := make(chan struct{})
-	mgr.Register("t", job.Adapt(func(ctx context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
-		close(started)
-		<-release
-		return nil, nil
-	}))
+	mgr.Register("t", blockingRunner(started, release))
 
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
+	first, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
 		t.Fatalf("first submit failed: %v", err)
 	}
 	<-started
-	waitForStatus(t, mgr, "t", jobModel.StatusRunning)
+	waitForStatus(t, mgr, first.ID, jobModel.StatusRunning)
 
 	if _, err := mgr.Submit(context.Background(), "t", nil); !errors.Is(err, job.ErrAlreadyRunning) {
 		t.Fatalf("expected ErrAlreadyRunning on concurrent submit, got %v", err)
 	}
 	close(release)
-	waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
+	waitForStatus(t, mgr, first.ID, jobModel.StatusSuccess)
+}
+
+// slowCreateRepo 的 Create 在 release 关闭前阻塞，用于观察落库期间 Manager 的锁。
+type slowCreateRepo struct {
+	*stubRepo
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func (r *slowCreateRepo) Create(ctx context.Context, j *jobModel.Job) error {
+	r.creating <- struct{}{}
+	<-r.release
+	return r.stubRepo.Create(ctx, j)
+}
+
+func TestSubmit_CreatesOutsideLock(t *testing.T) {
+	repo := &slowCreateRepo{stubRepo: newStubRepo(), creating: make(chan struct{}, 1), release: make(chan struct{})}
+	mgr := job.NewManager(repo)
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release))
+
+	submitted := make(chan jobModel.Job, 1)
+	go func() {
+		jb, err := mgr.Submit(context.Background(), "t", nil)
+		if err != nil {
+			t.Errorf("first submit failed: %v", err)
+		}
+		submitted <- jb
+	}()
+	<-repo.creating
+
+	// 落库期间锁是空闲的：Cancel 立即返回；预留的名额仍占着唯一的并发槽。
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- mgr.Cancel("other") }()
+	select {
+	case err := <-cancelled:
+		if err != nil {
+			t.Fatalf("cancel failed: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected Cancel not to wait for Submit's insert")
+	}
+	if _, err := mgr.Submit(context.Background(), "t", nil); !errors.Is(err, job.ErrAlreadyRunning) {
+		t.Fatalf("expected ErrAlreadyRunning while the first insert is in flight, got %v", err)
+	}
+
+	close(repo.release)
+	first := <-submitted
+	<-started
+	close(release)
+	waitForStatus(t, mgr, first.ID, jobModel.StatusSuccess)
+}
+
+func TestSubmit_QueuesAndRunsInOrder(t *testing.T) {
+	mgr := job.NewManager(newStubRepo(), job.WithDefaultLimits(job.Limits{Concurrency: 1, QueueSize: 1}))
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release))
+
+	first, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
+		t.Fatalf("first submit failed: %v", err)
+	}
+	<-started
+	second, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
+		t.Fatalf("second submit should queue, got %v", err)
+	}
+	if _, err := mgr.Submit(context.Background(), "t", nil); !errors.Is(err, job.ErrQueueFull) {
+		t.Fatalf("expected ErrQueueFull once the queue is full, got %v", err)
+	}
+
+	cur, err := mgr.Current(context.Background(), "t")
+	if err != nil || cur.ID != first.ID {
+		t.Fatalf("expected running job as current, got %+v (%v)", cur, err)
+	}
+	if jb, _ := mgr.GetByID(context.Background(), second.ID); jb.Status != jobModel.StatusPending || jb.StartedAt != nil {
+		t.Fatalf("expected second job queued without start time, got %+v", jb)
+	}
+
+	close(release)
+	waitForStatus(t, mgr, first.ID, jobModel.StatusSuccess)
+	<-started
+	waitForStatus(t, mgr, second.ID, jobModel.StatusSuccess)
+}
+
+func TestSubmit_ConcurrencyLimitPerType(t *testing.T) {
+	mgr := job.NewManager(newStubRepo(), job.WithLimits("t", job.Limits{Concurrency: 2}))
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release))
+
+	a, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
+		t.Fatalf("first submit failed: %v", err)
+	}
+	b, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
+		t.Fatalf("second submit within concurrency failed: %v", err)
+	}
+	<-started
+	<-started
+	if _, err := mgr.Submit(context.Background(), "t", nil); !errors.Is(err, job.ErrAlreadyRunning) {
+		t.Fatalf("expected ErrAlreadyRunning beyond concurrency, got %v", err)
+	}
+	close(release)
+	waitForStatus(t, mgr, a.ID, jobModel.StatusSuccess)
+	waitForStatus(t, mgr, b.ID, jobModel.StatusSuccess)
 }
 
 func TestCancel_RunningJob(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
 	started := make(chan struct{})
-	mgr.Register("t", job.Adapt(func(ctx context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
+	mgr.Register("t", job.Adapt(func(ctx context.Context, _ struct{}, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
 		close(started)
 		<-ctx.Done() // 协作式取消
 		return nil, ctx.Err()
 	}))
 
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
+	jb, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
 		t.Fatalf("submit failed: %v", err)
 	}
 	<-started
 	if err := mgr.Cancel("t"); err != nil {
 		t.Fatalf("cancel failed: %v", err)
 	}
-	waitForStatus(t, mgr, "t", jobModel.StatusCancelled)
+	waitForStatus(t, mgr, jb.ID, jobModel.StatusCancelled)
 }
 
-func TestStart_SweepsOrphans(t *testing.T) {
+func TestCancelByID_QueuedJob(t *testing.T) {
+	mgr := job.NewManager(newStubRepo(), job.WithDefaultLimits(job.Limits{Concurrency: 1, QueueSize: 2}))
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	mgr.Register("t", blockingRunner(started, release))
+
+	running, _ := mgr.Submit(context.Background(), "t", nil)
+	<-started
+	queued, err := mgr.Submit(context.Background(), "t", nil)
+	if err != nil {
+		t.Fatalf("queue submit failed: %v", err)
+	}
+
+	if err := mgr.CancelByID(context.Background(), queued.ID); err != nil {
+		t.Fatalf("cancel queued failed: %v", err)
+	}
+	jb := waitForStatus(t, mgr, queued.ID, jobModel.StatusCancelled)
+	if jb.FinishedAt == nil {
+		t.Fatalf("expected cancelled queued job to carry finished_at, got %+v", jb)
+	}
+
+	close(release)
+	waitForStatus(t, mgr, running.ID, jobModel.StatusSuccess)
+}
+
+func TestStart_FailsOrphanWithoutCheckpoint(t *testing.T) {
 	repo := newStubRepo()
-	_ = repo.Upsert(context.Background(), &jobModel.Job{Type: "t", Status: jobModel.StatusRunning})
+	_ = repo.Create(context.Background(), &jobModel.Job{ID: "orphan", Type: "t", Status: jobModel.StatusRunning})
 	mgr := job.NewManager(repo)
+	mgr.Register("t", blockingRunner(make(chan struct{}, 1), nil))
 
 	if err := mgr.Start(context.Background()); err != nil {
 		t.Fatalf("start failed: %v", err)
 	}
-	jb, err := mgr.Get(context.Background(), "t")
+	jb, err := mgr.GetByID(context.Background(), "orphan")
 	if err != nil {
 		t.Fatalf("get failed: %v", err)
 	}
-	if jb.Status != jobModel.StatusFailed {
-		t.Fatalf("expected orphan swept to failed, got %q", jb.Status)
+	if jb.Status != jobModel.StatusFailed || !strings.Contains(jb.Error, "no checkpoint") || jb.FinishedAt == nil {
+		t.Fatalf("expected orphan failed with a clear reason, got %+v", jb)
+	}
+}
+
+func TestStart_GivesUpAfterMaxResumes(t *testing.T) {
+	repo := newStubRepo()
+	checkpointAt := int64(1)
+	_ = repo.Create(context.Background(), &jobModel.Job{
+		ID: "orphan", Type: "t", Status: jobModel.StatusRunning, CheckpointAt: &checkpointAt, Resumes: 3,
+	})
+	mgr := job.NewManager(repo)
+	mgr.Register("t", blockingRunner(make(chan struct{}, 1), nil))
+
+	if err := mgr.Start(context.Background()); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	jb, _ := mgr.GetByID(context.Background(), "orphan")
+	if jb.Status != jobModel.StatusFailed || !strings.Contains(jb.Error, "gave up") {
+		t.Fatalf("expected orphan failed after too many resumes, got %+v", jb)
 	}
 }
 
-func TestSubmit_AfterTerminalReplaces(t *testing.T) {
+// countingPayload 是可续跑测试作业的 payload：Done 即断点。
+type countingPayload struct {
+	Done int `json:"done"`
+}
+
+func TestStart_ResumesFromCheckpointAfterShutdown(t *testing.T) {
+	repo := newStubRepo()
+	started := make(chan struct{}, 1)
+	first := job.NewManager(repo)
+	first.Register("t", job.Adapt(func(ctx context.Context, p countingPayload, _ job.ReportFunc, checkpoint job.CheckpointFunc) (any, error) {
+		p.Done = 2
+		checkpoint(p)
+		started <- struct{}{}
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}))
+	jb, err := first.Submit(context.Background(), "t", nil)
+	if err != nil {
+		t.Fatalf("submit failed: %v", err)
+	}
+	<-started
+	if err := first.Stop(context.Background()); err != nil {
+		t.Fatalf("stop failed: %v", err)
+	}
+
+	// Stop 等作业写回状态：停机打断的可续跑作业退回 pending，等下一个进程接手。
+	row, _ := repo.GetByID(context.Background(), jb.ID)
+	if row.Status != jobModel.StatusPending || row.CheckpointAt == nil || row.Payload != `{"done":2}` {
+		t.Fatalf("expected interrupted job requeued with its checkpoint, got %+v", row)
+	}
+
+	var resumedFrom countingPayload
+	second := job.NewManager(repo)
+	second.Register("t", job.Adapt(func(_ context.Context, p countingPayload, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
+		resumedFrom = p
+		return countingPayload{Done: 3}, nil
+	}))
+	if err := second.Start(context.Background()); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+
+	done := waitForStatus(t, second, jb.ID, jobModel.StatusSuccess)
+	if resumedFrom.Done != 2 {
+		t.Fatalf("expected runner to resume from checkpoint, got %+v", resumedFrom)
+	}
+	if done.Resumes != 0 || done.Payload != `{"done":3}` || done.StartedAt == nil {
+		t.Fatalf("expected shutdown not to count as a crash resume, got %+v", done)
+	}
+}
+
+func TestStart_CountsCrashResumes(t *testing.T) {
+	repo := newStubRepo()
+	checkpointAt := int64(1)
+	_ = repo.Create(context.Background(), &jobModel.Job{
+		ID: "orphan", Type: "t", Status: jobModel.StatusRunning, CheckpointAt: &checkpointAt, Payload: `{"done":1}`,
+	})
+	mgr := job.NewManager(repo)
+	mgr.Register("t", job.Adapt(func(_ context.Context, p countingPayload, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
+		return p, nil
+	}))
+
+	if err := mgr.Start(context.Background()); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	done := waitForStatus(t, mgr, "orphan", jobModel.StatusSuccess)
+	if done.Resumes != 1 {
+		t.Fatalf("expected crash recovery to count as a resume, got %+v", done)
+	}
+}
+
+func TestStart_RunsQueuedJobs(t *testing.T) {
+	repo := newStubRepo()
+	_ = repo.Create(context.Background(), &jobModel.Job{ID: "queued", Type: "t", Status: jobModel.StatusPending})
+	mgr := job.NewManager(repo)
+	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
+		return nil, nil
+	}))
+
+	if err := mgr.Start(context.Background()); err != nil {
+		t.Fatalf("start failed: %v", err)
+	}
+	waitForStatus(t, mgr, "queued", jobModel.StatusSuccess)
+}
+
+func TestSubmit_KeepsHistory(t *testing.T) {
+	repo := newStubRepo()
+	mgr := job.NewManager(repo, job.WithRetention(0, 2))
+	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
+		return nil, nil
+	}))
+
+	var ids []string
+	for range 3 {
+		jb, err := mgr.Submit(context.Background(), "t", nil)
+		if err != nil {
+			t.Fatalf("submit failed: %v", err)
+		}
+		waitForStatus(t, mgr, jb.ID, jobModel.StatusSuccess)
+		ids = append(ids, jb.ID)
+	}
+
+	// 终态后可再次提交，每次一行；超出 keep 的最旧一条被清理。清理在终态落库之后进行，
+	// 故轮询等它收敛。
+	var rows []jobModel.Job
+	var total int64
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		var err error
+		if rows, total, err = mgr.List(context.Background(), jobModel.ListQuery{Type: "t"}); err != nil {
+			t.Fatalf("list failed: %v", err)
+		}
+		if total == 2 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if total != 2 || rows[0].ID != ids[2] || rows[1].ID != ids[1] {
+		t.Fatalf("expected newest two jobs kept, got %+v", rows)
+	}
+	if _, err := mgr.GetByID(context.Background(), ids[0]); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected oldest job pruned, got %v", err)
+	}
+}
+
+func TestDismiss_ResetsCurrent(t *testing.T) {
 	mgr := job.NewManager(newStubRepo())
-	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc) (any, error) {
+	mgr.Register("t", job.Adapt(func(_ context.Context, _ struct{}, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
 		return nil, nil
 	}))
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
-		t.Fatalf("submit failed: %v", err)
+	jb, _ := mgr.Submit(context.Background(), "t", nil)
+	waitForStatus(t, mgr, jb.ID, jobModel.StatusSuccess)
+
+	if err := mgr.Dismiss(context.Background(), "t"); err != nil {
+		t.Fatalf("dismiss failed: %v", err)
+	}
+	if _, err := mgr.Current(context.Background(), "t"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected no current job after dismiss, got %v", err)
 	}
-	waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
-	// 终态后可再次提交（upsert 覆盖旧行）。
-	if _, err := mgr.Submit(context.Background(), "t", nil); err != nil {
-		t.Fatalf("resubmit after terminal failed: %v", err)
+	if got, err := mgr.GetByID(context.Background(), jb.ID); err != nil || !got.Dismissed {
+		t.Fatalf("expected dismissed job kept in history, got %+v (%v)", got, err)
 	}
-	waitForStatus(t, mgr, "t", jobModel.StatusSuccess)
 }
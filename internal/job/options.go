@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package job
+
+import "time"
+
+// Limits 是某类型作业的调度上限。
+type Limits struct {
+	// Concurrency 同类型同时运行的作业数上限，<=0 按 1 处理。
+	Concurrency int
+	// QueueSize 并发占满时允许排队的作业数；0 表示不排队，直接返回 ErrAlreadyRunning。
+	QueueSize int
+}
+
+func (l Limits) normalize() Limits {
+	l.Concurrency = max(l.Concurrency, 1)
+	l.QueueSize = max(l.QueueSize, 0)
+	return l
+}
+
+// Option 定制 Manager 的调度与历史保留策略。
+type Option func(*Manager)
+
+// WithDefaultLimits 设置未单独配置类型的调度上限（缺省并发 1、不排队）。
+func WithDefaultLimits(l Limits) Option {
+	return func(m *Manager) {
+		m.defaults = l.normalize()
+	}
+}
+
+// WithLimits 单独设置某类型的调度上限，覆盖默认值。
+func WithLimits(jobType string, l Limits) Option {
+	return func(m *Manager) {
+		m.limits[jobType] = l.normalize()
+	}
+}
+
+// WithRetention 设置历史保留策略：终态行超过 maxAge 或超出每类型最近 keep 条即被清理，
+// 两者 <=0 时各自不生效。每类型最近一条始终保留，领域据此判断「当前作业」。
+func WithRetention(maxAge time.Duration, keep int) Option {
+	return func(m *Manager) {
+		m.retention = max(maxAge, 0)
+		m.keep = max(keep, 0)
+	}
+}
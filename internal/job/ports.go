@@ -15,28 +15,49 @@ import (
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 )
 
-// ErrNotFound 表示该类型当前没有作业行。上层据此合成领域哨兵（如 migration 的 idle）。
+// ErrNotFound 表示查无作业行（按 ID，或该类型当前没有作业）。上层据此合成领域哨兵
+// （如 migration 的 idle）。
 var ErrNotFound = errors.New("job not found")
 
 // ReportFunc 供 Runner 上报实时进度（仅进内存，不落库）。phase 必填；snapshot 可为
 // nil，表示只更新阶段、不覆盖 durable Payload。
 type ReportFunc func(phase string, snapshot any)
 
-// Runner 是作业的工作单元：payload 为 Submit 传入的原始 JSON；返回的 result 作为终态
-// Payload 落库（nil 则保留原 payload）；返回 error 置 failed；必须在长循环里检查
-// ctx 取消。作者端不直接实现它，而用 Adapt 适配 typed 的工作函数。
+// CheckpointFunc 供可续跑的 Runner 落断点（durable，区别于只进内存的 ReportFunc）。
+// 传入值整体替换作业的 Payload，故须能按该 Runner 的输入类型解码：进程中断后 Manager
+// 原样把它作为 payload 重跑 Runner。须在 Run 返回前同步调用；落库失败只记日志。
+type CheckpointFunc func(payload any)
+
+// Runner 是作业的工作单元：payload 为 Submit 传入的原始 JSON（续跑时为最后一次断点）；
+// 返回的 result 作为终态 Payload 落库（nil 则保留原 payload）；返回 error 置 failed；
+// 必须在长循环里检查 ctx 取消。从未调用 checkpoint 的作业不可续跑，重启后直接置 failed。
+// 作者端不直接实现它，而用 Adapt 适配 typed 的工作函数。
 type Runner interface {
-	Run(ctx context.Context, payload []byte, report ReportFunc) (result any, err error)
+	Run(ctx context.Context, payload []byte, report ReportFunc, checkpoint CheckpointFunc) (result any, err error)
 }
 
-// JobRepository 是 jobs 表的持久化抽象（每类型单行）。
+// JobRepository 是 job_runs 表的持久化抽象（每次提交一行，保留历史）。
 type JobRepository interface {
-	Upsert(ctx context.Context, j *jobModel.Job) error
-	// GetByType 查无返回 (零值, ErrNotFound)。
-	GetByType(ctx context.Context, jobType string) (jobModel.Job, error)
-	// SweepRunning 把残留的 pending/running 行批量置 failed（启动期孤儿清理）。
-	SweepRunning(ctx context.Context, reason string) error
-	Delete(ctx context.Context, jobType string) error
+	Create(ctx context.Context, j *jobModel.Job) error
+	// Save 按 ID 整行覆盖。
+	Save(ctx context.Context, j *jobModel.Job) error
+	// GetByID 查无返回 (零值, ErrNotFound)。
+	GetByID(ctx context.Context, id string) (jobModel.Job, error)
+	// Current 返回该类型的当前作业：最早提交的 running，其次最早的 pending，否则最近
+	// 一条未 dismiss 的终态行；都没有返回 (零值, ErrNotFound)。
+	Current(ctx context.Context, jobType string) (jobModel.Job, error)
+	// NextPending 返回该类型最早提交的 pending 行（队首）；队空返回 ErrNotFound。
+	NextPending(ctx context.Context, jobType string) (jobModel.Job, error)
+	CountByStatus(ctx context.Context, jobType string, status jobModel.Status) (int64, error)
+	// List 按提交时间倒序分页列出历史。
+	List(ctx context.Context, q jobModel.ListQuery) ([]jobModel.Job, int64, error)
+	// Dismiss 把该类型所有终态行标为 dismissed（复位「当前作业」，保留历史）。
+	Dismiss(ctx context.Context, jobType string) error
+	// ListByStatus 按提交顺序列出所有类型中处于该状态的行（启动期找孤儿作业）。
+	ListByStatus(ctx context.Context, status jobModel.Status) ([]jobModel.Job, error)
+	// Prune 删除该类型超出保留策略的终态行：只留最近 keep 条（<=0 不限），且早于 before
+	// （Unix 秒，<=0 不限）的一并删除；该类型最近一条始终保留。
+	Prune(ctx context.Context, jobType string, before int64, keep int) error
 }
 
 // Progress 是内存态的实时进度。
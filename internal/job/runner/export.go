@@ -5,11 +5,15 @@ package runner
 
 import (
 	"context"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/lin-snow/ech0/internal/event"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	"github.com/lin-snow/ech0/internal/job"
 	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	"github.com/lin-snow/ech0/internal/migrator/artifact"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 	"github.com/lin-snow/ech0/pkg/busen"
 )
@@ -28,6 +32,10 @@ type CapsuleExporter interface {
 	) (coreMigrator.ExportOutcome, error)
 }
 
+// processStart 是本进程的启动时刻：续跑时只清理在它之前就停止写入的残余，不碰本进程里
+// 正在进行的导出（例如定时快照）。
+var processStart = time.Now()
+
 var (
 	_ SnapshotExporter = (*coreMigrator.ExportEngine)(nil)
 	_ CapsuleExporter  = (*coreMigrator.CapsuleEngine)(nil)
@@ -50,23 +58,76 @@ func NewExportRunner(
 	return &ExportRunner{exporter: exporter, capsuleExporter: capsuleExporter, bus: busProvider()}
 }
 
+// Run 打包并交付产物。打包是一整趟、无法分段，中断后不能接着打，只能从头重打；断点因此只有
+// 两个：开跑时记下开始时刻（续跑先清掉中断那趟留下的临时文件与半截产物，再重打），打包完成后
+// 记下产物文件名（收尾前中断时续跑直接交付这份产物）。
 func (r *ExportRunner) Run(
 	ctx context.Context,
 	p migratorModel.ExportPayload,
 	report job.ReportFunc,
+	checkpoint job.CheckpointFunc,
 ) (any, error) {
-	if p.Format == migratorModel.ExportFormatCapsule {
-		// 刻意不发 SystemSnapshot：webhook 订阅它来确认「备份已完成」，而胶囊不含账号与凭据、
-		// 不能用于灾难恢复，拿它冒充备份完成会给用户错误的安全感。
-		return r.capsuleExporter.Export(ctx, p.IncludePrivate, report)
+	if outcome, ok := packedArtifact(p); ok {
+		return r.deliver(ctx, outcome), nil
 	}
+	if p.PackingSince != 0 {
+		slot, _ := exportSlot(p)
+		if err := slot.Discard(time.Unix(p.PackingSince, 0), processStart); err != nil {
+			return nil, err
+		}
+	}
+	p.Artifact = ""
+	p.PackingSince = time.Now().Unix()
+	checkpoint(p)
 
-	outcome, err := r.exporter.Export(ctx, report)
+	var outcome coreMigrator.ExportOutcome
+	var err error
+	if p.Format == migratorModel.ExportFormatCapsule {
+		outcome, err = r.capsuleExporter.Export(ctx, p.IncludePrivate, report)
+	} else {
+		outcome, err = r.exporter.Export(ctx, report)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	eventbus.Notify(ctx, r.bus, event.SystemSnapshot{Info: "System manual snapshot completed"})
+	p.Artifact = outcome.FileName
+	checkpoint(p)
+	return r.deliver(ctx, outcome), nil
+}
+
+// deliver 做打包后的收尾。续跑时可能重复通知一次，webhook 端按「至少一次」处理。
+func (r *ExportRunner) deliver(ctx context.Context, outcome coreMigrator.ExportOutcome) coreMigrator.ExportOutcome {
+	// 刻意不为胶囊发 SystemSnapshot：webhook 订阅它来确认「备份已完成」，而胶囊不含账号与
+	// 凭据、不能用于灾难恢复，拿它冒充备份完成会给用户错误的安全感。
+	if outcome.Format != migratorModel.ExportFormatCapsule {
+		eventbus.Notify(ctx, r.bus, event.SystemSnapshot{Info: "System manual snapshot completed"})
+	}
+	return outcome
+}
+
+// packedArtifact 按断点里的文件名找回上次进程已打好的产物；没有断点或产物已被清理时返回 false。
+func packedArtifact(p migratorModel.ExportPayload) (coreMigrator.ExportOutcome, bool) {
+	if p.Artifact == "" || filepath.Base(p.Artifact) != p.Artifact {
+		return coreMigrator.ExportOutcome{}, false
+	}
+	slot, format := exportSlot(p)
+	path := slot.Path(p.Artifact)
+	info, err := os.Stat(path)
+	if err != nil {
+		return coreMigrator.ExportOutcome{}, false
+	}
+	return coreMigrator.ExportOutcome{
+		ArtifactPath: path,
+		FileName:     p.Artifact,
+		Size:         info.Size(),
+		Format:       format,
+	}, true
+}
 
-	return outcome, nil
+func exportSlot(p migratorModel.ExportPayload) (artifact.Slot, string) {
+	if p.Format == migratorModel.ExportFormatCapsule {
+		return artifact.Capsules(), migratorModel.ExportFormatCapsule
+	}
+	return artifact.Snapshots(), migratorModel.ExportFormatSnapshot
 }
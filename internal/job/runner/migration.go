@@ -34,7 +34,14 @@ func NewMigrationRunner(importer *coreMigrator.ImportEngine, capsuleImporter *co
 	return &MigrationRunner{importer: importer, capsuleImporter: capsuleImporter}
 }
 
-func (r *MigrationRunner) Run(ctx context.Context, p migratorModel.MigrationPayload, report job.ReportFunc) (any, error) {
+// Run 不落断点：导入会整库替换或逐条追加，中途打断后无法判断停在哪，重启后由框架置 failed，
+// 用户清理后重新上传。
+func (r *MigrationRunner) Run(
+	ctx context.Context,
+	p migratorModel.MigrationPayload,
+	report job.ReportFunc,
+	_ job.CheckpointFunc,
+) (any, error) {
 	if p.SourceType == migratorModel.MigrationSourceCapsule {
 		return r.capsuleImporter.Import(ctx, p, report)
 	}
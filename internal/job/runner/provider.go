@@ -10,4 +10,5 @@ var ProviderSet = wire.NewSet(
 	NewReindexRunner,
 	NewMigrationRunner,
 	NewExportRunner,
+	NewSnapshotUploadRunner,
 )
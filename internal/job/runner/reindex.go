@@ -11,8 +11,10 @@ import (
 	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
 )
 
-// ReindexPayload 无输入（全量重建）。
-type ReindexPayload struct{}
+// ReindexPayload 没有输入（全量重建），只承载断点：每页结束落一次，进程中断后从下一页续跑。
+type ReindexPayload struct {
+	embeddingService.BackfillCheckpoint
+}
 
 // ReindexRunner 把 EmbeddingService.Backfill 包成作业 Runner。
 type ReindexRunner struct {
@@ -23,10 +25,20 @@ func NewReindexRunner(svc embeddingService.Service) *ReindexRunner {
 	return &ReindexRunner{svc: svc}
 }
 
-// Run 跑 Backfill，每页结束上报累计计数；终态 result 为 BackfillResult。
-func (r *ReindexRunner) Run(ctx context.Context, _ ReindexPayload, report job.ReportFunc) (any, error) {
-	res, err := r.svc.Backfill(ctx, func(progress embeddingService.BackfillResult) {
-		report("indexing", progress)
+// Run 跑 Backfill，每页结束上报累计计数并落断点；终态 result 为 BackfillResult。
+//
+// 每页一次断点写入相对该页的 Embedding 请求可以忽略，不必节流。
+func (r *ReindexRunner) Run(
+	ctx context.Context,
+	p ReindexPayload,
+	report job.ReportFunc,
+	checkpoint job.CheckpointFunc,
+) (any, error) {
+	// 开跑即落断点：第一页跑完前中断也能从头续跑，而不是直接判失败。
+	checkpoint(p)
+	res, err := r.svc.Backfill(ctx, p.BackfillCheckpoint, func(cp embeddingService.BackfillCheckpoint) {
+		report("indexing", cp.BackfillResult)
+		checkpoint(ReindexPayload{BackfillCheckpoint: cp})
 	})
 	if err != nil {
 		return nil, err
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package runner
+
+import (
+	"context"
+
+	"github.com/lin-snow/ech0/internal/job"
+	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	"github.com/lin-snow/ech0/internal/migrator/snapshot"
+)
+
+// ChainUploader 是增量链上传执行端（由 migrator.ExportEngine 满足）。
+type ChainUploader interface {
+	UploadChain(ctx context.Context, report func(phase string, snapshot any)) (snapshot.ChainUpload, error)
+}
+
+var _ ChainUploader = (*coreMigrator.ExportEngine)(nil)
+
+// SnapshotUploadPayload 无输入：每次都同步整条本地链。
+type SnapshotUploadPayload struct{}
+
+// SnapshotUploadRunner 把增量链上传包成作业 Runner。上传本身按归档幂等（远端已有即跳过），
+// 所以被取消、失败或进程重启打断后，再跑一次就是续传，payload 里无需记具体断点。
+type SnapshotUploadRunner struct {
+	uploader ChainUploader
+}
+
+func NewSnapshotUploadRunner(uploader *coreMigrator.ExportEngine) *SnapshotUploadRunner {
+	return &SnapshotUploadRunner{uploader: uploader}
+}
+
+// Run 同步整条链，上传过程中上报计数；终态 result 为 snapshot.ChainUpload。开跑即落一次
+// 空断点，表明可续跑：进程重启后框架会自动再跑一遍。
+func (r *SnapshotUploadRunner) Run(
+	ctx context.Context,
+	p SnapshotUploadPayload,
+	report job.ReportFunc,
+	checkpoint job.CheckpointFunc,
+) (any, error) {
+	checkpoint(p)
+	res, err := r.uploader.UploadChain(ctx, report)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -15,6 +15,7 @@ import (
 	dashboardService "github.com/lin-snow/ech0/internal/service/dashboard"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
 )
@@ -29,6 +30,7 @@ type Adapter struct {
 	agentSvc     copilotService.SummaryService
 	settingSvc   settingService.Service
 	dashboardSvc dashboardService.Service
+	searchSvc    searchService.Service
 }
 
 func NewAdapter(
@@ -41,6 +43,7 @@ func NewAdapter(
 	agentSvc copilotService.SummaryService,
 	settingSvc settingService.Service,
 	dashboardSvc dashboardService.Service,
+	searchSvc searchService.Service,
 ) *Adapter {
 	return &Adapter{
 		echoSvc:      echoSvc,
@@ -52,6 +55,7 @@ func NewAdapter(
 		agentSvc:     agentSvc,
 		settingSvc:   settingSvc,
 		dashboardSvc: dashboardSvc,
+		searchSvc:    searchSvc,
 	}
 }
 
@@ -105,6 +109,21 @@ func boolArg(args map[string]any, key string) bool {
 	return false
 }
 
+// stringSliceArg 读取字符串数组参数，忽略其中的非字符串元素。
+func stringSliceArg(args map[string]any, key string) []string {
+	arr, ok := args[key].([]any)
+	if !ok {
+		return nil
+	}
+	var out []string
+	for _, v := range arr {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 func buildTags(args map[string]any) []echoModel.Tag {
 	raw, ok := args["tags"]
 	if !ok {
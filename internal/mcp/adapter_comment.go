@@ -55,6 +55,7 @@ func (a *Adapter) registerCommentTools(reg *Registry) {
 		Title: "Create Integration Comment",
 		Description: "Create a trusted integration/AI comment using the same backend as POST /api/comments/integration: " +
 			"no captcha or form_token; source is marked integration; subject to integration rate limits and duplicate checks. " +
+			"The post's comment policy applies: closed or auto-closed posts reject the call, approval-only posts keep the comment pending. " +
 			"Requires comment:write. See resource ech0://guide/integration-comment for the REST equivalent (curl) and audience rules.",
 		InputSchema: integrationCommentInputSchema,
 	}, a.createIntegrationComment, authModel.ScopeCommentWrite)
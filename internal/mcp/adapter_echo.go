@@ -16,23 +16,43 @@ import (
 )
 
 func (a *Adapter) registerEchoTools(reg *Registry) {
+	statusEnum := []string{echoModel.StatusPublished, echoModel.StatusDraft, echoModel.StatusScheduled}
+
 	reg.RegisterTool(ToolDefinition{
 		Name:        "search_posts",
 		Title:       "Search Posts",
-		Description: "Search posts by keyword and/or tag IDs. Returns paginated results: {items, total, page, page_size}. All parameters are optional; omitting everything returns the latest posts.",
+		Description: "Search posts by keyword and/or tag IDs. Returns paginated results: {items, total, highlights}; highlights maps post id to a matched snippet with hits wrapped in <mark>. When a query is given, results are ranked by relevance unless sort_by is set. All parameters are optional; omitting everything returns the latest posts.",
 		InputSchema: map[string]any{
 			"type": "object",
 			"properties": map[string]any{
-				"query":      map[string]any{"type": "string", "description": "Full-text search keyword (matched against post content)"},
+				"query":      map[string]any{"type": "string", "description": "Full-text search query matched against post content. Words are ANDed; supports \"exact phrase\", prefix*, -exclude / NOT word, and a OR b"},
 				"tag_ids":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Filter by one or more tag UUIDs (AND logic)"},
 				"page":       map[string]any{"type": "integer", "description": "Page number, 1-based", "default": 1},
 				"page_size":  map[string]any{"type": "integer", "description": "Results per page (1–100)", "default": 20},
-				"sort_by":    map[string]any{"type": "string", "enum": []string{"created_at", "fav_count"}, "description": "Field to sort by", "default": "created_at"},
+				"sort_by":    map[string]any{"type": "string", "enum": []string{"created_at", "fav_count", "relevance"}, "description": "Field to sort by; defaults to relevance when query is set, otherwise created_at"},
 				"sort_order": map[string]any{"type": "string", "enum": []string{"desc", "asc"}, "description": "Sort direction", "default": "desc"},
+				"status":     map[string]any{"type": "string", "enum": statusEnum, "description": "Publish status to list; defaults to published. Drafts and scheduled posts are only visible to admins"},
 			},
 		},
 	}, a.searchPosts, authModel.ScopeEchoRead)
 
+	reg.RegisterTool(ToolDefinition{
+		Name:        "hybrid_search_posts",
+		Title:       "Hybrid Search Posts",
+		Description: "Search posts by meaning and keywords at once: full-text and semantic (embedding) results are merged with reciprocal rank fusion. Use this for natural-language questions; use search_posts for exact keyword filtering or pagination. Returns {mode, items, keyword_total}; each item has {echo, score, keyword_rank, semantic_rank, highlight}. mode is \"keyword\" when embeddings are disabled.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"query"},
+			"properties": map[string]any{
+				"query":     map[string]any{"type": "string", "description": "Search query; same syntax as search_posts"},
+				"tag_ids":   map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Filter by one or more tag UUIDs"},
+				"limit":     map[string]any{"type": "integer", "description": "Number of results (1–50)", "default": 10},
+				"date_from": map[string]any{"type": "integer", "description": "Only posts created at or after this Unix timestamp (seconds)"},
+				"date_to":   map[string]any{"type": "integer", "description": "Only posts created at or before this Unix timestamp (seconds)"},
+			},
+		},
+	}, a.hybridSearchPosts, authModel.ScopeEchoRead)
+
 	reg.RegisterTool(ToolDefinition{
 		Name:        "get_post",
 		Title:       "Get Post",
@@ -46,6 +66,20 @@ func (a *Adapter) registerEchoTools(reg *Registry) {
 		},
 	}, a.getPost, authModel.ScopeEchoRead)
 
+	reg.RegisterTool(ToolDefinition{
+		Name:        "get_related_posts",
+		Title:       "Get Related Posts",
+		Description: "Find posts most similar to a given post by embedding nearest neighbours. Returns {mode, items}; each item has {echo, distance} (smaller is closer). Private and draft posts are filtered exactly as in search_posts. mode is \"unavailable\" with empty items when embeddings are disabled or the lookup failed.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]any{
+				"id":    map[string]any{"type": "string", "format": "uuid", "description": "UUID of the post to find neighbours for"},
+				"limit": map[string]any{"type": "integer", "description": "Number of results (1–20)", "default": 5},
+			},
+		},
+	}, a.getRelatedPosts, authModel.ScopeEchoRead)
+
 	reg.RegisterTool(ToolDefinition{
 		Name:        "list_tags",
 		Title:       "List Tags",
@@ -56,6 +90,21 @@ func (a *Adapter) registerEchoTools(reg *Registry) {
 		},
 	}, a.listTags, authModel.ScopeEchoRead)
 
+	reg.RegisterTool(ToolDefinition{
+		Name:        "suggest_tags",
+		Title:       "Suggest Tags",
+		Description: "Suggest existing tags for draft content before creating a post. Similar posts vote for their tags, weighted by closeness. Returns {mode, items}; each item has {tag, score, votes}. mode is \"keyword\" when embeddings are disabled or no neighbour carries a tag; suggestions are then the existing tags mentioned in the content.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"content"},
+			"properties": map[string]any{
+				"content": map[string]any{"type": "string", "description": "Draft post content"},
+				"tags":    map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "description": "Tag names the draft already has; they are never suggested"},
+				"limit":   map[string]any{"type": "integer", "description": "Number of suggestions (1–20)", "default": 5},
+			},
+		},
+	}, a.suggestTags, authModel.ScopeEchoRead)
+
 	echoFileSchema := map[string]any{
 		"type":     "object",
 		"required": []string{"file_id"},
@@ -98,6 +147,8 @@ func (a *Adapter) registerEchoTools(reg *Registry) {
 				"layout":     map[string]any{"type": "string", "enum": layoutEnum, "description": "Image layout style", "default": "waterfall"},
 				"echo_files": map[string]any{"type": "array", "items": echoFileSchema, "description": "Attached files (images, etc.) referenced by file_id"},
 				"extension":  extensionSchema,
+				"status":     map[string]any{"type": "string", "enum": statusEnum, "description": "published (default) goes live now; draft is kept hidden; scheduled goes live at publish_at", "default": echoModel.StatusPublished},
+				"publish_at": map[string]any{"type": "integer", "description": "Unix timestamp (seconds) to publish at; required when status is scheduled and must be in the future"},
 			},
 		},
 	}, a.createPost, authModel.ScopeEchoWrite)
@@ -118,6 +169,8 @@ func (a *Adapter) registerEchoTools(reg *Registry) {
 				"layout":     map[string]any{"type": "string", "enum": layoutEnum, "description": "Image layout style"},
 				"echo_files": map[string]any{"type": "array", "items": echoFileSchema, "description": "New attached files (replaces all existing attachments)"},
 				"extension":  extensionSchema,
+				"status":     map[string]any{"type": "string", "enum": statusEnum, "description": "Change publish status; omit to keep it. Set published to publish a draft now. A published post cannot go back to draft or scheduled"},
+				"publish_at": map[string]any{"type": "integer", "description": "New scheduled publish time (Unix seconds) for a scheduled post"},
 			},
 		},
 	}, a.updatePost, authModel.ScopeEchoWrite)
@@ -125,7 +178,7 @@ func (a *Adapter) registerEchoTools(reg *Registry) {
 	reg.RegisterTool(ToolDefinition{
 		Name:        "delete_post",
 		Title:       "Delete Post",
-		Description: "Permanently delete a post. Returns {id, message}. This action cannot be undone.",
+		Description: "Move a post to the trash. It can be brought back with restore_post until the retention period expires, after which it is deleted permanently along with its attachments. Returns {id, message}.",
 		InputSchema: map[string]any{
 			"type":     "object",
 			"required": []string{"id"},
@@ -135,6 +188,61 @@ func (a *Adapter) registerEchoTools(reg *Registry) {
 		},
 	}, a.deletePost, authModel.ScopeEchoWrite)
 
+	reg.RegisterTool(ToolDefinition{
+		Name:        "restore_post",
+		Title:       "Restore Post",
+		Description: "Restore a post from the trash. Attachments deleted in the meantime are dropped; the search and embedding indexes are rebuilt. Returns the restored post. Admin only.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]any{
+				"id": map[string]any{"type": "string", "format": "uuid", "description": "Post UUID"},
+			},
+		},
+	}, a.restorePost, authModel.ScopeEchoWrite)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "list_post_revisions",
+		Title:       "List Post Revisions",
+		Description: "List the edit history of a post, newest first. Each revision is a snapshot of the post as it was before an edit: {id, number, content, layout, private, tags, files, extension, editor_id, created_at}. Admin only.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]any{
+				"id": map[string]any{"type": "string", "format": "uuid", "description": "Post UUID"},
+			},
+		},
+	}, a.listPostRevisions, authModel.ScopeEchoRead)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "get_post_revision",
+		Title:       "Get Post Revision",
+		Description: "Retrieve one revision of a post with a line-level diff of its content. Returns {revision, compare_to, diff, stats}; each diff line is {op: equal|insert|delete, text}. Admin only.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id", "revision_id"},
+			"properties": map[string]any{
+				"id":          map[string]any{"type": "string", "format": "uuid", "description": "Post UUID"},
+				"revision_id": map[string]any{"type": "string", "format": "uuid", "description": "Revision UUID (from list_post_revisions)"},
+				"compare":     map[string]any{"type": "string", "enum": []string{echoModel.RevisionCompareNext, echoModel.RevisionCompareCurrent}, "description": "Diff against the following version (what this edit changed) or the current content (what restoring would change)", "default": echoModel.RevisionCompareNext},
+			},
+		},
+	}, a.getPostRevision, authModel.ScopeEchoRead)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "restore_post_revision",
+		Title:       "Restore Post Revision",
+		Description: "Restore a post to an earlier revision. Behaves like a normal edit: the current version is kept as a new revision and the echo.updated event fires (webhooks included). Attachments that no longer exist are skipped. Returns the restored post.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id", "revision_id"},
+			"properties": map[string]any{
+				"id":          map[string]any{"type": "string", "format": "uuid", "description": "Post UUID"},
+				"revision_id": map[string]any{"type": "string", "format": "uuid", "description": "Revision UUID to restore"},
+			},
+		},
+	}, a.restorePostRevision, authModel.ScopeEchoWrite)
+
 	reg.RegisterTool(ToolDefinition{
 		Name:        "get_today_posts",
 		Title:       "Get Today's Posts",
@@ -246,16 +354,7 @@ func (a *Adapter) searchPosts(ctx context.Context, args map[string]any) (*ToolCa
 	sortBy := stringArg(args, "sort_by")
 	sortOrder := stringArg(args, "sort_order")
 
-	var tagIDs []string
-	if raw, ok := args["tag_ids"]; ok {
-		if arr, ok := raw.([]any); ok {
-			for _, v := range arr {
-				if s, ok := v.(string); ok {
-					tagIDs = append(tagIDs, s)
-				}
-			}
-		}
-	}
+	tagIDs := stringSliceArg(args, "tag_ids")
 
 	result, err := a.echoSvc.QueryEchos(ctx, commonModel.EchoQueryDto{
 		Page:      page,
@@ -264,6 +363,26 @@ func (a *Adapter) searchPosts(ctx context.Context, args map[string]any) (*ToolCa
 		TagIDs:    tagIDs,
 		SortBy:    sortBy,
 		SortOrder: sortOrder,
+		Status:    stringArg(args, "status"),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(result)
+}
+
+func (a *Adapter) hybridSearchPosts(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	query := stringArg(args, "query")
+	if query == "" {
+		return textError("query is required"), nil
+	}
+
+	result, err := a.searchSvc.HybridSearch(ctx, commonModel.EchoSearchDto{
+		Query:    query,
+		Limit:    intArg(args, "limit", 10),
+		TagIDs:   stringSliceArg(args, "tag_ids"),
+		DateFrom: int64(intArg(args, "date_from", 0)),
+		DateTo:   int64(intArg(args, "date_to", 0)),
 	})
 	if err != nil {
 		return nil, err
@@ -283,6 +402,34 @@ func (a *Adapter) getPost(ctx context.Context, args map[string]any) (*ToolCallRe
 	return jsonResult(echo)
 }
 
+func (a *Adapter) getRelatedPosts(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	result, err := a.searchSvc.RelatedEchos(ctx, id, intArg(args, "limit", 5))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(result)
+}
+
+func (a *Adapter) suggestTags(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	content := stringArg(args, "content")
+	if strings.TrimSpace(content) == "" {
+		return textError("content is required"), nil
+	}
+	result, err := a.searchSvc.SuggestTags(ctx, commonModel.TagSuggestDto{
+		Content: content,
+		Tags:    stringSliceArg(args, "tags"),
+		Limit:   intArg(args, "limit", 5),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(result)
+}
+
 func (a *Adapter) listTags(_ context.Context, _ map[string]any) (*ToolCallResult, error) {
 	tags, err := a.echoSvc.GetAllTags()
 	if err != nil {
@@ -307,6 +454,8 @@ func (a *Adapter) createPost(ctx context.Context, args map[string]any) (*ToolCal
 		Tags:      buildTags(args),
 		EchoFiles: echoFiles,
 		Extension: extension,
+		Status:    stringArg(args, "status"),
+		PublishAt: int64(intArg(args, "publish_at", 0)),
 	}
 	if err := a.echoSvc.PostEcho(ctx, echo); err != nil {
 		return nil, err
@@ -335,6 +484,8 @@ func (a *Adapter) updatePost(ctx context.Context, args map[string]any) (*ToolCal
 	echo.Tags = buildTags(args)
 	echo.EchoFiles = buildEchoFiles(args)
 	echo.Extension = buildExtension(args)
+	echo.Status = stringArg(args, "status")
+	echo.PublishAt = int64(intArg(args, "publish_at", 0))
 
 	if err := a.echoSvc.UpdateEcho(ctx, echo); err != nil {
 		return nil, err
@@ -350,7 +501,57 @@ func (a *Adapter) deletePost(ctx context.Context, args map[string]any) (*ToolCal
 	if err := a.echoSvc.DeleteEchoById(ctx, id); err != nil {
 		return nil, err
 	}
-	return jsonResult(map[string]string{"id": id, "message": "post deleted successfully"})
+	return jsonResult(map[string]string{"id": id, "message": "post moved to trash"})
+}
+
+func (a *Adapter) restorePost(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	echo, err := a.echoSvc.RestoreEcho(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(echo)
+}
+
+func (a *Adapter) listPostRevisions(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	revisions, err := a.echoSvc.ListEchoRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(revisions)
+}
+
+func (a *Adapter) getPostRevision(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	revisionID := stringArg(args, "revision_id")
+	if id == "" || revisionID == "" {
+		return textError("id and revision_id are required"), nil
+	}
+	detail, err := a.echoSvc.GetEchoRevision(ctx, id, revisionID, stringArg(args, "compare"))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(detail)
+}
+
+func (a *Adapter) restorePostRevision(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	revisionID := stringArg(args, "revision_id")
+	if id == "" || revisionID == "" {
+		return textError("id and revision_id are required"), nil
+	}
+	echo, err := a.echoSvc.RestoreEchoRevision(ctx, id, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(echo)
 }
 
 func (a *Adapter) getTodayPosts(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
@@ -62,6 +62,24 @@ func TestIntArg(t *testing.T) {
 	}
 }
 
+func TestStringSliceArg(t *testing.T) {
+	cases := []struct {
+		name string
+		args map[string]any
+		want []string
+	}{
+		{"strings", map[string]any{"k": []any{"a", "b"}}, []string{"a", "b"}},
+		{"non-strings skipped", map[string]any{"k": []any{"a", 1.0, nil, "b"}}, []string{"a", "b"}},
+		{"missing key", map[string]any{}, nil},
+		{"wrong type", map[string]any{"k": "a"}, nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, stringSliceArg(tc.args, "k"))
+		})
+	}
+}
+
 func TestBoolArg(t *testing.T) {
 	cases := []struct {
 		name string
@@ -33,6 +33,7 @@ func (a *Adapter) resourceProfile(ctx context.Context, _ string) (*ResourceReadR
 		"email":    user.Email,
 		"avatar":   user.Avatar,
 		"is_admin": user.IsAdmin,
+		"role":     user.EffectiveRole(),
 	})
 	return &ResourceReadResult{
 		Contents: []ResourceContent{{URI: "ech0://profile/me", MimeType: "application/json", Text: string(data)}},
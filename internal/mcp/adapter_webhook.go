@@ -8,13 +8,14 @@ import (
 
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	"github.com/lin-snow/ech0/internal/webhook"
 )
 
 func (a *Adapter) registerWebhookTools(reg *Registry) {
 	reg.RegisterTool(ToolDefinition{
 		Name:        "list_webhooks",
 		Title:       "List Webhooks",
-		Description: "List all configured webhooks. Returns an array of webhook objects (id, name, url, is_active, last_status, last_trigger, timestamps). Secrets are never exposed.",
+		Description: "List all configured webhooks. Returns an array of webhook objects (id, name, url, is_active, topics, template, template_body, last_status, last_trigger, timestamps). Secrets are never exposed.",
 		InputSchema: map[string]any{
 			"type":       "object",
 			"properties": map[string]any{},
@@ -29,10 +30,13 @@ func (a *Adapter) registerWebhookTools(reg *Registry) {
 			"type":     "object",
 			"required": []string{"name", "url"},
 			"properties": map[string]any{
-				"name":      map[string]any{"type": "string", "description": "Webhook display name"},
-				"url":       map[string]any{"type": "string", "format": "uri", "description": "Endpoint URL that will receive POST requests"},
-				"secret":    map[string]any{"type": "string", "description": "Optional HMAC signing secret for request verification"},
-				"is_active": map[string]any{"type": "boolean", "description": "Enable or disable the webhook", "default": true},
+				"name":          map[string]any{"type": "string", "description": "Webhook display name"},
+				"url":           map[string]any{"type": "string", "format": "uri", "description": "Endpoint URL that will receive POST requests"},
+				"secret":        map[string]any{"type": "string", "description": "Optional HMAC signing secret for request verification"},
+				"is_active":     map[string]any{"type": "boolean", "description": "Enable or disable the webhook", "default": true},
+				"topics":        webhookTopicsSchema,
+				"template":      webhookTemplateSchema,
+				"template_body": webhookTemplateBodySchema,
 			},
 		},
 	}, a.createWebhook, authModel.ScopeAdminSettings)
@@ -45,11 +49,14 @@ func (a *Adapter) registerWebhookTools(reg *Registry) {
 			"type":     "object",
 			"required": []string{"id", "name", "url"},
 			"properties": map[string]any{
-				"id":        map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
-				"name":      map[string]any{"type": "string", "description": "Webhook display name"},
-				"url":       map[string]any{"type": "string", "format": "uri", "description": "Endpoint URL"},
-				"secret":    map[string]any{"type": "string", "description": "HMAC signing secret (leave empty to clear)"},
-				"is_active": map[string]any{"type": "boolean", "description": "Enable or disable the webhook"},
+				"id":            map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
+				"name":          map[string]any{"type": "string", "description": "Webhook display name"},
+				"url":           map[string]any{"type": "string", "format": "uri", "description": "Endpoint URL"},
+				"secret":        map[string]any{"type": "string", "description": "HMAC signing secret (leave empty to clear)"},
+				"is_active":     map[string]any{"type": "boolean", "description": "Enable or disable the webhook"},
+				"topics":        webhookTopicsSchema,
+				"template":      webhookTemplateSchema,
+				"template_body": webhookTemplateBodySchema,
 			},
 		},
 	}, a.updateWebhook, authModel.ScopeAdminSettings)
@@ -79,8 +86,94 @@ func (a *Adapter) registerWebhookTools(reg *Registry) {
 			},
 		},
 	}, a.testWebhook, authModel.ScopeAdminSettings)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "list_webhook_deliveries",
+		Title:       "List Webhook Deliveries",
+		Description: "List recent delivery attempts of a webhook, newest first. Each attempt has id, event_id, topic, attempt, success, status_code, error, latency_ms, request_body, response_body and redelivery_of. Retries of one event share the event_id.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]any{
+				"id":    map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
+				"limit": map[string]any{"type": "integer", "description": "Max attempts to return", "default": 20, "minimum": 1, "maximum": 100},
+			},
+		},
+	}, a.listWebhookDeliveries, authModel.ScopeAdminSettings)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "redeliver_webhook",
+		Title:       "Redeliver Webhook",
+		Description: "Send the event of a past delivery again, rendered with the webhook's current URL, template and secret. Returns the last attempt of the new delivery; check its success field, since a failing endpoint is not reported as a tool error.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id", "delivery_id"},
+			"properties": map[string]any{
+				"id":          map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
+				"delivery_id": map[string]any{"type": "string", "format": "uuid", "description": "Delivery UUID from list_webhook_deliveries"},
+			},
+		},
+	}, a.redeliverWebhook, authModel.ScopeAdminSettings)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "list_webhook_dead_letters",
+		Title:       "List Webhook Dead Letters",
+		Description: "List events that a webhook failed to receive after all retries, newest first. Each has id, event_id, topic, attempts, last_error and updated_at (when it was dead-lettered).",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]any{
+				"id":    map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
+				"limit": map[string]any{"type": "integer", "description": "Max dead letters to return", "default": 20, "minimum": 1, "maximum": 100},
+			},
+		},
+	}, a.listWebhookDeadLetters, authModel.ScopeAdminSettings)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "retry_webhook_dead_letter",
+		Title:       "Retry Webhook Dead Letter",
+		Description: "Put a dead letter back on the delivery queue with a fresh retry budget. Delivery happens in the background within a few seconds; check list_webhook_deliveries for the result.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id", "dead_letter_id"},
+			"properties": map[string]any{
+				"id":             map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
+				"dead_letter_id": map[string]any{"type": "string", "format": "uuid", "description": "Dead letter UUID from list_webhook_dead_letters"},
+			},
+		},
+	}, a.retryWebhookDeadLetter, authModel.ScopeAdminSettings)
+
+	reg.RegisterTool(ToolDefinition{
+		Name:        "purge_webhook_dead_letters",
+		Title:       "Purge Webhook Dead Letters",
+		Description: "Delete all dead letters of a webhook. Returns {id, purged}. This action cannot be undone.",
+		InputSchema: map[string]any{
+			"type":     "object",
+			"required": []string{"id"},
+			"properties": map[string]any{
+				"id": map[string]any{"type": "string", "format": "uuid", "description": "Webhook UUID"},
+			},
+		},
+	}, a.purgeWebhookDeadLetters, authModel.ScopeAdminSettings)
 }
 
+var (
+	webhookTopicsSchema = map[string]any{
+		"type":        "array",
+		"items":       map[string]any{"type": "string", "enum": webhook.Topics},
+		"description": "Event topics to receive; empty means all events",
+	}
+	webhookTemplateSchema = map[string]any{
+		"type":        "string",
+		"enum":        []string{"", "slack", "discord", "feishu", "custom"},
+		"description": "Payload template: empty for the Ech0 JSON envelope, a chat preset, or custom",
+	}
+	webhookTemplateBodySchema = map[string]any{
+		"type":        "string",
+		"description": "Go text/template rendering a JSON body; required when template is custom. Fields: .Topic .EventName .Payload .Metadata .OccurredAt .Summary; use {{ json .X }} to emit JSON values",
+	}
+)
+
 // --- Tool handlers ---
 
 func (a *Adapter) listWebhooks(ctx context.Context, _ map[string]any) (*ToolCallResult, error) {
@@ -101,10 +194,13 @@ func (a *Adapter) createWebhook(ctx context.Context, args map[string]any) (*Tool
 		return textError("url is required"), nil
 	}
 	dto := &settingModel.WebhookDto{
-		Name:     name,
-		URL:      url,
-		Secret:   stringArg(args, "secret"),
-		IsActive: true,
+		Name:         name,
+		URL:          url,
+		Secret:       stringArg(args, "secret"),
+		IsActive:     true,
+		Topics:       stringSliceArg(args, "topics"),
+		Template:     stringArg(args, "template"),
+		TemplateBody: stringArg(args, "template_body"),
 	}
 	if v, ok := args["is_active"]; ok {
 		if b, ok := v.(bool); ok {
@@ -131,10 +227,13 @@ func (a *Adapter) updateWebhook(ctx context.Context, args map[string]any) (*Tool
 		return textError("url is required"), nil
 	}
 	dto := &settingModel.WebhookDto{
-		Name:     name,
-		URL:      url,
-		Secret:   stringArg(args, "secret"),
-		IsActive: boolArg(args, "is_active"),
+		Name:         name,
+		URL:          url,
+		Secret:       stringArg(args, "secret"),
+		IsActive:     boolArg(args, "is_active"),
+		Topics:       stringSliceArg(args, "topics"),
+		Template:     stringArg(args, "template"),
+		TemplateBody: stringArg(args, "template_body"),
 	}
 	if err := a.settingSvc.UpdateWebhook(ctx, id, dto); err != nil {
 		return nil, err
@@ -163,3 +262,70 @@ func (a *Adapter) testWebhook(ctx context.Context, args map[string]any) (*ToolCa
 	}
 	return jsonResult(map[string]string{"id": id, "message": "webhook test dispatched"})
 }
+
+func (a *Adapter) listWebhookDeliveries(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	deliveries, err := a.settingSvc.ListWebhookDeliveries(ctx, id, intArg(args, "limit", 20))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(deliveries)
+}
+
+func (a *Adapter) redeliverWebhook(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	deliveryID := stringArg(args, "delivery_id")
+	if deliveryID == "" {
+		return textError("delivery_id is required"), nil
+	}
+	delivery, err := a.settingSvc.RedeliverWebhook(ctx, id, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(delivery)
+}
+
+func (a *Adapter) listWebhookDeadLetters(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	letters, err := a.settingSvc.ListWebhookDeadLetters(ctx, id, intArg(args, "limit", 20))
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(letters)
+}
+
+func (a *Adapter) retryWebhookDeadLetter(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	deadLetterID := stringArg(args, "dead_letter_id")
+	if deadLetterID == "" {
+		return textError("dead_letter_id is required"), nil
+	}
+	if err := a.settingSvc.RetryWebhookDeadLetter(ctx, id, deadLetterID); err != nil {
+		return nil, err
+	}
+	return jsonResult(map[string]string{"id": deadLetterID, "message": "dead letter requeued"})
+}
+
+func (a *Adapter) purgeWebhookDeadLetters(ctx context.Context, args map[string]any) (*ToolCallResult, error) {
+	id := stringArg(args, "id")
+	if id == "" {
+		return textError("id is required"), nil
+	}
+	purged, err := a.settingSvc.PurgeWebhookDeadLetters(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResult(map[string]any{"id": id, "purged": purged})
+}
@@ -12,6 +12,7 @@ import (
 	dashboardService "github.com/lin-snow/ech0/internal/service/dashboard"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
 )
@@ -30,9 +31,10 @@ func NewHandler(
 	agentSvc copilotService.SummaryService,
 	settingSvc settingService.Service,
 	dashboardSvc dashboardService.Service,
+	searchSvc searchService.Service,
 ) *Handler {
 	registry := NewRegistry()
-	adapter := NewAdapter(echoSvc, userSvc, commentSvc, fileSvc, commonSvc, connectSvc, agentSvc, settingSvc, dashboardSvc)
+	adapter := NewAdapter(echoSvc, userSvc, commentSvc, fileSvc, commonSvc, connectSvc, agentSvc, settingSvc, dashboardSvc, searchSvc)
 	adapter.RegisterAll(registry)
 	return &Handler{server: NewServer(registry)}
 }
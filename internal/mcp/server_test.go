@@ -459,7 +459,7 @@ func TestGetMethodNotAllowed(t *testing.T) {
 // security-sensitive decision this test guards (REST gates it behind admin too).
 func TestAdapterRegistersDiscoveryCapabilities(t *testing.T) {
 	reg := NewRegistry()
-	NewAdapter(nil, nil, nil, nil, nil, nil, nil, nil, nil).RegisterAll(reg)
+	NewAdapter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil).RegisterAll(reg)
 
 	for _, name := range []string{"get_hot_posts", "get_random_post", "get_on_this_day_posts"} {
 		_, scopes, ok := reg.LookupTool(name)
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package metrics
+
+import (
+	"database/sql"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// 队列深度与连接池状态都是「抓取时现读」的瞬时值，用自定义 Collector 在 Collect 时回调，
+// 而不是让各处定时 Set 一个 Gauge。
+
+// QueueStats 返回某个队列当前的积压数与容量。
+type QueueStats func() (length, capacity int)
+
+var (
+	queueLenDesc = prometheus.NewDesc(namespace+"_queue_length",
+		"Pending items in an in-process work queue.", []string{"queue"}, nil)
+	queueCapDesc = prometheus.NewDesc(namespace+"_queue_capacity",
+		"Capacity of an in-process work queue.", []string{"queue"}, nil)
+)
+
+type queueCollector struct {
+	mu     sync.RWMutex
+	queues map[string]QueueStats
+}
+
+var queues = &queueCollector{queues: make(map[string]QueueStats)}
+
+// TrackQueue 登记一个按名字区分的队列。同名重复登记以最后一次为准（如 Dispatcher 重建）。
+func TrackQueue(name string, stats QueueStats) {
+	queues.mu.Lock()
+	defer queues.mu.Unlock()
+	queues.queues[name] = stats
+}
+
+func (c *queueCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- queueLenDesc
+	ch <- queueCapDesc
+}
+
+func (c *queueCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for name, stats := range c.queues {
+		length, capacity := stats()
+		ch <- prometheus.MustNewConstMetric(queueLenDesc, prometheus.GaugeValue, float64(length), name)
+		ch <- prometheus.MustNewConstMetric(queueCapDesc, prometheus.GaugeValue, float64(capacity), name)
+	}
+}
+
+var (
+	sqlOpenDesc = prometheus.NewDesc(namespace+"_db_open_connections",
+		"Established database connections, in use and idle.", nil, nil)
+	sqlInUseDesc = prometheus.NewDesc(namespace+"_db_in_use_connections",
+		"Database connections currently in use.", nil, nil)
+	sqlIdleDesc = prometheus.NewDesc(namespace+"_db_idle_connections",
+		"Idle database connections.", nil, nil)
+	sqlWaitCountDesc = prometheus.NewDesc(namespace+"_db_wait_count_total",
+		"Total number of connections waited for.", nil, nil)
+	sqlWaitDurationDesc = prometheus.NewDesc(namespace+"_db_wait_duration_seconds_total",
+		"Total time blocked waiting for a new connection.", nil, nil)
+)
+
+// sqlPoolCollector 每次抓取时取当前 *sql.DB：恢复快照会热切换数据库，不能在启动时固定一个连接池。
+type sqlPoolCollector struct {
+	mu     sync.RWMutex
+	source func() *sql.DB
+}
+
+var sqlPool = &sqlPoolCollector{}
+
+// TrackSQLPool 登记数据库连接池的来源；source 返回 nil 时本次抓取不输出连接池指标。
+func TrackSQLPool(source func() *sql.DB) {
+	sqlPool.mu.Lock()
+	defer sqlPool.mu.Unlock()
+	sqlPool.source = source
+}
+
+func (c *sqlPoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sqlOpenDesc
+	ch <- sqlInUseDesc
+	ch <- sqlIdleDesc
+	ch <- sqlWaitCountDesc
+	ch <- sqlWaitDurationDesc
+}
+
+func (c *sqlPoolCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	source := c.source
+	c.mu.RUnlock()
+	if source == nil {
+		return
+	}
+	db := source()
+	if db == nil {
+		return
+	}
+	s := db.Stats()
+	ch <- prometheus.MustNewConstMetric(sqlOpenDesc, prometheus.GaugeValue, float64(s.OpenConnections))
+	ch <- prometheus.MustNewConstMetric(sqlInUseDesc, prometheus.GaugeValue, float64(s.InUse))
+	ch <- prometheus.MustNewConstMetric(sqlIdleDesc, prometheus.GaugeValue, float64(s.Idle))
+	ch <- prometheus.MustNewConstMetric(sqlWaitCountDesc, prometheus.CounterValue, float64(s.WaitCount))
+	ch <- prometheus.MustNewConstMetric(sqlWaitDurationDesc, prometheus.CounterValue, s.WaitDuration.Seconds())
+}
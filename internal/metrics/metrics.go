@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package metrics 汇总进程内的 Prometheus 指标，经 /metrics 以 OpenMetrics 格式暴露。
+//
+// 指标挂在包级独立 registry 上（不用 prometheus.DefaultRegisterer，避免第三方库的全局指标混入）；
+// 各领域只调用这里的 Observe/Inc 函数，不直接依赖 client_golang。未开启 /metrics 时记录照常进行，
+// 只是没有人来抓取，开销仅是几次原子加。
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "ech0"
+
+var registry = prometheus.NewRegistry()
+
+var (
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "HTTP request latency by route group, method and status class.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"group", "method", "code"})
+
+	eventPublished = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event",
+		Name:      "published_total",
+		Help:      "Events published on the in-process bus, by topic.",
+	}, []string{"topic", "result"})
+	eventDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event",
+		Name:      "dropped_total",
+		Help:      "Events dropped by a full async subscriber queue, by topic.",
+	}, []string{"topic"})
+	eventRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event",
+		Name:      "rejected_total",
+		Help:      "Events rejected by a fail-fast subscriber queue, by topic.",
+	}, []string{"topic"})
+
+	webhookDeliveries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "webhook",
+		Name:      "deliveries_total",
+		Help:      "Webhook outbox delivery attempts by outcome (success, retry, dead).",
+	}, []string{"outcome"})
+
+	jobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "job",
+		Name:      "duration_seconds",
+		Help:      "Background job run time by type and terminal status.",
+		Buckets:   []float64{0.1, 0.5, 1, 5, 15, 60, 300, 900, 3600},
+	}, []string{"type", "status"})
+
+	cacheLookups = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "lookups_total",
+		Help:      "In-memory cache lookups by result (hit, miss).",
+	}, []string{"result"})
+
+	aiDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "ai",
+		Name:      "request_duration_seconds",
+		Help:      "Embedding and LLM call latency by kind, provider and result.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 120},
+	}, []string{"kind", "provider", "result"})
+	aiTokens = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ai",
+		Name:      "tokens_total",
+		Help:      "Tokens reported by embedding and LLM providers, by kind, provider and direction.",
+	}, []string{"kind", "provider", "direction"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpDuration,
+		eventPublished,
+		eventDropped,
+		eventRejected,
+		webhookDeliveries,
+		jobDuration,
+		cacheLookups,
+		aiDuration,
+		aiTokens,
+		queues,
+		sqlPool,
+	)
+}
+
+// Handler 返回 /metrics 的抓取处理器；客户端协商支持时输出 OpenMetrics 文本格式。
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{
+		EnableOpenMetrics: true,
+		ErrorHandling:     promhttp.ContinueOnError,
+	})
+}
+
+// ObserveHTTP 记录一次 HTTP 请求。code 按状态码分段（2xx/4xx…），避免逐个状态码拆出时间序列。
+func ObserveHTTP(group, method string, status int, d time.Duration) {
+	httpDuration.WithLabelValues(group, method, statusClass(status)).Observe(d.Seconds())
+}
+
+// IncEventPublished 记录一次事件发布，failed 表示有订阅者处理出错。
+func IncEventPublished(topic string, failed bool) {
+	result := "ok"
+	if failed {
+		result = "error"
+	}
+	eventPublished.WithLabelValues(topic, result).Inc()
+}
+
+// IncEventDropped 记录一次因异步队列溢出而丢弃的事件。
+func IncEventDropped(topic string) {
+	eventDropped.WithLabelValues(topic).Inc()
+}
+
+// IncEventRejected 记录一次被 fail_fast 队列拒绝的事件。
+func IncEventRejected(topic string) {
+	eventRejected.WithLabelValues(topic).Inc()
+}
+
+// Webhook 投递结果。
+const (
+	WebhookSuccess = "success"
+	WebhookRetry   = "retry"
+	WebhookDead    = "dead"
+)
+
+// IncWebhookDelivery 记录一次 outbox 投递尝试的结果。
+func IncWebhookDelivery(outcome string) {
+	webhookDeliveries.WithLabelValues(outcome).Inc()
+}
+
+// ObserveJob 记录一次作业运行的时长与终态。
+func ObserveJob(jobType, status string, d time.Duration) {
+	jobDuration.WithLabelValues(jobType, status).Observe(d.Seconds())
+}
+
+// IncCacheLookup 记录一次缓存查找是否命中；命中率即 hit / (hit + miss)。
+func IncCacheLookup(hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(result).Inc()
+}
+
+// AI 调用类别。
+const (
+	KindEmbedding = "embedding"
+	KindLLM       = "llm"
+)
+
+// ObserveAI 记录一次 embedding / LLM 调用的耗时，err 非空时按失败计。
+func ObserveAI(kind, provider string, d time.Duration, err error) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	aiDuration.WithLabelValues(kind, provider, result).Observe(d.Seconds())
+}
+
+// AddAITokens 累加提供方回报的 token 用量；提供方未回报（0）时不记。
+func AddAITokens(kind, provider string, input, output int) {
+	if input > 0 {
+		aiTokens.WithLabelValues(kind, provider, "input").Add(float64(input))
+	}
+	if output > 0 {
+		aiTokens.WithLabelValues(kind, provider, "output").Add(float64(output))
+	}
+}
+
+func statusClass(status int) string {
+	switch {
+	case status >= 500:
+		return "5xx"
+	case status >= 400:
+		return "4xx"
+	case status >= 300:
+		return "3xx"
+	case status >= 200:
+		return "2xx"
+	default:
+		return "1xx"
+	}
+}
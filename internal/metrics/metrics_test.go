@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func scrape(t *testing.T) (contentType, body string) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("scrape status = %d", rec.Code)
+	}
+	raw, _ := io.ReadAll(rec.Body)
+	return rec.Header().Get("Content-Type"), string(raw)
+}
+
+func TestHandlerServesOpenMetrics(t *testing.T) {
+	ObserveHTTP("echo", http.MethodGet, http.StatusNotFound, 20*time.Millisecond)
+	IncEventPublished("event.EchoCreated", false)
+	IncWebhookDelivery(WebhookRetry)
+	ObserveJob("reindex", "success", time.Second)
+	IncCacheLookup(true)
+	ObserveAI(KindLLM, "openai", time.Second, errors.New("boom"))
+	AddAITokens(KindEmbedding, "openai", 12, 0)
+
+	ct, body := scrape(t)
+	if !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Errorf("content type = %q, want openmetrics", ct)
+	}
+	for _, want := range []string{
+		`ech0_http_request_duration_seconds_count{code="4xx",group="echo",method="GET"} 1`,
+		`ech0_event_published_total{result="ok",topic="event.EchoCreated"} 1`,
+		`ech0_webhook_deliveries_total{outcome="retry"} 1`,
+		`ech0_job_duration_seconds_count{status="success",type="reindex"} 1`,
+		`ech0_cache_lookups_total{result="hit"} 1`,
+		`ech0_ai_request_duration_seconds_count{kind="llm",provider="openai",result="error"} 1`,
+		`ech0_ai_tokens_total{direction="input",kind="embedding",provider="openai"} 12`,
+		"# EOF",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape missing %q", want)
+		}
+	}
+	if strings.Contains(body, `direction="output",kind="embedding"`) {
+		t.Errorf("zero token counts should not create a series")
+	}
+}
+
+func TestQueueAndSQLPoolCollectors(t *testing.T) {
+	TrackQueue("test", func() (int, int) { return 0, 8 })
+	TrackQueue("test", func() (int, int) { return 3, 8 })
+	TrackSQLPool(func() *sql.DB { return nil })
+
+	_, body := scrape(t)
+	if !strings.Contains(body, `ech0_queue_length{queue="test"} 3`) {
+		t.Errorf("queue length should come from the latest registration")
+	}
+	if !strings.Contains(body, `ech0_queue_capacity{queue="test"} 8`) {
+		t.Errorf("queue capacity missing")
+	}
+	if strings.Contains(body, "ech0_db_open_connections") {
+		t.Errorf("nil db should not emit pool stats")
+	}
+}
+
+func TestStatusClass(t *testing.T) {
+	for status, want := range map[int]string{101: "1xx", 204: "2xx", 302: "3xx", 429: "4xx", 503: "5xx"} {
+		if got := statusClass(status); got != want {
+			t.Errorf("statusClass(%d) = %q, want %q", status, got, want)
+		}
+	}
+}
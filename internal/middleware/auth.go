@@ -98,6 +98,25 @@ func writeRejection(ctx *gin.Context, rej *rejection) {
 	ctx.Abort()
 }
 
+// FormAccessToken 把表单字段 access_token 提升为 Authorization 头，须挂在 RequireAuth 之前。
+// 仅用于 Micropub 这类规范允许在请求体中携带 token 的端点；已带 Authorization 头时不做任何事。
+// 只认 x-www-form-urlencoded：multipart 上传要先把整个请求体解析（大文件落临时盘）才能读到
+// 这个字段，鉴权前做这件事等于让匿名请求白白消耗磁盘，上传须改用 Authorization 头。
+func FormAccessToken() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.GetHeader("Authorization") != "" {
+			ctx.Next()
+			return
+		}
+		if ctx.ContentType() == "application/x-www-form-urlencoded" {
+			if token := strings.TrimSpace(ctx.PostForm("access_token")); token != "" {
+				ctx.Request.Header.Set("Authorization", "Bearer "+token)
+			}
+		}
+		ctx.Next()
+	}
+}
+
 // RequireAuth 强制鉴权中间件：token 缺失 / 无效 / 已吊销一律拒绝。用于所有需要登录身份的路由。
 func RequireAuth(tokenBlacklist authService.TokenRevoker) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
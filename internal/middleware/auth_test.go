@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -188,3 +189,36 @@ func parseAuthErrorCode(body []byte) string {
 	_ = json.Unmarshal(body, &payload)
 	return payload.ErrorCode
 }
+
+func TestFormAccessToken_LiftsFormFieldIntoHeader(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Use(FormAccessToken())
+	r.POST("/micropub", func(c *gin.Context) {
+		c.String(http.StatusOK, c.GetHeader("Authorization"))
+	})
+
+	cases := []struct {
+		name        string
+		contentType string
+		header      string
+		want        string
+	}{
+		{name: "form body", contentType: "application/x-www-form-urlencoded", want: "Bearer abc"},
+		{name: "header wins", contentType: "application/x-www-form-urlencoded", header: "Bearer xyz", want: "Bearer xyz"},
+		{name: "json body ignored", contentType: "application/json", want: ""},
+		{name: "multipart body ignored", contentType: "multipart/form-data; boundary=x", want: ""},
+	}
+	for _, tc := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/micropub", strings.NewReader("h=entry&access_token=abc"))
+		req.Header.Set("Content-Type", tc.contentType)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		if got := rec.Body.String(); got != tc.want {
+			t.Fatalf("%s: expected Authorization %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package middleware
+
+import (
+	"crypto/subtle"
+	"log/slog"
+	"net/http"
+	"net/netip"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/metrics"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// HTTPMetrics 按路由分组记录请求耗时。分组取自匹配到的路由模板而不是原始 path，
+// 保证时间序列数量只随路由数增长，不随 Echo ID、文件名等变化。
+func HTTPMetrics() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		metrics.ObserveHTTP(routeGroup(c.FullPath(), c.Request.URL.Path), c.Request.Method, c.Writer.Status(), time.Since(start))
+	}
+}
+
+// routeGroup 把路由模板归到分组：/api/echo/:id → echo，/rss → rss；
+// 没有匹配路由的请求落到 NoRoute（前端页面），/api 下的记作 unmatched。
+func routeGroup(fullPath, path string) string {
+	if fullPath == "" {
+		if path == "/api" || strings.HasPrefix(path, "/api/") {
+			return "unmatched"
+		}
+		return "web"
+	}
+	segs := strings.Split(strings.Trim(fullPath, "/"), "/")
+	if segs[0] == "api" && len(segs) > 1 {
+		return segs[1]
+	}
+	if segs[0] == "" {
+		return "root"
+	}
+	return segs[0]
+}
+
+// MetricsAccess 保护 /metrics：携带正确的 Bearer token，或对端地址在白名单内，任一满足即放行；
+// 两者都未配置时只允许本机抓取。白名单按 TCP 对端地址判断，不信任 X-Forwarded-For。
+func MetricsAccess(cfg config.MetricsConfig) gin.HandlerFunc {
+	token := strings.TrimSpace(cfg.Token)
+	allowed := parsePrefixes(cfg.AllowIPs)
+	loopbackOnly := token == "" && len(allowed) == 0
+
+	return func(c *gin.Context) {
+		if token != "" && bearerMatches(c.GetHeader("Authorization"), token) {
+			c.Next()
+			return
+		}
+		if addr, err := netip.ParseAddr(c.RemoteIP()); err == nil {
+			addr = addr.Unmap()
+			if loopbackOnly && addr.IsLoopback() {
+				c.Next()
+				return
+			}
+			for _, p := range allowed {
+				if p.Contains(addr) {
+					c.Next()
+					return
+				}
+			}
+		}
+		if token != "" {
+			c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "metrics token required"})
+		} else {
+			c.JSON(http.StatusForbidden, gin.H{"error": "metrics access denied"})
+		}
+		c.Abort()
+	}
+}
+
+func bearerMatches(header, token string) bool {
+	got, ok := strings.CutPrefix(header, "Bearer ")
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(strings.TrimSpace(got)), []byte(token)) == 1
+}
+
+// parsePrefixes 解析白名单，单个 IP 视为 /32（/128）；无效项记日志后跳过。
+func parsePrefixes(entries []string) []netip.Prefix {
+	var out []netip.Prefix
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if p, err := netip.ParsePrefix(e); err == nil {
+			out = append(out, p.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(e); err == nil {
+			addr = addr.Unmap()
+			out = append(out, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		logUtil.GetLogger().Warn("ignore invalid metrics allowlist entry", slog.String("entry", e))
+	}
+	return out
+}
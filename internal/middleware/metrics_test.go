@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
+)
+
+func serveMetrics(t *testing.T, cfg config.MetricsConfig, remoteAddr, auth string) int {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/metrics", MetricsAccess(cfg), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.RemoteAddr = remoteAddr
+	if auth != "" {
+		req.Header.Set("Authorization", auth)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestMetricsAccessLoopbackOnlyByDefault(t *testing.T) {
+	cfg := config.MetricsConfig{Enabled: true}
+	if code := serveMetrics(t, cfg, "127.0.0.1:4000", ""); code != http.StatusOK {
+		t.Errorf("loopback: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := serveMetrics(t, cfg, "[::1]:4000", ""); code != http.StatusOK {
+		t.Errorf("ipv6 loopback: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := serveMetrics(t, cfg, "203.0.113.7:4000", ""); code != http.StatusForbidden {
+		t.Errorf("remote: status = %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestMetricsAccessToken(t *testing.T) {
+	cfg := config.MetricsConfig{Enabled: true, Token: "s3cret"}
+	if code := serveMetrics(t, cfg, "203.0.113.7:4000", "Bearer s3cret"); code != http.StatusOK {
+		t.Errorf("valid token: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := serveMetrics(t, cfg, "203.0.113.7:4000", "Bearer wrong"); code != http.StatusUnauthorized {
+		t.Errorf("wrong token: status = %d, want %d", code, http.StatusUnauthorized)
+	}
+	// 配了 token 后本机也不再默认放行。
+	if code := serveMetrics(t, cfg, "127.0.0.1:4000", ""); code != http.StatusUnauthorized {
+		t.Errorf("loopback without token: status = %d, want %d", code, http.StatusUnauthorized)
+	}
+}
+
+func TestMetricsAccessAllowlist(t *testing.T) {
+	cfg := config.MetricsConfig{Enabled: true, AllowIPs: []string{"10.0.0.0/8", "192.0.2.5", "not-an-ip"}}
+	if code := serveMetrics(t, cfg, "10.1.2.3:4000", ""); code != http.StatusOK {
+		t.Errorf("cidr match: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := serveMetrics(t, cfg, "192.0.2.5:4000", ""); code != http.StatusOK {
+		t.Errorf("single ip match: status = %d, want %d", code, http.StatusOK)
+	}
+	if code := serveMetrics(t, cfg, "192.0.2.6:4000", ""); code != http.StatusForbidden {
+		t.Errorf("outside allowlist: status = %d, want %d", code, http.StatusForbidden)
+	}
+}
+
+func TestMetricsAccessIgnoresForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.GET("/metrics", MetricsAccess(config.MetricsConfig{Enabled: true, AllowIPs: []string{"10.0.0.1"}}),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	req.RemoteAddr = "203.0.113.7:4000"
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("spoofed forwarded-for: status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
+
+func TestRouteGroup(t *testing.T) {
+	cases := []struct {
+		fullPath, path, want string
+	}{
+		{"/api/echo/:id", "/api/echo/42", "echo"},
+		{"/api/files/*filepath", "/api/files/a.png", "files"},
+		{"/rss", "/rss", "rss"},
+		{"/", "/", "root"},
+		{"", "/api/nope", "unmatched"},
+		{"", "/echo/42", "web"},
+	}
+	for _, c := range cases {
+		if got := routeGroup(c.fullPath, c.path); got != c.want {
+			t.Errorf("routeGroup(%q, %q) = %q, want %q", c.fullPath, c.path, got, c.want)
+		}
+	}
+}
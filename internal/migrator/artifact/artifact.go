@@ -24,7 +24,7 @@ var ErrNone = errors.New("artifact: no artifact available")
 // 即最新一份，无需 stat 每个文件。
 const timeLayout = "2006-01-02_15-04-05"
 
-// 产物目录布局。三者都在 data/ 下，且都**必须**被排除在快照之外——快照是「data/ 的 zip」，
+// 产物目录布局。四者都在 data/ 下，且都**必须**被排除在快照之外——快照是「data/ 的 zip」，
 // 把派生产物打进去会让快照套娃式膨胀。新增产物目录时改这里，Excluded 会自动带上，
 // 不会漏掉排除这一步。
 const (
@@ -32,6 +32,9 @@ const (
 	SnapshotDir = "files/snapshots"
 	CapsuleDir  = "files/capsules"
 	TmpDir      = "files/tmp"
+	// ChainDir 存放增量快照链（基线 + 增量归档）。它不走 Slot：链上的归档彼此依赖，
+	// 「只保留最新一份」会把基线删掉，留存由 snapshot 包按整条链裁剪。
+	ChainDir = "files/snapshot-chain"
 )
 
 // Snapshots 是快照产物槽位（整个 data/ 的 zip，含账号与凭据）。
@@ -47,7 +50,7 @@ func Capsules() Slot {
 
 // Excluded 返回不进快照的子树（相对 data/）。
 func Excluded() []string {
-	return []string{SnapshotDir, CapsuleDir, TmpDir}
+	return []string{SnapshotDir, CapsuleDir, TmpDir, ChainDir}
 }
 
 // Slot 是一个产物目录加文件名前缀。零值不可用，用 NewSlot 构造。
@@ -116,3 +119,31 @@ func (s Slot) KeepOnly(keep string) error {
 	}
 	return nil
 }
+
+// Discard 删除一趟中断的导出留下的残余：from 之后开始、before 之前就不再写入的隐藏临时文件 /
+// 暂存目录，以及文件名时刻不早于 from 的产物（可能只写了一半）。from 之前完成的产物与
+// before 之后仍在写的文件原样保留——后者属于正在进行的另一趟导出。
+func (s Slot) Discard(from, before time.Time) error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("read artifact dir: %w", err)
+	}
+	cutoff := s.Name(from)
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, ".") && (!strings.HasPrefix(name, s.prefix+"_") || name < cutoff) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.ModTime().Before(from) || !info.ModTime().Before(before) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(s.dir, name)); err != nil {
+			return fmt.Errorf("discard partial artifact %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package artifact
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSlotDiscard 校验：只清掉中断那趟留下的临时文件、暂存目录与半截产物，
+// 之前完成的产物与仍在写入的文件保留。
+func TestSlotDiscard(t *testing.T) {
+	slot := NewSlot(t.TempDir(), "ech0_capsule")
+	from := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	before := from.Add(time.Hour)
+
+	touch := func(name string, mod time.Time) {
+		path := slot.Path(name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+		require.NoError(t, os.Chtimes(path, mod, mod))
+	}
+	done := slot.Name(from.Add(-time.Hour))
+	partial := slot.Name(from.Add(time.Minute))
+	touch(done, from.Add(-time.Hour))
+	touch(partial, from.Add(2*time.Minute))
+	touch("."+partial+".tmp", from.Add(2*time.Minute))
+	touch(".ech0-capsule-123/records.json", from.Add(2*time.Minute))
+	require.NoError(t, os.Chtimes(slot.Path(".ech0-capsule-123"), from.Add(2*time.Minute), from.Add(2*time.Minute)))
+	live := slot.Name(before.Add(time.Minute))
+	touch("."+live+".tmp", before.Add(time.Minute))
+
+	require.NoError(t, slot.Discard(from, before))
+
+	entries, err := os.ReadDir(slot.Dir())
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{done, "." + live + ".tmp"}, names)
+
+	require.NoError(t, NewSlot(filepath.Join(t.TempDir(), "missing"), "x").Discard(from, before))
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"strings"
 
+	"github.com/lin-snow/ech0/internal/database"
+	"github.com/lin-snow/ech0/internal/migrator/snapshot"
 	"github.com/lin-snow/ech0/internal/migrator/spec"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 )
@@ -41,10 +43,8 @@ func (ex *ExportEngine) Export(
 	report func(phase string, snapshot any),
 ) (ExportOutcome, error) {
 	dest := migratorModel.ExportDestFS
-	if ex.storageManager != nil {
-		if sel := ex.storageManager.GetSelector(); sel != nil && sel.ObjectEnabled() {
-			dest = migratorModel.ExportDestS3
-		}
+	if ex.ObjectEnabled() {
+		dest = migratorModel.ExportDestS3
 	}
 
 	exporter, err := BuildExporter(dest, ex.storageManager)
@@ -70,3 +70,43 @@ func (ex *ExportEngine) Export(
 		Format:       migratorModel.ExportFormatSnapshot,
 	}, nil
 }
+
+// ObjectEnabled 报告当前是否配置了对象存储（决定导出产物是否需要上传）。
+func (ex *ExportEngine) ObjectEnabled() bool {
+	if ex.storageManager == nil {
+		return false
+	}
+	sel := ex.storageManager.GetSelector()
+	return sel != nil && sel.ObjectEnabled()
+}
+
+// ExportIncremental 在本地增量快照链上追加一份归档（定时快照与 CLI --incremental 的出口）。
+// 它只落本地、不上传：上传交给 UploadChain，由作业框架跑，失败或中断后可以续传。
+func (ex *ExportEngine) ExportIncremental(
+	_ context.Context,
+	report func(phase string, snapshot any),
+) (snapshot.ChainResult, error) {
+	report(migratorModel.ExportPhasePacking, nil)
+	// 与全量导出一样发生在运行中的实例上，数据库必须取 VACUUM INTO 的一致性副本。
+	result, err := snapshot.CreateIncremental(snapshot.WithConsistentDB(database.SnapshotTo))
+	if err != nil {
+		return snapshot.ChainResult{}, err
+	}
+	report(migratorModel.ExportPhaseCompleted, nil)
+	return result, nil
+}
+
+// UploadChain 把本地增量链同步到对象存储，已上传的归档跳过，故重跑即续传。未配置对象存储时
+// 直接返回零值结果。
+func (ex *ExportEngine) UploadChain(
+	ctx context.Context,
+	report func(phase string, snapshot any),
+) (snapshot.ChainUpload, error) {
+	if !ex.ObjectEnabled() {
+		return snapshot.ChainUpload{}, nil
+	}
+	cfg := ex.storageManager.GetStorageConfig(ctx)
+	return snapshot.UploadChainToS3(ctx, cfg, func(progress snapshot.ChainUpload) {
+		report(migratorModel.ExportPhaseUploading, progress)
+	})
+}
@@ -6,26 +6,46 @@ package migrator
 import (
 	"fmt"
 
+	"github.com/lin-snow/ech0/internal/database"
 	fsExporter "github.com/lin-snow/ech0/internal/migrator/exporter/fs"
 	s3Exporter "github.com/lin-snow/ech0/internal/migrator/exporter/s3"
+	blueskyImporter "github.com/lin-snow/ech0/internal/migrator/importer/bluesky"
 	ech0Importer "github.com/lin-snow/ech0/internal/migrator/importer/ech0"
+	mastodonImporter "github.com/lin-snow/ech0/internal/migrator/importer/mastodon"
 	memosImporter "github.com/lin-snow/ech0/internal/migrator/importer/memos"
+	"github.com/lin-snow/ech0/internal/migrator/importer/microblog"
+	twitterImporter "github.com/lin-snow/ech0/internal/migrator/importer/twitter"
 	"github.com/lin-snow/ech0/internal/migrator/spec"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
 )
 
-// BuildImporter 按来源选导入适配器(ech0 / memos),与 BuildExporter 对称。
-func BuildImporter(source string) (spec.Importer, error) {
+// BuildImporter 按来源选导入适配器(ech0 / memos / 微博客平台),与 BuildExporter 对称。
+// 微博客平台的媒体要写进当前存储后端,需 storageManager 取 selector。
+func BuildImporter(source string, storageManager StorageManager) (spec.Importer, error) {
 	switch source {
 	case migratorModel.MigrationSourceEch0:
 		return ech0Importer.New(), nil
 	case migratorModel.MigrationSourceMemos:
 		return memosImporter.New(), nil
+	case migratorModel.MigrationSourceTwitter:
+		return twitterImporter.New(microblogDeps(storageManager)), nil
+	case migratorModel.MigrationSourceMastodon:
+		return mastodonImporter.New(microblogDeps(storageManager)), nil
+	case migratorModel.MigrationSourceBluesky:
+		return blueskyImporter.New(microblogDeps(storageManager)), nil
 	default:
 		return nil, fmt.Errorf("unsupported import source: %s", source)
 	}
 }
 
+func microblogDeps(storageManager StorageManager) microblog.Deps {
+	deps := microblog.Deps{DB: database.GetDB()}
+	if storageManager != nil {
+		deps.Selector = storageManager.GetSelector()
+	}
+	return deps
+}
+
 // BuildExporter 按目的地选导出适配器(fs / s3),与 BuildImporter 对称。s3 需 storageManager 取配置。
 func BuildExporter(dest string, storageManager StorageManager) (spec.Exporter, error) {
 	switch dest {
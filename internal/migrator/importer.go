@@ -62,7 +62,7 @@ func (im *ImportEngine) Import(
 		}
 	}()
 
-	importer, err := BuildImporter(payload.SourceType)
+	importer, err := BuildImporter(payload.SourceType, im.storageManager)
 	if err != nil {
 		return nil, fmt.Errorf("构建导入器失败: %v", err)
 	}
@@ -76,7 +76,7 @@ func (im *ImportEngine) Import(
 				return
 			}
 			if phase := strings.TrimSpace(progress.CurrentPhase); phase != "" {
-				report(phase, nil)
+				report(phase, progressSnapshot(payload, progress))
 			}
 		},
 	})
@@ -112,6 +112,26 @@ func (im *ImportEngine) Import(
 	return enriched, nil
 }
 
+// progressSnapshot 把计数挂到 source_payload.progress 上交给作业展示，逐条导入的来源
+// (微博客平台)据此显示「已处理 / 总数」。只有阶段没有计数时不替换展示载荷。
+func progressSnapshot(payload migratorModel.MigrationPayload, progress spec.ImportProgress) any {
+	if progress.Total == 0 {
+		return nil
+	}
+	snapshot := payload
+	snapshot.SourcePayload = make(map[string]any, len(payload.SourcePayload)+1)
+	for k, v := range payload.SourcePayload {
+		snapshot.SourcePayload[k] = v
+	}
+	snapshot.SourcePayload["progress"] = map[string]any{
+		"processed":     progress.Processed,
+		"total":         progress.Total,
+		"success_count": progress.SuccessCount,
+		"fail_count":    progress.FailCount,
+	}
+	return snapshot
+}
+
 func (im *ImportEngine) applyMigratedSettings(ctx context.Context, report map[string]any) error {
 	if len(report) == 0 {
 		return nil
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package bluesky
+
+import (
+	"bufio"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// cidLinkTag 是 DAG-CBOR 里 CID 链接的 CBOR tag。
+const cidLinkTag = 42
+
+// maxBlockBytes 限制单个块的大小，防止损坏的长度前缀让我们一次申请巨量内存。
+const maxBlockBytes = 8 << 20
+
+var cidEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+var dagCBOR = func() cbor.DecMode {
+	mode, err := cbor.DecOptions{DefaultMapType: reflect.TypeOf(map[string]any(nil))}.DecMode()
+	if err != nil {
+		panic(err)
+	}
+	return mode
+}()
+
+// block 是 CAR 里的一个块：CID（multibase base32 字符串）+ 解码后的 DAG-CBOR。
+type block struct {
+	CID  string
+	Data map[string]any
+}
+
+// readCAR 顺序读出 CAR v1 的全部块。只认 DAG-CBOR 块，其他编码（如原始字节）跳过。
+func readCAR(r io.Reader) ([]block, error) {
+	br := bufio.NewReader(r)
+	header, err := readSection(br)
+	if err != nil {
+		return nil, fmt.Errorf("read car header: %w", err)
+	}
+	var h struct {
+		Version int `cbor:"version"`
+	}
+	if err := dagCBOR.Unmarshal(header, &h); err != nil || h.Version != 1 {
+		return nil, errors.New("not a CAR v1 file")
+	}
+
+	var blocks []block
+	for {
+		section, err := readSection(br)
+		if errors.Is(err, io.EOF) {
+			return blocks, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read car block: %w", err)
+		}
+		cidLen, err := cidLength(section)
+		if err != nil {
+			return nil, err
+		}
+		var data map[string]any
+		if err := dagCBOR.Unmarshal(section[cidLen:], &data); err != nil {
+			continue
+		}
+		blocks = append(blocks, block{CID: cidString(section[:cidLen]), Data: data})
+	}
+}
+
+// readSection 读一个「uvarint 长度 + 内容」段。
+func readSection(br *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, err
+	}
+	if size == 0 || size > maxBlockBytes {
+		return nil, fmt.Errorf("invalid section length %d", size)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(br, buf); err != nil {
+		return nil, err
+	}
+	return buf, nil
+}
+
+// cidLength 算出段首 CID 的字节数。CIDv0 是裸 sha2-256 multihash（34 字节），
+// CIDv1 是 version、codec 两个 varint 加一个 multihash。
+func cidLength(b []byte) (int, error) {
+	if len(b) >= 34 && b[0] == 0x12 && b[1] == 0x20 {
+		return 34, nil
+	}
+	off := 0
+	for range 3 { // version, codec, multihash code
+		_, n := binary.Uvarint(b[off:])
+		if n <= 0 {
+			return 0, errors.New("malformed cid")
+		}
+		off += n
+	}
+	digestLen, n := binary.Uvarint(b[off:])
+	if n <= 0 || uint64(len(b)-off-n) < digestLen {
+		return 0, errors.New("malformed cid")
+	}
+	return off + n + int(digestLen), nil
+}
+
+// cidString 把二进制 CID 编成 AT Protocol 通用的 multibase base32（"b" 前缀）字符串。
+func cidString(raw []byte) string {
+	return "b" + strings.ToLower(cidEncoding.EncodeToString(raw))
+}
+
+// linkCID 取 DAG-CBOR 链接（tag 42，内容为 0x00 + 二进制 CID）的字符串形式。
+func linkCID(v any) string {
+	tag, ok := v.(cbor.Tag)
+	if !ok || tag.Number != cidLinkTag {
+		return ""
+	}
+	raw, ok := tag.Content.([]byte)
+	if !ok || len(raw) < 2 || raw[0] != 0 {
+		return ""
+	}
+	return cidString(raw[1:])
+}
@@ -0,0 +1,345 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package bluesky 是 Bluesky 仓库导出（设置 → 账户 → 导出我的数据，得到 repo.car）→ Ech0
+// 的导入适配器。
+//
+// repo.car 是 CAR v1：帖子是 $type 为 app.bsky.feed.post 的 DAG-CBOR 记录，帖子的 rkey
+// 藏在 MST 节点里（条目 key 形如 "app.bsky.feed.post/<rkey>"，按前缀压缩）。CAR 不带图片
+// 字节，要导入图片需把 blobs/ 目录（文件名为 blob 的 CID）与 repo.car 一起打成 zip。
+package bluesky
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/microblog"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+const (
+	postCollection = "app.bsky.feed.post"
+	tagFeature     = "app.bsky.richtext.facet#tag"
+	linkFeature    = "app.bsky.richtext.facet#link"
+)
+
+type Importer struct {
+	deps microblog.Deps
+}
+
+func New(deps microblog.Deps) *Importer {
+	return &Importer{deps: deps}
+}
+
+func (e *Importer) Import(ctx context.Context, req spec.ImportRequest) (spec.ImportResult, error) {
+	if req.UpdateProgress != nil {
+		req.UpdateProgress(spec.ImportProgress{CurrentPhase: migratorModel.MigrationPhaseExtracting})
+	}
+	root, err := microblog.SourceRoot(req.SourcePayload)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	parsed, err := Parse(root)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	return microblog.Write(ctx, e.deps, microblog.Request{
+		Platform:       migratorModel.MigrationSourceBluesky,
+		Posts:          parsed.Posts,
+		Failed:         parsed.Failed,
+		CreatedBy:      microblog.CreatedBy(req.SourcePayload),
+		UpdateProgress: req.UpdateProgress,
+	})
+}
+
+// Parsed 是解析结果。
+type Parsed struct {
+	Posts  []microblog.Post
+	Failed []spec.FailedItem
+}
+
+// Parse 在暂存目录（或其一级子目录）里找第一个 .car 文件并解析，blobs/ 与它同级。
+func Parse(root string) (*Parsed, error) {
+	carPath, err := findCAR(root)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(carPath)
+	if err != nil {
+		return nil, fmt.Errorf("bluesky repo: open %s: %w", filepath.Base(carPath), err)
+	}
+	defer func() { _ = f.Close() }()
+	blocks, err := readCAR(f)
+	if err != nil {
+		return nil, fmt.Errorf("bluesky repo: %w", err)
+	}
+
+	did, rkeys := indexRepo(blocks)
+	blobs := indexBlobs(filepath.Join(filepath.Dir(carPath), "blobs"))
+	parsed := &Parsed{}
+	for i := range blocks {
+		b := &blocks[i]
+		if str(b.Data["$type"]) != postCollection {
+			continue
+		}
+		sourceID := b.CID
+		postURL := ""
+		if rkey, ok := rkeys[b.CID]; ok && did != "" {
+			sourceID = atURI(did, rkey)
+			postURL = "https://bsky.app/profile/" + did + "/post/" + rkey
+		}
+		post, err := toPost(b.Data, sourceID, postURL, blobs)
+		if err != nil {
+			parsed.Failed = append(parsed.Failed, spec.FailedItem{SourceID: sourceID, Reason: err.Error()})
+			continue
+		}
+		parsed.Posts = append(parsed.Posts, post)
+	}
+	return parsed, nil
+}
+
+func toPost(record map[string]any, sourceID, postURL string, blobs blobIndex) (microblog.Post, error) {
+	createdAt, err := time.Parse(time.RFC3339, str(record["createdAt"]))
+	if err != nil {
+		return microblog.Post{}, fmt.Errorf("invalid createdAt %q", str(record["createdAt"]))
+	}
+	facets := list(record["facets"])
+	post := microblog.Post{
+		SourceID:  sourceID,
+		URL:       postURL,
+		Content:   expandLinks(str(record["text"]), facets),
+		CreatedAt: createdAt,
+		Tags:      facetTags(facets),
+	}
+	for _, tag := range list(record["tags"]) {
+		post.Tags = append(post.Tags, str(tag))
+	}
+
+	embed := mapOf(record["embed"])
+	if media := mapOf(embed["media"]); media != nil {
+		embed = media // recordWithMedia：引用帖本身不导入，只取附带的媒体
+	}
+	for _, img := range list(embed["images"]) {
+		post.Media = append(post.Media, blobMedia(mapOf(mapOf(img)["image"]), blobs))
+	}
+	if video := mapOf(embed["video"]); video != nil {
+		post.Media = append(post.Media, blobMedia(video, blobs))
+	}
+	// 外链卡片没有对应的附件，把地址补进正文免得丢失。
+	if uri := str(mapOf(embed["external"])["uri"]); uri != "" && !strings.Contains(post.Content, uri) {
+		post.Content = strings.TrimSpace(post.Content + "\n\n" + uri)
+	}
+
+	if parent := mapOf(mapOf(record["reply"])["parent"]); parent != nil {
+		uri := str(parent["uri"])
+		post.ReplyTo = microblog.Reply{SourceID: uri, URL: webURL(uri)}
+	}
+	return post, nil
+}
+
+// blobMedia 把 blob 引用解析到 blobs/ 下的文件；blob 不在档案里时 Path 指向它本该在的位置，
+// 由落库端记为缺失媒体。
+func blobMedia(blob map[string]any, blobs blobIndex) microblog.Media {
+	cid := linkCID(blob["ref"])
+	if cid == "" {
+		cid = str(blob["cid"]) // 早期记录的 blob 引用是 {cid, mimeType}
+	}
+	contentType := str(blob["mimeType"])
+	return microblog.Media{Name: cid + extFor(contentType), ContentType: contentType, Path: blobs.path(cid)}
+}
+
+// extFor 给 blob 补一个扩展名，存储键与下载文件名都靠它识别类型。
+func extFor(contentType string) string {
+	switch contentType {
+	case "image/jpeg":
+		return ".jpg"
+	case "image/png":
+		return ".png"
+	case "image/webp":
+		return ".webp"
+	case "image/gif":
+		return ".gif"
+	case "video/mp4":
+		return ".mp4"
+	}
+	if exts, _ := mime.ExtensionsByType(contentType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ""
+}
+
+// indexRepo 从提交块取仓库 DID，从 MST 节点还原「记录 CID → rkey」。
+func indexRepo(blocks []block) (string, map[string]string) {
+	did := ""
+	rkeys := make(map[string]string)
+	for i := range blocks {
+		data := blocks[i].Data
+		if d := str(data["did"]); d != "" && data["sig"] != nil {
+			did = d
+		}
+		entries, ok := data["e"].([]any)
+		if !ok {
+			continue
+		}
+		// 节点内的 key 按前缀压缩：每条只存与上一条不同的后缀，p 为共享前缀长度。
+		var prev []byte
+		for _, e := range entries {
+			entry := mapOf(e)
+			suffix, _ := entry["k"].([]byte)
+			prefix, _ := entry["p"].(uint64)
+			if int(prefix) > len(prev) {
+				break
+			}
+			key := append(append([]byte{}, prev[:prefix]...), suffix...)
+			prev = key
+			collection, rkey, ok := strings.Cut(string(key), "/")
+			if ok && collection == postCollection {
+				if cid := linkCID(entry["v"]); cid != "" {
+					rkeys[cid] = rkey
+				}
+			}
+		}
+	}
+	return did, rkeys
+}
+
+// facetTags 取富文本里的 #标签。
+func facetTags(facets []any) []string {
+	var tags []string
+	for _, facet := range facets {
+		for _, feature := range list(mapOf(facet)["features"]) {
+			feature := mapOf(feature)
+			if str(feature["$type"]) == tagFeature {
+				tags = append(tags, str(feature["tag"]))
+			}
+		}
+	}
+	return tags
+}
+
+// expandLinks 把正文里被客户端截短显示的链接换回完整地址。facet 的区间是 UTF-8 字节偏移，
+// 从后往前替换才不会让前面的偏移失效。
+func expandLinks(text string, facets []any) string {
+	type span struct {
+		start, end int
+		uri        string
+	}
+	var spans []span
+	for _, facet := range facets {
+		facet := mapOf(facet)
+		index := mapOf(facet["index"])
+		start, okStart := index["byteStart"].(uint64)
+		end, okEnd := index["byteEnd"].(uint64)
+		if !okStart || !okEnd || start >= end || end > uint64(len(text)) {
+			continue
+		}
+		for _, feature := range list(facet["features"]) {
+			feature := mapOf(feature)
+			if str(feature["$type"]) == linkFeature && str(feature["uri"]) != "" {
+				spans = append(spans, span{start: int(start), end: int(end), uri: str(feature["uri"])})
+			}
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start > spans[j].start })
+	last := len(text) + 1
+	for _, s := range spans {
+		if s.end > last {
+			continue // 区间重叠的 facet 不合法，跳过
+		}
+		text = text[:s.start] + s.uri + text[s.end:]
+		last = s.start
+	}
+	return text
+}
+
+func findCAR(root string) (string, error) {
+	if path := microblog.Locate(root, "repo.car"); path != "" {
+		return path, nil
+	}
+	var found string
+	err := filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil || found != "" {
+			return err
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(path), ".car") {
+			found = path
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if found == "" {
+		return "", errors.New("bluesky repo: no .car file found")
+	}
+	return found, nil
+}
+
+// blobIndex 按 CID 索引 blobs/ 下的文件，容忍导出工具给文件名加的扩展名。
+type blobIndex struct {
+	dir   string
+	files map[string]string
+}
+
+func indexBlobs(dir string) blobIndex {
+	index := blobIndex{dir: dir, files: make(map[string]string)}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return index
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		index.files[strings.TrimSuffix(name, filepath.Ext(name))] = filepath.Join(dir, name)
+	}
+	return index
+}
+
+func (b blobIndex) path(cid string) string {
+	if path, ok := b.files[cid]; ok {
+		return path
+	}
+	return filepath.Join(b.dir, cid)
+}
+
+func atURI(did, rkey string) string {
+	return "at://" + did + "/" + postCollection + "/" + rkey
+}
+
+// webURL 把帖子的 at:// 地址换成 bsky.app 上的网页地址。
+func webURL(uri string) string {
+	rest, ok := strings.CutPrefix(uri, "at://")
+	if !ok {
+		return ""
+	}
+	parts := strings.Split(rest, "/")
+	if len(parts) != 3 || parts[1] != postCollection {
+		return ""
+	}
+	return "https://bsky.app/profile/" + parts[0] + "/post/" + parts[2]
+}
+
+func str(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func list(v any) []any {
+	l, _ := v.([]any)
+	return l
+}
+
+func mapOf(v any) map[string]any {
+	m, _ := v.(map[string]any)
+	return m
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package bluesky
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const did = "did:plc:abc123"
+
+// carBuilder 拼一个最小的 CAR v1：DAG-CBOR 块 + CIDv1(dag-cbor, sha2-256)。
+type carBuilder struct {
+	blocks bytes.Buffer
+}
+
+func cidOf(data []byte) []byte {
+	sum := sha256.Sum256(data)
+	return append([]byte{0x01, 0x71, 0x12, 0x20}, sum[:]...)
+}
+
+func link(cid []byte) cbor.Tag {
+	return cbor.Tag{Number: cidLinkTag, Content: append([]byte{0x00}, cid...)}
+}
+
+func (b *carBuilder) add(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := cbor.Marshal(v)
+	require.NoError(t, err)
+	cid := cidOf(data)
+	writeSection(&b.blocks, append(append([]byte{}, cid...), data...))
+	return cid
+}
+
+func (b *carBuilder) bytes(t *testing.T) []byte {
+	t.Helper()
+	header, err := cbor.Marshal(map[string]any{"version": 1, "roots": []any{}})
+	require.NoError(t, err)
+	var out bytes.Buffer
+	writeSection(&out, header)
+	out.Write(b.blocks.Bytes())
+	return out.Bytes()
+}
+
+func writeSection(buf *bytes.Buffer, data []byte) {
+	buf.Write(binary.AppendUvarint(nil, uint64(len(data))))
+	buf.Write(data)
+}
+
+func TestParse(t *testing.T) {
+	blobCID := cidOf([]byte("jpeg-bytes"))
+	var car carBuilder
+	parent := car.add(t, map[string]any{
+		"$type":     postCollection,
+		"text":      "hello example.com/lo... #atproto",
+		"createdAt": "2023-07-01T10:00:00.000Z",
+		"facets": []any{
+			map[string]any{
+				"index":    map[string]any{"byteStart": 6, "byteEnd": 23},
+				"features": []any{map[string]any{"$type": linkFeature, "uri": "https://example.com/long"}},
+			},
+			map[string]any{
+				"index":    map[string]any{"byteStart": 24, "byteEnd": 32},
+				"features": []any{map[string]any{"$type": tagFeature, "tag": "atproto"}},
+			},
+		},
+		"embed": map[string]any{
+			"$type": "app.bsky.embed.images",
+			"images": []any{
+				map[string]any{"alt": "a cat", "image": map[string]any{
+					"$type": "blob", "ref": link(blobCID), "mimeType": "image/jpeg", "size": 10,
+				}},
+			},
+		},
+	})
+	reply := car.add(t, map[string]any{
+		"$type":     postCollection,
+		"text":      "follow-up",
+		"createdAt": "2023-07-01T11:00:00Z",
+		"tags":      []any{"thread"},
+		"reply": map[string]any{
+			"root":   map[string]any{"uri": atURI(did, "3kparent"), "cid": "x"},
+			"parent": map[string]any{"uri": atURI(did, "3kparent"), "cid": "x"},
+		},
+	})
+	car.add(t, map[string]any{"$type": "app.bsky.feed.like", "createdAt": "2023-07-01T12:00:00Z"})
+	car.add(t, map[string]any{"$type": postCollection, "text": "bad", "createdAt": "never"})
+	car.add(t, map[string]any{
+		"e": []any{
+			map[string]any{"p": 0, "k": []byte(postCollection + "/3kparent"), "v": link(parent), "t": nil},
+			map[string]any{"p": len(postCollection) + 3, "k": []byte("reply"), "v": link(reply), "t": nil},
+		},
+		"l": nil,
+	})
+	car.add(t, map[string]any{"did": did, "version": 3, "rev": "r", "sig": []byte("sig"), "data": link(parent)})
+
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "repo.car"), car.bytes(t), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "blobs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "blobs", cidString(blobCID)), []byte("jpeg-bytes"), 0o644))
+
+	parsed, err := Parse(root)
+	require.NoError(t, err)
+	require.Len(t, parsed.Failed, 1)
+	require.Len(t, parsed.Posts, 2)
+
+	first := parsed.Posts[0]
+	assert.Equal(t, atURI(did, "3kparent"), first.SourceID)
+	assert.Equal(t, "https://bsky.app/profile/"+did+"/post/3kparent", first.URL)
+	assert.Equal(t, "hello https://example.com/long #atproto", first.Content)
+	assert.Equal(t, time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC), first.CreatedAt)
+	assert.Equal(t, []string{"atproto"}, first.Tags)
+	require.Len(t, first.Media, 1)
+	assert.Equal(t, cidString(blobCID)+".jpg", first.Media[0].Name)
+	assert.FileExists(t, first.Media[0].Path)
+
+	second := parsed.Posts[1]
+	assert.Equal(t, atURI(did, "3kreply"), second.SourceID)
+	assert.Equal(t, []string{"thread"}, second.Tags)
+	assert.Equal(t, first.SourceID, second.ReplyTo.SourceID)
+	assert.Equal(t, first.URL, second.ReplyTo.URL)
+}
+
+func TestReadCAR_RejectsGarbage(t *testing.T) {
+	_, err := readCAR(bytes.NewReader([]byte("not a car file")))
+	require.Error(t, err)
+}
@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package mastodon 是 Mastodon 账号导出（设置 → 导入与导出 → 请求你的存档）→ Ech0 的导入适配器。
+//
+// 导出是一个 tar.gz：outbox.json 是 ActivityStreams 的 OrderedCollection，嘟文是其中的
+// Create 活动；转嘟（Announce）不是自己的内容，私信只属于对话双方，两者都跳过。附件的
+// url 是档案内的相对路径（media_attachments/...）。
+package mastodon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/microblog"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	"golang.org/x/net/html"
+)
+
+const publicCollection = "https://www.w3.org/ns/activitystreams#Public"
+
+type Importer struct {
+	deps microblog.Deps
+}
+
+func New(deps microblog.Deps) *Importer {
+	return &Importer{deps: deps}
+}
+
+func (e *Importer) Import(ctx context.Context, req spec.ImportRequest) (spec.ImportResult, error) {
+	if req.UpdateProgress != nil {
+		req.UpdateProgress(spec.ImportProgress{CurrentPhase: migratorModel.MigrationPhaseExtracting})
+	}
+	root, err := microblog.SourceRoot(req.SourcePayload)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	parsed, err := Parse(root)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	return microblog.Write(ctx, e.deps, microblog.Request{
+		Platform:       migratorModel.MigrationSourceMastodon,
+		Posts:          parsed.Posts,
+		Failed:         parsed.Failed,
+		Skipped:        parsed.Skipped,
+		CreatedBy:      microblog.CreatedBy(req.SourcePayload),
+		UpdateProgress: req.UpdateProgress,
+	})
+}
+
+// Parsed 是解析结果：Skipped 为跳过的转嘟与私信数。
+type Parsed struct {
+	Posts   []microblog.Post
+	Failed  []spec.FailedItem
+	Skipped int64
+}
+
+type outbox struct {
+	OrderedItems []activity `json:"orderedItems"`
+}
+
+type activity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+type note struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Published string   `json:"published"`
+	Summary   string   `json:"summary"`
+	Content   string   `json:"content"`
+	InReplyTo string   `json:"inReplyTo"`
+	To        []string `json:"to"`
+	CC        []string `json:"cc"`
+	Tag       []struct {
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"tag"`
+	Attachment []struct {
+		MediaType string `json:"mediaType"`
+		URL       string `json:"url"`
+		Name      string `json:"name"`
+	} `json:"attachment"`
+}
+
+// Parse 读取档案根目录（或其一级子目录）下的 outbox.json。
+func Parse(root string) (*Parsed, error) {
+	outboxPath := microblog.Locate(root, "outbox.json")
+	if outboxPath == "" {
+		return nil, errors.New("mastodon archive: outbox.json not found")
+	}
+	raw, err := os.ReadFile(outboxPath)
+	if err != nil {
+		return nil, fmt.Errorf("mastodon archive: read outbox.json: %w", err)
+	}
+	var box outbox
+	if err := json.Unmarshal(raw, &box); err != nil {
+		return nil, fmt.Errorf("mastodon archive: parse outbox.json: %w", err)
+	}
+
+	archiveRoot := filepath.Dir(outboxPath)
+	parsed := &Parsed{}
+	for i := range box.OrderedItems {
+		item := &box.OrderedItems[i]
+		if item.Type != "Create" {
+			parsed.Skipped++
+			continue
+		}
+		var n note
+		if err := json.Unmarshal(item.Object, &n); err != nil {
+			parsed.Failed = append(parsed.Failed, spec.FailedItem{SourceID: item.ID, Reason: "malformed object"})
+			continue
+		}
+		public := slices.Contains(n.To, publicCollection) || slices.Contains(n.CC, publicCollection)
+		if !public && !addressesFollowers(n.To) {
+			parsed.Skipped++
+			continue
+		}
+		post, err := toPost(&n, archiveRoot, public)
+		if err != nil {
+			parsed.Failed = append(parsed.Failed, spec.FailedItem{SourceID: n.ID, Reason: err.Error()})
+			continue
+		}
+		parsed.Posts = append(parsed.Posts, post)
+	}
+	return parsed, nil
+}
+
+func toPost(n *note, archiveRoot string, public bool) (microblog.Post, error) {
+	if n.ID == "" {
+		return microblog.Post{}, errors.New("status has no id")
+	}
+	createdAt, err := time.Parse(time.RFC3339, n.Published)
+	if err != nil {
+		return microblog.Post{}, fmt.Errorf("invalid published %q", n.Published)
+	}
+
+	content := htmlToText(n.Content)
+	// 内容警告（CW）原本折叠正文，Ech0 没有对应概念，放在正文最前面提示。
+	if summary := strings.TrimSpace(n.Summary); summary != "" {
+		content = "CW: " + summary + "\n\n" + content
+	}
+	postURL := n.URL
+	if postURL == "" {
+		postURL = n.ID
+	}
+	post := microblog.Post{
+		SourceID:  n.ID,
+		URL:       postURL,
+		Content:   content,
+		CreatedAt: createdAt,
+		// 仅关注者可见的嘟文导入为私密。
+		Private: !public,
+	}
+	for _, tag := range n.Tag {
+		if tag.Type == "Hashtag" {
+			post.Tags = append(post.Tags, tag.Name)
+		}
+	}
+	for _, a := range n.Attachment {
+		post.Media = append(post.Media, attachmentMedia(archiveRoot, a.URL, a.MediaType))
+	}
+	if n.InReplyTo != "" {
+		post.ReplyTo = microblog.Reply{SourceID: n.InReplyTo, URL: n.InReplyTo}
+	}
+	return post, nil
+}
+
+// attachmentMedia 把附件 url 解析到档案内的文件；远端地址（旧导出偶有）登记为外链。
+func attachmentMedia(archiveRoot, rawURL, mediaType string) microblog.Media {
+	media := microblog.Media{ContentType: mediaType}
+	if strings.HasPrefix(rawURL, "http://") || strings.HasPrefix(rawURL, "https://") {
+		media.URL = rawURL
+		return media
+	}
+	rel := strings.TrimPrefix(rawURL, "/")
+	media.Name = filepath.Base(rel)
+	if rel != "" && filepath.IsLocal(filepath.FromSlash(rel)) {
+		media.Path = filepath.Join(archiveRoot, filepath.FromSlash(rel))
+	}
+	return media
+}
+
+// addressesFollowers 判断嘟文是否发给了关注者集合（仅关注者可见）；私信只发给被提及的人。
+func addressesFollowers(to []string) bool {
+	for _, addr := range to {
+		if strings.HasSuffix(addr, "/followers") {
+			return true
+		}
+	}
+	return false
+}
+
+// htmlToText 把嘟文的 HTML 正文转为纯文本：段落与换行保留为换行，其余标签只取文字。
+// Mastodon 把长链接拆成 invisible / ellipsis 几段 span，取全部文字正好拼回完整地址。
+func htmlToText(content string) string {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && n.Data == "p" {
+			b.WriteString("\n\n")
+		}
+	}
+	walk(doc)
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package mastodon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const outboxJSON = `{
+  "type": "OrderedCollection",
+  "orderedItems": [
+    {"type": "Create", "object": {
+      "id": "https://m.example/users/me/statuses/1",
+      "url": "https://m.example/@me/1",
+      "published": "2021-03-04T05:06:07Z",
+      "content": "<p>Hello <a href=\"https://m.example/tags/fediverse\" class=\"mention hashtag\">#<span>fediverse</span></a></p><p>line<br>two</p>",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"],
+      "tag": [{"type": "Hashtag", "name": "#fediverse"}, {"type": "Mention", "name": "@bob"}],
+      "attachment": [{"type": "Document", "mediaType": "image/png", "url": "/media_attachments/files/a.png"}]
+    }},
+    {"type": "Create", "object": {
+      "id": "https://m.example/users/me/statuses/2",
+      "published": "2021-03-04T06:00:00Z",
+      "summary": "spoiler",
+      "content": "<p>followers only reply</p>",
+      "inReplyTo": "https://m.example/users/me/statuses/1",
+      "to": ["https://m.example/users/me/followers"]
+    }},
+    {"type": "Create", "object": {
+      "id": "https://m.example/users/me/statuses/3",
+      "published": "2021-03-04T07:00:00Z",
+      "content": "<p>direct message</p>",
+      "to": ["https://other.example/users/bob"]
+    }},
+    {"type": "Announce", "object": "https://other.example/users/bob/statuses/9"},
+    {"type": "Create", "object": {
+      "id": "https://m.example/users/me/statuses/4",
+      "published": "not a date",
+      "to": ["https://www.w3.org/ns/activitystreams#Public"]
+    }}
+  ]
+}`
+
+func TestParse(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "outbox.json"), []byte(outboxJSON), 0o644))
+
+	parsed, err := Parse(root)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), parsed.Skipped) // 私信 + 转嘟
+	require.Len(t, parsed.Failed, 1)
+	assert.Equal(t, "https://m.example/users/me/statuses/4", parsed.Failed[0].SourceID)
+	require.Len(t, parsed.Posts, 2)
+
+	first := parsed.Posts[0]
+	assert.Equal(t, "https://m.example/@me/1", first.URL)
+	assert.Equal(t, "Hello #fediverse\n\nline\ntwo", first.Content)
+	assert.Equal(t, time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC), first.CreatedAt)
+	assert.False(t, first.Private)
+	assert.Equal(t, []string{"#fediverse"}, first.Tags)
+	require.Len(t, first.Media, 1)
+	assert.Equal(t, filepath.Join(root, "media_attachments", "files", "a.png"), first.Media[0].Path)
+	assert.Equal(t, "image/png", first.Media[0].ContentType)
+
+	second := parsed.Posts[1]
+	assert.True(t, second.Private)
+	assert.Equal(t, "CW: spoiler\n\nfollowers only reply", second.Content)
+	assert.Equal(t, first.SourceID, second.ReplyTo.SourceID)
+}
+
+func TestAttachmentMedia_RejectsTraversal(t *testing.T) {
+	media := attachmentMedia("/archive", "../../etc/passwd", "")
+	assert.Empty(t, media.Path)
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package microblog 是 Twitter/X、Mastodon、Bluesky 三个导入适配器共用的落库端。
+//
+// 各平台包只负责把导出档案解析成 []Post，时间戳、标签、媒体与回复关系的落地统一在这里：
+// Echo id 由「平台 + 原帖 id」派生，重复导入同一份档案只会跳过已有内容；单条失败记入
+// FailedItem 后继续，不拖垮整批。与胶囊导入一样不发布事件、不调 service 层。
+package microblog
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+)
+
+// Post 是从导出档案里解析出的一条原帖。
+type Post struct {
+	// SourceID 是原帖在平台上的唯一标识，也是回复关系的锚点。
+	SourceID string
+	// URL 是原帖的公开地址，没有时留空。
+	URL       string
+	Content   string
+	CreatedAt time.Time
+	Private   bool
+	Tags      []string
+	Media     []Media
+	ReplyTo   Reply
+}
+
+// Media 是一个附件。Path 指向档案内的文件；档案不带字节时退回 URL，登记为外链文件。
+type Media struct {
+	Name        string
+	ContentType string
+	Path        string
+	URL         string
+}
+
+// Reply 描述原帖回复的对象；SourceID 为空表示不是回复。
+type Reply struct {
+	SourceID string
+	URL      string
+	// Username 是被回复者的账号名，只有 Twitter/X 用得上（TWEET 扩展需要它）。
+	Username string
+}
+
+// IsReply 报告原帖是否是一条回复。
+func (p *Post) IsReply() bool {
+	return p.ReplyTo.SourceID != ""
+}
+
+// EchoID 返回原帖落库后的 Echo id。同一平台同一原帖恒得同一 id，这既是幂等锚点，
+// 也让回复无需等父帖落库就能算出父帖的 Echo id。
+func EchoID(platform, sourceID string) string {
+	return uuidUtil.NameBased("ech0-import:" + platform + "/" + sourceID)
+}
+
+// SourceRoot 取 source_payload.tmp_dir 对应的暂存目录（相对 data/）。
+func SourceRoot(payload map[string]any) (string, error) {
+	tmpDir, ok := payload["tmp_dir"].(string)
+	if !ok || strings.TrimSpace(tmpDir) == "" {
+		return "", errors.New("source_payload.tmp_dir is required")
+	}
+	root := filepath.Join("data", filepath.FromSlash(strings.TrimSpace(tmpDir)))
+	if _, err := os.Stat(root); err != nil {
+		return "", fmt.Errorf("source dir not found: %w", err)
+	}
+	return root, nil
+}
+
+// Locate 在暂存目录里找 rel：先看根目录，再看各一级子目录——用户常把导出解压后
+// 连同外层文件夹一起重新打包。找不到时返回空串。
+func Locate(root, rel string) string {
+	candidate := filepath.Join(root, filepath.FromSlash(rel))
+	if _, err := os.Stat(candidate); err == nil {
+		return candidate
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return ""
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		candidate = filepath.Join(root, entry.Name(), filepath.FromSlash(rel))
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// CreatedBy 取作业发起人（StartGlobalMigration 写入的 created_by），没有时返回空串。
+func CreatedBy(payload map[string]any) string {
+	id, _ := payload["created_by"].(string)
+	return strings.TrimSpace(id)
+}
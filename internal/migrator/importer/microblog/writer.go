@@ -0,0 +1,549 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package microblog
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	echoRepository "github.com/lin-snow/ech0/internal/repository/echo"
+	"github.com/lin-snow/ech0/internal/storage"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/virefs"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	// replyTitleRunes 是回复卡片标题里父帖摘要的长度上限。
+	replyTitleRunes = 60
+	// maxTagRunes 对齐 tags.name 的列宽，超长的平台标签截断而不是让整条失败。
+	maxTagRunes = 50
+
+	externalKeyPrefix = "external/"
+)
+
+type Deps struct {
+	DB       *gorm.DB
+	Selector *storage.StorageSelector
+}
+
+// Request 是一次落库的输入。Failed / Skipped 是解析阶段已经判定的条目，并入最终报告。
+type Request struct {
+	Platform       string
+	Posts          []Post
+	Failed         []spec.FailedItem
+	Skipped        int64
+	CreatedBy      string
+	UpdateProgress func(progress spec.ImportProgress)
+}
+
+// MissingMedia 是一个没能落地的附件：原帖照常导入，只是少了这个文件。
+type MissingMedia struct {
+	SourceID string `json:"source_id"`
+	Name     string `json:"name"`
+	Reason   string `json:"reason"`
+}
+
+// Write 按原帖时间先后逐条落库，每条一个事务。
+func Write(ctx context.Context, deps Deps, req Request) (spec.ImportResult, error) {
+	switch {
+	case deps.DB == nil:
+		return spec.ImportResult{}, errors.New("microblog import: database handle is required")
+	case deps.Selector == nil:
+		return spec.ImportResult{}, errors.New("microblog import: storage selector is required")
+	}
+
+	w, err := newWriter(ctx, deps, req)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	logUtil.GetLogger().Info("migration microblog started",
+		slog.String("module", "migration"),
+		slog.String("platform", req.Platform),
+		slog.String("job_id", w.jobID),
+		slog.Int("posts", len(req.Posts)),
+	)
+
+	posts := req.Posts
+	sort.SliceStable(posts, func(i, j int) bool { return posts[i].CreatedAt.Before(posts[j].CreatedAt) })
+	for i := range posts {
+		w.content[posts[i].SourceID] = posts[i].Content
+	}
+
+	w.progress(migratorModel.MigrationPhaseLoading)
+	for i := range posts {
+		if err := ctx.Err(); err != nil {
+			return spec.ImportResult{}, err
+		}
+		w.writePost(ctx, &posts[i])
+		w.processed++
+		w.progress(migratorModel.MigrationPhaseLoading)
+	}
+
+	if err := w.recountTagUsage(ctx); err != nil {
+		return spec.ImportResult{}, err
+	}
+	w.progress(migratorModel.MigrationPhaseReporting)
+
+	failCount := int64(len(w.failed))
+	summary := fmt.Sprintf("迁移完成: success=%d skipped=%d fail=%d", w.created, w.skipped, failCount)
+	w.progress(migratorModel.MigrationPhaseCompleted)
+	logUtil.GetLogger().Info("migration microblog finished",
+		slog.String("module", "migration"),
+		slog.String("platform", req.Platform),
+		slog.String("job_id", w.jobID),
+		slog.Int64("success_count", w.created),
+		slog.Int64("skipped_count", w.skipped),
+		slog.Int64("fail_count", failCount),
+		slog.Int("missing_media", len(w.missing)),
+	)
+
+	return spec.ImportResult{
+		Processed:    w.total,
+		Total:        w.total,
+		SuccessCount: w.created,
+		FailCount:    failCount,
+		ErrorSummary: summary,
+		JobID:        w.jobID,
+		Report: map[string]any{
+			"job_id":        w.jobID,
+			"platform":      req.Platform,
+			"processed":     w.total,
+			"success_count": w.created,
+			"skipped_count": w.skipped,
+			"fail_count":    failCount,
+			"failed_items":  w.failed,
+			"missing_media": w.missing,
+			"replies":       w.replies,
+		},
+	}, nil
+}
+
+// writer 承载一次落库的全部可变状态。
+type writer struct {
+	db       *gorm.DB
+	selector *storage.StorageSelector
+	req      Request
+	jobID    string
+
+	user userModel.User
+	// content 是本次档案内各原帖的正文，回复卡片的标题从父帖正文里截。
+	content map[string]string
+	// tagIDByName 缓存本次接触过的标签，收尾时只重算它们的 usage_count。
+	tagIDByName map[string]string
+	// newTags 是当前事务里新建的标签名；事务回滚后它们并不存在，须从缓存里剔除。
+	newTags []string
+
+	storageType storage.StorageType
+	provider    string
+	bucket      string
+	keygen      storage.KeyGenerator
+
+	total, processed, created, skipped, replies int64
+	failed                                      []spec.FailedItem
+	missing                                     []MissingMedia
+}
+
+func newWriter(ctx context.Context, deps Deps, req Request) (*writer, error) {
+	w := &writer{
+		db:          deps.DB.WithContext(ctx),
+		selector:    deps.Selector,
+		req:         req,
+		jobID:       uuidUtil.MustNewV7(),
+		content:     make(map[string]string, len(req.Posts)),
+		tagIDByName: make(map[string]string),
+		storageType: storage.StorageTypeLocal,
+		keygen:      storage.NewRandomKeyGenerator(),
+		total:       int64(len(req.Posts)+len(req.Failed)) + req.Skipped,
+		processed:   int64(len(req.Failed)) + req.Skipped,
+		skipped:     req.Skipped,
+		failed:      append([]spec.FailedItem{}, req.Failed...),
+		missing:     []MissingMedia{},
+	}
+	if deps.Selector.ObjectEnabled() {
+		w.storageType = storage.StorageTypeObject
+		w.provider, w.bucket = deps.Selector.ObjectRoute()
+	}
+
+	// 归属：发起导入的管理员，缺省（如 CLI 直接调用）时挂到站主。
+	query := w.db.Where("is_owner = ?", true)
+	if req.CreatedBy != "" {
+		query = w.db.Where("id = ?", req.CreatedBy)
+	}
+	if err := query.First(&w.user).Error; err != nil {
+		return nil, fmt.Errorf("microblog import: resolve owner user: %w", err)
+	}
+	return w, nil
+}
+
+func (w *writer) progress(phase string) {
+	if w.req.UpdateProgress == nil {
+		return
+	}
+	w.req.UpdateProgress(spec.ImportProgress{
+		CurrentPhase: phase,
+		Processed:    w.processed,
+		Total:        w.total,
+		SuccessCount: w.created,
+		FailCount:    int64(len(w.failed)),
+	})
+}
+
+func (w *writer) writePost(ctx context.Context, post *Post) {
+	echoID := EchoID(w.req.Platform, post.SourceID)
+	var existing int64
+	if err := w.db.Model(&echoModel.Echo{}).Where("id = ?", echoID).Count(&existing).Error; err != nil {
+		w.fail(post.SourceID, fmt.Errorf("probe echo: %w", err))
+		return
+	}
+	if existing > 0 {
+		// 幂等：已导入过的原帖整条跳过，不覆盖不合并。
+		w.skipped++
+		return
+	}
+
+	files, layout := w.storeMedia(ctx, post)
+	if strings.TrimSpace(post.Content) == "" && len(files) == 0 {
+		w.fail(post.SourceID, errors.New("empty post"))
+		return
+	}
+
+	w.newTags = w.newTags[:0]
+	err := w.db.Transaction(func(tx *gorm.DB) error {
+		createdAt := post.CreatedAt.Unix()
+		echo := echoModel.Echo{
+			ID:       echoID,
+			Content:  strings.TrimSpace(post.Content),
+			Username: w.user.Username,
+			Layout:   layout,
+			Private:  post.Private,
+			UserID:   w.user.ID,
+			Status:   echoModel.StatusPublished,
+			// CreatedAt 显式赋非零值时 autoCreateTime 不会代填，原帖时间得以保留。
+			CreatedAt: createdAt,
+			PublishAt: createdAt,
+		}
+		if err := tx.Omit(clause.Associations).Create(&echo).Error; err != nil {
+			return fmt.Errorf("create echo: %w", err)
+		}
+		if err := w.linkTags(tx, echoID, post.Tags); err != nil {
+			return err
+		}
+		if err := w.linkFiles(tx, echoID, files); err != nil {
+			return err
+		}
+		if err := w.linkReply(tx, echoID, post); err != nil {
+			return err
+		}
+		return echoRepository.NewEchoRepository(func() *gorm.DB { return tx }, nil).
+			UpsertSearchIndex(ctx, echoID, echo.Content)
+	})
+	if err != nil {
+		for _, name := range w.newTags {
+			delete(w.tagIDByName, name)
+		}
+		w.fail(post.SourceID, err)
+		return
+	}
+	w.created++
+	if post.IsReply() {
+		w.replies++
+	}
+}
+
+func (w *writer) fail(sourceID string, err error) {
+	w.failed = append(w.failed, spec.FailedItem{SourceID: sourceID, Reason: err.Error()})
+	logUtil.GetLogger().Warn("migration microblog post skipped",
+		slog.String("module", "migration"),
+		slog.String("platform", w.req.Platform),
+		slog.String("source_id", sourceID),
+		logUtil.Err(err),
+	)
+}
+
+func (w *writer) linkTags(tx *gorm.DB, echoID string, names []string) error {
+	for _, name := range names {
+		name = truncate(strings.TrimSpace(strings.TrimPrefix(name, "#")), maxTagRunes)
+		if name == "" {
+			continue
+		}
+		tagID, err := w.ensureTag(tx, name)
+		if err != nil {
+			return fmt.Errorf("tag %q: %w", name, err)
+		}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&echoModel.EchoTag{EchoID: echoID, TagID: tagID}).Error; err != nil {
+			return fmt.Errorf("link tag %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func (w *writer) ensureTag(tx *gorm.DB, name string) (string, error) {
+	if id, ok := w.tagIDByName[name]; ok {
+		return id, nil
+	}
+	var tag echoModel.Tag
+	err := tx.Where("name = ?", name).First(&tag).Error
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		tag = echoModel.Tag{ID: uuidUtil.MustNewV7(), Name: name}
+		if err := tx.Create(&tag).Error; err != nil {
+			return "", err
+		}
+		w.newTags = append(w.newTags, name)
+	default:
+		return "", err
+	}
+	w.tagIDByName[name] = tag.ID
+	return tag.ID, nil
+}
+
+// recountTagUsage 只重算本次接触过的标签：导入不该顺手改写与本次无关的行。
+func (w *writer) recountTagUsage(ctx context.Context) error {
+	if len(w.tagIDByName) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(w.tagIDByName))
+	for _, id := range w.tagIDByName {
+		ids = append(ids, id)
+	}
+	if err := w.db.WithContext(ctx).Exec(
+		"UPDATE tags SET usage_count = (SELECT COUNT(*) FROM echo_tags WHERE echo_tags.tag_id = tags.id) WHERE id IN ?",
+		ids,
+	).Error; err != nil {
+		return fmt.Errorf("microblog import: recount tag usage: %w", err)
+	}
+	return nil
+}
+
+// storeMedia 把附件字节写进当前存储后端并返回待关联的文件行。一条 Echo 的附件须同属
+// 一个类别，与第一个附件类别不同的附件、读不到的附件都记进 missing_media。
+func (w *writer) storeMedia(ctx context.Context, post *Post) ([]fileModel.File, string) {
+	var files []fileModel.File
+	var category storage.Category
+	for _, media := range post.Media {
+		name := media.Name
+		if name == "" {
+			name = nameOnly(media.Path + media.URL)
+		}
+		c := categoryOf(name, media.ContentType)
+		if category != "" && c != category {
+			w.missMedia(post.SourceID, name, "mixed media categories in one post")
+			continue
+		}
+		file, err := w.storeOne(ctx, media, name, c)
+		if err != nil {
+			w.missMedia(post.SourceID, name, err.Error())
+			continue
+		}
+		category = c
+		files = append(files, file)
+	}
+
+	layout := echoModel.LayoutWaterfall
+	if category == storage.CategoryAudio || category == storage.CategoryVideo {
+		layout = echoModel.LayoutNone
+	}
+	return files, layout
+}
+
+func (w *writer) storeOne(ctx context.Context, media Media, name string, category storage.Category) (fileModel.File, error) {
+	contentType := media.ContentType
+	if contentType == "" {
+		contentType = mimeForName(name)
+	}
+	if media.Path == "" {
+		if media.URL == "" {
+			return fileModel.File{}, errors.New("media has neither file nor url")
+		}
+		sum := sha256.Sum256([]byte(media.URL))
+		return fileModel.File{
+			Key:         externalKeyPrefix + string(category) + "/" + hex.EncodeToString(sum[:]),
+			StorageType: string(storage.StorageTypeExternal),
+			Provider:    string(storage.StorageTypeExternal),
+			URL:         media.URL,
+			Name:        name,
+			ContentType: contentType,
+			Category:    string(category),
+			UserID:      w.user.ID,
+		}, nil
+	}
+
+	f, err := os.Open(media.Path)
+	if err != nil {
+		return fileModel.File{}, fmt.Errorf("media file not in archive: %s", filepath.Base(media.Path))
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return fileModel.File{}, err
+	}
+	key, err := w.keygen.GenerateKey(category, w.user.ID, name)
+	if err != nil {
+		return fileModel.File{}, err
+	}
+	if err := w.selector.Put(ctx, w.storageType, key, f, virefs.WithContentType(contentType)); err != nil {
+		return fileModel.File{}, fmt.Errorf("store media: %w", err)
+	}
+	// URL 留空：托管文件的直链由 AfterFind 按当前存储配置重算。
+	return fileModel.File{
+		Key:         key,
+		StorageType: string(w.storageType),
+		Provider:    w.provider,
+		Bucket:      w.bucket,
+		Name:        name,
+		ContentType: contentType,
+		Size:        info.Size(),
+		Category:    string(category),
+		UserID:      w.user.ID,
+	}, nil
+}
+
+func (w *writer) missMedia(sourceID, name, reason string) {
+	w.missing = append(w.missing, MissingMedia{SourceID: sourceID, Name: name, Reason: reason})
+}
+
+func (w *writer) linkFiles(tx *gorm.DB, echoID string, files []fileModel.File) error {
+	for i := range files {
+		file := files[i]
+		if file.StorageType == string(storage.StorageTypeExternal) {
+			// 外链按 URL 去重，多条原帖引用同一张远端图片时共用一行。
+			var existing fileModel.File
+			err := tx.Where("storage_type = ? AND provider = ? AND bucket = ? AND key = ?",
+				file.StorageType, file.Provider, file.Bucket, file.Key).First(&existing).Error
+			switch {
+			case err == nil:
+				file = existing
+			case errors.Is(err, gorm.ErrRecordNotFound):
+				if err := tx.Create(&file).Error; err != nil {
+					return fmt.Errorf("create external file row: %w", err)
+				}
+			default:
+				return fmt.Errorf("probe external file: %w", err)
+			}
+		} else if err := tx.Create(&file).Error; err != nil {
+			return fmt.Errorf("create file row: %w", err)
+		}
+		if err := tx.Create(&fileModel.EchoFile{
+			ID:        uuidUtil.MustNewV7(),
+			EchoID:    echoID,
+			FileID:    file.ID,
+			SortOrder: i,
+		}).Error; err != nil {
+			return fmt.Errorf("link file: %w", err)
+		}
+	}
+	return nil
+}
+
+// linkReply 用扩展卡片保留回复关系：父帖在本次档案里时指向父帖导入后的 Echo，
+// 否则指向平台上的原帖（Twitter/X 用 TWEET 卡片，可直接内嵌）。
+func (w *writer) linkReply(tx *gorm.DB, echoID string, post *Post) error {
+	if !post.IsReply() {
+		return nil
+	}
+	var ext echoModel.EchoExtension
+	parentContent, inArchive := w.content[post.ReplyTo.SourceID]
+	switch {
+	case inArchive:
+		ext = echoModel.EchoExtension{Type: echoModel.Extension_WEBSITE, Payload: map[string]interface{}{
+			"title": "回复：" + snippet(parentContent),
+			"site":  "/echo/" + EchoID(w.req.Platform, post.ReplyTo.SourceID),
+		}}
+	case post.ReplyTo.Username != "" && post.ReplyTo.URL != "":
+		ext = echoModel.EchoExtension{Type: echoModel.Extension_TWEET, Payload: map[string]interface{}{
+			"url":      post.ReplyTo.URL,
+			"username": post.ReplyTo.Username,
+			"statusId": post.ReplyTo.SourceID,
+		}}
+	case post.ReplyTo.URL != "":
+		ext = echoModel.EchoExtension{Type: echoModel.Extension_WEBSITE, Payload: map[string]interface{}{
+			"title": "回复：" + post.ReplyTo.URL,
+			"site":  post.ReplyTo.URL,
+		}}
+	default:
+		return nil
+	}
+	ext.EchoID = echoID
+	if err := tx.Create(&ext).Error; err != nil {
+		return fmt.Errorf("create reply extension: %w", err)
+	}
+	return nil
+}
+
+func snippet(content string) string {
+	text := strings.Join(strings.Fields(content), " ")
+	if text == "" {
+		return "…"
+	}
+	if runes := []rune(text); len(runes) > replyTitleRunes {
+		return string(runes[:replyTitleRunes]) + "…"
+	}
+	return text
+}
+
+func truncate(s string, limit int) string {
+	if runes := []rune(s); len(runes) > limit {
+		return string(runes[:limit])
+	}
+	return s
+}
+
+// categoryOf 优先按 MIME 主类型归类，缺失时按扩展名推导（与胶囊导入同一张表）。
+func categoryOf(name, contentType string) storage.Category {
+	switch {
+	case strings.HasPrefix(contentType, "image/"):
+		return storage.CategoryImage
+	case strings.HasPrefix(contentType, "video/"):
+		return storage.CategoryVideo
+	case strings.HasPrefix(contentType, "audio/"):
+		return storage.CategoryAudio
+	}
+	switch strings.ToLower(path.Ext(name)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".webp", ".svg", ".avif", ".bmp", ".ico":
+		return storage.CategoryImage
+	case ".mp3", ".flac", ".wav", ".m4a", ".ogg":
+		return storage.CategoryAudio
+	case ".mp4", ".avi", ".mkv", ".webm", ".mov":
+		return storage.CategoryVideo
+	case ".pdf":
+		return storage.CategoryPDF
+	default:
+		return storage.CategoryFile
+	}
+}
+
+// nameOnly 剥掉 URL 的 query / fragment，好让扩展名推导对 "a.png?v=1" 免疫。
+func nameOnly(raw string) string {
+	if i := strings.IndexAny(raw, "?#"); i >= 0 {
+		raw = raw[:i]
+	}
+	return path.Base(filepath.ToSlash(raw))
+}
+
+func mimeForName(name string) string {
+	if ct := mime.TypeByExtension(path.Ext(name)); ct != "" {
+		return ct
+	}
+	return "application/octet-stream"
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package microblog
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const platform = "twitter"
+
+var (
+	parentAt = time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC)
+	replyAt  = parentAt.Add(time.Hour)
+)
+
+type fixture struct {
+	db      *gorm.DB
+	deps    Deps
+	ownerID string
+	dir     string
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	db := helpers.NewTestDB(t)
+	owner := userModel.User{Username: "owner", IsOwner: true, IsAdmin: true}
+	require.NoError(t, db.Create(&owner).Error)
+	return &fixture{
+		db:      db,
+		deps:    Deps{DB: db, Selector: storage.NewStorageManagerForTest(t.TempDir()).GetSelector()},
+		ownerID: owner.ID,
+		dir:     t.TempDir(),
+	}
+}
+
+func (f *fixture) writeMedia(t *testing.T, name, body string) string {
+	t.Helper()
+	path := filepath.Join(f.dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	return path
+}
+
+func (f *fixture) posts(t *testing.T) []Post {
+	return []Post{
+		// 故意把回复排在父帖前面：落库按原帖时间排序。
+		{
+			SourceID:  "2",
+			Content:   "reply in thread",
+			CreatedAt: replyAt,
+			ReplyTo:   Reply{SourceID: "1", URL: "https://x.com/me/status/1", Username: "me"},
+		},
+		{
+			SourceID:  "1",
+			Content:   "hello #golang",
+			CreatedAt: parentAt,
+			Tags:      []string{"golang", "#golang"},
+			Media: []Media{
+				{Path: f.writeMedia(t, "1-a.png", "png-bytes")},
+				{Path: filepath.Join(f.dir, "1-missing.png")},
+			},
+		},
+		{
+			SourceID:  "3",
+			Content:   "replying to someone else",
+			CreatedAt: replyAt.Add(time.Hour),
+			Private:   true,
+			ReplyTo:   Reply{SourceID: "99", URL: "https://x.com/bob/status/99", Username: "bob"},
+		},
+		{SourceID: "4", CreatedAt: replyAt},
+	}
+}
+
+func TestWrite_ImportsPostsWithTimestampsTagsMediaAndThreads(t *testing.T) {
+	f := newFixture(t)
+	var phases []string
+	result, err := Write(context.Background(), f.deps, Request{
+		Platform: platform,
+		Posts:    f.posts(t),
+		Failed:   []spec.FailedItem{{SourceID: "bad", Reason: "invalid created_at"}},
+		Skipped:  1,
+		UpdateProgress: func(p spec.ImportProgress) {
+			phases = append(phases, p.CurrentPhase)
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(6), result.Total)
+	assert.Equal(t, int64(3), result.SuccessCount)
+	assert.Equal(t, int64(2), result.FailCount)
+	assert.Equal(t, "completed", phases[len(phases)-1])
+	failed := result.Report["failed_items"].([]spec.FailedItem)
+	assert.Equal(t, []string{"bad", "4"}, []string{failed[0].SourceID, failed[1].SourceID})
+	missing := result.Report["missing_media"].([]MissingMedia)
+	require.Len(t, missing, 1)
+	assert.Equal(t, "1", missing[0].SourceID)
+	assert.Equal(t, int64(2), result.Report["replies"])
+
+	var parent echoModel.Echo
+	require.NoError(t, f.db.Preload("Tags").Preload("EchoFiles.File").
+		First(&parent, "id = ?", EchoID(platform, "1")).Error)
+	assert.Equal(t, parentAt.Unix(), parent.CreatedAt)
+	assert.Equal(t, parentAt.Unix(), parent.PublishAt)
+	assert.Equal(t, f.ownerID, parent.UserID)
+	assert.Equal(t, "owner", parent.Username)
+	require.Len(t, parent.Tags, 1)
+	assert.Equal(t, "golang", parent.Tags[0].Name)
+	require.Len(t, parent.EchoFiles, 1)
+	stored := parent.EchoFiles[0].File
+	assert.Equal(t, string(storage.CategoryImage), stored.Category)
+	rc, err := f.deps.Selector.Get(context.Background(), storage.StorageType(stored.StorageType), stored.Key)
+	require.NoError(t, err)
+	body, _ := io.ReadAll(rc)
+	_ = rc.Close()
+	assert.Equal(t, "png-bytes", string(body))
+
+	var tag echoModel.Tag
+	require.NoError(t, f.db.First(&tag, "name = ?", "golang").Error)
+	assert.Equal(t, 1, tag.UsageCount)
+
+	var threadExt echoModel.EchoExtension
+	require.NoError(t, f.db.First(&threadExt, "echo_id = ?", EchoID(platform, "2")).Error)
+	assert.Equal(t, echoModel.Extension_WEBSITE, threadExt.Type)
+	assert.Equal(t, "/echo/"+parent.ID, threadExt.Payload["site"])
+	assert.Equal(t, "回复：hello #golang", threadExt.Payload["title"])
+
+	var externalExt echoModel.EchoExtension
+	require.NoError(t, f.db.First(&externalExt, "echo_id = ?", EchoID(platform, "3")).Error)
+	assert.Equal(t, echoModel.Extension_TWEET, externalExt.Type)
+	assert.Equal(t, "bob", externalExt.Payload["username"])
+	assert.Equal(t, "99", externalExt.Payload["statusId"])
+
+	var private echoModel.Echo
+	require.NoError(t, f.db.First(&private, "id = ?", EchoID(platform, "3")).Error)
+	assert.True(t, private.Private)
+}
+
+func TestWrite_RerunSkipsImportedPosts(t *testing.T) {
+	f := newFixture(t)
+	_, err := Write(context.Background(), f.deps, Request{Platform: platform, Posts: f.posts(t)})
+	require.NoError(t, err)
+
+	result, err := Write(context.Background(), f.deps, Request{Platform: platform, Posts: f.posts(t)})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), result.SuccessCount)
+	assert.Equal(t, int64(3), result.Report["skipped_count"])
+
+	var echoes, files int64
+	require.NoError(t, f.db.Model(&echoModel.Echo{}).Count(&echoes).Error)
+	require.NoError(t, f.db.Model(&fileModel.File{}).Count(&files).Error)
+	assert.Equal(t, int64(3), echoes)
+	assert.Equal(t, int64(1), files)
+}
+
+func TestWrite_AttributesToCreatedBy(t *testing.T) {
+	f := newFixture(t)
+	admin := userModel.User{Username: "admin", IsAdmin: true}
+	require.NoError(t, f.db.Create(&admin).Error)
+
+	_, err := Write(context.Background(), f.deps, Request{
+		Platform:  platform,
+		CreatedBy: admin.ID,
+		Posts:     []Post{{SourceID: "1", Content: "hi", CreatedAt: parentAt}},
+	})
+	require.NoError(t, err)
+
+	var echo echoModel.Echo
+	require.NoError(t, f.db.First(&echo, "id = ?", EchoID(platform, "1")).Error)
+	assert.Equal(t, admin.ID, echo.UserID)
+	assert.Equal(t, "admin", echo.Username)
+}
+
+func TestWrite_ExternalMediaAndMixedCategories(t *testing.T) {
+	f := newFixture(t)
+	result, err := Write(context.Background(), f.deps, Request{
+		Platform: platform,
+		Posts: []Post{{
+			SourceID:  "1",
+			CreatedAt: parentAt,
+			Media: []Media{
+				{URL: "https://pbs.example/a.jpg"},
+				{Path: f.writeMedia(t, "clip.mp4", "mp4")},
+			},
+		}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), result.SuccessCount)
+	require.Len(t, result.Report["missing_media"].([]MissingMedia), 1)
+
+	var echo echoModel.Echo
+	require.NoError(t, f.db.Preload("EchoFiles.File").First(&echo, "id = ?", EchoID(platform, "1")).Error)
+	require.Len(t, echo.EchoFiles, 1)
+	assert.Equal(t, string(storage.StorageTypeExternal), echo.EchoFiles[0].File.StorageType)
+	assert.Equal(t, "https://pbs.example/a.jpg", echo.EchoFiles[0].File.URL)
+}
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package twitter 是 Twitter/X 账号归档（Your archive zip）→ Ech0 的导入适配器。
+//
+// 归档里的推文是 data/tweets.js（大号归档拆成 tweets-part1.js ...），内容是一段
+// `window.YTD.tweets.part0 = [...]` 形式的脚本；媒体在 data/tweets_media/ 下，
+// 以「推文 id-」为前缀命名。转推不是自己的内容，直接跳过。
+package twitter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/importer/microblog"
+	"github.com/lin-snow/ech0/internal/migrator/spec"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+// createdAtLayout 是归档里 created_at 的格式，如 "Wed Oct 10 20:19:24 +0000 2018"。
+const createdAtLayout = "Mon Jan 02 15:04:05 -0700 2006"
+
+type Importer struct {
+	deps microblog.Deps
+}
+
+func New(deps microblog.Deps) *Importer {
+	return &Importer{deps: deps}
+}
+
+func (e *Importer) Import(ctx context.Context, req spec.ImportRequest) (spec.ImportResult, error) {
+	if req.UpdateProgress != nil {
+		req.UpdateProgress(spec.ImportProgress{CurrentPhase: migratorModel.MigrationPhaseExtracting})
+	}
+	root, err := microblog.SourceRoot(req.SourcePayload)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	parsed, err := Parse(root)
+	if err != nil {
+		return spec.ImportResult{}, err
+	}
+	return microblog.Write(ctx, e.deps, microblog.Request{
+		Platform:       migratorModel.MigrationSourceTwitter,
+		Posts:          parsed.Posts,
+		Failed:         parsed.Failed,
+		Skipped:        parsed.Skipped,
+		CreatedBy:      microblog.CreatedBy(req.SourcePayload),
+		UpdateProgress: req.UpdateProgress,
+	})
+}
+
+// Parsed 是解析结果：Skipped 为跳过的转推数。
+type Parsed struct {
+	Posts   []microblog.Post
+	Failed  []spec.FailedItem
+	Skipped int64
+}
+
+type tweetEnvelope struct {
+	Tweet tweet `json:"tweet"`
+}
+
+type tweet struct {
+	IDStr                string   `json:"id_str"`
+	FullText             string   `json:"full_text"`
+	CreatedAt            string   `json:"created_at"`
+	InReplyToStatusIDStr string   `json:"in_reply_to_status_id_str"`
+	InReplyToScreenName  string   `json:"in_reply_to_screen_name"`
+	Retweeted            bool     `json:"retweeted"`
+	Entities             entities `json:"entities"`
+	ExtendedEntities     entities `json:"extended_entities"`
+}
+
+type entities struct {
+	Hashtags []struct {
+		Text string `json:"text"`
+	} `json:"hashtags"`
+	URLs []struct {
+		URL         string `json:"url"`
+		ExpandedURL string `json:"expanded_url"`
+	} `json:"urls"`
+	Media []struct {
+		URL           string `json:"url"`
+		MediaURLHTTPS string `json:"media_url_https"`
+	} `json:"media"`
+}
+
+// Parse 读取归档根目录（或其一级子目录）下的 data/ 目录。
+func Parse(root string) (*Parsed, error) {
+	dataDir := microblog.Locate(root, "data")
+	if dataDir == "" {
+		return nil, errors.New("twitter archive: data/ directory not found")
+	}
+	files, err := tweetFiles(dataDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, errors.New("twitter archive: data/tweets.js not found")
+	}
+
+	username := accountUsername(dataDir)
+	media := indexMedia(filepath.Join(dataDir, "tweets_media"))
+	parsed := &Parsed{}
+	for _, name := range files {
+		raw, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("twitter archive: read %s: %w", filepath.Base(name), err)
+		}
+		var envelopes []tweetEnvelope
+		if err := json.Unmarshal(stripAssignment(raw), &envelopes); err != nil {
+			return nil, fmt.Errorf("twitter archive: parse %s: %w", filepath.Base(name), err)
+		}
+		for i := range envelopes {
+			t := &envelopes[i].Tweet
+			if t.Retweeted || strings.HasPrefix(t.FullText, "RT @") {
+				parsed.Skipped++
+				continue
+			}
+			post, err := toPost(t, username, media[t.IDStr])
+			if err != nil {
+				parsed.Failed = append(parsed.Failed, spec.FailedItem{SourceID: t.IDStr, Reason: err.Error()})
+				continue
+			}
+			parsed.Posts = append(parsed.Posts, post)
+		}
+	}
+	return parsed, nil
+}
+
+func toPost(t *tweet, username string, mediaFiles []string) (microblog.Post, error) {
+	if t.IDStr == "" {
+		return microblog.Post{}, errors.New("tweet has no id_str")
+	}
+	createdAt, err := time.Parse(createdAtLayout, t.CreatedAt)
+	if err != nil {
+		return microblog.Post{}, fmt.Errorf("invalid created_at %q", t.CreatedAt)
+	}
+
+	post := microblog.Post{
+		SourceID:  t.IDStr,
+		URL:       statusURL(username, t.IDStr),
+		Content:   expandText(t),
+		CreatedAt: createdAt,
+	}
+	for _, tag := range t.Entities.Hashtags {
+		post.Tags = append(post.Tags, tag.Text)
+	}
+	for _, path := range mediaFiles {
+		post.Media = append(post.Media, microblog.Media{Name: filepath.Base(path), Path: path})
+	}
+	// 较早的归档不带媒体文件，退回远端地址。
+	if len(post.Media) == 0 {
+		for _, m := range t.ExtendedEntities.Media {
+			if m.MediaURLHTTPS != "" {
+				post.Media = append(post.Media, microblog.Media{URL: m.MediaURLHTTPS})
+			}
+		}
+	}
+	if t.InReplyToStatusIDStr != "" {
+		post.ReplyTo = microblog.Reply{
+			SourceID: t.InReplyToStatusIDStr,
+			URL:      statusURL(t.InReplyToScreenName, t.InReplyToStatusIDStr),
+			Username: t.InReplyToScreenName,
+		}
+	}
+	return post, nil
+}
+
+// expandText 还原推文正文：反转义 HTML 实体，把 t.co 短链换回原始地址，去掉指向
+// 推文自身媒体的短链（媒体已作为附件导入）。
+func expandText(t *tweet) string {
+	text := html.UnescapeString(t.FullText)
+	for _, u := range t.Entities.URLs {
+		if u.URL != "" && u.ExpandedURL != "" {
+			text = strings.ReplaceAll(text, u.URL, u.ExpandedURL)
+		}
+	}
+	for _, m := range t.Entities.Media {
+		if m.URL != "" {
+			text = strings.ReplaceAll(text, m.URL, "")
+		}
+	}
+	return strings.TrimSpace(text)
+}
+
+func statusURL(username, id string) string {
+	if username == "" {
+		return "https://x.com/i/web/status/" + id
+	}
+	return "https://x.com/" + username + "/status/" + id
+}
+
+// tweetFiles 列出 tweets.js / tweets-part*.js；更早的归档叫 tweet.js。
+func tweetFiles(dataDir string) ([]string, error) {
+	var files []string
+	for _, pattern := range []string{"tweets.js", "tweets-part*.js", "tweet.js", "tweet-part*.js"} {
+		matches, err := filepath.Glob(filepath.Join(dataDir, pattern))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// accountUsername 读 data/account.js 里的账号名，用来拼原推地址；读不到时返回空串。
+func accountUsername(dataDir string) string {
+	raw, err := os.ReadFile(filepath.Join(dataDir, "account.js"))
+	if err != nil {
+		return ""
+	}
+	var accounts []struct {
+		Account struct {
+			Username string `json:"username"`
+		} `json:"account"`
+	}
+	if err := json.Unmarshal(stripAssignment(raw), &accounts); err != nil || len(accounts) == 0 {
+		return ""
+	}
+	return accounts[0].Account.Username
+}
+
+// indexMedia 把 tweets_media/ 下的文件按推文 id 分组（文件名形如 "<id>-<原名>"）。
+func indexMedia(dir string) map[string][]string {
+	index := make(map[string][]string)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return index
+	}
+	for _, entry := range entries {
+		id, _, ok := strings.Cut(entry.Name(), "-")
+		if entry.IsDir() || !ok {
+			continue
+		}
+		index[id] = append(index[id], filepath.Join(dir, entry.Name()))
+	}
+	return index
+}
+
+// stripAssignment 去掉 "window.YTD.xxx.part0 = " 前缀，留下 JSON 数组。
+func stripAssignment(raw []byte) []byte {
+	s := string(raw)
+	if i := strings.Index(s, "="); i >= 0 && !strings.HasPrefix(strings.TrimSpace(s), "[") {
+		s = s[i+1:]
+	}
+	return []byte(strings.TrimSpace(s))
+}
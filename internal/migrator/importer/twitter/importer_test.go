@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package twitter
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tweetsJS = `window.YTD.tweets.part0 = [
+  {"tweet": {
+    "id_str": "100",
+    "created_at": "Fri May 01 08:00:00 +0000 2020",
+    "full_text": "Hello &amp; welcome #golang https://t.co/link https://t.co/pic",
+    "entities": {
+      "hashtags": [{"text": "golang"}],
+      "urls": [{"url": "https://t.co/link", "expanded_url": "https://go.dev"}],
+      "media": [{"url": "https://t.co/pic"}]
+    }
+  }},
+  {"tweet": {
+    "id_str": "101",
+    "created_at": "Fri May 01 09:00:00 +0000 2020",
+    "full_text": "@me thread",
+    "in_reply_to_status_id_str": "100",
+    "in_reply_to_screen_name": "me"
+  }},
+  {"tweet": {"id_str": "102", "created_at": "Fri May 01 10:00:00 +0000 2020", "full_text": "RT @bob: hi"}},
+  {"tweet": {"id_str": "103", "created_at": "yesterday", "full_text": "broken"}}
+]`
+
+func writeArchive(t *testing.T) string {
+	t.Helper()
+	// 外层再套一层文件夹：用户常连同解压目录一起重新打包。
+	root := t.TempDir()
+	data := filepath.Join(root, "twitter-2024", "data")
+	require.NoError(t, os.MkdirAll(filepath.Join(data, "tweets_media"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(data, "tweets.js"), []byte(tweetsJS), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(data, "account.js"),
+		[]byte(`window.YTD.account.part0 = [{"account": {"username": "me"}}]`), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(data, "tweets_media", "100-abc.jpg"), []byte("jpg"), 0o644))
+	return root
+}
+
+func TestParse(t *testing.T) {
+	parsed, err := Parse(writeArchive(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), parsed.Skipped)
+	require.Len(t, parsed.Failed, 1)
+	assert.Equal(t, "103", parsed.Failed[0].SourceID)
+	require.Len(t, parsed.Posts, 2)
+
+	first := parsed.Posts[0]
+	assert.Equal(t, "100", first.SourceID)
+	assert.Equal(t, "https://x.com/me/status/100", first.URL)
+	assert.Equal(t, "Hello & welcome #golang https://go.dev", first.Content)
+	assert.Equal(t, time.Date(2020, 5, 1, 8, 0, 0, 0, time.UTC), first.CreatedAt.UTC())
+	assert.Equal(t, []string{"golang"}, first.Tags)
+	require.Len(t, first.Media, 1)
+	assert.Equal(t, "100-abc.jpg", first.Media[0].Name)
+	assert.FileExists(t, first.Media[0].Path)
+
+	reply := parsed.Posts[1]
+	assert.True(t, reply.IsReply())
+	assert.Equal(t, "100", reply.ReplyTo.SourceID)
+	assert.Equal(t, "me", reply.ReplyTo.Username)
+	assert.Equal(t, "https://x.com/me/status/100", reply.ReplyTo.URL)
+}
+
+func TestParse_MissingTweets(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "data"), 0o755))
+	_, err := Parse(root)
+	require.Error(t, err)
+}
@@ -0,0 +1,477 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package snapshot
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/migrator/artifact"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+// 增量快照链：一份基线归档（全部文件）加若干增量归档（只含相对上一份变化的文件）。
+// 每份归档都带一份完整清单（ManifestName），列出该时刻 data/ 下的全部文件、内容哈希，
+// 以及字节实际存放在链上哪一份归档里。恢复时只看目标归档的清单，按清单从各归档取字节，
+// 不在清单里的文件即视为已删除——因此链上任意一份归档都是一个完整的还原点。
+//
+// 文件名形如 ech0_chain_<基线时刻>_<本份时刻>.zip：同一条链共享前缀、基线排在最前，
+// 按名排序即得「链内按时间、链间按时间」的顺序，裁剪与上传都无需下载清单。
+
+const (
+	// ManifestName 是清单在归档内的条目名，恢复时不落盘。
+	ManifestName = "ech0-manifest.json"
+	// MaxChainDepth 是一条链上增量归档数的上限，到达后下一次另起基线：链越长，恢复要读的
+	// 归档越多，坏掉一份波及的还原点也越多。
+	MaxChainDepth = 7
+	// LocalChainKeep 是本地保留的整链数：当前链加上一条完整链，新基线出问题时仍有退路。
+	LocalChainKeep = 2
+
+	manifestFormat = 1
+	chainPrefix    = "ech0_chain_"
+	// chainTimeLayout 与 artifact 的产物时间格式一致，字段从大到小排列，字典序即时间序。
+	chainTimeLayout = "2006-01-02_15-04-05"
+)
+
+// now 供测试固定时钟：同一秒内的两份归档会撞名。
+var now = time.Now
+
+// ErrBrokenChain 表示目标归档依赖的某份归档缺失或内容对不上，无法据此恢复。
+var ErrBrokenChain = errors.New("snapshot: chain is incomplete")
+
+// Manifest 是链上一份归档的清单。
+type Manifest struct {
+	Format    int            `json:"format"`
+	Name      string         `json:"name"`
+	Base      string         `json:"base"`
+	Parent    string         `json:"parent,omitempty"`
+	Depth     int            `json:"depth"`
+	CreatedAt time.Time      `json:"created_at"`
+	Files     []ManifestFile `json:"files"`
+}
+
+// ManifestFile 是清单中的一个文件。ModTime 只用来在下一次增量时跳过未改动文件的哈希计算，
+// 判定内容是否变化始终以 SHA256 为准。
+type ManifestFile struct {
+	Key     string `json:"key"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mtime"`
+	SHA256  string `json:"sha256"`
+	Archive string `json:"archive"`
+}
+
+// ChainResult 描述一次增量快照的结果。Unchanged 为 true 时没有写新归档，Path/Name 指向
+// 仍然代表当前状态的链头。
+type ChainResult struct {
+	Path      string `json:"-"`
+	Name      string `json:"name"`
+	Base      bool   `json:"base"`
+	Depth     int    `json:"depth"`
+	Changed   int    `json:"changed"`
+	Total     int    `json:"total"`
+	Size      int64  `json:"size"`
+	Unchanged bool   `json:"unchanged,omitempty"`
+}
+
+// ChainDir 返回本地增量链目录。
+func ChainDir() string {
+	return filepath.Join(dataDir, artifact.ChainDir)
+}
+
+// CreateIncremental 在本地增量链上追加一份归档：链为空、链头清单读不出、链已达
+// MaxChainDepth 或增量累计体积超过基线时另起基线，否则只打入相对链头变化的文件。
+// 与链头相比没有任何变化时不写归档。写完后按整链裁剪本地旧链。
+func CreateIncremental(opts ...CreateOption) (ChainResult, error) {
+	var cfg createConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	dir := ChainDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return ChainResult{}, fmt.Errorf("create snapshot chain dir: %w", err)
+	}
+	names, err := listChain(dir)
+	if err != nil {
+		return ChainResult{}, err
+	}
+
+	var head *Manifest
+	if len(names) > 0 {
+		if head, err = ReadManifest(filepath.Join(dir, names[len(names)-1])); err != nil {
+			logUtil.GetLogger().Warn("Failed to read snapshot chain head, starting a new base",
+				slog.String("archive", names[len(names)-1]), logUtil.Err(err))
+			head = nil
+		}
+	}
+
+	ctx := context.Background()
+	packFS, keys, cleanup, err := packView(ctx, cfg)
+	if err != nil {
+		return ChainResult{}, err
+	}
+	defer cleanup()
+	sort.Strings(keys)
+
+	at := now().UTC()
+	next := Manifest{Format: manifestFormat, CreatedAt: at}
+	prev := map[string]ManifestFile{}
+	if head == nil || head.Depth >= MaxChainDepth || deltaOutgrewBase(dir, names, head.Base) {
+		next.Name = chainName(at.Format(chainTimeLayout), at)
+		next.Base = next.Name
+	} else {
+		next.Name = chainName(chainID(head.Base), at)
+		next.Base = head.Base
+		next.Parent = head.Name
+		next.Depth = head.Depth + 1
+		for _, f := range head.Files {
+			prev[f.Key] = f
+		}
+	}
+
+	var changed []int
+	for _, key := range keys {
+		info, statErr := packFS.Stat(ctx, key)
+		if statErr != nil {
+			return ChainResult{}, fmt.Errorf("stat %s: %w", key, statErr)
+		}
+		entry := ManifestFile{Key: key, Size: info.Size, ModTime: info.LastModified.UnixNano()}
+		old, seen := prev[key]
+		if seen && old.Size == entry.Size && old.ModTime == entry.ModTime {
+			entry.SHA256, entry.Archive = old.SHA256, old.Archive
+		} else {
+			sum, hashErr := hashKey(ctx, packFS, key)
+			if hashErr != nil {
+				return ChainResult{}, hashErr
+			}
+			entry.SHA256 = sum
+			if seen && old.SHA256 == sum {
+				entry.Archive = old.Archive
+			} else {
+				entry.Archive = next.Name
+				changed = append(changed, len(next.Files))
+			}
+		}
+		next.Files = append(next.Files, entry)
+	}
+
+	if next.Parent != "" && len(changed) == 0 && len(next.Files) == len(head.Files) {
+		path := filepath.Join(dir, head.Name)
+		var size int64
+		if info, statErr := os.Stat(path); statErr == nil {
+			size = info.Size()
+		}
+		return ChainResult{
+			Path: path, Name: head.Name, Base: head.Parent == "", Depth: head.Depth,
+			Total: len(head.Files), Size: size, Unchanged: true,
+		}, nil
+	}
+
+	path := filepath.Join(dir, next.Name)
+	if _, statErr := os.Stat(path); statErr == nil {
+		return ChainResult{}, fmt.Errorf("snapshot chain archive %s already exists", next.Name)
+	}
+	if err := writeChainArchive(ctx, packFS, &next, changed, path); err != nil {
+		return ChainResult{}, err
+	}
+
+	if err := pruneLocalChains(dir, LocalChainKeep); err != nil {
+		logUtil.GetLogger().Warn("Failed to prune local snapshot chains", logUtil.Err(err))
+	}
+
+	var size int64
+	if info, statErr := os.Stat(path); statErr == nil {
+		size = info.Size()
+	}
+	return ChainResult{
+		Path: path, Name: next.Name, Base: next.Parent == "", Depth: next.Depth,
+		Changed: len(changed), Total: len(next.Files), Size: size,
+	}, nil
+}
+
+// writeChainArchive 把变化的文件与清单写成一份归档（先写临时文件再改名）。变化文件的哈希与
+// 大小在写入时重新计算：两趟读之间文件可能又被改过，清单必须描述归档里真实的字节。
+func writeChainArchive(ctx context.Context, packFS virefs.FS, m *Manifest, changed []int, path string) error {
+	tempPath := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	f, err := os.Create(tempPath)
+	if err != nil {
+		return fmt.Errorf("create chain archive: %w", err)
+	}
+	fail := func(err error) error {
+		_ = f.Close()
+		_ = os.Remove(tempPath)
+		return err
+	}
+
+	zw := zip.NewWriter(f)
+	for _, i := range changed {
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+		entry := &m.Files[i]
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: entry.Key, Method: zip.Deflate})
+		if err != nil {
+			return fail(err)
+		}
+		rc, err := packFS.Get(ctx, entry.Key)
+		if err != nil {
+			return fail(fmt.Errorf("read %s: %w", entry.Key, err))
+		}
+		h := sha256.New()
+		n, copyErr := io.Copy(io.MultiWriter(w, h), rc)
+		_ = rc.Close()
+		if copyErr != nil {
+			return fail(fmt.Errorf("pack %s: %w", entry.Key, copyErr))
+		}
+		entry.Size, entry.SHA256 = n, hex.EncodeToString(h.Sum(nil))
+	}
+
+	w, err := zw.Create(ManifestName)
+	if err != nil {
+		return fail(err)
+	}
+	if err := json.NewEncoder(w).Encode(m); err != nil {
+		return fail(fmt.Errorf("write manifest: %w", err))
+	}
+	if err := zw.Close(); err != nil {
+		return fail(fmt.Errorf("close chain archive: %w", err))
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("close chain archive: %w", err)
+	}
+	if err := os.Rename(tempPath, path); err != nil {
+		_ = os.Remove(tempPath)
+		return fmt.Errorf("finalize chain archive: %w", err)
+	}
+	return nil
+}
+
+// ReadManifest 读出链上归档的清单。不是链归档（例如全量快照 zip）时返回错误。
+func ReadManifest(path string) (*Manifest, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("open chain archive: %w", err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	for _, zf := range zr.File {
+		if zf.Name != ManifestName {
+			continue
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return nil, fmt.Errorf("open manifest: %w", err)
+		}
+		defer func() { _ = rc.Close() }()
+		var m Manifest
+		if err := json.NewDecoder(rc).Decode(&m); err != nil {
+			return nil, fmt.Errorf("decode manifest: %w", err)
+		}
+		if m.Format != manifestFormat {
+			return nil, fmt.Errorf("unsupported manifest format %d", m.Format)
+		}
+		return &m, nil
+	}
+	return nil, fmt.Errorf("%s has no %s", filepath.Base(path), ManifestName)
+}
+
+// Rebuild 按 target 的清单把 dir 中的链还原成完整的 data 目录写到 destDir。target 为空时取
+// 链头。每个文件都按清单校验哈希；依赖的归档缺失、缺条目或内容不符时返回 ErrBrokenChain。
+func Rebuild(ctx context.Context, dir, target, destDir string) (*Manifest, error) {
+	if target == "" {
+		names, err := listChain(dir)
+		if err != nil {
+			return nil, err
+		}
+		if len(names) == 0 {
+			return nil, ErrNoSnapshot
+		}
+		target = names[len(names)-1]
+	}
+
+	m, err := ReadManifest(filepath.Join(dir, target))
+	if err != nil {
+		return nil, err
+	}
+
+	byArchive := map[string][]ManifestFile{}
+	for _, f := range m.Files {
+		if chainID(f.Archive) == "" || chainID(f.Archive) != chainID(m.Name) {
+			return nil, fmt.Errorf("%w: %s points outside its chain (%s)", ErrBrokenChain, f.Key, f.Archive)
+		}
+		byArchive[f.Archive] = append(byArchive[f.Archive], f)
+	}
+	archives := make([]string, 0, len(byArchive))
+	for name := range byArchive {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return nil, fmt.Errorf("%w: missing %s", ErrBrokenChain, name)
+		}
+		archives = append(archives, name)
+	}
+	sort.Strings(archives)
+
+	dst, err := virefs.NewLocalFS(destDir, virefs.WithCreateRoot())
+	if err != nil {
+		return nil, fmt.Errorf("open dest dir: %w", err)
+	}
+	for _, name := range archives {
+		if err := extractFiles(ctx, filepath.Join(dir, name), byArchive[name], dst); err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+func extractFiles(ctx context.Context, archivePath string, files []ManifestFile, dst virefs.FS) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return fmt.Errorf("%w: open %s: %v", ErrBrokenChain, filepath.Base(archivePath), err)
+	}
+	defer func() { _ = zr.Close() }()
+
+	index := make(map[string]*zip.File, len(zr.File))
+	for _, zf := range zr.File {
+		index[zf.Name] = zf
+	}
+	for _, f := range files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		zf := index[f.Key]
+		if zf == nil {
+			return fmt.Errorf("%w: %s not found in %s", ErrBrokenChain, f.Key, filepath.Base(archivePath))
+		}
+		rc, err := zf.Open()
+		if err != nil {
+			return fmt.Errorf("open %s: %w", f.Key, err)
+		}
+		h := sha256.New()
+		putErr := dst.Put(ctx, f.Key, io.TeeReader(rc, h))
+		_ = rc.Close()
+		if putErr != nil {
+			return fmt.Errorf("restore %s: %w", f.Key, putErr)
+		}
+		if hex.EncodeToString(h.Sum(nil)) != f.SHA256 {
+			return fmt.Errorf("%w: %s checksum mismatch in %s", ErrBrokenChain, f.Key, filepath.Base(archivePath))
+		}
+	}
+	return nil
+}
+
+func hashKey(ctx context.Context, fsys virefs.FS, key string) (string, error) {
+	rc, err := fsys.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("read %s: %w", key, err)
+	}
+	defer func() { _ = rc.Close() }()
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", fmt.Errorf("hash %s: %w", key, err)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// deltaOutgrewBase 报告链上增量归档的累计体积是否已超过基线：到这一步恢复要读的字节比
+// 重打一份基线还多，另起基线更划算。
+func deltaOutgrewBase(dir string, names []string, base string) bool {
+	var baseSize, deltaSize int64
+	for _, name := range names {
+		if chainID(name) != chainID(base) {
+			continue
+		}
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		if name == base {
+			baseSize = info.Size()
+		} else {
+			deltaSize += info.Size()
+		}
+	}
+	return deltaSize > baseSize
+}
+
+// pruneLocalChains 只保留最新的 keep 条链，旧链整条删除。链内从新往旧删，删到一半失败时
+// 剩下的仍是「基线 + 连续增量」，不会留下断链。
+func pruneLocalChains(dir string, keep int) error {
+	names, err := listChain(dir)
+	if err != nil {
+		return err
+	}
+	for _, chain := range staleChains(names, keep) {
+		for i := len(chain) - 1; i >= 0; i-- {
+			if err := os.Remove(filepath.Join(dir, chain[i])); err != nil {
+				return fmt.Errorf("remove %s: %w", chain[i], err)
+			}
+		}
+	}
+	return nil
+}
+
+// staleChains 把已排序的归档名按链分组，返回最新 keep 条之外的旧链（每条链内按名排序）。
+func staleChains(names []string, keep int) [][]string {
+	var chains [][]string
+	for _, name := range names {
+		if n := len(chains); n > 0 && chainID(chains[n-1][0]) == chainID(name) {
+			chains[n-1] = append(chains[n-1], name)
+			continue
+		}
+		chains = append(chains, []string{name})
+	}
+	if len(chains) <= keep {
+		return nil
+	}
+	return chains[:len(chains)-keep]
+}
+
+// listChain 列出 dir 下的链归档名（已排序），目录不存在时返回空。
+func listChain(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read snapshot chain dir: %w", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if !entry.IsDir() && chainID(entry.Name()) != "" {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func chainName(baseStamp string, at time.Time) string {
+	return chainPrefix + baseStamp + "_" + at.Format(chainTimeLayout) + ".zip"
+}
+
+// chainID 取归档名里的基线时刻，即所属链的标识；不是链归档名时返回空串。
+func chainID(name string) string {
+	rest, ok := strings.CutPrefix(name, chainPrefix)
+	if !ok {
+		return ""
+	}
+	rest, ok = strings.CutSuffix(rest, ".zip")
+	if !ok || len(rest) != 2*len(chainTimeLayout)+1 || rest[len(chainTimeLayout)] != '_' {
+		return ""
+	}
+	return rest[:len(chainTimeLayout)]
+}
@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+// chainWorkspace 切到临时工作目录并把时钟固定成可推进的假时钟：归档名精确到秒，
+// 连续两次快照在真实时钟下会撞名。
+func chainWorkspace(t *testing.T) func() {
+	t.Helper()
+	workspace := t.TempDir()
+	prevWD, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("getwd failed: %v", err)
+	}
+	if err := os.Chdir(workspace); err != nil {
+		t.Fatalf("chdir failed: %v", err)
+	}
+	clock := time.Date(2026, 8, 2, 10, 0, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+	t.Cleanup(func() {
+		now = time.Now
+		_ = os.Chdir(prevWD)
+	})
+	return func() { clock = clock.Add(time.Minute) }
+}
+
+func writeDataFile(t *testing.T, key, content string) {
+	t.Helper()
+	path := filepath.Join(dataDir, key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir %s failed: %v", key, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s failed: %v", key, err)
+	}
+}
+
+func readTree(t *testing.T, root string) map[string]string {
+	t.Helper()
+	out := map[string]string{}
+	if err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(root, path)
+		body, readErr := os.ReadFile(path)
+		out[filepath.ToSlash(rel)] = string(body)
+		return readErr
+	}); err != nil {
+		t.Fatalf("walk %s failed: %v", root, err)
+	}
+	return out
+}
+
+func TestCreateIncremental_DeltaHoldsOnlyChangesAndRebuildsEveryPoint(t *testing.T) {
+	tick := chainWorkspace(t)
+	writeDataFile(t, "ech0.db", "db-v1")
+	writeDataFile(t, "files/images/a.png", "a-v1")
+	writeDataFile(t, "files/images/b.png", "b")
+	writeDataFile(t, "files/tmp/skip.txt", "excluded")
+
+	base, err := CreateIncremental()
+	if err != nil {
+		t.Fatalf("base snapshot failed: %v", err)
+	}
+	if !base.Base || base.Changed != 3 || base.Total != 3 {
+		t.Fatalf("base should pack all 3 files, got %+v", base)
+	}
+
+	tick()
+	writeDataFile(t, "ech0.db", "db-v2")
+	if err := os.Remove(filepath.Join(dataDir, "files/images/b.png")); err != nil {
+		t.Fatalf("remove b failed: %v", err)
+	}
+	writeDataFile(t, "files/images/c.png", "c")
+
+	delta, err := CreateIncremental()
+	if err != nil {
+		t.Fatalf("delta snapshot failed: %v", err)
+	}
+	if delta.Base || delta.Depth != 1 || delta.Changed != 2 || delta.Total != 3 {
+		t.Fatalf("delta should carry db + c only, got %+v", delta)
+	}
+	m, err := ReadManifest(delta.Path)
+	if err != nil {
+		t.Fatalf("read delta manifest failed: %v", err)
+	}
+	if m.Parent != base.Name || m.Base != base.Name {
+		t.Fatalf("delta should link to the base, got parent=%s base=%s", m.Parent, m.Base)
+	}
+
+	tick()
+	again, err := CreateIncremental()
+	if err != nil {
+		t.Fatalf("unchanged snapshot failed: %v", err)
+	}
+	if !again.Unchanged || again.Name != delta.Name {
+		t.Fatalf("nothing changed, expected the head to be reused, got %+v", again)
+	}
+
+	head := filepath.Join(t.TempDir(), "head")
+	if _, err := Rebuild(context.Background(), ChainDir(), "", head); err != nil {
+		t.Fatalf("rebuild head failed: %v", err)
+	}
+	want := map[string]string{"ech0.db": "db-v2", "files/images/a.png": "a-v1", "files/images/c.png": "c"}
+	if got := readTree(t, head); !equalTree(got, want) {
+		t.Fatalf("head rebuild = %v, want %v", got, want)
+	}
+
+	past := filepath.Join(t.TempDir(), "past")
+	if _, err := Rebuild(context.Background(), ChainDir(), base.Name, past); err != nil {
+		t.Fatalf("rebuild base failed: %v", err)
+	}
+	want = map[string]string{"ech0.db": "db-v1", "files/images/a.png": "a-v1", "files/images/b.png": "b"}
+	if got := readTree(t, past); !equalTree(got, want) {
+		t.Fatalf("base rebuild = %v, want %v", got, want)
+	}
+}
+
+func TestRebuild_MissingBaseIsBrokenChain(t *testing.T) {
+	tick := chainWorkspace(t)
+	writeDataFile(t, "files/images/a.png", "a")
+	base, err := CreateIncremental()
+	if err != nil {
+		t.Fatalf("base snapshot failed: %v", err)
+	}
+	tick()
+	writeDataFile(t, "files/images/b.png", "b")
+	if _, err := CreateIncremental(); err != nil {
+		t.Fatalf("delta snapshot failed: %v", err)
+	}
+
+	if err := os.Remove(base.Path); err != nil {
+		t.Fatalf("remove base failed: %v", err)
+	}
+	_, err = Rebuild(context.Background(), ChainDir(), "", t.TempDir())
+	if !errors.Is(err, ErrBrokenChain) {
+		t.Fatalf("rebuild without its base should fail with ErrBrokenChain, got %v", err)
+	}
+}
+
+func TestCreateIncremental_NewBaseAtMaxDepthAndPrunesWholeChains(t *testing.T) {
+	tick := chainWorkspace(t)
+	// 基线要比增量大得多，才能确认另起基线是深度上限而不是体积触发的。
+	writeDataFile(t, "files/images/big.bin", incompressible(64<<10))
+
+	var bases int
+	for i := 0; i <= (MaxChainDepth+1)*LocalChainKeep; i++ {
+		writeDataFile(t, "files/note.txt", time.Duration(i).String())
+		res, err := CreateIncremental()
+		if err != nil {
+			t.Fatalf("snapshot %d failed: %v", i, err)
+		}
+		if res.Depth > MaxChainDepth {
+			t.Fatalf("snapshot %d exceeded MaxChainDepth: %+v", i, res)
+		}
+		if res.Base {
+			bases++
+		}
+		tick()
+	}
+	if bases != LocalChainKeep+1 {
+		t.Fatalf("expected %d bases, got %d", LocalChainKeep+1, bases)
+	}
+
+	names, err := listChain(ChainDir())
+	if err != nil {
+		t.Fatalf("list chain failed: %v", err)
+	}
+	if stale := staleChains(names, LocalChainKeep); len(stale) != 0 {
+		t.Fatalf("local dir should keep at most %d chains, stale: %v", LocalChainKeep, stale)
+	}
+	// 剩下的每条链都必须从基线开始，且都能完整重建。
+	for _, name := range names {
+		if _, err := Rebuild(context.Background(), ChainDir(), name, t.TempDir()); err != nil {
+			t.Fatalf("rebuild %s after pruning failed: %v", name, err)
+		}
+	}
+}
+
+func TestSyncChain_ResumesAndKeepsWholeChains(t *testing.T) {
+	tick := chainWorkspace(t)
+	writeDataFile(t, "files/images/big.bin", incompressible(64<<10))
+	for i := 0; i < 3; i++ {
+		writeDataFile(t, "files/note.txt", time.Duration(i).String())
+		if _, err := CreateIncremental(); err != nil {
+			t.Fatalf("snapshot %d failed: %v", i, err)
+		}
+		tick()
+	}
+	names, _ := listChain(ChainDir())
+
+	remote, err := virefs.NewLocalFS(t.TempDir(), virefs.WithCreateRoot())
+	if err != nil {
+		t.Fatalf("open remote failed: %v", err)
+	}
+	// 模拟上一次上传在传完第一份后被打断。
+	if err := putFile(context.Background(), remote, s3ChainPrefix+names[0], filepath.Join(ChainDir(), names[0])); err != nil {
+		t.Fatalf("seed remote failed: %v", err)
+	}
+
+	state, err := syncChain(context.Background(), ChainDir(), remote, func(ChainUpload) {})
+	if err != nil {
+		t.Fatalf("sync failed: %v", err)
+	}
+	if state.Skipped != 1 || state.Uploaded != len(names)-1 {
+		t.Fatalf("sync should resume after the first archive, got %+v", state)
+	}
+
+	// 远端有超出保留数的旧链时整条删除，链头所在的链原样保留。
+	stale := []string{
+		chainName("2020-01-01_00-00-00", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)),
+		chainName("2020-01-01_00-00-00", time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)),
+		chainName("2021-01-01_00-00-00", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)),
+		chainName("2022-01-01_00-00-00", time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)),
+	}
+	for _, name := range stale {
+		if err := putFile(context.Background(), remote, s3ChainPrefix+name, filepath.Join(ChainDir(), names[0])); err != nil {
+			t.Fatalf("seed stale chain failed: %v", err)
+		}
+	}
+	if _, err := syncChain(context.Background(), ChainDir(), remote, func(ChainUpload) {}); err != nil {
+		t.Fatalf("second sync failed: %v", err)
+	}
+	for i, name := range stale {
+		exists, _ := remote.Exists(context.Background(), s3ChainPrefix+name)
+		if wantGone := i < 2; exists == wantGone {
+			t.Fatalf("%s exists=%v after pruning", name, exists)
+		}
+	}
+	for _, name := range names {
+		if exists, _ := remote.Exists(context.Background(), s3ChainPrefix+name); !exists {
+			t.Fatalf("current chain archive %s should stay on the remote", name)
+		}
+	}
+}
+
+func TestChainID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "ech0_chain_2026-08-02_10-00-00_2026-08-02_11-00-00.zip", want: "2026-08-02_10-00-00"},
+		{name: "ech0_snapshot_2026-08-02_10-00-00.zip", want: ""},
+		{name: ".ech0_chain_2026-08-02_10-00-00_2026-08-02_11-00-00.zip.tmp", want: ""},
+		{name: "ech0_chain_2026-08-02_10-00-00.zip", want: ""},
+	}
+	for _, tc := range tests {
+		if got := chainID(tc.name); got != tc.want {
+			t.Fatalf("chainID(%q)=%q, expected %q", tc.name, got, tc.want)
+		}
+	}
+}
+
+// incompressible 返回压不动的内容：归档是 deflate 的，全零字节压完几乎不占体积。
+func incompressible(n int) string {
+	r := rand.New(rand.NewPCG(1, 2))
+	buf := make([]byte, n)
+	for i := range buf {
+		buf[i] = byte(r.Uint32())
+	}
+	return string(buf)
+}
+
+func equalTree(got, want map[string]string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for k, v := range want {
+		if got[k] != v {
+			return false
+		}
+	}
+	return true
+}
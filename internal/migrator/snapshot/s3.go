@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"path"
+	"path/filepath"
 	"sort"
 	"strings"
 
@@ -20,8 +22,20 @@ import (
 const (
 	s3SnapshotPrefix    = "snapshots/"
 	s3SnapshotKeepCount = 3
+	// 增量链单独放一个前缀：cleanupOldS3Snapshots 按「snapshots/ 下的 .zip」清理，链归档混进去
+	// 会被当成旧全量快照删掉。
+	s3ChainPrefix    = "snapshot-chains/"
+	s3ChainKeepCount = 3
 )
 
+// ChainUpload 是增量链同步到 S3 的进度与结果。
+type ChainUpload struct {
+	Total    int    `json:"total"`
+	Uploaded int    `json:"uploaded"`
+	Skipped  int    `json:"skipped"`
+	Current  string `json:"current,omitempty"`
+}
+
 // BuildS3FS creates a VireFS ObjectFS dedicated to snapshot operations.
 // It respects the user's PathPrefix but omits Schema (no file-type classification),
 // so snapshots are stored at <prefix>/snapshots/ech0_snapshot_xxx.zip.
@@ -102,6 +116,95 @@ func UploadToS3(ctx context.Context, snapshotFilePath, fileName string, cfg conf
 	return nil
 }
 
+// UploadChainToS3 把本地增量链同步到 S3 的 snapshot-chains/ 下，再按整链裁剪远端旧链。
+//
+// 同步按归档名顺序逐份进行，远端已有同名同大小的对象即跳过，因此中断后再跑一次就从断点
+// 续传。任何一份上传失败立即返回、不再上传其后的归档：远端因此始终是每条链的一个前缀，
+// 链上最后一份之前的依赖都在，不会出现断链。
+func UploadChainToS3(ctx context.Context, cfg config.StorageConfig, progress func(ChainUpload)) (ChainUpload, error) {
+	s3FS, err := BuildS3FS(cfg)
+	if err != nil {
+		return ChainUpload{}, err
+	}
+	return syncChain(ctx, ChainDir(), s3FS, progress)
+}
+
+func syncChain(ctx context.Context, dir string, remote virefs.FS, progress func(ChainUpload)) (ChainUpload, error) {
+	names, err := listChain(dir)
+	if err != nil {
+		return ChainUpload{}, err
+	}
+
+	state := ChainUpload{Total: len(names)}
+	for _, name := range names {
+		if err := ctx.Err(); err != nil {
+			return state, err
+		}
+		local, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			// 本地裁剪与同步并发时可能刚被删掉；它之后的归档若属同一条链也已一并删除。
+			continue
+		}
+		key := s3ChainPrefix + name
+		if info, statErr := remote.Stat(ctx, key); statErr == nil && info.Size == local.Size() {
+			state.Skipped++
+			continue
+		}
+
+		state.Current = name
+		progress(state)
+		if err := putFile(ctx, remote, key, filepath.Join(dir, name)); err != nil {
+			return state, fmt.Errorf("upload %s: %w", name, err)
+		}
+		state.Uploaded++
+		logUtil.GetLogger().Info("Snapshot chain archive uploaded to S3", slog.String("key", key))
+	}
+	state.Current = ""
+	progress(state)
+
+	if err := cleanupOldS3Chains(ctx, remote, s3ChainKeepCount); err != nil {
+		logUtil.GetLogger().Warn("Failed to cleanup old S3 snapshot chains", logUtil.Err(err))
+	}
+	return state, nil
+}
+
+func putFile(ctx context.Context, remote virefs.FS, key, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	return remote.Put(ctx, key, f)
+}
+
+// cleanupOldS3Chains 只保留远端最新的 keepCount 条链。与本地裁剪一样整链删除、链内从新往旧，
+// 删到一半失败时远端剩下的仍是可恢复的链前缀。
+func cleanupOldS3Chains(ctx context.Context, s3FS virefs.FS, keepCount int) error {
+	result, err := s3FS.List(ctx, s3ChainPrefix)
+	if err != nil {
+		return fmt.Errorf("list s3 snapshot chains: %w", err)
+	}
+
+	var names []string
+	for _, item := range result.Files {
+		if name := path.Base(strings.Trim(item.Key, "/")); !item.IsDir && chainID(name) != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, chain := range staleChains(names, keepCount) {
+		for i := len(chain) - 1; i >= 0; i-- {
+			key := s3ChainPrefix + chain[i]
+			if err := s3FS.Delete(ctx, key); err != nil {
+				return fmt.Errorf("delete %s: %w", key, err)
+			}
+			logUtil.GetLogger().Info("Deleted old S3 snapshot chain archive", slog.String("key", key))
+		}
+	}
+	return nil
+}
+
 // cleanupOldS3Snapshots lists files under the snapshots/ prefix and removes
 // all but the most recent keepCount files (sorted by name, which embeds
 // a UTC timestamp).
@@ -83,22 +83,62 @@ func Create(opts ...CreateOption) (string, string, error) {
 		return "", "", fmt.Errorf("create snapshot dir: %w", err)
 	}
 
+	ctx := context.Background()
+	packFS, keys, cleanup, err := packView(ctx, cfg)
+	if err != nil {
+		return "", "", err
+	}
+	defer cleanup()
+
+	f, err := os.Create(tempPath)
+	if err != nil {
+		return "", "", fmt.Errorf("create zip file: %w", err)
+	}
+
+	if err := vizip.Pack(ctx, packFS, keys, f); err != nil {
+		if closeErr := f.Close(); closeErr != nil {
+			logUtil.GetLogger().Warn("Failed to close snapshot zip after pack error",
+				slog.String("path", tempPath), slog.String("error", closeErr.Error()))
+		}
+		_ = os.Remove(tempPath)
+		return "", "", fmt.Errorf("pack zip: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tempPath)
+		return "", "", fmt.Errorf("close zip file: %w", err)
+	}
+
+	if err := os.Rename(tempPath, snapshotPath); err != nil {
+		_ = os.Remove(tempPath)
+		return "", "", fmt.Errorf("finalize snapshot zip: %w", err)
+	}
+
+	if err := slot.KeepOnly(fileName); err != nil {
+		return "", "", err
+	}
+
+	return snapshotPath, fileName, nil
+}
+
+// packView 返回打包视图与待打包的 key 列表（已排除产物目录，启用一致性副本时以固定名带上
+// 数据库副本）。全量快照与增量链共用它，保证两者打进去的是同一组文件。
+func packView(ctx context.Context, cfg createConfig) (virefs.FS, []string, func(), error) {
 	dataFS, err := virefs.NewLocalFS(dataDir)
 	if err != nil {
-		return "", "", fmt.Errorf("open data dir: %w", err)
+		return nil, nil, nil, fmt.Errorf("open data dir: %w", err)
 	}
 
 	packFS := virefs.FS(dataFS)
+	cleanup := func() {}
 	if cfg.dbCopy != nil {
-		stageFS, cleanup, stageErr := stageConsistentDB(cfg.dbCopy)
+		stageFS, stageCleanup, stageErr := stageConsistentDB(cfg.dbCopy)
 		if stageErr != nil {
-			return "", "", stageErr
+			return nil, nil, nil, stageErr
 		}
-		defer cleanup()
+		cleanup = stageCleanup
 		packFS = &dbOverlayFS{FS: dataFS, stage: stageFS}
 	}
 
-	ctx := context.Background()
 	var keys []string
 	if err := virefs.Walk(ctx, dataFS, "", func(key string, info virefs.FileInfo, walkErr error) error {
 		if walkErr != nil {
@@ -120,41 +160,14 @@ func Create(opts ...CreateOption) (string, string, error) {
 		keys = append(keys, cleanKey)
 		return nil
 	}); err != nil {
-		return "", "", fmt.Errorf("walk data dir: %w", err)
+		cleanup()
+		return nil, nil, nil, fmt.Errorf("walk data dir: %w", err)
 	}
 	if cfg.dbCopy != nil {
 		// 实时库文件已从 walk 中排除,此处以固定名打入一致性副本(由 dbOverlayFS 路由)。
 		keys = append(keys, dbFileName)
 	}
-
-	f, err := os.Create(tempPath)
-	if err != nil {
-		return "", "", fmt.Errorf("create zip file: %w", err)
-	}
-
-	if err := vizip.Pack(ctx, packFS, keys, f); err != nil {
-		if closeErr := f.Close(); closeErr != nil {
-			logUtil.GetLogger().Warn("Failed to close snapshot zip after pack error",
-				slog.String("path", tempPath), slog.String("error", closeErr.Error()))
-		}
-		_ = os.Remove(tempPath)
-		return "", "", fmt.Errorf("pack zip: %w", err)
-	}
-	if err := f.Close(); err != nil {
-		_ = os.Remove(tempPath)
-		return "", "", fmt.Errorf("close zip file: %w", err)
-	}
-
-	if err := os.Rename(tempPath, snapshotPath); err != nil {
-		_ = os.Remove(tempPath)
-		return "", "", fmt.Errorf("finalize snapshot zip: %w", err)
-	}
-
-	if err := slot.KeepOnly(fileName); err != nil {
-		return "", "", err
-	}
-
-	return snapshotPath, fileName, nil
+	return packFS, keys, cleanup, nil
 }
 
 // LatestPath 返回最新一份快照 zip 的路径，无可用快照时返回 ErrNoSnapshot。
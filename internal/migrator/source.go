@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migrator
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/migrator/snapshot"
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	"github.com/lin-snow/ech0/pkg/virefs"
+)
+
+// BlueskyRepoFile 是 Bluesky 直接上传 .car 时在暂存目录里的落点文件名。
+const BlueskyRepoFile = "repo.car"
+
+// sourceArchiveExts 按来源列出可上传的归档后缀。Mastodon 官方导出是 tar.gz，
+// Bluesky 官方导出是单个 .car（要带媒体时可与 blobs/ 一起打成 zip）。
+var sourceArchiveExts = map[string][]string{
+	migratorModel.MigrationSourceEch0:     {".zip"},
+	migratorModel.MigrationSourceMemos:    {".zip"},
+	migratorModel.MigrationSourceCapsule:  {".zip"},
+	migratorModel.MigrationSourceTwitter:  {".zip"},
+	migratorModel.MigrationSourceMastodon: {".zip", ".tar.gz", ".tgz"},
+	migratorModel.MigrationSourceBluesky:  {".zip", ".car"},
+}
+
+// SourceArchiveExt 返回文件名匹配到的归档后缀；该来源不接受此类文件时返回空串。
+func SourceArchiveExt(sourceType, fileName string) string {
+	name := strings.ToLower(strings.TrimSpace(fileName))
+	for _, ext := range sourceArchiveExts[sourceType] {
+		if strings.HasSuffix(name, ext) {
+			return ext
+		}
+	}
+	return ""
+}
+
+// UnpackSource 把上传的来源归档展开到 destDir。ext 取自 SourceArchiveExt；
+// 条目路径一律经 virefs 清洗，越级条目直接跳过。
+func UnpackSource(archivePath, ext, destDir string) error {
+	switch ext {
+	case ".zip":
+		return snapshot.Unpack(archivePath, destDir)
+	case ".tar.gz", ".tgz":
+		return unpackTarGz(archivePath, destDir)
+	case ".car":
+		return copyFile(archivePath, filepath.Join(destDir, BlueskyRepoFile))
+	default:
+		return fmt.Errorf("unsupported source archive: %q", ext)
+	}
+}
+
+func unpackTarGz(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("open archive: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("open gzip: %w", err)
+	}
+	defer func() { _ = gz.Close() }()
+
+	dstFS, err := virefs.NewLocalFS(destDir, virefs.WithCreateRoot())
+	if err != nil {
+		return fmt.Errorf("open dest dir: %w", err)
+	}
+	ctx := context.Background()
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read tar: %w", err)
+		}
+		// 只展开普通文件：链接与设备文件在导入里没有用处，还可能被用来指向暂存目录之外。
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		key, err := virefs.CleanKey(hdr.Name)
+		if err != nil || key == "" {
+			continue
+		}
+		if err := dstFS.Put(ctx, key, tr); err != nil {
+			return err
+		}
+	}
+}
+
+func copyFile(srcPath, dstPath string) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = src.Close() }()
+	if err := os.MkdirAll(filepath.Dir(dstPath), 0o755); err != nil {
+		return err
+	}
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = dst.Close() }()
+	if _, err := io.Copy(dst, src); err != nil {
+		return err
+	}
+	return dst.Sync()
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package migrator
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+)
+
+func TestSourceArchiveExt(t *testing.T) {
+	cases := []struct {
+		source, name, want string
+	}{
+		{migratorModel.MigrationSourceEch0, "backup.ZIP", ".zip"},
+		{migratorModel.MigrationSourceEch0, "backup.tar.gz", ""},
+		{migratorModel.MigrationSourceTwitter, "twitter-2024.zip", ".zip"},
+		{migratorModel.MigrationSourceMastodon, "archive-2024.tar.gz", ".tar.gz"},
+		{migratorModel.MigrationSourceMastodon, "archive.tgz", ".tgz"},
+		{migratorModel.MigrationSourceBluesky, "repo.car", ".car"},
+		{migratorModel.MigrationSourceBluesky, "repo.txt", ""},
+		{"notion", "export.zip", ""},
+	}
+	for _, tc := range cases {
+		if got := SourceArchiveExt(tc.source, tc.name); got != tc.want {
+			t.Fatalf("SourceArchiveExt(%q, %q) = %q, want %q", tc.source, tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestUnpackSource_TarGz(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for name, body := range map[string]string{
+		"outbox.json":                   `{"orderedItems":[]}`,
+		"media_attachments/files/a.png": "png",
+		"../escape.txt":                 "nope",
+	} {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(body)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("write header: %v", err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatalf("write body: %v", err)
+		}
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: "link", Linkname: "/etc/passwd", Typeflag: tar.TypeSymlink}); err != nil {
+		t.Fatalf("write symlink: %v", err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatalf("close tar: %v", err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatalf("close gzip: %v", err)
+	}
+
+	base := t.TempDir()
+	archive := filepath.Join(base, "archive.tar.gz")
+	if err := os.WriteFile(archive, buf.Bytes(), 0o644); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	dest := filepath.Join(base, "out")
+	if err := UnpackSource(archive, ".tar.gz", dest); err != nil {
+		t.Fatalf("UnpackSource: %v", err)
+	}
+
+	if raw, err := os.ReadFile(filepath.Join(dest, "media_attachments", "files", "a.png")); err != nil || string(raw) != "png" {
+		t.Fatalf("expected nested file to be unpacked, got %q err=%v", raw, err)
+	}
+	if _, err := os.Stat(filepath.Join(base, "escape.txt")); !os.IsNotExist(err) {
+		t.Fatalf("expected traversal entry to be skipped")
+	}
+	if _, err := os.Lstat(filepath.Join(dest, "link")); !os.IsNotExist(err) {
+		t.Fatalf("expected symlink entry to be skipped")
+	}
+}
@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	"encoding/json"
+
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+const (
+	// ContentType 是 ActivityPub 对象的媒体类型（请求 Accept 与响应 Content-Type 均用它）。
+	ContentType = "application/activity+json"
+	// LDContentType 是部分实现在 Accept 中使用的等价 JSON-LD 写法。
+	LDContentType = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+	// JRDContentType 是 WebFinger 响应的媒体类型。
+	JRDContentType = "application/jrd+json"
+
+	ActivityStreamsContext = "https://www.w3.org/ns/activitystreams"
+	SecurityContext        = "https://w3id.org/security/v1"
+	// PublicAddress 是 ActivityStreams 的「公开」收件人。
+	PublicAddress = "https://www.w3.org/ns/activitystreams#Public"
+
+	// ActorKeySettingKey 是站长 actor RSA 密钥（PKCS#8 PEM）在键值存储中的键。
+	ActorKeySettingKey = "activitypub_actor_key"
+)
+
+// Activity 类型。
+const (
+	TypeCreate = "Create"
+	TypeUpdate = "Update"
+	TypeDelete = "Delete"
+	TypeFollow = "Follow"
+	TypeAccept = "Accept"
+	TypeUndo   = "Undo"
+	TypeLike   = "Like"
+)
+
+// Follower 是关注站长 actor 的远端账号。Inbox / SharedInbox 在 Follow 时从对方 actor 文档抓取。
+type Follower struct {
+	ID          string `gorm:"type:char(36);primaryKey" json:"id"`
+	ActorID     string `gorm:"size:512;not null;uniqueIndex" json:"actor_id"`
+	Handle      string `gorm:"size:255" json:"handle"` // user@host，仅用于展示
+	Inbox       string `gorm:"size:512;not null" json:"inbox"`
+	SharedInbox string `gorm:"size:512" json:"shared_inbox,omitempty"`
+	CreatedAt   int64  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (Follower) TableName() string { return "activitypub_followers" }
+
+func (f *Follower) BeforeCreate(_ *gorm.DB) error {
+	if f.ID == "" {
+		f.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// DeliveryInbox 优先返回共享收件箱，同一实例的多个关注者只投递一次。
+func (f Follower) DeliveryInbox() string {
+	if f.SharedInbox != "" {
+		return f.SharedInbox
+	}
+	return f.Inbox
+}
+
+// Like 记录远端账号对 Echo 的点赞，用于去重（同一账号对同一 Echo 只计一次）。
+type Like struct {
+	ID        string `gorm:"type:char(36);primaryKey" json:"id"`
+	ActorID   string `gorm:"size:512;not null;uniqueIndex:idx_activitypub_like_actor_echo" json:"actor_id"`
+	EchoID    string `gorm:"type:char(36);not null;uniqueIndex:idx_activitypub_like_actor_echo" json:"echo_id"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (Like) TableName() string { return "activitypub_likes" }
+
+func (l *Like) BeforeCreate(_ *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// WebFinger 是 /.well-known/webfinger 的 JRD 响应。
+type WebFinger struct {
+	Subject string          `json:"subject"`
+	Aliases []string        `json:"aliases,omitempty"`
+	Links   []WebFingerLink `json:"links"`
+}
+
+type WebFingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type,omitempty"`
+	Href string `json:"href,omitempty"`
+}
+
+// Actor 是 Person 文档。对外输出本站 actor，也用于解析远端 actor（只取用到的字段）。
+type Actor struct {
+	Context           any        `json:"@context,omitempty"`
+	ID                string     `json:"id"`
+	Type              string     `json:"type"`
+	PreferredUsername string     `json:"preferredUsername"`
+	Name              string     `json:"name,omitempty"`
+	Summary           string     `json:"summary,omitempty"`
+	URL               string     `json:"url,omitempty"`
+	Icon              *Image     `json:"icon,omitempty"`
+	Inbox             string     `json:"inbox"`
+	Outbox            string     `json:"outbox,omitempty"`
+	Followers         string     `json:"followers,omitempty"`
+	Endpoints         *Endpoints `json:"endpoints,omitempty"`
+	PublicKey         PublicKey  `json:"publicKey"`
+}
+
+type Endpoints struct {
+	SharedInbox string `json:"sharedInbox,omitempty"`
+}
+
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+type Image struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+}
+
+// Note 是一条 Echo 的 ActivityPub 表示。
+type Note struct {
+	Context      any          `json:"@context,omitempty"`
+	ID           string       `json:"id"`
+	Type         string       `json:"type"`
+	AttributedTo string       `json:"attributedTo"`
+	Content      string       `json:"content"`
+	URL          string       `json:"url"`
+	Published    string       `json:"published"`
+	Updated      string       `json:"updated,omitempty"`
+	To           []string     `json:"to"`
+	Cc           []string     `json:"cc,omitempty"`
+	Attachment   []Attachment `json:"attachment,omitempty"`
+	Tag          []Tag        `json:"tag,omitempty"`
+}
+
+type Attachment struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+	Name      string `json:"name,omitempty"`
+}
+
+type Tag struct {
+	Type string `json:"type"`
+	Href string `json:"href,omitempty"`
+	Name string `json:"name"`
+}
+
+// Tombstone 是被删除 Note 的占位。
+type Tombstone struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Activity 是发出的活动；Object 可以是内嵌对象或对象 ID。
+type Activity struct {
+	Context   any      `json:"@context,omitempty"`
+	ID        string   `json:"id"`
+	Type      string   `json:"type"`
+	Actor     string   `json:"actor"`
+	Published string   `json:"published,omitempty"`
+	To        []string `json:"to,omitempty"`
+	Cc        []string `json:"cc,omitempty"`
+	Object    any      `json:"object"`
+}
+
+// InboundActivity 是收件箱收到的活动，Object 延迟解析（可能是 ID 字符串或内嵌对象）。
+type InboundActivity struct {
+	ID     string          `json:"id"`
+	Type   string          `json:"type"`
+	Actor  string          `json:"actor"`
+	Object json.RawMessage `json:"object"`
+}
+
+// InboundObject 是内嵌对象中收件箱关心的字段（Follow / Like 的被 Undo 对象、回复 Note）。
+type InboundObject struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     string          `json:"actor"`
+	Object    json.RawMessage `json:"object"`
+	Content   string          `json:"content"`
+	InReplyTo string          `json:"inReplyTo"`
+	URL       json.RawMessage `json:"url"`
+}
+
+// OrderedCollection 是 outbox / followers 的集合根；First 指向第一页。
+type OrderedCollection struct {
+	Context    any    `json:"@context,omitempty"`
+	ID         string `json:"id"`
+	Type       string `json:"type"`
+	TotalItems int64  `json:"totalItems"`
+	First      string `json:"first,omitempty"`
+}
+
+type OrderedCollectionPage struct {
+	Context      any    `json:"@context,omitempty"`
+	ID           string `json:"id"`
+	Type         string `json:"type"`
+	PartOf       string `json:"partOf"`
+	TotalItems   int64  `json:"totalItems"`
+	Next         string `json:"next,omitempty"`
+	Prev         string `json:"prev,omitempty"`
+	OrderedItems []any  `json:"orderedItems"`
+}
@@ -4,6 +4,9 @@
 package model
 
 import (
+	"slices"
+
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
 	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	"gorm.io/gorm"
 )
@@ -22,6 +25,8 @@ const (
 	SourceGuest       SourceType = "guest"
 	SourceSystem      SourceType = "system"
 	SourceIntegration SourceType = "integration"
+	SourceFediverse   SourceType = "fediverse"  // 经 ActivityPub 收件箱收到的回复
+	SourceWebmention  SourceType = "webmention" // 经 /webmention 收到并校验通过的提及
 )
 
 const (
@@ -31,7 +36,9 @@ const (
 type Comment struct {
 	ID        string     `gorm:"type:char(36);primaryKey" json:"id"`
 	EchoID    string     `gorm:"type:char(36);not null;index" json:"echo_id"`
-	ParentID  *string    `gorm:"type:char(36);index" json:"parent_id,omitempty"` // NULL=顶层楼，非空=该楼的回复（两级盖楼）
+	ParentID  *string    `gorm:"type:char(36);index" json:"parent_id,omitempty"`  // NULL=顶层评论，非空=直接回复的评论（深度不限）
+	Path      string     `gorm:"type:text;not null;default:'';index" json:"path"` // 物化路径：祖先 ID 与自身 ID 以 / 连接，子树查询用前缀匹配
+	Depth     int        `gorm:"not null;default:0" json:"depth"`                 // 顶层为 0
 	UserID    *string    `gorm:"type:char(36);index" json:"user_id,omitempty"`
 	Nickname  string     `gorm:"size:100;not null;index" json:"nickname"`
 	Email     string     `gorm:"size:255;not null;index" json:"email"`
@@ -42,40 +49,59 @@ type Comment struct {
 	IPHash    string     `gorm:"size:128;index" json:"-"`
 	UserAgent string     `gorm:"size:512" json:"-"`
 	Source    SourceType `gorm:"type:varchar(20);not null;index" json:"source"`
-	CreatedAt int64      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt int64      `gorm:"autoUpdateTime" json:"updated_at"`
+	RemoteID  string     `gorm:"size:512;index" json:"remote_id,omitempty"` // fediverse 回复的 Note ID / webmention 的来源地址，用于去重与回复链
+	// 垃圾检测结果：由哪个后端检测、得分（0~1，越高越像垃圾）与结论；未检测时为空。
+	SpamBackend SpamProvider `gorm:"size:16;not null;default:''" json:"spam_backend,omitempty"`
+	SpamScore   float64      `gorm:"not null;default:0" json:"spam_score"`
+	SpamVerdict SpamVerdict  `gorm:"size:16;not null;default:'';index" json:"spam_verdict,omitempty"`
+	// SpamLabel 是这条评论已训练进内置分类器的标签，审核结论改变时据此撤销旧样本。
+	SpamLabel SpamVerdict `gorm:"size:16;not null;default:''" json:"-"`
+	CreatedAt int64       `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt int64       `gorm:"autoUpdateTime" json:"updated_at"`
+	DeletedAt int64       `gorm:"default:0;index" json:"deleted_at,omitempty"` // 非 0 = 已移入回收站
 }
 
 // PublicComment 是面向匿名访问者的安全投影，剥离 Email/IPHash/UserAgent/UserID
 // 等可能用于关联或骚扰的字段。仅供 /api/comments、/api/comments/public 与 MCP
 // 公共资源等无鉴权出口使用。后台管理仍直接返回 Comment 以便审核。
+//
+// ContentHTML 是 Content 经 Markdown 渲染与白名单过滤后的 HTML，前端可直接插入页面；
+// Reactions 由服务层按需填充。
 type PublicComment struct {
-	ID        string     `json:"id"`
-	EchoID    string     `json:"echo_id"`
-	ParentID  *string    `json:"parent_id,omitempty"`
-	Nickname  string     `json:"nickname"`
-	Website   string     `json:"website,omitempty"`
-	Content   string     `json:"content"`
-	Status    Status     `json:"status"`
-	Hot       bool       `json:"hot"`
-	Source    SourceType `json:"source"`
-	CreatedAt int64      `json:"created_at"`
-	UpdatedAt int64      `json:"updated_at"`
+	ID          string          `json:"id"`
+	EchoID      string          `json:"echo_id"`
+	ParentID    *string         `json:"parent_id,omitempty"`
+	Path        string          `json:"path"`
+	Depth       int             `json:"depth"`
+	Nickname    string          `json:"nickname"`
+	Website     string          `json:"website,omitempty"`
+	Content     string          `json:"content"`
+	ContentHTML string          `json:"content_html"`
+	Status      Status          `json:"status"`
+	Hot         bool            `json:"hot"`
+	Source      SourceType      `json:"source"`
+	Reactions   []ReactionCount `json:"reactions"`
+	CreatedAt   int64           `json:"created_at"`
+	UpdatedAt   int64           `json:"updated_at"`
 }
 
 func ToPublicComment(c Comment) PublicComment {
 	return PublicComment{
-		ID:        c.ID,
-		EchoID:    c.EchoID,
-		ParentID:  c.ParentID,
-		Nickname:  c.Nickname,
-		Website:   c.Website,
-		Content:   c.Content,
-		Status:    c.Status,
-		Hot:       c.Hot,
-		Source:    c.Source,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+		ID:          c.ID,
+		EchoID:      c.EchoID,
+		ParentID:    c.ParentID,
+		Path:        c.Path,
+		Depth:       c.Depth,
+		Nickname:    c.Nickname,
+		Website:     c.Website,
+		Content:     c.Content,
+		ContentHTML: mdUtil.CommentToHTML(c.Content),
+		Status:      c.Status,
+		Hot:         c.Hot,
+		Source:      c.Source,
+		Reactions:   []ReactionCount{},
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
 	}
 }
 
@@ -91,9 +117,67 @@ func (c *Comment) BeforeCreate(_ *gorm.DB) error {
 	if c.ID == "" {
 		c.ID = uuidUtil.MustNewV7()
 	}
+	if c.Path == "" {
+		c.Path = c.ID
+	}
 	return nil
 }
 
+// PathSeparator 分隔物化路径中的各级评论 ID。
+const PathSeparator = "/"
+
+// AttachTo 把评论挂到 parent 之下：预先生成 ID，并据父评论的路径填好 ParentID / Path / Depth。
+// parent 为 nil 时保持顶层评论（Path 在 BeforeCreate 中补为自身 ID）。
+func (c *Comment) AttachTo(parent *Comment) {
+	if parent == nil {
+		return
+	}
+	if c.ID == "" {
+		c.ID = uuidUtil.MustNewV7()
+	}
+	parentPath := parent.Path
+	if parentPath == "" {
+		parentPath = parent.ID
+	}
+	c.ParentID = &parent.ID
+	c.Path = parentPath + PathSeparator + c.ID
+	c.Depth = parent.Depth + 1
+}
+
+// CommentReaction 是某个访客 / 用户对一条评论的一个表情回应。
+// ActorKey 标识回应者（登录用户为 "user:<id>"，访客为 "ip:<IP 哈希>"），
+// 同一人对同一评论的同一表情只记一次。
+type CommentReaction struct {
+	ID        string  `gorm:"type:char(36);primaryKey" json:"id"`
+	CommentID string  `gorm:"type:char(36);not null;uniqueIndex:idx_comment_reaction_actor,priority:1" json:"comment_id"`
+	Emoji     string  `gorm:"size:16;not null;uniqueIndex:idx_comment_reaction_actor,priority:2" json:"emoji"`
+	ActorKey  string  `gorm:"size:160;not null;uniqueIndex:idx_comment_reaction_actor,priority:3" json:"-"`
+	IPHash    string  `gorm:"size:128;index" json:"-"`
+	UserID    *string `gorm:"type:char(36);index" json:"-"`
+	CreatedAt int64   `gorm:"autoCreateTime;index" json:"created_at"`
+}
+
+func (r *CommentReaction) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// Reactions 是允许使用的表情回应，顺序即前端展示顺序。
+var Reactions = []string{"👍", "❤️", "😄", "🎉", "😕", "👀"}
+
+// IsAllowedReaction 判断 emoji 是否在 Reactions 中。
+func IsAllowedReaction(emoji string) bool {
+	return slices.Contains(Reactions, emoji)
+}
+
+// ReactionCount 是一条评论上某个表情的回应数。
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int64  `json:"count"`
+}
+
 type CreateCommentDto struct {
 	EchoID        string `json:"echo_id" binding:"required"`
 	ParentID      string `json:"parent_id"` // 可选：回复的目标评论 ID（空=顶层评论）
@@ -106,6 +190,16 @@ type CreateCommentDto struct {
 	CaptchaToken  string `json:"captcha_token"`
 }
 
+type ReactCommentDto struct {
+	Emoji string `json:"emoji" binding:"required" enum:"👍,❤️,😄,🎉,😕,👀" doc:"表情回应；已回应过则取消"`
+}
+
+// ReactCommentResult 是切换表情回应后的结果：该评论最新的回应计数与调用方是否仍在回应。
+type ReactCommentResult struct {
+	Reactions []ReactionCount `json:"reactions"`
+	Reacted   bool            `json:"reacted"`
+}
+
 type CreateCommentResult struct {
 	ID     string `json:"id"`
 	Status Status `json:"status"`
@@ -118,6 +212,26 @@ type CreateIntegrationCommentDto struct {
 	Metadata string `json:"metadata"`
 }
 
+// CreateFederatedCommentDto 是 ActivityPub 回复落地为评论的入参。
+// ParentRemoteID 非空时表示回复的是另一条 fediverse 回复。
+type CreateFederatedCommentDto struct {
+	EchoID         string
+	RemoteID       string
+	ParentRemoteID string
+	Nickname       string
+	Website        string
+	Content        string
+}
+
+// CreateWebmentionCommentDto 是校验通过的 Webmention 落地为评论的入参。
+// Source 是提及所在页面的地址，同一页面对同一 Echo 只落地一条。
+type CreateWebmentionCommentDto struct {
+	EchoID   string
+	Source   string
+	Nickname string
+	Content  string
+}
+
 type UpdateCommentStatusDto struct {
 	Status Status `json:"status" binding:"required"`
 }
@@ -127,7 +241,7 @@ type UpdateCommentHotDto struct {
 }
 
 type BatchCommentActionDto struct {
-	Action string   `json:"action" binding:"required"`
+	Action string   `json:"action" binding:"required" doc:"approve / reject / delete（移入回收站）/ restore / purge（彻底删除）"`
 	IDs    []string `json:"ids" binding:"required"`
 }
 
@@ -138,6 +252,7 @@ type ListCommentQuery struct {
 	Status   string
 	EchoID   string
 	Hot      *bool
+	Trashed  bool // true 时只列回收站中的评论，否则排除回收站
 }
 
 type PageResult[T any] struct {
@@ -151,13 +266,29 @@ type FormMeta struct {
 	CaptchaEnabled     bool   `json:"captcha_enabled"`
 	CaptchaAPIEndpoint string `json:"captcha_api_endpoint"`
 	EnableComment      bool   `json:"enable_comment"`
+	// 以下字段仅在请求带 echo_id 时填充，说明这条 Echo 当前能否评论、为什么不能。
+	CommentPolicy   string       `json:"comment_policy,omitempty"`
+	ClosedReason    ClosedReason `json:"closed_reason,omitempty"`
+	LoginRequired   bool         `json:"login_required,omitempty"`
+	RequireApproval bool         `json:"require_approval,omitempty"`
+	ClosesAt        int64        `json:"closes_at,omitempty" doc:"自动关闭评论的时刻（Unix 秒），0 表示不会自动关闭"`
 }
 
+// ClosedReason 说明某条 Echo 为什么不接受评论。
+type ClosedReason string
+
+const (
+	ClosedReasonClosed     ClosedReason = "closed"      // 作者关闭了这条 Echo 的评论
+	ClosedReasonAutoClosed ClosedReason = "auto_closed" // 发布已满全局设置的天数
+)
+
 type SystemSetting struct {
 	EnableComment   bool               `json:"enable_comment"`
 	RequireApproval bool               `json:"require_approval"`
 	CaptchaEnabled  bool               `json:"captcha_enabled"`
+	AutoCloseDays   int                `json:"auto_close_days" doc:"Echo 发布满 N 天后自动关闭评论，0 为不关闭"`
 	EmailNotify     EmailNotifySetting `json:"email_notify"`
+	Spam            SpamSetting        `json:"spam"`
 }
 
 type EmailNotifySetting struct {
@@ -58,6 +58,16 @@ func TestToPublicComment(t *testing.T) {
 		assert.Same(t, c.ParentID, got.ParentID)
 	})
 
+	t.Run("content_is_rendered_and_sanitized", func(t *testing.T) {
+		c := newFullComment()
+		c.Content = "*hi* <script>x()</script>"
+		got := ToPublicComment(c)
+		assert.Equal(t, c.Content, got.Content)
+		assert.Contains(t, got.ContentHTML, "<em>hi</em>")
+		assert.NotContains(t, got.ContentHTML, "<script")
+		assert.NotNil(t, got.Reactions)
+	})
+
 	t.Run("nil_parent_id_stays_nil", func(t *testing.T) {
 		c := newFullComment()
 		c.ParentID = nil
@@ -103,3 +113,33 @@ func TestToPublicComments(t *testing.T) {
 		assert.Equal(t, ToPublicComment(in[0]), got[0])
 	})
 }
+
+func TestAttachTo(t *testing.T) {
+	t.Run("nil_parent_keeps_top_level", func(t *testing.T) {
+		var c Comment
+		c.AttachTo(nil)
+		assert.Nil(t, c.ParentID)
+		assert.Equal(t, 0, c.Depth)
+		require.NoError(t, c.BeforeCreate(nil))
+		assert.Equal(t, c.ID, c.Path)
+	})
+
+	t.Run("nests_under_parent_path", func(t *testing.T) {
+		parent := Comment{ID: "b", Path: "a/b", Depth: 1}
+		var c Comment
+		c.AttachTo(&parent)
+		require.NotEmpty(t, c.ID)
+		require.NotNil(t, c.ParentID)
+		assert.Equal(t, "b", *c.ParentID)
+		assert.Equal(t, "a/b/"+c.ID, c.Path)
+		assert.Equal(t, 2, c.Depth)
+	})
+
+	t.Run("legacy_parent_without_path_falls_back_to_id", func(t *testing.T) {
+		parent := Comment{ID: "p"}
+		c := Comment{ID: "c"}
+		c.AttachTo(&parent)
+		assert.Equal(t, "p/c", c.Path)
+		assert.Equal(t, 1, c.Depth)
+	})
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+// SpamProvider 是评论垃圾检测的后端。
+type SpamProvider string
+
+const (
+	SpamProviderNone    SpamProvider = ""        // 不做垃圾检测
+	SpamProviderAkismet SpamProvider = "akismet" // Akismet 协议（官方服务或兼容实现）
+	SpamProviderBayes   SpamProvider = "bayes"   // 内置朴素贝叶斯分类器，由审核结论训练
+)
+
+// SpamVerdict 是垃圾检测的结论。
+type SpamVerdict string
+
+const (
+	SpamVerdictHam    SpamVerdict = "ham"
+	SpamVerdictSpam   SpamVerdict = "spam"
+	SpamVerdictUnsure SpamVerdict = "unsure" // 后端无法下结论（如分类器样本不足）
+)
+
+// DefaultSpamThreshold 是内置分类器判定为垃圾的默认得分阈值。
+const DefaultSpamThreshold = 0.9
+
+// NormalizeSpamThreshold 把阈值收敛到 0.5~0.99，未设置时取默认值。
+func NormalizeSpamThreshold(v float64) float64 {
+	switch {
+	case v <= 0:
+		return DefaultSpamThreshold
+	case v < 0.5:
+		return 0.5
+	case v > 0.99:
+		return 0.99
+	}
+	return v
+}
+
+// SpamSetting 是评论垃圾检测设置。AkismetKey 与 SMTPPassword 一样只写不读，
+// 读出口以 AkismetKeySet 表示是否已配置。
+type SpamSetting struct {
+	Provider        SpamProvider `json:"provider" enum:",akismet,bayes" doc:"留空不检测"`
+	AkismetKey      string       `json:"akismet_key,omitempty"`
+	AkismetKeySet   bool         `json:"akismet_key_set,omitempty"`
+	AkismetEndpoint string       `json:"akismet_endpoint" doc:"Akismet 协议服务地址，留空使用官方 https://rest.akismet.com"`
+	Threshold       float64      `json:"threshold" doc:"内置分类器判定为垃圾的得分阈值（0.5~0.99）"`
+	RejectSpam      bool         `json:"reject_spam" doc:"true 时判定为垃圾的评论直接拒绝，否则留待审核"`
+}
+
+// SpamToken 是内置分类器的词频表：某个特征在垃圾 / 正常样本中各出现过多少条。
+type SpamToken struct {
+	Token string `gorm:"size:96;primaryKey" json:"token"`
+	Spam  int64  `gorm:"not null;default:0" json:"spam"`
+	Ham   int64  `gorm:"not null;default:0" json:"ham"`
+}
@@ -10,11 +10,12 @@ type Heatmap struct {
 }
 
 type (
-	UploadFileType string
-	S3Provider     string
-	OAuth2Provider string
-	AgentProtocol  string
-	Locale         string
+	UploadFileType    string
+	S3Provider        string
+	OAuth2Provider    string
+	AgentProtocol     string
+	EmbeddingProvider string
+	Locale            string
 )
 
 const (
@@ -49,6 +50,13 @@ const (
 	Anthropic AgentProtocol = "anthropic"
 )
 
+const (
+	// EmbeddingOpenAI 调用 OpenAI 兼容的 /v1/embeddings 接口
+	EmbeddingOpenAI EmbeddingProvider = "openai"
+	// EmbeddingLocal 内置本地向量化（纯 CPU、无网络）
+	EmbeddingLocal EmbeddingProvider = "local"
+)
+
 const (
 	LocaleZhCN     Locale = "zh-CN"
 	LocaleEnUS     Locale = "en-US"
@@ -115,12 +123,24 @@ const (
 	UserLocalAuthBackfilledKey = "user_local_auth_backfilled_v1"
 	// UsersPasswordColumnDroppedKey 是回填后删除 users.password 遗留列的幂等标记键
 	UsersPasswordColumnDroppedKey = "users_password_column_dropped_v1"
-	// ChatSessionKeyPrefix 是 Chat 持久化会话的键前缀（每个 userID 一条，键为前缀 + userID）
+	// ChatSessionKeyPrefix 是旧版 Chat 单会话的键前缀（每个 userID 一条，键为前缀 + userID）。
+	// 会话已迁入 copilot_threads，该前缀只剩迁移读取。
 	ChatSessionKeyPrefix = "chat_session:"
+	// ChatSessionsMigratedKey 是把旧版 KV 会话迁入 copilot_threads 的幂等标记键
+	ChatSessionsMigratedKey = "chat_sessions_to_threads_v1"
+	// UserRolesBackfilledKey 是按 is_owner / is_admin 回填 users.role 的幂等标记键
+	UserRolesBackfilledKey = "user_roles_backfilled_v1"
+	// CommentPathsBackfilledKey 是按 parent_id 回填 comments.path / depth 的幂等标记键
+	CommentPathsBackfilledKey = "comment_paths_backfilled_v1"
+	// LegacyJobsMigratedKey 是把旧版按类型单行的 jobs 表搬进 job_runs 的幂等标记键
+	LegacyJobsMigratedKey = "legacy_jobs_to_job_runs_v1"
 )
 
 // PageQueryResult 用于分页查询的结果数据传输对象
 type PageQueryResult[T any] struct {
 	Total int64 `json:"total"`
 	Items T     `json:"items"`
+	// Highlights 仅在带检索词的 Echo 查询中返回：键为 Echo ID，值为命中片段
+	// （已 HTML 转义，命中词以 <mark></mark> 包裹）。
+	Highlights map[string]string `json:"highlights,omitempty"`
 }
@@ -26,14 +26,53 @@ type EchoQueryDto struct {
 	// 0 或负数视为未设置。
 	DateFrom int64 `json:"dateFrom"`
 	DateTo   int64 `json:"dateTo"`
-	// Private：按可见性过滤的三态开关。nil 表示不过滤（现状行为：admin 公开+私密
-	// 混合，匿名仅公开）；true 仅私密、false 仅公开。仅当 viewer 具备私密可见权限
-	// （admin）时生效，无权限的请求在仓储层被静默忽略、仍强制仅公开。
+	// Private：按可见性过滤的三态开关。nil 表示不过滤（owner 公开+私密混合，
+	// 匿名仅公开）；true 仅私密、false 仅公开。仅作用于 viewer 看得到的私密 Echo
+	// （owner 全部、其余登录用户自己的），无权限的部分在仓储层被静默忽略、仍强制仅公开。
 	Private *bool `json:"private,omitempty"`
+	// Status：按发布状态过滤（published / draft / scheduled）。空串只返回已发布；
+	// 与 Private 一样只作用于 viewer 看得到的草稿，无权限时静默回落到 published，草稿不会外泄。
+	Status string `json:"status,omitempty"`
 	// UserID：按作者（echos.user_id）精确过滤。opt-in——空串表示不限定作者
 	// （公开 /echo/query 等调用方留空即保持原行为）；Copilot Chat 用它把检索
 	// 收口到当前对话用户本人发布的 Echo。不暴露给前端 JSON 契约，仅服务内部设置。
 	UserID string `json:"-"`
+	// EchoIDs：限定在给定 ID 集合内查询。同 UserID，仅服务内部设置——混合检索用它让
+	// 向量召回的候选重新经过 QueryEchos 的可见性与过滤条件，不会绕过私密裁决。
+	EchoIDs []string `json:"-"`
+	// ViewerID：调用方用户 ID，仅服务内部设置。没有全局私密可见权限（非 owner）的登录用户
+	// 由它放行自己名下的私密 / 未发布 Echo——Private / Status 过滤对这部分照常生效，
+	// 他人的 Echo 仍强制仅公开且已发布。
+	ViewerID string `json:"-"`
+}
+
+// EchoSearchDto 混合检索（关键词 + 语义）接口的请求体
+//
+// swagger:model EchoSearchDto
+type EchoSearchDto struct {
+	// Query：检索语句，语法同 EchoQueryDto.Search。
+	Query string `json:"query"`
+	// Limit：返回条数，缺省 10，上限 50。混合检索按融合得分取前 Limit 条，不分页。
+	Limit    int      `json:"limit,omitempty"`
+	TagIDs   []string `json:"tagIds,omitempty"`
+	DateFrom int64    `json:"dateFrom,omitempty"`
+	DateTo   int64    `json:"dateTo,omitempty"`
+	Private  *bool    `json:"private,omitempty"`
+	// UserID / Username：按作者收口（分别作用于 SQL 与向量索引的作者快照），仅服务内部设置。
+	UserID   string `json:"-"`
+	Username string `json:"-"`
+}
+
+// TagSuggestDto 标签建议接口的请求体
+//
+// swagger:model TagSuggestDto
+type TagSuggestDto struct {
+	// Content：草稿正文。
+	Content string `json:"content"`
+	// Tags：草稿已有的标签名，不会再被建议。
+	Tags []string `json:"tags,omitempty"`
+	// Limit：返回条数，缺省 5，上限 20。
+	Limit int `json:"limit,omitempty"`
 }
 
 // FileDto is the unified response for file operations.
@@ -96,6 +96,13 @@ const (
 	ECHO_CAN_NOT_BE_EMPTY      = "ECHO 内容不能为空"
 	ECHO_NOT_FOUND             = "找不到Echo"
 	ECHO_MIXED_FILE_CATEGORIES = "一条 Echo 只能包含同一类型的文件"
+	SEARCH_QUERY_EMPTY         = "检索语句不能为空"
+	TAG_SUGGEST_CONTENT_EMPTY  = "待建议标签的内容不能为空"
+	ECHO_REVISION_NOT_FOUND    = "找不到该 Echo 的历史版本"
+	ECHO_STATUS_INVALID        = "无效的 Echo 发布状态"
+	ECHO_PUBLISH_AT_INVALID    = "定时发布时间必须晚于当前时间"
+	ECHO_ALREADY_PUBLISHED     = "已发布的 Echo 不能改回草稿或定时发布"
+	ECHO_NOT_IN_TRASH          = "回收站中找不到该 Echo"
 )
 
 // Common 错误相关常量
@@ -118,6 +125,7 @@ const (
 // User 错误相关常量
 const (
 	USERNAME_ALREADY_EXISTS      = "用户名已存在"
+	INVALID_USER_ROLE            = "无效的用户角色"
 	OAUTH2_NOT_CONFIGURED        = "OAuth2 未配置"
 	OAUTH2_NOT_ENABLED           = "OAuth2 未启用"
 	NO_PERMISSION_BINDING_GITHUB = "没有权限绑定 GitHub 账号"
@@ -136,6 +144,10 @@ const (
 const (
 	WEBHOOK_NAME_OR_URL_CANNOT_BE_EMPTY = "未填写 Webhook 名称或 URL"
 	INVALID_WEBHOOK_URL                 = "webhook URL 不合法或不安全"
+	INVALID_WEBHOOK_TOPIC               = "未知的 Webhook 事件 topic"
+	INVALID_WEBHOOK_TEMPLATE            = "Webhook 载荷模板无效"
+	WEBHOOK_DELIVERY_NOT_FOUND          = "Webhook 投递记录不存在"
+	WEBHOOK_DEAD_LETTER_NOT_FOUND       = "Webhook 死信不存在"
 	INVALID_CRON_EXPRESSION             = "无效的 Cron 表达式"
 )
 
@@ -163,3 +175,17 @@ const (
 	AGENT_MODEL_MISSING      = "未配置 Agent 模型名称或模型名称不能为空"
 	AGENT_SETTING_NOT_FOUND  = "未找到 Agent 设置"
 )
+
+// Embedding 错误相关常量
+const (
+	EMBEDDING_PROVIDER_NOT_FOUND = "未找到对应的 Embedding 提供方"
+)
+
+// Chat 错误相关常量
+const (
+	CHAT_THREAD_NOT_FOUND      = "会话不存在"
+	INVALID_CHAT_TOKEN_BUDGET  = "会话历史预算超出范围"
+	INVALID_CHAT_EXPORT_FORMAT = "不支持的会话导出格式"
+	CHAT_ACTION_NOT_FOUND      = "待确认操作不存在"
+	CHAT_ACTION_NOT_PENDING    = "该操作已处理，不能重复确认或取消"
+)
@@ -29,6 +29,15 @@ const (
 	DELETE_TAG_SUCCESS            = "删除标签成功"
 	GET_ECHOS_BY_TAG_ID_SUCCESS   = "获取标签下的Echos成功"
 	QUERY_ECHOS_SUCCESS           = "查询Echos成功"
+	SEARCH_ECHOS_SUCCESS          = "检索Echos成功"
+	GET_RELATED_ECHOS_SUCCESS     = "获取相关Echos成功"
+	SUGGEST_TAGS_SUCCESS          = "获取标签建议成功"
+	LIST_ECHO_REVISIONS_SUCCESS   = "获取Echo历史版本成功"
+	GET_ECHO_REVISION_SUCCESS     = "获取Echo历史版本详情成功"
+	RESTORE_ECHO_REVISION_SUCCESS = "恢复Echo历史版本成功"
+	LIST_TRASHED_ECHOS_SUCCESS    = "获取回收站Echos成功"
+	RESTORE_ECHO_SUCCESS          = "恢复Echo成功"
+	PURGE_ECHO_SUCCESS            = "彻底删除Echo成功"
 	GET_HOT_ECHOS_SUCCESS         = "获取热门Echos成功"
 	GET_RANDOM_ECHO_SUCCESS       = "随机获取Echo成功"
 	GET_ON_THIS_DAY_ECHOS_SUCCESS = "获取那年今日Echos成功"
@@ -47,26 +56,31 @@ const (
 
 // Setting 成功相关常量
 const (
-	GET_SETTINGS_SUCCESS            = "获取设置成功！"
-	UPDATE_SETTINGS_SUCCESS         = "更新设置成功！"
-	GET_S3_SETTINGS_SUCCESS         = "获取 S3 存储设置成功！"
-	UPDATE_S3_SETTINGS_SUCCESS      = "更新 S3 存储设置成功！"
-	GET_OAUTH_SETTINGS_SUCCESS      = "获取 OAuth 设置成功！"
-	UPDATE_OAUTH_SETTINGS_SUCCESS   = "更新 OAuth 设置成功！"
-	GET_OAUTH2_STATUS_SUCCESS       = "获取 OAuth2 状态成功"
-	GET_PASSKEY_SETTINGS_SUCCESS    = "获取 Passkey 设置成功！"
-	UPDATE_PASSKEY_SETTINGS_SUCCESS = "更新 Passkey 设置成功！"
-	GET_PASSKEY_STATUS_SUCCESS      = "获取 Passkey 状态成功"
-	GET_WEBHOOK_SUCCESS             = "获取 Webhook 成功"
-	DELETE_WEBHOOK_SUCCESS          = "删除 Webhook 成功"
-	UPDATE_WEBHOOK_SUCCESS          = "更新 Webhook 成功"
-	CREATE_WEBHOOK_SUCCESS          = "创建 Webhook 成功"
-	TEST_WEBHOOK_SUCCESS            = "测试 Webhook 成功"
-	TEST_S3_CONNECTION_SUCCESS      = "S3 存储连接测试成功"
-	LIST_ACCESS_TOKENS_SUCCESS      = "列出访问令牌成功"
-	CREATE_ACCESS_TOKEN_SUCCESS     = "创建访问令牌成功"
-	DELETE_ACCESS_TOKEN_SUCCESS     = "删除访问令牌成功"
-	SCHEDULE_SNAPSHOT_SUCCESS       = "设置定时快照计划成功"
+	GET_SETTINGS_SUCCESS               = "获取设置成功！"
+	UPDATE_SETTINGS_SUCCESS            = "更新设置成功！"
+	GET_S3_SETTINGS_SUCCESS            = "获取 S3 存储设置成功！"
+	UPDATE_S3_SETTINGS_SUCCESS         = "更新 S3 存储设置成功！"
+	GET_OAUTH_SETTINGS_SUCCESS         = "获取 OAuth 设置成功！"
+	UPDATE_OAUTH_SETTINGS_SUCCESS      = "更新 OAuth 设置成功！"
+	GET_OAUTH2_STATUS_SUCCESS          = "获取 OAuth2 状态成功"
+	GET_PASSKEY_SETTINGS_SUCCESS       = "获取 Passkey 设置成功！"
+	UPDATE_PASSKEY_SETTINGS_SUCCESS    = "更新 Passkey 设置成功！"
+	GET_PASSKEY_STATUS_SUCCESS         = "获取 Passkey 状态成功"
+	GET_WEBHOOK_SUCCESS                = "获取 Webhook 成功"
+	DELETE_WEBHOOK_SUCCESS             = "删除 Webhook 成功"
+	UPDATE_WEBHOOK_SUCCESS             = "更新 Webhook 成功"
+	CREATE_WEBHOOK_SUCCESS             = "创建 Webhook 成功"
+	TEST_WEBHOOK_SUCCESS               = "测试 Webhook 成功"
+	GET_WEBHOOK_DELIVERIES_SUCCESS     = "获取 Webhook 投递记录成功"
+	REDELIVER_WEBHOOK_SUCCESS          = "已重新投递 Webhook"
+	GET_WEBHOOK_DEAD_LETTERS_SUCCESS   = "获取 Webhook 死信成功"
+	RETRY_WEBHOOK_DEAD_LETTER_SUCCESS  = "死信已重新入队"
+	PURGE_WEBHOOK_DEAD_LETTERS_SUCCESS = "已清除 Webhook 死信"
+	TEST_S3_CONNECTION_SUCCESS         = "S3 存储连接测试成功"
+	LIST_ACCESS_TOKENS_SUCCESS         = "列出访问令牌成功"
+	CREATE_ACCESS_TOKEN_SUCCESS        = "创建访问令牌成功"
+	DELETE_ACCESS_TOKEN_SUCCESS        = "删除访问令牌成功"
+	SCHEDULE_SNAPSHOT_SUCCESS          = "设置定时快照计划成功"
 )
 
 // User 成功相关常量
@@ -104,6 +118,11 @@ const (
 
 // Chat 成功相关常量
 const (
-	CHAT_SESSION_GET_SUCCESS   = "获取会话成功"
-	CHAT_SESSION_CLEAR_SUCCESS = "清除会话成功"
+	CHAT_THREAD_LIST_SUCCESS    = "获取会话列表成功"
+	CHAT_THREAD_GET_SUCCESS     = "获取会话成功"
+	CHAT_THREAD_CREATE_SUCCESS  = "创建会话成功"
+	CHAT_THREAD_UPDATE_SUCCESS  = "更新会话成功"
+	CHAT_THREAD_DELETE_SUCCESS  = "删除会话成功"
+	CHAT_ACTION_CONFIRM_SUCCESS = "操作已执行"
+	CHAT_ACTION_CANCEL_SUCCESS  = "操作已取消"
 )
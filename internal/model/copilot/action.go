@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// 待确认动作的类型，与提出它的工具同名。
+const (
+	ActionDraftEcho  = "draft_echo"
+	ActionTagEchos   = "tag_echos"
+	ActionSetPrivacy = "set_echo_privacy"
+)
+
+// 待确认动作的状态：pending → running → done / failed，或 pending → cancelled。
+const (
+	ActionStatusPending   = "pending"
+	ActionStatusRunning   = "running"
+	ActionStatusDone      = "done"
+	ActionStatusFailed    = "failed"
+	ActionStatusCancelled = "cancelled"
+)
+
+// ChatAction 是 Chat 里模型提出、等待用户确认的一次写操作。工具只负责落一条 pending 记录，
+// 用户经确认端点放行后才经 EchoService 真正执行，结果回写在同一行上。
+type ChatAction struct {
+	ID          string             `gorm:"type:char(36);primaryKey"     json:"id"`
+	UserID      string             `gorm:"type:char(36);not null;index" json:"-"`
+	Kind        string             `gorm:"size:32;not null"             json:"kind"`
+	Args        ChatActionArgs     `gorm:"serializer:json;type:text"    json:"args"`
+	Targets     []ChatActionTarget `gorm:"serializer:json;type:text"    json:"targets,omitempty"` // 提出时的目标 Echo 快照，供确认前预览
+	Status      string             `gorm:"size:16;not null"             json:"status"`
+	AffectedIDs []string           `gorm:"serializer:json;type:text"    json:"affected_ids,omitempty"` // 实际新建 / 修改了的 Echo
+	Error       string             `gorm:"type:text"                    json:"error,omitempty"`
+	CreatedAt   int64              `gorm:"autoCreateTime"               json:"created_at"`
+	UpdatedAt   int64              `gorm:"autoUpdateTime"               json:"updated_at"`
+}
+
+func (ChatAction) TableName() string { return "copilot_actions" }
+
+func (a *ChatAction) BeforeCreate(_ *gorm.DB) error {
+	if a.ID == "" {
+		a.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// ChatActionArgs 是动作的参数，按 Kind 取用其中一部分：
+// draft_echo 用 Content / Tags；tag_echos 用 EchoIDs / AddTags / RemoveTags；
+// set_echo_privacy 用 EchoIDs / Private。
+type ChatActionArgs struct {
+	Content    string   `json:"content,omitempty"`
+	Tags       []string `json:"tags,omitempty"`
+	EchoIDs    []string `json:"echo_ids,omitempty"`
+	AddTags    []string `json:"add_tags,omitempty"`
+	RemoveTags []string `json:"remove_tags,omitempty"`
+	Private    bool     `json:"private"`
+}
+
+// ChatActionTarget 是动作目标 Echo 的摘要。
+type ChatActionTarget struct {
+	EchoID  string `json:"echo_id"`
+	Excerpt string `json:"excerpt"`
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package model 定义 Copilot Chat 的持久化模型：会话线程与线程内的消息。
+package model
+
+import (
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// 消息角色。持久化里只有这两种，工具调用与 system prompt 不落库。
+const (
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// 导出格式
+const (
+	ExportFormatMarkdown = "markdown"
+	ExportFormatJSON     = "json"
+)
+
+// ChatThread 是某个用户的一条命名 Chat 会话。消息单独存在 copilot_messages，线程行只保存
+// 标题、历史预算与列表展示用的计数。
+type ChatThread struct {
+	ID           string `gorm:"type:char(36);primaryKey"                                               json:"id"`
+	UserID       string `gorm:"type:char(36);not null;index:idx_copilot_threads_user,priority:1"       json:"-"`
+	Title        string `gorm:"size:120;not null"                                                      json:"title"`
+	TokenBudget  int    `gorm:"not null;default:0"                                                     json:"token_budget"`  // 注入模型的历史 token 预算，0 表示默认值
+	MessageCount int    `gorm:"not null;default:0"                                                     json:"message_count"` // 也是下一条消息的 Seq
+	CreatedAt    int64  `gorm:"autoCreateTime"                                                         json:"created_at"`
+	UpdatedAt    int64  `gorm:"autoUpdateTime;index:idx_copilot_threads_user,priority:2"               json:"updated_at"`
+}
+
+func (ChatThread) TableName() string { return "copilot_threads" }
+
+func (t *ChatThread) BeforeCreate(_ *gorm.DB) error {
+	if t.ID == "" {
+		t.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// ChatMessage 是线程里的一条消息，按 Seq 递增排列。
+//
+// Reasoning / ReasoningMs 仅 assistant 轮有意义：推理过程文本与其耗时（毫秒），只供前端折叠展示，
+// 不会回灌给模型。
+type ChatMessage struct {
+	ID          string                        `gorm:"type:char(36);primaryKey"                                               json:"id,omitempty"`
+	ThreadID    string                        `gorm:"type:char(36);not null;uniqueIndex:idx_copilot_messages_seq,priority:1" json:"-"`
+	Seq         int                           `gorm:"not null;uniqueIndex:idx_copilot_messages_seq,priority:2"               json:"-"`
+	Role        string                        `gorm:"size:16;not null"                                                       json:"role"`
+	Content     string                        `gorm:"type:text"                                                              json:"content"`
+	Sources     []embeddingModel.SearchResult `gorm:"serializer:json;type:text"                                              json:"sources,omitempty"`
+	Reasoning   string                        `gorm:"type:text"                                                              json:"reasoning,omitempty"`
+	ReasoningMs int64                         `                                                                              json:"reasoning_ms,omitempty"`
+	ActionIDs   []string                      `gorm:"serializer:json;type:text"                                              json:"action_ids,omitempty"` // 本轮提出的待确认动作
+	CreatedAt   int64                         `gorm:"autoCreateTime"                                                         json:"created_at,omitempty"`
+}
+
+func (ChatMessage) TableName() string { return "copilot_messages" }
+
+func (m *ChatMessage) BeforeCreate(_ *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// ChatThreadDetail 是线程连同其全部消息，供前端恢复展示与导出。Actions 是消息里引用到的
+// 待确认动作的当前状态。
+type ChatThreadDetail struct {
+	Thread   ChatThread    `json:"thread"`
+	Messages []ChatMessage `json:"messages"`
+	Actions  []ChatAction  `json:"actions"`
+}
+
+// ChatThreadDto 是新建 / 更新线程的请求体。Title 为空时，线程以第一个问题命名。
+type ChatThreadDto struct {
+	Title       string `json:"title"`
+	TokenBudget int    `json:"token_budget"` // 0 表示默认值
+}
+
+// ChatThreadExport 是导出好的线程文件。
+type ChatThreadExport struct {
+	Filename    string
+	ContentType string
+	Body        []byte
+}
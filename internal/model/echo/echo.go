@@ -24,6 +24,32 @@ type Echo struct {
 	Tags      []Tag          `gorm:"many2many:echo_tags;"                          json:"tags,omitempty"`
 	FavCount  int            `gorm:"default:0"                                     json:"fav_count"`
 	CreatedAt int64          `gorm:"autoCreateTime;index:idx_echos_private_created,priority:2" json:"created_at"`
+	// Status 是发布状态（published / draft / scheduled），只有 published 对外可见。
+	Status string `gorm:"type:varchar(20);not null;default:'published';index:idx_echos_status_publish_at,priority:1" json:"status"`
+	// PublishAt 对 scheduled 是计划发布时间，对 published 是实际发布时间（旧数据为 0），草稿恒为 0。
+	PublishAt int64 `gorm:"default:0;index:idx_echos_status_publish_at,priority:2" json:"publish_at,omitempty"`
+	// DeletedAt 非 0 表示已移入回收站（删除时刻），恢复时清零；超过保留期后由定时任务彻底清除。
+	DeletedAt int64 `gorm:"default:0;index" json:"deleted_at,omitempty"`
+	// CommentPolicy 是这条 Echo 的评论策略（见 CommentPolicy* 常量），留空跟随全局评论设置。
+	CommentPolicy string `gorm:"type:varchar(20);not null;default:''" json:"comment_policy,omitempty"`
+}
+
+// IsTrashed 报告 Echo 是否在回收站中。
+func (e *Echo) IsTrashed() bool {
+	return e.DeletedAt > 0
+}
+
+// IsPublished 报告 Echo 是否已发布（对访客、RSS、Webhook 等可见）。
+func (e *Echo) IsPublished() bool {
+	return e.Status == "" || e.Status == StatusPublished
+}
+
+// PublishedAt 返回 Echo 的发布时刻（Unix 秒）；旧数据没有 PublishAt 时以创建时间代替。
+func (e *Echo) PublishedAt() int64 {
+	if e.PublishAt > 0 {
+		return e.PublishAt
+	}
+	return e.CreatedAt
 }
 
 type EchoExtension struct {
@@ -53,6 +79,9 @@ func (e *Echo) BeforeCreate(_ *gorm.DB) error {
 	if e.ID == "" {
 		e.ID = uuidUtil.MustNewV7()
 	}
+	if e.Status == "" {
+		e.Status = StatusPublished
+	}
 	return nil
 }
 
@@ -86,4 +115,29 @@ const (
 	// LayoutNone 表示"无图片布局"：音频/视频 Echo 使用，卡片对其走平铺(media-first)，
 	// 播放器本就忽略 layout。用于把 image 专属的多布局语义从音视频身上剥离。
 	LayoutNone = "none"
+
+	// StatusPublished 已发布，按 Private 决定对谁可见。
+	StatusPublished = "published"
+	// StatusDraft 草稿，仅管理员可见，不会自动发布。
+	StatusDraft = "draft"
+	// StatusScheduled 定时发布，到 PublishAt 由定时任务转为 published。
+	StatusScheduled = "scheduled"
+
+	// CommentPolicyOpen 始终开放评论，不受全局「满 N 天自动关闭」约束。
+	CommentPolicyOpen = "open"
+	// CommentPolicyClosed 关闭评论。
+	CommentPolicyClosed = "closed"
+	// CommentPolicyLoginOnly 仅登录用户可评论。
+	CommentPolicyLoginOnly = "login_only"
+	// CommentPolicyApproval 评论一律先审核，不论全局是否开启审核。
+	CommentPolicyApproval = "approval"
 )
+
+// IsValidCommentPolicy 报告 p 是否为合法的评论策略；空串表示跟随全局设置，也合法。
+func IsValidCommentPolicy(p string) bool {
+	switch p {
+	case "", CommentPolicyOpen, CommentPolicyClosed, CommentPolicyLoginOnly, CommentPolicyApproval:
+		return true
+	}
+	return false
+}
@@ -21,16 +21,25 @@ type EchoUpsertDto struct {
 	Extension *EchoExtensionDto `json:"extension,omitempty"`
 	Tags      []Tag             `json:"tags,omitempty"`
 	CreatedAt *int64            `json:"created_at,omitempty"`
+	// Status 为空时：新建按 published 处理，更新保持原状态。
+	Status string `json:"status,omitempty"`
+	// PublishAt 是定时发布时间（Unix 秒），status=scheduled 时必填且须晚于当前时间。
+	PublishAt int64 `json:"publish_at,omitempty"`
+	// CommentPolicy 为空表示跟随全局评论设置。
+	CommentPolicy string `json:"comment_policy,omitempty" enum:",open,closed,login_only,approval"`
 }
 
 func (dto *EchoUpsertDto) ToModel() *Echo {
 	echo := &Echo{
-		ID:        dto.ID,
-		Content:   dto.Content,
-		EchoFiles: dto.EchoFiles,
-		Layout:    dto.Layout,
-		Private:   dto.Private,
-		Tags:      dto.Tags,
+		ID:            dto.ID,
+		Content:       dto.Content,
+		EchoFiles:     dto.EchoFiles,
+		Layout:        dto.Layout,
+		Private:       dto.Private,
+		Tags:          dto.Tags,
+		Status:        dto.Status,
+		PublishAt:     dto.PublishAt,
+		CommentPolicy: dto.CommentPolicy,
 	}
 	if dto.CreatedAt != nil {
 		echo.CreatedAt = *dto.CreatedAt
@@ -43,3 +52,64 @@ func (dto *EchoUpsertDto) ToModel() *Echo {
 	}
 	return echo
 }
+
+// 混合检索结果所用的召回模式。
+const (
+	// SearchModeHybrid 表示关键词与向量语义两路召回经 RRF 融合。
+	SearchModeHybrid = "hybrid"
+	// SearchModeKeyword 表示仅关键词召回（Embedding 未启用或语义检索失败时回退）。
+	SearchModeKeyword = "keyword"
+	// SearchModeSemantic 表示仅向量近邻（相关推荐、标签建议）。
+	SearchModeSemantic = "semantic"
+	// SearchModeUnavailable 表示 Embedding 未启用或检索失败，相关推荐无结果可给。
+	SearchModeUnavailable = "unavailable"
+)
+
+// EchoSearchHit 是混合检索的一条命中。
+type EchoSearchHit struct {
+	Echo Echo `json:"echo"`
+	// Score 是倒数排名融合（RRF）得分，越大越相关。
+	Score float64 `json:"score"`
+	// KeywordRank / SemanticRank 是该 Echo 在两路召回中的名次（从 1 开始），0 表示该路未命中。
+	KeywordRank  int `json:"keyword_rank,omitempty"`
+	SemanticRank int `json:"semantic_rank,omitempty"`
+	// Highlight 是命中片段（已 HTML 转义，命中词以 <mark></mark> 包裹）；仅语义命中时可能为空。
+	Highlight string `json:"highlight,omitempty"`
+}
+
+// EchoSearchResult 是混合检索的响应体。
+type EchoSearchResult struct {
+	Mode  string          `json:"mode"`
+	Items []EchoSearchHit `json:"items"`
+	// KeywordTotal 是关键词召回的命中总数（不受 limit 截断），用于提示结果覆盖度。
+	KeywordTotal int64 `json:"keyword_total"`
+}
+
+// RelatedEchoHit 是一条相关 Echo。
+type RelatedEchoHit struct {
+	Echo Echo `json:"echo"`
+	// Distance 是与源 Echo 的向量距离，越小越相近。
+	Distance float64 `json:"distance"`
+}
+
+// RelatedEchoResult 是相关推荐的响应体。Mode 为 semantic 或 unavailable（此时 Items 为空）。
+type RelatedEchoResult struct {
+	Mode  string           `json:"mode"`
+	Items []RelatedEchoHit `json:"items"`
+}
+
+// TagSuggestion 是一条标签建议。
+type TagSuggestion struct {
+	Tag Tag `json:"tag"`
+	// Score 是归一化得分（0~1）：语义模式下为近邻投票权重占比，关键词模式下恒为 1。
+	Score float64 `json:"score"`
+	// Votes 是打了该标签的近邻 Echo 数；关键词模式下为 0。
+	Votes int `json:"votes"`
+}
+
+// TagSuggestionResult 是标签建议的响应体。Mode 为 semantic（近邻投票）或 keyword
+// （Embedding 未启用或近邻里没有可用标签时，回退为「正文里出现了哪些已有标签」）。
+type TagSuggestionResult struct {
+	Mode  string          `json:"mode"`
+	Items []TagSuggestion `json:"items"`
+}
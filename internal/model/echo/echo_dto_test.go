@@ -58,6 +58,8 @@ func TestEchoUpsertDto_ToModel(t *testing.T) {
 			Layout:    LayoutGrid,
 			Private:   true,
 			Tags:      tags,
+
+			CommentPolicy: CommentPolicyLoginOnly,
 		}
 		got := dto.ToModel()
 		assert.Equal(t, "e9", got.ID)
@@ -66,6 +68,7 @@ func TestEchoUpsertDto_ToModel(t *testing.T) {
 		assert.True(t, got.Private)
 		assert.Equal(t, files, got.EchoFiles)
 		assert.Equal(t, tags, got.Tags)
+		assert.Equal(t, CommentPolicyLoginOnly, got.CommentPolicy)
 	})
 
 	t.Run("zero_value_dto_maps_to_empty_model", func(t *testing.T) {
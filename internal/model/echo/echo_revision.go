@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	diffUtil "github.com/lin-snow/ech0/internal/util/diff"
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// EchoRevision 是一条 Echo 被编辑前的完整快照，由 UpdateEcho 在同一事务内写入。
+//
+// 快照只记「被覆盖掉的版本」：第 N 个修订 + 之后的修订 + 当前 Echo 构成完整的编辑链。
+// 标签按名字、附件按文件 ID 冗余成 JSON，不依赖关系表，使标签被删、附件被解绑后仍能原样查看；
+// 恢复时再按名字 / ID 重新解析（已删除的文件会被跳过）。
+type EchoRevision struct {
+	ID     string `gorm:"type:char(36);primaryKey"                                                     json:"id"`
+	EchoID string `gorm:"type:char(36);not null;uniqueIndex:idx_echo_revisions_echo_number,priority:1" json:"echo_id"`
+	// Number 是该 Echo 的修订序号，从 1 开始递增。
+	Number    int                `gorm:"not null;uniqueIndex:idx_echo_revisions_echo_number,priority:2" json:"number"`
+	Content   string             `gorm:"type:text;not null"                                             json:"content"`
+	Layout    string             `gorm:"type:varchar(50)"                                               json:"layout,omitempty"`
+	Private   bool               `gorm:"default:false"                                                  json:"private"`
+	Tags      []string           `gorm:"serializer:json;type:text"                                      json:"tags,omitempty"`
+	Files     []EchoRevisionFile `gorm:"serializer:json;type:text"                                      json:"files,omitempty"`
+	Extension *EchoExtensionDto  `gorm:"serializer:json;type:text"                                      json:"extension,omitempty"`
+	// EditorID 是做出这次覆盖编辑的用户。
+	EditorID  string `gorm:"type:char(36)"  json:"editor_id"`
+	CreatedAt int64  `gorm:"autoCreateTime" json:"created_at"`
+}
+
+// EchoRevisionFile 是修订快照中的一个附件引用。
+type EchoRevisionFile struct {
+	FileID    string `json:"file_id"`
+	SortOrder int    `json:"sort_order"`
+}
+
+func (r *EchoRevision) BeforeCreate(_ *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
+
+// NewEchoRevision 从 Echo 的当前状态生成一条修订快照（未分配 Number）。
+func NewEchoRevision(echo *Echo, editorID string) *EchoRevision {
+	rev := &EchoRevision{
+		EchoID:   echo.ID,
+		Content:  echo.Content,
+		Layout:   echo.Layout,
+		Private:  echo.Private,
+		EditorID: editorID,
+	}
+	for _, t := range echo.Tags {
+		rev.Tags = append(rev.Tags, t.Name)
+	}
+	for _, f := range echo.EchoFiles {
+		rev.Files = append(rev.Files, EchoRevisionFile{FileID: f.FileID, SortOrder: f.SortOrder})
+	}
+	if echo.Extension != nil {
+		rev.Extension = &EchoExtensionDto{Type: echo.Extension.Type, Payload: echo.Extension.Payload}
+	}
+	return rev
+}
+
+// 修订详情的对比基准。
+const (
+	// RevisionCompareNext 与紧随其后的版本对比，即「这次编辑改了什么」。
+	RevisionCompareNext = "next"
+	// RevisionCompareCurrent 与 Echo 当前内容对比，即「恢复它会改回什么」。
+	RevisionCompareCurrent = "current"
+)
+
+// EchoRevisionDetail 是单个修订的详情：快照本身 + 与对比基准之间的正文逐行差分。
+type EchoRevisionDetail struct {
+	Revision EchoRevision `json:"revision"`
+	// CompareTo 是实际使用的对比基准（next / current）；最新修订的 next 即当前版本。
+	CompareTo string          `json:"compare_to"`
+	Diff      []diffUtil.Line `json:"diff"`
+	Stats     diffUtil.Stats  `json:"stats"`
+}
@@ -23,28 +23,49 @@ func (s Status) IsTerminal() bool {
 	return s == StatusSuccess || s == StatusFailed || s == StatusCancelled
 }
 
-// 作业类型常量：作为 Job 主键 Type 的取值，供 handler/runner 共用。
+// 作业类型常量：作为 Job.Type 的取值，供 handler/runner 共用。
 const (
 	TypeReindex   = "reindex"
 	TypeMigration = "migration"
 	TypeExport    = "export"
+	// TypeSnapshotUpload 把本地增量快照链同步到对象存储，重跑即从断点续传。
+	TypeSnapshotUpload = "snapshot_upload"
 )
 
-// Job 是通用作业的持久化行。主键即 Type，结构性保证「每类型单行」：新一次 Submit
-// upsert 覆盖旧终态行，无 id、无历史。领域专属的输入/进度/结果序列化进 Payload(JSON)，
+// Job 是通用作业的持久化行：每次 Submit 一行，按 ID 区分，同类型的历史按 CreatedAt
+// 排列、由 Manager 按保留策略清理。领域专属的输入/进度/结果序列化进 Payload(JSON)，
 // 框架不解析它，只有对应 Runner 与前端认得。
+//
+// pending 即「排队中」：StartedAt 在真正开跑时才写入。Dismissed 表示终态行已被领域
+// 「复位」（如迁移清理），不再作为该类型的当前作业，但仍留在历史里。
+//
+// CheckpointAt 非空表示 Runner 落过断点（断点就写在 Payload 里），进程中断后可据此续跑；
+// Resumes 记录已续跑的次数，用来防止一个每次都把进程带崩的作业无限重来。
 type Job struct {
-	Type       string `gorm:"primaryKey;size:64"      json:"type"`
-	Status     Status `gorm:"type:varchar(32);index"  json:"status"`
-	Phase      string `gorm:"type:varchar(64)"        json:"phase"`
-	Error      string `gorm:"type:text"               json:"error"`
-	Payload    string `gorm:"type:text"               json:"payload"`
-	StartedAt  *int64 `                               json:"started_at"`
-	FinishedAt *int64 `                               json:"finished_at"`
-	UpdatedAt  int64  `gorm:"autoUpdateTime"          json:"updated_at"`
+	ID           string `gorm:"type:char(36);primaryKey"                     json:"id"`
+	Type         string `gorm:"size:64;index:idx_job_runs_type_created"     json:"type"`
+	Status       Status `gorm:"type:varchar(32);index"                       json:"status"`
+	Phase        string `gorm:"type:varchar(64)"                             json:"phase"`
+	Error        string `gorm:"type:text"                                    json:"error"`
+	Payload      string `gorm:"type:text"                                    json:"payload"`
+	Dismissed    bool   `gorm:"not null;default:false"                       json:"dismissed"`
+	CheckpointAt *int64 `                                                    json:"checkpoint_at"`
+	Resumes      int    `gorm:"not null;default:0"                           json:"resumes"`
+	CreatedAt    int64  `gorm:"index:idx_job_runs_type_created"              json:"created_at"`
+	StartedAt    *int64 `                                                    json:"started_at"`
+	FinishedAt   *int64 `                                                    json:"finished_at"`
+	UpdatedAt    int64  `gorm:"autoUpdateTime"                               json:"updated_at"`
 }
 
-// TableName 固定表名为 jobs。
+// TableName 固定表名为 job_runs（旧版按类型单行的 jobs 表由启动迁移搬入后删除）。
 func (Job) TableName() string {
-	return "jobs"
+	return "job_runs"
+}
+
+// ListQuery 是作业历史的分页筛选条件；Type / Status 为空表示不限。
+type ListQuery struct {
+	Type     string
+	Status   Status
+	Page     int
+	PageSize int
 }
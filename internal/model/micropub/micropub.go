@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import "mime/multipart"
+
+const (
+	ActionCreate   = "create"
+	ActionUpdate   = "update"
+	ActionDelete   = "delete"
+	ActionUndelete = "undelete"
+
+	TypeEntry = "h-entry"
+)
+
+// Properties 是 microformats2 的属性表，每个属性都是值数组。
+// 值可能是字符串，也可能是嵌套对象（如 {"value": ..., "alt": ...}、{"html": ...} 或 h-card）。
+type Properties map[string][]any
+
+// Request 是归一化后的 Micropub 请求：表单与 JSON 两种写法都转成它。
+type Request struct {
+	Action     string     `json:"action,omitempty"`
+	URL        string     `json:"url,omitempty"`
+	Type       []string   `json:"type,omitempty"`
+	Properties Properties `json:"properties,omitempty"`
+
+	// 以下仅用于 action=update。
+	Replace Properties `json:"replace,omitempty"`
+	Add     Properties `json:"add,omitempty"`
+	// Delete 为属性名数组（整体删除）或属性表（删除指定值）。
+	Delete any `json:"delete,omitempty"`
+
+	// Uploads 是 multipart 请求中随帖上传的文件，按属性名（photo / video / audio）分组。
+	Uploads map[string][]*multipart.FileHeader `json:"-"`
+}
+
+// Entry 是 q=source 的响应。
+type Entry struct {
+	Type       []string   `json:"type,omitempty"`
+	Properties Properties `json:"properties"`
+}
+
+// PostType 描述 q=config 中支持的帖子类型。
+type PostType struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Config 是 q=config 的响应。
+type Config struct {
+	MediaEndpoint string     `json:"media-endpoint"`
+	SyndicateTo   []any      `json:"syndicate-to"`
+	PostTypes     []PostType `json:"post-types"`
+	Q             []string   `json:"q"`
+}
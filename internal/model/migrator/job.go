@@ -5,8 +5,11 @@ package model
 
 // 导入来源类型(对应 importer/ 下的适配器)。
 const (
-	MigrationSourceEch0  = "ech0"
-	MigrationSourceMemos = "memos"
+	MigrationSourceEch0     = "ech0"
+	MigrationSourceMemos    = "memos"
+	MigrationSourceTwitter  = "twitter"
+	MigrationSourceMastodon = "mastodon"
+	MigrationSourceBluesky  = "bluesky"
 )
 
 // 导出目的地类型(对应 exporter/ 下的适配器):fs=本地目录,s3=对象存储。
@@ -22,6 +25,10 @@ const (
 	ExportPhaseCompleted = "completed"
 )
 
+// ExportPhaseUploading 是增量链上传作业（snapshot_upload）的阶段。它是独立作业，不影响上面
+// 导出作业「落盘即完成」的约定。
+const ExportPhaseUploading = "uploading"
+
 const (
 	MigrationStatusIdle      = "idle"
 	MigrationStatusPending   = "pending"
@@ -41,6 +41,10 @@ type ExportPayload struct {
 	Format string `json:"format,omitempty"`
 	// IncludePrivate 仅对胶囊有意义：快照本就整库带走，无所谓包含与否。
 	IncludePrivate bool `json:"include_private,omitempty"`
+	// Artifact 是断点：打包完成后写入产物文件名，进程在收尾前中断时续跑直接交付这份产物。
+	Artifact string `json:"artifact,omitempty"`
+	// PackingSince 是开跑断点：打包开始的 Unix 秒。续跑时据此清掉中断那趟留下的残缺产物。
+	PackingSince int64 `json:"packing_since,omitempty"`
 }
 
 // StartExportRequest 是 POST /migration/export 的请求体。两个字段皆可省：省略即
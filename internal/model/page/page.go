@@ -0,0 +1,28 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import "time"
+
+// EchoPage 是 /echo/{id} 服务端注入所需的页面元数据。地址在配置了站点地址时为绝对地址，
+// 否则为站内路径，由调用方按请求来源补全。
+type EchoPage struct {
+	SiteName    string
+	Title       string // 正文第一行；纯图片等没有文字时为空
+	Description string // 渲染后正文的纯文本摘要
+	URL         string // canonical 地址
+	Author      string
+	Published   time.Time
+	Tags        []string
+	Images      []Image // 按附件顺序；没有图片附件时取正文中的第一张图
+	ContentHTML string  // 渲染后的正文（Markdown → HTML，已丢弃原始 HTML），供无 JS 回退
+}
+
+// Image 是页面上的一张图片。
+type Image struct {
+	URL    string
+	Alt    string
+	Width  int
+	Height int
+}
@@ -3,12 +3,20 @@
 
 package model
 
+// 内置本地向量化的固定参数。模型名写入索引状态，算法调整时升版本号即可触发重建。
+const (
+	EmbeddingLocalModel      = "ech0-local-ngram-v1"
+	EmbeddingLocalDefaultDim = 384
+)
+
 // EmbeddingSetting 定义向量 Embedding 设置实体（独立于 Agent 的生成 LLM 配置）。
 //
-// v1 仅支持 OpenAI 兼容的 /v1/embeddings 接口（覆盖 OpenAI、Qwen/DashScope、
-// Ollama、Jina 等绝大多数提供商），因此不带 Protocol 字段。
+// Provider 为 openai（缺省）时走 OpenAI 兼容的 /v1/embeddings 接口（覆盖 OpenAI、
+// Qwen/DashScope、Ollama、Jina 等绝大多数提供商）；为 local 时用内置的哈希 n-gram
+// 向量化，纯 CPU、不联网，Model 固定为 EmbeddingLocalModel，ApiKey/BaseURL/BatchSize 不生效。
 type EmbeddingSetting struct {
 	Enable    bool   `json:"enable"`     // 是否启用 Embedding（Chat 检索的前置条件）
+	Provider  string `json:"provider"`   // 向量化提供方：openai / local
 	Model     string `json:"model"`      // Embedding 模型名，如 text-embedding-3-small
 	ApiKey    string `json:"api_key"`    // API Key（本地服务如 Ollama 可留空）
 	BaseURL   string `json:"base_url"`   // 自定义 API URL（可选）
@@ -19,6 +27,7 @@ type EmbeddingSetting struct {
 // EmbeddingSettingDto 是更新 Embedding 设置的入参
 type EmbeddingSettingDto struct {
 	Enable    bool   `json:"enable"`
+	Provider  string `json:"provider"`
 	Model     string `json:"model"`
 	ApiKey    string `json:"api_key"`
 	BaseURL   string `json:"base_url"`
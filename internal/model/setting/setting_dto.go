@@ -71,10 +71,13 @@ type PasskeyStatus struct {
 }
 
 type WebhookDto struct {
-	Name     string `json:"name"`                                 // Webhook 名称
-	URL      string `json:"url"`                                  // Webhook URL
-	Secret   string `json:"secret,omitempty"`                     // 签名密钥，用于请求验证（HMAC等）
-	IsActive bool   `json:"is_active"        gorm:"default:true"` // 启用/禁用状态
+	Name         string   `json:"name"`                                     // Webhook 名称
+	URL          string   `json:"url"`                                      // Webhook URL
+	Secret       string   `json:"secret,omitempty"`                         // 签名密钥，用于请求验证（HMAC等）
+	IsActive     bool     `json:"is_active"            gorm:"default:true"` // 启用/禁用状态
+	Topics       []string `json:"topics,omitempty"`                         // 订阅的事件 topic，为空表示全部
+	Template     string   `json:"template,omitempty"`                       // 载荷模板：空 / slack / discord / feishu / custom
+	TemplateBody string   `json:"template_body,omitempty"`                  // custom 模板正文（Go text/template，须渲染出 JSON）
 }
 
 type AccessTokenSettingDto struct {
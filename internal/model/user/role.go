@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import authModel "github.com/lin-snow/ech0/internal/model/auth"
+
+// Role 是用户在实例中的角色，决定其在服务层拥有哪些权限。
+type Role string
+
+const (
+	// RoleOwner 实例所有者（唯一）：拥有全部权限，且是除作者外唯一能看到私密 / 未发布 Echo 的人。
+	RoleOwner Role = "owner"
+	// RoleEditor 编辑：可发布、编辑与删除任何人的公开 Echo，管理标签、附件、评论与系统设置。
+	RoleEditor Role = "editor"
+	// RoleAuthor 作者：可发布 Echo、上传附件，只能编辑 / 删除自己的 Echo。
+	RoleAuthor Role = "author"
+	// RoleCommentModerator 评论审核员：只读内容，另可审核评论。
+	RoleCommentModerator Role = "comment_moderator"
+	// RoleReader 读者：只读内容，评论与访客一样需要审核。
+	RoleReader Role = "reader"
+)
+
+// Permission 是服务层校验的单项权限。
+type Permission string
+
+const (
+	// PermEchoWrite 发布 Echo、新建标签，编辑 / 删除自己的 Echo。
+	PermEchoWrite Permission = "echo:write"
+	// PermEchoEditOthers 编辑 / 删除他人的 Echo（他人的私密 / 未发布 Echo 仍仅 owner 可碰）。
+	PermEchoEditOthers Permission = "echo:edit_others"
+	// PermEchoViewPrivate 查看所有人的私密 / 未发布 Echo。
+	PermEchoViewPrivate Permission = "echo:view_private"
+	// PermTagManage 删除标签。
+	PermTagManage Permission = "tag:manage"
+	// PermFileWrite 上传附件、管理自己的附件。
+	PermFileWrite Permission = "file:write"
+	// PermFileManage 浏览与删除所有人的附件。
+	PermFileManage Permission = "file:manage"
+	// PermCommentModerate 审核、置顶、删除评论。
+	PermCommentModerate Permission = "comment:moderate"
+	// PermSystemManage 系统设置、存储、Webhook、连接、迁移等实例级配置。
+	PermSystemManage Permission = "system:manage"
+	// PermUserManage 调整其他用户的角色、删除用户。
+	PermUserManage Permission = "user:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleOwner: {
+		PermEchoWrite, PermEchoEditOthers, PermEchoViewPrivate, PermTagManage,
+		PermFileWrite, PermFileManage, PermCommentModerate, PermSystemManage, PermUserManage,
+	},
+	RoleEditor: {
+		PermEchoWrite, PermEchoEditOthers, PermTagManage,
+		PermFileWrite, PermFileManage, PermCommentModerate, PermSystemManage,
+	},
+	RoleAuthor:           {PermEchoWrite, PermFileWrite},
+	RoleCommentModerator: {PermCommentModerate},
+	RoleReader:           {},
+}
+
+// readerScopes 是任何角色都可授予 access token 的基础 scope。
+var readerScopes = []string{
+	authModel.ScopeEchoRead,
+	authModel.ScopeCommentRead,
+	authModel.ScopeCommentWrite,
+	authModel.ScopeFileRead,
+	authModel.ScopeConnectRead,
+	authModel.ScopeProfileRead,
+	authModel.ScopeProfileWrite,
+}
+
+// roleScopes 是各角色在基础 scope 之外还可授予 access token 的 scope。
+var roleScopes = map[Role][]string{
+	RoleOwner: {
+		authModel.ScopeEchoWrite, authModel.ScopeFileWrite, authModel.ScopeCommentMod,
+		authModel.ScopeConnectWrite, authModel.ScopeAdminSettings, authModel.ScopeAdminUser,
+		authModel.ScopeAdminToken,
+	},
+	RoleEditor: {
+		authModel.ScopeEchoWrite, authModel.ScopeFileWrite, authModel.ScopeCommentMod,
+		authModel.ScopeConnectWrite, authModel.ScopeAdminSettings, authModel.ScopeAdminToken,
+	},
+	RoleAuthor:           {authModel.ScopeEchoWrite, authModel.ScopeFileWrite},
+	RoleCommentModerator: {authModel.ScopeCommentMod},
+}
+
+// IsValidRole 判断 role 是否为已知角色。
+func IsValidRole(role Role) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// Can 报告角色是否拥有权限 p。
+func (r Role) Can(p Permission) bool {
+	for _, granted := range rolePermissions[r] {
+		if granted == p {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsScope 报告该角色的用户能否把 scope 授予自己的 access token。
+// token 的有效权限是 scope 与持有者角色的交集：签发时超出角色的 scope 直接拒绝，
+// 角色之后被下调时，服务层仍按当前角色校验，旧 token 上多出的 scope 不再生效。
+func (r Role) AllowsScope(scope string) bool {
+	for _, s := range readerScopes {
+		if s == scope {
+			return true
+		}
+	}
+	for _, s := range roleScopes[r] {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// EffectiveRole 返回用户的角色。Role 列为空的旧数据按 IsOwner / IsAdmin 推断：
+// owner → owner，管理员 → editor，其余 → reader。
+func (u User) EffectiveRole() Role {
+	if u.Role != "" {
+		return u.Role
+	}
+	switch {
+	case u.IsOwner:
+		return RoleOwner
+	case u.IsAdmin:
+		return RoleEditor
+	default:
+		return RoleReader
+	}
+}
+
+// Can 报告用户是否拥有权限 p。
+func (u User) Can(p Permission) bool {
+	return u.EffectiveRole().Can(p)
+}
+
+// SetRole 设置角色并同步 IsOwner / IsAdmin：二者保留为派生标记，
+// IsAdmin 表示具备实例管理权限（owner / editor）。
+func (u *User) SetRole(role Role) {
+	u.Role = role
+	u.IsOwner = role == RoleOwner
+	u.IsAdmin = role.Can(PermSystemManage)
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	"testing"
+
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEffectiveRole_FallsBackToFlags(t *testing.T) {
+	assert.Equal(t, RoleOwner, User{IsOwner: true, IsAdmin: true}.EffectiveRole())
+	assert.Equal(t, RoleEditor, User{IsAdmin: true}.EffectiveRole())
+	assert.Equal(t, RoleReader, User{}.EffectiveRole())
+	assert.Equal(t, RoleAuthor, User{Role: RoleAuthor, IsAdmin: true}.EffectiveRole(),
+		"an explicit role wins over stale flags")
+}
+
+func TestRole_Can(t *testing.T) {
+	assert.True(t, RoleOwner.Can(PermEchoViewPrivate))
+	assert.False(t, RoleEditor.Can(PermEchoViewPrivate))
+	assert.True(t, RoleEditor.Can(PermEchoEditOthers))
+	assert.True(t, RoleAuthor.Can(PermEchoWrite))
+	assert.False(t, RoleAuthor.Can(PermEchoEditOthers))
+	assert.True(t, RoleCommentModerator.Can(PermCommentModerate))
+	assert.False(t, RoleCommentModerator.Can(PermEchoWrite))
+	for _, p := range rolePermissions[RoleOwner] {
+		assert.False(t, RoleReader.Can(p), "reader must not hold %s", p)
+	}
+	assert.False(t, Role("root").Can(PermEchoWrite))
+}
+
+func TestRole_AllowsScope(t *testing.T) {
+	for _, role := range []Role{RoleOwner, RoleEditor, RoleAuthor, RoleCommentModerator, RoleReader} {
+		assert.True(t, role.AllowsScope(authModel.ScopeEchoRead), "%s", role)
+	}
+	assert.False(t, RoleReader.AllowsScope(authModel.ScopeEchoWrite))
+	assert.True(t, RoleAuthor.AllowsScope(authModel.ScopeFileWrite))
+	assert.False(t, RoleAuthor.AllowsScope(authModel.ScopeAdminSettings))
+	assert.False(t, RoleEditor.AllowsScope(authModel.ScopeAdminUser))
+	assert.True(t, RoleOwner.AllowsScope(authModel.ScopeAdminUser))
+}
+
+func TestSetRole_SyncsFlags(t *testing.T) {
+	var u User
+	u.SetRole(RoleEditor)
+	assert.True(t, u.IsAdmin)
+	assert.False(t, u.IsOwner)
+
+	u.SetRole(RoleAuthor)
+	assert.False(t, u.IsAdmin)
+	assert.Equal(t, RoleAuthor, u.EffectiveRole())
+
+	u.SetRole(RoleOwner)
+	assert.True(t, u.IsAdmin)
+	assert.True(t, u.IsOwner)
+}
@@ -21,6 +21,9 @@ type User struct {
 	IsOwner  bool   `gorm:"bool"                     json:"is_owner"`
 	Avatar   string `gorm:"size:255"                 json:"avatar"`
 	Locale   string `gorm:"size:16;default:zh-CN"    json:"locale"`
+	// Role 是用户角色（见 role.go）。IsAdmin / IsOwner 是由 SetRole 同步维护的派生标记，
+	// 旧数据在启动迁移中按二者回填。
+	Role Role `gorm:"size:32;not null;default:''" json:"role"`
 }
 
 func (u *User) BeforeCreate(_ *gorm.DB) error {
@@ -40,6 +40,15 @@ type UserInfoDto struct {
 	Locale string `json:"locale"`
 }
 
+// UserRoleDto 调整用户角色的请求体
+//
+// swagger:model UserRoleDto
+type UserRoleDto struct {
+	// 目标角色：editor / author / comment_moderator / reader（owner 唯一，不可授予）
+	// example: author
+	Role Role `json:"role" enum:"editor,author,comment_moderator,reader"`
+}
+
 // OAuthInfoDto OAuth2 信息数据传输对象
 type OAuthInfoDto struct {
 	Provider string `json:"provider"`
@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	"encoding/json"
+
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookDelivery 是一次 HTTP 尝试的投递记录：每次重试各占一行，同一次投递的各行共享 EventID。
+// Observation 保存原始事件观察，供重新投递时按 webhook 的当前配置重新渲染。
+type WebhookDelivery struct {
+	ID           string          `gorm:"type:char(36);primaryKey" json:"id"`
+	WebhookID    string          `gorm:"type:char(36);index"      json:"webhook_id"`
+	EventID      string          `gorm:"type:varchar(32);index"   json:"event_id"`
+	Topic        string          `gorm:"type:varchar(64)"         json:"topic"`
+	Attempt      int             `                                json:"attempt"`       // 第几次尝试，从 1 开始
+	Success      bool            `                                json:"success"`       // 是否收到 2xx
+	StatusCode   int             `                                json:"status_code"`   // 响应状态码，未收到响应时为 0
+	Error        string          `gorm:"type:text"                json:"error"`         // 网络错误或非 2xx 的说明
+	LatencyMs    int64           `                                json:"latency_ms"`    // 请求耗时（毫秒）
+	RequestBody  string          `gorm:"type:text"                json:"request_body"`  // 实际发出的请求体
+	ResponseBody string          `gorm:"type:text"                json:"response_body"` // 响应体（截断）
+	RedeliveryOf string          `gorm:"type:char(36)"            json:"redelivery_of"` // 重新投递时指向原记录
+	Observation  json.RawMessage `gorm:"type:text"                json:"-"`
+	CreatedAt    int64           `gorm:"autoCreateTime"           json:"created_at"`
+}
+
+func (d *WebhookDelivery) BeforeCreate(_ *gorm.DB) error {
+	if d.ID == "" {
+		d.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package model
+
+import (
+	"encoding/json"
+
+	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
+	"gorm.io/gorm"
+)
+
+// Outbox 条目状态。投递成功的条目直接删除，历史看投递记录。
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusDead    = "dead"
+)
+
+// WebhookOutbox 是一条待投递给某个 webhook 的事件。它与触发它的业务写入同一事务落库，由
+// Dispatcher 的 worker 取出投递；失败按指数退避重排，次数用尽后转为死信（Status=dead），
+// 等管理员重试或清除。
+type WebhookOutbox struct {
+	ID            string          `gorm:"type:char(36);primaryKey"                                 json:"id"`
+	WebhookID     string          `gorm:"type:char(36);index"                                      json:"webhook_id"`
+	EventID       string          `gorm:"type:varchar(32)"                                         json:"event_id"` // 各次尝试共用
+	Topic         string          `gorm:"type:varchar(64)"                                         json:"topic"`
+	Status        string          `gorm:"type:varchar(16);index:idx_webhook_outbox_due,priority:1" json:"status"`
+	Attempts      int             `                                                                json:"attempts"`        // 已失败的尝试次数
+	NextAttemptAt int64           `gorm:"index:idx_webhook_outbox_due,priority:2"                  json:"next_attempt_at"` // 下次可取出的时间（Unix 秒）
+	LastError     string          `gorm:"type:text"                                                json:"last_error"`
+	Observation   json.RawMessage `gorm:"type:text"                                                json:"-"`
+	CreatedAt     int64           `gorm:"autoCreateTime"                                           json:"created_at"`
+	UpdatedAt     int64           `gorm:"autoUpdateTime"                                           json:"updated_at"`
+}
+
+func (o *WebhookOutbox) BeforeCreate(_ *gorm.DB) error {
+	if o.ID == "" {
+		o.ID = uuidUtil.MustNewV7()
+	}
+	return nil
+}
@@ -4,21 +4,36 @@
 package model
 
 import (
+	"slices"
+
 	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	"gorm.io/gorm"
 )
 
+// 载荷模板。空值是 Ech0 自己的 JSON 信封；slack/discord/feishu 把事件摘要成对应机器人的消息体；
+// custom 用 TemplateBody（Go text/template）自行渲染。
+const (
+	TemplateDefault = ""
+	TemplateSlack   = "slack"
+	TemplateDiscord = "discord"
+	TemplateFeishu  = "feishu"
+	TemplateCustom  = "custom"
+)
+
 // Webhook 定义 Webhook 设置实体
 type Webhook struct {
-	ID          string `gorm:"type:char(36);primaryKey" json:"id"` // Webhook ID
-	Name        string `                    json:"name"`           // Webhook 名称
-	URL         string `                    json:"url"`            // Webhook URL
-	Secret      string `                    json:"-"`              // 签名密钥，用于请求验证（HMAC等）
-	IsActive    bool   `gorm:"default:true" json:"is_active"`      // 启用/禁用状态
-	LastStatus  string `                    json:"last_status"`    // 最近调用状态（如 success, failed）
-	LastTrigger int64  `                    json:"last_trigger"`   // 最近触发时间
-	CreatedAt   int64  `gorm:"autoCreateTime" json:"created_at"`   // 创建时间
-	UpdatedAt   int64  `gorm:"autoUpdateTime" json:"updated_at"`   // 更新时间
+	ID           string   `gorm:"type:char(36);primaryKey"  json:"id"`            // Webhook ID
+	Name         string   `                                 json:"name"`          // Webhook 名称
+	URL          string   `                                 json:"url"`           // Webhook URL
+	Secret       string   `                                 json:"-"`             // 签名密钥，用于请求验证（HMAC等）
+	IsActive     bool     `gorm:"default:true"              json:"is_active"`     // 启用/禁用状态
+	Topics       []string `gorm:"serializer:json;type:text" json:"topics"`        // 订阅的事件 topic（EventName），为空表示全部
+	Template     string   `gorm:"type:varchar(16)"          json:"template"`      // 载荷模板（见 Template* 常量）
+	TemplateBody string   `gorm:"type:text"                 json:"template_body"` // custom 模板正文
+	LastStatus   string   `                                 json:"last_status"`   // 最近调用状态（如 success, failed）
+	LastTrigger  int64    `                                 json:"last_trigger"`  // 最近触发时间
+	CreatedAt    int64    `gorm:"autoCreateTime"            json:"created_at"`    // 创建时间
+	UpdatedAt    int64    `gorm:"autoUpdateTime"            json:"updated_at"`    // 更新时间
 }
 
 func (w *Webhook) BeforeCreate(_ *gorm.DB) error {
@@ -27,3 +42,8 @@ func (w *Webhook) BeforeCreate(_ *gorm.DB) error {
 	}
 	return nil
 }
+
+// Subscribes 报告该 webhook 是否订阅了 topic。未配置订阅（老数据或显式留空）时接收全部事件。
+func (w *Webhook) Subscribes(topic string) bool {
+	return len(w.Topics) == 0 || slices.Contains(w.Topics, topic)
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	activitypubService "github.com/lin-snow/ech0/internal/service/activitypub"
+	"github.com/lin-snow/ech0/internal/transaction"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ActivityPubRepository struct {
+	db func() *gorm.DB
+}
+
+var _ activitypubService.Repository = (*ActivityPubRepository)(nil)
+
+func NewActivityPubRepository(dbProvider func() *gorm.DB) *ActivityPubRepository {
+	return &ActivityPubRepository{db: dbProvider}
+}
+
+// getDB 从上下文中获取事务
+func (r *ActivityPubRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return tx
+	}
+	return r.db()
+}
+
+// UpsertFollower 记录关注者；同一 actor 重复关注时刷新其收件箱地址。
+func (r *ActivityPubRepository) UpsertFollower(ctx context.Context, follower *model.Follower) error {
+	return r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "actor_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"handle", "inbox", "shared_inbox"}),
+	}).Create(follower).Error
+}
+
+// DeleteFollower 按 actor ID 移除关注者，不存在时不报错。
+func (r *ActivityPubRepository) DeleteFollower(ctx context.Context, actorID string) error {
+	return r.getDB(ctx).Where("actor_id = ?", actorID).Delete(&model.Follower{}).Error
+}
+
+// ListFollowers 返回全部关注者，最早关注的在前。
+func (r *ActivityPubRepository) ListFollowers(ctx context.Context) ([]model.Follower, error) {
+	var followers []model.Follower
+	if err := r.getDB(ctx).Order("created_at ASC").Find(&followers).Error; err != nil {
+		return nil, err
+	}
+	return followers, nil
+}
+
+func (r *ActivityPubRepository) CountFollowers(ctx context.Context) (int64, error) {
+	var total int64
+	err := r.getDB(ctx).Model(&model.Follower{}).Count(&total).Error
+	return total, err
+}
+
+// CreateLike 记录一次点赞；同一 actor 对同一 Echo 已点过赞时返回 false。
+func (r *ActivityPubRepository) CreateLike(ctx context.Context, like *model.Like) (bool, error) {
+	result := r.getDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(like)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// DeleteLike 撤销点赞记录，不存在时不报错。
+func (r *ActivityPubRepository) DeleteLike(ctx context.Context, actorID, echoID string) error {
+	return r.getDB(ctx).
+		Where("actor_id = ? AND echo_id = ?", actorID, echoID).
+		Delete(&model.Like{}).Error
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	activitypubRepository "github.com/lin-snow/ech0/internal/repository/activitypub"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func newRepo(t *testing.T) *activitypubRepository.ActivityPubRepository {
+	t.Helper()
+	db := helpers.NewTestDB(t)
+	return activitypubRepository.NewActivityPubRepository(func() *gorm.DB { return db })
+}
+
+// TestUpsertFollower 校验重复关注只保留一行，并刷新收件箱地址。
+func TestUpsertFollower(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertFollower(ctx, &model.Follower{
+		ActorID: "https://remote.example/users/alice",
+		Handle:  "alice@remote.example",
+		Inbox:   "https://remote.example/users/alice/inbox",
+	}))
+	require.NoError(t, repo.UpsertFollower(ctx, &model.Follower{
+		ActorID:     "https://remote.example/users/alice",
+		Handle:      "alice@remote.example",
+		Inbox:       "https://remote.example/users/alice/inbox2",
+		SharedInbox: "https://remote.example/inbox",
+	}))
+
+	followers, err := repo.ListFollowers(ctx)
+	require.NoError(t, err)
+	require.Len(t, followers, 1)
+	assert.Equal(t, "https://remote.example/users/alice/inbox2", followers[0].Inbox)
+	assert.Equal(t, "https://remote.example/inbox", followers[0].DeliveryInbox())
+
+	total, err := repo.CountFollowers(ctx)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, total)
+
+	require.NoError(t, repo.DeleteFollower(ctx, "https://remote.example/users/alice"))
+	total, err = repo.CountFollowers(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, total)
+}
+
+// TestCreateLike_Dedupes 校验同一 actor 对同一 Echo 只记一次，撤销后可再次点赞。
+func TestCreateLike_Dedupes(t *testing.T) {
+	repo := newRepo(t)
+	ctx := context.Background()
+	actor := "https://remote.example/users/alice"
+
+	created, err := repo.CreateLike(ctx, &model.Like{ActorID: actor, EchoID: "echo-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	created, err = repo.CreateLike(ctx, &model.Like{ActorID: actor, EchoID: "echo-1"})
+	require.NoError(t, err)
+	assert.False(t, created, "duplicate like must not be counted")
+
+	created, err = repo.CreateLike(ctx, &model.Like{ActorID: actor, EchoID: "echo-2"})
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	require.NoError(t, repo.DeleteLike(ctx, actor, "echo-1"))
+	created, err = repo.CreateLike(ctx, &model.Like{ActorID: actor, EchoID: "echo-1"})
+	require.NoError(t, err)
+	assert.True(t, created)
+}
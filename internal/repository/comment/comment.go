@@ -5,6 +5,7 @@ package repository
 
 import (
 	"context"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type CommentRepository struct {
@@ -31,6 +33,11 @@ func (r *CommentRepository) getDB(ctx context.Context) *gorm.DB {
 	return r.db()
 }
 
+// notTrashed 排除回收站中的评论。除回收站自身的读写外，所有读路径都应带上它。
+func notTrashed(db *gorm.DB) *gorm.DB {
+	return db.Where("deleted_at = 0")
+}
+
 func (r *CommentRepository) CreateComment(ctx context.Context, c *model.Comment) error {
 	return r.getDB(ctx).Create(c).Error
 }
@@ -38,15 +45,29 @@ func (r *CommentRepository) CreateComment(ctx context.Context, c *model.Comment)
 func (r *CommentRepository) ListPublicByEchoID(ctx context.Context, echoID string) ([]model.Comment, error) {
 	var out []model.Comment
 	err := r.getDB(ctx).
+		Scopes(notTrashed).
 		Where("echo_id = ? AND status = ?", echoID, model.StatusApproved).
 		Order("created_at asc").
 		Find(&out).Error
 	return out, err
 }
 
+// ListPublicSubtree 返回物化路径为 path 的评论及其全部后代中已通过且不在回收站的评论，
+// 按路径排序（即先序遍历顺序）。
+func (r *CommentRepository) ListPublicSubtree(ctx context.Context, path string) ([]model.Comment, error) {
+	var out []model.Comment
+	err := r.getDB(ctx).
+		Scopes(notTrashed).
+		Where("(path = ? OR path LIKE ?) AND status = ?", path, path+model.PathSeparator+"%", model.StatusApproved).
+		Order("path asc").
+		Find(&out).Error
+	return out, err
+}
+
 func (r *CommentRepository) ListPublicComments(ctx context.Context, limit int) ([]model.Comment, error) {
 	var out []model.Comment
 	err := r.getDB(ctx).
+		Scopes(notTrashed).
 		Where("status = ?", model.StatusApproved).
 		Order("created_at desc").
 		Limit(limit).
@@ -59,6 +80,11 @@ func (r *CommentRepository) ListComments(
 	query model.ListCommentQuery,
 ) (model.PageResult[model.Comment], error) {
 	db := r.getDB(ctx).Model(&model.Comment{})
+	if query.Trashed {
+		db = db.Where("deleted_at > 0")
+	} else {
+		db = db.Scopes(notTrashed)
+	}
 	if query.EchoID != "" {
 		db = db.Where("echo_id = ?", query.EchoID)
 	}
@@ -85,7 +111,11 @@ func (r *CommentRepository) ListComments(
 
 	var items []model.Comment
 	offset := (query.Page - 1) * query.PageSize
-	err := db.Order("created_at desc").
+	order := "created_at desc"
+	if query.Trashed {
+		order = "deleted_at desc"
+	}
+	err := db.Order(order).
 		Offset(offset).
 		Limit(query.PageSize).
 		Find(&items).Error
@@ -105,6 +135,20 @@ func (r *CommentRepository) GetCommentByID(ctx context.Context, id string) (mode
 	return item, err
 }
 
+// FindByRemoteID 按 fediverse Note ID 查评论（含回收站中的）；不存在时返回零值且不报错。
+func (r *CommentRepository) FindByRemoteID(ctx context.Context, remoteID string) (model.Comment, error) {
+	var item model.Comment
+	err := r.getDB(ctx).Where("remote_id = ?", remoteID).Limit(1).Find(&item).Error
+	return item, err
+}
+
+// FindByEchoAndRemoteID 按 Echo 与远端 ID 查找评论（含回收站），不存在时返回零值。
+func (r *CommentRepository) FindByEchoAndRemoteID(ctx context.Context, echoID, remoteID string) (model.Comment, error) {
+	var item model.Comment
+	err := r.getDB(ctx).Where("echo_id = ? AND remote_id = ?", echoID, remoteID).Limit(1).Find(&item).Error
+	return item, err
+}
+
 func (r *CommentRepository) UpdateCommentStatus(
 	ctx context.Context,
 	id string,
@@ -127,8 +171,68 @@ func (r *CommentRepository) UpdateCommentHot(
 		Update("hot", hot).Error
 }
 
+// UpdateSpamLabel 记录评论已计入内置分类器的样本标签。
+func (r *CommentRepository) UpdateSpamLabel(ctx context.Context, id string, label model.SpamVerdict) error {
+	return r.getDB(ctx).
+		Model(&model.Comment{}).
+		Where("id = ?", id).
+		Update("spam_label", label).Error
+}
+
+// SpamTokenCounts 读取给定特征的词频；从未出现过的特征不在结果中。
+func (r *CommentRepository) SpamTokenCounts(ctx context.Context, tokens []string) (map[string]model.SpamToken, error) {
+	out := make(map[string]model.SpamToken, len(tokens))
+	if len(tokens) == 0 {
+		return out, nil
+	}
+	var rows []model.SpamToken
+	if err := r.getDB(ctx).Where("token IN ?", tokens).Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.Token] = row
+	}
+	return out, nil
+}
+
+// LearnSpamTokens 以 delta 增减各特征的垃圾或正常计数，结果不低于 0。
+func (r *CommentRepository) LearnSpamTokens(ctx context.Context, tokens []string, spam bool, delta int64) error {
+	if len(tokens) == 0 || delta == 0 {
+		return nil
+	}
+	column := "ham"
+	if spam {
+		column = "spam"
+	}
+	rows := make([]model.SpamToken, 0, len(tokens))
+	seen := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		row := model.SpamToken{Token: token}
+		if spam {
+			row.Spam = max(delta, 0)
+		} else {
+			row.Ham = max(delta, 0)
+		}
+		rows = append(rows, row)
+	}
+	return r.getDB(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "token"}},
+			DoUpdates: clause.Set{{
+				Column: clause.Column{Name: column},
+				Value:  gorm.Expr("MAX(0, "+column+" + ?)", delta),
+			}},
+		}).
+		CreateInBatches(rows, 100).Error
+}
+
+// DeleteComment 把评论移入回收站；彻底删除见 PurgeComments。
 func (r *CommentRepository) DeleteComment(ctx context.Context, id string) error {
-	return r.getDB(ctx).Where("id = ?", id).Delete(&model.Comment{}).Error
+	return r.BatchDelete(ctx, []string{id})
 }
 
 func (r *CommentRepository) BatchUpdateStatus(
@@ -145,11 +249,137 @@ func (r *CommentRepository) BatchUpdateStatus(
 		Update("status", status).Error
 }
 
+// BatchDelete 把一批评论移入回收站，已在回收站中的保持原删除时间不变。
 func (r *CommentRepository) BatchDelete(ctx context.Context, ids []string) error {
 	if len(ids) == 0 {
 		return nil
 	}
-	return r.getDB(ctx).Where("id IN ?", ids).Delete(&model.Comment{}).Error
+	return r.getDB(ctx).
+		Model(&model.Comment{}).
+		Scopes(notTrashed).
+		Where("id IN ?", ids).
+		UpdateColumn("deleted_at", time.Now().UTC().Unix()).Error
+}
+
+// RestoreComments 把一批评论从回收站移回原处，返回实际恢复的条数。
+func (r *CommentRepository) RestoreComments(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	result := r.getDB(ctx).
+		Model(&model.Comment{}).
+		Where("id IN ? AND deleted_at > 0", ids).
+		UpdateColumn("deleted_at", 0)
+	return result.RowsAffected, result.Error
+}
+
+// PurgeComments 彻底删除一批回收站中的评论；不在回收站的评论不受影响。
+func (r *CommentRepository) PurgeComments(ctx context.Context, ids []string) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	var purged int64
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var trashed []string
+		if err := tx.Model(&model.Comment{}).
+			Where("id IN ? AND deleted_at > 0", ids).
+			Pluck("id", &trashed).Error; err != nil {
+			return err
+		}
+		if len(trashed) == 0 {
+			return nil
+		}
+		if err := tx.Where("comment_id IN ?", trashed).Delete(&model.CommentReaction{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id IN ?", trashed).Delete(&model.Comment{})
+		purged = result.RowsAffected
+		return result.Error
+	})
+	return purged, err
+}
+
+// ListExpiredTrashed 返回删除时间早于 before 的回收站评论，最早删除的在前，最多 limit 条。
+func (r *CommentRepository) ListExpiredTrashed(ctx context.Context, before int64, limit int) ([]model.Comment, error) {
+	var out []model.Comment
+	err := r.getDB(ctx).
+		Where("deleted_at > 0 AND deleted_at < ?", before).
+		Order("deleted_at asc").
+		Limit(limit).
+		Find(&out).Error
+	return out, err
+}
+
+// AddReaction 记录一个表情回应；同一回应者对同一评论的同一表情已存在时不重复写入，返回 false。
+func (r *CommentRepository) AddReaction(ctx context.Context, reaction *model.CommentReaction) (bool, error) {
+	result := r.getDB(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(reaction)
+	return result.RowsAffected > 0, result.Error
+}
+
+// RemoveReaction 撤回一个表情回应，返回是否确有记录被删除。
+func (r *CommentRepository) RemoveReaction(ctx context.Context, commentID, emoji, actorKey string) (bool, error) {
+	result := r.getDB(ctx).
+		Where("comment_id = ? AND emoji = ? AND actor_key = ?", commentID, emoji, actorKey).
+		Delete(&model.CommentReaction{})
+	return result.RowsAffected > 0, result.Error
+}
+
+// CountReactions 按评论汇总表情回应数，键为评论 ID，每条评论的计数按 model.Reactions 的顺序排列；
+// 没有回应的评论不出现在结果中。
+func (r *CommentRepository) CountReactions(ctx context.Context, commentIDs []string) (map[string][]model.ReactionCount, error) {
+	out := make(map[string][]model.ReactionCount)
+	if len(commentIDs) == 0 {
+		return out, nil
+	}
+	var rows []struct {
+		CommentID string
+		Emoji     string
+		Count     int64
+	}
+	err := r.getDB(ctx).
+		Model(&model.CommentReaction{}).
+		Select("comment_id, emoji, COUNT(*) AS count").
+		Where("comment_id IN ?", commentIDs).
+		Group("comment_id, emoji").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		out[row.CommentID] = append(out[row.CommentID], model.ReactionCount{Emoji: row.Emoji, Count: row.Count})
+	}
+	for _, counts := range out {
+		slices.SortFunc(counts, func(a, b model.ReactionCount) int {
+			return slices.Index(model.Reactions, a.Emoji) - slices.Index(model.Reactions, b.Emoji)
+		})
+	}
+	return out, nil
+}
+
+func (r *CommentRepository) CountReactionsByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
+	return r.countReactionsWithin(ctx, "ip_hash", ipHash, seconds)
+}
+
+func (r *CommentRepository) CountReactionsByUserWithin(ctx context.Context, userID string, seconds int64) (int64, error) {
+	return r.countReactionsWithin(ctx, "user_id", userID, seconds)
+}
+
+func (r *CommentRepository) countReactionsWithin(
+	ctx context.Context,
+	field string,
+	value string,
+	seconds int64,
+) (int64, error) {
+	var count int64
+	if strings.TrimSpace(value) == "" {
+		return 0, nil
+	}
+	since := time.Now().UTC().Unix() - seconds
+	err := r.getDB(ctx).
+		Model(&model.CommentReaction{}).
+		Where(field+" = ? AND created_at >= ?", value, since).
+		Count(&count).Error
+	return count, err
 }
 
 func (r *CommentRepository) CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
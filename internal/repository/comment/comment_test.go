@@ -433,7 +433,7 @@ func TestBatchDelete(t *testing.T) {
 		assert.Equal(t, int64(1), countRows(t, db))
 	})
 
-	t.Run("deletes only targeted ids", func(t *testing.T) {
+	t.Run("moves only targeted ids to trash", func(t *testing.T) {
 		repo, db := newRepo(t)
 		ctx := context.Background()
 		id1 := insert(t, repo, newComment())
@@ -441,16 +441,63 @@ func TestBatchDelete(t *testing.T) {
 		id3 := insert(t, repo, newComment())
 
 		require.NoError(t, repo.BatchDelete(ctx, []string{id1, id2}))
-		assert.Equal(t, int64(1), countRows(t, db))
+		assert.Equal(t, int64(3), countRows(t, db), "移入回收站不删行")
 
-		_, err := repo.GetCommentByID(ctx, id1)
-		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+		trashed, err := repo.GetCommentByID(ctx, id1)
+		require.NoError(t, err)
+		assert.NotZero(t, trashed.DeletedAt)
 		remaining, err := repo.GetCommentByID(ctx, id3)
 		require.NoError(t, err)
-		assert.Equal(t, id3, remaining.ID)
+		assert.Zero(t, remaining.DeletedAt)
+
+		live, err := repo.ListComments(ctx, model.ListCommentQuery{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), live.Total)
+		inTrash, err := repo.ListComments(ctx, model.ListCommentQuery{Page: 1, PageSize: 10, Trashed: true})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), inTrash.Total)
 	})
 }
 
+func TestRestoreAndPurgeComments(t *testing.T) {
+	repo, db := newRepo(t)
+	ctx := context.Background()
+	id1 := insert(t, repo, newComment(func(c *model.Comment) { c.Status = model.StatusApproved }))
+	id2 := insert(t, repo, newComment())
+	id3 := insert(t, repo, newComment())
+	require.NoError(t, repo.BatchDelete(ctx, []string{id1, id2}))
+
+	n, err := repo.RestoreComments(ctx, []string{id1, id3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "未在回收站的评论不计入恢复")
+	restored, err := repo.GetCommentByID(ctx, id1)
+	require.NoError(t, err)
+	assert.Zero(t, restored.DeletedAt)
+	assert.Equal(t, model.StatusApproved, restored.Status, "恢复保留原审核状态")
+
+	n, err = repo.PurgeComments(ctx, []string{id2, id3})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n, "彻底删除只作用于回收站中的评论")
+	assert.Equal(t, int64(2), countRows(t, db))
+	_, err = repo.GetCommentByID(ctx, id2)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestListExpiredTrashed(t *testing.T) {
+	repo, db := newRepo(t)
+	ctx := context.Background()
+	oldID := insert(t, repo, newComment())
+	newID := insert(t, repo, newComment())
+	insert(t, repo, newComment())
+	require.NoError(t, db.Model(&model.Comment{}).Where("id = ?", oldID).Update("deleted_at", 1_000).Error)
+	require.NoError(t, db.Model(&model.Comment{}).Where("id = ?", newID).Update("deleted_at", 5_000).Error)
+
+	expired, err := repo.ListExpiredTrashed(ctx, 2_000, 10)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, oldID, expired[0].ID)
+}
+
 // --- 其余 CRUD / 公共投影查询（顺带覆盖） --------------------------------------
 
 func TestCreateGetUpdateDelete(t *testing.T) {
@@ -471,8 +518,25 @@ func TestCreateGetUpdateDelete(t *testing.T) {
 	assert.True(t, got.Hot)
 
 	require.NoError(t, repo.DeleteComment(ctx, id))
-	_, err = repo.GetCommentByID(ctx, id)
-	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	got, err = repo.GetCommentByID(ctx, id)
+	require.NoError(t, err)
+	assert.NotZero(t, got.DeletedAt)
+}
+
+func TestFindByEchoAndRemoteID(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+	source := "https://blog.example/post"
+	id := insert(t, repo, newComment(func(c *model.Comment) { c.RemoteID = source; c.Source = model.SourceWebmention }))
+	insert(t, repo, newComment(func(c *model.Comment) { c.EchoID = "echo-2"; c.RemoteID = source }))
+	require.NoError(t, repo.DeleteComment(ctx, id))
+
+	got, err := repo.FindByEchoAndRemoteID(ctx, "echo-1", source)
+	require.NoError(t, err)
+	assert.Equal(t, id, got.ID, "回收站中的提及同样参与去重")
+	got, err = repo.FindByEchoAndRemoteID(ctx, "echo-3", source)
+	require.NoError(t, err)
+	assert.Empty(t, got.ID)
 }
 
 func TestListPublicByEchoID(t *testing.T) {
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPublicSubtree_FollowsMaterializedPath(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+	approved := func(c *model.Comment) { c.Status = model.StatusApproved }
+
+	root := newComment(approved)
+	require.NoError(t, repo.CreateComment(ctx, &root))
+	child := newComment(approved)
+	child.AttachTo(&root)
+	require.NoError(t, repo.CreateComment(ctx, &child))
+	grandchild := newComment(approved)
+	grandchild.AttachTo(&child)
+	require.NoError(t, repo.CreateComment(ctx, &grandchild))
+	pending := newComment()
+	pending.AttachTo(&child)
+	require.NoError(t, repo.CreateComment(ctx, &pending))
+	sibling := newComment(approved)
+	require.NoError(t, repo.CreateComment(ctx, &sibling))
+
+	got, err := repo.ListPublicSubtree(ctx, root.Path)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(got))
+	for _, c := range got {
+		ids = append(ids, c.ID)
+	}
+	assert.Equal(t, []string{root.ID, child.ID, grandchild.ID}, ids)
+
+	got, err = repo.ListPublicSubtree(ctx, child.Path)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, 2, got[1].Depth)
+}
+
+func TestReactions_AddRemoveCount(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+	id := insert(t, repo, newComment())
+
+	created, err := repo.AddReaction(ctx, &model.CommentReaction{CommentID: id, Emoji: "👍", ActorKey: "ip:a", IPHash: "a"})
+	require.NoError(t, err)
+	assert.True(t, created)
+	created, err = repo.AddReaction(ctx, &model.CommentReaction{CommentID: id, Emoji: "👍", ActorKey: "ip:a", IPHash: "a"})
+	require.NoError(t, err)
+	assert.False(t, created, "same actor and emoji is recorded once")
+	_, err = repo.AddReaction(ctx, &model.CommentReaction{CommentID: id, Emoji: "👍", ActorKey: "user:u", UserID: ptr("u")})
+	require.NoError(t, err)
+	_, err = repo.AddReaction(ctx, &model.CommentReaction{CommentID: id, Emoji: "🎉", ActorKey: "ip:a", IPHash: "a"})
+	require.NoError(t, err)
+
+	counts, err := repo.CountReactions(ctx, []string{id, "other"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]model.ReactionCount{
+		id: {{Emoji: "👍", Count: 2}, {Emoji: "🎉", Count: 1}},
+	}, counts)
+
+	n, err := repo.CountReactionsByIPWithin(ctx, "a", 60)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+	n, err = repo.CountReactionsByUserWithin(ctx, "u", 60)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	removed, err := repo.RemoveReaction(ctx, id, "👍", "ip:a")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = repo.RemoveReaction(ctx, id, "👍", "ip:a")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestPurgeComments_DropsReactions(t *testing.T) {
+	repo, db := newRepo(t)
+	ctx := context.Background()
+	id := insert(t, repo, newComment())
+	_, err := repo.AddReaction(ctx, &model.CommentReaction{CommentID: id, Emoji: "👀", ActorKey: "ip:a"})
+	require.NoError(t, err)
+	require.NoError(t, repo.DeleteComment(ctx, id))
+
+	purged, err := repo.PurgeComments(ctx, []string{id})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	var left int64
+	require.NoError(t, db.Model(&model.CommentReaction{}).Count(&left).Error)
+	assert.Zero(t, left)
+}
+
+func TestSpamTokens_LearnAndUnlearn(t *testing.T) {
+	repo, _ := newRepo(t)
+	ctx := context.Background()
+
+	require.NoError(t, repo.LearnSpamTokens(ctx, []string{"casino", "pills", "casino"}, true, 1))
+	require.NoError(t, repo.LearnSpamTokens(ctx, []string{"casino"}, true, 1))
+	require.NoError(t, repo.LearnSpamTokens(ctx, []string{"casino"}, false, 1))
+
+	got, err := repo.SpamTokenCounts(ctx, []string{"casino", "pills", "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, model.SpamToken{Token: "casino", Spam: 2, Ham: 1}, got["casino"])
+	assert.Equal(t, model.SpamToken{Token: "pills", Spam: 1}, got["pills"])
+	assert.NotContains(t, got, "unknown")
+
+	// 撤销不会把计数减到负数；首次出现即撤销也只记 0
+	require.NoError(t, repo.LearnSpamTokens(ctx, []string{"pills", "fresh"}, true, -1))
+	require.NoError(t, repo.LearnSpamTokens(ctx, []string{"pills"}, true, -1))
+	got, err = repo.SpamTokenCounts(ctx, []string{"pills", "fresh"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), got["pills"].Spam)
+	assert.Equal(t, int64(0), got["fresh"].Spam)
+}
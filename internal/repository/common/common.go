@@ -68,6 +68,7 @@ func (commonRepository *CommonRepository) GetAllEchos(ctx context.Context, showP
 		}).
 		Preload("EchoFiles.File").
 		Preload("Tags").
+		Where("status = ? AND deleted_at = 0", echoModel.StatusPublished).
 		Order("created_at DESC")
 
 	if !showPrivate {
@@ -89,6 +90,7 @@ func (commonRepository *CommonRepository) GetHeatMap(
 
 	err := commonRepository.getDB(ctx).
 		Table("echos").
+		Where("status = ? AND deleted_at = 0", echoModel.StatusPublished).
 		Where("created_at >= ? AND created_at < ?", startUTC, endUTC).
 		Order("created_at ASC").
 		Pluck("created_at", &results).Error
@@ -0,0 +1,234 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package repository 实现 Copilot Chat 线程、消息与待确认动作（copilot_threads / copilot_messages /
+// copilot_actions）的 GORM 持久化。
+package repository
+
+import (
+	"context"
+	"strings"
+
+	model "github.com/lin-snow/ech0/internal/model/copilot"
+	"github.com/lin-snow/ech0/internal/transaction"
+	"gorm.io/gorm"
+)
+
+type CopilotRepository struct {
+	db func() *gorm.DB
+}
+
+func NewCopilotRepository(dbProvider func() *gorm.DB) *CopilotRepository {
+	return &CopilotRepository{db: dbProvider}
+}
+
+func (copilotRepository *CopilotRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return tx
+	}
+	return copilotRepository.db()
+}
+
+// CreateChatThread 新建线程（BeforeCreate 自动补 ID）。
+func (copilotRepository *CopilotRepository) CreateChatThread(ctx context.Context, thread *model.ChatThread) error {
+	return copilotRepository.getDB(ctx).Create(thread).Error
+}
+
+// GetChatThread 按 ID 取某用户的线程；不存在或属于他人时返回 gorm.ErrRecordNotFound。
+func (copilotRepository *CopilotRepository) GetChatThread(
+	ctx context.Context,
+	userID, id string,
+) (*model.ChatThread, error) {
+	var thread model.ChatThread
+	if err := copilotRepository.getDB(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&thread).Error; err != nil {
+		return nil, err
+	}
+	return &thread, nil
+}
+
+// ListChatThreads 分页列出某用户的线程，最近活跃的在前。search 非空时匹配标题或任一消息正文。
+func (copilotRepository *CopilotRepository) ListChatThreads(
+	ctx context.Context,
+	userID, search string,
+	page, pageSize int,
+) ([]model.ChatThread, int64, error) {
+	var (
+		threads []model.ChatThread
+		total   int64
+	)
+	query := copilotRepository.getDB(ctx).Model(&model.ChatThread{}).Where("user_id = ?", userID)
+	if search = strings.TrimSpace(search); search != "" {
+		like := "%" + escapeLike(search) + "%"
+		matched := copilotRepository.getDB(ctx).Model(&model.ChatMessage{}).
+			Select("thread_id").
+			Where(`content LIKE ? ESCAPE '\'`, like)
+		query = query.Where(`title LIKE ? ESCAPE '\' OR id IN (?)`, like, matched)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.
+		Order("updated_at DESC, id DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&threads).Error; err != nil {
+		return nil, 0, err
+	}
+	return threads, total, nil
+}
+
+// UpdateChatThread 回写线程的标题与历史预算。
+func (copilotRepository *CopilotRepository) UpdateChatThread(ctx context.Context, thread *model.ChatThread) error {
+	return copilotRepository.getDB(ctx).
+		Model(&model.ChatThread{}).
+		Where("id = ? AND user_id = ?", thread.ID, thread.UserID).
+		Select("title", "token_budget").
+		Updates(thread).Error
+}
+
+// DeleteChatThread 删除某用户的线程及其消息，返回是否删到了线程。
+func (copilotRepository *CopilotRepository) DeleteChatThread(ctx context.Context, userID, id string) (bool, error) {
+	var deleted bool
+	err := copilotRepository.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("id = ? AND user_id = ?", id, userID).Delete(&model.ChatThread{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return nil
+		}
+		deleted = true
+		return tx.Where("thread_id = ?", id).Delete(&model.ChatMessage{}).Error
+	})
+	return deleted, err
+}
+
+// AppendChatMessages 把一轮消息追加到线程末尾：按线程当前的 MessageCount 依次分配 Seq，
+// 并在同一事务里推进计数与 updated_at。
+func (copilotRepository *CopilotRepository) AppendChatMessages(
+	ctx context.Context,
+	threadID string,
+	msgs []model.ChatMessage,
+) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	return copilotRepository.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		var thread model.ChatThread
+		if err := tx.Select("id", "message_count").Where("id = ?", threadID).First(&thread).Error; err != nil {
+			return err
+		}
+		for i := range msgs {
+			msgs[i].ThreadID = threadID
+			msgs[i].Seq = thread.MessageCount + i
+		}
+		if err := tx.Create(&msgs).Error; err != nil {
+			return err
+		}
+		return tx.Model(&model.ChatThread{}).
+			Where("id = ?", threadID).
+			Update("message_count", thread.MessageCount+len(msgs)).Error
+	})
+}
+
+// ListChatMessages 返回线程的全部消息，按时间正序。
+func (copilotRepository *CopilotRepository) ListChatMessages(
+	ctx context.Context,
+	threadID string,
+) ([]model.ChatMessage, error) {
+	var msgs []model.ChatMessage
+	if err := copilotRepository.getDB(ctx).
+		Where("thread_id = ?", threadID).
+		Order("seq ASC").
+		Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+	return msgs, nil
+}
+
+// ListRecentChatMessages 返回线程最近的 limit 条消息，按时间正序。
+func (copilotRepository *CopilotRepository) ListRecentChatMessages(
+	ctx context.Context,
+	threadID string,
+	limit int,
+) ([]model.ChatMessage, error) {
+	var msgs []model.ChatMessage
+	if err := copilotRepository.getDB(ctx).
+		Where("thread_id = ?", threadID).
+		Order("seq DESC").
+		Limit(limit).
+		Find(&msgs).Error; err != nil {
+		return nil, err
+	}
+	for l, r := 0, len(msgs)-1; l < r; l, r = l+1, r-1 {
+		msgs[l], msgs[r] = msgs[r], msgs[l]
+	}
+	return msgs, nil
+}
+
+// CreateChatAction 落一条待确认动作（BeforeCreate 自动补 ID）。
+func (copilotRepository *CopilotRepository) CreateChatAction(ctx context.Context, action *model.ChatAction) error {
+	return copilotRepository.getDB(ctx).Create(action).Error
+}
+
+// GetChatAction 按 ID 取某用户的动作；不存在或属于他人时返回 gorm.ErrRecordNotFound。
+func (copilotRepository *CopilotRepository) GetChatAction(
+	ctx context.Context,
+	userID, id string,
+) (*model.ChatAction, error) {
+	var action model.ChatAction
+	if err := copilotRepository.getDB(ctx).
+		Where("id = ? AND user_id = ?", id, userID).
+		First(&action).Error; err != nil {
+		return nil, err
+	}
+	return &action, nil
+}
+
+// ListChatActions 按 ID 批量取某用户的动作，按创建顺序排列；不属于该用户的 ID 被忽略。
+func (copilotRepository *CopilotRepository) ListChatActions(
+	ctx context.Context,
+	userID string,
+	ids []string,
+) ([]model.ChatAction, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	var actions []model.ChatAction
+	if err := copilotRepository.getDB(ctx).
+		Where("user_id = ? AND id IN ?", userID, ids).
+		Order("created_at ASC, id ASC").
+		Find(&actions).Error; err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// TransitionChatAction 把动作从 from 状态改到 to，返回是否改到：条件更新保证并发确认 / 取消
+// 只有一方生效。
+func (copilotRepository *CopilotRepository) TransitionChatAction(
+	ctx context.Context,
+	userID, id, from, to string,
+) (bool, error) {
+	result := copilotRepository.getDB(ctx).
+		Model(&model.ChatAction{}).
+		Where("id = ? AND user_id = ? AND status = ?", id, userID, from).
+		Update("status", to)
+	return result.RowsAffected > 0, result.Error
+}
+
+// FinishChatAction 回写动作的执行结果（状态、受影响的 Echo 与错误信息）。
+func (copilotRepository *CopilotRepository) FinishChatAction(ctx context.Context, action *model.ChatAction) error {
+	return copilotRepository.getDB(ctx).
+		Model(&model.ChatAction{}).
+		Where("id = ? AND user_id = ?", action.ID, action.UserID).
+		Select("status", "affected_ids", "error").
+		Updates(action).Error
+}
+
+// escapeLike 转义 LIKE 通配符，让检索词按字面匹配。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/copilot"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	copilotRepository "github.com/lin-snow/ech0/internal/repository/copilot"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newTestRepo(t *testing.T) (*copilotRepository.CopilotRepository, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open("file:"+t.Name()+"?mode=memory&cache=shared"), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite failed: %v", err)
+	}
+	if err := db.AutoMigrate(&model.ChatThread{}, &model.ChatMessage{}, &model.ChatAction{}); err != nil {
+		t.Fatalf("automigrate failed: %v", err)
+	}
+	return copilotRepository.NewCopilotRepository(func() *gorm.DB { return db }), db
+}
+
+func seedThread(t *testing.T, repo *copilotRepository.CopilotRepository, userID, title string) *model.ChatThread {
+	t.Helper()
+	thread := &model.ChatThread{UserID: userID, Title: title}
+	if err := repo.CreateChatThread(context.Background(), thread); err != nil {
+		t.Fatalf("create thread failed: %v", err)
+	}
+	return thread
+}
+
+func TestRepo_GetChatThread_ScopedToUser(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	thread := seedThread(t, repo, "u1", "旅行")
+	if thread.ID == "" {
+		t.Fatalf("BeforeCreate should assign an id")
+	}
+
+	got, err := repo.GetChatThread(context.Background(), "u1", thread.ID)
+	if err != nil || got.Title != "旅行" {
+		t.Fatalf("owner lookup failed: %+v %v", got, err)
+	}
+	if _, err := repo.GetChatThread(context.Background(), "u2", thread.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("other user should get ErrRecordNotFound, got %v", err)
+	}
+}
+
+func TestRepo_AppendChatMessages_AssignsSeqAndCount(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+	thread := seedThread(t, repo, "u1", "t")
+
+	if err := repo.AppendChatMessages(ctx, thread.ID, []model.ChatMessage{
+		{Role: model.RoleUser, Content: "q1"},
+		{Role: model.RoleAssistant, Content: "a1", Sources: []embeddingModel.SearchResult{{EchoID: "e1"}}},
+	}); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+	if err := repo.AppendChatMessages(ctx, thread.ID, []model.ChatMessage{
+		{Role: model.RoleUser, Content: "q2"},
+		{Role: model.RoleAssistant, Content: "a2"},
+	}); err != nil {
+		t.Fatalf("second append failed: %v", err)
+	}
+
+	msgs, err := repo.ListChatMessages(ctx, thread.ID)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(msgs) != 4 {
+		t.Fatalf("want 4 messages, got %d", len(msgs))
+	}
+	for i, m := range msgs {
+		if m.Seq != i {
+			t.Fatalf("message %d: want seq %d, got %d", i, i, m.Seq)
+		}
+	}
+	if len(msgs[1].Sources) != 1 || msgs[1].Sources[0].EchoID != "e1" {
+		t.Fatalf("sources should round-trip, got %+v", msgs[1].Sources)
+	}
+
+	got, _ := repo.GetChatThread(ctx, "u1", thread.ID)
+	if got.MessageCount != 4 {
+		t.Fatalf("message_count should follow appends, got %d", got.MessageCount)
+	}
+
+	recent, err := repo.ListRecentChatMessages(ctx, thread.ID, 3)
+	if err != nil {
+		t.Fatalf("list recent failed: %v", err)
+	}
+	if len(recent) != 3 || recent[0].Content != "a1" || recent[2].Content != "a2" {
+		t.Fatalf("recent should be the last 3 in order, got %+v", recent)
+	}
+}
+
+func TestRepo_ListChatThreads_SearchAndPaging(t *testing.T) {
+	repo, db := newTestRepo(t)
+	ctx := context.Background()
+	travel := seedThread(t, repo, "u1", "旅行计划")
+	books := seedThread(t, repo, "u1", "读书")
+	seedThread(t, repo, "u1", "100%_done")
+	seedThread(t, repo, "u2", "旅行")
+	if err := repo.AppendChatMessages(ctx, books.ID, []model.ChatMessage{
+		{Role: model.RoleUser, Content: "去年的旅行读了什么书"},
+	}); err != nil {
+		t.Fatalf("append failed: %v", err)
+	}
+	// 固定 updated_at，避免同一秒内的排序抖动。
+	db.Model(&model.ChatThread{}).Where("id = ?", travel.ID).UpdateColumn("updated_at", 10)
+	db.Model(&model.ChatThread{}).Where("id = ?", books.ID).UpdateColumn("updated_at", 20)
+
+	items, total, err := repo.ListChatThreads(ctx, "u1", "旅行", 1, 10)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if total != 2 || len(items) != 2 || items[0].ID != books.ID || items[1].ID != travel.ID {
+		t.Fatalf("search should match title or message body, newest first; got total=%d %+v", total, items)
+	}
+
+	items, total, _ = repo.ListChatThreads(ctx, "u1", "%", 1, 10)
+	if total != 1 || items[0].Title != "100%_done" {
+		t.Fatalf("LIKE wildcards should match literally, got total=%d %+v", total, items)
+	}
+
+	items, total, _ = repo.ListChatThreads(ctx, "u1", "", 2, 2)
+	if total != 3 || len(items) != 1 {
+		t.Fatalf("second page should hold the remaining thread, got total=%d len=%d", total, len(items))
+	}
+}
+
+func TestRepo_UpdateAndDeleteChatThread(t *testing.T) {
+	repo, db := newTestRepo(t)
+	ctx := context.Background()
+	thread := seedThread(t, repo, "u1", "旧")
+	_ = repo.AppendChatMessages(ctx, thread.ID, []model.ChatMessage{{Role: model.RoleUser, Content: "hi"}})
+
+	thread.Title, thread.TokenBudget = "新", 2000
+	if err := repo.UpdateChatThread(ctx, thread); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	got, _ := repo.GetChatThread(ctx, "u1", thread.ID)
+	if got.Title != "新" || got.TokenBudget != 2000 || got.MessageCount != 1 {
+		t.Fatalf("update should only touch title/budget, got %+v", got)
+	}
+
+	if deleted, err := repo.DeleteChatThread(ctx, "u2", thread.ID); err != nil || deleted {
+		t.Fatalf("other user must not delete, got deleted=%v err=%v", deleted, err)
+	}
+	if deleted, err := repo.DeleteChatThread(ctx, "u1", thread.ID); err != nil || !deleted {
+		t.Fatalf("owner delete failed: deleted=%v err=%v", deleted, err)
+	}
+	var n int64
+	db.Model(&model.ChatMessage{}).Where("thread_id = ?", thread.ID).Count(&n)
+	if n != 0 {
+		t.Fatalf("messages should be deleted with the thread, %d left", n)
+	}
+}
+
+func TestRepo_ChatActions_TransitionAndFinish(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+
+	action := &model.ChatAction{
+		UserID: "u1",
+		Kind:   model.ActionTagEchos,
+		Args:   model.ChatActionArgs{EchoIDs: []string{"e1"}, AddTags: []string{"golang"}},
+		Status: model.ActionStatusPending,
+	}
+	if err := repo.CreateChatAction(ctx, action); err != nil || action.ID == "" {
+		t.Fatalf("create action failed: %+v %v", action, err)
+	}
+	if _, err := repo.GetChatAction(ctx, "u2", action.ID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Fatalf("other user should get ErrRecordNotFound, got %v", err)
+	}
+
+	// 只有处于 from 状态的动作能被转移：第二次确认落空。
+	ok, err := repo.TransitionChatAction(ctx, "u1", action.ID, model.ActionStatusPending, model.ActionStatusRunning)
+	if err != nil || !ok {
+		t.Fatalf("first transition should win: %v %v", ok, err)
+	}
+	ok, err = repo.TransitionChatAction(ctx, "u1", action.ID, model.ActionStatusPending, model.ActionStatusRunning)
+	if err != nil || ok {
+		t.Fatalf("second transition should lose: %v %v", ok, err)
+	}
+
+	action.Status = model.ActionStatusDone
+	action.AffectedIDs = []string{"e1"}
+	if err := repo.FinishChatAction(ctx, action); err != nil {
+		t.Fatalf("finish action failed: %v", err)
+	}
+	got, err := repo.ListChatActions(ctx, "u1", []string{action.ID, "missing"})
+	if err != nil || len(got) != 1 {
+		t.Fatalf("list actions: %+v %v", got, err)
+	}
+	if got[0].Status != model.ActionStatusDone || len(got[0].AffectedIDs) != 1 || got[0].Args.AddTags[0] != "golang" {
+		t.Fatalf("unexpected stored action: %+v", got[0])
+	}
+	if others, _ := repo.ListChatActions(ctx, "u2", []string{action.ID}); len(others) != 0 {
+		t.Fatalf("other user must not list the action, got %+v", others)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/lin-snow/ech0/internal/cache"
@@ -15,6 +16,7 @@ import (
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	"github.com/lin-snow/ech0/internal/transaction"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
 	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
 	"gorm.io/gorm"
 )
@@ -22,6 +24,10 @@ import (
 type EchoRepository struct {
 	db    func() *gorm.DB
 	cache cache.ICache[string, any]
+
+	// searchIndexOnce 保证 FTS5 索引是否存在只检测一次，结果记在 searchIndex，见 hasSearchIndex。
+	searchIndexOnce sync.Once
+	searchIndex     bool
 }
 
 var _ echoService.Repository = (*EchoRepository)(nil)
@@ -75,7 +81,7 @@ func (echoRepository *EchoRepository) GetEchosByPage(
 			var echos []model.Echo
 			var total int64
 
-			query := echoRepository.db().Model(&model.Echo{})
+			query := echoRepository.db().Model(&model.Echo{}).Scopes(publishedOnly)
 			if search != "" {
 				query = query.Where("content LIKE ?", "%"+search+"%")
 			}
@@ -126,6 +132,7 @@ func (echoRepository *EchoRepository) GetEchosById(ctx context.Context, id strin
 				Preload("EchoFiles.File").
 				Preload("Extension").
 				Preload("Tags").
+				Scopes(notTrashed).
 				Where("id = ?", id).
 				First(&row)
 			if result.Error != nil {
@@ -142,6 +149,7 @@ func (echoRepository *EchoRepository) GetEchosById(ctx context.Context, id strin
 				Preload("EchoFiles.File").
 				Preload("Extension").
 				Preload("Tags").
+				Scopes(notTrashed).
 				Where("id = ?", id).
 				First(&row)
 			if result.Error != nil {
@@ -167,6 +175,9 @@ func (echoRepository *EchoRepository) DeleteEchoById(ctx context.Context, id str
 	if err := echoRepository.getDB(ctx).Where("echo_id = ?", id).Delete(&model.EchoExtension{}).Error; err != nil {
 		return err
 	}
+	if err := echoRepository.getDB(ctx).Where("echo_id = ?", id).Delete(&model.EchoRevision{}).Error; err != nil {
+		return err
+	}
 
 	result := echoRepository.getDB(ctx).Where("id = ?", id).Delete(&echo)
 	if result.Error != nil {
@@ -208,7 +219,7 @@ func (echoRepository *EchoRepository) GetTodayEchos(showPrivate bool, timezone s
 			startOfDayUTC := startOfDayUser.UTC().Unix()
 			endOfDayUTC := endOfDayUser.UTC().Unix()
 
-			query := echoRepository.db().Model(&model.Echo{})
+			query := echoRepository.db().Model(&model.Echo{}).Scopes(publishedOnly)
 			if !showPrivate {
 				query = query.Where("private = ?", false)
 			}
@@ -239,9 +250,12 @@ func (echoRepository *EchoRepository) UpdateEcho(ctx context.Context, echo *mode
 	}
 
 	updates := map[string]interface{}{
-		"content": echo.Content,
-		"private": echo.Private,
-		"layout":  echo.Layout,
+		"content":        echo.Content,
+		"private":        echo.Private,
+		"layout":         echo.Layout,
+		"status":         echo.Status,
+		"publish_at":     echo.PublishAt,
+		"comment_policy": echo.CommentPolicy,
 	}
 	if echo.CreatedAt != 0 {
 		updates["created_at"] = echo.CreatedAt
@@ -285,6 +299,7 @@ func (echoRepository *EchoRepository) LikeEcho(ctx context.Context, id string) e
 	if err := echoRepository.getDB(ctx).
 		Model(&model.Echo{}).
 		Select("count(*) > 0").
+		Scopes(notTrashed).
 		Where("id = ?", id).
 		Find(&exists).Error; err != nil {
 		return err
@@ -385,6 +400,11 @@ func (echoRepository *EchoRepository) QueryEchos(
 
 	hasTagFilter := len(queryDto.TagIDs) > 0
 
+	searchQuery := ftsUtil.ParseQuery(queryDto.Search)
+	useIndex := !searchQuery.Empty() && echoRepository.hasSearchIndex()
+	// relevance 仅在 FTS5 命中带出 bm25 得分时生效，否则回落到按时间倒序。
+	byRelevance := queryDto.SortBy == "relevance" && useIndex && searchQuery.MatchExpression() != ""
+
 	sortColumn := "echos.created_at"
 	if queryDto.SortBy == "fav_count" {
 		sortColumn = "echos.fav_count"
@@ -394,24 +414,42 @@ func (echoRepository *EchoRepository) QueryEchos(
 		sortDir = "ASC"
 	}
 	orderClause := sortColumn + " " + sortDir
+	if byRelevance {
+		orderClause = searchRankAlias + ".rank ASC, echos.created_at DESC"
+	}
 
 	applyFilters := func(db *gorm.DB) *gorm.DB {
 		if hasTagFilter {
 			db = db.Joins("JOIN echo_tags ON echo_tags.echo_id = echos.id").
 				Where("echo_tags.tag_id IN ?", queryDto.TagIDs)
 		}
-		if !showPrivate {
-			// 无私密可见权限：强制仅公开，dto.Private 被静默忽略（防泄漏兜底）。
-			db = db.Where("echos.private = ?", false)
-		} else if queryDto.Private != nil {
-			db = db.Where("echos.private = ?", *queryDto.Private)
+		if !showPrivate && queryDto.ViewerID == "" {
+			// 无私密可见权限：强制仅公开且已发布，dto.Private / dto.Status 被静默忽略（防泄漏兜底）。
+			db = db.Where("echos.private = ?", false).Scopes(publishedOnly)
+		} else {
+			if !showPrivate {
+				// 作者视角：自己名下的 Echo 照常按 dto.Private / dto.Status 过滤，他人的只留公开且已发布。
+				db = db.Where(
+					"(echos.user_id = ? OR (echos.private = ? AND echos.status = ?))",
+					queryDto.ViewerID, false, model.StatusPublished,
+				)
+			}
+			if queryDto.Private != nil {
+				db = db.Where("echos.private = ?", *queryDto.Private)
+			}
+			if queryDto.Status != "" {
+				db = db.Scopes(notTrashed).Where("echos.status = ?", queryDto.Status)
+			} else {
+				db = db.Scopes(publishedOnly)
+			}
 		}
 		if queryDto.UserID != "" {
 			db = db.Where("echos.user_id = ?", queryDto.UserID)
 		}
-		if queryDto.Search != "" {
-			db = db.Where("echos.content LIKE ?", "%"+queryDto.Search+"%")
+		if len(queryDto.EchoIDs) > 0 {
+			db = db.Where("echos.id IN ?", queryDto.EchoIDs)
 		}
+		db = applySearch(db, searchQuery, useIndex)
 		if queryDto.DateFrom > 0 {
 			db = db.Where("echos.created_at >= ?", queryDto.DateFrom)
 		}
@@ -448,18 +486,23 @@ func (echoRepository *EchoRepository) QueryEchos(
 		if len(echoIDs) == 0 {
 			return []model.Echo{}, total, nil
 		}
-		if err := echoRepository.db().
+		fetch := echoRepository.db().
 			Where("id IN ?", echoIDs).
 			Preload("EchoFiles", func(db *gorm.DB) *gorm.DB {
 				return db.Order("echo_files.sort_order ASC")
 			}).
 			Preload("EchoFiles.File").
 			Preload("Extension").
-			Preload("Tags").
-			Order(orderClause).
-			Find(&echos).Error; err != nil {
+			Preload("Tags")
+		if !byRelevance {
+			fetch = fetch.Order(orderClause)
+		}
+		if err := fetch.Find(&echos).Error; err != nil {
 			return nil, 0, err
 		}
+		if byRelevance {
+			echos = orderEchosByIDs(echos, echoIDs)
+		}
 	} else {
 		query := applyFilters(echoRepository.db().Model(&model.Echo{}))
 		if err := query.
@@ -493,7 +536,8 @@ func (echoRepository *EchoRepository) GetEchosByTagId(
 
 	applyFilters := func(db *gorm.DB) *gorm.DB {
 		db = db.Joins("JOIN echo_tags ON echo_tags.echo_id = echos.id").
-			Where("echo_tags.tag_id = ?", tagId)
+			Where("echo_tags.tag_id = ?", tagId).
+			Scopes(publishedOnly)
 
 		if !showPrivate {
 			db = db.Where("echos.private = ?", false)
@@ -556,6 +600,7 @@ func (echoRepository *EchoRepository) GetHotEchos(limit int, showPrivate bool) (
 	const recentPool = 10
 
 	recentQuery := echoRepository.db().Model(&model.Echo{}).
+		Scopes(publishedOnly).
 		Select("id").
 		Order("created_at DESC").
 		Limit(recentPool)
@@ -570,7 +615,7 @@ func (echoRepository *EchoRepository) GetHotEchos(limit int, showPrivate bool) (
 	hotQuery := echoRepository.db().Table("(?) AS recent", recentQuery).
 		Select("recent.id, echos.fav_count + COUNT(comments.id) * 2 AS hot_score").
 		Joins("JOIN echos ON echos.id = recent.id").
-		Joins("LEFT JOIN comments ON comments.echo_id = recent.id AND comments.status = 'approved'").
+		Joins("LEFT JOIN comments ON comments.echo_id = recent.id AND comments.status = 'approved' AND comments.deleted_at = 0").
 		Group("recent.id").
 		Order("hot_score DESC").
 		Limit(limit)
@@ -623,7 +668,7 @@ func (echoRepository *EchoRepository) GetRandomEcho(showPrivate bool) (*model.Ec
 		randomExpr = "RAND()"
 	}
 
-	query := echoRepository.db().Model(&model.Echo{})
+	query := echoRepository.db().Model(&model.Echo{}).Scopes(publishedOnly)
 	if !showPrivate {
 		query = query.Where("private = ?", false)
 	}
@@ -657,7 +702,7 @@ func (echoRepository *EchoRepository) GetOnThisDayEchos(showPrivate bool, timezo
 	month, day, currentYear := now.Month(), now.Day(), now.Year()
 
 	// 找出最早一条 Echo 所在年份，限定回溯范围
-	minQuery := echoRepository.db().Model(&model.Echo{})
+	minQuery := echoRepository.db().Model(&model.Echo{}).Scopes(publishedOnly)
 	if !showPrivate {
 		minQuery = minQuery.Where("private = ?", false)
 	}
@@ -687,6 +732,7 @@ func (echoRepository *EchoRepository) GetOnThisDayEchos(showPrivate bool, timezo
 
 	var echos []model.Echo
 	if err := echoRepository.db().
+		Scopes(publishedOnly).
 		Where(where, args...).
 		Preload("EchoFiles", func(db *gorm.DB) *gorm.DB {
 			return db.Order("echo_files.sort_order ASC")
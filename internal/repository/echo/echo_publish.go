@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	"gorm.io/gorm"
+)
+
+// publishedOnly 把查询限定在已发布且不在回收站的 Echo。草稿与定时发布中的 Echo 对时间线、
+// RSS、热门 / 随机 / 那年今日等公共读路径一律不可见，只有显式按状态查询的管理员能看到。
+func publishedOnly(db *gorm.DB) *gorm.DB {
+	return notTrashed(db).Where("echos.status = ?", model.StatusPublished)
+}
+
+// GetDueScheduledEchos 返回 PublishAt 不晚于 now 的定时 Echo，按计划时间升序，最多 limit 条。
+func (echoRepository *EchoRepository) GetDueScheduledEchos(ctx context.Context, now int64, limit int) ([]model.Echo, error) {
+	var echos []model.Echo
+	if err := echoRepository.getDB(ctx).
+		Scopes(notTrashed).
+		Where("status = ? AND publish_at <= ?", model.StatusScheduled, now).
+		Order("publish_at ASC").
+		Limit(limit).
+		Find(&echos).Error; err != nil {
+		return nil, err
+	}
+	return echos, nil
+}
+
+// PublishScheduledEcho 把一条定时 Echo 转为已发布，created_at 对齐到计划发布时间，
+// 使其按发布时刻进入时间线。条件更新保证并发 / 重入时只有一方发布成功（返回 true）。
+func (echoRepository *EchoRepository) PublishScheduledEcho(ctx context.Context, id string) (bool, error) {
+	result := echoRepository.getDB(ctx).
+		Model(&model.Echo{}).
+		Scopes(notTrashed).
+		Where("id = ? AND status = ?", id, model.StatusScheduled).
+		Updates(map[string]interface{}{
+			"status":     model.StatusPublished,
+			"created_at": gorm.Expr("publish_at"),
+		})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
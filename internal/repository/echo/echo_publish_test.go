@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// seedUnpublished 插入一条草稿 / 定时 Echo。
+func seedUnpublished(t *testing.T, db *gorm.DB, id, status string, createdAt, publishAt int64) {
+	t.Helper()
+	require.NoError(t, db.Create(&echoModel.Echo{
+		ID:        id,
+		Content:   id,
+		UserID:    "u1",
+		CreatedAt: createdAt,
+		Status:    status,
+		PublishAt: publishAt,
+	}).Error)
+}
+
+func TestEchoRepository_UnpublishedHiddenFromReads(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	todayTs := time.Now().Unix()
+	seedEcho(t, db, "e-pub", "live", false, 0, todayTs)
+	seedUnpublished(t, db, "e-draft", echoModel.StatusDraft, todayTs, 0)
+	seedUnpublished(t, db, "e-sched", echoModel.StatusScheduled, todayTs, todayTs+3600)
+
+	t.Run("seeded echos default to published", func(t *testing.T) {
+		var e echoModel.Echo
+		require.NoError(t, db.First(&e, "id = ?", "e-pub").Error)
+		assert.Equal(t, echoModel.StatusPublished, e.Status)
+	})
+
+	for _, showPrivate := range []bool{false, true} {
+		echos, total, err := repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10}, showPrivate)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"e-pub"}, echoIDs(echos))
+
+		assert.Equal(t, []string{"e-pub"}, echoIDs(repo.GetTodayEchos(showPrivate, "UTC")))
+	}
+
+	t.Run("admin can list drafts explicitly", func(t *testing.T) {
+		echos, _, err := repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10, Status: echoModel.StatusDraft}, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e-draft"}, echoIDs(echos))
+	})
+
+	t.Run("status filter is ignored without private access", func(t *testing.T) {
+		echos, _, err := repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10, Status: echoModel.StatusDraft}, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"e-pub"}, echoIDs(echos))
+	})
+
+	t.Run("backfill page skips unpublished", func(t *testing.T) {
+		echos, total := repo.GetEchosByPage(1, 10, "", true)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"e-pub"}, echoIDs(echos))
+	})
+}
+
+func TestEchoRepository_PublishScheduledEcho(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	ctx := context.Background()
+	seedUnpublished(t, db, "e-due", echoModel.StatusScheduled, 100, 500)
+	seedUnpublished(t, db, "e-later", echoModel.StatusScheduled, 100, 2000)
+	seedUnpublished(t, db, "e-draft", echoModel.StatusDraft, 100, 0)
+
+	due, err := repo.GetDueScheduledEchos(ctx, 1000, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e-due"}, echoIDs(due))
+
+	ok, err := repo.PublishScheduledEcho(ctx, "e-due")
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	var published echoModel.Echo
+	require.NoError(t, db.First(&published, "id = ?", "e-due").Error)
+	assert.Equal(t, echoModel.StatusPublished, published.Status)
+	assert.Equal(t, int64(500), published.CreatedAt, "created_at moves to the scheduled time")
+
+	ok, err = repo.PublishScheduledEcho(ctx, "e-due")
+	require.NoError(t, err)
+	assert.False(t, ok, "publishing twice is a no-op")
+
+	ok, err = repo.PublishScheduledEcho(ctx, "e-draft")
+	require.NoError(t, err)
+	assert.False(t, ok, "drafts are never auto-published")
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	"gorm.io/gorm"
+)
+
+// CreateEchoRevision 写入一条修订快照，Number 取该 Echo 现有最大序号 + 1。
+// 应与 echos 主表的更新处于同一事务，(echo_id, number) 唯一索引兜住并发编辑。
+func (echoRepository *EchoRepository) CreateEchoRevision(ctx context.Context, revision *model.EchoRevision) error {
+	db := echoRepository.getDB(ctx)
+	var maxNumber int
+	if err := db.Model(&model.EchoRevision{}).
+		Where("echo_id = ?", revision.EchoID).
+		Select("COALESCE(MAX(number), 0)").
+		Scan(&maxNumber).Error; err != nil {
+		return err
+	}
+	revision.Number = maxNumber + 1
+	return db.Create(revision).Error
+}
+
+// ListEchoRevisions 按序号倒序（最新在前）返回一条 Echo 的全部修订。
+func (echoRepository *EchoRepository) ListEchoRevisions(ctx context.Context, echoID string) ([]model.EchoRevision, error) {
+	var revisions []model.EchoRevision
+	if err := echoRepository.getDB(ctx).
+		Where("echo_id = ?", echoID).
+		Order("number DESC").
+		Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetEchoRevision 取指定 Echo 下的一条修订；不存在（或不属于该 Echo）时返回 ECHO_REVISION_NOT_FOUND。
+func (echoRepository *EchoRepository) GetEchoRevision(ctx context.Context, echoID, revisionID string) (*model.EchoRevision, error) {
+	var revision model.EchoRevision
+	err := echoRepository.getDB(ctx).
+		Where("id = ? AND echo_id = ?", revisionID, echoID).
+		First(&revision).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(commonModel.ECHO_REVISION_NOT_FOUND)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEchoRepository_EchoRevisions(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	ctx := context.Background()
+	seedEcho(t, db, "e1", "v3", false, 0, 100)
+	seedEcho(t, db, "e2", "other", false, 0, 200)
+
+	first := &echoModel.EchoRevision{EchoID: "e1", Content: "v1", Tags: []string{"go"}}
+	second := &echoModel.EchoRevision{EchoID: "e1", Content: "v2"}
+	other := &echoModel.EchoRevision{EchoID: "e2", Content: "o1"}
+	require.NoError(t, repo.CreateEchoRevision(ctx, first))
+	require.NoError(t, repo.CreateEchoRevision(ctx, second))
+	require.NoError(t, repo.CreateEchoRevision(ctx, other))
+	assert.Equal(t, 1, first.Number)
+	assert.Equal(t, 2, second.Number)
+	assert.Equal(t, 1, other.Number, "numbering is per echo")
+	assert.NotEmpty(t, first.ID)
+
+	revs, err := repo.ListEchoRevisions(ctx, "e1")
+	require.NoError(t, err)
+	require.Len(t, revs, 2)
+	assert.Equal(t, "v2", revs[0].Content, "newest first")
+	assert.Equal(t, []string{"go"}, revs[1].Tags)
+
+	got, err := repo.GetEchoRevision(ctx, "e1", first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", got.Content)
+
+	_, err = repo.GetEchoRevision(ctx, "e2", first.ID)
+	require.EqualError(t, err, commonModel.ECHO_REVISION_NOT_FOUND)
+
+	require.NoError(t, repo.DeleteEchoById(ctx, "e1"))
+	revs, err = repo.ListEchoRevisions(ctx, "e1")
+	require.NoError(t, err)
+	assert.Empty(t, revs, "revisions are removed with their echo")
+}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"gorm.io/gorm"
+)
+
+// searchRankAlias 是全文检索子查询在主查询里的别名，rank 列即 bm25 得分（越小越相关）。
+const searchRankAlias = "echo_search"
+
+// hasSearchIndex 报告当前库是否存在 FTS5 全文索引。索引由启动期的回填迁移器建立，有无只取决于
+// 构建时是否带 sqlite_fts5 标签（无 fts5 模块时不建表），进程内不会变化，故只在首次用到时
+// （迁移已完成）查一次 sqlite_master。没有索引时读写路径都退化为 LIKE 检索 / 跳过索引维护，
+// 并告警一次：索引维护静默成功，不留痕迹。
+func (echoRepository *EchoRepository) hasSearchIndex() bool {
+	echoRepository.searchIndexOnce.Do(func() {
+		echoRepository.searchIndex = searchIndexExists(echoRepository.db())
+		if !echoRepository.searchIndex {
+			logUtil.Warn(
+				"echo search index missing, skipping index maintenance and searching with LIKE",
+				slog.String("module", "repository"),
+				slog.String("table", ftsUtil.EchoIndexTable),
+			)
+		}
+	})
+	return echoRepository.searchIndex
+}
+
+// searchIndexExists 查询 sqlite_master 判断 FTS5 全文索引表是否存在。
+func searchIndexExists(db *gorm.DB) bool {
+	var n int64
+	if err := db.Raw(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?",
+		ftsUtil.EchoIndexTable,
+	).Scan(&n).Error; err != nil {
+		return false
+	}
+	return n > 0
+}
+
+// UpsertSearchIndex 写入（或覆盖）一条 Echo 的全文索引。FTS5 虚表不支持 UPSERT，先删后插；
+// 应与 echos 主表写入处于同一事务。
+func (echoRepository *EchoRepository) UpsertSearchIndex(ctx context.Context, echoID, content string) error {
+	if !echoRepository.hasSearchIndex() {
+		return nil
+	}
+	db := echoRepository.getDB(ctx)
+	if err := db.Exec("DELETE FROM "+ftsUtil.EchoIndexTable+" WHERE echo_id = ?", echoID).Error; err != nil {
+		return err
+	}
+	return db.Exec(
+		"INSERT INTO "+ftsUtil.EchoIndexTable+"(echo_id, content) VALUES (?, ?)",
+		echoID, ftsUtil.NormalizeText(strings.TrimSpace(content)),
+	).Error
+}
+
+// DeleteSearchIndex 删除一条 Echo 的全文索引。
+func (echoRepository *EchoRepository) DeleteSearchIndex(ctx context.Context, echoID string) error {
+	if !echoRepository.hasSearchIndex() {
+		return nil
+	}
+	db := echoRepository.getDB(ctx)
+	return db.Exec("DELETE FROM "+ftsUtil.EchoIndexTable+" WHERE echo_id = ?", echoID).Error
+}
+
+// applySearch 把检索语句挂到 echos 查询上。FTS5 可用时用 MATCH 子查询过滤并带出 bm25 得分
+// （别名 echo_search.rank，供相关度排序使用）；否则降级为逐词 LIKE。
+func applySearch(db *gorm.DB, query ftsUtil.Query, useIndex bool) *gorm.DB {
+	if query.Empty() {
+		return db
+	}
+
+	if useIndex {
+		if match := query.MatchExpression(); match != "" {
+			db = db.Joins(
+				"JOIN (SELECT echo_id, bm25("+ftsUtil.EchoIndexTable+") AS rank FROM "+ftsUtil.EchoIndexTable+
+					" WHERE "+ftsUtil.EchoIndexTable+" MATCH ?) AS "+searchRankAlias+
+					" ON "+searchRankAlias+".echo_id = echos.id",
+				match,
+			)
+		}
+		if exclude := query.ExcludeExpression(); exclude != "" {
+			db = db.Where(
+				"echos.id NOT IN (SELECT echo_id FROM "+ftsUtil.EchoIndexTable+" WHERE "+ftsUtil.EchoIndexTable+" MATCH ?)",
+				exclude,
+			)
+		}
+		return db
+	}
+
+	for _, group := range query.Groups {
+		conds := make([]string, 0, len(group))
+		args := make([]any, 0, len(group))
+		for _, term := range group {
+			conds = append(conds, `echos.content LIKE ? ESCAPE '\'`)
+			args = append(args, "%"+escapeLike(term.Text)+"%")
+		}
+		db = db.Where("("+strings.Join(conds, " OR ")+")", args...)
+	}
+	for _, term := range query.Excludes {
+		db = db.Where(`echos.content NOT LIKE ? ESCAPE '\'`, "%"+escapeLike(term.Text)+"%")
+	}
+	return db
+}
+
+// escapeLike 转义 LIKE 通配符，使检索词按字面匹配。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// orderEchosByIDs 按给定 ID 顺序重排查询结果（IN 查询不保证顺序，相关度排序需在 Go 侧还原）。
+func orderEchosByIDs(echos []model.Echo, ids []string) []model.Echo {
+	idOrder := make(map[string]int, len(ids))
+	for i, id := range ids {
+		idOrder[id] = i
+	}
+	sorted := make([]model.Echo, 0, len(echos))
+	slots := make([]*model.Echo, len(ids))
+	for i := range echos {
+		if pos, ok := idOrder[echos[i].ID]; ok {
+			slots[pos] = &echos[i]
+		}
+	}
+	for _, e := range slots {
+		if e != nil {
+			sorted = append(sorted, *e)
+		}
+	}
+	return sorted
+}
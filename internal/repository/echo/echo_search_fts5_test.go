@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+//go:build sqlite_fts5
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	dbMigration "github.com/lin-snow/ech0/internal/database/migration"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// buildSearchIndex 执行回填迁移器：建 echo_fts 并把已 seed 的 echo 写进索引。
+func buildSearchIndex(t *testing.T, db *gorm.DB) {
+	t.Helper()
+	require.NoError(t, dbMigration.NewEchoSearchIndexMigrator().Migrate(db))
+	require.True(t, searchIndexExists(db))
+}
+
+func TestEchoRepository_QueryEchos_FTS(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	seedEcho(t, db, "e1", "golang golang golang tips", false, 0, 100)
+	seedEcho(t, db, "e2", "a long note that mentions golang once among many other words", false, 0, 200)
+	seedEcho(t, db, "e3", "rust and golang", false, 0, 300)
+	seedEcho(t, db, "e4", "今天学习了全文检索", false, 0, 400)
+	seedEcho(t, db, "e5", "golang private", true, 0, 500)
+	seedEcho(t, db, "e6", "golangci-lint config", false, 0, 600)
+	buildSearchIndex(t, db)
+
+	search := func(q, sortBy string, showPrivate bool) []string {
+		echos, total, err := repo.QueryEchos(
+			commonModel.EchoQueryDto{Page: 1, PageSize: 10, Search: q, SortBy: sortBy},
+			showPrivate,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(echos)), total)
+		return echoIDs(echos)
+	}
+
+	t.Run("word boundaries", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"e1", "e2", "e3"}, search("golang", "created_at", false))
+	})
+
+	t.Run("bm25 ranks denser matches first", func(t *testing.T) {
+		got := search("golang", "relevance", false)
+		require.Len(t, got, 3)
+		assert.Equal(t, "e1", got[0])
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"e1", "e2", "e3", "e6"}, search("golang*", "created_at", false))
+	})
+
+	t.Run("phrase", func(t *testing.T) {
+		assert.Equal(t, []string{"e3"}, search(`"rust and golang"`, "relevance", false))
+		assert.Empty(t, search(`"golang and rust"`, "relevance", false))
+	})
+
+	t.Run("not", func(t *testing.T) {
+		assert.ElementsMatch(t, []string{"e1", "e2"}, search("golang -rust", "created_at", false))
+		assert.ElementsMatch(t, []string{"e1", "e2", "e4", "e6"}, search("NOT rust", "created_at", false))
+	})
+
+	t.Run("cjk substring", func(t *testing.T) {
+		assert.Equal(t, []string{"e4"}, search("全文", "relevance", false))
+	})
+
+	t.Run("private stays hidden", func(t *testing.T) {
+		assert.NotContains(t, search("private", "relevance", false), "e5")
+		assert.Equal(t, []string{"e5"}, search("private", "relevance", true))
+	})
+
+	t.Run("relevance survives tag filter", func(t *testing.T) {
+		seedTag(t, db, "t1", "go")
+		linkTag(t, db, "e1", "t1")
+		linkTag(t, db, "e2", "t1")
+		echos, total, err := repo.QueryEchos(
+			commonModel.EchoQueryDto{Page: 1, PageSize: 10, Search: "golang", SortBy: "relevance", TagIDs: []string{"t1"}},
+			false,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.Equal(t, []string{"e1", "e2"}, echoIDs(echos))
+	})
+
+	t.Run("fts operators in input are literal", func(t *testing.T) {
+		assert.Empty(t, search(`content:golang NEAR(`, "relevance", false))
+	})
+}
+
+func TestEchoRepository_SearchIndexSync(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	buildSearchIndex(t, db)
+	seedEcho(t, db, "e1", "before edit", false, 0, 100)
+	ctx := context.Background()
+
+	require.NoError(t, repo.UpsertSearchIndex(ctx, "e1", "before edit"))
+	echos, _, err := repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10, Search: "before"}, true)
+	require.NoError(t, err)
+	assert.Len(t, echos, 1)
+
+	require.NoError(t, repo.UpsertSearchIndex(ctx, "e1", "after edit"))
+	echos, _, err = repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10, Search: "before"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, echos)
+
+	require.NoError(t, repo.DeleteSearchIndex(ctx, "e1"))
+	echos, _, err = repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10, Search: "after"}, true)
+	require.NoError(t, err)
+	assert.Empty(t, echos)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// 未建 echo_fts（默认构建无 fts5 模块 / 回填迁移未执行）时，检索按解析后的检索项逐词 LIKE 降级。
+func TestEchoRepository_QueryEchos_SearchFallbackSyntax(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	seedEcho(t, db, "e1", "golang and sqlite", false, 0, 100)
+	seedEcho(t, db, "e2", "golang and rust", false, 0, 200)
+	seedEcho(t, db, "e3", "100% vue", false, 0, 300)
+
+	search := func(q string) []string {
+		echos, total, err := repo.QueryEchos(
+			commonModel.EchoQueryDto{Page: 1, PageSize: 10, Search: q, SortBy: "relevance"},
+			true,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(echos)), total)
+		return echoIDs(echos)
+	}
+
+	assert.Equal(t, []string{"e2", "e1"}, search("golang"), "relevance 无索引时回落到时间倒序")
+	assert.Equal(t, []string{"e1"}, search("golang sqlite"))
+	assert.Equal(t, []string{"e1"}, search("golang -rust"))
+	assert.Equal(t, []string{"e2", "e1"}, search("sqlite OR rust"))
+	assert.Equal(t, []string{"e3"}, search("100%"), "LIKE 通配符按字面匹配")
+	assert.Empty(t, search("0_"))
+}
+
+func TestEchoRepository_SearchIndexWritesAreNoopWithoutIndex(t *testing.T) {
+	repo, _ := newEchoRepo(t)
+	require.NoError(t, repo.UpsertSearchIndex(context.Background(), "e1", "content"))
+	require.NoError(t, repo.DeleteSearchIndex(context.Background(), "e1"))
+}
+
+// 索引是否存在只在首次用到时检测一次，之后不再查 sqlite_master。
+func TestEchoRepository_SearchIndexDetectedOnce(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	require.False(t, repo.hasSearchIndex())
+
+	require.NoError(t, db.Exec("CREATE TABLE echo_fts (echo_id TEXT, content TEXT)").Error)
+	assert.True(t, searchIndexExists(db))
+	assert.False(t, repo.hasSearchIndex(), "检测结果应被缓存")
+	require.NoError(t, repo.UpsertSearchIndex(context.Background(), "e1", "content"))
+	var n int64
+	require.NoError(t, db.Raw("SELECT COUNT(*) FROM echo_fts").Scan(&n).Error)
+	assert.Zero(t, n)
+}
+
+func TestOrderEchosByIDs(t *testing.T) {
+	echos := []echoModel.Echo{{ID: "b"}, {ID: "c"}, {ID: "a"}}
+	assert.Equal(t, []string{"a", "b", "c"}, echoIDs(orderEchosByIDs(echos, []string{"a", "missing", "b", "c"})))
+}
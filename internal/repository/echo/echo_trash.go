@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"errors"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"gorm.io/gorm"
+)
+
+// notTrashed 排除回收站中的 Echo。除回收站自身的读写外，所有读路径（含管理员）都应带上它。
+func notTrashed(db *gorm.DB) *gorm.DB {
+	return db.Where("echos.deleted_at = 0")
+}
+
+// TrashEcho 把 Echo 移入回收站：只打删除时间戳，附件关联、扩展、标签与修订原样保留以便恢复。
+func (echoRepository *EchoRepository) TrashEcho(ctx context.Context, id string, deletedAt int64) error {
+	result := echoRepository.getDB(ctx).
+		Model(&model.Echo{}).
+		Scopes(notTrashed).
+		Where("id = ?", id).
+		UpdateColumn("deleted_at", deletedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(commonModel.ECHO_NOT_FOUND)
+	}
+	return nil
+}
+
+// RestoreEcho 把回收站中的 Echo 移回原处；不在回收站时返回 ECHO_NOT_IN_TRASH。
+func (echoRepository *EchoRepository) RestoreEcho(ctx context.Context, id string) error {
+	result := echoRepository.getDB(ctx).
+		Model(&model.Echo{}).
+		Where("id = ? AND deleted_at > 0", id).
+		UpdateColumn("deleted_at", 0)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New(commonModel.ECHO_NOT_IN_TRASH)
+	}
+	return nil
+}
+
+// GetTrashedEchoById 取回收站中的一条 Echo（含附件、扩展与标签）；不存在时返回 nil, nil。
+func (echoRepository *EchoRepository) GetTrashedEchoById(ctx context.Context, id string) (*model.Echo, error) {
+	var echo model.Echo
+	err := echoRepository.getDB(ctx).
+		Preload("EchoFiles", func(db *gorm.DB) *gorm.DB {
+			return db.Order("echo_files.sort_order ASC")
+		}).
+		Preload("EchoFiles.File").
+		Preload("Extension").
+		Preload("Tags").
+		Where("id = ? AND deleted_at > 0", id).
+		First(&echo).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &echo, nil
+}
+
+// ListTrashedEchos 分页列出回收站，最近删除的在前；userID 非空时只列该用户的 Echo。
+func (echoRepository *EchoRepository) ListTrashedEchos(
+	ctx context.Context,
+	page, pageSize int,
+	userID string,
+) ([]model.Echo, int64, error) {
+	var (
+		echos []model.Echo
+		total int64
+	)
+	query := echoRepository.getDB(ctx).Model(&model.Echo{}).Where("deleted_at > 0")
+	if userID != "" {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	if err := query.
+		Preload("EchoFiles", func(db *gorm.DB) *gorm.DB {
+			return db.Order("echo_files.sort_order ASC")
+		}).
+		Preload("EchoFiles.File").
+		Preload("Extension").
+		Preload("Tags").
+		Order("deleted_at DESC").
+		Limit(pageSize).
+		Offset((page - 1) * pageSize).
+		Find(&echos).Error; err != nil {
+		return nil, 0, err
+	}
+	return echos, total, nil
+}
+
+// GetExpiredTrashedEchoIDs 返回删除时间早于 before 的回收站 Echo ID，最早删除的在前，最多 limit 条。
+func (echoRepository *EchoRepository) GetExpiredTrashedEchoIDs(ctx context.Context, before int64, limit int) ([]string, error) {
+	var ids []string
+	if err := echoRepository.getDB(ctx).
+		Model(&model.Echo{}).
+		Where("deleted_at > 0 AND deleted_at < ?", before).
+		Order("deleted_at ASC").
+		Limit(limit).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// PruneDanglingEchoFiles 摘掉指向已删除文件的附件关联（Echo 在回收站期间其附件可能被单独删除）。
+func (echoRepository *EchoRepository) PruneDanglingEchoFiles(ctx context.Context, echoID string) error {
+	return echoRepository.getDB(ctx).
+		Where("echo_id = ? AND file_id NOT IN (?)", echoID,
+			echoRepository.getDB(ctx).Model(&fileModel.File{}).Select("id")).
+		Delete(&fileModel.EchoFile{}).Error
+}
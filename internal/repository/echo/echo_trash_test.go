@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEchoRepository_TrashHidesAndRestores(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	ctx := context.Background()
+	todayTs := time.Now().Unix()
+	seedEcho(t, db, "e-live", "live", false, 0, todayTs)
+	seedEcho(t, db, "e-gone", "gone", false, 0, todayTs-1)
+
+	require.NoError(t, repo.TrashEcho(ctx, "e-gone", todayTs))
+	require.EqualError(t, repo.TrashEcho(ctx, "e-gone", todayTs), commonModel.ECHO_NOT_FOUND,
+		"trashing twice must not bump the deletion time")
+
+	for _, showPrivate := range []bool{false, true} {
+		echos, total, err := repo.QueryEchos(commonModel.EchoQueryDto{Page: 1, PageSize: 10}, showPrivate)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Equal(t, []string{"e-live"}, echoIDs(echos))
+		assert.Equal(t, []string{"e-live"}, echoIDs(repo.GetTodayEchos(showPrivate, "UTC")))
+	}
+	got, err := repo.GetEchosById(ctx, "e-gone")
+	require.NoError(t, err)
+	assert.Nil(t, got)
+	require.EqualError(t, repo.LikeEcho(ctx, "e-gone"), commonModel.ECHO_NOT_FOUND)
+
+	trashed, total, err := repo.ListTrashedEchos(ctx, 1, 10, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Equal(t, []string{"e-gone"}, echoIDs(trashed))
+	_, total, err = repo.ListTrashedEchos(ctx, 1, 10, "someone-else")
+	require.NoError(t, err)
+	assert.Zero(t, total, "a non-empty userID only lists that user's trash")
+
+	require.NoError(t, repo.RestoreEcho(ctx, "e-gone"))
+	require.EqualError(t, repo.RestoreEcho(ctx, "e-gone"), commonModel.ECHO_NOT_IN_TRASH)
+	got, err = repo.GetEchosById(ctx, "e-gone")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+	assert.Zero(t, got.DeletedAt)
+}
+
+func TestEchoRepository_GetExpiredTrashedEchoIDs(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	ctx := context.Background()
+	seedEcho(t, db, "e-old", "old", false, 0, 100)
+	seedEcho(t, db, "e-new", "new", false, 0, 200)
+	seedEcho(t, db, "e-live", "live", false, 0, 300)
+	require.NoError(t, repo.TrashEcho(ctx, "e-old", 1_000))
+	require.NoError(t, repo.TrashEcho(ctx, "e-new", 5_000))
+
+	ids, err := repo.GetExpiredTrashedEchoIDs(ctx, 2_000, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e-old"}, ids)
+
+	ids, err = repo.GetExpiredTrashedEchoIDs(ctx, 10_000, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"e-old", "e-new"}, ids)
+}
+
+// 回收站期间被单独删除的附件，恢复时只摘掉它的关联，其余附件原样保留。
+func TestEchoRepository_PruneDanglingEchoFiles(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	ctx := context.Background()
+	seedEcho(t, db, "e-1", "with files", false, 0, 100)
+	require.NoError(t, db.Create(&fileModel.File{ID: "f-keep", Key: "k", StorageType: "local"}).Error)
+	require.NoError(t, db.Create(&[]fileModel.EchoFile{
+		{ID: "ef-1", EchoID: "e-1", FileID: "f-keep", SortOrder: 0},
+		{ID: "ef-2", EchoID: "e-1", FileID: "f-deleted", SortOrder: 1},
+	}).Error)
+
+	require.NoError(t, repo.PruneDanglingEchoFiles(ctx, "e-1"))
+
+	var links []fileModel.EchoFile
+	require.NoError(t, db.Where("echo_id = ?", "e-1").Find(&links).Error)
+	require.Len(t, links, 1)
+	assert.Equal(t, "f-keep", links[0].FileID)
+
+	var kept echoModel.Echo
+	require.NoError(t, db.Preload("EchoFiles").First(&kept, "id = ?", "e-1").Error)
+	assert.Len(t, kept.EchoFiles, 1)
+}
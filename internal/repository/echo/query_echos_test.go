@@ -108,6 +108,27 @@ func TestEchoRepository_QueryEchos_PrivateFilter(t *testing.T) {
 		assert.Equal(t, "e-pub", echos[0].ID)
 	})
 
+	t.Run("ViewerID adds the viewer's own private echos only", func(t *testing.T) {
+		other := seedEcho(t, db, "e-prv-other", "someone else's", true, 0, 300)
+		require.NoError(t, db.Model(&other).Update("user_id", "u2").Error)
+		t.Cleanup(func() { db.Delete(&echoModel.Echo{}, "id = ?", other.ID) })
+
+		echos, total, err := repo.QueryEchos(
+			commonModel.EchoQueryDto{Page: 1, PageSize: 10, ViewerID: "u1"},
+			false,
+		)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+		assert.ElementsMatch(t, []string{"e-pub", "e-prv"}, echoIDs(echos))
+
+		echos, _, err = repo.QueryEchos(
+			commonModel.EchoQueryDto{Page: 1, PageSize: 10, ViewerID: "u2"},
+			false,
+		)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"e-pub", "e-prv-other"}, echoIDs(echos))
+	})
+
 	t.Run("showPrivate=false ignores Private=true (anti-leak)", func(t *testing.T) {
 		echos, total, err := repo.QueryEchos(
 			commonModel.EchoQueryDto{Page: 1, PageSize: 10, Private: ptr(true)},
@@ -304,3 +325,20 @@ func TestEchoRepository_QueryEchos_UserIDFilter(t *testing.T) {
 	require.Len(t, echos, 1)
 	assert.Equal(t, "e-alice", echos[0].ID)
 }
+
+func TestEchoRepository_QueryEchos_EchoIDsFilter(t *testing.T) {
+	repo, db := newEchoRepo(t)
+	require.NoError(t, db.Create(&echoModel.Echo{ID: "e-1", Content: "a", CreatedAt: 100}).Error)
+	require.NoError(t, db.Create(&echoModel.Echo{ID: "e-2", Content: "b", CreatedAt: 200}).Error)
+	require.NoError(t, db.Create(&echoModel.Echo{ID: "e-3", Content: "c", CreatedAt: 300, Private: true}).Error)
+
+	// 私密行即使在 ID 集合内，无私密权限时仍被过滤。
+	echos, total, err := repo.QueryEchos(
+		commonModel.EchoQueryDto{Page: 1, PageSize: 10, EchoIDs: []string{"e-1", "e-3"}},
+		false,
+	)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	require.Len(t, echos, 1)
+	assert.Equal(t, "e-1", echos[0].ID)
+}
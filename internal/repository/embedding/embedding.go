@@ -6,6 +6,7 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -110,6 +111,28 @@ func (r *EmbeddingRepository) GetMeta(ctx context.Context, echoID string) (*mode
 	return &m, true, nil
 }
 
+// GetVector 读出某条 Echo 已存的向量（相关推荐以它为 KNN 查询向量）。
+// 未建索引（或 vec_echo 尚未创建）时返回 ok=false。
+func (r *EmbeddingRepository) GetVector(ctx context.Context, echoID string) ([]float32, bool, error) {
+	var raw []string
+	if err := r.getDB(ctx).Raw(
+		"SELECT vec_to_json(embedding) FROM "+vecTable+" WHERE echo_id = ?", echoID,
+	).Scan(&raw).Error; err != nil {
+		if strings.Contains(err.Error(), "no such table") {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	if len(raw) == 0 {
+		return nil, false, nil
+	}
+	var vec []float32
+	if err := json.Unmarshal([]byte(raw[0]), &vec); err != nil {
+		return nil, false, err
+	}
+	return vec, true, nil
+}
+
 // searchOverfetchFactor：按作者过滤时 KNN 的超额取数倍数。vec0 虚表无法在 MATCH 里
 // 带元数据过滤，只能先按距离取一批再按 username 筛，故多取几倍以尽量凑满 k 条本人命中。
 const searchOverfetchFactor = 8
@@ -225,6 +225,34 @@ func TestEmbeddingRepository_GetMeta(t *testing.T) {
 	})
 }
 
+func TestEmbeddingRepository_GetVector(t *testing.T) {
+	repo, _ := newEmbeddingRepo(t)
+	ctx := context.Background()
+
+	t.Run("vec table not created yet", func(t *testing.T) {
+		got, ok, err := repo.GetVector(ctx, "nope")
+		require.NoError(t, err)
+		assert.False(t, ok)
+		assert.Nil(t, got)
+	})
+
+	require.NoError(t, repo.EnsureVecTable(ctx, 4))
+
+	t.Run("missing row", func(t *testing.T) {
+		_, ok, err := repo.GetVector(ctx, "nope")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("round-trips the stored vector", func(t *testing.T) {
+		seed(t, repo, ctx, "e-vec", "carol", 2.5)
+		got, ok, err := repo.GetVector(ctx, "e-vec")
+		require.NoError(t, err)
+		require.True(t, ok)
+		assert.Equal(t, vec4(2.5), got)
+	})
+}
+
 func TestEmbeddingRepository_Count(t *testing.T) {
 	repo, db := newEmbeddingRepo(t)
 	ctx := context.Background()
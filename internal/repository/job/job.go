@@ -1,22 +1,28 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-// Package repository 实现 job.JobRepository（jobs 表的 GORM 持久化）。
+// Package repository 实现 job.JobRepository（job_runs 表的 GORM 持久化）。
 package repository
 
 import (
 	"context"
 	"errors"
-	"time"
 
 	"github.com/lin-snow/ech0/internal/job"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"gorm.io/gorm"
-	"gorm.io/gorm/clause"
 )
 
-// JobRepository 是 jobs 表的 GORM 实现。
+// 历史列表分页：缺省每页 20 条，单页上限 100。
+const (
+	listDefaultPageSize = 20
+	listMaxPageSize     = 100
+)
+
+var terminalStatuses = []jobModel.Status{jobModel.StatusSuccess, jobModel.StatusFailed, jobModel.StatusCancelled}
+
+// JobRepository 是 job_runs 表的 GORM 实现。
 type JobRepository struct {
 	db func() *gorm.DB
 }
@@ -36,37 +42,129 @@ func (r *JobRepository) getDB(ctx context.Context) *gorm.DB {
 	return r.db()
 }
 
-// Upsert 按主键 Type 原子写入/覆盖（每 type 单行）。
-func (r *JobRepository) Upsert(ctx context.Context, j *jobModel.Job) error {
-	return r.getDB(ctx).Clauses(clause.OnConflict{
-		Columns:   []clause.Column{{Name: "type"}},
-		UpdateAll: true,
-	}).Create(j).Error
+// Create 插入一条新作业行。
+func (r *JobRepository) Create(ctx context.Context, j *jobModel.Job) error {
+	return r.getDB(ctx).Create(j).Error
 }
 
-// GetByType 查无返回 (零值, job.ErrNotFound)。
-func (r *JobRepository) GetByType(ctx context.Context, jobType string) (jobModel.Job, error) {
-	var j jobModel.Job
-	err := r.getDB(ctx).Where("type = ?", jobType).First(&j).Error
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		return jobModel.Job{}, job.ErrNotFound
+// Save 按 ID 整行覆盖。
+func (r *JobRepository) Save(ctx context.Context, j *jobModel.Job) error {
+	return r.getDB(ctx).Save(j).Error
+}
+
+// GetByID 查无返回 (零值, job.ErrNotFound)。
+func (r *JobRepository) GetByID(ctx context.Context, id string) (jobModel.Job, error) {
+	return first(r.getDB(ctx).Where("id = ?", id))
+}
+
+// Current 依次取该类型最早的 running、最早的 pending、最近一条未 dismiss 的终态行。
+func (r *JobRepository) Current(ctx context.Context, jobType string) (jobModel.Job, error) {
+	for _, status := range []jobModel.Status{jobModel.StatusRunning, jobModel.StatusPending} {
+		j, err := first(r.getDB(ctx).
+			Where("type = ? AND status = ?", jobType, status).
+			Order("created_at ASC, id ASC"))
+		if !errors.Is(err, job.ErrNotFound) {
+			return j, err
+		}
 	}
-	return j, err
+	return first(r.getDB(ctx).
+		Where("type = ? AND status IN ? AND dismissed = ?", jobType, terminalStatuses, false).
+		Order("created_at DESC, id DESC"))
+}
+
+// NextPending 返回该类型最早提交的 pending 行。
+func (r *JobRepository) NextPending(ctx context.Context, jobType string) (jobModel.Job, error) {
+	return first(r.getDB(ctx).
+		Where("type = ? AND status = ?", jobType, jobModel.StatusPending).
+		Order("created_at ASC, id ASC"))
+}
+
+// CountByStatus 统计该类型处于指定状态的行数。
+func (r *JobRepository) CountByStatus(ctx context.Context, jobType string, status jobModel.Status) (int64, error) {
+	var n int64
+	err := r.getDB(ctx).Model(&jobModel.Job{}).
+		Where("type = ? AND status = ?", jobType, status).
+		Count(&n).Error
+	return n, err
 }
 
-// SweepRunning 把残留的 pending/running 行批量置 failed（启动期孤儿清理）。幂等。
-func (r *JobRepository) SweepRunning(ctx context.Context, reason string) error {
-	now := time.Now().UTC().Unix()
+// List 按提交时间倒序分页列出历史。
+func (r *JobRepository) List(ctx context.Context, q jobModel.ListQuery) ([]jobModel.Job, int64, error) {
+	page := max(q.Page, 1)
+	pageSize := q.PageSize
+	if pageSize <= 0 {
+		pageSize = listDefaultPageSize
+	}
+	pageSize = min(pageSize, listMaxPageSize)
+
+	query := r.getDB(ctx).Model(&jobModel.Job{})
+	if q.Type != "" {
+		query = query.Where("type = ?", q.Type)
+	}
+	if q.Status != "" {
+		query = query.Where("status = ?", q.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+	var rows []jobModel.Job
+	err := query.Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&rows).Error
+	return rows, total, err
+}
+
+// Dismiss 把该类型所有终态行标为 dismissed。
+func (r *JobRepository) Dismiss(ctx context.Context, jobType string) error {
 	return r.getDB(ctx).Model(&jobModel.Job{}).
-		Where("status IN ?", []jobModel.Status{jobModel.StatusPending, jobModel.StatusRunning}).
-		Updates(map[string]any{
-			"status":      jobModel.StatusFailed,
-			"error":       reason,
-			"finished_at": now,
-		}).Error
+		Where("type = ? AND status IN ?", jobType, terminalStatuses).
+		Update("dismissed", true).Error
+}
+
+// ListByStatus 按提交顺序列出所有类型中处于该状态的行。
+func (r *JobRepository) ListByStatus(ctx context.Context, status jobModel.Status) ([]jobModel.Job, error) {
+	var rows []jobModel.Job
+	err := r.getDB(ctx).Where("status = ?", status).
+		Order("created_at ASC, id ASC").
+		Find(&rows).Error
+	return rows, err
+}
+
+// Prune 删除该类型超出保留策略的终态行，该类型最近一条始终保留。
+func (r *JobRepository) Prune(ctx context.Context, jobType string, before int64, keep int) error {
+	db := r.getDB(ctx)
+	newest := func(n int) *gorm.DB {
+		return db.Model(&jobModel.Job{}).Select("id").
+			Where("type = ?", jobType).
+			Order("created_at DESC, id DESC").
+			Limit(n)
+	}
+	terminal := func() *gorm.DB {
+		return db.Where("type = ? AND status IN ?", jobType, terminalStatuses)
+	}
+
+	if keep > 0 {
+		if err := terminal().Where("id NOT IN (?)", newest(keep)).Delete(&jobModel.Job{}).Error; err != nil {
+			return err
+		}
+	}
+	if before > 0 {
+		if err := terminal().Where("created_at < ? AND id NOT IN (?)", before, newest(1)).
+			Delete(&jobModel.Job{}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-// Delete 删除该 type 的行。
-func (r *JobRepository) Delete(ctx context.Context, jobType string) error {
-	return r.getDB(ctx).Where("type = ?", jobType).Delete(&jobModel.Job{}).Error
+func first(query *gorm.DB) (jobModel.Job, error) {
+	var j jobModel.Job
+	err := query.First(&j).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return jobModel.Job{}, job.ErrNotFound
+	}
+	return j, err
 }
@@ -30,76 +30,174 @@ func newTestRepo(t *testing.T) (*jobRepository.JobRepository, *gorm.DB) {
 	return jobRepository.NewJobRepository(func() *gorm.DB { return db }), db
 }
 
-func TestRepo_GetByType_NotFound(t *testing.T) {
+func seed(t *testing.T, repo *jobRepository.JobRepository, id, jobType string, status jobModel.Status, createdAt int64) {
+	t.Helper()
+	if err := repo.Create(context.Background(), &jobModel.Job{
+		ID: id, Type: jobType, Status: status, CreatedAt: createdAt,
+	}); err != nil {
+		t.Fatalf("create %s failed: %v", id, err)
+	}
+}
+
+func TestRepo_GetByID_NotFound(t *testing.T) {
 	repo, _ := newTestRepo(t)
-	if _, err := repo.GetByType(context.Background(), "reindex"); !errors.Is(err, job.ErrNotFound) {
+	if _, err := repo.GetByID(context.Background(), "nope"); !errors.Is(err, job.ErrNotFound) {
 		t.Fatalf("expected ErrNotFound, got %v", err)
 	}
 }
 
-func TestRepo_UpsertOverwritesByType(t *testing.T) {
+func TestRepo_SaveOverwritesByID(t *testing.T) {
 	repo, db := newTestRepo(t)
 	ctx := context.Background()
-	if err := repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusPending}); err != nil {
-		t.Fatalf("upsert pending failed: %v", err)
-	}
-	if err := repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusSuccess, Payload: `{"indexed":3}`}); err != nil {
-		t.Fatalf("upsert success failed: %v", err)
+	seed(t, repo, "a", "reindex", jobModel.StatusPending, 1)
+	if err := repo.Save(ctx, &jobModel.Job{ID: "a", Type: "reindex", Status: jobModel.StatusSuccess, Payload: `{"indexed":3}`, CreatedAt: 1}); err != nil {
+		t.Fatalf("save failed: %v", err)
 	}
-	got, err := repo.GetByType(ctx, "reindex")
+	got, err := repo.GetByID(ctx, "a")
 	if err != nil {
 		t.Fatalf("get failed: %v", err)
 	}
 	if got.Status != jobModel.StatusSuccess || got.Payload != `{"indexed":3}` {
-		t.Fatalf("expected single overwritten row, got %+v", got)
+		t.Fatalf("expected overwritten row, got %+v", got)
 	}
-	// 主键即 type → 同 type 仅一行。
+	// 同类型再建一行即多一条历史。
+	seed(t, repo, "b", "reindex", jobModel.StatusPending, 2)
 	var count int64
 	if err := db.Model(&jobModel.Job{}).Where("type = ?", "reindex").Count(&count).Error; err != nil {
 		t.Fatalf("count failed: %v", err)
 	}
-	if count != 1 {
-		t.Fatalf("expected exactly 1 row per type, got %d", count)
+	if count != 2 {
+		t.Fatalf("expected 2 rows of history, got %d", count)
 	}
 }
 
-func TestRepo_SweepRunning(t *testing.T) {
+func TestRepo_Current(t *testing.T) {
 	repo, _ := newTestRepo(t)
 	ctx := context.Background()
-	_ = repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusRunning})
-	if err := repo.SweepRunning(ctx, "interrupted by restart"); err != nil {
-		t.Fatalf("sweep failed: %v", err)
+	if _, err := repo.Current(ctx, "export"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound without rows, got %v", err)
 	}
-	got, err := repo.GetByType(ctx, "reindex")
+
+	seed(t, repo, "old", "export", jobModel.StatusSuccess, 1)
+	seed(t, repo, "new", "export", jobModel.StatusFailed, 2)
+	if got, _ := repo.Current(ctx, "export"); got.ID != "new" {
+		t.Fatalf("expected latest terminal row, got %q", got.ID)
+	}
+
+	seed(t, repo, "q2", "export", jobModel.StatusPending, 4)
+	seed(t, repo, "q1", "export", jobModel.StatusPending, 3)
+	if got, _ := repo.Current(ctx, "export"); got.ID != "q1" {
+		t.Fatalf("expected head of queue, got %q", got.ID)
+	}
+	if got, _ := repo.NextPending(ctx, "export"); got.ID != "q1" {
+		t.Fatalf("expected NextPending to return head of queue, got %q", got.ID)
+	}
+
+	seed(t, repo, "run", "export", jobModel.StatusRunning, 5)
+	if got, _ := repo.Current(ctx, "export"); got.ID != "run" {
+		t.Fatalf("expected running row first, got %q", got.ID)
+	}
+	if n, _ := repo.CountByStatus(ctx, "export", jobModel.StatusPending); n != 2 {
+		t.Fatalf("expected 2 queued, got %d", n)
+	}
+}
+
+func TestRepo_Dismiss(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+	seed(t, repo, "m1", "migration", jobModel.StatusSuccess, 1)
+	if err := repo.Dismiss(ctx, "migration"); err != nil {
+		t.Fatalf("dismiss failed: %v", err)
+	}
+	if _, err := repo.Current(ctx, "migration"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound after dismiss, got %v", err)
+	}
+	got, err := repo.GetByID(ctx, "m1")
+	if err != nil || !got.Dismissed {
+		t.Fatalf("expected dismissed row kept, got %+v (%v)", got, err)
+	}
+}
+
+func TestRepo_List(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+	seed(t, repo, "r1", "reindex", jobModel.StatusSuccess, 1)
+	seed(t, repo, "e1", "export", jobModel.StatusFailed, 2)
+	seed(t, repo, "r2", "reindex", jobModel.StatusFailed, 3)
+
+	rows, total, err := repo.List(ctx, jobModel.ListQuery{Page: 1, PageSize: 1})
 	if err != nil {
-		t.Fatalf("get failed: %v", err)
+		t.Fatalf("list failed: %v", err)
 	}
-	if got.Status != jobModel.StatusFailed || got.Error != "interrupted by restart" || got.FinishedAt == nil {
-		t.Fatalf("expected swept to failed with reason+finished, got %+v", got)
+	if total != 3 || len(rows) != 1 || rows[0].ID != "r2" {
+		t.Fatalf("expected newest first with total 3, got total=%d rows=%+v", total, rows)
+	}
+
+	rows, total, _ = repo.List(ctx, jobModel.ListQuery{Type: "reindex", Status: jobModel.StatusSuccess})
+	if total != 1 || rows[0].ID != "r1" {
+		t.Fatalf("expected filter by type+status, got total=%d rows=%+v", total, rows)
 	}
 }
 
-func TestRepo_SweepRunning_LeavesTerminal(t *testing.T) {
+func TestRepo_Prune(t *testing.T) {
 	repo, _ := newTestRepo(t)
 	ctx := context.Background()
-	_ = repo.Upsert(ctx, &jobModel.Job{Type: "reindex", Status: jobModel.StatusSuccess})
-	if err := repo.SweepRunning(ctx, "interrupted by restart"); err != nil {
-		t.Fatalf("sweep failed: %v", err)
+	seed(t, repo, "a", "reindex", jobModel.StatusSuccess, 10)
+	seed(t, repo, "b", "reindex", jobModel.StatusSuccess, 20)
+	seed(t, repo, "c", "reindex", jobModel.StatusFailed, 30)
+	seed(t, repo, "d", "reindex", jobModel.StatusPending, 40)
+	seed(t, repo, "x", "export", jobModel.StatusSuccess, 1)
+
+	// keep=3：最近三条 b/c/d 留下，a 被删；pending 行本就不在清理范围。
+	if err := repo.Prune(ctx, "reindex", 0, 3); err != nil {
+		t.Fatalf("prune by count failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "a"); !errors.Is(err, job.ErrNotFound) {
+		t.Fatalf("expected a pruned by count, got %v", err)
+	}
+
+	// 按时间：早于 35 的终态行删掉，但该类型最近一条（d）始终保留。
+	if err := repo.Prune(ctx, "reindex", 35, 0); err != nil {
+		t.Fatalf("prune by age failed: %v", err)
 	}
-	got, _ := repo.GetByType(ctx, "reindex")
-	if got.Status != jobModel.StatusSuccess {
-		t.Fatalf("sweep must not touch terminal rows, got %q", got.Status)
+	for _, id := range []string{"b", "c"} {
+		if _, err := repo.GetByID(ctx, id); !errors.Is(err, job.ErrNotFound) {
+			t.Fatalf("expected %s pruned by age, got %v", id, err)
+		}
+	}
+	if _, err := repo.GetByID(ctx, "d"); err != nil {
+		t.Fatalf("expected pending row kept, got %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "x"); err != nil {
+		t.Fatalf("prune must not touch other types, got %v", err)
+	}
+}
+
+func TestRepo_Prune_KeepsNewestRow(t *testing.T) {
+	repo, _ := newTestRepo(t)
+	ctx := context.Background()
+	seed(t, repo, "m1", "migration", jobModel.StatusSuccess, 1)
+	if err := repo.Prune(ctx, "migration", 100, 0); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+	if _, err := repo.GetByID(ctx, "m1"); err != nil {
+		t.Fatalf("expected newest row kept regardless of age, got %v", err)
 	}
 }
 
-func TestRepo_Delete(t *testing.T) {
+func TestRepo_ListByStatus(t *testing.T) {
 	repo, _ := newTestRepo(t)
 	ctx := context.Background()
-	_ = repo.Upsert(ctx, &jobModel.Job{Type: "migration", Status: jobModel.StatusSuccess})
-	if err := repo.Delete(ctx, "migration"); err != nil {
-		t.Fatalf("delete failed: %v", err)
+	seed(t, repo, "b", "export", jobModel.StatusRunning, 2)
+	seed(t, repo, "a", "reindex", jobModel.StatusRunning, 1)
+	seed(t, repo, "c", "reindex", jobModel.StatusPending, 3)
+	seed(t, repo, "d", "reindex", jobModel.StatusSuccess, 4)
+
+	rows, err := repo.ListByStatus(ctx, jobModel.StatusRunning)
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
 	}
-	if _, err := repo.GetByType(ctx, "migration"); !errors.Is(err, job.ErrNotFound) {
-		t.Fatalf("expected ErrNotFound after delete, got %v", err)
+	if len(rows) != 2 || rows[0].ID != "a" || rows[1].ID != "b" {
+		t.Fatalf("expected running rows of every type in submission order, got %+v", rows)
 	}
 }
@@ -7,10 +7,12 @@ import (
 	"github.com/google/wire"
 	"github.com/lin-snow/ech0/internal/job"
 	"github.com/lin-snow/ech0/internal/kvstore"
+	activitypubRepository "github.com/lin-snow/ech0/internal/repository/activitypub"
 	authRepository "github.com/lin-snow/ech0/internal/repository/auth"
 	commentRepository "github.com/lin-snow/ech0/internal/repository/comment"
 	commonRepository "github.com/lin-snow/ech0/internal/repository/common"
 	connectRepository "github.com/lin-snow/ech0/internal/repository/connect"
+	copilotRepository "github.com/lin-snow/ech0/internal/repository/copilot"
 	echoRepository "github.com/lin-snow/ech0/internal/repository/echo"
 	embeddingRepository "github.com/lin-snow/ech0/internal/repository/embedding"
 	fileRepository "github.com/lin-snow/ech0/internal/repository/file"
@@ -21,16 +23,20 @@ import (
 	userRepository "github.com/lin-snow/ech0/internal/repository/user"
 	visitorRepository "github.com/lin-snow/ech0/internal/repository/visitor"
 	webhookRepository "github.com/lin-snow/ech0/internal/repository/webhook"
+	activitypubService "github.com/lin-snow/ech0/internal/service/activitypub"
 	authService "github.com/lin-snow/ech0/internal/service/auth"
 	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	commonService "github.com/lin-snow/ech0/internal/service/common"
 	connectService "github.com/lin-snow/ech0/internal/service/connect"
+	copilotService "github.com/lin-snow/ech0/internal/service/copilot"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	initService "github.com/lin-snow/ech0/internal/service/init"
+	pageService "github.com/lin-snow/ech0/internal/service/page"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
+	webmentionService "github.com/lin-snow/ech0/internal/service/webmention"
 	webhookmodule "github.com/lin-snow/ech0/internal/webhook"
 )
 
@@ -53,6 +59,10 @@ var (
 		wire.Bind(new(echoService.Repository), new(*echoRepository.EchoRepository)),
 		wire.Bind(new(connectService.EchoRepository), new(*echoRepository.EchoRepository)),
 		wire.Bind(new(embeddingService.EchoReader), new(*echoRepository.EchoRepository)),
+		wire.Bind(new(activitypubService.EchoRepository), new(*echoRepository.EchoRepository)),
+		wire.Bind(new(webmentionService.EchoRepository), new(*echoRepository.EchoRepository)),
+		wire.Bind(new(commentService.EchoRepository), new(*echoRepository.EchoRepository)),
+		wire.Bind(new(pageService.EchoRepository), new(*echoRepository.EchoRepository)),
 	)
 	EmbeddingSet = wire.NewSet(
 		embeddingRepository.NewEmbeddingRepository,
@@ -67,6 +77,10 @@ var (
 		fileRepository.NewFileRepository,
 		wire.Bind(new(fileService.FileRepository), new(*fileRepository.FileRepository)),
 	)
+	ActivityPubSet = wire.NewSet(
+		activitypubRepository.NewActivityPubRepository,
+		wire.Bind(new(activitypubService.Repository), new(*activitypubRepository.ActivityPubRepository)),
+	)
 	CommentSet = wire.NewSet(
 		commentRepository.NewCommentRepository,
 		wire.Bind(new(commentService.Repository), new(*commentRepository.CommentRepository)),
@@ -91,6 +105,11 @@ var (
 		connectRepository.NewConnectRepository,
 		wire.Bind(new(connectService.Repository), new(*connectRepository.ConnectRepository)),
 	)
+	CopilotSet = wire.NewSet(
+		copilotRepository.NewCopilotRepository,
+		wire.Bind(new(copilotService.ThreadRepository), new(*copilotRepository.CopilotRepository)),
+		wire.Bind(new(copilotService.ActionRepository), new(*copilotRepository.CopilotRepository)),
+	)
 	WebhookSet = wire.NewSet(
 		webhookRepository.NewWebhookRepository,
 		wire.Bind(new(settingService.WebhookRepository), new(*webhookRepository.WebhookRepository)),
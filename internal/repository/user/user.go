@@ -8,6 +8,7 @@ import (
 
 	"github.com/lin-snow/ech0/internal/cache"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	model "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"gorm.io/gorm"
@@ -223,6 +224,24 @@ func (userRepository *UserRepository) DeleteUser(ctx context.Context, id string)
 		return err
 	}
 
+	// Copilot Chat 线程、消息与待确认动作同样随用户删除。
+	if err := userRepository.getDB(ctx).
+		Where("thread_id IN (?)", userRepository.getDB(ctx).
+			Model(&copilotModel.ChatThread{}).Select("id").Where("user_id = ?", id)).
+		Delete(&copilotModel.ChatMessage{}).Error; err != nil {
+		return err
+	}
+	if err := userRepository.getDB(ctx).
+		Where("user_id = ?", id).
+		Delete(&copilotModel.ChatThread{}).Error; err != nil {
+		return err
+	}
+	if err := userRepository.getDB(ctx).
+		Where("user_id = ?", id).
+		Delete(&copilotModel.ChatAction{}).Error; err != nil {
+		return err
+	}
+
 	userRepository.cache.Delete(GetUserIDKey(userToDel.ID))
 	userRepository.cache.Delete(GetUsernameKey(userToDel.Username))
 	if userToDel.IsAdmin {
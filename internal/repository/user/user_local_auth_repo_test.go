@@ -7,6 +7,7 @@ import (
 	"context"
 	"testing"
 
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -53,3 +54,33 @@ func TestUserRepository_DeleteUser_RemovesLocalAuth(t *testing.T) {
 	require.NoError(t, db.Model(&userModel.UserLocalAuth{}).Where("user_id = ?", "u1").Count(&count).Error)
 	assert.Equal(t, int64(0), count, "删除用户应一并清理其本地认证行")
 }
+
+func TestUserRepository_DeleteUser_RemovesChatThreads(t *testing.T) {
+	repo, db, _ := newUserRepo(t)
+	ctx := context.Background()
+
+	seedUser(t, db, userModel.User{ID: "u1", Username: "alice"})
+	seedUser(t, db, userModel.User{ID: "u2", Username: "bob"})
+	mine := copilotModel.ChatThread{UserID: "u1", Title: "mine"}
+	theirs := copilotModel.ChatThread{UserID: "u2", Title: "theirs"}
+	require.NoError(t, db.Create(&mine).Error)
+	require.NoError(t, db.Create(&theirs).Error)
+	require.NoError(t, db.Create(&[]copilotModel.ChatMessage{
+		{ThreadID: mine.ID, Seq: 0, Role: copilotModel.RoleUser, Content: "q"},
+		{ThreadID: theirs.ID, Seq: 0, Role: copilotModel.RoleUser, Content: "q"},
+	}).Error)
+	require.NoError(t, db.Create(&[]copilotModel.ChatAction{
+		{UserID: "u1", Kind: copilotModel.ActionDraftEcho, Status: copilotModel.ActionStatusPending},
+		{UserID: "u2", Kind: copilotModel.ActionDraftEcho, Status: copilotModel.ActionStatusPending},
+	}).Error)
+
+	require.NoError(t, repo.DeleteUser(ctx, "u1"))
+
+	var threads, msgs, actions int64
+	require.NoError(t, db.Model(&copilotModel.ChatThread{}).Count(&threads).Error)
+	require.NoError(t, db.Model(&copilotModel.ChatMessage{}).Count(&msgs).Error)
+	require.NoError(t, db.Model(&copilotModel.ChatAction{}).Count(&actions).Error)
+	assert.Equal(t, int64(1), threads, "只删被删用户的线程")
+	assert.Equal(t, int64(1), msgs, "只删被删用户线程里的消息")
+	assert.Equal(t, int64(1), actions, "只删被删用户的待确认动作")
+}
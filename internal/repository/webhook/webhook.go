@@ -6,6 +6,7 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	model "github.com/lin-snow/ech0/internal/model/webhook"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
@@ -53,11 +54,15 @@ func (webhookRepository *WebhookRepository) UpdateWebhookByID(
 	tx := webhookRepository.getDB(ctx).
 		Model(&model.Webhook{}).
 		Where("id = ?", id).
-		Updates(map[string]any{
-			"name":      webhook.Name,
-			"url":       webhook.URL,
-			"secret":    webhook.Secret,
-			"is_active": webhook.IsActive,
+		Select("name", "url", "secret", "is_active", "topics", "template", "template_body").
+		Updates(&model.Webhook{
+			Name:         webhook.Name,
+			URL:          webhook.URL,
+			Secret:       webhook.Secret,
+			IsActive:     webhook.IsActive,
+			Topics:       webhook.Topics,
+			Template:     webhook.Template,
+			TemplateBody: webhook.TemplateBody,
 		})
 	if tx.Error != nil {
 		return tx.Error
@@ -88,9 +93,16 @@ func (webhookRepository *WebhookRepository) GetWebhookByID(ctx context.Context,
 	return &webhook, nil
 }
 
-// DeleteWebhookByID 根据ID删除webhook
+// DeleteWebhookByID 根据ID删除webhook，连同它的投递记录与 outbox 条目
 func (webhookRepository *WebhookRepository) DeleteWebhookByID(ctx context.Context, id string) error {
-	if err := webhookRepository.getDB(ctx).Where("id = ?", id).Delete(&model.Webhook{}).Error; err != nil {
+	db := webhookRepository.getDB(ctx)
+	if err := db.Where("webhook_id = ?", id).Delete(&model.WebhookDelivery{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("webhook_id = ?", id).Delete(&model.WebhookOutbox{}).Error; err != nil {
+		return err
+	}
+	if err := db.Where("id = ?", id).Delete(&model.Webhook{}).Error; err != nil {
 		return err
 	}
 
@@ -123,3 +135,175 @@ func (webhookRepository *WebhookRepository) UpdateWebhookDeliveryStatus(
 		})
 	return tx.Error
 }
+
+// CreateWebhookDeliveries 写入一次投递的各次尝试记录
+func (webhookRepository *WebhookRepository) CreateWebhookDeliveries(
+	ctx context.Context,
+	deliveries []model.WebhookDelivery,
+) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return webhookRepository.getDB(ctx).Create(&deliveries).Error
+}
+
+// ListWebhookDeliveries 按时间倒序列出 webhook 最近的投递记录
+func (webhookRepository *WebhookRepository) ListWebhookDeliveries(
+	ctx context.Context,
+	webhookID string,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	var deliveries []model.WebhookDelivery
+	// ID 是 UUIDv7，按 ID 倒序即按写入时间倒序，且同一秒内的多次尝试也有确定顺序。
+	if err := webhookRepository.getDB(ctx).
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(limit).
+		Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+// GetWebhookDeliveryByID 根据 ID 获取投递记录
+func (webhookRepository *WebhookRepository) GetWebhookDeliveryByID(
+	ctx context.Context,
+	id string,
+) (*model.WebhookDelivery, error) {
+	var delivery model.WebhookDelivery
+	if err := webhookRepository.getDB(ctx).Where("id = ?", id).First(&delivery).Error; err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// PruneWebhookDeliveries 只保留 webhook 最新的 keep 条投递记录
+func (webhookRepository *WebhookRepository) PruneWebhookDeliveries(
+	ctx context.Context,
+	webhookID string,
+	keep int,
+) error {
+	db := webhookRepository.getDB(ctx)
+	keepIDs := db.Model(&model.WebhookDelivery{}).
+		Select("id").
+		Where("webhook_id = ?", webhookID).
+		Order("id DESC").
+		Limit(keep)
+	return db.Where("webhook_id = ? AND id NOT IN (?)", webhookID, keepIDs).
+		Delete(&model.WebhookDelivery{}).Error
+}
+
+// EnqueueWebhookOutbox 写入待投递的 outbox 条目。在事务里调用时随业务写入一起提交。
+func (webhookRepository *WebhookRepository) EnqueueWebhookOutbox(
+	ctx context.Context,
+	entries []model.WebhookOutbox,
+) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return webhookRepository.getDB(ctx).Create(&entries).Error
+}
+
+// ClaimDueWebhookOutbox 取出到期的 pending 条目（只取启用中的 webhook 的），并把下次时间推到租期之后
+func (webhookRepository *WebhookRepository) ClaimDueWebhookOutbox(
+	ctx context.Context,
+	now int64,
+	lease time.Duration,
+	limit int,
+) ([]model.WebhookOutbox, error) {
+	var entries []model.WebhookOutbox
+	err := webhookRepository.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		activeIDs := tx.Model(&model.Webhook{}).Select("id").Where("is_active = ?", true)
+		if err := tx.
+			Where("status = ? AND next_attempt_at <= ?", model.OutboxStatusPending, now).
+			Where("webhook_id IN (?)", activeIDs).
+			Order("next_attempt_at ASC, id ASC").
+			Limit(limit).
+			Find(&entries).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		ids := make([]string, len(entries))
+		for i := range entries {
+			ids[i] = entries[i].ID
+		}
+		return tx.Model(&model.WebhookOutbox{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now+int64(lease/time.Second)).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// UpdateWebhookOutbox 回写一次失败尝试后的条目状态（重排或转为死信）
+func (webhookRepository *WebhookRepository) UpdateWebhookOutbox(
+	ctx context.Context,
+	entry *model.WebhookOutbox,
+) error {
+	return webhookRepository.getDB(ctx).
+		Model(&model.WebhookOutbox{}).
+		Where("id = ?", entry.ID).
+		Select("status", "attempts", "next_attempt_at", "last_error").
+		Updates(&model.WebhookOutbox{
+			Status:        entry.Status,
+			Attempts:      entry.Attempts,
+			NextAttemptAt: entry.NextAttemptAt,
+			LastError:     entry.LastError,
+		}).Error
+}
+
+// DeleteWebhookOutbox 删除已投递成功的 outbox 条目
+func (webhookRepository *WebhookRepository) DeleteWebhookOutbox(ctx context.Context, id string) error {
+	return webhookRepository.getDB(ctx).Where("id = ?", id).Delete(&model.WebhookOutbox{}).Error
+}
+
+// ListWebhookDeadLetters 按转为死信的时间倒序列出 webhook 的死信
+func (webhookRepository *WebhookRepository) ListWebhookDeadLetters(
+	ctx context.Context,
+	webhookID string,
+	limit int,
+) ([]model.WebhookOutbox, error) {
+	var entries []model.WebhookOutbox
+	if err := webhookRepository.getDB(ctx).
+		Where("webhook_id = ? AND status = ?", webhookID, model.OutboxStatusDead).
+		Order("updated_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// RequeueWebhookDeadLetter 把一条死信放回队列并重置尝试次数，返回是否找到该死信
+func (webhookRepository *WebhookRepository) RequeueWebhookDeadLetter(
+	ctx context.Context,
+	webhookID string,
+	id string,
+	now int64,
+) (bool, error) {
+	tx := webhookRepository.getDB(ctx).
+		Model(&model.WebhookOutbox{}).
+		Where("id = ? AND webhook_id = ? AND status = ?", id, webhookID, model.OutboxStatusDead).
+		Updates(map[string]any{
+			"status":          model.OutboxStatusPending,
+			"attempts":        0,
+			"next_attempt_at": now,
+			"last_error":      "",
+		})
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// PurgeWebhookDeadLetters 删除 webhook 的全部死信，返回删除条数
+func (webhookRepository *WebhookRepository) PurgeWebhookDeadLetters(
+	ctx context.Context,
+	webhookID string,
+) (int64, error) {
+	tx := webhookRepository.getDB(ctx).
+		Where("webhook_id = ? AND status = ?", webhookID, model.OutboxStatusDead).
+		Delete(&model.WebhookOutbox{})
+	return tx.RowsAffected, tx.Error
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 	webhookRepository "github.com/lin-snow/ech0/internal/repository/webhook"
@@ -86,7 +87,7 @@ func TestWebhookRepository_ListActiveWebhooks(t *testing.T) {
 		assert.Empty(t, got)
 	})
 
-	// 两个保持激活，一个翻转为禁用（用 UpdateWebhookByID 写 map，绕开 GORM default 陷阱）。
+	// 两个保持激活，一个翻转为禁用（UpdateWebhookByID 显式 Select 列，绕开 GORM default 陷阱）。
 	active1 := makeWebhook(t, repo, "active1")
 	active2 := makeWebhook(t, repo, "active2")
 	inactive := makeWebhook(t, repo, "inactive")
@@ -117,6 +118,7 @@ func TestWebhookRepository_UpdateWebhookByID(t *testing.T) {
 		id := makeWebhook(t, repo, "before")
 		err := repo.UpdateWebhookByID(ctx, id, &webhookModel.Webhook{
 			Name: "after", URL: "https://new.example.com", Secret: "new-secret", IsActive: false,
+			Topics: []string{"comment.created"}, Template: webhookModel.TemplateSlack,
 		})
 		require.NoError(t, err)
 
@@ -126,6 +128,15 @@ func TestWebhookRepository_UpdateWebhookByID(t *testing.T) {
 		assert.Equal(t, "https://new.example.com", got.URL)
 		assert.Equal(t, "new-secret", got.Secret)
 		assert.False(t, got.IsActive)
+		assert.Equal(t, []string{"comment.created"}, got.Topics)
+		assert.Equal(t, webhookModel.TemplateSlack, got.Template)
+
+		// 清空订阅与模板也必须落库（零值不能被 GORM 跳过）。
+		require.NoError(t, repo.UpdateWebhookByID(ctx, id, &webhookModel.Webhook{Name: "after", URL: got.URL}))
+		got, err = repo.GetWebhookByID(ctx, id)
+		require.NoError(t, err)
+		assert.Empty(t, got.Topics)
+		assert.Empty(t, got.Template)
 	})
 
 	t.Run("not found returns error", func(t *testing.T) {
@@ -183,15 +194,63 @@ func TestWebhookRepository_DeleteWebhookByID(t *testing.T) {
 	ctx := context.Background()
 
 	id := makeWebhook(t, repo, "doomed")
+	require.NoError(t, repo.CreateWebhookDeliveries(ctx, []webhookModel.WebhookDelivery{{WebhookID: id, Attempt: 1}}))
 	require.NoError(t, repo.DeleteWebhookByID(ctx, id))
 
 	_, err := repo.GetWebhookByID(ctx, id)
 	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	deliveries, err := repo.ListWebhookDeliveries(ctx, id, 10)
+	require.NoError(t, err)
+	assert.Empty(t, deliveries, "删除 webhook 应连带删除投递记录")
 
 	// 删除不存在的 ID 也不报错（Delete 不校验 RowsAffected）。
 	require.NoError(t, repo.DeleteWebhookByID(ctx, "missing"))
 }
 
+func TestWebhookRepository_Deliveries(t *testing.T) {
+	repo, _ := newWebhookRepo(t)
+	ctx := context.Background()
+
+	id := makeWebhook(t, repo, "log")
+	other := makeWebhook(t, repo, "other")
+	for i := 1; i <= 5; i++ {
+		require.NoError(t, repo.CreateWebhookDeliveries(ctx, []webhookModel.WebhookDelivery{
+			{WebhookID: id, EventID: "e", Attempt: i},
+		}))
+	}
+	require.NoError(t, repo.CreateWebhookDeliveries(ctx, []webhookModel.WebhookDelivery{{WebhookID: other, Attempt: 1}}))
+	require.NoError(t, repo.CreateWebhookDeliveries(ctx, nil))
+
+	t.Run("lists newest first with limit", func(t *testing.T) {
+		got, err := repo.ListWebhookDeliveries(ctx, id, 3)
+		require.NoError(t, err)
+		require.Len(t, got, 3)
+		assert.Equal(t, []int{5, 4, 3}, []int{got[0].Attempt, got[1].Attempt, got[2].Attempt})
+
+		one, err := repo.GetWebhookDeliveryByID(ctx, got[0].ID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, one.Attempt)
+	})
+
+	t.Run("prune keeps the newest rows of that webhook only", func(t *testing.T) {
+		require.NoError(t, repo.PruneWebhookDeliveries(ctx, id, 2))
+		got, err := repo.ListWebhookDeliveries(ctx, id, 10)
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, 5, got[0].Attempt)
+		assert.Equal(t, 4, got[1].Attempt)
+
+		kept, err := repo.ListWebhookDeliveries(ctx, other, 10)
+		require.NoError(t, err)
+		assert.Len(t, kept, 1)
+	})
+
+	t.Run("missing delivery", func(t *testing.T) {
+		_, err := repo.GetWebhookDeliveryByID(ctx, "missing")
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+}
+
 func TestWebhookRepository_DBErrorsPropagate(t *testing.T) {
 	repo := webhookRepository.NewWebhookRepository(func() *gorm.DB { return closedDB(t) })
 	ctx := context.Background()
@@ -222,6 +281,76 @@ func TestWebhookRepository_DBErrorsPropagate(t *testing.T) {
 	})
 }
 
+func TestWebhookRepository_Outbox(t *testing.T) {
+	repo, db := newWebhookRepo(t)
+	ctx := context.Background()
+
+	active := makeWebhook(t, repo, "active")
+	paused := makeWebhook(t, repo, "paused")
+	require.NoError(t, db.Model(&webhookModel.Webhook{}).Where("id = ?", paused).Update("is_active", false).Error)
+
+	const now = int64(1_000)
+	require.NoError(t, repo.EnqueueWebhookOutbox(ctx, nil), "空批次直接返回")
+	require.NoError(t, repo.EnqueueWebhookOutbox(ctx, []webhookModel.WebhookOutbox{
+		{WebhookID: active, EventID: "e1", Topic: "echo.created", Status: webhookModel.OutboxStatusPending, NextAttemptAt: now},
+		{WebhookID: active, EventID: "e2", Topic: "echo.created", Status: webhookModel.OutboxStatusPending, NextAttemptAt: now + 60},
+		{WebhookID: paused, EventID: "e3", Topic: "echo.created", Status: webhookModel.OutboxStatusPending, NextAttemptAt: now},
+	}))
+
+	// 只领取已到期、且所属 webhook 处于启用状态的条目。
+	claimed, err := repo.ClaimDueWebhookOutbox(ctx, now, 2*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, "e1", claimed[0].EventID)
+
+	// 领取后 next_attempt_at 被租约推后，同一时刻再次领取为空。
+	again, err := repo.ClaimDueWebhookOutbox(ctx, now, 2*time.Minute, 10)
+	require.NoError(t, err)
+	assert.Empty(t, again)
+
+	entry := claimed[0]
+	entry.Attempts = 8
+	entry.Status = webhookModel.OutboxStatusDead
+	entry.LastError = "HTTP 500"
+	require.NoError(t, repo.UpdateWebhookOutbox(ctx, &entry))
+
+	dead, err := repo.ListWebhookDeadLetters(ctx, active, 10)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, "HTTP 500", dead[0].LastError)
+	assert.Equal(t, 8, dead[0].Attempts)
+
+	// 死信必须属于路径中的 webhook。
+	ok, err := repo.RequeueWebhookDeadLetter(ctx, paused, entry.ID, now)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	ok, err = repo.RequeueWebhookDeadLetter(ctx, active, entry.ID, now)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	requeued, err := repo.ClaimDueWebhookOutbox(ctx, now, 2*time.Minute, 10)
+	require.NoError(t, err)
+	require.Len(t, requeued, 1)
+	assert.Equal(t, entry.ID, requeued[0].ID)
+	assert.Zero(t, requeued[0].Attempts, "重新入队应清零尝试次数")
+	assert.Empty(t, requeued[0].LastError)
+
+	requeued[0].Status = webhookModel.OutboxStatusDead
+	require.NoError(t, repo.UpdateWebhookOutbox(ctx, &requeued[0]))
+	purged, err := repo.PurgeWebhookDeadLetters(ctx, active)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	dead, err = repo.ListWebhookDeadLetters(ctx, active, 10)
+	require.NoError(t, err)
+	assert.Empty(t, dead)
+
+	// 删除 webhook 连带删除其未投递的条目。
+	require.NoError(t, repo.DeleteWebhookByID(ctx, active))
+	var remaining int64
+	require.NoError(t, db.Model(&webhookModel.WebhookOutbox{}).Where("webhook_id = ?", active).Count(&remaining).Error)
+	assert.Zero(t, remaining)
+}
+
 func TestWebhookRepository_TxContext(t *testing.T) {
 	repo, db := newWebhookRepo(t)
 	transactor := transaction.NewGormTransactor(func() *gorm.DB { return db })
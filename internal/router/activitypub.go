@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/middleware"
+)
+
+// setupActivityPubRoutes 挂载联邦端点：它们返回 ActivityStreams JSON、收件箱校验 HTTP 签名，
+// 不走 Huma 的 Result 包装与 token 鉴权。未开启联邦时一律 404。
+func setupActivityPubRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
+	g := appRouterGroup.ResourceGroup
+	g.GET("/.well-known/webfinger", h.ActivityPubHandler.WebFinger)
+	g.GET("/ap/actor", h.ActivityPubHandler.Actor)
+	g.GET("/ap/outbox", h.ActivityPubHandler.Outbox)
+	g.GET("/ap/followers", h.ActivityPubHandler.Followers)
+	g.GET("/ap/notes/:id", h.ActivityPubHandler.Note)
+	g.POST("/ap/inbox", middleware.RateLimit(20, 40), h.ActivityPubHandler.Inbox)
+}
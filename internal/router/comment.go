@@ -63,6 +63,24 @@ func registerComment(api huma.API, h *handler.Bundle, revoker authService.TokenR
 		Middlewares: huma.Middlewares{nc},
 	}, h.CommentHandler.ListPublicComments)
 
+	route(api, public(), huma.Operation{
+		OperationID: "comment-thread",
+		Method:      http.MethodGet,
+		Path:        "/comments/{id}/thread",
+		Summary:     "获取评论及其全部回复",
+		Tags:        []string{"Comment"},
+		Middlewares: huma.Middlewares{nc},
+	}, h.CommentHandler.ListCommentThread)
+
+	route(api, public(), huma.Operation{
+		OperationID: "comment-react",
+		Method:      http.MethodPost,
+		Path:        "/comments/{id}/reactions",
+		Summary:     "切换评论表情回应",
+		Tags:        []string{"Comment"},
+		Middlewares: huma.Middlewares{stash, optViewer},
+	}, h.CommentHandler.ReactComment)
+
 	route(api, public(), huma.Operation{
 		OperationID: "comment-create",
 		Method:      http.MethodPost,
@@ -119,10 +137,26 @@ func registerComment(api huma.API, h *handler.Bundle, revoker authService.TokenR
 		OperationID: "comment-panel-delete",
 		Method:      http.MethodDelete,
 		Path:        "/panel/comments/{id}",
-		Summary:     "删除评论",
+		Summary:     "删除评论（移入回收站）",
 		Tags:        []string{"Comment"},
 	}, h.CommentHandler.DeleteComment)
 
+	route(api, moderate, huma.Operation{
+		OperationID: "comment-panel-restore",
+		Method:      http.MethodPost,
+		Path:        "/panel/comments/{id}/restore",
+		Summary:     "从回收站恢复评论",
+		Tags:        []string{"Comment"},
+	}, h.CommentHandler.RestoreComment)
+
+	route(api, moderate, huma.Operation{
+		OperationID: "comment-panel-purge",
+		Method:      http.MethodDelete,
+		Path:        "/panel/comments/trash/{id}",
+		Summary:     "彻底删除回收站中的评论",
+		Tags:        []string{"Comment"},
+	}, h.CommentHandler.PurgeComment)
+
 	route(api, moderate, huma.Operation{
 		OperationID: "comment-panel-batch",
 		Method:      http.MethodPost,
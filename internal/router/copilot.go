@@ -13,13 +13,18 @@ import (
 	authService "github.com/lin-snow/ech0/internal/service/auth"
 )
 
-// setupCopilotRoutes 仅保留 Chat 流式问答（SSE）走裸 gin。
+// setupCopilotRoutes 仅保留 Chat 流式问答（SSE）与会话导出（文件下载）走裸 gin。
 func setupCopilotRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
 	appRouterGroup.AuthRouterGroup.POST(
 		"/chat",
 		middleware.RequireScopes(authModel.ScopeAdminSettings),
 		h.CopilotHandler.Ask(),
 	)
+	appRouterGroup.AuthRouterGroup.GET(
+		"/chat/threads/:id/export",
+		middleware.RequireScopes(authModel.ScopeAdminSettings),
+		h.CopilotHandler.ExportThread(),
+	)
 }
 
 // registerCopilot 注册 Copilot 的 JSON 端点。
@@ -33,18 +38,59 @@ func registerCopilot(api huma.API, h *handler.Bundle, revoker authService.TokenR
 	}, h.CopilotHandler.GetRecent)
 
 	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
-		OperationID: "copilot-session-get",
+		OperationID: "copilot-threads-list",
+		Method:      http.MethodGet,
+		Path:        "/chat/threads",
+		Summary:     "分页获取 Chat 会话",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.ListThreads)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-thread-create",
+		Method:      http.MethodPost,
+		Path:        "/chat/threads",
+		Summary:     "新建 Chat 会话",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.CreateThread)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-thread-get",
 		Method:      http.MethodGet,
-		Path:        "/chat/session",
-		Summary:     "获取持久化 Chat 会话",
+		Path:        "/chat/threads/{id}",
+		Summary:     "获取 Chat 会话及其消息",
 		Tags:        []string{"Copilot"},
-	}, h.CopilotHandler.GetSession)
+	}, h.CopilotHandler.GetThread)
 
 	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
-		OperationID: "copilot-session-clear",
+		OperationID: "copilot-thread-update",
+		Method:      http.MethodPut,
+		Path:        "/chat/threads/{id}",
+		Summary:     "重命名或调整 Chat 会话",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.UpdateThread)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-thread-delete",
 		Method:      http.MethodDelete,
-		Path:        "/chat/session",
-		Summary:     "清除持久化 Chat 会话",
+		Path:        "/chat/threads/{id}",
+		Summary:     "删除 Chat 会话",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.DeleteThread)
+
+	// 执行待确认操作会经 EchoService 写 Echo，因此在 Chat 的 scope 之外还要求 echo:write。
+	route(api, secured(revoker, authModel.ScopeAdminSettings, authModel.ScopeEchoWrite), huma.Operation{
+		OperationID: "copilot-action-confirm",
+		Method:      http.MethodPost,
+		Path:        "/chat/actions/{id}/confirm",
+		Summary:     "确认并执行 Chat 提出的操作",
+		Tags:        []string{"Copilot"},
+	}, h.CopilotHandler.ConfirmAction)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "copilot-action-cancel",
+		Method:      http.MethodPost,
+		Path:        "/chat/actions/{id}/cancel",
+		Summary:     "取消 Chat 提出的操作",
 		Tags:        []string{"Copilot"},
-	}, h.CopilotHandler.ClearSession)
+	}, h.CopilotHandler.CancelAction)
 }
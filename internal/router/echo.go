@@ -48,6 +48,37 @@ func registerEcho(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		Tags:        []string{"Echo"},
 	}, h.EchoHandler.QueryEchos)
 
+	route(api, optional(revoker), huma.Operation{
+		OperationID: "echo-search",
+		Method:      http.MethodPost,
+		Path:        "/echo/search",
+		Summary:     "混合检索 Echo（关键词 + 语义）",
+		Description: "关键词（全文索引）与向量语义两路召回，按倒数排名融合（RRF）排序后返回前 limit 条；" +
+			"可见性与 /echo/query 一致。Embedding 未启用时回退为仅关键词，响应 mode=keyword。",
+		Tags: []string{"Echo"},
+	}, h.SearchHandler.HybridSearch)
+
+	route(api, optional(revoker), huma.Operation{
+		OperationID: "echo-related",
+		Method:      http.MethodGet,
+		Path:        "/echo/{id}/related",
+		Summary:     "获取相关 Echo",
+		Description: "以该 Echo 自身的向量做近邻检索；源 Echo 与结果的可见性均与 /echo/{id}、/echo/query 一致。" +
+			"Embedding 未启用或检索失败时返回空列表，响应 mode=unavailable。",
+		Tags: []string{"Echo"},
+	}, h.SearchHandler.RelatedEchos)
+
+	// 标签建议：近邻投票会参考当前身份可见的 Echo（管理员含私密），故要求登录。
+	route(api, secured(revoker, authModel.ScopeEchoRead), huma.Operation{
+		OperationID: "tag-suggest",
+		Method:      http.MethodPost,
+		Path:        "/tags/suggest",
+		Summary:     "为草稿建议已有标签",
+		Description: "按草稿正文的向量近邻投票，返回最可能的已有标签（mode=semantic）；" +
+			"Embedding 未启用或近邻里没有标签时回退为正文中出现的已有标签（mode=keyword）。",
+		Tags: []string{"Tag"},
+	}, h.SearchHandler.SuggestTags)
+
 	route(api, optional(revoker), huma.Operation{
 		OperationID: "echo-page-get",
 		Method:      http.MethodGet,
@@ -112,6 +143,32 @@ func registerEcho(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		Tags:        []string{"Echo"},
 	}, h.EchoHandler.GetEchoById)
 
+	// 编辑历史：快照含私密内容，仅管理员（服务层校验）可读。
+	route(api, secured(revoker, authModel.ScopeEchoRead), huma.Operation{
+		OperationID: "echo-revision-list",
+		Method:      http.MethodGet,
+		Path:        "/echo/{id}/revisions",
+		Summary:     "获取 Echo 的编辑历史",
+		Tags:        []string{"Echo"},
+	}, h.EchoHandler.ListEchoRevisions)
+
+	route(api, secured(revoker, authModel.ScopeEchoRead), huma.Operation{
+		OperationID: "echo-revision-get",
+		Method:      http.MethodGet,
+		Path:        "/echo/{id}/revisions/{revisionId}",
+		Summary:     "查看 Echo 历史版本及差分",
+		Tags:        []string{"Echo"},
+	}, h.EchoHandler.GetEchoRevision)
+
+	// 回收站：仅管理员（服务层校验）。
+	route(api, secured(revoker, authModel.ScopeEchoRead), huma.Operation{
+		OperationID: "echo-trash-list",
+		Method:      http.MethodGet,
+		Path:        "/echo/trash",
+		Summary:     "获取回收站中的 Echo",
+		Tags:        []string{"Echo"},
+	}, h.EchoHandler.ListTrashedEchos)
+
 	// 写接口（echo:write）
 	route(api, secured(revoker, authModel.ScopeEchoWrite), huma.Operation{
 		OperationID: "echo-create",
@@ -133,10 +190,36 @@ func registerEcho(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		OperationID: "echo-delete",
 		Method:      http.MethodDelete,
 		Path:        "/echo/{id}",
-		Summary:     "删除 Echo",
+		Summary:     "删除 Echo（移入回收站）",
+		Description: "Echo 移入回收站，可在保留期内恢复；过期后由定时任务彻底删除。已发布的 Echo 会触发 restorable=true 的 echo.deleted 事件。",
 		Tags:        []string{"Echo"},
 	}, h.EchoHandler.DeleteEcho)
 
+	route(api, secured(revoker, authModel.ScopeEchoWrite), huma.Operation{
+		OperationID: "echo-restore",
+		Method:      http.MethodPost,
+		Path:        "/echo/{id}/restore",
+		Summary:     "从回收站恢复 Echo",
+		Tags:        []string{"Echo"},
+	}, h.EchoHandler.RestoreEcho)
+
+	route(api, secured(revoker, authModel.ScopeEchoWrite), huma.Operation{
+		OperationID: "echo-trash-purge",
+		Method:      http.MethodDelete,
+		Path:        "/echo/trash/{id}",
+		Summary:     "彻底删除回收站中的 Echo",
+		Tags:        []string{"Echo"},
+	}, h.EchoHandler.PurgeEcho)
+
+	route(api, secured(revoker, authModel.ScopeEchoWrite), huma.Operation{
+		OperationID: "echo-revision-restore",
+		Method:      http.MethodPost,
+		Path:        "/echo/{id}/revisions/{revisionId}/restore",
+		Summary:     "恢复 Echo 历史版本",
+		Description: "以历史快照覆盖当前内容，等同一次普通编辑：当前版本会留下新的修订并触发 echo.updated 事件。",
+		Tags:        []string{"Echo"},
+	}, h.EchoHandler.RestoreEchoRevision)
+
 	route(api, secured(revoker, authModel.ScopeEchoWrite), huma.Operation{
 		OperationID: "tag-create",
 		Method:      http.MethodPost,
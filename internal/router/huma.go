@@ -112,6 +112,7 @@ func registerOperations(api huma.API, h *handler.Bundle, revoker authService.Tok
 	registerComment(api, h, revoker)
 	registerMigration(api, h, revoker)
 	registerEmbedding(api, h, revoker)
+	registerJob(api, h, revoker)
 }
 
 // GenerateOpenAPIYAML 构造一个一次性的 Huma API、注册全部 operation 并导出 OpenAPI YAML。
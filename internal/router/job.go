@@ -0,0 +1,43 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/lin-snow/ech0/internal/handler"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	authService "github.com/lin-snow/ech0/internal/service/auth"
+)
+
+// registerJob 注册后台作业历史操作（owner / 管理员，需 admin:settings scope）。
+func registerJob(api huma.API, h *handler.Bundle, revoker authService.TokenRevoker) {
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "job-list",
+		Method:      http.MethodGet,
+		Path:        "/jobs",
+		Summary:     "列出后台作业历史",
+		Description: "按提交时间倒序分页，可按类型与状态过滤；排队中的作业 status=pending。",
+		Tags:        []string{"Job"},
+	}, h.JobHandler.ListJobs)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "job-get",
+		Method:      http.MethodGet,
+		Path:        "/jobs/{id}",
+		Summary:     "查看单个后台作业",
+		Description: "返回作业的最终 payload 与错误信息。",
+		Tags:        []string{"Job"},
+	}, h.JobHandler.GetJob)
+
+	route(api, secured(revoker, authModel.ScopeAdminSettings), huma.Operation{
+		OperationID: "job-cancel",
+		Method:      http.MethodPost,
+		Path:        "/jobs/{id}/cancel",
+		Summary:     "取消单个后台作业",
+		Description: "排队中的作业直接取消；运行中的作业协作式取消，轮询收敛到 cancelled。",
+		Tags:        []string{"Job"},
+	}, h.JobHandler.CancelJob)
+}
@@ -0,0 +1,24 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/middleware"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	authService "github.com/lin-snow/ech0/internal/service/auth"
+)
+
+// setupMicropubRoutes 挂载 Micropub 与媒体端点：表单 / multipart / JSON 请求、Micropub 错误体，
+// 不走 Huma。token 可放在 Authorization 头或 urlencoded 表单的 access_token 中，按 echo:write / file:write 校验。
+func setupMicropubRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle, revoker authService.TokenRevoker) {
+	g := appRouterGroup.ResourceGroup.Group("/micropub",
+		middleware.NoCache(),
+		middleware.FormAccessToken(),
+		middleware.RequireAuth(revoker),
+	)
+	g.GET("", middleware.RequireScopes(authModel.ScopeEchoWrite), h.MicropubHandler.Query)
+	g.POST("", middleware.RequireScopes(authModel.ScopeEchoWrite), h.MicropubHandler.Post)
+	g.POST("/media", middleware.RequireScopes(authModel.ScopeFileWrite), h.MicropubHandler.Media)
+}
@@ -4,19 +4,33 @@
 package router
 
 import (
+	"net/http"
+
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/config"
 	i18nUtil "github.com/lin-snow/ech0/internal/i18n"
 	"github.com/lin-snow/ech0/internal/middleware"
+	"github.com/lin-snow/ech0/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
 // setupMiddleware 设置中间件
 func setupMiddleware(r *gin.Engine) {
+	// 追踪放在最外层，span 覆盖整个请求（含下面各中间件）；探活与抓取指标的请求不追踪。
+	if tracing.Enabled() {
+		r.Use(otelgin.Middleware(config.Config().Tracing.ServiceName, otelgin.WithFilter(func(req *http.Request) bool {
+			return req.URL.Path != "/healthz" && req.URL.Path != "/metrics"
+		})))
+	}
 	// Dev-only 彩色访问日志（绿色状态码 / 彩色方法徽章）。放在 Recovery 之外（更外层），
 	// 这样被 Recovery 兜住的 panic 仍能打出带最终 500 状态的访问行。Prod（release）不挂。
 	if config.Config().Server.Mode == "debug" {
 		r.Use(gin.Logger())
 	}
+	// 请求耗时指标同样放在 Recovery 外层，panic 兜成的 500 也会被计入。
+	if config.Config().Metrics.Enabled {
+		r.Use(middleware.HTTPMetrics())
+	}
 	// Recovery middleware to recover from any panics and write a 500 if there was one.
 	r.Use(gin.Recovery())
 	// Powered-by header
@@ -4,7 +4,11 @@
 package router
 
 import (
+	"github.com/gin-gonic/gin"
+	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/metrics"
+	"github.com/lin-snow/ech0/internal/middleware"
 )
 
 // setupResourceRoutes 设置资源路由。
@@ -14,4 +18,9 @@ func setupResourceRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
 	appRouterGroup.ResourceGroup.GET("/sitemap.xml", h.CommonHandler.GetSitemap)
 	appRouterGroup.ResourceGroup.GET("/rss", h.CommonHandler.GetRss)
 	appRouterGroup.ResourceGroup.GET("/healthz", h.CommonHandler.Healthz())
+
+	// /metrics 需显式开启（ECH0_METRICS_ENABLED），访问控制见 middleware.MetricsAccess。
+	if cfg := config.Config().Metrics; cfg.Enabled {
+		appRouterGroup.ResourceGroup.GET("/metrics", middleware.MetricsAccess(cfg), gin.WrapH(metrics.Handler()))
+	}
 }
@@ -38,6 +38,9 @@ func SetupRouter(r *gin.Engine, h *handler.Bundle, mwDeps *middleware.Deps) {
 	// 2. 业务域
 	revoker := revokerOf(mwDeps)
 	setupResourceRoutes(groups, h)
+	setupActivityPubRoutes(groups, h)
+	setupWebmentionRoutes(groups, h)
+	setupMicropubRoutes(groups, h, revoker)
 	setupAuthRoutes(groups, h)
 	setupCommentRoutes(groups, h)
 	setupFileRoutes(groups, h)
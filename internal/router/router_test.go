@@ -13,6 +13,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/database"
 	"github.com/lin-snow/ech0/internal/handler"
+	activitypubHandler "github.com/lin-snow/ech0/internal/handler/activitypub"
 	authHandler "github.com/lin-snow/ech0/internal/handler/auth"
 	commentHandler "github.com/lin-snow/ech0/internal/handler/comment"
 	commonHandler "github.com/lin-snow/ech0/internal/handler/common"
@@ -23,10 +24,14 @@ import (
 	embeddingHandler "github.com/lin-snow/ech0/internal/handler/embedding"
 	fileHandler "github.com/lin-snow/ech0/internal/handler/file"
 	initHandler "github.com/lin-snow/ech0/internal/handler/init"
+	jobHandler "github.com/lin-snow/ech0/internal/handler/job"
+	micropubHandler "github.com/lin-snow/ech0/internal/handler/micropub"
 	migratorHandler "github.com/lin-snow/ech0/internal/handler/migrator"
+	searchHandler "github.com/lin-snow/ech0/internal/handler/search"
 	settingHandler "github.com/lin-snow/ech0/internal/handler/setting"
 	userHandler "github.com/lin-snow/ech0/internal/handler/user"
 	webHandler "github.com/lin-snow/ech0/internal/handler/web"
+	webmentionHandler "github.com/lin-snow/ech0/internal/handler/webmention"
 	"github.com/lin-snow/ech0/internal/mcp"
 	"github.com/lin-snow/ech0/internal/middleware"
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
@@ -61,6 +66,12 @@ func TestSetupRouter_RegistersKeyRoutes(t *testing.T) {
 		{method: http.MethodGet, path: "/api/system/logs"},
 		{method: http.MethodGet, path: "/api/system/logs/stream"},
 		{method: http.MethodGet, path: "/ws/system/logs"},
+		{method: http.MethodGet, path: "/.well-known/webfinger"},
+		{method: http.MethodPost, path: "/ap/inbox"},
+		{method: http.MethodPost, path: "/webmention"},
+		{method: http.MethodGet, path: "/micropub"},
+		{method: http.MethodPost, path: "/micropub"},
+		{method: http.MethodPost, path: "/micropub/media"},
 	}
 
 	routes := engine.Routes()
@@ -338,7 +349,7 @@ func buildTestMWDeps() *middleware.Deps {
 
 func buildTestHandlers() *handler.Bundle {
 	return handler.NewBundle(
-		webHandler.NewWebHandler(visitor.NewTracker()),
+		webHandler.NewWebHandler(visitor.NewTracker(), nil),
 		userHandler.NewUserHandler(nil),
 		authHandler.NewAuthHandler(nil, nil),
 		echoHandler.NewEchoHandler(nil),
@@ -352,7 +363,13 @@ func buildTestHandlers() *handler.Bundle {
 		dashboardHandler.NewDashboardHandler(nil),
 		copilotHandler.NewCopilotHandler(nil, nil),
 		embeddingHandler.NewEmbeddingHandler(nil),
-		mcp.NewHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		searchHandler.NewSearchHandler(nil),
+		mcp.NewHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil),
+		activitypubHandler.NewActivityPubHandler(nil),
+		webmentionHandler.NewWebmentionHandler(nil),
+		micropubHandler.NewMicropubHandler(nil),
+		jobHandler.NewJobHandler(nil),
+		nil,
 	)
 }
 
@@ -155,6 +155,46 @@ func registerSetting(api huma.API, h *handler.Bundle, revoker authService.TokenR
 		Tags:        []string{"Setting"},
 	}, h.SettingHandler.TestWebhook)
 
+	route(api, adminSettings, huma.Operation{
+		OperationID: "webhook-deliveries",
+		Method:      http.MethodGet,
+		Path:        "/webhook/{id}/deliveries",
+		Summary:     "获取 Webhook 投递记录",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.ListWebhookDeliveries)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "webhook-redeliver",
+		Method:      http.MethodPost,
+		Path:        "/webhook/{id}/deliveries/{delivery_id}/redeliver",
+		Summary:     "重新投递 Webhook",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.RedeliverWebhook)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "webhook-dead-letters",
+		Method:      http.MethodGet,
+		Path:        "/webhook/{id}/dead-letters",
+		Summary:     "获取 Webhook 死信",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.ListWebhookDeadLetters)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "webhook-dead-letter-retry",
+		Method:      http.MethodPost,
+		Path:        "/webhook/{id}/dead-letters/{dead_letter_id}/retry",
+		Summary:     "重试 Webhook 死信",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.RetryWebhookDeadLetter)
+
+	route(api, adminSettings, huma.Operation{
+		OperationID: "webhook-dead-letters-purge",
+		Method:      http.MethodDelete,
+		Path:        "/webhook/{id}/dead-letters",
+		Summary:     "清除 Webhook 死信",
+		Tags:        []string{"Setting"},
+	}, h.SettingHandler.PurgeWebhookDeadLetters)
+
 	route(api, adminSettings, huma.Operation{
 		OperationID: "snapshot-schedule-get",
 		Method:      http.MethodGet,
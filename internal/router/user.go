@@ -63,4 +63,12 @@ func registerUser(api huma.API, h *handler.Bundle, revoker authService.TokenRevo
 		Summary:     "切换用户管理员权限",
 		Tags:        []string{"User"},
 	}, h.UserHandler.UpdateUserAdmin)
+
+	route(api, secured(revoker, authModel.ScopeAdminUser), huma.Operation{
+		OperationID: "user-set-role",
+		Method:      http.MethodPut,
+		Path:        "/user/{id}/role",
+		Summary:     "设置用户角色",
+		Tags:        []string{"User"},
+	}, h.UserHandler.UpdateUserRole)
 }
@@ -0,0 +1,15 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package router
+
+import (
+	"github.com/lin-snow/ech0/internal/handler"
+	"github.com/lin-snow/ech0/internal/middleware"
+)
+
+// setupWebmentionRoutes 挂载 Webmention 接收端点：表单请求、纯文本响应，不走 Huma 与鉴权。
+// 未开启 Webmention 时返回 404。
+func setupWebmentionRoutes(appRouterGroup *AppRouterGroup, h *handler.Bundle) {
+	appRouterGroup.ResourceGroup.POST("/webmention", middleware.RateLimit(10, 20), h.WebmentionHandler.Receive)
+}
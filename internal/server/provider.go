@@ -27,7 +27,7 @@ func ProvideGinEngine() *gin.Engine {
 
 func ProvideHTTPServer(engine *gin.Engine, handlers *handler.Bundle, mwDeps *middleware.Deps) *Server {
 	router.SetupRouter(engine, handlers, mwDeps)
-	return New(engine)
+	return New(engine, handlers.Workers...)
 }
 
 var ProviderSet = wire.NewSet(ProvideGinEngine, ProvideHTTPServer)
@@ -17,30 +17,33 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/handler"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	errUtil "github.com/lin-snow/ech0/internal/util/err"
 )
 
-// Server 是纯 HTTP runtime，只负责 gin/http 生命周期。
+// Server 是纯 HTTP runtime，只负责 gin/http 生命周期，以及随之启停的后台 Worker。
 type Server struct {
 	GinEngine  *gin.Engine
 	httpServer *http.Server // 用于优雅停止服务器
 	listener   net.Listener
+	workers    []handler.Worker
 }
 
 func (s *Server) Name() string {
 	return "server"
 }
 
-// New 创建一个新的 HTTP server 实例。
-func New(engine *gin.Engine) *Server {
+// New 创建一个新的 HTTP server 实例，workers 随服务启停。
+func New(engine *gin.Engine, workers ...handler.Worker) *Server {
 	return &Server{
 		GinEngine: engine,
+		workers:   workers,
 	}
 }
 
-// Start 启动服务器，并在返回前确认监听端口已成功绑定。
-func (s *Server) Start(context.Context) error {
+// Start 先启动后台 Worker 再启动服务器，并在返回前确认监听端口已成功绑定。
+func (s *Server) Start(ctx context.Context) error {
 	if s.GinEngine == nil {
 		return errors.New("gin engine is nil")
 	}
@@ -48,6 +51,13 @@ func (s *Server) Start(context.Context) error {
 		return errors.New("http server already started")
 	}
 
+	for i, w := range s.workers {
+		if err := w.Start(ctx); err != nil {
+			s.stopWorkers(ctx, s.workers[:i])
+			return err
+		}
+	}
+
 	port := config.Config().Server.Port
 	PrintGreetings(port)
 
@@ -58,6 +68,7 @@ func (s *Server) Start(context.Context) error {
 
 	listener, err := net.Listen("tcp", s.httpServer.Addr)
 	if err != nil {
+		s.stopWorkers(ctx, s.workers)
 		return err
 	}
 	s.listener = listener
@@ -97,5 +108,16 @@ func (s *Server) Stop(ctx context.Context) error {
 
 	s.httpServer = nil
 	s.listener = nil
-	return nil
+	// 停止监听后不会再有新任务进来，再让 Worker 排空已收下的任务。
+	return s.stopWorkers(shutdownCtx, s.workers)
+}
+
+func (s *Server) stopWorkers(ctx context.Context, workers []handler.Worker) error {
+	var errs []error
+	for _, w := range workers {
+		if err := w.Stop(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,212 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"path"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+)
+
+// outboxPageSize 是 outbox 每页的 Note 数量。
+const outboxPageSize = 20
+
+type ActivityPubService struct {
+	repo       Repository
+	echoRepo   EchoRepository
+	commentSvc CommentService
+	commonSvc  CommonService
+	kv         kvstore.Store
+	federator  *Federator
+	fetcher    actorFetcher
+}
+
+var _ Service = (*ActivityPubService)(nil)
+
+// actorFetcher 按 keyId 抓取签名者的 actor 文档；默认实现为签名 GET。
+type actorFetcher func(ctx context.Context, st site, keyID string) (remoteActor, error)
+
+func NewActivityPubService(
+	repo Repository,
+	echoRepo EchoRepository,
+	commentSvc CommentService,
+	commonSvc CommonService,
+	durableKV kvstore.Store,
+	federator *Federator,
+) *ActivityPubService {
+	return &ActivityPubService{
+		repo:       repo,
+		echoRepo:   echoRepo,
+		commentSvc: commentSvc,
+		commonSvc:  commonSvc,
+		kv:         durableKV,
+		federator:  federator,
+		fetcher:    federator.http.fetchSigner,
+	}
+}
+
+// WebFinger 解析 acct:站长用户名@站点域名（或直接给出 actor / 站点地址）到 actor。
+func (s *ActivityPubService) WebFinger(ctx context.Context, resource string) (model.WebFinger, error) {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return model.WebFinger{}, err
+	}
+	owner, err := s.commonSvc.GetOwner()
+	if err != nil {
+		return model.WebFinger{}, err
+	}
+	subject := "acct:" + owner.Username + "@" + st.host
+	resource = strings.TrimSpace(resource)
+	if !strings.EqualFold(resource, subject) && resource != st.actorID() && resource != st.base {
+		return model.WebFinger{}, ErrNotFound
+	}
+	return model.WebFinger{
+		Subject: subject,
+		Aliases: []string{st.actorID(), st.base},
+		Links: []model.WebFingerLink{
+			{Rel: "self", Type: model.ContentType, Href: st.actorID()},
+			{Rel: "http://webfinger.net/rel/profile-page", Type: "text/html", Href: st.base},
+		},
+	}, nil
+}
+
+// Actor 返回站长的 Person 文档；首次调用时生成签名密钥。
+func (s *ActivityPubService) Actor(ctx context.Context) (model.Actor, error) {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return model.Actor{}, err
+	}
+	owner, err := s.commonSvc.GetOwner()
+	if err != nil {
+		return model.Actor{}, err
+	}
+	pubPEM, err := s.federator.http.keys.publicKeyPEM(ctx)
+	if err != nil {
+		return model.Actor{}, err
+	}
+
+	name := strings.TrimSpace(st.system.ServerName)
+	if name == "" {
+		name = owner.Username
+	}
+	actor := model.Actor{
+		Context:           []string{model.ActivityStreamsContext, model.SecurityContext},
+		ID:                st.actorID(),
+		Type:              "Person",
+		PreferredUsername: owner.Username,
+		Name:              name,
+		Summary:           st.system.SiteTitle,
+		URL:               st.base,
+		Inbox:             st.inbox(),
+		Outbox:            st.outbox(),
+		Followers:         st.followers(),
+		Endpoints:         &model.Endpoints{SharedInbox: st.inbox()},
+		PublicKey: model.PublicKey{
+			ID:           st.keyID(),
+			Owner:        st.actorID(),
+			PublicKeyPem: pubPEM,
+		},
+	}
+	if avatar := strings.TrimSpace(owner.Avatar); avatar != "" {
+		actor.Icon = &model.Image{
+			Type:      "Image",
+			MediaType: mime.TypeByExtension(path.Ext(avatar)),
+			URL:       st.absolute(avatar),
+		}
+	}
+	return actor, nil
+}
+
+// Outbox 返回公开 Echo 的 Create(Note) 集合。page <= 0 时返回集合根，page 从 1 开始。
+func (s *ActivityPubService) Outbox(ctx context.Context, page int) (any, error) {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return nil, err
+	}
+	if page <= 0 {
+		_, total := s.echoRepo.GetEchosByPage(1, 1, "", false)
+		return model.OrderedCollection{
+			Context:    model.ActivityStreamsContext,
+			ID:         st.outbox(),
+			Type:       "OrderedCollection",
+			TotalItems: total,
+			First:      st.outbox() + "?page=1",
+		}, nil
+	}
+
+	echos, total := s.echoRepo.GetEchosByPage(page, outboxPageSize, "", false)
+	items := make([]any, 0, len(echos))
+	for i := range echos {
+		if !isFederated(&echos[i]) {
+			continue
+		}
+		items = append(items, createActivity(st, buildNote(st, &echos[i])))
+	}
+	result := model.OrderedCollectionPage{
+		Context:      model.ActivityStreamsContext,
+		ID:           fmt.Sprintf("%s?page=%d", st.outbox(), page),
+		Type:         "OrderedCollectionPage",
+		PartOf:       st.outbox(),
+		TotalItems:   total,
+		OrderedItems: items,
+	}
+	if int64(page*outboxPageSize) < total {
+		result.Next = fmt.Sprintf("%s?page=%d", st.outbox(), page+1)
+	}
+	if page > 1 {
+		result.Prev = fmt.Sprintf("%s?page=%d", st.outbox(), page-1)
+	}
+	return result, nil
+}
+
+// Followers 只公开关注者数量，不列出具体账号。
+func (s *ActivityPubService) Followers(ctx context.Context) (model.OrderedCollection, error) {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return model.OrderedCollection{}, err
+	}
+	total, err := s.repo.CountFollowers(ctx)
+	if err != nil {
+		return model.OrderedCollection{}, err
+	}
+	return model.OrderedCollection{
+		Context:    model.ActivityStreamsContext,
+		ID:         st.followers(),
+		Type:       "OrderedCollection",
+		TotalItems: total,
+	}, nil
+}
+
+// Note 返回单条公开 Echo 的 Note；私密、未发布或已删除的 Echo 视为不存在。
+func (s *ActivityPubService) Note(ctx context.Context, echoID string) (model.Note, error) {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return model.Note{}, err
+	}
+	echo, err := s.visibleEcho(ctx, echoID)
+	if err != nil {
+		return model.Note{}, err
+	}
+	note := buildNote(st, echo)
+	note.Context = model.ActivityStreamsContext
+	return note, nil
+}
+
+// visibleEcho 取对联邦可见的 Echo，其余情况一律返回 ErrNotFound。
+func (s *ActivityPubService) visibleEcho(ctx context.Context, echoID string) (*echoModel.Echo, error) {
+	if strings.TrimSpace(echoID) == "" {
+		return nil, ErrNotFound
+	}
+	echo, err := s.echoRepo.GetEchosById(ctx, echoID)
+	if err != nil || !isFederated(echo) {
+		return nil, ErrNotFound
+	}
+	return echo, nil
+}
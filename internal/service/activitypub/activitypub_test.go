@@ -0,0 +1,494 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/commentmock"
+	"github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	httpsig "github.com/lin-snow/ech0/internal/util/httpsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testBase      = "https://ech0.example"
+	remoteActorID = "https://remote.example/users/alice"
+	remoteKeyID   = remoteActorID + "#main-key"
+)
+
+// memRepo 是关注者 / 点赞表的内存替身。
+type memRepo struct {
+	mu        sync.Mutex
+	followers map[string]model.Follower
+	likes     map[string]bool
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{followers: map[string]model.Follower{}, likes: map[string]bool{}}
+}
+
+func (r *memRepo) UpsertFollower(_ context.Context, f *model.Follower) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.followers[f.ActorID] = *f
+	return nil
+}
+
+func (r *memRepo) DeleteFollower(_ context.Context, actorID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.followers, actorID)
+	return nil
+}
+
+func (r *memRepo) ListFollowers(context.Context) ([]model.Follower, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]model.Follower, 0, len(r.followers))
+	for _, f := range r.followers {
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func (r *memRepo) CountFollowers(context.Context) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int64(len(r.followers)), nil
+}
+
+func (r *memRepo) CreateLike(_ context.Context, l *model.Like) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := l.ActorID + "|" + l.EchoID
+	if r.likes[key] {
+		return false, nil
+	}
+	r.likes[key] = true
+	return true, nil
+}
+
+func (r *memRepo) DeleteLike(_ context.Context, actorID, echoID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.likes, actorID+"|"+echoID)
+	return nil
+}
+
+// memEchoRepo 只实现 ActivityPub 用到的 Echo 读取与点赞。
+type memEchoRepo struct {
+	echos map[string]echoModel.Echo
+	liked []string
+}
+
+func (r *memEchoRepo) GetEchosById(_ context.Context, id string) (*echoModel.Echo, error) {
+	e, ok := r.echos[id]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (r *memEchoRepo) GetEchosByPage(_, _ int, _ string, _ bool) ([]echoModel.Echo, int64) {
+	out := make([]echoModel.Echo, 0, len(r.echos))
+	for _, e := range r.echos {
+		out = append(out, e)
+	}
+	return out, int64(len(out))
+}
+
+func (r *memEchoRepo) LikeEcho(_ context.Context, id string) error {
+	r.liked = append(r.liked, id)
+	return nil
+}
+
+func (r *memEchoRepo) InvalidateEchoCaches(...string) {}
+
+// recordingClient 记录发出的请求并一律回 202；posted 用于等待后台投递。
+type recordingClient struct {
+	mu       sync.Mutex
+	requests []*http.Request
+	bodies   [][]byte
+	posted   chan struct{}
+}
+
+func newRecordingClient() *recordingClient {
+	return &recordingClient{posted: make(chan struct{}, 16)}
+}
+
+func (c *recordingClient) Do(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	c.mu.Lock()
+	c.requests = append(c.requests, req)
+	c.bodies = append(c.bodies, body)
+	c.mu.Unlock()
+	c.posted <- struct{}{}
+	return &http.Response{StatusCode: http.StatusAccepted, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+}
+
+type fixture struct {
+	svc       *ActivityPubService
+	repo      *memRepo
+	echoRepo  *memEchoRepo
+	comments  *commentmock.MockService
+	client    *recordingClient
+	remoteKey *rsa.PrivateKey
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	helpers.SetActivityPub(t, true)
+	ctx := context.Background()
+
+	kv := kvstore.NewMemory()
+	require.NoError(t, coreSetting.Set(ctx, kv, coreSetting.System, settingModel.SystemSetting{
+		ServerURL:  testBase + "/",
+		ServerName: "Ech0",
+	}))
+
+	remoteKey, err := httpsig.GenerateKey()
+	require.NoError(t, err)
+	remotePEM, err := httpsig.EncodePublicKey(&remoteKey.PublicKey)
+	require.NoError(t, err)
+
+	common := commonmock.NewMockService(t)
+	common.EXPECT().GetOwner().Return(userModel.User{Username: "owner"}, nil).Maybe()
+	comments := commentmock.NewMockService(t)
+
+	f := &fixture{
+		repo: newMemRepo(),
+		echoRepo: &memEchoRepo{echos: map[string]echoModel.Echo{
+			"echo-public":  helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "echo-public" }),
+			"echo-private": helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "echo-private"; e.Private = true }),
+		}},
+		comments:  comments,
+		client:    newRecordingClient(),
+		remoteKey: remoteKey,
+	}
+	federator := NewFederator(f.repo, kv)
+	federator.http.client = f.client
+	f.svc = NewActivityPubService(f.repo, f.echoRepo, comments, common, kv, federator)
+	f.svc.fetcher = func(_ context.Context, _ site, keyID string) (remoteActor, error) {
+		actor := remoteActor{
+			ID:                remoteActorID,
+			PreferredUsername: "alice",
+			Name:              "Alice",
+			Inbox:             remoteActorID + "/inbox",
+			PublicKey:         model.PublicKey{ID: remoteKeyID, Owner: remoteActorID, PublicKeyPem: remotePEM},
+		}
+		actor.Endpoints.SharedInbox = "https://remote.example/inbox"
+		if keyID != remoteKeyID {
+			t.Fatalf("unexpected keyId %s", keyID)
+		}
+		return actor, nil
+	}
+	return f
+}
+
+// inbox 构造一个由远端 actor 签名的收件箱请求。
+func (f *fixture) inbox(t *testing.T, activity map[string]any) (*http.Request, []byte) {
+	t.Helper()
+	body, err := json.Marshal(activity)
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, testBase+"/ap/inbox", bytes.NewReader(body))
+	require.NoError(t, httpsig.Sign(req, remoteKeyID, f.remoteKey, body))
+	return req, body
+}
+
+func TestWebFinger(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	wf, err := f.svc.WebFinger(ctx, "acct:owner@ech0.example")
+	require.NoError(t, err)
+	assert.Equal(t, "acct:owner@ech0.example", wf.Subject)
+	assert.Equal(t, testBase+"/ap/actor", wf.Links[0].Href)
+
+	_, err = f.svc.WebFinger(ctx, "acct:someone@ech0.example")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	helpers.SetActivityPub(t, false)
+	_, err = f.svc.WebFinger(ctx, "acct:owner@ech0.example")
+	assert.ErrorIs(t, err, ErrDisabled)
+}
+
+// TestActor_StableKey 校验 actor 公钥首次生成后持久化，后续读取一致。
+func TestActor_StableKey(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	first, err := f.svc.Actor(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, testBase+"/ap/actor#main-key", first.PublicKey.ID)
+	assert.NotEmpty(t, first.PublicKey.PublicKeyPem)
+
+	second, err := f.svc.Actor(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first.PublicKey.PublicKeyPem, second.PublicKey.PublicKeyPem)
+}
+
+func TestNote_HidesPrivateEcho(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	note, err := f.svc.Note(ctx, "echo-public")
+	require.NoError(t, err)
+	assert.Equal(t, testBase+"/ap/notes/echo-public", note.ID)
+	assert.Equal(t, testBase+"/echo/echo-public", note.URL)
+	assert.Contains(t, note.Content, "hello world")
+
+	_, err = f.svc.Note(ctx, "echo-private")
+	assert.ErrorIs(t, err, ErrNotFound)
+	_, err = f.svc.Note(ctx, "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestHandleInbox_FollowAndUndo(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	req, body := f.inbox(t, map[string]any{
+		"id":     remoteActorID + "#follow-1",
+		"type":   "Follow",
+		"actor":  remoteActorID,
+		"object": testBase + "/ap/actor",
+	})
+	require.NoError(t, f.svc.HandleInbox(ctx, req, body))
+
+	followers, _ := f.repo.ListFollowers(ctx)
+	require.Len(t, followers, 1)
+	assert.Equal(t, "alice@remote.example", followers[0].Handle)
+	assert.Equal(t, "https://remote.example/inbox", followers[0].SharedInbox)
+
+	select {
+	case <-f.client.posted:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Accept was not delivered")
+	}
+	f.client.mu.Lock()
+	accept := f.client.requests[0]
+	var sent model.InboundActivity
+	require.NoError(t, json.Unmarshal(f.client.bodies[0], &sent))
+	f.client.mu.Unlock()
+	assert.Equal(t, remoteActorID+"/inbox", accept.URL.String())
+	assert.NotEmpty(t, accept.Header.Get("Signature"))
+	assert.Equal(t, model.TypeAccept, sent.Type)
+
+	req, body = f.inbox(t, map[string]any{
+		"id":    remoteActorID + "#undo-1",
+		"type":  "Undo",
+		"actor": remoteActorID,
+		"object": map[string]any{
+			"type":   "Follow",
+			"actor":  remoteActorID,
+			"object": testBase + "/ap/actor",
+		},
+	})
+	require.NoError(t, f.svc.HandleInbox(ctx, req, body))
+	total, _ := f.repo.CountFollowers(ctx)
+	assert.Zero(t, total)
+}
+
+// TestHandleInbox_RejectsForgery 覆盖：签名者与 actor 不符、正文被篡改。
+func TestHandleInbox_RejectsForgery(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	req, body := f.inbox(t, map[string]any{
+		"type":   "Follow",
+		"actor":  "https://other.example/users/mallory",
+		"object": testBase + "/ap/actor",
+	})
+	assert.ErrorIs(t, f.svc.HandleInbox(ctx, req, body), ErrUnauthorized)
+
+	req, body = f.inbox(t, map[string]any{
+		"type":   "Like",
+		"actor":  remoteActorID,
+		"object": testBase + "/ap/notes/echo-public",
+	})
+	tampered := bytes.Replace(body, []byte("echo-public"), []byte("echo-privat"), 1)
+	assert.ErrorIs(t, f.svc.HandleInbox(ctx, req, tampered), ErrUnauthorized)
+	assert.Empty(t, f.echoRepo.liked)
+}
+
+// docClient 按 URL 返回预置的 ActivityPub 文档，模拟真实的签名者抓取。
+type docClient map[string]any
+
+func (c docClient) Do(req *http.Request) (*http.Response, error) {
+	doc, ok := c[req.URL.String()]
+	if !ok {
+		return &http.Response{StatusCode: http.StatusNotFound, Body: io.NopCloser(bytes.NewReader(nil))}, nil
+	}
+	body, _ := json.Marshal(doc)
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(body))}, nil
+}
+
+func TestHandleInbox_RejectsCrossOriginKeyDocument(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+	pem, err := httpsig.EncodePublicKey(&f.remoteKey.PublicKey)
+	require.NoError(t, err)
+
+	const evilKeyID = "https://evil.example/key#main-key"
+	f.svc.federator.http.client = docClient{
+		// evil.example 挂出一份自称是 remote.example 上 alice 的 actor 文档。
+		"https://evil.example/key": map[string]any{
+			"id":        remoteActorID,
+			"inbox":     remoteActorID + "/inbox",
+			"publicKey": map[string]any{"id": evilKeyID, "owner": remoteActorID, "publicKeyPem": pem},
+		},
+		// 独立公钥文档把 owner 指向别的实例。
+		"https://evil.example/standalone": map[string]any{
+			"id":           "https://evil.example/standalone",
+			"owner":        remoteActorID,
+			"publicKeyPem": pem,
+		},
+		remoteActorID: map[string]any{
+			"id":        remoteActorID,
+			"inbox":     remoteActorID + "/inbox",
+			"publicKey": map[string]any{"id": remoteKeyID, "owner": remoteActorID, "publicKeyPem": pem},
+		},
+	}
+	f.svc.fetcher = f.svc.federator.http.fetchSigner
+
+	for _, keyID := range []string{evilKeyID, "https://evil.example/standalone"} {
+		body, err := json.Marshal(map[string]any{
+			"type":   "Follow",
+			"actor":  remoteActorID,
+			"object": testBase + "/ap/actor",
+		})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, testBase+"/ap/inbox", bytes.NewReader(body))
+		require.NoError(t, httpsig.Sign(req, keyID, f.remoteKey, body))
+		assert.ErrorIs(t, f.svc.HandleInbox(ctx, req, body), ErrUnauthorized, keyID)
+	}
+	assert.Empty(t, f.repo.followers)
+
+	// 同源的 actor 文档照常通过。
+	req, body := f.inbox(t, map[string]any{
+		"type":   "Like",
+		"actor":  remoteActorID,
+		"object": testBase + "/ap/notes/echo-public",
+	})
+	require.NoError(t, f.svc.HandleInbox(ctx, req, body))
+	assert.Equal(t, []string{"echo-public"}, f.echoRepo.liked)
+}
+
+func TestHandleInbox_LikeCountsOnce(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	for range 2 {
+		req, body := f.inbox(t, map[string]any{
+			"type":   "Like",
+			"actor":  remoteActorID,
+			"object": testBase + "/ap/notes/echo-public",
+		})
+		require.NoError(t, f.svc.HandleInbox(ctx, req, body))
+	}
+	req, body := f.inbox(t, map[string]any{
+		"type":   "Like",
+		"actor":  remoteActorID,
+		"object": testBase + "/ap/notes/echo-private",
+	})
+	require.NoError(t, f.svc.HandleInbox(ctx, req, body))
+
+	assert.Equal(t, []string{"echo-public"}, f.echoRepo.liked)
+}
+
+func TestHandleInbox_ReplyBecomesComment(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	f.comments.EXPECT().
+		CreateFederatedComment(mock.Anything, mock.MatchedBy(func(dto *commentModel.CreateFederatedCommentDto) bool {
+			return dto.EchoID == "echo-public" &&
+				dto.RemoteID == "https://remote.example/notes/1" &&
+				dto.Nickname == "Alice" &&
+				dto.Content == "Nice post\nindeed"
+		})).
+		Return(commentModel.CreateCommentResult{ID: "c1", Status: commentModel.StatusApproved}, nil).
+		Once()
+
+	req, body := f.inbox(t, map[string]any{
+		"type":  "Create",
+		"actor": remoteActorID,
+		"object": map[string]any{
+			"id":        "https://remote.example/notes/1",
+			"type":      "Note",
+			"inReplyTo": testBase + "/ap/notes/echo-public",
+			"content":   "<p>Nice post<br>indeed</p>",
+		},
+	})
+	require.NoError(t, f.svc.HandleInbox(ctx, req, body))
+
+	// 冒充其他实例的 Note 被拒绝。
+	req, body = f.inbox(t, map[string]any{
+		"type":  "Create",
+		"actor": remoteActorID,
+		"object": map[string]any{
+			"id":        "https://other.example/notes/2",
+			"type":      "Note",
+			"inReplyTo": testBase + "/ap/notes/echo-public",
+			"content":   "spoofed",
+		},
+	})
+	assert.ErrorIs(t, f.svc.HandleInbox(ctx, req, body), ErrUnauthorized)
+}
+
+// TestFederator_PublishEcho 校验按共享收件箱去重投递，私密 Echo 不投递。
+func TestFederator_PublishEcho(t *testing.T) {
+	f := newFixture(t)
+	ctx := context.Background()
+
+	for _, follower := range []model.Follower{
+		{ActorID: "https://a.example/users/1", Inbox: "https://a.example/users/1/inbox", SharedInbox: "https://a.example/inbox"},
+		{ActorID: "https://a.example/users/2", Inbox: "https://a.example/users/2/inbox", SharedInbox: "https://a.example/inbox"},
+		{ActorID: "https://b.example/users/3", Inbox: "https://b.example/users/3/inbox"},
+	} {
+		require.NoError(t, f.repo.UpsertFollower(ctx, &follower))
+	}
+
+	require.NoError(t, f.svc.federator.PublishEcho(ctx, f.echoRepo.echos["echo-private"]))
+	assert.Empty(t, f.client.requests)
+
+	require.NoError(t, f.svc.federator.PublishEcho(ctx, f.echoRepo.echos["echo-public"]))
+	targets := map[string]bool{}
+	for i, req := range f.client.requests {
+		targets[req.URL.String()] = true
+		assert.Equal(t, httpsig.Digest(f.client.bodies[i]), req.Header.Get("Digest"))
+		var sent model.InboundActivity
+		require.NoError(t, json.Unmarshal(f.client.bodies[i], &sent))
+		assert.Equal(t, model.TypeCreate, sent.Type)
+	}
+	assert.Equal(t, map[string]bool{"https://a.example/inbox": true, "https://b.example/users/3/inbox": true}, targets)
+}
+
+func TestHTMLToText(t *testing.T) {
+	assert.Equal(t, "a\n\nb", htmlToText("<p>a</p><p>b</p>"))
+	assert.Equal(t, "@bob hi &", htmlToText(`<p><span class="h-card"><a href="x">@bob</a></span> hi &amp;</p>`))
+}
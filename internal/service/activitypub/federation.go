@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	httpsig "github.com/lin-snow/ech0/internal/util/httpsig"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxDocumentBytes 限制抓取远端文档与收件箱请求体的大小。
+	maxDocumentBytes = 1 << 20
+)
+
+var (
+	// ErrDisabled 表示未开启联邦（ECH0_ACTIVITYPUB_ENABLED）或未配置可用的站点地址。
+	ErrDisabled     = errors.New("activitypub: federation disabled")
+	ErrNotFound     = errors.New("activitypub: not found")
+	ErrBadRequest   = errors.New("activitypub: malformed activity")
+	ErrUnauthorized = errors.New("activitypub: signature verification failed")
+)
+
+// HTTPDoer 是发出请求所需的最小客户端能力。
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// site 是本站对外的地址集合，均由系统设置中的站点地址派生。
+type site struct {
+	base   string
+	host   string
+	system settingModel.SystemSetting
+}
+
+func (s site) actorID() string             { return s.base + "/ap/actor" }
+func (s site) keyID() string               { return s.actorID() + "#main-key" }
+func (s site) inbox() string               { return s.base + "/ap/inbox" }
+func (s site) outbox() string              { return s.base + "/ap/outbox" }
+func (s site) followers() string           { return s.base + "/ap/followers" }
+func (s site) noteID(echoID string) string { return s.base + "/ap/notes/" + echoID }
+func (s site) echoURL(echoID string) string {
+	return s.base + "/echo/" + echoID
+}
+
+// absolute 把站内相对地址（如本地存储的 /api/files/...）补成绝对地址。
+func (s site) absolute(raw string) string {
+	if strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//") {
+		return s.base + raw
+	}
+	return raw
+}
+
+// echoIDFromObject 把指向本站 Note 或 Echo 页面的地址还原成 Echo ID；不是本站对象时返回空串。
+func (s site) echoIDFromObject(object string) string {
+	for _, prefix := range []string{s.base + "/ap/notes/", s.base + "/echo/"} {
+		if id, ok := strings.CutPrefix(object, prefix); ok && id != "" && !strings.ContainsAny(id, "/?#") {
+			return id
+		}
+	}
+	return ""
+}
+
+// loadSite 读取站点地址。联邦未开启、或站点地址不是绝对 http(s) 地址时返回 ErrDisabled：
+// actor 与 Note 的 ID 一经发出就被远端长期引用，不能从请求 Host 临时拼凑。
+func loadSite(ctx context.Context, kv kvstore.Store) (site, error) {
+	if !config.Config().Federation.ActivityPub {
+		return site{}, ErrDisabled
+	}
+	system, err := coreSetting.Get(ctx, kv, coreSetting.System)
+	if err != nil {
+		return site{}, err
+	}
+	base := strings.TrimRight(strings.TrimSpace(system.ServerURL), "/")
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return site{}, ErrDisabled
+	}
+	return site{base: base, host: parsed.Host, system: system}, nil
+}
+
+// keyring 管理站长 actor 的 RSA 密钥：首次使用时生成并写入键值存储，之后每次从存储读出，
+// 使 Web 与事件投递两侧（各自的 DI 注入器）始终用同一把密钥。
+type keyring struct {
+	kv kvstore.Store
+	mu sync.Mutex
+}
+
+func (k *keyring) privateKey(ctx context.Context) (*rsa.PrivateKey, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	raw, err := k.kv.Get(ctx, model.ActorKeySettingKey)
+	if err == nil {
+		return httpsig.DecodePrivateKey(raw)
+	}
+	if !errors.Is(err, kvstore.ErrNotFound) {
+		return nil, err
+	}
+	key, err := httpsig.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := httpsig.EncodePrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := k.kv.Set(ctx, model.ActorKeySettingKey, encoded); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (k *keyring) publicKeyPEM(ctx context.Context) (string, error) {
+	key, err := k.privateKey(ctx)
+	if err != nil {
+		return "", err
+	}
+	return httpsig.EncodePublicKey(&key.PublicKey)
+}
+
+// remoteActor 是解析远端 actor（或独立公钥文档）时关心的字段。url 等字段在不同实现里
+// 可能是字符串、对象或数组，统一延迟解析。
+type remoteActor struct {
+	ID                string          `json:"id"`
+	Type              string          `json:"type"`
+	PreferredUsername string          `json:"preferredUsername"`
+	Name              string          `json:"name"`
+	URL               json.RawMessage `json:"url"`
+	Inbox             string          `json:"inbox"`
+	Endpoints         struct {
+		SharedInbox string `json:"sharedInbox"`
+	} `json:"endpoints"`
+	PublicKey model.PublicKey `json:"publicKey"`
+
+	// 独立公钥文档（keyId 不是 actor#fragment 形式时）直接在顶层给出 owner / publicKeyPem。
+	Owner        string `json:"owner"`
+	PublicKeyPem string `json:"publicKeyPem"`
+}
+
+// handle 返回 user@host 形式的展示名。
+func (a remoteActor) handle() string {
+	parsed, err := url.Parse(a.ID)
+	if err != nil || a.PreferredUsername == "" {
+		return a.ID
+	}
+	return a.PreferredUsername + "@" + parsed.Host
+}
+
+// profileURL 返回 actor 的个人主页地址，缺省时退回 actor ID。
+func (a remoteActor) profileURL() string {
+	if u := linkHref(a.URL); u != "" {
+		return u
+	}
+	return a.ID
+}
+
+// linkHref 从 ActivityStreams 的 url 字段取出第一个地址（字符串 / Link 对象 / 数组）。
+func linkHref(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var link struct {
+		Href string `json:"href"`
+	}
+	if json.Unmarshal(raw, &link) == nil && link.Href != "" {
+		return link.Href
+	}
+	var list []json.RawMessage
+	if json.Unmarshal(raw, &list) == nil {
+		for _, item := range list {
+			if href := linkHref(item); href != "" {
+				return href
+			}
+		}
+	}
+	return ""
+}
+
+// objectID 从活动的 object 字段取出对象 ID（字符串或内嵌对象的 id）。
+func objectID(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	var obj struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(raw, &obj) == nil {
+		return obj.ID
+	}
+	return ""
+}
+
+// signedClient 负责以站长 actor 身份发出签名请求（抓取远端文档与投递活动）。
+type signedClient struct {
+	keys   *keyring
+	client HTTPDoer
+}
+
+// fetch 以签名 GET 抓取远端 ActivityPub 文档（兼容开启了 authorized fetch 的实例）。
+func (c *signedClient) fetch(ctx context.Context, st site, rawURL string, out any) error {
+	if err := egress.Validate(rawURL); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", model.ContentType+", "+model.LDContentType)
+	key, err := c.keys.privateKey(ctx)
+	if err != nil {
+		return err
+	}
+	if err := httpsig.Sign(req, st.keyID(), key, nil); err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("fetch %s: status %d", rawURL, resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, maxDocumentBytes)).Decode(out)
+}
+
+// post 以签名 POST 把活动投递到远端收件箱。
+func (c *signedClient) post(ctx context.Context, st site, inbox string, body []byte) error {
+	if err := egress.Validate(inbox); err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, inbox, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", model.ContentType)
+	req.Header.Set("Accept", model.ContentType)
+	key, err := c.keys.privateKey(ctx)
+	if err != nil {
+		return err
+	}
+	if err := httpsig.Sign(req, st.keyID(), key, body); err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDocumentBytes))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("deliver to %s: status %d", inbox, resp.StatusCode)
+	}
+	return nil
+}
+
+// fetchSigner 按 keyId 取远端 actor：keyId 通常是 actor#main-key，也可能指向独立公钥文档，
+// 此时再按 owner 抓取 actor。文档只能为自己所在的实例作证：actor（及独立公钥文档）必须与 keyId
+// 同源，且公钥的 owner 就是该 actor，否则任何实例都能挂一份「自称是别人」的文档来冒充其他用户。
+func (c *signedClient) fetchSigner(ctx context.Context, st site, keyID string) (remoteActor, error) {
+	docURL, _, _ := strings.Cut(keyID, "#")
+	var doc remoteActor
+	if err := c.fetch(ctx, st, docURL, &doc); err != nil {
+		return remoteActor{}, err
+	}
+	if !sameOrigin(doc.ID, docURL) {
+		return remoteActor{}, fmt.Errorf("%w: document %s claims foreign id %s", ErrUnauthorized, docURL, doc.ID)
+	}
+	if doc.Inbox == "" && doc.Owner != "" {
+		if !sameOrigin(doc.Owner, docURL) {
+			return remoteActor{}, fmt.Errorf("%w: key %s claims foreign owner %s", ErrUnauthorized, keyID, doc.Owner)
+		}
+		var owner remoteActor
+		if err := c.fetch(ctx, st, doc.Owner, &owner); err != nil {
+			return remoteActor{}, err
+		}
+		if owner.ID != doc.Owner || !sameOrigin(owner.ID, docURL) {
+			return remoteActor{}, fmt.Errorf("%w: owner document of %s has id %s", ErrUnauthorized, keyID, owner.ID)
+		}
+		doc = owner
+	}
+	if doc.ID == "" || doc.Inbox == "" || doc.PublicKey.ID != keyID || doc.PublicKey.Owner != doc.ID {
+		return remoteActor{}, fmt.Errorf("%w: key %s does not belong to a usable actor", ErrUnauthorized, keyID)
+	}
+	return doc, nil
+}
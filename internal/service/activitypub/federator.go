@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	deliveryAttempts = 3
+	deliveryBackoff  = time.Second
+)
+
+// Federator 以站长 actor 身份向关注者投递活动。它只依赖关注者表与键值存储，
+// 因此可以单独装配进事件订阅侧，不必拉起整套 Echo / 评论服务。
+type Federator struct {
+	repo Repository
+	kv   kvstore.Store
+	http *signedClient
+}
+
+var _ Publisher = (*Federator)(nil)
+
+func NewFederator(repo Repository, durableKV kvstore.Store) *Federator {
+	return &Federator{
+		repo: repo,
+		kv:   durableKV,
+		http: &signedClient{
+			keys:   &keyring{kv: durableKV},
+			client: egress.NewClient(egress.Guard(), egress.Timeout(requestTimeout)),
+		},
+	}
+}
+
+// PublishEcho 把新发布的公开 Echo 以 Create(Note) 投递给关注者；私密 / 未发布的 Echo 直接忽略。
+func (f *Federator) PublishEcho(ctx context.Context, echo echoModel.Echo) error {
+	if !isFederated(&echo) {
+		return nil
+	}
+	st, err := loadSite(ctx, f.kv)
+	if err != nil {
+		return ignoreDisabled(err)
+	}
+	return f.deliver(ctx, st, createActivity(st, buildNote(st, &echo)))
+}
+
+// UpdateEcho 投递 Update(Note)。Echo 改为私密后远端无权再看到，改为投递 Delete。
+func (f *Federator) UpdateEcho(ctx context.Context, echo echoModel.Echo) error {
+	if echo.Private {
+		return f.RetractEcho(ctx, echo.ID)
+	}
+	if !isFederated(&echo) {
+		return nil
+	}
+	st, err := loadSite(ctx, f.kv)
+	if err != nil {
+		return ignoreDisabled(err)
+	}
+	note := buildNote(st, &echo)
+	note.Updated = formatTime(time.Now().Unix())
+	activity := wrapActivity(st, model.TypeUpdate, note.ID+"#update-"+note.Updated, note)
+	return f.deliver(ctx, st, activity)
+}
+
+// RetractEcho 投递 Delete(Tombstone)，让远端移除已同步的 Note。
+func (f *Federator) RetractEcho(ctx context.Context, echoID string) error {
+	st, err := loadSite(ctx, f.kv)
+	if err != nil {
+		return ignoreDisabled(err)
+	}
+	noteID := st.noteID(echoID)
+	activity := wrapActivity(st, model.TypeDelete, noteID+"#delete", model.Tombstone{ID: noteID, Type: "Tombstone"})
+	return f.deliver(ctx, st, activity)
+}
+
+// sendTo 把活动投递到单个收件箱（用于 Accept 等只回给对方的活动）。
+func (f *Federator) sendTo(ctx context.Context, st site, inbox string, activity model.Activity) error {
+	body, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+	return egress.Retry(deliveryAttempts, deliveryBackoff, func() error {
+		return f.http.post(ctx, st, inbox, body)
+	})
+}
+
+// deliver 按收件箱去重后逐个投递。单个收件箱失败只记日志，不影响其余关注者。
+func (f *Federator) deliver(ctx context.Context, st site, activity model.Activity) error {
+	followers, err := f.repo.ListFollowers(ctx)
+	if err != nil {
+		return err
+	}
+	seen := make(map[string]struct{}, len(followers))
+	for _, follower := range followers {
+		inbox := follower.DeliveryInbox()
+		if _, ok := seen[inbox]; ok {
+			continue
+		}
+		seen[inbox] = struct{}{}
+		if err := f.sendTo(ctx, st, inbox, activity); err != nil {
+			logUtil.GetLogger().Warn("activitypub delivery failed",
+				logUtil.Err(err),
+				slog.String("inbox", inbox),
+				slog.String("activity", activity.Type),
+			)
+		}
+	}
+	return nil
+}
+
+// ignoreDisabled 让未开启联邦时的投递静默成功。
+func ignoreDisabled(err error) error {
+	if errors.Is(err, ErrDisabled) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	httpsig "github.com/lin-snow/ech0/internal/util/httpsig"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"golang.org/x/net/html"
+)
+
+// HandleInbox 校验 HTTP 签名后处理收件箱活动：Follow / Undo(Follow|Like) / Like / Create(回复 Note)。
+// 签名者必须就是活动的 actor；不认识或与本站无关的活动静默接受，避免远端反复重投。
+func (s *ActivityPubService) HandleInbox(ctx context.Context, req *http.Request, body []byte) error {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return err
+	}
+
+	var activity model.InboundActivity
+	if err := json.Unmarshal(body, &activity); err != nil || activity.Type == "" || activity.Actor == "" {
+		return ErrBadRequest
+	}
+
+	var signer remoteActor
+	if _, err := httpsig.Verify(req, body, func(keyID string) (*rsa.PublicKey, error) {
+		actor, err := s.fetcher(ctx, st, keyID)
+		if err != nil {
+			return nil, err
+		}
+		signer = actor
+		return httpsig.DecodePublicKey(actor.PublicKey.PublicKeyPem)
+	}); err != nil {
+		return fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if signer.ID != activity.Actor {
+		return fmt.Errorf("%w: signer %s is not actor %s", ErrUnauthorized, signer.ID, activity.Actor)
+	}
+
+	switch activity.Type {
+	case model.TypeFollow:
+		return s.handleFollow(ctx, st, signer, activity)
+	case model.TypeUndo:
+		return s.handleUndo(ctx, st, activity)
+	case model.TypeLike:
+		return s.handleLike(ctx, st, activity.Actor, objectID(activity.Object))
+	case model.TypeCreate:
+		return s.handleCreate(ctx, st, signer, activity)
+	default:
+		return nil
+	}
+}
+
+func (s *ActivityPubService) handleFollow(
+	ctx context.Context,
+	st site,
+	signer remoteActor,
+	activity model.InboundActivity,
+) error {
+	if objectID(activity.Object) != st.actorID() {
+		return ErrBadRequest
+	}
+	follower := model.Follower{
+		ActorID: signer.ID,
+		Handle:  signer.handle(),
+		Inbox:   signer.Inbox,
+	}
+	if err := egress.Validate(follower.Inbox); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+	if shared := signer.Endpoints.SharedInbox; shared != "" && egress.Validate(shared) == nil {
+		follower.SharedInbox = shared
+	}
+	if err := s.repo.UpsertFollower(ctx, &follower); err != nil {
+		return err
+	}
+
+	// Accept 回给对方的个人收件箱；放到后台，不让远端等待我们的出站请求。
+	accept := model.Activity{
+		Context: model.ActivityStreamsContext,
+		ID:      st.actorID() + "#accept-" + follower.ID,
+		Type:    model.TypeAccept,
+		Actor:   st.actorID(),
+		Object: map[string]string{
+			"id":     activity.ID,
+			"type":   model.TypeFollow,
+			"actor":  activity.Actor,
+			"object": st.actorID(),
+		},
+	}
+	go func() {
+		if err := s.federator.sendTo(context.Background(), st, follower.Inbox, accept); err != nil {
+			logUtil.GetLogger().Warn("activitypub accept delivery failed",
+				logUtil.Err(err),
+				slog.String("actor", follower.ActorID),
+			)
+		}
+	}()
+	return nil
+}
+
+// handleUndo 处理取消关注与取消点赞。取消点赞只删去重记录，不回退 Echo 的点赞数——
+// 本站点赞本身也不可撤销，计数保持单调。
+func (s *ActivityPubService) handleUndo(ctx context.Context, st site, activity model.InboundActivity) error {
+	var inner model.InboundObject
+	if err := json.Unmarshal(activity.Object, &inner); err != nil {
+		return nil
+	}
+	if inner.Actor != "" && inner.Actor != activity.Actor {
+		return ErrUnauthorized
+	}
+	switch inner.Type {
+	case model.TypeFollow:
+		return s.repo.DeleteFollower(ctx, activity.Actor)
+	case model.TypeLike:
+		if echoID := st.echoIDFromObject(objectID(inner.Object)); echoID != "" {
+			return s.repo.DeleteLike(ctx, activity.Actor, echoID)
+		}
+	}
+	return nil
+}
+
+// handleLike 给本站公开 Echo 点赞；同一远端账号重复点赞只计一次。
+func (s *ActivityPubService) handleLike(ctx context.Context, st site, actorID, object string) error {
+	echoID := st.echoIDFromObject(object)
+	if echoID == "" {
+		return nil
+	}
+	if _, err := s.visibleEcho(ctx, echoID); err != nil {
+		return nil
+	}
+	created, err := s.repo.CreateLike(ctx, &model.Like{ActorID: actorID, EchoID: echoID})
+	if err != nil || !created {
+		return err
+	}
+	if err := s.echoRepo.LikeEcho(ctx, echoID); err != nil {
+		return err
+	}
+	s.echoRepo.InvalidateEchoCaches(echoID)
+	return nil
+}
+
+// handleCreate 把回复本站 Echo（或回复已落地的联邦评论）的 Note 落地为评论。
+func (s *ActivityPubService) handleCreate(
+	ctx context.Context,
+	st site,
+	signer remoteActor,
+	activity model.InboundActivity,
+) error {
+	var note model.InboundObject
+	if err := json.Unmarshal(activity.Object, &note); err != nil || note.Type != "Note" {
+		return nil
+	}
+	if note.ID == "" || note.InReplyTo == "" {
+		return nil
+	}
+	if !sameOrigin(note.ID, signer.ID) {
+		return ErrUnauthorized
+	}
+
+	dto := commentModel.CreateFederatedCommentDto{
+		RemoteID: note.ID,
+		Nickname: signer.Name,
+		Website:  signer.profileURL(),
+		Content:  htmlToText(note.Content),
+	}
+	if dto.Nickname == "" {
+		dto.Nickname = signer.PreferredUsername
+	}
+	if echoID := st.echoIDFromObject(note.InReplyTo); echoID != "" {
+		dto.EchoID = echoID
+	} else {
+		parent, err := s.commentSvc.FindFederatedComment(ctx, note.InReplyTo)
+		if err != nil {
+			return err
+		}
+		if parent.ID == "" {
+			return nil
+		}
+		dto.EchoID = parent.EchoID
+		dto.ParentRemoteID = parent.RemoteID
+	}
+	if _, err := s.visibleEcho(ctx, dto.EchoID); err != nil {
+		return nil
+	}
+	if dto.Content == "" {
+		return nil
+	}
+	if _, err := s.commentSvc.CreateFederatedComment(ctx, &dto); err != nil {
+		// 评论关闭等业务拒绝不应让远端重投，只记日志。
+		logUtil.GetLogger().Info("activitypub reply not stored",
+			logUtil.Err(err),
+			slog.String("remote_id", note.ID),
+		)
+	}
+	return nil
+}
+
+// sameOrigin 判断两个地址是否同属一个实例，防止 actor 冒充其他实例的对象。
+func sameOrigin(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	return errA == nil && errB == nil && ua.Host != "" &&
+		strings.EqualFold(ua.Scheme, ub.Scheme) && strings.EqualFold(ua.Host, ub.Host)
+}
+
+// htmlToText 把远端 Note 的 HTML 正文转为纯文本：段落与换行保留为换行，其余标签只取文字。
+func htmlToText(content string) string {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return strings.TrimSpace(content)
+	}
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+		case n.Type == html.ElementNode && n.Data == "br":
+			b.WriteString("\n")
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && n.Data == "p" {
+			b.WriteString("\n\n")
+		}
+	}
+	walk(doc)
+	return strings.TrimSpace(b.String())
+}
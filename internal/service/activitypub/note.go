@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"strings"
+	"time"
+
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
+)
+
+// isFederated 判断 Echo 是否对联邦可见：公开、已发布且不在回收站。
+func isFederated(echo *echoModel.Echo) bool {
+	return echo != nil && !echo.Private && echo.IsPublished() && !echo.IsTrashed()
+}
+
+func formatTime(unix int64) string {
+	return time.Unix(unix, 0).UTC().Format(time.RFC3339)
+}
+
+// buildNote 把 Echo 转成 Note：Markdown 渲染为 HTML，附件以绝对地址的 Document 给出，标签转为 Hashtag（前端没有标签页，不给 href）。
+func buildNote(st site, echo *echoModel.Echo) model.Note {
+	note := model.Note{
+		ID:           st.noteID(echo.ID),
+		Type:         "Note",
+		AttributedTo: st.actorID(),
+		Content:      string(mdUtil.MdToHTML([]byte(echo.Content))),
+		URL:          st.echoURL(echo.ID),
+		Published:    formatTime(echo.CreatedAt),
+		To:           []string{model.PublicAddress},
+		Cc:           []string{st.followers()},
+	}
+	for _, ef := range echo.EchoFiles {
+		if ef.File.URL == "" {
+			continue
+		}
+		note.Attachment = append(note.Attachment, model.Attachment{
+			Type:      "Document",
+			MediaType: ef.File.ContentType,
+			URL:       st.absolute(ef.File.URL),
+			Name:      ef.File.Name,
+		})
+	}
+	for _, tag := range echo.Tags {
+		name := strings.TrimPrefix(tag.Name, "#")
+		if name == "" {
+			continue
+		}
+		note.Tag = append(note.Tag, model.Tag{
+			Type: "Hashtag",
+			Name: "#" + name,
+		})
+	}
+	return note
+}
+
+// wrapActivity 生成由站长 actor 发出、面向公开与关注者的活动。
+func wrapActivity(st site, activityType, id string, object any) model.Activity {
+	return model.Activity{
+		Context:   model.ActivityStreamsContext,
+		ID:        id,
+		Type:      activityType,
+		Actor:     st.actorID(),
+		Published: formatTime(time.Now().Unix()),
+		To:        []string{model.PublicAddress},
+		Cc:        []string{st.followers()},
+		Object:    object,
+	}
+}
+
+// createActivity 是 Note 的 Create 包装；ID 固定，以便 outbox 与投递引用同一活动。
+func createActivity(st site, note model.Note) model.Activity {
+	activity := wrapActivity(st, model.TypeCreate, note.ID+"/activity", note)
+	activity.Published = note.Published
+	return activity
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"net/http"
+
+	model "github.com/lin-snow/ech0/internal/model/activitypub"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	commonService "github.com/lin-snow/ech0/internal/service/common"
+)
+
+// Service 提供站长 actor 的对外文档（WebFinger / actor / outbox / followers / Note）与收件箱处理。
+// 未开启联邦或未配置站点地址时，各方法返回 ErrDisabled。
+type Service interface {
+	WebFinger(ctx context.Context, resource string) (model.WebFinger, error)
+	Actor(ctx context.Context) (model.Actor, error)
+	Outbox(ctx context.Context, page int) (any, error)
+	Followers(ctx context.Context) (model.OrderedCollection, error)
+	Note(ctx context.Context, echoID string) (model.Note, error)
+	HandleInbox(ctx context.Context, req *http.Request, body []byte) error
+}
+
+// Publisher 把 Echo 的发布 / 修改 / 撤回投递给全部关注者，由事件订阅者驱动。
+type Publisher interface {
+	PublishEcho(ctx context.Context, echo echoModel.Echo) error
+	UpdateEcho(ctx context.Context, echo echoModel.Echo) error
+	RetractEcho(ctx context.Context, echoID string) error
+}
+
+type Repository interface {
+	UpsertFollower(ctx context.Context, follower *model.Follower) error
+	DeleteFollower(ctx context.Context, actorID string) error
+	ListFollowers(ctx context.Context) ([]model.Follower, error)
+	CountFollowers(ctx context.Context) (int64, error)
+	CreateLike(ctx context.Context, like *model.Like) (bool, error)
+	DeleteLike(ctx context.Context, actorID, echoID string) error
+}
+
+type EchoRepository interface {
+	GetEchosById(ctx context.Context, id string) (*echoModel.Echo, error)
+	GetEchosByPage(page, pageSize int, search string, showPrivate bool) ([]echoModel.Echo, int64)
+	LikeEcho(ctx context.Context, id string) error
+	InvalidateEchoCaches(echoIDs ...string)
+}
+
+type (
+	CommentService = commentService.Service
+	CommonService  = commonService.Service
+)
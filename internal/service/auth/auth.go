@@ -171,7 +171,7 @@ func (authService *AuthService) BindOAuth(
 		return "", err
 	}
 
-	if !user.IsAdmin {
+	if !user.Can(model.PermSystemManage) {
 		return "", bindingPermissionError(provider)
 	}
 
@@ -996,7 +996,7 @@ func (authService *AuthService) GetOAuthInfo(
 		return oauthInfo, err
 	}
 
-	if !user.IsAdmin {
+	if !user.Can(model.PermSystemManage) {
 		return oauthInfo, bindingPermissionError(provider)
 	}
 
@@ -29,6 +29,8 @@ import (
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/spam"
+	"github.com/lin-snow/ech0/internal/transaction"
 	jwtUtil "github.com/lin-snow/ech0/internal/util/jwt"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
@@ -39,39 +41,57 @@ const (
 	minSubmitMS        int64 = 2000
 	maxFormTokenHours  int64 = 24
 	maxCommentRunes          = 200
+	maxNicknameRunes         = 100 // 与 Comment.Nickname 列宽一致
 	recentDuplicateSec int64 = 90
 
 	integrationShortWindow int64 = 60
 	integrationLongWindow  int64 = 3600
 	integrationShortLimit  int64 = 5
 	integrationLongLimit   int64 = 30
+
+	reactionShortWindow int64 = 60
+	reactionLongWindow  int64 = 3600
+	reactionShortLimit  int64 = 10
+	reactionLongLimit   int64 = 60
+
+	// purgeBatchSize 是回收站自动清理每批取出的评论数。
+	purgeBatchSize = 500
 )
 
 type CommentService struct {
+	transactor    transaction.Transactor
 	commonService CommonService
 	repo          Repository
+	echoRepo      EchoRepository
 	durableKV     kvstore.Store
 	bus           *busen.Bus
 	mailer        Mailer
+	spam          *spam.Filter
 }
 
 func NewCommentService(
+	tx transaction.Transactor,
 	commonService CommonService,
 	repo Repository,
+	echoRepo EchoRepository,
 	durableKV kvstore.Store,
 	busProvider func() *busen.Bus,
 	mailer Mailer,
 ) *CommentService {
 	return &CommentService{
+		transactor:    tx,
 		commonService: commonService,
 		repo:          repo,
+		echoRepo:      echoRepo,
 		durableKV:     durableKV,
 		bus:           busProvider(),
 		mailer:        mailer,
+		spam:          spam.NewFilter(repo),
 	}
 }
 
-func (s *CommentService) GetFormMeta(ctx context.Context, clientIP, apiBaseURL string) (model.FormMeta, error) {
+// GetFormMeta 返回评论表单所需的元信息；echoID 非空时附带该 Echo 的评论策略。
+func (s *CommentService) GetFormMeta(ctx context.Context, clientIP, apiBaseURL, echoID string) (model.FormMeta, error) {
 	setting, err := s.GetSystemSetting(ctx)
 	if err != nil {
 		return model.FormMeta{}, err
@@ -82,13 +102,21 @@ func (s *CommentService) GetFormMeta(ctx context.Context, clientIP, apiBaseURL s
 		strings.TrimSpace(captchaCfg.Secret()) != ""
 	issuedAt := time.Now().UnixMilli()
 	token := s.signFormToken(clientIP, issuedAt)
-	return model.FormMeta{
+	meta := model.FormMeta{
 		FormToken:          token,
 		MinSubmitMs:        minSubmitMS,
 		CaptchaEnabled:     captchaReady,
 		CaptchaAPIEndpoint: captchaAPIEndpoint,
 		EnableComment:      setting.EnableComment,
-	}, nil
+	}
+	if echoID = strings.TrimSpace(echoID); echoID != "" {
+		policy, err := s.resolveEchoPolicy(ctx, setting, echoID)
+		if err != nil {
+			return model.FormMeta{}, err
+		}
+		policy.applyTo(&meta)
+	}
+	return meta, nil
 }
 
 func (s *CommentService) CreateComment(
@@ -106,7 +134,8 @@ func (s *CommentService) CreateComment(
 			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "提交过快或表单已失效")
 	}
 
-	setting, err := s.GetSystemSetting(ctx)
+	// 垃圾检测要用到 Akismet Key，这里读未脱敏的设置。
+	setting, err := s.getSystemSettingRaw(ctx)
 	if err != nil {
 		return model.CreateCommentResult{}, err
 	}
@@ -144,13 +173,25 @@ func (s *CommentService) CreateComment(
 			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论内容不能超过200字")
 	}
 
-	parentID, err := s.resolveParentID(ctx, comment.EchoID, dto.ParentID)
+	policy, err := s.resolveEchoPolicy(ctx, setting, comment.EchoID)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	// 读者之外的成员不受 Echo 评论策略限制，评论直接通过；读者与访客一样走策略与审核流程。
+	member := validUser && user.EffectiveRole() != userModel.RoleReader
+	if !member {
+		if err := policy.admit(validUser); err != nil {
+			return model.CreateCommentResult{}, err
+		}
+	}
+
+	parent, err := s.resolveParent(ctx, comment.EchoID, dto.ParentID)
 	if err != nil {
 		return model.CreateCommentResult{}, err
 	}
-	comment.ParentID = parentID
+	comment.AttachTo(parent)
 
-	if validUser && (user.IsAdmin || user.IsOwner) {
+	if member {
 		comment.Source = model.SourceSystem
 		comment.Nickname = user.Username
 		// 内部成员评论允许邮箱为空，不再自动填充占位邮箱。
@@ -180,7 +221,7 @@ func (s *CommentService) CreateComment(
 		comment.Email = email
 		comment.Website = website
 
-		if !setting.RequireApproval {
+		if !policy.requireApproval {
 			comment.Status = model.StatusApproved
 		}
 
@@ -206,10 +247,13 @@ func (s *CommentService) CreateComment(
 			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "请勿重复提交相同评论")
 	}
 
-	if err := s.repo.CreateComment(ctx, &comment); err != nil {
+	if comment.Source == model.SourceGuest {
+		s.checkSpam(ctx, setting.Spam, clientIP, &comment)
+	}
+
+	if err := s.createComment(ctx, &comment); err != nil {
 		return model.CreateCommentResult{}, err
 	}
-	s.emitCommentCreated(ctx, comment)
 	// 站长/管理员自己发的评论（SourceSystem），收件人就是站长本人，无需再给自己发「有新评论」提醒；
 	// 但若是回复访客，仍要走 notifyReplyTargetAsync 通知被回复者。
 	if shouldNotifyOwnerOnCreate(comment.Source) {
@@ -222,10 +266,85 @@ func (s *CommentService) CreateComment(
 	}, nil
 }
 
-// resolveParentID 校验回复目标并返回其真实父评论 ID。
-// 不做压平——前端按祖先链把回复归到「楼」下渲染，并据此展示「回复 @某人」。
+// checkSpam 用设置的后端检测访客评论，把得分与结论记在评论上。判定为垃圾时按设置直接拒绝
+// 或转入待审核；后端出错时不拦截评论，但保守地转入待审核。
+func (s *CommentService) checkSpam(ctx context.Context, setting model.SpamSetting, clientIP string, comment *model.Comment) {
+	if !spam.Enabled(setting) {
+		return
+	}
+	serverURL := s.resolveServerURL(ctx)
+	in := spamInput(*comment)
+	in.BlogURL = serverURL
+	in.Permalink = buildEchoLink(serverURL, comment.EchoID)
+	in.ClientIP = clientIP
+	in.UserAgent = comment.UserAgent
+	result, err := s.spam.Check(ctx, setting, in)
+	if err != nil {
+		logUtil.GetLogger().Warn("comment spam check failed",
+			slog.String("provider", string(setting.Provider)),
+			slog.String("error", err.Error()),
+		)
+		comment.Status = model.StatusPending
+		return
+	}
+	comment.SpamBackend = result.Backend
+	comment.SpamScore = result.Score
+	comment.SpamVerdict = result.Verdict
+	if result.Verdict == model.SpamVerdictSpam {
+		comment.Status = model.StatusPending
+		if setting.RejectSpam {
+			comment.Status = model.StatusRejected
+		}
+	}
+}
+
+// trainSpam 把管理员的审核结论计入内置分类器：通过记为正常样本，拒绝记为垃圾样本。
+// 结论改变时先撤销旧样本，同一结论不重复计数。只训练访客评论，失败只记日志。
+func (s *CommentService) trainSpam(ctx context.Context, comment model.Comment, status model.Status) {
+	var label model.SpamVerdict
+	switch status {
+	case model.StatusApproved:
+		label = model.SpamVerdictHam
+	case model.StatusRejected:
+		label = model.SpamVerdictSpam
+	default:
+		return
+	}
+	if comment.ID == "" || comment.Source != model.SourceGuest || comment.SpamLabel == label {
+		return
+	}
+	in := spamInput(comment)
+	err := func() error {
+		if comment.SpamLabel != "" {
+			if err := s.spam.Train(ctx, in, comment.SpamLabel == model.SpamVerdictSpam, -1); err != nil {
+				return err
+			}
+		}
+		if err := s.spam.Train(ctx, in, label == model.SpamVerdictSpam, 1); err != nil {
+			return err
+		}
+		return s.repo.UpdateSpamLabel(ctx, comment.ID, label)
+	}()
+	if err != nil {
+		logUtil.GetLogger().Warn("comment spam training failed",
+			slog.String("comment_id", comment.ID),
+			slog.String("error", err.Error()),
+		)
+	}
+}
+
+func spamInput(comment model.Comment) spam.Input {
+	return spam.Input{
+		Nickname: comment.Nickname,
+		Email:    comment.Email,
+		Website:  comment.Website,
+		Content:  comment.Content,
+	}
+}
+
+// resolveParent 校验回复目标并返回父评论，新评论据其物化路径挂到任意深度。
 // rawParentID 为空表示顶层评论，返回 nil。
-func (s *CommentService) resolveParentID(ctx context.Context, echoID, rawParentID string) (*string, error) {
+func (s *CommentService) resolveParent(ctx context.Context, echoID, rawParentID string) (*model.Comment, error) {
 	parentID := strings.TrimSpace(rawParentID)
 	if parentID == "" {
 		return nil, nil
@@ -234,10 +353,10 @@ func (s *CommentService) resolveParentID(ctx context.Context, echoID, rawParentI
 	if err != nil || parent.ID == "" || parent.EchoID != echoID {
 		return nil, commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "回复的评论不存在")
 	}
-	if parent.Status != model.StatusApproved {
+	if parent.DeletedAt > 0 || parent.Status != model.StatusApproved {
 		return nil, commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "该评论暂不可回复")
 	}
-	return &parent.ID, nil
+	return &parent, nil
 }
 
 func (s *CommentService) CreateIntegrationComment(
@@ -280,6 +399,15 @@ func (s *CommentService) CreateIntegrationComment(
 			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论内容不能超过200字")
 	}
 
+	// 集成令牌视同已登录：login_only 的 Echo 放行，关闭的 Echo 照样拒绝。
+	policy, err := s.resolveEchoPolicy(ctx, setting, comment.EchoID)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if err := policy.admit(true); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+
 	nickname := strings.TrimSpace(dto.Nickname)
 	if nickname == "" {
 		nickname = "Integration"
@@ -290,7 +418,7 @@ func (s *CommentService) CreateIntegrationComment(
 		comment.UserID = &userID
 	}
 
-	if !setting.RequireApproval {
+	if !policy.requireApproval {
 		comment.Status = model.StatusApproved
 	}
 
@@ -315,7 +443,7 @@ func (s *CommentService) CreateIntegrationComment(
 			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "请勿重复提交相同评论")
 	}
 
-	if err := s.repo.CreateComment(ctx, &comment); err != nil {
+	if err := s.createComment(ctx, &comment); err != nil {
 		return model.CreateCommentResult{}, err
 	}
 
@@ -328,7 +456,6 @@ func (s *CommentService) CreateIntegrationComment(
 		slog.String("metadata", strings.TrimSpace(dto.Metadata)),
 	)
 
-	s.emitCommentCreated(ctx, comment)
 	if shouldNotifyOwnerOnCreate(comment.Source) {
 		s.notifyOwnerAsync(ctx, "created", comment)
 	}
@@ -338,6 +465,175 @@ func (s *CommentService) CreateIntegrationComment(
 	}, nil
 }
 
+// CreateFederatedComment 把 ActivityPub 收件箱收到的回复落地为评论。
+// 签名校验与目标 Echo 的可见性由调用方负责；这里沿用评论开关、Echo 评论策略与字数规则，
+// 远端用户视同未登录，并按 RemoteID 去重——远端重复投递时直接返回已有评论。
+func (s *CommentService) CreateFederatedComment(
+	ctx context.Context,
+	dto *model.CreateFederatedCommentDto,
+) (model.CreateCommentResult, error) {
+	setting, err := s.GetSystemSetting(ctx)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if !setting.EnableComment {
+		return model.CreateCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论功能未启用")
+	}
+
+	comment := model.Comment{
+		EchoID:   strings.TrimSpace(dto.EchoID),
+		RemoteID: strings.TrimSpace(dto.RemoteID),
+		Content:  strings.TrimSpace(dto.Content),
+		Nickname: strings.TrimSpace(dto.Nickname),
+		Status:   model.StatusPending,
+		Source:   model.SourceFediverse,
+	}
+	if comment.EchoID == "" || comment.RemoteID == "" || comment.Content == "" {
+		return model.CreateCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论内容不能为空")
+	}
+	existing, err := s.repo.FindByRemoteID(ctx, comment.RemoteID)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if existing.ID != "" {
+		return model.CreateCommentResult{ID: existing.ID, Status: existing.Status}, nil
+	}
+	policy, err := s.resolveEchoPolicy(ctx, setting, comment.EchoID)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if err := policy.admit(false); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+
+	// 远端实例的字数上限与本站不同，超长回复截断而不是整条丢弃；原文经 Website 可达。
+	if runes := []rune(comment.Content); len(runes) > maxCommentRunes {
+		comment.Content = string(runes[:maxCommentRunes-1]) + "…"
+	}
+	if comment.Nickname == "" {
+		comment.Nickname = "Fediverse"
+	}
+	if website := strings.TrimSpace(dto.Website); website != "" {
+		if parsed, err := url.ParseRequestURI(website); err == nil && parsed.Host != "" &&
+			(parsed.Scheme == "https" || parsed.Scheme == "http") {
+			comment.Website = website
+		}
+	}
+	// 回复链只在能找到、且可回复的父评论时保留，否则退化为顶层评论。
+	if parentRemoteID := strings.TrimSpace(dto.ParentRemoteID); parentRemoteID != "" {
+		parent, err := s.repo.FindByRemoteID(ctx, parentRemoteID)
+		if err != nil {
+			return model.CreateCommentResult{}, err
+		}
+		if parent.ID != "" && parent.EchoID == comment.EchoID &&
+			parent.DeletedAt == 0 && parent.Status == model.StatusApproved {
+			comment.AttachTo(&parent)
+		}
+	}
+	if !policy.requireApproval {
+		comment.Status = model.StatusApproved
+	}
+
+	if err := s.createComment(ctx, &comment); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	s.notifyOwnerAsync(ctx, "created", comment)
+	s.notifyReplyTargetAsync(ctx, comment)
+	return model.CreateCommentResult{
+		ID:     comment.ID,
+		Status: comment.Status,
+	}, nil
+}
+
+// FindFederatedComment 按远端对象 ID 查找联邦评论，不存在时返回零值。
+func (s *CommentService) FindFederatedComment(ctx context.Context, remoteID string) (model.Comment, error) {
+	return s.repo.FindByRemoteID(ctx, strings.TrimSpace(remoteID))
+}
+
+// CreateWebmentionComment 把校验通过的 Webmention 落地为待审核评论。
+// 来源页面的抓取与链接校验由调用方完成；同一来源对同一 Echo 重复提及时返回已有评论。
+// 提及来自任意站点，视同未登录评论者受 Echo 评论策略约束，且不受免审核设置影响，一律进入待审核。
+func (s *CommentService) CreateWebmentionComment(
+	ctx context.Context,
+	dto *model.CreateWebmentionCommentDto,
+) (model.CreateCommentResult, error) {
+	setting, err := s.GetSystemSetting(ctx)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if !setting.EnableComment {
+		return model.CreateCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论功能未启用")
+	}
+
+	comment := model.Comment{
+		EchoID:   strings.TrimSpace(dto.EchoID),
+		RemoteID: strings.TrimSpace(dto.Source),
+		Website:  strings.TrimSpace(dto.Source),
+		Nickname: strings.TrimSpace(dto.Nickname),
+		Content:  strings.TrimSpace(dto.Content),
+		Status:   model.StatusPending,
+		Source:   model.SourceWebmention,
+	}
+	if comment.EchoID == "" || comment.RemoteID == "" {
+		return model.CreateCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论内容不能为空")
+	}
+	existing, err := s.repo.FindByEchoAndRemoteID(ctx, comment.EchoID, comment.RemoteID)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if existing.ID != "" {
+		return model.CreateCommentResult{ID: existing.ID, Status: existing.Status}, nil
+	}
+	policy, err := s.resolveEchoPolicy(ctx, setting, comment.EchoID)
+	if err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	if err := policy.admit(false); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+
+	if comment.Content == "" {
+		comment.Content = comment.RemoteID
+	}
+	if runes := []rune(comment.Content); len(runes) > maxCommentRunes {
+		comment.Content = string(runes[:maxCommentRunes-1]) + "…"
+	}
+	if comment.Nickname == "" {
+		comment.Nickname = "Webmention"
+	}
+	if runes := []rune(comment.Nickname); len(runes) > maxNicknameRunes {
+		comment.Nickname = string(runes[:maxNicknameRunes])
+	}
+
+	if err := s.createComment(ctx, &comment); err != nil {
+		return model.CreateCommentResult{}, err
+	}
+	s.notifyOwnerAsync(ctx, "created", comment)
+	return model.CreateCommentResult{
+		ID:     comment.ID,
+		Status: comment.Status,
+	}, nil
+}
+
+// RetractWebmentionComment 在来源页面删除了链接（或已 410）时把对应评论移入回收站；
+// 不存在或已在回收站时不做任何事。
+func (s *CommentService) RetractWebmentionComment(ctx context.Context, echoID, source string) error {
+	existing, err := s.repo.FindByEchoAndRemoteID(ctx, strings.TrimSpace(echoID), strings.TrimSpace(source))
+	if err != nil || existing.ID == "" || existing.DeletedAt != 0 || existing.Source != model.SourceWebmention {
+		return err
+	}
+	return s.transactor.Run(ctx, func(txCtx context.Context) error {
+		if err := s.repo.DeleteComment(txCtx, existing.ID); err != nil {
+			return err
+		}
+		return s.emitCommentDeleted(txCtx, existing, true)
+	})
+}
+
 func (s *CommentService) checkIntegrationRateLimit(ctx context.Context, ipHash, userID, _ string) error {
 	ipShort, err := s.repo.CountByIPWithin(ctx, ipHash, integrationShortWindow)
 	if err != nil {
@@ -376,7 +672,126 @@ func (s *CommentService) ListPublicByEchoID(ctx context.Context, echoID string)
 	if err != nil {
 		return nil, err
 	}
-	return model.ToPublicComments(rows), nil
+	return s.withReactions(ctx, model.ToPublicComments(rows))
+}
+
+// ListPublicThread 返回一条已公开评论及其全部公开后代，按物化路径先序排列；
+// 评论不存在或未公开时报不存在。
+func (s *CommentService) ListPublicThread(ctx context.Context, id string) ([]model.PublicComment, error) {
+	setting, err := s.GetSystemSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !setting.EnableComment {
+		return []model.PublicComment{}, nil
+	}
+	root, err := s.publicComment(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.repo.ListPublicSubtree(ctx, root.Path)
+	if err != nil {
+		return nil, err
+	}
+	return s.withReactions(ctx, model.ToPublicComments(rows))
+}
+
+// ToggleReaction 切换调用方对评论的表情回应：已回应过则撤回，否则新增。
+// 登录用户按用户 ID 识别，访客按 IP 哈希识别；新增回应按 checkReactionRateLimit 限频。
+func (s *CommentService) ToggleReaction(
+	ctx context.Context,
+	clientIP, id string,
+	dto *model.ReactCommentDto,
+) (model.ReactCommentResult, error) {
+	emoji := strings.TrimSpace(dto.Emoji)
+	if !model.IsAllowedReaction(emoji) {
+		return model.ReactCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "不支持的表情回应")
+	}
+	setting, err := s.GetSystemSetting(ctx)
+	if err != nil {
+		return model.ReactCommentResult{}, err
+	}
+	if !setting.EnableComment {
+		return model.ReactCommentResult{},
+			commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论功能未启用")
+	}
+	comment, err := s.publicComment(ctx, id)
+	if err != nil {
+		return model.ReactCommentResult{}, err
+	}
+
+	user, validUser, err := s.resolveRequestUser(ctx)
+	if err != nil {
+		return model.ReactCommentResult{}, err
+	}
+	reaction := model.CommentReaction{
+		CommentID: comment.ID,
+		Emoji:     emoji,
+		IPHash:    hashClientIP(clientIP),
+	}
+	if validUser {
+		reaction.UserID = &user.ID
+		reaction.ActorKey = "user:" + user.ID
+	} else {
+		reaction.ActorKey = "ip:" + reaction.IPHash
+	}
+
+	removed, err := s.repo.RemoveReaction(ctx, comment.ID, emoji, reaction.ActorKey)
+	if err != nil {
+		return model.ReactCommentResult{}, err
+	}
+	if !removed {
+		if err := s.checkReactionRateLimit(ctx, reaction.IPHash, derefString(reaction.UserID)); err != nil {
+			return model.ReactCommentResult{}, err
+		}
+		if _, err := s.repo.AddReaction(ctx, &reaction); err != nil {
+			return model.ReactCommentResult{}, err
+		}
+	}
+
+	counts, err := s.repo.CountReactions(ctx, []string{comment.ID})
+	if err != nil {
+		return model.ReactCommentResult{}, err
+	}
+	out := counts[comment.ID]
+	if out == nil {
+		out = []model.ReactionCount{}
+	}
+	return model.ReactCommentResult{Reactions: out, Reacted: !removed}, nil
+}
+
+// publicComment 取一条对访客可见（已通过且不在回收站）的评论。
+func (s *CommentService) publicComment(ctx context.Context, id string) (model.Comment, error) {
+	comment, err := s.repo.GetCommentByID(ctx, strings.TrimSpace(id))
+	if err != nil || comment.ID == "" || comment.DeletedAt > 0 || comment.Status != model.StatusApproved {
+		return model.Comment{}, commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论不存在")
+	}
+	if comment.Path == "" {
+		comment.Path = comment.ID
+	}
+	return comment, nil
+}
+
+// withReactions 为公开评论批量填充表情回应计数。
+func (s *CommentService) withReactions(ctx context.Context, items []model.PublicComment) ([]model.PublicComment, error) {
+	if len(items) == 0 {
+		return items, nil
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+	counts, err := s.repo.CountReactions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		if c, ok := counts[items[i].ID]; ok {
+			items[i].Reactions = c
+		}
+	}
+	return items, nil
 }
 
 func (s *CommentService) ListPublicComments(ctx context.Context, limit int) ([]model.PublicComment, error) {
@@ -397,14 +812,14 @@ func (s *CommentService) ListPublicComments(ctx context.Context, limit int) ([]m
 	if err != nil {
 		return nil, err
 	}
-	return model.ToPublicComments(rows), nil
+	return s.withReactions(ctx, model.ToPublicComments(rows))
 }
 
 func (s *CommentService) ListPanelComments(
 	ctx context.Context,
 	query model.ListCommentQuery,
 ) (model.PageResult[model.Comment], error) {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
 		return model.PageResult[model.Comment]{}, err
 	}
 	if query.Page <= 0 {
@@ -417,31 +832,26 @@ func (s *CommentService) ListPanelComments(
 }
 
 func (s *CommentService) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
 		return model.Comment{}, err
 	}
 	return s.repo.GetCommentByID(ctx, id)
 }
 
 func (s *CommentService) UpdateCommentStatus(ctx context.Context, id string, status model.Status) error {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
 		return err
 	}
 	if status != model.StatusPending && status != model.StatusApproved && status != model.StatusRejected {
 		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "无效的评论状态")
 	}
-	if err := s.repo.UpdateCommentStatus(ctx, id, status); err != nil {
-		return err
-	}
-	if updated, err := s.repo.GetCommentByID(ctx, id); err == nil && updated.ID != "" {
-		s.emitCommentStatusUpdated(ctx, updated)
-		s.notifyOwnerAsync(ctx, "status", updated)
-	}
-	return nil
+	return s.updateStatus(ctx, []string{id}, status, func(txCtx context.Context) error {
+		return s.repo.UpdateCommentStatus(txCtx, id, status)
+	})
 }
 
 func (s *CommentService) UpdateCommentHot(ctx context.Context, id string, hot bool) error {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
 		return err
 	}
 	if err := s.repo.UpdateCommentHot(ctx, id, hot); err != nil {
@@ -455,85 +865,268 @@ func (s *CommentService) UpdateCommentHot(ctx context.Context, id string, hot bo
 	return nil
 }
 
+// DeleteComment 把评论移入回收站，可经 RestoreComment 恢复；PurgeComment 或超过保留期后才真正删除。
 func (s *CommentService) DeleteComment(ctx context.Context, id string) error {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
+		return err
+	}
+	return s.transactor.Run(ctx, func(txCtx context.Context) error {
+		beforeDelete, _ := s.repo.GetCommentByID(txCtx, id)
+		if err := s.repo.DeleteComment(txCtx, id); err != nil {
+			return err
+		}
+		if beforeDelete.DeletedAt == 0 {
+			return s.emitCommentDeleted(txCtx, beforeDelete, true)
+		}
+		return nil
+	})
+}
+
+// RestoreComment 把评论从回收站移回原处，恢复时保留原审核状态。
+func (s *CommentService) RestoreComment(ctx context.Context, id string) error {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
 		return err
 	}
-	beforeDelete, _ := s.repo.GetCommentByID(ctx, id)
-	if err := s.repo.DeleteComment(ctx, id); err != nil {
+	n, err := s.restoreComments(ctx, []string{id})
+	if err != nil {
 		return err
 	}
-	if beforeDelete.ID != "" {
-		s.emitCommentDeleted(ctx, beforeDelete)
+	if n == 0 {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "回收站中找不到该评论")
 	}
 	return nil
 }
 
-func (s *CommentService) BatchAction(ctx context.Context, action string, ids []string) error {
-	if err := s.requireAdmin(ctx); err != nil {
+// PurgeComment 彻底删除回收站中的一条评论。
+func (s *CommentService) PurgeComment(ctx context.Context, id string) error {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
 		return err
 	}
-	if len(ids) == 0 {
-		return nil
+	n, err := s.purgeComments(ctx, []string{id})
+	if err != nil {
+		return err
 	}
-	switch action {
-	case "approve":
-		if err := s.repo.BatchUpdateStatus(ctx, ids, model.StatusApproved); err != nil {
+	if n == 0 {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "回收站中找不到该评论")
+	}
+	return nil
+}
+
+// PurgeExpiredTrash 彻底删除删除时间早于 before 的回收站评论，返回清除条数。
+// 由定时任务以系统身份调用（无 viewer），按批取出直到某批取不满。
+func (s *CommentService) PurgeExpiredTrash(ctx context.Context, before int64) (int, error) {
+	purged := 0
+	for {
+		expired, err := s.repo.ListExpiredTrashed(ctx, before, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+		if len(expired) == 0 {
+			return purged, nil
+		}
+		ids := make([]string, 0, len(expired))
+		for _, c := range expired {
+			ids = append(ids, c.ID)
+		}
+		var n int64
+		if err := s.transactor.Run(ctx, func(txCtx context.Context) error {
+			if n, err = s.repo.PurgeComments(txCtx, ids); err != nil {
+				return err
+			}
+			for _, c := range expired {
+				if err := s.emitCommentDeleted(txCtx, c, false); err != nil {
+					return err
+				}
+			}
+			return nil
+		}); err != nil {
+			return purged, err
+		}
+		purged += int(n)
+		if len(expired) < purgeBatchSize {
+			return purged, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+	}
+}
+
+// createComment 在一个事务里写入评论并登记 comment.created；outbox 入队失败时评论一并回滚。
+func (s *CommentService) createComment(ctx context.Context, comment *model.Comment) error {
+	return s.transactor.Run(ctx, func(txCtx context.Context) error {
+		if err := s.repo.CreateComment(txCtx, comment); err != nil {
+			return err
+		}
+		return s.emitCommentCreated(txCtx, *comment)
+	})
+}
+
+// updateStatus 在一个事务里用 write 改写 ids 的审核状态，并为每条评论登记 comment.status_updated；
+// 提交后再训练垃圾过滤器、通知站长，二者失败都不影响已提交的状态。
+func (s *CommentService) updateStatus(
+	ctx context.Context,
+	ids []string,
+	status model.Status,
+	write func(txCtx context.Context) error,
+) error {
+	var updated []model.Comment
+	if err := s.transactor.Run(ctx, func(txCtx context.Context) error {
+		if err := write(txCtx); err != nil {
 			return err
 		}
-		for _, id := range ids {
-			if updated, err := s.repo.GetCommentByID(ctx, id); err == nil && updated.ID != "" {
-				s.emitCommentStatusUpdated(ctx, updated)
-				s.notifyOwnerAsync(ctx, "status", updated)
+		updated = s.loadComments(txCtx, ids)
+		for _, comment := range updated {
+			if err := s.emitCommentStatusUpdated(txCtx, comment); err != nil {
+				return err
 			}
 		}
 		return nil
-	case "reject":
-		if err := s.repo.BatchUpdateStatus(ctx, ids, model.StatusRejected); err != nil {
+	}); err != nil {
+		return err
+	}
+	for _, comment := range updated {
+		s.trainSpam(ctx, comment, status)
+		s.notifyOwnerAsync(ctx, "status", comment)
+	}
+	return nil
+}
+
+func (s *CommentService) trashComments(ctx context.Context, ids []string) error {
+	return s.transactor.Run(ctx, func(txCtx context.Context) error {
+		beforeDelete := s.loadComments(txCtx, ids)
+		if err := s.repo.BatchDelete(txCtx, ids); err != nil {
 			return err
 		}
-		for _, id := range ids {
-			if updated, err := s.repo.GetCommentByID(ctx, id); err == nil && updated.ID != "" {
-				s.emitCommentStatusUpdated(ctx, updated)
-				s.notifyOwnerAsync(ctx, "status", updated)
+		for _, comment := range beforeDelete {
+			if comment.DeletedAt != 0 {
+				continue
+			}
+			if err := s.emitCommentDeleted(txCtx, comment, true); err != nil {
+				return err
 			}
 		}
 		return nil
-	case "delete":
-		beforeDelete := make([]model.Comment, 0, len(ids))
-		for _, id := range ids {
-			if comment, err := s.repo.GetCommentByID(ctx, id); err == nil && comment.ID != "" {
-				beforeDelete = append(beforeDelete, comment)
+	})
+}
+
+func (s *CommentService) restoreComments(ctx context.Context, ids []string) (int64, error) {
+	var n int64
+	err := s.transactor.Run(ctx, func(txCtx context.Context) error {
+		beforeRestore := s.loadComments(txCtx, ids)
+		var err error
+		if n, err = s.repo.RestoreComments(txCtx, ids); err != nil {
+			return err
+		}
+		for _, comment := range beforeRestore {
+			if comment.DeletedAt == 0 {
+				continue
+			}
+			comment.DeletedAt = 0
+			if err := s.emitCommentRestored(txCtx, comment); err != nil {
+				return err
 			}
 		}
-		if err := s.repo.BatchDelete(ctx, ids); err != nil {
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (s *CommentService) purgeComments(ctx context.Context, ids []string) (int64, error) {
+	var n int64
+	err := s.transactor.Run(ctx, func(txCtx context.Context) error {
+		beforePurge := s.loadComments(txCtx, ids)
+		var err error
+		if n, err = s.repo.PurgeComments(txCtx, ids); err != nil {
 			return err
 		}
-		for _, comment := range beforeDelete {
-			s.emitCommentDeleted(ctx, comment)
+		for _, comment := range beforePurge {
+			if comment.DeletedAt == 0 {
+				continue
+			}
+			if err := s.emitCommentDeleted(txCtx, comment, false); err != nil {
+				return err
+			}
 		}
 		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// loadComments 按 ID 读出评论（含回收站中的），查不到的静默跳过。
+func (s *CommentService) loadComments(ctx context.Context, ids []string) []model.Comment {
+	out := make([]model.Comment, 0, len(ids))
+	for _, id := range ids {
+		if comment, err := s.repo.GetCommentByID(ctx, id); err == nil && comment.ID != "" {
+			out = append(out, comment)
+		}
+	}
+	return out
+}
+
+func (s *CommentService) BatchAction(ctx context.Context, action string, ids []string) error {
+	if err := s.requirePermission(ctx, userModel.PermCommentModerate); err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	switch action {
+	case "approve":
+		return s.updateStatus(ctx, ids, model.StatusApproved, func(txCtx context.Context) error {
+			return s.repo.BatchUpdateStatus(txCtx, ids, model.StatusApproved)
+		})
+	case "reject":
+		return s.updateStatus(ctx, ids, model.StatusRejected, func(txCtx context.Context) error {
+			return s.repo.BatchUpdateStatus(txCtx, ids, model.StatusRejected)
+		})
+	case "delete":
+		return s.trashComments(ctx, ids)
+	case "restore":
+		_, err := s.restoreComments(ctx, ids)
+		return err
+	case "purge":
+		_, err := s.purgeComments(ctx, ids)
+		return err
 	default:
 		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "无效的批量动作")
 	}
 }
 
-func (s *CommentService) emitCommentCreated(ctx context.Context, comment model.Comment) {
-	if comment.ID != "" {
-		eventbus.Notify(ctx, s.bus, event.CommentCreated{Comment: comment})
+// emitComment* 须在写入评论的事务里调用：webhook outbox 与评论同进同退，返回的错误应作为
+// 事务函数的返回值。
+func (s *CommentService) emitCommentCreated(ctx context.Context, comment model.Comment) error {
+	if comment.ID == "" {
+		return nil
+	}
+	return eventbus.NotifyTx(ctx, s.bus, event.CommentCreated{Comment: comment})
+}
+
+func (s *CommentService) emitCommentStatusUpdated(ctx context.Context, comment model.Comment) error {
+	if comment.ID == "" {
+		return nil
 	}
+	return eventbus.NotifyTx(ctx, s.bus, event.CommentStatusUpdated{Comment: comment})
 }
 
-func (s *CommentService) emitCommentStatusUpdated(ctx context.Context, comment model.Comment) {
-	if comment.ID != "" {
-		eventbus.Notify(ctx, s.bus, event.CommentStatusUpdated{Comment: comment})
+func (s *CommentService) emitCommentDeleted(ctx context.Context, comment model.Comment, restorable bool) error {
+	if comment.ID == "" {
+		return nil
 	}
+	return eventbus.NotifyTx(ctx, s.bus, event.CommentDeleted{Comment: comment, Restorable: restorable})
 }
 
-func (s *CommentService) emitCommentDeleted(ctx context.Context, comment model.Comment) {
-	if comment.ID != "" {
-		eventbus.Notify(ctx, s.bus, event.CommentDeleted{Comment: comment})
+func (s *CommentService) emitCommentRestored(ctx context.Context, comment model.Comment) error {
+	if comment.ID == "" {
+		return nil
 	}
+	return eventbus.NotifyTx(ctx, s.bus, event.CommentRestored{Comment: comment})
 }
 
 func (s *CommentService) GetSystemSetting(ctx context.Context) (model.SystemSetting, error) {
@@ -550,14 +1143,22 @@ func (s *CommentService) getSystemSettingRaw(ctx context.Context) (model.SystemS
 }
 
 func (s *CommentService) UpdateSystemSetting(ctx context.Context, setting model.SystemSetting) error {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermSystemManage); err != nil {
 		return err
 	}
 	applySettingDefaults(&setting)
+	switch setting.Spam.Provider {
+	case model.SpamProviderNone, model.SpamProviderAkismet, model.SpamProviderBayes:
+	default:
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "无效的垃圾检测后端")
+	}
 	current, err := s.getSystemSettingRaw(ctx)
 	if err == nil && strings.TrimSpace(setting.EmailNotify.SMTPPassword) == "" {
 		setting.EmailNotify.SMTPPassword = current.EmailNotify.SMTPPassword
 	}
+	if err == nil && strings.TrimSpace(setting.Spam.AkismetKey) == "" {
+		setting.Spam.AkismetKey = current.Spam.AkismetKey
+	}
 	buf, err := json.Marshal(setting)
 	if err != nil {
 		return err
@@ -566,7 +1167,7 @@ func (s *CommentService) UpdateSystemSetting(ctx context.Context, setting model.
 }
 
 func (s *CommentService) SendTestEmail(ctx context.Context, setting model.SystemSetting) error {
-	if err := s.requireAdmin(ctx); err != nil {
+	if err := s.requirePermission(ctx, userModel.PermSystemManage); err != nil {
 		return err
 	}
 	applySettingDefaults(&setting)
@@ -775,12 +1376,16 @@ func applySettingDefaults(setting *model.SystemSetting) {
 	if setting.EmailNotify.SMTPPort <= 0 {
 		setting.EmailNotify.SMTPPort = 587
 	}
+	setting.Spam.Threshold = model.NormalizeSpamThreshold(setting.Spam.Threshold)
+	setting.AutoCloseDays = max(setting.AutoCloseDays, 0)
 }
 
 func sanitizeSettingForOutput(in model.SystemSetting) model.SystemSetting {
 	out := in
 	out.EmailNotify.SMTPPasswordSet = strings.TrimSpace(out.EmailNotify.SMTPPassword) != ""
 	out.EmailNotify.SMTPPassword = ""
+	out.Spam.AkismetKeySet = strings.TrimSpace(out.Spam.AkismetKey) != ""
+	out.Spam.AkismetKey = ""
 	return out
 }
 
@@ -846,6 +1451,33 @@ func (s *CommentService) checkRateLimit(ctx context.Context, ipHash, email, user
 	return nil
 }
 
+// checkReactionRateLimit 与 checkRateLimit 同一套时间窗，按 IP 哈希与用户 ID 分别计数新增的表情回应。
+func (s *CommentService) checkReactionRateLimit(ctx context.Context, ipHash, userID string) error {
+	ipShort, err := s.repo.CountReactionsByIPWithin(ctx, ipHash, reactionShortWindow)
+	if err != nil {
+		return err
+	}
+	ipLong, err := s.repo.CountReactionsByIPWithin(ctx, ipHash, reactionLongWindow)
+	if err != nil {
+		return err
+	}
+	if ipShort >= reactionShortLimit || ipLong >= reactionLongLimit {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "回应过于频繁，请稍后再试")
+	}
+
+	if userID != "" {
+		userShort, err := s.repo.CountReactionsByUserWithin(ctx, userID, reactionShortWindow)
+		if err != nil {
+			return err
+		}
+		if userShort >= reactionShortLimit {
+			return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "回应过于频繁，请稍后再试")
+		}
+	}
+
+	return nil
+}
+
 func (s *CommentService) verifyFormToken(clientIP, token string) error {
 	parts := strings.Split(strings.TrimSpace(token), ".")
 	if len(parts) != 2 {
@@ -880,7 +1512,8 @@ func (s *CommentService) computeHMAC(payload string) string {
 	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
 }
 
-func (s *CommentService) requireAdmin(ctx context.Context) error {
+// requirePermission 要求调用方已登录且拥有权限 p。
+func (s *CommentService) requirePermission(ctx context.Context, p userModel.Permission) error {
 	v := viewer.MustFromContext(ctx)
 	if v == nil || strings.TrimSpace(v.UserID()) == "" {
 		return commonModel.NewBizError(commonModel.ErrCodePermissionDenied, commonModel.NO_PERMISSION_DENIED)
@@ -889,7 +1522,7 @@ func (s *CommentService) requireAdmin(ctx context.Context) error {
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin && !user.IsOwner {
+	if !user.Can(p) {
 		return commonModel.NewBizError(commonModel.ErrCodePermissionDenied, commonModel.NO_PERMISSION_DENIED)
 	}
 	return nil
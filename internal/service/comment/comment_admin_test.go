@@ -6,6 +6,7 @@ package service_test
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
@@ -389,10 +390,10 @@ func TestListPanelComments(t *testing.T) {
 	})
 }
 
-// --- resolveParentID (valid-parent branch, exercised via CreateComment) -----
+// --- resolveParent (valid-parent branch, exercised via CreateComment) -----
 
-// resolveParentID 的三个错误分支已在 comment_create_test.go 覆盖；这里补「合法已审核父评论」
-// 的成功分支：返回 &parent.ID 并落到 comment.ParentID。走管理员路径以跳过频率限制噪声。
+// resolveParent 的三个错误分支已在 comment_create_test.go 覆盖；这里补「合法已审核父评论」
+// 的成功分支：挂到父评论之下并落到 comment.ParentID / Path。走管理员路径以跳过频率限制噪声。
 func TestResolveParentID_ValidParentSetsParentID(t *testing.T) {
 	helpers.SetJWTSecret(t, testSecret)
 	d := newDeps(t)
@@ -441,4 +442,6 @@ func TestResolveParentID_ValidParentSetsParentID(t *testing.T) {
 	assert.Equal(t, "child-1", res.ID)
 	require.NotNil(t, captured.ParentID)
 	assert.Equal(t, "parent-1", *captured.ParentID)
+	assert.Equal(t, 1, captured.Depth)
+	assert.True(t, strings.HasPrefix(captured.Path, "parent-1/"), "path %q", captured.Path)
 }
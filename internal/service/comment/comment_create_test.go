@@ -16,6 +16,7 @@ import (
 
 	commentModel "github.com/lin-snow/ech0/internal/model/comment"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	"github.com/lin-snow/ech0/internal/test/helpers"
 	commentmock "github.com/lin-snow/ech0/internal/test/mocks/commentmock"
@@ -25,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 const (
@@ -35,15 +37,37 @@ const (
 // deps 把一次测试需要的全部协作者 mock 收在一起，并提供构造被测服务的便捷方法。
 type deps struct {
 	repo   *commentmock.MockRepository
+	echos  echoStub
 	kv     *kvmock.MockStore
 	common *commonmock.MockService
 	mailer *commentmock.MockMailer
 }
 
+// passTx 直接在调用方 ctx 上执行事务函数：单测用 mock 仓储，只关心事务内外的业务分支。
+type passTx struct{}
+
+func (passTx) Run(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }
+
+// echoStub 是内存版的 Echo 读取：未登记的 ID 视为一条刚发布、未设评论策略的 Echo，
+// 登记为 nil 表示不存在。
+type echoStub map[string]*echoModel.Echo
+
+func (s echoStub) GetEchosById(_ context.Context, id string) (*echoModel.Echo, error) {
+	e, ok := s[id]
+	if !ok {
+		return &echoModel.Echo{ID: id, CreatedAt: time.Now().Unix()}, nil
+	}
+	if e == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return e, nil
+}
+
 func newDeps(t *testing.T) deps {
 	t.Helper()
 	return deps{
 		repo:   commentmock.NewMockRepository(t),
+		echos:  echoStub{},
 		kv:     kvmock.NewMockStore(t),
 		common: commonmock.NewMockService(t),
 		mailer: commentmock.NewMockMailer(t),
@@ -54,8 +78,10 @@ func (d deps) service() *commentService.CommentService {
 	// busProvider 返回一条全新的、无订阅者的 busen 总线：emit 时无人接收即 no-op，
 	// 不产生 goroutine，保持测试同步、确定。
 	return commentService.NewCommentService(
+		passTx{},
 		d.common,
 		d.repo,
+		d.echos,
 		d.kv,
 		func() *busen.Bus { return busen.New() },
 		d.mailer,
@@ -620,3 +646,140 @@ func TestCreateIntegrationComment_Happy(t *testing.T) {
 		})
 	}
 }
+
+// --- CreateFederatedComment ------------------------------------------------
+
+func TestCreateFederatedComment_DedupesByRemoteID(t *testing.T) {
+	d := newDeps(t)
+	d.expectSetting(t, enabledSetting())
+	d.repo.EXPECT().
+		FindByRemoteID(mock.Anything, "https://remote.example/notes/1").
+		Return(commentModel.Comment{ID: "c-existing", Status: commentModel.StatusPending}, nil).
+		Once()
+
+	res, err := d.service().CreateFederatedComment(context.Background(), &commentModel.CreateFederatedCommentDto{
+		EchoID:   "echo-1",
+		RemoteID: "https://remote.example/notes/1",
+		Content:  "again",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "c-existing", res.ID)
+}
+
+func TestCreateFederatedComment_Happy(t *testing.T) {
+	d := newDeps(t)
+	setting := enabledSetting()
+	setting.RequireApproval = false
+	d.expectSetting(t, setting)
+	d.repo.EXPECT().
+		FindByRemoteID(mock.Anything, "https://remote.example/notes/2").
+		Return(commentModel.Comment{}, nil).
+		Once()
+	d.repo.EXPECT().
+		FindByRemoteID(mock.Anything, "https://remote.example/notes/1").
+		Return(commentModel.Comment{
+			ID:       "parent-1",
+			EchoID:   "echo-1",
+			RemoteID: "https://remote.example/notes/1",
+			Status:   commentModel.StatusApproved,
+		}, nil).
+		Once()
+
+	var captured commentModel.Comment
+	d.repo.EXPECT().
+		CreateComment(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, c *commentModel.Comment) {
+			c.ID = "new-federated-1"
+			captured = *c
+		}).
+		Return(nil).
+		Once()
+
+	res, err := d.service().CreateFederatedComment(context.Background(), &commentModel.CreateFederatedCommentDto{
+		EchoID:         "echo-1",
+		RemoteID:       "https://remote.example/notes/2",
+		ParentRemoteID: "https://remote.example/notes/1",
+		Nickname:       "",
+		Website:        "javascript:alert(1)",
+		Content:        strings.Repeat("长", 500),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new-federated-1", res.ID)
+	assert.Equal(t, commentModel.StatusApproved, captured.Status)
+	assert.Equal(t, commentModel.SourceFediverse, captured.Source)
+	assert.Equal(t, "Fediverse", captured.Nickname)
+	assert.Empty(t, captured.Website, "non-http website must be dropped")
+	require.NotNil(t, captured.ParentID)
+	assert.Equal(t, "parent-1", *captured.ParentID)
+	assert.True(t, strings.HasSuffix(captured.Content, "…"))
+}
+
+// --- CreateWebmentionComment / RetractWebmentionComment ---------------------
+
+func TestCreateWebmentionComment_AlwaysPending(t *testing.T) {
+	d := newDeps(t)
+	setting := enabledSetting()
+	setting.RequireApproval = false
+	d.expectSetting(t, setting)
+	d.repo.EXPECT().
+		FindByEchoAndRemoteID(mock.Anything, "echo-1", "https://blog.example/post").
+		Return(commentModel.Comment{}, nil).
+		Once()
+
+	var captured commentModel.Comment
+	d.repo.EXPECT().
+		CreateComment(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, c *commentModel.Comment) {
+			c.ID = "new-webmention-1"
+			captured = *c
+		}).
+		Return(nil).
+		Once()
+
+	res, err := d.service().CreateWebmentionComment(context.Background(), &commentModel.CreateWebmentionCommentDto{
+		EchoID:   "echo-1",
+		Source:   "https://blog.example/post",
+		Nickname: "blog.example",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "new-webmention-1", res.ID)
+	assert.Equal(t, commentModel.StatusPending, captured.Status, "webmention 不论审核设置一律待审核")
+	assert.Equal(t, commentModel.SourceWebmention, captured.Source)
+	assert.Equal(t, "https://blog.example/post", captured.RemoteID)
+	assert.Equal(t, "https://blog.example/post", captured.Website)
+	assert.Equal(t, "https://blog.example/post", captured.Content, "无标题时以来源地址为正文")
+}
+
+func TestCreateWebmentionComment_DedupesPerEcho(t *testing.T) {
+	d := newDeps(t)
+	d.expectSetting(t, enabledSetting())
+	d.repo.EXPECT().
+		FindByEchoAndRemoteID(mock.Anything, "echo-1", "https://blog.example/post").
+		Return(commentModel.Comment{ID: "c-existing", Status: commentModel.StatusApproved}, nil).
+		Once()
+
+	res, err := d.service().CreateWebmentionComment(context.Background(), &commentModel.CreateWebmentionCommentDto{
+		EchoID: "echo-1",
+		Source: "https://blog.example/post",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "c-existing", res.ID)
+	assert.Equal(t, commentModel.StatusApproved, res.Status)
+}
+
+func TestRetractWebmentionComment(t *testing.T) {
+	d := newDeps(t)
+	d.repo.EXPECT().
+		FindByEchoAndRemoteID(mock.Anything, "echo-1", "https://blog.example/post").
+		Return(commentModel.Comment{ID: "c-1", EchoID: "echo-1", Source: commentModel.SourceWebmention}, nil).
+		Once()
+	d.repo.EXPECT().DeleteComment(mock.Anything, "c-1").Return(nil).Once()
+	require.NoError(t, d.service().RetractWebmentionComment(context.Background(), "echo-1", "https://blog.example/post"))
+
+	// 已在回收站或不存在时不做任何事。
+	d.repo.EXPECT().
+		FindByEchoAndRemoteID(mock.Anything, "echo-1", "https://blog.example/gone").
+		Return(commentModel.Comment{ID: "c-2", Source: commentModel.SourceWebmention, DeletedAt: 1}, nil).
+		Once()
+	require.NoError(t, d.service().RetractWebmentionComment(context.Background(), "echo-1", "https://blog.example/gone"))
+}
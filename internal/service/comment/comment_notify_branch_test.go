@@ -96,7 +96,7 @@ func TestCreateComment_ReplyNotifySkipsInvalidParentEmail(t *testing.T) {
 		CommonGetUserByUserId(mock.Anything, "owner-1").
 		Return(owner, nil).
 		Once()
-	// 父评论被读两次：resolveParentID 校验 + notifyReplyTargetAsync 取收件邮箱。
+	// 父评论被读两次：resolveParent 校验 + notifyReplyTargetAsync 取收件邮箱。
 	d.repo.EXPECT().
 		GetCommentByID(mock.Anything, "parent-1").
 		Return(commentModel.Comment{
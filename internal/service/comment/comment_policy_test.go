@@ -0,0 +1,193 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const day = int64(24 * 60 * 60)
+
+// policyEcho 构造一条 daysAgo 天前发布、带给定评论策略的 Echo。
+func policyEcho(policy string, daysAgo int64) *echoModel.Echo {
+	return &echoModel.Echo{
+		ID:            "echo-1",
+		CommentPolicy: policy,
+		CreatedAt:     time.Now().Unix() - daysAgo*day,
+	}
+}
+
+// --- CreateComment 按 Echo 评论策略放行 --------------------------------------
+
+func TestCreateComment_EchoPolicyRejects(t *testing.T) {
+	helpers.SetJWTSecret(t, testSecret)
+
+	cases := []struct {
+		name     string
+		echo     *echoModel.Echo
+		autoDays int
+		wantMsg  string
+	}{
+		{"missing echo", nil, 0, "评论的 Echo 不存在"},
+		{"closed", policyEcho(echoModel.CommentPolicyClosed, 0), 0, "该 Echo 已关闭评论"},
+		{"auto closed after N days", policyEcho("", 30), 7, "该 Echo 发布已久，评论已自动关闭"},
+		{"login only rejects guests", policyEcho(echoModel.CommentPolicyLoginOnly, 0), 0, "该 Echo 仅允许登录用户评论"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDeps(t)
+			d.echos["echo-1"] = tc.echo
+			s := enabledSetting()
+			s.AutoCloseDays = tc.autoDays
+			d.expectSetting(t, s)
+			_, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", guestDto())
+			assertBiz(t, err, commonModel.ErrCodeInvalidRequest, tc.wantMsg)
+		})
+	}
+}
+
+func TestCreateComment_EchoPolicyAdmits(t *testing.T) {
+	helpers.SetJWTSecret(t, testSecret)
+
+	t.Run("open policy is exempt from auto close", func(t *testing.T) {
+		d := newDeps(t)
+		d.echos["echo-1"] = policyEcho(echoModel.CommentPolicyOpen, 30)
+		s := enabledSetting()
+		s.RequireApproval = false
+		s.AutoCloseDays = 7
+		expectGuestCreate(t, d, s)
+		res, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", guestDto())
+		require.NoError(t, err)
+		assert.Equal(t, commentModel.StatusApproved, res.Status)
+	})
+
+	t.Run("approval policy holds comments even when global approval is off", func(t *testing.T) {
+		d := newDeps(t)
+		d.echos["echo-1"] = policyEcho(echoModel.CommentPolicyApproval, 0)
+		s := enabledSetting()
+		s.RequireApproval = false
+		expectGuestCreate(t, d, s)
+		res, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", guestDto())
+		require.NoError(t, err)
+		assert.Equal(t, commentModel.StatusPending, res.Status)
+	})
+}
+
+func TestCreateIntegrationComment_EchoPolicy(t *testing.T) {
+	t.Run("closed echo rejects integration comments", func(t *testing.T) {
+		d := newDeps(t)
+		d.echos["echo-1"] = policyEcho(echoModel.CommentPolicyClosed, 0)
+		d.expectSetting(t, enabledSetting())
+		_, err := d.service().CreateIntegrationComment(integrationCtx(), testIP, "ua",
+			&commentModel.CreateIntegrationCommentDto{EchoID: "echo-1", Content: "hi"})
+		assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "该 Echo 已关闭评论")
+	})
+}
+
+func TestRemoteComments_EchoPolicy(t *testing.T) {
+	federated := func(d deps) error {
+		d.repo.EXPECT().FindByRemoteID(mock.Anything, "https://remote.example/notes/1").
+			Return(commentModel.Comment{}, nil).Once()
+		_, err := d.service().CreateFederatedComment(context.Background(), &commentModel.CreateFederatedCommentDto{
+			EchoID: "echo-1", RemoteID: "https://remote.example/notes/1", Content: "hi",
+		})
+		return err
+	}
+	webmention := func(d deps) error {
+		d.repo.EXPECT().FindByEchoAndRemoteID(mock.Anything, "echo-1", "https://blog.example/post").
+			Return(commentModel.Comment{}, nil).Once()
+		_, err := d.service().CreateWebmentionComment(context.Background(), &commentModel.CreateWebmentionCommentDto{
+			EchoID: "echo-1", Source: "https://blog.example/post",
+		})
+		return err
+	}
+
+	cases := []struct {
+		name     string
+		echo     *echoModel.Echo
+		autoDays int
+		wantMsg  string
+	}{
+		{"closed", policyEcho(echoModel.CommentPolicyClosed, 0), 0, "该 Echo 已关闭评论"},
+		{"auto closed", policyEcho("", 30), 7, "该 Echo 发布已久，评论已自动关闭"},
+		{"login only", policyEcho(echoModel.CommentPolicyLoginOnly, 0), 0, "该 Echo 仅允许登录用户评论"},
+	}
+	for _, tc := range cases {
+		for name, create := range map[string]func(deps) error{"federated": federated, "webmention": webmention} {
+			t.Run(name+" "+tc.name, func(t *testing.T) {
+				d := newDeps(t)
+				d.echos["echo-1"] = tc.echo
+				s := enabledSetting()
+				s.AutoCloseDays = tc.autoDays
+				d.expectSetting(t, s)
+				assertBiz(t, create(d), commonModel.ErrCodeInvalidRequest, tc.wantMsg)
+			})
+		}
+	}
+
+	t.Run("approval policy holds federated replies", func(t *testing.T) {
+		d := newDeps(t)
+		d.echos["echo-1"] = policyEcho(echoModel.CommentPolicyApproval, 0)
+		s := enabledSetting()
+		s.RequireApproval = false
+		d.expectSetting(t, s)
+		var captured commentModel.Comment
+		d.repo.EXPECT().CreateComment(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, c *commentModel.Comment) { captured = *c }).
+			Return(nil).Once()
+		require.NoError(t, federated(d))
+		assert.Equal(t, commentModel.StatusPending, captured.Status)
+	})
+}
+
+// --- GetFormMeta 附带策略 -----------------------------------------------------
+
+func TestGetFormMeta_EchoPolicy(t *testing.T) {
+	helpers.SetJWTSecret(t, testSecret)
+
+	t.Run("reports auto close time while still open", func(t *testing.T) {
+		d := newDeps(t)
+		echo := policyEcho("", 2)
+		d.echos["echo-1"] = echo
+		s := enabledSetting()
+		s.AutoCloseDays = 7
+		d.expectSetting(t, s)
+		meta, err := d.service().GetFormMeta(context.Background(), testIP, "https://host", "echo-1")
+		require.NoError(t, err)
+		assert.Empty(t, meta.ClosedReason)
+		assert.Equal(t, echo.CreatedAt+7*day, meta.ClosesAt)
+		assert.True(t, meta.RequireApproval)
+	})
+
+	t.Run("explains why commenting is disabled", func(t *testing.T) {
+		d := newDeps(t)
+		d.echos["echo-1"] = policyEcho(echoModel.CommentPolicyClosed, 0)
+		d.expectSetting(t, enabledSetting())
+		meta, err := d.service().GetFormMeta(context.Background(), testIP, "https://host", "echo-1")
+		require.NoError(t, err)
+		assert.Equal(t, echoModel.CommentPolicyClosed, meta.CommentPolicy)
+		assert.Equal(t, commentModel.ClosedReasonClosed, meta.ClosedReason)
+		assert.Zero(t, meta.ClosesAt)
+	})
+
+	t.Run("login only is flagged for the form", func(t *testing.T) {
+		d := newDeps(t)
+		d.echos["echo-1"] = policyEcho(echoModel.CommentPolicyLoginOnly, 0)
+		d.expectSetting(t, enabledSetting())
+		meta, err := d.service().GetFormMeta(context.Background(), testIP, "https://host", "echo-1")
+		require.NoError(t, err)
+		assert.True(t, meta.LoginRequired)
+		assert.Empty(t, meta.ClosedReason)
+	})
+}
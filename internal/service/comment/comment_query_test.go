@@ -28,7 +28,7 @@ func TestGetFormMeta(t *testing.T) {
 			Get(mock.Anything, commentModel.CommentSystemSettingKey).
 			Return("", errRepoBoom).
 			Once()
-		_, err := d.service().GetFormMeta(helpers.CtxAnonymous(), testIP, "https://host")
+		_, err := d.service().GetFormMeta(helpers.CtxAnonymous(), testIP, "https://host", "")
 		require.ErrorIs(t, err, errRepoBoom)
 	})
 
@@ -36,7 +36,7 @@ func TestGetFormMeta(t *testing.T) {
 		helpers.SetJWTSecret(t, testSecret)
 		d := newDeps(t)
 		d.expectSetting(t, enabledSetting()) // CaptchaEnabled=false
-		meta, err := d.service().GetFormMeta(helpers.CtxAnonymous(), testIP, "https://host")
+		meta, err := d.service().GetFormMeta(helpers.CtxAnonymous(), testIP, "https://host", "")
 		require.NoError(t, err)
 		assert.NotEmpty(t, meta.FormToken)
 		assert.Equal(t, int64(2000), meta.MinSubmitMs)
@@ -52,7 +52,7 @@ func TestGetFormMeta(t *testing.T) {
 		s := enabledSetting()
 		s.CaptchaEnabled = true
 		d.expectSetting(t, s)
-		meta, err := d.service().GetFormMeta(helpers.CtxAnonymous(), testIP, "https://host")
+		meta, err := d.service().GetFormMeta(helpers.CtxAnonymous(), testIP, "https://host", "")
 		require.NoError(t, err)
 		assert.True(t, meta.CaptchaEnabled)
 		assert.Contains(t, meta.CaptchaAPIEndpoint, "https://host/api/cap/")
@@ -93,20 +93,27 @@ func TestListPublicByEchoID(t *testing.T) {
 		require.ErrorIs(t, err, errRepoBoom)
 	})
 
-	t.Run("success maps rows and trims echo id", func(t *testing.T) {
+	t.Run("success maps rows, trims echo id and attaches reactions", func(t *testing.T) {
 		d := newDeps(t)
 		d.expectSetting(t, enabledSetting())
 		d.repo.EXPECT().
 			ListPublicByEchoID(mock.Anything, "echo-1").
 			Return([]commentModel.Comment{
-				{ID: "c-1", EchoID: "echo-1", Status: commentModel.StatusApproved, Nickname: "A"},
+				{ID: "c-1", EchoID: "echo-1", Status: commentModel.StatusApproved, Nickname: "A", Content: "**hi**"},
 				{ID: "c-2", EchoID: "echo-1", Status: commentModel.StatusApproved, Nickname: "B"},
 			}, nil).
 			Once()
+		d.repo.EXPECT().
+			CountReactions(mock.Anything, []string{"c-1", "c-2"}).
+			Return(map[string][]commentModel.ReactionCount{"c-1": {{Emoji: "👍", Count: 2}}}, nil).
+			Once()
 		got, err := d.service().ListPublicByEchoID(context.Background(), "  echo-1  ")
 		require.NoError(t, err)
 		require.Len(t, got, 2)
 		assert.Equal(t, "c-1", got[0].ID)
+		assert.Equal(t, "<p><strong>hi</strong></p>\n", got[0].ContentHTML)
+		assert.Equal(t, []commentModel.ReactionCount{{Emoji: "👍", Count: 2}}, got[0].Reactions)
+		assert.Empty(t, got[1].Reactions)
 	})
 }
 
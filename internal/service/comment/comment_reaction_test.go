@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func approvedComment(id string) commentModel.Comment {
+	return commentModel.Comment{ID: id, EchoID: "echo-1", Path: id, Status: commentModel.StatusApproved}
+}
+
+// --- ListPublicThread -------------------------------------------------------
+
+func TestListPublicThread(t *testing.T) {
+	t.Run("hidden comment reads as missing", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting())
+		c := approvedComment("c-1")
+		c.Status = commentModel.StatusPending
+		d.repo.EXPECT().GetCommentByID(mock.Anything, "c-1").Return(c, nil).Once()
+		_, err := d.service().ListPublicThread(context.Background(), "c-1")
+		require.Error(t, err)
+	})
+
+	t.Run("lists subtree by materialized path", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting())
+		root := approvedComment("c-1")
+		root.Path = "c-0/c-1"
+		d.repo.EXPECT().GetCommentByID(mock.Anything, "c-1").Return(root, nil).Once()
+		d.repo.EXPECT().
+			ListPublicSubtree(mock.Anything, "c-0/c-1").
+			Return([]commentModel.Comment{root, {ID: "c-2", Path: "c-0/c-1/c-2", Depth: 2}}, nil).
+			Once()
+		d.repo.EXPECT().
+			CountReactions(mock.Anything, []string{"c-1", "c-2"}).
+			Return(map[string][]commentModel.ReactionCount{}, nil).
+			Once()
+		got, err := d.service().ListPublicThread(context.Background(), "c-1")
+		require.NoError(t, err)
+		require.Len(t, got, 2)
+		assert.Equal(t, 2, got[1].Depth)
+	})
+}
+
+// --- ToggleReaction ---------------------------------------------------------
+
+func TestToggleReaction(t *testing.T) {
+	t.Run("rejects emoji outside the allowlist", func(t *testing.T) {
+		d := newDeps(t)
+		_, err := d.service().ToggleReaction(helpers.CtxAnonymous(), testIP, "c-1",
+			&commentModel.ReactCommentDto{Emoji: "💩"})
+		require.Error(t, err)
+	})
+
+	t.Run("guest adds a reaction keyed by ip hash", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting())
+		d.repo.EXPECT().GetCommentByID(mock.Anything, "c-1").Return(approvedComment("c-1"), nil).Once()
+		d.repo.EXPECT().RemoveReaction(mock.Anything, "c-1", "👍", mock.Anything).Return(false, nil).Once()
+		d.repo.EXPECT().CountReactionsByIPWithin(mock.Anything, mock.Anything, mock.Anything).Return(0, nil).Twice()
+		var added commentModel.CommentReaction
+		d.repo.EXPECT().
+			AddReaction(mock.Anything, mock.Anything).
+			Run(func(_ context.Context, r *commentModel.CommentReaction) { added = *r }).
+			Return(true, nil).
+			Once()
+		d.repo.EXPECT().
+			CountReactions(mock.Anything, []string{"c-1"}).
+			Return(map[string][]commentModel.ReactionCount{"c-1": {{Emoji: "👍", Count: 1}}}, nil).
+			Once()
+
+		res, err := d.service().ToggleReaction(helpers.CtxAnonymous(), testIP, "c-1",
+			&commentModel.ReactCommentDto{Emoji: "👍"})
+		require.NoError(t, err)
+		assert.True(t, res.Reacted)
+		assert.Equal(t, []commentModel.ReactionCount{{Emoji: "👍", Count: 1}}, res.Reactions)
+		assert.Equal(t, "ip:"+added.IPHash, added.ActorKey)
+		assert.Nil(t, added.UserID)
+	})
+
+	t.Run("second toggle removes without rate limiting", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting())
+		d.common.EXPECT().
+			CommonGetUserByUserId(mock.Anything, "user-1").
+			Return(helpers.NewUser(helpers.WithUserID("user-1")), nil).
+			Once()
+		d.repo.EXPECT().GetCommentByID(mock.Anything, "c-1").Return(approvedComment("c-1"), nil).Once()
+		d.repo.EXPECT().RemoveReaction(mock.Anything, "c-1", "🎉", "user:user-1").Return(true, nil).Once()
+		d.repo.EXPECT().
+			CountReactions(mock.Anything, []string{"c-1"}).
+			Return(map[string][]commentModel.ReactionCount{}, nil).
+			Once()
+
+		res, err := d.service().ToggleReaction(helpers.CtxAsUser("user-1"), testIP, "c-1",
+			&commentModel.ReactCommentDto{Emoji: "🎉"})
+		require.NoError(t, err)
+		assert.False(t, res.Reacted)
+		assert.Equal(t, []commentModel.ReactionCount{}, res.Reactions)
+	})
+
+	t.Run("rate limited by ip window", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectSetting(t, enabledSetting())
+		d.repo.EXPECT().GetCommentByID(mock.Anything, "c-1").Return(approvedComment("c-1"), nil).Once()
+		d.repo.EXPECT().RemoveReaction(mock.Anything, "c-1", "👀", mock.Anything).Return(false, nil).Once()
+		d.repo.EXPECT().CountReactionsByIPWithin(mock.Anything, mock.Anything, int64(60)).Return(10, nil).Once()
+		d.repo.EXPECT().CountReactionsByIPWithin(mock.Anything, mock.Anything, int64(3600)).Return(10, nil).Once()
+
+		_, err := d.service().ToggleReaction(helpers.CtxAnonymous(), testIP, "c-1",
+			&commentModel.ReactCommentDto{Emoji: "👀"})
+		require.ErrorContains(t, err, "回应过于频繁")
+	})
+}
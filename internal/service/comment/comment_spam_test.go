@@ -0,0 +1,213 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/stretchr/testify/assert"
+	mock "github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// expectGuestCreate 铺好访客发评论直到落库前的 mock，返回落库时捕获的评论。
+func expectGuestCreate(t *testing.T, d deps, s commentModel.SystemSetting) *commentModel.Comment {
+	t.Helper()
+	d.expectSetting(t, s)
+	d.kv.EXPECT().Get(mock.Anything, commonModel.ServerURLKey).Return("https://blog.example", nil).Maybe()
+	d.repo.EXPECT().CountByIPWithin(mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+	d.repo.EXPECT().CountByEmailWithin(mock.Anything, mock.Anything, mock.Anything).Return(int64(0), nil)
+	d.repo.EXPECT().
+		ExistsRecentDuplicate(
+			mock.Anything, mock.Anything, mock.Anything, mock.Anything,
+			mock.Anything, mock.Anything, mock.Anything,
+		).
+		Return(false, nil).
+		Once()
+	captured := &commentModel.Comment{}
+	d.repo.EXPECT().
+		CreateComment(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, c *commentModel.Comment) {
+			c.ID = "guest-cmt"
+			*captured = *c
+		}).
+		Return(nil).
+		Once()
+	return captured
+}
+
+func guestDto() *commentModel.CreateCommentDto {
+	return &commentModel.CreateCommentDto{
+		EchoID:    "echo-1",
+		Content:   "cheap casino pills",
+		Nickname:  "Guest",
+		Email:     "guest@example.com",
+		FormToken: freshToken(),
+	}
+}
+
+// --- CreateComment 垃圾检测 -------------------------------------------------
+
+func TestCreateComment_SpamCheck(t *testing.T) {
+	helpers.SetJWTSecret(t, testSecret)
+
+	cases := []struct {
+		name       string
+		rejectSpam bool
+		wantStatus commentModel.Status
+	}{
+		{"spam is held for review", false, commentModel.StatusPending},
+		{"spam is rejected outright", true, commentModel.StatusRejected},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newDeps(t)
+			s := enabledSetting()
+			s.RequireApproval = false
+			s.Spam = commentModel.SpamSetting{Provider: commentModel.SpamProviderBayes, RejectSpam: tc.rejectSpam}
+			captured := expectGuestCreate(t, d, s)
+			// 每个特征都只在垃圾样本中出现过，分类器应给出高分。
+			d.repo.EXPECT().
+				SpamTokenCounts(mock.Anything, mock.Anything).
+				RunAndReturn(func(_ context.Context, tokens []string) (map[string]commentModel.SpamToken, error) {
+					out := make(map[string]commentModel.SpamToken)
+					for _, tok := range tokens {
+						out[tok] = commentModel.SpamToken{Token: tok, Spam: 20, Ham: 20}
+						if tok != "*docs*" {
+							out[tok] = commentModel.SpamToken{Token: tok, Spam: 20}
+						}
+					}
+					return out, nil
+				}).
+				Once()
+
+			res, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", guestDto())
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, res.Status)
+			assert.Equal(t, commentModel.SpamProviderBayes, captured.SpamBackend)
+			assert.Equal(t, commentModel.SpamVerdictSpam, captured.SpamVerdict)
+			assert.Greater(t, captured.SpamScore, 0.9)
+		})
+	}
+
+	t.Run("backend failure holds the comment for review", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte("invalid"))
+		}))
+		t.Cleanup(srv.Close)
+
+		d := newDeps(t)
+		s := enabledSetting()
+		s.RequireApproval = false
+		s.Spam = commentModel.SpamSetting{
+			Provider:        commentModel.SpamProviderAkismet,
+			AkismetKey:      "key",
+			AkismetEndpoint: srv.URL,
+		}
+		captured := expectGuestCreate(t, d, s)
+
+		res, err := d.service().CreateComment(helpers.CtxAnonymous(), testIP, "ua", guestDto())
+		require.NoError(t, err)
+		assert.Equal(t, commentModel.StatusPending, res.Status)
+		assert.Empty(t, captured.SpamVerdict)
+	})
+}
+
+// --- 审核结论训练分类器 ------------------------------------------------------
+
+func TestBatchAction_TrainsSpamFilter(t *testing.T) {
+	t.Run("reject relabels a comment previously approved", func(t *testing.T) {
+		d := newDeps(t)
+		expectAdmin(t, d, "admin-1")
+		d.repo.EXPECT().BatchUpdateStatus(mock.Anything, []string{"c-1"}, commentModel.StatusRejected).Return(nil).Once()
+		d.repo.EXPECT().
+			GetCommentByID(mock.Anything, "c-1").
+			Return(commentModel.Comment{
+				ID:        "c-1",
+				Content:   "cheap pills",
+				Source:    commentModel.SourceGuest,
+				Status:    commentModel.StatusRejected,
+				SpamLabel: commentModel.SpamVerdictHam,
+			}, nil).
+			Once()
+		d.repo.EXPECT().LearnSpamTokens(mock.Anything, mock.Anything, false, int64(-1)).Return(nil).Once()
+		d.repo.EXPECT().LearnSpamTokens(mock.Anything, mock.Anything, true, int64(1)).Return(nil).Once()
+		d.repo.EXPECT().UpdateSpamLabel(mock.Anything, "c-1", commentModel.SpamVerdictSpam).Return(nil).Once()
+		d.expectSetting(t, enabledSetting())
+
+		require.NoError(t, d.service().BatchAction(helpers.CtxAsUser("admin-1"), "reject", []string{"c-1"}))
+	})
+
+	t.Run("same label and non-guest comments are not trained", func(t *testing.T) {
+		d := newDeps(t)
+		expectAdmin(t, d, "admin-1")
+		ids := []string{"c-1", "c-2"}
+		d.repo.EXPECT().BatchUpdateStatus(mock.Anything, ids, commentModel.StatusApproved).Return(nil).Once()
+		d.repo.EXPECT().
+			GetCommentByID(mock.Anything, "c-1").
+			Return(commentModel.Comment{ID: "c-1", Source: commentModel.SourceGuest, SpamLabel: commentModel.SpamVerdictHam}, nil).
+			Once()
+		d.repo.EXPECT().
+			GetCommentByID(mock.Anything, "c-2").
+			Return(commentModel.Comment{ID: "c-2", Source: commentModel.SourceSystem}, nil).
+			Once()
+		d.expectSetting(t, enabledSetting())
+
+		require.NoError(t, d.service().BatchAction(helpers.CtxAsUser("admin-1"), "approve", ids))
+	})
+}
+
+// --- 垃圾检测设置 -------------------------------------------------------------
+
+func TestUpdateSystemSetting_Spam(t *testing.T) {
+	t.Run("unknown provider is rejected", func(t *testing.T) {
+		d := newDeps(t)
+		expectAdmin(t, d, "admin-1")
+		in := enabledSetting()
+		in.Spam.Provider = "magic"
+		err := d.service().UpdateSystemSetting(helpers.CtxAsUser("admin-1"), in)
+		assertBiz(t, err, commonModel.ErrCodeInvalidRequest, "无效的垃圾检测后端")
+	})
+
+	t.Run("blank akismet key keeps the stored one and threshold is clamped", func(t *testing.T) {
+		d := newDeps(t)
+		expectAdmin(t, d, "admin-1")
+		current := enabledSetting()
+		current.Spam.AkismetKey = "old-key"
+		d.expectSetting(t, current)
+		var persisted string
+		d.kv.EXPECT().
+			Set(mock.Anything, commentModel.CommentSystemSettingKey, mock.Anything).
+			Run(func(_ context.Context, _ string, v string) { persisted = v }).
+			Return(nil).
+			Once()
+
+		in := enabledSetting()
+		in.Spam = commentModel.SpamSetting{Provider: commentModel.SpamProviderAkismet, Threshold: 2}
+		require.NoError(t, d.service().UpdateSystemSetting(helpers.CtxAsUser("admin-1"), in))
+
+		var saved commentModel.SystemSetting
+		require.NoError(t, json.Unmarshal([]byte(persisted), &saved))
+		assert.Equal(t, "old-key", saved.Spam.AkismetKey)
+		assert.Equal(t, 0.99, saved.Spam.Threshold)
+	})
+
+	t.Run("akismet key is write-only", func(t *testing.T) {
+		d := newDeps(t)
+		s := enabledSetting()
+		s.Spam.AkismetKey = "secret"
+		d.expectSetting(t, s)
+		got, err := d.service().GetSystemSetting(context.Background())
+		require.NoError(t, err)
+		assert.Empty(t, got.Spam.AkismetKey)
+		assert.True(t, got.Spam.AkismetKeySet)
+	})
+}
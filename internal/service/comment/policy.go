@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"gorm.io/gorm"
+)
+
+const secondsPerDay int64 = 24 * 60 * 60
+
+// echoPolicy 是某条 Echo 在当前全局设置下生效的评论策略。
+type echoPolicy struct {
+	policy          string
+	closedReason    model.ClosedReason
+	closesAt        int64 // 自动关闭时刻，0 表示不会自动关闭
+	loginRequired   bool
+	requireApproval bool
+}
+
+// resolveEchoPolicy 合并 Echo 自身的评论策略与全局设置：审核开关与「发布满 N 天自动关闭」。
+// 显式设为 open 的 Echo 不受自动关闭约束。
+func (s *CommentService) resolveEchoPolicy(
+	ctx context.Context,
+	setting model.SystemSetting,
+	echoID string,
+) (echoPolicy, error) {
+	echo, err := s.echoRepo.GetEchosById(ctx, echoID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return echoPolicy{}, err
+	}
+	if echo == nil || echo.ID == "" {
+		return echoPolicy{}, commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "评论的 Echo 不存在")
+	}
+
+	p := echoPolicy{policy: echo.CommentPolicy, requireApproval: setting.RequireApproval}
+	switch echo.CommentPolicy {
+	case echoModel.CommentPolicyClosed:
+		p.closedReason = model.ClosedReasonClosed
+	case echoModel.CommentPolicyLoginOnly:
+		p.loginRequired = true
+	case echoModel.CommentPolicyApproval:
+		p.requireApproval = true
+	}
+	if setting.AutoCloseDays > 0 && echo.CommentPolicy != echoModel.CommentPolicyOpen && p.closedReason == "" {
+		p.closesAt = echo.PublishedAt() + int64(setting.AutoCloseDays)*secondsPerDay
+		if time.Now().UTC().Unix() >= p.closesAt {
+			p.closedReason = model.ClosedReasonAutoClosed
+		}
+	}
+	return p, nil
+}
+
+// admit 判断评论者能否在这条 Echo 下评论；loggedIn 表示已登录（含集成令牌）。
+func (p echoPolicy) admit(loggedIn bool) error {
+	switch p.closedReason {
+	case model.ClosedReasonClosed:
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "该 Echo 已关闭评论")
+	case model.ClosedReasonAutoClosed:
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "该 Echo 发布已久，评论已自动关闭")
+	}
+	if p.loginRequired && !loggedIn {
+		return commonModel.NewBizError(commonModel.ErrCodeInvalidRequest, "该 Echo 仅允许登录用户评论")
+	}
+	return nil
+}
+
+// applyTo 把策略写进表单元信息，供评论框说明能否评论。
+func (p echoPolicy) applyTo(meta *model.FormMeta) {
+	meta.CommentPolicy = p.policy
+	meta.ClosedReason = p.closedReason
+	meta.LoginRequired = p.loginRequired
+	meta.RequireApproval = p.requireApproval
+	meta.ClosesAt = p.closesAt
+}
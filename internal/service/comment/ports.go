@@ -7,12 +7,14 @@ import (
 	"context"
 
 	model "github.com/lin-snow/ech0/internal/model/comment"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	commonService "github.com/lin-snow/ech0/internal/service/common"
+	"github.com/lin-snow/ech0/internal/spam"
 )
 
 type Service interface {
-	GetFormMeta(ctx context.Context, clientIP, apiBaseURL string) (model.FormMeta, error)
+	GetFormMeta(ctx context.Context, clientIP, apiBaseURL, echoID string) (model.FormMeta, error)
 	CreateComment(
 		ctx context.Context,
 		clientIP,
@@ -25,14 +27,23 @@ type Service interface {
 		userAgent string,
 		dto *model.CreateIntegrationCommentDto,
 	) (model.CreateCommentResult, error)
+	CreateFederatedComment(ctx context.Context, dto *model.CreateFederatedCommentDto) (model.CreateCommentResult, error)
+	FindFederatedComment(ctx context.Context, remoteID string) (model.Comment, error)
+	CreateWebmentionComment(ctx context.Context, dto *model.CreateWebmentionCommentDto) (model.CreateCommentResult, error)
+	RetractWebmentionComment(ctx context.Context, echoID, source string) error
 	ListPublicByEchoID(ctx context.Context, echoID string) ([]model.PublicComment, error)
 	ListPublicComments(ctx context.Context, limit int) ([]model.PublicComment, error)
+	ListPublicThread(ctx context.Context, id string) ([]model.PublicComment, error)
+	ToggleReaction(ctx context.Context, clientIP, id string, dto *model.ReactCommentDto) (model.ReactCommentResult, error)
 	ListPanelComments(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error)
 	GetCommentByID(ctx context.Context, id string) (model.Comment, error)
 	UpdateCommentStatus(ctx context.Context, id string, status model.Status) error
 	UpdateCommentHot(ctx context.Context, id string, hot bool) error
 	DeleteComment(ctx context.Context, id string) error
+	RestoreComment(ctx context.Context, id string) error
+	PurgeComment(ctx context.Context, id string) error
 	BatchAction(ctx context.Context, action string, ids []string) error
+	PurgeExpiredTrash(ctx context.Context, before int64) (int, error)
 	GetSystemSetting(ctx context.Context) (model.SystemSetting, error)
 	UpdateSystemSetting(ctx context.Context, setting model.SystemSetting) error
 	SendTestEmail(ctx context.Context, setting model.SystemSetting) error
@@ -42,13 +53,20 @@ type Repository interface {
 	CreateComment(ctx context.Context, c *model.Comment) error
 	ListPublicByEchoID(ctx context.Context, echoID string) ([]model.Comment, error)
 	ListPublicComments(ctx context.Context, limit int) ([]model.Comment, error)
+	ListPublicSubtree(ctx context.Context, path string) ([]model.Comment, error)
 	ListComments(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error)
 	GetCommentByID(ctx context.Context, id string) (model.Comment, error)
+	FindByRemoteID(ctx context.Context, remoteID string) (model.Comment, error)
+	FindByEchoAndRemoteID(ctx context.Context, echoID, remoteID string) (model.Comment, error)
 	UpdateCommentStatus(ctx context.Context, id string, status model.Status) error
 	UpdateCommentHot(ctx context.Context, id string, hot bool) error
+	UpdateSpamLabel(ctx context.Context, id string, label model.SpamVerdict) error
 	DeleteComment(ctx context.Context, id string) error
 	BatchUpdateStatus(ctx context.Context, ids []string, status model.Status) error
 	BatchDelete(ctx context.Context, ids []string) error
+	RestoreComments(ctx context.Context, ids []string) (int64, error)
+	PurgeComments(ctx context.Context, ids []string) (int64, error)
+	ListExpiredTrashed(ctx context.Context, before int64, limit int) ([]model.Comment, error)
 	CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error)
 	CountByEmailWithin(ctx context.Context, email string, seconds int64) (int64, error)
 	CountByUserWithin(ctx context.Context, userID string, seconds int64) (int64, error)
@@ -57,6 +75,17 @@ type Repository interface {
 		echoID, content, email, ipHash, userID string,
 		seconds int64,
 	) (bool, error)
+	AddReaction(ctx context.Context, reaction *model.CommentReaction) (bool, error)
+	RemoveReaction(ctx context.Context, commentID, emoji, actorKey string) (bool, error)
+	CountReactions(ctx context.Context, commentIDs []string) (map[string][]model.ReactionCount, error)
+	CountReactionsByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error)
+	CountReactionsByUserWithin(ctx context.Context, userID string, seconds int64) (int64, error)
+	spam.TokenStore
+}
+
+// EchoRepository 读取评论所属的 Echo，用于按其评论策略放行。
+type EchoRepository interface {
+	GetEchosById(ctx context.Context, id string) (*echoModel.Echo, error)
 }
 
 type CommonService = commonService.Service
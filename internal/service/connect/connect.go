@@ -16,6 +16,7 @@ import (
 	"github.com/lin-snow/ech0/internal/kvstore"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/connect"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/internal/transaction"
 	"github.com/lin-snow/ech0/internal/util/egress"
@@ -107,7 +108,7 @@ func (connectService *ConnectService) AddConnect(ctx context.Context, connected
 			return err
 		}
 
-		if !user.IsAdmin {
+		if !user.Can(userModel.PermSystemManage) {
 			return errors.New(commonModel.NO_PERMISSION_DENIED)
 		}
 
@@ -161,7 +162,7 @@ func (connectService *ConnectService) DeleteConnect(ctx context.Context, id stri
 			return err
 		}
 
-		if !user.IsAdmin {
+		if !user.Can(userModel.PermSystemManage) {
 			return errors.New(commonModel.NO_PERMISSION_DENIED)
 		}
 
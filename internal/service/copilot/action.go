@@ -0,0 +1,376 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/agent"
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"gorm.io/gorm"
+)
+
+// maxActionEchos 是单个动作可涉及的 Echo 数上限：批量打标签 / 改可见性一次最多改这么多条，
+// 既够「把讲 Go 的都打上 #golang」这类需求，又不至于一次确认改动过大。
+const maxActionEchos = 50
+
+// actionExcerptRunes 是动作目标预览里每条 Echo 摘要的长度。
+const actionExcerptRunes = 60
+
+// draftEchoArgs 是 draft_echo 的入参。
+type draftEchoArgs struct {
+	Content string   `json:"content"`
+	Tags    []string `json:"tags"`
+}
+
+// tagEchosArgs 是 tag_echos 的入参：add / remove 至少其一。
+type tagEchosArgs struct {
+	EchoIDs []string `json:"echo_ids"`
+	Add     []string `json:"add"`
+	Remove  []string `json:"remove"`
+}
+
+// setPrivacyArgs 是 set_echo_privacy 的入参；Private 必填，用指针区分「没给」与 false。
+type setPrivacyArgs struct {
+	EchoIDs []string `json:"echo_ids"`
+	Private *bool    `json:"private"`
+}
+
+// canWriteEchos 报告当前身份能否提出写动作：访问令牌须带 echo:write（与确认端点要求一致），
+// 会话登录不受 scope 约束。没有写权限时写类工具干脆不注入，模型也就不会提出注定无法执行的动作。
+func canWriteEchos(ctx context.Context) bool {
+	v := viewer.MustFromContext(ctx)
+	return v.TokenType() != authModel.TokenTypeAccess || slices.Contains(v.Scopes(), authModel.ScopeEchoWrite)
+}
+
+// writeTools 返回注入给 agent 的写类工具。它们都不直接改数据：Execute 只落一条 pending 动作，
+// 经 SSE action 事件交给前端，用户确认后由 ConfirmAction 经 EchoService 执行。
+func (s *CopilotService) writeTools(locale string, user chatUser) []agent.Tool {
+	return []agent.Tool{
+		s.draftEchoTool(locale, user),
+		s.tagEchosTool(locale, user),
+		s.setEchoPrivacyTool(locale, user),
+	}
+}
+
+func (s *CopilotService) draftEchoTool(locale string, user chatUser) agent.Tool {
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        copilotModel.ActionDraftEcho,
+			Description: "为用户起草一条新的 Echo（保存为草稿，不会发布）。调用后只会生成一条待确认操作，需用户在界面确认后才会真正保存。用户要你「写/起草/整理成一条 Echo」时用它。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"content":{"type":"string","description":"Echo 正文，支持 Markdown"},"tags":{"type":"array","items":{"type":"string"},"description":"可选，标签名（不带 #）"}},"required":["content"]}`),
+		},
+		Execute: func(ctx context.Context, args json.RawMessage) (agent.ToolOutput, error) {
+			var a draftEchoArgs
+			_ = json.Unmarshal(args, &a)
+			a.Content = strings.TrimSpace(a.Content)
+			if a.Content == "" {
+				return agent.ToolOutput{}, errors.New("draft_echo 需要 content")
+			}
+			return s.proposeAction(ctx, locale, &copilotModel.ChatAction{
+				UserID: user.ID,
+				Kind:   copilotModel.ActionDraftEcho,
+				Args:   copilotModel.ChatActionArgs{Content: a.Content, Tags: normalizeTagNames(a.Tags)},
+			})
+		},
+	}
+}
+
+func (s *CopilotService) tagEchosTool(locale string, user chatUser) agent.Tool {
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        copilotModel.ActionTagEchos,
+			Description: "给若干条 Echo 批量添加和/或移除标签。echo_ids 取自 search_echos 结果里的 [id:…]；先检索确定目标再调用。调用后只会生成一条待确认操作，需用户确认后才会执行。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"echo_ids":{"type":"array","items":{"type":"string"},"description":"目标 Echo 的 ID（最多 50 条）"},"add":{"type":"array","items":{"type":"string"},"description":"要添加的标签名（不带 #）"},"remove":{"type":"array","items":{"type":"string"},"description":"要移除的标签名（不带 #）"}},"required":["echo_ids"]}`),
+		},
+		Execute: func(ctx context.Context, args json.RawMessage) (agent.ToolOutput, error) {
+			var a tagEchosArgs
+			_ = json.Unmarshal(args, &a)
+			add, remove := normalizeTagNames(a.Add), normalizeTagNames(a.Remove)
+			if len(add) == 0 && len(remove) == 0 {
+				return agent.ToolOutput{}, errors.New("tag_echos 需要 add 或 remove 至少其一")
+			}
+			ids, targets, err := s.resolveActionTargets(ctx, user, a.EchoIDs)
+			if err != nil {
+				return agent.ToolOutput{}, err
+			}
+			return s.proposeAction(ctx, locale, &copilotModel.ChatAction{
+				UserID:  user.ID,
+				Kind:    copilotModel.ActionTagEchos,
+				Args:    copilotModel.ChatActionArgs{EchoIDs: ids, AddTags: add, RemoveTags: remove},
+				Targets: targets,
+			})
+		},
+	}
+}
+
+func (s *CopilotService) setEchoPrivacyTool(locale string, user chatUser) agent.Tool {
+	return agent.Tool{
+		Def: agent.ToolDef{
+			Name:        copilotModel.ActionSetPrivacy,
+			Description: "把若干条 Echo 设为私密（private=true）或公开（private=false）。echo_ids 取自 search_echos 结果里的 [id:…]。调用后只会生成一条待确认操作，需用户确认后才会执行。",
+			Parameters:  json.RawMessage(`{"type":"object","properties":{"echo_ids":{"type":"array","items":{"type":"string"},"description":"目标 Echo 的 ID（最多 50 条）"},"private":{"type":"boolean","description":"true 设为私密，false 设为公开"}},"required":["echo_ids","private"]}`),
+		},
+		Execute: func(ctx context.Context, args json.RawMessage) (agent.ToolOutput, error) {
+			var a setPrivacyArgs
+			_ = json.Unmarshal(args, &a)
+			if a.Private == nil {
+				return agent.ToolOutput{}, errors.New("set_echo_privacy 需要 private")
+			}
+			ids, targets, err := s.resolveActionTargets(ctx, user, a.EchoIDs)
+			if err != nil {
+				return agent.ToolOutput{}, err
+			}
+			return s.proposeAction(ctx, locale, &copilotModel.ChatAction{
+				UserID:  user.ID,
+				Kind:    copilotModel.ActionSetPrivacy,
+				Args:    copilotModel.ChatActionArgs{EchoIDs: ids, Private: *a.Private},
+				Targets: targets,
+			})
+		},
+	}
+}
+
+// proposeAction 把动作落为 pending，并告诉模型它尚未执行。Meta 携带动作本身，由 AskStream
+// 转成 SSE action 事件。
+func (s *CopilotService) proposeAction(
+	ctx context.Context,
+	locale string,
+	action *copilotModel.ChatAction,
+) (agent.ToolOutput, error) {
+	action.Status = copilotModel.ActionStatusPending
+	if err := s.actions.CreateChatAction(ctx, action); err != nil {
+		return agent.ToolOutput{}, err
+	}
+	return agent.ToolOutput{Content: pendingActionNoteFor(locale), Meta: action}, nil
+}
+
+// resolveActionTargets 去重并校验模型给的 echo_ids：每条都必须存在且属于当前对话用户，
+// 否则整体报错让模型修正（而不是悄悄丢掉几条）。返回规范化后的 ID 与供预览的摘要。
+func (s *CopilotService) resolveActionTargets(
+	ctx context.Context,
+	user chatUser,
+	echoIDs []string,
+) ([]string, []copilotModel.ChatActionTarget, error) {
+	var ids []string
+	for _, id := range echoIDs {
+		if id = strings.TrimSpace(id); id != "" && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil, errors.New("需要 echo_ids（取自 search_echos 结果里的 [id:…]）")
+	}
+	if len(ids) > maxActionEchos {
+		return nil, nil, fmt.Errorf("一次最多操作 %d 条 Echo，请缩小范围或分批进行", maxActionEchos)
+	}
+
+	targets := make([]copilotModel.ChatActionTarget, 0, len(ids))
+	var unknown []string
+	for _, id := range ids {
+		echo, err := s.echoService.GetEchoById(ctx, id)
+		if err != nil || echo == nil || echo.UserID != user.ID {
+			unknown = append(unknown, id)
+			continue
+		}
+		targets = append(targets, copilotModel.ChatActionTarget{
+			EchoID:  echo.ID,
+			Excerpt: normalizeThreadTitle(echo.Content, actionExcerptRunes),
+		})
+	}
+	if len(unknown) > 0 {
+		return nil, nil, fmt.Errorf("以下 echo_id 不存在或不属于当前用户：%s", strings.Join(unknown, ", "))
+	}
+	return ids, targets, nil
+}
+
+// ConfirmAction 执行当前用户的一条待确认动作。先把状态从 pending 条件更新为 running，
+// 保证重复点击或并发确认只执行一次；执行走 EchoService（沿用确认请求的身份），事件、权限与
+// 修订记录照常生效。结果（含部分成功时已改动的 Echo）回写到动作上，执行失败时返回该错误。
+func (s *CopilotService) ConfirmAction(ctx context.Context, id string) (*copilotModel.ChatAction, error) {
+	action, err := s.ownAction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	claimed, err := s.actions.TransitionChatAction(
+		ctx, action.UserID, action.ID, copilotModel.ActionStatusPending, copilotModel.ActionStatusRunning,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		return nil, errors.New(commonModel.CHAT_ACTION_NOT_PENDING)
+	}
+
+	affected, execErr := s.executeAction(ctx, action)
+	action.AffectedIDs = affected
+	action.Status = copilotModel.ActionStatusDone
+	if execErr != nil {
+		action.Status = copilotModel.ActionStatusFailed
+		action.Error = execErr.Error()
+	}
+	if err := s.actions.FinishChatAction(ctx, action); err != nil {
+		return nil, err
+	}
+	if execErr != nil {
+		return nil, execErr
+	}
+	return action, nil
+}
+
+// CancelAction 放弃当前用户的一条待确认动作；已执行或已取消的动作不能再取消。
+func (s *CopilotService) CancelAction(ctx context.Context, id string) (*copilotModel.ChatAction, error) {
+	action, err := s.ownAction(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	cancelled, err := s.actions.TransitionChatAction(
+		ctx, action.UserID, action.ID, copilotModel.ActionStatusPending, copilotModel.ActionStatusCancelled,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !cancelled {
+		return nil, errors.New(commonModel.CHAT_ACTION_NOT_PENDING)
+	}
+	action.Status = copilotModel.ActionStatusCancelled
+	return action, nil
+}
+
+// ownAction 取当前用户的动作；不存在与属于他人一样返回 CHAT_ACTION_NOT_FOUND。
+func (s *CopilotService) ownAction(ctx context.Context, id string) (*copilotModel.ChatAction, error) {
+	action, err := s.actions.GetChatAction(ctx, viewer.MustFromContext(ctx).UserID(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(commonModel.CHAT_ACTION_NOT_FOUND)
+	}
+	return action, err
+}
+
+// executeAction 按动作类型调用 EchoService，返回实际新建 / 修改了的 Echo。批量动作逐条执行，
+// 遇错即停：已改动的几条保留并如实返回，不做回滚。
+func (s *CopilotService) executeAction(ctx context.Context, action *copilotModel.ChatAction) ([]string, error) {
+	switch action.Kind {
+	case copilotModel.ActionDraftEcho:
+		echo := &echoModel.Echo{
+			Content: action.Args.Content,
+			Status:  echoModel.StatusDraft,
+			Tags:    tagsOf(action.Args.Tags),
+		}
+		if err := s.echoService.PostEcho(ctx, echo); err != nil {
+			return nil, err
+		}
+		return []string{echo.ID}, nil
+	case copilotModel.ActionTagEchos:
+		return s.updateEchos(ctx, action, func(echo *echoModel.Echo) bool {
+			names := retagNames(echo.Tags, action.Args.AddTags, action.Args.RemoveTags)
+			if slices.Equal(names, tagNamesOf(echo.Tags)) {
+				return false
+			}
+			echo.Tags = tagsOf(names)
+			return true
+		})
+	case copilotModel.ActionSetPrivacy:
+		return s.updateEchos(ctx, action, func(echo *echoModel.Echo) bool {
+			if echo.Private == action.Args.Private {
+				return false
+			}
+			echo.Private = action.Args.Private
+			return true
+		})
+	default:
+		return nil, errors.New(commonModel.INVALID_PARAMS)
+	}
+}
+
+// updateEchos 对动作的每条目标 Echo 取最新版本、应用 mutate 后经 UpdateEcho 回写；mutate 返回
+// false 表示无需改动（如标签已存在），跳过以免产生空修订。目标须仍属于动作的所有者。
+func (s *CopilotService) updateEchos(
+	ctx context.Context,
+	action *copilotModel.ChatAction,
+	mutate func(echo *echoModel.Echo) bool,
+) ([]string, error) {
+	var affected []string
+	for _, id := range action.Args.EchoIDs {
+		current, err := s.echoService.GetEchoById(ctx, id)
+		if err != nil {
+			return affected, err
+		}
+		if current == nil || current.UserID != action.UserID {
+			return affected, errors.New(commonModel.ECHO_NOT_FOUND)
+		}
+		// 在副本上改：GetEchoById 可能返回缓存里的同一对象。
+		echo := *current
+		echo.Tags = slices.Clone(current.Tags)
+		if !mutate(&echo) {
+			continue
+		}
+		if err := s.echoService.UpdateEcho(ctx, &echo); err != nil {
+			return affected, err
+		}
+		affected = append(affected, id)
+	}
+	return affected, nil
+}
+
+// retagNames 在已有标签上先移除 remove、再追加 add（保持原顺序、去重），返回新的标签名列表。
+func retagNames(current []echoModel.Tag, add, remove []string) []string {
+	var names []string
+	for _, name := range tagNamesOf(current) {
+		if !slices.Contains(remove, name) {
+			names = append(names, name)
+		}
+	}
+	for _, name := range add {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// normalizeTagNames 去掉模型常带的 # 前缀与空白，去重并丢弃空名。
+func normalizeTagNames(names []string) []string {
+	var out []string
+	for _, name := range names {
+		name = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+		if name != "" && !slices.Contains(out, name) {
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+func tagNamesOf(tags []echoModel.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, t := range tags {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// tagsOf 把标签名包装成只带 Name 的 Tag，由 EchoService.ProcessEchoTags 解析或新建。
+func tagsOf(names []string) []echoModel.Tag {
+	tags := make([]echoModel.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, echoModel.Tag{Name: name})
+	}
+	return tags
+}
+
+// pendingActionNoteFor 是写类工具回喂模型的结果：明确动作尚未执行，避免模型宣称「已完成」。
+func pendingActionNoteFor(locale string) string {
+	if localeIsZH(locale) {
+		return "已生成一条待确认操作，界面上会显示给用户。它尚未执行：用户点击确认后才会生效。请简要说明将要做什么并提醒用户确认，不要声称已经完成。"
+	}
+	return "A pending action has been created and is shown to the user. It has NOT run yet; it takes effect only after the user confirms it. Briefly explain what it will do and ask the user to confirm — do not claim it is done."
+}
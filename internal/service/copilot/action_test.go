@@ -0,0 +1,290 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	authModel "github.com/lin-snow/ech0/internal/model/auth"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"gorm.io/gorm"
+)
+
+// memActions 是内存版 ActionRepository，语义与 GORM 实现一致（按用户隔离、条件转移状态）。
+type memActions struct {
+	actions map[string]*copilotModel.ChatAction
+	nextID  int
+}
+
+func newMemActions() *memActions {
+	return &memActions{actions: map[string]*copilotModel.ChatAction{}}
+}
+
+func (m *memActions) CreateChatAction(_ context.Context, action *copilotModel.ChatAction) error {
+	m.nextID++
+	action.ID = "a" + strings.Repeat("0", m.nextID)
+	stored := *action
+	m.actions[action.ID] = &stored
+	return nil
+}
+
+func (m *memActions) GetChatAction(_ context.Context, userID, id string) (*copilotModel.ChatAction, error) {
+	a, ok := m.actions[id]
+	if !ok || a.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *a
+	return &cp, nil
+}
+
+func (m *memActions) ListChatActions(_ context.Context, userID string, ids []string) ([]copilotModel.ChatAction, error) {
+	var out []copilotModel.ChatAction
+	for _, id := range ids {
+		if a, ok := m.actions[id]; ok && a.UserID == userID {
+			out = append(out, *a)
+		}
+	}
+	return out, nil
+}
+
+func (m *memActions) TransitionChatAction(_ context.Context, userID, id, from, to string) (bool, error) {
+	a, ok := m.actions[id]
+	if !ok || a.UserID != userID || a.Status != from {
+		return false, nil
+	}
+	a.Status = to
+	return true, nil
+}
+
+func (m *memActions) FinishChatAction(_ context.Context, action *copilotModel.ChatAction) error {
+	a := m.actions[action.ID]
+	a.Status, a.AffectedIDs, a.Error = action.Status, action.AffectedIDs, action.Error
+	return nil
+}
+
+// writeEchoSvc 在 stubEchoSvc 之上记录写调用，GetEchoById 从 echos 里取。
+type writeEchoSvc struct {
+	stubEchoSvc
+	echos   map[string]*echoModel.Echo
+	posted  []echoModel.Echo
+	updated []echoModel.Echo
+}
+
+func (f *writeEchoSvc) GetEchoById(_ context.Context, id string) (*echoModel.Echo, error) {
+	if e, ok := f.echos[id]; ok {
+		return e, nil
+	}
+	return nil, errPropagate
+}
+
+func (f *writeEchoSvc) PostEcho(_ context.Context, echo *echoModel.Echo) error {
+	echo.ID = "new-echo"
+	f.posted = append(f.posted, *echo)
+	return nil
+}
+
+func (f *writeEchoSvc) UpdateEcho(_ context.Context, echo *echoModel.Echo) error {
+	f.updated = append(f.updated, *echo)
+	return nil
+}
+
+func newWriteFixture() (*CopilotService, *writeEchoSvc, *memActions) {
+	echoSvc := &writeEchoSvc{echos: map[string]*echoModel.Echo{
+		"e1": {ID: "e1", UserID: "u1", Content: "学 Go 的并发", Tags: []echoModel.Tag{{Name: "编程"}}},
+		"e2": {ID: "e2", UserID: "u1", Content: "Go 泛型笔记", Tags: []echoModel.Tag{{Name: "golang"}}},
+		"x1": {ID: "x1", UserID: "u2", Content: "别人的 Echo"},
+	}}
+	actions := newMemActions()
+	return &CopilotService{echoService: echoSvc, actions: actions}, echoSvc, actions
+}
+
+// 写类工具只落 pending 动作，不碰 EchoService 的写方法；Meta 携带动作供 SSE 下发。
+func TestTagEchosTool_ProposesWithoutWriting(t *testing.T) {
+	s, echoSvc, actions := newWriteFixture()
+	tool := s.tagEchosTool("zh-CN", chatUser{ID: "u1"})
+
+	out, err := tool.Execute(helpers.CtxAsUser("u1"), mustArgs(t, map[string]any{
+		"echo_ids": []string{"e1", "e2", "e1"},
+		"add":      []string{"#golang"},
+	}))
+	if err != nil {
+		t.Fatalf("execute: %v", err)
+	}
+	action, ok := out.Meta.(*copilotModel.ChatAction)
+	if !ok || action.Status != copilotModel.ActionStatusPending {
+		t.Fatalf("want pending action meta, got %#v", out.Meta)
+	}
+	if !slices.Equal(action.Args.EchoIDs, []string{"e1", "e2"}) || !slices.Equal(action.Args.AddTags, []string{"golang"}) {
+		t.Fatalf("args not normalized: %+v", action.Args)
+	}
+	if len(action.Targets) != 2 || action.Targets[0].Excerpt != "学 Go 的并发" {
+		t.Fatalf("targets preview: %+v", action.Targets)
+	}
+	if !strings.Contains(out.Content, "尚未执行") {
+		t.Fatalf("tool output must tell the model the action has not run: %q", out.Content)
+	}
+	if len(echoSvc.updated) != 0 || len(actions.actions) != 1 {
+		t.Fatalf("proposal must not write echos: updated=%d actions=%d", len(echoSvc.updated), len(actions.actions))
+	}
+}
+
+// 不存在或属于他人的 echo_id 整体报错让模型修正，且不落动作。
+func TestTagEchosTool_RejectsForeignEchos(t *testing.T) {
+	s, _, actions := newWriteFixture()
+	tool := s.tagEchosTool("zh-CN", chatUser{ID: "u1"})
+
+	_, err := tool.Execute(helpers.CtxAsUser("u1"), mustArgs(t, map[string]any{
+		"echo_ids": []string{"e1", "x1", "missing"},
+		"add":      []string{"golang"},
+	}))
+	if err == nil || !strings.Contains(err.Error(), "x1, missing") {
+		t.Fatalf("want foreign/missing ids reported, got %v", err)
+	}
+	if len(actions.actions) != 0 {
+		t.Fatalf("no action should be stored on invalid targets")
+	}
+
+	if _, err := tool.Execute(helpers.CtxAsUser("u1"), mustArgs(t, map[string]any{"echo_ids": []string{"e1"}})); err == nil {
+		t.Fatalf("want error when neither add nor remove is given")
+	}
+}
+
+// 确认后经 UpdateEcho 改标签；已满足的 Echo 跳过；重复确认被拒。
+func TestConfirmAction_TagEchos(t *testing.T) {
+	s, echoSvc, actions := newWriteFixture()
+	ctx := helpers.CtxAsUser("u1")
+	out, err := s.tagEchosTool("zh-CN", chatUser{ID: "u1"}).Execute(ctx, mustArgs(t, map[string]any{
+		"echo_ids": []string{"e1", "e2"},
+		"add":      []string{"golang"},
+		"remove":   []string{"编程"},
+	}))
+	if err != nil {
+		t.Fatalf("propose: %v", err)
+	}
+	id := out.Meta.(*copilotModel.ChatAction).ID
+
+	got, err := s.ConfirmAction(ctx, id)
+	if err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+	if got.Status != copilotModel.ActionStatusDone || !slices.Equal(got.AffectedIDs, []string{"e1"}) {
+		t.Fatalf("want done with only e1 affected, got %+v", got)
+	}
+	if len(echoSvc.updated) != 1 || !slices.Equal(tagNamesOf(echoSvc.updated[0].Tags), []string{"golang"}) {
+		t.Fatalf("unexpected update: %+v", echoSvc.updated)
+	}
+	if names := tagNamesOf(echoSvc.echos["e1"].Tags); !slices.Equal(names, []string{"编程"}) {
+		t.Fatalf("source echo must not be mutated in place, got %v", names)
+	}
+	if actions.actions[id].Status != copilotModel.ActionStatusDone {
+		t.Fatalf("result not persisted: %+v", actions.actions[id])
+	}
+
+	if _, err := s.ConfirmAction(ctx, id); err == nil || err.Error() != commonModel.CHAT_ACTION_NOT_PENDING {
+		t.Fatalf("second confirm should be rejected, got %v", err)
+	}
+}
+
+func TestConfirmAction_DraftAndPrivacy(t *testing.T) {
+	s, echoSvc, _ := newWriteFixture()
+	ctx := helpers.CtxAsUser("u1")
+	user := chatUser{ID: "u1"}
+
+	out, err := s.draftEchoTool("en-US", user).Execute(ctx, mustArgs(t, map[string]any{
+		"content": "  This week: Go generics  ",
+		"tags":    []string{"weekly"},
+	}))
+	if err != nil {
+		t.Fatalf("propose draft: %v", err)
+	}
+	draft, err := s.ConfirmAction(ctx, out.Meta.(*copilotModel.ChatAction).ID)
+	if err != nil {
+		t.Fatalf("confirm draft: %v", err)
+	}
+	if len(echoSvc.posted) != 1 || echoSvc.posted[0].Status != echoModel.StatusDraft ||
+		echoSvc.posted[0].Content != "This week: Go generics" || !slices.Equal(draft.AffectedIDs, []string{"new-echo"}) {
+		t.Fatalf("draft should be posted as status=draft: %+v / %+v", echoSvc.posted, draft)
+	}
+
+	out, err = s.setEchoPrivacyTool("en-US", user).Execute(ctx, mustArgs(t, map[string]any{
+		"echo_ids": []string{"e2"},
+		"private":  true,
+	}))
+	if err != nil {
+		t.Fatalf("propose privacy: %v", err)
+	}
+	if _, err := s.ConfirmAction(ctx, out.Meta.(*copilotModel.ChatAction).ID); err != nil {
+		t.Fatalf("confirm privacy: %v", err)
+	}
+	if len(echoSvc.updated) != 1 || !echoSvc.updated[0].Private {
+		t.Fatalf("want e2 updated to private, got %+v", echoSvc.updated)
+	}
+
+	if _, err := s.setEchoPrivacyTool("en-US", user).Execute(ctx, mustArgs(t, map[string]any{
+		"echo_ids": []string{"e2"},
+	})); err == nil {
+		t.Fatalf("set_echo_privacy without private should fail")
+	}
+}
+
+// 取消只对本人的 pending 动作有效；他人的动作与不存在一样。
+func TestCancelAction(t *testing.T) {
+	s, echoSvc, _ := newWriteFixture()
+	out, err := s.draftEchoTool("zh-CN", chatUser{ID: "u1"}).Execute(helpers.CtxAsUser("u1"), mustArgs(t, map[string]any{
+		"content": "草稿",
+	}))
+	if err != nil {
+		t.Fatalf("propose: %v", err)
+	}
+	id := out.Meta.(*copilotModel.ChatAction).ID
+
+	if _, err := s.CancelAction(helpers.CtxAsUser("u2"), id); err == nil || err.Error() != commonModel.CHAT_ACTION_NOT_FOUND {
+		t.Fatalf("other user should get not found, got %v", err)
+	}
+	got, err := s.CancelAction(helpers.CtxAsUser("u1"), id)
+	if err != nil || got.Status != copilotModel.ActionStatusCancelled {
+		t.Fatalf("cancel: %+v %v", got, err)
+	}
+	if _, err := s.ConfirmAction(helpers.CtxAsUser("u1"), id); err == nil || err.Error() != commonModel.CHAT_ACTION_NOT_PENDING {
+		t.Fatalf("cancelled action must not run, got %v", err)
+	}
+	if len(echoSvc.posted) != 0 {
+		t.Fatalf("cancelled draft must not be posted")
+	}
+}
+
+func TestCanWriteEchos(t *testing.T) {
+	cases := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{"session", helpers.CtxAsToken("u1", authModel.TokenTypeSession, nil, nil, ""), true},
+		{"access with echo:write", helpers.CtxAsToken("u1", authModel.TokenTypeAccess,
+			[]string{authModel.ScopeAdminSettings, authModel.ScopeEchoWrite}, nil, "j"), true},
+		{"access without echo:write", helpers.CtxAsToken("u1", authModel.TokenTypeAccess,
+			[]string{authModel.ScopeAdminSettings}, nil, "j"), false},
+	}
+	for _, c := range cases {
+		if got := canWriteEchos(c.ctx); got != c.want {
+			t.Fatalf("%s: canWriteEchos = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestRetagNames(t *testing.T) {
+	current := []echoModel.Tag{{Name: "a"}, {Name: "b"}}
+	if got := retagNames(current, []string{"c", "a"}, []string{"b"}); !slices.Equal(got, []string{"a", "c"}) {
+		t.Fatalf("retagNames = %v", got)
+	}
+	if got := normalizeTagNames([]string{" #go ", "go", "", "#"}); !slices.Equal(got, []string{"go"}) {
+		t.Fatalf("normalizeTagNames = %v", got)
+	}
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/lin-snow/ech0/internal/kvstore"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/test/helpers"
@@ -41,7 +42,7 @@ func TestAskStream_StreamingUnsupported(t *testing.T) {
 	s := &CopilotService{durableKV: kvstore.NewMemory()}
 	w := &noFlushWriter{h: http.Header{}}
 
-	err := s.AskStream(helpers.CtxAsUser("u1"), "hi", "zh-CN", "", w)
+	err := s.AskStream(helpers.CtxAsUser("u1"), "", "hi", "zh-CN", "", w)
 	if err == nil || !strings.Contains(err.Error(), "streaming unsupported") {
 		t.Fatalf("want streaming-unsupported error, got %v", err)
 	}
@@ -52,7 +53,7 @@ func TestAskStream_EmptyQuestion(t *testing.T) {
 	s := &CopilotService{durableKV: kvstore.NewMemory()}
 	rec := httptest.NewRecorder()
 
-	if err := s.AskStream(helpers.CtxAsUser("u1"), "   ", "zh-CN", "", rec); err != nil {
+	if err := s.AskStream(helpers.CtxAsUser("u1"), "", "   ", "zh-CN", "", rec); err != nil {
 		t.Fatalf("AskStream should return nil and report via SSE, got %v", err)
 	}
 	body := rec.Body.String()
@@ -72,7 +73,7 @@ func TestAskStream_UserLookupError(t *testing.T) {
 	}
 	rec := httptest.NewRecorder()
 
-	if err := s.AskStream(helpers.CtxAsUser("u1"), "你好", "zh-CN", "", rec); err != nil {
+	if err := s.AskStream(helpers.CtxAsUser("u1"), "", "你好", "zh-CN", "", rec); err != nil {
 		t.Fatalf("AskStream should return nil, got %v", err)
 	}
 	if body := rec.Body.String(); !strings.Contains(body, "event: error") || !strings.Contains(body, "user gone") {
@@ -80,6 +81,28 @@ func TestAskStream_UserLookupError(t *testing.T) {
 	}
 }
 
+// 线程不存在（或属于他人）→ SSE error CHAT_THREAD_NOT_FOUND，不会退化为新建线程。
+func TestAskStream_ThreadNotFound(t *testing.T) {
+	repo := newMemThreads()
+	_ = repo.CreateChatThread(context.Background(), &copilotModel.ChatThread{UserID: "u2", Title: "别人的"})
+	s := &CopilotService{
+		durableKV:  kvstore.NewMemory(),
+		userReader: &stubUserReader{user: userModel.User{ID: "u1", Username: "alice"}},
+		threads:    repo,
+	}
+	rec := httptest.NewRecorder()
+
+	if err := s.AskStream(helpers.CtxAsUser("u1"), "t1", "你好", "zh-CN", "", rec); err != nil {
+		t.Fatalf("AskStream should return nil, got %v", err)
+	}
+	if body := rec.Body.String(); !strings.Contains(body, "event: error") || !strings.Contains(body, commonModel.CHAT_THREAD_NOT_FOUND) {
+		t.Fatalf("expected SSE thread-not-found error, got %q", body)
+	}
+	if len(repo.threads) != 1 {
+		t.Fatalf("a missing thread must not be recreated, got %d threads", len(repo.threads))
+	}
+}
+
 // Agent 设置缺失 → SSE error AGENT_SETTING_NOT_FOUND。
 func TestAskStream_AgentSettingMissing(t *testing.T) {
 	s := &CopilotService{
@@ -88,7 +111,7 @@ func TestAskStream_AgentSettingMissing(t *testing.T) {
 	}
 	rec := httptest.NewRecorder()
 
-	if err := s.AskStream(helpers.CtxAsUser("u1"), "你好", "zh-CN", "", rec); err != nil {
+	if err := s.AskStream(helpers.CtxAsUser("u1"), "", "你好", "zh-CN", "", rec); err != nil {
 		t.Fatalf("AskStream should return nil, got %v", err)
 	}
 	if body := rec.Body.String(); !strings.Contains(body, "event: error") || !strings.Contains(body, commonModel.AGENT_SETTING_NOT_FOUND) {
@@ -108,7 +131,7 @@ func TestAskStream_AgentRunValidationError(t *testing.T) {
 	}
 	rec := httptest.NewRecorder()
 
-	if err := s.AskStream(helpers.CtxAsUser("u1"), "你好", "zh-CN", "Asia/Shanghai", rec); err != nil {
+	if err := s.AskStream(helpers.CtxAsUser("u1"), "", "你好", "zh-CN", "Asia/Shanghai", rec); err != nil {
 		t.Fatalf("AskStream should return nil, got %v", err)
 	}
 	if body := rec.Body.String(); !strings.Contains(body, "event: error") || !strings.Contains(body, commonModel.AGENT_NOT_ENABLED) {
@@ -15,10 +15,12 @@ import (
 	"github.com/lin-snow/ech0/internal/agent"
 	"github.com/lin-snow/ech0/internal/config"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
 	"github.com/lin-snow/ech0/pkg/viewer"
+	"gorm.io/gorm"
 )
 
 // chatTemperature 是 Chat 生成温度
@@ -36,14 +38,16 @@ func (s *CopilotService) agentSetting(ctx context.Context) (settingModel.AgentSe
 	return setting, nil
 }
 
-// AskStream 以 Agent（function calling）形态执行一轮问答：模型在一次对话内自主决定
-// 是否检索、检索几次（search_echos 工具），全过程以 SSE 写入 w。
+// AskStream 以 Agent（function calling）形态在线程 threadID 里执行一轮问答：模型在一次对话内
+// 自主决定是否检索、检索几次（search_echos 工具），全过程以 SSE 写入 w。threadID 为空表示
+// 新对话，本轮成功落盘时才新建线程。
 //
 // 设计上：尽早写出 SSE 头，之后所有错误都以 SSE "error" 事件回传，而非 HTTP 状态码。
 // SSE 事件：searching（模型决定检索）/ sources（命中来源，可多次）/ reasoning（推理增量，
 // 推理模型才有）/ reasoning_done（推理结束，含耗时 duration_ms）/ delta（文本增量）/
-// done（收尾）/ error（中止）。
-func (s *CopilotService) AskStream(ctx context.Context, question string, locale string, timezone string, w http.ResponseWriter) error {
+// action（写类工具提出的待确认动作，经 ConfirmAction 放行后才执行）/
+// thread（本轮已落盘，携带所在线程）/ done（收尾）/ error（中止）。
+func (s *CopilotService) AskStream(ctx context.Context, threadID, question, locale, timezone string, w http.ResponseWriter) error {
 	flusher, ok := w.(http.Flusher)
 	if !ok {
 		return errors.New("streaming unsupported")
@@ -61,7 +65,7 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 		return nil
 	}
 
-	// 登录用户：ID 用于持久化会话 + 把检索按作者收口（SQL 走 user_id），Username 用于
+	// 登录用户：ID 用于线程归属 + 把检索按作者收口（SQL 走 user_id），Username 用于
 	// 向量检索按作者收口 + 注入 system prompt。多用户实例下据此隔离他人 Echo（含私密）。
 	// 解析失败直接以 SSE error 中止——绝不退化成不收口检索（防泄露）。
 	userID := viewer.MustFromContext(ctx).UserID()
@@ -71,9 +75,23 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 		return nil
 	}
 	user := chatUser{ID: currentUser.ID, Username: currentUser.Username}
+
+	// 指定了线程则必须是本人的线程；他人线程与不存在一样报错，不泄露其存在。
+	var thread *copilotModel.ChatThread
+	if threadID = strings.TrimSpace(threadID); threadID != "" {
+		if thread, err = s.threads.GetChatThread(ctx, userID, threadID); err != nil {
+			msg := err.Error()
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				msg = commonModel.CHAT_THREAD_NOT_FOUND
+			}
+			writeSSE(w, flusher, "error", map[string]string{"message": msg})
+			return nil
+		}
+	}
 	// 收集本轮 assistant 文本与命中来源，正常收尾时一并持久化。
 	var assistantBuf strings.Builder
 	var collectedSources []embeddingModel.SearchResult
+	var actionIDs []string
 	// 推理（reasoning）分流收集：reasoningBuf 累积思考文本，计时从首个 reasoning 增量起、到首个答案
 	// 增量止（或收尾兜底），算出 reasoningMs 供前端展示「已思考（用时 X 秒）」并随会话持久化。
 	var reasoningBuf strings.Builder
@@ -104,23 +122,29 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 	today := time.Now().UTC().In(loc).Format("2006-01-02")
 	tagNames := tagNamesForPrompt(allTags)
 
-	// 整请求 token 护栏：从历史预算里扣掉固定开销（system prompt + 工具定义），让历史让路，
+	// 整请求 token 护栏：从线程的历史预算里扣掉固定开销（system prompt + 工具定义），让历史让路，
 	// 避免 system + 工具定义 + 多轮历史叠加撑爆上下文窗口（不足下限时至少保留最近若干轮）。
-	historyBudget := max(maxHistoryTokens-estimateTokens(buildSystemPrompt(locale, today, tagNames, currentUser.Username))-toolDefTokenEstimate, minHistoryTokens)
+	historyBudget := max(historyBudgetOf(thread)-estimateTokens(buildSystemPrompt(locale, today, tagNames, currentUser.Username))-toolDefTokenEstimate, minHistoryTokens)
 
-	// 多轮记忆：加载已持久化的会话并投影成模型历史（在 persistTurn 之前加载，本轮 question
+	// 多轮记忆：加载线程已持久化的消息并投影成模型历史（在 persistTurn 之前加载，本轮 question
 	// 不在其中，由 buildChatMessages 单独追加，不重复计入）。
-	history := historyForModel(s.loadSession(ctx, userID), locale, historyBudget, loc)
+	history := historyForModel(s.threadHistory(ctx, thread), locale, historyBudget, loc)
+
+	tools := []agent.Tool{
+		s.searchEchosTool(allTags, agentSetting.Multimodal, locale, loc, agentSetting.ContextWindow, user), // 点查：top-k 检索
+		s.summarizeEchosTool(allTags, agentSetting, locale, loc, user),                                     // 聚合：区间穷举 + 窗口自适应总结
+		s.statsOverviewTool(allTags, locale, loc, user),                                                    // 量化：区间精确统计（纯 SQL）
+	}
+	// 写类工具只提出待确认动作，身份没有 echo:write 时不注入（确认端点同样要求该 scope）。
+	if canWriteEchos(ctx) {
+		tools = append(tools, s.writeTools(locale, user)...)
+	}
 
 	temp := chatTemperature // 取地址需可寻址的局部变量（chatTemperature 是 const）
 	stream, err := agent.Run(ctx, agent.RunRequest{
-		Setting:  agentSetting,
-		Messages: buildChatMessages(history, question, locale, today, tagNames, currentUser.Username),
-		Tools: []agent.Tool{
-			s.searchEchosTool(allTags, agentSetting.Multimodal, locale, loc, agentSetting.ContextWindow, user), // 点查：top-k 检索
-			s.summarizeEchosTool(allTags, agentSetting, locale, loc, user),                                     // 聚合：区间穷举 + 窗口自适应总结
-			s.statsOverviewTool(allTags, locale, loc, user),                                                    // 量化：区间精确统计（纯 SQL）
-		},
+		Setting:          agentSetting,
+		Messages:         buildChatMessages(history, question, locale, today, tagNames, currentUser.Username),
+		Tools:            tools,
 		MaxRounds:        config.Config().Agent.MaxRounds,
 		Temp:             &temp,
 		Strings:          runStringsFor(locale),
@@ -132,6 +156,20 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 		return nil
 	}
 
+	// finish 正常收尾：落盘本轮并告知前端所在线程，再发 done。
+	finish := func() {
+		endReasoning() // 纯推理无答案时也定格耗时
+		saved := s.persistTurn(ctx, userID, thread, question, assistantTurn{
+			answer: assistantBuf.String(), sources: collectedSources,
+			reasoning: reasoningBuf.String(), reasoningMs: reasoningMs,
+			actionIDs: actionIDs,
+		})
+		if saved != nil {
+			writeSSE(w, flusher, "thread", saved)
+		}
+		writeSSE(w, flusher, "done", map[string]bool{"done": true})
+	}
+
 	keepAlive := time.NewTicker(15 * time.Second)
 	defer keepAlive.Stop()
 
@@ -145,12 +183,7 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 		case ev, ok := <-stream:
 			if !ok {
 				// Run 正常会在关闭前发 done/error；兜底以 done 收尾
-				endReasoning() // 纯推理无答案时也定格耗时
-				s.persistTurn(ctx, userID, question, assistantTurn{
-					answer: assistantBuf.String(), sources: collectedSources,
-					reasoning: reasoningBuf.String(), reasoningMs: reasoningMs,
-				})
-				writeSSE(w, flusher, "done", map[string]bool{"done": true})
+				finish()
 				return nil
 			}
 			switch ev.Kind {
@@ -174,8 +207,9 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 					"query": searchHintOf(ev.ToolArgs),
 				})
 			case agent.AgentToolResult:
-				// 两类工具结果的 Meta 形状不同：search_echos → []SearchResult（sources 引用），
-				// summarize_echos → aggregateCoverage（coverage 覆盖度）。按类型分流到各自 SSE 事件，
+				// 各类工具结果的 Meta 形状不同：search_echos → []SearchResult（sources 引用），
+				// summarize_echos → aggregateCoverage（coverage 覆盖度），写类工具 → *ChatAction
+				// （action 待确认动作）。按类型分流到各自 SSE 事件，
 				// 既不把覆盖度当 sources 数组喂坏前端，也保持「加法不替换」（旧前端忽略未知 coverage）。
 				switch meta := ev.Meta.(type) {
 				case []embeddingModel.SearchResult:
@@ -183,14 +217,12 @@ func (s *CopilotService) AskStream(ctx context.Context, question string, locale
 					writeSSE(w, flusher, "sources", meta)
 				case aggregateCoverage:
 					writeSSE(w, flusher, "coverage", meta)
+				case *copilotModel.ChatAction:
+					actionIDs = append(actionIDs, meta.ID)
+					writeSSE(w, flusher, "action", meta)
 				}
 			case agent.AgentDone:
-				endReasoning() // 纯推理无答案时也定格耗时
-				s.persistTurn(ctx, userID, question, assistantTurn{
-					answer: assistantBuf.String(), sources: collectedSources,
-					reasoning: reasoningBuf.String(), reasoningMs: reasoningMs,
-				})
-				writeSSE(w, flusher, "done", map[string]bool{"done": true})
+				finish()
 				return nil
 			case agent.AgentError:
 				writeSSE(w, flusher, "error", map[string]string{"message": ev.Err.Error()})
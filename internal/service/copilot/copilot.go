@@ -10,11 +10,13 @@ import (
 )
 
 // CopilotService 是 Copilot 域的统一服务，同时实现 SummaryService 与 ChatService。
-// 近期总结逻辑见 summary.go，Chat 流式问答见 chat.go。
+// 近期总结逻辑见 summary.go，Chat 流式问答见 chat.go，写类工具与待确认动作见 action.go。
 type CopilotService struct {
 	echoService    EchoService
-	embedding      EmbeddingService
-	userReader     UserReader // 取当前对话用户：展示名 + 检索按作者收口
+	search         SearchService // search_echos 工具的关键词 + 语义混合检索
+	userReader     UserReader    // 取当前对话用户：展示名 + 检索按作者收口
+	threads        ThreadRepository
+	actions        ActionRepository // 写类工具提出、待用户确认的动作
 	durableKV      kvstore.Store
 	storage        *storage.Manager // 多模态：读取命中 Echo 配图字节用于注入模型
 	recentGenGroup singleflight.Group
@@ -34,15 +36,19 @@ type chatUser struct {
 
 func NewCopilotService(
 	echoService EchoService,
-	embedding EmbeddingService,
+	search SearchService,
 	userReader UserReader,
+	threads ThreadRepository,
+	actions ActionRepository,
 	durableKV kvstore.Store,
 	storageManager *storage.Manager,
 ) *CopilotService {
 	return &CopilotService{
 		echoService: echoService,
-		embedding:   embedding,
+		search:      search,
 		userReader:  userReader,
+		threads:     threads,
+		actions:     actions,
 		durableKV:   durableKV,
 		storage:     storageManager,
 	}
@@ -5,72 +5,53 @@ package service
 
 import (
 	"context"
+	"errors"
 	"testing"
 
-	"github.com/lin-snow/ech0/internal/kvstore"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
-	"github.com/lin-snow/ech0/internal/test/helpers"
 )
 
-// loadSession 在 userID 为空、KV 未命中、JSON 损坏时都 best-effort 返回 nil。
-func TestLoadSession_BestEffort(t *testing.T) {
-	t.Run("empty userID", func(t *testing.T) {
-		s := &CopilotService{durableKV: kvstore.NewMemory()}
-		if got := s.loadSession(context.Background(), ""); got != nil {
-			t.Fatalf("empty userID should yield nil, got %#v", got)
-		}
-	})
-	t.Run("kv miss", func(t *testing.T) {
-		s := &CopilotService{durableKV: kvstore.NewMemory()}
-		if got := s.loadSession(context.Background(), "u1"); got != nil {
-			t.Fatalf("kv miss should yield nil, got %#v", got)
-		}
-	})
-	t.Run("corrupt json", func(t *testing.T) {
-		kv := kvstore.NewMemory()
-		if err := kv.Set(context.Background(), chatSessionKey("u1"), "{not json"); err != nil {
-			t.Fatalf("seed: %v", err)
-		}
-		s := &CopilotService{durableKV: kv}
-		if got := s.loadSession(context.Background(), "u1"); got != nil {
-			t.Fatalf("corrupt json should yield nil, got %#v", got)
-		}
-	})
-}
-
-// persistTurn：答案为空且无来源 → 视为空轮，跳过落盘（不留永久空气泡）。
+// persistTurn：答案为空且无来源 → 视为空轮，跳过落盘，也不会为它新建线程。
 func TestPersistTurn_SkipsEmpty(t *testing.T) {
-	kv := kvstore.NewMemory()
-	s := &CopilotService{durableKV: kv}
-
-	s.persistTurn(context.Background(), "u1", "问题", assistantTurn{answer: "   "})
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
 
-	if got := s.loadSession(context.Background(), "u1"); got != nil {
+	if got := s.persistTurn(context.Background(), "u1", nil, "问题", assistantTurn{answer: "   "}); got != nil {
 		t.Fatalf("empty turn should not persist, got %#v", got)
 	}
+	if len(repo.threads) != 0 {
+		t.Fatalf("empty turn should not create a thread, got %d", len(repo.threads))
+	}
 }
 
-// persistTurn：有答案时写入 user + assistant 两条，保留来源/推理元数据。
-func TestPersistTurn_WritesUserAndAssistant(t *testing.T) {
-	kv := kvstore.NewMemory()
-	s := &CopilotService{durableKV: kv}
+// persistTurn：未指定线程时以问题命名并新建，再写入 user + assistant 两条，保留来源/推理元数据。
+func TestPersistTurn_CreatesThreadLazily(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
 
-	s.persistTurn(context.Background(), "u1", "今年读了什么", assistantTurn{
+	thread := s.persistTurn(context.Background(), "u1", nil, "今年读了什么", assistantTurn{
 		answer:      "你读了三体",
 		sources:     []embeddingModel.SearchResult{{EchoID: "e1", Content: "三体"}},
 		reasoning:   "想了想",
 		reasoningMs: 1234,
 	})
+	if thread == nil || thread.ID == "" {
+		t.Fatalf("want a freshly created thread, got %#v", thread)
+	}
+	if thread.UserID != "u1" || thread.Title != "今年读了什么" || thread.MessageCount != 2 {
+		t.Fatalf("unexpected thread: %+v", thread)
+	}
 
-	msgs := s.loadSession(context.Background(), "u1")
+	msgs := repo.messages[thread.ID]
 	if len(msgs) != 2 {
 		t.Fatalf("want 2 messages, got %d (%#v)", len(msgs), msgs)
 	}
-	if msgs[0].Role != "user" || msgs[0].Content != "今年读了什么" {
+	if msgs[0].Role != copilotModel.RoleUser || msgs[0].Content != "今年读了什么" {
 		t.Fatalf("first message should be the user turn, got %+v", msgs[0])
 	}
 	a := msgs[1]
-	if a.Role != "assistant" || a.Content != "你读了三体" {
+	if a.Role != copilotModel.RoleAssistant || a.Content != "你读了三体" {
 		t.Fatalf("second message should be the assistant turn, got %+v", a)
 	}
 	if len(a.Sources) != 1 || a.Sources[0].EchoID != "e1" {
@@ -81,85 +62,72 @@ func TestPersistTurn_WritesUserAndAssistant(t *testing.T) {
 	}
 }
 
-// appendTurn：超过 maxStoredChatMessages 时只保留最近 N 条。
-func TestAppendTurn_CapsAtMax(t *testing.T) {
-	kv := kvstore.NewMemory()
-	s := &CopilotService{durableKV: kv}
-
-	// 一次性追加 maxStoredChatMessages + 5 条，确认封顶且保留的是最近的。
-	turns := make([]ChatMessage, 0, maxStoredChatMessages+5)
-	for i := 0; i < maxStoredChatMessages+5; i++ {
-		turns = append(turns, ChatMessage{Role: "user", Content: string(rune('A' + i%26))})
+// persistTurn：已有线程时追加到末尾；未命名的线程以本轮问题补上标题。
+func TestPersistTurn_AppendsAndNamesUntitled(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	thread := &copilotModel.ChatThread{UserID: "u1"}
+	if err := repo.CreateChatThread(context.Background(), thread); err != nil {
+		t.Fatalf("seed: %v", err)
 	}
-	s.appendTurn(context.Background(), "u1", turns...)
 
-	msgs := s.loadSession(context.Background(), "u1")
-	if len(msgs) != maxStoredChatMessages {
-		t.Fatalf("session should be capped at %d, got %d", maxStoredChatMessages, len(msgs))
+	s.persistTurn(context.Background(), "u1", thread, "第一问", assistantTurn{answer: "一"})
+	s.persistTurn(context.Background(), "u1", thread, "第二问", assistantTurn{answer: "二"})
+
+	if got := repo.threads[thread.ID].Title; got != "第一问" {
+		t.Fatalf("untitled thread should take the first question as title, got %q", got)
 	}
-	// 最后一条应等于最后追加的那条（最近保留）。
-	if msgs[len(msgs)-1].Content != turns[len(turns)-1].Content {
-		t.Fatalf("cap should keep the most recent turn, got %q", msgs[len(msgs)-1].Content)
+	msgs := repo.messages[thread.ID]
+	if len(msgs) != 4 || msgs[2].Content != "第二问" || msgs[3].Seq != 3 {
+		t.Fatalf("second turn should be appended in order, got %+v", msgs)
 	}
 }
 
-// appendTurn：userID 为空直接跳过，不落任何键。
-func TestAppendTurn_EmptyUserSkips(t *testing.T) {
-	kv := kvstore.NewMemory()
-	s := &CopilotService{durableKV: kv}
-
-	s.appendTurn(context.Background(), "", ChatMessage{Role: "user", Content: "x"})
+// persistTurn：落盘失败只记日志，返回 nil（前端因此收不到 thread 事件）。
+func TestPersistTurn_AppendErrorReturnsNil(t *testing.T) {
+	repo := newMemThreads()
+	repo.appendErr = errors.New("disk full")
+	s := &CopilotService{threads: repo}
 
-	if got := s.loadSession(context.Background(), "u1"); got != nil {
-		t.Fatalf("empty userID append should not persist anything, got %#v", got)
+	if got := s.persistTurn(context.Background(), "u1", nil, "问题", assistantTurn{answer: "答"}); got != nil {
+		t.Fatalf("failed append should yield nil, got %#v", got)
 	}
 }
 
-// GetSession：无会话返回空切片（非 nil，便于前端拿数组）；有会话原样返回。
-func TestGetSession(t *testing.T) {
-	t.Run("none returns empty slice", func(t *testing.T) {
-		s := &CopilotService{durableKV: kvstore.NewMemory()}
-		got, err := s.GetSession(helpers.CtxAsUser("u1"))
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if got == nil || len(got) != 0 {
-			t.Fatalf("want empty non-nil slice, got %#v", got)
-		}
-	})
-	t.Run("returns persisted", func(t *testing.T) {
-		kv := kvstore.NewMemory()
-		s := &CopilotService{durableKV: kv}
-		s.appendTurn(context.Background(), "u1", ChatMessage{Role: "user", Content: "hi"})
-
-		got, err := s.GetSession(helpers.CtxAsUser("u1"))
-		if err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if len(got) != 1 || got[0].Content != "hi" {
-			t.Fatalf("unexpected session: %#v", got)
-		}
-	})
+// threadHistory：新对话（无线程）没有历史；有线程时只取最近 maxHistoryMessages 条，按时间正序。
+func TestThreadHistory(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	if got := s.threadHistory(context.Background(), nil); got != nil {
+		t.Fatalf("nil thread should have no history, got %#v", got)
+	}
+
+	thread := &copilotModel.ChatThread{UserID: "u1", Title: "t"}
+	_ = repo.CreateChatThread(context.Background(), thread)
+	msgs := make([]ChatMessage, 0, maxHistoryMessages+4)
+	for i := 0; i < maxHistoryMessages+4; i++ {
+		msgs = append(msgs, ChatMessage{Role: copilotModel.RoleUser, Content: string(rune('A' + i%26))})
+	}
+	_ = repo.AppendChatMessages(context.Background(), thread.ID, msgs)
+
+	got := s.threadHistory(context.Background(), thread)
+	if len(got) != maxHistoryMessages {
+		t.Fatalf("history should be capped at %d, got %d", maxHistoryMessages, len(got))
+	}
+	if got[len(got)-1].Seq != maxHistoryMessages+3 || got[0].Seq != 4 {
+		t.Fatalf("history should keep the most recent messages in order, got seq %d..%d",
+			got[0].Seq, got[len(got)-1].Seq)
+	}
 }
 
-// ClearSession：登录用户删除其会话；匿名（空 userID）直接 no-op 返回 nil。
-func TestClearSession(t *testing.T) {
-	t.Run("deletes for user", func(t *testing.T) {
-		kv := kvstore.NewMemory()
-		s := &CopilotService{durableKV: kv}
-		s.appendTurn(context.Background(), "u1", ChatMessage{Role: "user", Content: "hi"})
-
-		if err := s.ClearSession(helpers.CtxAsUser("u1")); err != nil {
-			t.Fatalf("unexpected error: %v", err)
-		}
-		if got := s.loadSession(context.Background(), "u1"); got != nil {
-			t.Fatalf("session should be cleared, got %#v", got)
-		}
-	})
-	t.Run("anonymous is noop", func(t *testing.T) {
-		s := &CopilotService{durableKV: kvstore.NewMemory()}
-		if err := s.ClearSession(helpers.CtxAnonymous()); err != nil {
-			t.Fatalf("anonymous clear should be a nil no-op, got %v", err)
-		}
-	})
+func TestHistoryBudgetOf(t *testing.T) {
+	if got := historyBudgetOf(nil); got != maxHistoryTokens {
+		t.Fatalf("nil thread should use default budget, got %d", got)
+	}
+	if got := historyBudgetOf(&copilotModel.ChatThread{}); got != maxHistoryTokens {
+		t.Fatalf("zero budget should use default, got %d", got)
+	}
+	if got := historyBudgetOf(&copilotModel.ChatThread{TokenBudget: 12000}); got != 12000 {
+		t.Fatalf("thread budget should override default, got %d", got)
+	}
 }
@@ -10,9 +10,11 @@ import (
 	"context"
 	"net/http"
 
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
-	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
 )
 
 // SummaryService 暴露 AI 近期总结能力（实现见 summary.go）。
@@ -20,20 +22,49 @@ type SummaryService interface {
 	GetRecent(ctx context.Context) (string, error)
 }
 
-// ChatService 暴露 Chat 流式问答能力（实现见 chat.go）与会话持久化（实现见 session.go）。
+// ChatService 暴露 Chat 流式问答能力（实现见 chat.go）与会话线程管理（实现见 thread.go）。
+// 线程只对创建它的用户可见。
 type ChatService interface {
-	AskStream(ctx context.Context, question string, locale string, timezone string, w http.ResponseWriter) error
-	// GetSession 返回当前登录用户的持久化会话（无会话时返回空切片）。
-	GetSession(ctx context.Context) ([]ChatMessage, error)
-	// ClearSession 删除当前登录用户的持久化会话。
-	ClearSession(ctx context.Context) error
+	// AskStream 在 threadID 指定的线程里问答一轮；threadID 为空时在本轮成功后新建线程。
+	AskStream(ctx context.Context, threadID, question, locale, timezone string, w http.ResponseWriter) error
+	ListThreads(ctx context.Context, query commonModel.PageQueryDto) (commonModel.PageQueryResult[[]copilotModel.ChatThread], error)
+	CreateThread(ctx context.Context, dto copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error)
+	GetThread(ctx context.Context, id string) (*copilotModel.ChatThreadDetail, error)
+	UpdateThread(ctx context.Context, id string, dto copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error)
+	DeleteThread(ctx context.Context, id string) error
+	// ExportThread 把线程导出为 Markdown 或 JSON 文件，Markdown 里的时间按 timezone 渲染。
+	ExportThread(ctx context.Context, id, format, timezone string) (copilotModel.ChatThreadExport, error)
+	// ConfirmAction 经 EchoService 执行一条待确认动作（实现见 action.go），CancelAction 放弃它。
+	ConfirmAction(ctx context.Context, id string) (*copilotModel.ChatAction, error)
+	CancelAction(ctx context.Context, id string) (*copilotModel.ChatAction, error)
 }
 
 type (
-	EchoService      = echoService.Service
-	EmbeddingService = embeddingService.Service
+	EchoService   = echoService.Service
+	SearchService = searchService.Service
 )
 
+// ThreadRepository 是 Chat 线程与消息的持久化。带 userID 的方法只命中该用户自己的线程。
+type ThreadRepository interface {
+	CreateChatThread(ctx context.Context, thread *copilotModel.ChatThread) error
+	GetChatThread(ctx context.Context, userID, id string) (*copilotModel.ChatThread, error)
+	ListChatThreads(ctx context.Context, userID, search string, page, pageSize int) ([]copilotModel.ChatThread, int64, error)
+	UpdateChatThread(ctx context.Context, thread *copilotModel.ChatThread) error
+	DeleteChatThread(ctx context.Context, userID, id string) (bool, error)
+	AppendChatMessages(ctx context.Context, threadID string, msgs []copilotModel.ChatMessage) error
+	ListChatMessages(ctx context.Context, threadID string) ([]copilotModel.ChatMessage, error)
+	ListRecentChatMessages(ctx context.Context, threadID string, limit int) ([]copilotModel.ChatMessage, error)
+}
+
+// ActionRepository 是待确认动作的持久化，同样按用户隔离。
+type ActionRepository interface {
+	CreateChatAction(ctx context.Context, action *copilotModel.ChatAction) error
+	GetChatAction(ctx context.Context, userID, id string) (*copilotModel.ChatAction, error)
+	ListChatActions(ctx context.Context, userID string, ids []string) ([]copilotModel.ChatAction, error)
+	TransitionChatAction(ctx context.Context, userID, id, from, to string) (bool, error)
+	FinishChatAction(ctx context.Context, action *copilotModel.ChatAction) error
+}
+
 // UserReader 用于按 ID 取当前对话用户信息（展示名 + 作为检索作者收口的依据）。
 // 窄接口而非整个 user 服务：Chat 只需读单个用户，便于测试替身。
 type UserReader interface {
@@ -58,9 +58,9 @@ type searchArgs struct {
 
 // searchEchosTool 是注入给 agent 的领域工具：检索用户过往 Echo。
 //
-// 检索路由（一条规则）：只要带结构化过滤（tags / date_*）就走 QueryEchos（SQL 精确，
-// 向量索引做不了元数据过滤）；纯 query 且向量已启用才走 embedding 语义检索，否则回退
-// QueryEchos 的 content LIKE。allTags 用于把模型给的标签名解析成 ID（UUID 不进 prompt）。
+// 检索路由（一条规则）：带 query 就走混合检索（关键词 + 语义 RRF 融合，结构化过滤对两路都生效，
+// 向量未启用时由 search 服务自行回退为仅关键词）；只有 tags / date_* 时走 QueryEchos 做纯 SQL 筛选。
+// allTags 用于把模型给的标签名解析成 ID（UUID 不进 prompt）。
 func (s *CopilotService) searchEchosTool(allTags []echoModel.Tag, multimodal bool, locale string, loc *time.Location, window int, user chatUser) agent.Tool {
 	return agent.Tool{
 		Def: agent.ToolDef{
@@ -85,15 +85,10 @@ func (s *CopilotService) searchEchosTool(allTags []echoModel.Tag, multimodal boo
 			var results []embeddingModel.SearchResult
 			var total int64
 			var execErr error
-			switch {
-			case structured:
-				// 带结构化过滤：SQL 精确路径，query 降级为 content LIKE。
-				results, total, execErr = s.queryEchos(ctx, user.ID, a.Query, tagIDs, from, to, topK)
-			case s.embedding.Enabled(ctx):
-				// 向量语义检索：按当前用户名收口，多用户实例下不召回他人 Echo。
-				results, execErr = s.embedding.Search(ctx, a.Query, topK, user.Username)
-			default:
-				results, total, execErr = s.queryEchos(ctx, user.ID, a.Query, nil, 0, 0, topK)
+			if a.Query != "" {
+				results, total, execErr = s.hybridSearch(ctx, user, a.Query, tagIDs, from, to, topK)
+			} else {
+				results, total, execErr = s.queryEchos(ctx, user.ID, "", tagIDs, from, to, topK)
 			}
 			if execErr != nil {
 				return agent.ToolOutput{}, execErr
@@ -142,6 +137,28 @@ func (s *CopilotService) queryEchos(ctx context.Context, userID, search string,
 	return results, page.Total, nil
 }
 
+// hybridSearch 走 search 服务的混合检索。user 同时按 ID（SQL 路）与用户名（向量路）收口到本人；
+// 返回的 total 是关键词召回的命中总数，语义召回没有「总数」概念，不计入覆盖度提示。
+func (s *CopilotService) hybridSearch(ctx context.Context, user chatUser, query string, tagIDs []string, from, to int64, limit int) ([]embeddingModel.SearchResult, int64, error) {
+	res, err := s.search.HybridSearch(ctx, commonModel.EchoSearchDto{
+		Query:    query,
+		Limit:    limit,
+		TagIDs:   tagIDs,
+		DateFrom: from,
+		DateTo:   to,
+		UserID:   user.ID,
+		Username: user.Username,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	results := make([]embeddingModel.SearchResult, 0, len(res.Items))
+	for i := range res.Items {
+		results = append(results, echoToSearchResult(res.Items[i].Echo))
+	}
+	return results, res.KeywordTotal, nil
+}
+
 // echoToSearchResult 把一条 Echo 映射成检索结果形状，使 SQL 检索路径与向量检索同构，
 // 复用 formatSearchResults / enrichHits / SSE sources 等下游逻辑。
 func echoToSearchResult(e echoModel.Echo) embeddingModel.SearchResult {
@@ -227,7 +244,8 @@ func formatSearchResults(results []embeddingModel.SearchResult, exts map[string]
 	var b strings.Builder
 	for i, r := range results {
 		day := time.Unix(r.EchoCreated, 0).In(loc).Format("2006-01-02")
-		parts := []string{fmt.Sprintf("【%d】(%s)", i+1, day)}
+		// [id:…] 供写类工具（tag_echos / set_echo_privacy）引用目标 Echo。
+		parts := []string{fmt.Sprintf("【%d】(%s) [id:%s]", i+1, day, r.EchoID)}
 		if c := strings.TrimSpace(r.Content); c != "" {
 			parts = append(parts, c)
 		}
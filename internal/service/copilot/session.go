@@ -5,7 +5,6 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -13,22 +12,23 @@ import (
 	"unicode/utf8"
 
 	"github.com/lin-snow/ech0/internal/agent"
-	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
-	"github.com/lin-snow/ech0/pkg/viewer"
 )
 
-// maxStoredChatMessages 是单个用户持久化会话保留的最大消息条数（超出取最近 N 条）。
-const maxStoredChatMessages = 50
+// maxHistoryMessages 是单轮从线程读取的最多历史消息条数，读出后再按 token 预算截断。
+const maxHistoryMessages = 50
 
-// maxHistoryTokens 是注入模型的会话历史 token 预算（保守固定值，与模型窗口解耦）。
-// 微博客问答通常很短，4000 token ≈ 十几轮，留足窗口给 system + 本轮工具结果 + 本轮问题。
+// maxHistoryTokens 是注入模型的会话历史 token 预算默认值（保守固定值，与模型窗口解耦），
+// 线程可用 TokenBudget 覆盖。微博客问答通常很短，4000 token ≈ 十几轮，留足窗口给
+// system + 本轮工具结果 + 本轮问题。
 const maxHistoryTokens = 4000
 
-// toolDefTokenEstimate 是注入模型的工具定义（search_echos + summarize_echos 的描述 + JSON Schema）
-// 的粗略 token 估算，计入固定开销以收紧历史预算（整请求护栏，避免 system + 工具定义 + 历史叠加超窗）。
-const toolDefTokenEstimate = 640
+// toolDefTokenEstimate 是注入模型的工具定义（读类检索/聚合/统计 + 写类草稿/标签/可见性的描述 +
+// JSON Schema）的粗略 token 估算，计入固定开销以收紧历史预算（整请求护栏，避免 system + 工具定义 +
+// 历史叠加超窗）。按全部注入估算，没有写权限时略偏保守。
+const toolDefTokenEstimate = 1100
 
 // minHistoryTokens 是历史预算下限：即便固定开销很大，也至少给历史留这点空间（保留最近若干轮）。
 const minHistoryTokens = 500
@@ -52,7 +52,7 @@ func historyForModel(msgs []ChatMessage, locale string, budgetTokens int, loc *t
 	// 定位最近一条带非空 Sources 的 assistant；仅此一轮把检索原文折进文本。
 	lastSourced := -1
 	for i := len(msgs) - 1; i >= 0; i-- {
-		if msgs[i].Role == "assistant" && len(msgs[i].Sources) > 0 {
+		if msgs[i].Role == copilotModel.RoleAssistant && len(msgs[i].Sources) > 0 {
 			lastSourced = i
 			break
 		}
@@ -97,111 +97,94 @@ func historyForModel(msgs []ChatMessage, locale string, budgetTokens int, loc *t
 
 // roleFromString 把持久化的角色字符串映射成 agent.Role（持久化里只有 user/assistant）。
 func roleFromString(r string) agent.Role {
-	if r == "assistant" {
+	if r == copilotModel.RoleAssistant {
 		return agent.RoleAssistant
 	}
 	return agent.RoleUser
 }
 
-// ChatMessage 是持久化在 KeyValue 里的一条聊天消息（仅做展示恢复，模型不读历史）。
-//
-// Reasoning / ReasoningMs 仅 assistant 轮有意义：推理过程文本与其耗时（毫秒），用于前端折叠展示
-// 「已思考（用时 X 秒）」。**只供展示回显，绝不进模型上下文**——historyForModel 只取 Content，
-// 故 reasoning 天然不会被回灌给模型。
-type ChatMessage struct {
-	Role        string                        `json:"role"`
-	Content     string                        `json:"content"`
-	Sources     []embeddingModel.SearchResult `json:"sources,omitempty"`
-	Reasoning   string                        `json:"reasoning,omitempty"`
-	ReasoningMs int64                         `json:"reasoning_ms,omitempty"`
-}
+// ChatMessage 是线程里的一条聊天消息。historyForModel 只取 Content（及最近一轮的 Sources），
+// Reasoning 只供展示回显，绝不进模型上下文。
+type ChatMessage = copilotModel.ChatMessage
 
-// chatSessionKey 按 userID 生成会话键（每个用户一条会话）。
-func chatSessionKey(userID string) string {
-	return commonModel.ChatSessionKeyPrefix + userID
+// assistantTurn 收束本轮 assistant 的可持久化产物：答案、来源、推理过程及其耗时，以及本轮提出的待确认动作。
+type assistantTurn struct {
+	answer      string
+	sources     []embeddingModel.SearchResult
+	reasoning   string
+	reasoningMs int64
+	actionIDs   []string
 }
 
-// loadSession 读取某用户的持久化会话；userID 为空、未命中或解析失败都返回 nil（best-effort）。
-func (s *CopilotService) loadSession(ctx context.Context, userID string) []ChatMessage {
-	if userID == "" {
+// threadHistory 读取线程最近的消息供 historyForModel 投影；新线程（nil）或读取失败都返回 nil
+// （best-effort，少了历史只是模型少了上下文）。
+func (s *CopilotService) threadHistory(ctx context.Context, thread *copilotModel.ChatThread) []ChatMessage {
+	if thread == nil {
 		return nil
 	}
-	raw, err := s.durableKV.Get(ctx, chatSessionKey(userID))
+	msgs, err := s.threads.ListRecentChatMessages(ctx, thread.ID, maxHistoryMessages)
 	if err != nil {
-		return nil
-	}
-	var msgs []ChatMessage
-	if err := json.Unmarshal([]byte(raw), &msgs); err != nil {
+		logUtil.GetLogger().Warn("failed to load chat history",
+			slog.String("module", "copilot"), slog.String("thread_id", thread.ID), logUtil.Err(err))
 		return nil
 	}
 	return msgs
 }
 
-// appendTurn 把本轮消息追加进用户会话并落盘（封顶最近 maxStoredChatMessages 条）。
-// userID 为空直接跳过；任何失败仅告警，不返回错误（best-effort，不影响主流程）。
-func (s *CopilotService) appendTurn(ctx context.Context, userID string, turn ...ChatMessage) {
-	if userID == "" {
-		return
-	}
-	msgs := append(s.loadSession(ctx, userID), turn...)
-	if len(msgs) > maxStoredChatMessages {
-		msgs = msgs[len(msgs)-maxStoredChatMessages:]
-	}
-	payload, err := json.Marshal(msgs)
-	if err != nil {
-		logUtil.GetLogger().Warn("failed to marshal chat session",
-			slog.String("module", "copilot"), logUtil.Err(err))
-		return
-	}
-	if err := s.durableKV.Set(ctx, chatSessionKey(userID), string(payload)); err != nil {
-		logUtil.GetLogger().Warn("failed to persist chat session",
-			slog.String("module", "copilot"), logUtil.Err(err))
+// historyBudgetOf 返回线程的历史 token 预算：线程未设置时取 maxHistoryTokens。
+func historyBudgetOf(thread *copilotModel.ChatThread) int {
+	if thread != nil && thread.TokenBudget > 0 {
+		return thread.TokenBudget
 	}
+	return maxHistoryTokens
 }
 
-// assistantTurn 收束本轮 assistant 的可持久化产物：答案、来源、推理过程及其耗时。
-type assistantTurn struct {
-	answer      string
-	sources     []embeddingModel.SearchResult
-	reasoning   string
-	reasoningMs int64
-}
-
-// persistTurn 在一轮问答正常收尾时把 user/assistant 两条消息追加进持久化会话。
-// 「答案为空且无来源」视为失败/空轮次（模型一个 token 都没产出）：直接跳过落盘，
-// 既不在持久化会话里留下永久空白气泡，也让前端可就地重发后历史仍保持干净
-// （重发会以同一问题重新跑一轮并在成功时落盘）。
-func (s *CopilotService) persistTurn(ctx context.Context, userID, question string, turn assistantTurn) {
-	if strings.TrimSpace(turn.answer) == "" && len(turn.sources) == 0 {
-		return
+// persistTurn 在一轮问答正常收尾时把 user/assistant 两条消息追加进线程，返回落盘后的线程。
+// thread 为 nil 时以问题为标题新建线程；未命名的线程同样补上标题。
+//
+// 「答案为空、无来源也没提出动作」视为失败/空轮次（模型一个 token 都没产出）：直接跳过落盘并返回 nil，
+// 既不在线程里留下永久空白气泡，也不会为一次失败的提问新建线程（前端就地重发即可）。
+// 任何写入失败仅告警，不影响主流程。
+func (s *CopilotService) persistTurn(
+	ctx context.Context,
+	userID string,
+	thread *copilotModel.ChatThread,
+	question string,
+	turn assistantTurn,
+) *copilotModel.ChatThread {
+	if strings.TrimSpace(turn.answer) == "" && len(turn.sources) == 0 && len(turn.actionIDs) == 0 {
+		return nil
 	}
-	s.appendTurn(ctx, userID,
-		ChatMessage{Role: "user", Content: question},
-		ChatMessage{
-			Role:        "assistant",
+	if thread == nil {
+		thread = &copilotModel.ChatThread{UserID: userID, Title: threadTitleFrom(question)}
+		if err := s.threads.CreateChatThread(ctx, thread); err != nil {
+			logUtil.GetLogger().Warn("failed to create chat thread",
+				slog.String("module", "copilot"), logUtil.Err(err))
+			return nil
+		}
+	} else if thread.Title == "" {
+		thread.Title = threadTitleFrom(question)
+		if err := s.threads.UpdateChatThread(ctx, thread); err != nil {
+			logUtil.GetLogger().Warn("failed to name chat thread",
+				slog.String("module", "copilot"), slog.String("thread_id", thread.ID), logUtil.Err(err))
+		}
+	}
+
+	if err := s.threads.AppendChatMessages(ctx, thread.ID, []ChatMessage{
+		{Role: copilotModel.RoleUser, Content: question},
+		{
+			Role:        copilotModel.RoleAssistant,
 			Content:     turn.answer,
 			Sources:     turn.sources,
 			Reasoning:   turn.reasoning,
 			ReasoningMs: turn.reasoningMs,
+			ActionIDs:   turn.actionIDs,
 		},
-	)
-}
-
-// GetSession 返回当前登录用户的持久化会话（无会话时返回空切片，便于前端拿到数组）。
-func (s *CopilotService) GetSession(ctx context.Context) ([]ChatMessage, error) {
-	userID := viewer.MustFromContext(ctx).UserID()
-	msgs := s.loadSession(ctx, userID)
-	if msgs == nil {
-		return []ChatMessage{}, nil
-	}
-	return msgs, nil
-}
-
-// ClearSession 删除当前登录用户的持久化会话（不可恢复，由前端二次确认）。
-func (s *CopilotService) ClearSession(ctx context.Context) error {
-	userID := viewer.MustFromContext(ctx).UserID()
-	if userID == "" {
+	}); err != nil {
+		logUtil.GetLogger().Warn("failed to persist chat turn",
+			slog.String("module", "copilot"), slog.String("thread_id", thread.ID), logUtil.Err(err))
 		return nil
 	}
-	return s.durableKV.Delete(ctx, chatSessionKey(userID))
+	thread.MessageCount += 2
+	return thread
 }
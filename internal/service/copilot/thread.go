@@ -0,0 +1,226 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
+	timezoneUtil "github.com/lin-snow/ech0/internal/util/timezone"
+	"github.com/lin-snow/ech0/pkg/viewer"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxThreadTitleRunes 是线程标题的最大长度，与 copilot_threads.title 列宽一致。
+	maxThreadTitleRunes = 120
+	// autoTitleRunes 是以第一个问题自动命名时截取的长度。
+	autoTitleRunes = 40
+	// maxThreadTokenBudget 是线程可设置的历史 token 预算上限。
+	maxThreadTokenBudget = 32_000
+
+	defaultThreadPageSize = 20
+	maxThreadPageSize     = 100
+)
+
+// ListThreads 分页列出当前用户的线程，最近活跃的在前；Search 非空时匹配标题或消息正文。
+func (s *CopilotService) ListThreads(
+	ctx context.Context,
+	query commonModel.PageQueryDto,
+) (commonModel.PageQueryResult[[]copilotModel.ChatThread], error) {
+	if query.Page < 1 {
+		query.Page = 1
+	}
+	if query.PageSize < 1 {
+		query.PageSize = defaultThreadPageSize
+	}
+	query.PageSize = min(query.PageSize, maxThreadPageSize)
+
+	userID := viewer.MustFromContext(ctx).UserID()
+	threads, total, err := s.threads.ListChatThreads(ctx, userID, query.Search, query.Page, query.PageSize)
+	if err != nil {
+		return commonModel.PageQueryResult[[]copilotModel.ChatThread]{}, err
+	}
+	return commonModel.PageQueryResult[[]copilotModel.ChatThread]{Items: threads, Total: total}, nil
+}
+
+// CreateThread 为当前用户新建一个空线程。标题留空时，第一轮问答会以问题为它命名。
+func (s *CopilotService) CreateThread(
+	ctx context.Context,
+	dto copilotModel.ChatThreadDto,
+) (*copilotModel.ChatThread, error) {
+	if err := validateTokenBudget(dto.TokenBudget); err != nil {
+		return nil, err
+	}
+	thread := &copilotModel.ChatThread{
+		UserID:      viewer.MustFromContext(ctx).UserID(),
+		Title:       normalizeThreadTitle(dto.Title, maxThreadTitleRunes),
+		TokenBudget: dto.TokenBudget,
+	}
+	if err := s.threads.CreateChatThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// GetThread 返回当前用户的线程及其全部消息，连同消息里提出过的动作的当前状态。
+func (s *CopilotService) GetThread(ctx context.Context, id string) (*copilotModel.ChatThreadDetail, error) {
+	thread, err := s.ownThread(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := s.threads.ListChatMessages(ctx, thread.ID)
+	if err != nil {
+		return nil, err
+	}
+	if msgs == nil {
+		msgs = []copilotModel.ChatMessage{}
+	}
+	var actionIDs []string
+	for _, m := range msgs {
+		actionIDs = append(actionIDs, m.ActionIDs...)
+	}
+	actions := []copilotModel.ChatAction{}
+	if len(actionIDs) > 0 {
+		if actions, err = s.actions.ListChatActions(ctx, thread.UserID, actionIDs); err != nil {
+			return nil, err
+		}
+	}
+	return &copilotModel.ChatThreadDetail{Thread: *thread, Messages: msgs, Actions: actions}, nil
+}
+
+// UpdateThread 重命名线程或调整它的历史预算。
+func (s *CopilotService) UpdateThread(
+	ctx context.Context,
+	id string,
+	dto copilotModel.ChatThreadDto,
+) (*copilotModel.ChatThread, error) {
+	if err := validateTokenBudget(dto.TokenBudget); err != nil {
+		return nil, err
+	}
+	thread, err := s.ownThread(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	thread.Title = normalizeThreadTitle(dto.Title, maxThreadTitleRunes)
+	thread.TokenBudget = dto.TokenBudget
+	if err := s.threads.UpdateChatThread(ctx, thread); err != nil {
+		return nil, err
+	}
+	return thread, nil
+}
+
+// DeleteThread 删除当前用户的线程及其消息（不可恢复，由前端二次确认）。
+func (s *CopilotService) DeleteThread(ctx context.Context, id string) error {
+	deleted, err := s.threads.DeleteChatThread(ctx, viewer.MustFromContext(ctx).UserID(), id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return errors.New(commonModel.CHAT_THREAD_NOT_FOUND)
+	}
+	return nil
+}
+
+// ExportThread 把当前用户的线程导出为 Markdown（默认）或 JSON。Markdown 里的时间按 timezone 渲染。
+func (s *CopilotService) ExportThread(
+	ctx context.Context,
+	id, format, timezone string,
+) (copilotModel.ChatThreadExport, error) {
+	format = strings.ToLower(strings.TrimSpace(format))
+	if format == "" || format == "md" {
+		format = copilotModel.ExportFormatMarkdown
+	}
+	if format != copilotModel.ExportFormatMarkdown && format != copilotModel.ExportFormatJSON {
+		return copilotModel.ChatThreadExport{}, errors.New(commonModel.INVALID_CHAT_EXPORT_FORMAT)
+	}
+
+	detail, err := s.GetThread(ctx, id)
+	if err != nil {
+		return copilotModel.ChatThreadExport{}, err
+	}
+
+	if format == copilotModel.ExportFormatJSON {
+		body, err := json.MarshalIndent(detail, "", "  ")
+		if err != nil {
+			return copilotModel.ChatThreadExport{}, err
+		}
+		return copilotModel.ChatThreadExport{
+			Filename:    "ech0-chat-" + detail.Thread.ID + ".json",
+			ContentType: "application/json; charset=utf-8",
+			Body:        body,
+		}, nil
+	}
+	return copilotModel.ChatThreadExport{
+		Filename:    "ech0-chat-" + detail.Thread.ID + ".md",
+		ContentType: "text/markdown; charset=utf-8",
+		Body:        []byte(renderThreadMarkdown(detail, timezoneUtil.LoadLocationOrUTC(timezone))),
+	}, nil
+}
+
+// ownThread 取当前用户的线程；不存在与属于他人一样返回 CHAT_THREAD_NOT_FOUND。
+func (s *CopilotService) ownThread(ctx context.Context, id string) (*copilotModel.ChatThread, error) {
+	thread, err := s.threads.GetChatThread(ctx, viewer.MustFromContext(ctx).UserID(), id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errors.New(commonModel.CHAT_THREAD_NOT_FOUND)
+	}
+	return thread, err
+}
+
+// renderThreadMarkdown 把线程渲染成可读的 Markdown：提问与回答依次排列，来源列在回答之后。
+// 推理过程不导出。
+func renderThreadMarkdown(detail *copilotModel.ChatThreadDetail, loc *time.Location) string {
+	var b strings.Builder
+	title := detail.Thread.Title
+	if title == "" {
+		title = "Ech0 Copilot"
+	}
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "_%s_\n", time.Unix(detail.Thread.CreatedAt, 0).In(loc).Format("2006-01-02 15:04 MST"))
+
+	for _, m := range detail.Messages {
+		if m.Role == copilotModel.RoleUser {
+			fmt.Fprintf(&b, "\n## %s\n", strings.TrimSpace(m.Content))
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(m.Content))
+		if len(m.Sources) == 0 {
+			continue
+		}
+		b.WriteString("\n")
+		for _, src := range m.Sources {
+			day := time.Unix(src.EchoCreated, 0).In(loc).Format("2006-01-02")
+			fmt.Fprintf(&b, "- %s · %s (`%s`)\n", day, normalizeThreadTitle(src.Content, autoTitleRunes), src.EchoID)
+		}
+	}
+	return b.String()
+}
+
+// threadTitleFrom 以问题命名新线程。
+func threadTitleFrom(question string) string {
+	return normalizeThreadTitle(question, autoTitleRunes)
+}
+
+// normalizeThreadTitle 把文本压成单行并截断到 limit 个字符（超出以省略号收尾）。
+func normalizeThreadTitle(s string, limit int) string {
+	runes := []rune(strings.Join(strings.Fields(s), " "))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+// validateTokenBudget 校验线程的历史预算：0 表示默认值，否则须落在 [minHistoryTokens, maxThreadTokenBudget]。
+func validateTokenBudget(budget int) error {
+	if budget == 0 || (budget >= minHistoryTokens && budget <= maxThreadTokenBudget) {
+		return nil
+	}
+	return errors.New(commonModel.INVALID_CHAT_TOKEN_BUDGET)
+}
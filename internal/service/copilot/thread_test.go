@@ -0,0 +1,315 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"gorm.io/gorm"
+)
+
+// memThreads 是内存版 ThreadRepository，语义与 GORM 实现一致（按用户隔离、Seq 递增）。
+type memThreads struct {
+	threads   map[string]*copilotModel.ChatThread
+	messages  map[string][]copilotModel.ChatMessage
+	nextID    int
+	appendErr error
+}
+
+func newMemThreads() *memThreads {
+	return &memThreads{
+		threads:  map[string]*copilotModel.ChatThread{},
+		messages: map[string][]copilotModel.ChatMessage{},
+	}
+}
+
+func (m *memThreads) CreateChatThread(_ context.Context, thread *copilotModel.ChatThread) error {
+	m.nextID++
+	thread.ID = fmt.Sprintf("t%d", m.nextID)
+	thread.CreatedAt = int64(m.nextID)
+	thread.UpdatedAt = int64(m.nextID)
+	cp := *thread
+	m.threads[thread.ID] = &cp
+	return nil
+}
+
+func (m *memThreads) GetChatThread(_ context.Context, userID, id string) (*copilotModel.ChatThread, error) {
+	t, ok := m.threads[id]
+	if !ok || t.UserID != userID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (m *memThreads) ListChatThreads(
+	_ context.Context,
+	userID, search string,
+	page, pageSize int,
+) ([]copilotModel.ChatThread, int64, error) {
+	var out []copilotModel.ChatThread
+	for _, t := range m.threads {
+		if t.UserID == userID && (search == "" || strings.Contains(t.Title, search)) {
+			out = append(out, *t)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].UpdatedAt > out[j].UpdatedAt })
+	total := int64(len(out))
+	start := min((page-1)*pageSize, len(out))
+	return out[start:min(start+pageSize, len(out))], total, nil
+}
+
+func (m *memThreads) UpdateChatThread(_ context.Context, thread *copilotModel.ChatThread) error {
+	if t, ok := m.threads[thread.ID]; ok && t.UserID == thread.UserID {
+		t.Title, t.TokenBudget = thread.Title, thread.TokenBudget
+	}
+	return nil
+}
+
+func (m *memThreads) DeleteChatThread(_ context.Context, userID, id string) (bool, error) {
+	t, ok := m.threads[id]
+	if !ok || t.UserID != userID {
+		return false, nil
+	}
+	delete(m.threads, id)
+	delete(m.messages, id)
+	return true, nil
+}
+
+func (m *memThreads) AppendChatMessages(_ context.Context, threadID string, msgs []copilotModel.ChatMessage) error {
+	if m.appendErr != nil {
+		return m.appendErr
+	}
+	t := m.threads[threadID]
+	for i := range msgs {
+		msgs[i].ThreadID = threadID
+		msgs[i].Seq = t.MessageCount + i
+	}
+	m.messages[threadID] = append(m.messages[threadID], msgs...)
+	t.MessageCount += len(msgs)
+	return nil
+}
+
+func (m *memThreads) ListChatMessages(_ context.Context, threadID string) ([]copilotModel.ChatMessage, error) {
+	return append([]copilotModel.ChatMessage(nil), m.messages[threadID]...), nil
+}
+
+func (m *memThreads) ListRecentChatMessages(
+	_ context.Context,
+	threadID string,
+	limit int,
+) ([]copilotModel.ChatMessage, error) {
+	msgs := m.messages[threadID]
+	return append([]copilotModel.ChatMessage(nil), msgs[max(len(msgs)-limit, 0):]...), nil
+}
+
+func TestCreateThread_ValidatesBudgetAndTitle(t *testing.T) {
+	s := &CopilotService{threads: newMemThreads()}
+	ctx := helpers.CtxAsUser("u1")
+
+	thread, err := s.CreateThread(ctx, copilotModel.ChatThreadDto{Title: "  读书\n笔记  ", TokenBudget: 8000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if thread.UserID != "u1" || thread.Title != "读书 笔记" || thread.TokenBudget != 8000 {
+		t.Fatalf("unexpected thread: %+v", thread)
+	}
+
+	for _, budget := range []int{-1, minHistoryTokens - 1, maxThreadTokenBudget + 1} {
+		_, err := s.CreateThread(ctx, copilotModel.ChatThreadDto{TokenBudget: budget})
+		if err == nil || err.Error() != commonModel.INVALID_CHAT_TOKEN_BUDGET {
+			t.Fatalf("budget %d: want INVALID_CHAT_TOKEN_BUDGET, got %v", budget, err)
+		}
+	}
+}
+
+func TestListThreads_ClampsPaging(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	ctx := helpers.CtxAsUser("u1")
+	for i := 0; i < 3; i++ {
+		_ = repo.CreateChatThread(ctx, &copilotModel.ChatThread{UserID: "u1", Title: fmt.Sprintf("t%d", i)})
+	}
+	_ = repo.CreateChatThread(ctx, &copilotModel.ChatThread{UserID: "u2", Title: "别人的"})
+
+	got, err := s.ListThreads(ctx, commonModel.PageQueryDto{Page: 0, PageSize: 10_000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Total != 3 || len(got.Items) != 3 {
+		t.Fatalf("want only the caller's 3 threads, got %+v", got)
+	}
+	if got.Items[0].Title != "t2" {
+		t.Fatalf("most recent thread should come first, got %q", got.Items[0].Title)
+	}
+}
+
+// 他人的线程一律视为不存在：读、改、删、导出都返回 CHAT_THREAD_NOT_FOUND。
+func TestThreads_OwnerIsolation(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	owned := &copilotModel.ChatThread{UserID: "u1", Title: "私密"}
+	_ = repo.CreateChatThread(context.Background(), owned)
+	other := helpers.CtxAsUser("u2")
+
+	if _, err := s.GetThread(other, owned.ID); err == nil || err.Error() != commonModel.CHAT_THREAD_NOT_FOUND {
+		t.Fatalf("GetThread: want not found, got %v", err)
+	}
+	if _, err := s.UpdateThread(other, owned.ID, copilotModel.ChatThreadDto{Title: "x"}); err == nil ||
+		err.Error() != commonModel.CHAT_THREAD_NOT_FOUND {
+		t.Fatalf("UpdateThread: want not found, got %v", err)
+	}
+	if err := s.DeleteThread(other, owned.ID); err == nil || err.Error() != commonModel.CHAT_THREAD_NOT_FOUND {
+		t.Fatalf("DeleteThread: want not found, got %v", err)
+	}
+	if _, err := s.ExportThread(other, owned.ID, "", "UTC"); err == nil || err.Error() != commonModel.CHAT_THREAD_NOT_FOUND {
+		t.Fatalf("ExportThread: want not found, got %v", err)
+	}
+	if _, ok := repo.threads[owned.ID]; !ok || repo.threads[owned.ID].Title != "私密" {
+		t.Fatalf("other user's calls must not touch the thread")
+	}
+}
+
+func TestGetThread_EmptyMessagesIsNonNil(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	thread := &copilotModel.ChatThread{UserID: "u1"}
+	_ = repo.CreateChatThread(context.Background(), thread)
+
+	got, err := s.GetThread(helpers.CtxAsUser("u1"), thread.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Messages == nil || len(got.Messages) != 0 {
+		t.Fatalf("want empty non-nil messages, got %#v", got.Messages)
+	}
+}
+
+// 提出过动作的轮次落盘后，GetThread 连同动作的当前状态一起返回，供前端恢复确认卡片。
+func TestGetThread_IncludesActions(t *testing.T) {
+	repo, actions := newMemThreads(), newMemActions()
+	s := &CopilotService{threads: repo, actions: actions}
+	action := &copilotModel.ChatAction{UserID: "u1", Kind: copilotModel.ActionDraftEcho, Status: copilotModel.ActionStatusPending}
+	_ = actions.CreateChatAction(context.Background(), action)
+
+	thread := s.persistTurn(context.Background(), "u1", nil, "起草一条周记", assistantTurn{actionIDs: []string{action.ID}})
+	if thread == nil {
+		t.Fatalf("a turn that only proposed an action should still persist")
+	}
+	got, err := s.GetThread(helpers.CtxAsUser("u1"), thread.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got.Actions) != 1 || got.Actions[0].ID != action.ID || got.Messages[1].ActionIDs[0] != action.ID {
+		t.Fatalf("want the proposed action attached, got %+v", got)
+	}
+}
+
+func TestUpdateAndDeleteThread(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	ctx := helpers.CtxAsUser("u1")
+	thread := &copilotModel.ChatThread{UserID: "u1", Title: "旧"}
+	_ = repo.CreateChatThread(ctx, thread)
+	_ = repo.AppendChatMessages(ctx, thread.ID, []ChatMessage{{Role: copilotModel.RoleUser, Content: "hi"}})
+
+	updated, err := s.UpdateThread(ctx, thread.ID, copilotModel.ChatThreadDto{Title: "新", TokenBudget: 2000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Title != "新" || repo.threads[thread.ID].TokenBudget != 2000 {
+		t.Fatalf("update not applied: %+v", repo.threads[thread.ID])
+	}
+
+	if err := s.DeleteThread(ctx, thread.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := repo.threads[thread.ID]; ok || len(repo.messages[thread.ID]) != 0 {
+		t.Fatalf("thread and its messages should be gone")
+	}
+	if err := s.DeleteThread(ctx, thread.ID); err == nil || err.Error() != commonModel.CHAT_THREAD_NOT_FOUND {
+		t.Fatalf("second delete: want not found, got %v", err)
+	}
+}
+
+func seedExportThread(t *testing.T, repo *memThreads) *copilotModel.ChatThread {
+	t.Helper()
+	thread := &copilotModel.ChatThread{UserID: "u1", Title: "读书"}
+	_ = repo.CreateChatThread(context.Background(), thread)
+	_ = repo.AppendChatMessages(context.Background(), thread.ID, []ChatMessage{
+		{Role: copilotModel.RoleUser, Content: "今年读了什么"},
+		{
+			Role:      copilotModel.RoleAssistant,
+			Content:   "你读了三体",
+			Reasoning: "内部推理",
+			Sources:   []embeddingModel.SearchResult{{EchoID: "e1", Content: "三体 读完了", EchoCreated: 1_700_000_000}},
+		},
+	})
+	return thread
+}
+
+func TestExportThread_Markdown(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	thread := seedExportThread(t, repo)
+
+	out, err := s.ExportThread(helpers.CtxAsUser("u1"), thread.ID, "", "Asia/Shanghai")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.Filename != "ech0-chat-"+thread.ID+".md" || !strings.HasPrefix(out.ContentType, "text/markdown") {
+		t.Fatalf("unexpected export meta: %q %q", out.Filename, out.ContentType)
+	}
+	body := string(out.Body)
+	for _, want := range []string{"# 读书", "## 今年读了什么", "你读了三体", "- 2023-11-15 · 三体 读完了 (`e1`)"} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("markdown should contain %q, got:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "内部推理") {
+		t.Fatalf("reasoning must not be exported, got:\n%s", body)
+	}
+}
+
+func TestExportThread_JSONAndUnknownFormat(t *testing.T) {
+	repo := newMemThreads()
+	s := &CopilotService{threads: repo}
+	thread := seedExportThread(t, repo)
+	ctx := helpers.CtxAsUser("u1")
+
+	out, err := s.ExportThread(ctx, thread.ID, "JSON", "UTC")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var detail copilotModel.ChatThreadDetail
+	if err := json.Unmarshal(out.Body, &detail); err != nil {
+		t.Fatalf("json export should decode: %v", err)
+	}
+	if detail.Thread.ID != thread.ID || len(detail.Messages) != 2 {
+		t.Fatalf("unexpected json export: %+v", detail)
+	}
+
+	if _, err := s.ExportThread(ctx, thread.ID, "pdf", "UTC"); err == nil ||
+		err.Error() != commonModel.INVALID_CHAT_EXPORT_FORMAT {
+		t.Fatalf("want INVALID_CHAT_EXPORT_FORMAT, got %v", err)
+	}
+}
+
+func TestNormalizeThreadTitle(t *testing.T) {
+	if got := normalizeThreadTitle("  a\n\tb  ", 10); got != "a b" {
+		t.Fatalf("whitespace should collapse, got %q", got)
+	}
+	if got := normalizeThreadTitle("一二三四五六", 4); got != "一二三…" {
+		t.Fatalf("long title should truncate with ellipsis, got %q", got)
+	}
+}
@@ -16,6 +16,8 @@ import (
 	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
 	userModel "github.com/lin-snow/ech0/internal/model/user"
+	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
 )
 
 // --- 手写极简替身（copilot 域接口很窄，逐方法覆写即可，其余嵌入 nil 接口未调用即不触发） ---
@@ -44,9 +46,9 @@ func (f *stubEchoSvc) GetEchoById(_ context.Context, id string) (*echoModel.Echo
 
 func (f *stubEchoSvc) GetAllTags() ([]echoModel.Tag, error) { return f.tags, nil }
 
-// stubEmbeddingSvc 覆写 copilot 实际调用到的 Enabled / Search。
+// stubEmbeddingSvc 覆写混合检索实际调用到的 Enabled / Search（经 searchService 注入 copilot）。
 type stubEmbeddingSvc struct {
-	EmbeddingService
+	embeddingService.Service
 	enabled    bool
 	searchFn   func(query string, k int, author string) ([]embeddingModel.SearchResult, error)
 	gotAuthor  string
@@ -125,9 +127,14 @@ func TestEffectiveTopK(t *testing.T) {
 
 func newSearchUser() chatUser { return chatUser{ID: "u1", Username: "alice"} }
 
+// newHybridCopilot 用真实的混合检索服务（底层为替身）装配 CopilotService。
+func newHybridCopilot(echoSvc *stubEchoSvc, emb *stubEmbeddingSvc) *CopilotService {
+	return &CopilotService{echoService: echoSvc, search: searchService.NewSearchService(echoSvc, emb)}
+}
+
 // 既无 query 又无结构化过滤 → 直接返回参数错误，不触碰任何依赖。
 func TestSearchEchosTool_NeedsQueryOrFilter(t *testing.T) {
-	s := &CopilotService{echoService: &stubEchoSvc{}, embedding: &stubEmbeddingSvc{}}
+	s := newHybridCopilot(&stubEchoSvc{}, &stubEmbeddingSvc{})
 	tool := s.searchEchosTool(nil, false, "zh-CN", time.UTC, 0, newSearchUser())
 
 	_, err := tool.Execute(context.Background(), mustArgs(t, searchArgs{}))
@@ -136,7 +143,8 @@ func TestSearchEchosTool_NeedsQueryOrFilter(t *testing.T) {
 	}
 }
 
-// 带结构化过滤（日期范围）→ 走 SQL 路径；命中数 > 展示数时前置覆盖度提示；enrichHits 折入扩展分享。
+// query + 结构化过滤（日期范围）且向量未启用 → 混合检索回退为仅关键词；命中数 > 展示数时
+// 前置覆盖度提示；enrichHits 折入扩展分享。
 func TestSearchEchosTool_StructuredWithCoverageNote(t *testing.T) {
 	items := []echoModel.Echo{
 		{ID: "e1", Content: "读了三体", CreatedAt: ts("2026-01-05")},
@@ -151,7 +159,7 @@ func TestSearchEchosTool_StructuredWithCoverageNote(t *testing.T) {
 			}, nil
 		},
 	}
-	s := &CopilotService{echoService: echoSvc, embedding: &stubEmbeddingSvc{}}
+	s := newHybridCopilot(echoSvc, &stubEmbeddingSvc{})
 	tool := s.searchEchosTool(nil, false, "zh-CN", time.UTC, 0, newSearchUser())
 
 	out, err := tool.Execute(context.Background(), mustArgs(t, searchArgs{
@@ -172,8 +180,8 @@ func TestSearchEchosTool_StructuredWithCoverageNote(t *testing.T) {
 	}
 }
 
-// 纯 query + 向量启用 → 走 embedding 语义检索，并按当前用户名收口。
-func TestSearchEchosTool_SemanticPath(t *testing.T) {
+// 纯 query + 向量启用 → 混合检索带上语义召回，向量路按当前用户名、SQL 路按用户 ID 收口。
+func TestSearchEchosTool_HybridPath(t *testing.T) {
 	emb := &stubEmbeddingSvc{
 		enabled: true,
 		searchFn: func(query string, k int, author string) ([]embeddingModel.SearchResult, error) {
@@ -182,10 +190,22 @@ func TestSearchEchosTool_SemanticPath(t *testing.T) {
 			}, nil
 		},
 	}
-	echoSvc := &stubEchoSvc{getByIDFn: func(id string) (*echoModel.Echo, error) {
-		return &echoModel.Echo{ID: id}, nil
-	}}
-	s := &CopilotService{echoService: echoSvc, embedding: emb}
+	var userIDs []string
+	echoSvc := &stubEchoSvc{
+		queryFn: func(dto commonModel.EchoQueryDto) (commonModel.PageQueryResult[[]echoModel.Echo], error) {
+			userIDs = append(userIDs, dto.UserID)
+			if len(dto.EchoIDs) > 0 {
+				// 语义命中回查可见性。
+				return commonModel.PageQueryResult[[]echoModel.Echo]{
+					Items: []echoModel.Echo{{ID: "e1", Content: "向量命中", CreatedAt: ts("2026-02-01")}},
+					Total: 1,
+				}, nil
+			}
+			return commonModel.PageQueryResult[[]echoModel.Echo]{}, nil
+		},
+		getByIDFn: func(id string) (*echoModel.Echo, error) { return &echoModel.Echo{ID: id}, nil },
+	}
+	s := newHybridCopilot(echoSvc, emb)
 	tool := s.searchEchosTool(nil, false, "zh-CN", time.UTC, 0, newSearchUser())
 
 	out, err := tool.Execute(context.Background(), mustArgs(t, searchArgs{Query: "意识"}))
@@ -198,13 +218,18 @@ func TestSearchEchosTool_SemanticPath(t *testing.T) {
 	if emb.gotAuthor != "alice" {
 		t.Fatalf("semantic search must scope by username, got author=%q", emb.gotAuthor)
 	}
+	for _, id := range userIDs {
+		if id != "u1" {
+			t.Fatalf("keyword/visibility queries must scope by user id, got %q", id)
+		}
+	}
 	results, ok := out.Meta.([]embeddingModel.SearchResult)
 	if !ok || len(results) != 1 || results[0].EchoID != "e1" {
 		t.Fatalf("unexpected results: %#v", out.Meta)
 	}
 }
 
-// 纯 query + 向量未启用 → 回退 SQL LIKE 路径（queryEchos）。
+// 纯 query + 向量未启用 → 混合检索回退为仅关键词（QueryEchos）。
 func TestSearchEchosTool_DefaultSQLFallback(t *testing.T) {
 	var gotSearch string
 	echoSvc := &stubEchoSvc{
@@ -217,7 +242,7 @@ func TestSearchEchosTool_DefaultSQLFallback(t *testing.T) {
 		},
 		getByIDFn: func(id string) (*echoModel.Echo, error) { return &echoModel.Echo{ID: id}, nil },
 	}
-	s := &CopilotService{echoService: echoSvc, embedding: &stubEmbeddingSvc{enabled: false}}
+	s := newHybridCopilot(echoSvc, &stubEmbeddingSvc{enabled: false})
 	tool := s.searchEchosTool(nil, false, "zh-CN", time.UTC, 0, newSearchUser())
 
 	out, err := tool.Execute(context.Background(), mustArgs(t, searchArgs{Query: "fallback"}))
@@ -238,7 +263,7 @@ func TestSearchEchosTool_QueryErrorPropagates(t *testing.T) {
 	echoSvc := &stubEchoSvc{queryFn: func(commonModel.EchoQueryDto) (commonModel.PageQueryResult[[]echoModel.Echo], error) {
 		return commonModel.PageQueryResult[[]echoModel.Echo]{}, wantErr
 	}}
-	s := &CopilotService{echoService: echoSvc, embedding: &stubEmbeddingSvc{}}
+	s := newHybridCopilot(echoSvc, &stubEmbeddingSvc{})
 	tool := s.searchEchosTool(nil, false, "zh-CN", time.UTC, 0, newSearchUser())
 
 	_, err := tool.Execute(context.Background(), mustArgs(t, searchArgs{Query: "x", DateFrom: "2026-01-01"}))
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/lin-snow/ech0/pkg/viewer"
+)
+
+// 角色相关的访问规则集中在这里：
+//   - 私密 / 草稿 / 定时 Echo 只有作者本人与 owner（PermEchoViewPrivate）可见；
+//   - 修改 / 删除自己的 Echo 需要 PermEchoWrite，他人的需要 PermEchoEditOthers，
+//     且仍须先「看得见」——编辑碰不到别人的私密 Echo；
+//   - access token 的 scope 由路由层校验，这里按持有者当前角色再校验一次，二者取交集。
+
+// requireUser 取调用方用户并要求其拥有权限 p。
+func (echoService *EchoService) requireUser(ctx context.Context, p userModel.Permission) (userModel.User, error) {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return userModel.User{}, err
+	}
+	if !user.Can(p) {
+		return userModel.User{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	return user, nil
+}
+
+// viewerUser 取调用方用户；匿名访问返回 nil。
+func (echoService *EchoService) viewerUser(ctx context.Context) (*userModel.User, error) {
+	userid := viewer.MustFromContext(ctx).UserID()
+	if userid == "" {
+		return nil, nil
+	}
+	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// showPrivateFor 报告调用方能否看到所有人的私密 / 未发布 Echo（仅 owner）。
+// 今日 / 热门 / 随机 / 历史上的今天等聚合列表只区分这两档，作者自己的私密 Echo
+// 在时间线与检索（QueryEchos）中可见。
+func (echoService *EchoService) showPrivateFor(ctx context.Context) (bool, error) {
+	user, err := echoService.viewerUser(ctx)
+	if err != nil || user == nil {
+		return false, err
+	}
+	return user.Can(userModel.PermEchoViewPrivate), nil
+}
+
+// canView 报告 user（nil 为匿名）能否看到 echo。
+func canView(user *userModel.User, echo *model.Echo) bool {
+	if !echo.Private && echo.IsPublished() {
+		return true
+	}
+	if user == nil {
+		return false
+	}
+	return echo.UserID == user.ID || user.Can(userModel.PermEchoViewPrivate)
+}
+
+// canEdit 报告 user 能否修改、删除、恢复 echo 或查看其修订历史。
+func canEdit(user userModel.User, echo *model.Echo) bool {
+	if echo.UserID == user.ID {
+		return user.Can(userModel.PermEchoWrite)
+	}
+	return user.Can(userModel.PermEchoEditOthers) && canView(&user, echo)
+}
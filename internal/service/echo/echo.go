@@ -8,16 +8,18 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log/slog"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/lin-snow/ech0/internal/event"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/echo"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/transaction"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
@@ -52,15 +54,11 @@ func (echoService *EchoService) PostEcho(ctx context.Context, newEcho *model.Ech
 	userid := viewer.MustFromContext(ctx).UserID()
 	newEcho.UserID = userid
 
-	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
 	if err != nil {
 		return err
 	}
 
-	if !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
-
 	layout := strings.TrimSpace(newEcho.Layout)
 	if layout == "" || (layout != model.LayoutWaterfall &&
 		layout != model.LayoutGrid &&
@@ -70,6 +68,9 @@ func (echoService *EchoService) PostEcho(ctx context.Context, newEcho *model.Ech
 		layout != model.LayoutNone) {
 		newEcho.Layout = model.LayoutWaterfall
 	}
+	if !model.IsValidCommentPolicy(newEcho.CommentPolicy) {
+		newEcho.CommentPolicy = ""
+	}
 
 	normalizedExt, err := normalizeEchoExtension(newEcho.Extension)
 	if err != nil {
@@ -87,24 +88,30 @@ func (echoService *EchoService) PostEcho(ctx context.Context, newEcho *model.Ech
 		return err
 	}
 
+	if err := normalizePublishState(newEcho, nil, time.Now()); err != nil {
+		return err
+	}
+
 	if err := echoService.transactor.Run(ctx, func(txCtx context.Context) error {
 		if err := echoService.ProcessEchoTags(txCtx, newEcho); err != nil {
 			return err
 		}
-		return echoService.echoRepository.CreateEcho(txCtx, newEcho)
+		if err := echoService.echoRepository.CreateEcho(txCtx, newEcho); err != nil {
+			return err
+		}
+		if err := echoService.echoRepository.UpsertSearchIndex(txCtx, newEcho.ID, newEcho.Content); err != nil {
+			return err
+		}
+		// 草稿 / 定时 Echo 在发布那一刻才发 EchoCreated（见 notifyPublished / PublishDueEchos）。
+		if newEcho.IsPublished() {
+			return echoService.notifyPublished(txCtx, newEcho.ID, user)
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
 
 	echoService.echoRepository.InvalidateEchoCaches()
-
-	savedEcho, fetchErr := echoService.echoRepository.GetEchosById(ctx, newEcho.ID)
-	if fetchErr != nil {
-		return fetchErr
-	}
-	if savedEcho != nil {
-		eventbus.Notify(context.Background(), echoService.bus, event.EchoCreated{Echo: *savedEcho, User: user})
-	}
 	if err := echoService.fileService.ConfirmTempFiles(ctx, collectEchoFileIDs(newEcho)); err != nil {
 		logUtil.GetLogger().Warn("confirm temp files after post echo failed", logUtil.Err(err))
 	}
@@ -124,21 +131,15 @@ func (echoService *EchoService) GetEchosByPage(
 	})
 }
 
+// DeleteEchoById 把 Echo 移入回收站：附件、标签、扩展与修订都原样保留，可经 RestoreEcho 恢复；
+// 超过保留期或手动 PurgeEcho 后才真正删除。
 func (echoService *EchoService) DeleteEchoById(ctx context.Context, id string) error {
-	userid := viewer.MustFromContext(ctx).UserID()
-	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 
-	type deletableFileRef struct {
-		key         string
-		storageType string
-	}
-	var deletableFiles []deletableFileRef
+	var trashed *model.Echo
 	if err := echoService.transactor.Run(ctx, func(txCtx context.Context) error {
 		echo, err := echoService.echoRepository.GetEchosById(txCtx, id)
 		if err != nil {
@@ -147,54 +148,34 @@ func (echoService *EchoService) DeleteEchoById(ctx context.Context, id string) e
 		if echo == nil {
 			return errors.New(commonModel.ECHO_NOT_FOUND)
 		}
-
-		for _, ef := range echo.EchoFiles {
-			if ef.File.Key != "" && storage.NormalizeStorageType(ef.File.StorageType) != storage.StorageTypeExternal {
-				deletableFiles = append(deletableFiles, deletableFileRef{
-					key:         ef.File.Key,
-					storageType: ef.File.StorageType,
-				})
-			}
-			if ef.File.ID != "" {
-				if err := echoService.fileService.DeleteFileRecord(txCtx, ef.File.ID); err != nil {
-					return err
-				}
-			}
+		if !canEdit(user, echo) {
+			return errors.New(commonModel.NO_PERMISSION_DENIED)
 		}
-
-		return echoService.echoRepository.DeleteEchoById(txCtx, id)
+		echo.DeletedAt = time.Now().Unix()
+		if err := echoService.echoRepository.TrashEcho(txCtx, id, echo.DeletedAt); err != nil {
+			return err
+		}
+		trashed = echo
+		if err := echoService.echoRepository.DeleteSearchIndex(txCtx, id); err != nil {
+			return err
+		}
+		// 草稿 / 定时 Echo 从未对外可见，订阅方无需撤下。
+		if trashed.IsPublished() {
+			return eventbus.NotifyTx(txCtx, echoService.bus, event.EchoDeleted{Echo: *trashed, User: user, Restorable: true})
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
 
 	echoService.echoRepository.InvalidateEchoCaches(id)
-
-	eventbus.Notify(context.Background(), echoService.bus, event.EchoDeleted{Echo: model.Echo{ID: id}, User: user})
-
-	for _, file := range deletableFiles {
-		if err := echoService.fileService.DeleteStoredFile(file.storageType, file.key); err != nil {
-			logUtil.GetLogger().Warn(
-				"delete stored file after echo delete failed",
-				slog.String("echo_id", id),
-				slog.String("file_key", file.key),
-				slog.String("storage_type", file.storageType),
-				logUtil.Err(err),
-			)
-		}
-	}
-
 	return nil
 }
 
 func (echoService *EchoService) GetTodayEchos(ctx context.Context, timezone string) ([]model.Echo, error) {
-	userid := viewer.MustFromContext(ctx).UserID()
-	showPrivate := false
-	if userid != "" {
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
-		if err != nil {
-			return nil, err
-		}
-		showPrivate = user.IsAdmin
+	showPrivate, err := echoService.showPrivateFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	todayEchos := echoService.echoRepository.GetTodayEchos(showPrivate, timezone)
@@ -202,53 +183,35 @@ func (echoService *EchoService) GetTodayEchos(ctx context.Context, timezone stri
 }
 
 func (echoService *EchoService) GetHotEchos(ctx context.Context, limit int) ([]model.Echo, error) {
-	userid := viewer.MustFromContext(ctx).UserID()
-	showPrivate := false
-	if userid != "" {
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
-		if err != nil {
-			return nil, err
-		}
-		showPrivate = user.IsAdmin
+	showPrivate, err := echoService.showPrivateFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 	return echoService.echoRepository.GetHotEchos(limit, showPrivate)
 }
 
 func (echoService *EchoService) GetRandomEcho(ctx context.Context) (*model.Echo, error) {
-	userid := viewer.MustFromContext(ctx).UserID()
-	showPrivate := false
-	if userid != "" {
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
-		if err != nil {
-			return nil, err
-		}
-		showPrivate = user.IsAdmin
+	showPrivate, err := echoService.showPrivateFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 	return echoService.echoRepository.GetRandomEcho(showPrivate)
 }
 
 func (echoService *EchoService) GetOnThisDayEchos(ctx context.Context, timezone string) ([]model.Echo, error) {
-	userid := viewer.MustFromContext(ctx).UserID()
-	showPrivate := false
-	if userid != "" {
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
-		if err != nil {
-			return nil, err
-		}
-		showPrivate = user.IsAdmin
+	showPrivate, err := echoService.showPrivateFor(ctx)
+	if err != nil {
+		return nil, err
 	}
 	return echoService.echoRepository.GetOnThisDayEchos(showPrivate, timezone), nil
 }
 
 func (echoService *EchoService) UpdateEcho(ctx context.Context, echo *model.Echo) error {
-	userid := viewer.MustFromContext(ctx).UserID()
-	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
+	userid := viewer.MustFromContext(ctx).UserID()
 
 	layout := strings.TrimSpace(echo.Layout)
 	if layout == "" || (layout != model.LayoutWaterfall &&
@@ -259,6 +222,9 @@ func (echoService *EchoService) UpdateEcho(ctx context.Context, echo *model.Echo
 		layout != model.LayoutNone) {
 		echo.Layout = model.LayoutWaterfall
 	}
+	if !model.IsValidCommentPolicy(echo.CommentPolicy) {
+		echo.CommentPolicy = ""
+	}
 
 	normalizedExt, err := normalizeEchoExtension(echo.Extension)
 	if err != nil {
@@ -278,18 +244,48 @@ func (echoService *EchoService) UpdateEcho(ctx context.Context, echo *model.Echo
 		return err
 	}
 
+	var prev *model.Echo
 	if err := echoService.transactor.Run(ctx, func(txCtx context.Context) error {
+		var err error
+		if prev, err = echoService.echoRepository.GetEchosById(txCtx, echo.ID); err != nil {
+			return err
+		}
+		if prev == nil {
+			return errors.New(commonModel.ECHO_NOT_FOUND)
+		}
+		if !canEdit(user, prev) {
+			return errors.New(commonModel.NO_PERMISSION_DENIED)
+		}
+		if err := normalizePublishState(echo, prev, time.Now()); err != nil {
+			return err
+		}
 		if err := echoService.ProcessEchoTags(txCtx, echo); err != nil {
 			return err
 		}
-		return echoService.echoRepository.UpdateEcho(txCtx, echo)
+		if err := echoService.recordRevision(txCtx, prev, echo, userid); err != nil {
+			return err
+		}
+		if err := echoService.echoRepository.UpdateEcho(txCtx, echo); err != nil {
+			return err
+		}
+		if err := echoService.echoRepository.UpsertSearchIndex(txCtx, echo.ID, echo.Content); err != nil {
+			return err
+		}
+
+		// 对外可见性决定事件：已发布的改动照常 EchoUpdated；草稿首次发布算一次 EchoCreated；
+		// 仍未发布的编辑不对外广播。
+		switch {
+		case prev.IsPublished():
+			return eventbus.NotifyTx(txCtx, echoService.bus, event.EchoUpdated{Echo: *echo, User: user})
+		case echo.IsPublished():
+			return echoService.notifyPublished(txCtx, echo.ID, user)
+		}
+		return nil
 	}); err != nil {
 		return err
 	}
 
 	echoService.echoRepository.InvalidateEchoCaches(echo.ID)
-
-	eventbus.Notify(context.Background(), echoService.bus, event.EchoUpdated{Echo: *echo, User: user})
 	if err := echoService.fileService.ConfirmTempFiles(ctx, collectEchoFileIDs(echo)); err != nil {
 		logUtil.GetLogger().Warn("confirm temp files after update echo failed", logUtil.Err(err))
 	}
@@ -305,18 +301,13 @@ func (echoService *EchoService) LikeEcho(ctx context.Context, id string) error {
 	if echo == nil {
 		return errors.New(commonModel.ECHO_NOT_FOUND)
 	}
-	// 与 GetEchoById 的可见性规则保持一致：匿名调用方禁止点赞私密 echo，
-	// 已认证非管理员同样禁止；管理员（含 MCP 路径）允许。
-	if echo.Private {
-		userID := viewer.MustFromContext(ctx).UserID()
-		if userID == "" {
-			return errors.New(commonModel.NO_PERMISSION_DENIED)
-		}
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userID)
+	// 与 GetEchoById 的可见性规则保持一致：私密 / 未发布 echo 只有作者与 owner 能点赞。
+	if echo.Private || !echo.IsPublished() {
+		user, err := echoService.viewerUser(ctx)
 		if err != nil {
 			return err
 		}
-		if !user.IsAdmin {
+		if !canView(user, echo) {
 			return errors.New(commonModel.NO_PERMISSION_DENIED)
 		}
 	}
@@ -331,7 +322,6 @@ func (echoService *EchoService) LikeEcho(ctx context.Context, id string) error {
 }
 
 func (echoService *EchoService) GetEchoById(ctx context.Context, id string) (*model.Echo, error) {
-	userId := viewer.MustFromContext(ctx).UserID()
 	echo, err := echoService.echoRepository.GetEchosById(ctx, id)
 	if err != nil {
 		return nil, err
@@ -340,16 +330,13 @@ func (echoService *EchoService) GetEchoById(ctx context.Context, id string) (*mo
 		return nil, errors.New(commonModel.ECHO_NOT_FOUND)
 	}
 
-	if userId == "" {
-		if echo.Private {
-			return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
-		}
-	} else {
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userId)
+	// 草稿 / 定时 Echo 与私密 Echo 同等对待：仅作者本人与 owner 可读。
+	if echo.Private || !echo.IsPublished() {
+		user, err := echoService.viewerUser(ctx)
 		if err != nil {
 			return nil, err
 		}
-		if echo.Private && !user.IsAdmin {
+		if !canView(user, echo) {
 			return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
 		}
 	}
@@ -362,14 +349,10 @@ func (echoService *EchoService) GetAllTags() ([]model.Tag, error) {
 }
 
 func (echoService *EchoService) CreateTag(ctx context.Context, name string) (*model.Tag, error) {
-	userid := viewer.MustFromContext(ctx).UserID()
-	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
-	if err != nil {
+	// 发布时本就会自动建出新标签，能发 Echo 的角色都可以预先建标签
+	if _, err := echoService.requireUser(ctx, userModel.PermEchoWrite); err != nil {
 		return nil, err
 	}
-	if !user.IsAdmin {
-		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 
 	cleaned := strings.TrimSpace(strings.TrimPrefix(name, "#"))
 	if cleaned == "" {
@@ -399,14 +382,9 @@ func (echoService *EchoService) CreateTag(ctx context.Context, name string) (*mo
 }
 
 func (echoService *EchoService) DeleteTag(ctx context.Context, id string) error {
-	userid := viewer.MustFromContext(ctx).UserID()
-	user, err := echoService.commonService.CommonGetUserByUserId(ctx, userid)
-	if err != nil {
+	if _, err := echoService.requireUser(ctx, userModel.PermTagManage); err != nil {
 		return err
 	}
-	if !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 
 	return echoService.transactor.Run(ctx, func(txCtx context.Context) error {
 		return echoService.echoRepository.DeleteTagById(txCtx, id)
@@ -489,21 +467,26 @@ func (echoService *EchoService) QueryEchos(
 	}
 	queryDto.Search = strings.TrimSpace(queryDto.Search)
 
+	// 带检索词且未指定排序时默认按相关度（bm25）；FTS5 不可用时仓储层自动回落到按时间倒序。
 	if queryDto.SortBy == "" {
 		queryDto.SortBy = "created_at"
+		if queryDto.Search != "" {
+			queryDto.SortBy = "relevance"
+		}
 	}
 	if queryDto.SortOrder == "" {
 		queryDto.SortOrder = "desc"
 	}
 
-	userId := viewer.MustFromContext(ctx).UserID()
-	showPrivate := false
-	if userId != "" {
-		user, err := echoService.commonService.CommonGetUserByUserId(ctx, userId)
-		if err != nil {
-			return commonModel.PageQueryResult[[]model.Echo]{}, err
-		}
-		showPrivate = user.IsAdmin
+	// owner 看到全部；其余登录用户除公开内容外还能看到自己名下的私密 / 草稿。
+	user, err := echoService.viewerUser(ctx)
+	if err != nil {
+		return commonModel.PageQueryResult[[]model.Echo]{}, err
+	}
+	showPrivate := user != nil && user.Can(userModel.PermEchoViewPrivate)
+	queryDto.ViewerID = ""
+	if user != nil && !showPrivate {
+		queryDto.ViewerID = user.ID
 	}
 
 	echos, total, err := echoService.echoRepository.QueryEchos(queryDto, showPrivate)
@@ -512,11 +495,33 @@ func (echoService *EchoService) QueryEchos(
 	}
 
 	return commonModel.PageQueryResult[[]model.Echo]{
-		Items: echos,
-		Total: total,
+		Items:      echos,
+		Total:      total,
+		Highlights: buildSearchHighlights(echos, queryDto.Search),
 	}, nil
 }
 
+// buildSearchHighlights 为检索命中的 Echo 生成高亮片段（键为 Echo ID）。无检索词或无命中片段时返回 nil。
+func buildSearchHighlights(echos []model.Echo, search string) map[string]string {
+	if search == "" || len(echos) == 0 {
+		return nil
+	}
+	terms := ftsUtil.ParseQuery(search).Terms()
+	if len(terms) == 0 {
+		return nil
+	}
+	highlights := make(map[string]string, len(echos))
+	for _, e := range echos {
+		if snippet := ftsUtil.Highlight(e.Content, terms, ftsUtil.DefaultSnippetRunes); snippet != "" {
+			highlights[e.ID] = snippet
+		}
+	}
+	if len(highlights) == 0 {
+		return nil
+	}
+	return highlights
+}
+
 // isSafeTagName 拒绝包含 HTML 元字符的标签名，配合 RSS 渲染端的 HTML 转义形成纵深防御
 // （GHSA-3v85-fqvh-7rxf）。即使后续新增其他出口忘记转义，含 <>"'& 的标签也无法落库。
 func isSafeTagName(name string) bool {
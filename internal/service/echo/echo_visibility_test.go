@@ -10,6 +10,7 @@ import (
 
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	"github.com/lin-snow/ech0/internal/test/helpers"
 	commonmock "github.com/lin-snow/ech0/internal/test/mocks/commonmock"
@@ -32,7 +33,7 @@ const (
 )
 
 // TestGetEchoById_Visibility 覆盖私密 echo 的可见性规则：
-// 匿名/非作者非管理员看不到 private；管理员可见；公开 echo 任何人可见。
+// 匿名 / 非作者看不到 private（编辑也不行）；作者本人与 owner 可见；公开 echo 任何人可见。
 func TestGetEchoById_Visibility(t *testing.T) {
 	t.Run("anonymous cannot read private echo", func(t *testing.T) {
 		repo := echomock.NewMockRepository(t)
@@ -48,6 +49,18 @@ func TestGetEchoById_Visibility(t *testing.T) {
 		assert.Nil(t, got)
 	})
 
+	t.Run("anonymous cannot read draft echo", func(t *testing.T) {
+		repo := echomock.NewMockRepository(t)
+		common := commonmock.NewMockService(t)
+		draft := helpers.NewEcho(func(e *echoModel.Echo) { e.Status = echoModel.StatusDraft })
+		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&draft, nil).Once()
+
+		svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
+		_, err := svc.GetEchoById(helpers.CtxAnonymous(), echoID)
+
+		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	})
+
 	t.Run("anonymous can read public echo", func(t *testing.T) {
 		repo := echomock.NewMockRepository(t)
 		common := commonmock.NewMockService(t)
@@ -69,7 +82,7 @@ func TestGetEchoById_Visibility(t *testing.T) {
 		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&private, nil).Once()
 		common.EXPECT().
 			CommonGetUserByUserId(mock.Anything, userID).
-			Return(helpers.NewUser(), nil).
+			Return(helpers.NewUser(helpers.WithUserID(userID)), nil).
 			Once()
 
 		svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
@@ -79,14 +92,14 @@ func TestGetEchoById_Visibility(t *testing.T) {
 		assert.Nil(t, got)
 	})
 
-	t.Run("admin can read private echo", func(t *testing.T) {
+	t.Run("owner can read private echo", func(t *testing.T) {
 		repo := echomock.NewMockRepository(t)
 		common := commonmock.NewMockService(t)
 		private := helpers.NewEcho(helpers.AsPrivate)
 		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&private, nil).Once()
 		common.EXPECT().
 			CommonGetUserByUserId(mock.Anything, adminID).
-			Return(helpers.NewUser(helpers.AsAdmin), nil).
+			Return(helpers.NewUser(helpers.WithUserID(adminID), helpers.AsOwner), nil).
 			Once()
 
 		svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
@@ -97,6 +110,38 @@ func TestGetEchoById_Visibility(t *testing.T) {
 		assert.True(t, got.Private)
 	})
 
+	t.Run("editor cannot read someone else's private echo", func(t *testing.T) {
+		repo := echomock.NewMockRepository(t)
+		common := commonmock.NewMockService(t)
+		private := helpers.NewEcho(helpers.AsPrivate)
+		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&private, nil).Once()
+		common.EXPECT().
+			CommonGetUserByUserId(mock.Anything, adminID).
+			Return(helpers.NewUser(helpers.WithUserID(adminID), helpers.AsAdmin), nil).
+			Once()
+
+		svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
+		got, err := svc.GetEchoById(helpers.CtxAsUser(adminID), echoID)
+
+		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+		assert.Nil(t, got)
+	})
+
+	t.Run("author can read own private echo", func(t *testing.T) {
+		repo := echomock.NewMockRepository(t)
+		common := commonmock.NewMockService(t)
+		private := helpers.NewEcho(helpers.AsPrivate)
+		author := helpers.NewUser(helpers.WithRole(userModel.RoleAuthor))
+		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&private, nil).Once()
+		common.EXPECT().CommonGetUserByUserId(mock.Anything, author.ID).Return(author, nil).Once()
+
+		svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
+		got, err := svc.GetEchoById(helpers.CtxAsUser(author.ID), echoID)
+
+		require.NoError(t, err)
+		require.NotNil(t, got)
+	})
+
 	t.Run("not found returns ECHO_NOT_FOUND", func(t *testing.T) {
 		repo := echomock.NewMockRepository(t)
 		common := commonmock.NewMockService(t)
@@ -149,7 +194,7 @@ func TestLikeEcho_Visibility(t *testing.T) {
 		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&private, nil).Once()
 		common.EXPECT().
 			CommonGetUserByUserId(mock.Anything, userID).
-			Return(helpers.NewUser(), nil).
+			Return(helpers.NewUser(helpers.WithUserID(userID)), nil).
 			Once()
 
 		svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
@@ -158,7 +203,7 @@ func TestLikeEcho_Visibility(t *testing.T) {
 		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
 	})
 
-	t.Run("admin can like private echo", func(t *testing.T) {
+	t.Run("owner can like private echo", func(t *testing.T) {
 		repo := echomock.NewMockRepository(t)
 		common := commonmock.NewMockService(t)
 		tx := txmock.NewMockTransactor(t)
@@ -166,7 +211,7 @@ func TestLikeEcho_Visibility(t *testing.T) {
 		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&private, nil).Once()
 		common.EXPECT().
 			CommonGetUserByUserId(mock.Anything, adminID).
-			Return(helpers.NewUser(helpers.AsAdmin), nil).
+			Return(helpers.NewUser(helpers.WithUserID(adminID), helpers.AsOwner), nil).
 			Once()
 		tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
 		repo.EXPECT().LikeEcho(mock.Anything, echoID).Return(nil).Once()
@@ -251,3 +296,39 @@ func TestQueryEchos_PageSizeClamp(t *testing.T) {
 		})
 	}
 }
+
+// TestQueryEchos_ViewerScope 覆盖检索的私密可见范围：owner 看全部，
+// 其余登录用户带上 ViewerID 只额外看到自己的私密 / 未发布 Echo。
+func TestQueryEchos_ViewerScope(t *testing.T) {
+	cases := []struct {
+		name            string
+		user            userModel.User
+		wantShowPrivate bool
+		wantViewerID    string
+	}{
+		{"owner sees everything", helpers.NewUser(helpers.WithUserID(adminID), helpers.AsOwner), true, ""},
+		{"editor is scoped to own echos", helpers.NewUser(helpers.WithUserID(adminID), helpers.AsAdmin), false, adminID},
+		{"reader is scoped to own echos", helpers.NewUser(helpers.WithUserID(userID)), false, userID},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := echomock.NewMockRepository(t)
+			common := commonmock.NewMockService(t)
+			common.EXPECT().CommonGetUserByUserId(mock.Anything, tc.user.ID).Return(tc.user, nil).Once()
+
+			var captured commonModel.EchoQueryDto
+			repo.EXPECT().
+				QueryEchos(mock.Anything, tc.wantShowPrivate).
+				Run(func(dto commonModel.EchoQueryDto, _ bool) { captured = dto }).
+				Return([]echoModel.Echo{}, int64(0), nil).
+				Once()
+
+			svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
+			_, err := svc.QueryEchos(helpers.CtxAsUser(tc.user.ID), commonModel.EchoQueryDto{})
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantViewerID, captured.ViewerID)
+		})
+	}
+}
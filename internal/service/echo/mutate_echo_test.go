@@ -12,6 +12,7 @@ import (
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	echoService "github.com/lin-snow/ech0/internal/service/echo"
 	"github.com/lin-snow/ech0/internal/test/helpers"
 	commonmock "github.com/lin-snow/ech0/internal/test/mocks/commonmock"
@@ -86,11 +87,10 @@ func TestDeleteEchoById_GetEchoError(t *testing.T) {
 	require.ErrorIs(t, svc.DeleteEchoById(helpers.CtxAsUser(adminID), echoID), boom)
 }
 
-// TestDeleteEchoById_Success 覆盖完整删除路径：
-//   - 本地文件：登记进待删存储集合 + 删除文件记录；
-//   - 外部文件（external）：跳过存储删除（仅删记录）；
-//   - 仅删本地的物理对象，失败被吞（不影响返回）；
-//   - 缓存失效 + 发出 EchoDeleted。
+// TestDeleteEchoById_Success 覆盖移入回收站：
+//   - 只打删除时间戳，附件记录与存储中的文件都不动；
+//   - 撤下全文索引 + 缓存失效；
+//   - 发出带 Restorable 的 EchoDeleted，携带完整 Echo。
 func TestDeleteEchoById_Success(t *testing.T) {
 	repo := echomock.NewMockRepository(t)
 	common := commonmock.NewMockService(t)
@@ -108,17 +108,12 @@ func TestDeleteEchoById_Success(t *testing.T) {
 		e.ID = echoID
 		e.EchoFiles = []fileModel.EchoFile{
 			{File: fileModel.File{ID: "f-local", Key: "k-local", StorageType: "local"}},
-			{File: fileModel.File{ID: "f-ext", Key: "k-ext", StorageType: "external"}},
 		}
 	})
 	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&stored, nil).Once()
-	// 两个文件都有记录 ID -> 都删记录。
-	file.EXPECT().DeleteFileRecord(mock.Anything, "f-local").Return(nil).Once()
-	file.EXPECT().DeleteFileRecord(mock.Anything, "f-ext").Return(nil).Once()
-	repo.EXPECT().DeleteEchoById(mock.Anything, echoID).Return(nil).Once()
+	repo.EXPECT().TrashEcho(mock.Anything, echoID, mock.AnythingOfType("int64")).Return(nil).Once()
+	repo.EXPECT().DeleteSearchIndex(mock.Anything, echoID).Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
-	// 仅本地对象进入物理删除（external 被排除）；删除失败被忽略。
-	file.EXPECT().DeleteStoredFile("local", "k-local").Return(errors.New("ignored")).Once()
 
 	var got event.EchoDeleted
 	var fired int
@@ -135,31 +130,29 @@ func TestDeleteEchoById_Success(t *testing.T) {
 
 	require.Equal(t, 1, fired)
 	assert.Equal(t, echoID, got.Echo.ID)
+	assert.Equal(t, stored.Content, got.Echo.Content)
+	assert.NotZero(t, got.Echo.DeletedAt)
+	assert.True(t, got.Restorable)
 	assert.True(t, got.User.IsAdmin)
 }
 
-// TestDeleteEchoById_DeleteFileRecordError 确认事务内删除文件记录失败时整体回滚并上抛，
-// 不触达缓存失效 / 物理删除 / 事件。
-func TestDeleteEchoById_DeleteFileRecordError(t *testing.T) {
+// TestDeleteEchoById_TrashError 确认移入回收站失败时整体回滚并上抛，不触达缓存失效 / 事件。
+func TestDeleteEchoById_TrashError(t *testing.T) {
 	repo := echomock.NewMockRepository(t)
 	common := commonmock.NewMockService(t)
-	file := filemock.NewMockService(t)
 	tx := txmock.NewMockTransactor(t)
-	boom := errors.New("delete record failed")
+	boom := errors.New("trash failed")
 
 	common.EXPECT().
 		CommonGetUserByUserId(mock.Anything, adminID).
 		Return(helpers.NewUser(helpers.AsAdmin), nil).
 		Once()
 	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
-	stored := helpers.NewEcho(func(e *echoModel.Echo) {
-		e.ID = echoID
-		e.EchoFiles = []fileModel.EchoFile{{File: fileModel.File{ID: "f-1", Key: "k-1", StorageType: "local"}}}
-	})
+	stored := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = echoID })
 	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&stored, nil).Once()
-	file.EXPECT().DeleteFileRecord(mock.Anything, "f-1").Return(boom).Once()
+	repo.EXPECT().TrashEcho(mock.Anything, echoID, mock.AnythingOfType("int64")).Return(boom).Once()
 
-	svc := echoService.NewEchoService(tx, common, file, repo, nilBus)
+	svc := echoService.NewEchoService(tx, common, nil, repo, nilBus)
 	require.ErrorIs(t, svc.DeleteEchoById(helpers.CtxAsUser(adminID), echoID), boom)
 }
 
@@ -233,6 +226,15 @@ func TestUpdateEcho_Success(t *testing.T) {
 		Return([]commonModel.FileDto{{ID: "file-1", Category: "image"}}, nil).
 		Once()
 	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	// 被覆盖的旧版本在同一事务内写成修订。
+	stored := helpers.NewEcho()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&stored, nil).Once()
+	var revision *echoModel.EchoRevision
+	repo.EXPECT().
+		CreateEchoRevision(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, r *echoModel.EchoRevision) { revision = r }).
+		Return(nil).
+		Once()
 	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
 
 	var updated echoModel.Echo
@@ -241,6 +243,7 @@ func TestUpdateEcho_Success(t *testing.T) {
 		Run(func(_ context.Context, e *echoModel.Echo) { updated = *e }).
 		Return(nil).
 		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, "updated").Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
 	file.EXPECT().ConfirmTempFiles(mock.Anything, []string{"file-1"}).Return(nil).Once()
 
@@ -266,6 +269,9 @@ func TestUpdateEcho_Success(t *testing.T) {
 	assert.Equal(t, echoModel.LayoutWaterfall, updated.Layout)
 	require.Len(t, updated.EchoFiles, 1)
 	assert.Equal(t, echoID, updated.EchoFiles[0].EchoID) // EchoID 被回填
+	require.NotNil(t, revision)
+	assert.Equal(t, stored.Content, revision.Content)
+	assert.Equal(t, adminID, revision.EditorID)
 	require.Equal(t, 1, fired)
 	assert.Equal(t, echoID, got.Echo.ID)
 }
@@ -282,6 +288,9 @@ func TestUpdateEcho_TransactionError(t *testing.T) {
 		Return(helpers.NewUser(helpers.AsAdmin), nil).
 		Once()
 	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	stored := helpers.NewEcho()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&stored, nil).Once()
+	repo.EXPECT().CreateEchoRevision(mock.Anything, mock.Anything).Return(nil).Once()
 	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
 	repo.EXPECT().UpdateEcho(mock.Anything, mock.Anything).Return(boom).Once()
 
@@ -291,3 +300,22 @@ func TestUpdateEcho_TransactionError(t *testing.T) {
 		boom,
 	)
 }
+
+// TestUpdateEcho_AuthorCannotEditOthers 确认作者只能修改自己的 Echo：权限不足在写任何数据前返回。
+func TestUpdateEcho_AuthorCannotEditOthers(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+
+	author := helpers.NewUser(helpers.WithUserID(userID), helpers.WithRole(userModel.RoleAuthor))
+	common.EXPECT().CommonGetUserByUserId(mock.Anything, userID).Return(author, nil).Once()
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	stored := helpers.NewEcho() // 作者是 helpers 默认用户，不是 author
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&stored, nil).Once()
+
+	svc := echoService.NewEchoService(tx, common, nil, repo, nilBus)
+	require.EqualError(t,
+		svc.UpdateEcho(helpers.CtxAsUser(userID), &echoModel.Echo{ID: echoID, Content: "x", Layout: echoModel.LayoutGrid}),
+		commonModel.NO_PERMISSION_DENIED,
+	)
+}
@@ -28,6 +28,14 @@ type Service interface {
 	GetHotEchos(ctx context.Context, limit int) ([]model.Echo, error)
 	GetRandomEcho(ctx context.Context) (*model.Echo, error)
 	GetOnThisDayEchos(ctx context.Context, timezone string) ([]model.Echo, error)
+	ListEchoRevisions(ctx context.Context, echoID string) ([]model.EchoRevision, error)
+	GetEchoRevision(ctx context.Context, echoID, revisionID, compareTo string) (*model.EchoRevisionDetail, error)
+	RestoreEchoRevision(ctx context.Context, echoID, revisionID string) (*model.Echo, error)
+	PublishDueEchos(ctx context.Context) (int, error)
+	ListTrashedEchos(ctx context.Context, pageQueryDto commonModel.PageQueryDto) (commonModel.PageQueryResult[[]model.Echo], error)
+	RestoreEcho(ctx context.Context, id string) (*model.Echo, error)
+	PurgeEcho(ctx context.Context, id string) error
+	PurgeExpiredTrash(ctx context.Context, before int64) (int, error)
 }
 
 type (
@@ -54,4 +62,17 @@ type Repository interface {
 	GetHotEchos(limit int, showPrivate bool) ([]model.Echo, error)
 	GetRandomEcho(showPrivate bool) (*model.Echo, error)
 	GetOnThisDayEchos(showPrivate bool, timezone string) []model.Echo
+	UpsertSearchIndex(ctx context.Context, echoID, content string) error
+	DeleteSearchIndex(ctx context.Context, echoID string) error
+	CreateEchoRevision(ctx context.Context, revision *model.EchoRevision) error
+	ListEchoRevisions(ctx context.Context, echoID string) ([]model.EchoRevision, error)
+	GetEchoRevision(ctx context.Context, echoID, revisionID string) (*model.EchoRevision, error)
+	GetDueScheduledEchos(ctx context.Context, now int64, limit int) ([]model.Echo, error)
+	PublishScheduledEcho(ctx context.Context, id string) (bool, error)
+	TrashEcho(ctx context.Context, id string, deletedAt int64) error
+	RestoreEcho(ctx context.Context, id string) error
+	GetTrashedEchoById(ctx context.Context, id string) (*model.Echo, error)
+	ListTrashedEchos(ctx context.Context, page, pageSize int, userID string) ([]model.Echo, int64, error)
+	GetExpiredTrashedEchoIDs(ctx context.Context, before int64, limit int) ([]string, error)
+	PruneDanglingEchoFiles(ctx context.Context, echoID string) error
 }
@@ -115,6 +115,8 @@ func TestPostEcho_Success(t *testing.T) {
 		Run(func(_ context.Context, e *echoModel.Echo) { created = *e }).
 		Return(nil).
 		Once()
+	// 全文索引与主表写入同一事务，写入的是原始正文。
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, "hello").Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches().Once()
 
 	saved := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "saved-1"; e.Content = "hello" })
@@ -169,6 +171,7 @@ func TestPostEcho_ValidLayoutPreserved(t *testing.T) {
 		Run(func(_ context.Context, e *echoModel.Echo) { created = *e }).
 		Return(nil).
 		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches().Once()
 	saved := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "saved-2" })
 	repo.EXPECT().GetEchosById(mock.Anything, mock.Anything).Return(&saved, nil).Once()
@@ -219,6 +222,7 @@ func TestPostEcho_SavedEchoNilSkipsEvent(t *testing.T) {
 	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
 	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
 	repo.EXPECT().CreateEcho(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches().Once()
 	repo.EXPECT().GetEchosById(mock.Anything, mock.Anything).Return(nil, nil).Once()
 	file.EXPECT().ConfirmTempFiles(mock.Anything, mock.Anything).Return(nil).Once()
@@ -237,7 +241,7 @@ func TestPostEcho_SavedEchoNilSkipsEvent(t *testing.T) {
 	assert.Equal(t, 0, fired)
 }
 
-// TestPostEcho_RefetchError 确认事务成功后回查出错时返回该错误。
+// TestPostEcho_RefetchError 确认事务内回查出错时整笔回滚（不清缓存）并返回该错误。
 func TestPostEcho_RefetchError(t *testing.T) {
 	repo := echomock.NewMockRepository(t)
 	common := commonmock.NewMockService(t)
@@ -252,7 +256,7 @@ func TestPostEcho_RefetchError(t *testing.T) {
 	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
 	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
 	repo.EXPECT().CreateEcho(mock.Anything, mock.Anything).Return(nil).Once()
-	repo.EXPECT().InvalidateEchoCaches().Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	repo.EXPECT().GetEchosById(mock.Anything, mock.Anything).Return(nil, boom).Once()
 
 	svc := echoService.NewEchoService(tx, common, nil, repo, func() *busen.Bus { return bus })
@@ -282,6 +286,7 @@ func TestPostEcho_LayoutNonePreserved(t *testing.T) {
 		Run(func(_ context.Context, e *echoModel.Echo) { created = *e }).
 		Return(nil).
 		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches().Once()
 	saved := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "saved-none" })
 	repo.EXPECT().GetEchosById(mock.Anything, mock.Anything).Return(&saved, nil).Once()
@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// publishBatchSize 是定时任务单次最多发布的 Echo 数，余下的留给下一轮。
+const publishBatchSize = 50
+
+// PublishDueEchos 发布所有到期的定时 Echo，返回本轮实际发布的条数。
+//
+// 由定时任务以系统身份调用（无 viewer）。每条 Echo 在转为 published 后才发 EchoCreated，
+// Webhook、RSS 与 Embedding 索引因此只在发布时刻第一次看到它。
+func (echoService *EchoService) PublishDueEchos(ctx context.Context) (int, error) {
+	due, err := echoService.echoRepository.GetDueScheduledEchos(ctx, time.Now().Unix(), publishBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	for _, e := range due {
+		owner, err := echoService.commonService.CommonGetUserByUserId(ctx, e.UserID)
+		if err != nil {
+			logUtil.GetLogger().Warn("resolve owner of scheduled echo failed",
+				slog.String("echo_id", e.ID), logUtil.Err(err))
+			owner = userModel.User{}
+		}
+
+		var ok bool
+		if err := echoService.transactor.Run(ctx, func(txCtx context.Context) error {
+			if ok, err = echoService.echoRepository.PublishScheduledEcho(txCtx, e.ID); err != nil || !ok {
+				// !ok：已被手动发布或改回草稿。
+				return err
+			}
+			return echoService.notifyPublished(txCtx, e.ID, owner)
+		}); err != nil {
+			logUtil.GetLogger().Error("publish scheduled echo failed",
+				slog.String("echo_id", e.ID), logUtil.Err(err))
+			continue
+		}
+		if !ok {
+			continue
+		}
+		published++
+		echoService.echoRepository.InvalidateEchoCaches(e.ID)
+	}
+	return published, nil
+}
+
+// notifyPublished 回读已发布的 Echo（带齐文件 / 标签 / 扩展）并发出 EchoCreated。须在发布它的
+// 事务里调用，事件随事务提交才对外发出；webhook outbox 写入失败时返回错误，发布随之回滚。
+func (echoService *EchoService) notifyPublished(ctx context.Context, echoID string, user userModel.User) error {
+	saved, err := echoService.echoRepository.GetEchosById(ctx, echoID)
+	if err != nil {
+		return err
+	}
+	if saved == nil {
+		return nil
+	}
+	return eventbus.NotifyTx(ctx, echoService.bus, event.EchoCreated{Echo: *saved, User: user})
+}
+
+// normalizePublishState 校验并补全新建（prev 为 nil）或更新时的发布状态。
+//
+//   - 状态为空：新建视为 published，更新沿用原状态与原计划时间；
+//   - 已发布的 Echo 不能退回 draft / scheduled；
+//   - 首次发布时 PublishAt 记为当前时间，草稿转发布还会把 created_at 挪到发布时刻，
+//     使其按发布时间进入时间线（显式改过 created_at 的请求除外）；
+//   - scheduled 要求 PublishAt 晚于当前时间；编辑仍在排期、计划时间未改但已到期的 Echo 时
+//     直接按计划时间发布；draft 的 PublishAt 恒为 0。
+func normalizePublishState(echo, prev *model.Echo, now time.Time) error {
+	if prev != nil && echo.Status == "" {
+		echo.Status = prev.Status
+		if echo.PublishAt == 0 {
+			echo.PublishAt = prev.PublishAt
+		}
+	}
+	if prev != nil && prev.IsPublished() && !echo.IsPublished() {
+		return errors.New(commonModel.ECHO_ALREADY_PUBLISHED)
+	}
+
+	switch echo.Status {
+	case "", model.StatusPublished:
+		echo.Status = model.StatusPublished
+		switch {
+		case prev == nil:
+			echo.PublishAt = now.Unix()
+		case prev.IsPublished():
+			echo.PublishAt = prev.PublishAt
+		default:
+			echo.PublishAt = now.Unix()
+			if echo.CreatedAt == 0 || echo.CreatedAt == prev.CreatedAt {
+				echo.CreatedAt = now.Unix()
+			}
+		}
+	case model.StatusDraft:
+		echo.PublishAt = 0
+	case model.StatusScheduled:
+		if echo.PublishAt > now.Unix() {
+			break
+		}
+		// 计划时间未改、只是已经到期（定时任务还没来得及跑）：直接按计划时间发布，
+		// 与 PublishScheduledEcho 一致，而不是拒绝这次编辑。
+		if prev == nil || prev.Status != model.StatusScheduled || echo.PublishAt != prev.PublishAt {
+			return errors.New(commonModel.ECHO_PUBLISH_AT_INVALID)
+		}
+		echo.Status = model.StatusPublished
+		if echo.CreatedAt == 0 || echo.CreatedAt == prev.CreatedAt {
+			echo.CreatedAt = echo.PublishAt
+		}
+	default:
+		return errors.New(commonModel.ECHO_STATUS_INVALID)
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/event"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	commonmock "github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	echomock "github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	filemock "github.com/lin-snow/ech0/internal/test/mocks/filemock"
+	txmock "github.com/lin-snow/ech0/internal/test/mocks/txmock"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// countEvents 同步订阅 E，返回累计投递次数的读取函数。
+func countEvents[E any](t *testing.T, bus *busen.Bus) func() int {
+	t.Helper()
+	var n int
+	unsub, err := busen.Subscribe(bus, func(context.Context, busen.Event[E]) error {
+		n++
+		return nil
+	})
+	require.NoError(t, err)
+	t.Cleanup(unsub)
+	return func() int { return n }
+}
+
+// 草稿保存时不发 EchoCreated，Webhook / RSS / Embedding 都看不到它。
+func TestPostEcho_DraftDefersEchoCreated(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
+	var created echoModel.Echo
+	repo.EXPECT().
+		CreateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { created = *e }).
+		Return(nil).
+		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, "wip").Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches().Once()
+	file.EXPECT().ConfirmTempFiles(mock.Anything, mock.Anything).Return(nil).Once()
+	createdEvents := countEvents[event.EchoCreated](t, bus)
+
+	svc := echoService.NewEchoService(tx, common, file, repo, func() *busen.Bus { return bus })
+	require.NoError(t, svc.PostEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{
+		Content:   "wip",
+		Status:    echoModel.StatusDraft,
+		PublishAt: 12345,
+	}))
+
+	assert.Equal(t, echoModel.StatusDraft, created.Status)
+	assert.Zero(t, created.PublishAt, "drafts carry no publish time")
+	assert.Zero(t, createdEvents())
+}
+
+func TestPostEcho_PublishStateValidation(t *testing.T) {
+	cases := []struct {
+		name string
+		echo echoModel.Echo
+		want string
+	}{
+		{"scheduled in the past", echoModel.Echo{Content: "x", Status: echoModel.StatusScheduled, PublishAt: time.Now().Add(-time.Minute).Unix()}, commonModel.ECHO_PUBLISH_AT_INVALID},
+		{"scheduled without time", echoModel.Echo{Content: "x", Status: echoModel.StatusScheduled}, commonModel.ECHO_PUBLISH_AT_INVALID},
+		{"unknown status", echoModel.Echo{Content: "x", Status: "archived"}, commonModel.ECHO_STATUS_INVALID},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			common := commonmock.NewMockService(t)
+			expectAdmin(common)
+			svc := echoService.NewEchoService(nil, common, nil, echomock.NewMockRepository(t), nilBus)
+			echo := tc.echo
+			require.EqualError(t, svc.PostEcho(helpers.CtxAsUser(adminID), &echo), tc.want)
+		})
+	}
+}
+
+// 草稿转发布：发 EchoCreated 而不是 EchoUpdated，created_at 挪到发布时刻。
+func TestUpdateEcho_PublishingDraftEmitsCreated(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	draft := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.Status = echoModel.StatusDraft
+		e.CreatedAt = 100
+	})
+	published := draft
+	published.Status = echoModel.StatusPublished
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&draft, nil).Once()
+	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
+	repo.EXPECT().CreateEchoRevision(mock.Anything, mock.Anything).Return(nil).Once()
+	var updated echoModel.Echo
+	repo.EXPECT().
+		UpdateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { updated = *e }).
+		Return(nil).
+		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, "ready").Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&published, nil).Once()
+	file.EXPECT().ConfirmTempFiles(mock.Anything, mock.Anything).Return(nil).Once()
+	createdEvents := countEvents[event.EchoCreated](t, bus)
+	updatedEvents := countEvents[event.EchoUpdated](t, bus)
+
+	before := time.Now().Unix()
+	svc := echoService.NewEchoService(tx, common, file, repo, func() *busen.Bus { return bus })
+	require.NoError(t, svc.UpdateEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{
+		ID:        echoID,
+		Content:   "ready",
+		Status:    echoModel.StatusPublished,
+		CreatedAt: 100,
+	}))
+
+	assert.Equal(t, echoModel.StatusPublished, updated.Status)
+	assert.GreaterOrEqual(t, updated.CreatedAt, before)
+	assert.Equal(t, updated.CreatedAt, updated.PublishAt)
+	assert.Equal(t, 1, createdEvents())
+	assert.Zero(t, updatedEvents())
+}
+
+// 未发布的编辑不对外广播；状态缺省时沿用原状态。
+func TestUpdateEcho_DraftEditIsSilent(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	scheduled := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.Status = echoModel.StatusScheduled
+		e.PublishAt = time.Now().Add(time.Hour).Unix()
+	})
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&scheduled, nil).Once()
+	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
+	repo.EXPECT().CreateEchoRevision(mock.Anything, mock.Anything).Return(nil).Once()
+	var updated echoModel.Echo
+	repo.EXPECT().
+		UpdateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { updated = *e }).
+		Return(nil).
+		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, "tweaked").Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+	file.EXPECT().ConfirmTempFiles(mock.Anything, mock.Anything).Return(nil).Once()
+	createdEvents := countEvents[event.EchoCreated](t, bus)
+	updatedEvents := countEvents[event.EchoUpdated](t, bus)
+
+	svc := echoService.NewEchoService(tx, common, file, repo, func() *busen.Bus { return bus })
+	require.NoError(t, svc.UpdateEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{ID: echoID, Content: "tweaked"}))
+
+	assert.Equal(t, echoModel.StatusScheduled, updated.Status)
+	assert.Equal(t, scheduled.PublishAt, updated.PublishAt)
+	assert.Zero(t, createdEvents())
+	assert.Zero(t, updatedEvents())
+}
+
+// 定时 Echo 已到期但定时任务还没跑：原样编辑（计划时间未改）按计划时间直接发布，不报 PublishAt 无效。
+func TestUpdateEcho_DueScheduledEditPublishes(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	due := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.Status = echoModel.StatusScheduled
+		e.PublishAt = time.Now().Add(-time.Minute).Unix()
+		e.CreatedAt = 100
+	})
+	published := due
+	published.Status = echoModel.StatusPublished
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&due, nil).Once()
+	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).Return([]*echoModel.Tag{}, nil).Once()
+	repo.EXPECT().CreateEchoRevision(mock.Anything, mock.Anything).Return(nil).Once()
+	var updated echoModel.Echo
+	repo.EXPECT().
+		UpdateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { updated = *e }).
+		Return(nil).
+		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, "last tweak").Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&published, nil).Once()
+	file.EXPECT().ConfirmTempFiles(mock.Anything, mock.Anything).Return(nil).Once()
+	createdEvents := countEvents[event.EchoCreated](t, bus)
+
+	svc := echoService.NewEchoService(tx, common, file, repo, func() *busen.Bus { return bus })
+	require.NoError(t, svc.UpdateEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{
+		ID:        echoID,
+		Content:   "last tweak",
+		Status:    echoModel.StatusScheduled,
+		PublishAt: due.PublishAt,
+	}))
+
+	assert.Equal(t, echoModel.StatusPublished, updated.Status)
+	assert.Equal(t, due.PublishAt, updated.PublishAt)
+	assert.Equal(t, due.PublishAt, updated.CreatedAt)
+	assert.Equal(t, 1, createdEvents())
+
+	// 改成一个已过去的新时间仍然拒绝。
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&due, nil).Once()
+	require.EqualError(t,
+		svc.UpdateEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{
+			ID:        echoID,
+			Content:   "x",
+			Status:    echoModel.StatusScheduled,
+			PublishAt: due.PublishAt - 60,
+		}),
+		commonModel.ECHO_PUBLISH_AT_INVALID,
+	)
+}
+
+func TestUpdateEcho_PublishedCannotRevertToDraft(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	expectAdmin(common)
+
+	live := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = echoID; e.Status = echoModel.StatusPublished })
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&live, nil).Once()
+
+	svc := echoService.NewEchoService(tx, common, nil, repo, nilBus)
+	require.EqualError(t,
+		svc.UpdateEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{ID: echoID, Content: "x", Status: echoModel.StatusDraft}),
+		commonModel.ECHO_ALREADY_PUBLISHED,
+	)
+}
+
+// 到期的定时 Echo 逐条发布并各发一次 EchoCreated；抢输的（已被手动处理）跳过。
+func TestPublishDueEchos(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+
+	due := []echoModel.Echo{
+		{ID: "due-1", UserID: adminID, Status: echoModel.StatusScheduled},
+		{ID: "due-2", UserID: adminID, Status: echoModel.StatusScheduled},
+	}
+	repo.EXPECT().GetDueScheduledEchos(mock.Anything, mock.Anything, mock.Anything).Return(due, nil).Once()
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Times(2)
+	repo.EXPECT().PublishScheduledEcho(mock.Anything, "due-1").Return(true, nil).Once()
+	repo.EXPECT().PublishScheduledEcho(mock.Anything, "due-2").Return(false, nil).Once()
+	repo.EXPECT().InvalidateEchoCaches("due-1").Once()
+	published := echoModel.Echo{ID: "due-1", UserID: adminID, Status: echoModel.StatusPublished}
+	repo.EXPECT().GetEchosById(mock.Anything, "due-1").Return(&published, nil).Once()
+	expectAdmin(common)
+
+	var got event.EchoCreated
+	unsub, err := busen.Subscribe(bus, func(_ context.Context, e busen.Event[event.EchoCreated]) error {
+		got = e.Value
+		return nil
+	})
+	require.NoError(t, err)
+	defer unsub()
+	createdEvents := countEvents[event.EchoCreated](t, bus)
+
+	svc := echoService.NewEchoService(tx, common, nil, repo, func() *busen.Bus { return bus })
+	n, err := svc.PublishDueEchos(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 1, createdEvents())
+	assert.Equal(t, "due-1", got.Echo.ID)
+	assert.True(t, got.User.IsAdmin)
+}
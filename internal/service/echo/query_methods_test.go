@@ -73,7 +73,7 @@ func readMethods() []readMethod {
 }
 
 // TestReadEchos_ShowPrivateResolution 锁定四个只读方法共享的可见性解析：
-// 匿名 / 非管理员 → showPrivate=false（不查私密）；管理员 → showPrivate=true。
+// 匿名 / 编辑 / 普通用户 → showPrivate=false（不查私密）；owner → showPrivate=true。
 // 匿名分支不应触达 commonService。
 func TestReadEchos_ShowPrivateResolution(t *testing.T) {
 	viewerCases := []struct {
@@ -89,16 +89,27 @@ func TestReadEchos_ShowPrivateResolution(t *testing.T) {
 			showPrivate: false,
 		},
 		{
-			name: "admin resolves to private-visible",
+			name: "owner resolves to private-visible",
 			ctx:  helpers.CtxAsUser(adminID),
 			setupCommon: func(c *commonmock.MockService) {
 				c.EXPECT().
 					CommonGetUserByUserId(mock.Anything, adminID).
-					Return(helpers.NewUser(helpers.AsAdmin), nil).
+					Return(helpers.NewUser(helpers.AsOwner), nil).
 					Once()
 			},
 			showPrivate: true,
 		},
+		{
+			name: "editor resolves to public-only",
+			ctx:  helpers.CtxAsUser(adminID),
+			setupCommon: func(c *commonmock.MockService) {
+				c.EXPECT().
+					CommonGetUserByUserId(mock.Anything, adminID).
+					Return(helpers.NewUser(helpers.AsAdmin), nil).
+					Once()
+			},
+			showPrivate: false,
+		},
 		{
 			name: "non-admin resolves to public-only",
 			ctx:  helpers.CtxAsUser(userID),
@@ -173,14 +184,14 @@ func TestReadEchos_RepoErrorPropagates(t *testing.T) {
 }
 
 // TestQueryEchos_ViewerResolution 覆盖 QueryEchos 的 showPrivate 解析：
-// 管理员可见私密；已认证用户解析失败时上抛错误且不查询。
+// owner 可见私密；已认证用户解析失败时上抛错误且不查询。
 func TestQueryEchos_ViewerResolution(t *testing.T) {
-	t.Run("admin queries with showPrivate true", func(t *testing.T) {
+	t.Run("owner queries with showPrivate true", func(t *testing.T) {
 		repo := echomock.NewMockRepository(t)
 		common := commonmock.NewMockService(t)
 		common.EXPECT().
 			CommonGetUserByUserId(mock.Anything, adminID).
-			Return(helpers.NewUser(helpers.AsAdmin), nil).
+			Return(helpers.NewUser(helpers.AsOwner), nil).
 			Once()
 		repo.EXPECT().
 			QueryEchos(mock.Anything, true).
@@ -219,6 +230,27 @@ func TestQueryEchos_ViewerResolution(t *testing.T) {
 	})
 }
 
+// TestQueryEchos_Search 覆盖带检索词的查询：未指定排序时默认按相关度，且为命中的 Echo 返回高亮片段。
+func TestQueryEchos_Search(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+
+	var captured commonModel.EchoQueryDto
+	repo.EXPECT().
+		QueryEchos(mock.Anything, false).
+		Run(func(dto commonModel.EchoQueryDto, _ bool) { captured = dto }).
+		Return([]echoModel.Echo{helpers.NewEcho()}, int64(1), nil).
+		Once()
+
+	svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
+	got, err := svc.QueryEchos(helpers.CtxAnonymous(), commonModel.EchoQueryDto{
+		Page: 1, PageSize: 10, Search: "world",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "relevance", captured.SortBy)
+	assert.Equal(t, map[string]string{"echo-test-0001": "hello <mark>world</mark>"}, got.Highlights)
+}
+
 // TestGetEchosByPage_DelegatesToQuery 确认弃用的分页接口透传 Page/PageSize/Search 给 QueryEchos。
 func TestGetEchosByPage_DelegatesToQuery(t *testing.T) {
 	repo := echomock.NewMockRepository(t)
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	diffUtil "github.com/lin-snow/ech0/internal/util/diff"
+)
+
+// ListEchoRevisions 返回一条 Echo 的全部历史版本（最新在前），与编辑同权：作者本人、
+// 或可编辑他人 Echo 的角色（他人的私密 Echo 仍仅 owner）。
+func (echoService *EchoService) ListEchoRevisions(ctx context.Context, echoID string) ([]model.EchoRevision, error) {
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
+	if err != nil {
+		return nil, err
+	}
+	echo, err := echoService.echoRepository.GetEchosById(ctx, echoID)
+	if err != nil {
+		return nil, err
+	}
+	if echo != nil && !canEdit(user, echo) {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	return echoService.echoRepository.ListEchoRevisions(ctx, echoID)
+}
+
+// GetEchoRevision 返回单个历史版本及其正文的逐行差分。
+//
+// compareTo 为 next（默认）时与紧随其后的版本对比，展示这次编辑改了什么；
+// 最新修订的「下一个版本」就是 Echo 当前内容。compareTo 为 current 时始终与当前内容对比。
+func (echoService *EchoService) GetEchoRevision(
+	ctx context.Context,
+	echoID, revisionID, compareTo string,
+) (*model.EchoRevisionDetail, error) {
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
+	if err != nil {
+		return nil, err
+	}
+	compareTo = strings.TrimSpace(compareTo)
+	if compareTo == "" {
+		compareTo = model.RevisionCompareNext
+	}
+	if compareTo != model.RevisionCompareNext && compareTo != model.RevisionCompareCurrent {
+		return nil, errors.New(commonModel.INVALID_PARAMS)
+	}
+
+	current, err := echoService.echoRepository.GetEchosById(ctx, echoID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New(commonModel.ECHO_NOT_FOUND)
+	}
+	if !canEdit(user, current) {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	revision, err := echoService.echoRepository.GetEchoRevision(ctx, echoID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	target := current.Content
+	if compareTo == model.RevisionCompareNext {
+		revisions, err := echoService.echoRepository.ListEchoRevisions(ctx, echoID)
+		if err != nil {
+			return nil, err
+		}
+		// revisions 按序号倒序，最靠近且更大的序号即下一个版本。
+		for _, r := range revisions {
+			if r.Number > revision.Number {
+				target = r.Content
+				continue
+			}
+			break
+		}
+	}
+
+	lines := diffUtil.Lines(revision.Content, target)
+	return &model.EchoRevisionDetail{
+		Revision:  *revision,
+		CompareTo: compareTo,
+		Diff:      lines,
+		Stats:     diffUtil.Summarize(lines),
+	}, nil
+}
+
+// RestoreEchoRevision 把 Echo 恢复成指定历史版本。
+//
+// 恢复走 UpdateEcho 的完整路径：被覆盖的当前版本同样会留下一条修订，
+// 并像普通编辑一样发出 EchoUpdated。快照里已不存在的附件会被跳过。
+func (echoService *EchoService) RestoreEchoRevision(ctx context.Context, echoID, revisionID string) (*model.Echo, error) {
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
+	if err != nil {
+		return nil, err
+	}
+	current, err := echoService.echoRepository.GetEchosById(ctx, echoID)
+	if err != nil {
+		return nil, err
+	}
+	if current == nil {
+		return nil, errors.New(commonModel.ECHO_NOT_FOUND)
+	}
+	if !canEdit(user, current) {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	revision, err := echoService.echoRepository.GetEchoRevision(ctx, echoID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	restored := &model.Echo{
+		ID:        current.ID,
+		Content:   revision.Content,
+		Username:  current.Username,
+		Layout:    revision.Layout,
+		Private:   revision.Private,
+		UserID:    current.UserID,
+		FavCount:  current.FavCount,
+		CreatedAt: current.CreatedAt,
+	}
+	for _, name := range revision.Tags {
+		restored.Tags = append(restored.Tags, model.Tag{Name: name})
+	}
+	if revision.Extension != nil {
+		restored.Extension = &model.EchoExtension{Type: revision.Extension.Type, Payload: revision.Extension.Payload}
+	}
+	if len(revision.Files) > 0 {
+		ids := make([]string, 0, len(revision.Files))
+		for _, f := range revision.Files {
+			ids = append(ids, f.FileID)
+		}
+		files, err := echoService.fileService.GetFilesByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		alive := make(map[string]struct{}, len(files))
+		for _, f := range files {
+			alive[f.ID] = struct{}{}
+		}
+		for _, f := range revision.Files {
+			if _, ok := alive[f.FileID]; ok {
+				restored.EchoFiles = append(restored.EchoFiles, fileModel.EchoFile{FileID: f.FileID, SortOrder: f.SortOrder})
+			}
+		}
+	}
+
+	if err := echoService.UpdateEcho(ctx, restored); err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+// recordRevision 在 UpdateEcho 的事务内为即将被覆盖的版本 prev 写一条修订；
+// 内容、布局、可见性、标签、附件、扩展都没变的空编辑不留记录。
+func (echoService *EchoService) recordRevision(ctx context.Context, prev, next *model.Echo, editorID string) error {
+	revision := model.NewEchoRevision(prev, editorID)
+	if sameRevisionSnapshot(revision, model.NewEchoRevision(next, editorID)) {
+		return nil
+	}
+	return echoService.echoRepository.CreateEchoRevision(ctx, revision)
+}
+
+// sameRevisionSnapshot 比较两份快照的可见内容；标签与顺序无关，扩展按 JSON 归一后比较。
+func sameRevisionSnapshot(a, b *model.EchoRevision) bool {
+	if a.Content != b.Content || a.Layout != b.Layout || a.Private != b.Private {
+		return false
+	}
+	if !slices.Equal(a.Files, b.Files) {
+		return false
+	}
+	tagsA, tagsB := slices.Clone(a.Tags), slices.Clone(b.Tags)
+	slices.Sort(tagsA)
+	slices.Sort(tagsB)
+	if !slices.Equal(tagsA, tagsB) {
+		return false
+	}
+	extA, errA := json.Marshal(a.Extension)
+	extB, errB := json.Marshal(b.Extension)
+	return errA == nil && errB == nil && string(extA) == string(extB)
+}
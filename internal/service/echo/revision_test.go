@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	commonmock "github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	echomock "github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	filemock "github.com/lin-snow/ech0/internal/test/mocks/filemock"
+	txmock "github.com/lin-snow/ech0/internal/test/mocks/txmock"
+	diffUtil "github.com/lin-snow/ech0/internal/util/diff"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func expectAdmin(common *commonmock.MockService) {
+	common.EXPECT().
+		CommonGetUserByUserId(mock.Anything, adminID).
+		Return(helpers.NewUser(helpers.AsAdmin), nil)
+}
+
+func TestListEchoRevisions_NonAdminDenied(t *testing.T) {
+	common := commonmock.NewMockService(t)
+	common.EXPECT().CommonGetUserByUserId(mock.Anything, userID).Return(helpers.NewUser(), nil).Once()
+
+	svc := echoService.NewEchoService(nil, common, nil, echomock.NewMockRepository(t), nilBus)
+	_, err := svc.ListEchoRevisions(helpers.CtxAsUser(userID), echoID)
+	require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+}
+
+// 编辑链：r1 "a" → r2 "a\nb" → 当前 "a\nc"。
+func TestGetEchoRevision_Diff(t *testing.T) {
+	r1 := echoModel.EchoRevision{ID: "r1", EchoID: echoID, Number: 1, Content: "a"}
+	r2 := echoModel.EchoRevision{ID: "r2", EchoID: echoID, Number: 2, Content: "a\nb"}
+	current := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = echoID; e.Content = "a\nc" })
+
+	newSvc := func(t *testing.T, rev echoModel.EchoRevision) (*echoService.EchoService, *echomock.MockRepository) {
+		repo := echomock.NewMockRepository(t)
+		common := commonmock.NewMockService(t)
+		expectAdmin(common)
+		repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&current, nil).Once()
+		repo.EXPECT().GetEchoRevision(mock.Anything, echoID, rev.ID).Return(&rev, nil).Once()
+		return echoService.NewEchoService(nil, common, nil, repo, nilBus), repo
+	}
+
+	t.Run("next of an older revision is the following revision", func(t *testing.T) {
+		svc, repo := newSvc(t, r1)
+		repo.EXPECT().ListEchoRevisions(mock.Anything, echoID).Return([]echoModel.EchoRevision{r2, r1}, nil).Once()
+
+		detail, err := svc.GetEchoRevision(helpers.CtxAsUser(adminID), echoID, "r1", "")
+		require.NoError(t, err)
+		assert.Equal(t, echoModel.RevisionCompareNext, detail.CompareTo)
+		assert.Equal(t, diffUtil.Stats{Added: 1}, detail.Stats)
+	})
+
+	t.Run("next of the latest revision is the current echo", func(t *testing.T) {
+		svc, repo := newSvc(t, r2)
+		repo.EXPECT().ListEchoRevisions(mock.Anything, echoID).Return([]echoModel.EchoRevision{r2, r1}, nil).Once()
+
+		detail, err := svc.GetEchoRevision(helpers.CtxAsUser(adminID), echoID, "r2", echoModel.RevisionCompareNext)
+		require.NoError(t, err)
+		assert.Equal(t, diffUtil.Stats{Added: 1, Removed: 1}, detail.Stats)
+	})
+
+	t.Run("current compares against the live content", func(t *testing.T) {
+		svc, _ := newSvc(t, r1)
+
+		detail, err := svc.GetEchoRevision(helpers.CtxAsUser(adminID), echoID, "r1", echoModel.RevisionCompareCurrent)
+		require.NoError(t, err)
+		assert.Equal(t, echoModel.RevisionCompareCurrent, detail.CompareTo)
+		assert.Equal(t, []diffUtil.Line{
+			{Op: diffUtil.OpEqual, Text: "a"},
+			{Op: diffUtil.OpInsert, Text: "c"},
+		}, detail.Diff)
+	})
+
+	t.Run("unknown compare target is rejected", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectAdmin(common)
+		svc := echoService.NewEchoService(nil, common, nil, echomock.NewMockRepository(t), nilBus)
+		_, err := svc.GetEchoRevision(helpers.CtxAsUser(adminID), echoID, "r1", "previous")
+		require.EqualError(t, err, commonModel.INVALID_PARAMS)
+	})
+}
+
+// 恢复走 UpdateEcho：当前版本被记为新修订、已删除的附件被跳过、发出 EchoUpdated。
+func TestRestoreEchoRevision(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	current := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.Content = "edited"
+		e.CreatedAt = 42
+	})
+	rev := echoModel.EchoRevision{
+		ID:      "r1",
+		EchoID:  echoID,
+		Number:  1,
+		Content: "original",
+		Layout:  echoModel.LayoutGrid,
+		Tags:    []string{"go"},
+		Files: []echoModel.EchoRevisionFile{
+			{FileID: "gone", SortOrder: 0},
+			{FileID: "kept", SortOrder: 1},
+		},
+	}
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&current, nil).Twice()
+	repo.EXPECT().GetEchoRevision(mock.Anything, echoID, "r1").Return(&rev, nil).Once()
+	file.EXPECT().
+		GetFilesByIDs(mock.Anything, []string{"gone", "kept"}).
+		Return([]commonModel.FileDto{{ID: "kept", Category: "image"}}, nil).
+		Once()
+	file.EXPECT().
+		GetFilesByIDs(mock.Anything, []string{"kept"}).
+		Return([]commonModel.FileDto{{ID: "kept", Category: "image"}}, nil).
+		Once()
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	var recorded *echoModel.EchoRevision
+	repo.EXPECT().
+		CreateEchoRevision(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, r *echoModel.EchoRevision) { recorded = r }).
+		Return(nil).
+		Once()
+	repo.EXPECT().GetTagsByNames(mock.Anything, []string{"go"}).
+		Return([]*echoModel.Tag{{ID: "t1", Name: "go"}}, nil).
+		Once()
+	repo.EXPECT().IncrementTagUsageCount(mock.Anything, "t1").Return(nil).Once()
+	var updated echoModel.Echo
+	repo.EXPECT().
+		UpdateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { updated = *e }).
+		Return(nil).
+		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, "original").Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+	file.EXPECT().ConfirmTempFiles(mock.Anything, []string{"kept"}).Return(nil).Once()
+
+	var fired int
+	unsub, err := busen.Subscribe(bus, func(_ context.Context, e busen.Event[event.EchoUpdated]) error {
+		fired++
+		return nil
+	})
+	require.NoError(t, err)
+	defer unsub()
+
+	svc := echoService.NewEchoService(tx, common, file, repo, func() *busen.Bus { return bus })
+	got, err := svc.RestoreEchoRevision(helpers.CtxAsUser(adminID), echoID, "r1")
+	require.NoError(t, err)
+
+	assert.Equal(t, "original", got.Content)
+	assert.Equal(t, echoModel.LayoutGrid, updated.Layout)
+	assert.Equal(t, int64(42), updated.CreatedAt)
+	require.Len(t, updated.EchoFiles, 1)
+	assert.Equal(t, "kept", updated.EchoFiles[0].FileID)
+	require.NotNil(t, recorded)
+	assert.Equal(t, "edited", recorded.Content)
+	assert.Equal(t, 1, fired)
+}
+
+// 内容、布局、标签都没变的保存不产生修订。
+func TestUpdateEcho_NoopEditSkipsRevision(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	expectAdmin(common)
+
+	stored := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.Layout = echoModel.LayoutWaterfall
+		e.Tags = []echoModel.Tag{{ID: "t2", Name: "b"}, {ID: "t1", Name: "a"}}
+	})
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&stored, nil).Once()
+	repo.EXPECT().GetTagsByNames(mock.Anything, mock.Anything).
+		Return([]*echoModel.Tag{{ID: "t1", Name: "a"}, {ID: "t2", Name: "b"}}, nil).
+		Once()
+	repo.EXPECT().IncrementTagUsageCount(mock.Anything, mock.Anything).Return(nil).Twice()
+	repo.EXPECT().UpdateEcho(mock.Anything, mock.Anything).Return(nil).Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, stored.Content).Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+
+	file := filemock.NewMockService(t)
+	file.EXPECT().ConfirmTempFiles(mock.Anything, mock.Anything).Return(nil).Once()
+
+	svc := echoService.NewEchoService(tx, common, file, repo, nilBus)
+	require.NoError(t, svc.UpdateEcho(helpers.CtxAsUser(adminID), &echoModel.Echo{
+		ID:      echoID,
+		Content: stored.Content,
+		Layout:  echoModel.LayoutWaterfall,
+		Tags:    []echoModel.Tag{{Name: "#a"}, {Name: "b"}},
+	}))
+}
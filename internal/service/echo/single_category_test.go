@@ -118,6 +118,7 @@ func TestValidateSingleFileCategory_MultipleImagesAllowed(t *testing.T) {
 		Run(func(_ context.Context, e *echoModel.Echo) { created = *e }).
 		Return(nil).
 		Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
 	repo.EXPECT().InvalidateEchoCaches().Once()
 	saved := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "saved-1" })
 	repo.EXPECT().GetEchosById(mock.Anything, mock.Anything).Return(&saved, nil).Once()
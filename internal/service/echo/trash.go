@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	model "github.com/lin-snow/ech0/internal/model/echo"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	"github.com/lin-snow/ech0/internal/storage"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// purgeBatchSize 是回收站自动清理每批取出的 Echo 数。
+const purgeBatchSize = 100
+
+// ListTrashedEchos 分页列出回收站，最近删除的在前。owner 看到全部，其余有发布权限的用户只看到自己的。
+func (echoService *EchoService) ListTrashedEchos(
+	ctx context.Context,
+	pageQueryDto commonModel.PageQueryDto,
+) (commonModel.PageQueryResult[[]model.Echo], error) {
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
+	if err != nil {
+		return commonModel.PageQueryResult[[]model.Echo]{}, err
+	}
+	ownerID := user.ID
+	if user.Can(userModel.PermEchoViewPrivate) {
+		ownerID = ""
+	}
+	if pageQueryDto.Page < 1 {
+		pageQueryDto.Page = 1
+	}
+	if pageQueryDto.PageSize < 1 {
+		pageQueryDto.PageSize = 10
+	}
+	if pageQueryDto.PageSize > 100 {
+		pageQueryDto.PageSize = 100
+	}
+
+	echos, total, err := echoService.echoRepository.ListTrashedEchos(ctx, pageQueryDto.Page, pageQueryDto.PageSize, ownerID)
+	if err != nil {
+		return commonModel.PageQueryResult[[]model.Echo]{}, err
+	}
+	return commonModel.PageQueryResult[[]model.Echo]{Items: echos, Total: total}, nil
+}
+
+// RestoreEcho 把 Echo 从回收站移回原处。
+//
+// 附件关联在回收站期间原样保留，恢复时只摘掉期间已被删除的文件；全文索引随之重建，
+// 已发布的 Echo 另发 EchoRestored，Embedding 索引与 Webhook 据此重新收录。
+func (echoService *EchoService) RestoreEcho(ctx context.Context, id string) (*model.Echo, error) {
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
+	if err != nil {
+		return nil, err
+	}
+
+	var restored *model.Echo
+	if err := echoService.transactor.Run(ctx, func(txCtx context.Context) error {
+		trashed, err := echoService.echoRepository.GetTrashedEchoById(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if trashed == nil {
+			return errors.New(commonModel.ECHO_NOT_IN_TRASH)
+		}
+		if !canEdit(user, trashed) {
+			return errors.New(commonModel.NO_PERMISSION_DENIED)
+		}
+		if err := echoService.echoRepository.RestoreEcho(txCtx, id); err != nil {
+			return err
+		}
+		if err := echoService.echoRepository.PruneDanglingEchoFiles(txCtx, id); err != nil {
+			return err
+		}
+		if err := echoService.echoRepository.UpsertSearchIndex(txCtx, id, trashed.Content); err != nil {
+			return err
+		}
+
+		if restored, err = echoService.echoRepository.GetEchosById(txCtx, id); err != nil {
+			return err
+		}
+		if restored == nil {
+			return errors.New(commonModel.ECHO_NOT_FOUND)
+		}
+		if restored.IsPublished() {
+			return eventbus.NotifyTx(txCtx, echoService.bus, event.EchoRestored{Echo: *restored, User: user})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	echoService.echoRepository.InvalidateEchoCaches(id)
+	return restored, nil
+}
+
+// PurgeEcho 彻底删除回收站中的一条 Echo（权限同 RestoreEcho），连同附件记录与存储中的文件。
+func (echoService *EchoService) PurgeEcho(ctx context.Context, id string) error {
+	user, err := echoService.requireUser(ctx, userModel.PermEchoWrite)
+	if err != nil {
+		return err
+	}
+	trashed, err := echoService.echoRepository.GetTrashedEchoById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if trashed == nil {
+		return errors.New(commonModel.ECHO_NOT_IN_TRASH)
+	}
+	if !canEdit(user, trashed) {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	return echoService.purgeEcho(ctx, id, user)
+}
+
+// PurgeExpiredTrash 彻底删除删除时间早于 before 的回收站 Echo，返回清除的条数。
+// 由定时任务以系统身份调用（无 viewer），单条失败只记日志、不影响其余。按批取出直到某批取不满；
+// 某批有失败时就此停下，失败的条目仍在回收站里，留给下一轮，免得同一批反复重试。
+func (echoService *EchoService) PurgeExpiredTrash(ctx context.Context, before int64) (int, error) {
+	purged := 0
+	for {
+		ids, err := echoService.echoRepository.GetExpiredTrashedEchoIDs(ctx, before, purgeBatchSize)
+		if err != nil {
+			return purged, err
+		}
+
+		failed := false
+		for _, id := range ids {
+			if err := echoService.purgeEcho(ctx, id, userModel.User{}); err != nil {
+				logUtil.GetLogger().Error("purge trashed echo failed",
+					slog.String("echo_id", id), logUtil.Err(err))
+				failed = true
+				continue
+			}
+			purged++
+		}
+		if failed || len(ids) < purgeBatchSize {
+			return purged, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return purged, err
+		}
+	}
+}
+
+// purgeEcho 在事务内删除回收站 Echo 及其附件记录并发 EchoDeleted，提交后再删存储中的文件。
+func (echoService *EchoService) purgeEcho(ctx context.Context, id string, user userModel.User) error {
+	type deletableFileRef struct {
+		key         string
+		storageType string
+	}
+	var deletableFiles []deletableFileRef
+	if err := echoService.transactor.Run(ctx, func(txCtx context.Context) error {
+		echo, err := echoService.echoRepository.GetTrashedEchoById(txCtx, id)
+		if err != nil {
+			return err
+		}
+		if echo == nil {
+			return errors.New(commonModel.ECHO_NOT_IN_TRASH)
+		}
+
+		for _, ef := range echo.EchoFiles {
+			if ef.File.Key != "" && storage.NormalizeStorageType(ef.File.StorageType) != storage.StorageTypeExternal {
+				deletableFiles = append(deletableFiles, deletableFileRef{
+					key:         ef.File.Key,
+					storageType: ef.File.StorageType,
+				})
+			}
+			if ef.File.ID != "" {
+				if err := echoService.fileService.DeleteFileRecord(txCtx, ef.File.ID); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := echoService.echoRepository.DeleteEchoById(txCtx, id); err != nil {
+			return err
+		}
+		if err := echoService.echoRepository.DeleteSearchIndex(txCtx, id); err != nil {
+			return err
+		}
+		if echo.IsPublished() {
+			return eventbus.NotifyTx(txCtx, echoService.bus, event.EchoDeleted{Echo: model.Echo{ID: id}, User: user})
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, file := range deletableFiles {
+		if err := echoService.fileService.DeleteStoredFile(file.storageType, file.key); err != nil {
+			logUtil.GetLogger().Warn(
+				"delete stored file after echo purge failed",
+				slog.String("echo_id", id),
+				slog.String("file_key", file.key),
+				slog.String("storage_type", file.storageType),
+				logUtil.Err(err),
+			)
+		}
+	}
+	return nil
+}
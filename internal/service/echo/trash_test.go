@@ -0,0 +1,253 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	commonmock "github.com/lin-snow/ech0/internal/test/mocks/commonmock"
+	echomock "github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	filemock "github.com/lin-snow/ech0/internal/test/mocks/filemock"
+	txmock "github.com/lin-snow/ech0/internal/test/mocks/txmock"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// 恢复：清零删除时间、摘掉悬空附件、重建全文索引，再发 EchoRestored。
+func TestRestoreEcho_Success(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	trashed := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.DeletedAt = 1_700_000_000
+	})
+	restored := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = echoID })
+
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, echoID).Return(&trashed, nil).Once()
+	repo.EXPECT().RestoreEcho(mock.Anything, echoID).Return(nil).Once()
+	repo.EXPECT().PruneDanglingEchoFiles(mock.Anything, echoID).Return(nil).Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, trashed.Content).Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&restored, nil).Once()
+	restoredEvents := countEvents[event.EchoRestored](t, bus)
+
+	svc := echoService.NewEchoService(tx, common, nil, repo, func() *busen.Bus { return bus })
+	got, err := svc.RestoreEcho(helpers.CtxAsUser(adminID), echoID)
+	require.NoError(t, err)
+	assert.Equal(t, echoID, got.ID)
+	assert.Equal(t, 1, restoredEvents())
+}
+
+// 恢复草稿不发 EchoRestored：它从未对外可见。
+func TestRestoreEcho_DraftIsSilent(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+
+	draft := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.Status = echoModel.StatusDraft
+	})
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, echoID).Return(&draft, nil).Once()
+	repo.EXPECT().RestoreEcho(mock.Anything, echoID).Return(nil).Once()
+	repo.EXPECT().PruneDanglingEchoFiles(mock.Anything, echoID).Return(nil).Once()
+	repo.EXPECT().UpsertSearchIndex(mock.Anything, echoID, draft.Content).Return(nil).Once()
+	repo.EXPECT().InvalidateEchoCaches(echoID).Once()
+	repo.EXPECT().GetEchosById(mock.Anything, echoID).Return(&draft, nil).Once()
+	restoredEvents := countEvents[event.EchoRestored](t, bus)
+
+	svc := echoService.NewEchoService(tx, common, nil, repo, func() *busen.Bus { return bus })
+	_, err := svc.RestoreEcho(helpers.CtxAsUser(adminID), echoID)
+	require.NoError(t, err)
+	assert.Zero(t, restoredEvents())
+}
+
+func TestRestoreEcho_NotInTrash(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	expectAdmin(common)
+
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, echoID).Return(nil, nil).Once()
+
+	svc := echoService.NewEchoService(tx, common, nil, repo, nilBus)
+	_, err := svc.RestoreEcho(helpers.CtxAsUser(adminID), echoID)
+	require.EqualError(t, err, commonModel.ECHO_NOT_IN_TRASH)
+}
+
+func TestTrash_NonAdminDenied(t *testing.T) {
+	common := commonmock.NewMockService(t)
+	common.EXPECT().
+		CommonGetUserByUserId(mock.Anything, userID).
+		Return(helpers.NewUser(), nil).
+		Times(3)
+
+	svc := echoService.NewEchoService(nil, common, nil, nil, nilBus)
+	ctx := helpers.CtxAsUser(userID)
+
+	_, err := svc.ListTrashedEchos(ctx, commonModel.PageQueryDto{})
+	require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	_, err = svc.RestoreEcho(ctx, echoID)
+	require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	require.EqualError(t, svc.PurgeEcho(ctx, echoID), commonModel.NO_PERMISSION_DENIED)
+}
+
+// TestPurgeEcho_Success 覆盖彻底删除路径：
+//   - 本地文件：登记进待删存储集合 + 删除文件记录；
+//   - 外部文件（external）：跳过存储删除（仅删记录）；
+//   - 仅删本地的物理对象，失败被吞（不影响返回）；
+//   - 发出不可恢复的 EchoDeleted。
+func TestPurgeEcho_Success(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	bus := helpers.NewTestBus(t)
+	expectAdmin(common)
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+
+	stored := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.DeletedAt = 1_700_000_000
+		e.EchoFiles = []fileModel.EchoFile{
+			{File: fileModel.File{ID: "f-local", Key: "k-local", StorageType: "local"}},
+			{File: fileModel.File{ID: "f-ext", Key: "k-ext", StorageType: "external"}},
+		}
+	})
+	// 一次用于权限校验，一次在事务内。
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, echoID).Return(&stored, nil).Times(2)
+	file.EXPECT().DeleteFileRecord(mock.Anything, "f-local").Return(nil).Once()
+	file.EXPECT().DeleteFileRecord(mock.Anything, "f-ext").Return(nil).Once()
+	repo.EXPECT().DeleteEchoById(mock.Anything, echoID).Return(nil).Once()
+	repo.EXPECT().DeleteSearchIndex(mock.Anything, echoID).Return(nil).Once()
+	file.EXPECT().DeleteStoredFile("local", "k-local").Return(errors.New("ignored")).Once()
+
+	var got event.EchoDeleted
+	var fired int
+	unsub, err := busen.Subscribe(bus, func(_ context.Context, e busen.Event[event.EchoDeleted]) error {
+		got = e.Value
+		fired++
+		return nil
+	})
+	require.NoError(t, err)
+	defer unsub()
+
+	svc := echoService.NewEchoService(tx, common, file, repo, func() *busen.Bus { return bus })
+	require.NoError(t, svc.PurgeEcho(helpers.CtxAsUser(adminID), echoID))
+
+	require.Equal(t, 1, fired)
+	assert.Equal(t, echoID, got.Echo.ID)
+	assert.False(t, got.Restorable)
+}
+
+// TestPurgeEcho_DeleteFileRecordError 确认事务内删除文件记录失败时整体回滚并上抛，
+// 不触达物理删除 / 事件。
+func TestPurgeEcho_DeleteFileRecordError(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	file := filemock.NewMockService(t)
+	tx := txmock.NewMockTransactor(t)
+	boom := errors.New("delete record failed")
+	expectAdmin(common)
+
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Once()
+	stored := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.EchoFiles = []fileModel.EchoFile{{File: fileModel.File{ID: "f-1", Key: "k-1", StorageType: "local"}}}
+	})
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, echoID).Return(&stored, nil).Times(2)
+	file.EXPECT().DeleteFileRecord(mock.Anything, "f-1").Return(boom).Once()
+
+	svc := echoService.NewEchoService(tx, common, file, repo, nilBus)
+	require.ErrorIs(t, svc.PurgeEcho(helpers.CtxAsUser(adminID), echoID), boom)
+}
+
+// 作者只能彻底删除自己的 Echo，碰不到他人的。
+func TestPurgeEcho_AuthorCannotPurgeOthers(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	common := commonmock.NewMockService(t)
+	author := helpers.NewUser(helpers.WithUserID(userID), helpers.WithRole(userModel.RoleAuthor))
+	common.EXPECT().CommonGetUserByUserId(mock.Anything, userID).Return(author, nil).Once()
+	stored := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = echoID
+		e.DeletedAt = 1_700_000_000
+	})
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, echoID).Return(&stored, nil).Once()
+
+	svc := echoService.NewEchoService(nil, common, nil, repo, nilBus)
+	require.EqualError(t, svc.PurgeEcho(helpers.CtxAsUser(userID), echoID), commonModel.NO_PERMISSION_DENIED)
+}
+
+// 过期清理逐条彻底删除，单条失败不影响其余。
+func TestPurgeExpiredTrash(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	tx := txmock.NewMockTransactor(t)
+	const before = int64(1_700_000_000)
+
+	repo.EXPECT().GetExpiredTrashedEchoIDs(mock.Anything, before, mock.Anything).Return([]string{"e-1", "e-2"}, nil).Once()
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Twice()
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, "e-1").Return(nil, errors.New("db down")).Once()
+	e2 := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "e-2" })
+	repo.EXPECT().GetTrashedEchoById(mock.Anything, "e-2").Return(&e2, nil).Once()
+	repo.EXPECT().DeleteEchoById(mock.Anything, "e-2").Return(nil).Once()
+	repo.EXPECT().DeleteSearchIndex(mock.Anything, "e-2").Return(nil).Once()
+
+	svc := echoService.NewEchoService(tx, nil, nil, repo, nilBus)
+	n, err := svc.PurgeExpiredTrash(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+// 过期条目多于一批时同一轮接着取，直到某批取不满。
+func TestPurgeExpiredTrash_DrainsAllBatches(t *testing.T) {
+	repo := echomock.NewMockRepository(t)
+	tx := txmock.NewMockTransactor(t)
+	const (
+		before    = int64(1_700_000_000)
+		batchSize = 100 // 与 purgeBatchSize 一致
+	)
+
+	first := make([]string, batchSize)
+	for i := range first {
+		first[i] = fmt.Sprintf("e-%d", i)
+	}
+	repo.EXPECT().GetExpiredTrashedEchoIDs(mock.Anything, before, batchSize).Return(first, nil).Once()
+	repo.EXPECT().GetExpiredTrashedEchoIDs(mock.Anything, before, batchSize).Return([]string{"e-last"}, nil).Once()
+	tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTx).Times(batchSize + 1)
+	repo.EXPECT().
+		GetTrashedEchoById(mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, id string) (*echoModel.Echo, error) {
+			e := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = id })
+			return &e, nil
+		}).
+		Times(batchSize + 1)
+	repo.EXPECT().DeleteEchoById(mock.Anything, mock.Anything).Return(nil).Times(batchSize + 1)
+	repo.EXPECT().DeleteSearchIndex(mock.Anything, mock.Anything).Return(nil).Times(batchSize + 1)
+
+	svc := echoService.NewEchoService(tx, nil, nil, repo, nilBus)
+	n, err := svc.PurgeExpiredTrash(context.Background(), before)
+	require.NoError(t, err)
+	assert.Equal(t, batchSize+1, n)
+}
@@ -170,7 +170,51 @@ func (s *EmbeddingService) Search(ctx context.Context, query string, k int, auth
 	return s.repo.Search(ctx, vec, k, authorUsername)
 }
 
-func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(BackfillResult)) (BackfillResult, error) {
+func (s *EmbeddingService) Related(ctx context.Context, echoID string, k int) ([]model.SearchResult, error) {
+	setting, err := s.getSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !setting.Enable || setting.Model == "" || setting.Dim <= 0 {
+		return nil, embedding.ErrNotEnabled
+	}
+	if k <= 0 {
+		k = defaultTopK
+	}
+
+	// 换模型后、回填完成前，旧向量与新索引不在同一空间，宁可不推荐也不给错的
+	meta, ok, err := s.repo.GetMeta(ctx, echoID)
+	if err != nil || !ok || meta.Model != setting.Model || meta.Dim != setting.Dim {
+		return nil, err
+	}
+	vec, ok, err := s.repo.GetVector(ctx, echoID)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	// 多取一条：KNN 的第一名通常是它自己
+	hits, err := s.repo.Search(ctx, vec, k+1, "")
+	if err != nil {
+		return nil, err
+	}
+	results := make([]model.SearchResult, 0, k)
+	for _, h := range hits {
+		if h.EchoID == echoID {
+			continue
+		}
+		results = append(results, h)
+		if len(results) >= k {
+			break
+		}
+	}
+	return results, nil
+}
+
+func (s *EmbeddingService) Backfill(
+	ctx context.Context,
+	from BackfillCheckpoint,
+	onProgress func(BackfillCheckpoint),
+) (BackfillResult, error) {
 	var result BackfillResult
 
 	setting, err := s.getSetting(ctx)
@@ -186,6 +230,12 @@ func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(Backfil
 
 	const pageSize = 100
 	page := 1
+	// 续跑：跳过断点前已完成的页。按页偏移续跑在期间有增删时会重扫或漏掉少量 Echo——
+	// Upsert 幂等，漏掉的也会在下次编辑时增量补上，故不为此引入游标。
+	if from.Pages > 0 && from.Model == setting.Model && from.Dim == setting.Dim {
+		result = from.BackfillResult
+		page = from.Pages + 1
+	}
 	var lastErr error
 	for {
 		// 尊重取消：异步 reindex 作业被取消时中断 page 循环。
@@ -236,9 +286,9 @@ func (s *EmbeddingService) Backfill(ctx context.Context, onProgress func(Backfil
 			}
 		}
 
-		// 每页结束上报累计计数（仅进内存，不落库）。
+		// 每页结束上报截至该页的断点（由 reindex 作业决定如何进内存、落断点）。
 		if onProgress != nil {
-			onProgress(result)
+			onProgress(BackfillCheckpoint{BackfillResult: result, Pages: page, Model: setting.Model, Dim: setting.Dim})
 		}
 
 		if page*pageSize >= int(total) {
@@ -257,7 +257,7 @@ func TestBackfill_PreSeamGates(t *testing.T) {
 		svc, _, kv := newSvc(t)
 		boom := errors.New("kv backend down")
 		kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return("", boom).Once()
-		res, err := svc.Backfill(ctx, nil)
+		res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 		require.ErrorIs(t, err, boom)
 		assert.Equal(t, embeddingService.BackfillResult{}, res)
 	})
@@ -266,7 +266,7 @@ func TestBackfill_PreSeamGates(t *testing.T) {
 		ctx := context.Background()
 		svc, _, kv := newSvc(t)
 		kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return("", kvstore.ErrNotFound).Once()
-		_, err := svc.Backfill(ctx, nil)
+		_, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 		require.ErrorIs(t, err, embedding.ErrNotEnabled)
 	})
 
@@ -277,7 +277,7 @@ func TestBackfill_PreSeamGates(t *testing.T) {
 		kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
 		kv.EXPECT().Get(ctx, commonModel.EmbeddingIndexStateKey).Return("", kvstore.ErrNotFound).Once()
 		repo.EXPECT().DropVecTable(ctx).Return(boom).Once()
-		_, err := svc.Backfill(ctx, nil)
+		_, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 		require.ErrorIs(t, err, boom)
 	})
 
@@ -291,7 +291,7 @@ func TestBackfill_PreSeamGates(t *testing.T) {
 			Return(mustJSONState(t, testModel, testDim), nil).Once()
 		repo.EXPECT().EnsureVecTable(ctx, testDim).Return(nil).Once()
 		// ...then the loop's ctx.Err() check fires before GetEchosByPage runs.
-		_, err := svc.Backfill(ctx, nil)
+		_, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 		require.ErrorIs(t, err, context.Canceled)
 	})
 }
@@ -19,6 +19,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/lin-snow/ech0/internal/embedding"
+	"github.com/lin-snow/ech0/internal/kvstore"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	echoModel "github.com/lin-snow/ech0/internal/model/echo"
 	embModel "github.com/lin-snow/ech0/internal/model/embedding"
@@ -32,11 +34,13 @@ import (
 )
 
 // enabledSetting is the EmbeddingSetting value the service hands to the embedder.
-// It must equal what enabledSettingJSON marshals (the Embedding spec has no
-// Normalize, so the unmarshaled value passes through verbatim) — that lets tests
+// It must equal what enabledSettingJSON marshals after the Embedding spec's
+// Normalize (which only fills an empty provider with "openai") — that lets tests
 // match the embedder's `setting` argument exactly and prove it threads through.
 func enabledSetting() settingModel.EmbeddingSetting {
-	return settingModel.EmbeddingSetting{Enable: true, Model: testModel, Dim: testDim}
+	return settingModel.EmbeddingSetting{
+		Enable: true, Provider: string(commonModel.EmbeddingOpenAI), Model: testModel, Dim: testDim,
+	}
 }
 
 // newSeamSvc builds a service with all four collaborators mocked plus an injected
@@ -266,7 +270,7 @@ func TestBackfill_SinglePageIndexesAll(t *testing.T) {
 			vecs = append(vecs, v)
 		}).Return(nil).Times(2)
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, embeddingService.BackfillResult{Total: 2, Indexed: 2}, res)
 
@@ -300,7 +304,7 @@ func TestBackfill_SkipsEmptyText(t *testing.T) {
 		Return([][]float32{{1}, {2}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Times(2)
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, embeddingService.BackfillResult{Total: 3, Indexed: 2, Skipped: 1}, res)
 }
@@ -320,7 +324,7 @@ func TestBackfill_EmbedError_ReturnsLastErr(t *testing.T) {
 	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a", "b"}).Return(nil, boom).Once()
 	// No Upsert: the page never reaches persistence.
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.ErrorIs(t, err, boom)
 	assert.Equal(t, 0, res.Indexed)
 	assert.Equal(t, 2, res.Failed)
@@ -345,7 +349,7 @@ func TestBackfill_PartialUpsertFailure(t *testing.T) {
 	repo.EXPECT().Upsert(ctx, mock.MatchedBy(func(m *embModel.EchoEmbedding) bool { return m.EchoID == "e2" }), mock.Anything).
 		Return(errors.New("upsert boom")).Once()
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.NoError(t, err, "Upsert failures alone never surface an error")
 	assert.Equal(t, embeddingService.BackfillResult{Total: 2, Indexed: 1, Failed: 1}, res)
 }
@@ -367,7 +371,7 @@ func TestBackfill_AllUpsertFail_NoError(t *testing.T) {
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).
 		Return(errors.New("upsert boom")).Times(2)
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, embeddingService.BackfillResult{Total: 2, Indexed: 0, Failed: 2}, res)
 }
@@ -390,7 +394,7 @@ func TestBackfill_MultiPage(t *testing.T) {
 	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"b"}).Return([][]float32{{2}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Times(2)
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, 150, res.Total)
 	assert.Equal(t, 2, res.Indexed)
@@ -406,12 +410,12 @@ func TestBackfill_EmptyFirstPage(t *testing.T) {
 	expectEnsureReadyFastPath(t, repo, kv, ctx)
 	reader.EXPECT().GetEchosByPage(1, 100, "", true).Return([]echoModel.Echo{}, int64(0)).Once()
 
-	res, err := svc.Backfill(ctx, nil)
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
 	require.NoError(t, err)
 	assert.Equal(t, embeddingService.BackfillResult{}, res)
 }
 
-// TestBackfill_OnProgress reports cumulative counts once per processed page.
+// TestBackfill_OnProgress reports the cumulative checkpoint once per processed page.
 func TestBackfill_OnProgress(t *testing.T) {
 	ctx := context.Background()
 	svc, repo, kv, reader, emb := newSeamSvc(t)
@@ -423,14 +427,68 @@ func TestBackfill_OnProgress(t *testing.T) {
 	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Once()
 
-	var progress []embeddingService.BackfillResult
-	res, err := svc.Backfill(ctx, func(r embeddingService.BackfillResult) {
-		progress = append(progress, r)
+	var progress []embeddingService.BackfillCheckpoint
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, func(cp embeddingService.BackfillCheckpoint) {
+		progress = append(progress, cp)
 	})
 	require.NoError(t, err)
 	require.Len(t, progress, 1)
-	assert.Equal(t, embeddingService.BackfillResult{Total: 1, Indexed: 1}, progress[0])
-	assert.Equal(t, progress[0], res)
+	assert.Equal(t, embeddingService.BackfillCheckpoint{
+		BackfillResult: embeddingService.BackfillResult{Total: 1, Indexed: 1},
+		Pages:          1,
+		Model:          testModel,
+		Dim:            testDim,
+	}, progress[0])
+	assert.Equal(t, progress[0].BackfillResult, res)
+}
+
+// TestBackfill_ResumesFromCheckpoint: a checkpoint taken with the current model
+// skips the pages it covers and keeps accumulating from its counts.
+func TestBackfill_ResumesFromCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, kv, reader, emb := newSeamSvc(t)
+
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
+	expectEnsureReadyFastPath(t, repo, kv, ctx)
+	// page 1 is never fetched: the checkpoint says it is done.
+	reader.EXPECT().GetEchosByPage(2, 100, "", true).
+		Return([]echoModel.Echo{newBackfillEcho("e2", "b", "u", 2)}, int64(150)).Once()
+	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"b"}).Return([][]float32{{2}}, nil).Once()
+	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	from := embeddingService.BackfillCheckpoint{
+		BackfillResult: embeddingService.BackfillResult{Total: 150, Indexed: 99, Skipped: 1},
+		Pages:          1,
+		Model:          testModel,
+		Dim:            testDim,
+	}
+	res, err := svc.Backfill(ctx, from, nil)
+	require.NoError(t, err)
+	assert.Equal(t, embeddingService.BackfillResult{Total: 150, Indexed: 100, Skipped: 1}, res)
+}
+
+// TestBackfill_StaleCheckpointStartsOver: a checkpoint from another model is
+// discarded, because switching models rebuilds the index from scratch.
+func TestBackfill_StaleCheckpointStartsOver(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, kv, reader, emb := newSeamSvc(t)
+
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
+	expectEnsureReadyFastPath(t, repo, kv, ctx)
+	reader.EXPECT().GetEchosByPage(1, 100, "", true).
+		Return([]echoModel.Echo{newBackfillEcho("e1", "a", "u", 1)}, int64(1)).Once()
+	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
+	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+	from := embeddingService.BackfillCheckpoint{
+		BackfillResult: embeddingService.BackfillResult{Total: 500, Indexed: 300},
+		Pages:          3,
+		Model:          "old-model",
+		Dim:            testDim,
+	}
+	res, err := svc.Backfill(ctx, from, nil)
+	require.NoError(t, err)
+	assert.Equal(t, embeddingService.BackfillResult{Total: 1, Indexed: 1}, res)
 }
 
 // TestBackfill_ContextCancelledMidLoop: cancelling during the page-1 progress
@@ -449,7 +507,105 @@ func TestBackfill_ContextCancelledMidLoop(t *testing.T) {
 	emb.EXPECT().Embed(ctx, enabledSetting(), []string{"a"}).Return([][]float32{{1}}, nil).Once()
 	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).Return(nil).Once()
 
-	res, err := svc.Backfill(ctx, func(embeddingService.BackfillResult) { cancel() })
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, func(embeddingService.BackfillCheckpoint) { cancel() })
 	require.ErrorIs(t, err, context.Canceled)
 	assert.Equal(t, 1, res.Indexed, "page 1 work is preserved in the returned partial result")
 }
+
+// TestBackfill_LocalProviderRealEmbedder runs the default (non-mocked) embedder
+// with the built-in local provider: no network is involved, the first backfill
+// builds vec_echo at the normalized default dimension, and every upserted vector
+// matches it.
+func TestBackfill_LocalProviderRealEmbedder(t *testing.T) {
+	ctx := context.Background()
+	repo := embeddingmock.NewMockRepository(t)
+	kv := kvmock.NewMockStore(t)
+	reader := embeddingmock.NewMockEchoReader(t)
+	svc := embeddingService.NewEmbeddingService(repo, kv, reader)
+
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).
+		Return(mustSettingJSON(t, settingModel.EmbeddingSetting{
+			Enable: true, Provider: string(commonModel.EmbeddingLocal),
+		}), nil).Once()
+	// 首次启用：无索引状态 → 丢弃重建到本地默认维度
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingIndexStateKey).Return("", kvstore.ErrNotFound).Once()
+	repo.EXPECT().DropVecTable(ctx).Return(nil).Once()
+	repo.EXPECT().ClearAll(ctx).Return(nil).Once()
+	repo.EXPECT().EnsureVecTable(ctx, settingModel.EmbeddingLocalDefaultDim).Return(nil).Once()
+	kv.EXPECT().Set(ctx, commonModel.EmbeddingIndexStateKey,
+		mustJSONState(t, settingModel.EmbeddingLocalModel, settingModel.EmbeddingLocalDefaultDim)).
+		Return(nil).Once()
+	reader.EXPECT().GetEchosByPage(1, 100, "", true).
+		Return([]echoModel.Echo{
+			newBackfillEcho("e1", "离线也能检索", "alice", 1),
+			newBackfillEcho("e2", "offline search", "alice", 2),
+		}, int64(2)).Once()
+
+	repo.EXPECT().Upsert(ctx, mock.Anything, mock.Anything).
+		Run(func(_ context.Context, m *embModel.EchoEmbedding, v []float32) {
+			assert.Equal(t, settingModel.EmbeddingLocalModel, m.Model)
+			assert.Equal(t, settingModel.EmbeddingLocalDefaultDim, m.Dim)
+			assert.Len(t, v, settingModel.EmbeddingLocalDefaultDim)
+		}).Return(nil).Times(2)
+
+	res, err := svc.Backfill(ctx, embeddingService.BackfillCheckpoint{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, embeddingService.BackfillResult{Total: 2, Indexed: 2}, res)
+}
+
+// ---------------------------------------------------------------------------
+// Related — KNN from an echo's own stored vector
+// ---------------------------------------------------------------------------
+
+func TestRelated_DropsSelfAndTrims(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, kv, _, _ := newSeamSvc(t)
+
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
+	repo.EXPECT().GetMeta(ctx, "e1").
+		Return(&embModel.EchoEmbedding{EchoID: "e1", Model: testModel, Dim: testDim}, true, nil).Once()
+	repo.EXPECT().GetVector(ctx, "e1").Return([]float32{1, 0}, true, nil).Once()
+	repo.EXPECT().Search(ctx, []float32{1, 0}, 3, "").
+		Return([]embModel.SearchResult{{EchoID: "e1"}, {EchoID: "e2"}, {EchoID: "e3"}}, nil).Once()
+
+	got, err := svc.Related(ctx, "e1", 2)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "e2", got[0].EchoID)
+	assert.Equal(t, "e3", got[1].EchoID)
+}
+
+// 未建索引或索引属于旧模型（换模型后尚未回填）时不做 KNN，返回空。
+func TestRelated_NotIndexedOrStale(t *testing.T) {
+	cases := []struct {
+		name string
+		meta *embModel.EchoEmbedding
+		ok   bool
+	}{
+		{"not indexed", nil, false},
+		{"stale model", &embModel.EchoEmbedding{EchoID: "e1", Model: "old-model", Dim: testDim}, true},
+		{"stale dim", &embModel.EchoEmbedding{EchoID: "e1", Model: testModel, Dim: testDim * 2}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			svc, repo, kv, _, _ := newSeamSvc(t)
+			kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).Return(enabledSettingJSON(t), nil).Once()
+			repo.EXPECT().GetMeta(ctx, "e1").Return(tc.meta, tc.ok, nil).Once()
+
+			got, err := svc.Related(ctx, "e1", 5)
+			require.NoError(t, err)
+			assert.Empty(t, got)
+		})
+	}
+}
+
+func TestRelated_NotEnabled(t *testing.T) {
+	ctx := context.Background()
+	svc, _, kv, _, _ := newSeamSvc(t)
+	kv.EXPECT().Get(ctx, commonModel.EmbeddingSettingKey).
+		Return(mustSettingJSON(t, settingModel.EmbeddingSetting{Enable: false}), nil).Once()
+
+	_, err := svc.Related(ctx, "e1", 5)
+	assert.ErrorIs(t, err, embedding.ErrNotEnabled)
+}
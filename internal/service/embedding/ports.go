@@ -27,12 +27,16 @@ type Embedder interface {
 type Service interface {
 	IndexEcho(ctx context.Context, echo echoModel.Echo) error
 	RemoveEcho(ctx context.Context, echoID string) error
-	// Backfill 全量回填历史 Echo 的向量。onProgress 非 nil 时每页结束回调累计计数
-	// （供异步 job 上报实时进度）；长循环尊重 ctx 取消（reindex job 可中断）。
-	Backfill(ctx context.Context, onProgress func(BackfillResult)) (BackfillResult, error)
+	// Backfill 全量回填历史 Echo 的向量。from 非零时从该断点之后的页接着跑（模型或维度
+	// 已变则作废、从头来）。onProgress 非 nil 时每页结束回调截至该页的断点（供异步 job
+	// 上报进度并落断点）；长循环尊重 ctx 取消（reindex job 可中断）。
+	Backfill(ctx context.Context, from BackfillCheckpoint, onProgress func(BackfillCheckpoint)) (BackfillResult, error)
 	// Search 做语义检索。authorUsername 非空时把命中收口到该作者发布的 Echo
 	// （Copilot Chat 用它隔离多用户实例下的他人 Echo）；空串表示不限定作者。
 	Search(ctx context.Context, query string, k int, authorUsername string) ([]model.SearchResult, error)
+	// Related 以某条 Echo 自身已存的向量做 KNN，返回最相近的至多 k 条（不含自身、不限作者）。
+	// 该 Echo 尚未建立索引或索引属于旧模型时返回空。
+	Related(ctx context.Context, echoID string, k int) ([]model.SearchResult, error)
 	Enabled(ctx context.Context) bool
 }
 
@@ -49,6 +53,7 @@ type Repository interface {
 	Upsert(ctx context.Context, meta *model.EchoEmbedding, vector []float32) error
 	Delete(ctx context.Context, echoID string) error
 	GetMeta(ctx context.Context, echoID string) (*model.EchoEmbedding, bool, error)
+	GetVector(ctx context.Context, echoID string) ([]float32, bool, error)
 	// Search 做向量 KNN 检索。authorUsername 非空时把命中收口到该作者
 	// （over-fetch 后按 username 过滤，仍返回最多 k 条）；空串表示不限定作者。
 	Search(ctx context.Context, vector []float32, k int, authorUsername string) ([]model.SearchResult, error)
@@ -68,3 +73,13 @@ type BackfillResult struct {
 	Skipped int `json:"skipped"`
 	Failed  int `json:"failed"`
 }
+
+// BackfillCheckpoint 是回填的断点：已完成的页数与截至该页的累计计数，外加跑它时的模型与
+// 维度——换了模型索引会被整表重建，旧断点随之作废。嵌入 BackfillResult，序列化后仍带
+// total/indexed 等字段，前端按进度解析无需区分。
+type BackfillCheckpoint struct {
+	BackfillResult
+	Pages int    `json:"pages,omitempty"`
+	Model string `json:"model,omitempty"`
+	Dim   int    `json:"dim,omitempty"`
+}
@@ -26,6 +26,7 @@ import (
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	"github.com/lin-snow/ech0/internal/storage"
 	"github.com/lin-snow/ech0/internal/transaction"
 	imgUtil "github.com/lin-snow/ech0/internal/util/img"
@@ -99,7 +100,7 @@ func (s *FileService) UploadFile(
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileWrite) {
 		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -230,7 +231,7 @@ func (s *FileService) CreateExternalFile(
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileWrite) {
 		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -358,7 +359,7 @@ func (s *FileService) DeleteFile(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileWrite) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 	if id == "" {
@@ -369,6 +370,9 @@ func (s *FileService) DeleteFile(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
+	if !canManageFile(user, fileRecord) {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
 
 	if err := s.transactor.Run(context.Background(), func(ctx context.Context) error {
 		return s.DeleteFileRecord(ctx, fileRecord.ID)
@@ -395,7 +399,7 @@ func (s *FileService) GetFileByID(ctx context.Context, id string) (commonModel.F
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileWrite) {
 		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -403,7 +407,44 @@ func (s *FileService) GetFileByID(ctx context.Context, id string) (commonModel.F
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
+	if !canManageFile(user, fileRecord) {
+		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	return fileDtoOf(fileRecord), nil
+}
+
+// GetFileByURL 按站内地址（本地存储文件的 FileDto.URL，形如 /api/files/...）找回调用方自己上传的
+// 文件记录：地址可由客户端任意填写，即使调用方能管理全部文件也不返回别人的文件。
+// 不是本地文件地址、查无记录或不属于调用方时返回 ErrRecordNotFound。
+func (s *FileService) GetFileByURL(ctx context.Context, fileURL string) (commonModel.FileDto, error) {
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
+	if err != nil {
+		return commonModel.FileDto{}, err
+	}
+	if !user.Can(userModel.PermFileWrite) {
+		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	filePath, ok := strings.CutPrefix(fileURL, "/api/files/")
+	if !ok {
+		return commonModel.FileDto{}, gorm.ErrRecordNotFound
+	}
+	candidates := s.getSelector().ResolveKeyCandidatesByPath(storage.StorageTypeLocal, filePath)
+	records, err := s.fileRepository.ListByStorageTypeAndKeys(ctx, string(storage.StorageTypeLocal), candidates)
+	if err != nil {
+		return commonModel.FileDto{}, err
+	}
+	for i := range records {
+		if records[i].URL == fileURL && records[i].UserID == user.ID {
+			return fileDtoOf(&records[i]), nil
+		}
+	}
+	return commonModel.FileDto{}, gorm.ErrRecordNotFound
+}
 
+func fileDtoOf(fileRecord *fileModel.File) commonModel.FileDto {
 	return commonModel.FileDto{
 		ID:          fileRecord.ID,
 		Name:        fileRecord.Name,
@@ -415,7 +456,7 @@ func (s *FileService) GetFileByID(ctx context.Context, id string) (commonModel.F
 		Size:        fileRecord.Size,
 		Width:       fileRecord.Width,
 		Height:      fileRecord.Height,
-	}, nil
+	}
 }
 
 // GetFilesByIDs batch-loads file metadata for the given IDs in a single query,
@@ -423,7 +464,7 @@ func (s *FileService) GetFileByID(ctx context.Context, id string) (commonModel.F
 // without paying N per-file lookups. Missing IDs are simply absent from the result.
 //
 // It is authorization-agnostic on purpose: its only caller runs inside
-// PostEcho/UpdateEcho, which already gate on IsAdmin, so re-checking here would
+// PostEcho/UpdateEcho, which already gate on PermEchoWrite, so re-checking here would
 // just repeat a users-table lookup on the write hot path. Any future caller must
 // enforce access control itself before calling this.
 func (s *FileService) GetFilesByIDs(ctx context.Context, ids []string) ([]commonModel.FileDto, error) {
@@ -465,7 +506,7 @@ func (s *FileService) UpdateFileMeta(
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileWrite) {
 		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 	if id == "" || dto.Size < 0 {
@@ -482,6 +523,9 @@ func (s *FileService) UpdateFileMeta(
 	if err != nil {
 		return commonModel.FileDto{}, err
 	}
+	if !canManageFile(user, fileRecord) {
+		return commonModel.FileDto{}, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
 	if storage.NormalizeStorageType(fileRecord.StorageType) != storage.StorageTypeObject {
 		return commonModel.FileDto{}, errors.New(commonModel.INVALID_PARAMS)
 	}
@@ -527,7 +571,7 @@ func (s *FileService) ListFiles(
 	if err != nil {
 		return result, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileManage) {
 		return result, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -591,7 +635,7 @@ func (s *FileService) ListFileTree(
 	if err != nil {
 		return result, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileManage) {
 		return result, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -749,7 +793,7 @@ func (s *FileService) StreamFileByID(ctx *gin.Context, id string) {
 func (s *FileService) StreamFileByPath(ctx *gin.Context, query commonModel.FilePathStreamQueryDto) {
 	userid := viewer.MustFromContext(ctx.Request.Context()).UserID()
 	user, err := s.commonRepository.GetUserByUserId(context.Background(), userid)
-	if err != nil || !user.IsAdmin {
+	if err != nil || !user.Can(userModel.PermFileManage) {
 		ctx.String(http.StatusForbidden, "无权限")
 		return
 	}
@@ -838,7 +882,7 @@ func (s *FileService) GetFilePresignURL(
 	if err != nil {
 		return result, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermFileWrite) {
 		return result, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -1211,6 +1255,15 @@ func resolveContentType(filename string, detected string) string {
 	return detected
 }
 
+// canManageFile 报告 user 能否查看、修改或删除 file：自己上传的需要 PermFileWrite，
+// 他人的需要 PermFileManage。
+func canManageFile(user userModel.User, file *fileModel.File) bool {
+	if file.UserID == user.ID {
+		return user.Can(userModel.PermFileWrite)
+	}
+	return user.Can(userModel.PermFileManage)
+}
+
 func (s *FileService) getSelector() *storage.StorageSelector {
 	if s.storageManager == nil {
 		return storage.NewStorageSelector(config.Config().Storage)
@@ -432,6 +432,30 @@ func TestFileService_GetFileByID(t *testing.T) {
 	})
 }
 
+// --- GetFileByURL -----------------------------------------------------------
+
+func TestFileService_GetFileByURL(t *testing.T) {
+	fix := newFileFix(t)
+	uploaded := fix.uploadPNG(t, "photo.png", 6, 6)
+	fix.expectAdmin()
+	require.True(t, strings.HasPrefix(uploaded.URL, "/api/files/"), "local upload URL: %s", uploaded.URL)
+
+	dto, err := fix.svc.GetFileByURL(fix.adminCtx(), uploaded.URL)
+	require.NoError(t, err)
+	assert.Equal(t, uploaded.ID, dto.ID)
+
+	for _, u := range []string{"/api/files/missing.png", "https://cdn.example.com/photo.png", "/echo/1"} {
+		_, err := fix.svc.GetFileByURL(fix.adminCtx(), u)
+		assert.ErrorIs(t, err, gorm.ErrRecordNotFound, u)
+	}
+
+	// 别人上传的文件即使调用方能管理全部文件也不返回。
+	require.NoError(t, fix.db.Model(&fileModel.File{}).Where("id = ?", uploaded.ID).
+		Update("user_id", "someone-else").Error)
+	_, err = fix.svc.GetFileByURL(fix.adminCtx(), uploaded.URL)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
 // --- UpdateFileMeta ---------------------------------------------------------
 
 func TestFileService_UpdateFileMeta(t *testing.T) {
@@ -24,6 +24,7 @@ type Service interface {
 	CreateExternalFile(ctx context.Context, dto commonModel.CreateExternalFileDto) (commonModel.FileDto, error)
 	DeleteFile(ctx context.Context, id string) error
 	GetFileByID(ctx context.Context, id string) (commonModel.FileDto, error)
+	GetFileByURL(ctx context.Context, fileURL string) (commonModel.FileDto, error)
 	GetFilesByIDs(ctx context.Context, ids []string) ([]commonModel.FileDto, error)
 	ListFiles(ctx context.Context, query commonModel.FileListQueryDto) (commonModel.FileListResultDto, error)
 	ListFileTree(ctx context.Context, query commonModel.FileTreeQueryDto) (commonModel.FileTreeResultDto, error)
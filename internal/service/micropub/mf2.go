@@ -0,0 +1,170 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	model "github.com/lin-snow/ech0/internal/model/micropub"
+)
+
+// mediaProperties 是映射到 EchoFile 的属性，值为各自对应的文件类别。
+var mediaProperties = map[string]string{
+	"photo": "image",
+	"video": "video",
+	"audio": "audio",
+}
+
+// textValue 取属性值的文本：字符串原样返回，对象取 html 或 value。
+func textValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		return val
+	case map[string]any:
+		if html, ok := val["html"].(string); ok {
+			return html
+		}
+		if value, ok := val["value"].(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// texts 取属性的全部非空文本值。
+func texts(values []any) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if text := strings.TrimSpace(textValue(v)); text != "" {
+			out = append(out, text)
+		}
+	}
+	return out
+}
+
+// parseLocation 把 location 属性转成 LOCATION 扩展。支持 geo: URI（RFC 5870）
+// 与带 latitude / longitude 的 h-card / h-geo / h-adr 对象；没有坐标的地点无法映射。
+func parseLocation(v any) (*echoModel.EchoExtension, error) {
+	var lat, lng float64
+	var name string
+	switch val := v.(type) {
+	case string:
+		coords, ok := strings.CutPrefix(strings.TrimSpace(val), "geo:")
+		if !ok {
+			return nil, fmt.Errorf("%w: location must be a geo: URI or carry latitude and longitude", ErrInvalidRequest)
+		}
+		coords, _, _ = strings.Cut(coords, ";")
+		parts := strings.Split(coords, ",")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("%w: malformed geo: URI", ErrInvalidRequest)
+		}
+		var errLat, errLng error
+		lat, errLat = strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		lng, errLng = strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if errLat != nil || errLng != nil {
+			return nil, fmt.Errorf("%w: malformed geo: URI", ErrInvalidRequest)
+		}
+	case map[string]any:
+		props, _ := val["properties"].(map[string]any)
+		var okLat, okLng bool
+		lat, okLat = numberProperty(props, "latitude")
+		lng, okLng = numberProperty(props, "longitude")
+		if !okLat || !okLng {
+			return nil, fmt.Errorf("%w: location must carry latitude and longitude", ErrInvalidRequest)
+		}
+		for _, key := range []string{"name", "label", "locality"} {
+			if values, ok := props[key].([]any); ok {
+				if name = firstOf(values); name != "" {
+					break
+				}
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unsupported location value", ErrInvalidRequest)
+	}
+	if lat < -90 || lat > 90 || lng < -180 || lng > 180 {
+		return nil, fmt.Errorf("%w: location out of range", ErrInvalidRequest)
+	}
+	if name == "" {
+		name = fmt.Sprintf("%.5f, %.5f", lat, lng)
+	}
+	return &echoModel.EchoExtension{
+		Type: echoModel.Extension_LOCATION,
+		Payload: map[string]any{
+			"latitude":    lat,
+			"longitude":   lng,
+			"placeholder": name,
+		},
+	}, nil
+}
+
+// firstOf 取第一个非空文本值。
+func firstOf(values []any) string {
+	for _, v := range values {
+		if text := strings.TrimSpace(textValue(v)); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// numberProperty 读取 mf2 对象中的数值属性；值可以是数字或数字字符串。
+func numberProperty(props map[string]any, key string) (float64, bool) {
+	values, ok := props[key].([]any)
+	if !ok || len(values) == 0 {
+		return 0, false
+	}
+	switch v := values[0].(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// locationProperty 把 LOCATION 扩展还原为 h-card。
+func locationProperty(ext *echoModel.EchoExtension) map[string]any {
+	return map[string]any{
+		"type": []string{"h-card"},
+		"properties": map[string]any{
+			"name":      []any{ext.Payload["placeholder"]},
+			"latitude":  []any{ext.Payload["latitude"]},
+			"longitude": []any{ext.Payload["longitude"]},
+		},
+	}
+}
+
+// deleteSpec 解析 update 请求的 delete：属性名数组表示整体删除，属性表表示删除其中的值。
+func deleteSpec(raw any) (whole []string, values model.Properties, err error) {
+	switch val := raw.(type) {
+	case nil:
+		return nil, nil, nil
+	case []any:
+		for _, v := range val {
+			name, ok := v.(string)
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: delete must list property names", ErrInvalidRequest)
+			}
+			whole = append(whole, name)
+		}
+		return whole, nil, nil
+	case map[string]any:
+		values = make(model.Properties, len(val))
+		for key, v := range val {
+			list, ok := v.([]any)
+			if !ok {
+				return nil, nil, fmt.Errorf("%w: delete values must be arrays", ErrInvalidRequest)
+			}
+			values[key] = list
+		}
+		return nil, values, nil
+	default:
+		return nil, nil, fmt.Errorf("%w: malformed delete", ErrInvalidRequest)
+	}
+}
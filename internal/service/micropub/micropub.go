@@ -0,0 +1,472 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	model "github.com/lin-snow/ech0/internal/model/micropub"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	urlUtil "github.com/lin-snow/ech0/internal/util/url"
+)
+
+var (
+	// ErrInvalidRequest 对应 Micropub 的 invalid_request：请求格式不对，或指向的 Echo 不存在。
+	ErrInvalidRequest = errors.New("micropub: invalid request")
+	// ErrForbidden 对应 forbidden：调用方的角色没有所需权限（如发帖、上传文件）。
+	// token 缺少 scope 由处理器在调用前拦下，返回 insufficient_scope。
+	ErrForbidden = errors.New("micropub: forbidden")
+)
+
+type MicropubService struct {
+	echoSvc EchoService
+	fileSvc FileService
+	kv      kvstore.Store
+}
+
+var _ Service = (*MicropubService)(nil)
+
+func NewMicropubService(echoSvc EchoService, fileSvc FileService, durableKV kvstore.Store) *MicropubService {
+	return &MicropubService{echoSvc: echoSvc, fileSvc: fileSvc, kv: durableKV}
+}
+
+// Config 返回 q=config：媒体端点与支持的查询。
+func (s *MicropubService) Config(ctx context.Context) (model.Config, error) {
+	base, err := s.base(ctx)
+	if err != nil {
+		return model.Config{}, err
+	}
+	return model.Config{
+		MediaEndpoint: base + "/micropub/media",
+		SyndicateTo:   []any{},
+		PostTypes: []model.PostType{
+			{Type: "note", Name: "Note"},
+			{Type: "photo", Name: "Photo"},
+		},
+		Q: []string{"config", "source", "syndicate-to", "category"},
+	}, nil
+}
+
+// Source 返回 q=source：Echo 的 h-entry 属性，properties 非空时只返回其中列出的属性。
+func (s *MicropubService) Source(ctx context.Context, rawURL string, properties []string) (model.Entry, error) {
+	base, err := s.base(ctx)
+	if err != nil {
+		return model.Entry{}, err
+	}
+	echo, err := s.lookup(ctx, rawURL)
+	if err != nil {
+		return model.Entry{}, err
+	}
+
+	props := model.Properties{
+		"content":     {echo.Content},
+		"url":         {base + "/echo/" + echo.ID},
+		"published":   {time.Unix(echo.CreatedAt, 0).UTC().Format(time.RFC3339)},
+		"post-status": {"published"},
+		"visibility":  {"public"},
+	}
+	if !echo.IsPublished() {
+		props["post-status"] = []any{"draft"}
+	}
+	if echo.Private {
+		props["visibility"] = []any{"private"}
+	}
+	for _, tag := range echo.Tags {
+		props["category"] = append(props["category"], tag.Name)
+	}
+	for _, ef := range echo.EchoFiles {
+		for key, category := range mediaProperties {
+			if storage.NormalizeCategory(ef.File.Category) == storage.Category(category) {
+				props[key] = append(props[key], urlUtil.Absolute(base, ef.File.URL))
+			}
+		}
+	}
+	if echo.Extension != nil && echo.Extension.Type == echoModel.Extension_LOCATION {
+		props["location"] = []any{locationProperty(echo.Extension)}
+	}
+
+	if len(properties) > 0 {
+		for key := range props {
+			if !slices.Contains(properties, key) {
+				delete(props, key)
+			}
+		}
+		return model.Entry{Properties: props}, nil
+	}
+	return model.Entry{Type: []string{model.TypeEntry}, Properties: props}, nil
+}
+
+// Categories 返回 q=category：已有标签名，filter 非空时按子串过滤。
+func (s *MicropubService) Categories(_ context.Context, filter string) ([]string, error) {
+	tags, err := s.echoSvc.GetAllTags()
+	if err != nil {
+		return nil, err
+	}
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if filter == "" || strings.Contains(strings.ToLower(tag.Name), filter) {
+			names = append(names, tag.Name)
+		}
+	}
+	return names, nil
+}
+
+// Create 把 h-entry 发布为 Echo，返回其页面地址。
+func (s *MicropubService) Create(ctx context.Context, req *model.Request) (string, error) {
+	if len(req.Type) > 0 && !slices.Contains(req.Type, model.TypeEntry) {
+		return "", fmt.Errorf("%w: only h-entry is supported", ErrInvalidRequest)
+	}
+	base, err := s.base(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	echo := &echoModel.Echo{}
+	props := req.Properties
+	echo.Content = firstOf(props["content"])
+	if echo.Content == "" {
+		echo.Content = firstOf(props["name"])
+	}
+	for _, name := range texts(props["category"]) {
+		echo.Tags = append(echo.Tags, echoModel.Tag{Name: name})
+	}
+	for _, key := range []string{"photo", "video", "audio"} {
+		ids, err := s.resolveMedia(ctx, key, props[key], req.Uploads[key])
+		if err != nil {
+			return "", err
+		}
+		for _, id := range ids {
+			echo.EchoFiles = append(echo.EchoFiles, echoModel.EchoFile{FileID: id, SortOrder: len(echo.EchoFiles)})
+		}
+	}
+	if values := props["location"]; len(values) > 0 {
+		if echo.Extension, err = parseLocation(values[0]); err != nil {
+			return "", err
+		}
+	}
+	if err := applyState(echo, props); err != nil {
+		return "", err
+	}
+
+	if err := s.echoSvc.PostEcho(ctx, echo); err != nil {
+		return "", classify(err)
+	}
+	return base + "/echo/" + echo.ID, nil
+}
+
+// Update 按 replace / add / delete 修改 Echo。支持的属性：content、category、photo / video / audio、
+// location、post-status、visibility；其余属性忽略。
+func (s *MicropubService) Update(ctx context.Context, req *model.Request) error {
+	echo, err := s.lookup(ctx, req.URL)
+	if err != nil {
+		return err
+	}
+	whole, removals, err := deleteSpec(req.Delete)
+	if err != nil {
+		return err
+	}
+
+	tags := make([]string, 0, len(echo.Tags))
+	for _, tag := range echo.Tags {
+		tags = append(tags, tag.Name)
+	}
+	files := echo.EchoFiles
+
+	if content := firstOf(req.Replace["content"]); content != "" {
+		echo.Content = content
+	}
+	if values, ok := req.Replace["category"]; ok {
+		tags = texts(values)
+	}
+	for _, name := range texts(req.Add["category"]) {
+		if !slices.Contains(tags, name) {
+			tags = append(tags, name)
+		}
+	}
+	tags = slices.DeleteFunc(tags, func(name string) bool {
+		return slices.Contains(whole, "category") || slices.Contains(texts(removals["category"]), name)
+	})
+
+	for key, category := range mediaProperties {
+		isKind := func(ef echoModel.EchoFile) bool {
+			return storage.NormalizeCategory(ef.File.Category) == storage.Category(category)
+		}
+		if values, ok := req.Replace[key]; ok || slices.Contains(whole, key) {
+			files = slices.DeleteFunc(slices.Clone(files), isKind)
+			ids, err := s.resolveMedia(ctx, key, values, nil)
+			if err != nil {
+				return err
+			}
+			files = appendFiles(files, ids)
+		}
+		if urls := texts(removals[key]); len(urls) > 0 {
+			files = slices.DeleteFunc(slices.Clone(files), func(ef echoModel.EchoFile) bool {
+				return isKind(ef) && matchesAny(ef.File.URL, urls)
+			})
+		}
+		ids, err := s.resolveMedia(ctx, key, req.Add[key], nil)
+		if err != nil {
+			return err
+		}
+		files = appendFiles(files, ids)
+	}
+
+	isLocation := echo.Extension != nil && echo.Extension.Type == echoModel.Extension_LOCATION
+	if values := firstNonEmpty(req.Replace["location"], req.Add["location"]); len(values) > 0 {
+		if echo.Extension, err = parseLocation(values[0]); err != nil {
+			return err
+		}
+	} else if isLocation && (slices.Contains(whole, "location") || len(removals["location"]) > 0) {
+		echo.Extension = nil
+	}
+	if err := applyState(echo, req.Replace); err != nil {
+		return err
+	}
+
+	echo.Tags = make([]echoModel.Tag, 0, len(tags))
+	for _, name := range tags {
+		echo.Tags = append(echo.Tags, echoModel.Tag{Name: name})
+	}
+	echo.EchoFiles = make([]echoModel.EchoFile, 0, len(files))
+	for i, ef := range files {
+		echo.EchoFiles = append(echo.EchoFiles, echoModel.EchoFile{FileID: ef.FileID, SortOrder: i})
+	}
+	return classify(s.echoSvc.UpdateEcho(ctx, echo))
+}
+
+// Delete 把 Echo 移入回收站。
+func (s *MicropubService) Delete(ctx context.Context, rawURL string) error {
+	id, err := echoIDFromURL(rawURL)
+	if err != nil {
+		return err
+	}
+	return classify(s.echoSvc.DeleteEchoById(ctx, id))
+}
+
+// Undelete 从回收站恢复 Echo。
+func (s *MicropubService) Undelete(ctx context.Context, rawURL string) error {
+	id, err := echoIDFromURL(rawURL)
+	if err != nil {
+		return err
+	}
+	_, err = s.echoSvc.RestoreEcho(ctx, id)
+	return classify(err)
+}
+
+// UploadMedia 实现媒体端点：经 FileService 上传，返回文件地址。随后 photo 等属性引用这个地址时，
+// 由 knownMedia 按文件记录找回同一个文件。
+func (s *MicropubService) UploadMedia(ctx context.Context, file *multipart.FileHeader) (string, error) {
+	if file == nil {
+		return "", fmt.Errorf("%w: missing file", ErrInvalidRequest)
+	}
+	base, err := s.base(ctx)
+	if err != nil {
+		return "", err
+	}
+	dto, err := s.fileSvc.UploadFile(ctx, file, categoryOf(file), storage.StorageTypeLocal)
+	if err != nil {
+		return "", classify(err)
+	}
+	return urlUtil.Absolute(base, dto.URL), nil
+}
+
+// resolveMedia 把一组媒体属性值与随帖上传的文件转成文件 ID。媒体端点返回过的地址直接复用对应文件，
+// 其他地址登记为外链文件。
+func (s *MicropubService) resolveMedia(
+	ctx context.Context,
+	key string,
+	values []any,
+	uploads []*multipart.FileHeader,
+) ([]string, error) {
+	ids := make([]string, 0, len(values)+len(uploads))
+	for _, v := range values {
+		raw := strings.TrimSpace(textValue(v))
+		if raw == "" {
+			continue
+		}
+		if id := s.knownMedia(ctx, raw); id != "" {
+			ids = append(ids, id)
+			continue
+		}
+		dto, err := s.fileSvc.CreateExternalFile(ctx, commonModel.CreateExternalFileDto{
+			URL:      raw,
+			Category: mediaProperties[key],
+			Name:     altText(v),
+		})
+		if err != nil {
+			return nil, classify(err)
+		}
+		ids = append(ids, dto.ID)
+	}
+	for _, upload := range uploads {
+		dto, err := s.fileSvc.UploadFile(ctx, upload, storage.Category(mediaProperties[key]), storage.StorageTypeLocal)
+		if err != nil {
+			return nil, classify(err)
+		}
+		ids = append(ids, dto.ID)
+	}
+	return ids, nil
+}
+
+// knownMedia 把调用方自己上传的本站文件地址还原成文件 ID：媒体端点返回的是站点地址下的文件地址，
+// 未配置站点地址时是站内路径，客户端也可能自行补成绝对地址，故绝对地址只取路径。
+// 别人的文件与其他地址返回空串，按外链登记，不会把别人的文件挂到自己的 Echo 上。
+func (s *MicropubService) knownMedia(ctx context.Context, raw string) string {
+	base, err := s.base(ctx)
+	if err != nil {
+		return ""
+	}
+	filePath := raw
+	if rest, ok := strings.CutPrefix(raw, base+"/"); ok && base != "" {
+		filePath = "/" + rest
+	} else if parsed, err := url.Parse(raw); err == nil && parsed.IsAbs() {
+		if base != "" {
+			return ""
+		}
+		filePath = parsed.Path
+	}
+	dto, err := s.fileSvc.GetFileByURL(ctx, filePath)
+	if err != nil {
+		return ""
+	}
+	return dto.ID
+}
+
+// lookup 按 Echo 页面地址取 Echo。
+func (s *MicropubService) lookup(ctx context.Context, rawURL string) (*echoModel.Echo, error) {
+	id, err := echoIDFromURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	echo, err := s.echoSvc.GetEchoById(ctx, id)
+	if err != nil {
+		return nil, classify(err)
+	}
+	return echo, nil
+}
+
+// base 返回站点地址；未配置为绝对 http(s) 地址时返回空串，生成的地址即为站内路径。
+func (s *MicropubService) base(ctx context.Context) (string, error) {
+	system, err := coreSetting.Get(ctx, s.kv, coreSetting.System)
+	if err != nil {
+		return "", err
+	}
+	return urlUtil.SiteBase(system.ServerURL), nil
+}
+
+// applyState 处理 post-status（published / draft）与 visibility（public / unlisted / private）。
+func applyState(echo *echoModel.Echo, props model.Properties) error {
+	switch status := firstOf(props["post-status"]); status {
+	case "":
+	case "published":
+		echo.Status = echoModel.StatusPublished
+	case "draft":
+		echo.Status = echoModel.StatusDraft
+	default:
+		return fmt.Errorf("%w: unsupported post-status %q", ErrInvalidRequest, status)
+	}
+	switch visibility := firstOf(props["visibility"]); visibility {
+	case "":
+	case "public", "unlisted":
+		echo.Private = false
+	case "private":
+		echo.Private = true
+	default:
+		return fmt.Errorf("%w: unsupported visibility %q", ErrInvalidRequest, visibility)
+	}
+	return nil
+}
+
+// echoIDFromURL 从 Echo 页面地址（…/echo/{id}）取出 Echo ID。
+func echoIDFromURL(rawURL string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return "", fmt.Errorf("%w: malformed url", ErrInvalidRequest)
+	}
+	_, id, ok := strings.Cut(parsed.Path, "/echo/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return "", fmt.Errorf("%w: url is not an echo page", ErrInvalidRequest)
+	}
+	return id, nil
+}
+
+// classify 把 Echo / 文件服务的业务错误归入 Micropub 错误，其余错误原样返回。
+func classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch err.Error() {
+	case commonModel.NO_PERMISSION_DENIED:
+		return fmt.Errorf("%w: %s", ErrForbidden, err.Error())
+	case commonModel.ECHO_NOT_FOUND,
+		commonModel.ECHO_CAN_NOT_BE_EMPTY,
+		commonModel.ECHO_MIXED_FILE_CATEGORIES,
+		commonModel.ECHO_ALREADY_PUBLISHED,
+		commonModel.ECHO_NOT_IN_TRASH,
+		commonModel.INVALID_PARAMS,
+		commonModel.FILE_TYPE_NOT_ALLOWED,
+		commonModel.FILE_SIZE_EXCEED_LIMIT:
+		return fmt.Errorf("%w: %s", ErrInvalidRequest, err.Error())
+	}
+	return err
+}
+
+// categoryOf 按上传声明的类型选择文件类别，默认为图片。
+func categoryOf(file *multipart.FileHeader) storage.Category {
+	contentType := file.Header.Get("Content-Type")
+	switch {
+	case strings.HasPrefix(contentType, "video/"):
+		return storage.CategoryVideo
+	case strings.HasPrefix(contentType, "audio/"):
+		return storage.CategoryAudio
+	default:
+		return storage.CategoryImage
+	}
+}
+
+func altText(v any) string {
+	if obj, ok := v.(map[string]any); ok {
+		alt, _ := obj["alt"].(string)
+		return strings.TrimSpace(alt)
+	}
+	return ""
+}
+
+func appendFiles(files []echoModel.EchoFile, ids []string) []echoModel.EchoFile {
+	for _, id := range ids {
+		files = append(files, echoModel.EchoFile{FileID: id})
+	}
+	return files
+}
+
+func matchesAny(fileURL string, urls []string) bool {
+	for _, u := range urls {
+		// 站内文件以路径保存，客户端拿到的是补全了站点地址的绝对地址。
+		if u == fileURL || (strings.HasPrefix(fileURL, "/") && strings.HasSuffix(u, fileURL)) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(lists ...[]any) []any {
+	for _, list := range lists {
+		if len(list) > 0 {
+			return list
+		}
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"net/textproto"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	model "github.com/lin-snow/ech0/internal/model/micropub"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/lin-snow/ech0/internal/test/mocks/filemock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testBase = "https://ech0.example"
+
+type fixture struct {
+	svc   *MicropubService
+	echos *echomock.MockService
+	files *filemock.MockService
+	kv    kvstore.Store
+}
+
+func newFixture(t *testing.T, serverURL string) *fixture {
+	t.Helper()
+	kv := kvstore.NewMemory()
+	require.NoError(t, coreSetting.Set(context.Background(), kv, coreSetting.System, settingModel.SystemSetting{
+		ServerURL: serverURL,
+	}))
+	echos := echomock.NewMockService(t)
+	files := filemock.NewMockService(t)
+	return &fixture{
+		svc:   NewMicropubService(echos, files, kv),
+		echos: echos,
+		files: files,
+		kv:    kv,
+	}
+}
+
+func existingEcho() *echoModel.Echo {
+	return &echoModel.Echo{
+		ID:      "e1",
+		Content: "old",
+		Status:  echoModel.StatusPublished,
+		Tags:    []echoModel.Tag{{Name: "go"}, {Name: "life"}},
+		EchoFiles: []echoModel.EchoFile{
+			{FileID: "f1", File: fileModel.File{ID: "f1", URL: "/api/files/a.jpg", Category: "image"}},
+			{FileID: "f2", File: fileModel.File{ID: "f2", URL: "https://cdn.example/b.jpg", Category: "image"}},
+		},
+	}
+}
+
+func TestCreate_MapsEntry(t *testing.T) {
+	f := newFixture(t, testBase+"/")
+	ctx := helpers.CtxAsUser("admin")
+	f.files.EXPECT().GetFileByURL(mock.Anything, "/api/files/up.jpg").
+		Return(commonModel.FileDto{ID: "f-up"}, nil).Once()
+
+	f.files.EXPECT().
+		CreateExternalFile(mock.Anything, commonModel.CreateExternalFileDto{
+			URL:      "https://photos.example/x.jpg",
+			Category: "image",
+			Name:     "a cat",
+		}).
+		Return(commonModel.FileDto{ID: "f-ext"}, nil).Once()
+
+	var posted *echoModel.Echo
+	f.echos.EXPECT().PostEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) {
+			e.ID = "new-id"
+			posted = e
+		}).
+		Return(nil).Once()
+
+	location, err := f.svc.Create(ctx, &model.Request{
+		Type: []string{model.TypeEntry},
+		Properties: model.Properties{
+			"content":  {map[string]any{"html": "<p>hello</p>"}},
+			"category": {"go", " ", "indieweb"},
+			"photo": {
+				testBase + "/api/files/up.jpg",
+				map[string]any{"value": "https://photos.example/x.jpg", "alt": "a cat"},
+			},
+			"location":    {"geo:31.2304,121.4737;u=35"},
+			"post-status": {"draft"},
+			"visibility":  {"private"},
+		},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, testBase+"/echo/new-id", location)
+	require.NotNil(t, posted)
+	assert.Equal(t, "<p>hello</p>", posted.Content)
+	assert.Equal(t, []echoModel.Tag{{Name: "go"}, {Name: "indieweb"}}, posted.Tags)
+	require.Len(t, posted.EchoFiles, 2)
+	assert.Equal(t, "f-up", posted.EchoFiles[0].FileID)
+	assert.Equal(t, "f-ext", posted.EchoFiles[1].FileID)
+	assert.Equal(t, 1, posted.EchoFiles[1].SortOrder)
+	require.NotNil(t, posted.Extension)
+	assert.Equal(t, echoModel.Extension_LOCATION, posted.Extension.Type)
+	assert.InDelta(t, 31.2304, posted.Extension.Payload["latitude"], 1e-9)
+	assert.InDelta(t, 121.4737, posted.Extension.Payload["longitude"], 1e-9)
+	assert.Equal(t, "31.23040, 121.47370", posted.Extension.Payload["placeholder"])
+	assert.Equal(t, echoModel.StatusDraft, posted.Status)
+	assert.True(t, posted.Private)
+}
+
+func TestCreate_Validation(t *testing.T) {
+	cases := []struct {
+		name  string
+		req   *model.Request
+		setup func(f *fixture)
+		want  error
+	}{
+		{
+			name: "non h-entry type",
+			req:  &model.Request{Type: []string{"h-event"}},
+			want: ErrInvalidRequest,
+		},
+		{
+			name: "location without coordinates",
+			req:  &model.Request{Properties: model.Properties{"content": {"x"}, "location": {"Shanghai"}}},
+			want: ErrInvalidRequest,
+		},
+		{
+			name: "location out of range",
+			req:  &model.Request{Properties: model.Properties{"content": {"x"}, "location": {"geo:91,0"}}},
+			want: ErrInvalidRequest,
+		},
+		{
+			name: "unsupported post-status",
+			req:  &model.Request{Properties: model.Properties{"content": {"x"}, "post-status": {"archived"}}},
+			want: ErrInvalidRequest,
+		},
+		{
+			name: "empty echo",
+			req:  &model.Request{Properties: model.Properties{}},
+			setup: func(f *fixture) {
+				f.echos.EXPECT().PostEcho(mock.Anything, mock.Anything).
+					Return(errors.New(commonModel.ECHO_CAN_NOT_BE_EMPTY)).Once()
+			},
+			want: ErrInvalidRequest,
+		},
+		{
+			name: "not admin",
+			req:  &model.Request{Properties: model.Properties{"content": {"x"}}},
+			setup: func(f *fixture) {
+				f.echos.EXPECT().PostEcho(mock.Anything, mock.Anything).
+					Return(errors.New(commonModel.NO_PERMISSION_DENIED)).Once()
+			},
+			want: ErrForbidden,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := newFixture(t, testBase)
+			if tc.setup != nil {
+				tc.setup(f)
+			}
+			_, err := f.svc.Create(helpers.CtxAsUser("admin"), tc.req)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestCreate_WithoutServerURLReturnsPath(t *testing.T) {
+	f := newFixture(t, "")
+	f.echos.EXPECT().PostEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { e.ID = "new-id" }).
+		Return(nil).Once()
+
+	location, err := f.svc.Create(helpers.CtxAsUser("admin"), &model.Request{
+		Properties: model.Properties{"content": {"hi"}},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/echo/new-id", location)
+}
+
+func TestUpdate_ReplaceAddDelete(t *testing.T) {
+	f := newFixture(t, testBase)
+	ctx := helpers.CtxAsUser("admin")
+	f.echos.EXPECT().GetEchoById(mock.Anything, "e1").Return(existingEcho(), nil).Once()
+	f.files.EXPECT().CreateExternalFile(mock.Anything, mock.Anything).
+		Return(commonModel.FileDto{ID: "f3"}, nil).Once()
+
+	var updated *echoModel.Echo
+	f.echos.EXPECT().UpdateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { updated = e }).
+		Return(nil).Once()
+
+	err := f.svc.Update(ctx, &model.Request{
+		Action:  model.ActionUpdate,
+		URL:     testBase + "/echo/e1",
+		Replace: model.Properties{"content": {"new"}, "visibility": {"private"}},
+		Add:     model.Properties{"category": {"rust", "go"}, "photo": {"https://cdn.example/c.jpg"}},
+		Delete: map[string]any{
+			"category": []any{"life"},
+			"photo":    []any{testBase + "/api/files/a.jpg"},
+		},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Equal(t, "new", updated.Content)
+	assert.True(t, updated.Private)
+	assert.Equal(t, []echoModel.Tag{{Name: "go"}, {Name: "rust"}}, updated.Tags)
+	assert.Equal(t, []echoModel.EchoFile{
+		{FileID: "f2", SortOrder: 0},
+		{FileID: "f3", SortOrder: 1},
+	}, updated.EchoFiles)
+}
+
+func TestUpdate_DeleteWholeProperties(t *testing.T) {
+	f := newFixture(t, testBase)
+	echo := existingEcho()
+	echo.Extension = &echoModel.EchoExtension{
+		Type:    echoModel.Extension_LOCATION,
+		Payload: map[string]any{"latitude": 1.0, "longitude": 2.0, "placeholder": "here"},
+	}
+	f.echos.EXPECT().GetEchoById(mock.Anything, "e1").Return(echo, nil).Once()
+
+	var updated *echoModel.Echo
+	f.echos.EXPECT().UpdateEcho(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, e *echoModel.Echo) { updated = e }).
+		Return(nil).Once()
+
+	err := f.svc.Update(helpers.CtxAsUser("admin"), &model.Request{
+		URL:    "/echo/e1",
+		Delete: []any{"category", "photo", "location"},
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, updated)
+	assert.Empty(t, updated.Tags)
+	assert.Empty(t, updated.EchoFiles)
+	assert.Nil(t, updated.Extension)
+	assert.Equal(t, "old", updated.Content)
+}
+
+func TestUpdate_UnknownEcho(t *testing.T) {
+	f := newFixture(t, testBase)
+	f.echos.EXPECT().GetEchoById(mock.Anything, "missing").
+		Return(nil, errors.New(commonModel.ECHO_NOT_FOUND)).Once()
+
+	err := f.svc.Update(helpers.CtxAsUser("admin"), &model.Request{URL: testBase + "/echo/missing"})
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+
+	err = f.svc.Update(helpers.CtxAsUser("admin"), &model.Request{URL: testBase + "/about"})
+	assert.ErrorIs(t, err, ErrInvalidRequest)
+}
+
+func TestDeleteAndUndelete(t *testing.T) {
+	f := newFixture(t, testBase)
+	ctx := helpers.CtxAsUser("admin")
+	f.echos.EXPECT().DeleteEchoById(mock.Anything, "e1").Return(nil).Once()
+	f.echos.EXPECT().RestoreEcho(mock.Anything, "e1").Return(&echoModel.Echo{ID: "e1"}, nil).Once()
+
+	require.NoError(t, f.svc.Delete(ctx, testBase+"/echo/e1"))
+	require.NoError(t, f.svc.Undelete(ctx, testBase+"/echo/e1"))
+}
+
+func TestSource(t *testing.T) {
+	f := newFixture(t, testBase)
+	echo := existingEcho()
+	echo.Private = true
+	echo.Extension = &echoModel.EchoExtension{
+		Type:    echoModel.Extension_LOCATION,
+		Payload: map[string]any{"latitude": 1.5, "longitude": 2.5, "placeholder": "here"},
+	}
+	f.echos.EXPECT().GetEchoById(mock.Anything, "e1").Return(echo, nil).Twice()
+
+	entry, err := f.svc.Source(helpers.CtxAsUser("admin"), testBase+"/echo/e1", nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{model.TypeEntry}, entry.Type)
+	assert.Equal(t, []any{"old"}, entry.Properties["content"])
+	assert.Equal(t, []any{"go", "life"}, entry.Properties["category"])
+	assert.Equal(t, []any{testBase + "/api/files/a.jpg", "https://cdn.example/b.jpg"}, entry.Properties["photo"])
+	assert.Equal(t, []any{"private"}, entry.Properties["visibility"])
+	assert.Len(t, entry.Properties["location"], 1)
+
+	entry, err = f.svc.Source(helpers.CtxAsUser("admin"), testBase+"/echo/e1", []string{"category"})
+	require.NoError(t, err)
+	assert.Empty(t, entry.Type)
+	assert.Equal(t, model.Properties{"category": {"go", "life"}}, entry.Properties)
+}
+
+func TestUploadMedia_LocationResolvesToFile(t *testing.T) {
+	f := newFixture(t, testBase)
+	ctx := helpers.CtxAsUser("admin")
+	header := &multipart.FileHeader{
+		Filename: "clip.mp4",
+		Header:   textproto.MIMEHeader{"Content-Type": {"video/mp4"}},
+	}
+	f.files.EXPECT().UploadFile(mock.Anything, header, storage.CategoryVideo, storage.StorageTypeLocal).
+		Return(commonModel.FileDto{ID: "f9", URL: "/api/files/clip.mp4"}, nil).Once()
+
+	location, err := f.svc.UploadMedia(ctx, header)
+
+	require.NoError(t, err)
+	assert.Equal(t, testBase+"/api/files/clip.mp4", location)
+
+	// 地址不落键值存储，引用时按文件记录找回；其他站点的地址不查文件记录。
+	f.files.EXPECT().GetFileByURL(mock.Anything, "/api/files/clip.mp4").
+		Return(commonModel.FileDto{ID: "f9"}, nil).Once()
+	assert.Equal(t, "f9", f.svc.knownMedia(ctx, location))
+	assert.Empty(t, f.svc.knownMedia(ctx, "https://cdn.example/api/files/clip.mp4"))
+}
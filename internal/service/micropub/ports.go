@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"mime/multipart"
+
+	model "github.com/lin-snow/ech0/internal/model/micropub"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	fileService "github.com/lin-snow/ech0/internal/service/file"
+)
+
+// Service 实现 W3C Micropub：把 h-entry 映射为 Echo，并经 EchoService / FileService 落库，
+// 权限与校验沿用两者（按调用方角色的权限），引用的本站媒体只限调用方自己上传的文件。
+// 返回的地址在配置了站点地址时为绝对地址，否则为站内路径。
+type Service interface {
+	Config(ctx context.Context) (model.Config, error)
+	Source(ctx context.Context, rawURL string, properties []string) (model.Entry, error)
+	Categories(ctx context.Context, filter string) ([]string, error)
+	Create(ctx context.Context, req *model.Request) (string, error)
+	Update(ctx context.Context, req *model.Request) error
+	Delete(ctx context.Context, rawURL string) error
+	Undelete(ctx context.Context, rawURL string) error
+	UploadMedia(ctx context.Context, file *multipart.FileHeader) (string, error)
+}
+
+type (
+	EchoService = echoService.Service
+	FileService = fileService.Service
+)
@@ -22,6 +22,9 @@ func TestValidateSourceType(t *testing.T) {
 	}{
 		{"ech0", migratorModel.MigrationSourceEch0, false},
 		{"memos", migratorModel.MigrationSourceMemos, false},
+		{"twitter", migratorModel.MigrationSourceTwitter, false},
+		{"mastodon", migratorModel.MigrationSourceMastodon, false},
+		{"bluesky", migratorModel.MigrationSourceBluesky, false},
 		{"ech0 with surrounding whitespace", "  ech0  ", false},
 		{"memos with tabs", "\tmemos\t", false},
 		{"unknown source", "notion", true},
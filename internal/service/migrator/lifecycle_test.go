@@ -8,11 +8,13 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"testing"
 
@@ -42,58 +44,157 @@ func TestMain(m *testing.M) {
 }
 
 // fakeJobRepo is a tiny in-memory, concurrency-safe job.JobRepository. The real
-// job.Manager spins a goroutine on Submit success that calls Upsert, so the map
+// job.Manager spins a goroutine on Submit success that calls Save, so the map
 // is guarded by a mutex to stay race-clean.
 type fakeJobRepo struct {
 	mu     sync.Mutex
 	jobs   map[string]jobModel.Job
-	getErr error // forced (non-NotFound) error for GetByType, when set
+	seq    int64
+	getErr error // forced (non-NotFound) error for Current/GetByID, when set
 }
 
 func newFakeJobRepo() *fakeJobRepo {
 	return &fakeJobRepo{jobs: make(map[string]jobModel.Job)}
 }
 
-func (r *fakeJobRepo) Upsert(_ context.Context, j *jobModel.Job) error {
+// ofType returns rows of jobType in submission order. Caller holds mu.
+func (r *fakeJobRepo) ofType(jobType string) []jobModel.Job {
+	var out []jobModel.Job
+	for _, j := range r.jobs {
+		if jobType == "" || j.Type == jobType {
+			out = append(out, j)
+		}
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].CreatedAt < out[b].CreatedAt })
+	return out
+}
+
+func (r *fakeJobRepo) Create(_ context.Context, j *jobModel.Job) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.jobs[j.Type] = *j
+	r.jobs[j.ID] = *j
 	return nil
 }
 
-func (r *fakeJobRepo) GetByType(_ context.Context, jobType string) (jobModel.Job, error) {
+func (r *fakeJobRepo) Save(ctx context.Context, j *jobModel.Job) error { return r.Create(ctx, j) }
+
+func (r *fakeJobRepo) GetByID(_ context.Context, id string) (jobModel.Job, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	if r.getErr != nil {
 		return jobModel.Job{}, r.getErr
 	}
-	j, ok := r.jobs[jobType]
+	j, ok := r.jobs[id]
 	if !ok {
 		return jobModel.Job{}, job.ErrNotFound
 	}
 	return j, nil
 }
 
-func (r *fakeJobRepo) SweepRunning(_ context.Context, _ string) error { return nil }
+func (r *fakeJobRepo) Current(_ context.Context, jobType string) (jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.getErr != nil {
+		return jobModel.Job{}, r.getErr
+	}
+	rows := r.ofType(jobType)
+	for _, status := range []jobModel.Status{jobModel.StatusRunning, jobModel.StatusPending} {
+		for _, j := range rows {
+			if j.Status == status {
+				return j, nil
+			}
+		}
+	}
+	for i := len(rows) - 1; i >= 0; i-- {
+		if rows[i].Status.IsTerminal() && !rows[i].Dismissed {
+			return rows[i], nil
+		}
+	}
+	return jobModel.Job{}, job.ErrNotFound
+}
+
+func (r *fakeJobRepo) NextPending(_ context.Context, jobType string) (jobModel.Job, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, j := range r.ofType(jobType) {
+		if j.Status == jobModel.StatusPending {
+			return j, nil
+		}
+	}
+	return jobModel.Job{}, job.ErrNotFound
+}
+
+func (r *fakeJobRepo) CountByStatus(_ context.Context, jobType string, status jobModel.Status) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var n int64
+	for _, j := range r.ofType(jobType) {
+		if j.Status == status {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *fakeJobRepo) List(context.Context, jobModel.ListQuery) ([]jobModel.Job, int64, error) {
+	return nil, 0, nil
+}
 
-func (r *fakeJobRepo) Delete(_ context.Context, jobType string) error {
+func (r *fakeJobRepo) Dismiss(_ context.Context, jobType string) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	delete(r.jobs, jobType)
+	for id, j := range r.jobs {
+		if j.Type == jobType && j.Status.IsTerminal() {
+			j.Dismissed = true
+			r.jobs[id] = j
+		}
+	}
 	return nil
 }
 
+func (r *fakeJobRepo) ListByStatus(context.Context, jobModel.Status) ([]jobModel.Job, error) {
+	return nil, nil
+}
+
+func (r *fakeJobRepo) Prune(context.Context, string, int64, int) error { return nil }
+
+// seed stores j as the newest row, filling in an ID when the test leaves it blank.
 func (r *fakeJobRepo) seed(j jobModel.Job) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.jobs[j.Type] = j
+	r.seq++
+	if j.ID == "" {
+		j.ID = fmt.Sprintf("seed-%d", r.seq)
+	}
+	j.CreatedAt = r.seq
+	r.jobs[j.ID] = j
 }
 
 // noopRunner immediately succeeds; used only so Submit's happy path can return a
 // pending row. The async transition to success is irrelevant to the assertions.
 type noopRunner struct{}
 
-func (noopRunner) Run(context.Context, []byte, job.ReportFunc) (any, error) { return nil, nil }
+func (noopRunner) Run(context.Context, []byte, job.ReportFunc, job.CheckpointFunc) (any, error) {
+	return nil, nil
+}
+
+// blockingRunner runs until cancelled; used to hold the single concurrency slot.
+type blockingRunner struct{}
+
+func (blockingRunner) Run(ctx context.Context, _ []byte, _ job.ReportFunc, _ job.CheckpointFunc) (any, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// occupy submits a blocking job of jobType so the manager sees it running, and
+// stops the manager when the test ends.
+func occupy(t *testing.T, mgr *job.Manager, jobType string) {
+	t.Helper()
+	mgr.Register(jobType, blockingRunner{})
+	_, err := mgr.Submit(context.Background(), jobType, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = mgr.Stop(context.Background()) })
+}
 
 // newService wires a MigratorService over a mocked CommonService and a real
 // job.Manager backed by an in-memory repo. busProvider may be nil-returning for
@@ -165,10 +266,8 @@ func TestStartGlobalMigration(t *testing.T) {
 	t.Run("already running mapped to friendly message", func(t *testing.T) {
 		common := commonmock.NewMockService(t)
 		expectUser(t, common, adminUser(), nil)
-		repo := newFakeJobRepo()
-		repo.seed(jobModel.Job{Type: jobModel.TypeMigration, Status: jobModel.StatusRunning})
-		s := newService(common, repo, nil)
-		s.jobManager.Register(jobModel.TypeMigration, noopRunner{})
+		s := newService(common, newFakeJobRepo(), nil)
+		occupy(t, s.jobManager, jobModel.TypeMigration)
 		_, err := s.StartGlobalMigration(helpers.CtxAsUser(adminID), validReq())
 		require.Error(t, err)
 		assert.Equal(t, "请先结束/清理当前迁移", err.Error())
@@ -312,7 +411,7 @@ func TestCleanupGlobalMigration(t *testing.T) {
 		assert.Equal(t, "迁移进行中，无法清理", err.Error())
 	})
 
-	t.Run("terminal job cleans tmp and deletes row", func(t *testing.T) {
+	t.Run("terminal job cleans tmp and dismisses row", func(t *testing.T) {
 		common := commonmock.NewMockService(t)
 		expectUser(t, common, adminUser(), nil)
 		repo := newFakeJobRepo()
@@ -323,9 +422,10 @@ func TestCleanupGlobalMigration(t *testing.T) {
 		})
 		s := newService(common, repo, nil)
 		require.NoError(t, s.CleanupGlobalMigration(helpers.CtxAsUser(adminID)))
-		// row removed
-		_, err := repo.GetByType(context.Background(), jobModel.TypeMigration)
+		// row dismissed: back to idle, but kept as history
+		_, err := repo.Current(context.Background(), jobModel.TypeMigration)
 		assert.ErrorIs(t, err, job.ErrNotFound)
+		assert.Len(t, repo.jobs, 1)
 	})
 
 	t.Run("repo error propagates", func(t *testing.T) {
@@ -365,10 +465,8 @@ func TestStartExport(t *testing.T) {
 	t.Run("already running mapped to friendly message", func(t *testing.T) {
 		common := commonmock.NewMockService(t)
 		expectUser(t, common, adminUser(), nil)
-		repo := newFakeJobRepo()
-		repo.seed(jobModel.Job{Type: jobModel.TypeExport, Status: jobModel.StatusRunning})
-		s := newService(common, repo, nil)
-		s.jobManager.Register(jobModel.TypeExport, noopRunner{})
+		s := newService(common, newFakeJobRepo(), nil)
+		occupy(t, s.jobManager, jobModel.TypeExport)
 		_, err := s.StartExport(helpers.CtxAsUser(adminID), migratorModel.StartExportRequest{})
 		require.Error(t, err)
 		assert.Equal(t, "导出进行中，请稍候", err.Error())
@@ -681,6 +779,34 @@ func TestUploadSourceZip(t *testing.T) {
 		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
 	})
 
+	t.Run("archive type is checked per source", func(t *testing.T) {
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+		_, err := s.UploadSourceZip(
+			helpers.CtxAsUser(adminID),
+			migratorModel.MigrationSourceTwitter,
+			&multipart.FileHeader{Filename: "archive.tar.gz"},
+		)
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_REQUEST_BODY, err.Error())
+	})
+
+	t.Run("bluesky car is staged as repo.car", func(t *testing.T) {
+		chdirTemp(t)
+		common := commonmock.NewMockService(t)
+		expectUser(t, common, adminUser(), nil)
+		s := newService(common, newFakeJobRepo(), nil)
+
+		header := zipFileHeader(t, "repo.car", []byte("car-bytes"))
+		resp, err := s.UploadSourceZip(helpers.CtxAsUser(adminID), migratorModel.MigrationSourceBluesky, header)
+		require.NoError(t, err)
+		assert.Contains(t, resp.TmpDir, "files/tmp/bluesky_")
+		raw, err := os.ReadFile(filepath.Join("data", filepath.FromSlash(resp.TmpDir), "repo.car"))
+		require.NoError(t, err)
+		assert.Equal(t, "car-bytes", string(raw))
+	})
+
 	t.Run("success unpacks and returns tmp dir DTO", func(t *testing.T) {
 		chdirTemp(t)
 		common := commonmock.NewMockService(t)
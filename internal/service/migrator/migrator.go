@@ -21,10 +21,10 @@ import (
 	"github.com/lin-snow/ech0/internal/job"
 	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
 	"github.com/lin-snow/ech0/internal/migrator/artifact"
-	snapshot "github.com/lin-snow/ech0/internal/migrator/snapshot"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	migratorModel "github.com/lin-snow/ech0/internal/model/migrator"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	uuidUtil "github.com/lin-snow/ech0/internal/util/uuid"
 	"github.com/lin-snow/ech0/pkg/busen"
 	"github.com/lin-snow/ech0/pkg/viewer"
@@ -115,17 +115,19 @@ func (s *MigratorService) UploadSourceZip(
 	if err != nil {
 		return migratorModel.UploadMigrationSourceZipResponse{}, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return migratorModel.UploadMigrationSourceZipResponse{}, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
-	// 非 idle（存在任何作业行，无论在跑还是终态）则要求先清理，沿用旧语义。
-	if _, err := s.jobManager.Get(ctx, jobModel.TypeMigration); err == nil {
+	// 非 idle（存在当前作业，无论在跑还是未清理的终态）则要求先清理，沿用旧语义。
+	if _, err := s.jobManager.Current(ctx, jobModel.TypeMigration); err == nil {
 		return migratorModel.UploadMigrationSourceZipResponse{}, errors.New("请先结束/清理当前迁移")
 	} else if !errors.Is(err, job.ErrNotFound) {
 		return migratorModel.UploadMigrationSourceZipResponse{}, err
 	}
 
-	if !strings.HasSuffix(strings.ToLower(file.Filename), ".zip") {
+	// 各来源的官方导出格式不同（Mastodon 为 tar.gz、Bluesky 为 .car），按来源校验后缀。
+	ext := coreMigrator.SourceArchiveExt(sourceType, file.Filename)
+	if ext == "" {
 		return migratorModel.UploadMigrationSourceZipResponse{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
 
@@ -136,22 +138,22 @@ func (s *MigratorService) UploadSourceZip(
 
 	uploadID := uuidUtil.MustNewV7()
 	folderName := fmt.Sprintf("%s_%s", strings.TrimSpace(sourceType), uploadID)
-	zipPath := filepath.Join(baseTmpDir, folderName+".zip")
+	archivePath := filepath.Join(baseTmpDir, folderName+ext)
 	extractDir := filepath.Join(baseTmpDir, folderName)
 
-	if err := saveMultipartFile(file, zipPath); err != nil {
-		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("save uploaded zip: %w", err)
+	if err := saveMultipartFile(file, archivePath); err != nil {
+		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("save uploaded archive: %w", err)
 	}
 	defer func() {
-		_ = os.Remove(zipPath)
+		_ = os.Remove(archivePath)
 	}()
 
 	if err := os.MkdirAll(extractDir, 0o755); err != nil {
 		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("create extract dir: %w", err)
 	}
-	if err := snapshot.Unpack(zipPath, extractDir); err != nil {
+	if err := coreMigrator.UnpackSource(archivePath, ext, extractDir); err != nil {
 		_ = os.RemoveAll(extractDir)
-		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("unpack migration zip: %w", err)
+		return migratorModel.UploadMigrationSourceZipResponse{}, fmt.Errorf("unpack migration archive: %w", err)
 	}
 
 	relativeTmpDir := filepath.ToSlash(filepath.Join(coreMigrator.TmpRelativeDir, folderName))
@@ -204,7 +206,7 @@ func (s *MigratorService) GetGlobalMigrationStatus(ctx context.Context) (migrato
 	if _, err := s.ensureAdmin(ctx); err != nil {
 		return migratorModel.GlobalMigrationStateDTO{}, err
 	}
-	jb, err := s.jobManager.Get(ctx, jobModel.TypeMigration)
+	jb, err := s.jobManager.Current(ctx, jobModel.TypeMigration)
 	if errors.Is(err, job.ErrNotFound) {
 		return migratorModel.GlobalMigrationStateDTO{Version: 1, Status: migratorModel.MigrationStatusIdle}, nil
 	}
@@ -219,7 +221,7 @@ func (s *MigratorService) CancelGlobalMigration(ctx context.Context) (migratorMo
 	if _, err := s.ensureAdmin(ctx); err != nil {
 		return migratorModel.GlobalMigrationStateDTO{}, err
 	}
-	jb, err := s.jobManager.Get(ctx, jobModel.TypeMigration)
+	jb, err := s.jobManager.Current(ctx, jobModel.TypeMigration)
 	if errors.Is(err, job.ErrNotFound) {
 		return migratorModel.GlobalMigrationStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
@@ -230,19 +232,19 @@ func (s *MigratorService) CancelGlobalMigration(ctx context.Context) (migratorMo
 		return migratorModel.GlobalMigrationStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
 	_ = s.jobManager.Cancel(jobModel.TypeMigration)
-	jb, err = s.jobManager.Get(ctx, jobModel.TypeMigration)
+	jb, err = s.jobManager.Current(ctx, jobModel.TypeMigration)
 	if err != nil {
 		return migratorModel.GlobalMigrationStateDTO{}, err
 	}
 	return s.jobToDTO(jb), nil
 }
 
-// CleanupGlobalMigration 清理 tmp 目录并删除作业行（复位 idle）。
+// CleanupGlobalMigration 清理 tmp 目录并把作业行移出当前状态（复位 idle，历史保留）。
 func (s *MigratorService) CleanupGlobalMigration(ctx context.Context) error {
 	if _, err := s.ensureAdmin(ctx); err != nil {
 		return err
 	}
-	jb, err := s.jobManager.Get(ctx, jobModel.TypeMigration)
+	jb, err := s.jobManager.Current(ctx, jobModel.TypeMigration)
 	if errors.Is(err, job.ErrNotFound) {
 		return nil // 已是 idle，幂等
 	}
@@ -259,7 +261,7 @@ func (s *MigratorService) CleanupGlobalMigration(ctx context.Context) error {
 	if err := coreMigrator.CleanupTmpDirFromPayload(payload.SourcePayload); err != nil {
 		return fmt.Errorf("cleanup migration tmp dir: %w", err)
 	}
-	return s.jobManager.Delete(ctx, jobModel.TypeMigration)
+	return s.jobManager.Dismiss(ctx, jobModel.TypeMigration)
 }
 
 // StartExport 提交一次导出作业（手动导出的异步出口），格式由请求决定：快照或胶囊。
@@ -287,7 +289,7 @@ func (s *MigratorService) StartExport(
 	}
 	jb, err := s.jobManager.Submit(ctx, jobModel.TypeExport, raw)
 	if err != nil {
-		if errors.Is(err, job.ErrAlreadyRunning) {
+		if errors.Is(err, job.ErrAlreadyRunning) || errors.Is(err, job.ErrQueueFull) {
 			return migratorModel.ExportStateDTO{}, errors.New("导出进行中，请稍候")
 		}
 		return migratorModel.ExportStateDTO{}, err
@@ -300,7 +302,7 @@ func (s *MigratorService) GetExportStatus(ctx context.Context) (migratorModel.Ex
 	if _, err := s.ensureAdmin(ctx); err != nil {
 		return migratorModel.ExportStateDTO{}, err
 	}
-	jb, err := s.jobManager.Get(ctx, jobModel.TypeExport)
+	jb, err := s.jobManager.Current(ctx, jobModel.TypeExport)
 	if errors.Is(err, job.ErrNotFound) {
 		return migratorModel.ExportStateDTO{Version: 1, Status: migratorModel.MigrationStatusIdle}, nil
 	}
@@ -315,7 +317,7 @@ func (s *MigratorService) CancelExport(ctx context.Context) (migratorModel.Expor
 	if _, err := s.ensureAdmin(ctx); err != nil {
 		return migratorModel.ExportStateDTO{}, err
 	}
-	jb, err := s.jobManager.Get(ctx, jobModel.TypeExport)
+	jb, err := s.jobManager.Current(ctx, jobModel.TypeExport)
 	if errors.Is(err, job.ErrNotFound) {
 		return migratorModel.ExportStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
@@ -326,7 +328,7 @@ func (s *MigratorService) CancelExport(ctx context.Context) (migratorModel.Expor
 		return migratorModel.ExportStateDTO{}, errors.New(commonModel.INVALID_REQUEST_BODY)
 	}
 	_ = s.jobManager.Cancel(jobModel.TypeExport)
-	jb, err = s.jobManager.Get(ctx, jobModel.TypeExport)
+	jb, err = s.jobManager.Current(ctx, jobModel.TypeExport)
 	if err != nil {
 		return migratorModel.ExportStateDTO{}, err
 	}
@@ -409,7 +411,7 @@ func (s *MigratorService) ensureAdmin(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return "", errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 	return userID, nil
@@ -419,7 +421,10 @@ func validateSourceType(sourceType string) error {
 	switch strings.TrimSpace(sourceType) {
 	case migratorModel.MigrationSourceMemos,
 		migratorModel.MigrationSourceEch0,
-		migratorModel.MigrationSourceCapsule:
+		migratorModel.MigrationSourceCapsule,
+		migratorModel.MigrationSourceTwitter,
+		migratorModel.MigrationSourceMastodon,
+		migratorModel.MigrationSourceBluesky:
 		return nil
 	default:
 		return errors.New(commonModel.INVALID_REQUEST_BODY)
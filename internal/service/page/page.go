@@ -0,0 +1,168 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	model "github.com/lin-snow/ech0/internal/model/page"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/storage"
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
+	urlUtil "github.com/lin-snow/ech0/internal/util/url"
+	"golang.org/x/net/html"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxTitleRunes / maxDescriptionRunes 对齐常见卡片的展示长度，超出部分由平台截断也无妨。
+	maxTitleRunes       = 60
+	maxDescriptionRunes = 200
+	defaultSiteName     = "Ech0"
+)
+
+// ErrNotFound 表示 Echo 不存在或对外不可见（私密、草稿 / 定时、回收站）。
+var ErrNotFound = errors.New("page: not found")
+
+type PageService struct {
+	echoRepo EchoRepository
+	kv       kvstore.Store
+}
+
+var _ Service = (*PageService)(nil)
+
+func NewPageService(echoRepo EchoRepository, durableKV kvstore.Store) *PageService {
+	return &PageService{echoRepo: echoRepo, kv: durableKV}
+}
+
+// EchoPage 组装 /echo/{id} 的页面元数据。标题取正文第一行，摘要取渲染后正文的纯文本；
+// 不可见的 Echo 与不存在的一样返回 ErrNotFound，不透露它是否存在。
+func (s *PageService) EchoPage(ctx context.Context, id string) (model.EchoPage, error) {
+	echo, err := s.echoRepo.GetEchosById(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !isVisible(echo)) {
+		return model.EchoPage{}, ErrNotFound
+	}
+	if err != nil {
+		return model.EchoPage{}, err
+	}
+	system, err := coreSetting.Get(ctx, s.kv, coreSetting.System)
+	if err != nil {
+		return model.EchoPage{}, err
+	}
+	base := urlUtil.SiteBase(system.ServerURL)
+	siteName := strings.TrimSpace(system.SiteTitle)
+	if siteName == "" {
+		siteName = defaultSiteName
+	}
+
+	content := mdUtil.MdToHTML([]byte(echo.Content))
+	text := extractText(content)
+	page := model.EchoPage{
+		SiteName:    siteName,
+		Description: truncate(strings.Join(strings.Fields(strings.Join(text.lines, " ")), " "), maxDescriptionRunes),
+		URL:         base + "/echo/" + echo.ID,
+		Author:      echo.Username,
+		Published:   time.Unix(echo.PublishedAt(), 0).UTC(),
+		ContentHTML: string(content),
+	}
+	if len(text.lines) > 0 {
+		page.Title = truncate(text.lines[0], maxTitleRunes)
+	}
+	for _, tag := range echo.Tags {
+		if name := strings.TrimPrefix(tag.Name, "#"); name != "" {
+			page.Tags = append(page.Tags, name)
+		}
+	}
+	for _, ef := range echo.EchoFiles {
+		if ef.File.URL == "" || ef.File.Category != string(storage.CategoryImage) {
+			continue
+		}
+		page.Images = append(page.Images, model.Image{
+			URL:    urlUtil.Absolute(base, ef.File.URL),
+			Alt:    ef.File.Name,
+			Width:  ef.File.Width,
+			Height: ef.File.Height,
+		})
+	}
+	if len(page.Images) == 0 && text.image != "" {
+		page.Images = append(page.Images, model.Image{URL: urlUtil.Absolute(base, text.image)})
+	}
+	return page, nil
+}
+
+// isVisible 判断 Echo 是否对外可见：公开、已发布且不在回收站。
+func isVisible(echo *echoModel.Echo) bool {
+	return echo != nil && !echo.Private && echo.IsPublished() && !echo.IsTrashed()
+}
+
+// renderedText 是渲染后正文的纯文本（按块级元素分行）与其中第一张图片。
+type renderedText struct {
+	lines []string
+	image string
+}
+
+// blockElements 结束时换行，使标题只取第一段而不是把整段正文拼在一起。
+var blockElements = map[string]bool{
+	"p": true, "br": true, "li": true, "pre": true, "blockquote": true, "tr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"dt": true, "dd": true, "hr": true,
+}
+
+func extractText(content []byte) renderedText {
+	var out renderedText
+	root, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return out
+	}
+	var line strings.Builder
+	flush := func() {
+		if s := strings.Join(strings.Fields(line.String()), " "); s != "" {
+			out.lines = append(out.lines, s)
+		}
+		line.Reset()
+	}
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			line.WriteString(n.Data)
+		case html.ElementNode:
+			if n.Data == "img" && out.image == "" {
+				out.image = attr(n, "src")
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+		if n.Type == html.ElementNode && blockElements[n.Data] {
+			flush()
+		}
+	}
+	walk(root)
+	flush()
+	return out
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "…"
+}
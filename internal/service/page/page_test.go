@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	fileModel "github.com/lin-snow/ech0/internal/model/file"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const testBase = "https://ech0.example"
+
+type memEchoRepo struct {
+	echos map[string]echoModel.Echo
+}
+
+func (r *memEchoRepo) GetEchosById(_ context.Context, id string) (*echoModel.Echo, error) {
+	echo, ok := r.echos[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &echo, nil
+}
+
+func newService(t *testing.T, serverURL string, echos ...echoModel.Echo) *PageService {
+	t.Helper()
+	kv := kvstore.NewMemory()
+	require.NoError(t, coreSetting.Set(context.Background(), kv, coreSetting.System, settingModel.SystemSetting{
+		SiteTitle: "My Ech0",
+		ServerURL: serverURL,
+	}))
+	repo := &memEchoRepo{echos: make(map[string]echoModel.Echo)}
+	for _, e := range echos {
+		repo.echos[e.ID] = e
+	}
+	return NewPageService(repo, kv)
+}
+
+func publicEcho() echoModel.Echo {
+	return echoModel.Echo{
+		ID:        "e1",
+		Content:   "# Hello **world**\n\nSecond paragraph with a [link](https://example.com).\n",
+		Username:  "alice",
+		Status:    echoModel.StatusPublished,
+		CreatedAt: 1700000000,
+		PublishAt: 1700000100,
+		Tags:      []echoModel.Tag{{Name: "go"}, {Name: "#life"}},
+		EchoFiles: []echoModel.EchoFile{
+			{File: fileModel.File{URL: "/api/files/a.pdf", Category: "pdf"}},
+			{File: fileModel.File{URL: "/api/files/b.png", Name: "b.png", Category: "image", Width: 800, Height: 600}},
+		},
+	}
+}
+
+func TestEchoPage_BuildsMetadata(t *testing.T) {
+	svc := newService(t, testBase+"/", publicEcho())
+
+	page, err := svc.EchoPage(context.Background(), "e1")
+	require.NoError(t, err)
+	assert.Equal(t, "My Ech0", page.SiteName)
+	assert.Equal(t, "Hello world", page.Title)
+	assert.Equal(t, "Hello world Second paragraph with a link.", page.Description)
+	assert.Equal(t, testBase+"/echo/e1", page.URL)
+	assert.Equal(t, "alice", page.Author)
+	assert.Equal(t, time.Unix(1700000100, 0).UTC(), page.Published)
+	assert.Equal(t, []string{"go", "life"}, page.Tags)
+	require.Len(t, page.Images, 1, "only image attachments become card images")
+	assert.Equal(t, testBase+"/api/files/b.png", page.Images[0].URL)
+	assert.Equal(t, 800, page.Images[0].Width)
+	assert.Contains(t, page.ContentHTML, "<strong>world</strong>")
+}
+
+func TestEchoPage_RelativeWithoutServerURL(t *testing.T) {
+	echo := publicEcho()
+	echo.EchoFiles = nil
+	echo.Content = "look ![cat](/api/files/cat.jpg)"
+	svc := newService(t, "", echo)
+
+	page, err := svc.EchoPage(context.Background(), "e1")
+	require.NoError(t, err)
+	assert.Equal(t, "/echo/e1", page.URL)
+	require.Len(t, page.Images, 1, "falls back to the first image in the content")
+	assert.Equal(t, "/api/files/cat.jpg", page.Images[0].URL)
+}
+
+func TestEchoPage_TruncatesLongText(t *testing.T) {
+	echo := publicEcho()
+	echo.Content = strings.Repeat("字", 300)
+	svc := newService(t, testBase, echo)
+
+	page, err := svc.EchoPage(context.Background(), "e1")
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("字", maxTitleRunes)+"…", page.Title)
+	assert.Equal(t, strings.Repeat("字", maxDescriptionRunes)+"…", page.Description)
+}
+
+func TestEchoPage_HidesInvisibleEchos(t *testing.T) {
+	private := publicEcho()
+	private.ID, private.Private = "private", true
+	draft := publicEcho()
+	draft.ID, draft.Status = "draft", echoModel.StatusDraft
+	scheduled := publicEcho()
+	scheduled.ID, scheduled.Status = "scheduled", echoModel.StatusScheduled
+	trashed := publicEcho()
+	trashed.ID, trashed.DeletedAt = "trashed", 1700000200
+	svc := newService(t, testBase, private, draft, scheduled, trashed)
+
+	for _, id := range []string{"private", "draft", "scheduled", "trashed", "missing"} {
+		page, err := svc.EchoPage(context.Background(), id)
+		assert.ErrorIs(t, err, ErrNotFound, id)
+		assert.Empty(t, page.ContentHTML, id)
+	}
+}
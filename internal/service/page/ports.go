@@ -0,0 +1,21 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	model "github.com/lin-snow/ech0/internal/model/page"
+)
+
+// Service 为前端路由提供服务端注入用的页面元数据（Open Graph、JSON-LD、无 JS 回退正文）。
+// 只对外可见的 Echo 有结果，其余一律 ErrNotFound，调用方据此原样返回 SPA 页面。
+type Service interface {
+	EchoPage(ctx context.Context, id string) (model.EchoPage, error)
+}
+
+type EchoRepository interface {
+	GetEchosById(ctx context.Context, id string) (*echoModel.Echo, error)
+}
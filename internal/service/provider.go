@@ -5,6 +5,7 @@ package service
 
 import (
 	"github.com/google/wire"
+	activitypubService "github.com/lin-snow/ech0/internal/service/activitypub"
 	authService "github.com/lin-snow/ech0/internal/service/auth"
 	commentService "github.com/lin-snow/ech0/internal/service/comment"
 	commonService "github.com/lin-snow/ech0/internal/service/common"
@@ -15,9 +16,13 @@ import (
 	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
 	fileService "github.com/lin-snow/ech0/internal/service/file"
 	initService "github.com/lin-snow/ech0/internal/service/init"
+	micropubService "github.com/lin-snow/ech0/internal/service/micropub"
 	migratorService "github.com/lin-snow/ech0/internal/service/migrator"
+	pageService "github.com/lin-snow/ech0/internal/service/page"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	userService "github.com/lin-snow/ech0/internal/service/user"
+	webmentionService "github.com/lin-snow/ech0/internal/service/webmention"
 )
 
 var (
@@ -34,6 +39,33 @@ var (
 		fileService.NewFileService,
 		wire.Bind(new(fileService.Service), new(*fileService.FileService)),
 	)
+	// FederationSet 只装配投递侧，供事件订阅使用；ActivityPubSet 在此之上提供对外文档与收件箱。
+	FederationSet = wire.NewSet(
+		activitypubService.NewFederator,
+		wire.Bind(new(activitypubService.Publisher), new(*activitypubService.Federator)),
+	)
+	ActivityPubSet = wire.NewSet(
+		FederationSet,
+		activitypubService.NewActivityPubService,
+		wire.Bind(new(activitypubService.Service), new(*activitypubService.ActivityPubService)),
+	)
+	// WebmentionSenderSet 供事件订阅发送提及；WebmentionSet 提供接收端点。
+	WebmentionSenderSet = wire.NewSet(
+		webmentionService.NewWebmentionSender,
+		wire.Bind(new(webmentionService.Sender), new(*webmentionService.WebmentionSender)),
+	)
+	WebmentionSet = wire.NewSet(
+		webmentionService.NewWebmentionService,
+		wire.Bind(new(webmentionService.Service), new(*webmentionService.WebmentionService)),
+	)
+	MicropubSet = wire.NewSet(
+		micropubService.NewMicropubService,
+		wire.Bind(new(micropubService.Service), new(*micropubService.MicropubService)),
+	)
+	PageSet = wire.NewSet(
+		pageService.NewPageService,
+		wire.Bind(new(pageService.Service), new(*pageService.PageService)),
+	)
 	CommentSet = wire.NewSet(
 		commentService.NewGoMailSender,
 		wire.Bind(new(commentService.Mailer), new(*commentService.GoMailSender)),
@@ -65,6 +97,10 @@ var (
 		wire.Bind(new(embeddingService.Service), new(*embeddingService.EmbeddingService)),
 		wire.Bind(new(embeddingService.Indexer), new(*embeddingService.EmbeddingService)),
 	)
+	SearchSet = wire.NewSet(
+		searchService.NewSearchService,
+		wire.Bind(new(searchService.Service), new(*searchService.SearchService)),
+	)
 	CopilotSet = wire.NewSet(
 		copilotService.NewCopilotService,
 		wire.Bind(new(copilotService.SummaryService), new(*copilotService.CopilotService)),
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package service 实现 Echo 的混合检索：关键词（FTS5 / LIKE）与向量语义（sqlite-vec）两路召回，
+// 以倒数排名融合（RRF）合并为一个结果列表；以及同样基于向量近邻的相关推荐与标签建议。
+package service
+
+import (
+	"context"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
+)
+
+// Service 是混合检索的对外接口，REST / MCP / Copilot 共用。
+type Service interface {
+	// HybridSearch 同时做关键词与语义检索并融合排序。可见性与 QueryEchos 完全一致；
+	// Embedding 未启用或语义检索失败时回退为仅关键词（结果 Mode=keyword）。
+	HybridSearch(ctx context.Context, dto commonModel.EchoSearchDto) (echoModel.EchoSearchResult, error)
+	// RelatedEchos 以某条 Echo 自身的向量做 KNN，返回与它最相近的可见 Echo。源 Echo 的可见性
+	// 与 GetEchoById 一致；Embedding 未启用或检索失败时返回空结果（Mode=unavailable），不报错。
+	RelatedEchos(ctx context.Context, echoID string, limit int) (echoModel.RelatedEchoResult, error)
+	// SuggestTags 为草稿正文建议已有标签：近邻 Echo 按相似度加权投票（Mode=semantic）；
+	// Embedding 未启用、检索失败或近邻里没有标签时回退为正文中出现的已有标签（Mode=keyword）。
+	SuggestTags(ctx context.Context, dto commonModel.TagSuggestDto) (echoModel.TagSuggestionResult, error)
+}
+
+type (
+	EchoService      = echoService.Service
+	EmbeddingService = embeddingService.Service
+)
@@ -0,0 +1,263 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	// defaultRelatedLimit / maxRelatedLimit 是相关推荐返回条数的缺省值与上限。
+	defaultRelatedLimit = 5
+	maxRelatedLimit     = 20
+	// defaultSuggestLimit / maxSuggestLimit 是标签建议返回条数的缺省值与上限。
+	defaultSuggestLimit = 5
+	maxSuggestLimit     = 20
+	// tagVoteNeighbors 是标签投票时参考的近邻 Echo 数：太少票数稀疏，太多会把不相干的
+	// 常用标签也拉进来。
+	tagVoteNeighbors = 30
+)
+
+func (s *SearchService) RelatedEchos(
+	ctx context.Context,
+	echoID string,
+	limit int,
+) (echoModel.RelatedEchoResult, error) {
+	// 先按详情页同样的规则裁决源 Echo 本身：看不到它的人也不该拿到它的「邻居」。
+	if _, err := s.echoService.GetEchoById(ctx, echoID); err != nil {
+		return echoModel.RelatedEchoResult{}, err
+	}
+	if limit < 1 {
+		limit = defaultRelatedLimit
+	}
+	limit = min(limit, maxRelatedLimit)
+
+	unavailable := echoModel.RelatedEchoResult{Mode: echoModel.SearchModeUnavailable, Items: []echoModel.RelatedEchoHit{}}
+	if s.embedding == nil || !s.embedding.Enabled(ctx) {
+		return unavailable, nil
+	}
+	// 超额取数：近邻里的私密 / 草稿 Echo 会在可见性回查中被裁掉。
+	neighbors, err := s.embedding.Related(ctx, echoID, min(limit*candidateFactor, maxCandidates))
+	if err != nil {
+		logUtil.Warn("related echos lookup failed", slog.String("module", "search"), logUtil.Err(err))
+		return unavailable, nil
+	}
+
+	result := echoModel.RelatedEchoResult{Mode: echoModel.SearchModeSemantic, Items: []echoModel.RelatedEchoHit{}}
+	if len(neighbors) == 0 {
+		return result, nil
+	}
+	ids := make([]string, 0, len(neighbors))
+	distance := make(map[string]float64, len(neighbors))
+	for _, n := range neighbors {
+		ids = append(ids, n.EchoID)
+		distance[n.EchoID] = n.Distance
+	}
+	visible, err := s.visibleInOrder(ctx, ids, commonModel.EchoQueryDto{})
+	if err != nil {
+		return echoModel.RelatedEchoResult{}, err
+	}
+	for _, e := range visible {
+		result.Items = append(result.Items, echoModel.RelatedEchoHit{Echo: e, Distance: distance[e.ID]})
+		if len(result.Items) >= limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+// tagVote 是标签投票的累计。
+type tagVote struct {
+	tag    echoModel.Tag
+	weight float64
+	votes  int
+}
+
+func (s *SearchService) SuggestTags(
+	ctx context.Context,
+	dto commonModel.TagSuggestDto,
+) (echoModel.TagSuggestionResult, error) {
+	content := strings.TrimSpace(dto.Content)
+	if content == "" {
+		return echoModel.TagSuggestionResult{}, commonModel.NewBizError(
+			commonModel.ErrCodeInvalidRequest, commonModel.TAG_SUGGEST_CONTENT_EMPTY,
+		)
+	}
+	limit := dto.Limit
+	if limit < 1 {
+		limit = defaultSuggestLimit
+	}
+	limit = min(limit, maxSuggestLimit)
+
+	skip := make(map[string]bool, len(dto.Tags))
+	for _, name := range dto.Tags {
+		skip[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+
+	if items, ok := s.voteTags(ctx, content, skip, limit); ok && len(items) > 0 {
+		return echoModel.TagSuggestionResult{Mode: echoModel.SearchModeSemantic, Items: items}, nil
+	}
+	items, err := s.mentionedTags(content, skip, limit)
+	if err != nil {
+		return echoModel.TagSuggestionResult{}, err
+	}
+	return echoModel.TagSuggestionResult{Mode: echoModel.SearchModeKeyword, Items: items}, nil
+}
+
+// voteTags 取与草稿最相近的一批可见 Echo，让它们各自的标签按相似度加权投票：
+// 越近的邻居票越重（1/(1+distance)），得分为该标签所得权重占全部邻居权重的比例。
+// ok=false 表示没做语义投票（未启用或检索失败），调用方回退为关键词匹配。
+func (s *SearchService) voteTags(
+	ctx context.Context,
+	content string,
+	skip map[string]bool,
+	limit int,
+) ([]echoModel.TagSuggestion, bool) {
+	if s.embedding == nil || !s.embedding.Enabled(ctx) {
+		return nil, false
+	}
+	neighbors, err := s.embedding.Search(ctx, content, tagVoteNeighbors, "")
+	if err != nil {
+		logUtil.Warn(
+			"tag suggestion recall failed, falling back to keyword match",
+			slog.String("module", "search"),
+			logUtil.Err(err),
+		)
+		return nil, false
+	}
+	if len(neighbors) == 0 {
+		return nil, true
+	}
+
+	ids := make([]string, 0, len(neighbors))
+	distance := make(map[string]float64, len(neighbors))
+	for _, n := range neighbors {
+		ids = append(ids, n.EchoID)
+		distance[n.EchoID] = n.Distance
+	}
+	visible, err := s.visibleInOrder(ctx, ids, commonModel.EchoQueryDto{})
+	if err != nil {
+		logUtil.Warn(
+			"tag suggestion filtering failed, falling back to keyword match",
+			slog.String("module", "search"),
+			logUtil.Err(err),
+		)
+		return nil, false
+	}
+
+	var total float64
+	votes := make(map[string]*tagVote)
+	for _, e := range visible {
+		w := 1 / (1 + distance[e.ID])
+		total += w
+		for _, tag := range e.Tags {
+			if skip[strings.ToLower(tag.Name)] {
+				continue
+			}
+			v, ok := votes[tag.ID]
+			if !ok {
+				v = &tagVote{tag: tag}
+				votes[tag.ID] = v
+			}
+			v.weight += w
+			v.votes++
+		}
+	}
+	if total == 0 {
+		return nil, true
+	}
+
+	ranked := make([]*tagVote, 0, len(votes))
+	for _, v := range votes {
+		ranked = append(ranked, v)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].weight != ranked[j].weight {
+			return ranked[i].weight > ranked[j].weight
+		}
+		return ranked[i].tag.Name < ranked[j].tag.Name
+	})
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	items := make([]echoModel.TagSuggestion, 0, len(ranked))
+	for _, v := range ranked {
+		items = append(items, echoModel.TagSuggestion{Tag: v.tag, Score: v.weight / total, Votes: v.votes})
+	}
+	return items, true
+}
+
+// mentionedTags 是不依赖向量的兜底：正文里（不区分大小写）作为完整词出现过的已有标签，
+// 常用的排在前面。
+func (s *SearchService) mentionedTags(
+	content string,
+	skip map[string]bool,
+	limit int,
+) ([]echoModel.TagSuggestion, error) {
+	tags, err := s.echoService.GetAllTags()
+	if err != nil {
+		return nil, err
+	}
+	lower := strings.ToLower(content)
+	matched := make([]echoModel.Tag, 0)
+	for _, tag := range tags {
+		name := strings.ToLower(tag.Name)
+		if name == "" || skip[name] || !mentions(lower, name) {
+			continue
+		}
+		matched = append(matched, tag)
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		if matched[i].UsageCount != matched[j].UsageCount {
+			return matched[i].UsageCount > matched[j].UsageCount
+		}
+		return matched[i].Name < matched[j].Name
+	})
+	if len(matched) > limit {
+		matched = matched[:limit]
+	}
+	items := make([]echoModel.TagSuggestion, 0, len(matched))
+	for _, tag := range matched {
+		items = append(items, echoModel.TagSuggestion{Tag: tag, Score: 1})
+	}
+	return items, nil
+}
+
+// mentions 判断 name 是否在 text 中作为完整词出现：拉丁字母 / 数字的标签要求两侧不紧贴
+// 同类字符（「go」不命中「good」）；中日韩文字没有词边界，出现即算。
+func mentions(text, name string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], name)
+		if i < 0 {
+			return false
+		}
+		start, end := offset+i, offset+i+len(name)
+		before, _ := utf8.DecodeLastRuneInString(text[:start])
+		after, _ := utf8.DecodeRuneInString(text[end:])
+		first, _ := utf8.DecodeRuneInString(name)
+		last, _ := utf8.DecodeLastRuneInString(name)
+		if !(isWordRune(first) && isWordRune(before)) && !(isWordRune(last) && isWordRune(after)) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+// isWordRune 判断字符是否属于以空格分词的文字（拉丁字母、数字等，不含中日韩文字）。
+func isWordRune(r rune) bool {
+	if ftsUtil.IsCJK(r) {
+		return false
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
@@ -0,0 +1,29 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMentions(t *testing.T) {
+	cases := []struct {
+		text, name string
+		want       bool
+	}{
+		{"learning go today", "go", true},
+		{"go", "go", true},
+		{"(go)", "go", true},
+		{"a good day", "go", false},
+		{"ergo go", "go", true},
+		{"周末去爬山", "爬山", true},
+		{"学习golang", "golang", true},
+		{"c++ tips", "c++", true},
+	}
+	for _, tc := range cases {
+		assert.Equal(t, tc.want, mentions(tc.text, tc.name), "%q in %q", tc.name, tc.text)
+	}
+}
@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
+	echomock "github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// relatedEmbedding 在 stubEmbedding 之上再覆写 Related。
+type relatedEmbedding struct {
+	stubEmbedding
+	related    []embeddingModel.SearchResult
+	relatedErr error
+	gotRelK    int
+}
+
+func (s *relatedEmbedding) Related(_ context.Context, _ string, k int) ([]embeddingModel.SearchResult, error) {
+	s.gotRelK = k
+	return s.related, s.relatedErr
+}
+
+func relatedIDs(res echoModel.RelatedEchoResult) []string {
+	ids := make([]string, 0, len(res.Items))
+	for _, h := range res.Items {
+		ids = append(ids, h.Echo.ID)
+	}
+	return ids
+}
+
+func tagNames(res echoModel.TagSuggestionResult) []string {
+	names := make([]string, 0, len(res.Items))
+	for _, s := range res.Items {
+		names = append(names, s.Tag.Name)
+	}
+	return names
+}
+
+// 近邻里被可见性裁掉的 Echo（私密 / 草稿）不出现，剩下的保持距离序并带上距离。
+func TestRelatedEchos_FiltersByVisibility(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "src").Return(&echoModel.Echo{ID: "src"}, nil).Once()
+	echoSvc.EXPECT().
+		QueryEchos(mock.Anything, mock.Anything).
+		Run(func(_ context.Context, dto commonModel.EchoQueryDto) {
+			assert.Equal(t, []string{"private", "near", "far"}, dto.EchoIDs)
+		}).
+		Return(page(echoModel.Echo{ID: "far"}, echoModel.Echo{ID: "near"}), nil).
+		Once()
+	emb := &relatedEmbedding{stubEmbedding: stubEmbedding{enabled: true}, related: []embeddingModel.SearchResult{
+		{EchoID: "private", Distance: 0.1}, {EchoID: "near", Distance: 0.2}, {EchoID: "far", Distance: 0.9},
+	}}
+
+	svc := searchService.NewSearchService(echoSvc, emb)
+	res, err := svc.RelatedEchos(context.Background(), "src", 2)
+	require.NoError(t, err)
+	assert.Equal(t, echoModel.SearchModeSemantic, res.Mode)
+	assert.Equal(t, 6, emb.gotRelK) // limit 2 × 3 倍候选
+	assert.Equal(t, []string{"near", "far"}, relatedIDs(res))
+	assert.InDelta(t, 0.2, res.Items[0].Distance, 1e-9)
+}
+
+// 看不到源 Echo 的人拿不到它的相关推荐。
+func TestRelatedEchos_SourceVisibilityError(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().GetEchoById(mock.Anything, "secret").
+		Return(nil, errors.New(commonModel.NO_PERMISSION_DENIED)).Once()
+
+	svc := searchService.NewSearchService(echoSvc, &relatedEmbedding{stubEmbedding: stubEmbedding{enabled: true}})
+	_, err := svc.RelatedEchos(context.Background(), "secret", 5)
+	require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+}
+
+func TestRelatedEchos_DegradesWhenUnavailable(t *testing.T) {
+	cases := []struct {
+		name string
+		emb  *relatedEmbedding
+	}{
+		{"disabled", &relatedEmbedding{}},
+		{"lookup error", &relatedEmbedding{stubEmbedding: stubEmbedding{enabled: true}, relatedErr: errors.New("boom")}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			echoSvc := echomock.NewMockService(t)
+			echoSvc.EXPECT().GetEchoById(mock.Anything, "src").Return(&echoModel.Echo{ID: "src"}, nil).Once()
+
+			svc := searchService.NewSearchService(echoSvc, tc.emb)
+			res, err := svc.RelatedEchos(context.Background(), "src", 0)
+			require.NoError(t, err)
+			assert.Equal(t, echoModel.SearchModeUnavailable, res.Mode)
+			assert.NotNil(t, res.Items)
+			assert.Empty(t, res.Items)
+		})
+	}
+}
+
+func TestSuggestTags_EmptyContent(t *testing.T) {
+	svc := searchService.NewSearchService(echomock.NewMockService(t), &stubEmbedding{})
+	_, err := svc.SuggestTags(context.Background(), commonModel.TagSuggestDto{Content: " \n "})
+	require.Error(t, err)
+}
+
+// 近邻按相似度加权投票：越近的票越重；草稿已有的标签不再建议。
+func TestSuggestTags_VotesByNeighbours(t *testing.T) {
+	golang := echoModel.Tag{ID: "t-go", Name: "golang"}
+	backend := echoModel.Tag{ID: "t-be", Name: "backend"}
+	life := echoModel.Tag{ID: "t-life", Name: "life"}
+
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().
+		QueryEchos(mock.Anything, mock.Anything).
+		Return(page(
+			echoModel.Echo{ID: "a", Tags: []echoModel.Tag{golang, backend}},
+			echoModel.Echo{ID: "b", Tags: []echoModel.Tag{golang}},
+			echoModel.Echo{ID: "c", Tags: []echoModel.Tag{life}},
+			echoModel.Echo{ID: "d"},
+		), nil).
+		Once()
+	emb := &stubEmbedding{enabled: true, results: []embeddingModel.SearchResult{
+		{EchoID: "a", Distance: 0}, {EchoID: "b", Distance: 0.5}, {EchoID: "c", Distance: 3}, {EchoID: "d", Distance: 1},
+	}}
+
+	svc := searchService.NewSearchService(echoSvc, emb)
+	res, err := svc.SuggestTags(context.Background(), commonModel.TagSuggestDto{
+		Content: "goroutine leak in the http server", Tags: []string{"Backend"}, Limit: 5,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, echoModel.SearchModeSemantic, res.Mode)
+	assert.Equal(t, []string{"golang", "life"}, tagNames(res))
+	assert.Equal(t, 2, res.Items[0].Votes)
+	// 权重：a=1, b=1/1.5, c=1/4, d=1/2 → golang 占 (1+2/3)/(1+2/3+1/4+1/2)
+	assert.InDelta(t, (1+2.0/3)/(1+2.0/3+0.25+0.5), res.Items[0].Score, 1e-9)
+}
+
+// 未启用向量或近邻里没有标签时，回退为正文中出现的已有标签，常用的在前。
+func TestSuggestTags_FallsBackToMentionedTags(t *testing.T) {
+	cases := []struct {
+		name    string
+		emb     *stubEmbedding
+		queries int
+	}{
+		{"embedding disabled", &stubEmbedding{}, 0},
+		{"search error", &stubEmbedding{enabled: true, err: errors.New("boom")}, 0},
+		{"neighbours without tags", &stubEmbedding{enabled: true, results: []embeddingModel.SearchResult{{EchoID: "x"}}}, 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			echoSvc := echomock.NewMockService(t)
+			if tc.queries > 0 {
+				echoSvc.EXPECT().QueryEchos(mock.Anything, mock.Anything).Return(page(echoModel.Echo{ID: "x"}), nil).Once()
+			}
+			echoSvc.EXPECT().GetAllTags().Return([]echoModel.Tag{
+				{ID: "1", Name: "Go", UsageCount: 3},
+				{ID: "2", Name: "Vue", UsageCount: 9},
+				{ID: "3", Name: "rust", UsageCount: 20},
+				{ID: "4", Name: "draft", UsageCount: 1},
+			}, nil).Once()
+
+			svc := searchService.NewSearchService(echoSvc, tc.emb)
+			res, err := svc.SuggestTags(context.Background(), commonModel.TagSuggestDto{
+				Content: "Porting the Vue app to go — trusty old code, still a draft", Tags: []string{"draft"},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, echoModel.SearchModeKeyword, res.Mode)
+			assert.Equal(t, []string{"Vue", "Go"}, tagNames(res))
+			assert.Equal(t, 1.0, res.Items[0].Score)
+		})
+	}
+}
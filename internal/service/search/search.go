@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	// defaultLimit / maxLimit 是混合检索返回条数的缺省值与上限。
+	defaultLimit = 10
+	maxLimit     = 50
+	// candidateFactor 是每路召回相对 limit 的超额倍数：融合前多取一些候选，
+	// 让只在一路靠后命中的 Echo 也有机会被另一路拉上来。
+	candidateFactor = 3
+	// maxCandidates 与 QueryEchos 的 PageSize 上限对齐。
+	maxCandidates = 100
+	// rrfK 是 RRF 的平滑常数（score = Σ 1/(k+rank)），取文献常用值 60，
+	// 使头部名次的差距不至于压倒另一路的召回。
+	rrfK = 60
+)
+
+type SearchService struct {
+	echoService EchoService
+	embedding   EmbeddingService
+}
+
+var _ Service = (*SearchService)(nil)
+
+func NewSearchService(echoService EchoService, embedding EmbeddingService) *SearchService {
+	return &SearchService{
+		echoService: echoService,
+		embedding:   embedding,
+	}
+}
+
+// fusedHit 是融合过程中的中间态。
+type fusedHit struct {
+	echo         echoModel.Echo
+	score        float64
+	keywordRank  int
+	semanticRank int
+}
+
+func (s *SearchService) HybridSearch(
+	ctx context.Context,
+	dto commonModel.EchoSearchDto,
+) (echoModel.EchoSearchResult, error) {
+	dto.Query = strings.TrimSpace(dto.Query)
+	if dto.Query == "" {
+		return echoModel.EchoSearchResult{}, commonModel.NewBizError(
+			commonModel.ErrCodeInvalidQuery, commonModel.SEARCH_QUERY_EMPTY,
+		)
+	}
+	limit := dto.Limit
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	limit = min(limit, maxLimit)
+	candidates := min(limit*candidateFactor, maxCandidates)
+
+	// 关键词召回：直接复用 QueryEchos（含可见性裁决与结构化过滤），按 bm25 相关度排序。
+	keywordPage, err := s.echoService.QueryEchos(ctx, commonModel.EchoQueryDto{
+		Page:     1,
+		PageSize: candidates,
+		Search:   dto.Query,
+		TagIDs:   dto.TagIDs,
+		SortBy:   "relevance",
+		DateFrom: dto.DateFrom,
+		DateTo:   dto.DateTo,
+		Private:  dto.Private,
+		UserID:   dto.UserID,
+	})
+	if err != nil {
+		return echoModel.EchoSearchResult{}, err
+	}
+
+	hits := make(map[string]*fusedHit, len(keywordPage.Items))
+	for i, e := range keywordPage.Items {
+		hits[e.ID] = &fusedHit{echo: e, keywordRank: i + 1, score: rrfScore(i + 1)}
+	}
+
+	mode := echoModel.SearchModeKeyword
+	if semantic, ok := s.semanticRecall(ctx, dto, candidates); ok {
+		mode = echoModel.SearchModeHybrid
+		for i, e := range semantic {
+			rank := i + 1
+			h, exists := hits[e.ID]
+			if !exists {
+				h = &fusedHit{echo: e}
+				hits[e.ID] = h
+			}
+			h.semanticRank = rank
+			h.score += rrfScore(rank)
+		}
+	}
+
+	fused := make([]*fusedHit, 0, len(hits))
+	for _, h := range hits {
+		fused = append(fused, h)
+	}
+	sort.SliceStable(fused, func(i, j int) bool {
+		if fused[i].score != fused[j].score {
+			return fused[i].score > fused[j].score
+		}
+		if fused[i].echo.CreatedAt != fused[j].echo.CreatedAt {
+			return fused[i].echo.CreatedAt > fused[j].echo.CreatedAt
+		}
+		return fused[i].echo.ID < fused[j].echo.ID
+	})
+	if len(fused) > limit {
+		fused = fused[:limit]
+	}
+
+	terms := ftsUtil.ParseQuery(dto.Query).Terms()
+	items := make([]echoModel.EchoSearchHit, 0, len(fused))
+	for _, h := range fused {
+		items = append(items, echoModel.EchoSearchHit{
+			Echo:         h.echo,
+			Score:        h.score,
+			KeywordRank:  h.keywordRank,
+			SemanticRank: h.semanticRank,
+			Highlight:    ftsUtil.Highlight(h.echo.Content, terms, ftsUtil.DefaultSnippetRunes),
+		})
+	}
+	return echoModel.EchoSearchResult{
+		Mode:         mode,
+		Items:        items,
+		KeywordTotal: keywordPage.Total,
+	}, nil
+}
+
+// semanticRecall 做向量召回，返回按距离排序、且已通过可见性与过滤条件的 Echo。
+// 向量索引的元数据不含私密标记与标签，因此命中 ID 必须回到 QueryEchos（EchoIDs 过滤）再裁决一次，
+// 被裁掉的命中不占名次。ok=false 表示本次不做语义召回（未启用或检索失败），调用方回退为仅关键词。
+func (s *SearchService) semanticRecall(
+	ctx context.Context,
+	dto commonModel.EchoSearchDto,
+	candidates int,
+) ([]echoModel.Echo, bool) {
+	if s.embedding == nil || !s.embedding.Enabled(ctx) {
+		return nil, false
+	}
+	results, err := s.embedding.Search(ctx, dto.Query, candidates, dto.Username)
+	if err != nil {
+		logUtil.Warn(
+			"semantic recall failed, falling back to keyword search",
+			slog.String("module", "search"),
+			logUtil.Err(err),
+		)
+		return nil, false
+	}
+	if len(results) == 0 {
+		return nil, true
+	}
+
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.EchoID)
+	}
+	ordered, err := s.visibleInOrder(ctx, ids, commonModel.EchoQueryDto{
+		TagIDs:   dto.TagIDs,
+		DateFrom: dto.DateFrom,
+		DateTo:   dto.DateTo,
+		Private:  dto.Private,
+		UserID:   dto.UserID,
+	})
+	if err != nil {
+		logUtil.Warn(
+			"semantic recall filtering failed, falling back to keyword search",
+			slog.String("module", "search"),
+			logUtil.Err(err),
+		)
+		return nil, false
+	}
+	return ordered, true
+}
+
+// visibleInOrder 让向量命中的 ID 回到 QueryEchos（EchoIDs 过滤 + filter 里的其余条件）裁决可见性，
+// 按 ids 的原顺序返回留下的 Echo。被裁掉的命中直接消失，不占名次。
+func (s *SearchService) visibleInOrder(
+	ctx context.Context,
+	ids []string,
+	filter commonModel.EchoQueryDto,
+) ([]echoModel.Echo, error) {
+	filter.Page = 1
+	filter.PageSize = len(ids)
+	filter.EchoIDs = ids
+	page, err := s.echoService.QueryEchos(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	visible := make(map[string]echoModel.Echo, len(page.Items))
+	for _, e := range page.Items {
+		visible[e.ID] = e
+	}
+	ordered := make([]echoModel.Echo, 0, len(visible))
+	for _, id := range ids {
+		if e, ok := visible[id]; ok {
+			ordered = append(ordered, e)
+		}
+	}
+	return ordered, nil
+}
+
+func rrfScore(rank int) float64 {
+	return 1 / float64(rrfK+rank)
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	embeddingModel "github.com/lin-snow/ech0/internal/model/embedding"
+	embeddingService "github.com/lin-snow/ech0/internal/service/embedding"
+	searchService "github.com/lin-snow/ech0/internal/service/search"
+	echomock "github.com/lin-snow/ech0/internal/test/mocks/echomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stubEmbedding 覆写混合检索用到的 Enabled / Search，其余方法嵌入 nil 接口、未调用即不触发。
+type stubEmbedding struct {
+	embeddingService.Service
+	enabled bool
+	results []embeddingModel.SearchResult
+	err     error
+	gotK    int
+}
+
+func (s *stubEmbedding) Enabled(context.Context) bool { return s.enabled }
+
+func (s *stubEmbedding) Search(_ context.Context, _ string, k int, _ string) ([]embeddingModel.SearchResult, error) {
+	s.gotK = k
+	return s.results, s.err
+}
+
+func page(items ...echoModel.Echo) commonModel.PageQueryResult[[]echoModel.Echo] {
+	return commonModel.PageQueryResult[[]echoModel.Echo]{Items: items, Total: int64(len(items))}
+}
+
+func isKeywordQuery(dto commonModel.EchoQueryDto) bool { return len(dto.EchoIDs) == 0 }
+
+func hitIDs(res echoModel.EchoSearchResult) []string {
+	ids := make([]string, 0, len(res.Items))
+	for _, h := range res.Items {
+		ids = append(ids, h.Echo.ID)
+	}
+	return ids
+}
+
+func TestHybridSearch_EmptyQuery(t *testing.T) {
+	svc := searchService.NewSearchService(echomock.NewMockService(t), &stubEmbedding{})
+	_, err := svc.HybridSearch(context.Background(), commonModel.EchoSearchDto{Query: "  "})
+	require.Error(t, err)
+}
+
+// 两路都命中的 Echo 融合得分最高；只在一路命中的按名次排在后面。
+func TestHybridSearch_FusesWithRRF(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().
+		QueryEchos(mock.Anything, mock.MatchedBy(isKeywordQuery)).
+		Run(func(_ context.Context, dto commonModel.EchoQueryDto) {
+			assert.Equal(t, "go", dto.Search)
+			assert.Equal(t, "relevance", dto.SortBy)
+			assert.Equal(t, 6, dto.PageSize) // limit 2 × 3 倍候选
+		}).
+		Return(page(
+			echoModel.Echo{ID: "kw-only", Content: "learning go"},
+			echoModel.Echo{ID: "both", Content: "go concurrency"},
+		), nil).
+		Once()
+	echoSvc.EXPECT().
+		QueryEchos(mock.Anything, mock.MatchedBy(func(dto commonModel.EchoQueryDto) bool { return !isKeywordQuery(dto) })).
+		Run(func(_ context.Context, dto commonModel.EchoQueryDto) {
+			assert.Equal(t, []string{"both", "sem-only"}, dto.EchoIDs)
+			assert.Empty(t, dto.Search)
+		}).
+		Return(page(
+			echoModel.Echo{ID: "sem-only", Content: "goroutines and channels"},
+			echoModel.Echo{ID: "both", Content: "go concurrency"},
+		), nil).
+		Once()
+	emb := &stubEmbedding{enabled: true, results: []embeddingModel.SearchResult{
+		{EchoID: "both"}, {EchoID: "sem-only"},
+	}}
+
+	svc := searchService.NewSearchService(echoSvc, emb)
+	res, err := svc.HybridSearch(context.Background(), commonModel.EchoSearchDto{Query: "go", Limit: 2})
+	require.NoError(t, err)
+	assert.Equal(t, echoModel.SearchModeHybrid, res.Mode)
+	assert.Equal(t, 6, emb.gotK)
+	require.Equal(t, []string{"both", "kw-only"}, hitIDs(res))
+	assert.Equal(t, 2, res.Items[0].KeywordRank)
+	assert.Equal(t, 1, res.Items[0].SemanticRank)
+	assert.Equal(t, "<mark>go</mark> concurrency", res.Items[0].Highlight)
+	assert.Equal(t, int64(2), res.KeywordTotal)
+}
+
+// 向量命中若在可见性回查中被裁掉（如私密 Echo 对匿名访客），不得出现在结果里。
+func TestHybridSearch_SemanticHitsRespectVisibility(t *testing.T) {
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.MatchedBy(isKeywordQuery)).Return(page(), nil).Once()
+	echoSvc.EXPECT().
+		QueryEchos(mock.Anything, mock.MatchedBy(func(dto commonModel.EchoQueryDto) bool { return !isKeywordQuery(dto) })).
+		Return(page(echoModel.Echo{ID: "public"}), nil).
+		Once()
+	emb := &stubEmbedding{enabled: true, results: []embeddingModel.SearchResult{
+		{EchoID: "private"}, {EchoID: "public"},
+	}}
+
+	svc := searchService.NewSearchService(echoSvc, emb)
+	res, err := svc.HybridSearch(context.Background(), commonModel.EchoSearchDto{Query: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"public"}, hitIDs(res))
+	assert.Equal(t, 1, res.Items[0].SemanticRank)
+}
+
+func TestHybridSearch_FallsBackToKeyword(t *testing.T) {
+	cases := []struct {
+		name string
+		emb  *stubEmbedding
+	}{
+		{"embedding disabled", &stubEmbedding{enabled: false}},
+		{"semantic search fails", &stubEmbedding{enabled: true, err: errors.New("provider down")}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			echoSvc := echomock.NewMockService(t)
+			echoSvc.EXPECT().
+				QueryEchos(mock.Anything, mock.MatchedBy(isKeywordQuery)).
+				Return(page(echoModel.Echo{ID: "e1", Content: "hello"}), nil).
+				Once()
+
+			svc := searchService.NewSearchService(echoSvc, tc.emb)
+			res, err := svc.HybridSearch(context.Background(), commonModel.EchoSearchDto{Query: "hello"})
+			require.NoError(t, err)
+			assert.Equal(t, echoModel.SearchModeKeyword, res.Mode)
+			assert.Equal(t, []string{"e1"}, hitIDs(res))
+			assert.Zero(t, res.Items[0].SemanticRank)
+		})
+	}
+}
+
+func TestHybridSearch_KeywordErrorPropagates(t *testing.T) {
+	boom := errors.New("query failed")
+	echoSvc := echomock.NewMockService(t)
+	echoSvc.EXPECT().QueryEchos(mock.Anything, mock.Anything).Return(page(), boom).Once()
+
+	svc := searchService.NewSearchService(echoSvc, &stubEmbedding{enabled: true})
+	_, err := svc.HybridSearch(context.Background(), commonModel.EchoSearchDto{Query: "x"})
+	require.ErrorIs(t, err, boom)
+}
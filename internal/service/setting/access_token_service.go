@@ -23,15 +23,12 @@ import (
 func (settingService *SettingService) ListAccessTokens(
 	ctx context.Context,
 ) ([]model.AccessTokenSetting, error) {
-	// 鉴权
+	// 鉴权：任何登录用户都只列自己的令牌
 	userid := viewer.MustFromContext(ctx).UserID()
 	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
 	if err != nil {
 		return nil, err
 	}
-	if !user.IsAdmin {
-		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 
 	tokens, err := settingService.settingRepository.ListAccessTokens(ctx, user.ID)
 	if err != nil {
@@ -57,7 +54,7 @@ func (settingService *SettingService) ListAccessTokens(
 	return validTokens, nil
 }
 
-// CreateAccessToken 创建访问令牌
+// CreateAccessToken 创建访问令牌，scope 不得超出调用方角色可授予的范围。
 func (settingService *SettingService) CreateAccessToken(
 	ctx context.Context,
 	newToken *model.AccessTokenSettingDto,
@@ -68,9 +65,6 @@ func (settingService *SettingService) CreateAccessToken(
 	if err != nil {
 		return "", err
 	}
-	if !user.IsAdmin {
-		return "", errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 	if err := validateAccessTokenRequest(user, newToken); err != nil {
 		return "", err
 	}
@@ -152,8 +146,11 @@ func validateAccessTokenRequest(user userModel.User, dto *model.AccessTokenSetti
 			return errors.New(commonModel.INVALID_PARAMS_BODY)
 		}
 	}
-	if authModel.HasAdminScope(dto.Scopes) && !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	role := user.EffectiveRole()
+	for _, scope := range dto.Scopes {
+		if !role.AllowsScope(scope) {
+			return errors.New(commonModel.NO_PERMISSION_DENIED)
+		}
 	}
 	return nil
 }
@@ -186,17 +183,16 @@ func (settingService *SettingService) DeleteAccessToken(ctx context.Context, id
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 
 	// 取出 JTI 与剩余过期时间用于黑名单写入。读失败不阻断删除（兼容历史损坏行）。
-	if settingService.tokenRevoker != nil {
-		token, getErr := settingService.settingRepository.GetAccessTokenByID(ctx, id)
-		if getErr == nil && token.JTI != "" {
-			ttl := remainingTTLForRevoke(token.Expiry)
-			settingService.tokenRevoker.RevokeToken(token.JTI, ttl)
-		}
+	// 他人的令牌只有具备用户管理权限（owner）才能删除。
+	token, getErr := settingService.settingRepository.GetAccessTokenByID(ctx, id)
+	if getErr == nil && token.UserID != user.ID && !user.Can(userModel.PermUserManage) {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+	if settingService.tokenRevoker != nil && getErr == nil && token.JTI != "" {
+		ttl := remainingTTLForRevoke(token.Expiry)
+		settingService.tokenRevoker.RevokeToken(token.JTI, ttl)
 	}
 
 	return settingService.transactor.Run(ctx, func(txCtx context.Context) error {
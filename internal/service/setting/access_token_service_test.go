@@ -62,3 +62,37 @@ func TestCreateAccessToken_RejectsAdminScopeForNonAdminUser(t *testing.T) {
 		t.Fatal("expected error for admin scope on non-admin user")
 	}
 }
+
+func TestCreateAccessToken_ScopesIntersectRole(t *testing.T) {
+	cases := []struct {
+		role  userModel.Role
+		scope string
+		ok    bool
+	}{
+		{userModel.RoleReader, authModel.ScopeEchoRead, true},
+		{userModel.RoleReader, authModel.ScopeEchoWrite, false},
+		{userModel.RoleAuthor, authModel.ScopeEchoWrite, true},
+		{userModel.RoleAuthor, authModel.ScopeCommentMod, false},
+		{userModel.RoleCommentModerator, authModel.ScopeCommentMod, true},
+		{userModel.RoleEditor, authModel.ScopeAdminSettings, true},
+		{userModel.RoleEditor, authModel.ScopeAdminUser, false},
+		{userModel.RoleOwner, authModel.ScopeAdminUser, true},
+	}
+	for _, tc := range cases {
+		var user userModel.User
+		user.SetRole(tc.role)
+		dto := &model.AccessTokenSettingDto{
+			Name:     "scoped",
+			Expiry:   model.EIGHT_HOUR_EXPIRY,
+			Scopes:   []string{tc.scope},
+			Audience: authModel.AudiencePublic,
+		}
+		err := validateAccessTokenRequest(user, dto)
+		if tc.ok && err != nil {
+			t.Fatalf("%s should be able to grant %s, got error: %v", tc.role, tc.scope, err)
+		}
+		if !tc.ok && err == nil {
+			t.Fatalf("%s must not be able to grant %s", tc.role, tc.scope)
+		}
+	}
+}
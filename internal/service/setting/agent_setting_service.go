@@ -11,6 +11,7 @@ import (
 	"github.com/lin-snow/ech0/internal/agent"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 	"github.com/lin-snow/ech0/pkg/viewer"
@@ -49,7 +50,7 @@ func (settingService *SettingService) GetAgentSettings(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -72,7 +73,7 @@ func (settingService *SettingService) UpdateAgentSettings(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -101,7 +102,7 @@ func (settingService *SettingService) TestAgentConnection(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -10,6 +10,7 @@ import (
 
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/pkg/viewer"
 )
@@ -35,12 +36,22 @@ func (settingService *SettingService) UpdateEmbeddingSetting(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
+	// provider 留空视为 OpenAI 兼容（兼容升级前的前端）；本地向量化的模型名与默认维度
+	// 由 setting 引擎的 Normalize 统一补齐。
+	provider := strings.TrimSpace(dto.Provider)
+	switch commonModel.EmbeddingProvider(provider) {
+	case "", commonModel.EmbeddingOpenAI, commonModel.EmbeddingLocal:
+	default:
+		return errors.New(commonModel.EMBEDDING_PROVIDER_NOT_FOUND)
+	}
+
 	setting := model.EmbeddingSetting{
 		Enable:    dto.Enable,
+		Provider:  provider,
 		Model:     strings.TrimSpace(dto.Model),
 		ApiKey:    strings.TrimSpace(dto.ApiKey),
 		BaseURL:   strings.TrimSpace(dto.BaseURL),
@@ -9,6 +9,7 @@ import (
 
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 	"github.com/lin-snow/ech0/pkg/viewer"
@@ -25,7 +26,7 @@ func (settingService *SettingService) GetOAuth2Setting(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -47,7 +48,7 @@ func (settingService *SettingService) UpdateOAuth2Setting(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -31,6 +31,11 @@ type Service interface {
 	UpdateWebhook(ctx context.Context, id string, newWebhook *model.WebhookDto) error
 	CreateWebhook(ctx context.Context, newWebhook *model.WebhookDto) error
 	TestWebhook(ctx context.Context, id string) error
+	ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]webhookModel.WebhookDelivery, error)
+	RedeliverWebhook(ctx context.Context, webhookID, deliveryID string) (*webhookModel.WebhookDelivery, error)
+	ListWebhookDeadLetters(ctx context.Context, webhookID string, limit int) ([]webhookModel.WebhookOutbox, error)
+	RetryWebhookDeadLetter(ctx context.Context, webhookID, id string) error
+	PurgeWebhookDeadLetters(ctx context.Context, webhookID string) (int64, error)
 	ListAccessTokens(ctx context.Context) ([]model.AccessTokenSetting, error)
 	CreateAccessToken(ctx context.Context, newToken *model.AccessTokenSettingDto) (string, error)
 	DeleteAccessToken(ctx context.Context, id string) error
@@ -69,4 +74,11 @@ type WebhookRepository interface {
 	UpdateWebhookByID(ctx context.Context, id string, webhook *webhookModel.Webhook) error
 	UpdateWebhookDeliveryStatus(ctx context.Context, id string, status string, lastTrigger int64) error
 	DeleteWebhookByID(ctx context.Context, id string) error
+	CreateWebhookDeliveries(ctx context.Context, deliveries []webhookModel.WebhookDelivery) error
+	ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]webhookModel.WebhookDelivery, error)
+	GetWebhookDeliveryByID(ctx context.Context, id string) (*webhookModel.WebhookDelivery, error)
+	PruneWebhookDeliveries(ctx context.Context, webhookID string, keep int) error
+	ListWebhookDeadLetters(ctx context.Context, webhookID string, limit int) ([]webhookModel.WebhookOutbox, error)
+	RequeueWebhookDeadLetter(ctx context.Context, webhookID, id string, now int64) (bool, error)
+	PurgeWebhookDeadLetters(ctx context.Context, webhookID string) (int64, error)
 }
@@ -11,6 +11,7 @@ import (
 
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 	"github.com/lin-snow/ech0/pkg/viewer"
@@ -38,7 +39,7 @@ func (settingService *SettingService) GetS3Setting(ctx context.Context, setting
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		maskS3Secrets(setting)
 	}
 	return nil
@@ -61,7 +62,7 @@ func (settingService *SettingService) UpdateS3Setting(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -108,7 +109,7 @@ func (settingService *SettingService) TestS3Connection(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 	if settingService.storageManager == nil {
@@ -5,6 +5,7 @@ package service_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	authModel "github.com/lin-snow/ech0/internal/model/auth"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 	settingService "github.com/lin-snow/ech0/internal/service/setting"
 	"github.com/lin-snow/ech0/internal/test/helpers"
@@ -24,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	mock "github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 )
 
 // deps 聚合 SettingService 的协作者 mock，便于按需设置期望后 build。
@@ -102,16 +105,24 @@ func TestSettingService_NonAdminDenied(t *testing.T) {
 		"TestWebhook": func(svc *settingService.SettingService) error {
 			return svc.TestWebhook(ctx, "id-1")
 		},
-		"ListAccessTokens": func(svc *settingService.SettingService) error {
-			_, err := svc.ListAccessTokens(ctx)
+		"ListWebhookDeliveries": func(svc *settingService.SettingService) error {
+			_, err := svc.ListWebhookDeliveries(ctx, "id-1", 0)
 			return err
 		},
-		"CreateAccessToken": func(svc *settingService.SettingService) error {
-			_, err := svc.CreateAccessToken(ctx, &settingModel.AccessTokenSettingDto{Name: "t"})
+		"RedeliverWebhook": func(svc *settingService.SettingService) error {
+			_, err := svc.RedeliverWebhook(ctx, "id-1", "d-1")
 			return err
 		},
-		"DeleteAccessToken": func(svc *settingService.SettingService) error {
-			return svc.DeleteAccessToken(ctx, "id-1")
+		"ListWebhookDeadLetters": func(svc *settingService.SettingService) error {
+			_, err := svc.ListWebhookDeadLetters(ctx, "id-1", 0)
+			return err
+		},
+		"RetryWebhookDeadLetter": func(svc *settingService.SettingService) error {
+			return svc.RetryWebhookDeadLetter(ctx, "id-1", "o-1")
+		},
+		"PurgeWebhookDeadLetters": func(svc *settingService.SettingService) error {
+			_, err := svc.PurgeWebhookDeadLetters(ctx, "id-1")
+			return err
 		},
 		"UpdateS3Setting": func(svc *settingService.SettingService) error {
 			return svc.UpdateS3Setting(ctx, &settingModel.S3SettingDto{})
@@ -148,18 +159,25 @@ func TestSettingService_NonAdminDenied(t *testing.T) {
 		},
 	}
 
+	// 权限按角色判定：角色缺少 system:manage 时，遗留的 IsAdmin 标记不放行。
+	callers := map[string]func(*userModel.User){
+		"reader":            func(*userModel.User) {},
+		"author with admin": func(u *userModel.User) { u.Role = userModel.RoleAuthor; u.IsAdmin = true },
+	}
 	for name, call := range calls {
-		t.Run(name, func(t *testing.T) {
-			d := newDeps(t)
-			d.common.EXPECT().
-				CommonGetUserByUserId(mock.Anything, mock.Anything).
-				Return(helpers.NewUser(), nil). // 普通用户，非管理员
-				Once()
-
-			err := call(d.build())
-			require.Error(t, err)
-			assert.Equal(t, commonModel.NO_PERMISSION_DENIED, err.Error())
-		})
+		for who, as := range callers {
+			t.Run(name+"/"+who, func(t *testing.T) {
+				d := newDeps(t)
+				d.common.EXPECT().
+					CommonGetUserByUserId(mock.Anything, mock.Anything).
+					Return(helpers.NewUser(as), nil).
+					Once()
+
+				err := call(d.build())
+				require.Error(t, err)
+				assert.Equal(t, commonModel.NO_PERMISSION_DENIED, err.Error())
+			})
+		}
 	}
 }
 
@@ -196,6 +214,48 @@ func TestCreateWebhook(t *testing.T) {
 		assert.Equal(t, commonModel.INVALID_WEBHOOK_URL, err.Error())
 	})
 
+	t.Run("unknown topic rejected", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		err := d.build().CreateWebhook(ctx, &settingModel.WebhookDto{
+			Name: "hook", URL: "https://hooks.example.com/path", Topics: []string{"echo.created", "echo.liked"},
+		})
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_WEBHOOK_TOPIC, err.Error())
+	})
+
+	t.Run("invalid template rejected", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		err := d.build().CreateWebhook(ctx, &settingModel.WebhookDto{
+			Name: "hook", URL: "https://hooks.example.com/path", Template: webhookModel.TemplateCustom,
+		})
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_WEBHOOK_TEMPLATE, err.Error())
+	})
+
+	t.Run("topics deduplicated and preset drops template body", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
+		d.webhookRepo.EXPECT().
+			CreateWebhook(mock.Anything, mock.MatchedBy(func(w *webhookModel.Webhook) bool {
+				return len(w.Topics) == 1 && w.Topics[0] == "comment.created" &&
+					w.Template == webhookModel.TemplateDiscord && w.TemplateBody == ""
+			})).
+			Return(nil).
+			Once()
+
+		err := d.build().CreateWebhook(ctx, &settingModel.WebhookDto{
+			Name:         "mod-bot",
+			URL:          "https://hooks.example.com/path",
+			Topics:       []string{" comment.created", "comment.created", ""},
+			Template:     webhookModel.TemplateDiscord,
+			TemplateBody: `{"stale":true}`,
+		})
+		require.NoError(t, err)
+	})
+
 	t.Run("valid webhook persisted via transaction", func(t *testing.T) {
 		d := newDeps(t)
 		d.expectAdmin()
@@ -244,7 +304,7 @@ func TestDeleteAccessToken_RevokesJTI(t *testing.T) {
 		future := time.Now().UTC().Add(3 * time.Hour).Unix()
 		d.settingRepo.EXPECT().
 			GetAccessTokenByID(mock.Anything, "tok-1").
-			Return(settingModel.AccessTokenSetting{JTI: "jti-1", Expiry: &future}, nil).
+			Return(settingModel.AccessTokenSetting{UserID: helpers.NewUser().ID, JTI: "jti-1", Expiry: &future}, nil).
 			Once()
 		d.revoker.EXPECT().
 			RevokeToken("jti-1", mock.MatchedBy(func(ttl time.Duration) bool { return ttl > 0 })).
@@ -274,13 +334,26 @@ func TestDeleteAccessToken_RevokesJTI(t *testing.T) {
 		d.expectAdmin()
 		d.settingRepo.EXPECT().
 			GetAccessTokenByID(mock.Anything, "tok-3").
-			Return(settingModel.AccessTokenSetting{JTI: ""}, nil).
+			Return(settingModel.AccessTokenSetting{UserID: helpers.NewUser().ID, JTI: ""}, nil).
 			Once()
 		d.tx.EXPECT().Run(mock.Anything, mock.Anything).RunAndReturn(runTxExec()).Once()
 		d.settingRepo.EXPECT().DeleteAccessTokenByID(mock.Anything, "tok-3").Return(nil).Once()
 
 		require.NoError(t, d.build().DeleteAccessToken(ctx, "tok-3"))
 	})
+
+	t.Run("someone else's token is denied without user management", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.settingRepo.EXPECT().
+			GetAccessTokenByID(mock.Anything, "tok-4").
+			Return(settingModel.AccessTokenSetting{UserID: "someone-else", JTI: "jti-4"}, nil).
+			Once()
+		// 既不拉黑也不删除（无期望 => 调用即失败）。
+
+		err := d.build().DeleteAccessToken(ctx, "tok-4")
+		require.EqualError(t, err, commonModel.NO_PERMISSION_DENIED)
+	})
 }
 
 func TestListAccessTokens(t *testing.T) {
@@ -425,6 +498,39 @@ func TestUpdateEmbeddingSetting_Persists(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// TestUpdateEmbeddingSetting_LocalProvider 本地向量化固定模型名、补齐默认维度，不需要 API Key。
+func TestUpdateEmbeddingSetting_LocalProvider(t *testing.T) {
+	d := newDeps(t)
+	d.expectAdmin()
+	var saved string
+	d.kv.EXPECT().
+		Set(mock.Anything, commonModel.EmbeddingSettingKey, mock.Anything).
+		Run(func(_ context.Context, _ string, value string) { saved = value }).
+		Return(nil).
+		Once()
+
+	err := d.build().UpdateEmbeddingSetting(helpers.CtxAsUser(testUserID), settingModel.EmbeddingSettingDto{
+		Enable: true, Provider: " local ", Model: "text-embedding-3-small",
+	})
+	require.NoError(t, err)
+
+	var got settingModel.EmbeddingSetting
+	require.NoError(t, json.Unmarshal([]byte(saved), &got))
+	assert.Equal(t, string(commonModel.EmbeddingLocal), got.Provider)
+	assert.Equal(t, settingModel.EmbeddingLocalModel, got.Model)
+	assert.Equal(t, settingModel.EmbeddingLocalDefaultDim, got.Dim)
+}
+
+func TestUpdateEmbeddingSetting_RejectsUnknownProvider(t *testing.T) {
+	d := newDeps(t)
+	d.expectAdmin()
+
+	err := d.build().UpdateEmbeddingSetting(helpers.CtxAsUser(testUserID), settingModel.EmbeddingSettingDto{
+		Enable: true, Provider: "onnx",
+	})
+	require.EqualError(t, err, commonModel.EMBEDDING_PROVIDER_NOT_FOUND)
+}
+
 // TestUpdateS3Setting_PersistsWhenStorageNil 在 storageManager 为 nil 时跳过应用、仅落库。
 func TestUpdateS3Setting_PersistsWhenStorageNil(t *testing.T) {
 	d := newDeps(t)
@@ -442,3 +548,82 @@ func TestUpdateS3Setting_PersistsWhenStorageNil(t *testing.T) {
 	})
 	require.NoError(t, err)
 }
+
+func TestListWebhookDeliveries_ClampsLimit(t *testing.T) {
+	ctx := helpers.CtxAsUser(testUserID)
+	tests := []struct{ in, want int }{{0, 20}, {-1, 20}, {5, 5}, {500, 100}}
+	for _, tc := range tests {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.webhookRepo.EXPECT().ListWebhookDeliveries(mock.Anything, "wh-1", tc.want).Return(nil, nil).Once()
+		_, err := d.build().ListWebhookDeliveries(ctx, "wh-1", tc.in)
+		require.NoError(t, err)
+	}
+}
+
+func TestRetryWebhookDeadLetter(t *testing.T) {
+	ctx := helpers.CtxAsUser(testUserID)
+
+	t.Run("not found", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.webhookRepo.EXPECT().
+			RequeueWebhookDeadLetter(mock.Anything, "wh-1", "o-1", mock.AnythingOfType("int64")).
+			Return(false, nil).
+			Once()
+		err := d.build().RetryWebhookDeadLetter(ctx, "wh-1", "o-1")
+		require.Error(t, err)
+		assert.Equal(t, commonModel.WEBHOOK_DEAD_LETTER_NOT_FOUND, err.Error())
+	})
+
+	t.Run("requeued", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.webhookRepo.EXPECT().
+			RequeueWebhookDeadLetter(mock.Anything, "wh-1", "o-1", mock.AnythingOfType("int64")).
+			Return(true, nil).
+			Once()
+		require.NoError(t, d.build().RetryWebhookDeadLetter(ctx, "wh-1", "o-1"))
+	})
+}
+
+func TestRedeliverWebhook_DeliveryMustBelongToWebhook(t *testing.T) {
+	ctx := helpers.CtxAsUser(testUserID)
+
+	t.Run("missing delivery", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.webhookRepo.EXPECT().GetWebhookDeliveryByID(mock.Anything, "d-1").Return(nil, gorm.ErrRecordNotFound).Once()
+		_, err := d.build().RedeliverWebhook(ctx, "wh-1", "d-1")
+		require.Error(t, err)
+		assert.Equal(t, commonModel.WEBHOOK_DELIVERY_NOT_FOUND, err.Error())
+	})
+
+	t.Run("delivery of another webhook", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.webhookRepo.EXPECT().
+			GetWebhookDeliveryByID(mock.Anything, "d-1").
+			Return(&webhookModel.WebhookDelivery{ID: "d-1", WebhookID: "wh-2"}, nil).
+			Once()
+		_, err := d.build().RedeliverWebhook(ctx, "wh-1", "d-1")
+		require.Error(t, err)
+		assert.Equal(t, commonModel.WEBHOOK_DELIVERY_NOT_FOUND, err.Error())
+	})
+
+	t.Run("unsafe url rejected before sending", func(t *testing.T) {
+		d := newDeps(t)
+		d.expectAdmin()
+		d.webhookRepo.EXPECT().
+			GetWebhookDeliveryByID(mock.Anything, "d-1").
+			Return(&webhookModel.WebhookDelivery{ID: "d-1", WebhookID: "wh-1"}, nil).
+			Once()
+		d.webhookRepo.EXPECT().
+			GetWebhookByID(mock.Anything, "wh-1").
+			Return(&webhookModel.Webhook{ID: "wh-1", URL: "http://127.0.0.1/x"}, nil).
+			Once()
+		_, err := d.build().RedeliverWebhook(ctx, "wh-1", "d-1")
+		require.Error(t, err)
+		assert.Equal(t, commonModel.INVALID_WEBHOOK_URL, err.Error())
+	})
+}
@@ -11,6 +11,7 @@ import (
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	fmtUtil "github.com/lin-snow/ech0/internal/util/format"
 	"github.com/lin-snow/ech0/pkg/viewer"
@@ -39,7 +40,7 @@ func (settingService *SettingService) UpdateSnapshotScheduleSetting(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -11,6 +11,7 @@ import (
 	i18nUtil "github.com/lin-snow/ech0/internal/i18n"
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
@@ -76,7 +77,7 @@ func (settingService *SettingService) UpdateSetting(
 		if err != nil {
 			return err
 		}
-		if !user.IsAdmin {
+		if !user.Can(userModel.PermSystemManage) {
 			return errors.New(commonModel.NO_PERMISSION_DENIED)
 		}
 
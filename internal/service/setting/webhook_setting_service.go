@@ -6,14 +6,25 @@ package service
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"time"
 
 	commonModel "github.com/lin-snow/ech0/internal/model/common"
 	model "github.com/lin-snow/ech0/internal/model/setting"
+	userModel "github.com/lin-snow/ech0/internal/model/user"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
 	"github.com/lin-snow/ech0/internal/util/egress"
 	urlUtil "github.com/lin-snow/ech0/internal/util/url"
+	webhookclient "github.com/lin-snow/ech0/internal/webhook"
 	"github.com/lin-snow/ech0/pkg/viewer"
+	"gorm.io/gorm"
+)
+
+// 投递记录列表的分页大小。
+const (
+	defaultDeliveryPageSize = 20
+	maxDeliveryPageSize     = 100
 )
 
 // GetAllWebhooks 获取所有 Webhook
@@ -24,7 +35,7 @@ func (settingService *SettingService) GetAllWebhooks(ctx context.Context) ([]web
 	if err != nil {
 		return nil, err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -44,7 +55,7 @@ func (settingService *SettingService) DeleteWebhook(ctx context.Context, id stri
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -65,7 +76,7 @@ func (settingService *SettingService) UpdateWebhook(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -79,13 +90,27 @@ func (settingService *SettingService) UpdateWebhook(
 	if err := validateWebhookURL(newWebhook.URL); err != nil {
 		return err
 	}
+	topics, err := normalizeWebhookTopics(newWebhook.Topics)
+	if err != nil {
+		return err
+	}
+	if err := webhookclient.ValidateTemplate(newWebhook.Template, newWebhook.TemplateBody); err != nil {
+		return errors.New(commonModel.INVALID_WEBHOOK_TEMPLATE)
+	}
+	templateBody := ""
+	if newWebhook.Template == webhookModel.TemplateCustom {
+		templateBody = newWebhook.TemplateBody
+	}
 
 	// 保存到数据库
 	webhook := &webhookModel.Webhook{
-		Name:     newWebhook.Name,
-		URL:      newWebhook.URL,
-		Secret:   newWebhook.Secret,
-		IsActive: newWebhook.IsActive,
+		Name:         newWebhook.Name,
+		URL:          newWebhook.URL,
+		Secret:       newWebhook.Secret,
+		IsActive:     newWebhook.IsActive,
+		Topics:       topics,
+		Template:     newWebhook.Template,
+		TemplateBody: templateBody,
 	}
 
 	return settingService.transactor.Run(ctx, func(ctx context.Context) error {
@@ -104,7 +129,7 @@ func (settingService *SettingService) CreateWebhook(
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -118,13 +143,27 @@ func (settingService *SettingService) CreateWebhook(
 	if err := validateWebhookURL(newWebhook.URL); err != nil {
 		return err
 	}
+	topics, err := normalizeWebhookTopics(newWebhook.Topics)
+	if err != nil {
+		return err
+	}
+	if err := webhookclient.ValidateTemplate(newWebhook.Template, newWebhook.TemplateBody); err != nil {
+		return errors.New(commonModel.INVALID_WEBHOOK_TEMPLATE)
+	}
+	templateBody := ""
+	if newWebhook.Template == webhookModel.TemplateCustom {
+		templateBody = newWebhook.TemplateBody
+	}
 
 	// 保存到数据库
 	webhook := &webhookModel.Webhook{
-		Name:     newWebhook.Name,
-		URL:      newWebhook.URL,
-		Secret:   newWebhook.Secret,
-		IsActive: newWebhook.IsActive,
+		Name:         newWebhook.Name,
+		URL:          newWebhook.URL,
+		Secret:       newWebhook.Secret,
+		IsActive:     newWebhook.IsActive,
+		Topics:       topics,
+		Template:     newWebhook.Template,
+		TemplateBody: templateBody,
 	}
 
 	return settingService.transactor.Run(ctx, func(ctx context.Context) error {
@@ -140,7 +179,7 @@ func (settingService *SettingService) TestWebhook(ctx context.Context, id string
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
+	if !user.Can(userModel.PermSystemManage) {
 		return errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -153,7 +192,8 @@ func (settingService *SettingService) TestWebhook(ctx context.Context, id string
 	}
 
 	triggerAt := time.Now().UTC().Unix()
-	sendErr := settingService.webhookSender.SendTest(webhook)
+	attempts, sendErr := settingService.webhookSender.SendTest(webhook)
+	webhookclient.RecordDeliveries(ctx, settingService.webhookRepository, webhook.ID, attempts)
 	status := "success"
 	if sendErr != nil {
 		status = "failed"
@@ -162,6 +202,151 @@ func (settingService *SettingService) TestWebhook(ctx context.Context, id string
 	return sendErr
 }
 
+// ListWebhookDeliveries 按时间倒序列出 Webhook 最近的投递记录
+func (settingService *SettingService) ListWebhookDeliveries(
+	ctx context.Context,
+	webhookID string,
+	limit int,
+) ([]webhookModel.WebhookDelivery, error) {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Can(userModel.PermSystemManage) {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveryPageSize
+	}
+	limit = min(limit, maxDeliveryPageSize)
+	return settingService.webhookRepository.ListWebhookDeliveries(ctx, webhookID, limit)
+}
+
+// RedeliverWebhook 用投递记录里保存的原始事件重新投递一次，返回本次最后一次尝试的记录。
+// 对端仍然失败不算错误：结果体现在返回记录的 success / error 上，与正常投递一样写入投递日志。
+func (settingService *SettingService) RedeliverWebhook(
+	ctx context.Context,
+	webhookID string,
+	deliveryID string,
+) (*webhookModel.WebhookDelivery, error) {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Can(userModel.PermSystemManage) {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	original, err := settingService.webhookRepository.GetWebhookDeliveryByID(ctx, deliveryID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && original.WebhookID != webhookID) {
+		return nil, errors.New(commonModel.WEBHOOK_DELIVERY_NOT_FOUND)
+	}
+	if err != nil {
+		return nil, err
+	}
+	webhook, err := settingService.webhookRepository.GetWebhookByID(ctx, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateWebhookURL(webhook.URL); err != nil {
+		return nil, err
+	}
+
+	triggerAt := time.Now().UTC().Unix()
+	attempts, sendErr := settingService.webhookSender.Redeliver(webhook, original)
+	if len(attempts) == 0 {
+		return nil, sendErr
+	}
+	webhookclient.RecordDeliveries(ctx, settingService.webhookRepository, webhook.ID, attempts)
+	status := "success"
+	if sendErr != nil {
+		status = "failed"
+	}
+	_ = settingService.webhookRepository.UpdateWebhookDeliveryStatus(ctx, webhook.ID, status, triggerAt)
+	return &attempts[len(attempts)-1], nil
+}
+
+// ListWebhookDeadLetters 列出 webhook 的死信（重试次数用尽仍未投递成功的事件），最新在前。
+func (settingService *SettingService) ListWebhookDeadLetters(
+	ctx context.Context,
+	webhookID string,
+	limit int,
+) ([]webhookModel.WebhookOutbox, error) {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Can(userModel.PermSystemManage) {
+		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	if limit <= 0 {
+		limit = defaultDeliveryPageSize
+	}
+	limit = min(limit, maxDeliveryPageSize)
+	return settingService.webhookRepository.ListWebhookDeadLetters(ctx, webhookID, limit)
+}
+
+// RetryWebhookDeadLetter 把一条死信放回投递队列，重置尝试次数，由 outbox worker 稍后投递。
+func (settingService *SettingService) RetryWebhookDeadLetter(ctx context.Context, webhookID, id string) error {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return err
+	}
+	if !user.Can(userModel.PermSystemManage) {
+		return errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	found, err := settingService.webhookRepository.RequeueWebhookDeadLetter(ctx, webhookID, id, time.Now().UTC().Unix())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errors.New(commonModel.WEBHOOK_DEAD_LETTER_NOT_FOUND)
+	}
+	return nil
+}
+
+// PurgeWebhookDeadLetters 删除 webhook 的全部死信，返回删除条数。
+func (settingService *SettingService) PurgeWebhookDeadLetters(ctx context.Context, webhookID string) (int64, error) {
+	// 鉴权
+	userid := viewer.MustFromContext(ctx).UserID()
+	user, err := settingService.commonService.CommonGetUserByUserId(ctx, userid)
+	if err != nil {
+		return 0, err
+	}
+	if !user.Can(userModel.PermSystemManage) {
+		return 0, errors.New(commonModel.NO_PERMISSION_DENIED)
+	}
+
+	return settingService.webhookRepository.PurgeWebhookDeadLetters(ctx, webhookID)
+}
+
+// normalizeWebhookTopics 去掉空白与重复项，并拒绝不存在的 topic。返回 nil 表示订阅全部事件。
+func normalizeWebhookTopics(topics []string) ([]string, error) {
+	var out []string
+	for _, topic := range topics {
+		topic = strings.TrimSpace(topic)
+		if topic == "" || slices.Contains(out, topic) {
+			continue
+		}
+		if !slices.Contains(webhookclient.Topics, topic) {
+			return nil, errors.New(commonModel.INVALID_WEBHOOK_TOPIC)
+		}
+		out = append(out, topic)
+	}
+	return out, nil
+}
+
 func validateWebhookURL(rawURL string) error {
 	if err := egress.Validate(rawURL); err != nil {
 		return errors.New(commonModel.INVALID_WEBHOOK_URL)
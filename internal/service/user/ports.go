@@ -16,6 +16,7 @@ type Service interface {
 	Register(registerDto *authModel.RegisterDto) error
 	UpdateUser(ctx context.Context, userdto model.UserInfoDto) error
 	UpdateUserAdmin(ctx context.Context, id string) error
+	UpdateUserRole(ctx context.Context, id string, role model.Role) error
 	GetAllUsers(ctx context.Context) ([]model.User, error)
 	GetOwner() (model.User, error)
 	DeleteUser(ctx context.Context, id string) error
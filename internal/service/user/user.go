@@ -128,10 +128,9 @@ func (userService *UserService) InitOwner(registerDto *authModel.RegisterDto) er
 		owner = model.User{
 			Username: registerDto.Username,
 			Email:    email,
-			IsAdmin:  true,
-			IsOwner:  true,
 			Locale:   ownerLocale,
 		}
+		owner.SetRole(model.RoleOwner)
 
 		if err := userService.userRepository.CreateUser(ctx, &owner); err != nil {
 			return err
@@ -144,14 +143,16 @@ func (userService *UserService) InitOwner(registerDto *authModel.RegisterDto) er
 			return err
 		}
 
-		return userService.userRepository.MarkInitialized(ctx)
+		if err := userService.userRepository.MarkInitialized(ctx); err != nil {
+			return err
+		}
+
+		// 发布用户注册事件（站点默认语言由 InitService 在编排层经 SettingService 落库，不在此处）。
+		return eventbus.NotifyTx(ctx, userService.bus, event.UserCreated{User: owner})
 	}); err != nil {
 		return err
 	}
 
-	// 发布用户注册事件（站点默认语言由 InitService 在编排层经 SettingService 落库，不在此处）。
-	eventbus.Notify(context.Background(), userService.bus, event.UserCreated{User: owner})
-
 	return nil
 }
 
@@ -199,10 +200,10 @@ func (userService *UserService) Register(registerDto *authModel.RegisterDto) err
 	newUser := model.User{
 		Username: registerDto.Username,
 		Email:    email,
-		IsAdmin:  false,
-		IsOwner:  false,
 		Locale:   string(commonModel.DefaultLocale),
 	}
+	// 新注册用户只读，由 owner 在用户管理中按需调整角色
+	newUser.SetRole(model.RoleReader)
 
 	// 检查用户是否已经存在
 	user, err := userService.userRepository.GetUserByUsername(context.Background(), newUser.Username)
@@ -222,40 +223,39 @@ func (userService *UserService) Register(registerDto *authModel.RegisterDto) err
 		if err := userService.userRepository.CreateUser(ctx, &newUser); err != nil {
 			return err
 		}
-		return userService.userRepository.UpsertLocalAuth(ctx, &model.UserLocalAuth{
+		if err := userService.userRepository.UpsertLocalAuth(ctx, &model.UserLocalAuth{
 			UserID:       newUser.ID,
 			PasswordHash: passwordHash,
 			PasswordAlgo: cryptoUtil.AlgoBcrypt,
-		})
+		}); err != nil {
+			return err
+		}
+
+		// 发布用户注册事件
+		return eventbus.NotifyTx(ctx, userService.bus, event.UserCreated{User: newUser})
 	}); err != nil {
 		return err
 	}
 
-	// 发布用户注册事件
-	eventbus.Notify(context.Background(), userService.bus, event.UserCreated{User: newUser})
-
 	return nil
 }
 
 // UpdateUser 更新用户信息
-// 只有管理员可以更新用户信息，支持更新用户名、密码和头像
+// 更新当前用户自己的资料，支持更新用户名、密码、头像、邮箱与语言
 //
 // 参数:
-//   - userid: 执行更新操作的用户ID（必须为管理员）
+//   - userid: 执行更新操作的用户ID（即被更新的用户）
 //   - userdto: 用户信息数据传输对象，包含要更新的用户信息
 //
 // 返回:
 //   - error: 更新过程中的错误信息
 func (userService *UserService) UpdateUser(ctx context.Context, userdto model.UserInfoDto) error {
 	userid := viewer.MustFromContext(ctx).UserID()
-	// 检查执行操作的用户是否为管理员
+	// 任何角色都能维护自己的资料（作者、读者同样需要头像与昵称）
 	user, err := userService.userRepository.GetUserByID(ctx, userid)
 	if err != nil {
 		return err
 	}
-	if !user.IsAdmin {
-		return errors.New(commonModel.NO_PERMISSION_DENIED)
-	}
 
 	// 检查是否需要更新用户名
 	if userdto.Username != "" && userdto.Username != user.Username {
@@ -303,13 +303,17 @@ func (userService *UserService) UpdateUser(ctx context.Context, userdto model.Us
 		}
 		// 如有改密，写入 user_local_auth
 		if newPasswordHash != "" {
-			return userService.userRepository.UpsertLocalAuth(txCtx, &model.UserLocalAuth{
+			if err := userService.userRepository.UpsertLocalAuth(txCtx, &model.UserLocalAuth{
 				UserID:       user.ID,
 				PasswordHash: newPasswordHash,
 				PasswordAlgo: cryptoUtil.AlgoBcrypt,
-			})
+			}); err != nil {
+				return err
+			}
 		}
-		return nil
+
+		// 发布用户更新事件
+		return eventbus.NotifyTx(txCtx, userService.bus, event.UserUpdated{User: user})
 	}); err != nil {
 		return err
 	}
@@ -319,29 +323,58 @@ func (userService *UserService) UpdateUser(ctx context.Context, userdto model.Us
 		}
 	}
 
-	// 发布用户更新事件
-	eventbus.Notify(context.Background(), userService.bus, event.UserUpdated{User: user})
-
 	return nil
 }
 
-// UpdateUserAdmin 更新用户的管理员权限
-// 只有 Owner 可以修改其他用户的管理员权限，不能修改自己和 Owner 的权限
+// UpdateUserAdmin 切换用户的管理员权限：非管理员提升为 editor，管理员降为 reader。
+// 保留给旧版前端与脚本，新调用方请用 UpdateUserRole。
+// 只有 Owner 可以修改其他用户的权限，不能修改自己和 Owner 的权限
 //
 // 参数:
-//   - userid: 执行操作的用户ID（必须为管理员）
+//   - userid: 执行操作的用户ID（必须为 Owner）
 //   - id: 要修改权限的用户ID
 //
 // 返回:
 //   - error: 更新过程中的错误信息
 func (userService *UserService) UpdateUserAdmin(ctx context.Context, id string) error {
+	return userService.changeUserRole(ctx, id, func(user model.User) model.Role {
+		if user.Can(model.PermSystemManage) {
+			return model.RoleReader
+		}
+		return model.RoleEditor
+	})
+}
+
+// UpdateUserRole 设置用户角色。
+// 只有 Owner 可以调整角色，不能修改自己和 Owner，也不能把他人设为 Owner（Owner 唯一）。
+//
+// 参数:
+//   - id: 要修改角色的用户ID
+//   - role: 目标角色
+//
+// 返回:
+//   - error: 更新过程中的错误信息
+func (userService *UserService) UpdateUserRole(ctx context.Context, id string, role model.Role) error {
+	if !model.IsValidRole(role) || role == model.RoleOwner {
+		return errors.New(commonModel.INVALID_USER_ROLE)
+	}
+	return userService.changeUserRole(ctx, id, func(model.User) model.Role { return role })
+}
+
+// changeUserRole 是角色调整的公共路径：校验操作者为 Owner、目标不是自己或 Owner，
+// 再按 next 算出的新角色落库并发布 UserUpdated。
+func (userService *UserService) changeUserRole(
+	ctx context.Context,
+	id string,
+	next func(user model.User) model.Role,
+) error {
 	userid := viewer.MustFromContext(ctx).UserID()
 	// 检查执行操作的用户是否为 Owner
 	operator, err := userService.userRepository.GetUserByID(ctx, userid)
 	if err != nil {
 		return err
 	}
-	if !operator.IsOwner {
+	if !operator.Can(model.PermUserManage) {
 		return errors.New(commonModel.ONLY_OWNER_CAN_MANAGE)
 	}
 
@@ -356,18 +389,20 @@ func (userService *UserService) UpdateUserAdmin(ctx context.Context, id string)
 		return errors.New(commonModel.INVALID_PARAMS_BODY)
 	}
 
-	user.IsAdmin = !user.IsAdmin
+	user.SetRole(next(user))
 
 	if err := userService.transactor.Run(ctx, func(txCtx context.Context) error {
 		// 更新用户信息
-		return userService.userRepository.UpdateUser(txCtx, &user)
+		if err := userService.userRepository.UpdateUser(txCtx, &user); err != nil {
+			return err
+		}
+
+		// 发布用户更新事件
+		return eventbus.NotifyTx(txCtx, userService.bus, event.UserUpdated{User: user})
 	}); err != nil {
 		return err
 	}
 
-	// 发布用户更新事件
-	eventbus.Notify(context.Background(), userService.bus, event.UserUpdated{User: user})
-
 	return nil
 }
 
@@ -384,7 +419,7 @@ func (userService *UserService) GetAllUsers(ctx context.Context) ([]model.User,
 	if err != nil {
 		return nil, err
 	}
-	if !caller.IsAdmin {
+	if !caller.Can(model.PermSystemManage) {
 		return nil, errors.New(commonModel.NO_PERMISSION_DENIED)
 	}
 
@@ -434,14 +469,13 @@ func (userService *UserService) GetOwner() (model.User, error) {
 //   - error: 删除过程中的错误信息
 func (userService *UserService) DeleteUser(ctx context.Context, id string) error {
 	userid := viewer.MustFromContext(ctx).UserID()
-	var deletedUser model.User
 	err := userService.transactor.Run(ctx, func(txCtx context.Context) error {
 		// 检查执行操作的用户是否为 Owner
 		operator, err := userService.userRepository.GetUserByID(txCtx, userid)
 		if err != nil {
 			return err
 		}
-		if !operator.IsOwner {
+		if !operator.Can(model.PermUserManage) {
 			return errors.New(commonModel.ONLY_OWNER_CAN_MANAGE)
 		}
 
@@ -455,18 +489,16 @@ func (userService *UserService) DeleteUser(ctx context.Context, id string) error
 			return errors.New(commonModel.INVALID_PARAMS_BODY)
 		}
 
-		deletedUser = user
 		if err := userService.userRepository.DeleteUser(txCtx, id); err != nil {
 			return err
 		}
 
-		return nil
+		return eventbus.NotifyTx(txCtx, userService.bus, event.UserDeleted{User: user})
 	})
 	if err != nil {
 		return err
 	}
 
-	eventbus.Notify(context.Background(), userService.bus, event.UserDeleted{User: deletedUser})
 	return nil
 }
 
@@ -365,6 +365,60 @@ func TestUpdateUserAdmin_Success(t *testing.T) {
 	assert.True(t, updated.IsAdmin, "普通用户应被提升为 admin（取反）")
 }
 
+// ---------------------------------------------------------------------------
+// UpdateUserRole：owner-only，只能授予非 owner 的已知角色
+// ---------------------------------------------------------------------------
+
+func TestUpdateUserRole_RejectsInvalidRole(t *testing.T) {
+	svc, _ := newUserSvc(t)
+	for _, role := range []userModel.Role{"", "root", userModel.RoleOwner} {
+		err := svc.UpdateUserRole(helpers.CtxAsUser("owner-1"), "u-2", role)
+		require.EqualError(t, err, commonModel.INVALID_USER_ROLE, "role %q", role)
+	}
+}
+
+func TestUpdateUserRole_NotOwner(t *testing.T) {
+	svc, m := newUserSvc(t)
+	// 编辑拥有系统管理权限，但不能调整他人角色。
+	m.repo.EXPECT().GetUserByID(mock.Anything, "admin-1").
+		Return(helpers.NewUser(withID("admin-1"), helpers.AsAdmin), nil).Once()
+
+	err := svc.UpdateUserRole(helpers.CtxAsUser("admin-1"), "u-2", userModel.RoleAuthor)
+	require.EqualError(t, err, commonModel.ONLY_OWNER_CAN_MANAGE)
+}
+
+func TestUpdateUserRole_Success(t *testing.T) {
+	cases := []struct {
+		role      userModel.Role
+		wantAdmin bool
+	}{
+		{userModel.RoleEditor, true},
+		{userModel.RoleAuthor, false},
+		{userModel.RoleCommentModerator, false},
+		{userModel.RoleReader, false},
+	}
+	for _, tc := range cases {
+		t.Run(string(tc.role), func(t *testing.T) {
+			svc, m := newUserSvc(t)
+			m.repo.EXPECT().GetUserByID(mock.Anything, "owner-1").
+				Return(helpers.NewUser(withID("owner-1"), helpers.AsOwner), nil).Once()
+			m.repo.EXPECT().GetUserByID(mock.Anything, "u-2").
+				Return(helpers.NewUser(withID("u-2"), helpers.AsAdmin), nil).Once()
+			m.expectTxPassthrough()
+
+			var updated userModel.User
+			m.repo.EXPECT().UpdateUser(mock.Anything, mock.Anything).
+				Run(func(_ context.Context, u *userModel.User) { updated = *u }).
+				Return(nil).Once()
+
+			require.NoError(t, svc.UpdateUserRole(helpers.CtxAsUser("owner-1"), "u-2", tc.role))
+			assert.Equal(t, tc.role, updated.Role)
+			assert.Equal(t, tc.wantAdmin, updated.IsAdmin, "IsAdmin 随角色同步")
+			assert.False(t, updated.IsOwner)
+		})
+	}
+}
+
 // ---------------------------------------------------------------------------
 // DeleteUser：事务内 self/owner 守卫
 // ---------------------------------------------------------------------------
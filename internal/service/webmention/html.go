@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"bytes"
+	"mime"
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// maxTitleRunes 是来源页面标题落入评论正文时的长度上限。
+const maxTitleRunes = 200
+
+// sourceDoc 是对来源页面的校验结果。
+type sourceDoc struct {
+	linked bool
+	title  string
+}
+
+// inspectSource 判断来源页面是否链接到 target。HTML 只认 a / link / area / img 等元素上的地址，
+// 其他文本类型退化为字符串包含判断。
+func inspectSource(contentType string, body []byte, target string) sourceDoc {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+		return sourceDoc{linked: bytes.Contains(body, []byte(target))}
+	}
+	root, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return sourceDoc{}
+	}
+	var doc sourceDoc
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if doc.title == "" && n.FirstChild != nil {
+					doc.title = truncate(strings.Join(strings.Fields(n.FirstChild.Data), " "), maxTitleRunes)
+				}
+			case "a", "link", "area":
+				doc.linked = doc.linked || attr(n, "href") == target
+			case "img", "video", "audio", "source":
+				doc.linked = doc.linked || attr(n, "src") == target
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	return doc
+}
+
+// extractLinks 取出渲染后正文中的外部 http(s) 链接，按出现顺序去重，最多 limit 个。
+func extractLinks(content []byte, ownHost string, limit int) []string {
+	root, err := html.Parse(bytes.NewReader(content))
+	if err != nil {
+		return nil
+	}
+	var links []string
+	seen := make(map[string]struct{})
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if len(links) >= limit {
+			return
+		}
+		if n.Type == html.ElementNode && n.Data == "a" {
+			href := attr(n, "href")
+			if parsed, ok := parseHTTPURL(href); ok && !strings.EqualFold(parsed.Host, ownHost) {
+				if _, dup := seen[href]; !dup {
+					seen[href] = struct{}{}
+					links = append(links, href)
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	return links
+}
+
+// endpointFromHeader 解析 Link 响应头中 rel 含 webmention 的地址。
+func endpointFromHeader(values []string) (string, bool) {
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			segments := strings.Split(part, ";")
+			ref := strings.TrimSpace(segments[0])
+			if !strings.HasPrefix(ref, "<") || !strings.HasSuffix(ref, ">") {
+				continue
+			}
+			for _, param := range segments[1:] {
+				key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+				if ok && strings.EqualFold(strings.TrimSpace(key), "rel") && hasRel(strings.Trim(val, `"' `)) {
+					return ref[1 : len(ref)-1], true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// endpointFromHTML 按文档顺序取第一个 rel 含 webmention 的 link / a 元素的 href。
+func endpointFromHTML(body []byte) (string, bool) {
+	root, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return "", false
+	}
+	var found *html.Node
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if found != nil {
+			return
+		}
+		if n.Type == html.ElementNode && (n.Data == "link" || n.Data == "a") && hasRel(attr(n, "rel")) {
+			if _, ok := attrValue(n, "href"); ok {
+				found = n
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(root)
+	if found == nil {
+		return "", false
+	}
+	return attr(found, "href"), true
+}
+
+// resolveEndpoint 以目标页面的最终地址解析相对端点；空 href 即目标页面本身。
+func resolveEndpoint(base *url.URL, ref string) (string, bool) {
+	parsed, err := base.Parse(strings.TrimSpace(ref))
+	if err != nil {
+		return "", false
+	}
+	if _, ok := parseHTTPURL(parsed.String()); !ok {
+		return "", false
+	}
+	return parsed.String(), true
+}
+
+func hasRel(rel string) bool {
+	for _, token := range strings.Fields(rel) {
+		if strings.EqualFold(token, "webmention") {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	value, _ := attrValue(n, key)
+	return value
+}
+
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit]) + "…"
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"net/http"
+
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+)
+
+// Service 接收 Webmention。Receive 只做同步的参数校验，来源页面的抓取与链接校验在后台完成。
+// 未开启 Webmention 或未配置站点地址时返回 ErrDisabled。
+type Service interface {
+	Receive(ctx context.Context, source, target string) error
+}
+
+// Sender 向 Echo 正文中的外链发送 Webmention，由事件订阅者驱动。
+type Sender interface {
+	SendForEcho(ctx context.Context, echo echoModel.Echo) error
+	RetractForEcho(ctx context.Context, echoID string) error
+}
+
+type EchoRepository interface {
+	GetEchosById(ctx context.Context, id string) (*echoModel.Echo, error)
+}
+
+// HTTPDoer 是发出请求所需的最小客户端能力。
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type CommentService = commentService.Service
@@ -0,0 +1,208 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	mdUtil "github.com/lin-snow/ech0/internal/util/md"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	// maxLinksPerEcho 限制单条 Echo 发送 Webmention 的外链数量。
+	maxLinksPerEcho = 20
+	sendAttempts    = 3
+	sendBackoff     = time.Second
+	// sentKeyPrefix 加 Echo ID 是该 Echo 上次通知过的目标列表（JSON 字符串数组）。
+	sentKeyPrefix = "webmention_sent:"
+)
+
+// errNoEndpoint 表示目标页面没有声明 Webmention 端点，属于正常情况，不重试也不记日志。
+var errNoEndpoint = errors.New("webmention: no endpoint")
+
+// WebmentionSender 只依赖键值存储，可以单独装配进事件订阅侧。
+type WebmentionSender struct {
+	kv     kvstore.Store
+	client HTTPDoer
+}
+
+var _ Sender = (*WebmentionSender)(nil)
+
+func NewWebmentionSender(durableKV kvstore.Store) *WebmentionSender {
+	return &WebmentionSender{
+		kv:     durableKV,
+		client: egress.NewClient(egress.Guard(), egress.Timeout(requestTimeout)),
+	}
+}
+
+// SendForEcho 对公开 Echo 正文中的每个外链发现端点并发送 Webmention；上次通知过、这次已不在
+// 正文中（或 Echo 已改为私密）的目标也再通知一次，对方重新抓取来源后据此更新或撤回提及。
+// 单个目标失败只记日志，不影响其余链接；未开启时静默成功。
+func (s *WebmentionSender) SendForEcho(ctx context.Context, echo echoModel.Echo) error {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		if errors.Is(err, ErrDisabled) {
+			return nil
+		}
+		return err
+	}
+	var targets []string
+	if isVisible(&echo) {
+		targets = extractLinks(mdUtil.MdToHTML([]byte(echo.Content)), st.host, maxLinksPerEcho)
+	}
+	return s.notify(ctx, st, echo.ID, targets)
+}
+
+// RetractForEcho 在 Echo 被删除后通知它上次提及过的全部目标。
+func (s *WebmentionSender) RetractForEcho(ctx context.Context, echoID string) error {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		if errors.Is(err, ErrDisabled) {
+			return nil
+		}
+		return err
+	}
+	return s.notify(ctx, st, echoID, nil)
+}
+
+// notify 向 targets 与上次通知过的目标的并集发送 Webmention，再把 targets 记为该 Echo 的
+// 已通知集合（为空时删除记录）。
+func (s *WebmentionSender) notify(ctx context.Context, st site, echoID string, targets []string) error {
+	key := sentKeyPrefix + echoID
+	var prev []string
+	if raw, err := s.kv.Get(ctx, key); err == nil {
+		if err := json.Unmarshal([]byte(raw), &prev); err != nil {
+			logUtil.GetLogger().Warn("decode sent webmention targets failed",
+				logUtil.Err(err), slog.String("echo_id", echoID))
+		}
+	} else if !errors.Is(err, kvstore.ErrNotFound) {
+		return err
+	}
+
+	all := append([]string(nil), targets...)
+	for _, target := range prev {
+		if !slices.Contains(targets, target) {
+			all = append(all, target)
+		}
+	}
+	source := st.echoURL(echoID)
+	for _, target := range all {
+		if err := s.send(ctx, source, target); err != nil && !errors.Is(err, errNoEndpoint) {
+			logUtil.GetLogger().Warn("webmention send failed",
+				logUtil.Err(err),
+				slog.String("source", source),
+				slog.String("target", target),
+			)
+		}
+	}
+
+	if len(targets) == 0 {
+		if len(prev) == 0 {
+			return nil
+		}
+		return s.kv.Delete(ctx, key)
+	}
+	raw, err := json.Marshal(targets)
+	if err != nil {
+		return err
+	}
+	return s.kv.Set(ctx, key, string(raw))
+}
+
+func (s *WebmentionSender) send(ctx context.Context, source, target string) error {
+	if err := egress.Validate(target); err != nil {
+		return err
+	}
+	endpoint, err := s.discover(ctx, target)
+	if err != nil {
+		return err
+	}
+	if err := egress.Validate(endpoint); err != nil {
+		return err
+	}
+	form := url.Values{"source": {source}, "target": {target}}.Encode()
+	return egress.Retry(sendAttempts, sendBackoff, func() error {
+		return s.post(ctx, endpoint, form)
+	})
+}
+
+// discover 先看 Link 响应头，再按文档顺序找 rel="webmention" 的 link / a 元素。
+func (s *WebmentionSender) discover(ctx context.Context, target string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.5")
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("discover %s: status %d", target, resp.StatusCode)
+	}
+
+	base := req.URL
+	if resp.Request != nil && resp.Request.URL != nil {
+		base = resp.Request.URL
+	}
+	ref, ok := endpointFromHeader(resp.Header.Values("Link"))
+	if !ok && strings.Contains(resp.Header.Get("Content-Type"), "html") {
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentBytes))
+		if err != nil {
+			return "", err
+		}
+		ref, ok = endpointFromHTML(body)
+	}
+	if !ok {
+		return "", errNoEndpoint
+	}
+	endpoint, ok := resolveEndpoint(base, ref)
+	if !ok {
+		return "", errNoEndpoint
+	}
+	return endpoint, nil
+}
+
+func (s *WebmentionSender) post(ctx context.Context, endpoint, form string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDocumentBytes))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
+		// 对方拒收（目标不接受提及、参数不合法等），重试也不会变。
+		return egress.Permanent(fmt.Errorf("post %s: status %d", endpoint, resp.StatusCode))
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("post %s: status %d", endpoint, resp.StatusCode)
+	}
+	return nil
+}
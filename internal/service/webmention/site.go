@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/config"
+	"github.com/lin-snow/ech0/internal/kvstore"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+)
+
+const (
+	requestTimeout = 10 * time.Second
+	// maxDocumentBytes 限制抓取来源 / 目标页面时读取的大小。
+	maxDocumentBytes = 1 << 20
+	userAgent        = "Ech0-Webmention"
+)
+
+var (
+	// ErrDisabled 表示未开启 Webmention（ECH0_WEBMENTION_ENABLED）或未配置可用的站点地址。
+	ErrDisabled = errors.New("webmention: disabled")
+	// ErrBadRequest 表示 source / target 不合法，或 target 不是本站可接受提及的页面。
+	ErrBadRequest = errors.New("webmention: invalid source or target")
+	// ErrBusy 表示待校验队列已满。
+	ErrBusy = errors.New("webmention: verification queue is full")
+)
+
+// site 是由系统设置中的站点地址派生的本站信息。
+type site struct {
+	base string
+	host string
+	path string
+}
+
+func (s site) echoURL(echoID string) string { return s.base + "/echo/" + echoID }
+
+// echoIDFromPath 把本站 Echo 页面的路径还原成 Echo ID；不是 Echo 页面时返回空串。
+func (s site) echoIDFromPath(p string) string {
+	id, ok := strings.CutPrefix(p, s.path+"/echo/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return ""
+	}
+	return id
+}
+
+func loadSite(ctx context.Context, kv kvstore.Store) (site, error) {
+	if !config.Config().Federation.Webmention {
+		return site{}, ErrDisabled
+	}
+	system, err := coreSetting.Get(ctx, kv, coreSetting.System)
+	if err != nil {
+		return site{}, err
+	}
+	base := strings.TrimRight(strings.TrimSpace(system.ServerURL), "/")
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return site{}, ErrDisabled
+	}
+	return site{base: base, host: parsed.Host, path: strings.TrimRight(parsed.Path, "/")}, nil
+}
+
+// parseHTTPURL 只接受绝对 http(s) 地址。
+func parseHTTPURL(raw string) (*url.URL, bool) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return nil, false
+	}
+	return parsed, true
+}
+
+// isVisible 判断 Echo 是否对外可见：公开、已发布且不在回收站。
+func isVisible(echo *echoModel.Echo) bool {
+	return echo != nil && !echo.Private && echo.IsPublished() && !echo.IsTrashed()
+}
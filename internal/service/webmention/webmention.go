@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/lin-snow/ech0/internal/util/egress"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+const (
+	// maxConcurrentVerifications 限制同时进行的来源校验数，避免被批量提及拖垮出站连接。
+	maxConcurrentVerifications = 4
+	// maxQueuedVerifications 是等待校验的提及上限；队列满时拒收，由发送方稍后重试。
+	maxQueuedVerifications = 256
+)
+
+// mention 是一条待校验的提及。
+type mention struct {
+	echoID string
+	source string
+	target string
+}
+
+type WebmentionService struct {
+	echoRepo   EchoRepository
+	commentSvc CommentService
+	kv         kvstore.Store
+	client     HTTPDoer
+	queue      chan mention
+	workers    sync.WaitGroup
+	// ctx 是 worker 的上下文，Stop 等不及排空时取消它，放弃余下的提及。
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu sync.Mutex
+	// pending 是已入队、尚未开始校验的 (source, target)，重复提及直接合并。
+	pending map[[2]string]struct{}
+	// stopped 后不再收新的提及，队列已关闭。
+	stopped bool
+	// verified 在每次后台校验结束后回调，仅供测试同步。
+	verified func()
+}
+
+var _ Service = (*WebmentionService)(nil)
+
+func NewWebmentionService(
+	echoRepo EchoRepository,
+	commentSvc CommentService,
+	durableKV kvstore.Store,
+) *WebmentionService {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebmentionService{
+		echoRepo:   echoRepo,
+		commentSvc: commentSvc,
+		kv:         durableKV,
+		client:     egress.NewClient(egress.Guard(), egress.Timeout(requestTimeout)),
+		queue:      make(chan mention, maxQueuedVerifications),
+		ctx:        ctx,
+		cancel:     cancel,
+		pending:    make(map[[2]string]struct{}),
+	}
+}
+
+// Name 实现 app.Namer。
+func (s *WebmentionService) Name() string { return "webmention" }
+
+// Start 启动后台校验 worker，随 HTTP 服务启动。
+func (s *WebmentionService) Start(context.Context) error {
+	for range maxConcurrentVerifications {
+		s.workers.Add(1)
+		go func() {
+			defer s.workers.Done()
+			s.work()
+		}()
+	}
+	return nil
+}
+
+// Stop 不再收新的提及（Receive 返回 ErrBusy），等 worker 校验完已排队的提及。ctx 先结束时
+// 取消进行中的校验、丢弃余下的提及并返回 ctx 的错误；发送方会在稍后重试。
+func (s *WebmentionService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	if !s.stopped {
+		s.stopped = true
+		close(s.queue)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		logUtil.GetLogger().Warn("webmention verification queue dropped on shutdown",
+			slog.Int("queued", len(s.queue)))
+		return ctx.Err()
+	}
+}
+
+// Receive 校验 source / target 后把来源校验放进后台队列：target 必须是本站公开 Echo 的页面，
+// source 必须是可出站访问的外部地址。校验通过的提及落地为待审核评论。同一对 (source, target)
+// 尚在排队时重复提及只算一次；队列已满返回 ErrBusy。
+func (s *WebmentionService) Receive(ctx context.Context, source, target string) error {
+	st, err := loadSite(ctx, s.kv)
+	if err != nil {
+		return err
+	}
+	sourceURL, okSource := parseHTTPURL(source)
+	targetURL, okTarget := parseHTTPURL(target)
+	if !okSource || !okTarget || sourceURL.String() == targetURL.String() {
+		return ErrBadRequest
+	}
+	if !strings.EqualFold(targetURL.Host, st.host) {
+		return fmt.Errorf("%w: target is not on this site", ErrBadRequest)
+	}
+	echoID := st.echoIDFromPath(targetURL.Path)
+	if echoID == "" {
+		return fmt.Errorf("%w: target does not accept webmentions", ErrBadRequest)
+	}
+	echo, err := s.echoRepo.GetEchosById(ctx, echoID)
+	if err != nil || !isVisible(echo) {
+		return fmt.Errorf("%w: target does not accept webmentions", ErrBadRequest)
+	}
+	if err := egress.Validate(sourceURL.String()); err != nil {
+		return fmt.Errorf("%w: %v", ErrBadRequest, err)
+	}
+
+	return s.enqueue(mention{echoID: echoID, source: sourceURL.String(), target: target})
+}
+
+func (s *WebmentionService) enqueue(m mention) error {
+	key := [2]string{m.source, m.target}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return ErrBusy
+	}
+	if _, ok := s.pending[key]; ok {
+		return nil
+	}
+	select {
+	case s.queue <- m:
+		s.pending[key] = struct{}{}
+		return nil
+	default:
+		return ErrBusy
+	}
+}
+
+// work 逐条取出排队的提及做来源校验，直到队列关闭且取空；Stop 取消上下文后余下的提及只出队
+// 不校验。出队即移出 pending：校验进行中再收到同一提及时重新排队，来源页面可能已经改过。
+func (s *WebmentionService) work() {
+	for m := range s.queue {
+		s.mu.Lock()
+		delete(s.pending, [2]string{m.source, m.target})
+		s.mu.Unlock()
+		if s.ctx.Err() != nil {
+			continue
+		}
+		s.verify(s.ctx, m.echoID, m.source, m.target)
+		if s.verified != nil {
+			s.verified()
+		}
+	}
+}
+
+// verify 抓取来源页面并确认其中确实链接到 target：链接存在则落地（或保留）评论，
+// 来源已删除（410）或不再链接时撤回此前落地的评论。
+func (s *WebmentionService) verify(ctx context.Context, echoID, source, target string) {
+	logger := logUtil.GetLogger()
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "text/html, */*;q=0.5")
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		logger.Info("webmention source fetch failed", logUtil.Err(err), slog.String("source", source))
+		return
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode == http.StatusGone {
+		s.retract(ctx, echoID, source)
+		return
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		logger.Info("webmention source unavailable",
+			slog.String("source", source),
+			slog.Int("status", resp.StatusCode),
+		)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxDocumentBytes))
+	if err != nil {
+		return
+	}
+
+	doc := inspectSource(resp.Header.Get("Content-Type"), body, target)
+	if !doc.linked {
+		s.retract(ctx, echoID, source)
+		return
+	}
+	dto := commentModel.CreateWebmentionCommentDto{
+		EchoID:   echoID,
+		Source:   source,
+		Nickname: req.URL.Hostname(),
+		Content:  doc.title,
+	}
+	if _, err := s.commentSvc.CreateWebmentionComment(ctx, &dto); err != nil {
+		// 评论关闭等业务拒绝不影响发送方，只记日志。
+		logger.Info("webmention not stored", logUtil.Err(err), slog.String("source", source))
+	}
+}
+
+func (s *WebmentionService) retract(ctx context.Context, echoID, source string) {
+	if err := s.commentSvc.RetractWebmentionComment(ctx, echoID, source); err != nil {
+		logUtil.GetLogger().Warn("webmention retract failed", logUtil.Err(err), slog.String("source", source))
+	}
+}
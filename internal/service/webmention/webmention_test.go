@@ -0,0 +1,354 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lin-snow/ech0/internal/kvstore"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	settingModel "github.com/lin-snow/ech0/internal/model/setting"
+	coreSetting "github.com/lin-snow/ech0/internal/setting"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/test/mocks/commentmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testBase = "https://ech0.example"
+
+type memEchoRepo struct {
+	echos map[string]echoModel.Echo
+}
+
+func (r *memEchoRepo) GetEchosById(_ context.Context, id string) (*echoModel.Echo, error) {
+	echo, ok := r.echos[id]
+	if !ok {
+		return nil, nil
+	}
+	return &echo, nil
+}
+
+// page 是 fakeWeb 上的一个页面。
+type page struct {
+	status      int
+	contentType string
+	link        string
+	body        string
+}
+
+// fakeWeb 按 URL 返回预置页面，并记录所有请求。
+type fakeWeb struct {
+	mu       sync.Mutex
+	pages    map[string]page
+	requests []*http.Request
+	forms    []url.Values
+	// postStatus 是端点对 POST 的响应码，默认 202。
+	postStatus int
+}
+
+func (w *fakeWeb) Do(req *http.Request) (*http.Response, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.requests = append(w.requests, req)
+	if req.Method == http.MethodPost {
+		body, _ := io.ReadAll(req.Body)
+		form, _ := url.ParseQuery(string(body))
+		w.forms = append(w.forms, form)
+		status := w.postStatus
+		if status == 0 {
+			status = http.StatusAccepted
+		}
+		return &http.Response{StatusCode: status, Body: io.NopCloser(bytes.NewReader(nil)), Request: req}, nil
+	}
+	p, ok := w.pages[req.URL.String()]
+	if !ok {
+		p = page{status: http.StatusNotFound}
+	}
+	if p.status == 0 {
+		p.status = http.StatusOK
+	}
+	header := http.Header{}
+	if p.contentType != "" {
+		header.Set("Content-Type", p.contentType)
+	}
+	if p.link != "" {
+		header.Set("Link", p.link)
+	}
+	return &http.Response{
+		StatusCode: p.status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader([]byte(p.body))),
+		Request:    req,
+	}, nil
+}
+
+func newSiteKV(t *testing.T) kvstore.Store {
+	t.Helper()
+	helpers.SetWebmention(t, true)
+	kv := kvstore.NewMemory()
+	require.NoError(t, coreSetting.Set(context.Background(), kv, coreSetting.System, settingModel.SystemSetting{
+		ServerURL: testBase + "/",
+	}))
+	return kv
+}
+
+type receiverFixture struct {
+	svc      *WebmentionService
+	web      *fakeWeb
+	comments *commentmock.MockService
+	done     chan struct{}
+}
+
+func newReceiverFixture(t *testing.T) *receiverFixture {
+	t.Helper()
+	kv := newSiteKV(t)
+	echos := &memEchoRepo{echos: map[string]echoModel.Echo{
+		"echo-public":  helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "echo-public" }),
+		"echo-private": helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "echo-private"; e.Private = true }),
+	}}
+	f := &receiverFixture{
+		web:      &fakeWeb{pages: map[string]page{}},
+		comments: commentmock.NewMockService(t),
+		done:     make(chan struct{}, 4),
+	}
+	f.svc = NewWebmentionService(echos, f.comments, kv)
+	f.svc.client = f.web
+	f.svc.verified = func() { f.done <- struct{}{} }
+	return f
+}
+
+// start 起 worker，测试结束时停下。
+func (f *receiverFixture) start(t *testing.T) {
+	t.Helper()
+	require.NoError(t, f.svc.Start(context.Background()))
+	t.Cleanup(func() { _ = f.svc.Stop(context.Background()) })
+}
+
+func (f *receiverFixture) wait(t *testing.T) {
+	t.Helper()
+	select {
+	case <-f.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("verification did not finish")
+	}
+}
+
+func TestReceive_Validation(t *testing.T) {
+	f := newReceiverFixture(t)
+	ctx := context.Background()
+	target := testBase + "/echo/echo-public"
+
+	for name, tc := range map[string][2]string{
+		"relative source": {"/post", target},
+		"same url":        {target, target},
+		"foreign target":  {"https://blog.example/post", "https://other.example/echo/echo-public"},
+		"not an echo":     {"https://blog.example/post", testBase + "/about"},
+		"private echo":    {"https://blog.example/post", testBase + "/echo/echo-private"},
+		"unknown echo":    {"https://blog.example/post", testBase + "/echo/missing"},
+		"private source":  {"http://127.0.0.1/post", target},
+	} {
+		assert.ErrorIs(t, f.svc.Receive(ctx, tc[0], tc[1]), ErrBadRequest, name)
+	}
+
+	helpers.SetWebmention(t, false)
+	assert.ErrorIs(t, f.svc.Receive(ctx, "https://blog.example/post", target), ErrDisabled)
+}
+
+func TestReceive_VerifiedMentionBecomesComment(t *testing.T) {
+	f := newReceiverFixture(t)
+	f.start(t)
+	target := testBase + "/echo/echo-public"
+	f.web.pages["https://blog.example/post"] = page{
+		contentType: "text/html; charset=utf-8",
+		body:        `<html><head><title> A  reply </title></head><body><a href="` + target + `">link</a></body></html>`,
+	}
+	f.comments.EXPECT().
+		CreateWebmentionComment(mock.Anything, &commentModel.CreateWebmentionCommentDto{
+			EchoID:   "echo-public",
+			Source:   "https://blog.example/post",
+			Nickname: "blog.example",
+			Content:  "A reply",
+		}).
+		Return(commentModel.CreateCommentResult{ID: "c1", Status: commentModel.StatusPending}, nil).
+		Once()
+
+	require.NoError(t, f.svc.Receive(context.Background(), "https://blog.example/post", target))
+	f.wait(t)
+}
+
+// TestReceive_MissingLinkRetracts 校验来源不再链接或已删除（410）时撤回此前的提及。
+func TestReceive_MissingLinkRetracts(t *testing.T) {
+	f := newReceiverFixture(t)
+	f.start(t)
+	target := testBase + "/echo/echo-public"
+	f.web.pages["https://blog.example/edited"] = page{
+		contentType: "text/html",
+		body:        `<p>mentions ` + target + ` only as text</p><a href="` + target + `/x">near miss</a>`,
+	}
+	f.web.pages["https://blog.example/gone"] = page{status: http.StatusGone}
+	f.comments.EXPECT().RetractWebmentionComment(mock.Anything, "echo-public", "https://blog.example/edited").Return(nil).Once()
+	f.comments.EXPECT().RetractWebmentionComment(mock.Anything, "echo-public", "https://blog.example/gone").Return(nil).Once()
+
+	require.NoError(t, f.svc.Receive(context.Background(), "https://blog.example/edited", target))
+	f.wait(t)
+	require.NoError(t, f.svc.Receive(context.Background(), "https://blog.example/gone", target))
+	f.wait(t)
+}
+
+// TestReceive_QueueCoalescesAndRejectsWhenFull 校验：排队中的同一提及只校验一次，队列满时返回 ErrBusy。
+func TestReceive_QueueCoalescesAndRejectsWhenFull(t *testing.T) {
+	f := newReceiverFixture(t)
+	f.svc.queue = make(chan mention, 2) // 先不起 worker，让提及停在队列里
+	target := testBase + "/echo/echo-public"
+	ctx := context.Background()
+
+	require.NoError(t, f.svc.Receive(ctx, "https://blog.example/a", target))
+	require.NoError(t, f.svc.Receive(ctx, "https://blog.example/a", target), "duplicate mention is coalesced")
+	require.NoError(t, f.svc.Receive(ctx, "https://blog.example/b", target))
+	assert.ErrorIs(t, f.svc.Receive(ctx, "https://blog.example/c", target), ErrBusy)
+	assert.Len(t, f.svc.queue, 2)
+
+	// 两个来源都 404：各校验一次、不落评论。
+	f.start(t)
+	f.wait(t)
+	f.wait(t)
+	assert.Len(t, f.web.requests, 2)
+	assert.Empty(t, f.svc.pending)
+}
+
+// TestStop_DrainsQueueThenRejects 校验停机时排空已收下的提及，之后的提及返回 ErrBusy；
+// 等不及排空时丢弃余下的提及。
+func TestStop_DrainsQueueThenRejects(t *testing.T) {
+	f := newReceiverFixture(t)
+	target := testBase + "/echo/echo-public"
+	ctx := context.Background()
+	require.NoError(t, f.svc.Receive(ctx, "https://blog.example/a", target))
+	require.NoError(t, f.svc.Receive(ctx, "https://blog.example/b", target))
+
+	require.NoError(t, f.svc.Start(ctx))
+	require.NoError(t, f.svc.Stop(ctx))
+	assert.Len(t, f.web.requests, 2, "queued mentions are verified before Stop returns")
+	assert.ErrorIs(t, f.svc.Receive(ctx, "https://blog.example/c", target), ErrBusy)
+
+	// 来源一直不响应：Stop 等到超时即取消进行中的校验，还没轮到的提及直接丢弃。
+	g := newReceiverFixture(t)
+	web := &stalledWeb{}
+	g.svc.client = web
+	for i := range maxConcurrentVerifications + 2 {
+		require.NoError(t, g.svc.Receive(ctx, fmt.Sprintf("https://blog.example/%d", i), target))
+	}
+	require.NoError(t, g.svc.Start(ctx))
+	require.Eventually(t, func() bool { return web.calls.Load() == maxConcurrentVerifications },
+		5*time.Second, 10*time.Millisecond)
+	stopCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, g.svc.Stop(stopCtx), context.DeadlineExceeded)
+	g.svc.workers.Wait()
+	assert.EqualValues(t, maxConcurrentVerifications, web.calls.Load())
+}
+
+// stalledWeb 的请求一直挂到调用方取消。
+type stalledWeb struct{ calls atomic.Int32 }
+
+func (w *stalledWeb) Do(req *http.Request) (*http.Response, error) {
+	w.calls.Add(1)
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func TestSendForEcho(t *testing.T) {
+	kv := newSiteKV(t)
+	web := &fakeWeb{pages: map[string]page{
+		"https://header.example/post": {
+			contentType: "text/html",
+			link:        `<https://header.example/other>; rel="other", </mention?x=1>; rel="webmention"`,
+			body:        `<link rel="webmention" href="https://wrong.example/">`,
+		},
+		"https://html.example/a/post": {
+			contentType: "text/html",
+			body:        `<a rel="nofollow" href="/nope">x</a><link rel="me webmention" href="endpoint"><a rel="webmention" href="/later">`,
+		},
+		"https://none.example/": {contentType: "text/html", body: `<p>nothing</p>`},
+	}}
+	sender := NewWebmentionSender(kv)
+	sender.client = web
+
+	echo := helpers.NewEcho(func(e *echoModel.Echo) {
+		e.ID = "e1"
+		e.Content = "[a](https://header.example/post) [b](https://html.example/a/post) " +
+			"[c](https://none.example/) [self](" + testBase + "/echo/e2) [again](https://header.example/post)"
+	})
+	require.NoError(t, sender.SendForEcho(context.Background(), echo))
+
+	var endpoints []string
+	for _, req := range web.requests {
+		if req.Method == http.MethodPost {
+			endpoints = append(endpoints, req.URL.String())
+		}
+	}
+	require.Len(t, web.forms, 2)
+	assert.Equal(t, []string{"https://header.example/mention?x=1", "https://html.example/a/endpoint"}, endpoints)
+	for _, form := range web.forms {
+		assert.Equal(t, testBase+"/echo/e1", form.Get("source"))
+	}
+	assert.Equal(t, "https://header.example/post", web.forms[0].Get("target"))
+	assert.Equal(t, "https://html.example/a/post", web.forms[1].Get("target"))
+
+	// 改掉一个链接：新旧目标都通知，被移除的目标据此撤回提及。
+	web.requests, web.forms = nil, nil
+	echo.Content = "[b](https://html.example/a/post)"
+	require.NoError(t, sender.SendForEcho(context.Background(), echo))
+	assert.ElementsMatch(t, []string{"https://html.example/a/post", "https://header.example/post"}, formTargets(web))
+
+	// 改为私密：只通知上次还在正文中的目标，之后不再有需要通知的目标。
+	web.requests, web.forms = nil, nil
+	echo.Private = true
+	require.NoError(t, sender.SendForEcho(context.Background(), echo))
+	assert.Equal(t, []string{"https://html.example/a/post"}, formTargets(web))
+	web.requests, web.forms = nil, nil
+	require.NoError(t, sender.SendForEcho(context.Background(), echo))
+	assert.Empty(t, web.requests)
+}
+
+// TestRetractForEcho 校验删除 Echo 时通知上次提及过的目标，且端点 4xx 不重试。
+func TestRetractForEcho(t *testing.T) {
+	kv := newSiteKV(t)
+	web := &fakeWeb{pages: map[string]page{
+		"https://blog.example/post": {contentType: "text/html", link: `</wm>; rel="webmention"`},
+	}}
+	sender := NewWebmentionSender(kv)
+	sender.client = web
+	echo := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = "e1"; e.Content = "<https://blog.example/post>" })
+	require.NoError(t, sender.SendForEcho(context.Background(), echo))
+	require.Equal(t, []string{"https://blog.example/post"}, formTargets(web))
+
+	web.requests, web.forms = nil, nil
+	web.postStatus = http.StatusBadRequest
+	require.NoError(t, sender.RetractForEcho(context.Background(), "e1"))
+	assert.Equal(t, []string{"https://blog.example/post"}, formTargets(web), "4xx is not retried")
+
+	web.requests, web.forms = nil, nil
+	require.NoError(t, sender.RetractForEcho(context.Background(), "e1"))
+	assert.Empty(t, web.requests)
+}
+
+func formTargets(web *fakeWeb) []string {
+	var targets []string
+	for _, form := range web.forms {
+		targets = append(targets, form.Get("target"))
+	}
+	return targets
+}
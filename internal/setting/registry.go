@@ -129,6 +129,7 @@ var (
 		Default: func() settingModel.EmbeddingSetting {
 			return settingModel.EmbeddingSetting{Enable: false}
 		},
+		Normalize: normalizeEmbedding,
 	}
 
 	// Comment 评论系统设置（含邮件通知）。SMTPPassword 的脱敏（SMTPPasswordSet 模式）
@@ -188,6 +189,22 @@ func normalizeComment(s *commentModel.SystemSetting) {
 	if s.EmailNotify.SMTPPort <= 0 {
 		s.EmailNotify.SMTPPort = 587
 	}
+	s.Spam.Threshold = commentModel.NormalizeSpamThreshold(s.Spam.Threshold)
+	s.AutoCloseDays = max(s.AutoCloseDays, 0)
+}
+
+// normalizeEmbedding 为历史设置补齐 provider（此前只有 OpenAI 兼容一种）；本地向量化
+// 固定模型名并补齐默认维度，使「启用判定」与换模型检测对两种 provider 一视同仁。
+func normalizeEmbedding(s *settingModel.EmbeddingSetting) {
+	if s.Provider == "" {
+		s.Provider = string(commonModel.EmbeddingOpenAI)
+	}
+	if s.Provider == string(commonModel.EmbeddingLocal) {
+		s.Model = settingModel.EmbeddingLocalModel
+		if s.Dim <= 0 {
+			s.Dim = settingModel.EmbeddingLocalDefaultDim
+		}
+	}
 }
 
 // migratePasskeyFromLegacy 从旧 oauth2_setting 中读取曾经内联的 WebAuthn 字段。
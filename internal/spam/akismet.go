@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package spam
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+)
+
+// DefaultAkismetEndpoint 是官方 Akismet 服务地址。
+const DefaultAkismetEndpoint = "https://rest.akismet.com"
+
+// akismetBackend 调用 Akismet 协议的 comment-check 接口。
+// 协议只给出是 / 否，得分按结论取 1 或 0。
+type akismetBackend struct {
+	client *http.Client
+}
+
+func (a akismetBackend) Check(ctx context.Context, setting model.SpamSetting, in Input) (Result, error) {
+	key := strings.TrimSpace(setting.AkismetKey)
+	if key == "" {
+		return Result{}, ErrAkismetKeyMissing
+	}
+	endpoint := strings.TrimRight(strings.TrimSpace(setting.AkismetEndpoint), "/")
+	if endpoint == "" {
+		endpoint = DefaultAkismetEndpoint
+	}
+
+	form := url.Values{
+		"api_key":              {key},
+		"blog":                 {in.BlogURL},
+		"user_ip":              {in.ClientIP},
+		"user_agent":           {in.UserAgent},
+		"permalink":            {in.Permalink},
+		"comment_type":         {"comment"},
+		"comment_author":       {in.Nickname},
+		"comment_author_email": {in.Email},
+		"comment_author_url":   {in.Website},
+		"comment_content":      {in.Content},
+		"blog_charset":         {"UTF-8"},
+	}
+	req, err := http.NewRequestWithContext(
+		ctx,
+		http.MethodPost,
+		endpoint+"/1.1/comment-check",
+		strings.NewReader(form.Encode()),
+	)
+	if err != nil {
+		return Result{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return Result{}, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if err != nil {
+		return Result{}, err
+	}
+
+	result := Result{Backend: model.SpamProviderAkismet}
+	switch strings.TrimSpace(string(body)) {
+	case "true":
+		result.Score, result.Verdict = 1, model.SpamVerdictSpam
+	case "false":
+		result.Score, result.Verdict = 0, model.SpamVerdictHam
+	default:
+		// 出错时协议返回 "invalid" 等，原因放在 X-akismet-debug-help 头里。
+		help := resp.Header.Get("X-akismet-debug-help")
+		return Result{}, fmt.Errorf("spam: akismet status %d: %q %s", resp.StatusCode, body, help)
+	}
+	return result, nil
+}
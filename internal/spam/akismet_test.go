@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package spam
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// akismetStub 模拟 Akismet 的 comment-check：正文含 "viagra" 判为垃圾，api_key 不对返回 invalid。
+func akismetStub(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/1.1/comment-check", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "comment", r.PostForm.Get("comment_type"))
+		assert.Equal(t, "203.0.113.5", r.PostForm.Get("user_ip"))
+		if r.PostForm.Get("api_key") != "good-key" {
+			w.Header().Set("X-akismet-debug-help", "Invalid API key")
+			_, _ = w.Write([]byte("invalid"))
+			return
+		}
+		if r.PostForm.Get("comment_author") == "viagra-test-123" || r.PostForm.Get("comment_content") == "buy viagra" {
+			_, _ = w.Write([]byte("true"))
+			return
+		}
+		_, _ = w.Write([]byte("false"))
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func akismetSetting(endpoint, key string) model.SpamSetting {
+	return model.SpamSetting{Provider: model.SpamProviderAkismet, AkismetEndpoint: endpoint + "/", AkismetKey: key}
+}
+
+func TestAkismet_Check(t *testing.T) {
+	srv := akismetStub(t)
+	f := newFilter(nil, srv.Client())
+	in := Input{ClientIP: "203.0.113.5", Nickname: "alice", Content: "nice post"}
+
+	got, err := f.Check(context.Background(), akismetSetting(srv.URL, "good-key"), in)
+	require.NoError(t, err)
+	assert.Equal(t, Result{Backend: model.SpamProviderAkismet, Score: 0, Verdict: model.SpamVerdictHam}, got)
+
+	in.Content = "buy viagra"
+	got, err = f.Check(context.Background(), akismetSetting(srv.URL, "good-key"), in)
+	require.NoError(t, err)
+	assert.Equal(t, model.SpamVerdictSpam, got.Verdict)
+	assert.Equal(t, 1.0, got.Score)
+
+	_, err = f.Check(context.Background(), akismetSetting(srv.URL, "bad-key"), in)
+	require.ErrorContains(t, err, "Invalid API key")
+
+	_, err = f.Check(context.Background(), akismetSetting(srv.URL, " "), in)
+	require.ErrorIs(t, err, ErrAkismetKeyMissing)
+}
+
+func TestFilter_Check_DisabledAndUnknown(t *testing.T) {
+	f := newFilter(nil, http.DefaultClient)
+	got, err := f.Check(context.Background(), model.SpamSetting{}, Input{})
+	require.NoError(t, err)
+	assert.Equal(t, Result{}, got)
+
+	_, err = f.Check(context.Background(), model.SpamSetting{Provider: "other"}, Input{})
+	require.ErrorIs(t, err, ErrProviderNotFound)
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package spam
+
+import (
+	"context"
+	"math"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	ftsUtil "github.com/lin-snow/ech0/internal/util/fts"
+)
+
+const (
+	// docsToken 记录垃圾 / 正常样本总数；分词只产出字母数字与带前缀的特征，不会与之冲突。
+	docsToken = "*docs*"
+	// minTrainingDocs 是两类样本各自至少需要的条数，不足时分类器不下结论。
+	minTrainingDocs = 5
+	// maxTokens 限制单条评论参与计算的特征数，避免超长灌水拖慢查询。
+	maxTokens = 200
+	// maxTokenLen 与 SpamToken.Token 列宽一致（按字节）。
+	maxTokenLen = 96
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>()"']+`)
+
+// bayesBackend 是内置朴素贝叶斯分类器：按词频表估计每个特征属于垃圾的概率
+// （Robinson 平滑，避免低频特征给出极端值），再连同先验以对数几率累加。
+type bayesBackend struct {
+	store TokenStore
+}
+
+func (b *bayesBackend) Check(ctx context.Context, setting model.SpamSetting, in Input) (Result, error) {
+	tokens := Tokenize(in)
+	counts, err := b.store.SpamTokenCounts(ctx, append(tokens, docsToken))
+	if err != nil {
+		return Result{}, err
+	}
+	docs := counts[docsToken]
+	if docs.Spam < minTrainingDocs || docs.Ham < minTrainingDocs {
+		return Result{Backend: model.SpamProviderBayes, Score: 0.5, Verdict: model.SpamVerdictUnsure}, nil
+	}
+
+	spamDocs, hamDocs := float64(docs.Spam), float64(docs.Ham)
+	logOdds := math.Log(spamDocs / hamDocs)
+	for _, token := range tokens {
+		c, ok := counts[token]
+		if !ok || c.Spam+c.Ham == 0 {
+			continue
+		}
+		spamFreq := float64(c.Spam) / spamDocs
+		hamFreq := float64(c.Ham) / hamDocs
+		p := spamFreq / (spamFreq + hamFreq)
+		n := float64(c.Spam + c.Ham)
+		// Robinson：以 0.5 为先验、强度 1，样本越多越接近观测值。
+		f := (0.5 + n*p) / (1 + n)
+		f = min(max(f, 0.01), 0.99)
+		logOdds += math.Log(f / (1 - f))
+	}
+	score := 1 / (1 + math.Exp(-logOdds))
+
+	threshold := setting.Threshold
+	if threshold <= 0 {
+		threshold = model.DefaultSpamThreshold
+	}
+	verdict := model.SpamVerdictHam
+	if score >= threshold {
+		verdict = model.SpamVerdictSpam
+	}
+	return Result{Backend: model.SpamProviderBayes, Score: score, Verdict: verdict}, nil
+}
+
+// Tokenize 把评论拆成去重后的分类特征：
+//   - 正文中的拉丁字母 / 数字词（小写，2 个字符起）；
+//   - 连续汉字等无空格文字按二元组切分（单字成词时保留单字）；
+//   - 链接数量分档（link:0 / 1 / 2 / 3+）与链接、网址、邮箱的域名（host:、mail:）。
+func Tokenize(in Input) []string {
+	seen := make(map[string]struct{})
+	out := make([]string, 0, 64)
+	add := func(token string) {
+		if token == "" || len(token) > maxTokenLen || len(out) >= maxTokens {
+			return
+		}
+		if _, ok := seen[token]; ok {
+			return
+		}
+		seen[token] = struct{}{}
+		out = append(out, token)
+	}
+
+	links := linkPattern.FindAllString(in.Content, -1)
+	add("link:" + linkBucket(len(links)))
+	for _, link := range links {
+		add(hostToken(link))
+	}
+	if in.Website != "" {
+		add(hostToken(in.Website))
+	}
+	if addr, err := mail.ParseAddress(in.Email); err == nil {
+		if at := strings.LastIndex(addr.Address, "@"); at >= 0 {
+			add("mail:" + strings.ToLower(addr.Address[at+1:]))
+		}
+	}
+
+	text := linkPattern.ReplaceAllString(in.Content, " ")
+	var word []rune
+	var cjk []rune
+	flushWord := func() {
+		if len(word) >= 2 {
+			add(string(word))
+		}
+		word = word[:0]
+	}
+	flushCJK := func() {
+		switch len(cjk) {
+		case 0:
+		case 1:
+			add(string(cjk))
+		default:
+			for i := 0; i+1 < len(cjk); i++ {
+				add(string(cjk[i : i+2]))
+			}
+		}
+		cjk = cjk[:0]
+	}
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case ftsUtil.IsCJK(r):
+			flushWord()
+			cjk = append(cjk, r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			flushCJK()
+			word = append(word, r)
+		default:
+			flushWord()
+			flushCJK()
+		}
+	}
+	flushWord()
+	flushCJK()
+	return out
+}
+
+func linkBucket(n int) string {
+	if n >= 3 {
+		return "3+"
+	}
+	return strconv.Itoa(n)
+}
+
+func hostToken(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Hostname() == "" {
+		return ""
+	}
+	return "host:" + strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package spam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memStore 是内存版词频表。
+type memStore map[string]model.SpamToken
+
+func (m memStore) SpamTokenCounts(_ context.Context, tokens []string) (map[string]model.SpamToken, error) {
+	out := make(map[string]model.SpamToken)
+	for _, t := range tokens {
+		if c, ok := m[t]; ok {
+			out[t] = c
+		}
+	}
+	return out, nil
+}
+
+func (m memStore) LearnSpamTokens(_ context.Context, tokens []string, spam bool, delta int64) error {
+	for _, t := range tokens {
+		c := m[t]
+		c.Token = t
+		if spam {
+			c.Spam = max(c.Spam+delta, 0)
+		} else {
+			c.Ham = max(c.Ham+delta, 0)
+		}
+		m[t] = c
+	}
+	return nil
+}
+
+func TestTokenize(t *testing.T) {
+	got := Tokenize(Input{
+		Content: "Great POST! 写得真好 see https://www.Spam.example/x?a=1",
+		Website: "http://blog.example",
+		Email:   "Bob@Mail.Example",
+	})
+	assert.Equal(t, []string{
+		"link:1", "host:spam.example", "host:blog.example", "mail:mail.example",
+		"great", "post", "写得", "得真", "真好", "see",
+	}, got)
+	assert.Contains(t, Tokenize(Input{Content: "好"}), "好")
+	assert.Contains(t, Tokenize(Input{Content: "a http://a.io http://b.io http://c.io"}), "link:3+")
+}
+
+func TestBayes_LearnsFromDecisions(t *testing.T) {
+	store := memStore{}
+	f := newFilter(store, http.DefaultClient)
+	setting := model.SpamSetting{Provider: model.SpamProviderBayes}
+	ctx := context.Background()
+
+	got, err := f.Check(ctx, setting, Input{Content: "cheap pills"})
+	require.NoError(t, err)
+	assert.Equal(t, model.SpamVerdictUnsure, got.Verdict, "untrained classifier does not decide")
+
+	for i := range 6 {
+		require.NoError(t, f.Train(ctx, Input{
+			Content: fmt.Sprintf("cheap pills discount casino https://pills%d.example", i),
+		}, true, 1))
+		require.NoError(t, f.Train(ctx, Input{
+			Content: fmt.Sprintf("写得真好，第 %d 次来看，期待下一篇", i),
+		}, false, 1))
+	}
+
+	spamRes, err := f.Check(ctx, setting, Input{Content: "cheap casino pills https://x.example"})
+	require.NoError(t, err)
+	assert.Equal(t, model.SpamVerdictSpam, spamRes.Verdict)
+	assert.Greater(t, spamRes.Score, 0.9)
+
+	hamRes, err := f.Check(ctx, setting, Input{Content: "写得真好，期待"})
+	require.NoError(t, err)
+	assert.Equal(t, model.SpamVerdictHam, hamRes.Verdict)
+	assert.Less(t, hamRes.Score, 0.5)
+
+	// 撤销样本后计数回落
+	require.NoError(t, f.Train(ctx, Input{Content: "cheap pills discount casino https://pills0.example"}, true, -1))
+	assert.Equal(t, int64(5), store[docsToken].Spam)
+}
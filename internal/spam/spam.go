@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package spam 为评论提供可插拔的垃圾检测：Akismet 协议客户端（官方服务或任意兼容实现），
+// 以及由管理员审核结论训练的内置朴素贝叶斯分类器。
+package spam
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	model "github.com/lin-snow/ech0/internal/model/comment"
+	"github.com/lin-snow/ech0/internal/util/egress"
+)
+
+var (
+	// ErrProviderNotFound 表示配置了未知的垃圾检测后端
+	ErrProviderNotFound = errors.New("spam: provider not found")
+	// ErrAkismetKeyMissing 表示选择了 Akismet 但未配置 API Key
+	ErrAkismetKeyMissing = errors.New("spam: akismet key missing")
+)
+
+// requestTimeout 是单次 Akismet 请求的超时；检测在发评论的请求路径上同步进行，宜短。
+const requestTimeout = 5 * time.Second
+
+// Input 是一条待检测 / 待训练的评论。ClientIP 等请求侧信息只在检测时可得，训练时为空。
+type Input struct {
+	BlogURL   string
+	Permalink string
+	ClientIP  string
+	UserAgent string
+	Nickname  string
+	Email     string
+	Website   string
+	Content   string
+}
+
+// Result 是一次检测的结果：Score 在 0~1 之间，越高越像垃圾。
+type Result struct {
+	Backend model.SpamProvider
+	Score   float64
+	Verdict model.SpamVerdict
+}
+
+// Backend 是某种垃圾检测实现的适配层。
+type Backend interface {
+	Check(ctx context.Context, setting model.SpamSetting, in Input) (Result, error)
+}
+
+// TokenStore 持久化内置分类器的词频表。LearnSpamTokens 以 delta 增减各特征在垃圾或正常样本中的计数
+// （delta 为负表示撤销旧样本），计数不低于 0。
+type TokenStore interface {
+	SpamTokenCounts(ctx context.Context, tokens []string) (map[string]model.SpamToken, error)
+	LearnSpamTokens(ctx context.Context, tokens []string, spam bool, delta int64) error
+}
+
+// Filter 按设置把检测分派到对应后端，并负责训练内置分类器。
+type Filter struct {
+	akismet Backend
+	bayes   *bayesBackend
+}
+
+// NewFilter 用给定词频表构造 Filter。
+func NewFilter(store TokenStore) *Filter {
+	return newFilter(store, nil)
+}
+
+// newFilter 允许测试替换 Akismet 请求用的 http.Client。
+func newFilter(store TokenStore, client *http.Client) *Filter {
+	if client == nil {
+		client = egress.NewClient(egress.Timeout(requestTimeout))
+	}
+	return &Filter{
+		akismet: akismetBackend{client: client},
+		bayes:   &bayesBackend{store: store},
+	}
+}
+
+// Enabled 判断设置是否开启了垃圾检测。
+func Enabled(setting model.SpamSetting) bool {
+	return setting.Provider != model.SpamProviderNone
+}
+
+// Check 按 setting.Provider 检测一条评论；未开启时返回零值结果。
+func (f *Filter) Check(ctx context.Context, setting model.SpamSetting, in Input) (Result, error) {
+	var backend Backend
+	switch setting.Provider {
+	case model.SpamProviderNone:
+		return Result{}, nil
+	case model.SpamProviderAkismet:
+		backend = f.akismet
+	case model.SpamProviderBayes:
+		backend = f.bayes
+	default:
+		return Result{}, ErrProviderNotFound
+	}
+	return backend.Check(ctx, setting, in)
+}
+
+// Train 把一条评论作为垃圾（spam=true）或正常样本计入内置分类器；delta 为 -1 时撤销。
+// 与当前选用的后端无关——分类器始终随审核结论积累样本，切换到内置分类器时即可用。
+func (f *Filter) Train(ctx context.Context, in Input, spam bool, delta int64) error {
+	return f.bayes.store.LearnSpamTokens(ctx, append(Tokenize(in), docsToken), spam, delta)
+}
@@ -10,4 +10,6 @@ var ProviderSet = wire.NewSet(
 	NewCleanup,
 	NewSnapshot,
 	NewVisitorSnapshot,
+	NewScheduledPublish,
+	NewPurgeTrash,
 )
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// publishInterval 是定时发布的轮询间隔，也即发布时间的最大延迟。
+const publishInterval = time.Minute
+
+// ScheduledPublish 按分钟轮询并发布到期的定时 Echo。
+type ScheduledPublish struct {
+	echoService echoService.Service
+}
+
+func NewScheduledPublish(echoSvc echoService.Service) *ScheduledPublish {
+	return &ScheduledPublish{echoService: echoSvc}
+}
+
+func (p *ScheduledPublish) Name() string { return "publish-scheduled-echos" }
+
+// Schedule 每分钟发布一次到期的定时 Echo；启动时立即补发停机期间到期的那些。
+func (p *ScheduledPublish) Schedule(_ context.Context, s gocron.Scheduler) error {
+	_, err := s.NewJob(
+		gocron.DurationJob(publishInterval),
+		gocron.NewTask(p.run),
+		gocron.WithStartAt(gocron.WithStartImmediately()),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to schedule publish task",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	return err
+}
+
+func (p *ScheduledPublish) run() {
+	n, err := p.echoService.PublishDueEchos(context.Background())
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to publish scheduled echos",
+			slog.String("module", logModule), logUtil.Err(err))
+		return
+	}
+	if n > 0 {
+		logUtil.GetLogger().Info("Published scheduled echos",
+			slog.String("module", logModule), slog.Int("count", n))
+	}
+}
@@ -5,6 +5,7 @@ package scheduled
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 	"sync"
@@ -12,8 +13,10 @@ import (
 	"github.com/go-co-op/gocron/v2"
 	"github.com/lin-snow/ech0/internal/event"
 	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	"github.com/lin-snow/ech0/internal/job"
 	"github.com/lin-snow/ech0/internal/kvstore"
 	coreMigrator "github.com/lin-snow/ech0/internal/migrator"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
 	coreSetting "github.com/lin-snow/ech0/internal/setting"
 	"github.com/lin-snow/ech0/pkg/busen"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
@@ -21,15 +24,17 @@ import (
 
 const snapshotScheduleTag = "SnapshotSchedule"
 
-// Snapshot 定时创建系统快照（产出统一 Snapshot，含尽力 S3 上传）。它自管「调度 + 订阅」整个
+// Snapshot 定时在本地增量快照链上追加一份归档，配置了对象存储时再提交 snapshot_upload 作业
+// 把链同步上去（只传新归档，不再每次重传全部媒体）。它自管「调度 + 订阅」整个
 // 生命周期：Schedule 时捕获 scheduler 并订阅 UpdateSnapshotSchedule，收到即 reload；OnStop 退订。
 // 计划配置统一经 setting 引擎读 durableKV（而非依赖整个 SettingService），从根上断开
 // 「SettingService → Snapshot → SettingService」的构造环，也无需跨注入器的订阅者壳 / 反射查找。
-// 打包 + S3 的执行收敛到 migrator.ExportEngine，定时快照不走 job.Manager（无需 UI 状态/取消，
-// 且避免与手动导出抢占同一作业行）。
+// 打包收敛到 migrator.ExportEngine，不走 job.Manager（无需 UI 状态/取消，且避免与手动导出抢占
+// 同一作业行）；上传则交给作业框架，中断后由它按断点续传。
 type Snapshot struct {
 	durableKV kvstore.Store
 	exporter  *coreMigrator.ExportEngine
+	jobs      jobSubmitter
 	bus       *busen.Bus
 
 	// mu 同时保护 scheduler/unsub 字段与「移除旧作业 + 挂新作业」的重配过程。
@@ -38,17 +43,27 @@ type Snapshot struct {
 	unsub     func()           // 总线订阅的退订句柄，OnStop 时调用
 }
 
+// jobSubmitter 是提交上传作业所需的最小能力（由 *job.Manager 满足）。
+type jobSubmitter interface {
+	Submit(ctx context.Context, jobType string, payload []byte) (jobModel.Job, error)
+	Current(ctx context.Context, jobType string) (jobModel.Job, error)
+}
+
 func NewSnapshot(
 	durableKV kvstore.Store,
 	exporter *coreMigrator.ExportEngine,
+	jobManager *job.Manager,
 	busProvider func() *busen.Bus,
 ) *Snapshot {
-	return &Snapshot{durableKV: durableKV, exporter: exporter, bus: busProvider()}
+	return &Snapshot{durableKV: durableKV, exporter: exporter, jobs: jobManager, bus: busProvider()}
 }
 
 func (s *Snapshot) Name() string { return "snapshot" }
 
 // Schedule 捕获 scheduler，订阅运行期计划变更，并按当前计划挂上定时快照作业。
+// 启动时顺带补交一次链上传，把上次进程没传完的归档带上去。上次进程若在上传途中退出，
+// 那条作业行会由 job.Manager 按断点续跑（停机时退回 pending，崩溃时由 Start 接管），
+// 此时已有排队或在跑的上传，不再重复提交。
 func (s *Snapshot) Schedule(ctx context.Context, scheduler gocron.Scheduler) error {
 	s.mu.Lock()
 	s.scheduler = scheduler
@@ -57,7 +72,13 @@ func (s *Snapshot) Schedule(ctx context.Context, scheduler gocron.Scheduler) err
 	if err := s.subscribe(); err != nil {
 		return err
 	}
-	return s.reload(ctx)
+	if err := s.reload(ctx); err != nil {
+		return err
+	}
+	if !s.uploadInFlight(ctx) {
+		s.submitUpload(ctx)
+	}
+	return nil
 }
 
 // OnStop 退订总线，避免停机后残留订阅。实现 task.StopHook。
@@ -136,13 +157,21 @@ func (s *Snapshot) scheduleJob(cronExpression string) error {
 		gocron.NewTask(func() {
 			ctx := context.Background()
 
-			if _, err := s.exporter.Export(ctx, func(string, any) {}); err != nil {
+			result, err := s.exporter.ExportIncremental(ctx, func(string, any) {})
+			if err != nil {
 				logUtil.GetLogger().Error("Failed to execute scheduled snapshot",
 					slog.String("module", logModule),
 					logUtil.Err(err))
 				return
 			}
-
+			logUtil.GetLogger().Info("Scheduled snapshot written",
+				slog.String("module", logModule),
+				slog.String("archive", result.Name),
+				slog.Bool("base", result.Base),
+				slog.Int("changed", result.Changed),
+				slog.Bool("unchanged", result.Unchanged))
+
+			s.submitUpload(ctx)
 			eventbus.Notify(ctx, s.bus, event.SystemSnapshot{Info: "System scheduled snapshot completed"})
 		}),
 		gocron.WithTags(snapshotScheduleTag),
@@ -156,3 +185,30 @@ func (s *Snapshot) scheduleJob(cronExpression string) error {
 	}
 	return err
 }
+
+// submitUpload 在配置了对象存储时提交链上传作业。上一次上传仍在跑时排到它后面；队列已满
+// 则直接跳过——排着的那次开跑时会重新扫描本地目录，新归档一并带上。
+func (s *Snapshot) submitUpload(ctx context.Context) {
+	if s.jobs == nil || !s.exporter.ObjectEnabled() {
+		return
+	}
+	if _, err := s.jobs.Submit(ctx, jobModel.TypeSnapshotUpload, nil); err != nil {
+		if errors.Is(err, job.ErrAlreadyRunning) || errors.Is(err, job.ErrQueueFull) {
+			return
+		}
+		logUtil.GetLogger().Warn("Failed to submit snapshot upload",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+}
+
+// uploadInFlight 判断是否已有排队或在跑的链上传作业。查询失败时按没有处理，宁可多交一次。
+func (s *Snapshot) uploadInFlight(ctx context.Context) bool {
+	if s.jobs == nil {
+		return false
+	}
+	current, err := s.jobs.Current(ctx, jobModel.TypeSnapshotUpload)
+	if err != nil {
+		return false
+	}
+	return current.Status == jobModel.StatusPending || current.Status == jobModel.StatusRunning
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/job"
+	jobModel "github.com/lin-snow/ech0/internal/model/job"
+)
+
+type currentJob struct {
+	job jobModel.Job
+	err error
+}
+
+func (c currentJob) Submit(context.Context, string, []byte) (jobModel.Job, error) {
+	return jobModel.Job{}, errors.New("unexpected submit")
+}
+
+func (c currentJob) Current(context.Context, string) (jobModel.Job, error) {
+	return c.job, c.err
+}
+
+func TestSnapshotUploadInFlight(t *testing.T) {
+	cases := []struct {
+		name string
+		jobs jobSubmitter
+		want bool
+	}{
+		{"no manager", nil, false},
+		{"never uploaded", currentJob{err: job.ErrNotFound}, false},
+		{"pending", currentJob{job: jobModel.Job{Status: jobModel.StatusPending}}, true},
+		{"running", currentJob{job: jobModel.Job{Status: jobModel.StatusRunning}}, true},
+		{"last one failed", currentJob{job: jobModel.Job{Status: jobModel.StatusFailed}}, false},
+	}
+	for _, tc := range cases {
+		s := &Snapshot{jobs: tc.jobs}
+		if got := s.uploadInFlight(context.Background()); got != tc.want {
+			t.Errorf("%s: uploadInFlight = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package scheduled
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/lin-snow/ech0/internal/config"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	echoService "github.com/lin-snow/ech0/internal/service/echo"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+)
+
+// purgeTrashInterval 是回收站过期清理的执行间隔。
+const purgeTrashInterval = 24 * time.Hour
+
+// PurgeTrash 每天彻底删除回收站中超过保留期（config.Trash.RetentionDays）的 Echo 与评论。
+type PurgeTrash struct {
+	echoService    echoService.Service
+	commentService commentService.Service
+}
+
+func NewPurgeTrash(echoSvc echoService.Service, commentSvc commentService.Service) *PurgeTrash {
+	return &PurgeTrash{echoService: echoSvc, commentService: commentSvc}
+}
+
+func (p *PurgeTrash) Name() string { return "purge-trash" }
+
+// Schedule 每天清理一次过期的回收站条目；启动时立即执行，补上停机期间错过的清理。
+func (p *PurgeTrash) Schedule(_ context.Context, s gocron.Scheduler) error {
+	_, err := s.NewJob(
+		gocron.DurationJob(purgeTrashInterval),
+		gocron.NewTask(p.run),
+		gocron.WithStartAt(gocron.WithStartImmediately()),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+	)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to schedule trash purge task",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	return err
+}
+
+func (p *PurgeTrash) run() {
+	days := config.Config().Trash.RetentionDays
+	if days <= 0 {
+		return
+	}
+	ctx := context.Background()
+	before := time.Now().Add(-time.Duration(days) * 24 * time.Hour).Unix()
+
+	echos, err := p.echoService.PurgeExpiredTrash(ctx, before)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to purge trashed echos",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	comments, err := p.commentService.PurgeExpiredTrash(ctx, before)
+	if err != nil {
+		logUtil.GetLogger().Error("Failed to purge trashed comments",
+			slog.String("module", logModule), logUtil.Err(err))
+	}
+	if echos > 0 || comments > 0 {
+		logUtil.GetLogger().Info("Purged expired trash",
+			slog.String("module", logModule),
+			slog.Int("echos", echos),
+			slog.Int("comments", comments))
+	}
+}
@@ -18,3 +18,21 @@ func SetJWTSecret(t *testing.T, secret string) {
 	cfg.Security.JWTSecret = []byte(secret)
 	t.Cleanup(func() { cfg.Security.JWTSecret = prev })
 }
+
+// SetActivityPub 覆写测试期的联邦开关（ECH0_ACTIVITYPUB_ENABLED），并在测试结束时还原。
+func SetActivityPub(t *testing.T, enabled bool) {
+	t.Helper()
+	cfg := config.Config()
+	prev := cfg.Federation.ActivityPub
+	cfg.Federation.ActivityPub = enabled
+	t.Cleanup(func() { cfg.Federation.ActivityPub = prev })
+}
+
+// SetWebmention 覆写测试期的 Webmention 开关（ECH0_WEBMENTION_ENABLED），并在测试结束时还原。
+func SetWebmention(t *testing.T, enabled bool) {
+	t.Helper()
+	cfg := config.Config()
+	prev := cfg.Federation.Webmention
+	cfg.Federation.Webmention = enabled
+	t.Cleanup(func() { cfg.Federation.Webmention = prev })
+}
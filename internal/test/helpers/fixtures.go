@@ -20,6 +20,16 @@ func NewUser(opts ...func(*userModel.User)) userModel.User {
 	return u
 }
 
+// WithUserID 覆盖用户 ID，用于构造「非作者」的调用方。
+func WithUserID(id string) func(*userModel.User) {
+	return func(u *userModel.User) { u.ID = id }
+}
+
+// WithRole 设置用户角色，并同步 IsAdmin / IsOwner。
+func WithRole(role userModel.Role) func(*userModel.User) {
+	return func(u *userModel.User) { u.SetRole(role) }
+}
+
 // AsAdmin 把用户标记为管理员。
 func AsAdmin(u *userModel.User) { u.IsAdmin = true }
 
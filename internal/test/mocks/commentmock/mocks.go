@@ -183,6 +183,72 @@ func (_c *MockService_CreateComment_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// CreateFederatedComment provides a mock function for the type MockService
+func (_mock *MockService) CreateFederatedComment(ctx context.Context, dto *model.CreateFederatedCommentDto) (model.CreateCommentResult, error) {
+	ret := _mock.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateFederatedComment")
+	}
+
+	var r0 model.CreateCommentResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CreateFederatedCommentDto) (model.CreateCommentResult, error)); ok {
+		return returnFunc(ctx, dto)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CreateFederatedCommentDto) model.CreateCommentResult); ok {
+		r0 = returnFunc(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(model.CreateCommentResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.CreateFederatedCommentDto) error); ok {
+		r1 = returnFunc(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateFederatedComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateFederatedComment'
+type MockService_CreateFederatedComment_Call struct {
+	*mock.Call
+}
+
+// CreateFederatedComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dto *model.CreateFederatedCommentDto
+func (_e *MockService_Expecter) CreateFederatedComment(ctx any, dto any) *MockService_CreateFederatedComment_Call {
+	return &MockService_CreateFederatedComment_Call{Call: _e.mock.On("CreateFederatedComment", ctx, dto)}
+}
+
+func (_c *MockService_CreateFederatedComment_Call) Run(run func(ctx context.Context, dto *model.CreateFederatedCommentDto)) *MockService_CreateFederatedComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.CreateFederatedCommentDto
+		if args[1] != nil {
+			arg1 = args[1].(*model.CreateFederatedCommentDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateFederatedComment_Call) Return(createCommentResult model.CreateCommentResult, err error) *MockService_CreateFederatedComment_Call {
+	_c.Call.Return(createCommentResult, err)
+	return _c
+}
+
+func (_c *MockService_CreateFederatedComment_Call) RunAndReturn(run func(ctx context.Context, dto *model.CreateFederatedCommentDto) (model.CreateCommentResult, error)) *MockService_CreateFederatedComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateIntegrationComment provides a mock function for the type MockService
 func (_mock *MockService) CreateIntegrationComment(ctx context.Context, clientIP string, userAgent string, dto *model.CreateIntegrationCommentDto) (model.CreateCommentResult, error) {
 	ret := _mock.Called(ctx, clientIP, userAgent, dto)
@@ -261,6 +327,72 @@ func (_c *MockService_CreateIntegrationComment_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
+// CreateWebmentionComment provides a mock function for the type MockService
+func (_mock *MockService) CreateWebmentionComment(ctx context.Context, dto *model.CreateWebmentionCommentDto) (model.CreateCommentResult, error) {
+	ret := _mock.Called(ctx, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWebmentionComment")
+	}
+
+	var r0 model.CreateCommentResult
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CreateWebmentionCommentDto) (model.CreateCommentResult, error)); ok {
+		return returnFunc(ctx, dto)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CreateWebmentionCommentDto) model.CreateCommentResult); ok {
+		r0 = returnFunc(ctx, dto)
+	} else {
+		r0 = ret.Get(0).(model.CreateCommentResult)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.CreateWebmentionCommentDto) error); ok {
+		r1 = returnFunc(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_CreateWebmentionComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebmentionComment'
+type MockService_CreateWebmentionComment_Call struct {
+	*mock.Call
+}
+
+// CreateWebmentionComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dto *model.CreateWebmentionCommentDto
+func (_e *MockService_Expecter) CreateWebmentionComment(ctx any, dto any) *MockService_CreateWebmentionComment_Call {
+	return &MockService_CreateWebmentionComment_Call{Call: _e.mock.On("CreateWebmentionComment", ctx, dto)}
+}
+
+func (_c *MockService_CreateWebmentionComment_Call) Run(run func(ctx context.Context, dto *model.CreateWebmentionCommentDto)) *MockService_CreateWebmentionComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.CreateWebmentionCommentDto
+		if args[1] != nil {
+			arg1 = args[1].(*model.CreateWebmentionCommentDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_CreateWebmentionComment_Call) Return(createCommentResult model.CreateCommentResult, err error) *MockService_CreateWebmentionComment_Call {
+	_c.Call.Return(createCommentResult, err)
+	return _c
+}
+
+func (_c *MockService_CreateWebmentionComment_Call) RunAndReturn(run func(ctx context.Context, dto *model.CreateWebmentionCommentDto) (model.CreateCommentResult, error)) *MockService_CreateWebmentionComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DeleteComment provides a mock function for the type MockService
 func (_mock *MockService) DeleteComment(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
@@ -318,6 +450,72 @@ func (_c *MockService_DeleteComment_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// FindFederatedComment provides a mock function for the type MockService
+func (_mock *MockService) FindFederatedComment(ctx context.Context, remoteID string) (model.Comment, error) {
+	ret := _mock.Called(ctx, remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindFederatedComment")
+	}
+
+	var r0 model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Comment, error)); ok {
+		return returnFunc(ctx, remoteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Comment); ok {
+		r0 = returnFunc(ctx, remoteID)
+	} else {
+		r0 = ret.Get(0).(model.Comment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_FindFederatedComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindFederatedComment'
+type MockService_FindFederatedComment_Call struct {
+	*mock.Call
+}
+
+// FindFederatedComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - remoteID string
+func (_e *MockService_Expecter) FindFederatedComment(ctx any, remoteID any) *MockService_FindFederatedComment_Call {
+	return &MockService_FindFederatedComment_Call{Call: _e.mock.On("FindFederatedComment", ctx, remoteID)}
+}
+
+func (_c *MockService_FindFederatedComment_Call) Run(run func(ctx context.Context, remoteID string)) *MockService_FindFederatedComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_FindFederatedComment_Call) Return(comment model.Comment, err error) *MockService_FindFederatedComment_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MockService_FindFederatedComment_Call) RunAndReturn(run func(ctx context.Context, remoteID string) (model.Comment, error)) *MockService_FindFederatedComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetCommentByID provides a mock function for the type MockService
 func (_mock *MockService) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
 	ret := _mock.Called(ctx, id)
@@ -385,8 +583,8 @@ func (_c *MockService_GetCommentByID_Call) RunAndReturn(run func(ctx context.Con
 }
 
 // GetFormMeta provides a mock function for the type MockService
-func (_mock *MockService) GetFormMeta(ctx context.Context, clientIP string, apiBaseURL string) (model.FormMeta, error) {
-	ret := _mock.Called(ctx, clientIP, apiBaseURL)
+func (_mock *MockService) GetFormMeta(ctx context.Context, clientIP string, apiBaseURL string, echoID string) (model.FormMeta, error) {
+	ret := _mock.Called(ctx, clientIP, apiBaseURL, echoID)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetFormMeta")
@@ -394,16 +592,16 @@ func (_mock *MockService) GetFormMeta(ctx context.Context, clientIP string, apiB
 
 	var r0 model.FormMeta
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.FormMeta, error)); ok {
-		return returnFunc(ctx, clientIP, apiBaseURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (model.FormMeta, error)); ok {
+		return returnFunc(ctx, clientIP, apiBaseURL, echoID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.FormMeta); ok {
-		r0 = returnFunc(ctx, clientIP, apiBaseURL)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) model.FormMeta); ok {
+		r0 = returnFunc(ctx, clientIP, apiBaseURL, echoID)
 	} else {
 		r0 = ret.Get(0).(model.FormMeta)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
-		r1 = returnFunc(ctx, clientIP, apiBaseURL)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, clientIP, apiBaseURL, echoID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -419,11 +617,12 @@ type MockService_GetFormMeta_Call struct {
 //   - ctx context.Context
 //   - clientIP string
 //   - apiBaseURL string
-func (_e *MockService_Expecter) GetFormMeta(ctx any, clientIP any, apiBaseURL any) *MockService_GetFormMeta_Call {
-	return &MockService_GetFormMeta_Call{Call: _e.mock.On("GetFormMeta", ctx, clientIP, apiBaseURL)}
+//   - echoID string
+func (_e *MockService_Expecter) GetFormMeta(ctx any, clientIP any, apiBaseURL any, echoID any) *MockService_GetFormMeta_Call {
+	return &MockService_GetFormMeta_Call{Call: _e.mock.On("GetFormMeta", ctx, clientIP, apiBaseURL, echoID)}
 }
 
-func (_c *MockService_GetFormMeta_Call) Run(run func(ctx context.Context, clientIP string, apiBaseURL string)) *MockService_GetFormMeta_Call {
+func (_c *MockService_GetFormMeta_Call) Run(run func(ctx context.Context, clientIP string, apiBaseURL string, echoID string)) *MockService_GetFormMeta_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -437,10 +636,15 @@ func (_c *MockService_GetFormMeta_Call) Run(run func(ctx context.Context, client
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
@@ -451,7 +655,7 @@ func (_c *MockService_GetFormMeta_Call) Return(formMeta model.FormMeta, err erro
 	return _c
 }
 
-func (_c *MockService_GetFormMeta_Call) RunAndReturn(run func(ctx context.Context, clientIP string, apiBaseURL string) (model.FormMeta, error)) *MockService_GetFormMeta_Call {
+func (_c *MockService_GetFormMeta_Call) RunAndReturn(run func(ctx context.Context, clientIP string, apiBaseURL string, echoID string) (model.FormMeta, error)) *MockService_GetFormMeta_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -718,44 +922,55 @@ func (_c *MockService_ListPublicComments_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
-// SendTestEmail provides a mock function for the type MockService
-func (_mock *MockService) SendTestEmail(ctx context.Context, setting model.SystemSetting) error {
-	ret := _mock.Called(ctx, setting)
+// ListPublicThread provides a mock function for the type MockService
+func (_mock *MockService) ListPublicThread(ctx context.Context, id string) ([]model.PublicComment, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SendTestEmail")
+		panic("no return value specified for ListPublicThread")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SystemSetting) error); ok {
-		r0 = returnFunc(ctx, setting)
+	var r0 []model.PublicComment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.PublicComment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.PublicComment); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PublicComment)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_SendTestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTestEmail'
-type MockService_SendTestEmail_Call struct {
+// MockService_ListPublicThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicThread'
+type MockService_ListPublicThread_Call struct {
 	*mock.Call
 }
 
-// SendTestEmail is a helper method to define mock.On call
+// ListPublicThread is a helper method to define mock.On call
 //   - ctx context.Context
-//   - setting model.SystemSetting
-func (_e *MockService_Expecter) SendTestEmail(ctx any, setting any) *MockService_SendTestEmail_Call {
-	return &MockService_SendTestEmail_Call{Call: _e.mock.On("SendTestEmail", ctx, setting)}
+//   - id string
+func (_e *MockService_Expecter) ListPublicThread(ctx any, id any) *MockService_ListPublicThread_Call {
+	return &MockService_ListPublicThread_Call{Call: _e.mock.On("ListPublicThread", ctx, id)}
 }
 
-func (_c *MockService_SendTestEmail_Call) Run(run func(ctx context.Context, setting model.SystemSetting)) *MockService_SendTestEmail_Call {
+func (_c *MockService_ListPublicThread_Call) Run(run func(ctx context.Context, id string)) *MockService_ListPublicThread_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.SystemSetting
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(model.SystemSetting)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -765,47 +980,46 @@ func (_c *MockService_SendTestEmail_Call) Run(run func(ctx context.Context, sett
 	return _c
 }
 
-func (_c *MockService_SendTestEmail_Call) Return(err error) *MockService_SendTestEmail_Call {
-	_c.Call.Return(err)
+func (_c *MockService_ListPublicThread_Call) Return(publicComments []model.PublicComment, err error) *MockService_ListPublicThread_Call {
+	_c.Call.Return(publicComments, err)
 	return _c
 }
 
-func (_c *MockService_SendTestEmail_Call) RunAndReturn(run func(ctx context.Context, setting model.SystemSetting) error) *MockService_SendTestEmail_Call {
+func (_c *MockService_ListPublicThread_Call) RunAndReturn(run func(ctx context.Context, id string) ([]model.PublicComment, error)) *MockService_ListPublicThread_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateCommentHot provides a mock function for the type MockService
-func (_mock *MockService) UpdateCommentHot(ctx context.Context, id string, hot bool) error {
-	ret := _mock.Called(ctx, id, hot)
+// PurgeComment provides a mock function for the type MockService
+func (_mock *MockService) PurgeComment(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateCommentHot")
+		panic("no return value specified for PurgeComment")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
-		r0 = returnFunc(ctx, id, hot)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateCommentHot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentHot'
-type MockService_UpdateCommentHot_Call struct {
+// MockService_PurgeComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeComment'
+type MockService_PurgeComment_Call struct {
 	*mock.Call
 }
 
-// UpdateCommentHot is a helper method to define mock.On call
+// PurgeComment is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-//   - hot bool
-func (_e *MockService_Expecter) UpdateCommentHot(ctx any, id any, hot any) *MockService_UpdateCommentHot_Call {
-	return &MockService_UpdateCommentHot_Call{Call: _e.mock.On("UpdateCommentHot", ctx, id, hot)}
+func (_e *MockService_Expecter) PurgeComment(ctx any, id any) *MockService_PurgeComment_Call {
+	return &MockService_PurgeComment_Call{Call: _e.mock.On("PurgeComment", ctx, id)}
 }
 
-func (_c *MockService_UpdateCommentHot_Call) Run(run func(ctx context.Context, id string, hot bool)) *MockService_UpdateCommentHot_Call {
+func (_c *MockService_PurgeComment_Call) Run(run func(ctx context.Context, id string)) *MockService_PurgeComment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -815,130 +1029,128 @@ func (_c *MockService_UpdateCommentHot_Call) Run(run func(ctx context.Context, i
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 bool
-		if args[2] != nil {
-			arg2 = args[2].(bool)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateCommentHot_Call) Return(err error) *MockService_UpdateCommentHot_Call {
+func (_c *MockService_PurgeComment_Call) Return(err error) *MockService_PurgeComment_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateCommentHot_Call) RunAndReturn(run func(ctx context.Context, id string, hot bool) error) *MockService_UpdateCommentHot_Call {
+func (_c *MockService_PurgeComment_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_PurgeComment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateCommentStatus provides a mock function for the type MockService
-func (_mock *MockService) UpdateCommentStatus(ctx context.Context, id string, status model.Status) error {
-	ret := _mock.Called(ctx, id, status)
+// PurgeExpiredTrash provides a mock function for the type MockService
+func (_mock *MockService) PurgeExpiredTrash(ctx context.Context, before int64) (int, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateCommentStatus")
+		panic("no return value specified for PurgeExpiredTrash")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Status) error); ok {
-		r0 = returnFunc(ctx, id, status)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (int, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) int); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_UpdateCommentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentStatus'
-type MockService_UpdateCommentStatus_Call struct {
+// MockService_PurgeExpiredTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredTrash'
+type MockService_PurgeExpiredTrash_Call struct {
 	*mock.Call
 }
 
-// UpdateCommentStatus is a helper method to define mock.On call
+// PurgeExpiredTrash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-//   - status model.Status
-func (_e *MockService_Expecter) UpdateCommentStatus(ctx any, id any, status any) *MockService_UpdateCommentStatus_Call {
-	return &MockService_UpdateCommentStatus_Call{Call: _e.mock.On("UpdateCommentStatus", ctx, id, status)}
+//   - before int64
+func (_e *MockService_Expecter) PurgeExpiredTrash(ctx any, before any) *MockService_PurgeExpiredTrash_Call {
+	return &MockService_PurgeExpiredTrash_Call{Call: _e.mock.On("PurgeExpiredTrash", ctx, before)}
 }
 
-func (_c *MockService_UpdateCommentStatus_Call) Run(run func(ctx context.Context, id string, status model.Status)) *MockService_UpdateCommentStatus_Call {
+func (_c *MockService_PurgeExpiredTrash_Call) Run(run func(ctx context.Context, before int64)) *MockService_PurgeExpiredTrash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 model.Status
-		if args[2] != nil {
-			arg2 = args[2].(model.Status)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockService_UpdateCommentStatus_Call) Return(err error) *MockService_UpdateCommentStatus_Call {
-	_c.Call.Return(err)
+func (_c *MockService_PurgeExpiredTrash_Call) Return(n int, err error) *MockService_PurgeExpiredTrash_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockService_UpdateCommentStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status model.Status) error) *MockService_UpdateCommentStatus_Call {
+func (_c *MockService_PurgeExpiredTrash_Call) RunAndReturn(run func(ctx context.Context, before int64) (int, error)) *MockService_PurgeExpiredTrash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateSystemSetting provides a mock function for the type MockService
-func (_mock *MockService) UpdateSystemSetting(ctx context.Context, setting model.SystemSetting) error {
-	ret := _mock.Called(ctx, setting)
+// RestoreComment provides a mock function for the type MockService
+func (_mock *MockService) RestoreComment(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateSystemSetting")
+		panic("no return value specified for RestoreComment")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SystemSetting) error); ok {
-		r0 = returnFunc(ctx, setting)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateSystemSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSystemSetting'
-type MockService_UpdateSystemSetting_Call struct {
+// MockService_RestoreComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreComment'
+type MockService_RestoreComment_Call struct {
 	*mock.Call
 }
 
-// UpdateSystemSetting is a helper method to define mock.On call
+// RestoreComment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - setting model.SystemSetting
-func (_e *MockService_Expecter) UpdateSystemSetting(ctx any, setting any) *MockService_UpdateSystemSetting_Call {
-	return &MockService_UpdateSystemSetting_Call{Call: _e.mock.On("UpdateSystemSetting", ctx, setting)}
+//   - id string
+func (_e *MockService_Expecter) RestoreComment(ctx any, id any) *MockService_RestoreComment_Call {
+	return &MockService_RestoreComment_Call{Call: _e.mock.On("RestoreComment", ctx, id)}
 }
 
-func (_c *MockService_UpdateSystemSetting_Call) Run(run func(ctx context.Context, setting model.SystemSetting)) *MockService_UpdateSystemSetting_Call {
+func (_c *MockService_RestoreComment_Call) Run(run func(ctx context.Context, id string)) *MockService_RestoreComment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.SystemSetting
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(model.SystemSetting)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -948,203 +1160,177 @@ func (_c *MockService_UpdateSystemSetting_Call) Run(run func(ctx context.Context
 	return _c
 }
 
-func (_c *MockService_UpdateSystemSetting_Call) Return(err error) *MockService_UpdateSystemSetting_Call {
+func (_c *MockService_RestoreComment_Call) Return(err error) *MockService_RestoreComment_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateSystemSetting_Call) RunAndReturn(run func(ctx context.Context, setting model.SystemSetting) error) *MockService_UpdateSystemSetting_Call {
+func (_c *MockService_RestoreComment_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_RestoreComment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockRepository {
-	mock := &MockRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockRepository is an autogenerated mock type for the Repository type
-type MockRepository struct {
-	mock.Mock
-}
-
-type MockRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
-	return &MockRepository_Expecter{mock: &_m.Mock}
-}
-
-// BatchDelete provides a mock function for the type MockRepository
-func (_mock *MockRepository) BatchDelete(ctx context.Context, ids []string) error {
-	ret := _mock.Called(ctx, ids)
+// RetractWebmentionComment provides a mock function for the type MockService
+func (_mock *MockService) RetractWebmentionComment(ctx context.Context, echoID string, source string) error {
+	ret := _mock.Called(ctx, echoID, source)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BatchDelete")
+		panic("no return value specified for RetractWebmentionComment")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
-		r0 = returnFunc(ctx, ids)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, echoID, source)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_BatchDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchDelete'
-type MockRepository_BatchDelete_Call struct {
+// MockService_RetractWebmentionComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetractWebmentionComment'
+type MockService_RetractWebmentionComment_Call struct {
 	*mock.Call
 }
 
-// BatchDelete is a helper method to define mock.On call
+// RetractWebmentionComment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ids []string
-func (_e *MockRepository_Expecter) BatchDelete(ctx any, ids any) *MockRepository_BatchDelete_Call {
-	return &MockRepository_BatchDelete_Call{Call: _e.mock.On("BatchDelete", ctx, ids)}
+//   - echoID string
+//   - source string
+func (_e *MockService_Expecter) RetractWebmentionComment(ctx any, echoID any, source any) *MockService_RetractWebmentionComment_Call {
+	return &MockService_RetractWebmentionComment_Call{Call: _e.mock.On("RetractWebmentionComment", ctx, echoID, source)}
 }
 
-func (_c *MockRepository_BatchDelete_Call) Run(run func(ctx context.Context, ids []string)) *MockRepository_BatchDelete_Call {
+func (_c *MockService_RetractWebmentionComment_Call) Run(run func(ctx context.Context, echoID string, source string)) *MockService_RetractWebmentionComment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_BatchDelete_Call) Return(err error) *MockRepository_BatchDelete_Call {
+func (_c *MockService_RetractWebmentionComment_Call) Return(err error) *MockService_RetractWebmentionComment_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_BatchDelete_Call) RunAndReturn(run func(ctx context.Context, ids []string) error) *MockRepository_BatchDelete_Call {
+func (_c *MockService_RetractWebmentionComment_Call) RunAndReturn(run func(ctx context.Context, echoID string, source string) error) *MockService_RetractWebmentionComment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// BatchUpdateStatus provides a mock function for the type MockRepository
-func (_mock *MockRepository) BatchUpdateStatus(ctx context.Context, ids []string, status model.Status) error {
-	ret := _mock.Called(ctx, ids, status)
+// SendTestEmail provides a mock function for the type MockService
+func (_mock *MockService) SendTestEmail(ctx context.Context, setting model.SystemSetting) error {
+	ret := _mock.Called(ctx, setting)
 
 	if len(ret) == 0 {
-		panic("no return value specified for BatchUpdateStatus")
+		panic("no return value specified for SendTestEmail")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, model.Status) error); ok {
-		r0 = returnFunc(ctx, ids, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SystemSetting) error); ok {
+		r0 = returnFunc(ctx, setting)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_BatchUpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchUpdateStatus'
-type MockRepository_BatchUpdateStatus_Call struct {
+// MockService_SendTestEmail_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendTestEmail'
+type MockService_SendTestEmail_Call struct {
 	*mock.Call
 }
 
-// BatchUpdateStatus is a helper method to define mock.On call
+// SendTestEmail is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ids []string
-//   - status model.Status
-func (_e *MockRepository_Expecter) BatchUpdateStatus(ctx any, ids any, status any) *MockRepository_BatchUpdateStatus_Call {
-	return &MockRepository_BatchUpdateStatus_Call{Call: _e.mock.On("BatchUpdateStatus", ctx, ids, status)}
+//   - setting model.SystemSetting
+func (_e *MockService_Expecter) SendTestEmail(ctx any, setting any) *MockService_SendTestEmail_Call {
+	return &MockService_SendTestEmail_Call{Call: _e.mock.On("SendTestEmail", ctx, setting)}
 }
 
-func (_c *MockRepository_BatchUpdateStatus_Call) Run(run func(ctx context.Context, ids []string, status model.Status)) *MockRepository_BatchUpdateStatus_Call {
+func (_c *MockService_SendTestEmail_Call) Run(run func(ctx context.Context, setting model.SystemSetting)) *MockService_SendTestEmail_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 model.SystemSetting
 		if args[1] != nil {
-			arg1 = args[1].([]string)
-		}
-		var arg2 model.Status
-		if args[2] != nil {
-			arg2 = args[2].(model.Status)
+			arg1 = args[1].(model.SystemSetting)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_BatchUpdateStatus_Call) Return(err error) *MockRepository_BatchUpdateStatus_Call {
+func (_c *MockService_SendTestEmail_Call) Return(err error) *MockService_SendTestEmail_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_BatchUpdateStatus_Call) RunAndReturn(run func(ctx context.Context, ids []string, status model.Status) error) *MockRepository_BatchUpdateStatus_Call {
+func (_c *MockService_SendTestEmail_Call) RunAndReturn(run func(ctx context.Context, setting model.SystemSetting) error) *MockService_SendTestEmail_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountByEmailWithin provides a mock function for the type MockRepository
-func (_mock *MockRepository) CountByEmailWithin(ctx context.Context, email string, seconds int64) (int64, error) {
-	ret := _mock.Called(ctx, email, seconds)
+// ToggleReaction provides a mock function for the type MockService
+func (_mock *MockService) ToggleReaction(ctx context.Context, clientIP string, id string, dto *model.ReactCommentDto) (model.ReactCommentResult, error) {
+	ret := _mock.Called(ctx, clientIP, id, dto)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountByEmailWithin")
+		panic("no return value specified for ToggleReaction")
 	}
 
-	var r0 int64
+	var r0 model.ReactCommentResult
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
-		return returnFunc(ctx, email, seconds)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *model.ReactCommentDto) (model.ReactCommentResult, error)); ok {
+		return returnFunc(ctx, clientIP, id, dto)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
-		r0 = returnFunc(ctx, email, seconds)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *model.ReactCommentDto) model.ReactCommentResult); ok {
+		r0 = returnFunc(ctx, clientIP, id, dto)
 	} else {
-		r0 = ret.Get(0).(int64)
+		r0 = ret.Get(0).(model.ReactCommentResult)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = returnFunc(ctx, email, seconds)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, *model.ReactCommentDto) error); ok {
+		r1 = returnFunc(ctx, clientIP, id, dto)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_CountByEmailWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByEmailWithin'
-type MockRepository_CountByEmailWithin_Call struct {
+// MockService_ToggleReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ToggleReaction'
+type MockService_ToggleReaction_Call struct {
 	*mock.Call
 }
 
-// CountByEmailWithin is a helper method to define mock.On call
+// ToggleReaction is a helper method to define mock.On call
 //   - ctx context.Context
-//   - email string
-//   - seconds int64
-func (_e *MockRepository_Expecter) CountByEmailWithin(ctx any, email any, seconds any) *MockRepository_CountByEmailWithin_Call {
-	return &MockRepository_CountByEmailWithin_Call{Call: _e.mock.On("CountByEmailWithin", ctx, email, seconds)}
+//   - clientIP string
+//   - id string
+//   - dto *model.ReactCommentDto
+func (_e *MockService_Expecter) ToggleReaction(ctx any, clientIP any, id any, dto any) *MockService_ToggleReaction_Call {
+	return &MockService_ToggleReaction_Call{Call: _e.mock.On("ToggleReaction", ctx, clientIP, id, dto)}
 }
 
-func (_c *MockRepository_CountByEmailWithin_Call) Run(run func(ctx context.Context, email string, seconds int64)) *MockRepository_CountByEmailWithin_Call {
+func (_c *MockService_ToggleReaction_Call) Run(run func(ctx context.Context, clientIP string, id string, dto *model.ReactCommentDto)) *MockService_ToggleReaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1154,69 +1340,65 @@ func (_c *MockRepository_CountByEmailWithin_Call) Run(run func(ctx context.Conte
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 int64
+		var arg2 string
 		if args[2] != nil {
-			arg2 = args[2].(int64)
+			arg2 = args[2].(string)
+		}
+		var arg3 *model.ReactCommentDto
+		if args[3] != nil {
+			arg3 = args[3].(*model.ReactCommentDto)
 		}
 		run(
 			arg0,
 			arg1,
 			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_CountByEmailWithin_Call) Return(n int64, err error) *MockRepository_CountByEmailWithin_Call {
-	_c.Call.Return(n, err)
+func (_c *MockService_ToggleReaction_Call) Return(reactCommentResult model.ReactCommentResult, err error) *MockService_ToggleReaction_Call {
+	_c.Call.Return(reactCommentResult, err)
 	return _c
 }
 
-func (_c *MockRepository_CountByEmailWithin_Call) RunAndReturn(run func(ctx context.Context, email string, seconds int64) (int64, error)) *MockRepository_CountByEmailWithin_Call {
+func (_c *MockService_ToggleReaction_Call) RunAndReturn(run func(ctx context.Context, clientIP string, id string, dto *model.ReactCommentDto) (model.ReactCommentResult, error)) *MockService_ToggleReaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountByIPWithin provides a mock function for the type MockRepository
-func (_mock *MockRepository) CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
-	ret := _mock.Called(ctx, ipHash, seconds)
+// UpdateCommentHot provides a mock function for the type MockService
+func (_mock *MockService) UpdateCommentHot(ctx context.Context, id string, hot bool) error {
+	ret := _mock.Called(ctx, id, hot)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountByIPWithin")
+		panic("no return value specified for UpdateCommentHot")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
-		return returnFunc(ctx, ipHash, seconds)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
-		r0 = returnFunc(ctx, ipHash, seconds)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = returnFunc(ctx, ipHash, seconds)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, bool) error); ok {
+		r0 = returnFunc(ctx, id, hot)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepository_CountByIPWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByIPWithin'
-type MockRepository_CountByIPWithin_Call struct {
+// MockService_UpdateCommentHot_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentHot'
+type MockService_UpdateCommentHot_Call struct {
 	*mock.Call
 }
 
-// CountByIPWithin is a helper method to define mock.On call
+// UpdateCommentHot is a helper method to define mock.On call
 //   - ctx context.Context
-//   - ipHash string
-//   - seconds int64
-func (_e *MockRepository_Expecter) CountByIPWithin(ctx any, ipHash any, seconds any) *MockRepository_CountByIPWithin_Call {
-	return &MockRepository_CountByIPWithin_Call{Call: _e.mock.On("CountByIPWithin", ctx, ipHash, seconds)}
+//   - id string
+//   - hot bool
+func (_e *MockService_Expecter) UpdateCommentHot(ctx any, id any, hot any) *MockService_UpdateCommentHot_Call {
+	return &MockService_UpdateCommentHot_Call{Call: _e.mock.On("UpdateCommentHot", ctx, id, hot)}
 }
 
-func (_c *MockRepository_CountByIPWithin_Call) Run(run func(ctx context.Context, ipHash string, seconds int64)) *MockRepository_CountByIPWithin_Call {
+func (_c *MockService_UpdateCommentHot_Call) Run(run func(ctx context.Context, id string, hot bool)) *MockService_UpdateCommentHot_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1226,9 +1408,9 @@ func (_c *MockRepository_CountByIPWithin_Call) Run(run func(ctx context.Context,
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 int64
+		var arg2 bool
 		if args[2] != nil {
-			arg2 = args[2].(int64)
+			arg2 = args[2].(bool)
 		}
 		run(
 			arg0,
@@ -1239,56 +1421,47 @@ func (_c *MockRepository_CountByIPWithin_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepository_CountByIPWithin_Call) Return(n int64, err error) *MockRepository_CountByIPWithin_Call {
-	_c.Call.Return(n, err)
+func (_c *MockService_UpdateCommentHot_Call) Return(err error) *MockService_UpdateCommentHot_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_CountByIPWithin_Call) RunAndReturn(run func(ctx context.Context, ipHash string, seconds int64) (int64, error)) *MockRepository_CountByIPWithin_Call {
+func (_c *MockService_UpdateCommentHot_Call) RunAndReturn(run func(ctx context.Context, id string, hot bool) error) *MockService_UpdateCommentHot_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CountByUserWithin provides a mock function for the type MockRepository
-func (_mock *MockRepository) CountByUserWithin(ctx context.Context, userID string, seconds int64) (int64, error) {
-	ret := _mock.Called(ctx, userID, seconds)
+// UpdateCommentStatus provides a mock function for the type MockService
+func (_mock *MockService) UpdateCommentStatus(ctx context.Context, id string, status model.Status) error {
+	ret := _mock.Called(ctx, id, status)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CountByUserWithin")
+		panic("no return value specified for UpdateCommentStatus")
 	}
 
-	var r0 int64
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
-		return returnFunc(ctx, userID, seconds)
-	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
-		r0 = returnFunc(ctx, userID, seconds)
-	} else {
-		r0 = ret.Get(0).(int64)
-	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
-		r1 = returnFunc(ctx, userID, seconds)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Status) error); ok {
+		r0 = returnFunc(ctx, id, status)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
-	return r0, r1
+	return r0
 }
 
-// MockRepository_CountByUserWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUserWithin'
-type MockRepository_CountByUserWithin_Call struct {
+// MockService_UpdateCommentStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateCommentStatus'
+type MockService_UpdateCommentStatus_Call struct {
 	*mock.Call
 }
 
-// CountByUserWithin is a helper method to define mock.On call
+// UpdateCommentStatus is a helper method to define mock.On call
 //   - ctx context.Context
-//   - userID string
-//   - seconds int64
-func (_e *MockRepository_Expecter) CountByUserWithin(ctx any, userID any, seconds any) *MockRepository_CountByUserWithin_Call {
-	return &MockRepository_CountByUserWithin_Call{Call: _e.mock.On("CountByUserWithin", ctx, userID, seconds)}
+//   - id string
+//   - status model.Status
+func (_e *MockService_Expecter) UpdateCommentStatus(ctx any, id any, status any) *MockService_UpdateCommentStatus_Call {
+	return &MockService_UpdateCommentStatus_Call{Call: _e.mock.On("UpdateCommentStatus", ctx, id, status)}
 }
 
-func (_c *MockRepository_CountByUserWithin_Call) Run(run func(ctx context.Context, userID string, seconds int64)) *MockRepository_CountByUserWithin_Call {
+func (_c *MockService_UpdateCommentStatus_Call) Run(run func(ctx context.Context, id string, status model.Status)) *MockService_UpdateCommentStatus_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1298,9 +1471,9 @@ func (_c *MockRepository_CountByUserWithin_Call) Run(run func(ctx context.Contex
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 int64
+		var arg2 model.Status
 		if args[2] != nil {
-			arg2 = args[2].(int64)
+			arg2 = args[2].(model.Status)
 		}
 		run(
 			arg0,
@@ -1311,54 +1484,54 @@ func (_c *MockRepository_CountByUserWithin_Call) Run(run func(ctx context.Contex
 	return _c
 }
 
-func (_c *MockRepository_CountByUserWithin_Call) Return(n int64, err error) *MockRepository_CountByUserWithin_Call {
-	_c.Call.Return(n, err)
+func (_c *MockService_UpdateCommentStatus_Call) Return(err error) *MockService_UpdateCommentStatus_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_CountByUserWithin_Call) RunAndReturn(run func(ctx context.Context, userID string, seconds int64) (int64, error)) *MockRepository_CountByUserWithin_Call {
+func (_c *MockService_UpdateCommentStatus_Call) RunAndReturn(run func(ctx context.Context, id string, status model.Status) error) *MockService_UpdateCommentStatus_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateComment provides a mock function for the type MockRepository
-func (_mock *MockRepository) CreateComment(ctx context.Context, c *model.Comment) error {
-	ret := _mock.Called(ctx, c)
+// UpdateSystemSetting provides a mock function for the type MockService
+func (_mock *MockService) UpdateSystemSetting(ctx context.Context, setting model.SystemSetting) error {
+	ret := _mock.Called(ctx, setting)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateComment")
+		panic("no return value specified for UpdateSystemSetting")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Comment) error); ok {
-		r0 = returnFunc(ctx, c)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.SystemSetting) error); ok {
+		r0 = returnFunc(ctx, setting)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_CreateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateComment'
-type MockRepository_CreateComment_Call struct {
+// MockService_UpdateSystemSetting_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSystemSetting'
+type MockService_UpdateSystemSetting_Call struct {
 	*mock.Call
 }
 
-// CreateComment is a helper method to define mock.On call
+// UpdateSystemSetting is a helper method to define mock.On call
 //   - ctx context.Context
-//   - c *model.Comment
-func (_e *MockRepository_Expecter) CreateComment(ctx any, c any) *MockRepository_CreateComment_Call {
-	return &MockRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", ctx, c)}
+//   - setting model.SystemSetting
+func (_e *MockService_Expecter) UpdateSystemSetting(ctx any, setting any) *MockService_UpdateSystemSetting_Call {
+	return &MockService_UpdateSystemSetting_Call{Call: _e.mock.On("UpdateSystemSetting", ctx, setting)}
 }
 
-func (_c *MockRepository_CreateComment_Call) Run(run func(ctx context.Context, c *model.Comment)) *MockRepository_CreateComment_Call {
+func (_c *MockService_UpdateSystemSetting_Call) Run(run func(ctx context.Context, setting model.SystemSetting)) *MockService_UpdateSystemSetting_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Comment
+		var arg1 model.SystemSetting
 		if args[1] != nil {
-			arg1 = args[1].(*model.Comment)
+			arg1 = args[1].(model.SystemSetting)
 		}
 		run(
 			arg0,
@@ -1368,54 +1541,1397 @@ func (_c *MockRepository_CreateComment_Call) Run(run func(ctx context.Context, c
 	return _c
 }
 
-func (_c *MockRepository_CreateComment_Call) Return(err error) *MockRepository_CreateComment_Call {
+func (_c *MockService_UpdateSystemSetting_Call) Return(err error) *MockService_UpdateSystemSetting_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_CreateComment_Call) RunAndReturn(run func(ctx context.Context, c *model.Comment) error) *MockRepository_CreateComment_Call {
+func (_c *MockService_UpdateSystemSetting_Call) RunAndReturn(run func(ctx context.Context, setting model.SystemSetting) error) *MockService_UpdateSystemSetting_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteComment provides a mock function for the type MockRepository
-func (_mock *MockRepository) DeleteComment(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// AddReaction provides a mock function for the type MockRepository
+func (_mock *MockRepository) AddReaction(ctx context.Context, reaction *model.CommentReaction) (bool, error) {
+	ret := _mock.Called(ctx, reaction)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteComment")
+		panic("no return value specified for AddReaction")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CommentReaction) (bool, error)); ok {
+		return returnFunc(ctx, reaction)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.CommentReaction) bool); ok {
+		r0 = returnFunc(ctx, reaction)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *model.CommentReaction) error); ok {
+		r1 = returnFunc(ctx, reaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_AddReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddReaction'
+type MockRepository_AddReaction_Call struct {
+	*mock.Call
+}
+
+// AddReaction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reaction *model.CommentReaction
+func (_e *MockRepository_Expecter) AddReaction(ctx any, reaction any) *MockRepository_AddReaction_Call {
+	return &MockRepository_AddReaction_Call{Call: _e.mock.On("AddReaction", ctx, reaction)}
+}
+
+func (_c *MockRepository_AddReaction_Call) Run(run func(ctx context.Context, reaction *model.CommentReaction)) *MockRepository_AddReaction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.CommentReaction
+		if args[1] != nil {
+			arg1 = args[1].(*model.CommentReaction)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_AddReaction_Call) Return(b bool, err error) *MockRepository_AddReaction_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_AddReaction_Call) RunAndReturn(run func(ctx context.Context, reaction *model.CommentReaction) (bool, error)) *MockRepository_AddReaction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BatchDelete provides a mock function for the type MockRepository
+func (_mock *MockRepository) BatchDelete(ctx context.Context, ids []string) error {
+	ret := _mock.Called(ctx, ids)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchDelete")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = returnFunc(ctx, ids)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
-type MockRepository_DeleteComment_Call struct {
+// MockRepository_BatchDelete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchDelete'
+type MockRepository_BatchDelete_Call struct {
 	*mock.Call
 }
 
-// DeleteComment is a helper method to define mock.On call
+// BatchDelete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+func (_e *MockRepository_Expecter) BatchDelete(ctx any, ids any) *MockRepository_BatchDelete_Call {
+	return &MockRepository_BatchDelete_Call{Call: _e.mock.On("BatchDelete", ctx, ids)}
+}
+
+func (_c *MockRepository_BatchDelete_Call) Run(run func(ctx context.Context, ids []string)) *MockRepository_BatchDelete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_BatchDelete_Call) Return(err error) *MockRepository_BatchDelete_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_BatchDelete_Call) RunAndReturn(run func(ctx context.Context, ids []string) error) *MockRepository_BatchDelete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// BatchUpdateStatus provides a mock function for the type MockRepository
+func (_mock *MockRepository) BatchUpdateStatus(ctx context.Context, ids []string, status model.Status) error {
+	ret := _mock.Called(ctx, ids, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BatchUpdateStatus")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, model.Status) error); ok {
+		r0 = returnFunc(ctx, ids, status)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_BatchUpdateStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'BatchUpdateStatus'
+type MockRepository_BatchUpdateStatus_Call struct {
+	*mock.Call
+}
+
+// BatchUpdateStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []string
+//   - status model.Status
+func (_e *MockRepository_Expecter) BatchUpdateStatus(ctx any, ids any, status any) *MockRepository_BatchUpdateStatus_Call {
+	return &MockRepository_BatchUpdateStatus_Call{Call: _e.mock.On("BatchUpdateStatus", ctx, ids, status)}
+}
+
+func (_c *MockRepository_BatchUpdateStatus_Call) Run(run func(ctx context.Context, ids []string, status model.Status)) *MockRepository_BatchUpdateStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 model.Status
+		if args[2] != nil {
+			arg2 = args[2].(model.Status)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_BatchUpdateStatus_Call) Return(err error) *MockRepository_BatchUpdateStatus_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_BatchUpdateStatus_Call) RunAndReturn(run func(ctx context.Context, ids []string, status model.Status) error) *MockRepository_BatchUpdateStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByEmailWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountByEmailWithin(ctx context.Context, email string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, email, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByEmailWithin")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return returnFunc(ctx, email, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = returnFunc(ctx, email, seconds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, email, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountByEmailWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByEmailWithin'
+type MockRepository_CountByEmailWithin_Call struct {
+	*mock.Call
+}
+
+// CountByEmailWithin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - email string
+//   - seconds int64
+func (_e *MockRepository_Expecter) CountByEmailWithin(ctx any, email any, seconds any) *MockRepository_CountByEmailWithin_Call {
+	return &MockRepository_CountByEmailWithin_Call{Call: _e.mock.On("CountByEmailWithin", ctx, email, seconds)}
+}
+
+func (_c *MockRepository_CountByEmailWithin_Call) Run(run func(ctx context.Context, email string, seconds int64)) *MockRepository_CountByEmailWithin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountByEmailWithin_Call) Return(n int64, err error) *MockRepository_CountByEmailWithin_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountByEmailWithin_Call) RunAndReturn(run func(ctx context.Context, email string, seconds int64) (int64, error)) *MockRepository_CountByEmailWithin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByIPWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, ipHash, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByIPWithin")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return returnFunc(ctx, ipHash, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = returnFunc(ctx, ipHash, seconds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, ipHash, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountByIPWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByIPWithin'
+type MockRepository_CountByIPWithin_Call struct {
+	*mock.Call
+}
+
+// CountByIPWithin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ipHash string
+//   - seconds int64
+func (_e *MockRepository_Expecter) CountByIPWithin(ctx any, ipHash any, seconds any) *MockRepository_CountByIPWithin_Call {
+	return &MockRepository_CountByIPWithin_Call{Call: _e.mock.On("CountByIPWithin", ctx, ipHash, seconds)}
+}
+
+func (_c *MockRepository_CountByIPWithin_Call) Run(run func(ctx context.Context, ipHash string, seconds int64)) *MockRepository_CountByIPWithin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountByIPWithin_Call) Return(n int64, err error) *MockRepository_CountByIPWithin_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountByIPWithin_Call) RunAndReturn(run func(ctx context.Context, ipHash string, seconds int64) (int64, error)) *MockRepository_CountByIPWithin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountByUserWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountByUserWithin(ctx context.Context, userID string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, userID, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountByUserWithin")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return returnFunc(ctx, userID, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = returnFunc(ctx, userID, seconds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, userID, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountByUserWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountByUserWithin'
+type MockRepository_CountByUserWithin_Call struct {
+	*mock.Call
+}
+
+// CountByUserWithin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - seconds int64
+func (_e *MockRepository_Expecter) CountByUserWithin(ctx any, userID any, seconds any) *MockRepository_CountByUserWithin_Call {
+	return &MockRepository_CountByUserWithin_Call{Call: _e.mock.On("CountByUserWithin", ctx, userID, seconds)}
+}
+
+func (_c *MockRepository_CountByUserWithin_Call) Run(run func(ctx context.Context, userID string, seconds int64)) *MockRepository_CountByUserWithin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountByUserWithin_Call) Return(n int64, err error) *MockRepository_CountByUserWithin_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountByUserWithin_Call) RunAndReturn(run func(ctx context.Context, userID string, seconds int64) (int64, error)) *MockRepository_CountByUserWithin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountReactions provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountReactions(ctx context.Context, commentIDs []string) (map[string][]model.ReactionCount, error) {
+	ret := _mock.Called(ctx, commentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReactions")
+	}
+
+	var r0 map[string][]model.ReactionCount
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string][]model.ReactionCount, error)); ok {
+		return returnFunc(ctx, commentIDs)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string][]model.ReactionCount); ok {
+		r0 = returnFunc(ctx, commentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]model.ReactionCount)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, commentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountReactions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountReactions'
+type MockRepository_CountReactions_Call struct {
+	*mock.Call
+}
+
+// CountReactions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - commentIDs []string
+func (_e *MockRepository_Expecter) CountReactions(ctx any, commentIDs any) *MockRepository_CountReactions_Call {
+	return &MockRepository_CountReactions_Call{Call: _e.mock.On("CountReactions", ctx, commentIDs)}
+}
+
+func (_c *MockRepository_CountReactions_Call) Run(run func(ctx context.Context, commentIDs []string)) *MockRepository_CountReactions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountReactions_Call) Return(vMap map[string][]model.ReactionCount, err error) *MockRepository_CountReactions_Call {
+	_c.Call.Return(vMap, err)
+	return _c
+}
+
+func (_c *MockRepository_CountReactions_Call) RunAndReturn(run func(ctx context.Context, commentIDs []string) (map[string][]model.ReactionCount, error)) *MockRepository_CountReactions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountReactionsByIPWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountReactionsByIPWithin(ctx context.Context, ipHash string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, ipHash, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReactionsByIPWithin")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return returnFunc(ctx, ipHash, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = returnFunc(ctx, ipHash, seconds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, ipHash, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountReactionsByIPWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountReactionsByIPWithin'
+type MockRepository_CountReactionsByIPWithin_Call struct {
+	*mock.Call
+}
+
+// CountReactionsByIPWithin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ipHash string
+//   - seconds int64
+func (_e *MockRepository_Expecter) CountReactionsByIPWithin(ctx any, ipHash any, seconds any) *MockRepository_CountReactionsByIPWithin_Call {
+	return &MockRepository_CountReactionsByIPWithin_Call{Call: _e.mock.On("CountReactionsByIPWithin", ctx, ipHash, seconds)}
+}
+
+func (_c *MockRepository_CountReactionsByIPWithin_Call) Run(run func(ctx context.Context, ipHash string, seconds int64)) *MockRepository_CountReactionsByIPWithin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountReactionsByIPWithin_Call) Return(n int64, err error) *MockRepository_CountReactionsByIPWithin_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountReactionsByIPWithin_Call) RunAndReturn(run func(ctx context.Context, ipHash string, seconds int64) (int64, error)) *MockRepository_CountReactionsByIPWithin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountReactionsByUserWithin provides a mock function for the type MockRepository
+func (_mock *MockRepository) CountReactionsByUserWithin(ctx context.Context, userID string, seconds int64) (int64, error) {
+	ret := _mock.Called(ctx, userID, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountReactionsByUserWithin")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) (int64, error)); ok {
+		return returnFunc(ctx, userID, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) int64); ok {
+		r0 = returnFunc(ctx, userID, seconds)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = returnFunc(ctx, userID, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_CountReactionsByUserWithin_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountReactionsByUserWithin'
+type MockRepository_CountReactionsByUserWithin_Call struct {
+	*mock.Call
+}
+
+// CountReactionsByUserWithin is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - seconds int64
+func (_e *MockRepository_Expecter) CountReactionsByUserWithin(ctx any, userID any, seconds any) *MockRepository_CountReactionsByUserWithin_Call {
+	return &MockRepository_CountReactionsByUserWithin_Call{Call: _e.mock.On("CountReactionsByUserWithin", ctx, userID, seconds)}
+}
+
+func (_c *MockRepository_CountReactionsByUserWithin_Call) Run(run func(ctx context.Context, userID string, seconds int64)) *MockRepository_CountReactionsByUserWithin_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CountReactionsByUserWithin_Call) Return(n int64, err error) *MockRepository_CountReactionsByUserWithin_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockRepository_CountReactionsByUserWithin_Call) RunAndReturn(run func(ctx context.Context, userID string, seconds int64) (int64, error)) *MockRepository_CountReactionsByUserWithin_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateComment provides a mock function for the type MockRepository
+func (_mock *MockRepository) CreateComment(ctx context.Context, c *model.Comment) error {
+	ret := _mock.Called(ctx, c)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Comment) error); ok {
+		r0 = returnFunc(ctx, c)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CreateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateComment'
+type MockRepository_CreateComment_Call struct {
+	*mock.Call
+}
+
+// CreateComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - c *model.Comment
+func (_e *MockRepository_Expecter) CreateComment(ctx any, c any) *MockRepository_CreateComment_Call {
+	return &MockRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", ctx, c)}
+}
+
+func (_c *MockRepository_CreateComment_Call) Run(run func(ctx context.Context, c *model.Comment)) *MockRepository_CreateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Comment
+		if args[1] != nil {
+			arg1 = args[1].(*model.Comment)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateComment_Call) Return(err error) *MockRepository_CreateComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CreateComment_Call) RunAndReturn(run func(ctx context.Context, c *model.Comment) error) *MockRepository_CreateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteComment provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteComment(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MockRepository_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) DeleteComment(ctx any, id any) *MockRepository_DeleteComment_Call {
+	return &MockRepository_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, id)}
+}
+
+func (_c *MockRepository_DeleteComment_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteComment_Call) Return(err error) *MockRepository_DeleteComment_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteComment_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ExistsRecentDuplicate provides a mock function for the type MockRepository
+func (_mock *MockRepository) ExistsRecentDuplicate(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error) {
+	ret := _mock.Called(ctx, echoID, content, email, ipHash, userID, seconds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExistsRecentDuplicate")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) (bool, error)); ok {
+		return returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) bool); ok {
+		r0 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, int64) error); ok {
+		r1 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ExistsRecentDuplicate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsRecentDuplicate'
+type MockRepository_ExistsRecentDuplicate_Call struct {
+	*mock.Call
+}
+
+// ExistsRecentDuplicate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - content string
+//   - email string
+//   - ipHash string
+//   - userID string
+//   - seconds int64
+func (_e *MockRepository_Expecter) ExistsRecentDuplicate(ctx any, echoID any, content any, email any, ipHash any, userID any, seconds any) *MockRepository_ExistsRecentDuplicate_Call {
+	return &MockRepository_ExistsRecentDuplicate_Call{Call: _e.mock.On("ExistsRecentDuplicate", ctx, echoID, content, email, ipHash, userID, seconds)}
+}
+
+func (_c *MockRepository_ExistsRecentDuplicate_Call) Run(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64)) *MockRepository_ExistsRecentDuplicate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		var arg6 int64
+		if args[6] != nil {
+			arg6 = args[6].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ExistsRecentDuplicate_Call) Return(b bool, err error) *MockRepository_ExistsRecentDuplicate_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockRepository_ExistsRecentDuplicate_Call) RunAndReturn(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error)) *MockRepository_ExistsRecentDuplicate_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByEchoAndRemoteID provides a mock function for the type MockRepository
+func (_mock *MockRepository) FindByEchoAndRemoteID(ctx context.Context, echoID string, remoteID string) (model.Comment, error) {
+	ret := _mock.Called(ctx, echoID, remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByEchoAndRemoteID")
+	}
+
+	var r0 model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (model.Comment, error)); ok {
+		return returnFunc(ctx, echoID, remoteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) model.Comment); ok {
+		r0 = returnFunc(ctx, echoID, remoteID)
+	} else {
+		r0 = ret.Get(0).(model.Comment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, echoID, remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_FindByEchoAndRemoteID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByEchoAndRemoteID'
+type MockRepository_FindByEchoAndRemoteID_Call struct {
+	*mock.Call
+}
+
+// FindByEchoAndRemoteID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - remoteID string
+func (_e *MockRepository_Expecter) FindByEchoAndRemoteID(ctx any, echoID any, remoteID any) *MockRepository_FindByEchoAndRemoteID_Call {
+	return &MockRepository_FindByEchoAndRemoteID_Call{Call: _e.mock.On("FindByEchoAndRemoteID", ctx, echoID, remoteID)}
+}
+
+func (_c *MockRepository_FindByEchoAndRemoteID_Call) Run(run func(ctx context.Context, echoID string, remoteID string)) *MockRepository_FindByEchoAndRemoteID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_FindByEchoAndRemoteID_Call) Return(comment model.Comment, err error) *MockRepository_FindByEchoAndRemoteID_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MockRepository_FindByEchoAndRemoteID_Call) RunAndReturn(run func(ctx context.Context, echoID string, remoteID string) (model.Comment, error)) *MockRepository_FindByEchoAndRemoteID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FindByRemoteID provides a mock function for the type MockRepository
+func (_mock *MockRepository) FindByRemoteID(ctx context.Context, remoteID string) (model.Comment, error) {
+	ret := _mock.Called(ctx, remoteID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindByRemoteID")
+	}
+
+	var r0 model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Comment, error)); ok {
+		return returnFunc(ctx, remoteID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Comment); ok {
+		r0 = returnFunc(ctx, remoteID)
+	} else {
+		r0 = ret.Get(0).(model.Comment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, remoteID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_FindByRemoteID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FindByRemoteID'
+type MockRepository_FindByRemoteID_Call struct {
+	*mock.Call
+}
+
+// FindByRemoteID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - remoteID string
+func (_e *MockRepository_Expecter) FindByRemoteID(ctx any, remoteID any) *MockRepository_FindByRemoteID_Call {
+	return &MockRepository_FindByRemoteID_Call{Call: _e.mock.On("FindByRemoteID", ctx, remoteID)}
+}
+
+func (_c *MockRepository_FindByRemoteID_Call) Run(run func(ctx context.Context, remoteID string)) *MockRepository_FindByRemoteID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_FindByRemoteID_Call) Return(comment model.Comment, err error) *MockRepository_FindByRemoteID_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MockRepository_FindByRemoteID_Call) RunAndReturn(run func(ctx context.Context, remoteID string) (model.Comment, error)) *MockRepository_FindByRemoteID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCommentByID provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCommentByID")
+	}
+
+	var r0 model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Comment, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Comment); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Get(0).(model.Comment)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetCommentByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentByID'
+type MockRepository_GetCommentByID_Call struct {
+	*mock.Call
+}
+
+// GetCommentByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) GetCommentByID(ctx any, id any) *MockRepository_GetCommentByID_Call {
+	return &MockRepository_GetCommentByID_Call{Call: _e.mock.On("GetCommentByID", ctx, id)}
+}
+
+func (_c *MockRepository_GetCommentByID_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetCommentByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetCommentByID_Call) Return(comment model.Comment, err error) *MockRepository_GetCommentByID_Call {
+	_c.Call.Return(comment, err)
+	return _c
+}
+
+func (_c *MockRepository_GetCommentByID_Call) RunAndReturn(run func(ctx context.Context, id string) (model.Comment, error)) *MockRepository_GetCommentByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LearnSpamTokens provides a mock function for the type MockRepository
+func (_mock *MockRepository) LearnSpamTokens(ctx context.Context, tokens []string, spam bool, delta int64) error {
+	ret := _mock.Called(ctx, tokens, spam, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LearnSpamTokens")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string, bool, int64) error); ok {
+		r0 = returnFunc(ctx, tokens, spam, delta)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_LearnSpamTokens_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LearnSpamTokens'
+type MockRepository_LearnSpamTokens_Call struct {
+	*mock.Call
+}
+
+// LearnSpamTokens is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokens []string
+//   - spam bool
+//   - delta int64
+func (_e *MockRepository_Expecter) LearnSpamTokens(ctx any, tokens any, spam any, delta any) *MockRepository_LearnSpamTokens_Call {
+	return &MockRepository_LearnSpamTokens_Call{Call: _e.mock.On("LearnSpamTokens", ctx, tokens, spam, delta)}
+}
+
+func (_c *MockRepository_LearnSpamTokens_Call) Run(run func(ctx context.Context, tokens []string, spam bool, delta int64)) *MockRepository_LearnSpamTokens_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		var arg2 bool
+		if args[2] != nil {
+			arg2 = args[2].(bool)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_LearnSpamTokens_Call) Return(err error) *MockRepository_LearnSpamTokens_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_LearnSpamTokens_Call) RunAndReturn(run func(ctx context.Context, tokens []string, spam bool, delta int64) error) *MockRepository_LearnSpamTokens_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListComments provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListComments(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListComments")
+	}
+
+	var r0 model.PageResult[model.Comment]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ListCommentQuery) (model.PageResult[model.Comment], error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ListCommentQuery) model.PageResult[model.Comment]); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(model.PageResult[model.Comment])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.ListCommentQuery) error); ok {
+		r1 = returnFunc(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListComments'
+type MockRepository_ListComments_Call struct {
+	*mock.Call
+}
+
+// ListComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - query model.ListCommentQuery
+func (_e *MockRepository_Expecter) ListComments(ctx any, query any) *MockRepository_ListComments_Call {
+	return &MockRepository_ListComments_Call{Call: _e.mock.On("ListComments", ctx, query)}
+}
+
+func (_c *MockRepository_ListComments_Call) Run(run func(ctx context.Context, query model.ListCommentQuery)) *MockRepository_ListComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.ListCommentQuery
+		if args[1] != nil {
+			arg1 = args[1].(model.ListCommentQuery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListComments_Call) Return(pageResult model.PageResult[model.Comment], err error) *MockRepository_ListComments_Call {
+	_c.Call.Return(pageResult, err)
+	return _c
+}
+
+func (_c *MockRepository_ListComments_Call) RunAndReturn(run func(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error)) *MockRepository_ListComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListExpiredTrashed provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListExpiredTrashed(ctx context.Context, before int64, limit int) ([]model.Comment, error) {
+	ret := _mock.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListExpiredTrashed")
+	}
+
+	var r0 []model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]model.Comment, error)); ok {
+		return returnFunc(ctx, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []model.Comment); ok {
+		r0 = returnFunc(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListExpiredTrashed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListExpiredTrashed'
+type MockRepository_ListExpiredTrashed_Call struct {
+	*mock.Call
+}
+
+// ListExpiredTrashed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before int64
+//   - limit int
+func (_e *MockRepository_Expecter) ListExpiredTrashed(ctx any, before any, limit any) *MockRepository_ListExpiredTrashed_Call {
+	return &MockRepository_ListExpiredTrashed_Call{Call: _e.mock.On("ListExpiredTrashed", ctx, before, limit)}
+}
+
+func (_c *MockRepository_ListExpiredTrashed_Call) Run(run func(ctx context.Context, before int64, limit int)) *MockRepository_ListExpiredTrashed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListExpiredTrashed_Call) Return(comments []model.Comment, err error) *MockRepository_ListExpiredTrashed_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MockRepository_ListExpiredTrashed_Call) RunAndReturn(run func(ctx context.Context, before int64, limit int) ([]model.Comment, error)) *MockRepository_ListExpiredTrashed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPublicByEchoID provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListPublicByEchoID(ctx context.Context, echoID string) ([]model.Comment, error) {
+	ret := _mock.Called(ctx, echoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublicByEchoID")
+	}
+
+	var r0 []model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.Comment, error)); ok {
+		return returnFunc(ctx, echoID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.Comment); ok {
+		r0 = returnFunc(ctx, echoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, echoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListPublicByEchoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicByEchoID'
+type MockRepository_ListPublicByEchoID_Call struct {
+	*mock.Call
+}
+
+// ListPublicByEchoID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockRepository_Expecter) ListPublicByEchoID(ctx any, echoID any) *MockRepository_ListPublicByEchoID_Call {
+	return &MockRepository_ListPublicByEchoID_Call{Call: _e.mock.On("ListPublicByEchoID", ctx, echoID)}
+}
+
+func (_c *MockRepository_ListPublicByEchoID_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_ListPublicByEchoID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_ListPublicByEchoID_Call) Return(comments []model.Comment, err error) *MockRepository_ListPublicByEchoID_Call {
+	_c.Call.Return(comments, err)
+	return _c
+}
+
+func (_c *MockRepository_ListPublicByEchoID_Call) RunAndReturn(run func(ctx context.Context, echoID string) ([]model.Comment, error)) *MockRepository_ListPublicByEchoID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListPublicComments provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListPublicComments(ctx context.Context, limit int) ([]model.Comment, error) {
+	ret := _mock.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublicComments")
+	}
+
+	var r0 []model.Comment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]model.Comment, error)); ok {
+		return returnFunc(ctx, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []model.Comment); ok {
+		r0 = returnFunc(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = returnFunc(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_ListPublicComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicComments'
+type MockRepository_ListPublicComments_Call struct {
+	*mock.Call
+}
+
+// ListPublicComments is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockRepository_Expecter) DeleteComment(ctx any, id any) *MockRepository_DeleteComment_Call {
-	return &MockRepository_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, id)}
+//   - limit int
+func (_e *MockRepository_Expecter) ListPublicComments(ctx any, limit any) *MockRepository_ListPublicComments_Call {
+	return &MockRepository_ListPublicComments_Call{Call: _e.mock.On("ListPublicComments", ctx, limit)}
 }
 
-func (_c *MockRepository_DeleteComment_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteComment_Call {
+func (_c *MockRepository_ListPublicComments_Call) Run(run func(ctx context.Context, limit int)) *MockRepository_ListPublicComments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int)
 		}
 		run(
 			arg0,
@@ -1425,60 +2941,57 @@ func (_c *MockRepository_DeleteComment_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockRepository_DeleteComment_Call) Return(err error) *MockRepository_DeleteComment_Call {
-	_c.Call.Return(err)
+func (_c *MockRepository_ListPublicComments_Call) Return(comments []model.Comment, err error) *MockRepository_ListPublicComments_Call {
+	_c.Call.Return(comments, err)
 	return _c
 }
 
-func (_c *MockRepository_DeleteComment_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteComment_Call {
+func (_c *MockRepository_ListPublicComments_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]model.Comment, error)) *MockRepository_ListPublicComments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ExistsRecentDuplicate provides a mock function for the type MockRepository
-func (_mock *MockRepository) ExistsRecentDuplicate(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error) {
-	ret := _mock.Called(ctx, echoID, content, email, ipHash, userID, seconds)
+// ListPublicSubtree provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListPublicSubtree(ctx context.Context, path string) ([]model.Comment, error) {
+	ret := _mock.Called(ctx, path)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ExistsRecentDuplicate")
+		panic("no return value specified for ListPublicSubtree")
 	}
 
-	var r0 bool
+	var r0 []model.Comment
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) (bool, error)); ok {
-		return returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.Comment, error)); ok {
+		return returnFunc(ctx, path)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string, int64) bool); ok {
-		r0 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.Comment); ok {
+		r0 = returnFunc(ctx, path)
 	} else {
-		r0 = ret.Get(0).(bool)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Comment)
+		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string, string, string, int64) error); ok {
-		r1 = returnFunc(ctx, echoID, content, email, ipHash, userID, seconds)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, path)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_ExistsRecentDuplicate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExistsRecentDuplicate'
-type MockRepository_ExistsRecentDuplicate_Call struct {
+// MockRepository_ListPublicSubtree_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicSubtree'
+type MockRepository_ListPublicSubtree_Call struct {
 	*mock.Call
 }
 
-// ExistsRecentDuplicate is a helper method to define mock.On call
+// ListPublicSubtree is a helper method to define mock.On call
 //   - ctx context.Context
-//   - echoID string
-//   - content string
-//   - email string
-//   - ipHash string
-//   - userID string
-//   - seconds int64
-func (_e *MockRepository_Expecter) ExistsRecentDuplicate(ctx any, echoID any, content any, email any, ipHash any, userID any, seconds any) *MockRepository_ExistsRecentDuplicate_Call {
-	return &MockRepository_ExistsRecentDuplicate_Call{Call: _e.mock.On("ExistsRecentDuplicate", ctx, echoID, content, email, ipHash, userID, seconds)}
+//   - path string
+func (_e *MockRepository_Expecter) ListPublicSubtree(ctx any, path any) *MockRepository_ListPublicSubtree_Call {
+	return &MockRepository_ListPublicSubtree_Call{Call: _e.mock.On("ListPublicSubtree", ctx, path)}
 }
 
-func (_c *MockRepository_ExistsRecentDuplicate_Call) Run(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64)) *MockRepository_ExistsRecentDuplicate_Call {
+func (_c *MockRepository_ListPublicSubtree_Call) Run(run func(ctx context.Context, path string)) *MockRepository_ListPublicSubtree_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1488,96 +3001,71 @@ func (_c *MockRepository_ExistsRecentDuplicate_Call) Run(run func(ctx context.Co
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 string
-		if args[2] != nil {
-			arg2 = args[2].(string)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 string
-		if args[4] != nil {
-			arg4 = args[4].(string)
-		}
-		var arg5 string
-		if args[5] != nil {
-			arg5 = args[5].(string)
-		}
-		var arg6 int64
-		if args[6] != nil {
-			arg6 = args[6].(int64)
-		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4,
-			arg5,
-			arg6,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_ExistsRecentDuplicate_Call) Return(b bool, err error) *MockRepository_ExistsRecentDuplicate_Call {
-	_c.Call.Return(b, err)
+func (_c *MockRepository_ListPublicSubtree_Call) Return(comments []model.Comment, err error) *MockRepository_ListPublicSubtree_Call {
+	_c.Call.Return(comments, err)
 	return _c
 }
 
-func (_c *MockRepository_ExistsRecentDuplicate_Call) RunAndReturn(run func(ctx context.Context, echoID string, content string, email string, ipHash string, userID string, seconds int64) (bool, error)) *MockRepository_ExistsRecentDuplicate_Call {
+func (_c *MockRepository_ListPublicSubtree_Call) RunAndReturn(run func(ctx context.Context, path string) ([]model.Comment, error)) *MockRepository_ListPublicSubtree_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetCommentByID provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetCommentByID(ctx context.Context, id string) (model.Comment, error) {
-	ret := _mock.Called(ctx, id)
+// PurgeComments provides a mock function for the type MockRepository
+func (_mock *MockRepository) PurgeComments(ctx context.Context, ids []string) (int64, error) {
+	ret := _mock.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetCommentByID")
+		panic("no return value specified for PurgeComments")
 	}
 
-	var r0 model.Comment
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.Comment, error)); ok {
-		return returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return returnFunc(ctx, ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.Comment); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = returnFunc(ctx, ids)
 	} else {
-		r0 = ret.Get(0).(model.Comment)
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_GetCommentByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCommentByID'
-type MockRepository_GetCommentByID_Call struct {
+// MockRepository_PurgeComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeComments'
+type MockRepository_PurgeComments_Call struct {
 	*mock.Call
 }
 
-// GetCommentByID is a helper method to define mock.On call
+// PurgeComments is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockRepository_Expecter) GetCommentByID(ctx any, id any) *MockRepository_GetCommentByID_Call {
-	return &MockRepository_GetCommentByID_Call{Call: _e.mock.On("GetCommentByID", ctx, id)}
+//   - ids []string
+func (_e *MockRepository_Expecter) PurgeComments(ctx any, ids any) *MockRepository_PurgeComments_Call {
+	return &MockRepository_PurgeComments_Call{Call: _e.mock.On("PurgeComments", ctx, ids)}
 }
 
-func (_c *MockRepository_GetCommentByID_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetCommentByID_Call {
+func (_c *MockRepository_PurgeComments_Call) Run(run func(ctx context.Context, ids []string)) *MockRepository_PurgeComments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -1587,131 +3075,141 @@ func (_c *MockRepository_GetCommentByID_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepository_GetCommentByID_Call) Return(comment model.Comment, err error) *MockRepository_GetCommentByID_Call {
-	_c.Call.Return(comment, err)
+func (_c *MockRepository_PurgeComments_Call) Return(n int64, err error) *MockRepository_PurgeComments_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepository_GetCommentByID_Call) RunAndReturn(run func(ctx context.Context, id string) (model.Comment, error)) *MockRepository_GetCommentByID_Call {
+func (_c *MockRepository_PurgeComments_Call) RunAndReturn(run func(ctx context.Context, ids []string) (int64, error)) *MockRepository_PurgeComments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListComments provides a mock function for the type MockRepository
-func (_mock *MockRepository) ListComments(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error) {
-	ret := _mock.Called(ctx, query)
+// RemoveReaction provides a mock function for the type MockRepository
+func (_mock *MockRepository) RemoveReaction(ctx context.Context, commentID string, emoji string, actorKey string) (bool, error) {
+	ret := _mock.Called(ctx, commentID, emoji, actorKey)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListComments")
+		panic("no return value specified for RemoveReaction")
 	}
 
-	var r0 model.PageResult[model.Comment]
+	var r0 bool
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ListCommentQuery) (model.PageResult[model.Comment], error)); ok {
-		return returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (bool, error)); ok {
+		return returnFunc(ctx, commentID, emoji, actorKey)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model.ListCommentQuery) model.PageResult[model.Comment]); ok {
-		r0 = returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) bool); ok {
+		r0 = returnFunc(ctx, commentID, emoji, actorKey)
 	} else {
-		r0 = ret.Get(0).(model.PageResult[model.Comment])
+		r0 = ret.Get(0).(bool)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model.ListCommentQuery) error); ok {
-		r1 = returnFunc(ctx, query)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, commentID, emoji, actorKey)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_ListComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListComments'
-type MockRepository_ListComments_Call struct {
+// MockRepository_RemoveReaction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RemoveReaction'
+type MockRepository_RemoveReaction_Call struct {
 	*mock.Call
 }
 
-// ListComments is a helper method to define mock.On call
+// RemoveReaction is a helper method to define mock.On call
 //   - ctx context.Context
-//   - query model.ListCommentQuery
-func (_e *MockRepository_Expecter) ListComments(ctx any, query any) *MockRepository_ListComments_Call {
-	return &MockRepository_ListComments_Call{Call: _e.mock.On("ListComments", ctx, query)}
+//   - commentID string
+//   - emoji string
+//   - actorKey string
+func (_e *MockRepository_Expecter) RemoveReaction(ctx any, commentID any, emoji any, actorKey any) *MockRepository_RemoveReaction_Call {
+	return &MockRepository_RemoveReaction_Call{Call: _e.mock.On("RemoveReaction", ctx, commentID, emoji, actorKey)}
 }
 
-func (_c *MockRepository_ListComments_Call) Run(run func(ctx context.Context, query model.ListCommentQuery)) *MockRepository_ListComments_Call {
+func (_c *MockRepository_RemoveReaction_Call) Run(run func(ctx context.Context, commentID string, emoji string, actorKey string)) *MockRepository_RemoveReaction_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model.ListCommentQuery
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(model.ListCommentQuery)
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_ListComments_Call) Return(pageResult model.PageResult[model.Comment], err error) *MockRepository_ListComments_Call {
-	_c.Call.Return(pageResult, err)
+func (_c *MockRepository_RemoveReaction_Call) Return(b bool, err error) *MockRepository_RemoveReaction_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockRepository_ListComments_Call) RunAndReturn(run func(ctx context.Context, query model.ListCommentQuery) (model.PageResult[model.Comment], error)) *MockRepository_ListComments_Call {
+func (_c *MockRepository_RemoveReaction_Call) RunAndReturn(run func(ctx context.Context, commentID string, emoji string, actorKey string) (bool, error)) *MockRepository_RemoveReaction_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPublicByEchoID provides a mock function for the type MockRepository
-func (_mock *MockRepository) ListPublicByEchoID(ctx context.Context, echoID string) ([]model.Comment, error) {
-	ret := _mock.Called(ctx, echoID)
+// RestoreComments provides a mock function for the type MockRepository
+func (_mock *MockRepository) RestoreComments(ctx context.Context, ids []string) (int64, error) {
+	ret := _mock.Called(ctx, ids)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPublicByEchoID")
+		panic("no return value specified for RestoreComments")
 	}
 
-	var r0 []model.Comment
+	var r0 int64
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.Comment, error)); ok {
-		return returnFunc(ctx, echoID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (int64, error)); ok {
+		return returnFunc(ctx, ids)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.Comment); ok {
-		r0 = returnFunc(ctx, echoID)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) int64); ok {
+		r0 = returnFunc(ctx, ids)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Comment)
-		}
+		r0 = ret.Get(0).(int64)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = returnFunc(ctx, echoID)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, ids)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_ListPublicByEchoID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicByEchoID'
-type MockRepository_ListPublicByEchoID_Call struct {
+// MockRepository_RestoreComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreComments'
+type MockRepository_RestoreComments_Call struct {
 	*mock.Call
 }
 
-// ListPublicByEchoID is a helper method to define mock.On call
+// RestoreComments is a helper method to define mock.On call
 //   - ctx context.Context
-//   - echoID string
-func (_e *MockRepository_Expecter) ListPublicByEchoID(ctx any, echoID any) *MockRepository_ListPublicByEchoID_Call {
-	return &MockRepository_ListPublicByEchoID_Call{Call: _e.mock.On("ListPublicByEchoID", ctx, echoID)}
+//   - ids []string
+func (_e *MockRepository_Expecter) RestoreComments(ctx any, ids any) *MockRepository_RestoreComments_Call {
+	return &MockRepository_RestoreComments_Call{Call: _e.mock.On("RestoreComments", ctx, ids)}
 }
 
-func (_c *MockRepository_ListPublicByEchoID_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_ListPublicByEchoID_Call {
+func (_c *MockRepository_RestoreComments_Call) Run(run func(ctx context.Context, ids []string)) *MockRepository_RestoreComments_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -1721,65 +3219,65 @@ func (_c *MockRepository_ListPublicByEchoID_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockRepository_ListPublicByEchoID_Call) Return(comments []model.Comment, err error) *MockRepository_ListPublicByEchoID_Call {
-	_c.Call.Return(comments, err)
+func (_c *MockRepository_RestoreComments_Call) Return(n int64, err error) *MockRepository_RestoreComments_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepository_ListPublicByEchoID_Call) RunAndReturn(run func(ctx context.Context, echoID string) ([]model.Comment, error)) *MockRepository_ListPublicByEchoID_Call {
+func (_c *MockRepository_RestoreComments_Call) RunAndReturn(run func(ctx context.Context, ids []string) (int64, error)) *MockRepository_RestoreComments_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ListPublicComments provides a mock function for the type MockRepository
-func (_mock *MockRepository) ListPublicComments(ctx context.Context, limit int) ([]model.Comment, error) {
-	ret := _mock.Called(ctx, limit)
+// SpamTokenCounts provides a mock function for the type MockRepository
+func (_mock *MockRepository) SpamTokenCounts(ctx context.Context, tokens []string) (map[string]model.SpamToken, error) {
+	ret := _mock.Called(ctx, tokens)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ListPublicComments")
+		panic("no return value specified for SpamTokenCounts")
 	}
 
-	var r0 []model.Comment
+	var r0 map[string]model.SpamToken
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) ([]model.Comment, error)); ok {
-		return returnFunc(ctx, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) (map[string]model.SpamToken, error)); ok {
+		return returnFunc(ctx, tokens)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, int) []model.Comment); ok {
-		r0 = returnFunc(ctx, limit)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) map[string]model.SpamToken); ok {
+		r0 = returnFunc(ctx, tokens)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Comment)
+			r0 = ret.Get(0).(map[string]model.SpamToken)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, int) error); ok {
-		r1 = returnFunc(ctx, limit)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, tokens)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_ListPublicComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPublicComments'
-type MockRepository_ListPublicComments_Call struct {
+// MockRepository_SpamTokenCounts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SpamTokenCounts'
+type MockRepository_SpamTokenCounts_Call struct {
 	*mock.Call
 }
 
-// ListPublicComments is a helper method to define mock.On call
+// SpamTokenCounts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - limit int
-func (_e *MockRepository_Expecter) ListPublicComments(ctx any, limit any) *MockRepository_ListPublicComments_Call {
-	return &MockRepository_ListPublicComments_Call{Call: _e.mock.On("ListPublicComments", ctx, limit)}
+//   - tokens []string
+func (_e *MockRepository_Expecter) SpamTokenCounts(ctx any, tokens any) *MockRepository_SpamTokenCounts_Call {
+	return &MockRepository_SpamTokenCounts_Call{Call: _e.mock.On("SpamTokenCounts", ctx, tokens)}
 }
 
-func (_c *MockRepository_ListPublicComments_Call) Run(run func(ctx context.Context, limit int)) *MockRepository_ListPublicComments_Call {
+func (_c *MockRepository_SpamTokenCounts_Call) Run(run func(ctx context.Context, tokens []string)) *MockRepository_SpamTokenCounts_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 []string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].([]string)
 		}
 		run(
 			arg0,
@@ -1789,12 +3287,12 @@ func (_c *MockRepository_ListPublicComments_Call) Run(run func(ctx context.Conte
 	return _c
 }
 
-func (_c *MockRepository_ListPublicComments_Call) Return(comments []model.Comment, err error) *MockRepository_ListPublicComments_Call {
-	_c.Call.Return(comments, err)
+func (_c *MockRepository_SpamTokenCounts_Call) Return(vMap map[string]model.SpamToken, err error) *MockRepository_SpamTokenCounts_Call {
+	_c.Call.Return(vMap, err)
 	return _c
 }
 
-func (_c *MockRepository_ListPublicComments_Call) RunAndReturn(run func(ctx context.Context, limit int) ([]model.Comment, error)) *MockRepository_ListPublicComments_Call {
+func (_c *MockRepository_SpamTokenCounts_Call) RunAndReturn(run func(ctx context.Context, tokens []string) (map[string]model.SpamToken, error)) *MockRepository_SpamTokenCounts_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -1925,6 +3423,69 @@ func (_c *MockRepository_UpdateCommentStatus_Call) RunAndReturn(run func(ctx con
 	return _c
 }
 
+// UpdateSpamLabel provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpdateSpamLabel(ctx context.Context, id string, label model.SpamVerdict) error {
+	ret := _mock.Called(ctx, id, label)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateSpamLabel")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.SpamVerdict) error); ok {
+		r0 = returnFunc(ctx, id, label)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_UpdateSpamLabel_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateSpamLabel'
+type MockRepository_UpdateSpamLabel_Call struct {
+	*mock.Call
+}
+
+// UpdateSpamLabel is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - label model.SpamVerdict
+func (_e *MockRepository_Expecter) UpdateSpamLabel(ctx any, id any, label any) *MockRepository_UpdateSpamLabel_Call {
+	return &MockRepository_UpdateSpamLabel_Call{Call: _e.mock.On("UpdateSpamLabel", ctx, id, label)}
+}
+
+func (_c *MockRepository_UpdateSpamLabel_Call) Run(run func(ctx context.Context, id string, label model.SpamVerdict)) *MockRepository_UpdateSpamLabel_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.SpamVerdict
+		if args[2] != nil {
+			arg2 = args[2].(model.SpamVerdict)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpdateSpamLabel_Call) Return(err error) *MockRepository_UpdateSpamLabel_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_UpdateSpamLabel_Call) RunAndReturn(run func(ctx context.Context, id string, label model.SpamVerdict) error) *MockRepository_UpdateSpamLabel_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockMailer creates a new instance of MockMailer. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockMailer(t interface {
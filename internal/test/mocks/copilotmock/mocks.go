@@ -11,7 +11,8 @@ import (
 	"context"
 	"net/http"
 
-	"github.com/lin-snow/ech0/internal/service/copilot"
+	commonModel "github.com/lin-snow/ech0/internal/model/common"
+	copilotModel "github.com/lin-snow/ech0/internal/model/copilot"
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -130,16 +131,16 @@ func (_m *MockChatService) EXPECT() *MockChatService_Expecter {
 }
 
 // AskStream provides a mock function for the type MockChatService
-func (_mock *MockChatService) AskStream(ctx context.Context, question string, locale string, timezone string, w http.ResponseWriter) error {
-	ret := _mock.Called(ctx, question, locale, timezone, w)
+func (_mock *MockChatService) AskStream(ctx context.Context, threadID string, question string, locale string, timezone string, w http.ResponseWriter) error {
+	ret := _mock.Called(ctx, threadID, question, locale, timezone, w)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AskStream")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, http.ResponseWriter) error); ok {
-		r0 = returnFunc(ctx, question, locale, timezone, w)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, http.ResponseWriter) error); ok {
+		r0 = returnFunc(ctx, threadID, question, locale, timezone, w)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -153,15 +154,16 @@ type MockChatService_AskStream_Call struct {
 
 // AskStream is a helper method to define mock.On call
 //   - ctx context.Context
+//   - threadID string
 //   - question string
 //   - locale string
 //   - timezone string
 //   - w http.ResponseWriter
-func (_e *MockChatService_Expecter) AskStream(ctx any, question any, locale any, timezone any, w any) *MockChatService_AskStream_Call {
-	return &MockChatService_AskStream_Call{Call: _e.mock.On("AskStream", ctx, question, locale, timezone, w)}
+func (_e *MockChatService_Expecter) AskStream(ctx any, threadID any, question any, locale any, timezone any, w any) *MockChatService_AskStream_Call {
+	return &MockChatService_AskStream_Call{Call: _e.mock.On("AskStream", ctx, threadID, question, locale, timezone, w)}
 }
 
-func (_c *MockChatService_AskStream_Call) Run(run func(ctx context.Context, question string, locale string, timezone string, w http.ResponseWriter)) *MockChatService_AskStream_Call {
+func (_c *MockChatService_AskStream_Call) Run(run func(ctx context.Context, threadID string, question string, locale string, timezone string, w http.ResponseWriter)) *MockChatService_AskStream_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -179,9 +181,13 @@ func (_c *MockChatService_AskStream_Call) Run(run func(ctx context.Context, ques
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
-		var arg4 http.ResponseWriter
+		var arg4 string
 		if args[4] != nil {
-			arg4 = args[4].(http.ResponseWriter)
+			arg4 = args[4].(string)
+		}
+		var arg5 http.ResponseWriter
+		if args[5] != nil {
+			arg5 = args[5].(http.ResponseWriter)
 		}
 		run(
 			arg0,
@@ -189,6 +195,7 @@ func (_c *MockChatService_AskStream_Call) Run(run func(ctx context.Context, ques
 			arg2,
 			arg3,
 			arg4,
+			arg5,
 		)
 	})
 	return _c
@@ -199,120 +206,554 @@ func (_c *MockChatService_AskStream_Call) Return(err error) *MockChatService_Ask
 	return _c
 }
 
-func (_c *MockChatService_AskStream_Call) RunAndReturn(run func(ctx context.Context, question string, locale string, timezone string, w http.ResponseWriter) error) *MockChatService_AskStream_Call {
+func (_c *MockChatService_AskStream_Call) RunAndReturn(run func(ctx context.Context, threadID string, question string, locale string, timezone string, w http.ResponseWriter) error) *MockChatService_AskStream_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// ClearSession provides a mock function for the type MockChatService
-func (_mock *MockChatService) ClearSession(ctx context.Context) error {
-	ret := _mock.Called(ctx)
+// CancelAction provides a mock function for the type MockChatService
+func (_mock *MockChatService) CancelAction(ctx context.Context, id string) (*copilotModel.ChatAction, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CancelAction")
+	}
+
+	var r0 *copilotModel.ChatAction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*copilotModel.ChatAction, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *copilotModel.ChatAction); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*copilotModel.ChatAction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_CancelAction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CancelAction'
+type MockChatService_CancelAction_Call struct {
+	*mock.Call
+}
+
+// CancelAction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockChatService_Expecter) CancelAction(ctx any, id any) *MockChatService_CancelAction_Call {
+	return &MockChatService_CancelAction_Call{Call: _e.mock.On("CancelAction", ctx, id)}
+}
+
+func (_c *MockChatService_CancelAction_Call) Run(run func(ctx context.Context, id string)) *MockChatService_CancelAction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_CancelAction_Call) Return(chatAction *copilotModel.ChatAction, err error) *MockChatService_CancelAction_Call {
+	_c.Call.Return(chatAction, err)
+	return _c
+}
+
+func (_c *MockChatService_CancelAction_Call) RunAndReturn(run func(ctx context.Context, id string) (*copilotModel.ChatAction, error)) *MockChatService_CancelAction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ConfirmAction provides a mock function for the type MockChatService
+func (_mock *MockChatService) ConfirmAction(ctx context.Context, id string) (*copilotModel.ChatAction, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmAction")
+	}
+
+	var r0 *copilotModel.ChatAction
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*copilotModel.ChatAction, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *copilotModel.ChatAction); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*copilotModel.ChatAction)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_ConfirmAction_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ConfirmAction'
+type MockChatService_ConfirmAction_Call struct {
+	*mock.Call
+}
+
+// ConfirmAction is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockChatService_Expecter) ConfirmAction(ctx any, id any) *MockChatService_ConfirmAction_Call {
+	return &MockChatService_ConfirmAction_Call{Call: _e.mock.On("ConfirmAction", ctx, id)}
+}
+
+func (_c *MockChatService_ConfirmAction_Call) Run(run func(ctx context.Context, id string)) *MockChatService_ConfirmAction_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_ConfirmAction_Call) Return(chatAction *copilotModel.ChatAction, err error) *MockChatService_ConfirmAction_Call {
+	_c.Call.Return(chatAction, err)
+	return _c
+}
+
+func (_c *MockChatService_ConfirmAction_Call) RunAndReturn(run func(ctx context.Context, id string) (*copilotModel.ChatAction, error)) *MockChatService_ConfirmAction_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateThread provides a mock function for the type MockChatService
+func (_mock *MockChatService) CreateThread(ctx context.Context, dto copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error) {
+	ret := _mock.Called(ctx, dto)
 
 	if len(ret) == 0 {
-		panic("no return value specified for ClearSession")
+		panic("no return value specified for CreateThread")
+	}
+
+	var r0 *copilotModel.ChatThread
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error)); ok {
+		return returnFunc(ctx, dto)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, copilotModel.ChatThreadDto) *copilotModel.ChatThread); ok {
+		r0 = returnFunc(ctx, dto)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*copilotModel.ChatThread)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, copilotModel.ChatThreadDto) error); ok {
+		r1 = returnFunc(ctx, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_CreateThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateThread'
+type MockChatService_CreateThread_Call struct {
+	*mock.Call
+}
+
+// CreateThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dto copilotModel.ChatThreadDto
+func (_e *MockChatService_Expecter) CreateThread(ctx any, dto any) *MockChatService_CreateThread_Call {
+	return &MockChatService_CreateThread_Call{Call: _e.mock.On("CreateThread", ctx, dto)}
+}
+
+func (_c *MockChatService_CreateThread_Call) Run(run func(ctx context.Context, dto copilotModel.ChatThreadDto)) *MockChatService_CreateThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 copilotModel.ChatThreadDto
+		if args[1] != nil {
+			arg1 = args[1].(copilotModel.ChatThreadDto)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_CreateThread_Call) Return(chatThread *copilotModel.ChatThread, err error) *MockChatService_CreateThread_Call {
+	_c.Call.Return(chatThread, err)
+	return _c
+}
+
+func (_c *MockChatService_CreateThread_Call) RunAndReturn(run func(ctx context.Context, dto copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error)) *MockChatService_CreateThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteThread provides a mock function for the type MockChatService
+func (_mock *MockChatService) DeleteThread(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteThread")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) error); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockChatService_ClearSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClearSession'
-type MockChatService_ClearSession_Call struct {
+// MockChatService_DeleteThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteThread'
+type MockChatService_DeleteThread_Call struct {
 	*mock.Call
 }
 
-// ClearSession is a helper method to define mock.On call
+// DeleteThread is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockChatService_Expecter) ClearSession(ctx any) *MockChatService_ClearSession_Call {
-	return &MockChatService_ClearSession_Call{Call: _e.mock.On("ClearSession", ctx)}
+//   - id string
+func (_e *MockChatService_Expecter) DeleteThread(ctx any, id any) *MockChatService_DeleteThread_Call {
+	return &MockChatService_DeleteThread_Call{Call: _e.mock.On("DeleteThread", ctx, id)}
 }
 
-func (_c *MockChatService_ClearSession_Call) Run(run func(ctx context.Context)) *MockChatService_ClearSession_Call {
+func (_c *MockChatService_DeleteThread_Call) Run(run func(ctx context.Context, id string)) *MockChatService_DeleteThread_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChatService_ClearSession_Call) Return(err error) *MockChatService_ClearSession_Call {
+func (_c *MockChatService_DeleteThread_Call) Return(err error) *MockChatService_DeleteThread_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockChatService_ClearSession_Call) RunAndReturn(run func(ctx context.Context) error) *MockChatService_ClearSession_Call {
+func (_c *MockChatService_DeleteThread_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockChatService_DeleteThread_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetSession provides a mock function for the type MockChatService
-func (_mock *MockChatService) GetSession(ctx context.Context) ([]service.ChatMessage, error) {
-	ret := _mock.Called(ctx)
+// ExportThread provides a mock function for the type MockChatService
+func (_mock *MockChatService) ExportThread(ctx context.Context, id string, format string, timezone string) (copilotModel.ChatThreadExport, error) {
+	ret := _mock.Called(ctx, id, format, timezone)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetSession")
+		panic("no return value specified for ExportThread")
 	}
 
-	var r0 []service.ChatMessage
+	var r0 copilotModel.ChatThreadExport
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context) ([]service.ChatMessage, error)); ok {
-		return returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (copilotModel.ChatThreadExport, error)); ok {
+		return returnFunc(ctx, id, format, timezone)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context) []service.ChatMessage); ok {
-		r0 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) copilotModel.ChatThreadExport); ok {
+		r0 = returnFunc(ctx, id, format, timezone)
+	} else {
+		r0 = ret.Get(0).(copilotModel.ChatThreadExport)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, id, format, timezone)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_ExportThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExportThread'
+type MockChatService_ExportThread_Call struct {
+	*mock.Call
+}
+
+// ExportThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - format string
+//   - timezone string
+func (_e *MockChatService_Expecter) ExportThread(ctx any, id any, format any, timezone any) *MockChatService_ExportThread_Call {
+	return &MockChatService_ExportThread_Call{Call: _e.mock.On("ExportThread", ctx, id, format, timezone)}
+}
+
+func (_c *MockChatService_ExportThread_Call) Run(run func(ctx context.Context, id string, format string, timezone string)) *MockChatService_ExportThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_ExportThread_Call) Return(chatThreadExport copilotModel.ChatThreadExport, err error) *MockChatService_ExportThread_Call {
+	_c.Call.Return(chatThreadExport, err)
+	return _c
+}
+
+func (_c *MockChatService_ExportThread_Call) RunAndReturn(run func(ctx context.Context, id string, format string, timezone string) (copilotModel.ChatThreadExport, error)) *MockChatService_ExportThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetThread provides a mock function for the type MockChatService
+func (_mock *MockChatService) GetThread(ctx context.Context, id string) (*copilotModel.ChatThreadDetail, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetThread")
+	}
+
+	var r0 *copilotModel.ChatThreadDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*copilotModel.ChatThreadDetail, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *copilotModel.ChatThreadDetail); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]service.ChatMessage)
+			r0 = ret.Get(0).(*copilotModel.ChatThreadDetail)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = returnFunc(ctx)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_GetThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetThread'
+type MockChatService_GetThread_Call struct {
+	*mock.Call
+}
+
+// GetThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockChatService_Expecter) GetThread(ctx any, id any) *MockChatService_GetThread_Call {
+	return &MockChatService_GetThread_Call{Call: _e.mock.On("GetThread", ctx, id)}
+}
+
+func (_c *MockChatService_GetThread_Call) Run(run func(ctx context.Context, id string)) *MockChatService_GetThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_GetThread_Call) Return(chatThreadDetail *copilotModel.ChatThreadDetail, err error) *MockChatService_GetThread_Call {
+	_c.Call.Return(chatThreadDetail, err)
+	return _c
+}
+
+func (_c *MockChatService_GetThread_Call) RunAndReturn(run func(ctx context.Context, id string) (*copilotModel.ChatThreadDetail, error)) *MockChatService_GetThread_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListThreads provides a mock function for the type MockChatService
+func (_mock *MockChatService) ListThreads(ctx context.Context, query commonModel.PageQueryDto) (commonModel.PageQueryResult[[]copilotModel.ChatThread], error) {
+	ret := _mock.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListThreads")
+	}
+
+	var r0 commonModel.PageQueryResult[[]copilotModel.ChatThread]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, commonModel.PageQueryDto) (commonModel.PageQueryResult[[]copilotModel.ChatThread], error)); ok {
+		return returnFunc(ctx, query)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, commonModel.PageQueryDto) commonModel.PageQueryResult[[]copilotModel.ChatThread]); ok {
+		r0 = returnFunc(ctx, query)
+	} else {
+		r0 = ret.Get(0).(commonModel.PageQueryResult[[]copilotModel.ChatThread])
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, commonModel.PageQueryDto) error); ok {
+		r1 = returnFunc(ctx, query)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockChatService_GetSession_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSession'
-type MockChatService_GetSession_Call struct {
+// MockChatService_ListThreads_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListThreads'
+type MockChatService_ListThreads_Call struct {
 	*mock.Call
 }
 
-// GetSession is a helper method to define mock.On call
+// ListThreads is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *MockChatService_Expecter) GetSession(ctx any) *MockChatService_GetSession_Call {
-	return &MockChatService_GetSession_Call{Call: _e.mock.On("GetSession", ctx)}
+//   - query commonModel.PageQueryDto
+func (_e *MockChatService_Expecter) ListThreads(ctx any, query any) *MockChatService_ListThreads_Call {
+	return &MockChatService_ListThreads_Call{Call: _e.mock.On("ListThreads", ctx, query)}
 }
 
-func (_c *MockChatService_GetSession_Call) Run(run func(ctx context.Context)) *MockChatService_GetSession_Call {
+func (_c *MockChatService_ListThreads_Call) Run(run func(ctx context.Context, query commonModel.PageQueryDto)) *MockChatService_ListThreads_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
+		var arg1 commonModel.PageQueryDto
+		if args[1] != nil {
+			arg1 = args[1].(commonModel.PageQueryDto)
+		}
 		run(
 			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockChatService_ListThreads_Call) Return(pageQueryResult commonModel.PageQueryResult[[]copilotModel.ChatThread], err error) *MockChatService_ListThreads_Call {
+	_c.Call.Return(pageQueryResult, err)
+	return _c
+}
+
+func (_c *MockChatService_ListThreads_Call) RunAndReturn(run func(ctx context.Context, query commonModel.PageQueryDto) (commonModel.PageQueryResult[[]copilotModel.ChatThread], error)) *MockChatService_ListThreads_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateThread provides a mock function for the type MockChatService
+func (_mock *MockChatService) UpdateThread(ctx context.Context, id string, dto copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error) {
+	ret := _mock.Called(ctx, id, dto)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateThread")
+	}
+
+	var r0 *copilotModel.ChatThread
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error)); ok {
+		return returnFunc(ctx, id, dto)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, copilotModel.ChatThreadDto) *copilotModel.ChatThread); ok {
+		r0 = returnFunc(ctx, id, dto)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*copilotModel.ChatThread)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, copilotModel.ChatThreadDto) error); ok {
+		r1 = returnFunc(ctx, id, dto)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockChatService_UpdateThread_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateThread'
+type MockChatService_UpdateThread_Call struct {
+	*mock.Call
+}
+
+// UpdateThread is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - dto copilotModel.ChatThreadDto
+func (_e *MockChatService_Expecter) UpdateThread(ctx any, id any, dto any) *MockChatService_UpdateThread_Call {
+	return &MockChatService_UpdateThread_Call{Call: _e.mock.On("UpdateThread", ctx, id, dto)}
+}
+
+func (_c *MockChatService_UpdateThread_Call) Run(run func(ctx context.Context, id string, dto copilotModel.ChatThreadDto)) *MockChatService_UpdateThread_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 copilotModel.ChatThreadDto
+		if args[2] != nil {
+			arg2 = args[2].(copilotModel.ChatThreadDto)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockChatService_GetSession_Call) Return(chatMessages []service.ChatMessage, err error) *MockChatService_GetSession_Call {
-	_c.Call.Return(chatMessages, err)
+func (_c *MockChatService_UpdateThread_Call) Return(chatThread *copilotModel.ChatThread, err error) *MockChatService_UpdateThread_Call {
+	_c.Call.Return(chatThread, err)
 	return _c
 }
 
-func (_c *MockChatService_GetSession_Call) RunAndReturn(run func(ctx context.Context) ([]service.ChatMessage, error)) *MockChatService_GetSession_Call {
+func (_c *MockChatService_UpdateThread_Call) RunAndReturn(run func(ctx context.Context, id string, dto copilotModel.ChatThreadDto) (*copilotModel.ChatThread, error)) *MockChatService_UpdateThread_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -347,6 +347,86 @@ func (_c *MockService_GetEchoById_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// GetEchoRevision provides a mock function for the type MockService
+func (_mock *MockService) GetEchoRevision(ctx context.Context, echoID string, revisionID string, compareTo string) (*model.EchoRevisionDetail, error) {
+	ret := _mock.Called(ctx, echoID, revisionID, compareTo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEchoRevision")
+	}
+
+	var r0 *model.EchoRevisionDetail
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) (*model.EchoRevisionDetail, error)); ok {
+		return returnFunc(ctx, echoID, revisionID, compareTo)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string) *model.EchoRevisionDetail); ok {
+		r0 = returnFunc(ctx, echoID, revisionID, compareTo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EchoRevisionDetail)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = returnFunc(ctx, echoID, revisionID, compareTo)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetEchoRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchoRevision'
+type MockService_GetEchoRevision_Call struct {
+	*mock.Call
+}
+
+// GetEchoRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - revisionID string
+//   - compareTo string
+func (_e *MockService_Expecter) GetEchoRevision(ctx any, echoID any, revisionID any, compareTo any) *MockService_GetEchoRevision_Call {
+	return &MockService_GetEchoRevision_Call{Call: _e.mock.On("GetEchoRevision", ctx, echoID, revisionID, compareTo)}
+}
+
+func (_c *MockService_GetEchoRevision_Call) Run(run func(ctx context.Context, echoID string, revisionID string, compareTo string)) *MockService_GetEchoRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetEchoRevision_Call) Return(echoRevisionDetail *model.EchoRevisionDetail, err error) *MockService_GetEchoRevision_Call {
+	_c.Call.Return(echoRevisionDetail, err)
+	return _c
+}
+
+func (_c *MockService_GetEchoRevision_Call) RunAndReturn(run func(ctx context.Context, echoID string, revisionID string, compareTo string) (*model.EchoRevisionDetail, error)) *MockService_GetEchoRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetEchosByPage provides a mock function for the type MockService
 func (_mock *MockService) GetEchosByPage(ctx context.Context, pageQueryDto model0.PageQueryDto) (model0.PageQueryResult[[]model.Echo], error) {
 	ret := _mock.Called(ctx, pageQueryDto)
@@ -808,44 +888,55 @@ func (_c *MockService_LikeEcho_Call) RunAndReturn(run func(ctx context.Context,
 	return _c
 }
 
-// PostEcho provides a mock function for the type MockService
-func (_mock *MockService) PostEcho(ctx context.Context, newEcho *model.Echo) error {
-	ret := _mock.Called(ctx, newEcho)
+// ListEchoRevisions provides a mock function for the type MockService
+func (_mock *MockService) ListEchoRevisions(ctx context.Context, echoID string) ([]model.EchoRevision, error) {
+	ret := _mock.Called(ctx, echoID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for PostEcho")
+		panic("no return value specified for ListEchoRevisions")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Echo) error); ok {
-		r0 = returnFunc(ctx, newEcho)
+	var r0 []model.EchoRevision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.EchoRevision, error)); ok {
+		return returnFunc(ctx, echoID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.EchoRevision); ok {
+		r0 = returnFunc(ctx, echoID)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.EchoRevision)
+		}
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, echoID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockService_PostEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostEcho'
-type MockService_PostEcho_Call struct {
+// MockService_ListEchoRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEchoRevisions'
+type MockService_ListEchoRevisions_Call struct {
 	*mock.Call
 }
 
-// PostEcho is a helper method to define mock.On call
+// ListEchoRevisions is a helper method to define mock.On call
 //   - ctx context.Context
-//   - newEcho *model.Echo
-func (_e *MockService_Expecter) PostEcho(ctx any, newEcho any) *MockService_PostEcho_Call {
-	return &MockService_PostEcho_Call{Call: _e.mock.On("PostEcho", ctx, newEcho)}
+//   - echoID string
+func (_e *MockService_Expecter) ListEchoRevisions(ctx any, echoID any) *MockService_ListEchoRevisions_Call {
+	return &MockService_ListEchoRevisions_Call{Call: _e.mock.On("ListEchoRevisions", ctx, echoID)}
 }
 
-func (_c *MockService_PostEcho_Call) Run(run func(ctx context.Context, newEcho *model.Echo)) *MockService_PostEcho_Call {
+func (_c *MockService_ListEchoRevisions_Call) Run(run func(ctx context.Context, echoID string)) *MockService_ListEchoRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Echo
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.Echo)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -855,63 +946,63 @@ func (_c *MockService_PostEcho_Call) Run(run func(ctx context.Context, newEcho *
 	return _c
 }
 
-func (_c *MockService_PostEcho_Call) Return(err error) *MockService_PostEcho_Call {
-	_c.Call.Return(err)
+func (_c *MockService_ListEchoRevisions_Call) Return(echoRevisions []model.EchoRevision, err error) *MockService_ListEchoRevisions_Call {
+	_c.Call.Return(echoRevisions, err)
 	return _c
 }
 
-func (_c *MockService_PostEcho_Call) RunAndReturn(run func(ctx context.Context, newEcho *model.Echo) error) *MockService_PostEcho_Call {
+func (_c *MockService_ListEchoRevisions_Call) RunAndReturn(run func(ctx context.Context, echoID string) ([]model.EchoRevision, error)) *MockService_ListEchoRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryEchos provides a mock function for the type MockService
-func (_mock *MockService) QueryEchos(ctx context.Context, queryDto model0.EchoQueryDto) (model0.PageQueryResult[[]model.Echo], error) {
-	ret := _mock.Called(ctx, queryDto)
+// ListTrashedEchos provides a mock function for the type MockService
+func (_mock *MockService) ListTrashedEchos(ctx context.Context, pageQueryDto model0.PageQueryDto) (model0.PageQueryResult[[]model.Echo], error) {
+	ret := _mock.Called(ctx, pageQueryDto)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryEchos")
+		panic("no return value specified for ListTrashedEchos")
 	}
 
 	var r0 model0.PageQueryResult[[]model.Echo]
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model0.EchoQueryDto) (model0.PageQueryResult[[]model.Echo], error)); ok {
-		return returnFunc(ctx, queryDto)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model0.PageQueryDto) (model0.PageQueryResult[[]model.Echo], error)); ok {
+		return returnFunc(ctx, pageQueryDto)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, model0.EchoQueryDto) model0.PageQueryResult[[]model.Echo]); ok {
-		r0 = returnFunc(ctx, queryDto)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model0.PageQueryDto) model0.PageQueryResult[[]model.Echo]); ok {
+		r0 = returnFunc(ctx, pageQueryDto)
 	} else {
 		r0 = ret.Get(0).(model0.PageQueryResult[[]model.Echo])
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, model0.EchoQueryDto) error); ok {
-		r1 = returnFunc(ctx, queryDto)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model0.PageQueryDto) error); ok {
+		r1 = returnFunc(ctx, pageQueryDto)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockService_QueryEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryEchos'
-type MockService_QueryEchos_Call struct {
+// MockService_ListTrashedEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrashedEchos'
+type MockService_ListTrashedEchos_Call struct {
 	*mock.Call
 }
 
-// QueryEchos is a helper method to define mock.On call
+// ListTrashedEchos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - queryDto model0.EchoQueryDto
-func (_e *MockService_Expecter) QueryEchos(ctx any, queryDto any) *MockService_QueryEchos_Call {
-	return &MockService_QueryEchos_Call{Call: _e.mock.On("QueryEchos", ctx, queryDto)}
+//   - pageQueryDto model0.PageQueryDto
+func (_e *MockService_Expecter) ListTrashedEchos(ctx any, pageQueryDto any) *MockService_ListTrashedEchos_Call {
+	return &MockService_ListTrashedEchos_Call{Call: _e.mock.On("ListTrashedEchos", ctx, pageQueryDto)}
 }
 
-func (_c *MockService_QueryEchos_Call) Run(run func(ctx context.Context, queryDto model0.EchoQueryDto)) *MockService_QueryEchos_Call {
+func (_c *MockService_ListTrashedEchos_Call) Run(run func(ctx context.Context, pageQueryDto model0.PageQueryDto)) *MockService_ListTrashedEchos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 model0.EchoQueryDto
+		var arg1 model0.PageQueryDto
 		if args[1] != nil {
-			arg1 = args[1].(model0.EchoQueryDto)
+			arg1 = args[1].(model0.PageQueryDto)
 		}
 		run(
 			arg0,
@@ -921,46 +1012,46 @@ func (_c *MockService_QueryEchos_Call) Run(run func(ctx context.Context, queryDt
 	return _c
 }
 
-func (_c *MockService_QueryEchos_Call) Return(pageQueryResult model0.PageQueryResult[[]model.Echo], err error) *MockService_QueryEchos_Call {
+func (_c *MockService_ListTrashedEchos_Call) Return(pageQueryResult model0.PageQueryResult[[]model.Echo], err error) *MockService_ListTrashedEchos_Call {
 	_c.Call.Return(pageQueryResult, err)
 	return _c
 }
 
-func (_c *MockService_QueryEchos_Call) RunAndReturn(run func(ctx context.Context, queryDto model0.EchoQueryDto) (model0.PageQueryResult[[]model.Echo], error)) *MockService_QueryEchos_Call {
+func (_c *MockService_ListTrashedEchos_Call) RunAndReturn(run func(ctx context.Context, pageQueryDto model0.PageQueryDto) (model0.PageQueryResult[[]model.Echo], error)) *MockService_ListTrashedEchos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateEcho provides a mock function for the type MockService
-func (_mock *MockService) UpdateEcho(ctx context.Context, echo *model.Echo) error {
-	ret := _mock.Called(ctx, echo)
+// PostEcho provides a mock function for the type MockService
+func (_mock *MockService) PostEcho(ctx context.Context, newEcho *model.Echo) error {
+	ret := _mock.Called(ctx, newEcho)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateEcho")
+		panic("no return value specified for PostEcho")
 	}
 
 	var r0 error
 	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Echo) error); ok {
-		r0 = returnFunc(ctx, echo)
+		r0 = returnFunc(ctx, newEcho)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockService_UpdateEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEcho'
-type MockService_UpdateEcho_Call struct {
+// MockService_PostEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PostEcho'
+type MockService_PostEcho_Call struct {
 	*mock.Call
 }
 
-// UpdateEcho is a helper method to define mock.On call
+// PostEcho is a helper method to define mock.On call
 //   - ctx context.Context
-//   - echo *model.Echo
-func (_e *MockService_Expecter) UpdateEcho(ctx any, echo any) *MockService_UpdateEcho_Call {
-	return &MockService_UpdateEcho_Call{Call: _e.mock.On("UpdateEcho", ctx, echo)}
+//   - newEcho *model.Echo
+func (_e *MockService_Expecter) PostEcho(ctx any, newEcho any) *MockService_PostEcho_Call {
+	return &MockService_PostEcho_Call{Call: _e.mock.On("PostEcho", ctx, newEcho)}
 }
 
-func (_c *MockService_UpdateEcho_Call) Run(run func(ctx context.Context, echo *model.Echo)) *MockService_UpdateEcho_Call {
+func (_c *MockService_PostEcho_Call) Run(run func(ctx context.Context, newEcho *model.Echo)) *MockService_PostEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -978,138 +1069,114 @@ func (_c *MockService_UpdateEcho_Call) Run(run func(ctx context.Context, echo *m
 	return _c
 }
 
-func (_c *MockService_UpdateEcho_Call) Return(err error) *MockService_UpdateEcho_Call {
+func (_c *MockService_PostEcho_Call) Return(err error) *MockService_PostEcho_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockService_UpdateEcho_Call) RunAndReturn(run func(ctx context.Context, echo *model.Echo) error) *MockService_UpdateEcho_Call {
+func (_c *MockService_PostEcho_Call) RunAndReturn(run func(ctx context.Context, newEcho *model.Echo) error) *MockService_PostEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-// The first argument is typically a *testing.T value.
-func NewMockRepository(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *MockRepository {
-	mock := &MockRepository{}
-	mock.Mock.Test(t)
-
-	t.Cleanup(func() { mock.AssertExpectations(t) })
-
-	return mock
-}
-
-// MockRepository is an autogenerated mock type for the Repository type
-type MockRepository struct {
-	mock.Mock
-}
-
-type MockRepository_Expecter struct {
-	mock *mock.Mock
-}
-
-func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
-	return &MockRepository_Expecter{mock: &_m.Mock}
-}
-
-// CreateEcho provides a mock function for the type MockRepository
-func (_mock *MockRepository) CreateEcho(ctx context.Context, newEcho *model.Echo) error {
-	ret := _mock.Called(ctx, newEcho)
+// PublishDueEchos provides a mock function for the type MockService
+func (_mock *MockService) PublishDueEchos(ctx context.Context) (int, error) {
+	ret := _mock.Called(ctx)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateEcho")
+		panic("no return value specified for PublishDueEchos")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Echo) error); ok {
-		r0 = returnFunc(ctx, newEcho)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return returnFunc(ctx)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = returnFunc(ctx)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = returnFunc(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepository_CreateEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEcho'
-type MockRepository_CreateEcho_Call struct {
+// MockService_PublishDueEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishDueEchos'
+type MockService_PublishDueEchos_Call struct {
 	*mock.Call
 }
 
-// CreateEcho is a helper method to define mock.On call
+// PublishDueEchos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - newEcho *model.Echo
-func (_e *MockRepository_Expecter) CreateEcho(ctx any, newEcho any) *MockRepository_CreateEcho_Call {
-	return &MockRepository_CreateEcho_Call{Call: _e.mock.On("CreateEcho", ctx, newEcho)}
+func (_e *MockService_Expecter) PublishDueEchos(ctx any) *MockService_PublishDueEchos_Call {
+	return &MockService_PublishDueEchos_Call{Call: _e.mock.On("PublishDueEchos", ctx)}
 }
 
-func (_c *MockRepository_CreateEcho_Call) Run(run func(ctx context.Context, newEcho *model.Echo)) *MockRepository_CreateEcho_Call {
+func (_c *MockService_PublishDueEchos_Call) Run(run func(ctx context.Context)) *MockService_PublishDueEchos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Echo
-		if args[1] != nil {
-			arg1 = args[1].(*model.Echo)
-		}
 		run(
 			arg0,
-			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_CreateEcho_Call) Return(err error) *MockRepository_CreateEcho_Call {
-	_c.Call.Return(err)
+func (_c *MockService_PublishDueEchos_Call) Return(n int, err error) *MockService_PublishDueEchos_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepository_CreateEcho_Call) RunAndReturn(run func(ctx context.Context, newEcho *model.Echo) error) *MockRepository_CreateEcho_Call {
+func (_c *MockService_PublishDueEchos_Call) RunAndReturn(run func(ctx context.Context) (int, error)) *MockService_PublishDueEchos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateTag provides a mock function for the type MockRepository
-func (_mock *MockRepository) CreateTag(ctx context.Context, tag *model.Tag) error {
-	ret := _mock.Called(ctx, tag)
+// PurgeEcho provides a mock function for the type MockService
+func (_mock *MockService) PurgeEcho(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTag")
+		panic("no return value specified for PurgeEcho")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Tag) error); ok {
-		r0 = returnFunc(ctx, tag)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
-type MockRepository_CreateTag_Call struct {
+// MockService_PurgeEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeEcho'
+type MockService_PurgeEcho_Call struct {
 	*mock.Call
 }
 
-// CreateTag is a helper method to define mock.On call
+// PurgeEcho is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tag *model.Tag
-func (_e *MockRepository_Expecter) CreateTag(ctx any, tag any) *MockRepository_CreateTag_Call {
-	return &MockRepository_CreateTag_Call{Call: _e.mock.On("CreateTag", ctx, tag)}
+//   - id string
+func (_e *MockService_Expecter) PurgeEcho(ctx any, id any) *MockService_PurgeEcho_Call {
+	return &MockService_PurgeEcho_Call{Call: _e.mock.On("PurgeEcho", ctx, id)}
 }
 
-func (_c *MockRepository_CreateTag_Call) Run(run func(ctx context.Context, tag *model.Tag)) *MockRepository_CreateTag_Call {
+func (_c *MockService_PurgeEcho_Call) Run(run func(ctx context.Context, id string)) *MockService_PurgeEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 *model.Tag
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(*model.Tag)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1119,54 +1186,63 @@ func (_c *MockRepository_CreateTag_Call) Run(run func(ctx context.Context, tag *
 	return _c
 }
 
-func (_c *MockRepository_CreateTag_Call) Return(err error) *MockRepository_CreateTag_Call {
+func (_c *MockService_PurgeEcho_Call) Return(err error) *MockService_PurgeEcho_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_CreateTag_Call) RunAndReturn(run func(ctx context.Context, tag *model.Tag) error) *MockRepository_CreateTag_Call {
+func (_c *MockService_PurgeEcho_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockService_PurgeEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteEchoById provides a mock function for the type MockRepository
-func (_mock *MockRepository) DeleteEchoById(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// PurgeExpiredTrash provides a mock function for the type MockService
+func (_mock *MockService) PurgeExpiredTrash(ctx context.Context, before int64) (int, error) {
+	ret := _mock.Called(ctx, before)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteEchoById")
+		panic("no return value specified for PurgeExpiredTrash")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 int
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) (int, error)); ok {
+		return returnFunc(ctx, before)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64) int); ok {
+		r0 = returnFunc(ctx, before)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(int)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64) error); ok {
+		r1 = returnFunc(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepository_DeleteEchoById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEchoById'
-type MockRepository_DeleteEchoById_Call struct {
+// MockService_PurgeExpiredTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeExpiredTrash'
+type MockService_PurgeExpiredTrash_Call struct {
 	*mock.Call
 }
 
-// DeleteEchoById is a helper method to define mock.On call
+// PurgeExpiredTrash is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockRepository_Expecter) DeleteEchoById(ctx any, id any) *MockRepository_DeleteEchoById_Call {
-	return &MockRepository_DeleteEchoById_Call{Call: _e.mock.On("DeleteEchoById", ctx, id)}
+//   - before int64
+func (_e *MockService_Expecter) PurgeExpiredTrash(ctx any, before any) *MockService_PurgeExpiredTrash_Call {
+	return &MockService_PurgeExpiredTrash_Call{Call: _e.mock.On("PurgeExpiredTrash", ctx, before)}
 }
 
-func (_c *MockRepository_DeleteEchoById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteEchoById_Call {
+func (_c *MockService_PurgeExpiredTrash_Call) Run(run func(ctx context.Context, before int64)) *MockService_PurgeExpiredTrash_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 int64
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(int64)
 		}
 		run(
 			arg0,
@@ -1176,54 +1252,63 @@ func (_c *MockRepository_DeleteEchoById_Call) Run(run func(ctx context.Context,
 	return _c
 }
 
-func (_c *MockRepository_DeleteEchoById_Call) Return(err error) *MockRepository_DeleteEchoById_Call {
-	_c.Call.Return(err)
+func (_c *MockService_PurgeExpiredTrash_Call) Return(n int, err error) *MockService_PurgeExpiredTrash_Call {
+	_c.Call.Return(n, err)
 	return _c
 }
 
-func (_c *MockRepository_DeleteEchoById_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteEchoById_Call {
+func (_c *MockService_PurgeExpiredTrash_Call) RunAndReturn(run func(ctx context.Context, before int64) (int, error)) *MockService_PurgeExpiredTrash_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTagById provides a mock function for the type MockRepository
-func (_mock *MockRepository) DeleteTagById(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// QueryEchos provides a mock function for the type MockService
+func (_mock *MockService) QueryEchos(ctx context.Context, queryDto model0.EchoQueryDto) (model0.PageQueryResult[[]model.Echo], error) {
+	ret := _mock.Called(ctx, queryDto)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTagById")
+		panic("no return value specified for QueryEchos")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	var r0 model0.PageQueryResult[[]model.Echo]
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model0.EchoQueryDto) (model0.PageQueryResult[[]model.Echo], error)); ok {
+		return returnFunc(ctx, queryDto)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model0.EchoQueryDto) model0.PageQueryResult[[]model.Echo]); ok {
+		r0 = returnFunc(ctx, queryDto)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(model0.PageQueryResult[[]model.Echo])
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model0.EchoQueryDto) error); ok {
+		r1 = returnFunc(ctx, queryDto)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepository_DeleteTagById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTagById'
-type MockRepository_DeleteTagById_Call struct {
+// MockService_QueryEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryEchos'
+type MockService_QueryEchos_Call struct {
 	*mock.Call
 }
 
-// DeleteTagById is a helper method to define mock.On call
+// QueryEchos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockRepository_Expecter) DeleteTagById(ctx any, id any) *MockRepository_DeleteTagById_Call {
-	return &MockRepository_DeleteTagById_Call{Call: _e.mock.On("DeleteTagById", ctx, id)}
+//   - queryDto model0.EchoQueryDto
+func (_e *MockService_Expecter) QueryEchos(ctx any, queryDto any) *MockService_QueryEchos_Call {
+	return &MockService_QueryEchos_Call{Call: _e.mock.On("QueryEchos", ctx, queryDto)}
 }
 
-func (_c *MockRepository_DeleteTagById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteTagById_Call {
+func (_c *MockService_QueryEchos_Call) Run(run func(ctx context.Context, queryDto model0.EchoQueryDto)) *MockService_QueryEchos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 model0.EchoQueryDto
 		if args[1] != nil {
-			arg1 = args[1].(string)
+			arg1 = args[1].(model0.EchoQueryDto)
 		}
 		run(
 			arg0,
@@ -1233,77 +1318,22 @@ func (_c *MockRepository_DeleteTagById_Call) Run(run func(ctx context.Context, i
 	return _c
 }
 
-func (_c *MockRepository_DeleteTagById_Call) Return(err error) *MockRepository_DeleteTagById_Call {
-	_c.Call.Return(err)
-	return _c
-}
-
-func (_c *MockRepository_DeleteTagById_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteTagById_Call {
-	_c.Call.Return(run)
-	return _c
-}
-
-// GetAllTags provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetAllTags() ([]model.Tag, error) {
-	ret := _mock.Called()
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetAllTags")
-	}
-
-	var r0 []model.Tag
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func() ([]model.Tag, error)); ok {
-		return returnFunc()
-	}
-	if returnFunc, ok := ret.Get(0).(func() []model.Tag); ok {
-		r0 = returnFunc()
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Tag)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func() error); ok {
-		r1 = returnFunc()
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-// MockRepository_GetAllTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTags'
-type MockRepository_GetAllTags_Call struct {
-	*mock.Call
-}
-
-// GetAllTags is a helper method to define mock.On call
-func (_e *MockRepository_Expecter) GetAllTags() *MockRepository_GetAllTags_Call {
-	return &MockRepository_GetAllTags_Call{Call: _e.mock.On("GetAllTags")}
-}
-
-func (_c *MockRepository_GetAllTags_Call) Run(run func()) *MockRepository_GetAllTags_Call {
-	_c.Call.Run(func(args mock.Arguments) {
-		run()
-	})
-	return _c
-}
-
-func (_c *MockRepository_GetAllTags_Call) Return(tags []model.Tag, err error) *MockRepository_GetAllTags_Call {
-	_c.Call.Return(tags, err)
+func (_c *MockService_QueryEchos_Call) Return(pageQueryResult model0.PageQueryResult[[]model.Echo], err error) *MockService_QueryEchos_Call {
+	_c.Call.Return(pageQueryResult, err)
 	return _c
 }
 
-func (_c *MockRepository_GetAllTags_Call) RunAndReturn(run func() ([]model.Tag, error)) *MockRepository_GetAllTags_Call {
+func (_c *MockService_QueryEchos_Call) RunAndReturn(run func(ctx context.Context, queryDto model0.EchoQueryDto) (model0.PageQueryResult[[]model.Echo], error)) *MockService_QueryEchos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEchosById provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetEchosById(ctx context.Context, id string) (*model.Echo, error) {
+// RestoreEcho provides a mock function for the type MockService
+func (_mock *MockService) RestoreEcho(ctx context.Context, id string) (*model.Echo, error) {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEchosById")
+		panic("no return value specified for RestoreEcho")
 	}
 
 	var r0 *model.Echo
@@ -1326,19 +1356,19 @@ func (_mock *MockRepository) GetEchosById(ctx context.Context, id string) (*mode
 	return r0, r1
 }
 
-// MockRepository_GetEchosById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchosById'
-type MockRepository_GetEchosById_Call struct {
+// MockService_RestoreEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEcho'
+type MockService_RestoreEcho_Call struct {
 	*mock.Call
 }
 
-// GetEchosById is a helper method to define mock.On call
+// RestoreEcho is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockRepository_Expecter) GetEchosById(ctx any, id any) *MockRepository_GetEchosById_Call {
-	return &MockRepository_GetEchosById_Call{Call: _e.mock.On("GetEchosById", ctx, id)}
+func (_e *MockService_Expecter) RestoreEcho(ctx any, id any) *MockService_RestoreEcho_Call {
+	return &MockService_RestoreEcho_Call{Call: _e.mock.On("RestoreEcho", ctx, id)}
 }
 
-func (_c *MockRepository_GetEchosById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetEchosById_Call {
+func (_c *MockService_RestoreEcho_Call) Run(run func(ctx context.Context, id string)) *MockService_RestoreEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1356,237 +1386,1398 @@ func (_c *MockRepository_GetEchosById_Call) Run(run func(ctx context.Context, id
 	return _c
 }
 
-func (_c *MockRepository_GetEchosById_Call) Return(echo *model.Echo, err error) *MockRepository_GetEchosById_Call {
+func (_c *MockService_RestoreEcho_Call) Return(echo *model.Echo, err error) *MockService_RestoreEcho_Call {
 	_c.Call.Return(echo, err)
 	return _c
 }
 
-func (_c *MockRepository_GetEchosById_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Echo, error)) *MockRepository_GetEchosById_Call {
+func (_c *MockService_RestoreEcho_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Echo, error)) *MockService_RestoreEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEchosByPage provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetEchosByPage(page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64) {
-	ret := _mock.Called(page, pageSize, search, showPrivate)
+// RestoreEchoRevision provides a mock function for the type MockService
+func (_mock *MockService) RestoreEchoRevision(ctx context.Context, echoID string, revisionID string) (*model.Echo, error) {
+	ret := _mock.Called(ctx, echoID, revisionID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEchosByPage")
+		panic("no return value specified for RestoreEchoRevision")
 	}
 
-	var r0 []model.Echo
-	var r1 int64
-	if returnFunc, ok := ret.Get(0).(func(int, int, string, bool) ([]model.Echo, int64)); ok {
-		return returnFunc(page, pageSize, search, showPrivate)
+	var r0 *model.Echo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Echo, error)); ok {
+		return returnFunc(ctx, echoID, revisionID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, int, string, bool) []model.Echo); ok {
-		r0 = returnFunc(page, pageSize, search, showPrivate)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Echo); ok {
+		r0 = returnFunc(ctx, echoID, revisionID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Echo)
+			r0 = ret.Get(0).(*model.Echo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, int, string, bool) int64); ok {
-		r1 = returnFunc(page, pageSize, search, showPrivate)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, echoID, revisionID)
 	} else {
-		r1 = ret.Get(1).(int64)
+		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_GetEchosByPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchosByPage'
-type MockRepository_GetEchosByPage_Call struct {
+// MockService_RestoreEchoRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEchoRevision'
+type MockService_RestoreEchoRevision_Call struct {
 	*mock.Call
 }
 
-// GetEchosByPage is a helper method to define mock.On call
-//   - page int
-//   - pageSize int
-//   - search string
-//   - showPrivate bool
-func (_e *MockRepository_Expecter) GetEchosByPage(page any, pageSize any, search any, showPrivate any) *MockRepository_GetEchosByPage_Call {
-	return &MockRepository_GetEchosByPage_Call{Call: _e.mock.On("GetEchosByPage", page, pageSize, search, showPrivate)}
+// RestoreEchoRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - revisionID string
+func (_e *MockService_Expecter) RestoreEchoRevision(ctx any, echoID any, revisionID any) *MockService_RestoreEchoRevision_Call {
+	return &MockService_RestoreEchoRevision_Call{Call: _e.mock.On("RestoreEchoRevision", ctx, echoID, revisionID)}
 }
 
-func (_c *MockRepository_GetEchosByPage_Call) Run(run func(page int, pageSize int, search string, showPrivate bool)) *MockRepository_GetEchosByPage_Call {
+func (_c *MockService_RestoreEchoRevision_Call) Run(run func(ctx context.Context, echoID string, revisionID string)) *MockService_RestoreEchoRevision_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(int)
+			arg1 = args[1].(string)
 		}
 		var arg2 string
 		if args[2] != nil {
 			arg2 = args[2].(string)
 		}
-		var arg3 bool
-		if args[3] != nil {
-			arg3 = args[3].(bool)
-		}
 		run(
 			arg0,
 			arg1,
 			arg2,
-			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetEchosByPage_Call) Return(echos []model.Echo, n int64) *MockRepository_GetEchosByPage_Call {
-	_c.Call.Return(echos, n)
+func (_c *MockService_RestoreEchoRevision_Call) Return(echo *model.Echo, err error) *MockService_RestoreEchoRevision_Call {
+	_c.Call.Return(echo, err)
 	return _c
 }
 
-func (_c *MockRepository_GetEchosByPage_Call) RunAndReturn(run func(page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64)) *MockRepository_GetEchosByPage_Call {
+func (_c *MockService_RestoreEchoRevision_Call) RunAndReturn(run func(ctx context.Context, echoID string, revisionID string) (*model.Echo, error)) *MockService_RestoreEchoRevision_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetEchosByTagId provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetEchosByTagId(tagID string, page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64, error) {
-	ret := _mock.Called(tagID, page, pageSize, search, showPrivate)
+// UpdateEcho provides a mock function for the type MockService
+func (_mock *MockService) UpdateEcho(ctx context.Context, echo *model.Echo) error {
+	ret := _mock.Called(ctx, echo)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetEchosByTagId")
+		panic("no return value specified for UpdateEcho")
 	}
 
-	var r0 []model.Echo
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(string, int, int, string, bool) ([]model.Echo, int64, error)); ok {
-		return returnFunc(tagID, page, pageSize, search, showPrivate)
-	}
-	if returnFunc, ok := ret.Get(0).(func(string, int, int, string, bool) []model.Echo); ok {
-		r0 = returnFunc(tagID, page, pageSize, search, showPrivate)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Echo)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(string, int, int, string, bool) int64); ok {
-		r1 = returnFunc(tagID, page, pageSize, search, showPrivate)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(string, int, int, string, bool) error); ok {
-		r2 = returnFunc(tagID, page, pageSize, search, showPrivate)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Echo) error); ok {
+		r0 = returnFunc(ctx, echo)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockRepository_GetEchosByTagId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchosByTagId'
-type MockRepository_GetEchosByTagId_Call struct {
+// MockService_UpdateEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateEcho'
+type MockService_UpdateEcho_Call struct {
 	*mock.Call
 }
 
-// GetEchosByTagId is a helper method to define mock.On call
-//   - tagID string
-//   - page int
-//   - pageSize int
-//   - search string
-//   - showPrivate bool
-func (_e *MockRepository_Expecter) GetEchosByTagId(tagID any, page any, pageSize any, search any, showPrivate any) *MockRepository_GetEchosByTagId_Call {
-	return &MockRepository_GetEchosByTagId_Call{Call: _e.mock.On("GetEchosByTagId", tagID, page, pageSize, search, showPrivate)}
+// UpdateEcho is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echo *model.Echo
+func (_e *MockService_Expecter) UpdateEcho(ctx any, echo any) *MockService_UpdateEcho_Call {
+	return &MockService_UpdateEcho_Call{Call: _e.mock.On("UpdateEcho", ctx, echo)}
 }
 
-func (_c *MockRepository_GetEchosByTagId_Call) Run(run func(tagID string, page int, pageSize int, search string, showPrivate bool)) *MockRepository_GetEchosByTagId_Call {
+func (_c *MockService_UpdateEcho_Call) Run(run func(ctx context.Context, echo *model.Echo)) *MockService_UpdateEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 string
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(string)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 int
+		var arg1 *model.Echo
 		if args[1] != nil {
-			arg1 = args[1].(int)
-		}
-		var arg2 int
-		if args[2] != nil {
-			arg2 = args[2].(int)
-		}
-		var arg3 string
-		if args[3] != nil {
-			arg3 = args[3].(string)
-		}
-		var arg4 bool
-		if args[4] != nil {
-			arg4 = args[4].(bool)
+			arg1 = args[1].(*model.Echo)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
-			arg3,
-			arg4,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetEchosByTagId_Call) Return(echos []model.Echo, n int64, err error) *MockRepository_GetEchosByTagId_Call {
-	_c.Call.Return(echos, n, err)
+func (_c *MockService_UpdateEcho_Call) Return(err error) *MockService_UpdateEcho_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_GetEchosByTagId_Call) RunAndReturn(run func(tagID string, page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64, error)) *MockRepository_GetEchosByTagId_Call {
+func (_c *MockService_UpdateEcho_Call) RunAndReturn(run func(ctx context.Context, echo *model.Echo) error) *MockService_UpdateEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetHotEchos provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetHotEchos(limit int, showPrivate bool) ([]model.Echo, error) {
-	ret := _mock.Called(limit, showPrivate)
-
-	if len(ret) == 0 {
-		panic("no return value specified for GetHotEchos")
-	}
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+type MockRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockRepository) EXPECT() *MockRepository_Expecter {
+	return &MockRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateEcho provides a mock function for the type MockRepository
+func (_mock *MockRepository) CreateEcho(ctx context.Context, newEcho *model.Echo) error {
+	ret := _mock.Called(ctx, newEcho)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEcho")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Echo) error); ok {
+		r0 = returnFunc(ctx, newEcho)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CreateEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEcho'
+type MockRepository_CreateEcho_Call struct {
+	*mock.Call
+}
+
+// CreateEcho is a helper method to define mock.On call
+//   - ctx context.Context
+//   - newEcho *model.Echo
+func (_e *MockRepository_Expecter) CreateEcho(ctx any, newEcho any) *MockRepository_CreateEcho_Call {
+	return &MockRepository_CreateEcho_Call{Call: _e.mock.On("CreateEcho", ctx, newEcho)}
+}
+
+func (_c *MockRepository_CreateEcho_Call) Run(run func(ctx context.Context, newEcho *model.Echo)) *MockRepository_CreateEcho_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Echo
+		if args[1] != nil {
+			arg1 = args[1].(*model.Echo)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateEcho_Call) Return(err error) *MockRepository_CreateEcho_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CreateEcho_Call) RunAndReturn(run func(ctx context.Context, newEcho *model.Echo) error) *MockRepository_CreateEcho_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateEchoRevision provides a mock function for the type MockRepository
+func (_mock *MockRepository) CreateEchoRevision(ctx context.Context, revision *model.EchoRevision) error {
+	ret := _mock.Called(ctx, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateEchoRevision")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.EchoRevision) error); ok {
+		r0 = returnFunc(ctx, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CreateEchoRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateEchoRevision'
+type MockRepository_CreateEchoRevision_Call struct {
+	*mock.Call
+}
+
+// CreateEchoRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - revision *model.EchoRevision
+func (_e *MockRepository_Expecter) CreateEchoRevision(ctx any, revision any) *MockRepository_CreateEchoRevision_Call {
+	return &MockRepository_CreateEchoRevision_Call{Call: _e.mock.On("CreateEchoRevision", ctx, revision)}
+}
+
+func (_c *MockRepository_CreateEchoRevision_Call) Run(run func(ctx context.Context, revision *model.EchoRevision)) *MockRepository_CreateEchoRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.EchoRevision
+		if args[1] != nil {
+			arg1 = args[1].(*model.EchoRevision)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateEchoRevision_Call) Return(err error) *MockRepository_CreateEchoRevision_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CreateEchoRevision_Call) RunAndReturn(run func(ctx context.Context, revision *model.EchoRevision) error) *MockRepository_CreateEchoRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTag provides a mock function for the type MockRepository
+func (_mock *MockRepository) CreateTag(ctx context.Context, tag *model.Tag) error {
+	ret := _mock.Called(ctx, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTag")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *model.Tag) error); ok {
+		r0 = returnFunc(ctx, tag)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_CreateTag_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTag'
+type MockRepository_CreateTag_Call struct {
+	*mock.Call
+}
+
+// CreateTag is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tag *model.Tag
+func (_e *MockRepository_Expecter) CreateTag(ctx any, tag any) *MockRepository_CreateTag_Call {
+	return &MockRepository_CreateTag_Call{Call: _e.mock.On("CreateTag", ctx, tag)}
+}
+
+func (_c *MockRepository_CreateTag_Call) Run(run func(ctx context.Context, tag *model.Tag)) *MockRepository_CreateTag_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 *model.Tag
+		if args[1] != nil {
+			arg1 = args[1].(*model.Tag)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_CreateTag_Call) Return(err error) *MockRepository_CreateTag_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_CreateTag_Call) RunAndReturn(run func(ctx context.Context, tag *model.Tag) error) *MockRepository_CreateTag_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteEchoById provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteEchoById(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteEchoById")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteEchoById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteEchoById'
+type MockRepository_DeleteEchoById_Call struct {
+	*mock.Call
+}
+
+// DeleteEchoById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) DeleteEchoById(ctx any, id any) *MockRepository_DeleteEchoById_Call {
+	return &MockRepository_DeleteEchoById_Call{Call: _e.mock.On("DeleteEchoById", ctx, id)}
+}
+
+func (_c *MockRepository_DeleteEchoById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteEchoById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteEchoById_Call) Return(err error) *MockRepository_DeleteEchoById_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteEchoById_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteEchoById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteSearchIndex provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteSearchIndex(ctx context.Context, echoID string) error {
+	ret := _mock.Called(ctx, echoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSearchIndex")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, echoID)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteSearchIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteSearchIndex'
+type MockRepository_DeleteSearchIndex_Call struct {
+	*mock.Call
+}
+
+// DeleteSearchIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockRepository_Expecter) DeleteSearchIndex(ctx any, echoID any) *MockRepository_DeleteSearchIndex_Call {
+	return &MockRepository_DeleteSearchIndex_Call{Call: _e.mock.On("DeleteSearchIndex", ctx, echoID)}
+}
+
+func (_c *MockRepository_DeleteSearchIndex_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_DeleteSearchIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteSearchIndex_Call) Return(err error) *MockRepository_DeleteSearchIndex_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteSearchIndex_Call) RunAndReturn(run func(ctx context.Context, echoID string) error) *MockRepository_DeleteSearchIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTagById provides a mock function for the type MockRepository
+func (_mock *MockRepository) DeleteTagById(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTagById")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_DeleteTagById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTagById'
+type MockRepository_DeleteTagById_Call struct {
+	*mock.Call
+}
+
+// DeleteTagById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) DeleteTagById(ctx any, id any) *MockRepository_DeleteTagById_Call {
+	return &MockRepository_DeleteTagById_Call{Call: _e.mock.On("DeleteTagById", ctx, id)}
+}
+
+func (_c *MockRepository_DeleteTagById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_DeleteTagById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_DeleteTagById_Call) Return(err error) *MockRepository_DeleteTagById_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_DeleteTagById_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_DeleteTagById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAllTags provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetAllTags() ([]model.Tag, error) {
+	ret := _mock.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTags")
+	}
+
+	var r0 []model.Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func() ([]model.Tag, error)); ok {
+		return returnFunc()
+	}
+	if returnFunc, ok := ret.Get(0).(func() []model.Tag); ok {
+		r0 = returnFunc()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func() error); ok {
+		r1 = returnFunc()
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetAllTags_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAllTags'
+type MockRepository_GetAllTags_Call struct {
+	*mock.Call
+}
+
+// GetAllTags is a helper method to define mock.On call
+func (_e *MockRepository_Expecter) GetAllTags() *MockRepository_GetAllTags_Call {
+	return &MockRepository_GetAllTags_Call{Call: _e.mock.On("GetAllTags")}
+}
+
+func (_c *MockRepository_GetAllTags_Call) Run(run func()) *MockRepository_GetAllTags_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetAllTags_Call) Return(tags []model.Tag, err error) *MockRepository_GetAllTags_Call {
+	_c.Call.Return(tags, err)
+	return _c
+}
+
+func (_c *MockRepository_GetAllTags_Call) RunAndReturn(run func() ([]model.Tag, error)) *MockRepository_GetAllTags_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDueScheduledEchos provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetDueScheduledEchos(ctx context.Context, now int64, limit int) ([]model.Echo, error) {
+	ret := _mock.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDueScheduledEchos")
+	}
+
+	var r0 []model.Echo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]model.Echo, error)); ok {
+		return returnFunc(ctx, now, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []model.Echo); ok {
+		r0 = returnFunc(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetDueScheduledEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDueScheduledEchos'
+type MockRepository_GetDueScheduledEchos_Call struct {
+	*mock.Call
+}
+
+// GetDueScheduledEchos is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now int64
+//   - limit int
+func (_e *MockRepository_Expecter) GetDueScheduledEchos(ctx any, now any, limit any) *MockRepository_GetDueScheduledEchos_Call {
+	return &MockRepository_GetDueScheduledEchos_Call{Call: _e.mock.On("GetDueScheduledEchos", ctx, now, limit)}
+}
+
+func (_c *MockRepository_GetDueScheduledEchos_Call) Run(run func(ctx context.Context, now int64, limit int)) *MockRepository_GetDueScheduledEchos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetDueScheduledEchos_Call) Return(echos []model.Echo, err error) *MockRepository_GetDueScheduledEchos_Call {
+	_c.Call.Return(echos, err)
+	return _c
+}
+
+func (_c *MockRepository_GetDueScheduledEchos_Call) RunAndReturn(run func(ctx context.Context, now int64, limit int) ([]model.Echo, error)) *MockRepository_GetDueScheduledEchos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEchoRevision provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetEchoRevision(ctx context.Context, echoID string, revisionID string) (*model.EchoRevision, error) {
+	ret := _mock.Called(ctx, echoID, revisionID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEchoRevision")
+	}
+
+	var r0 *model.EchoRevision
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.EchoRevision, error)); ok {
+		return returnFunc(ctx, echoID, revisionID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.EchoRevision); ok {
+		r0 = returnFunc(ctx, echoID, revisionID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EchoRevision)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, echoID, revisionID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetEchoRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchoRevision'
+type MockRepository_GetEchoRevision_Call struct {
+	*mock.Call
+}
+
+// GetEchoRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - revisionID string
+func (_e *MockRepository_Expecter) GetEchoRevision(ctx any, echoID any, revisionID any) *MockRepository_GetEchoRevision_Call {
+	return &MockRepository_GetEchoRevision_Call{Call: _e.mock.On("GetEchoRevision", ctx, echoID, revisionID)}
+}
+
+func (_c *MockRepository_GetEchoRevision_Call) Run(run func(ctx context.Context, echoID string, revisionID string)) *MockRepository_GetEchoRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetEchoRevision_Call) Return(echoRevision *model.EchoRevision, err error) *MockRepository_GetEchoRevision_Call {
+	_c.Call.Return(echoRevision, err)
+	return _c
+}
+
+func (_c *MockRepository_GetEchoRevision_Call) RunAndReturn(run func(ctx context.Context, echoID string, revisionID string) (*model.EchoRevision, error)) *MockRepository_GetEchoRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEchosById provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetEchosById(ctx context.Context, id string) (*model.Echo, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEchosById")
+	}
+
+	var r0 *model.Echo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Echo, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Echo); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetEchosById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchosById'
+type MockRepository_GetEchosById_Call struct {
+	*mock.Call
+}
+
+// GetEchosById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) GetEchosById(ctx any, id any) *MockRepository_GetEchosById_Call {
+	return &MockRepository_GetEchosById_Call{Call: _e.mock.On("GetEchosById", ctx, id)}
+}
+
+func (_c *MockRepository_GetEchosById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetEchosById_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetEchosById_Call) Return(echo *model.Echo, err error) *MockRepository_GetEchosById_Call {
+	_c.Call.Return(echo, err)
+	return _c
+}
+
+func (_c *MockRepository_GetEchosById_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Echo, error)) *MockRepository_GetEchosById_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEchosByPage provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetEchosByPage(page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64) {
+	ret := _mock.Called(page, pageSize, search, showPrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEchosByPage")
+	}
+
+	var r0 []model.Echo
+	var r1 int64
+	if returnFunc, ok := ret.Get(0).(func(int, int, string, bool) ([]model.Echo, int64)); ok {
+		return returnFunc(page, pageSize, search, showPrivate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, int, string, bool) []model.Echo); ok {
+		r0 = returnFunc(page, pageSize, search, showPrivate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, int, string, bool) int64); ok {
+		r1 = returnFunc(page, pageSize, search, showPrivate)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetEchosByPage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchosByPage'
+type MockRepository_GetEchosByPage_Call struct {
+	*mock.Call
+}
+
+// GetEchosByPage is a helper method to define mock.On call
+//   - page int
+//   - pageSize int
+//   - search string
+//   - showPrivate bool
+func (_e *MockRepository_Expecter) GetEchosByPage(page any, pageSize any, search any, showPrivate any) *MockRepository_GetEchosByPage_Call {
+	return &MockRepository_GetEchosByPage_Call{Call: _e.mock.On("GetEchosByPage", page, pageSize, search, showPrivate)}
+}
+
+func (_c *MockRepository_GetEchosByPage_Call) Run(run func(page int, pageSize int, search string, showPrivate bool)) *MockRepository_GetEchosByPage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 bool
+		if args[3] != nil {
+			arg3 = args[3].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetEchosByPage_Call) Return(echos []model.Echo, n int64) *MockRepository_GetEchosByPage_Call {
+	_c.Call.Return(echos, n)
+	return _c
+}
+
+func (_c *MockRepository_GetEchosByPage_Call) RunAndReturn(run func(page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64)) *MockRepository_GetEchosByPage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetEchosByTagId provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetEchosByTagId(tagID string, page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64, error) {
+	ret := _mock.Called(tagID, page, pageSize, search, showPrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEchosByTagId")
+	}
+
+	var r0 []model.Echo
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(string, int, int, string, bool) ([]model.Echo, int64, error)); ok {
+		return returnFunc(tagID, page, pageSize, search, showPrivate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(string, int, int, string, bool) []model.Echo); ok {
+		r0 = returnFunc(tagID, page, pageSize, search, showPrivate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(string, int, int, string, bool) int64); ok {
+		r1 = returnFunc(tagID, page, pageSize, search, showPrivate)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(string, int, int, string, bool) error); ok {
+		r2 = returnFunc(tagID, page, pageSize, search, showPrivate)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepository_GetEchosByTagId_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetEchosByTagId'
+type MockRepository_GetEchosByTagId_Call struct {
+	*mock.Call
+}
+
+// GetEchosByTagId is a helper method to define mock.On call
+//   - tagID string
+//   - page int
+//   - pageSize int
+//   - search string
+//   - showPrivate bool
+func (_e *MockRepository_Expecter) GetEchosByTagId(tagID any, page any, pageSize any, search any, showPrivate any) *MockRepository_GetEchosByTagId_Call {
+	return &MockRepository_GetEchosByTagId_Call{Call: _e.mock.On("GetEchosByTagId", tagID, page, pageSize, search, showPrivate)}
+}
+
+func (_c *MockRepository_GetEchosByTagId_Call) Run(run func(tagID string, page int, pageSize int, search string, showPrivate bool)) *MockRepository_GetEchosByTagId_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 string
+		if args[0] != nil {
+			arg0 = args[0].(string)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 bool
+		if args[4] != nil {
+			arg4 = args[4].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetEchosByTagId_Call) Return(echos []model.Echo, n int64, err error) *MockRepository_GetEchosByTagId_Call {
+	_c.Call.Return(echos, n, err)
+	return _c
+}
+
+func (_c *MockRepository_GetEchosByTagId_Call) RunAndReturn(run func(tagID string, page int, pageSize int, search string, showPrivate bool) ([]model.Echo, int64, error)) *MockRepository_GetEchosByTagId_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetExpiredTrashedEchoIDs provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetExpiredTrashedEchoIDs(ctx context.Context, before int64, limit int) ([]string, error) {
+	ret := _mock.Called(ctx, before, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiredTrashedEchoIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) ([]string, error)); ok {
+		return returnFunc(ctx, before, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int64, int) []string); ok {
+		r0 = returnFunc(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = returnFunc(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetExpiredTrashedEchoIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetExpiredTrashedEchoIDs'
+type MockRepository_GetExpiredTrashedEchoIDs_Call struct {
+	*mock.Call
+}
+
+// GetExpiredTrashedEchoIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before int64
+//   - limit int
+func (_e *MockRepository_Expecter) GetExpiredTrashedEchoIDs(ctx any, before any, limit any) *MockRepository_GetExpiredTrashedEchoIDs_Call {
+	return &MockRepository_GetExpiredTrashedEchoIDs_Call{Call: _e.mock.On("GetExpiredTrashedEchoIDs", ctx, before, limit)}
+}
+
+func (_c *MockRepository_GetExpiredTrashedEchoIDs_Call) Run(run func(ctx context.Context, before int64, limit int)) *MockRepository_GetExpiredTrashedEchoIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int64
+		if args[1] != nil {
+			arg1 = args[1].(int64)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetExpiredTrashedEchoIDs_Call) Return(strings []string, err error) *MockRepository_GetExpiredTrashedEchoIDs_Call {
+	_c.Call.Return(strings, err)
+	return _c
+}
+
+func (_c *MockRepository_GetExpiredTrashedEchoIDs_Call) RunAndReturn(run func(ctx context.Context, before int64, limit int) ([]string, error)) *MockRepository_GetExpiredTrashedEchoIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetHotEchos provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetHotEchos(limit int, showPrivate bool) ([]model.Echo, error) {
+	ret := _mock.Called(limit, showPrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetHotEchos")
+	}
+
+	var r0 []model.Echo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(int, bool) ([]model.Echo, error)); ok {
+		return returnFunc(limit, showPrivate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(int, bool) []model.Echo); ok {
+		r0 = returnFunc(limit, showPrivate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(int, bool) error); ok {
+		r1 = returnFunc(limit, showPrivate)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetHotEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHotEchos'
+type MockRepository_GetHotEchos_Call struct {
+	*mock.Call
+}
+
+// GetHotEchos is a helper method to define mock.On call
+//   - limit int
+//   - showPrivate bool
+func (_e *MockRepository_Expecter) GetHotEchos(limit any, showPrivate any) *MockRepository_GetHotEchos_Call {
+	return &MockRepository_GetHotEchos_Call{Call: _e.mock.On("GetHotEchos", limit, showPrivate)}
+}
+
+func (_c *MockRepository_GetHotEchos_Call) Run(run func(limit int, showPrivate bool)) *MockRepository_GetHotEchos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 int
+		if args[0] != nil {
+			arg0 = args[0].(int)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetHotEchos_Call) Return(echos []model.Echo, err error) *MockRepository_GetHotEchos_Call {
+	_c.Call.Return(echos, err)
+	return _c
+}
+
+func (_c *MockRepository_GetHotEchos_Call) RunAndReturn(run func(limit int, showPrivate bool) ([]model.Echo, error)) *MockRepository_GetHotEchos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetOnThisDayEchos provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetOnThisDayEchos(showPrivate bool, timezone string) []model.Echo {
+	ret := _mock.Called(showPrivate, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOnThisDayEchos")
+	}
+
+	var r0 []model.Echo
+	if returnFunc, ok := ret.Get(0).(func(bool, string) []model.Echo); ok {
+		r0 = returnFunc(showPrivate, timezone)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Echo)
+		}
+	}
+	return r0
+}
 
-	var r0 []model.Echo
+// MockRepository_GetOnThisDayEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOnThisDayEchos'
+type MockRepository_GetOnThisDayEchos_Call struct {
+	*mock.Call
+}
+
+// GetOnThisDayEchos is a helper method to define mock.On call
+//   - showPrivate bool
+//   - timezone string
+func (_e *MockRepository_Expecter) GetOnThisDayEchos(showPrivate any, timezone any) *MockRepository_GetOnThisDayEchos_Call {
+	return &MockRepository_GetOnThisDayEchos_Call{Call: _e.mock.On("GetOnThisDayEchos", showPrivate, timezone)}
+}
+
+func (_c *MockRepository_GetOnThisDayEchos_Call) Run(run func(showPrivate bool, timezone string)) *MockRepository_GetOnThisDayEchos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 bool
+		if args[0] != nil {
+			arg0 = args[0].(bool)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetOnThisDayEchos_Call) Return(echos []model.Echo) *MockRepository_GetOnThisDayEchos_Call {
+	_c.Call.Return(echos)
+	return _c
+}
+
+func (_c *MockRepository_GetOnThisDayEchos_Call) RunAndReturn(run func(showPrivate bool, timezone string) []model.Echo) *MockRepository_GetOnThisDayEchos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRandomEcho provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetRandomEcho(showPrivate bool) (*model.Echo, error) {
+	ret := _mock.Called(showPrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRandomEcho")
+	}
+
+	var r0 *model.Echo
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(int, bool) ([]model.Echo, error)); ok {
-		return returnFunc(limit, showPrivate)
+	if returnFunc, ok := ret.Get(0).(func(bool) (*model.Echo, error)); ok {
+		return returnFunc(showPrivate)
 	}
-	if returnFunc, ok := ret.Get(0).(func(int, bool) []model.Echo); ok {
-		r0 = returnFunc(limit, showPrivate)
+	if returnFunc, ok := ret.Get(0).(func(bool) *model.Echo); ok {
+		r0 = returnFunc(showPrivate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(bool) error); ok {
+		r1 = returnFunc(showPrivate)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetRandomEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRandomEcho'
+type MockRepository_GetRandomEcho_Call struct {
+	*mock.Call
+}
+
+// GetRandomEcho is a helper method to define mock.On call
+//   - showPrivate bool
+func (_e *MockRepository_Expecter) GetRandomEcho(showPrivate any) *MockRepository_GetRandomEcho_Call {
+	return &MockRepository_GetRandomEcho_Call{Call: _e.mock.On("GetRandomEcho", showPrivate)}
+}
+
+func (_c *MockRepository_GetRandomEcho_Call) Run(run func(showPrivate bool)) *MockRepository_GetRandomEcho_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 bool
+		if args[0] != nil {
+			arg0 = args[0].(bool)
+		}
+		run(
+			arg0,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetRandomEcho_Call) Return(echo *model.Echo, err error) *MockRepository_GetRandomEcho_Call {
+	_c.Call.Return(echo, err)
+	return _c
+}
+
+func (_c *MockRepository_GetRandomEcho_Call) RunAndReturn(run func(showPrivate bool) (*model.Echo, error)) *MockRepository_GetRandomEcho_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTagsByNames provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetTagsByNames(ctx context.Context, names []string) ([]*model.Tag, error) {
+	ret := _mock.Called(ctx, names)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTagsByNames")
+	}
+
+	var r0 []*model.Tag
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*model.Tag, error)); ok {
+		return returnFunc(ctx, names)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*model.Tag); ok {
+		r0 = returnFunc(ctx, names)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Tag)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = returnFunc(ctx, names)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockRepository_GetTagsByNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagsByNames'
+type MockRepository_GetTagsByNames_Call struct {
+	*mock.Call
+}
+
+// GetTagsByNames is a helper method to define mock.On call
+//   - ctx context.Context
+//   - names []string
+func (_e *MockRepository_Expecter) GetTagsByNames(ctx any, names any) *MockRepository_GetTagsByNames_Call {
+	return &MockRepository_GetTagsByNames_Call{Call: _e.mock.On("GetTagsByNames", ctx, names)}
+}
+
+func (_c *MockRepository_GetTagsByNames_Call) Run(run func(ctx context.Context, names []string)) *MockRepository_GetTagsByNames_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 []string
+		if args[1] != nil {
+			arg1 = args[1].([]string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTagsByNames_Call) Return(tags []*model.Tag, err error) *MockRepository_GetTagsByNames_Call {
+	_c.Call.Return(tags, err)
+	return _c
+}
+
+func (_c *MockRepository_GetTagsByNames_Call) RunAndReturn(run func(ctx context.Context, names []string) ([]*model.Tag, error)) *MockRepository_GetTagsByNames_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTodayEchos provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetTodayEchos(showPrivate bool, timezone string) []model.Echo {
+	ret := _mock.Called(showPrivate, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTodayEchos")
+	}
+
+	var r0 []model.Echo
+	if returnFunc, ok := ret.Get(0).(func(bool, string) []model.Echo); ok {
+		r0 = returnFunc(showPrivate, timezone)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.Echo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(int, bool) error); ok {
-		r1 = returnFunc(limit, showPrivate)
+	return r0
+}
+
+// MockRepository_GetTodayEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTodayEchos'
+type MockRepository_GetTodayEchos_Call struct {
+	*mock.Call
+}
+
+// GetTodayEchos is a helper method to define mock.On call
+//   - showPrivate bool
+//   - timezone string
+func (_e *MockRepository_Expecter) GetTodayEchos(showPrivate any, timezone any) *MockRepository_GetTodayEchos_Call {
+	return &MockRepository_GetTodayEchos_Call{Call: _e.mock.On("GetTodayEchos", showPrivate, timezone)}
+}
+
+func (_c *MockRepository_GetTodayEchos_Call) Run(run func(showPrivate bool, timezone string)) *MockRepository_GetTodayEchos_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 bool
+		if args[0] != nil {
+			arg0 = args[0].(bool)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetTodayEchos_Call) Return(echos []model.Echo) *MockRepository_GetTodayEchos_Call {
+	_c.Call.Return(echos)
+	return _c
+}
+
+func (_c *MockRepository_GetTodayEchos_Call) RunAndReturn(run func(showPrivate bool, timezone string) []model.Echo) *MockRepository_GetTodayEchos_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrashedEchoById provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetTrashedEchoById(ctx context.Context, id string) (*model.Echo, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrashedEchoById")
+	}
+
+	var r0 *model.Echo
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Echo, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Echo); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_GetHotEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetHotEchos'
-type MockRepository_GetHotEchos_Call struct {
+// MockRepository_GetTrashedEchoById_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrashedEchoById'
+type MockRepository_GetTrashedEchoById_Call struct {
 	*mock.Call
 }
 
-// GetHotEchos is a helper method to define mock.On call
-//   - limit int
-//   - showPrivate bool
-func (_e *MockRepository_Expecter) GetHotEchos(limit any, showPrivate any) *MockRepository_GetHotEchos_Call {
-	return &MockRepository_GetHotEchos_Call{Call: _e.mock.On("GetHotEchos", limit, showPrivate)}
+// GetTrashedEchoById is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) GetTrashedEchoById(ctx any, id any) *MockRepository_GetTrashedEchoById_Call {
+	return &MockRepository_GetTrashedEchoById_Call{Call: _e.mock.On("GetTrashedEchoById", ctx, id)}
 }
 
-func (_c *MockRepository_GetHotEchos_Call) Run(run func(limit int, showPrivate bool)) *MockRepository_GetHotEchos_Call {
+func (_c *MockRepository_GetTrashedEchoById_Call) Run(run func(ctx context.Context, id string)) *MockRepository_GetTrashedEchoById_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 int
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(int)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 bool
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(bool)
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
@@ -1596,52 +2787,159 @@ func (_c *MockRepository_GetHotEchos_Call) Run(run func(limit int, showPrivate b
 	return _c
 }
 
-func (_c *MockRepository_GetHotEchos_Call) Return(echos []model.Echo, err error) *MockRepository_GetHotEchos_Call {
-	_c.Call.Return(echos, err)
+func (_c *MockRepository_GetTrashedEchoById_Call) Return(echo *model.Echo, err error) *MockRepository_GetTrashedEchoById_Call {
+	_c.Call.Return(echo, err)
 	return _c
 }
 
-func (_c *MockRepository_GetHotEchos_Call) RunAndReturn(run func(limit int, showPrivate bool) ([]model.Echo, error)) *MockRepository_GetHotEchos_Call {
+func (_c *MockRepository_GetTrashedEchoById_Call) RunAndReturn(run func(ctx context.Context, id string) (*model.Echo, error)) *MockRepository_GetTrashedEchoById_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetOnThisDayEchos provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetOnThisDayEchos(showPrivate bool, timezone string) []model.Echo {
-	ret := _mock.Called(showPrivate, timezone)
+// IncrementTagUsageCount provides a mock function for the type MockRepository
+func (_mock *MockRepository) IncrementTagUsageCount(ctx context.Context, tagID string) error {
+	ret := _mock.Called(ctx, tagID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetOnThisDayEchos")
+		panic("no return value specified for IncrementTagUsageCount")
 	}
 
-	var r0 []model.Echo
-	if returnFunc, ok := ret.Get(0).(func(bool, string) []model.Echo); ok {
-		r0 = returnFunc(showPrivate, timezone)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, tagID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Echo)
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_IncrementTagUsageCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementTagUsageCount'
+type MockRepository_IncrementTagUsageCount_Call struct {
+	*mock.Call
+}
+
+// IncrementTagUsageCount is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tagID string
+func (_e *MockRepository_Expecter) IncrementTagUsageCount(ctx any, tagID any) *MockRepository_IncrementTagUsageCount_Call {
+	return &MockRepository_IncrementTagUsageCount_Call{Call: _e.mock.On("IncrementTagUsageCount", ctx, tagID)}
+}
+
+func (_c *MockRepository_IncrementTagUsageCount_Call) Run(run func(ctx context.Context, tagID string)) *MockRepository_IncrementTagUsageCount_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_IncrementTagUsageCount_Call) Return(err error) *MockRepository_IncrementTagUsageCount_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_IncrementTagUsageCount_Call) RunAndReturn(run func(ctx context.Context, tagID string) error) *MockRepository_IncrementTagUsageCount_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// InvalidateEchoCaches provides a mock function for the type MockRepository
+func (_mock *MockRepository) InvalidateEchoCaches(echoIDs ...string) {
+	// string
+	_va := make([]any, len(echoIDs))
+	for _i := range echoIDs {
+		_va[_i] = echoIDs[_i]
+	}
+	var _ca []any
+	_ca = append(_ca, _va...)
+	_mock.Called(_ca...)
+	return
+}
+
+// MockRepository_InvalidateEchoCaches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateEchoCaches'
+type MockRepository_InvalidateEchoCaches_Call struct {
+	*mock.Call
+}
+
+// InvalidateEchoCaches is a helper method to define mock.On call
+//   - echoIDs ...string
+func (_e *MockRepository_Expecter) InvalidateEchoCaches(echoIDs ...any) *MockRepository_InvalidateEchoCaches_Call {
+	return &MockRepository_InvalidateEchoCaches_Call{Call: _e.mock.On("InvalidateEchoCaches",
+		append([]any{}, echoIDs...)...)}
+}
+
+func (_c *MockRepository_InvalidateEchoCaches_Call) Run(run func(echoIDs ...string)) *MockRepository_InvalidateEchoCaches_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 []string
+		variadicArgs := make([]string, len(args)-0)
+		for i, a := range args[0:] {
+			if a != nil {
+				variadicArgs[i] = a.(string)
+			}
+		}
+		arg0 = variadicArgs
+		run(
+			arg0...,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_InvalidateEchoCaches_Call) Return() *MockRepository_InvalidateEchoCaches_Call {
+	_c.Call.Return()
+	return _c
+}
+
+func (_c *MockRepository_InvalidateEchoCaches_Call) RunAndReturn(run func(echoIDs ...string)) *MockRepository_InvalidateEchoCaches_Call {
+	_c.Run(run)
+	return _c
+}
+
+// LikeEcho provides a mock function for the type MockRepository
+func (_mock *MockRepository) LikeEcho(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LikeEcho")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_GetOnThisDayEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetOnThisDayEchos'
-type MockRepository_GetOnThisDayEchos_Call struct {
+// MockRepository_LikeEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LikeEcho'
+type MockRepository_LikeEcho_Call struct {
 	*mock.Call
 }
 
-// GetOnThisDayEchos is a helper method to define mock.On call
-//   - showPrivate bool
-//   - timezone string
-func (_e *MockRepository_Expecter) GetOnThisDayEchos(showPrivate any, timezone any) *MockRepository_GetOnThisDayEchos_Call {
-	return &MockRepository_GetOnThisDayEchos_Call{Call: _e.mock.On("GetOnThisDayEchos", showPrivate, timezone)}
+// LikeEcho is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockRepository_Expecter) LikeEcho(ctx any, id any) *MockRepository_LikeEcho_Call {
+	return &MockRepository_LikeEcho_Call{Call: _e.mock.On("LikeEcho", ctx, id)}
 }
 
-func (_c *MockRepository_GetOnThisDayEchos_Call) Run(run func(showPrivate bool, timezone string)) *MockRepository_GetOnThisDayEchos_Call {
+func (_c *MockRepository_LikeEcho_Call) Run(run func(ctx context.Context, id string)) *MockRepository_LikeEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 bool
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(bool)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
@@ -1655,182 +2953,204 @@ func (_c *MockRepository_GetOnThisDayEchos_Call) Run(run func(showPrivate bool,
 	return _c
 }
 
-func (_c *MockRepository_GetOnThisDayEchos_Call) Return(echos []model.Echo) *MockRepository_GetOnThisDayEchos_Call {
-	_c.Call.Return(echos)
+func (_c *MockRepository_LikeEcho_Call) Return(err error) *MockRepository_LikeEcho_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_GetOnThisDayEchos_Call) RunAndReturn(run func(showPrivate bool, timezone string) []model.Echo) *MockRepository_GetOnThisDayEchos_Call {
+func (_c *MockRepository_LikeEcho_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_LikeEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetRandomEcho provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetRandomEcho(showPrivate bool) (*model.Echo, error) {
-	ret := _mock.Called(showPrivate)
+// ListEchoRevisions provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListEchoRevisions(ctx context.Context, echoID string) ([]model.EchoRevision, error) {
+	ret := _mock.Called(ctx, echoID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetRandomEcho")
+		panic("no return value specified for ListEchoRevisions")
 	}
 
-	var r0 *model.Echo
+	var r0 []model.EchoRevision
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(bool) (*model.Echo, error)); ok {
-		return returnFunc(showPrivate)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]model.EchoRevision, error)); ok {
+		return returnFunc(ctx, echoID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(bool) *model.Echo); ok {
-		r0 = returnFunc(showPrivate)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []model.EchoRevision); ok {
+		r0 = returnFunc(ctx, echoID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Echo)
+			r0 = ret.Get(0).([]model.EchoRevision)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(bool) error); ok {
-		r1 = returnFunc(showPrivate)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, echoID)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// MockRepository_GetRandomEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRandomEcho'
-type MockRepository_GetRandomEcho_Call struct {
+// MockRepository_ListEchoRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListEchoRevisions'
+type MockRepository_ListEchoRevisions_Call struct {
 	*mock.Call
 }
 
-// GetRandomEcho is a helper method to define mock.On call
-//   - showPrivate bool
-func (_e *MockRepository_Expecter) GetRandomEcho(showPrivate any) *MockRepository_GetRandomEcho_Call {
-	return &MockRepository_GetRandomEcho_Call{Call: _e.mock.On("GetRandomEcho", showPrivate)}
+// ListEchoRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockRepository_Expecter) ListEchoRevisions(ctx any, echoID any) *MockRepository_ListEchoRevisions_Call {
+	return &MockRepository_ListEchoRevisions_Call{Call: _e.mock.On("ListEchoRevisions", ctx, echoID)}
 }
 
-func (_c *MockRepository_GetRandomEcho_Call) Run(run func(showPrivate bool)) *MockRepository_GetRandomEcho_Call {
+func (_c *MockRepository_ListEchoRevisions_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_ListEchoRevisions_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 bool
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(bool)
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
 		}
 		run(
 			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetRandomEcho_Call) Return(echo *model.Echo, err error) *MockRepository_GetRandomEcho_Call {
-	_c.Call.Return(echo, err)
+func (_c *MockRepository_ListEchoRevisions_Call) Return(echoRevisions []model.EchoRevision, err error) *MockRepository_ListEchoRevisions_Call {
+	_c.Call.Return(echoRevisions, err)
 	return _c
 }
 
-func (_c *MockRepository_GetRandomEcho_Call) RunAndReturn(run func(showPrivate bool) (*model.Echo, error)) *MockRepository_GetRandomEcho_Call {
+func (_c *MockRepository_ListEchoRevisions_Call) RunAndReturn(run func(ctx context.Context, echoID string) ([]model.EchoRevision, error)) *MockRepository_ListEchoRevisions_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTagsByNames provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetTagsByNames(ctx context.Context, names []string) ([]*model.Tag, error) {
-	ret := _mock.Called(ctx, names)
+// ListTrashedEchos provides a mock function for the type MockRepository
+func (_mock *MockRepository) ListTrashedEchos(ctx context.Context, page int, pageSize int, userID string) ([]model.Echo, int64, error) {
+	ret := _mock.Called(ctx, page, pageSize, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTagsByNames")
+		panic("no return value specified for ListTrashedEchos")
 	}
 
-	var r0 []*model.Tag
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) ([]*model.Tag, error)); ok {
-		return returnFunc(ctx, names)
+	var r0 []model.Echo
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string) ([]model.Echo, int64, error)); ok {
+		return returnFunc(ctx, page, pageSize, userID)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, []string) []*model.Tag); ok {
-		r0 = returnFunc(ctx, names)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, int, int, string) []model.Echo); ok {
+		r0 = returnFunc(ctx, page, pageSize, userID)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Tag)
+			r0 = ret.Get(0).([]model.Echo)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, []string) error); ok {
-		r1 = returnFunc(ctx, names)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, int, int, string) int64); ok {
+		r1 = returnFunc(ctx, page, pageSize, userID)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(int64)
 	}
-	return r0, r1
+	if returnFunc, ok := ret.Get(2).(func(context.Context, int, int, string) error); ok {
+		r2 = returnFunc(ctx, page, pageSize, userID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockRepository_GetTagsByNames_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTagsByNames'
-type MockRepository_GetTagsByNames_Call struct {
+// MockRepository_ListTrashedEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListTrashedEchos'
+type MockRepository_ListTrashedEchos_Call struct {
 	*mock.Call
 }
 
-// GetTagsByNames is a helper method to define mock.On call
+// ListTrashedEchos is a helper method to define mock.On call
 //   - ctx context.Context
-//   - names []string
-func (_e *MockRepository_Expecter) GetTagsByNames(ctx any, names any) *MockRepository_GetTagsByNames_Call {
-	return &MockRepository_GetTagsByNames_Call{Call: _e.mock.On("GetTagsByNames", ctx, names)}
+//   - page int
+//   - pageSize int
+//   - userID string
+func (_e *MockRepository_Expecter) ListTrashedEchos(ctx any, page any, pageSize any, userID any) *MockRepository_ListTrashedEchos_Call {
+	return &MockRepository_ListTrashedEchos_Call{Call: _e.mock.On("ListTrashedEchos", ctx, page, pageSize, userID)}
 }
 
-func (_c *MockRepository_GetTagsByNames_Call) Run(run func(ctx context.Context, names []string)) *MockRepository_GetTagsByNames_Call {
+func (_c *MockRepository_ListTrashedEchos_Call) Run(run func(ctx context.Context, page int, pageSize int, userID string)) *MockRepository_ListTrashedEchos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 []string
+		var arg1 int
 		if args[1] != nil {
-			arg1 = args[1].([]string)
+			arg1 = args[1].(int)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
+			arg3,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_GetTagsByNames_Call) Return(tags []*model.Tag, err error) *MockRepository_GetTagsByNames_Call {
-	_c.Call.Return(tags, err)
+func (_c *MockRepository_ListTrashedEchos_Call) Return(echos []model.Echo, n int64, err error) *MockRepository_ListTrashedEchos_Call {
+	_c.Call.Return(echos, n, err)
 	return _c
 }
 
-func (_c *MockRepository_GetTagsByNames_Call) RunAndReturn(run func(ctx context.Context, names []string) ([]*model.Tag, error)) *MockRepository_GetTagsByNames_Call {
+func (_c *MockRepository_ListTrashedEchos_Call) RunAndReturn(run func(ctx context.Context, page int, pageSize int, userID string) ([]model.Echo, int64, error)) *MockRepository_ListTrashedEchos_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTodayEchos provides a mock function for the type MockRepository
-func (_mock *MockRepository) GetTodayEchos(showPrivate bool, timezone string) []model.Echo {
-	ret := _mock.Called(showPrivate, timezone)
+// PruneDanglingEchoFiles provides a mock function for the type MockRepository
+func (_mock *MockRepository) PruneDanglingEchoFiles(ctx context.Context, echoID string) error {
+	ret := _mock.Called(ctx, echoID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTodayEchos")
+		panic("no return value specified for PruneDanglingEchoFiles")
 	}
 
-	var r0 []model.Echo
-	if returnFunc, ok := ret.Get(0).(func(bool, string) []model.Echo); ok {
-		r0 = returnFunc(showPrivate, timezone)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, echoID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Echo)
-		}
+		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockRepository_GetTodayEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTodayEchos'
-type MockRepository_GetTodayEchos_Call struct {
+// MockRepository_PruneDanglingEchoFiles_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneDanglingEchoFiles'
+type MockRepository_PruneDanglingEchoFiles_Call struct {
 	*mock.Call
 }
 
-// GetTodayEchos is a helper method to define mock.On call
-//   - showPrivate bool
-//   - timezone string
-func (_e *MockRepository_Expecter) GetTodayEchos(showPrivate any, timezone any) *MockRepository_GetTodayEchos_Call {
-	return &MockRepository_GetTodayEchos_Call{Call: _e.mock.On("GetTodayEchos", showPrivate, timezone)}
+// PruneDanglingEchoFiles is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockRepository_Expecter) PruneDanglingEchoFiles(ctx any, echoID any) *MockRepository_PruneDanglingEchoFiles_Call {
+	return &MockRepository_PruneDanglingEchoFiles_Call{Call: _e.mock.On("PruneDanglingEchoFiles", ctx, echoID)}
 }
 
-func (_c *MockRepository_GetTodayEchos_Call) Run(run func(showPrivate bool, timezone string)) *MockRepository_GetTodayEchos_Call {
+func (_c *MockRepository_PruneDanglingEchoFiles_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_PruneDanglingEchoFiles_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 bool
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(bool)
+			arg0 = args[0].(context.Context)
 		}
 		var arg1 string
 		if args[1] != nil {
@@ -1844,46 +3164,55 @@ func (_c *MockRepository_GetTodayEchos_Call) Run(run func(showPrivate bool, time
 	return _c
 }
 
-func (_c *MockRepository_GetTodayEchos_Call) Return(echos []model.Echo) *MockRepository_GetTodayEchos_Call {
-	_c.Call.Return(echos)
+func (_c *MockRepository_PruneDanglingEchoFiles_Call) Return(err error) *MockRepository_PruneDanglingEchoFiles_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_GetTodayEchos_Call) RunAndReturn(run func(showPrivate bool, timezone string) []model.Echo) *MockRepository_GetTodayEchos_Call {
+func (_c *MockRepository_PruneDanglingEchoFiles_Call) RunAndReturn(run func(ctx context.Context, echoID string) error) *MockRepository_PruneDanglingEchoFiles_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// IncrementTagUsageCount provides a mock function for the type MockRepository
-func (_mock *MockRepository) IncrementTagUsageCount(ctx context.Context, tagID string) error {
-	ret := _mock.Called(ctx, tagID)
+// PublishScheduledEcho provides a mock function for the type MockRepository
+func (_mock *MockRepository) PublishScheduledEcho(ctx context.Context, id string) (bool, error) {
+	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for IncrementTagUsageCount")
+		panic("no return value specified for PublishScheduledEcho")
 	}
 
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, tagID)
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = returnFunc(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(bool)
 	}
-	return r0
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
 }
 
-// MockRepository_IncrementTagUsageCount_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IncrementTagUsageCount'
-type MockRepository_IncrementTagUsageCount_Call struct {
+// MockRepository_PublishScheduledEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishScheduledEcho'
+type MockRepository_PublishScheduledEcho_Call struct {
 	*mock.Call
 }
 
-// IncrementTagUsageCount is a helper method to define mock.On call
+// PublishScheduledEcho is a helper method to define mock.On call
 //   - ctx context.Context
-//   - tagID string
-func (_e *MockRepository_Expecter) IncrementTagUsageCount(ctx any, tagID any) *MockRepository_IncrementTagUsageCount_Call {
-	return &MockRepository_IncrementTagUsageCount_Call{Call: _e.mock.On("IncrementTagUsageCount", ctx, tagID)}
+//   - id string
+func (_e *MockRepository_Expecter) PublishScheduledEcho(ctx any, id any) *MockRepository_PublishScheduledEcho_Call {
+	return &MockRepository_PublishScheduledEcho_Call{Call: _e.mock.On("PublishScheduledEcho", ctx, id)}
 }
 
-func (_c *MockRepository_IncrementTagUsageCount_Call) Run(run func(ctx context.Context, tagID string)) *MockRepository_IncrementTagUsageCount_Call {
+func (_c *MockRepository_PublishScheduledEcho_Call) Run(run func(ctx context.Context, id string)) *MockRepository_PublishScheduledEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -1901,74 +3230,96 @@ func (_c *MockRepository_IncrementTagUsageCount_Call) Run(run func(ctx context.C
 	return _c
 }
 
-func (_c *MockRepository_IncrementTagUsageCount_Call) Return(err error) *MockRepository_IncrementTagUsageCount_Call {
-	_c.Call.Return(err)
+func (_c *MockRepository_PublishScheduledEcho_Call) Return(b bool, err error) *MockRepository_PublishScheduledEcho_Call {
+	_c.Call.Return(b, err)
 	return _c
 }
 
-func (_c *MockRepository_IncrementTagUsageCount_Call) RunAndReturn(run func(ctx context.Context, tagID string) error) *MockRepository_IncrementTagUsageCount_Call {
+func (_c *MockRepository_PublishScheduledEcho_Call) RunAndReturn(run func(ctx context.Context, id string) (bool, error)) *MockRepository_PublishScheduledEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// InvalidateEchoCaches provides a mock function for the type MockRepository
-func (_mock *MockRepository) InvalidateEchoCaches(echoIDs ...string) {
-	// string
-	_va := make([]any, len(echoIDs))
-	for _i := range echoIDs {
-		_va[_i] = echoIDs[_i]
+// QueryEchos provides a mock function for the type MockRepository
+func (_mock *MockRepository) QueryEchos(queryDto model0.EchoQueryDto, showPrivate bool) ([]model.Echo, int64, error) {
+	ret := _mock.Called(queryDto, showPrivate)
+
+	if len(ret) == 0 {
+		panic("no return value specified for QueryEchos")
 	}
-	var _ca []any
-	_ca = append(_ca, _va...)
-	_mock.Called(_ca...)
-	return
+
+	var r0 []model.Echo
+	var r1 int64
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(model0.EchoQueryDto, bool) ([]model.Echo, int64, error)); ok {
+		return returnFunc(queryDto, showPrivate)
+	}
+	if returnFunc, ok := ret.Get(0).(func(model0.EchoQueryDto, bool) []model.Echo); ok {
+		r0 = returnFunc(queryDto, showPrivate)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Echo)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(model0.EchoQueryDto, bool) int64); ok {
+		r1 = returnFunc(queryDto, showPrivate)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+	if returnFunc, ok := ret.Get(2).(func(model0.EchoQueryDto, bool) error); ok {
+		r2 = returnFunc(queryDto, showPrivate)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
 }
 
-// MockRepository_InvalidateEchoCaches_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'InvalidateEchoCaches'
-type MockRepository_InvalidateEchoCaches_Call struct {
+// MockRepository_QueryEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryEchos'
+type MockRepository_QueryEchos_Call struct {
 	*mock.Call
 }
 
-// InvalidateEchoCaches is a helper method to define mock.On call
-//   - echoIDs ...string
-func (_e *MockRepository_Expecter) InvalidateEchoCaches(echoIDs ...any) *MockRepository_InvalidateEchoCaches_Call {
-	return &MockRepository_InvalidateEchoCaches_Call{Call: _e.mock.On("InvalidateEchoCaches",
-		append([]any{}, echoIDs...)...)}
+// QueryEchos is a helper method to define mock.On call
+//   - queryDto model0.EchoQueryDto
+//   - showPrivate bool
+func (_e *MockRepository_Expecter) QueryEchos(queryDto any, showPrivate any) *MockRepository_QueryEchos_Call {
+	return &MockRepository_QueryEchos_Call{Call: _e.mock.On("QueryEchos", queryDto, showPrivate)}
 }
 
-func (_c *MockRepository_InvalidateEchoCaches_Call) Run(run func(echoIDs ...string)) *MockRepository_InvalidateEchoCaches_Call {
+func (_c *MockRepository_QueryEchos_Call) Run(run func(queryDto model0.EchoQueryDto, showPrivate bool)) *MockRepository_QueryEchos_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 []string
-		variadicArgs := make([]string, len(args)-0)
-		for i, a := range args[0:] {
-			if a != nil {
-				variadicArgs[i] = a.(string)
-			}
+		var arg0 model0.EchoQueryDto
+		if args[0] != nil {
+			arg0 = args[0].(model0.EchoQueryDto)
+		}
+		var arg1 bool
+		if args[1] != nil {
+			arg1 = args[1].(bool)
 		}
-		arg0 = variadicArgs
 		run(
-			arg0...,
+			arg0,
+			arg1,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_InvalidateEchoCaches_Call) Return() *MockRepository_InvalidateEchoCaches_Call {
-	_c.Call.Return()
+func (_c *MockRepository_QueryEchos_Call) Return(echos []model.Echo, n int64, err error) *MockRepository_QueryEchos_Call {
+	_c.Call.Return(echos, n, err)
 	return _c
 }
 
-func (_c *MockRepository_InvalidateEchoCaches_Call) RunAndReturn(run func(echoIDs ...string)) *MockRepository_InvalidateEchoCaches_Call {
-	_c.Run(run)
+func (_c *MockRepository_QueryEchos_Call) RunAndReturn(run func(queryDto model0.EchoQueryDto, showPrivate bool) ([]model.Echo, int64, error)) *MockRepository_QueryEchos_Call {
+	_c.Call.Return(run)
 	return _c
 }
 
-// LikeEcho provides a mock function for the type MockRepository
-func (_mock *MockRepository) LikeEcho(ctx context.Context, id string) error {
+// RestoreEcho provides a mock function for the type MockRepository
+func (_mock *MockRepository) RestoreEcho(ctx context.Context, id string) error {
 	ret := _mock.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for LikeEcho")
+		panic("no return value specified for RestoreEcho")
 	}
 
 	var r0 error
@@ -1980,19 +3331,19 @@ func (_mock *MockRepository) LikeEcho(ctx context.Context, id string) error {
 	return r0
 }
 
-// MockRepository_LikeEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LikeEcho'
-type MockRepository_LikeEcho_Call struct {
+// MockRepository_RestoreEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreEcho'
+type MockRepository_RestoreEcho_Call struct {
 	*mock.Call
 }
 
-// LikeEcho is a helper method to define mock.On call
+// RestoreEcho is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockRepository_Expecter) LikeEcho(ctx any, id any) *MockRepository_LikeEcho_Call {
-	return &MockRepository_LikeEcho_Call{Call: _e.mock.On("LikeEcho", ctx, id)}
+func (_e *MockRepository_Expecter) RestoreEcho(ctx any, id any) *MockRepository_RestoreEcho_Call {
+	return &MockRepository_RestoreEcho_Call{Call: _e.mock.On("RestoreEcho", ctx, id)}
 }
 
-func (_c *MockRepository_LikeEcho_Call) Run(run func(ctx context.Context, id string)) *MockRepository_LikeEcho_Call {
+func (_c *MockRepository_RestoreEcho_Call) Run(run func(ctx context.Context, id string)) *MockRepository_RestoreEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -2010,86 +3361,75 @@ func (_c *MockRepository_LikeEcho_Call) Run(run func(ctx context.Context, id str
 	return _c
 }
 
-func (_c *MockRepository_LikeEcho_Call) Return(err error) *MockRepository_LikeEcho_Call {
+func (_c *MockRepository_RestoreEcho_Call) Return(err error) *MockRepository_RestoreEcho_Call {
 	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_LikeEcho_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_LikeEcho_Call {
+func (_c *MockRepository_RestoreEcho_Call) RunAndReturn(run func(ctx context.Context, id string) error) *MockRepository_RestoreEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// QueryEchos provides a mock function for the type MockRepository
-func (_mock *MockRepository) QueryEchos(queryDto model0.EchoQueryDto, showPrivate bool) ([]model.Echo, int64, error) {
-	ret := _mock.Called(queryDto, showPrivate)
+// TrashEcho provides a mock function for the type MockRepository
+func (_mock *MockRepository) TrashEcho(ctx context.Context, id string, deletedAt int64) error {
+	ret := _mock.Called(ctx, id, deletedAt)
 
 	if len(ret) == 0 {
-		panic("no return value specified for QueryEchos")
+		panic("no return value specified for TrashEcho")
 	}
 
-	var r0 []model.Echo
-	var r1 int64
-	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(model0.EchoQueryDto, bool) ([]model.Echo, int64, error)); ok {
-		return returnFunc(queryDto, showPrivate)
-	}
-	if returnFunc, ok := ret.Get(0).(func(model0.EchoQueryDto, bool) []model.Echo); ok {
-		r0 = returnFunc(queryDto, showPrivate)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]model.Echo)
-		}
-	}
-	if returnFunc, ok := ret.Get(1).(func(model0.EchoQueryDto, bool) int64); ok {
-		r1 = returnFunc(queryDto, showPrivate)
-	} else {
-		r1 = ret.Get(1).(int64)
-	}
-	if returnFunc, ok := ret.Get(2).(func(model0.EchoQueryDto, bool) error); ok {
-		r2 = returnFunc(queryDto, showPrivate)
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = returnFunc(ctx, id, deletedAt)
 	} else {
-		r2 = ret.Error(2)
+		r0 = ret.Error(0)
 	}
-	return r0, r1, r2
+	return r0
 }
 
-// MockRepository_QueryEchos_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QueryEchos'
-type MockRepository_QueryEchos_Call struct {
+// MockRepository_TrashEcho_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TrashEcho'
+type MockRepository_TrashEcho_Call struct {
 	*mock.Call
 }
 
-// QueryEchos is a helper method to define mock.On call
-//   - queryDto model0.EchoQueryDto
-//   - showPrivate bool
-func (_e *MockRepository_Expecter) QueryEchos(queryDto any, showPrivate any) *MockRepository_QueryEchos_Call {
-	return &MockRepository_QueryEchos_Call{Call: _e.mock.On("QueryEchos", queryDto, showPrivate)}
+// TrashEcho is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - deletedAt int64
+func (_e *MockRepository_Expecter) TrashEcho(ctx any, id any, deletedAt any) *MockRepository_TrashEcho_Call {
+	return &MockRepository_TrashEcho_Call{Call: _e.mock.On("TrashEcho", ctx, id, deletedAt)}
 }
 
-func (_c *MockRepository_QueryEchos_Call) Run(run func(queryDto model0.EchoQueryDto, showPrivate bool)) *MockRepository_QueryEchos_Call {
+func (_c *MockRepository_TrashEcho_Call) Run(run func(ctx context.Context, id string, deletedAt int64)) *MockRepository_TrashEcho_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		var arg0 model0.EchoQueryDto
+		var arg0 context.Context
 		if args[0] != nil {
-			arg0 = args[0].(model0.EchoQueryDto)
+			arg0 = args[0].(context.Context)
 		}
-		var arg1 bool
+		var arg1 string
 		if args[1] != nil {
-			arg1 = args[1].(bool)
+			arg1 = args[1].(string)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
 		}
 		run(
 			arg0,
 			arg1,
+			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *MockRepository_QueryEchos_Call) Return(echos []model.Echo, n int64, err error) *MockRepository_QueryEchos_Call {
-	_c.Call.Return(echos, n, err)
+func (_c *MockRepository_TrashEcho_Call) Return(err error) *MockRepository_TrashEcho_Call {
+	_c.Call.Return(err)
 	return _c
 }
 
-func (_c *MockRepository_QueryEchos_Call) RunAndReturn(run func(queryDto model0.EchoQueryDto, showPrivate bool) ([]model.Echo, int64, error)) *MockRepository_QueryEchos_Call {
+func (_c *MockRepository_TrashEcho_Call) RunAndReturn(run func(ctx context.Context, id string, deletedAt int64) error) *MockRepository_TrashEcho_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -2150,3 +3490,66 @@ func (_c *MockRepository_UpdateEcho_Call) RunAndReturn(run func(ctx context.Cont
 	_c.Call.Return(run)
 	return _c
 }
+
+// UpsertSearchIndex provides a mock function for the type MockRepository
+func (_mock *MockRepository) UpsertSearchIndex(ctx context.Context, echoID string, content string) error {
+	ret := _mock.Called(ctx, echoID, content)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertSearchIndex")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, echoID, content)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockRepository_UpsertSearchIndex_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertSearchIndex'
+type MockRepository_UpsertSearchIndex_Call struct {
+	*mock.Call
+}
+
+// UpsertSearchIndex is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+//   - content string
+func (_e *MockRepository_Expecter) UpsertSearchIndex(ctx any, echoID any, content any) *MockRepository_UpsertSearchIndex_Call {
+	return &MockRepository_UpsertSearchIndex_Call{Call: _e.mock.On("UpsertSearchIndex", ctx, echoID, content)}
+}
+
+func (_c *MockRepository_UpsertSearchIndex_Call) Run(run func(ctx context.Context, echoID string, content string)) *MockRepository_UpsertSearchIndex_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_UpsertSearchIndex_Call) Return(err error) *MockRepository_UpsertSearchIndex_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockRepository_UpsertSearchIndex_Call) RunAndReturn(run func(ctx context.Context, echoID string, content string) error) *MockRepository_UpsertSearchIndex_Call {
+	_c.Call.Return(run)
+	return _c
+}
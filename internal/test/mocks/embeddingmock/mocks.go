@@ -709,6 +709,80 @@ func (_c *MockRepository_GetMeta_Call) RunAndReturn(run func(ctx context.Context
 	return _c
 }
 
+// GetVector provides a mock function for the type MockRepository
+func (_mock *MockRepository) GetVector(ctx context.Context, echoID string) ([]float32, bool, error) {
+	ret := _mock.Called(ctx, echoID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVector")
+	}
+
+	var r0 []float32
+	var r1 bool
+	var r2 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]float32, bool, error)); ok {
+		return returnFunc(ctx, echoID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []float32); ok {
+		r0 = returnFunc(ctx, echoID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]float32)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = returnFunc(ctx, echoID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string) error); ok {
+		r2 = returnFunc(ctx, echoID)
+	} else {
+		r2 = ret.Error(2)
+	}
+	return r0, r1, r2
+}
+
+// MockRepository_GetVector_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetVector'
+type MockRepository_GetVector_Call struct {
+	*mock.Call
+}
+
+// GetVector is a helper method to define mock.On call
+//   - ctx context.Context
+//   - echoID string
+func (_e *MockRepository_Expecter) GetVector(ctx any, echoID any) *MockRepository_GetVector_Call {
+	return &MockRepository_GetVector_Call{Call: _e.mock.On("GetVector", ctx, echoID)}
+}
+
+func (_c *MockRepository_GetVector_Call) Run(run func(ctx context.Context, echoID string)) *MockRepository_GetVector_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockRepository_GetVector_Call) Return(float32s []float32, b bool, err error) *MockRepository_GetVector_Call {
+	_c.Call.Return(float32s, b, err)
+	return _c
+}
+
+func (_c *MockRepository_GetVector_Call) RunAndReturn(run func(ctx context.Context, echoID string) ([]float32, bool, error)) *MockRepository_GetVector_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Search provides a mock function for the type MockRepository
 func (_mock *MockRepository) Search(ctx context.Context, vector []float32, k int, authorUsername string) ([]model1.SearchResult, error) {
 	ret := _mock.Called(ctx, vector, k, authorUsername)
@@ -450,6 +450,72 @@ func (_c *MockService_GetFileByID_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
+// GetFileByURL provides a mock function for the type MockService
+func (_mock *MockService) GetFileByURL(ctx context.Context, fileURL string) (model.FileDto, error) {
+	ret := _mock.Called(ctx, fileURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFileByURL")
+	}
+
+	var r0 model.FileDto
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (model.FileDto, error)); ok {
+		return returnFunc(ctx, fileURL)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) model.FileDto); ok {
+		r0 = returnFunc(ctx, fileURL)
+	} else {
+		r0 = ret.Get(0).(model.FileDto)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, fileURL)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_GetFileByURL_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetFileByURL'
+type MockService_GetFileByURL_Call struct {
+	*mock.Call
+}
+
+// GetFileByURL is a helper method to define mock.On call
+//   - ctx context.Context
+//   - fileURL string
+func (_e *MockService_Expecter) GetFileByURL(ctx any, fileURL any) *MockService_GetFileByURL_Call {
+	return &MockService_GetFileByURL_Call{Call: _e.mock.On("GetFileByURL", ctx, fileURL)}
+}
+
+func (_c *MockService_GetFileByURL_Call) Run(run func(ctx context.Context, fileURL string)) *MockService_GetFileByURL_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_GetFileByURL_Call) Return(fileDto model.FileDto, err error) *MockService_GetFileByURL_Call {
+	_c.Call.Return(fileDto, err)
+	return _c
+}
+
+func (_c *MockService_GetFileByURL_Call) RunAndReturn(run func(ctx context.Context, fileURL string) (model.FileDto, error)) *MockService_GetFileByURL_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetFilePresignURL provides a mock function for the type MockService
 func (_mock *MockService) GetFilePresignURL(ctx context.Context, dto *model.GetPresignURLDto) (model.PresignDto, error) {
 	ret := _mock.Called(ctx, dto)
@@ -1004,6 +1004,357 @@ func (_c *MockService_ListAccessTokens_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListWebhookDeadLetters provides a mock function for the type MockService
+func (_mock *MockService) ListWebhookDeadLetters(ctx context.Context, webhookID string, limit int) ([]model0.WebhookOutbox, error) {
+	ret := _mock.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeadLetters")
+	}
+
+	var r0 []model0.WebhookOutbox
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model0.WebhookOutbox, error)); ok {
+		return returnFunc(ctx, webhookID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model0.WebhookOutbox); ok {
+		r0 = returnFunc(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model0.WebhookOutbox)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListWebhookDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeadLetters'
+type MockService_ListWebhookDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - limit int
+func (_e *MockService_Expecter) ListWebhookDeadLetters(ctx any, webhookID any, limit any) *MockService_ListWebhookDeadLetters_Call {
+	return &MockService_ListWebhookDeadLetters_Call{Call: _e.mock.On("ListWebhookDeadLetters", ctx, webhookID, limit)}
+}
+
+func (_c *MockService_ListWebhookDeadLetters_Call) Run(run func(ctx context.Context, webhookID string, limit int)) *MockService_ListWebhookDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListWebhookDeadLetters_Call) Return(webhookOutboxs []model0.WebhookOutbox, err error) *MockService_ListWebhookDeadLetters_Call {
+	_c.Call.Return(webhookOutboxs, err)
+	return _c
+}
+
+func (_c *MockService_ListWebhookDeadLetters_Call) RunAndReturn(run func(ctx context.Context, webhookID string, limit int) ([]model0.WebhookOutbox, error)) *MockService_ListWebhookDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function for the type MockService
+func (_mock *MockService) ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]model0.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []model0.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model0.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, webhookID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model0.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model0.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockService_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - limit int
+func (_e *MockService_Expecter) ListWebhookDeliveries(ctx any, webhookID any, limit any) *MockService_ListWebhookDeliveries_Call {
+	return &MockService_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, webhookID, limit)}
+}
+
+func (_c *MockService_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, webhookID string, limit int)) *MockService_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_ListWebhookDeliveries_Call) Return(webhookDeliverys []model0.WebhookDelivery, err error) *MockService_ListWebhookDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, err)
+	return _c
+}
+
+func (_c *MockService_ListWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, webhookID string, limit int) ([]model0.WebhookDelivery, error)) *MockService_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeWebhookDeadLetters provides a mock function for the type MockService
+func (_mock *MockService) PurgeWebhookDeadLetters(ctx context.Context, webhookID string) (int64, error) {
+	ret := _mock.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeWebhookDeadLetters")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, webhookID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, webhookID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_PurgeWebhookDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeWebhookDeadLetters'
+type MockService_PurgeWebhookDeadLetters_Call struct {
+	*mock.Call
+}
+
+// PurgeWebhookDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *MockService_Expecter) PurgeWebhookDeadLetters(ctx any, webhookID any) *MockService_PurgeWebhookDeadLetters_Call {
+	return &MockService_PurgeWebhookDeadLetters_Call{Call: _e.mock.On("PurgeWebhookDeadLetters", ctx, webhookID)}
+}
+
+func (_c *MockService_PurgeWebhookDeadLetters_Call) Run(run func(ctx context.Context, webhookID string)) *MockService_PurgeWebhookDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_PurgeWebhookDeadLetters_Call) Return(n int64, err error) *MockService_PurgeWebhookDeadLetters_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockService_PurgeWebhookDeadLetters_Call) RunAndReturn(run func(ctx context.Context, webhookID string) (int64, error)) *MockService_PurgeWebhookDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RedeliverWebhook provides a mock function for the type MockService
+func (_mock *MockService) RedeliverWebhook(ctx context.Context, webhookID string, deliveryID string) (*model0.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, webhookID, deliveryID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RedeliverWebhook")
+	}
+
+	var r0 *model0.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model0.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, webhookID, deliveryID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model0.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, webhookID, deliveryID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model0.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, webhookID, deliveryID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockService_RedeliverWebhook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RedeliverWebhook'
+type MockService_RedeliverWebhook_Call struct {
+	*mock.Call
+}
+
+// RedeliverWebhook is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - deliveryID string
+func (_e *MockService_Expecter) RedeliverWebhook(ctx any, webhookID any, deliveryID any) *MockService_RedeliverWebhook_Call {
+	return &MockService_RedeliverWebhook_Call{Call: _e.mock.On("RedeliverWebhook", ctx, webhookID, deliveryID)}
+}
+
+func (_c *MockService_RedeliverWebhook_Call) Run(run func(ctx context.Context, webhookID string, deliveryID string)) *MockService_RedeliverWebhook_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RedeliverWebhook_Call) Return(webhookDelivery *model0.WebhookDelivery, err error) *MockService_RedeliverWebhook_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockService_RedeliverWebhook_Call) RunAndReturn(run func(ctx context.Context, webhookID string, deliveryID string) (*model0.WebhookDelivery, error)) *MockService_RedeliverWebhook_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RetryWebhookDeadLetter provides a mock function for the type MockService
+func (_mock *MockService) RetryWebhookDeadLetter(ctx context.Context, webhookID string, id string) error {
+	ret := _mock.Called(ctx, webhookID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryWebhookDeadLetter")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = returnFunc(ctx, webhookID, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_RetryWebhookDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RetryWebhookDeadLetter'
+type MockService_RetryWebhookDeadLetter_Call struct {
+	*mock.Call
+}
+
+// RetryWebhookDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - id string
+func (_e *MockService_Expecter) RetryWebhookDeadLetter(ctx any, webhookID any, id any) *MockService_RetryWebhookDeadLetter_Call {
+	return &MockService_RetryWebhookDeadLetter_Call{Call: _e.mock.On("RetryWebhookDeadLetter", ctx, webhookID, id)}
+}
+
+func (_c *MockService_RetryWebhookDeadLetter_Call) Run(run func(ctx context.Context, webhookID string, id string)) *MockService_RetryWebhookDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_RetryWebhookDeadLetter_Call) Return(err error) *MockService_RetryWebhookDeadLetter_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_RetryWebhookDeadLetter_Call) RunAndReturn(run func(ctx context.Context, webhookID string, id string) error) *MockService_RetryWebhookDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // TestAgentConnection provides a mock function for the type MockService
 func (_mock *MockService) TestAgentConnection(ctx context.Context, newSetting *model.AgentSettingDto) error {
 	ret := _mock.Called(ctx, newSetting)
@@ -2069,42 +2420,99 @@ func (_c *MockWebhookRepository_CreateWebhook_Call) RunAndReturn(run func(ctx co
 	return _c
 }
 
-// DeleteWebhookByID provides a mock function for the type MockWebhookRepository
-func (_mock *MockWebhookRepository) DeleteWebhookByID(ctx context.Context, id string) error {
-	ret := _mock.Called(ctx, id)
+// CreateWebhookDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) CreateWebhookDeliveries(ctx context.Context, deliveries []model0.WebhookDelivery) error {
+	ret := _mock.Called(ctx, deliveries)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteWebhookByID")
+		panic("no return value specified for CreateWebhookDeliveries")
 	}
 
 	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = returnFunc(ctx, id)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, []model0.WebhookDelivery) error); ok {
+		r0 = returnFunc(ctx, deliveries)
 	} else {
 		r0 = ret.Error(0)
 	}
 	return r0
 }
 
-// MockWebhookRepository_DeleteWebhookByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookByID'
-type MockWebhookRepository_DeleteWebhookByID_Call struct {
+// MockWebhookRepository_CreateWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWebhookDeliveries'
+type MockWebhookRepository_CreateWebhookDeliveries_Call struct {
 	*mock.Call
 }
 
-// DeleteWebhookByID is a helper method to define mock.On call
+// CreateWebhookDeliveries is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id string
-func (_e *MockWebhookRepository_Expecter) DeleteWebhookByID(ctx any, id any) *MockWebhookRepository_DeleteWebhookByID_Call {
-	return &MockWebhookRepository_DeleteWebhookByID_Call{Call: _e.mock.On("DeleteWebhookByID", ctx, id)}
+//   - deliveries []model0.WebhookDelivery
+func (_e *MockWebhookRepository_Expecter) CreateWebhookDeliveries(ctx any, deliveries any) *MockWebhookRepository_CreateWebhookDeliveries_Call {
+	return &MockWebhookRepository_CreateWebhookDeliveries_Call{Call: _e.mock.On("CreateWebhookDeliveries", ctx, deliveries)}
 }
 
-func (_c *MockWebhookRepository_DeleteWebhookByID_Call) Run(run func(ctx context.Context, id string)) *MockWebhookRepository_DeleteWebhookByID_Call {
+func (_c *MockWebhookRepository_CreateWebhookDeliveries_Call) Run(run func(ctx context.Context, deliveries []model0.WebhookDelivery)) *MockWebhookRepository_CreateWebhookDeliveries_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 []model0.WebhookDelivery
+		if args[1] != nil {
+			arg1 = args[1].([]model0.WebhookDelivery)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateWebhookDeliveries_Call) Return(err error) *MockWebhookRepository_CreateWebhookDeliveries_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_CreateWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, deliveries []model0.WebhookDelivery) error) *MockWebhookRepository_CreateWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteWebhookByID provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) DeleteWebhookByID(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteWebhookByID")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_DeleteWebhookByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteWebhookByID'
+type MockWebhookRepository_DeleteWebhookByID_Call struct {
+	*mock.Call
+}
+
+// DeleteWebhookByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookRepository_Expecter) DeleteWebhookByID(ctx any, id any) *MockWebhookRepository_DeleteWebhookByID_Call {
+	return &MockWebhookRepository_DeleteWebhookByID_Call{Call: _e.mock.On("DeleteWebhookByID", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_DeleteWebhookByID_Call) Run(run func(ctx context.Context, id string)) *MockWebhookRepository_DeleteWebhookByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
@@ -2256,6 +2664,429 @@ func (_c *MockWebhookRepository_GetWebhookByID_Call) RunAndReturn(run func(ctx c
 	return _c
 }
 
+// GetWebhookDeliveryByID provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) GetWebhookDeliveryByID(ctx context.Context, id string) (*model0.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWebhookDeliveryByID")
+	}
+
+	var r0 *model0.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model0.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model0.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model0.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_GetWebhookDeliveryByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWebhookDeliveryByID'
+type MockWebhookRepository_GetWebhookDeliveryByID_Call struct {
+	*mock.Call
+}
+
+// GetWebhookDeliveryByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockWebhookRepository_Expecter) GetWebhookDeliveryByID(ctx any, id any) *MockWebhookRepository_GetWebhookDeliveryByID_Call {
+	return &MockWebhookRepository_GetWebhookDeliveryByID_Call{Call: _e.mock.On("GetWebhookDeliveryByID", ctx, id)}
+}
+
+func (_c *MockWebhookRepository_GetWebhookDeliveryByID_Call) Run(run func(ctx context.Context, id string)) *MockWebhookRepository_GetWebhookDeliveryByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetWebhookDeliveryByID_Call) Return(webhookDelivery *model0.WebhookDelivery, err error) *MockWebhookRepository_GetWebhookDeliveryByID_Call {
+	_c.Call.Return(webhookDelivery, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_GetWebhookDeliveryByID_Call) RunAndReturn(run func(ctx context.Context, id string) (*model0.WebhookDelivery, error)) *MockWebhookRepository_GetWebhookDeliveryByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeadLetters provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ListWebhookDeadLetters(ctx context.Context, webhookID string, limit int) ([]model0.WebhookOutbox, error) {
+	ret := _mock.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeadLetters")
+	}
+
+	var r0 []model0.WebhookOutbox
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model0.WebhookOutbox, error)); ok {
+		return returnFunc(ctx, webhookID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model0.WebhookOutbox); ok {
+		r0 = returnFunc(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model0.WebhookOutbox)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ListWebhookDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeadLetters'
+type MockWebhookRepository_ListWebhookDeadLetters_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) ListWebhookDeadLetters(ctx any, webhookID any, limit any) *MockWebhookRepository_ListWebhookDeadLetters_Call {
+	return &MockWebhookRepository_ListWebhookDeadLetters_Call{Call: _e.mock.On("ListWebhookDeadLetters", ctx, webhookID, limit)}
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeadLetters_Call) Run(run func(ctx context.Context, webhookID string, limit int)) *MockWebhookRepository_ListWebhookDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeadLetters_Call) Return(webhookOutboxs []model0.WebhookOutbox, err error) *MockWebhookRepository_ListWebhookDeadLetters_Call {
+	_c.Call.Return(webhookOutboxs, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeadLetters_Call) RunAndReturn(run func(ctx context.Context, webhookID string, limit int) ([]model0.WebhookOutbox, error)) *MockWebhookRepository_ListWebhookDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListWebhookDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) ListWebhookDeliveries(ctx context.Context, webhookID string, limit int) ([]model0.WebhookDelivery, error) {
+	ret := _mock.Called(ctx, webhookID, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWebhookDeliveries")
+	}
+
+	var r0 []model0.WebhookDelivery
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) ([]model0.WebhookDelivery, error)); ok {
+		return returnFunc(ctx, webhookID, limit)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) []model0.WebhookDelivery); ok {
+		r0 = returnFunc(ctx, webhookID, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model0.WebhookDelivery)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = returnFunc(ctx, webhookID, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_ListWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListWebhookDeliveries'
+type MockWebhookRepository_ListWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// ListWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - limit int
+func (_e *MockWebhookRepository_Expecter) ListWebhookDeliveries(ctx any, webhookID any, limit any) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	return &MockWebhookRepository_ListWebhookDeliveries_Call{Call: _e.mock.On("ListWebhookDeliveries", ctx, webhookID, limit)}
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeliveries_Call) Run(run func(ctx context.Context, webhookID string, limit int)) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeliveries_Call) Return(webhookDeliverys []model0.WebhookDelivery, err error) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	_c.Call.Return(webhookDeliverys, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_ListWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, webhookID string, limit int) ([]model0.WebhookDelivery, error)) *MockWebhookRepository_ListWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PruneWebhookDeliveries provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) PruneWebhookDeliveries(ctx context.Context, webhookID string, keep int) error {
+	ret := _mock.Called(ctx, webhookID, keep)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneWebhookDeliveries")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, webhookID, keep)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockWebhookRepository_PruneWebhookDeliveries_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PruneWebhookDeliveries'
+type MockWebhookRepository_PruneWebhookDeliveries_Call struct {
+	*mock.Call
+}
+
+// PruneWebhookDeliveries is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - keep int
+func (_e *MockWebhookRepository_Expecter) PruneWebhookDeliveries(ctx any, webhookID any, keep any) *MockWebhookRepository_PruneWebhookDeliveries_Call {
+	return &MockWebhookRepository_PruneWebhookDeliveries_Call{Call: _e.mock.On("PruneWebhookDeliveries", ctx, webhookID, keep)}
+}
+
+func (_c *MockWebhookRepository_PruneWebhookDeliveries_Call) Run(run func(ctx context.Context, webhookID string, keep int)) *MockWebhookRepository_PruneWebhookDeliveries_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_PruneWebhookDeliveries_Call) Return(err error) *MockWebhookRepository_PruneWebhookDeliveries_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_PruneWebhookDeliveries_Call) RunAndReturn(run func(ctx context.Context, webhookID string, keep int) error) *MockWebhookRepository_PruneWebhookDeliveries_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeWebhookDeadLetters provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) PurgeWebhookDeadLetters(ctx context.Context, webhookID string) (int64, error) {
+	ret := _mock.Called(ctx, webhookID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeWebhookDeadLetters")
+	}
+
+	var r0 int64
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return returnFunc(ctx, webhookID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = returnFunc(ctx, webhookID)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_PurgeWebhookDeadLetters_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeWebhookDeadLetters'
+type MockWebhookRepository_PurgeWebhookDeadLetters_Call struct {
+	*mock.Call
+}
+
+// PurgeWebhookDeadLetters is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+func (_e *MockWebhookRepository_Expecter) PurgeWebhookDeadLetters(ctx any, webhookID any) *MockWebhookRepository_PurgeWebhookDeadLetters_Call {
+	return &MockWebhookRepository_PurgeWebhookDeadLetters_Call{Call: _e.mock.On("PurgeWebhookDeadLetters", ctx, webhookID)}
+}
+
+func (_c *MockWebhookRepository_PurgeWebhookDeadLetters_Call) Run(run func(ctx context.Context, webhookID string)) *MockWebhookRepository_PurgeWebhookDeadLetters_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_PurgeWebhookDeadLetters_Call) Return(n int64, err error) *MockWebhookRepository_PurgeWebhookDeadLetters_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_PurgeWebhookDeadLetters_Call) RunAndReturn(run func(ctx context.Context, webhookID string) (int64, error)) *MockWebhookRepository_PurgeWebhookDeadLetters_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RequeueWebhookDeadLetter provides a mock function for the type MockWebhookRepository
+func (_mock *MockWebhookRepository) RequeueWebhookDeadLetter(ctx context.Context, webhookID string, id string, now int64) (bool, error) {
+	ret := _mock.Called(ctx, webhookID, id, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RequeueWebhookDeadLetter")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int64) (bool, error)); ok {
+		return returnFunc(ctx, webhookID, id, now)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int64) bool); ok {
+		r0 = returnFunc(ctx, webhookID, id, now)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = returnFunc(ctx, webhookID, id, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockWebhookRepository_RequeueWebhookDeadLetter_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RequeueWebhookDeadLetter'
+type MockWebhookRepository_RequeueWebhookDeadLetter_Call struct {
+	*mock.Call
+}
+
+// RequeueWebhookDeadLetter is a helper method to define mock.On call
+//   - ctx context.Context
+//   - webhookID string
+//   - id string
+//   - now int64
+func (_e *MockWebhookRepository_Expecter) RequeueWebhookDeadLetter(ctx any, webhookID any, id any, now any) *MockWebhookRepository_RequeueWebhookDeadLetter_Call {
+	return &MockWebhookRepository_RequeueWebhookDeadLetter_Call{Call: _e.mock.On("RequeueWebhookDeadLetter", ctx, webhookID, id, now)}
+}
+
+func (_c *MockWebhookRepository_RequeueWebhookDeadLetter_Call) Run(run func(ctx context.Context, webhookID string, id string, now int64)) *MockWebhookRepository_RequeueWebhookDeadLetter_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int64
+		if args[3] != nil {
+			arg3 = args[3].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockWebhookRepository_RequeueWebhookDeadLetter_Call) Return(b bool, err error) *MockWebhookRepository_RequeueWebhookDeadLetter_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockWebhookRepository_RequeueWebhookDeadLetter_Call) RunAndReturn(run func(ctx context.Context, webhookID string, id string, now int64) (bool, error)) *MockWebhookRepository_RequeueWebhookDeadLetter_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateWebhookByID provides a mock function for the type MockWebhookRepository
 func (_mock *MockWebhookRepository) UpdateWebhookByID(ctx context.Context, id string, webhook *model0.Webhook) error {
 	ret := _mock.Called(ctx, id, webhook)
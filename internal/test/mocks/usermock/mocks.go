@@ -490,6 +490,69 @@ func (_c *MockService_UpdateUserAdmin_Call) RunAndReturn(run func(ctx context.Co
 	return _c
 }
 
+// UpdateUserRole provides a mock function for the type MockService
+func (_mock *MockService) UpdateUserRole(ctx context.Context, id string, role model.Role) error {
+	ret := _mock.Called(ctx, id, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserRole")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.Role) error); ok {
+		r0 = returnFunc(ctx, id, role)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// MockService_UpdateUserRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserRole'
+type MockService_UpdateUserRole_Call struct {
+	*mock.Call
+}
+
+// UpdateUserRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - role model.Role
+func (_e *MockService_Expecter) UpdateUserRole(ctx any, id any, role any) *MockService_UpdateUserRole_Call {
+	return &MockService_UpdateUserRole_Call{Call: _e.mock.On("UpdateUserRole", ctx, id, role)}
+}
+
+func (_c *MockService_UpdateUserRole_Call) Run(run func(ctx context.Context, id string, role model.Role)) *MockService_UpdateUserRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.Role
+		if args[2] != nil {
+			arg2 = args[2].(model.Role)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockService_UpdateUserRole_Call) Return(err error) *MockService_UpdateUserRole_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *MockService_UpdateUserRole_Call) RunAndReturn(run func(ctx context.Context, id string, role model.Role) error) *MockService_UpdateUserRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockUserRepo creates a new instance of MockUserRepo. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserRepo(t interface {
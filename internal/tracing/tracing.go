@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package tracing 接入 OpenTelemetry 分布式追踪，经 OTLP/HTTP 导出到本地 collector。
+//
+// 各处埋点统一走 Start 拿 span，依赖全局 TracerProvider：未开启时它是 no-op，
+// 开销只是一次接口调用；开启后由 Component 在启动时装上 SDK provider、停机时刷出缓冲。
+// 请求、GORM 这类需要在构造期挂钩子的埋点另按 Enabled 判断，关闭时完全不挂。
+package tracing
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/lin-snow/ech0/internal/config"
+	versionPkg "github.com/lin-snow/ech0/internal/version"
+	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName 是 Ech0 自身埋点的 instrumentation scope。
+const ScopeName = "github.com/lin-snow/ech0"
+
+// Enabled 报告配置里是否开启了追踪。
+func Enabled() bool {
+	return config.Config().Tracing.Enabled
+}
+
+// Start 以 ctx 中的 span 为父开启一个子 span；调用方负责 span.End()。
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(ScopeName).Start(ctx, name, opts...)
+}
+
+// End 在 err 非空时把 span 标为失败并记录错误，然后结束 span。惯用法：
+//
+//	ctx, span := tracing.Start(ctx, "x")
+//	defer func() { tracing.End(span, err) }()
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Component 把 TracerProvider 的生命周期挂进 app：排在最前启动、最后停止，
+// 停机时其余组件产生的 span 都能刷出去。
+type Component struct {
+	provider *sdktrace.TracerProvider
+}
+
+func NewComponent() *Component {
+	return &Component{}
+}
+
+func (c *Component) Name() string {
+	return "tracing"
+}
+
+// Start 在开启追踪时装上 SDK provider 与 W3C trace context 传播器；未开启时什么也不做。
+// 导出器连不上 collector 不影响启动，span 会在批量导出时丢弃并记日志。
+func (c *Component) Start(ctx context.Context) error {
+	cfg := config.Config().Tracing
+	if !cfg.Enabled {
+		return nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, exporterOptions(cfg)...)
+	if err != nil {
+		return err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(cfg.ServiceName),
+		semconv.ServiceVersion(versionPkg.Version),
+	))
+	if err != nil {
+		return err
+	}
+
+	c.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(c.provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+	logUtil.GetLogger().Info("tracing enabled",
+		slog.String("endpoint", cfg.Endpoint), slog.Float64("sample_ratio", cfg.SampleRatio))
+	return nil
+}
+
+// Stop 刷出尚未导出的 span 并关闭 provider。
+func (c *Component) Stop(ctx context.Context) error {
+	if c.provider == nil {
+		return nil
+	}
+	err := c.provider.Shutdown(ctx)
+	c.provider = nil
+	return err
+}
+
+// exporterOptions 按配置组装 OTLP/HTTP 导出参数。Endpoint 可写 host:port，也可写完整 URL
+// （带路径时按原样使用）；留空则交给 OTEL_EXPORTER_OTLP_* 环境变量与导出器默认值。
+func exporterOptions(cfg config.TracingConfig) []otlptracehttp.Option {
+	var opts []otlptracehttp.Option
+	switch {
+	case strings.Contains(cfg.Endpoint, "://"):
+		opts = append(opts, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	case cfg.Endpoint != "":
+		opts = append(opts, otlptracehttp.WithEndpoint(cfg.Endpoint))
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+	}
+	return opts
+}
+
+// Inject 把 ctx 里的 trace context 以 W3C 头（traceparent 等）写进 carrier，供跨 goroutine、
+// 跨进程的消费方接续。未开启追踪时传播器为空，什么也不写。
+func Inject(ctx context.Context, carrier map[string]string) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(carrier))
+}
+
+// Extract 从 carrier 还原 Inject 写入的 trace context，作为 ctx 中的远端父 span。
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(carrier))
+}
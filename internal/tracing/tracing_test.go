@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/tracing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestInjectExtract_RoundTrip(t *testing.T) {
+	helpers.RecordSpans(t)
+
+	ctx, span := tracing.Start(context.Background(), "parent")
+	defer span.End()
+
+	carrier := map[string]string{}
+	tracing.Inject(ctx, carrier)
+	require.Contains(t, carrier, "traceparent")
+
+	remote := trace.SpanContextFromContext(tracing.Extract(context.Background(), carrier))
+	assert.True(t, remote.IsRemote())
+	assert.Equal(t, span.SpanContext().TraceID(), remote.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), remote.SpanID())
+}
+
+func TestEnd_RecordsError(t *testing.T) {
+	recorder := helpers.RecordSpans(t)
+
+	_, ok := tracing.Start(context.Background(), "ok")
+	tracing.End(ok, nil)
+	_, failed := tracing.Start(context.Background(), "failed")
+	tracing.End(failed, errors.New("boom"))
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 2)
+	assert.Equal(t, codes.Unset, ended[0].Status().Code)
+	assert.Equal(t, codes.Error, ended[1].Status().Code)
+	assert.Equal(t, "boom", ended[1].Status().Description)
+	require.Len(t, ended[1].Events(), 1, "error should be recorded as a span event")
+}
+
+func TestComponent_DisabledIsNoop(t *testing.T) {
+	c := tracing.NewComponent()
+	require.False(t, tracing.Enabled())
+	require.NoError(t, c.Start(context.Background()))
+	require.NoError(t, c.Stop(context.Background()))
+}
@@ -22,3 +22,30 @@ func HasTx(ctx context.Context) bool {
 	_, ok := TxFromContext(ctx)
 	return ok
 }
+
+// afterCommitHooks 收集事务内登记的提交后回调，由 Run 在提交成功后依次执行。
+type afterCommitHooks struct {
+	fns []func()
+}
+
+// AfterCommit 登记一个在当前事务提交后执行的回调；事务回滚时丢弃。ctx 不在事务里时立即执行。
+func AfterCommit(ctx context.Context, fn func()) {
+	if ctx != nil {
+		if hooks, ok := ctx.Value(afterCommitKey).(*afterCommitHooks); ok {
+			hooks.fns = append(hooks.fns, fn)
+			return
+		}
+	}
+	fn()
+}
+
+// Detach 返回一个脱离当前事务的 ctx：保留其余值，但不再携带事务句柄与提交回调，也不随原 ctx 取消。
+// 提交后回调里若要继续读写数据库，应使用它而不是事务内的 ctx。
+func Detach(ctx context.Context) context.Context {
+	if ctx == nil {
+		return context.Background()
+	}
+	ctx = context.WithoutCancel(ctx)
+	ctx = context.WithValue(ctx, TxKey, nil)
+	return context.WithValue(ctx, afterCommitKey, nil)
+}
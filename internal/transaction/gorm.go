@@ -36,11 +36,21 @@ func (tx *GormTransactor) Run(ctx context.Context, fn func(ctx context.Context)
 
 	// 返回一个新的事务上下文
 	// 在这个上下文中，TxKey 被设置为当前事务的 gorm.DB，使用 GORM 自带的自动事务管理
-	return tx.dbProvider().Transaction(func(gormTx *gorm.DB) error {
+	hooks := &afterCommitHooks{}
+	if err := tx.dbProvider().Transaction(func(gormTx *gorm.DB) error {
 		// 将当前事务的 gorm.DB 设置到上下文中，这里创建一个新的上下文
 		txCtx := context.WithValue(ctx, TxKey, gormTx)
+		txCtx = context.WithValue(txCtx, afterCommitKey, hooks)
 
 		// 执行传入的函数，并传递事务上下文
 		return fn(txCtx)
-	})
+	}); err != nil {
+		return err
+	}
+
+	// 提交成功后才执行 AfterCommit 登记的回调
+	for _, fn := range hooks.fns {
+		fn()
+	}
+	return nil
 }
@@ -9,7 +9,10 @@ import (
 
 type contextKey string
 
-const TxKey contextKey = "tx"
+const (
+	TxKey          contextKey = "tx"
+	afterCommitKey contextKey = "after_commit"
+)
 
 // Transactor 定义事务执行器接口
 type Transactor interface {
@@ -62,6 +62,16 @@ func (p *WorkerPool) Submit(job func() error) {
 	p.jobs <- job
 }
 
+// QueueLen 返回已提交、尚未被 worker 取走的任务数。
+func (p *WorkerPool) QueueLen() int {
+	return len(p.jobs)
+}
+
+// QueueCap 返回任务通道的缓冲容量。
+func (p *WorkerPool) QueueCap() int {
+	return cap(p.jobs)
+}
+
 // Wait 等待所有任务完成
 func (p *WorkerPool) Wait() {
 	p.wg.Wait()
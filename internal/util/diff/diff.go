@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package util 提供按行比较两段文本的最小编辑脚本（Myers 差分算法）。
+package util
+
+import "strings"
+
+// Op 是差分行的操作类型。
+type Op string
+
+const (
+	OpEqual  Op = "equal"
+	OpInsert Op = "insert"
+	OpDelete Op = "delete"
+)
+
+// Line 是差分结果中的一行。
+type Line struct {
+	Op   Op     `json:"op"`
+	Text string `json:"text"`
+}
+
+// Stats 汇总差分结果中的增删行数。
+type Stats struct {
+	Added   int `json:"added"`
+	Removed int `json:"removed"`
+}
+
+// Lines 计算把 before 变成 after 的逐行差分。两段文本都按 "\n" 切行（统一 "\r\n"），
+// 空串视为零行。结果按原文顺序排列，删除行先于同位置的插入行。
+func Lines(before, after string) []Line {
+	a, b := splitLines(before), splitLines(after)
+
+	// 去掉公共前后缀，缩小 Myers 的搜索空间（编辑通常只动中间几行）。
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	out := make([]Line, 0, len(a)+len(b))
+	for _, s := range a[:prefix] {
+		out = append(out, Line{Op: OpEqual, Text: s})
+	}
+	out = append(out, myers(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, s := range a[len(a)-suffix:] {
+		out = append(out, Line{Op: OpEqual, Text: s})
+	}
+	return out
+}
+
+// Summarize 统计差分结果的增删行数。
+func Summarize(lines []Line) Stats {
+	var st Stats
+	for _, l := range lines {
+		switch l.Op {
+		case OpInsert:
+			st.Added++
+		case OpDelete:
+			st.Removed++
+		}
+	}
+	return st
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.Split(s, "\n")
+}
+
+// myers 按 Myers O(ND) 算法求最短编辑脚本，再沿记录的 V 数组回溯出逐行操作。
+func myers(a, b []string) []Line {
+	n, m := len(a), len(b)
+	if n == 0 && m == 0 {
+		return nil
+	}
+	maxD := n + m
+	offset := maxD
+	v := make([]int, 2*maxD+2)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		snapshot := make([]int, len(v))
+		copy(snapshot, v)
+		trace = append(trace, snapshot)
+
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1] // 下移：插入 b 的一行
+			} else {
+				x = v[offset+k-1] + 1 // 右移：删除 a 的一行
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b, offset)
+			}
+		}
+	}
+	return nil
+}
+
+func backtrack(trace [][]int, a, b []string, offset int) []Line {
+	x, y := len(a), len(b)
+	var rev []Line
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			rev = append(rev, Line{Op: OpEqual, Text: a[x]})
+		}
+		if d == 0 {
+			break
+		}
+		if x == prevX {
+			y--
+			rev = append(rev, Line{Op: OpInsert, Text: b[y]})
+		} else {
+			x--
+			rev = append(rev, Line{Op: OpDelete, Text: a[x]})
+		}
+	}
+
+	out := make([]Line, len(rev))
+	for i := range rev {
+		out[i] = rev[len(rev)-1-i]
+	}
+	return out
+}
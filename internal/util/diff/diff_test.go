@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// apply 用差分结果分别还原出 before / after，验证编辑脚本自洽。
+func apply(lines []Line) (before, after string) {
+	var a, b []string
+	for _, l := range lines {
+		if l.Op != OpInsert {
+			a = append(a, l.Text)
+		}
+		if l.Op != OpDelete {
+			b = append(b, l.Text)
+		}
+	}
+	return strings.Join(a, "\n"), strings.Join(b, "\n")
+}
+
+func TestLines(t *testing.T) {
+	cases := []struct {
+		name          string
+		before, after string
+		want          []Line
+	}{
+		{"both empty", "", "", []Line{}},
+		{"identical", "a\nb", "a\nb", []Line{{OpEqual, "a"}, {OpEqual, "b"}}},
+		{"from empty", "", "a", []Line{{OpInsert, "a"}}},
+		{"to empty", "a", "", []Line{{OpDelete, "a"}}},
+		{
+			"replace middle line",
+			"a\nb\nc", "a\nx\nc",
+			[]Line{{OpEqual, "a"}, {OpDelete, "b"}, {OpInsert, "x"}, {OpEqual, "c"}},
+		},
+		{
+			"crlf normalized",
+			"a\r\nb", "a\nb",
+			[]Line{{OpEqual, "a"}, {OpEqual, "b"}},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := Lines(tc.before, tc.after)
+			if len(tc.want) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLines_MinimalAndConsistent(t *testing.T) {
+	before := "title\nline 1\nline 2\nline 3\nline 4\nfooter"
+	after := "title\nline 1\nline 2.5\nline 3\nnew line\nline 4\nfooter"
+
+	got := Lines(before, after)
+	b, a := apply(got)
+	assert.Equal(t, before, b)
+	assert.Equal(t, after, a)
+	assert.Equal(t, Stats{Added: 2, Removed: 1}, Summarize(got))
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	logUtil "github.com/lin-snow/ech0/pkg/log"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
 // clientConfig holds the resolved options for NewClient.
@@ -57,8 +58,9 @@ func NewClient(opts ...Option) *http.Client {
 	}
 
 	client := &http.Client{
-		Timeout:   cfg.timeout,
-		Transport: &loggingRoundTripper{base: transport},
+		Timeout: cfg.timeout,
+		// otelhttp 为每个出站请求开 client span 并注入 traceparent；未开启追踪时 provider 是 no-op。
+		Transport: otelhttp.NewTransport(&loggingRoundTripper{base: transport}),
 	}
 
 	if cfg.guard {
@@ -107,10 +109,26 @@ func (l *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, erro
 	return resp, err
 }
 
+// permanentError marks an error that Retry must not retry.
+type permanentError struct{ err error }
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err so that Retry gives up immediately and returns err
+// unwrapped, e.g. for a 4xx response that will not change on a second try.
+// A nil err stays nil.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
 // Retry runs fn up to maxAttempts times, sleeping with exponential backoff
 // between attempts (starting at initialBackoff). It returns nil on the first
 // success and the last error otherwise. It does not sleep after the final
-// attempt.
+// attempt. An error wrapped with Permanent ends the loop at once.
 func Retry(maxAttempts int, initialBackoff time.Duration, fn func() error) error {
 	var err error
 	delay := initialBackoff
@@ -118,6 +136,10 @@ func Retry(maxAttempts int, initialBackoff time.Duration, fn func() error) error
 		if err = fn(); err == nil {
 			return nil
 		}
+		var perm *permanentError
+		if errors.As(err, &perm) {
+			return perm.err
+		}
 		if i < maxAttempts-1 {
 			time.Sleep(delay)
 			delay *= 2
@@ -92,3 +92,23 @@ func TestRetry_exhausts_attempts(t *testing.T) {
 		t.Fatalf("expected 3 attempts, got %d", calls)
 	}
 }
+
+func TestRetry_stops_on_permanent_error(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	sentinel := errors.New("rejected")
+	err := Retry(3, time.Millisecond, func() error {
+		calls++
+		return Permanent(sentinel)
+	})
+	if err != sentinel {
+		t.Fatalf("expected unwrapped sentinel error, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 attempt, got %d", calls)
+	}
+	if Permanent(nil) != nil {
+		t.Fatal("Permanent(nil) should be nil")
+	}
+}
@@ -0,0 +1,325 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package util 提供 SQLite FTS5 全文检索的文本归一化、查询语法解析与命中高亮。
+//
+// FTS5 内置的 unicode61 分词器按「非字母数字字符」切词，连续的中日韩文字会被整段当作一个词，
+// 无法做子串检索。这里在入索引前把每个 CJK 字符两侧补空格（单字索引），查询时再把 CJK 片段
+// 转成相邻单字的短语查询，从而在不引入自定义 C 分词器的前提下同时支持西文分词与中文检索。
+package util
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// EchoIndexTable 是 Echo 正文的 FTS5 虚表名。echo_id 不参与分词，仅用于回连 echos 主表。
+const EchoIndexTable = "echo_fts"
+
+// CreateEchoIndexSQL 建立 Echo 全文索引虚表。需要 go-sqlite3 以 sqlite_fts5 构建标签编译，
+// 否则执行会报 "no such module: fts5"，调用方应据此降级为 LIKE 检索。
+const CreateEchoIndexSQL = "CREATE VIRTUAL TABLE IF NOT EXISTS " + EchoIndexTable +
+	" USING fts5(echo_id UNINDEXED, content, tokenize = 'unicode61 remove_diacritics 2')"
+
+// NormalizeText 把正文转换成写入 FTS 索引的形式：CJK 字符逐字独立成词，其余字符原样保留。
+func NormalizeText(content string) string {
+	var b strings.Builder
+	b.Grow(len(content) + len(content)/2)
+	prevCJK, prevSpace := false, true
+	for _, r := range content {
+		if IsCJK(r) {
+			if !prevSpace {
+				b.WriteByte(' ')
+			}
+			b.WriteRune(r)
+			prevCJK, prevSpace = true, false
+			continue
+		}
+		if prevCJK && !unicode.IsSpace(r) {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+		prevCJK, prevSpace = false, unicode.IsSpace(r)
+	}
+	return b.String()
+}
+
+// Term 是检索语句里的一个检索项。
+type Term struct {
+	// Text 是原始检索词（已去掉引号、前缀星号与否定前缀）。
+	Text string
+	// Phrase 表示来自双引号短语，按相邻词序匹配。
+	Phrase bool
+	// Prefix 表示以 * 结尾的前缀匹配。
+	Prefix bool
+}
+
+// Query 是解析后的检索语句。Groups 之间为 AND，同一 Group 内的检索项为 OR；Excludes 为 NOT。
+type Query struct {
+	Groups   [][]Term
+	Excludes []Term
+}
+
+// Empty 报告检索语句是否不含任何有效检索项。
+func (q Query) Empty() bool {
+	return len(q.Groups) == 0 && len(q.Excludes) == 0
+}
+
+// Terms 返回全部正向检索项的文本，供高亮与 LIKE 降级使用。
+func (q Query) Terms() []string {
+	var terms []string
+	for _, group := range q.Groups {
+		for _, term := range group {
+			terms = append(terms, term.Text)
+		}
+	}
+	return terms
+}
+
+// ParseQuery 解析用户输入的检索语句，支持：
+//   - 空格分隔的多个词（AND）；
+//   - "双引号短语"；
+//   - 以 * 结尾的前缀匹配，如 gol*；
+//   - -词 或 NOT 词 排除；
+//   - 词 OR 词 任一匹配。
+//
+// 语法错误（如未闭合的引号）按尽力而为处理，不会返回错误。
+func ParseQuery(input string) Query {
+	var (
+		query     Query
+		negateNxt bool
+		orNext    bool
+	)
+	for _, tok := range tokenize(input) {
+		if !tok.quoted {
+			switch tok.text {
+			case "OR":
+				orNext = len(query.Groups) > 0
+				continue
+			case "AND":
+				continue
+			case "NOT", "-":
+				negateNxt = true
+				continue
+			}
+		}
+
+		term := Term{Text: tok.text, Phrase: tok.quoted}
+		negate := negateNxt
+		negateNxt = false
+		if !tok.quoted {
+			if strings.HasPrefix(term.Text, "-") {
+				negate = true
+				term.Text = strings.TrimLeft(term.Text, "-")
+			}
+			if strings.HasSuffix(term.Text, "*") {
+				term.Prefix = true
+				term.Text = strings.TrimRight(term.Text, "*")
+			}
+		}
+		if !hasToken(term.Text) {
+			orNext = false
+			continue
+		}
+
+		if negate {
+			query.Excludes = append(query.Excludes, term)
+			orNext = false
+			continue
+		}
+		if orNext {
+			last := len(query.Groups) - 1
+			query.Groups[last] = append(query.Groups[last], term)
+			orNext = false
+			continue
+		}
+		query.Groups = append(query.Groups, []Term{term})
+	}
+	return query
+}
+
+// MatchExpression 返回正向检索项对应的 FTS5 MATCH 表达式；没有正向检索项时返回空串。
+func (q Query) MatchExpression() string {
+	return buildExpression(q.Groups, " AND ")
+}
+
+// ExcludeExpression 返回排除项对应的 FTS5 MATCH 表达式（任一命中即排除）；没有排除项时返回空串。
+func (q Query) ExcludeExpression() string {
+	if len(q.Excludes) == 0 {
+		return ""
+	}
+	return buildExpression([][]Term{q.Excludes}, "")
+}
+
+func buildExpression(groups [][]Term, sep string) string {
+	parts := make([]string, 0, len(groups))
+	for _, group := range groups {
+		items := make([]string, 0, len(group))
+		for _, term := range group {
+			items = append(items, termExpression(term))
+		}
+		if len(items) == 1 {
+			parts = append(parts, items[0])
+			continue
+		}
+		parts = append(parts, "("+strings.Join(items, " OR ")+")")
+	}
+	return strings.Join(parts, sep)
+}
+
+// termExpression 把单个检索项转成 FTS5 字符串字面量：所有用户输入都包进双引号（内部双引号转义为两个），
+// 因此不会被当作 FTS5 运算符或列过滤器解析。
+func termExpression(term Term) string {
+	text := strings.Join(strings.Fields(NormalizeText(term.Text)), " ")
+	expr := `"` + strings.ReplaceAll(text, `"`, `""`) + `"`
+	if term.Prefix {
+		expr += " *"
+	}
+	return expr
+}
+
+// DefaultSnippetRunes 是 Highlight 生成摘要的默认最大字符数。
+const DefaultSnippetRunes = 120
+
+// Highlight 在正文里定位首个命中的检索词，截取其附近不超过 maxRunes 个字符的片段，
+// 做 HTML 转义后用 <mark></mark> 包裹全部命中。没有命中时返回空串。
+func Highlight(content string, terms []string, maxRunes int) string {
+	if maxRunes <= 0 {
+		maxRunes = DefaultSnippetRunes
+	}
+	text := []rune(strings.Join(strings.Fields(content), " "))
+	lower := make([]rune, len(text))
+	for i, r := range text {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	needles := make([][]rune, 0, len(terms))
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		needle := []rune(term)
+		for i, r := range needle {
+			needle[i] = unicode.ToLower(r)
+		}
+		needles = append(needles, needle)
+	}
+	if len(needles) == 0 {
+		return ""
+	}
+
+	marks := make([]bool, len(text))
+	first := -1
+	for _, needle := range needles {
+		for i := 0; i+len(needle) <= len(lower); i++ {
+			if !runesEqual(lower[i:i+len(needle)], needle) {
+				continue
+			}
+			for j := i; j < i+len(needle); j++ {
+				marks[j] = true
+			}
+			if first == -1 || i < first {
+				first = i
+			}
+		}
+	}
+	if first == -1 {
+		return ""
+	}
+
+	start := max(first-maxRunes/4, 0)
+	end := min(start+maxRunes, len(text))
+	if end-start < maxRunes {
+		start = max(end-maxRunes, 0)
+	}
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	inMark := false
+	for i := start; i < end; i++ {
+		if marks[i] && !inMark {
+			b.WriteString("<mark>")
+			inMark = true
+		}
+		if !marks[i] && inMark {
+			b.WriteString("</mark>")
+			inMark = false
+		}
+		b.WriteString(html.EscapeString(string(text[i])))
+	}
+	if inMark {
+		b.WriteString("</mark>")
+	}
+	if end < len(text) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+type queryToken struct {
+	text   string
+	quoted bool
+}
+
+// tokenize 按空白切分检索语句，双引号内的内容作为整体保留。
+func tokenize(input string) []queryToken {
+	var (
+		tokens []queryToken
+		cur    strings.Builder
+		quoted bool
+	)
+	flush := func(q bool) {
+		text := strings.TrimSpace(cur.String())
+		cur.Reset()
+		if text != "" {
+			tokens = append(tokens, queryToken{text: text, quoted: q})
+		}
+	}
+	for _, r := range input {
+		switch {
+		case r == '"':
+			flush(quoted)
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			flush(false)
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	flush(quoted)
+	return tokens
+}
+
+// hasToken 报告文本里是否至少含有一个可被 unicode61 分词器索引的字符。
+func hasToken(text string) bool {
+	for _, r := range text {
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCJK 判断字符是否属于不以空格分词的中日韩文字（汉字、假名、谚文）。
+func IsCJK(r rune) bool {
+	return unicode.Is(unicode.Han, r) ||
+		unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) ||
+		unicode.Is(unicode.Hangul, r)
+}
+
+func runesEqual(a, b []rune) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
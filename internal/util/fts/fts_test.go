@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeText_SplitsCJKRunes(t *testing.T) {
+	assert.Equal(t, "hello 世 界 world", NormalizeText("hello世界world"))
+	assert.Equal(t, "go 语 言", NormalizeText("go 语言"))
+	assert.Equal(t, "plain text", NormalizeText("plain text"))
+}
+
+func TestParseQuery_Expressions(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   string
+		match   string
+		exclude string
+	}{
+		{name: "single term", input: "golang", match: `"golang"`},
+		{name: "implicit and", input: "golang sqlite", match: `"golang" AND "sqlite"`},
+		{name: "phrase", input: `"hello world" go`, match: `"hello world" AND "go"`},
+		{name: "prefix", input: "gol*", match: `"gol" *`},
+		{name: "dash negation", input: "golang -rust", match: `"golang"`, exclude: `"rust"`},
+		{name: "not keyword", input: "golang NOT rust NOT zig", match: `"golang"`, exclude: `("rust" OR "zig")`},
+		{name: "negated phrase", input: `go -"hello world"`, match: `"go"`, exclude: `"hello world"`},
+		{name: "or group", input: "go OR rust sqlite", match: `("go" OR "rust") AND "sqlite"`},
+		{name: "cjk becomes phrase", input: "中文检索", match: `"中 文 检 索"`},
+		{name: "quotes are escaped", input: `say"hi`, match: `"say" AND "hi"`},
+		{name: "operators are literal", input: "content:foo NEAR(", match: `"content:foo" AND "NEAR("`},
+		{name: "only negation", input: "-rust", exclude: `"rust"`},
+		{name: "punctuation only", input: "*** - OR"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			q := ParseQuery(tc.input)
+			assert.Equal(t, tc.match, q.MatchExpression())
+			assert.Equal(t, tc.exclude, q.ExcludeExpression())
+		})
+	}
+
+	assert.True(t, ParseQuery("  ").Empty())
+	assert.Equal(t, []string{"go", "rust"}, ParseQuery("go OR rust -zig").Terms())
+}
+
+func TestHighlight(t *testing.T) {
+	t.Run("marks every hit case-insensitively and escapes html", func(t *testing.T) {
+		got := Highlight("Go <3 and go again", []string{"go"}, 0)
+		assert.Equal(t, "<mark>Go</mark> &lt;3 and <mark>go</mark> again", got)
+	})
+
+	t.Run("cjk hit", func(t *testing.T) {
+		assert.Equal(t, "今天写了<mark>全文检索</mark>", Highlight("今天写了全文检索", []string{"全文检索"}, 0))
+	})
+
+	t.Run("windows long content around the first hit", func(t *testing.T) {
+		content := "aaaaaaaaaa bbbbbbbbbb cccccccccc needle dddddddddd eeeeeeeeee"
+		got := Highlight(content, []string{"needle"}, 20)
+		assert.Contains(t, got, "<mark>needle</mark>")
+		assert.True(t, len([]rune(got)) < len([]rune(content)))
+		assert.Equal(t, "…", string([]rune(got)[0]))
+	})
+
+	t.Run("no hit returns empty", func(t *testing.T) {
+		assert.Empty(t, Highlight("nothing here", []string{"absent"}, 0))
+		assert.Empty(t, Highlight("nothing here", nil, 0))
+	})
+}
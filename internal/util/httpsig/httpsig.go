@@ -0,0 +1,245 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+// Package util 实现 ActivityPub 实例间通用的 HTTP Signatures（draft-cavage-http-signatures，
+// rsa-sha256）签名与校验，以及配套的 RSA 密钥 PEM 编解码。
+package util
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxClockSkew 是校验时允许的 Date 与本机时间的最大偏差。
+const MaxClockSkew = 12 * time.Hour
+
+var (
+	ErrMissingSignature = errors.New("httpsig: missing signature header")
+	ErrInvalidSignature = errors.New("httpsig: invalid signature")
+)
+
+// now 供测试替换。
+var now = time.Now
+
+// GenerateKey 生成 2048 位 RSA 密钥。
+func GenerateKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, 2048)
+}
+
+// EncodePrivateKey 把私钥编码为 PKCS#8 PEM。
+func EncodePrivateKey(key *rsa.PrivateKey) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// DecodePrivateKey 解析 PKCS#8 或 PKCS#1 PEM 私钥。
+func DecodePrivateKey(pemText string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(pemText))
+	if block == nil {
+		return nil, errors.New("httpsig: no PEM block")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("httpsig: not an RSA private key")
+	}
+	return key, nil
+}
+
+// EncodePublicKey 把公钥编码为 PKIX PEM（actor 文档 publicKeyPem 的通行格式）。
+func EncodePublicKey(key *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// DecodePublicKey 解析 PKIX 或 PKCS#1 PEM 公钥。
+func DecodePublicKey(pemText string) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(pemText))
+	if block == nil {
+		return nil, errors.New("httpsig: no PEM block")
+	}
+	if key, err := x509.ParsePKCS1PublicKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("httpsig: not an RSA public key")
+	}
+	return key, nil
+}
+
+// Digest 返回 body 的 Digest 头取值（SHA-256）。
+func Digest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "SHA-256=" + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// Sign 为请求补齐 Host / Date（有 body 时再加 Digest）并写入 Signature 头。
+// 签名覆盖 (request-target)、host、date 与 digest，与 Mastodon 的要求一致。
+func Sign(req *http.Request, keyID string, key *rsa.PrivateKey, body []byte) error {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	req.Header.Set("Host", host)
+	req.Header.Set("Date", now().UTC().Format(http.TimeFormat))
+	headers := []string{"(request-target)", "host", "date"}
+	if body != nil {
+		req.Header.Set("Digest", Digest(body))
+		headers = append(headers, "digest")
+	}
+
+	signingString, err := buildSigningString(req, headers)
+	if err != nil {
+		return err
+	}
+	hashed := sha256.Sum256([]byte(signingString))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Signature", fmt.Sprintf(
+		`keyId="%s",algorithm="rsa-sha256",headers="%s",signature="%s"`,
+		keyID, strings.Join(headers, " "), base64.StdEncoding.EncodeToString(sig),
+	))
+	return nil
+}
+
+// KeyID 读出 Signature 头中的 keyId（未校验）。
+func KeyID(req *http.Request) (string, error) {
+	params, err := parseSignatureHeader(req.Header.Get("Signature"))
+	if err != nil {
+		return "", err
+	}
+	return params["keyId"], nil
+}
+
+// Verify 校验请求签名，返回签名所用的 keyId。lookup 按 keyId 取公钥（通常抓取远端 actor）。
+// 要求签名覆盖 (request-target)、host 与 date，且 Date 与本机时间相差不超过 MaxClockSkew；
+// body 非空时还要求签名覆盖 digest，且 Digest 与 body 一致。
+func Verify(req *http.Request, body []byte, lookup func(keyID string) (*rsa.PublicKey, error)) (string, error) {
+	params, err := parseSignatureHeader(req.Header.Get("Signature"))
+	if err != nil {
+		return "", err
+	}
+	keyID := params["keyId"]
+	if keyID == "" || params["signature"] == "" {
+		return "", ErrInvalidSignature
+	}
+	if alg := params["algorithm"]; alg != "" && alg != "rsa-sha256" && alg != "hs2019" {
+		return "", fmt.Errorf("%w: unsupported algorithm %q", ErrInvalidSignature, alg)
+	}
+
+	headers := strings.Fields(strings.ToLower(params["headers"]))
+	if len(headers) == 0 {
+		headers = []string{"date"}
+	}
+	required := []string{"(request-target)", "host", "date"}
+	if len(body) > 0 {
+		required = append(required, "digest")
+	}
+	for _, h := range required {
+		if !slices.Contains(headers, h) {
+			return "", fmt.Errorf("%w: %s not signed", ErrInvalidSignature, h)
+		}
+	}
+
+	date, err := http.ParseTime(req.Header.Get("Date"))
+	if err != nil {
+		return "", fmt.Errorf("%w: bad date", ErrInvalidSignature)
+	}
+	if skew := now().Sub(date); skew > MaxClockSkew || skew < -MaxClockSkew {
+		return "", fmt.Errorf("%w: date out of range", ErrInvalidSignature)
+	}
+	if len(body) > 0 && req.Header.Get("Digest") != Digest(body) {
+		return "", fmt.Errorf("%w: digest mismatch", ErrInvalidSignature)
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(params["signature"])
+	if err != nil {
+		return "", fmt.Errorf("%w: bad encoding", ErrInvalidSignature)
+	}
+	signingString, err := buildSigningString(req, headers)
+	if err != nil {
+		return "", err
+	}
+	pub, err := lookup(keyID)
+	if err != nil {
+		return "", err
+	}
+	hashed := sha256.Sum256([]byte(signingString))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, hashed[:], sig); err != nil {
+		return "", ErrInvalidSignature
+	}
+	return keyID, nil
+}
+
+func buildSigningString(req *http.Request, headers []string) (string, error) {
+	lines := make([]string, 0, len(headers))
+	for _, h := range headers {
+		switch h {
+		case "(request-target)":
+			lines = append(lines, "(request-target): "+strings.ToLower(req.Method)+" "+req.URL.RequestURI())
+		case "host":
+			host := req.Host
+			if host == "" {
+				host = req.Header.Get("Host")
+			}
+			if host == "" {
+				host = req.URL.Host
+			}
+			lines = append(lines, "host: "+host)
+		default:
+			values := req.Header.Values(h)
+			if len(values) == 0 {
+				return "", fmt.Errorf("%w: missing header %s", ErrInvalidSignature, h)
+			}
+			lines = append(lines, h+": "+strings.Join(values, ", "))
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// parseSignatureHeader 解析 `k="v",k2="v2"` 形式的 Signature 头。
+func parseSignatureHeader(raw string) (map[string]string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, ErrMissingSignature
+	}
+	params := make(map[string]string)
+	for _, part := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, ErrInvalidSignature
+		}
+		params[strings.TrimSpace(key)] = strings.Trim(strings.TrimSpace(value), `"`)
+	}
+	return params, nil
+}
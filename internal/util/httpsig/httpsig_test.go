@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package util
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSignedRequest(t *testing.T, key *rsa.PrivateKey, body []byte) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "https://remote.example/users/alice/inbox", bytes.NewReader(body))
+	require.NoError(t, err)
+	require.NoError(t, Sign(req, "https://ech0.example/ap/actor#main-key", key, body))
+	return req
+}
+
+func TestSignVerify_RoundTrip(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	body := []byte(`{"type":"Follow"}`)
+	req := newSignedRequest(t, key, body)
+
+	var asked string
+	keyID, err := Verify(req, body, func(id string) (*rsa.PublicKey, error) {
+		asked = id
+		return &key.PublicKey, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://ech0.example/ap/actor#main-key", keyID)
+	assert.Equal(t, keyID, asked)
+}
+
+func TestVerify_RejectsTamperedBody(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	req := newSignedRequest(t, key, []byte(`{"type":"Follow"}`))
+
+	_, err = Verify(req, []byte(`{"type":"Undo"}`), func(string) (*rsa.PublicKey, error) { return &key.PublicKey, nil })
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerify_RejectsWrongKey(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	other, err := GenerateKey()
+	require.NoError(t, err)
+	body := []byte(`{}`)
+	req := newSignedRequest(t, key, body)
+
+	_, err = Verify(req, body, func(string) (*rsa.PublicKey, error) { return &other.PublicKey, nil })
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerify_RejectsStaleDate(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+	body := []byte(`{}`)
+	now = func() time.Time { return time.Now().Add(-MaxClockSkew - time.Hour) }
+	req := newSignedRequest(t, key, body)
+	now = time.Now
+
+	_, err = Verify(req, body, func(string) (*rsa.PublicKey, error) { return &key.PublicKey, nil })
+	assert.ErrorIs(t, err, ErrInvalidSignature)
+}
+
+func TestVerify_MissingHeader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "https://ech0.example/ap/inbox", nil)
+	require.NoError(t, err)
+	_, err = Verify(req, nil, nil)
+	assert.ErrorIs(t, err, ErrMissingSignature)
+}
+
+func TestKeyPEM_RoundTrip(t *testing.T) {
+	key, err := GenerateKey()
+	require.NoError(t, err)
+
+	privPEM, err := EncodePrivateKey(key)
+	require.NoError(t, err)
+	decoded, err := DecodePrivateKey(privPEM)
+	require.NoError(t, err)
+	assert.True(t, key.Equal(decoded))
+
+	pubPEM, err := EncodePublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pub, err := DecodePublicKey(pubPEM)
+	require.NoError(t, err)
+	assert.True(t, key.PublicKey.Equal(pub))
+}
@@ -26,8 +26,9 @@ func MdToHTML(md []byte) []byte {
 
 	// 创建 HTML 渲染器
 	// SkipHTML 丢弃 markdown 中的原始 HTML 块/内联，阻止 <script> 等标签被原样输出。
-	// 该函数仅服务于 RSS Atom <summary type="html"> 渲染，前端正文走客户端 markdown-it，
-	// 因此关闭原始 HTML 透传不会影响 Web UI。
+	// 该函数用于服务端输出：RSS Atom <summary type="html">、/echo/{id} 的无 JS 回退正文、
+	// ActivityPub Note 内容与 Webmention 的链接提取；前端正文走客户端 markdown-it，
+	// 因此关闭原始 HTML 透传不会影响 Web UI，服务端输出也不会带出不受信的标签。
 	htmlFlags := html.CommonFlags |
 		html.Safelink |
 		html.HrefTargetBlank |
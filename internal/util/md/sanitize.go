@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package util
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/html"
+	"github.com/gomarkdown/markdown/parser"
+	xhtml "golang.org/x/net/html"
+)
+
+// commentTags 是评论 HTML 允许保留的标签；值为允许的属性（a 的 href 另行校验）。
+var commentTags = map[string][]string{
+	"p":          nil,
+	"br":         nil,
+	"em":         nil,
+	"strong":     nil,
+	"del":        nil,
+	"code":       nil,
+	"pre":        nil,
+	"blockquote": nil,
+	"ul":         nil,
+	"ol":         nil,
+	"li":         nil,
+	"a":          {"href"},
+}
+
+// dropWithContent 中的标签连同其内容一起丢弃，其余不在白名单的标签只丢标签、保留文本。
+var dropWithContent = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"iframe":   true,
+	"object":   true,
+	"embed":    true,
+	"template": true,
+	"noscript": true,
+	"textarea": true,
+	"title":    true,
+	"svg":      true,
+	"math":     true,
+}
+
+// CommentToHTML 把评论 Markdown 渲染为可直接插入页面的 HTML。
+//
+// 评论来自匿名访客，规则比 MdToHTML 更严：只支持段落、强调、删除线、行内 / 块代码、
+// 引用、列表与链接，不渲染标题、表格与图片；渲染结果再经 SanitizeCommentHTML 白名单过滤。
+func CommentToHTML(md string) string {
+	extensions := parser.NoIntraEmphasis |
+		parser.FencedCode |
+		parser.Autolink |
+		parser.Strikethrough |
+		parser.HardLineBreak
+	doc := parser.NewWithExtensions(extensions).Parse([]byte(md))
+
+	htmlFlags := html.Safelink | html.SkipHTML | html.SkipImages
+	renderer := html.NewRenderer(html.RendererOptions{Flags: htmlFlags})
+	return SanitizeCommentHTML(string(markdown.Render(doc, renderer)))
+}
+
+// SanitizeCommentHTML 按白名单过滤 HTML：未列出的标签被去掉（script 等连同内容），
+// 属性只保留白名单中的；链接只允许 http / https / mailto，并统一加上
+// rel="nofollow noopener noreferrer ugc" 与 target="_blank"。标签保证成对闭合。
+func SanitizeCommentHTML(in string) string {
+	var (
+		b    strings.Builder
+		open []string // 已输出、尚未闭合的白名单标签
+		skip int      // >0 时处于需整体丢弃的标签内部
+	)
+	closeTo := func(name string) {
+		for i := len(open) - 1; i >= 0; i-- {
+			if open[i] != name {
+				continue
+			}
+			for j := len(open) - 1; j >= i; j-- {
+				b.WriteString("</" + open[j] + ">")
+			}
+			open = open[:i]
+			return
+		}
+	}
+
+	tz := xhtml.NewTokenizer(strings.NewReader(in))
+	for {
+		tt := tz.Next()
+		if tt == xhtml.ErrorToken {
+			break
+		}
+		tok := tz.Token()
+		name := tok.Data
+
+		switch tt {
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			if dropWithContent[name] {
+				if tt == xhtml.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			allowed, ok := commentTags[name]
+			if skip > 0 || !ok {
+				continue
+			}
+			b.WriteString(renderStartTag(name, tok.Attr, allowed))
+			switch {
+			case name == "br":
+			case tt == xhtml.StartTagToken:
+				open = append(open, name)
+			default:
+				b.WriteString("</" + name + ">")
+			}
+		case xhtml.EndTagToken:
+			if dropWithContent[name] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if _, ok := commentTags[name]; ok && skip == 0 {
+				closeTo(name)
+			}
+		case xhtml.TextToken:
+			if skip == 0 {
+				b.WriteString(xhtml.EscapeString(tok.Data))
+			}
+		}
+	}
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i] + ">")
+	}
+	return b.String()
+}
+
+func renderStartTag(name string, attrs []xhtml.Attribute, allowed []string) string {
+	var b strings.Builder
+	b.WriteString("<" + name)
+	for _, a := range attrs {
+		if !slices.Contains(allowed, a.Key) || (a.Key == "href" && !isSafeLink(a.Val)) {
+			continue
+		}
+		b.WriteString(" " + a.Key + `="` + xhtml.EscapeString(a.Val) + `"`)
+	}
+	if name == "a" {
+		b.WriteString(` rel="nofollow noopener noreferrer ugc" target="_blank"`)
+	}
+	b.WriteString(">")
+	return b.String()
+}
+
+func isSafeLink(raw string) bool {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return false
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https":
+		return u.Host != ""
+	case "mailto":
+		return u.Opaque != ""
+	default:
+		return false
+	}
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCommentToHTML(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    []string
+		notWant []string
+	}{
+		{
+			name: "renders emphasis and code",
+			in:   "**bold** and `code`",
+			want: []string{"<strong>bold</strong>", "<code>code</code>"},
+		},
+		{
+			name:    "drops raw html tags",
+			in:      "<script>alert(1)</script><b onclick=\"x()\">hi</b>",
+			notWant: []string{"<script", "onclick", "<b"},
+		},
+		{
+			name:    "does not render images or headings",
+			in:      "# title\n\n![x](https://example.com/x.png)",
+			notWant: []string{"<img", "<h1"},
+		},
+		{
+			name: "hardens links",
+			in:   "[site](https://example.com)",
+			want: []string{`href="https://example.com"`, `rel="nofollow noopener noreferrer ugc"`, `target="_blank"`},
+		},
+		{
+			name:    "strips javascript links",
+			in:      "[x](javascript:alert(1))",
+			notWant: []string{"javascript:"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			out := CommentToHTML(tc.in)
+			for _, w := range tc.want {
+				if !strings.Contains(out, w) {
+					t.Fatalf("expected %q in %q", w, out)
+				}
+			}
+			for _, w := range tc.notWant {
+				if strings.Contains(out, w) {
+					t.Fatalf("unexpected %q in %q", w, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSanitizeCommentHTML(t *testing.T) {
+	cases := map[string]string{
+		`<p>a<style>p{}</style>b</p>`:                 `<p>ab</p>`,
+		`<p><a href="https://x.test" class="c">x</a>`: `<p><a href="https://x.test" rel="nofollow noopener noreferrer ugc" target="_blank">x</a></p>`,
+		`<em>unclosed <strong>nested</em>`:            `<em>unclosed <strong>nested</strong></em>`,
+		`<div><p>1 &lt; 2</p></div>`:                  `<p>1 &lt; 2</p>`,
+		`<p>a<br/>b</p>`:                              `<p>a<br>b</p>`,
+	}
+	for in, want := range cases {
+		if got := SanitizeCommentHTML(in); got != want {
+			t.Errorf("SanitizeCommentHTML(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
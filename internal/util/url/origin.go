@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package url
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SiteBase 规范化系统设置中的站点地址（ServerURL）：去掉首尾空白与末尾斜杠；
+// 未配置或不是带主机名的 http(s) 绝对地址时返回空串。
+func SiteBase(serverURL string) string {
+	base := strings.TrimRight(strings.TrimSpace(serverURL), "/")
+	parsed, err := url.Parse(base)
+	if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+		return ""
+	}
+	return base
+}
+
+// Absolute 把站内路径（以 / 开头、且不是 // 开头的协议相对地址）补成 base 下的地址，
+// 其余地址原样返回。base 为空时同样原样返回。
+func Absolute(base, raw string) string {
+	if base != "" && strings.HasPrefix(raw, "/") && !strings.HasPrefix(raw, "//") {
+		return base + raw
+	}
+	return raw
+}
+
+// RequestOrigin 返回请求的来源（scheme://host），只用于在未配置站点地址时补全站内路径：
+// 对外地址应先由 SiteBase 取配置的站点地址，Host 与 X-Forwarded-Proto 可被客户端伪造。
+func RequestOrigin(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https" {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host
+}
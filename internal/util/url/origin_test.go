@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package url
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSiteBase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: " https://example.com/ ", want: "https://example.com"},
+		{in: "http://example.com/blog", want: "http://example.com/blog"},
+		{in: "example.com", want: ""},
+		{in: "ftp://example.com", want: ""},
+	}
+	for _, tt := range tests {
+		if got := SiteBase(tt.in); got != tt.want {
+			t.Fatalf("SiteBase(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestAbsolute(t *testing.T) {
+	tests := []struct {
+		base, raw, want string
+	}{
+		{base: "https://example.com", raw: "/echo/1", want: "https://example.com/echo/1"},
+		{base: "https://example.com", raw: "//cdn.example.com/a.png", want: "//cdn.example.com/a.png"},
+		{base: "https://example.com", raw: "https://other.com/a.png", want: "https://other.com/a.png"},
+		{base: "", raw: "/echo/1", want: "/echo/1"},
+	}
+	for _, tt := range tests {
+		if got := Absolute(tt.base, tt.raw); got != tt.want {
+			t.Fatalf("Absolute(%q, %q) = %q, want %q", tt.base, tt.raw, got, tt.want)
+		}
+	}
+}
+
+func TestRequestOrigin(t *testing.T) {
+	r := httptest.NewRequest("GET", "/echo/1", nil)
+	r.Host = "example.com"
+	if got := RequestOrigin(r); got != "http://example.com" {
+		t.Fatalf("RequestOrigin = %q", got)
+	}
+	r.Header.Set("X-Forwarded-Proto", "https")
+	if got := RequestOrigin(r); got != "https://example.com" {
+		t.Fatalf("RequestOrigin behind proxy = %q", got)
+	}
+}
@@ -27,6 +27,11 @@ func MustNewV7() string {
 	return id
 }
 
+// NameBased returns a deterministic UUIDv5 for name, so the same name always maps to the same ID.
+func NameBased(name string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte(name)).String()
+}
+
 // IsValid reports whether s is a valid UUID string.
 func IsValid(s string) bool {
 	_, err := uuid.Parse(s)
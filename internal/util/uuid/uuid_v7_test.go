@@ -68,3 +68,16 @@ func TestMustNewV7(t *testing.T) {
 		t.Fatalf("MustNewV7() returned identical ids: %q", id)
 	}
 }
+
+func TestNameBased(t *testing.T) {
+	a := NameBased("ech0-import:twitter/1")
+	if a != NameBased("ech0-import:twitter/1") {
+		t.Fatalf("NameBased is not deterministic")
+	}
+	if a == NameBased("ech0-import:twitter/2") {
+		t.Fatalf("NameBased collided for different names")
+	}
+	if !IsValid(a) {
+		t.Fatalf("NameBased returned invalid uuid %q", a)
+	}
+}
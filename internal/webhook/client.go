@@ -8,7 +8,6 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -20,27 +19,24 @@ import (
 	"github.com/lin-snow/ech0/internal/util/egress"
 )
 
-func buildRequest(wh *webhookModel.Webhook, obs event.WebhookObservation) (*http.Request, error) {
-	eventID := fmt.Sprintf("%d", time.Now().UTC().UnixNano())
+// maxLoggedResponse 是投递记录里保留的响应体上限。接收端偶尔会回一整页 HTML 错误页，全存没有意义。
+const maxLoggedResponse = 4 << 10
+
+// newEventID 生成一次投递的事件 ID。同一次投递的各次重试共用它，接收端才能据此去重。
+func newEventID() string {
+	return strconv.FormatInt(time.Now().UTC().UnixNano(), 10)
+}
+
+// buildRequest 用已渲染好的请求体构造一次投递请求。签名覆盖的是实际发送的字节。
+func buildRequest(wh *webhookModel.Webhook, topic, eventID string, body []byte) (*http.Request, error) {
 	timestamp := strconv.FormatInt(time.Now().UTC().Unix(), 10)
 	headers := make(http.Header)
 	headers.Set("Content-Type", "application/json")
-	headers.Set("X-Ech0-Event", obs.Topic)
+	headers.Set("X-Ech0-Event", topic)
 	headers.Set("User-Agent", "Ech0-Webhook-Client")
 	headers.Set("X-Ech0-Event-ID", eventID)
 	headers.Set("X-Ech0-Timestamp", timestamp)
 
-	body, err := json.Marshal(map[string]any{
-		"topic":       obs.Topic,
-		"event_name":  obs.EventName,
-		"payload_raw": obs.Payload,
-		"metadata":    obs.Metadata,
-		"occurred_at": obs.OccurredAt,
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	if wh.Secret != "" {
 		signature := buildWebhookSignature(wh.Secret, body)
 		headers.Set("X-Ech0-Signature", "sha256="+signature)
@@ -59,29 +55,100 @@ func buildRequest(wh *webhookModel.Webhook, obs event.WebhookObservation) (*http
 	return req, nil
 }
 
+// sendWithRetry 投递一次观察并即时重试，返回每次尝试的记录（按尝试顺序）。只用于同步等结果的
+// 场景（连通性测试、重新投递）；正式投递走 outbox，由 worker 按退避排期重试，见 sendOnce。
+// 请求体只渲染一次；渲染失败时不发请求，但仍留下一条带错误的记录，投递日志里才看得出失败原因。
 func sendWithRetry(
 	client *http.Client,
 	wh *webhookModel.Webhook,
 	obs event.WebhookObservation,
 	maxRetries int,
 	initialBackoff time.Duration,
-) error {
-	return egress.Retry(maxRetries, initialBackoff, func() error {
-		req, err := buildRequest(wh, obs)
-		if err != nil {
-			return err
-		}
-		resp, err := client.Do(req)
-		if err != nil {
-			return err
-		}
-		defer func() { _ = resp.Body.Close() }()
-
-		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-			return nil
-		}
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+) ([]webhookModel.WebhookDelivery, error) {
+	eventID := newEventID()
+	body, err := renderBody(wh, obs)
+	if err != nil {
+		record := newRecord(wh, obs.Topic, eventID, 1, nil)
+		record.Error = err.Error()
+		return []webhookModel.WebhookDelivery{record}, err
+	}
+
+	var attempts []webhookModel.WebhookDelivery
+	err = egress.Retry(maxRetries, initialBackoff, func() error {
+		record, err := post(client, wh, obs.Topic, eventID, len(attempts)+1, body)
+		attempts = append(attempts, record)
+		return err
 	})
+	return attempts, err
+}
+
+// sendOnce 只发一次，不做即时重试。eventID 与 attempt 由 outbox 条目给出，同一条目的各次尝试
+// 共用同一个事件 ID。
+func sendOnce(
+	client *http.Client,
+	wh *webhookModel.Webhook,
+	obs event.WebhookObservation,
+	eventID string,
+	attempt int,
+) (webhookModel.WebhookDelivery, error) {
+	body, err := renderBody(wh, obs)
+	if err != nil {
+		record := newRecord(wh, obs.Topic, eventID, attempt, nil)
+		record.Error = err.Error()
+		return record, err
+	}
+	return post(client, wh, obs.Topic, eventID, attempt, body)
+}
+
+func newRecord(wh *webhookModel.Webhook, topic, eventID string, attempt int, body []byte) webhookModel.WebhookDelivery {
+	return webhookModel.WebhookDelivery{
+		WebhookID:   wh.ID,
+		EventID:     eventID,
+		Topic:       topic,
+		Attempt:     attempt,
+		RequestBody: string(body),
+	}
+}
+
+// post 用渲染好的请求体发出一次请求，返回这次尝试的记录。
+func post(
+	client *http.Client,
+	wh *webhookModel.Webhook,
+	topic, eventID string,
+	attempt int,
+	body []byte,
+) (webhookModel.WebhookDelivery, error) {
+	record := newRecord(wh, topic, eventID, attempt, body)
+	req, err := buildRequest(wh, topic, eventID, body)
+	if err != nil {
+		record.Error = err.Error()
+		return record, err
+	}
+	err = doAttempt(client, req, &record)
+	return record, err
+}
+
+// doAttempt 发出一次请求，把状态码、响应体、耗时与错误写进 record。非 2xx 视为失败。
+func doAttempt(client *http.Client, req *http.Request, record *webhookModel.WebhookDelivery) error {
+	start := time.Now()
+	resp, err := client.Do(req)
+	record.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		record.Error = err.Error()
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, maxLoggedResponse))
+	record.StatusCode = resp.StatusCode
+	record.ResponseBody = string(respBody)
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		record.Success = true
+		return nil
+	}
+	err = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	record.Error = err.Error()
+	return err
 }
 
 func buildWebhookSignature(secret string, payload []byte) string {
@@ -6,6 +6,7 @@ package webhook
 import (
 	"encoding/json"
 	"io"
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -75,6 +76,14 @@ func newObs(t *testing.T) event.WebhookObservation {
 	}
 }
 
+// buildObsRequest 按 webhook 的模板渲染 obs 后构造请求，与 sendWithRetry 的单次尝试一致。
+func buildObsRequest(t *testing.T, wh *webhookModel.Webhook, obs event.WebhookObservation) (*http.Request, error) {
+	t.Helper()
+	body, err := renderBody(wh, obs)
+	require.NoError(t, err)
+	return buildRequest(wh, obs.Topic, newEventID(), body)
+}
+
 // readBody 通过 GetBody 取一份新鲜的 body 拷贝，避免消费 req.Body。
 func readBody(t *testing.T, getBody func() (io.ReadCloser, error)) []byte {
 	t.Helper()
@@ -97,7 +106,7 @@ func TestBuildRequest_HeaderContract(t *testing.T) {
 	}
 	obs := newObs(t)
 
-	req, err := buildRequest(wh, obs)
+	req, err := buildObsRequest(t, wh, obs)
 	require.NoError(t, err)
 	require.NotNil(t, req)
 
@@ -127,7 +136,7 @@ func TestBuildRequest_SignatureHeader(t *testing.T) {
 
 	t.Run("with secret sets sha256 signature over body", func(t *testing.T) {
 		wh := &webhookModel.Webhook{URL: "https://example.com/hook", Secret: "abc123"}
-		req, err := buildRequest(wh, obs)
+		req, err := buildObsRequest(t, wh, obs)
 		require.NoError(t, err)
 
 		sig := req.Header.Get("X-Ech0-Signature")
@@ -143,7 +152,7 @@ func TestBuildRequest_SignatureHeader(t *testing.T) {
 
 	t.Run("without secret omits signature header", func(t *testing.T) {
 		wh := &webhookModel.Webhook{URL: "https://example.com/hook", Secret: ""}
-		req, err := buildRequest(wh, obs)
+		req, err := buildObsRequest(t, wh, obs)
 		require.NoError(t, err)
 		assert.Empty(t, req.Header.Get("X-Ech0-Signature"), "无密钥不应带签名头")
 	})
@@ -154,7 +163,7 @@ func TestBuildRequest_BodyShape(t *testing.T) {
 	wh := &webhookModel.Webhook{URL: "https://example.com/hook"}
 	obs := newObs(t)
 
-	req, err := buildRequest(wh, obs)
+	req, err := buildObsRequest(t, wh, obs)
 	require.NoError(t, err)
 
 	body := readBody(t, req.GetBody)
@@ -182,6 +191,6 @@ func TestBuildRequest_BodyShape(t *testing.T) {
 // TestBuildRequest_InvalidURL 校验非法 URL 时返回错误。
 func TestBuildRequest_InvalidURL(t *testing.T) {
 	wh := &webhookModel.Webhook{URL: "://bad-url"}
-	_, err := buildRequest(wh, newObs(t))
+	_, err := buildObsRequest(t, wh, newObs(t))
 	assert.Error(t, err, "非法 URL 应导致 http.NewRequest 报错")
 }
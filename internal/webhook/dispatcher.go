@@ -5,18 +5,57 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
+	"sync"
 	"time"
 
 	"github.com/lin-snow/ech0/internal/config"
 	"github.com/lin-snow/ech0/internal/event"
+	"github.com/lin-snow/ech0/internal/metrics"
 	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	"github.com/lin-snow/ech0/internal/transaction"
 	asyncUtil "github.com/lin-snow/ech0/internal/util/async"
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
 
+// DeliveryRetention 是每个 webhook 保留的投递记录条数（每次尝试一条），超出的旧记录在写入后裁掉。
+const DeliveryRetention = 200
+
+// DeliveryLog 是投递记录的落库端口。Dispatcher 与设置页的测试 / 重新投递共用它。
+type DeliveryLog interface {
+	CreateWebhookDeliveries(ctx context.Context, deliveries []webhookModel.WebhookDelivery) error
+	PruneWebhookDeliveries(ctx context.Context, webhookID string, keep int) error
+}
+
+// Outbox 的投递节奏。worker 平时按 outboxPollInterval 轮询，有新条目入队时被提前唤醒。
+const (
+	outboxPollInterval = 2 * time.Second
+	outboxBatchSize    = 32
+	// outboxLease 是条目被取出后的租期：进程在投递途中崩溃时，租期过后条目会被重新取出。
+	outboxLease = 2 * time.Minute
+	// OutboxMaxAttempts 是条目转为死信前的最多尝试次数。按 outboxBackoff 的退避，从第一次失败
+	// 到进入死信大约一小时。
+	OutboxMaxAttempts = 8
+	outboxBaseBackoff = 30 * time.Second
+	outboxMaxBackoff  = time.Hour
+)
+
+// OutboxStore 是 outbox 的落库端口。入队走调用方的 ctx，在事务里调用时随业务写入一起提交。
+type OutboxStore interface {
+	EnqueueWebhookOutbox(ctx context.Context, entries []webhookModel.WebhookOutbox) error
+	// ClaimDueWebhookOutbox 取出到期的 pending 条目（只取启用中的 webhook 的），并把它们的
+	// 下次时间推到 now+lease，避免被重复取出。
+	ClaimDueWebhookOutbox(ctx context.Context, now int64, lease time.Duration, limit int) ([]webhookModel.WebhookOutbox, error)
+	UpdateWebhookOutbox(ctx context.Context, entry *webhookModel.WebhookOutbox) error
+	DeleteWebhookOutbox(ctx context.Context, id string) error
+}
+
 type WebhookStore interface {
+	DeliveryLog
+	OutboxStore
 	ListActiveWebhooks(ctx context.Context) ([]webhookModel.Webhook, error)
+	GetWebhookByID(ctx context.Context, id string) (*webhookModel.Webhook, error)
 	UpdateWebhookDeliveryStatus(
 		ctx context.Context,
 		id string,
@@ -25,54 +64,191 @@ type WebhookStore interface {
 	) error
 }
 
+// Dispatcher 把事件观察写进 outbox，再由后台 worker 取出投递。进程重启或崩溃不会丢事件：
+// 未投递的条目留在库里，下次启动后继续。
 type Dispatcher struct {
 	sender *Sender
 	repo   WebhookStore
 	pool   *asyncUtil.WorkerPool
+
+	wake      chan struct{}
+	stop      chan struct{}
+	done      chan struct{}
+	startOnce sync.Once
+	stopOnce  sync.Once
 }
 
 func NewDispatcher(repo WebhookStore) *Dispatcher {
+	pool := asyncUtil.NewWorkerPool(
+		config.Config().Event.WebhookPoolWorkers,
+		config.Config().Event.WebhookPoolQueue,
+	)
+	metrics.TrackQueue("webhook", func() (int, int) { return pool.QueueLen(), pool.QueueCap() })
 	return &Dispatcher{
 		repo:   repo,
 		sender: NewSender(),
-		pool: asyncUtil.NewWorkerPool(
-			config.Config().Event.WebhookPoolWorkers,
-			config.Config().Event.WebhookPoolQueue,
-		),
+		pool:   pool,
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
 	}
 }
 
+// HandleObservation 为每个订阅了该 topic 的启用中 webhook 写一条 outbox 条目。它是事务内订阅，
+// ctx 带着业务事务时条目随业务一起提交；提交后再唤醒 worker。
 func (wd *Dispatcher) HandleObservation(ctx context.Context, obs event.WebhookObservation) error {
 	webhooks, err := wd.repo.ListActiveWebhooks(ctx)
 	if err != nil {
 		return err
 	}
+	raw, err := json.Marshal(obs)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC().Unix()
+	var entries []webhookModel.WebhookOutbox
 	for _, wh := range webhooks {
-		wh := wh
+		if !wh.Subscribes(obs.Topic) {
+			continue
+		}
+		entries = append(entries, webhookModel.WebhookOutbox{
+			WebhookID:     wh.ID,
+			EventID:       newEventID(),
+			Topic:         obs.Topic,
+			Status:        webhookModel.OutboxStatusPending,
+			NextAttemptAt: now,
+			Observation:   raw,
+		})
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+	if err := wd.repo.EnqueueWebhookOutbox(ctx, entries); err != nil {
+		return err
+	}
+	transaction.AfterCommit(ctx, wd.notify)
+	return nil
+}
+
+// Start 启动 outbox worker，重复调用无副作用。
+func (wd *Dispatcher) Start() {
+	wd.startOnce.Do(func() {
+		go wd.run()
+	})
+}
+
+func (wd *Dispatcher) run() {
+	defer close(wd.done)
+	ctx := context.Background()
+	for {
+		// 取满一批说明可能还有积压，不等下一轮直接接着取。
+		for wd.drain(ctx) == outboxBatchSize {
+			select {
+			case <-wd.stop:
+				return
+			default:
+			}
+		}
+		select {
+		case <-wd.stop:
+			return
+		case <-wd.wake:
+		case <-time.After(outboxPollInterval):
+		}
+	}
+}
+
+// drain 取出一批到期条目并发投递，等这一批全部结束后返回取出的条数。
+func (wd *Dispatcher) drain(ctx context.Context) int {
+	entries, err := wd.repo.ClaimDueWebhookOutbox(ctx, time.Now().UTC().Unix(), outboxLease, outboxBatchSize)
+	if err != nil {
+		logUtil.GetLogger().Warn("claim webhook outbox failed", logUtil.Err(err))
+		return 0
+	}
+	for _, entry := range entries {
+		entry := entry
 		wd.pool.Submit(func() error {
-			wd.Dispatch(ctx, &wh, obs)
+			wd.Dispatch(ctx, &entry)
 			return nil
 		})
 	}
-
-	return nil
+	wd.pool.Wait()
+	return len(entries)
 }
 
-func (wd *Dispatcher) Dispatch(ctx context.Context, wh *webhookModel.Webhook, obs event.WebhookObservation) {
+// Dispatch 对一条 outbox 条目做一次投递尝试：成功则删除条目；失败则按退避重排，次数用尽转为死信。
+func (wd *Dispatcher) Dispatch(ctx context.Context, entry *webhookModel.WebhookOutbox) {
+	wh, err := wd.repo.GetWebhookByID(ctx, entry.WebhookID)
+	if err != nil {
+		// webhook 删除时会连同条目一起删掉，这里多半是临时的读库失败，留给租期到后重试。
+		logUtil.GetLogger().Warn("load webhook for outbox entry failed",
+			slog.String("entry_id", entry.ID), slog.String("webhook_id", entry.WebhookID), logUtil.Err(err))
+		return
+	}
+
 	triggerAt := time.Now().UTC().Unix()
-	if err := wd.sender.Deliver(wh, obs); err != nil {
-		wd.updateWebhookStatus(ctx, wh.ID, "failed", triggerAt)
-		logUtil.GetLogger().Error("Webhook Handle Failed", slog.String("name", wh.Name), slog.String("url", wh.URL), logUtil.Err(err))
+	record, err := wd.sender.Deliver(wh, entry)
+	RecordDeliveries(ctx, wd.repo, wh.ID, []webhookModel.WebhookDelivery{record})
+	if err == nil {
+		metrics.IncWebhookDelivery(metrics.WebhookSuccess)
+		if err := wd.repo.DeleteWebhookOutbox(ctx, entry.ID); err != nil {
+			logUtil.GetLogger().Warn("delete delivered outbox entry failed",
+				slog.String("entry_id", entry.ID), logUtil.Err(err))
+		}
+		wd.updateWebhookStatus(ctx, wh.ID, "success", triggerAt)
 		return
 	}
-	wd.updateWebhookStatus(ctx, wh.ID, "success", triggerAt)
+
+	entry.Attempts++
+	entry.LastError = err.Error()
+	if entry.Attempts >= OutboxMaxAttempts {
+		entry.Status = webhookModel.OutboxStatusDead
+		metrics.IncWebhookDelivery(metrics.WebhookDead)
+		logUtil.GetLogger().Error("Webhook Delivery Dead-Lettered",
+			slog.String("name", wh.Name), slog.String("url", wh.URL),
+			slog.String("topic", entry.Topic), slog.Int("attempts", entry.Attempts), logUtil.Err(err))
+	} else {
+		entry.NextAttemptAt = time.Now().UTC().Add(outboxBackoff(entry.Attempts)).Unix()
+		metrics.IncWebhookDelivery(metrics.WebhookRetry)
+		logUtil.GetLogger().Warn("Webhook Delivery Failed",
+			slog.String("name", wh.Name), slog.String("url", wh.URL),
+			slog.String("topic", entry.Topic), slog.Int("attempts", entry.Attempts), logUtil.Err(err))
+	}
+	if err := wd.repo.UpdateWebhookOutbox(ctx, entry); err != nil {
+		logUtil.GetLogger().Warn("reschedule outbox entry failed",
+			slog.String("entry_id", entry.ID), logUtil.Err(err))
+	}
+	wd.updateWebhookStatus(ctx, wh.ID, "failed", triggerAt)
+}
+
+// outboxBackoff 返回第 attempts 次失败后的等待时间：30s、1m、2m……封顶 outboxMaxBackoff。
+func outboxBackoff(attempts int) time.Duration {
+	d := outboxBaseBackoff
+	for i := 1; i < attempts && d < outboxMaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, outboxMaxBackoff)
+}
+
+// notify 唤醒 worker；已有未处理的唤醒时直接丢弃。
+func (wd *Dispatcher) notify() {
+	select {
+	case wd.wake <- struct{}{}:
+	default:
+	}
 }
 
 func (wd *Dispatcher) Wait() {
 	wd.pool.Wait()
 }
 
+// Stop 停掉 worker 并等在途投递结束。未投递的条目留在库里，下次启动后继续。
 func (wd *Dispatcher) Stop() {
+	wd.stopOnce.Do(func() {
+		close(wd.stop)
+		wd.startOnce.Do(func() { close(wd.done) })
+		<-wd.done
+	})
 	wd.pool.Stop()
 }
 
@@ -94,3 +270,24 @@ func (wd *Dispatcher) updateWebhookStatus(
 		)
 	}
 }
+
+// RecordDeliveries 写入一次投递的各次尝试记录并裁剪旧记录。记录失败只打日志，不影响投递结果。
+func RecordDeliveries(
+	ctx context.Context,
+	store DeliveryLog,
+	webhookID string,
+	attempts []webhookModel.WebhookDelivery,
+) {
+	if webhookID == "" || len(attempts) == 0 {
+		return
+	}
+	if err := store.CreateWebhookDeliveries(ctx, attempts); err != nil {
+		logUtil.GetLogger().Warn("record webhook deliveries failed",
+			slog.String("webhook_id", webhookID), logUtil.Err(err))
+		return
+	}
+	if err := store.PruneWebhookDeliveries(ctx, webhookID, DeliveryRetention); err != nil {
+		logUtil.GetLogger().Warn("prune webhook deliveries failed",
+			slog.String("webhook_id", webhookID), logUtil.Err(err))
+	}
+}
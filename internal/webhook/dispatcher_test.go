@@ -0,0 +1,299 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStore 是 WebhookStore 的内存实现，记录 outbox 条目、投递记录与状态回写。
+type memoryStore struct {
+	mu         sync.Mutex
+	webhooks   []webhookModel.Webhook
+	outbox     []webhookModel.WebhookOutbox
+	deliveries []webhookModel.WebhookDelivery
+	statuses   map[string]string
+	pruned     map[string]int
+}
+
+func newMemoryStore(webhooks ...webhookModel.Webhook) *memoryStore {
+	return &memoryStore{webhooks: webhooks, statuses: map[string]string{}, pruned: map[string]int{}}
+}
+
+func (s *memoryStore) ListActiveWebhooks(context.Context) ([]webhookModel.Webhook, error) {
+	return s.webhooks, nil
+}
+
+func (s *memoryStore) GetWebhookByID(_ context.Context, id string) (*webhookModel.Webhook, error) {
+	for i := range s.webhooks {
+		if s.webhooks[i].ID == id {
+			wh := s.webhooks[i]
+			return &wh, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func (s *memoryStore) UpdateWebhookDeliveryStatus(_ context.Context, id, status string, _ int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[id] = status
+	return nil
+}
+
+func (s *memoryStore) CreateWebhookDeliveries(_ context.Context, deliveries []webhookModel.WebhookDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.deliveries = append(s.deliveries, deliveries...)
+	return nil
+}
+
+func (s *memoryStore) PruneWebhookDeliveries(_ context.Context, webhookID string, keep int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pruned[webhookID] = keep
+	return nil
+}
+
+func (s *memoryStore) EnqueueWebhookOutbox(_ context.Context, entries []webhookModel.WebhookOutbox) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range entries {
+		e.ID = fmt.Sprintf("ob-%d", len(s.outbox)+1)
+		s.outbox = append(s.outbox, e)
+	}
+	return nil
+}
+
+func (s *memoryStore) ClaimDueWebhookOutbox(
+	_ context.Context,
+	now int64,
+	lease time.Duration,
+	limit int,
+) ([]webhookModel.WebhookOutbox, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []webhookModel.WebhookOutbox
+	for i := range s.outbox {
+		e := &s.outbox[i]
+		if len(out) == limit || e.Status != webhookModel.OutboxStatusPending || e.NextAttemptAt > now {
+			continue
+		}
+		out = append(out, *e)
+		e.NextAttemptAt = now + int64(lease/time.Second)
+	}
+	return out, nil
+}
+
+func (s *memoryStore) UpdateWebhookOutbox(_ context.Context, entry *webhookModel.WebhookOutbox) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.outbox {
+		if s.outbox[i].ID == entry.ID {
+			s.outbox[i] = *entry
+		}
+	}
+	return nil
+}
+
+func (s *memoryStore) DeleteWebhookOutbox(_ context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox = slices.DeleteFunc(s.outbox, func(e webhookModel.WebhookOutbox) bool { return e.ID == id })
+	return nil
+}
+
+func newTestDispatcher(store *memoryStore, client *http.Client) *Dispatcher {
+	wd := NewDispatcher(store)
+	wd.sender = &Sender{client: client}
+	return wd
+}
+
+// TestDispatcher_EnqueuesOnlySubscribedWebhooks 校验：观察只为订阅了该 topic 的 webhook 入队，
+// 入队时不出网。
+func TestDispatcher_EnqueuesOnlySubscribedWebhooks(t *testing.T) {
+	rec := &requestRecorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec.record(req)
+	}))
+	defer srv.Close()
+
+	store := newMemoryStore(
+		webhookModel.Webhook{ID: "all", URL: srv.URL},
+		webhookModel.Webhook{ID: "echo-only", URL: srv.URL, Topics: []string{"echo.created", "echo.deleted"}},
+		webhookModel.Webhook{ID: "moderation", URL: srv.URL, Topics: []string{"comment.created"}},
+	)
+	wd := newTestDispatcher(store, srv.Client())
+	defer wd.Stop()
+
+	require.NoError(t, wd.HandleObservation(context.Background(), newObs(t)))
+
+	assert.Zero(t, rec.count(), "入队阶段不应出网")
+	require.Len(t, store.outbox, 2)
+	assert.Equal(t, "all", store.outbox[0].WebhookID)
+	assert.Equal(t, "echo-only", store.outbox[1].WebhookID)
+	for _, e := range store.outbox {
+		assert.Equal(t, webhookModel.OutboxStatusPending, e.Status)
+		assert.Equal(t, "echo.created", e.Topic)
+		assert.NotEmpty(t, e.EventID)
+		assert.NotEmpty(t, e.Observation)
+	}
+}
+
+// TestDispatcher_DrainDeliversAndRecords 校验：worker 取出条目投递，成功后删除条目、
+// 记下投递记录并触发裁剪。
+func TestDispatcher_DrainDeliversAndRecords(t *testing.T) {
+	rec := &requestRecorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec.record(req)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	store := newMemoryStore(
+		webhookModel.Webhook{ID: "a", URL: srv.URL},
+		webhookModel.Webhook{ID: "b", URL: srv.URL},
+	)
+	wd := newTestDispatcher(store, srv.Client())
+	defer wd.Stop()
+
+	require.NoError(t, wd.HandleObservation(context.Background(), newObs(t)))
+	eventIDs := map[string]string{}
+	for _, e := range store.outbox {
+		eventIDs[e.WebhookID] = e.EventID
+	}
+
+	assert.Equal(t, 2, wd.drain(context.Background()))
+	assert.Empty(t, store.outbox)
+	assert.Len(t, rec.snapshot(), 2)
+	assert.Equal(t, map[string]string{"a": "success", "b": "success"}, store.statuses)
+	assert.Equal(t, map[string]int{"a": DeliveryRetention, "b": DeliveryRetention}, store.pruned)
+
+	require.Len(t, store.deliveries, 2)
+	for _, d := range store.deliveries {
+		assert.True(t, d.Success)
+		assert.Equal(t, 1, d.Attempt)
+		assert.Equal(t, eventIDs[d.WebhookID], d.EventID)
+		assert.NotEmpty(t, d.Observation, "记录必须带原始观察，才能重新投递")
+	}
+}
+
+// TestDispatcher_FailureBacksOffThenDeadLetters 校验：失败后按退避重排、事件 ID 不变，
+// 次数用尽后转为死信且不再被取出。
+func TestDispatcher_FailureBacksOffThenDeadLetters(t *testing.T) {
+	rec := &requestRecorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec.record(req)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	store := newMemoryStore(webhookModel.Webhook{ID: "wh", URL: srv.URL})
+	wd := newTestDispatcher(store, srv.Client())
+	defer wd.Stop()
+
+	require.NoError(t, wd.HandleObservation(context.Background(), newObs(t)))
+	store.outbox[0].Attempts = OutboxMaxAttempts - 2
+
+	before := time.Now().UTC().Unix()
+	assert.Equal(t, 1, wd.drain(context.Background()))
+	entry := store.outbox[0]
+	assert.Equal(t, webhookModel.OutboxStatusPending, entry.Status)
+	assert.Equal(t, OutboxMaxAttempts-1, entry.Attempts)
+	assert.Contains(t, entry.LastError, "502")
+	assert.GreaterOrEqual(t, entry.NextAttemptAt, before+int64(outboxBackoff(entry.Attempts)/time.Second))
+	assert.Equal(t, "failed", store.statuses["wh"])
+
+	// 还没到重排时间，不会被取出。
+	assert.Zero(t, wd.drain(context.Background()))
+
+	store.outbox[0].NextAttemptAt = 0
+	assert.Equal(t, 1, wd.drain(context.Background()))
+	entry = store.outbox[0]
+	assert.Equal(t, webhookModel.OutboxStatusDead, entry.Status)
+	assert.Equal(t, OutboxMaxAttempts, entry.Attempts)
+
+	store.outbox[0].NextAttemptAt = 0
+	assert.Zero(t, wd.drain(context.Background()), "死信不再投递")
+
+	reqs := rec.snapshot()
+	require.Len(t, reqs, 2)
+	assert.Equal(t, reqs[0].headers.Get("X-Ech0-Event-ID"), reqs[1].headers.Get("X-Ech0-Event-ID"))
+	require.Len(t, store.deliveries, 2)
+	assert.Equal(t, []int{OutboxMaxAttempts - 1, OutboxMaxAttempts},
+		[]int{store.deliveries[0].Attempt, store.deliveries[1].Attempt})
+}
+
+// TestDispatcher_WorkerWakesOnEnqueue 校验：启动后的 worker 在新条目入队时被唤醒，不必等轮询。
+func TestDispatcher_WorkerWakesOnEnqueue(t *testing.T) {
+	delivered := make(chan struct{}, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		delivered <- struct{}{}
+	}))
+	defer srv.Close()
+
+	store := newMemoryStore(webhookModel.Webhook{ID: "wh", URL: srv.URL})
+	wd := newTestDispatcher(store, srv.Client())
+	wd.Start()
+	defer wd.Stop()
+
+	require.NoError(t, wd.HandleObservation(context.Background(), newObs(t)))
+	select {
+	case <-delivered:
+	case <-time.After(outboxPollInterval):
+		t.Fatal("worker was not woken by the enqueue")
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, outboxBackoff(1))
+	assert.Equal(t, time.Minute, outboxBackoff(2))
+	assert.Equal(t, 32*time.Minute, outboxBackoff(7))
+	assert.Equal(t, outboxMaxBackoff, outboxBackoff(20))
+}
+
+// TestSender_RedeliverUsesStoredObservation 校验：重新投递按记录里的原始观察重发，并指回原记录。
+func TestSender_RedeliverUsesStoredObservation(t *testing.T) {
+	rec := &requestRecorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		rec.record(req)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	sender := &Sender{client: srv.Client()}
+	wh := &webhookModel.Webhook{ID: "wh", URL: srv.URL}
+	raw, err := json.Marshal(echoObs(t, "hello"))
+	require.NoError(t, err)
+	first, err := sender.Deliver(wh, &webhookModel.WebhookOutbox{EventID: newEventID(), Observation: raw})
+	require.NoError(t, err)
+	first.ID = "d-1"
+
+	// 改成 Slack 预设后重新投递：请求体按当前配置重新渲染。
+	wh.Template = webhookModel.TemplateSlack
+	again, err := sender.Redeliver(wh, &first)
+	require.NoError(t, err)
+	require.Len(t, again, 1)
+	assert.Equal(t, "d-1", again[0].RedeliveryOf)
+	assert.NotEqual(t, first.EventID, again[0].EventID)
+
+	reqs := rec.snapshot()
+	require.Len(t, reqs, 2)
+	assert.Equal(t, "echo.created", reqs[1].headers.Get("X-Ech0-Event"))
+	assert.JSONEq(t, `{"text":"[Ech0] echo.created\nhello"}`, string(reqs[1].body))
+}
@@ -83,7 +83,7 @@ func TestSendWithRetry_Success2xx(t *testing.T) {
 			defer srv.Close()
 
 			wh := &webhookModel.Webhook{URL: srv.URL, Secret: "s"}
-			err := sendWithRetry(srv.Client(), wh, newObs(t), 3, fastBackoff)
+			_, err := sendWithRetry(srv.Client(), wh, newObs(t), 3, fastBackoff)
 
 			require.NoError(t, err, "2xx 必须视为成功")
 			assert.Equal(t, 1, rec.count(), "成功时不应重试")
@@ -112,7 +112,7 @@ func TestSendWithRetry_ExhaustsOn5xx(t *testing.T) {
 			defer srv.Close()
 
 			wh := &webhookModel.Webhook{URL: srv.URL, Secret: "s"}
-			err := sendWithRetry(srv.Client(), wh, newObs(t), tc.maxRetries, fastBackoff)
+			_, err := sendWithRetry(srv.Client(), wh, newObs(t), tc.maxRetries, fastBackoff)
 
 			require.Error(t, err, "持续 5xx 必须最终报错")
 			assert.Contains(t, err.Error(), "unexpected status code", "错误应描述非 2xx 状态码")
@@ -137,7 +137,7 @@ func TestSendWithRetry_RecoversAfterTransient(t *testing.T) {
 	defer srv.Close()
 
 	wh := &webhookModel.Webhook{URL: srv.URL, Secret: "s"}
-	err := sendWithRetry(srv.Client(), wh, newObs(t), 5, fastBackoff)
+	_, err := sendWithRetry(srv.Client(), wh, newObs(t), 5, fastBackoff)
 
 	require.NoError(t, err, "瞬时失败后恢复应最终成功")
 	assert.Equal(t, failBefore+1, rec.count(), "应在第一次成功后停止重试")
@@ -156,7 +156,7 @@ func TestSendWithRetry_SignatureEndToEnd(t *testing.T) {
 
 	obs := newObs(t)
 	wh := &webhookModel.Webhook{URL: srv.URL, Secret: secret}
-	err := sendWithRetry(srv.Client(), wh, obs, 3, fastBackoff)
+	_, err := sendWithRetry(srv.Client(), wh, obs, 3, fastBackoff)
 	require.NoError(t, err)
 
 	reqs := rec.snapshot()
@@ -187,7 +187,8 @@ func TestSendWithRetry_NoSecretNoSignature(t *testing.T) {
 	defer srv.Close()
 
 	wh := &webhookModel.Webhook{URL: srv.URL, Secret: ""}
-	require.NoError(t, sendWithRetry(srv.Client(), wh, newObs(t), 2, fastBackoff))
+	_, err := sendWithRetry(srv.Client(), wh, newObs(t), 2, fastBackoff)
+	require.NoError(t, err)
 
 	reqs := rec.snapshot()
 	require.Len(t, reqs, 1)
@@ -212,7 +213,8 @@ func TestSendWithRetry_BodyDeliveredEachAttempt(t *testing.T) {
 
 	obs := newObs(t)
 	wh := &webhookModel.Webhook{URL: srv.URL, Secret: secret}
-	require.NoError(t, sendWithRetry(srv.Client(), wh, obs, 3, fastBackoff))
+	_, err := sendWithRetry(srv.Client(), wh, obs, 3, fastBackoff)
+	require.NoError(t, err)
 
 	reqs := rec.snapshot()
 	require.Len(t, reqs, 2, "应发生一次重试，共两次请求")
@@ -226,6 +228,44 @@ func TestSendWithRetry_BodyDeliveredEachAttempt(t *testing.T) {
 	}
 	// 两次 body 必须完全一致（重试不应损坏/截断 body）。
 	assert.Equal(t, reqs[0].body, reqs[1].body, "重试发送的 body 必须与首次一致")
+	// 同一次投递的重试共用事件 ID，接收端才能去重。
+	assert.Equal(t, reqs[0].headers.Get("X-Ech0-Event-ID"), reqs[1].headers.Get("X-Ech0-Event-ID"))
+}
+
+// TestSendWithRetry_RecordsEachAttempt 校验：每次尝试各留一条记录，带序号、状态码、响应体与共享的事件 ID。
+func TestSendWithRetry_RecordsEachAttempt(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(strings.Repeat("x", maxLoggedResponse+10)))
+			return
+		}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	wh := &webhookModel.Webhook{ID: "wh-1", URL: srv.URL}
+	attempts, err := sendWithRetry(srv.Client(), wh, newObs(t), 3, fastBackoff)
+	require.NoError(t, err)
+	require.Len(t, attempts, 2)
+
+	first, second := attempts[0], attempts[1]
+	assert.Equal(t, 1, first.Attempt)
+	assert.False(t, first.Success)
+	assert.Equal(t, http.StatusBadGateway, first.StatusCode)
+	assert.Len(t, first.ResponseBody, maxLoggedResponse, "响应体应截断")
+	assert.Contains(t, first.Error, "502")
+
+	assert.Equal(t, 2, second.Attempt)
+	assert.True(t, second.Success)
+	assert.Equal(t, "ok", second.ResponseBody)
+	assert.Empty(t, second.Error)
+
+	assert.Equal(t, first.EventID, second.EventID)
+	assert.Equal(t, "wh-1", second.WebhookID)
+	assert.Equal(t, "echo.created", second.Topic)
+	assert.Equal(t, first.RequestBody, second.RequestBody)
 }
 
 // countingErrTransport 是一个始终返回连接错误的 RoundTripper，用于在不依赖真实网络的前提下
@@ -246,7 +286,7 @@ func TestSendWithRetry_TransportErrorRetried(t *testing.T) {
 	wh := &webhookModel.Webhook{URL: "https://example.com/hook", Secret: "s"}
 
 	const maxRetries = 4
-	err := sendWithRetry(client, wh, newObs(t), maxRetries, fastBackoff)
+	_, err := sendWithRetry(client, wh, newObs(t), maxRetries, fastBackoff)
 
 	require.Error(t, err, "传输错误应最终冒泡")
 	assert.Contains(t, err.Error(), "simulated connection failure")
@@ -259,8 +299,10 @@ func TestSendWithRetry_BuildRequestError(t *testing.T) {
 	client := &http.Client{Transport: countingErrTransport{calls: &calls}}
 	wh := &webhookModel.Webhook{URL: "://bad-url", Secret: "s"}
 
-	err := sendWithRetry(client, wh, newObs(t), 3, fastBackoff)
+	attempts, err := sendWithRetry(client, wh, newObs(t), 3, fastBackoff)
 
 	require.Error(t, err, "非法 URL 必须导致 buildRequest 报错")
 	assert.Zero(t, atomic.LoadInt32(&calls), "buildRequest 失败时不应触达 transport")
+	require.Len(t, attempts, 3, "每次失败的尝试仍应留下记录")
+	assert.NotEmpty(t, attempts[0].Error)
 }
@@ -4,6 +4,9 @@
 package webhook
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -15,15 +18,15 @@ import (
 const (
 	defaultWebhookTimeout = 5 * time.Second
 
-	deliverMaxRetries = 3
-	deliverBackoff    = 500 * time.Millisecond
-	testMaxRetries    = 2
-	testBackoff       = 300 * time.Millisecond
+	redeliverMaxRetries = 3
+	redeliverBackoff    = 500 * time.Millisecond
+	testMaxRetries      = 2
+	testBackoff         = 300 * time.Millisecond
 )
 
-// Sender 是 webhook 的唯一出网出口：持有出网 HTTP client，负责签名构造 + 重试发送。
-// 正式投递（Dispatcher）与连通性测试（设置页 TestWebhook）共用它，避免 client 构造、
-// 超时、重试参数在两处各写一份而漂移。
+// Sender 是 webhook 的唯一出网出口：持有出网 HTTP client，负责签名构造与发送。
+// 正式投递（Dispatcher 的 outbox worker）与连通性测试、重新投递（设置页）共用它，避免 client
+// 构造、超时、重试参数在两处各写一份而漂移。
 type Sender struct {
 	client *http.Client
 }
@@ -34,20 +37,65 @@ func NewSender() *Sender {
 	}
 }
 
-// Deliver 投递一次正式事件观察。即时重试仍失败则由调用方（Dispatcher）记录失败状态，不再补投。
-func (s *Sender) Deliver(wh *webhookModel.Webhook, obs event.WebhookObservation) error {
-	return sendWithRetry(s.client, wh, obs, deliverMaxRetries, deliverBackoff)
+// Deliver 对 outbox 条目做一次投递尝试（不即时重试），返回这次尝试的投递记录（尚未落库）。
+// 失败后的重排与死信由 Dispatcher 决定。
+func (s *Sender) Deliver(
+	wh *webhookModel.Webhook,
+	entry *webhookModel.WebhookOutbox,
+) (webhookModel.WebhookDelivery, error) {
+	var obs event.WebhookObservation
+	if err := json.Unmarshal(entry.Observation, &obs); err != nil {
+		return webhookModel.WebhookDelivery{}, fmt.Errorf("decode outbox observation: %w", err)
+	}
+	record, err := sendOnce(s.client, wh, obs, entry.EventID, entry.Attempts+1)
+	record.Observation = entry.Observation
+	return record, err
+}
+
+// Redeliver 用投递记录里保存的原始观察重新投递一次，按 webhook 的当前配置（URL、模板、密钥）
+// 重新渲染。新记录的 RedeliveryOf 指向 original。
+func (s *Sender) Redeliver(
+	wh *webhookModel.Webhook,
+	original *webhookModel.WebhookDelivery,
+) ([]webhookModel.WebhookDelivery, error) {
+	var obs event.WebhookObservation
+	if err := json.Unmarshal(original.Observation, &obs); err != nil {
+		return nil, fmt.Errorf("decode stored observation: %w", err)
+	}
+	attempts, err := s.send(wh, obs, redeliverMaxRetries, redeliverBackoff)
+	for i := range attempts {
+		attempts[i].RedeliveryOf = original.ID
+	}
+	return attempts, err
 }
 
-// SendTest 构造一次连通性测试观察并发送，供设置页 TestWebhook 复用。
-func (s *Sender) SendTest(wh *webhookModel.Webhook) error {
+// SendTest 构造一次连通性测试观察并发送，供设置页 TestWebhook 复用。测试事件不受 topic 订阅限制。
+func (s *Sender) SendTest(wh *webhookModel.Webhook) ([]webhookModel.WebhookDelivery, error) {
 	obs, err := event.NewWebhookObservation("webhook.test", map[string]any{
 		"message": "webhook connectivity test from ech0",
 		"webhook": wh.Name,
 		"time":    time.Now().UTC().Format(time.RFC3339),
 	}, map[string]string{"source": "setting.test"})
 	if err != nil {
-		return err
+		return nil, err
+	}
+	return s.send(wh, obs, testMaxRetries, testBackoff)
+}
+
+// send 发送并给每条尝试记录附上原始观察，使任意一条都能单独重新投递。
+func (s *Sender) send(
+	wh *webhookModel.Webhook,
+	obs event.WebhookObservation,
+	maxRetries int,
+	backoff time.Duration,
+) ([]webhookModel.WebhookDelivery, error) {
+	attempts, err := sendWithRetry(s.client, wh, obs, maxRetries, backoff)
+	raw, marshalErr := json.Marshal(obs)
+	if marshalErr != nil {
+		return attempts, errors.Join(err, marshalErr)
+	}
+	for i := range attempts {
+		attempts[i].Observation = raw
 	}
-	return sendWithRetry(s.client, wh, obs, testMaxRetries, testBackoff)
+	return attempts, err
 }
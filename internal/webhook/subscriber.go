@@ -5,6 +5,7 @@ package webhook
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/lin-snow/ech0/internal/event"
@@ -12,9 +13,28 @@ import (
 	logUtil "github.com/lin-snow/ech0/pkg/log"
 )
 
-// Registrations 让 Dispatcher 作为普通事件订阅者自注册：为每个可观测事件登记一条同步订阅，
-// 把强类型事件转为中立 WebhookObservation 后投递。并发 / 重试由 Dispatcher 的 worker pool 承担，
-// 故此处同步即可。新增可观测事件时，记得在此追加对应的 observe 行。
+// Topics 是可订阅的事件 topic，顺序与 Registrations 一致。webhook 的 topic 订阅只能从中选择。
+var Topics = []string{
+	event.UserCreated{}.EventName(),
+	event.UserUpdated{}.EventName(),
+	event.UserDeleted{}.EventName(),
+	event.EchoCreated{}.EventName(),
+	event.EchoUpdated{}.EventName(),
+	event.EchoDeleted{}.EventName(),
+	event.EchoRestored{}.EventName(),
+	event.CommentCreated{}.EventName(),
+	event.CommentStatusUpdated{}.EventName(),
+	event.CommentDeleted{}.EventName(),
+	event.CommentRestored{}.EventName(),
+	event.ResourceUploaded{}.EventName(),
+	event.SystemSnapshot{}.EventName(),
+	event.SystemExport{}.EventName(),
+	event.UpdateSnapshotSchedule{}.EventName(),
+}
+
+// Registrations 让 Dispatcher 作为事件订阅者自注册：为每个可观测事件登记一条事务内订阅，
+// 把强类型事件转为中立 WebhookObservation 后写进 outbox。出网投递由 Dispatcher 的 worker 承担，
+// 这里只落库。新增可观测事件时，记得在此追加对应的 observe 行，并同步 Topics。
 func (wd *Dispatcher) Registrations() []eventbus.Registration {
 	return []eventbus.Registration{
 		observe[event.UserCreated](wd.HandleObservation),
@@ -23,9 +43,11 @@ func (wd *Dispatcher) Registrations() []eventbus.Registration {
 		observe[event.EchoCreated](wd.HandleObservation),
 		observe[event.EchoUpdated](wd.HandleObservation),
 		observe[event.EchoDeleted](wd.HandleObservation),
+		observe[event.EchoRestored](wd.HandleObservation),
 		observe[event.CommentCreated](wd.HandleObservation),
 		observe[event.CommentStatusUpdated](wd.HandleObservation),
 		observe[event.CommentDeleted](wd.HandleObservation),
+		observe[event.CommentRestored](wd.HandleObservation),
 		observe[event.ResourceUploaded](wd.HandleObservation),
 		observe[event.SystemSnapshot](wd.HandleObservation),
 		observe[event.SystemExport](wd.HandleObservation),
@@ -33,12 +55,13 @@ func (wd *Dispatcher) Registrations() []eventbus.Registration {
 	}
 }
 
-// observe 构造单个事件类型的 webhook 观察订阅（同步）。它需要 busen 信封的 Meta（source 等元数据），
-// 故走 eventbus.OnWithMeta 而非 On —— On 只透传 Value。
+// observe 构造单个事件类型的 webhook 观察订阅。走 eventbus.InTx：事务里发布的事件在同一事务内
+// 入队，且 InTx 会带上 busen 信封的 Meta（source 等元数据）。入队失败时返回错误，
+// 经 eventbus.NotifyTx 交回调用方回滚业务写入，避免业务已提交而 outbox 缺了这条观察。
 func observe[T event.Named](
 	deliver func(context.Context, event.WebhookObservation) error,
 ) eventbus.Registration {
-	return eventbus.OnWithMeta(func(ctx context.Context, v T, meta map[string]string) error {
+	return eventbus.InTx(func(ctx context.Context, v T, meta map[string]string) error {
 		obs, err := event.NewWebhookObservation(v.EventName(), v, meta)
 		if err != nil {
 			logUtil.GetLogger().Warn("build webhook observation failed",
@@ -46,8 +69,7 @@ func observe[T event.Named](
 			return nil
 		}
 		if err := deliver(ctx, obs); err != nil {
-			logUtil.GetLogger().Warn("dispatch webhook observation failed",
-				slog.String("event", v.EventName()), logUtil.Err(err))
+			return fmt.Errorf("enqueue webhook observation %s: %w", v.EventName(), err)
 		}
 		return nil
 	})
@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webhook_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	eventbus "github.com/lin-snow/ech0/internal/event/bus"
+	commentModel "github.com/lin-snow/ech0/internal/model/comment"
+	echoModel "github.com/lin-snow/ech0/internal/model/echo"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	commentRepository "github.com/lin-snow/ech0/internal/repository/comment"
+	webhookRepository "github.com/lin-snow/ech0/internal/repository/webhook"
+	commentService "github.com/lin-snow/ech0/internal/service/comment"
+	"github.com/lin-snow/ech0/internal/test/helpers"
+	"github.com/lin-snow/ech0/internal/transaction"
+	"github.com/lin-snow/ech0/internal/webhook"
+	"github.com/lin-snow/ech0/pkg/busen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// newWebhookBus 登记一个启用的 webhook，返回挂好 webhook 分发器订阅的总线。
+func newWebhookBus(t *testing.T, db *gorm.DB) *busen.Bus {
+	t.Helper()
+	repo := webhookRepository.NewWebhookRepository(func() *gorm.DB { return db })
+	require.NoError(t, repo.CreateWebhook(context.Background(), &webhookModel.Webhook{
+		ID: "wh-1", Name: "hook", URL: "https://example.com/hook", IsActive: true,
+	}))
+
+	b := helpers.NewTestBus(t)
+	for _, reg := range webhook.NewDispatcher(repo).Registrations() {
+		unsub, err := reg(b)
+		require.NoError(t, err)
+		t.Cleanup(unsub)
+	}
+	return b
+}
+
+// TestObserve_OutboxFailureRollsBackBusinessWrite 校验：事务内 outbox 入队失败时，NotifyTx 把错误交回
+// 调用方，同一事务里的 Echo 写入随之回滚，不会出现 Echo 已落库而 webhook 永远收不到的情况。
+func TestObserve_OutboxFailureRollsBackBusinessWrite(t *testing.T) {
+	db := helpers.NewTestDB(t)
+	tx := transaction.NewGormTransactor(func() *gorm.DB { return db })
+	b := newWebhookBus(t, db)
+
+	post := func(id string) error {
+		return tx.Run(context.Background(), func(txCtx context.Context) error {
+			txDB, _ := transaction.TxFromContext(txCtx)
+			echo := helpers.NewEcho(func(e *echoModel.Echo) { e.ID = id })
+			if err := txDB.Create(&echo).Error; err != nil {
+				return err
+			}
+			return eventbus.NotifyTx(txCtx, b, event.EchoCreated{Echo: echo})
+		})
+	}
+	countEchos := func() int64 {
+		var n int64
+		require.NoError(t, db.Model(&echoModel.Echo{}).Count(&n).Error)
+		return n
+	}
+
+	require.NoError(t, post("echo-ok"))
+	assert.EqualValues(t, 1, countEchos())
+
+	require.NoError(t, db.Migrator().DropTable(&webhookModel.WebhookOutbox{}))
+	err := post("echo-lost")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), event.EchoCreated{}.EventName())
+	assert.EqualValues(t, 1, countEchos(), "echo write must roll back with the failed outbox insert")
+}
+
+// TestObserve_OutboxFailureRollsBackCommentWrite 校验评论写入同样与 webhook outbox 同进同退：
+// outbox 入队失败时撤回 Webmention 评论的操作报错，评论仍留在原处。
+func TestObserve_OutboxFailureRollsBackCommentWrite(t *testing.T) {
+	db := helpers.NewTestDB(t)
+	b := newWebhookBus(t, db)
+	repo := commentRepository.NewCommentRepository(func() *gorm.DB { return db })
+	svc := commentService.NewCommentService(
+		transaction.NewGormTransactor(func() *gorm.DB { return db }),
+		nil, repo, nil, nil,
+		func() *busen.Bus { return b },
+		nil,
+	)
+
+	seed := func(id, source string) {
+		require.NoError(t, repo.CreateComment(context.Background(), &commentModel.Comment{
+			ID: id, EchoID: "echo-1", Nickname: "Webmention", Content: source,
+			Status: commentModel.StatusPending, Source: commentModel.SourceWebmention,
+			RemoteID: source, Website: source,
+		}))
+	}
+	trashedAt := func(id string) int64 {
+		c, err := repo.GetCommentByID(context.Background(), id)
+		require.NoError(t, err)
+		return c.DeletedAt
+	}
+	countOutbox := func() int64 {
+		var n int64
+		require.NoError(t, db.Model(&webhookModel.WebhookOutbox{}).Count(&n).Error)
+		return n
+	}
+
+	seed("c-ok", "https://a.example/post")
+	require.NoError(t, svc.RetractWebmentionComment(context.Background(), "echo-1", "https://a.example/post"))
+	assert.NotZero(t, trashedAt("c-ok"))
+	assert.EqualValues(t, 1, countOutbox())
+
+	seed("c-kept", "https://b.example/post")
+	require.NoError(t, db.Migrator().DropTable(&webhookModel.WebhookOutbox{}))
+	err := svc.RetractWebmentionComment(context.Background(), "echo-1", "https://b.example/post")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), event.CommentDeleted{}.EventName())
+	assert.Zero(t, trashedAt("c-kept"), "comment trash must roll back with the failed outbox insert")
+}
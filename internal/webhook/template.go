@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/lin-snow/ech0/internal/event"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+)
+
+// summaryExcerpt 是聊天机器人预设里正文摘录的最大字符数。
+const summaryExcerpt = 200
+
+var (
+	ErrUnknownTemplate   = errors.New("unknown webhook template")
+	ErrEmptyTemplateBody = errors.New("custom webhook template body is empty")
+	ErrTemplateNotJSON   = errors.New("webhook template did not render valid JSON")
+)
+
+// TemplateData 是 custom 模板的渲染上下文。Payload 是事件载荷解码后的 JSON 值，
+// 可以用 {{ .Payload.Echo.content }} 这样的路径取字段。
+type TemplateData struct {
+	Topic      string
+	EventName  string
+	Payload    any
+	Metadata   map[string]string
+	OccurredAt int64
+	// Summary 是预设模板使用的一行摘要（topic 加正文摘录）。
+	Summary string
+}
+
+var templateFuncs = template.FuncMap{
+	// json 把任意值编码成 JSON 字面量，拼字符串字段时用它转义引号与换行。
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ValidateTemplate 校验 webhook 的模板配置：预设名必须已知，custom 模板必须非空且能解析。
+// 渲染结果是否为合法 JSON 取决于具体事件，只能在投递时检查，失败会写进投递记录。
+func ValidateTemplate(name, body string) error {
+	switch name {
+	case webhookModel.TemplateDefault, webhookModel.TemplateSlack,
+		webhookModel.TemplateDiscord, webhookModel.TemplateFeishu:
+		return nil
+	case webhookModel.TemplateCustom:
+		_, err := parseCustom(body)
+		return err
+	default:
+		return ErrUnknownTemplate
+	}
+}
+
+// renderBody 按 webhook 选择的模板渲染请求体。
+func renderBody(wh *webhookModel.Webhook, obs event.WebhookObservation) ([]byte, error) {
+	switch wh.Template {
+	case webhookModel.TemplateDefault:
+		return json.Marshal(map[string]any{
+			"topic":       obs.Topic,
+			"event_name":  obs.EventName,
+			"payload_raw": obs.Payload,
+			"metadata":    obs.Metadata,
+			"occurred_at": obs.OccurredAt,
+		})
+	case webhookModel.TemplateSlack:
+		return json.Marshal(map[string]any{"text": summarize(obs)})
+	case webhookModel.TemplateDiscord:
+		return json.Marshal(map[string]any{"content": summarize(obs)})
+	case webhookModel.TemplateFeishu:
+		return json.Marshal(map[string]any{
+			"msg_type": "text",
+			"content":  map[string]string{"text": summarize(obs)},
+		})
+	case webhookModel.TemplateCustom:
+		return renderCustom(wh.TemplateBody, obs)
+	default:
+		return nil, ErrUnknownTemplate
+	}
+}
+
+func parseCustom(body string) (*template.Template, error) {
+	if strings.TrimSpace(body) == "" {
+		return nil, ErrEmptyTemplateBody
+	}
+	tmpl, err := template.New("webhook").Funcs(templateFuncs).Parse(body)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook template: %w", err)
+	}
+	return tmpl, nil
+}
+
+func renderCustom(body string, obs event.WebhookObservation) ([]byte, error) {
+	tmpl, err := parseCustom(body)
+	if err != nil {
+		return nil, err
+	}
+	data := TemplateData{
+		Topic:      obs.Topic,
+		EventName:  obs.EventName,
+		Metadata:   obs.Metadata,
+		OccurredAt: obs.OccurredAt,
+		Summary:    summarize(obs),
+	}
+	if len(obs.Payload) > 0 {
+		if err := json.Unmarshal(obs.Payload, &data.Payload); err != nil {
+			return nil, err
+		}
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("render webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, ErrTemplateNotJSON
+	}
+	return buf.Bytes(), nil
+}
+
+// summarize 把事件压成一行给聊天机器人看：「[Ech0] topic」后面跟上最能说明事件的那段文字
+// （Echo / 评论正文、用户名或文件名），取不到就只有 topic。
+func summarize(obs event.WebhookObservation) string {
+	var payload struct {
+		Echo     *struct{ Content string }
+		Comment  *struct{ Nickname, Content string }
+		User     *struct{ Username string }
+		FileName string
+	}
+	_ = json.Unmarshal(obs.Payload, &payload)
+
+	var detail string
+	switch {
+	case payload.Echo != nil && payload.Echo.Content != "":
+		detail = payload.Echo.Content
+	case payload.Comment != nil:
+		detail = payload.Comment.Nickname + ": " + payload.Comment.Content
+	case payload.FileName != "":
+		detail = payload.FileName
+	case payload.User != nil && payload.User.Username != "":
+		detail = payload.User.Username
+	}
+
+	line := "[Ech0] " + obs.Topic
+	if detail = strings.TrimSpace(detail); detail == "" {
+		return line
+	}
+	if runes := []rune(detail); len(runes) > summaryExcerpt {
+		detail = string(runes[:summaryExcerpt]) + "…"
+	}
+	return line + "\n" + detail
+}
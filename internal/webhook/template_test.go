@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: AGPL-3.0-or-later
+// Copyright (C) 2025-2026 lin-snow
+
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/lin-snow/ech0/internal/event"
+	webhookModel "github.com/lin-snow/ech0/internal/model/webhook"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func echoObs(t *testing.T, content string) event.WebhookObservation {
+	t.Helper()
+	obs, err := event.NewWebhookObservation("echo.created", event.EchoCreated{}, nil)
+	require.NoError(t, err)
+	obs.Payload = json.RawMessage(`{"Echo":{"id":"e1","content":` + mustJSON(t, content) + `},"User":{"username":"admin"}}`)
+	return obs
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(b)
+}
+
+// TestRenderBody_Presets 校验三个聊天机器人预设的消息体形状。
+func TestRenderBody_Presets(t *testing.T) {
+	obs := echoObs(t, "hello")
+	want := "[Ech0] echo.created\nhello"
+
+	tests := []struct {
+		template string
+		decode   func(map[string]any) any
+	}{
+		{webhookModel.TemplateSlack, func(m map[string]any) any { return m["text"] }},
+		{webhookModel.TemplateDiscord, func(m map[string]any) any { return m["content"] }},
+		{webhookModel.TemplateFeishu, func(m map[string]any) any {
+			assert.Equal(t, "text", m["msg_type"])
+			return m["content"].(map[string]any)["text"]
+		}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.template, func(t *testing.T) {
+			body, err := renderBody(&webhookModel.Webhook{Template: tc.template}, obs)
+			require.NoError(t, err)
+			var decoded map[string]any
+			require.NoError(t, json.Unmarshal(body, &decoded))
+			assert.Equal(t, want, tc.decode(decoded))
+		})
+	}
+}
+
+// TestRenderBody_Custom 校验 custom 模板：字段可按路径取，json 函数负责转义，结果必须是合法 JSON。
+func TestRenderBody_Custom(t *testing.T) {
+	obs := echoObs(t, `say "hi"`+"\n")
+
+	t.Run("renders payload fields", func(t *testing.T) {
+		wh := &webhookModel.Webhook{
+			Template:     webhookModel.TemplateCustom,
+			TemplateBody: `{"event":{{ json .Topic }},"text":{{ json .Payload.Echo.content }},"by":{{ json .Payload.User.username }}}`,
+		}
+		body, err := renderBody(wh, obs)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"event":"echo.created","text":"say \"hi\"\n","by":"admin"}`, string(body))
+	})
+
+	t.Run("non-JSON output is rejected", func(t *testing.T) {
+		wh := &webhookModel.Webhook{Template: webhookModel.TemplateCustom, TemplateBody: `text={{ .Topic }}`}
+		_, err := renderBody(wh, obs)
+		assert.ErrorIs(t, err, ErrTemplateNotJSON)
+	})
+
+	t.Run("missing payload field renders null", func(t *testing.T) {
+		wh := &webhookModel.Webhook{Template: webhookModel.TemplateCustom, TemplateBody: `{"x":{{ json .Payload.Comment.content }}}`}
+		body, err := renderBody(wh, obs)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"x":null}`, string(body))
+	})
+}
+
+func TestValidateTemplate(t *testing.T) {
+	assert.NoError(t, ValidateTemplate("", ""))
+	assert.NoError(t, ValidateTemplate(webhookModel.TemplateFeishu, ""))
+	assert.NoError(t, ValidateTemplate(webhookModel.TemplateCustom, `{"t":{{ json .Summary }}}`))
+	assert.ErrorIs(t, ValidateTemplate("teams", ""), ErrUnknownTemplate)
+	assert.ErrorIs(t, ValidateTemplate(webhookModel.TemplateCustom, "  "), ErrEmptyTemplateBody)
+	assert.Error(t, ValidateTemplate(webhookModel.TemplateCustom, `{{ .Topic `))
+}
+
+// TestSummarize 校验摘要取材与截断。
+func TestSummarize(t *testing.T) {
+	long := strings.Repeat("字", summaryExcerpt+5)
+	got := summarize(echoObs(t, long))
+	assert.True(t, strings.HasSuffix(got, "…"))
+	assert.Equal(t, summaryExcerpt+1, len([]rune(strings.TrimPrefix(got, "[Ech0] echo.created\n"))))
+
+	comment := event.WebhookObservation{
+		Topic:   "comment.created",
+		Payload: json.RawMessage(`{"Comment":{"nickname":"bob","content":"nice"}}`),
+	}
+	assert.Equal(t, "[Ech0] comment.created\nbob: nice", summarize(comment))
+
+	bare := event.WebhookObservation{Topic: "system.export", Payload: json.RawMessage(`{"Info":"x"}`)}
+	assert.Equal(t, "[Ech0] system.export", summarize(bare))
+}
+
+// TestTopicsMatchRegistrations 防止新增可观测事件时漏改 Topics。
+func TestTopicsMatchRegistrations(t *testing.T) {
+	wd := &Dispatcher{}
+	assert.Len(t, Topics, len(wd.Registrations()))
+}
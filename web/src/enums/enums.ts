@@ -85,3 +85,9 @@ export enum AgentProtocol {
   OPENAI = 'openai',
   ANTHROPIC = 'anthropic',
 }
+
+// Embedding 向量化提供方 —— OPENAI 覆盖所有 OpenAI 兼容的 /v1/embeddings 服务；LOCAL 为内置离线实现
+export enum EmbeddingProvider {
+  OPENAI = 'openai',
+  LOCAL = 'local',
+}
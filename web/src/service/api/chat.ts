@@ -1,25 +1,79 @@
 // SPDX-License-Identifier: AGPL-3.0-or-later
 // Copyright (C) 2025-2026 lin-snow
 
-import { request } from '@/service/request'
+import { downloadFile, request } from '@/service/request'
 import { sseStream } from '@/service/request/sse'
 
-/** 获取当前用户的持久化 Chat 会话（重载页面恢复展示用） */
-export function getChatSession() {
-  return request<App.Api.Chat.ChatMessage[]>({
-    url: `/chat/session`,
+/** 分页获取当前用户的 Chat 会话（最近活跃的在前），search 匹配标题或消息正文 */
+export function fetchChatThreads(params: App.Api.Chat.ThreadListQuery) {
+  const search = new URLSearchParams()
+  search.set('page', String(params.page))
+  search.set('pageSize', String(params.pageSize))
+  if (params.search) search.set('search', params.search)
+  return request<App.Api.Chat.ThreadPageResult>({
+    url: `/chat/threads?${search.toString()}`,
     method: 'GET',
   })
 }
 
-/** 清除当前用户的持久化 Chat 会话（不可恢复） */
-export function clearChatSession() {
+/** 获取会话及其全部消息（重载页面 / 切换会话时恢复展示） */
+export function fetchChatThread(id: string) {
+  return request<App.Api.Chat.ThreadDetail>({
+    url: `/chat/threads/${id}`,
+    method: 'GET',
+  })
+}
+
+/** 新建空会话；标题留空时以第一个问题命名 */
+export function fetchCreateChatThread(payload: App.Api.Chat.ThreadPayload) {
+  return request<App.Api.Chat.Thread>({
+    url: `/chat/threads`,
+    method: 'POST',
+    data: payload,
+  })
+}
+
+/** 重命名会话或调整它的历史预算 */
+export function fetchUpdateChatThread(id: string, payload: App.Api.Chat.ThreadPayload) {
+  return request<App.Api.Chat.Thread>({
+    url: `/chat/threads/${id}`,
+    method: 'PUT',
+    data: payload,
+  })
+}
+
+/** 删除会话及其消息（不可恢复） */
+export function fetchDeleteChatThread(id: string) {
   return request({
-    url: `/chat/session`,
+    url: `/chat/threads/${id}`,
     method: 'DELETE',
   })
 }
 
+/** 导出会话为 Markdown 或 JSON 文件（鉴权下载，返回 blob） */
+export function fetchExportChatThread(id: string, format: App.Api.Chat.ThreadExportFormat) {
+  return downloadFile({
+    url: `/chat/threads/${id}/export?format=${format}`,
+    method: 'GET',
+  })
+}
+
+/** 确认并执行 Chat 提出的待确认动作（经 EchoService 写入，需 echo:write） */
+export function fetchConfirmChatAction(id: string) {
+  return request<App.Api.Chat.ChatAction>({
+    url: `/chat/actions/${id}/confirm`,
+    method: 'POST',
+  })
+}
+
+/** 放弃 Chat 提出的待确认动作 */
+export function fetchCancelChatAction(id: string) {
+  return request<App.Api.Chat.ChatAction>({
+    url: `/chat/actions/${id}/cancel`,
+    method: 'POST',
+  })
+}
+
 interface ChatStreamHandlers {
   /** 模型决定检索时触发（Agent 形态，可多次），携带本次检索关键词 */
   onSearching?: (query: string) => void
@@ -32,16 +86,24 @@ interface ChatStreamHandlers {
   /** 推理阶段结束，携带后端权威耗时（毫秒），供展示「已思考（用时 X 秒）」 */
   onReasoningDone?: (durationMs: number) => void
   onDelta?: (text: string) => void
+  /** 写类工具提出了待确认动作（可多次），用户确认后才执行 */
+  onAction?: (action: App.Api.Chat.ChatAction) => void
   onError?: (message: string) => void
+  /** 本轮已落盘，携带所在会话（首轮问答时会话由后端惰性创建，借此拿到 ID） */
+  onThread?: (thread: App.Api.Chat.Thread) => void
   onDone?: () => void
 }
 
 /**
- * 发起 Chat 流式问答（SSE）。传输细节（fetch + ReadableStream、公共头、abort、帧解析）
+ * 在会话 threadId 里发起 Chat 流式问答（SSE）；threadId 为空表示开启新会话。传输细节（fetch + ReadableStream、公共头、abort、帧解析）
  * 收口在 service/request/sse.ts；此处仅把语义事件映射为类型化 handler。
  * 返回一个 abort 函数用于中断。
  */
-export function chatStream(question: string, handlers: ChatStreamHandlers): () => void {
+export function chatStream(
+  threadId: string,
+  question: string,
+  handlers: ChatStreamHandlers,
+): () => void {
   let done = false
   const finish = () => {
     if (done) return
@@ -51,7 +113,7 @@ export function chatStream(question: string, handlers: ChatStreamHandlers): () =
 
   return sseStream({
     path: '/chat',
-    body: { question },
+    body: { thread_id: threadId, question },
     onEvent: (event, data) => {
       switch (event) {
         case 'searching':
@@ -72,9 +134,15 @@ export function chatStream(question: string, handlers: ChatStreamHandlers): () =
         case 'delta':
           handlers.onDelta?.((data as { text: string }).text)
           break
+        case 'action':
+          handlers.onAction?.(data as App.Api.Chat.ChatAction)
+          break
         case 'error':
           handlers.onError?.((data as { message: string }).message)
           break
+        case 'thread':
+          handlers.onThread?.(data as App.Api.Chat.Thread)
+          break
         case 'done':
           finish()
           break
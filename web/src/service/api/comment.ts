@@ -3,9 +3,10 @@
 
 import { request } from '../request'
 
-export function fetchGetCommentFormMeta() {
+export function fetchGetCommentFormMeta(echoId?: string) {
+  const query = echoId ? `?echo_id=${encodeURIComponent(echoId)}` : ''
   return request<App.Api.Comment.FormMeta>({
-    url: '/comments/form',
+    url: `/comments/form${query}`,
     method: 'GET',
   })
 }
@@ -34,6 +35,14 @@ export function fetchCreateComment(payload: App.Api.Comment.CreateCommentDto) {
   })
 }
 
+export function fetchToggleCommentReaction(id: string, emoji: string) {
+  return request<App.Api.Comment.ReactCommentResult>({
+    url: `/comments/${encodeURIComponent(id)}/reactions`,
+    method: 'POST',
+    data: { emoji },
+  })
+}
+
 export function fetchGetPanelComments(params: App.Api.Comment.PanelListQuery) {
   const search = new URLSearchParams()
   search.set('page', String(params.page))
@@ -42,6 +51,7 @@ export function fetchGetPanelComments(params: App.Api.Comment.PanelListQuery) {
   if (params.status) search.set('status', params.status)
   if (params.echo_id) search.set('echo_id', params.echo_id)
   if (typeof params.hot === 'boolean') search.set('hot', String(params.hot))
+  if (params.trashed) search.set('trashed', 'true')
   return request<App.Api.Comment.PanelPageResult>({
     url: `/panel/comments?${search.toString()}`,
     method: 'GET',
@@ -78,6 +88,20 @@ export function fetchDeletePanelComment(id: string) {
   })
 }
 
+export function fetchRestorePanelComment(id: string) {
+  return request({
+    url: `/panel/comments/${id}/restore`,
+    method: 'POST',
+  })
+}
+
+export function fetchPurgePanelComment(id: string) {
+  return request({
+    url: `/panel/comments/trash/${id}`,
+    method: 'DELETE',
+  })
+}
+
 export function fetchBatchPanelComments(action: App.Api.Comment.BatchAction, ids: string[]) {
   return request({
     url: '/panel/comments/batch',
@@ -12,6 +12,32 @@ export async function fetchQueryEchos(params: App.Api.Ech0.EchoQueryParams) {
   })
 }
 
+// 混合检索 Echos（关键词 + 语义，RRF 融合；未启用 Embedding 时回退为仅关键词）
+export async function fetchHybridSearchEchos(params: App.Api.Ech0.EchoSearchParams) {
+  return request<App.Api.Ech0.EchoSearchResult>({
+    url: `/echo/search`,
+    method: 'POST',
+    data: params,
+  })
+}
+
+// 获取与某条 Echo 相近的 Echos（向量近邻；未启用 Embedding 时 mode 为 unavailable）
+export async function fetchRelatedEchos(echoId: string, limit = 5) {
+  return request<App.Api.Ech0.RelatedEchoResult>({
+    url: `/echo/${echoId}/related?limit=${limit}`,
+    method: 'GET',
+  })
+}
+
+// 为草稿内容建议已有标签（近邻投票；未启用 Embedding 时回退为正文提及的标签）
+export async function fetchSuggestTags(params: App.Api.Ech0.TagSuggestParams) {
+  return request<App.Api.Ech0.TagSuggestionResult>({
+    url: `/tags/suggest`,
+    method: 'POST',
+    data: params,
+  })
+}
+
 // @deprecated 请使用 fetchQueryEchos
 export async function fetchGetEchosByPage(searchParams: App.Api.Ech0.ParamsByPagination) {
   return request<App.Api.Ech0.PaginationResult>({
@@ -38,6 +64,30 @@ export function fetchDeleteEcho(echoId: string) {
   })
 }
 
+// 获取回收站中的Echo（最近删除的在前）
+export function fetchGetTrashedEchos(page: number, pageSize: number) {
+  return request<App.Api.Ech0.PaginationResult>({
+    url: `/echo/trash?page=${page}&pageSize=${pageSize}`,
+    method: 'GET',
+  })
+}
+
+// 从回收站恢复Echo
+export function fetchRestoreEcho(echoId: string) {
+  return request<App.Api.Ech0.Echo>({
+    url: `/echo/${echoId}/restore`,
+    method: 'POST',
+  })
+}
+
+// 彻底删除回收站中的Echo
+export function fetchPurgeEcho(echoId: string) {
+  return request({
+    url: `/echo/trash/${echoId}`,
+    method: 'DELETE',
+  })
+}
+
 // 更新Echo
 export function fetchUpdateEcho(echo: App.Api.Ech0.EchoToUpdate) {
   return request({
@@ -47,6 +97,34 @@ export function fetchUpdateEcho(echo: App.Api.Ech0.EchoToUpdate) {
   })
 }
 
+// 获取Echo的编辑历史（最新在前）
+export function fetchGetEchoRevisions(echoId: string) {
+  return request<App.Api.Ech0.EchoRevision[]>({
+    url: `/echo/${echoId}/revisions`,
+    method: 'GET',
+  })
+}
+
+// 查看Echo历史版本及逐行差分
+export function fetchGetEchoRevision(
+  echoId: string,
+  revisionId: string,
+  compare: App.Api.Ech0.EchoRevisionCompare = 'next',
+) {
+  return request<App.Api.Ech0.EchoRevisionDetail>({
+    url: `/echo/${echoId}/revisions/${revisionId}?compare=${compare}`,
+    method: 'GET',
+  })
+}
+
+// 恢复Echo历史版本
+export function fetchRestoreEchoRevision(echoId: string, revisionId: string) {
+  return request<App.Api.Ech0.Echo>({
+    url: `/echo/${echoId}/revisions/${revisionId}/restore`,
+    method: 'POST',
+  })
+}
+
 // 点赞Echo
 export function fetchLikeEcho(echoId: string) {
   return request({
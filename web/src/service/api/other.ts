@@ -42,8 +42,15 @@ export function fetchGetWebsiteTitle(websiteURL: string) {
   })
 }
 
-// 迁移来源:ech0 快照、memos 导出,以及 Ech0 胶囊(内容交换格式)。
-export type MigrationSourceType = 'ech0' | 'memos' | 'capsule'
+// 迁移来源:ech0 快照、memos 导出、Ech0 胶囊(内容交换格式),以及 Twitter/X、Mastodon、
+// Bluesky 三个微博客平台的官方导出。
+export type MigrationSourceType =
+  | 'ech0'
+  | 'memos'
+  | 'capsule'
+  | 'twitter'
+  | 'mastodon'
+  | 'bluesky'
 
 export interface StartMigrationPayload {
   source_type: MigrationSourceType
@@ -147,6 +147,22 @@ export function fetchTestWebhook(webhookId: string) {
   })
 }
 
+// 获取 Webhook 投递记录
+export function fetchWebhookDeliveries(webhookId: string, limit = 20) {
+  return request<App.Api.Setting.WebhookDelivery[]>({
+    url: `/webhook/${webhookId}/deliveries?limit=${limit}`,
+    method: 'GET',
+  })
+}
+
+// 重新投递 Webhook
+export function fetchRedeliverWebhook(webhookId: string, deliveryId: string) {
+  return request<App.Api.Setting.WebhookDelivery>({
+    url: `/webhook/${webhookId}/deliveries/${deliveryId}/redeliver`,
+    method: 'POST',
+  })
+}
+
 // 列出访问令牌
 export function fetchListAccessTokens() {
   return request<App.Api.Setting.AccessToken[]>({
@@ -28,11 +28,12 @@ export function fetchGetAllUsers() {
   })
 }
 
-// 更新用户权限
-export function fetchUpdateUserPermission(id: string) {
+// 更新用户角色
+export function fetchUpdateUserRole(id: string, role: App.Api.User.Role) {
   return request({
-    url: `/user/admin/${id}`,
+    url: `/user/${id}/role`,
     method: 'PUT',
+    data: { role },
   })
 }
 
@@ -38,6 +38,7 @@ export const useEditorStore = defineStore('editorStore', () => {
     private: false,
     layout: ImageLayout.WATERFALL,
     extension: null,
+    comment_policy: '',
   })
   const tagToAdd = ref<string[]>([])
 
@@ -112,6 +113,7 @@ export const useEditorStore = defineStore('editorStore', () => {
       layout: ImageLayout.WATERFALL,
       extension: null,
       tags: [],
+      comment_policy: '',
     }
     files.fileToAdd.value = {
       id: undefined,
@@ -256,6 +258,7 @@ export const useEditorStore = defineStore('editorStore', () => {
         echoStore.echoToUpdate.echo_files = echoToAdd.value.echo_files
         echoStore.echoToUpdate.extension = echoToAdd.value.extension
         echoStore.echoToUpdate.tags = echoToAdd.value.tags
+        echoStore.echoToUpdate.comment_policy = echoToAdd.value.comment_policy
 
         theToast.promise(fetchUpdateEcho(echoStore.echoToUpdate), {
           loading: justSyncFiles ? t('editor.syncingFiles') : t('editor.updating'),
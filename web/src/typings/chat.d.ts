@@ -58,8 +58,71 @@ declare namespace App {
         reasoning_ms?: number
         // 仅前端瞬态：推理是否仍在流式（true→「思考中」；false/缺省→已结束，展示耗时）。不持久化。
         reasoningActive?: boolean
+        // 本轮提出的待确认动作 ID（后端持久化字段），恢复会话时据此从 ThreadDetail.actions 取出
+        action_ids?: string[]
+        // 仅前端：本轮提出的待确认动作（流式时由 action 事件累积，恢复时由 action_ids 解析）
+        actions?: ChatAction[]
       }
 
+      type ChatActionKind = 'draft_echo' | 'tag_echos' | 'set_echo_privacy'
+
+      type ChatActionStatus = 'pending' | 'running' | 'done' | 'failed' | 'cancelled'
+
+      // 写类工具提出的待确认动作（后端 copilot_actions），用户确认后才经 EchoService 执行
+      type ChatAction = {
+        id: string
+        kind: ChatActionKind
+        args: {
+          content?: string
+          tags?: string[]
+          echo_ids?: string[]
+          add_tags?: string[]
+          remove_tags?: string[]
+          private: boolean
+        }
+        // 提出时目标 Echo 的摘要，供确认前预览
+        targets?: { echo_id: string; excerpt: string }[]
+        status: ChatActionStatus
+        affected_ids?: string[] // 实际新建 / 修改了的 Echo
+        error?: string
+        created_at: number
+        updated_at: number
+      }
+
+      // 一条命名 Chat 会话（后端 copilot_threads）
+      type Thread = {
+        id: string
+        title: string
+        token_budget: number // 注入模型的历史 token 预算，0 表示默认值
+        message_count: number
+        created_at: number
+        updated_at: number
+      }
+
+      type ThreadDetail = {
+        thread: Thread
+        messages: ChatMessage[]
+        actions: ChatAction[]
+      }
+
+      type ThreadPayload = {
+        title: string
+        token_budget: number
+      }
+
+      type ThreadListQuery = {
+        page: number
+        pageSize: number
+        search?: string
+      }
+
+      type ThreadPageResult = {
+        items: Thread[]
+        total: number
+      }
+
+      type ThreadExportFormat = 'markdown' | 'json'
+
       // SSE 事件载荷
       type StreamEvent =
         | { type: 'searching'; data: { name: string; query: string } }
@@ -69,6 +132,8 @@ declare namespace App {
         | { type: 'reasoning_done'; data: { duration_ms: number } }
         | { type: 'delta'; data: { text: string } }
         | { type: 'error'; data: { message: string } }
+        | { type: 'action'; data: ChatAction }
+        | { type: 'thread'; data: Thread }
         | { type: 'done'; data: { done: boolean } }
     }
   }
@@ -6,22 +6,49 @@ declare namespace App {
   namespace Api {
     namespace Comment {
       type CommentStatus = 'pending' | 'approved' | 'rejected'
-      type BatchAction = 'approve' | 'reject' | 'delete'
+      type BatchAction = 'approve' | 'reject' | 'delete' | 'restore' | 'purge'
+
+      type ReactionCount = {
+        emoji: string
+        count: number
+      }
 
       type CommentItem = {
         id: string
         echo_id: string
         parent_id?: string | null
+        /** 物化路径：祖先 ID 与自身 ID 以 / 连接 */
+        path?: string
+        /** 顶层为 0 */
+        depth?: number
         user_id?: string
         nickname: string
         email: string
         website?: string
         content: string
+        /** 服务端渲染并过滤后的 Markdown HTML（仅公开接口返回） */
+        content_html?: string
         status: CommentStatus
         hot: boolean
-        source: 'guest' | 'system'
+        source: 'guest' | 'system' | 'fediverse' | 'webmention'
+        /** 联邦评论的远端 Note ID */
+        remote_id?: string
+        /** 垃圾检测后端；未检测时为空 */
+        spam_backend?: SpamProvider
+        /** 垃圾检测得分（0~1，越高越像垃圾） */
+        spam_score?: number
+        spam_verdict?: SpamVerdict
         created_at: number
         updated_at: number
+        /** 非零表示在回收站中（Unix 秒） */
+        deleted_at?: number
+        /** 表情回应计数（仅公开接口返回） */
+        reactions?: ReactionCount[]
+      }
+
+      type ReactCommentResult = {
+        reactions: ReactionCount[]
+        reacted: boolean
       }
 
       type FormMeta = {
@@ -30,6 +57,13 @@ declare namespace App {
         captcha_enabled: boolean
         captcha_api_endpoint: string
         enable_comment: boolean
+        /** 以下字段仅在请求带 echo_id 时返回 */
+        comment_policy?: App.Api.Ech0.CommentPolicy
+        closed_reason?: 'closed' | 'auto_closed'
+        login_required?: boolean
+        require_approval?: boolean
+        /** 自动关闭评论的时刻（Unix 秒） */
+        closes_at?: number
       }
 
       type CreateCommentDto = {
@@ -56,6 +90,8 @@ declare namespace App {
         status?: string
         echo_id?: string
         hot?: boolean
+        /** true 时只列回收站中的评论 */
+        trashed?: boolean
       }
 
       type PanelPageResult = {
@@ -63,10 +99,24 @@ declare namespace App {
         total: number
       }
 
+      type SpamProvider = '' | 'akismet' | 'bayes'
+      type SpamVerdict = 'ham' | 'spam' | 'unsure'
+
+      type SpamSetting = {
+        provider: SpamProvider
+        akismet_key?: string
+        akismet_key_set?: boolean
+        akismet_endpoint: string
+        threshold: number
+        reject_spam: boolean
+      }
+
       type SystemSetting = {
         enable_comment: boolean
         require_approval: boolean
         captcha_enabled: boolean
+        /** Echo 发布满 N 天后自动关闭评论，0 为不关闭 */
+        auto_close_days: number
         email_notify: {
           enabled: boolean
           smtp_host: string
@@ -76,6 +126,7 @@ declare namespace App {
           smtp_password_set?: boolean
           smtp_sender: string
         }
+        spam: SpamSetting
       }
     }
   }
@@ -41,8 +41,13 @@ declare namespace App {
         dateTo?: number
         /** 可见性过滤：true 仅私密、false 仅公开、缺省不过滤。非 admin 请求被服务端忽略 */
         private?: boolean
+        /** 发布状态过滤，缺省仅已发布。非 admin 请求被服务端忽略 */
+        status?: EchoStatus
       }
 
+      /** published 对外可见；draft 仅管理员可见；scheduled 到 publish_at 自动发布 */
+      type EchoStatus = 'published' | 'draft' | 'scheduled'
+
       type Echo = {
         id: string
         content: string
@@ -56,8 +61,18 @@ declare namespace App {
         fav_count: number
         /** Unix 秒/毫秒或 ISO 字符串，视 API / 序列化而定 */
         created_at: number | string
+        status: EchoStatus
+        /** scheduled 为计划发布时间，published 为实际发布时间（Unix 秒） */
+        publish_at?: number
+        /** 非零表示在回收站中（Unix 秒） */
+        deleted_at?: number
+        /** 评论策略，缺省跟随全局评论设置 */
+        comment_policy?: CommentPolicy
       }
 
+      /** 单条 Echo 的评论策略；空串表示跟随全局设置 */
+      type CommentPolicy = '' | 'open' | 'closed' | 'login_only' | 'approval'
+
       type FileObject = {
         id: string
         echo_id: string
@@ -127,6 +142,11 @@ declare namespace App {
         layout?: string | null
         extension?: EchoExtension | null
         private: boolean
+        /** 缺省为 published */
+        status?: EchoStatus
+        /** status=scheduled 时必填，Unix 秒 */
+        publish_at?: number
+        comment_policy?: CommentPolicy
       }
 
       type EchoToUpdate = {
@@ -140,11 +160,99 @@ declare namespace App {
         user_id: string
         extension?: EchoExtension | null
         created_at: number | string
+        /** 缺省保持原状态；已发布的 Echo 不能改回 draft / scheduled */
+        status?: EchoStatus
+        publish_at?: number
+        comment_policy?: CommentPolicy
       }
 
       type PaginationResult = {
         items: Echo[]
         total: number
+        /** 带检索词时返回：Echo ID → 命中片段（已转义，命中词包在 <mark> 中） */
+        highlights?: Record<string, string>
+      }
+
+      type EchoSearchParams = {
+        query: string
+        /** 返回条数，缺省 10，上限 50 */
+        limit?: number
+        tagIds?: string[]
+        dateFrom?: number
+        dateTo?: number
+        private?: boolean
+      }
+
+      type EchoSearchHit = {
+        echo: Echo
+        /** RRF 融合得分，越大越相关 */
+        score: number
+        /** 两路召回中的名次（从 1 开始），缺省表示该路未命中 */
+        keyword_rank?: number
+        semantic_rank?: number
+        highlight?: string
+      }
+
+      type EchoSearchResult = {
+        mode: 'hybrid' | 'keyword'
+        items: EchoSearchHit[]
+        keyword_total: number
+      }
+
+      type RelatedEchoHit = {
+        echo: Echo
+        /** 向量距离，越小越相近 */
+        distance: number
+      }
+
+      type RelatedEchoResult = {
+        mode: 'semantic' | 'unavailable'
+        items: RelatedEchoHit[]
+      }
+
+      type TagSuggestParams = {
+        content: string
+        /** 草稿已有的标签名，不会再被建议 */
+        tags?: string[]
+        /** 返回条数，缺省 5，上限 20 */
+        limit?: number
+      }
+
+      type TagSuggestion = {
+        tag: Tag
+        /** 投票占比（0–1）；关键词兜底时恒为 1 */
+        score: number
+        /** 投票的近邻数 */
+        votes: number
+      }
+
+      type TagSuggestionResult = {
+        mode: 'semantic' | 'keyword'
+        items: TagSuggestion[]
+      }
+
+      /** Echo 被编辑前的完整快照 */
+      type EchoRevision = {
+        id: string
+        echo_id: string
+        number: number
+        content: string
+        layout?: string
+        private: boolean
+        tags?: string[]
+        files?: { file_id: string; sort_order: number }[]
+        extension?: { type: string; payload: Record<string, unknown> }
+        editor_id: string
+        created_at: number
+      }
+
+      type EchoRevisionCompare = 'next' | 'current'
+
+      type EchoRevisionDetail = {
+        revision: EchoRevision
+        compare_to: EchoRevisionCompare
+        diff: { op: 'equal' | 'insert' | 'delete'; text: string }[]
+        stats: { added: number; removed: number }
       }
 
       type HeatMap = {
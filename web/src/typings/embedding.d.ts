@@ -8,6 +8,7 @@ declare namespace App {
       // Embedding 向量设置
       type EmbeddingSetting = {
         enable: boolean
+        provider: 'openai' | 'local'
         model: string
         api_key: string
         base_url: string
@@ -79,11 +79,16 @@ declare namespace App {
         auth_type: string
       }
 
+      type WebhookTemplate = '' | 'slack' | 'discord' | 'feishu' | 'custom'
+
       type Webhook = {
         id: string
         name: string
         url: string
         is_active: boolean
+        topics: string[] | null
+        template: WebhookTemplate
+        template_body: string
         last_status: string
         last_trigger: number
         created_at: number
@@ -95,6 +100,25 @@ declare namespace App {
         url: string
         secret?: string
         is_active: boolean
+        topics?: string[]
+        template?: WebhookTemplate
+        template_body?: string
+      }
+
+      type WebhookDelivery = {
+        id: string
+        webhook_id: string
+        event_id: string
+        topic: string
+        attempt: number
+        success: boolean
+        status_code: number
+        error: string
+        latency_ms: number
+        request_body: string
+        response_body: string
+        redelivery_of: string
+        created_at: number
       }
 
       type AccessToken = {
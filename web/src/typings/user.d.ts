@@ -5,6 +5,8 @@
 declare namespace App {
   namespace Api {
     namespace User {
+      type Role = 'owner' | 'editor' | 'author' | 'comment_moderator' | 'reader'
+
       type User = {
         id: string
         username: string
@@ -12,6 +14,7 @@ declare namespace App {
         password?: string
         is_admin: boolean
         is_owner?: boolean
+        role?: Role
         avatar?: string
         locale: string
       }